@@ -0,0 +1,22 @@
+// Package auth holds the token checks Jan Server services share.
+package auth
+
+import "slices"
+
+// IntegrationTokenMessage is the error services answer integration tokens with.
+const IntegrationTokenMessage = "integration tokens cannot access this service"
+
+// IsIntegrationClient reports whether azp, a token's authorized party, is one
+// of the third-party integration clients listed in INTEGRATION_CLIENTS.
+// Integration scopes are only enforced by llm-api, so the other services
+// refuse these tokens.
+func IsIntegrationClient(azp string, clients []string) bool {
+	return azp != "" && slices.Contains(clients, azp)
+}
+
+// IsIntegrationToken is IsIntegrationClient for the azp claim of decoded JWT
+// claims, such as jwt.MapClaims.
+func IsIntegrationToken(claims map[string]any, clients []string) bool {
+	azp, _ := claims["azp"].(string)
+	return IsIntegrationClient(azp, clients)
+}
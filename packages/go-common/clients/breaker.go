@@ -0,0 +1,101 @@
+package clients
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// BreakerConfig tunes the circuit breaker of one client.
+type BreakerConfig struct {
+	FailureThreshold int           // Consecutive failures (connection errors, 5xx) that open the circuit; 0 disables the breaker
+	OpenTimeout      time.Duration // How long the circuit stays open before one trial call is let through
+}
+
+// DefaultBreakerConfig opens the circuit after 5 consecutive failures for 30s.
+func DefaultBreakerConfig() BreakerConfig {
+	return BreakerConfig{FailureThreshold: 5, OpenTimeout: 30 * time.Second}
+}
+
+// BreakerFromEnv overrides defaults with prefix plus BREAKER_FAILURES and
+// BREAKER_OPEN_TIMEOUT, so a target shares the prefix of its httpclient
+// settings: BreakerFromEnv("MEMORY_HTTP_", defaults) reads
+// MEMORY_HTTP_BREAKER_FAILURES. Unset variables keep the default.
+func BreakerFromEnv(prefix string, defaults BreakerConfig) (BreakerConfig, error) {
+	cfg := defaults
+	if raw := strings.TrimSpace(os.Getenv(prefix + "BREAKER_FAILURES")); raw != "" {
+		failures, err := strconv.Atoi(raw)
+		if err != nil || failures < 0 {
+			return cfg, fmt.Errorf("invalid %sBREAKER_FAILURES %q", prefix, raw)
+		}
+		cfg.FailureThreshold = failures
+	}
+	if raw := strings.TrimSpace(os.Getenv(prefix + "BREAKER_OPEN_TIMEOUT")); raw != "" {
+		timeout, err := time.ParseDuration(raw)
+		if err != nil || timeout < 0 {
+			return cfg, fmt.Errorf("invalid %sBREAKER_OPEN_TIMEOUT %q", prefix, raw)
+		}
+		cfg.OpenTimeout = timeout
+	}
+	return cfg, nil
+}
+
+// breaker is a consecutive-failure circuit breaker. Once open it rejects calls
+// until OpenTimeout has passed, then lets a single trial call through: its
+// success closes the circuit, its failure opens it again.
+type breaker struct {
+	cfg BreakerConfig
+
+	mu       sync.Mutex
+	failures int
+	openedAt time.Time
+	trial    bool // a half-open trial call is in flight
+}
+
+func newBreaker(cfg BreakerConfig) *breaker {
+	return &breaker{cfg: cfg}
+}
+
+func (b *breaker) allow() error {
+	if b.cfg.FailureThreshold <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.failures < b.cfg.FailureThreshold {
+		return nil
+	}
+	if b.trial || time.Since(b.openedAt) < b.cfg.OpenTimeout {
+		return ErrCircuitOpen
+	}
+	b.trial = true
+	return nil
+}
+
+func (b *breaker) record(success bool) {
+	if b.cfg.FailureThreshold <= 0 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+	if success {
+		b.failures = 0
+		return
+	}
+	b.failures++
+	if b.failures >= b.cfg.FailureThreshold {
+		b.openedAt = time.Now()
+	}
+}
+
+// abandon ends a call that says nothing about the service's health, such as
+// one the caller cancelled.
+func (b *breaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.trial = false
+}
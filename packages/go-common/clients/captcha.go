@@ -0,0 +1,39 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// CaptchaClient calls a CAPTCHA siteverify API. Cloudflare Turnstile, hCaptcha
+// and reCAPTCHA share its form fields and the success flag of the response.
+type CaptchaClient struct {
+	c *client
+}
+
+// NewCaptchaClient builds a CAPTCHA client for verifyURL
+// (e.g. https://challenges.cloudflare.com/turnstile/v0/siteverify) over httpClient.
+func NewCaptchaClient(verifyURL string, httpClient *http.Client, breaker BreakerConfig) *CaptchaClient {
+	return &CaptchaClient{c: newClient("captcha", verifyURL, httpClient, breaker)}
+}
+
+// SiteVerifyResponse is the verdict on a CAPTCHA token.
+type SiteVerifyResponse struct {
+	Success    bool     `json:"success"`
+	ErrorCodes []string `json:"error-codes,omitempty"`
+}
+
+// SiteVerify checks token, solved by the client at remoteIP (optional), with
+// secret.
+func (s *CaptchaClient) SiteVerify(ctx context.Context, secret, token, remoteIP string) (*SiteVerifyResponse, error) {
+	form := url.Values{"secret": {secret}, "response": {token}}
+	if remoteIP != "" {
+		form.Set("remoteip", remoteIP)
+	}
+	var resp SiteVerifyResponse
+	if err := s.c.do(ctx, http.MethodPost, "", nil, form, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
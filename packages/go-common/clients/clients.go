@@ -0,0 +1,210 @@
+// Package clients provides typed clients for the APIs Jan Server services call
+// on each other: llm-api conversations, memory-tools, media-api and mcp-tools.
+// They behave the same way when a downstream service is degraded: retries and
+// pooling come from the httpclient transport they are built on, a circuit
+// breaker fails calls fast while the service keeps failing, and the caller's
+// trace context is propagated so downstream spans join the caller's trace.
+package clients
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/propagation"
+)
+
+// maxErrorBody caps how much of an error response is kept in APIError.
+const maxErrorBody = 4 << 10
+
+// ErrCircuitOpen is returned without calling the service while its circuit is open.
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+// APIError is a response with an error status from a downstream service.
+type APIError struct {
+	Service    string
+	StatusCode int
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("%s returned status %d: %s", e.Service, e.StatusCode, e.Body)
+}
+
+// StatusCode returns the status of an APIError in err's chain, or 0.
+func StatusCode(err error) int {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode
+	}
+	return 0
+}
+
+// client sends JSON requests to one service through its circuit breaker.
+type client struct {
+	service    string
+	baseURL    string
+	httpClient *http.Client
+	breaker    *breaker
+}
+
+func newClient(service, baseURL string, httpClient *http.Client, breakerCfg BreakerConfig) *client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &client{
+		service:    service,
+		baseURL:    strings.TrimRight(strings.TrimSpace(baseURL), "/"),
+		httpClient: httpClient,
+		breaker:    newBreaker(breakerCfg),
+	}
+}
+
+// do sends in as the body of method baseURL+path, form-encoded for url.Values
+// and as JSON otherwise, and decodes the response into out. Either may be nil.
+func (c *client) do(ctx context.Context, method, path string, header http.Header, in, out any) error {
+	body, contentType, err := c.encode(in)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return fmt.Errorf("create %s request: %w", c.service, err)
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	if err := c.breaker.allow(); err != nil {
+		return fmt.Errorf("%s: %w", c.service, err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// A caller giving up says nothing about the service's health, but
+			// missing the caller's deadline counts as a failure
+			c.breaker.abandon()
+		} else {
+			c.breaker.record(false)
+		}
+		return fmt.Errorf("call %s: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+	c.breaker.record(resp.StatusCode < http.StatusInternalServerError)
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return &APIError{Service: c.service, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(raw))}
+	}
+	if out == nil {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("decode %s response: %w", c.service, err)
+	}
+	return nil
+}
+
+// encode returns the request body for in with its content type; both are empty
+// for a nil in.
+func (c *client) encode(in any) (io.Reader, string, error) {
+	switch in := in.(type) {
+	case nil:
+		return nil, "", nil
+	case url.Values:
+		return strings.NewReader(in.Encode()), "application/x-www-form-urlencoded", nil
+	}
+	payload, err := json.Marshal(in)
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal %s request: %w", c.service, err)
+	}
+	// A bytes.Reader lets the retry transport replay the body
+	return bytes.NewReader(payload), "application/json", nil
+}
+
+// fetch sends in, if not nil, as the JSON body of method baseURL+path and
+// returns the raw response body, up to maxBytes, with its content type.
+func (c *client) fetch(ctx context.Context, method, path string, header http.Header, in any, maxBytes int64) ([]byte, string, error) {
+	var body io.Reader
+	if in != nil {
+		payload, err := json.Marshal(in)
+		if err != nil {
+			return nil, "", fmt.Errorf("marshal %s request: %w", c.service, err)
+		}
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, body)
+	if err != nil {
+		return nil, "", fmt.Errorf("create %s request: %w", c.service, err)
+	}
+	for key, values := range header {
+		for _, value := range values {
+			req.Header.Add(key, value)
+		}
+	}
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	if err := c.breaker.allow(); err != nil {
+		return nil, "", fmt.Errorf("%s: %w", c.service, err)
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			c.breaker.abandon()
+		} else {
+			c.breaker.record(false)
+		}
+		return nil, "", fmt.Errorf("call %s: %w", c.service, err)
+	}
+	defer resp.Body.Close()
+	c.breaker.record(resp.StatusCode < http.StatusInternalServerError)
+
+	data, err := readBody(c.service, resp, maxBytes)
+	if err != nil {
+		return nil, "", err
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
+
+// readBody returns the body of a successful response, up to maxBytes, or an
+// APIError for an error status.
+func readBody(service string, resp *http.Response, maxBytes int64) ([]byte, error) {
+	if resp.StatusCode >= http.StatusBadRequest {
+		raw, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBody))
+		return nil, &APIError{Service: service, StatusCode: resp.StatusCode, Body: strings.TrimSpace(string(raw))}
+	}
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("read %s response: %w", service, err)
+	}
+	if int64(len(data)) > maxBytes {
+		return nil, fmt.Errorf("%s response exceeds %d bytes", service, maxBytes)
+	}
+	return data, nil
+}
+
+// authHeader carries the caller's Authorization header, if any.
+func authHeader(authorization string) http.Header {
+	header := http.Header{}
+	if authorization = strings.TrimSpace(authorization); authorization != "" {
+		header.Set("Authorization", authorization)
+	}
+	return header
+}
@@ -0,0 +1,54 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+)
+
+// ConversationsClient calls the llm-api conversations API.
+type ConversationsClient struct {
+	c *client
+}
+
+// NewConversationsClient builds an llm-api client for baseURL
+// (e.g. http://llm-api:8080) over httpClient.
+func NewConversationsClient(baseURL string, httpClient *http.Client, breaker BreakerConfig) *ConversationsClient {
+	return &ConversationsClient{c: newClient("llm-api", baseURL, httpClient, breaker)}
+}
+
+// UpdateItemRequest completes or fails an in_progress tool call item.
+type UpdateItemRequest struct {
+	Status      string  `json:"status"` // completed or failed
+	Output      *string `json:"output,omitempty"`
+	Error       *string `json:"error,omitempty"`
+	ErrorDetail any     `json:"error_detail,omitempty"` // Structured form of Error
+	Name        *string `json:"name,omitempty"`
+	Arguments   *string `json:"arguments,omitempty"`
+	ServerLabel *string `json:"server_label,omitempty"`
+}
+
+// ConversationItem is an item as returned by the conversations API.
+type ConversationItem struct {
+	ID        string `json:"id"`
+	Object    string `json:"object"`
+	Type      string `json:"type"`
+	CallID    string `json:"call_id"`
+	Status    string `json:"status"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// UpdateItemByCallID updates the tool call item with callID in a conversation
+// of the user whose Authorization header is authorization. llm-api answers 409
+// when the item was already completed.
+func (cc *ConversationsClient) UpdateItemByCallID(ctx context.Context, authorization, conversationID, callID string, req UpdateItemRequest) (*ConversationItem, error) {
+	path := "/v1/conversations/" + url.PathEscape(conversationID) + "/items/by-call-id/" + url.PathEscape(callID)
+	header := authHeader(authorization)
+	// A replayed update answers 409, so the retry transport may resend it
+	header.Set("Idempotency-Key", callID)
+	var item ConversationItem
+	if err := cc.c.do(ctx, http.MethodPatch, path, header, req, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
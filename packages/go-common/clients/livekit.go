@@ -0,0 +1,47 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+)
+
+// RoomServiceClient calls the Twirp RoomService API of a LiveKit server.
+type RoomServiceClient struct {
+	c *client
+}
+
+// NewRoomServiceClient builds a LiveKit RoomService client for baseURL
+// (e.g. https://your-livekit-server.com) over httpClient.
+func NewRoomServiceClient(baseURL string, httpClient *http.Client, breaker BreakerConfig) *RoomServiceClient {
+	return &RoomServiceClient{c: newClient("livekit", baseURL, httpClient, breaker)}
+}
+
+// SendDataRequest publishes Data to the participants of Room on Topic.
+type SendDataRequest struct {
+	Room  string `json:"room"`
+	Data  []byte `json:"data"` // Sent base64-encoded, as protojson expects bytes
+	Kind  string `json:"kind"` // RELIABLE or LOSSY
+	Topic string `json:"topic,omitempty"`
+}
+
+// Participant is the part of LiveKit's ParticipantInfo services read.
+type Participant struct {
+	Identity string `json:"identity"`
+}
+
+// SendData publishes a data packet through POST /twirp/livekit.RoomService/SendData.
+// token must grant roomAdmin on the room.
+func (s *RoomServiceClient) SendData(ctx context.Context, token string, req SendDataRequest) error {
+	return s.c.do(ctx, http.MethodPost, "/twirp/livekit.RoomService/SendData", authHeader("Bearer "+token), req, nil)
+}
+
+// ListParticipants returns the participants of room. token must grant
+// roomAdmin on the room.
+func (s *RoomServiceClient) ListParticipants(ctx context.Context, token, room string) ([]Participant, error) {
+	var resp struct {
+		Participants []Participant `json:"participants"`
+	}
+	err := s.c.do(ctx, http.MethodPost, "/twirp/livekit.RoomService/ListParticipants", authHeader("Bearer "+token),
+		map[string]string{"room": room}, &resp)
+	return resp.Participants, err
+}
@@ -0,0 +1,115 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+)
+
+// contextBudgetHeader tells mcp-tools how many tokens the model has left for a result.
+const contextBudgetHeader = "X-Context-Budget-Tokens"
+
+// MCPClient calls the JSON-RPC endpoint of mcp-tools.
+type MCPClient struct {
+	c *client
+}
+
+// NewMCPClient builds an mcp-tools client for baseURL
+// (e.g. http://mcp-tools:8091) over httpClient.
+func NewMCPClient(baseURL string, httpClient *http.Client, breaker BreakerConfig) *MCPClient {
+	return &MCPClient{c: newClient("mcp-tools", baseURL, httpClient, breaker)}
+}
+
+// MCPTool is a tool listed by mcp-tools.
+type MCPTool struct {
+	Name        string         `json:"name"`
+	Description string         `json:"description"`
+	InputSchema map[string]any `json:"inputSchema"`
+}
+
+// MCPContent is one part of a tool result.
+type MCPContent struct {
+	Type     string         `json:"type"`
+	Text     string         `json:"text,omitempty"`
+	Resource map[string]any `json:"resource,omitempty"`
+}
+
+// MCPCallRequest is a tools/call invocation.
+type MCPCallRequest struct {
+	ID            string // JSON-RPC id, usually the tool call ID
+	Name          string
+	Arguments     map[string]any
+	ContextBudget int // Tokens left in the model's context for the result; 0 when unknown
+}
+
+// MCPCallResult is the result of a tools/call invocation.
+type MCPCallResult struct {
+	Content []MCPContent `json:"content"`
+	IsError bool         `json:"isError"`
+	Error   string       `json:"error"`
+}
+
+// MCPError is a JSON-RPC error returned by mcp-tools.
+type MCPError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *MCPError) Error() string {
+	return fmt.Sprintf("mcp error (%d): %s", e.Code, e.Message)
+}
+
+type rpcRequest struct {
+	JSONRPC string `json:"jsonrpc"`
+	Method  string `json:"method"`
+	Params  any    `json:"params"`
+	ID      any    `json:"id"`
+}
+
+type rpcResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  *MCPError       `json:"error"`
+}
+
+// ListTools lists the tools of mcp-tools.
+func (m *MCPClient) ListTools(ctx context.Context) ([]MCPTool, error) {
+	var result struct {
+		Tools []MCPTool `json:"tools"`
+	}
+	if err := m.call(ctx, nil, "tools/list", map[string]any{}, 1, &result); err != nil {
+		return nil, err
+	}
+	return result.Tools, nil
+}
+
+// CallTool runs a tool.
+func (m *MCPClient) CallTool(ctx context.Context, req MCPCallRequest) (*MCPCallResult, error) {
+	header := http.Header{}
+	if req.ContextBudget > 0 {
+		// Lets mcp-tools size text-heavy results to the model's remaining context
+		header.Set(contextBudgetHeader, strconv.Itoa(req.ContextBudget))
+	}
+	params := map[string]any{"name": req.Name, "arguments": req.Arguments}
+	var result MCPCallResult
+	if err := m.call(ctx, header, "tools/call", params, req.ID, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+func (m *MCPClient) call(ctx context.Context, header http.Header, method string, params, id, out any) error {
+	var resp rpcResponse
+	req := rpcRequest{JSONRPC: "2.0", Method: method, Params: params, ID: id}
+	if err := m.c.do(ctx, http.MethodPost, "/v1/mcp", header, req, &resp); err != nil {
+		return err
+	}
+	if resp.Error != nil {
+		return resp.Error
+	}
+	if err := json.Unmarshal(resp.Result, out); err != nil {
+		return fmt.Errorf("decode %s result: %w", method, err)
+	}
+	return nil
+}
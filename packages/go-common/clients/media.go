@@ -0,0 +1,121 @@
+package clients
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// MediaClient uploads to media-api.
+type MediaClient struct {
+	c *client
+}
+
+// NewMediaClient builds a media-api client for its ingest endpoint
+// (e.g. http://kong:8000/media/v1/media) over httpClient.
+func NewMediaClient(ingestURL string, httpClient *http.Client, breaker BreakerConfig) *MediaClient {
+	return &MediaClient{c: newClient("media-api", ingestURL, httpClient, breaker)}
+}
+
+// MediaSource is where media-api reads an upload from.
+type MediaSource struct {
+	Type    string `json:"type"` // data_url or remote_url
+	DataURL string `json:"data_url,omitempty"`
+	URL     string `json:"url,omitempty"`
+}
+
+// MediaIngestRequest asks media-api to store an object.
+type MediaIngestRequest struct {
+	Source   MediaSource `json:"source"`
+	Filename string      `json:"filename,omitempty"`
+	UserID   string      `json:"user_id,omitempty"`
+}
+
+// MediaObject is the media-api record of a stored object.
+type MediaObject struct {
+	ID      string `json:"id"` // Media ID (jan_*)
+	Mime    string `json:"mime"`
+	Bytes   int64  `json:"bytes"`
+	Deduped bool   `json:"deduped"`
+	URL     string `json:"url"`
+}
+
+// Ingest stores an object on behalf of the caller whose Authorization header
+// is authorization.
+func (m *MediaClient) Ingest(ctx context.Context, authorization string, req MediaIngestRequest) (*MediaObject, error) {
+	var obj MediaObject
+	if err := m.c.do(ctx, http.MethodPost, "", authHeader(authorization), req, &obj); err != nil {
+		return nil, err
+	}
+	return &obj, nil
+}
+
+// ResolvedMedia is a stored object with a URL it can be downloaded from.
+type ResolvedMedia struct {
+	ID        string `json:"id"`
+	Mime      string `json:"mime"`
+	Bytes     int64  `json:"bytes"`
+	URL       string `json:"url"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"` // Unix seconds; nil when the URL does not expire
+}
+
+// Resolve looks up objects by media ID on behalf of the caller whose
+// Authorization header is authorization. IDs media-api does not know are left out.
+func (m *MediaClient) Resolve(ctx context.Context, authorization string, ids []string) ([]ResolvedMedia, error) {
+	var resp struct {
+		Data []ResolvedMedia `json:"data"`
+	}
+	req := struct {
+		IDs []string `json:"ids"`
+	}{IDs: ids}
+	if err := m.c.do(ctx, http.MethodPost, "/resolve", authHeader(authorization), req, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Data, nil
+}
+
+// Upload stores data as a data URL of mimeType.
+func (m *MediaClient) Upload(ctx context.Context, authorization string, data []byte, mimeType, filename string) (*MediaObject, error) {
+	return m.Ingest(ctx, authorization, MediaIngestRequest{
+		Source: MediaSource{
+			Type:    "data_url",
+			DataURL: fmt.Sprintf("data:%s;base64,%s", mimeType, base64.StdEncoding.EncodeToString(data)),
+		},
+		Filename: filename,
+	})
+}
+
+// Download returns the bytes of the object with media ID id, read on behalf
+// of the caller whose Authorization header is authorization. Objects larger
+// than maxBytes fail. When media-api does not proxy downloads (MEDIA_PROXY_DOWNLOAD
+// off), the object is read from the storage URL it returns instead.
+func (m *MediaClient) Download(ctx context.Context, authorization, id string, maxBytes int64) ([]byte, error) {
+	data, contentType, err := m.c.fetch(ctx, http.MethodGet, "/"+url.PathEscape(id), authHeader(authorization), nil, maxBytes)
+	if err != nil {
+		return nil, err
+	}
+	if !strings.HasPrefix(contentType, "application/json") {
+		return data, nil
+	}
+
+	var redirect struct {
+		URL string `json:"url"`
+	}
+	if err := json.Unmarshal(data, &redirect); err != nil || redirect.URL == "" {
+		return nil, fmt.Errorf("decode %s download: unexpected JSON response", m.c.service)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, redirect.URL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("create %s download: %w", m.c.service, err)
+	}
+	resp, err := m.c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download from %s storage: %w", m.c.service, err)
+	}
+	defer resp.Body.Close()
+	return readBody(m.c.service, resp, maxBytes)
+}
@@ -0,0 +1,133 @@
+package clients
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"time"
+)
+
+// MemoryClient calls the memory-tools API.
+type MemoryClient struct {
+	c *client
+}
+
+// NewMemoryClient builds a memory-tools client for baseURL
+// (e.g. http://memory-tools:8090) over httpClient.
+func NewMemoryClient(baseURL string, httpClient *http.Client, breaker BreakerConfig) *MemoryClient {
+	return &MemoryClient{c: newClient("memory-tools", baseURL, httpClient, breaker)}
+}
+
+// MemoryLoadRequest asks for the memories relevant to a query.
+type MemoryLoadRequest struct {
+	UserID         string            `json:"user_id"`
+	ProjectID      string            `json:"project_id,omitempty"`
+	ConversationID string            `json:"conversation_id,omitempty"`
+	Query          string            `json:"query"`
+	Options        MemoryLoadOptions `json:"options"`
+}
+
+// MemoryLoadOptions bounds how many memories of each kind are loaded.
+type MemoryLoadOptions struct {
+	MaxUserItems     int     `json:"max_user_items"`
+	MaxProjectItems  int     `json:"max_project_items"`
+	MaxEpisodicItems int     `json:"max_episodic_items"`
+	MinSimilarity    float32 `json:"min_similarity"`
+}
+
+// MemoryLoadResponse contains the loaded memories.
+type MemoryLoadResponse struct {
+	CoreMemory     []UserMemoryItem `json:"core_memory"`
+	EpisodicMemory []EpisodicEvent  `json:"episodic_memory"`
+	SemanticMemory []ProjectFact    `json:"semantic_memory"`
+}
+
+// UserMemoryItem is a memory about the user.
+type UserMemoryItem struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Scope      string    `json:"scope"`
+	Text       string    `json:"text"`
+	Score      int       `json:"score"`
+	Similarity float32   `json:"similarity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ProjectFact is a fact recorded for a project.
+type ProjectFact struct {
+	ID         string    `json:"id"`
+	ProjectID  string    `json:"project_id"`
+	Kind       string    `json:"kind"`
+	Title      string    `json:"title"`
+	Text       string    `json:"text"`
+	Confidence float32   `json:"confidence"`
+	Similarity float32   `json:"similarity"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// EpisodicEvent is something that happened in an earlier conversation.
+type EpisodicEvent struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	Time       time.Time `json:"time"`
+	Text       string    `json:"text"`
+	Kind       string    `json:"kind"`
+	Similarity float32   `json:"similarity"`
+}
+
+// MemoryObserveRequest hands a conversation to memory-tools for extraction.
+type MemoryObserveRequest struct {
+	UserID         string          `json:"user_id"`
+	ProjectID      string          `json:"project_id,omitempty"`
+	ConversationID string          `json:"conversation_id"`
+	Messages       []MemoryMessage `json:"messages"`
+}
+
+// MemoryMessage is a conversation message observed by memory-tools.
+type MemoryMessage struct {
+	ItemID    string    `json:"item_id,omitempty"` // Conversation item ID; memory-tools observes each item once
+	Role      string    `json:"role"`
+	Content   string    `json:"content"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// Load retrieves the memories relevant to req.Query.
+func (m *MemoryClient) Load(ctx context.Context, req MemoryLoadRequest) (*MemoryLoadResponse, error) {
+	var resp MemoryLoadResponse
+	if err := m.c.do(ctx, http.MethodPost, "/v1/memory/load", nil, req, &resp); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// Observe stores a conversation for memory extraction. Observing items that
+// were observed before is a no-op, so requests whose messages all carry item
+// IDs get an Idempotency-Key and are retried.
+func (m *MemoryClient) Observe(ctx context.Context, req MemoryObserveRequest) error {
+	var header http.Header
+	if key := observeIdempotencyKey(req); key != "" {
+		header = http.Header{"Idempotency-Key": []string{key}}
+	}
+	return m.c.do(ctx, http.MethodPost, "/v1/memory/observe", header, req, nil)
+}
+
+// observeIdempotencyKey derives a key from the conversation and its item IDs;
+// it is empty when a message has no item ID.
+func observeIdempotencyKey(req MemoryObserveRequest) string {
+	hash := sha256.New()
+	hash.Write([]byte(req.ConversationID))
+	for _, msg := range req.Messages {
+		if msg.ItemID == "" {
+			return ""
+		}
+		hash.Write([]byte{0})
+		hash.Write([]byte(msg.ItemID))
+	}
+	return "observe-" + hex.EncodeToString(hash.Sum(nil))
+}
+
+// Health checks that memory-tools is serving.
+func (m *MemoryClient) Health(ctx context.Context) error {
+	return m.c.do(ctx, http.MethodGet, "/healthz", nil, nil, nil)
+}
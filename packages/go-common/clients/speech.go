@@ -0,0 +1,40 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+)
+
+// maxSpeechBytes bounds the audio returned for one synthesis request; a
+// sentence of 24 kHz 16-bit PCM is well under a megabyte.
+const maxSpeechBytes = 16 << 20
+
+// SpeechClient calls an OpenAI-compatible text-to-speech server.
+type SpeechClient struct {
+	c *client
+}
+
+// NewSpeechClient builds a text-to-speech client for baseURL
+// (e.g. https://api.openai.com/v1) over httpClient.
+func NewSpeechClient(baseURL string, httpClient *http.Client, breaker BreakerConfig) *SpeechClient {
+	return &SpeechClient{c: newClient("tts", baseURL, httpClient, breaker)}
+}
+
+// SpeechRequest asks for input to be spoken by voice.
+type SpeechRequest struct {
+	Model          string `json:"model"`
+	Input          string `json:"input"`
+	Voice          string `json:"voice"`
+	ResponseFormat string `json:"response_format"` // pcm (24 kHz 16-bit mono) or opus (Ogg)
+}
+
+// Speech synthesizes req through POST /audio/speech and returns the audio
+// bytes. apiKey, if set, is sent as a bearer token.
+func (s *SpeechClient) Speech(ctx context.Context, apiKey string, req SpeechRequest) ([]byte, error) {
+	header := http.Header{}
+	if apiKey != "" {
+		header.Set("Authorization", "Bearer "+apiKey)
+	}
+	data, _, err := s.c.fetch(ctx, http.MethodPost, "/audio/speech", header, req, maxSpeechBytes)
+	return data, err
+}
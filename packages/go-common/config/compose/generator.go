@@ -196,6 +196,7 @@ services:
       # Database (constructed DSN)
       DB_POSTGRESQL_WRITE_DSN: "postgres://{{ .Database.Postgres.User }}:{{ .Database.Postgres.Password }}@api-db:5432/{{ .Database.Postgres.Database }}?sslmode=disable"
       DB_POSTGRESQL_READ1_DSN: ""
+      DB_POSTGRESQL_READ_DSNS: ""
       
       # Auth
       KEYCLOAK_BASE_URL: {{ .Auth.Keycloak.BaseUrl }}
@@ -65,6 +65,9 @@ type PostgresConfig struct {
 
 	// Connection max lifetime
 	ConnMaxLifetime time.Duration `yaml:"conn_max_lifetime" json:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" envDefault:"30m" description:"Maximum connection lifetime"`
+
+	// Read replica DSNs (optional, comma separated in env)
+	ReadReplicaDSNs []string `yaml:"read_replica_dsns,omitempty" json:"read_replica_dsns,omitempty" env:"DB_POSTGRESQL_READ_DSNS" envSeparator:"," description:"PostgreSQL read replica DSNs; read-only queries are routed to them"`
 }
 
 // AuthConfig contains authentication and authorization settings
@@ -0,0 +1,210 @@
+// Package egress guards outbound requests to URLs chosen by users or models,
+// so services that fetch arbitrary URLs can't be pointed at internal cluster
+// endpoints or cloud metadata services.
+package egress
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const defaultMaxRedirects = 5
+
+// ErrDenied is wrapped by every error the policy returns for a blocked destination
+var ErrDenied = errors.New("egress denied")
+
+// deniedPrefixes are the networks no fetch may reach, on top of the loopback,
+// private, link-local, multicast and unspecified ranges netip classifies itself
+var deniedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "This" network
+	netip.MustParsePrefix("100.64.0.0/10"),  // Carrier-grade NAT, also Alibaba Cloud metadata
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // Benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // Reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, which maps IPv4 back in
+	netip.MustParsePrefix("64:ff9b:1::/48"), // Local-use NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4, which embeds an IPv4 address
+}
+
+// Config configures a Policy
+type Config struct {
+	AllowedSchemes       []string // URL schemes that may be fetched; defaults to http and https
+	AllowedHosts         []string // Hosts exempt from the address checks; ".example.com" matches subdomains
+	AllowPrivateNetworks bool     // Disables the address checks, for local development only
+	MaxRedirects         int      // Redirects followed per request; defaults to 5
+}
+
+// Policy decides which destinations outbound requests may reach. The zero
+// value is not usable; create one with NewPolicy.
+type Policy struct {
+	schemes      map[string]bool
+	hosts        []string
+	allowPrivate bool
+	maxRedirects int
+	resolver     *net.Resolver
+}
+
+// NewPolicy creates a policy from cfg
+func NewPolicy(cfg Config) *Policy {
+	schemes := make(map[string]bool)
+	for _, scheme := range cfg.AllowedSchemes {
+		if scheme = strings.ToLower(strings.TrimSpace(scheme)); scheme != "" {
+			schemes[scheme] = true
+		}
+	}
+	if len(schemes) == 0 {
+		schemes["http"], schemes["https"] = true, true
+	}
+	maxRedirects := cfg.MaxRedirects
+	if maxRedirects <= 0 {
+		maxRedirects = defaultMaxRedirects
+	}
+	p := &Policy{
+		schemes:      schemes,
+		allowPrivate: cfg.AllowPrivateNetworks,
+		maxRedirects: maxRedirects,
+		resolver:     net.DefaultResolver,
+	}
+	return p.WithAllowedHosts(cfg.AllowedHosts...)
+}
+
+// WithAllowedHosts returns a copy of the policy that also exempts hosts from
+// the address checks, e.g. an operator-configured upstream on the cluster network
+func (p *Policy) WithAllowedHosts(hosts ...string) *Policy {
+	copied := *p
+	copied.hosts = append([]string(nil), p.hosts...)
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			copied.hosts = append(copied.hosts, host)
+		}
+	}
+	return &copied
+}
+
+// CheckURL validates a URL before it is fetched, or handed to a service that
+// fetches it: the scheme must be allowed and every address the host resolves
+// to must be public. Requests sent through the policy's transport are checked
+// again when they connect.
+func (p *Policy) CheckURL(ctx context.Context, rawURL string) error {
+	parsed, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return fmt.Errorf("%w: invalid url: %v", ErrDenied, err)
+	}
+	if !p.schemes[strings.ToLower(parsed.Scheme)] {
+		return fmt.Errorf("%w: scheme %q is not allowed", ErrDenied, parsed.Scheme)
+	}
+	host := parsed.Hostname()
+	if host == "" {
+		return fmt.Errorf("%w: url has no host", ErrDenied)
+	}
+	if p.allowPrivate || p.hostAllowed(host) {
+		return nil
+	}
+
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return checkAddr(host, addr)
+	}
+	addrs, err := p.resolver.LookupNetIP(ctx, "ip", host)
+	if err != nil {
+		return fmt.Errorf("resolve %s: %w", host, err)
+	}
+	for _, addr := range addrs {
+		if err := checkAddr(host, addr); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// CheckRedirect limits redirects and applies the scheme and host checks to
+// each hop; use it as http.Client.CheckRedirect
+func (p *Policy) CheckRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) > p.maxRedirects {
+		return fmt.Errorf("%w: stopped after %d redirects", ErrDenied, p.maxRedirects)
+	}
+	return p.CheckURL(req.Context(), req.URL.String())
+}
+
+// Transport returns a transport that refuses to connect to denied addresses.
+// The address is checked after DNS resolution, right before connecting, so a
+// host that resolves to a public address during CheckURL and to an internal
+// one afterwards (DNS rebinding) is still refused. Proxies from the
+// environment are ignored, since they would hide the real destination.
+func (p *Policy) Transport(base *http.Transport) *http.Transport {
+	var transport *http.Transport
+	if base != nil {
+		transport = base.Clone()
+	} else {
+		transport = http.DefaultTransport.(*http.Transport).Clone()
+	}
+	transport.Proxy = nil
+	transport.DialContext = p.dialContext
+	return transport
+}
+
+// NewHTTPClient returns an http.Client that enforces the policy on every
+// connection and redirect
+func (p *Policy) NewHTTPClient(timeout time.Duration) *http.Client {
+	return &http.Client{
+		Timeout:       timeout,
+		Transport:     p.Transport(nil),
+		CheckRedirect: p.CheckRedirect,
+	}
+}
+
+// dialContext connects to address, refusing denied addresses once they are
+// resolved unless the host is exempt
+func (p *Policy) dialContext(ctx context.Context, network, address string) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+	host, _, err := net.SplitHostPort(address)
+	if err != nil || !(p.allowPrivate || p.hostAllowed(host)) {
+		dialer.Control = p.control
+	}
+	return dialer.DialContext(ctx, network, address)
+}
+
+// control runs on the resolved address of every connection attempt
+func (p *Policy) control(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrDenied, err)
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil {
+		return fmt.Errorf("%w: unexpected address %q", ErrDenied, host)
+	}
+	return checkAddr(host, addr)
+}
+
+func (p *Policy) hostAllowed(host string) bool {
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	for _, allowed := range p.hosts {
+		if host == allowed || (strings.HasPrefix(allowed, ".") && strings.HasSuffix(host, allowed)) {
+			return true
+		}
+	}
+	return false
+}
+
+func checkAddr(host string, addr netip.Addr) error {
+	addr = addr.Unmap().WithZone("")
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsUnspecified() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return fmt.Errorf("%w: %s resolves to non-public address %s", ErrDenied, host, addr)
+	}
+	for _, prefix := range deniedPrefixes {
+		if prefix.Contains(addr) {
+			return fmt.Errorf("%w: %s resolves to reserved address %s", ErrDenied, host, addr)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,159 @@
+package egress
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// hopHeaders are meaningful for a single connection and are not forwarded
+var hopHeaders = []string{
+	"Connection",
+	"Keep-Alive",
+	"Proxy-Authenticate",
+	"Proxy-Authorization",
+	"Proxy-Connection",
+	"Te",
+	"Trailer",
+	"Transfer-Encoding",
+	"Upgrade",
+}
+
+// Proxy returns a forward HTTP proxy that applies the policy to every request
+// sent through it. Clients that fetch on their own, such as a headless
+// browser loading a page's redirects, frames, scripts and XHRs, can't be
+// checked URL by URL; pointing them at this proxy covers all of their traffic.
+// HTTPS goes through CONNECT tunnels, which are checked on their host and
+// port before the tunnel is opened.
+func (p *Policy) Proxy() http.Handler {
+	return &proxy{policy: p, transport: p.Transport(nil)}
+}
+
+type proxy struct {
+	policy    *Policy
+	transport *http.Transport
+}
+
+func (h *proxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method == http.MethodConnect {
+		h.tunnel(w, r)
+		return
+	}
+	if !r.URL.IsAbs() {
+		http.Error(w, "egress proxy only forwards absolute URLs", http.StatusBadRequest)
+		return
+	}
+	if err := h.policy.CheckURL(r.Context(), r.URL.String()); err != nil {
+		writeDenied(w, err)
+		return
+	}
+
+	out := r.Clone(r.Context())
+	out.RequestURI = ""
+	removeHopHeaders(out.Header)
+	resp, err := h.transport.RoundTrip(out)
+	if err != nil {
+		writeDenied(w, err)
+		return
+	}
+	defer resp.Body.Close()
+
+	removeHopHeaders(resp.Header)
+	for key, values := range resp.Header {
+		for _, value := range values {
+			w.Header().Add(key, value)
+		}
+	}
+	w.WriteHeader(resp.StatusCode)
+	_, _ = io.Copy(w, resp.Body)
+}
+
+// tunnel serves CONNECT, the way clients reach HTTPS and WSS through a proxy
+func (h *proxy) tunnel(w http.ResponseWriter, r *http.Request) {
+	if !h.policy.schemes["https"] {
+		writeDenied(w, fmt.Errorf("%w: scheme %q is not allowed", ErrDenied, "https"))
+		return
+	}
+	if err := h.policy.CheckURL(r.Context(), "https://"+r.Host); err != nil {
+		writeDenied(w, err)
+		return
+	}
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		http.Error(w, "egress proxy can't tunnel over this connection", http.StatusInternalServerError)
+		return
+	}
+
+	upstream, err := h.policy.dialContext(r.Context(), "tcp", r.Host)
+	if err != nil {
+		writeDenied(w, err)
+		return
+	}
+	client, buffered, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		return
+	}
+	if _, err := client.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		client.Close()
+		upstream.Close()
+		return
+	}
+	// Bytes the client sent right after the CONNECT request may already be buffered.
+	if n := buffered.Reader.Buffered(); n > 0 {
+		pending, _ := buffered.Reader.Peek(n)
+		if _, err := upstream.Write(pending); err != nil {
+			client.Close()
+			upstream.Close()
+			return
+		}
+	}
+	pipe(client, upstream)
+}
+
+// pipe copies both ways until either side is done, then closes both
+func pipe(a, b net.Conn) {
+	var once sync.Once
+	closeBoth := func() {
+		a.Close()
+		b.Close()
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(a, b)
+		once.Do(closeBoth)
+	}()
+	go func() {
+		defer wg.Done()
+		_, _ = io.Copy(b, a)
+		once.Do(closeBoth)
+	}()
+	wg.Wait()
+}
+
+func writeDenied(w http.ResponseWriter, err error) {
+	status := http.StatusBadGateway
+	if errors.Is(err, ErrDenied) {
+		status = http.StatusForbidden
+	}
+	http.Error(w, err.Error(), status)
+}
+
+func removeHopHeaders(header http.Header) {
+	for _, value := range header.Values("Connection") {
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				header.Del(name)
+			}
+		}
+	}
+	for _, name := range hopHeaders {
+		header.Del(name)
+	}
+}
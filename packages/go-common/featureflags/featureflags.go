@@ -0,0 +1,291 @@
+// Package featureflags decides per user whether a feature is on, so features
+// can be ramped up gradually, or switched off, without a redeploy.
+//
+// A Client merges the flags of its Sources: typically defaults derived from
+// the service's *_ENABLED settings and FEATURE_FLAGS, then a YAML file, then a
+// Redis hash, later sources overriding earlier ones. It reloads them
+// periodically while Run is active.
+package featureflags
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math/rand/v2"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// DefaultRefreshInterval is how often a Client reloads its sources.
+const DefaultRefreshInterval = 30 * time.Second
+
+// Flag is the rollout of one feature.
+type Flag struct {
+	Enabled bool     `yaml:"enabled" json:"enabled"`                 // Off for everyone when false
+	Percent int      `yaml:"percent" json:"percent"`                 // Share of users, 0-100
+	Users   []string `yaml:"users,omitempty" json:"users,omitempty"` // User keys always included
+}
+
+// On is a flag enabled for every user.
+var On = Flag{Enabled: true, Percent: 100}
+
+// Bool returns On when enabled and an off flag otherwise, for defaults
+// derived from boolean settings.
+func Bool(enabled bool) Flag {
+	if enabled {
+		return On
+	}
+	return Flag{}
+}
+
+// ParseFlag parses the short form of a flag: true/on/yes/1, false/off/no/0,
+// or a percentage such as "25%".
+func ParseFlag(value string) (Flag, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	switch value {
+	case "true", "on", "yes", "1":
+		return On, nil
+	case "false", "off", "no", "0":
+		return Flag{}, nil
+	}
+	if percent, ok := strings.CutSuffix(value, "%"); ok {
+		n, err := strconv.Atoi(strings.TrimSpace(percent))
+		if err != nil || n < 0 || n > 100 {
+			return Flag{}, fmt.Errorf("invalid rollout percentage %q", value)
+		}
+		return Flag{Enabled: true, Percent: n}, nil
+	}
+	return Flag{}, fmt.Errorf("invalid flag value %q: want true, false or a percentage", value)
+}
+
+// ParseFlags parses comma-separated key=value pairs in the short form, e.g.
+// "memory=true,deep_research=25%".
+func ParseFlags(spec string) (map[string]Flag, error) {
+	flags := make(map[string]Flag)
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid flag %q: want key=value", pair)
+		}
+		flag, err := ParseFlag(value)
+		if err != nil {
+			return nil, fmt.Errorf("flag %s: %w", strings.TrimSpace(key), err)
+		}
+		flags[strings.TrimSpace(key)] = flag
+	}
+	return flags, nil
+}
+
+// UnmarshalYAML accepts both the short form and the full mapping.
+func (f *Flag) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		flag, err := ParseFlag(node.Value)
+		if err != nil {
+			return err
+		}
+		*f = flag
+		return nil
+	}
+	var full fullFlag
+	if err := node.Decode(&full); err != nil {
+		return err
+	}
+	flag, err := full.flag()
+	if err != nil {
+		return err
+	}
+	*f = flag
+	return nil
+}
+
+// fullFlag is the mapping form of a flag, in which percent defaults to 100.
+type fullFlag struct {
+	Enabled bool     `yaml:"enabled" json:"enabled"`
+	Percent *int     `yaml:"percent" json:"percent"`
+	Users   []string `yaml:"users" json:"users"`
+}
+
+func (f fullFlag) flag() (Flag, error) {
+	flag := Flag{Enabled: f.Enabled, Percent: 100, Users: f.Users}
+	if f.Percent != nil {
+		if *f.Percent < 0 || *f.Percent > 100 {
+			return Flag{}, fmt.Errorf("invalid rollout percentage %d", *f.Percent)
+		}
+		flag.Percent = *f.Percent
+	}
+	return flag, nil
+}
+
+// User identifies whom a flag is evaluated for.
+type User struct {
+	Key   string   // Stable ID the rollout percentage is computed on
+	Flags []string // Flags granted explicitly, e.g. through Keycloak groups
+}
+
+type userContextKey struct{}
+
+// WithUser returns a copy of ctx carrying user, for Client.Enabled.
+func WithUser(ctx context.Context, user User) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the user stored by WithUser.
+func UserFromContext(ctx context.Context) (User, bool) {
+	user, ok := ctx.Value(userContextKey{}).(User)
+	return user, ok
+}
+
+// Config configures a Client.
+type Config struct {
+	RefreshInterval time.Duration // How often Run reloads the sources; defaults to DefaultRefreshInterval
+	// OnError reports sources that fail to load. The flags they loaded last
+	// stay in effect.
+	OnError func(err error)
+}
+
+// Client evaluates flags merged from its sources.
+type Client struct {
+	cfg     Config
+	sources []Source
+
+	mu     sync.RWMutex
+	loaded []map[string]Flag // Last successful load of each source
+	flags  map[string]Flag
+}
+
+// NewClient creates a Client and loads its sources once. Sources are merged
+// in order, so later ones override the flags of earlier ones.
+func NewClient(ctx context.Context, cfg Config, sources ...Source) *Client {
+	if cfg.RefreshInterval <= 0 {
+		cfg.RefreshInterval = DefaultRefreshInterval
+	}
+	c := &Client{
+		cfg:     cfg,
+		sources: sources,
+		loaded:  make([]map[string]Flag, len(sources)),
+		flags:   make(map[string]Flag),
+	}
+	if err := c.Refresh(ctx); err != nil && cfg.OnError != nil {
+		cfg.OnError(err)
+	}
+	return c
+}
+
+// Run reloads the sources every RefreshInterval until ctx is cancelled.
+func (c *Client) Run(ctx context.Context) {
+	ticker := time.NewTicker(c.cfg.RefreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := c.Refresh(ctx); err != nil && c.cfg.OnError != nil {
+				c.cfg.OnError(err)
+			}
+		}
+	}
+}
+
+// Refresh reloads every source. A source that fails to load keeps its last
+// flags; one that loads only some of its flags uses those.
+func (c *Client) Refresh(ctx context.Context) error {
+	var errs []error
+	loaded := make([]map[string]Flag, len(c.sources))
+	for i, source := range c.sources {
+		flags, err := source.Load(ctx)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("load feature flags from %s: %w", source, err))
+		}
+		if flags == nil && err != nil {
+			c.mu.RLock()
+			flags = c.loaded[i]
+			c.mu.RUnlock()
+		}
+		loaded[i] = flags
+	}
+
+	merged := make(map[string]Flag)
+	for _, flags := range loaded {
+		for key, flag := range flags {
+			merged[key] = flag
+		}
+	}
+
+	c.mu.Lock()
+	c.loaded = loaded
+	c.flags = merged
+	c.mu.Unlock()
+	return errors.Join(errs...)
+}
+
+// Enabled reports whether the flag key is on for the user stored in ctx by
+// WithUser. Without a user, partial rollouts apply to that share of calls.
+// A nil Client has every flag off.
+func (c *Client) Enabled(ctx context.Context, key string) bool {
+	user, _ := UserFromContext(ctx)
+	return c.EnabledFor(key, user)
+}
+
+// EnabledFor reports whether the flag key is on for user.
+func (c *Client) EnabledFor(key string, user User) bool {
+	if c == nil {
+		return false
+	}
+	c.mu.RLock()
+	flag, ok := c.flags[key]
+	c.mu.RUnlock()
+	if !ok || !flag.Enabled {
+		return false
+	}
+	if flag.Percent >= 100 {
+		return true
+	}
+	for _, granted := range user.Flags {
+		if strings.EqualFold(granted, key) {
+			return true
+		}
+	}
+	if user.Key == "" {
+		return rand.IntN(100) < flag.Percent
+	}
+	for _, included := range flag.Users {
+		if included == user.Key {
+			return true
+		}
+	}
+	return bucket(key, user.Key) < flag.Percent
+}
+
+// Flags returns a copy of the merged flags.
+func (c *Client) Flags() map[string]Flag {
+	if c == nil {
+		return nil
+	}
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	flags := make(map[string]Flag, len(c.flags))
+	for key, flag := range c.flags {
+		flags[key] = flag
+	}
+	return flags
+}
+
+// bucket maps a user to one of 100 buckets of a flag. Hashing the flag key in
+// lets each flag roll out to a different slice of users.
+func bucket(key, userKey string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	h.Write([]byte{0})
+	h.Write([]byte(userKey))
+	return int(h.Sum32() % 100)
+}
@@ -0,0 +1,112 @@
+package featureflags
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Source loads flags from one backend.
+type Source interface {
+	Load(ctx context.Context) (map[string]Flag, error)
+	// String describes the source in errors
+	String() string
+}
+
+// Static is a fixed set of flags, e.g. the defaults of a service's settings.
+type Static map[string]Flag
+
+// Load returns the flags.
+func (s Static) Load(context.Context) (map[string]Flag, error) {
+	return s, nil
+}
+
+func (s Static) String() string {
+	return "config"
+}
+
+// File reads flags from a YAML (or JSON) file mapping keys to flags in the
+// short or full form:
+//
+//	memory: true
+//	deep_research: 25%
+//	vllm_routing:
+//	  enabled: true
+//	  percent: 10
+//	  users: [2f0c...]
+//
+// In the mapping form, percent defaults to 100. A missing file has no flags,
+// so it can be mounted after startup.
+type File string
+
+// Load reads the file.
+func (f File) Load(context.Context) (map[string]Flag, error) {
+	data, err := os.ReadFile(string(f))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var flags map[string]Flag
+	if err := yaml.Unmarshal(data, &flags); err != nil {
+		return nil, err
+	}
+	return flags, nil
+}
+
+func (f File) String() string {
+	return string(f)
+}
+
+// Hash reads flags from the fields of a Redis hash, whose values are in the
+// short form or a JSON object like the file's mapping form, e.g.
+// HSET jan:feature-flags memory 25%. HGetAll keeps this package free of a
+// Redis client:
+//
+//	featureflags.Hash{Key: "jan:feature-flags", HGetAll: func(ctx context.Context, key string) (map[string]string, error) {
+//		return redisClient.HGetAll(ctx, key).Result()
+//	}}
+type Hash struct {
+	Key     string
+	HGetAll func(ctx context.Context, key string) (map[string]string, error)
+}
+
+// Load reads the hash. Fields with an invalid value are skipped and reported.
+func (h Hash) Load(ctx context.Context) (map[string]Flag, error) {
+	fields, err := h.HGetAll(ctx, h.Key)
+	if err != nil {
+		return nil, err
+	}
+	flags := make(map[string]Flag, len(fields))
+	var errs []error
+	for key, value := range fields {
+		flag, err := parseHashValue(value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("field %s: %w", key, err))
+			continue
+		}
+		flags[key] = flag
+	}
+	return flags, errors.Join(errs...)
+}
+
+func (h Hash) String() string {
+	return "redis hash " + h.Key
+}
+
+func parseHashValue(value string) (Flag, error) {
+	if !strings.HasPrefix(strings.TrimSpace(value), "{") {
+		return ParseFlag(value)
+	}
+	var full fullFlag
+	if err := json.Unmarshal([]byte(value), &full); err != nil {
+		return Flag{}, err
+	}
+	return full.flag()
+}
@@ -0,0 +1,185 @@
+// Package health serves the /readyz report of Jan Server services: registered
+// dependency probes run concurrently, critical ones failing make the service
+// not_ready (503) and optional ones only degrade it.
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+const (
+	StatusReady    = "ready"
+	StatusDegraded = "degraded"
+	StatusNotReady = "not_ready"
+
+	StatusUp   = "up"
+	StatusDown = "down"
+)
+
+// Check probes a single dependency and returns an error when it is unavailable.
+type Check func(ctx context.Context) error
+
+// DependencyStatus is the per-dependency result reported by /readyz.
+type DependencyStatus struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+// Report is the JSON body returned by /readyz.
+type Report struct {
+	Service      string                      `json:"service"`
+	Status       string                      `json:"status"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+	Dependencies map[string]DependencyStatus `json:"dependencies"`
+}
+
+type registeredCheck struct {
+	name     string
+	critical bool
+	check    Check
+}
+
+// Checker runs the registered dependency probes concurrently.
+// Critical dependencies turn the service not_ready; optional ones only degrade it.
+type Checker struct {
+	service string
+	timeout time.Duration
+	mu      sync.RWMutex
+	checks  []registeredCheck
+}
+
+// NewChecker creates a readiness checker with a per-probe timeout.
+func NewChecker(service string, timeout time.Duration) *Checker {
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	return &Checker{service: service, timeout: timeout}
+}
+
+// Register adds a dependency probe. Nil checks are ignored.
+func (c *Checker) Register(name string, critical bool, check Check) {
+	if c == nil || check == nil {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.checks = append(c.checks, registeredCheck{name: name, critical: critical, check: check})
+}
+
+// Run executes all probes and aggregates the results.
+func (c *Checker) Run(ctx context.Context) Report {
+	c.mu.RLock()
+	checks := make([]registeredCheck, len(c.checks))
+	copy(checks, c.checks)
+	c.mu.RUnlock()
+
+	report := Report{
+		Service:      c.service,
+		Status:       StatusReady,
+		CheckedAt:    time.Now().UTC(),
+		Dependencies: make(map[string]DependencyStatus, len(checks)),
+	}
+
+	var (
+		wg sync.WaitGroup
+		mu sync.Mutex
+	)
+	for _, rc := range checks {
+		wg.Add(1)
+		go func(rc registeredCheck) {
+			defer wg.Done()
+			probeCtx, cancel := context.WithTimeout(ctx, c.timeout)
+			defer cancel()
+
+			start := time.Now()
+			err := rc.check(probeCtx)
+			status := DependencyStatus{
+				Status:    StatusUp,
+				Critical:  rc.critical,
+				LatencyMs: time.Since(start).Milliseconds(),
+			}
+			if err != nil {
+				status.Status = StatusDown
+				status.Error = err.Error()
+			}
+
+			mu.Lock()
+			report.Dependencies[rc.name] = status
+			mu.Unlock()
+		}(rc)
+	}
+	wg.Wait()
+
+	for _, dep := range report.Dependencies {
+		if dep.Status == StatusUp {
+			continue
+		}
+		if dep.Critical {
+			report.Status = StatusNotReady
+			break
+		}
+		report.Status = StatusDegraded
+	}
+	return report
+}
+
+// ServeHTTP serves the aggregated readiness report; 503 when a critical dependency is down.
+// Gin services mount it with gin.WrapH.
+func (c *Checker) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	report := c.Run(r.Context())
+	status := http.StatusOK
+	if report.Status == StatusNotReady {
+		status = http.StatusServiceUnavailable
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(report)
+}
+
+// DatabaseCheck pings the underlying SQL connection pool.
+func DatabaseCheck(db *gorm.DB) Check {
+	if db == nil {
+		return nil
+	}
+	return func(ctx context.Context) error {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return err
+		}
+		return sqlDB.PingContext(ctx)
+	}
+}
+
+// HTTPCheck issues a GET and treats any non-5xx response as reachable.
+func HTTPCheck(client *http.Client, url string) Check {
+	if url == "" {
+		return nil
+	}
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return err
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode >= http.StatusInternalServerError {
+			return fmt.Errorf("unexpected status %d", resp.StatusCode)
+		}
+		return nil
+	}
+}
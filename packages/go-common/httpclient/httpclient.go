@@ -0,0 +1,185 @@
+// Package httpclient builds the outbound HTTP clients of Jan Server services
+// from one tunable configuration per target (model providers, mcp-tools,
+// media-api, memory-tools, ...), instead of ad-hoc http.Clients that share
+// http.DefaultTransport or build a fresh transport per request.
+package httpclient
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config tunes the client of one outbound target.
+type Config struct {
+	Timeout               time.Duration // Whole exchange including the body; 0 disables it (streaming)
+	DialTimeout           time.Duration // TCP connect
+	TLSHandshakeTimeout   time.Duration
+	ResponseHeaderTimeout time.Duration // Wait for response headers after the request is sent; 0 disables it
+	IdleConnTimeout       time.Duration // How long an idle pooled connection is kept
+	MaxIdleConns          int           // Idle connections kept across all hosts
+	MaxIdleConnsPerHost   int           // Idle connections kept per host
+	MaxConnsPerHost       int           // Dialing, active and idle connections per host; 0 is unlimited
+	Retries               int           // Extra attempts for idempotent requests on connection errors and 429/502/503/504
+	RetryBackoff          time.Duration // Wait before the first retry, doubled on each attempt
+	ProxyURL              string        // Empty uses HTTP_PROXY, HTTPS_PROXY and NO_PROXY; "direct" disables proxying
+}
+
+// DefaultConfig returns settings suited to short request/response calls
+// between services. Targets override the fields they need.
+func DefaultConfig() Config {
+	return Config{
+		Timeout:             30 * time.Second,
+		DialTimeout:         5 * time.Second,
+		TLSHandshakeTimeout: 5 * time.Second,
+		IdleConnTimeout:     90 * time.Second,
+		MaxIdleConns:        100,
+		MaxIdleConnsPerHost: 10,
+		RetryBackoff:        200 * time.Millisecond,
+	}
+}
+
+// FromEnv overrides defaults with the target's environment variables, each
+// named prefix plus the setting: TIMEOUT, DIAL_TIMEOUT, TLS_HANDSHAKE_TIMEOUT,
+// RESPONSE_HEADER_TIMEOUT, IDLE_CONN_TIMEOUT, MAX_IDLE_CONNS,
+// MAX_IDLE_CONNS_PER_HOST, MAX_CONNS_PER_HOST, RETRIES, RETRY_BACKOFF and
+// PROXY_URL. For example FromEnv("MEMORY_HTTP_", defaults) reads
+// MEMORY_HTTP_TIMEOUT. Unset variables keep the default.
+func FromEnv(prefix string, defaults Config) (Config, error) {
+	cfg := defaults
+	durations := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"TIMEOUT", &cfg.Timeout},
+		{"DIAL_TIMEOUT", &cfg.DialTimeout},
+		{"TLS_HANDSHAKE_TIMEOUT", &cfg.TLSHandshakeTimeout},
+		{"RESPONSE_HEADER_TIMEOUT", &cfg.ResponseHeaderTimeout},
+		{"IDLE_CONN_TIMEOUT", &cfg.IdleConnTimeout},
+		{"RETRY_BACKOFF", &cfg.RetryBackoff},
+	}
+	for _, d := range durations {
+		raw, ok := lookup(prefix + d.name)
+		if !ok {
+			continue
+		}
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid %s%s: %w", prefix, d.name, err)
+		}
+		*d.value = parsed
+	}
+
+	ints := []struct {
+		name  string
+		value *int
+	}{
+		{"MAX_IDLE_CONNS", &cfg.MaxIdleConns},
+		{"MAX_IDLE_CONNS_PER_HOST", &cfg.MaxIdleConnsPerHost},
+		{"MAX_CONNS_PER_HOST", &cfg.MaxConnsPerHost},
+		{"RETRIES", &cfg.Retries},
+	}
+	for _, i := range ints {
+		raw, ok := lookup(prefix + i.name)
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid %s%s: %w", prefix, i.name, err)
+		}
+		*i.value = parsed
+	}
+
+	if raw, ok := lookup(prefix + "PROXY_URL"); ok {
+		cfg.ProxyURL = raw
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, fmt.Errorf("%s*: %w", prefix, err)
+	}
+	return cfg, nil
+}
+
+func lookup(name string) (string, bool) {
+	raw, ok := os.LookupEnv(name)
+	raw = strings.TrimSpace(raw)
+	return raw, ok && raw != ""
+}
+
+// Validate rejects negative values and malformed proxy URLs.
+func (c Config) Validate() error {
+	for name, d := range map[string]time.Duration{
+		"timeout":                 c.Timeout,
+		"dial timeout":            c.DialTimeout,
+		"TLS handshake timeout":   c.TLSHandshakeTimeout,
+		"response header timeout": c.ResponseHeaderTimeout,
+		"idle connection timeout": c.IdleConnTimeout,
+		"retry backoff":           c.RetryBackoff,
+	} {
+		if d < 0 {
+			return fmt.Errorf("%s must not be negative", name)
+		}
+	}
+	if c.MaxIdleConns < 0 || c.MaxIdleConnsPerHost < 0 || c.MaxConnsPerHost < 0 || c.Retries < 0 {
+		return fmt.Errorf("connection limits and retries must not be negative")
+	}
+	if _, err := c.proxy(); err != nil {
+		return err
+	}
+	return nil
+}
+
+func (c Config) proxy() (func(*http.Request) (*url.URL, error), error) {
+	switch c.ProxyURL {
+	case "":
+		return http.ProxyFromEnvironment, nil
+	case "direct":
+		return nil, nil
+	}
+	proxyURL, err := url.Parse(c.ProxyURL)
+	if err != nil || proxyURL.Host == "" {
+		return nil, fmt.Errorf("invalid proxy URL %q", c.ProxyURL)
+	}
+	return http.ProxyURL(proxyURL), nil
+}
+
+// NewTransport builds a pooled transport from cfg. Share one transport per
+// target so connections are reused across requests.
+func NewTransport(cfg Config) (*http.Transport, error) {
+	proxy, err := cfg.proxy()
+	if err != nil {
+		return nil, err
+	}
+	dialer := &net.Dialer{Timeout: cfg.DialTimeout, KeepAlive: 30 * time.Second}
+	return &http.Transport{
+		Proxy:                 proxy,
+		DialContext:           dialer.DialContext,
+		ForceAttemptHTTP2:     true,
+		TLSHandshakeTimeout:   cfg.TLSHandshakeTimeout,
+		ResponseHeaderTimeout: cfg.ResponseHeaderTimeout,
+		IdleConnTimeout:       cfg.IdleConnTimeout,
+		MaxIdleConns:          cfg.MaxIdleConns,
+		MaxIdleConnsPerHost:   cfg.MaxIdleConnsPerHost,
+		MaxConnsPerHost:       cfg.MaxConnsPerHost,
+		ExpectContinueTimeout: time.Second,
+	}, nil
+}
+
+// New builds an http.Client for one target: a pooled transport from cfg,
+// wrapped with retries when cfg.Retries > 0.
+func New(cfg Config) (*http.Client, error) {
+	transport, err := NewTransport(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var roundTripper http.RoundTripper = transport
+	if cfg.Retries > 0 {
+		roundTripper = &retryTransport{next: transport, retries: cfg.Retries, backoff: cfg.RetryBackoff}
+	}
+	return &http.Client{Timeout: cfg.Timeout, Transport: roundTripper}, nil
+}
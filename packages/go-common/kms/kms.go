@@ -0,0 +1,227 @@
+// Package kms wraps the per-owner data keys of Jan Server's encryption at rest
+// with a master key held in a KMS (a local key or Vault's transit engine), and
+// seals data with AES-256-GCM under those data keys.
+package kms
+
+import (
+	"bytes"
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// KMS providers
+const (
+	ProviderLocal = "local" // Master key from ENCRYPTION_MASTER_KEY
+	ProviderVault = "vault" // HashiCorp Vault transit secrets engine
+)
+
+const (
+	KeySize       = 32 // AES-256 master and data keys
+	formatVersion = 1  // First byte of every ciphertext
+)
+
+// Config selects the KMS that wraps data keys
+type Config struct {
+	Provider     string // local or vault
+	MasterKey    string // local: base64-encoded 32-byte key
+	VaultAddr    string
+	VaultToken   string
+	VaultKeyName string
+}
+
+// NewKeyWrapper creates the wrapper cfg selects, or returns nil when the
+// selected provider is not configured
+func NewKeyWrapper(cfg Config) (KeyWrapper, error) {
+	switch strings.ToLower(strings.TrimSpace(cfg.Provider)) {
+	case "", ProviderLocal:
+		if strings.TrimSpace(cfg.MasterKey) == "" {
+			return nil, nil
+		}
+		return NewLocalKeyWrapper(cfg.MasterKey)
+	case ProviderVault:
+		if strings.TrimSpace(cfg.VaultAddr) == "" {
+			return nil, nil
+		}
+		return NewVaultKeyWrapper(cfg.VaultAddr, cfg.VaultToken, cfg.VaultKeyName)
+	default:
+		return nil, fmt.Errorf("unknown KMS provider %q", cfg.Provider)
+	}
+}
+
+// KeyWrapper encrypts and decrypts data keys with a master key that never
+// leaves the KMS
+type KeyWrapper interface {
+	Name() string
+	Wrap(ctx context.Context, dataKey []byte) ([]byte, error)
+	Unwrap(ctx context.Context, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyWrapper wraps data keys with an AES-256-GCM master key held in
+// process memory. It suits single-node deployments; use Vault when the master
+// key must stay in a dedicated KMS.
+type LocalKeyWrapper struct {
+	aead cipher.AEAD
+}
+
+// NewLocalKeyWrapper creates a wrapper from a base64-encoded 32-byte master key
+func NewLocalKeyWrapper(masterKey string) (*LocalKeyWrapper, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(masterKey))
+	if err != nil {
+		return nil, fmt.Errorf("decode master key: %w", err)
+	}
+	if len(key) != KeySize {
+		return nil, fmt.Errorf("master key must be %d bytes, got %d", KeySize, len(key))
+	}
+	aead, err := NewAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalKeyWrapper{aead: aead}, nil
+}
+
+// Name implements KeyWrapper.
+func (w *LocalKeyWrapper) Name() string {
+	return ProviderLocal
+}
+
+// Wrap implements KeyWrapper.
+func (w *LocalKeyWrapper) Wrap(_ context.Context, dataKey []byte) ([]byte, error) {
+	return Seal(w.aead, dataKey, nil)
+}
+
+// Unwrap implements KeyWrapper.
+func (w *LocalKeyWrapper) Unwrap(_ context.Context, wrapped []byte) ([]byte, error) {
+	return Open(w.aead, wrapped, nil)
+}
+
+// VaultKeyWrapper wraps data keys with a named key of Vault's transit engine,
+// so the master key never reaches this service
+type VaultKeyWrapper struct {
+	addr       string
+	token      string
+	keyName    string
+	httpClient *http.Client
+}
+
+// NewVaultKeyWrapper creates a wrapper for the transit key keyName at addr
+func NewVaultKeyWrapper(addr, token, keyName string) (*VaultKeyWrapper, error) {
+	addr = strings.TrimRight(strings.TrimSpace(addr), "/")
+	if addr == "" || token == "" || keyName == "" {
+		return nil, errors.New("vault address, token and key name are required")
+	}
+	return &VaultKeyWrapper{
+		addr:       addr,
+		token:      token,
+		keyName:    keyName,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}, nil
+}
+
+// Name implements KeyWrapper.
+func (w *VaultKeyWrapper) Name() string {
+	return ProviderVault
+}
+
+// Wrap implements KeyWrapper. The wrapped key is Vault's "vault:v1:..." ciphertext.
+func (w *VaultKeyWrapper) Wrap(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Ciphertext string `json:"ciphertext"`
+		} `json:"data"`
+	}
+	body := map[string]string{"plaintext": base64.StdEncoding.EncodeToString(dataKey)}
+	if err := w.call(ctx, "encrypt", body, &resp); err != nil {
+		return nil, err
+	}
+	if resp.Data.Ciphertext == "" {
+		return nil, errors.New("vault returned no ciphertext")
+	}
+	return []byte(resp.Data.Ciphertext), nil
+}
+
+// Unwrap implements KeyWrapper.
+func (w *VaultKeyWrapper) Unwrap(ctx context.Context, wrapped []byte) ([]byte, error) {
+	var resp struct {
+		Data struct {
+			Plaintext string `json:"plaintext"`
+		} `json:"data"`
+	}
+	body := map[string]string{"ciphertext": string(wrapped)}
+	if err := w.call(ctx, "decrypt", body, &resp); err != nil {
+		return nil, err
+	}
+	return base64.StdEncoding.DecodeString(resp.Data.Plaintext)
+}
+
+func (w *VaultKeyWrapper) call(ctx context.Context, operation string, body any, out any) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	url := fmt.Sprintf("%s/v1/transit/%s/%s", w.addr, operation, w.keyName)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-Vault-Token", w.token)
+
+	resp, err := w.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("vault transit %s: %w", operation, err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("read vault response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("vault transit %s (status %d): %s", operation, resp.StatusCode, strings.TrimSpace(string(respBody)))
+	}
+	return json.Unmarshal(respBody, out)
+}
+
+// NewAEAD creates an AES-GCM cipher for a 32-byte key
+func NewAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// Seal encrypts plaintext as version byte, nonce, then the GCM ciphertext.
+// additionalData binds the ciphertext to the row it belongs to.
+func Seal(aead cipher.AEAD, plaintext, additionalData []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	out := make([]byte, 0, 1+len(nonce)+len(plaintext)+aead.Overhead())
+	out = append(out, formatVersion)
+	out = append(out, nonce...)
+	return aead.Seal(out, nonce, plaintext, additionalData), nil
+}
+
+// Open reverses Seal
+func Open(aead cipher.AEAD, ciphertext, additionalData []byte) ([]byte, error) {
+	if len(ciphertext) < 1+aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	if ciphertext[0] != formatVersion {
+		return nil, fmt.Errorf("unsupported ciphertext version %d", ciphertext[0])
+	}
+	nonce := ciphertext[1 : 1+aead.NonceSize()]
+	return aead.Open(nil, nonce, ciphertext[1+aead.NonceSize():], additionalData)
+}
@@ -0,0 +1,264 @@
+package leader
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+)
+
+const (
+	serviceAccountDir = "/var/run/secrets/kubernetes.io/serviceaccount"
+	microTimeLayout   = "2006-01-02T15:04:05.000000Z07:00"
+)
+
+// KubernetesLeaseLocker holds a coordination.k8s.io/v1 Lease, the same
+// object client-go's leader election uses. It talks to the API server with
+// the pod's service account, which needs get, create and update on leases in
+// its namespace.
+type KubernetesLeaseLocker struct {
+	client        *http.Client
+	apiURL        string
+	namespace     string
+	name          string
+	leaseDuration time.Duration
+}
+
+// KubernetesLeaseConfig configures a KubernetesLeaseLocker.
+type KubernetesLeaseConfig struct {
+	Name          string        // Lease name, shared by all replicas
+	Namespace     string        // Defaults to the pod's namespace
+	LeaseDuration time.Duration // How long a leader that stops renewing keeps the lease; defaults to 15s
+}
+
+// NewKubernetesLeaseLocker creates a locker from the in-cluster service account.
+func NewKubernetesLeaseLocker(cfg KubernetesLeaseConfig) (*KubernetesLeaseLocker, error) {
+	host, port := os.Getenv("KUBERNETES_SERVICE_HOST"), os.Getenv("KUBERNETES_SERVICE_PORT")
+	if host == "" || port == "" {
+		return nil, fmt.Errorf("not running in a Kubernetes cluster")
+	}
+	if cfg.Name == "" {
+		return nil, fmt.Errorf("lease name is required")
+	}
+	if cfg.Namespace == "" {
+		namespace, err := os.ReadFile(serviceAccountDir + "/namespace")
+		if err != nil {
+			return nil, fmt.Errorf("read pod namespace: %w", err)
+		}
+		cfg.Namespace = strings.TrimSpace(string(namespace))
+	}
+	if cfg.LeaseDuration <= 0 {
+		cfg.LeaseDuration = 15 * time.Second
+	}
+
+	ca, err := os.ReadFile(serviceAccountDir + "/ca.crt")
+	if err != nil {
+		return nil, fmt.Errorf("read cluster CA: %w", err)
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(ca) {
+		return nil, fmt.Errorf("parse cluster CA")
+	}
+
+	return &KubernetesLeaseLocker{
+		client: &http.Client{
+			Timeout:   10 * time.Second,
+			Transport: &http.Transport{TLSClientConfig: &tls.Config{RootCAs: pool, MinVersion: tls.VersionTLS12}},
+		},
+		apiURL:        "https://" + net.JoinHostPort(host, port),
+		namespace:     cfg.Namespace,
+		name:          cfg.Name,
+		leaseDuration: cfg.LeaseDuration,
+	}, nil
+}
+
+type lease struct {
+	APIVersion string        `json:"apiVersion"`
+	Kind       string        `json:"kind"`
+	Metadata   leaseMetadata `json:"metadata"`
+	Spec       leaseSpec     `json:"spec"`
+}
+
+type leaseMetadata struct {
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+type leaseSpec struct {
+	HolderIdentity       *string `json:"holderIdentity,omitempty"`
+	LeaseDurationSeconds *int    `json:"leaseDurationSeconds,omitempty"`
+	AcquireTime          *string `json:"acquireTime,omitempty"`
+	RenewTime            *string `json:"renewTime,omitempty"`
+	LeaseTransitions     *int    `json:"leaseTransitions,omitempty"`
+}
+
+// TryLock implements Locker.
+func (l *KubernetesLeaseLocker) TryLock(ctx context.Context, identity string) (bool, error) {
+	current, err := l.get(ctx)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	nowText := now.UTC().Format(microTimeLayout)
+	seconds := int(l.leaseDuration.Seconds())
+
+	if current == nil {
+		zero := 0
+		created := &lease{
+			APIVersion: "coordination.k8s.io/v1",
+			Kind:       "Lease",
+			Metadata:   leaseMetadata{Name: l.name, Namespace: l.namespace},
+			Spec: leaseSpec{
+				HolderIdentity:       &identity,
+				LeaseDurationSeconds: &seconds,
+				AcquireTime:          &nowText,
+				RenewTime:            &nowText,
+				LeaseTransitions:     &zero,
+			},
+		}
+		return l.write(ctx, http.MethodPost, l.collectionURL(), created)
+	}
+
+	holder := ""
+	if current.Spec.HolderIdentity != nil {
+		holder = *current.Spec.HolderIdentity
+	}
+	if holder != "" && holder != identity && !leaseExpired(current.Spec, now) {
+		return false, nil
+	}
+
+	spec := current.Spec
+	if holder != identity {
+		transitions := 1
+		if spec.LeaseTransitions != nil {
+			transitions = *spec.LeaseTransitions + 1
+		}
+		spec.HolderIdentity = &identity
+		spec.AcquireTime = &nowText
+		spec.LeaseTransitions = &transitions
+	}
+	spec.LeaseDurationSeconds = &seconds
+	spec.RenewTime = &nowText
+	current.Spec = spec
+	// The resourceVersion makes the update fail with 409 if another replica
+	// took the lease since we read it.
+	return l.write(ctx, http.MethodPut, l.objectURL(), current)
+}
+
+// Unlock implements Locker. It clears the holder so another replica can take
+// over without waiting for the lease to expire.
+func (l *KubernetesLeaseLocker) Unlock(ctx context.Context, identity string) error {
+	current, err := l.get(ctx)
+	if err != nil || current == nil {
+		return err
+	}
+	if current.Spec.HolderIdentity == nil || *current.Spec.HolderIdentity != identity {
+		return nil
+	}
+	empty := ""
+	current.Spec.HolderIdentity = &empty
+	_, err = l.write(ctx, http.MethodPut, l.objectURL(), current)
+	return err
+}
+
+func leaseExpired(spec leaseSpec, now time.Time) bool {
+	if spec.RenewTime == nil || spec.LeaseDurationSeconds == nil {
+		return true
+	}
+	renewed, err := time.Parse(time.RFC3339Nano, *spec.RenewTime)
+	if err != nil {
+		return true
+	}
+	return now.After(renewed.Add(time.Duration(*spec.LeaseDurationSeconds) * time.Second))
+}
+
+func (l *KubernetesLeaseLocker) get(ctx context.Context) (*lease, error) {
+	resp, err := l.do(ctx, http.MethodGet, l.objectURL(), nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		var current lease
+		if err := json.NewDecoder(resp.Body).Decode(&current); err != nil {
+			return nil, fmt.Errorf("decode lease: %w", err)
+		}
+		return &current, nil
+	case http.StatusNotFound:
+		return nil, nil
+	default:
+		return nil, statusError("get", resp)
+	}
+}
+
+// write creates or updates the lease. A 409 means another replica won the race.
+func (l *KubernetesLeaseLocker) write(ctx context.Context, method, url string, body *lease) (bool, error) {
+	resp, err := l.do(ctx, method, url, body)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK, http.StatusCreated:
+		return true, nil
+	case http.StatusConflict:
+		return false, nil
+	default:
+		return false, statusError(strings.ToLower(method), resp)
+	}
+}
+
+func (l *KubernetesLeaseLocker) do(ctx context.Context, method, url string, body *lease) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return nil, err
+	}
+	// Projected service account tokens rotate; read the current one each time.
+	token, err := os.ReadFile(serviceAccountDir + "/token")
+	if err != nil {
+		return nil, fmt.Errorf("read service account token: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+strings.TrimSpace(string(token)))
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	resp, err := l.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("lease request: %w", err)
+	}
+	return resp, nil
+}
+
+func (l *KubernetesLeaseLocker) collectionURL() string {
+	return fmt.Sprintf("%s/apis/coordination.k8s.io/v1/namespaces/%s/leases", l.apiURL, l.namespace)
+}
+
+func (l *KubernetesLeaseLocker) objectURL() string {
+	return l.collectionURL() + "/" + l.name
+}
+
+func statusError(op string, resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("%s lease: status %d: %s", op, resp.StatusCode, strings.TrimSpace(string(body)))
+}
@@ -0,0 +1,162 @@
+// Package leader elects one replica of a service to run periodic jobs
+// (model sync, purgers, canaries) so they run exactly once across replicas.
+//
+// An Elector campaigns through a Locker backend: a Postgres advisory lock
+// (no extra infrastructure) or a Kubernetes Lease (no database session held).
+package leader
+
+import (
+	"context"
+	"os"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultRetryPeriod is how often an Elector tries to acquire or renew the lock.
+const DefaultRetryPeriod = 5 * time.Second
+
+// Locker is a distributed lock backend.
+type Locker interface {
+	// TryLock acquires the lock for identity, or renews it when identity
+	// already holds it. It reports whether identity holds the lock afterwards.
+	TryLock(ctx context.Context, identity string) (bool, error)
+	// Unlock releases the lock if identity holds it.
+	Unlock(ctx context.Context, identity string) error
+}
+
+// Config configures an Elector.
+type Config struct {
+	Identity    string        // Unique per replica; defaults to the hostname (the pod name in Kubernetes)
+	RetryPeriod time.Duration // How often to acquire or renew; defaults to DefaultRetryPeriod
+
+	// OnStartedLeading runs in its own goroutine when this replica becomes the
+	// leader. Its context is cancelled when leadership is lost.
+	OnStartedLeading func(ctx context.Context)
+	// OnStoppedLeading runs when this replica loses or releases leadership.
+	OnStoppedLeading func()
+	// OnError reports lock backend errors. Errors never stop the campaign.
+	OnError func(err error)
+}
+
+// Elector campaigns for leadership until its context is cancelled.
+type Elector struct {
+	locker  Locker
+	cfg     Config
+	leading atomic.Bool
+
+	mu     sync.Mutex
+	cancel context.CancelFunc // Cancels the OnStartedLeading context
+}
+
+// NewElector creates an Elector. Call Run to start campaigning.
+func NewElector(locker Locker, cfg Config) *Elector {
+	if cfg.Identity == "" {
+		cfg.Identity, _ = os.Hostname()
+	}
+	if cfg.RetryPeriod <= 0 {
+		cfg.RetryPeriod = DefaultRetryPeriod
+	}
+	return &Elector{locker: locker, cfg: cfg}
+}
+
+// Identity returns this replica's identity.
+func (e *Elector) Identity() string {
+	return e.cfg.Identity
+}
+
+// IsLeader reports whether this replica currently holds leadership. A nil
+// Elector (leader election disabled) is always the leader.
+func (e *Elector) IsLeader() bool {
+	return e == nil || e.leading.Load()
+}
+
+// RunIfLeader runs fn when this replica is the leader and reports whether it ran.
+func (e *Elector) RunIfLeader(fn func()) bool {
+	if !e.IsLeader() {
+		return false
+	}
+	fn()
+	return true
+}
+
+// Run campaigns for leadership until ctx is cancelled, then releases the lock.
+func (e *Elector) Run(ctx context.Context) error {
+	ticker := time.NewTicker(e.cfg.RetryPeriod)
+	defer ticker.Stop()
+
+	for {
+		e.tryLock(ctx)
+		select {
+		case <-ctx.Done():
+			e.release()
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+func (e *Elector) tryLock(ctx context.Context) {
+	// Bound each attempt so a hung backend cannot outlive the lease.
+	attemptCtx, cancel := context.WithTimeout(ctx, e.cfg.RetryPeriod)
+	defer cancel()
+
+	held, err := e.locker.TryLock(attemptCtx, e.cfg.Identity)
+	if err != nil {
+		e.reportError(err)
+		held = false
+	}
+	switch {
+	case held && !e.leading.Load():
+		e.startLeading(ctx)
+	case !held && e.leading.Load():
+		e.stopLeading()
+	}
+}
+
+func (e *Elector) startLeading(ctx context.Context) {
+	e.mu.Lock()
+	leaderCtx, cancel := context.WithCancel(ctx)
+	e.cancel = cancel
+	e.mu.Unlock()
+
+	e.leading.Store(true)
+	if e.cfg.OnStartedLeading != nil {
+		go e.cfg.OnStartedLeading(leaderCtx)
+	}
+}
+
+func (e *Elector) stopLeading() {
+	e.leading.Store(false)
+
+	e.mu.Lock()
+	if e.cancel != nil {
+		e.cancel()
+		e.cancel = nil
+	}
+	e.mu.Unlock()
+
+	if e.cfg.OnStoppedLeading != nil {
+		e.cfg.OnStoppedLeading()
+	}
+}
+
+func (e *Elector) release() {
+	if !e.leading.Load() {
+		return
+	}
+	e.stopLeading()
+
+	// The campaign context is already cancelled; give the release its own deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), e.cfg.RetryPeriod)
+	defer cancel()
+	if err := e.locker.Unlock(ctx, e.cfg.Identity); err != nil {
+		e.reportError(err)
+	}
+}
+
+func (e *Elector) reportError(err error) {
+	if e.cfg.OnError != nil {
+		e.cfg.OnError(err)
+	}
+}
@@ -0,0 +1,96 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"hash/fnv"
+	"sync"
+)
+
+// PostgresLocker holds a session-level Postgres advisory lock on a dedicated
+// connection. The lock is released when the connection closes, so a crashed
+// replica loses leadership as soon as Postgres notices the dropped session.
+//
+// Session locks do not survive transaction-mode connection poolers such as
+// PgBouncer in transaction pooling; point db at Postgres or a session pooler.
+type PostgresLocker struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn // Holds the lock while non-nil
+}
+
+// NewPostgresLocker creates a locker for the named lock. Every replica must
+// use the same name; distinct jobs may use distinct names.
+func NewPostgresLocker(db *sql.DB, name string) *PostgresLocker {
+	return &PostgresLocker{db: db, key: AdvisoryLockKey(name)}
+}
+
+// AdvisoryLockKey maps a lock name to a pg_advisory_lock key.
+func AdvisoryLockKey(name string) int64 {
+	h := fnv.New64a()
+	h.Write([]byte(name))
+	return int64(h.Sum64())
+}
+
+// TryLock implements Locker.
+func (l *PostgresLocker) TryLock(ctx context.Context, _ string) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		// The lock lives as long as the session; make sure it is still up.
+		if err := l.conn.PingContext(ctx); err != nil {
+			discard(l.conn)
+			l.conn = nil
+			return false, fmt.Errorf("advisory lock session lost: %w", err)
+		}
+		return true, nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("open advisory lock session: %w", err)
+	}
+	var acquired bool
+	if err := conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", l.key).Scan(&acquired); err != nil {
+		conn.Close()
+		return false, fmt.Errorf("pg_try_advisory_lock: %w", err)
+	}
+	if !acquired {
+		conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Unlock implements Locker.
+func (l *PostgresLocker) Unlock(ctx context.Context, _ string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	_, err := l.conn.ExecContext(ctx, "SELECT pg_advisory_unlock($1)", l.key)
+	if err != nil {
+		// Dropping the session releases the lock even though the unlock failed.
+		discard(l.conn)
+		l.conn = nil
+		return fmt.Errorf("pg_advisory_unlock: %w", err)
+	}
+	err = l.conn.Close()
+	l.conn = nil
+	return err
+}
+
+// discard closes the underlying session instead of returning it to the pool,
+// where it would keep holding the lock.
+func discard(conn *sql.Conn) {
+	_ = conn.Raw(func(any) error { return driver.ErrBadConn })
+	_ = conn.Close()
+}
@@ -0,0 +1,379 @@
+// Package logging exports zerolog events to an OTLP/HTTP collector and tags
+// them with the active span, so the logs of every service correlate with its
+// traces in Loki/Tempo or Grafana Cloud.
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+const (
+	otlpBatchSize     = 512
+	otlpQueueSize     = 8192
+	otlpFlushInterval = 2 * time.Second
+	otlpExportTimeout = 10 * time.Second
+	otlpScopeName     = "jan-server/logger"
+)
+
+// OTLPConfig configures the export of log events to an OTLP/HTTP collector.
+type OTLPConfig struct {
+	// Endpoint is the collector base URL, e.g. "otel-collector:4318" or
+	// "https://otlp-gateway-prod-eu-west-2.grafana.net/otlp". Records are
+	// posted to <Endpoint>/v1/logs.
+	Endpoint string
+	// Headers are sent with every export, in the OTEL_EXPORTER_OTLP_HEADERS
+	// format: "key1=value1,key2=value2" with URL-encoded values.
+	Headers          string
+	ServiceName      string
+	ServiceNamespace string
+	Environment      string
+}
+
+// OTLPExporter is a zerolog writer that converts JSON events to OTLP log
+// records and exports them in batches in the background. Writes never block
+// on the network; events are dropped while the queue is full.
+type OTLPExporter struct {
+	url      string
+	headers  map[string]string
+	resource otlpResource
+	client   *http.Client
+
+	mu      sync.Mutex
+	queue   []otlpLogRecord
+	dropped int
+
+	wake     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+// NewOTLPExporter creates an exporter and starts its background flush loop.
+func NewOTLPExporter(cfg OTLPConfig) *OTLPExporter {
+	endpoint := strings.TrimSpace(cfg.Endpoint)
+	if !strings.Contains(endpoint, "://") {
+		endpoint = "http://" + endpoint
+	}
+
+	attributes := []otlpKeyValue{stringAttribute("service.name", cfg.ServiceName)}
+	if cfg.ServiceNamespace != "" {
+		attributes = append(attributes, stringAttribute("service.namespace", cfg.ServiceNamespace))
+	}
+	if cfg.Environment != "" {
+		attributes = append(attributes, stringAttribute("deployment.environment", cfg.Environment))
+	}
+
+	e := &OTLPExporter{
+		url:      strings.TrimSuffix(endpoint, "/") + "/v1/logs",
+		headers:  parseOTLPHeaders(cfg.Headers),
+		resource: otlpResource{Attributes: attributes},
+		client:   &http.Client{Timeout: otlpExportTimeout},
+		wake:     make(chan struct{}, 1),
+		stop:     make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+	go e.run()
+	return e
+}
+
+// Write queues one zerolog JSON event for export.
+func (e *OTLPExporter) Write(p []byte) (int, error) {
+	record, ok := toOTLPLogRecord(p)
+	if !ok {
+		return len(p), nil
+	}
+
+	e.mu.Lock()
+	if len(e.queue) >= otlpQueueSize {
+		e.dropped++
+		e.mu.Unlock()
+		return len(p), nil
+	}
+	e.queue = append(e.queue, record)
+	full := len(e.queue) >= otlpBatchSize
+	e.mu.Unlock()
+
+	if full {
+		select {
+		case e.wake <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Shutdown exports the queued events and stops the flush loop.
+func (e *OTLPExporter) Shutdown(ctx context.Context) error {
+	e.stopOnce.Do(func() { close(e.stop) })
+	select {
+	case <-e.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *OTLPExporter) run() {
+	defer close(e.done)
+	ticker := time.NewTicker(otlpFlushInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+		case <-e.wake:
+		case <-e.stop:
+			e.flush()
+			return
+		}
+		e.flush()
+	}
+}
+
+func (e *OTLPExporter) flush() {
+	for {
+		e.mu.Lock()
+		n := min(len(e.queue), otlpBatchSize)
+		batch := e.queue[:n:n]
+		e.queue = e.queue[n:]
+		dropped := e.dropped
+		e.dropped = 0
+		e.mu.Unlock()
+
+		if dropped > 0 {
+			// The logger itself cannot be used here without looping back into the exporter
+			fmt.Fprintf(os.Stderr, "otlp log export: dropped %d events, queue full\n", dropped)
+		}
+		if n == 0 {
+			return
+		}
+		if err := e.export(batch); err != nil {
+			fmt.Fprintf(os.Stderr, "otlp log export: %v\n", err)
+		}
+	}
+}
+
+func (e *OTLPExporter) export(records []otlpLogRecord) error {
+	body, err := json.Marshal(otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: e.resource,
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: otlpScopeName},
+				LogRecords: records,
+			}},
+		}},
+	})
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), otlpExportTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, e.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range e.headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("collector returned %s for %d records", resp.Status, len(records))
+	}
+	return nil
+}
+
+// toOTLPLogRecord maps the level, message, time, trace_id and span_id fields
+// of a zerolog event to the record; the other fields become attributes.
+func toOTLPLogRecord(p []byte) (otlpLogRecord, bool) {
+	decoder := json.NewDecoder(bytes.NewReader(p))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return otlpLogRecord{}, false
+	}
+
+	now := time.Now()
+	record := otlpLogRecord{
+		TimeUnixNano:         strconv.FormatInt(now.UnixNano(), 10),
+		ObservedTimeUnixNano: strconv.FormatInt(now.UnixNano(), 10),
+	}
+	for key, value := range fields {
+		switch key {
+		case zerolog.LevelFieldName:
+			level, _ := value.(string)
+			record.SeverityText = strings.ToUpper(level)
+			record.SeverityNumber = severityNumber(level)
+		case zerolog.MessageFieldName:
+			record.Body = anyValue(value)
+		case zerolog.TimestampFieldName:
+			if ts, ok := eventTime(value); ok {
+				record.TimeUnixNano = strconv.FormatInt(ts.UnixNano(), 10)
+			}
+		case "trace_id":
+			record.TraceID, _ = value.(string)
+		case "span_id":
+			record.SpanID, _ = value.(string)
+		default:
+			record.Attributes = append(record.Attributes, otlpKeyValue{Key: key, Value: anyValue(value)})
+		}
+	}
+	return record, true
+}
+
+// severityNumber maps zerolog levels to OTLP severity numbers.
+func severityNumber(level string) int {
+	switch level {
+	case zerolog.LevelTraceValue:
+		return 1
+	case zerolog.LevelDebugValue:
+		return 5
+	case zerolog.LevelInfoValue:
+		return 9
+	case zerolog.LevelWarnValue:
+		return 13
+	case zerolog.LevelErrorValue:
+		return 17
+	case zerolog.LevelFatalValue:
+		return 21
+	case zerolog.LevelPanicValue:
+		return 24
+	default:
+		return 0
+	}
+}
+
+func eventTime(value any) (time.Time, bool) {
+	switch v := value.(type) {
+	case string:
+		ts, err := time.Parse(time.RFC3339Nano, v)
+		return ts, err == nil
+	case json.Number:
+		n, err := v.Int64()
+		if err != nil {
+			return time.Time{}, false
+		}
+		switch zerolog.TimeFieldFormat {
+		case zerolog.TimeFormatUnixMs:
+			return time.UnixMilli(n), true
+		case zerolog.TimeFormatUnixMicro:
+			return time.UnixMicro(n), true
+		case zerolog.TimeFormatUnixNano:
+			return time.Unix(0, n), true
+		default:
+			return time.Unix(n, 0), true
+		}
+	default:
+		return time.Time{}, false
+	}
+}
+
+func anyValue(value any) otlpAnyValue {
+	switch v := value.(type) {
+	case string:
+		return otlpAnyValue{StringValue: &v}
+	case bool:
+		return otlpAnyValue{BoolValue: &v}
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			s := v.String()
+			return otlpAnyValue{IntValue: &s}
+		}
+		if f, err := v.Float64(); err == nil {
+			return otlpAnyValue{DoubleValue: &f}
+		}
+		s := v.String()
+		return otlpAnyValue{StringValue: &s}
+	default:
+		// Objects and arrays are exported as their JSON text
+		raw, _ := json.Marshal(v)
+		s := string(raw)
+		return otlpAnyValue{StringValue: &s}
+	}
+}
+
+func stringAttribute(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func parseOTLPHeaders(raw string) map[string]string {
+	headers := make(map[string]string)
+	for _, pair := range strings.Split(raw, ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			continue
+		}
+		if unescaped, err := url.QueryUnescape(value); err == nil {
+			value = unescaped
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if key != "" && value != "" {
+			headers[key] = value
+		}
+	}
+	return headers
+}
+
+// OTLP/HTTP JSON encoding of ExportLogsServiceRequest
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano         string         `json:"timeUnixNano"`
+	ObservedTimeUnixNano string         `json:"observedTimeUnixNano"`
+	SeverityNumber       int            `json:"severityNumber,omitempty"`
+	SeverityText         string         `json:"severityText,omitempty"`
+	Body                 otlpAnyValue   `json:"body"`
+	Attributes           []otlpKeyValue `json:"attributes,omitempty"`
+	TraceID              string         `json:"traceId,omitempty"`
+	SpanID               string         `json:"spanId,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
@@ -0,0 +1,20 @@
+package logging
+
+import (
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TraceHook adds the trace_id and span_id of the active span to events logged
+// with Ctx(ctx), so logs and traces correlate in Loki/Tempo or Grafana Cloud.
+type TraceHook struct{}
+
+// Run implements zerolog.Hook.
+func (TraceHook) Run(e *zerolog.Event, _ zerolog.Level, _ string) {
+	spanContext := trace.SpanContextFromContext(e.GetCtx())
+	if !spanContext.IsValid() {
+		return
+	}
+	e.Str("trace_id", spanContext.TraceID().String()).
+		Str("span_id", spanContext.SpanID().String())
+}
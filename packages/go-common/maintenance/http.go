@@ -0,0 +1,73 @@
+package maintenance
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Blocks reports whether r is turned away while state is on: requests that
+// change data, and WebSocket upgrades, whose messages can. Reads, health
+// checks and exemptPaths are served as usual.
+func Blocks(state State, r *http.Request, exemptPaths ...string) bool {
+	if !state.Enabled {
+		return false
+	}
+	if !isMutatingMethod(r.Method) && !isWebSocketUpgrade(r) {
+		return false
+	}
+	for _, path := range exemptPaths {
+		if r.URL.Path == path {
+			return false
+		}
+	}
+	return true
+}
+
+// WriteError answers a request turned away during maintenance with 503, the
+// operator's message and Retry-After when the expected end is known.
+func WriteError(w http.ResponseWriter, state State) {
+	if retryAfter := state.RetryAfterSeconds(time.Now()); retryAfter > 0 {
+		w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_ = json.NewEncoder(w).Encode(map[string]string{
+		"type":    "maintenance",
+		"error":   "service is in maintenance mode",
+		"message": state.Message,
+	})
+}
+
+// Middleware turns away the requests Blocks matches while maintenance mode
+// is on. Gin services wrap their engine with it in the http.Server.
+func (s *Service) Middleware(exemptPaths ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		if s == nil {
+			return next
+		}
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if state := s.Current(); Blocks(state, r, exemptPaths...) {
+				WriteError(w, state)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+func isMutatingMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return false
+	default:
+		return true
+	}
+}
+
+func isWebSocketUpgrade(r *http.Request) bool {
+	return strings.Contains(strings.ToLower(r.Header.Get("Connection")), "upgrade") &&
+		strings.EqualFold(r.Header.Get("Upgrade"), "websocket")
+}
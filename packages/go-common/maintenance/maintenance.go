@@ -0,0 +1,161 @@
+// Package maintenance switches every Jan Server service into maintenance
+// mode, in which requests that change data are turned away with 503 while
+// reads and health checks keep working, e.g. during database migrations.
+//
+// The state lives under one Redis key shared by the services: llm-api's admin
+// API switches it, and each service refreshes its cached copy in the
+// background and checks it on every request.
+package maintenance
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/rs/zerolog"
+)
+
+// DefaultMessage is shown to clients when maintenance is enabled without one
+const DefaultMessage = "Jan is undergoing maintenance. Changes are disabled for now; reading still works."
+
+// State is the maintenance mode shared by the services.
+type State struct {
+	Enabled   bool       `json:"enabled"`
+	Message   string     `json:"message,omitempty"`
+	StartedAt *time.Time `json:"started_at,omitempty"`
+	StartedBy string     `json:"started_by,omitempty"` // Principal that enabled it
+	EndsAt    *time.Time `json:"ends_at,omitempty"`    // Expected end, sent to clients as Retry-After
+}
+
+// RetryAfterSeconds is the Retry-After value until the expected end, 0 when
+// there is none or it has passed.
+func (s State) RetryAfterSeconds(now time.Time) int {
+	if s.EndsAt == nil || !s.EndsAt.After(now) {
+		return 0
+	}
+	return int(s.EndsAt.Sub(now).Round(time.Second) / time.Second)
+}
+
+// Store keeps the state. It must be shared by every replica and service for
+// maintenance mode to be cluster-wide.
+type Store interface {
+	// Get returns the stored state, the zero State if there is none.
+	Get(ctx context.Context) (State, error)
+	Set(ctx context.Context, state State) error
+}
+
+// ErrEndsInPast means the expected end of maintenance has already passed
+var ErrEndsInPast = errors.New("ends_at must be in the future")
+
+// Service caches the state of the store, so checking it costs no round trip,
+// and refreshes it every refreshInterval while Run is active. A nil Service is
+// never in maintenance, so services without Redis can mount it unconditionally.
+type Service struct {
+	store           Store
+	refreshInterval time.Duration
+	log             zerolog.Logger
+
+	mu    sync.RWMutex
+	state State
+}
+
+// NewService creates a service and loads the current state.
+func NewService(store Store, refreshInterval time.Duration, log zerolog.Logger) *Service {
+	s := &Service{
+		store:           store,
+		refreshInterval: refreshInterval,
+		log:             log.With().Str("component", "maintenance").Logger(),
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.Refresh(ctx); err != nil {
+		s.log.Warn().Err(err).Msg("failed to load maintenance state, assuming it is off")
+	}
+	return s
+}
+
+// Current returns the cached state.
+func (s *Service) Current() State {
+	if s == nil {
+		return State{}
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.state
+}
+
+// Refresh reloads the state from the store. The cached state is kept when
+// the store cannot be reached.
+func (s *Service) Refresh(ctx context.Context) error {
+	state, err := s.store.Get(ctx)
+	if err != nil {
+		return err
+	}
+	s.setCached(state)
+	return nil
+}
+
+// Run refreshes the state until ctx is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	if s == nil || s.refreshInterval <= 0 {
+		return
+	}
+	ticker := time.NewTicker(s.refreshInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.Refresh(ctx); err != nil {
+				s.log.Warn().Err(err).Msg("failed to refresh maintenance state, keeping the last one")
+			}
+		}
+	}
+}
+
+// Enable turns maintenance mode on. An enabled state keeps its start, so the
+// message or expected end can be changed while it lasts.
+func (s *Service) Enable(ctx context.Context, message string, endsAt *time.Time, startedBy string) (State, error) {
+	now := time.Now().UTC()
+	if endsAt != nil && !endsAt.After(now) {
+		return State{}, ErrEndsInPast
+	}
+	message = strings.TrimSpace(message)
+	if message == "" {
+		message = DefaultMessage
+	}
+
+	current, err := s.store.Get(ctx)
+	if err != nil {
+		return State{}, err
+	}
+	state := State{Enabled: true, Message: message, StartedAt: &now, StartedBy: startedBy, EndsAt: endsAt}
+	if current.Enabled {
+		state.StartedAt, state.StartedBy = current.StartedAt, current.StartedBy
+	}
+	if err := s.store.Set(ctx, state); err != nil {
+		return State{}, err
+	}
+	s.setCached(state)
+	s.log.Warn().Str("started_by", startedBy).Str("message", message).Msg("maintenance mode enabled")
+	return state, nil
+}
+
+// Disable turns maintenance mode off.
+func (s *Service) Disable(ctx context.Context) (State, error) {
+	if err := s.store.Set(ctx, State{}); err != nil {
+		return State{}, err
+	}
+	s.setCached(State{})
+	s.log.Info().Msg("maintenance mode disabled")
+	return State{}, nil
+}
+
+func (s *Service) setCached(state State) {
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+}
@@ -0,0 +1,94 @@
+package maintenance
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+// RedisKey holds the state in the Redis at REDIS_URL, which is shared with
+// other data. Every service reads the same key.
+const RedisKey = "jan:maintenance"
+
+// RedisStore keeps the state in Redis.
+type RedisStore struct {
+	client redis.UniversalClient
+}
+
+// NewRedisStore creates a store on client.
+func NewRedisStore(client redis.UniversalClient) *RedisStore {
+	return &RedisStore{client: client}
+}
+
+// Get returns the stored state, the zero State if there is none.
+func (s *RedisStore) Get(ctx context.Context) (State, error) {
+	var state State
+	payload, err := s.client.Get(ctx, RedisKey).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(payload, &state)
+	return state, err
+}
+
+// Set stores state; the key is removed when maintenance is off.
+func (s *RedisStore) Set(ctx context.Context, state State) error {
+	if !state.Enabled {
+		return s.client.Del(ctx, RedisKey).Err()
+	}
+	payload, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, RedisKey, payload, 0).Err()
+}
+
+// MemoryStore keeps the state of one replica in memory.
+type MemoryStore struct {
+	mu    sync.Mutex
+	state State
+}
+
+// NewMemoryStore creates a store with maintenance off.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+// Get returns the stored state.
+func (s *MemoryStore) Get(context.Context) (State, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.state, nil
+}
+
+// Set stores state.
+func (s *MemoryStore) Set(_ context.Context, state State) error {
+	s.mu.Lock()
+	s.state = state
+	s.mu.Unlock()
+	return nil
+}
+
+// FromRedisURL builds the Service of a service that follows the maintenance
+// mode switched on llm-api. It returns nil, never in maintenance, when
+// redisURL is empty. Start its refresh loop with Run.
+func FromRedisURL(redisURL string, refreshInterval time.Duration, log zerolog.Logger) (*Service, error) {
+	if redisURL == "" {
+		log.Warn().Msg("REDIS_URL not set; maintenance mode switched on llm-api does not apply here")
+		return nil, nil
+	}
+	opts, err := redis.ParseURL(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("parse REDIS_URL: %w", err)
+	}
+	return NewService(NewRedisStore(redis.NewClient(opts)), refreshInterval, log), nil
+}
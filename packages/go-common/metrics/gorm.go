@@ -0,0 +1,163 @@
+package metrics
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"gorm.io/gorm"
+)
+
+// DBQueryDurationBuckets resolves single-digit millisecond lookups as well as
+// the slow conversation scans the histogram exists to surface.
+var DBQueryDurationBuckets = []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5}
+
+// dbRowsBuckets groups rows affected from single-row writes to bulk updates.
+var dbRowsBuckets = []float64{0, 1, 5, 10, 50, 100, 500, 1000, 5000}
+
+const queryStartKey = "metrics:query_start"
+
+// dbQueryMetrics are shared by every service so one dashboard covers them all;
+// the db_name label tells them apart, as with the go_sql_* pool metrics.
+type dbQueryMetrics struct {
+	duration *prometheus.HistogramVec
+	rows     *prometheus.HistogramVec
+	errors   *prometheus.CounterVec
+}
+
+var (
+	dbQueryMetricsOnce sync.Once
+	sharedDBQuery      *dbQueryMetrics
+)
+
+func getDBQueryMetrics() *dbQueryMetrics {
+	dbQueryMetricsOnce.Do(func() {
+		labels := []string{"db_name", "operation", "table"}
+		m := &dbQueryMetrics{
+			duration: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: "jan",
+					Subsystem: "db",
+					Name:      "query_duration_seconds",
+					Help:      "GORM query duration in seconds by statement family and table",
+					Buckets:   DBQueryDurationBuckets,
+				},
+				labels,
+			),
+			rows: prometheus.NewHistogramVec(
+				prometheus.HistogramOpts{
+					Namespace: "jan",
+					Subsystem: "db",
+					Name:      "query_rows_affected",
+					Help:      "Rows returned or affected per GORM query",
+					Buckets:   dbRowsBuckets,
+				},
+				labels,
+			),
+			errors: prometheus.NewCounterVec(
+				prometheus.CounterOpts{
+					Namespace: "jan",
+					Subsystem: "db",
+					Name:      "query_errors_total",
+					Help:      "GORM queries that failed, excluding record-not-found",
+				},
+				labels,
+			),
+		}
+		m.duration = registerOrExisting(m.duration).(*prometheus.HistogramVec)
+		m.rows = registerOrExisting(m.rows).(*prometheus.HistogramVec)
+		m.errors = registerOrExisting(m.errors).(*prometheus.CounterVec)
+		sharedDBQuery = m
+	})
+	return sharedDBQuery
+}
+
+// GormPlugin times every GORM statement and records it as jan_db_query_*
+// metrics labelled with the database name, statement family and table.
+// Register it with db.Use(metrics.NewGormPlugin("response_api")).
+type GormPlugin struct {
+	dbName  string
+	metrics *dbQueryMetrics
+}
+
+// NewGormPlugin creates the query metrics plugin for dbName.
+func NewGormPlugin(dbName string) *GormPlugin {
+	return &GormPlugin{dbName: dbName, metrics: getDBQueryMetrics()}
+}
+
+// Name implements gorm.Plugin.
+func (p *GormPlugin) Name() string {
+	return "jan:metrics"
+}
+
+// Initialize implements gorm.Plugin by wrapping each GORM processor.
+func (p *GormPlugin) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	return errors.Join(
+		cb.Create().Before("gorm:create").Register("jan:metrics:before_create", p.before),
+		cb.Create().After("gorm:create").Register("jan:metrics:after_create", p.after("create")),
+		cb.Query().Before("gorm:query").Register("jan:metrics:before_query", p.before),
+		cb.Query().After("gorm:query").Register("jan:metrics:after_query", p.after("query")),
+		cb.Update().Before("gorm:update").Register("jan:metrics:before_update", p.before),
+		cb.Update().After("gorm:update").Register("jan:metrics:after_update", p.after("update")),
+		cb.Delete().Before("gorm:delete").Register("jan:metrics:before_delete", p.before),
+		cb.Delete().After("gorm:delete").Register("jan:metrics:after_delete", p.after("delete")),
+		cb.Row().Before("gorm:row").Register("jan:metrics:before_row", p.before),
+		cb.Row().After("gorm:row").Register("jan:metrics:after_row", p.after("row")),
+		cb.Raw().Before("gorm:raw").Register("jan:metrics:before_raw", p.before),
+		cb.Raw().After("gorm:raw").Register("jan:metrics:after_raw", p.after("raw")),
+	)
+}
+
+func (p *GormPlugin) before(db *gorm.DB) {
+	db.InstanceSet(queryStartKey, time.Now())
+}
+
+func (p *GormPlugin) after(processor string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		if db.DryRun || db.Statement == nil {
+			return
+		}
+		value, ok := db.InstanceGet(queryStartKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+
+		operation := statementFamily(processor, db.Statement.SQL.String())
+		table := db.Statement.Table
+		if table == "" {
+			table = "unknown"
+		}
+		p.metrics.duration.WithLabelValues(p.dbName, operation, table).Observe(time.Since(start).Seconds())
+		p.metrics.rows.WithLabelValues(p.dbName, operation, table).Observe(float64(max(db.RowsAffected, 0)))
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			p.metrics.errors.WithLabelValues(p.dbName, operation, table).Inc()
+		}
+	}
+}
+
+// statementFamily maps a GORM processor to the SQL statement it runs. Row and
+// raw statements are classified by their leading keyword.
+func statementFamily(processor, sql string) string {
+	switch processor {
+	case "create":
+		return "insert"
+	case "query":
+		return "select"
+	case "update", "delete":
+		return processor
+	}
+	keyword, _, _ := strings.Cut(strings.TrimSpace(sql), " ")
+	switch keyword = strings.ToLower(keyword); keyword {
+	case "select", "insert", "update", "delete":
+		return keyword
+	default:
+		return "other"
+	}
+}
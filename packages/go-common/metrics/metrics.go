@@ -0,0 +1,232 @@
+// Package metrics provides shared Prometheus helpers so every Jan Server
+// service exposes the same RED (rate, errors, duration), cache, database pool
+// and query metrics under its own namespace/subsystem.
+package metrics
+
+import (
+	"database/sql"
+	"errors"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// DefaultDurationBuckets covers fast API calls through long-running streams.
+var DefaultDurationBuckets = []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5, 10, 30, 60}
+
+// Handler returns the Prometheus scrape handler for the default registry.
+func Handler() http.Handler {
+	return promhttp.Handler()
+}
+
+// HTTPMetrics records request counts, durations and in-flight requests.
+type HTTPMetrics struct {
+	requests *prometheus.CounterVec
+	duration *prometheus.HistogramVec
+	inFlight prometheus.Gauge
+}
+
+// NewHTTPMetrics registers the HTTP metrics on the default registry.
+// Nil buckets fall back to DefaultDurationBuckets.
+func NewHTTPMetrics(namespace, subsystem string, buckets []float64) *HTTPMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultDurationBuckets
+	}
+	m := &HTTPMetrics{
+		requests: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "requests_total",
+				Help:      "Total number of HTTP requests",
+			},
+			[]string{"method", "endpoint", "status"},
+		),
+		duration: prometheus.NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "request_duration_seconds",
+				Help:      "HTTP request duration in seconds",
+				Buckets:   buckets,
+			},
+			[]string{"method", "endpoint", "status"},
+		),
+		inFlight: prometheus.NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "requests_in_flight",
+				Help:      "Number of HTTP requests currently being served",
+			},
+		),
+	}
+	m.requests = registerOrExisting(m.requests).(*prometheus.CounterVec)
+	m.duration = registerOrExisting(m.duration).(*prometheus.HistogramVec)
+	m.inFlight = registerOrExisting(m.inFlight).(prometheus.Gauge)
+	return m
+}
+
+// Start marks a request as in flight and returns a function that records it.
+// It is intended for framework adapters (gin, chi) that resolve the route after routing.
+func (m *HTTPMetrics) Start() func(method, endpoint string, status int) {
+	start := time.Now()
+	m.inFlight.Inc()
+	return func(method, endpoint string, status int) {
+		m.inFlight.Dec()
+		m.Observe(method, endpoint, status, time.Since(start))
+	}
+}
+
+// Observe records a completed request.
+func (m *HTTPMetrics) Observe(method, endpoint string, status int, elapsed time.Duration) {
+	if endpoint == "" {
+		endpoint = "unmatched"
+	}
+	code := strconv.Itoa(status)
+	m.requests.WithLabelValues(method, endpoint, code).Inc()
+	m.duration.WithLabelValues(method, endpoint, code).Observe(elapsed.Seconds())
+}
+
+// Middleware wraps a net/http handler. endpointFn must return a low-cardinality
+// route name (e.g. the ServeMux pattern), never the raw URL path.
+func (m *HTTPMetrics) Middleware(endpointFn func(*http.Request) string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			done := m.Start()
+			rec := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(rec, r)
+			done(r.Method, endpointFn(r), rec.status)
+		})
+	}
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (r *statusRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+// Flush keeps streaming handlers working behind the middleware.
+func (r *statusRecorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// CacheMetrics tracks hits, misses and the running hit ratio per cache.
+type CacheMetrics struct {
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+	ratio  *prometheus.GaugeVec
+
+	mu     sync.Mutex
+	totals map[string]*cacheTotals
+}
+
+type cacheTotals struct {
+	hits   float64
+	misses float64
+}
+
+// NewCacheMetrics registers the cache metrics on the default registry.
+func NewCacheMetrics(namespace, subsystem string) *CacheMetrics {
+	c := &CacheMetrics{
+		hits: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "cache_hits_total",
+				Help:      "Total cache hits",
+			},
+			[]string{"cache_type"},
+		),
+		misses: prometheus.NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "cache_misses_total",
+				Help:      "Total cache misses",
+			},
+			[]string{"cache_type"},
+		),
+		ratio: prometheus.NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Subsystem: subsystem,
+				Name:      "cache_hit_ratio",
+				Help:      "Cache hit ratio since process start (0-1)",
+			},
+			[]string{"cache_type"},
+		),
+		totals: make(map[string]*cacheTotals),
+	}
+	c.hits = registerOrExisting(c.hits).(*prometheus.CounterVec)
+	c.misses = registerOrExisting(c.misses).(*prometheus.CounterVec)
+	c.ratio = registerOrExisting(c.ratio).(*prometheus.GaugeVec)
+	return c
+}
+
+// Hit records a cache hit.
+func (c *CacheMetrics) Hit(cacheType string) {
+	c.hits.WithLabelValues(cacheType).Inc()
+	c.update(cacheType, true)
+}
+
+// Miss records a cache miss.
+func (c *CacheMetrics) Miss(cacheType string) {
+	c.misses.WithLabelValues(cacheType).Inc()
+	c.update(cacheType, false)
+}
+
+func (c *CacheMetrics) update(cacheType string, hit bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t, ok := c.totals[cacheType]
+	if !ok {
+		t = &cacheTotals{}
+		c.totals[cacheType] = t
+	}
+	if hit {
+		t.hits++
+	} else {
+		t.misses++
+	}
+	c.ratio.WithLabelValues(cacheType).Set(t.hits / (t.hits + t.misses))
+}
+
+// RegisterDBPool exposes database/sql pool gauges (open, in use, idle, waits)
+// as go_sql_* metrics labelled with dbName. Registering the same name twice is a no-op.
+func RegisterDBPool(db *sql.DB, dbName string) error {
+	if db == nil {
+		return errors.New("metrics: nil database handle")
+	}
+	err := prometheus.Register(collectors.NewDBStatsCollector(db, dbName))
+	var already prometheus.AlreadyRegisteredError
+	if errors.As(err, &already) {
+		return nil
+	}
+	return err
+}
+
+// registerOrExisting registers c on the default registry, returning the
+// previously registered collector when one with the same descriptor exists.
+func registerOrExisting(c prometheus.Collector) prometheus.Collector {
+	if err := prometheus.Register(c); err != nil {
+		var already prometheus.AlreadyRegisteredError
+		if errors.As(err, &already) {
+			return already.ExistingCollector
+		}
+		panic(err)
+	}
+	return c
+}
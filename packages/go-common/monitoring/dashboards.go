@@ -0,0 +1,395 @@
+package monitoring
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Dashboard is the subset of the Grafana dashboard model the generator emits.
+type Dashboard struct {
+	ID            *int       `json:"id"`
+	UID           string     `json:"uid"`
+	Title         string     `json:"title"`
+	Description   string     `json:"description"`
+	Tags          []string   `json:"tags"`
+	Editable      bool       `json:"editable"`
+	GraphTooltip  int        `json:"graphTooltip"`
+	Refresh       string     `json:"refresh"`
+	SchemaVersion int        `json:"schemaVersion"`
+	Time          timeRange  `json:"time"`
+	Timezone      string     `json:"timezone"`
+	Templating    templating `json:"templating"`
+	Panels        []panel    `json:"panels"`
+	Version       int        `json:"version"`
+}
+
+type timeRange struct {
+	From string `json:"from"`
+	To   string `json:"to"`
+}
+
+type templating struct {
+	List []variable `json:"list"`
+}
+
+type variable struct {
+	Name       string     `json:"name"`
+	Label      string     `json:"label"`
+	Type       string     `json:"type"`
+	Datasource datasource `json:"datasource"`
+	Query      string     `json:"query"`
+	Refresh    int        `json:"refresh"`
+	Multi      bool       `json:"multi"`
+	IncludeAll bool       `json:"includeAll"`
+}
+
+type datasource struct {
+	Type string `json:"type"`
+	UID  string `json:"uid"`
+}
+
+type gridPos struct {
+	H int `json:"h"`
+	W int `json:"w"`
+	X int `json:"x"`
+	Y int `json:"y"`
+}
+
+type panel struct {
+	ID          int          `json:"id"`
+	Type        string       `json:"type"`
+	Title       string       `json:"title"`
+	GridPos     gridPos      `json:"gridPos"`
+	Datasource  datasource   `json:"datasource"`
+	Targets     []target     `json:"targets"`
+	FieldConfig fieldConfig  `json:"fieldConfig"`
+	Options     panelOptions `json:"options"`
+}
+
+type target struct {
+	RefID        string `json:"refId"`
+	Expr         string `json:"expr"`
+	LegendFormat string `json:"legendFormat"`
+}
+
+type fieldConfig struct {
+	Defaults fieldDefaults `json:"defaults"`
+}
+
+type fieldDefaults struct {
+	Unit string `json:"unit"`
+}
+
+type panelOptions struct {
+	Legend legendOptions `json:"legend"`
+}
+
+type legendOptions struct {
+	DisplayMode string `json:"displayMode"`
+	Placement   string `json:"placement"`
+	ShowLegend  bool   `json:"showLegend"`
+}
+
+var prometheusDS = datasource{Type: "prometheus", UID: "prometheus"}
+
+// dashboardBuilder lays panels out on Grafana's 24-column grid, two per row.
+type dashboardBuilder struct {
+	panels []panel
+	nextID int
+}
+
+func (b *dashboardBuilder) add(kind, title, unit string, targets ...target) {
+	b.nextID++
+	idx := len(b.panels)
+	p := panel{
+		ID:          b.nextID,
+		Type:        kind,
+		Title:       title,
+		GridPos:     gridPos{H: 8, W: 12, X: (idx % 2) * 12, Y: (idx / 2) * 8},
+		Datasource:  prometheusDS,
+		Targets:     targets,
+		FieldConfig: fieldConfig{Defaults: fieldDefaults{Unit: unit}},
+		Options: panelOptions{Legend: legendOptions{
+			DisplayMode: "list",
+			Placement:   "bottom",
+			ShowLegend:  true,
+		}},
+	}
+	for i := range p.Targets {
+		p.Targets[i].RefID = string(rune('A' + i))
+	}
+	b.panels = append(b.panels, p)
+}
+
+func newDashboard(uid, title, description string, tags []string, panels []panel, vars ...variable) Dashboard {
+	if vars == nil {
+		vars = []variable{}
+	}
+	return Dashboard{
+		UID:           uid,
+		Title:         title,
+		Description:   description,
+		Tags:          append([]string{"jan-server", "generated"}, tags...),
+		Editable:      true,
+		GraphTooltip:  1,
+		Refresh:       "30s",
+		SchemaVersion: 38,
+		Time:          timeRange{From: "now-6h", To: "now"},
+		Timezone:      "browser",
+		Templating:    templating{List: vars},
+		Panels:        panels,
+		Version:       1,
+	}
+}
+
+// ServiceDashboard builds the RED (rate, errors, duration) dashboard for svc.
+func ServiceDashboard(svc ServiceSLO) Dashboard {
+	b := &dashboardBuilder{}
+	errorRatio := fmt.Sprintf(
+		`sum(rate(%[1]s{status=~"5.."}[5m])) / clamp_min(sum(rate(%[1]s[5m])), 1e-9)`,
+		svc.RequestsMetric,
+	)
+
+	b.add("timeseries", "Request rate by status", "reqps", target{
+		Expr:         fmt.Sprintf(`sum by (status) (rate(%s[5m]))`, svc.RequestsMetric),
+		LegendFormat: "{{status}}",
+	})
+	b.add("timeseries", "Error ratio (5xx)", "percentunit",
+		target{Expr: errorRatio, LegendFormat: "error ratio"},
+		target{Expr: formatFloat(1 - svc.Objective), LegendFormat: "SLO budget"},
+	)
+	b.add("timeseries", "Latency quantiles", "s",
+		quantileTarget(0.5, svc.DurationMetric, ""),
+		quantileTarget(0.95, svc.DurationMetric, ""),
+		quantileTarget(0.99, svc.DurationMetric, ""),
+	)
+	b.add("timeseries", "p95 latency by "+svc.LatencyLabel, "s",
+		quantileTarget(0.95, svc.DurationMetric, svc.LatencyLabel),
+	)
+	b.add("stat", "Error budget remaining (30d)", "percentunit", target{
+		Expr: fmt.Sprintf(
+			`1 - (sum(increase(%[1]s{status=~"5.."}[30d])) / clamp_min(sum(increase(%[1]s[30d])), 1e-9)) / %[2]s`,
+			svc.RequestsMetric, formatFloat(1-svc.Objective),
+		),
+		LegendFormat: "budget",
+	})
+	b.add("timeseries", "Burn rate (1h / 6h)", "short",
+		target{Expr: fmt.Sprintf(`%s{service=%q} / %s`, errorRatioRecord("1h"), svc.Name, formatFloat(1-svc.Objective)), LegendFormat: "1h"},
+		target{Expr: fmt.Sprintf(`%s{service=%q} / %s`, errorRatioRecord("6h"), svc.Name, formatFloat(1-svc.Objective)), LegendFormat: "6h"},
+	)
+
+	return newDashboard(
+		"jan-red-"+svc.Name,
+		fmt.Sprintf("RED - %s", svc.Name),
+		fmt.Sprintf("Generated from packages/go-common/monitoring. Availability SLO %s%%.", formatFloat(svc.Objective*100)),
+		[]string{svc.Name, "slo"},
+		b.panels,
+	)
+}
+
+// ModelDashboard builds the per-model token throughput and latency dashboard.
+func ModelDashboard(m ModelMetrics) Dashboard {
+	b := &dashboardBuilder{}
+	sel := `{model=~"$model"}`
+
+	b.add("timeseries", "Completion tokens/sec by model", "short", target{
+		Expr:         fmt.Sprintf(`sum by (model) (rate(%s%s[5m]))`, m.CompletionTokensMetric, sel),
+		LegendFormat: "{{model}}",
+	})
+	b.add("timeseries", "Prompt tokens/sec by model", "short", target{
+		Expr:         fmt.Sprintf(`sum by (model) (rate(%s%s[5m]))`, m.PromptTokensMetric, sel),
+		LegendFormat: "{{model}}",
+	})
+	b.add("timeseries", "Time to first token p50 / p95", "s",
+		target{
+			Expr:         fmt.Sprintf(`histogram_quantile(0.5, sum by (le, model) (rate(%s_bucket%s[5m])))`, m.FirstTokenMetric, sel),
+			LegendFormat: "p50 {{model}}",
+		},
+		target{
+			Expr:         fmt.Sprintf(`histogram_quantile(0.95, sum by (le, model) (rate(%s_bucket%s[5m])))`, m.FirstTokenMetric, sel),
+			LegendFormat: "p95 {{model}}",
+		},
+	)
+	b.add("timeseries", "Streaming tokens/sec p50 by model", "short", target{
+		Expr:         fmt.Sprintf(`histogram_quantile(0.5, sum by (le, model) (rate(%s_bucket%s[5m])))`, m.TokensPerSecondMetric, sel),
+		LegendFormat: "{{model}}",
+	})
+	b.add("timeseries", "Inter-token latency p95 by model", "s", target{
+		Expr:         fmt.Sprintf(`histogram_quantile(0.95, sum by (le, model) (rate(%s_bucket%s[5m])))`, m.InterTokenMetric, sel),
+		LegendFormat: "{{model}}",
+	})
+	b.add("timeseries", "LLM call duration p95", "s", target{
+		Expr:         fmt.Sprintf(`histogram_quantile(0.95, sum by (le, model) (rate(%s_bucket%s[5m])))`, m.LLMDurationMetric, sel),
+		LegendFormat: "{{model}}",
+	})
+
+	return newDashboard(
+		"jan-models",
+		"Models - throughput and latency",
+		"Generated from packages/go-common/monitoring.",
+		[]string{"llm-api", "models"},
+		b.panels,
+		variable{
+			Name:       "model",
+			Label:      "Model",
+			Type:       "query",
+			Datasource: prometheusDS,
+			Query:      fmt.Sprintf("label_values(%s, model)", m.CompletionTokensMetric),
+			Refresh:    2,
+			Multi:      true,
+			IncludeAll: true,
+		},
+	)
+}
+
+// VLLMDashboard builds the vLLM load and routing dashboard.
+func VLLMDashboard(v VLLMMetrics) Dashboard {
+	b := &dashboardBuilder{}
+
+	b.add("timeseries", "Requests waiting by model", "short", target{
+		Expr:         fmt.Sprintf(`%s{model=~"$model"}`, vllmModelWaitingRecord),
+		LegendFormat: "{{model}}",
+	})
+	b.add("timeseries", "Requests running by model", "short", target{
+		Expr:         fmt.Sprintf(`%s{model=~"$model"}`, vllmModelRunningRecord),
+		LegendFormat: "{{model}}",
+	})
+	b.add("timeseries", "KV-cache utilization by endpoint", "percentunit",
+		target{Expr: vllmEndpointKVCacheRecord, LegendFormat: "{{endpoint}}"},
+		target{Expr: formatFloat(v.SaturationKVCache), LegendFormat: "saturation"},
+	)
+	b.add("timeseries", "Queue depth by endpoint", "short",
+		target{Expr: vllmEndpointQueueRecord, LegendFormat: "{{endpoint}}"},
+		target{Expr: formatFloat(v.SaturationQueueDepth), LegendFormat: "saturation"},
+	)
+	b.add("timeseries", "Routing weight by endpoint (%)", "short", target{
+		Expr:         fmt.Sprintf(`max by (provider, endpoint) (%s)`, v.RoutingWeightMetric),
+		LegendFormat: "{{endpoint}}",
+	})
+	b.add("stat", "Saturated endpoints", "short", target{
+		Expr:         fmt.Sprintf(`sum(%s) or vector(0)`, vllmProviderSaturatedCount),
+		LegendFormat: "saturated",
+	})
+
+	return newDashboard(
+		"jan-vllm",
+		"vLLM - load and routing",
+		"Generated from packages/go-common/monitoring.",
+		[]string{"llm-api", "vllm"},
+		b.panels,
+		variable{
+			Name:       "model",
+			Label:      "Model",
+			Type:       "query",
+			Datasource: prometheusDS,
+			Query:      fmt.Sprintf("label_values(%s, model)", v.WaitingMetric),
+			Refresh:    2,
+			Multi:      true,
+			IncludeAll: true,
+		},
+	)
+}
+
+// DatabaseDashboard builds the query timing and connection pool dashboard.
+func DatabaseDashboard(d DatabaseMetrics) Dashboard {
+	b := &dashboardBuilder{}
+	sel := `{db_name=~"$db_name"}`
+
+	b.add("timeseries", "Query p95 by table", "s",
+		target{Expr: fmt.Sprintf(`topk(10, %s%s)`, dbTableQueryP95Record, sel), LegendFormat: "{{db_name}} {{operation}} {{table}}"},
+		target{Expr: formatFloat(d.SlowQueryP95Seconds), LegendFormat: "slow"},
+	)
+	b.add("timeseries", "Queries/sec by operation", "ops", target{
+		Expr:         fmt.Sprintf(`%s%s`, dbOperationRateRecord, sel),
+		LegendFormat: "{{db_name}} {{operation}}",
+	})
+	b.add("timeseries", "Rows affected p95 by table", "short", target{
+		Expr:         fmt.Sprintf(`histogram_quantile(0.95, sum by (le, db_name, table) (rate(%s_bucket%s[5m])))`, d.RowsAffectedMetric, sel),
+		LegendFormat: "{{db_name}} {{table}}",
+	})
+	b.add("timeseries", "Query errors/sec by table", "ops", target{
+		Expr:         fmt.Sprintf(`%s%s > 0`, dbErrorRateRecord, sel),
+		LegendFormat: "{{db_name}} {{operation}} {{table}}",
+	})
+	b.add("timeseries", "Average pool wait", "s",
+		target{Expr: fmt.Sprintf(`%s%s`, dbPoolWaitRecord, sel), LegendFormat: "{{db_name}}"},
+		target{Expr: formatFloat(d.PoolWaitSeconds), LegendFormat: "threshold"},
+	)
+	b.add("timeseries", "Pool utilization", "percentunit", target{
+		Expr:         fmt.Sprintf(`%s%s`, dbPoolUtilizationRecord, sel),
+		LegendFormat: "{{db_name}}",
+	})
+
+	return newDashboard(
+		"jan-database",
+		"Database - queries and pools",
+		"Generated from packages/go-common/monitoring.",
+		[]string{"database"},
+		b.panels,
+		variable{
+			Name:       "db_name",
+			Label:      "Database",
+			Type:       "query",
+			Datasource: prometheusDS,
+			Query:      fmt.Sprintf("label_values(%s_count, db_name)", d.QueryDurationMetric),
+			Refresh:    2,
+			Multi:      true,
+			IncludeAll: true,
+		},
+	)
+}
+
+func quantileTarget(q float64, metric, by string) target {
+	group := "le"
+	legend := fmt.Sprintf("p%s", formatFloat(q*100))
+	if by != "" {
+		group += ", " + by
+		legend = "{{" + by + "}}"
+	}
+	return target{
+		Expr:         fmt.Sprintf(`histogram_quantile(%s, sum by (%s) (rate(%s_bucket[5m])))`, formatFloat(q), group, metric),
+		LegendFormat: legend,
+	}
+}
+
+// GenerateDashboards writes one RED dashboard per service plus the model, vLLM
+// and database dashboards into outputDir, using a "generated-" file name prefix.
+func GenerateDashboards(outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0755); err != nil {
+		return fmt.Errorf("create output directory: %w", err)
+	}
+
+	dashboards := map[string]Dashboard{
+		"generated-database.json": DatabaseDashboard(Database),
+		"generated-models.json":   ModelDashboard(Models),
+		"generated-vllm.json":     VLLMDashboard(VLLM),
+	}
+	for _, svc := range Services {
+		name := "generated-red-" + strings.ToLower(svc.Name) + ".json"
+		dashboards[name] = ServiceDashboard(svc)
+	}
+
+	names := make([]string, 0, len(dashboards))
+	for name := range dashboards {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		dashboard := dashboards[name]
+		data, err := json.MarshalIndent(dashboard, "", "  ")
+		if err != nil {
+			return fmt.Errorf("marshal %s: %w", name, err)
+		}
+		path := filepath.Join(outputDir, name)
+		if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", path, err)
+		}
+		fmt.Printf("✓ Generated %s\n", path)
+	}
+	return nil
+}
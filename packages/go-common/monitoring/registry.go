@@ -0,0 +1,160 @@
+// Package monitoring keeps Prometheus alert rules and Grafana dashboards in
+// sync with the metrics the services actually export. The registry below is
+// the single source of truth; `jan-cli monitor generate` renders it.
+package monitoring
+
+// ServiceSLO describes the RED metrics and availability objective of a service.
+type ServiceSLO struct {
+	// Name is the service name used for labels, file names and dashboard titles.
+	Name string
+	// RequestsMetric is a counter with a `status` label holding the HTTP status code.
+	RequestsMetric string
+	// DurationMetric is a histogram (without the _bucket suffix) of request latency.
+	DurationMetric string
+	// LatencyLabel is the DurationMetric label used for the per-route latency panel.
+	LatencyLabel string
+	// Objective is the availability target, e.g. 0.995 for 99.5%.
+	Objective float64
+	// LatencyP95Seconds raises a warning when p95 latency exceeds it for 10m.
+	LatencyP95Seconds float64
+}
+
+// ModelMetrics names the llm-api metrics used for per-model dashboards.
+type ModelMetrics struct {
+	PromptTokensMetric     string
+	CompletionTokensMetric string
+	FirstTokenMetric       string
+	TokensPerSecondMetric  string
+	InterTokenMetric       string
+	LLMDurationMetric      string
+	// FirstTokenP95Seconds raises a warning when a model's p95 TTFT exceeds it.
+	FirstTokenP95Seconds float64
+}
+
+// VLLMMetrics names the llm-api gauges re-exported from vLLM's /metrics. Each
+// series is labelled by provider, endpoint and model, and every llm-api replica
+// scrapes the same endpoints, so rules take the max across replicas first.
+type VLLMMetrics struct {
+	WaitingMetric       string
+	RunningMetric       string
+	KVCacheMetric       string
+	RoutingWeightMetric string
+	// SaturationKVCache and SaturationQueueDepth mirror llm-api's
+	// VLLM_SATURATION_* defaults; an endpoint over either is saturated.
+	SaturationKVCache    float64
+	SaturationQueueDepth float64
+}
+
+// DatabaseMetrics names the GORM query and database/sql pool metrics every
+// service exports through packages/go-common/metrics, labelled by db_name.
+type DatabaseMetrics struct {
+	QueryDurationMetric    string
+	RowsAffectedMetric     string
+	QueryErrorsMetric      string
+	PoolWaitCountMetric    string
+	PoolWaitDurationMetric string
+	PoolInUseMetric        string
+	PoolMaxOpenMetric      string
+	// SlowQueryP95Seconds raises a warning when a table's p95 query time exceeds it.
+	SlowQueryP95Seconds float64
+	// PoolWaitSeconds raises a warning when the average wait for a pooled
+	// connection exceeds it.
+	PoolWaitSeconds float64
+}
+
+// BurnRateWindow is one multi-window, multi-burn-rate alert from the SRE workbook.
+// The alert fires when both the long and the short window burn faster than Factor.
+type BurnRateWindow struct {
+	Long     string
+	Short    string
+	Factor   float64
+	Severity string
+	For      string
+}
+
+// Services lists every HTTP service with an SLO. Keep metric names in sync
+// with each service's internal metrics package.
+var Services = []ServiceSLO{
+	{
+		Name:              "llm-api",
+		RequestsMetric:    "jan_llm_api_requests_total",
+		DurationMetric:    "jan_llm_api_request_duration_seconds",
+		LatencyLabel:      "endpoint",
+		Objective:         0.995,
+		LatencyP95Seconds: 30,
+	},
+	{
+		Name:              "response-api",
+		RequestsMetric:    "jan_response_api_requests_total",
+		DurationMetric:    "jan_response_api_request_duration_seconds",
+		LatencyLabel:      "endpoint",
+		Objective:         0.995,
+		LatencyP95Seconds: 60,
+	},
+	{
+		Name:              "media-api",
+		RequestsMetric:    "jan_media_api_requests_total",
+		DurationMetric:    "jan_media_api_request_duration_seconds",
+		LatencyLabel:      "endpoint",
+		Objective:         0.999,
+		LatencyP95Seconds: 5,
+	},
+	{
+		Name:              "memory-tools",
+		RequestsMetric:    "jan_memory_requests_total",
+		DurationMetric:    "jan_memory_request_duration_seconds",
+		LatencyLabel:      "endpoint",
+		Objective:         0.99,
+		LatencyP95Seconds: 5,
+	},
+	{
+		Name:              "mcp-tools",
+		RequestsMetric:    "jan_mcp_requests_total",
+		DurationMetric:    "jan_mcp_tool_duration_seconds",
+		LatencyLabel:      "tool_name",
+		Objective:         0.99,
+		LatencyP95Seconds: 30,
+	},
+}
+
+// Models describes the llm-api per-model metrics.
+var Models = ModelMetrics{
+	PromptTokensMetric:     "jan_llm_api_tokens_prompt_total",
+	CompletionTokensMetric: "jan_llm_api_tokens_completion_total",
+	FirstTokenMetric:       "jan_llm_api_first_token_seconds",
+	TokensPerSecondMetric:  "jan_llm_api_completion_tokens_per_second",
+	InterTokenMetric:       "jan_llm_api_inter_token_latency_seconds",
+	LLMDurationMetric:      "jan_llm_api_llm_duration_seconds",
+	FirstTokenP95Seconds:   5,
+}
+
+// VLLM describes the vLLM load signals scraped by llm-api.
+var VLLM = VLLMMetrics{
+	WaitingMetric:        "jan_llm_api_vllm_requests_waiting",
+	RunningMetric:        "jan_llm_api_vllm_requests_running",
+	KVCacheMetric:        "jan_llm_api_vllm_kv_cache_utilization",
+	RoutingWeightMetric:  "jan_llm_api_endpoint_routing_weight",
+	SaturationKVCache:    0.9,
+	SaturationQueueDepth: 8,
+}
+
+// Database describes the query timings and pool metrics shared by all services.
+var Database = DatabaseMetrics{
+	QueryDurationMetric:    "jan_db_query_duration_seconds",
+	RowsAffectedMetric:     "jan_db_query_rows_affected",
+	QueryErrorsMetric:      "jan_db_query_errors_total",
+	PoolWaitCountMetric:    "go_sql_wait_count_total",
+	PoolWaitDurationMetric: "go_sql_wait_duration_seconds_total",
+	PoolInUseMetric:        "go_sql_in_use_connections",
+	PoolMaxOpenMetric:      "go_sql_max_open_connections",
+	SlowQueryP95Seconds:    0.5,
+	PoolWaitSeconds:        0.1,
+}
+
+// BurnRateWindows are the standard page/ticket windows for a 30-day SLO period.
+var BurnRateWindows = []BurnRateWindow{
+	{Long: "1h", Short: "5m", Factor: 14.4, Severity: "critical", For: "2m"},
+	{Long: "6h", Short: "30m", Factor: 6, Severity: "critical", For: "15m"},
+	{Long: "1d", Short: "2h", Factor: 3, Severity: "warning", For: "1h"},
+	{Long: "3d", Short: "6h", Factor: 1, Severity: "warning", For: "3h"},
+}
@@ -0,0 +1,291 @@
+package monitoring
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"os"
+	"strconv"
+
+	"gopkg.in/yaml.v3"
+)
+
+const generatedHeader = `# Jan Server SLO rules
+# Generated from packages/go-common/monitoring/registry.go
+# DO NOT EDIT MANUALLY - run 'make monitoring-generate' instead
+`
+
+type ruleFile struct {
+	Groups []ruleGroup `yaml:"groups"`
+}
+
+type ruleGroup struct {
+	Name     string `yaml:"name"`
+	Interval string `yaml:"interval,omitempty"`
+	Rules    []rule `yaml:"rules"`
+}
+
+type rule struct {
+	Record      string            `yaml:"record,omitempty"`
+	Alert       string            `yaml:"alert,omitempty"`
+	Expr        string            `yaml:"expr"`
+	For         string            `yaml:"for,omitempty"`
+	Labels      map[string]string `yaml:"labels,omitempty"`
+	Annotations map[string]string `yaml:"annotations,omitempty"`
+}
+
+// RenderPrometheusRules returns the recording and alerting rules for all
+// registered services and models.
+func RenderPrometheusRules() ([]byte, error) {
+	file := ruleFile{}
+	for _, svc := range Services {
+		file.Groups = append(file.Groups, serviceRecordingGroup(svc), serviceAlertGroup(svc))
+	}
+	file.Groups = append(file.Groups, modelAlertGroup(Models))
+	file.Groups = append(file.Groups, vllmRecordingGroup(VLLM), vllmAlertGroup(VLLM))
+	file.Groups = append(file.Groups, databaseRecordingGroup(Database), databaseAlertGroup(Database))
+
+	var buf bytes.Buffer
+	buf.WriteString(generatedHeader)
+	enc := yaml.NewEncoder(&buf)
+	enc.SetIndent(2)
+	if err := enc.Encode(file); err != nil {
+		return nil, fmt.Errorf("encode rules: %w", err)
+	}
+	if err := enc.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// GeneratePrometheusRules writes the rendered rules to outputPath.
+func GeneratePrometheusRules(outputPath string) error {
+	data, err := RenderPrometheusRules()
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(outputPath, data, 0644); err != nil {
+		return fmt.Errorf("write %s: %w", outputPath, err)
+	}
+	fmt.Printf("✓ Generated %s\n", outputPath)
+	return nil
+}
+
+// errorRatioRecord is the recording rule name for a service's 5xx ratio over window.
+func errorRatioRecord(window string) string {
+	return "slo:sli_error:ratio_rate" + window
+}
+
+func serviceRecordingGroup(svc ServiceSLO) ruleGroup {
+	windows := []string{}
+	seen := map[string]bool{}
+	for _, w := range BurnRateWindows {
+		for _, win := range []string{w.Short, w.Long} {
+			if !seen[win] {
+				seen[win] = true
+				windows = append(windows, win)
+			}
+		}
+	}
+
+	group := ruleGroup{Name: "slo_" + svc.Name + "_recordings", Interval: "30s"}
+	for _, win := range windows {
+		group.Rules = append(group.Rules, rule{
+			Record: errorRatioRecord(win),
+			Expr: fmt.Sprintf(
+				`sum(rate(%[1]s{status=~"5.."}[%[2]s])) / clamp_min(sum(rate(%[1]s[%[2]s])), 1e-9)`,
+				svc.RequestsMetric, win,
+			),
+			Labels: map[string]string{"service": svc.Name},
+		})
+	}
+	return group
+}
+
+func serviceAlertGroup(svc ServiceSLO) ruleGroup {
+	budget := 1 - svc.Objective
+	group := ruleGroup{Name: "slo_" + svc.Name + "_alerts"}
+
+	for _, w := range BurnRateWindows {
+		threshold := formatFloat(w.Factor * budget)
+		group.Rules = append(group.Rules, rule{
+			Alert: "ErrorBudgetBurn",
+			Expr: fmt.Sprintf(
+				`%s{service=%q} > %s and %s{service=%q} > %s`,
+				errorRatioRecord(w.Long), svc.Name, threshold,
+				errorRatioRecord(w.Short), svc.Name, threshold,
+			),
+			For: w.For,
+			Labels: map[string]string{
+				"service":     svc.Name,
+				"severity":    w.Severity,
+				"long_window": w.Long,
+			},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("%s is burning its %s%% availability budget %sx too fast",
+					svc.Name, formatFloat(svc.Objective*100), formatFloat(w.Factor)),
+				"description": fmt.Sprintf("5xx ratio over %s and %s exceeds %s", w.Long, w.Short, threshold),
+				"runbook":     "docs/runbooks/monitoring.md#error-budget-burn",
+			},
+		})
+	}
+
+	group.Rules = append(group.Rules, rule{
+		Alert: "HighP95Latency",
+		Expr: fmt.Sprintf(
+			`histogram_quantile(0.95, sum by (le) (rate(%s_bucket[5m]))) > %s`,
+			svc.DurationMetric, formatFloat(svc.LatencyP95Seconds),
+		),
+		For:    "10m",
+		Labels: map[string]string{"service": svc.Name, "severity": "warning"},
+		Annotations: map[string]string{
+			"summary": fmt.Sprintf("%s p95 latency above %ss", svc.Name, formatFloat(svc.LatencyP95Seconds)),
+			"runbook": "docs/runbooks/monitoring.md#high-latency",
+		},
+	})
+	return group
+}
+
+func modelAlertGroup(m ModelMetrics) ruleGroup {
+	return ruleGroup{
+		Name: "slo_models_alerts",
+		Rules: []rule{{
+			Alert: "HighTimeToFirstToken",
+			Expr: fmt.Sprintf(
+				`histogram_quantile(0.95, sum by (le, model) (rate(%s_bucket[10m]))) > %s`,
+				m.FirstTokenMetric, formatFloat(m.FirstTokenP95Seconds),
+			),
+			For:    "10m",
+			Labels: map[string]string{"service": "llm-api", "severity": "warning"},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("Model {{ $labels.model }} p95 time-to-first-token above %ss", formatFloat(m.FirstTokenP95Seconds)),
+				"runbook": "docs/runbooks/monitoring.md#high-llm-latency",
+			},
+		}},
+	}
+}
+
+// vLLM recording rule names
+const (
+	vllmModelWaitingRecord     = "model:vllm_requests_waiting:sum"
+	vllmModelRunningRecord     = "model:vllm_requests_running:sum"
+	vllmEndpointQueueRecord    = "endpoint:vllm_queue_depth:sum"
+	vllmEndpointKVCacheRecord  = "endpoint:vllm_kv_cache_utilization:max"
+	vllmProviderSaturatedCount = "provider:vllm_saturated_endpoints:count"
+)
+
+// vllmDedup collapses the copies of a vLLM gauge exported by each llm-api replica.
+func vllmDedup(metric string) string {
+	return fmt.Sprintf("max by (provider, endpoint, model) (%s)", metric)
+}
+
+// vllmSaturated selects the endpoints over either saturation threshold.
+func vllmSaturated(v VLLMMetrics) string {
+	return fmt.Sprintf("%s >= %s or %s >= %s",
+		vllmEndpointKVCacheRecord, formatFloat(v.SaturationKVCache),
+		vllmEndpointQueueRecord, formatFloat(v.SaturationQueueDepth),
+	)
+}
+
+func vllmRecordingGroup(v VLLMMetrics) ruleGroup {
+	return ruleGroup{
+		Name:     "vllm_recordings",
+		Interval: "30s",
+		Rules: []rule{
+			{Record: vllmModelWaitingRecord, Expr: fmt.Sprintf("sum by (model) (%s)", vllmDedup(v.WaitingMetric))},
+			{Record: vllmModelRunningRecord, Expr: fmt.Sprintf("sum by (model) (%s)", vllmDedup(v.RunningMetric))},
+			{Record: vllmEndpointQueueRecord, Expr: fmt.Sprintf("sum by (provider, endpoint) (%s)", vllmDedup(v.WaitingMetric))},
+			{Record: vllmEndpointKVCacheRecord, Expr: fmt.Sprintf("max by (provider, endpoint) (%s)", v.KVCacheMetric)},
+			{Record: vllmProviderSaturatedCount, Expr: fmt.Sprintf("count by (provider) (%s)", vllmSaturated(v))},
+		},
+	}
+}
+
+func vllmAlertGroup(v VLLMMetrics) ruleGroup {
+	return ruleGroup{
+		Name: "vllm_alerts",
+		Rules: []rule{{
+			Alert:  "VLLMEndpointSaturated",
+			Expr:   vllmSaturated(v),
+			For:    "10m",
+			Labels: map[string]string{"service": "llm-api", "severity": "warning"},
+			Annotations: map[string]string{
+				"summary": fmt.Sprintf("vLLM endpoint {{ $labels.endpoint }} saturated (KV cache >= %s%% or >= %s requests waiting)",
+					formatFloat(v.SaturationKVCache*100), formatFloat(v.SaturationQueueDepth)),
+				"runbook": "docs/runbooks/monitoring.md#vllm-saturation",
+			},
+		}},
+	}
+}
+
+// Database recording rule names
+const (
+	dbTableQueryP95Record   = "db_table:query_duration_seconds:p95"
+	dbOperationRateRecord   = "db_operation:queries:rate5m"
+	dbErrorRateRecord       = "db_table:query_errors:rate5m"
+	dbPoolWaitRecord        = "db:pool_wait_seconds:avg5m"
+	dbPoolUtilizationRecord = "db:pool_utilization:ratio"
+)
+
+func databaseRecordingGroup(d DatabaseMetrics) ruleGroup {
+	return ruleGroup{
+		Name:     "db_recordings",
+		Interval: "30s",
+		Rules: []rule{
+			{
+				Record: dbTableQueryP95Record,
+				Expr:   fmt.Sprintf("histogram_quantile(0.95, sum by (le, db_name, operation, table) (rate(%s_bucket[5m])))", d.QueryDurationMetric),
+			},
+			{
+				Record: dbOperationRateRecord,
+				Expr:   fmt.Sprintf("sum by (db_name, operation) (rate(%s_count[5m]))", d.QueryDurationMetric),
+			},
+			{
+				Record: dbErrorRateRecord,
+				Expr:   fmt.Sprintf("sum by (db_name, operation, table) (rate(%s[5m]))", d.QueryErrorsMetric),
+			},
+			{
+				Record: dbPoolWaitRecord,
+				Expr: fmt.Sprintf("sum by (db_name) (rate(%s[5m])) / clamp_min(sum by (db_name) (rate(%s[5m])), 1e-9)",
+					d.PoolWaitDurationMetric, d.PoolWaitCountMetric),
+			},
+			{
+				Record: dbPoolUtilizationRecord,
+				Expr:   fmt.Sprintf("sum by (db_name) (%s) / clamp_min(sum by (db_name) (%s), 1)", d.PoolInUseMetric, d.PoolMaxOpenMetric),
+			},
+		},
+	}
+}
+
+func databaseAlertGroup(d DatabaseMetrics) ruleGroup {
+	return ruleGroup{
+		Name: "db_alerts",
+		Rules: []rule{
+			{
+				Alert:  "SlowDatabaseQueries",
+				Expr:   fmt.Sprintf("%s > %s", dbTableQueryP95Record, formatFloat(d.SlowQueryP95Seconds)),
+				For:    "10m",
+				Labels: map[string]string{"severity": "warning"},
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("{{ $labels.db_name }} p95 {{ $labels.operation }} on {{ $labels.table }} above %ss", formatFloat(d.SlowQueryP95Seconds)),
+					"runbook": "docs/runbooks/monitoring.md#slow-database-queries",
+				},
+			},
+			{
+				Alert:  "DatabasePoolWait",
+				Expr:   fmt.Sprintf("%s > %s", dbPoolWaitRecord, formatFloat(d.PoolWaitSeconds)),
+				For:    "10m",
+				Labels: map[string]string{"severity": "warning"},
+				Annotations: map[string]string{
+					"summary": fmt.Sprintf("{{ $labels.db_name }} requests wait over %ss on average for a pooled connection", formatFloat(d.PoolWaitSeconds)),
+					"runbook": "docs/runbooks/monitoring.md#slow-database-queries",
+				},
+			},
+		},
+	}
+}
+
+// formatFloat renders v without float noise (14.4*0.005 -> 0.072).
+func formatFloat(v float64) string {
+	return strconv.FormatFloat(math.Round(v*1e6)/1e6, 'f', -1, 64)
+}
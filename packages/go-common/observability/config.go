@@ -15,10 +15,16 @@ type Config struct {
 	MetricsEnabled bool
 	OTLPEndpoint   string
 	OTLPHeaders    map[string]string
-	SamplingRate   float64 // 0.0 - 1.0
+	SamplingRate   float64 // 0.0 - 1.0, for routes without an override
 	PIILevel       string  // none|hashed|full
 	MetricsPort    int
 
+	// Trace sampling, see SamplingConfig
+	RouteSamplingRates map[string]float64       // route prefix -> rate
+	SlowTraceThreshold time.Duration            // unsampled traces at least this slow are still exported
+	SlowTraceRoutes    map[string]time.Duration // route prefix -> threshold
+	TailSampling       bool                     // sample everything, leave the decision to the collector
+
 	// Advanced settings
 	TraceBatchTimeout time.Duration
 	MetricInterval    time.Duration
@@ -28,16 +34,28 @@ type Config struct {
 // DefaultConfig returns sensible defaults
 func DefaultConfig(serviceName string) Config {
 	return Config{
-		ServiceName:       serviceName,
-		ServiceVersion:    "unknown",
-		Environment:       "development",
-		TracingEnabled:    true,
-		MetricsEnabled:    true,
-		OTLPEndpoint:      "http://otel-collector:4318",
-		SamplingRate:      1.0,
-		PIILevel:          "hashed",
-		MetricsPort:       8080,
-		TraceBatchTimeout: 5 * time.Second,
-		MetricInterval:    15 * time.Second,
+		ServiceName:        serviceName,
+		ServiceVersion:     "unknown",
+		Environment:        "development",
+		TracingEnabled:     true,
+		MetricsEnabled:     true,
+		OTLPEndpoint:       "http://otel-collector:4318",
+		SamplingRate:       1.0,
+		SlowTraceThreshold: 5 * time.Second,
+		PIILevel:           "hashed",
+		MetricsPort:        8080,
+		TraceBatchTimeout:  5 * time.Second,
+		MetricInterval:     15 * time.Second,
+	}
+}
+
+// Sampling returns the trace sampling settings of the config.
+func (c Config) Sampling() SamplingConfig {
+	return SamplingConfig{
+		Rate:          c.SamplingRate,
+		RouteRates:    c.RouteSamplingRates,
+		SlowThreshold: c.SlowTraceThreshold,
+		SlowRoutes:    c.SlowTraceRoutes,
+		TailSampling:  c.TailSampling,
 	}
 }
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
 	"go.opentelemetry.io/otel/metric"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
 	"go.opentelemetry.io/otel/trace"
@@ -62,6 +63,9 @@ func HTTPMiddleware(tracer trace.Tracer, meter metric.Meter, serviceName string)
 			if rw.statusCode >= 400 {
 				span.RecordError(fmt.Errorf("HTTP %d", rw.statusCode))
 			}
+			if rw.statusCode >= 500 {
+				span.SetStatus(codes.Error, http.StatusText(rw.statusCode))
+			}
 		})
 	}
 }
@@ -108,16 +108,15 @@ func initTracerProvider(ctx context.Context, cfg Config, res *resource.Resource)
 		return nil, err
 	}
 
-	sampler := sdktrace.ParentBased(
-		sdktrace.TraceIDRatioBased(cfg.SamplingRate),
+	sampling := cfg.Sampling()
+	batcher := sdktrace.NewBatchSpanProcessor(exporter,
+		sdktrace.WithBatchTimeout(cfg.TraceBatchTimeout),
 	)
 
 	tp := sdktrace.NewTracerProvider(
-		sdktrace.WithBatcher(exporter,
-			sdktrace.WithBatchTimeout(cfg.TraceBatchTimeout),
-		),
+		sdktrace.WithSpanProcessor(NewRetainingProcessor(batcher, sampling)),
 		sdktrace.WithResource(res),
-		sdktrace.WithSampler(sampler),
+		sdktrace.WithSampler(NewSampler(sampling)),
 	)
 
 	return tp, nil
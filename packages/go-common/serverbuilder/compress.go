@@ -0,0 +1,269 @@
+package serverbuilder
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+)
+
+const (
+	encodingBrotli = "br"
+	encodingGzip   = "gzip"
+)
+
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+var encoderPools = map[string]*sync.Pool{
+	encodingBrotli: {New: func() any { return brotli.NewWriterLevel(nil, 4) }},
+	encodingGzip: {New: func() any {
+		w, _ := gzip.NewWriterLevel(nil, gzip.DefaultCompression)
+		return w
+	}},
+}
+
+// Compress encodes compressible responses of at least minSize bytes with
+// brotli or gzip, whichever the client accepts (brotli first). Server-sent
+// event streams, already-encoded bodies and protocol upgrades pass through
+// untouched.
+func Compress(next http.Handler, minSize int) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"))
+		if encoding == "" || r.Method == http.MethodHead || r.Header.Get("Upgrade") != "" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		cw := &compressWriter{ResponseWriter: w, encoding: encoding, minSize: minSize}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// negotiateEncoding picks the encoding with the highest non-zero q-value in
+// an Accept-Encoding header, preferring brotli on ties.
+func negotiateEncoding(header string) string {
+	if header == "" {
+		return ""
+	}
+	var brQ, gzipQ, anyQ float64 = -1, -1, -1
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		q := 1.0
+		if value, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			parsed, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			q = parsed
+		}
+		switch strings.ToLower(strings.TrimSpace(name)) {
+		case encodingBrotli:
+			brQ = q
+		case encodingGzip, "x-gzip":
+			gzipQ = q
+		case "*":
+			anyQ = q
+		}
+	}
+	if brQ < 0 {
+		brQ = anyQ
+	}
+	if gzipQ < 0 {
+		gzipQ = anyQ
+	}
+	switch {
+	case brQ > 0 && brQ >= gzipQ:
+		return encodingBrotli
+	case gzipQ > 0:
+		return encodingGzip
+	}
+	return ""
+}
+
+// compressible reports whether a content type benefits from compression.
+func compressible(contentType string) bool {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	mediaType = strings.ToLower(strings.TrimSpace(mediaType))
+	switch {
+	case mediaType == "text/event-stream":
+		// Every event must reach the client as soon as it is flushed
+		return false
+	case strings.HasPrefix(mediaType, "text/"),
+		strings.HasSuffix(mediaType, "+json"),
+		strings.HasSuffix(mediaType, "+xml"):
+		return true
+	}
+	switch mediaType {
+	case "application/json", "application/x-ndjson", "application/javascript",
+		"application/xml", "application/yaml", "image/svg+xml":
+		return true
+	}
+	return false
+}
+
+type writerMode int
+
+const (
+	modePending writerMode = iota
+	modePassthrough
+	modeCompress
+)
+
+// compressWriter buffers the start of a response until it knows whether to
+// compress it: the headers must name a compressible type and the body must
+// reach minSize, or be flushed before that.
+type compressWriter struct {
+	http.ResponseWriter
+	encoding string
+	minSize  int
+	mode     writerMode
+	status   int
+	buf      []byte
+	encoder  encoder
+}
+
+func (w *compressWriter) WriteHeader(status int) {
+	if w.mode != modePending || (status >= 100 && status < 200) {
+		w.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if w.status == 0 {
+		w.status = status
+	}
+	if !w.eligible() {
+		_ = w.passthrough()
+	}
+}
+
+func (w *compressWriter) Write(p []byte) (int, error) {
+	switch w.mode {
+	case modePassthrough:
+		return w.ResponseWriter.Write(p)
+	case modeCompress:
+		return w.encoder.Write(p)
+	}
+
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.eligible() || w.declaredSmall() {
+		if err := w.passthrough(); err != nil {
+			return 0, err
+		}
+		return w.ResponseWriter.Write(p)
+	}
+	w.buf = append(w.buf, p...)
+	if len(w.buf) >= w.minSize {
+		if err := w.startCompression(); err != nil {
+			return 0, err
+		}
+	}
+	return len(p), nil
+}
+
+// Flush commits to a mode so streamed responses are not held back.
+func (w *compressWriter) Flush() {
+	if w.mode == modePending {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		var err error
+		if w.eligible() {
+			err = w.startCompression()
+		} else {
+			err = w.passthrough()
+		}
+		if err != nil {
+			return
+		}
+	}
+	if w.mode == modeCompress {
+		if err := w.encoder.Flush(); err != nil {
+			return
+		}
+	}
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer.
+func (w *compressWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
+
+// eligible reports whether the response, as described by its status and
+// headers so far, may be compressed.
+func (w *compressWriter) eligible() bool {
+	if w.status < http.StatusOK || w.status == http.StatusNoContent || w.status == http.StatusNotModified {
+		return false
+	}
+	h := w.Header()
+	if h.Get("Content-Encoding") != "" || h.Get("Content-Range") != "" {
+		return false
+	}
+	return compressible(h.Get("Content-Type"))
+}
+
+func (w *compressWriter) declaredSmall() bool {
+	length, err := strconv.Atoi(w.Header().Get("Content-Length"))
+	return err == nil && length < w.minSize
+}
+
+func (w *compressWriter) passthrough() error {
+	w.mode = modePassthrough
+	if w.eligible() {
+		w.Header().Add("Vary", "Accept-Encoding")
+	}
+	w.ResponseWriter.WriteHeader(w.status)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+func (w *compressWriter) startCompression() error {
+	w.mode = modeCompress
+	h := w.Header()
+	h.Del("Content-Length")
+	h.Set("Content-Encoding", w.encoding)
+	h.Add("Vary", "Accept-Encoding")
+	w.ResponseWriter.WriteHeader(w.status)
+
+	w.encoder = encoderPools[w.encoding].Get().(encoder)
+	w.encoder.Reset(w.ResponseWriter)
+	if len(w.buf) == 0 {
+		return nil
+	}
+	_, err := w.encoder.Write(w.buf)
+	w.buf = nil
+	return err
+}
+
+// close sends whatever the handler left pending and finishes the encoding.
+func (w *compressWriter) close() {
+	switch w.mode {
+	case modePending:
+		if w.status != 0 || len(w.buf) > 0 {
+			if w.status == 0 {
+				w.status = http.StatusOK
+			}
+			_ = w.passthrough()
+		}
+	case modeCompress:
+		_ = w.encoder.Close()
+		w.encoder.Reset(nil)
+		encoderPools[w.encoding].Put(w.encoder)
+		w.encoder = nil
+	}
+}
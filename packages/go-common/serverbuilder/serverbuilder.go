@@ -0,0 +1,137 @@
+// Package serverbuilder builds the HTTP servers of Jan Server's gin services
+// with shared settings: cleartext HTTP/2 (h2c) next to HTTP/1.1, gzip/brotli
+// response compression that leaves server-sent event streams alone, and
+// bounded header reads.
+package serverbuilder
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Config tunes a service's HTTP server.
+type Config struct {
+	ReadHeaderTimeout  time.Duration // Time allowed to read request headers
+	ReadTimeout        time.Duration // Whole request including the body; 0 disables it (uploads)
+	WriteTimeout       time.Duration // Whole response; 0 disables it (streaming)
+	IdleTimeout        time.Duration // How long a keep-alive connection waits for the next request
+	H2C                bool          // Accept cleartext HTTP/2 next to HTTP/1.1
+	Compression        bool          // Compress responses with brotli or gzip
+	CompressionMinSize int           // Responses smaller than this are sent as is
+}
+
+// DefaultConfig returns settings that suit every gin service: no read or write
+// deadline, so uploads and streams are not cut off, but headers must arrive
+// within 10s.
+func DefaultConfig() Config {
+	return Config{
+		ReadHeaderTimeout:  10 * time.Second,
+		IdleTimeout:        120 * time.Second,
+		H2C:                true,
+		Compression:        true,
+		CompressionMinSize: 1024,
+	}
+}
+
+// FromEnv overrides defaults with HTTP_READ_HEADER_TIMEOUT, HTTP_READ_TIMEOUT,
+// HTTP_WRITE_TIMEOUT, HTTP_IDLE_TIMEOUT, HTTP_H2C_ENABLED,
+// HTTP_COMPRESSION_ENABLED and HTTP_COMPRESSION_MIN_SIZE. Unset variables keep
+// the default.
+func FromEnv(defaults Config) (Config, error) {
+	cfg := defaults
+	durations := []struct {
+		name  string
+		value *time.Duration
+	}{
+		{"HTTP_READ_HEADER_TIMEOUT", &cfg.ReadHeaderTimeout},
+		{"HTTP_READ_TIMEOUT", &cfg.ReadTimeout},
+		{"HTTP_WRITE_TIMEOUT", &cfg.WriteTimeout},
+		{"HTTP_IDLE_TIMEOUT", &cfg.IdleTimeout},
+	}
+	for _, d := range durations {
+		raw, ok := lookup(d.name)
+		if !ok {
+			continue
+		}
+		parsed, err := time.ParseDuration(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid %s: %w", d.name, err)
+		}
+		*d.value = parsed
+	}
+
+	bools := []struct {
+		name  string
+		value *bool
+	}{
+		{"HTTP_H2C_ENABLED", &cfg.H2C},
+		{"HTTP_COMPRESSION_ENABLED", &cfg.Compression},
+	}
+	for _, b := range bools {
+		raw, ok := lookup(b.name)
+		if !ok {
+			continue
+		}
+		parsed, err := strconv.ParseBool(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid %s: %w", b.name, err)
+		}
+		*b.value = parsed
+	}
+
+	if raw, ok := lookup("HTTP_COMPRESSION_MIN_SIZE"); ok {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil {
+			return cfg, fmt.Errorf("invalid HTTP_COMPRESSION_MIN_SIZE: %w", err)
+		}
+		cfg.CompressionMinSize = parsed
+	}
+	if err := cfg.Validate(); err != nil {
+		return cfg, err
+	}
+	return cfg, nil
+}
+
+func lookup(name string) (string, bool) {
+	raw, ok := os.LookupEnv(name)
+	raw = strings.TrimSpace(raw)
+	return raw, ok && raw != ""
+}
+
+// Validate rejects negative timeouts and sizes.
+func (c Config) Validate() error {
+	if c.ReadHeaderTimeout < 0 || c.ReadTimeout < 0 || c.WriteTimeout < 0 || c.IdleTimeout < 0 {
+		return fmt.Errorf("HTTP server timeouts must not be negative")
+	}
+	if c.CompressionMinSize < 0 {
+		return fmt.Errorf("HTTP_COMPRESSION_MIN_SIZE must not be negative")
+	}
+	return nil
+}
+
+// New builds the server for handler listening on addr.
+func New(addr string, handler http.Handler, cfg Config) *http.Server {
+	if cfg.Compression {
+		handler = Compress(handler, cfg.CompressionMinSize)
+	}
+	server := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: cfg.ReadHeaderTimeout,
+		ReadTimeout:       cfg.ReadTimeout,
+		WriteTimeout:      cfg.WriteTimeout,
+		IdleTimeout:       cfg.IdleTimeout,
+	}
+	if cfg.H2C {
+		protocols := new(http.Protocols)
+		protocols.SetHTTP1(true)
+		protocols.SetHTTP2(true)
+		protocols.SetUnencryptedHTTP2(true)
+		server.Protocols = protocols
+	}
+	return server
+}
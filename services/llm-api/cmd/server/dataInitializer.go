@@ -168,6 +168,7 @@ func toDomainEndpoints(cfgs []config.EndpointConfig) model.EndpointList {
 			URL:      urlStr,
 			Weight:   weight,
 			Priority: ep.Priority,
+			Region:   model.NormalizeRegion(ep.Region),
 			Healthy:  true,
 		})
 	}
@@ -11,6 +11,7 @@ import (
 	"jan-server/services/llm-api/internal/infrastructure/observability"
 	"jan-server/services/llm-api/internal/interfaces/httpserver"
 
+	"github.com/janhq/jan-server/packages/go-common/logging"
 	"golang.org/x/sync/errgroup"
 
 	_ "net/http/pprof"
@@ -38,6 +39,16 @@ func init() {
 		log := logger.GetLogger()
 		log.Fatal().Err(err).Msg("failed to initialize logger")
 	}
+
+	if cfg.LogExporter == "otlp" && cfg.OTLPEndpoint != "" {
+		logger.EnableOTLPExport(logging.OTLPConfig{
+			Endpoint:         cfg.OTLPEndpoint,
+			Headers:          cfg.OTLPHeaders,
+			ServiceName:      cfg.ServiceName,
+			ServiceNamespace: cfg.ServiceNamespace,
+			Environment:      cfg.Environment,
+		})
+	}
 }
 
 // @title Jan Server LLM API
@@ -92,6 +103,12 @@ func main() {
 	if cfg == nil {
 		log.Fatal().Msg("config not loaded")
 	}
+	defer func() {
+		// Flush exported logs last, after the shutdown errors are logged
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = logger.Shutdown(shutdownCtx)
+	}()
 
 	// Initialize Observability (Tracing & Metrics)
 	otelShutdown, err := observability.Setup(ctx, cfg, log)
@@ -8,59 +8,104 @@ package main
 
 import (
 	"jan-server/services/llm-api/internal/domain"
+	"jan-server/services/llm-api/internal/domain/admission"
+	"jan-server/services/llm-api/internal/domain/analytics"
 	"jan-server/services/llm-api/internal/domain/apikey"
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/domain/comparison"
 	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/deltasync"
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/domain/linkpolicy"
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
 	"jan-server/services/llm-api/internal/domain/mcptool"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
 	"jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/domain/modelalias"
 	"jan-server/services/llm-api/internal/domain/modelprompttemplate"
+	"jan-server/services/llm-api/internal/domain/persona"
 	"jan-server/services/llm-api/internal/domain/project"
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
 	"jan-server/services/llm-api/internal/domain/prompttemplate"
+	"jan-server/services/llm-api/internal/domain/region"
 	"jan-server/services/llm-api/internal/domain/share"
 	"jan-server/services/llm-api/internal/domain/user"
 	"jan-server/services/llm-api/internal/domain/usersettings"
+	"jan-server/services/llm-api/internal/domain/webhook"
 	"jan-server/services/llm-api/internal/infrastructure"
 	"jan-server/services/llm-api/internal/infrastructure/crontab"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/analyticsrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/apikeyrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/coldstoragerepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/comparisonrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/conversationrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/evalrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/finetunerepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/mcpcredentialrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/mcptoolrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/memorybackfillrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/modelaliasrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/modelprompttemplaterepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/modelrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/personarepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/projectrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/promptlibraryrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/prompttemplaterepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/sharerepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/syncrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/userrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/usersettingsrepo"
 	"jan-server/services/llm-api/internal/infrastructure/inference"
 	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/regionprobe"
+	"jan-server/services/llm-api/internal/infrastructure/vllmmetrics"
 	"jan-server/services/llm-api/internal/interfaces/httpserver"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/admin"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/analyticshandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/apikeyhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/chathandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/comparehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/conversationhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/evalhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/finetunehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/guesthandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/imagehandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/mcpcredentialhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/mcptoolhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/memorybackfillhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelaliashandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelprompthandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/personahandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/projecthandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/promptlibraryhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/prompttemplatehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/sharehandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/synchandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/usersettingshandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/webhookhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/auth"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/public"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1"
 	admin2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin"
 	model3 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin/model"
 	provider2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin/provider"
+	analytics2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/analytics"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/chat"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/compare"
 	conversation2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/conversation"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/image"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/llm/projects"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/mcpcredentials"
 	model2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/model"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/model/provider"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/personas"
+	promptlibrary2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/promptlibrary"
 	share2 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/share"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/sync"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/users"
 )
 
@@ -80,7 +125,11 @@ func CreateApplication() (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	database := infrastructure.ProvideTransactionDatabase(db)
+	database := infrastructure.ProvideTransactionDatabase(config, db, zerologLogger)
+	encryptionService, err := infrastructure.ProvideEncryptionService(config, database, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
 	providerRepository := modelrepo.NewProviderGormRepository(database)
 	providerModelRepository := modelrepo.NewProviderModelGormRepository(database)
 	modelCatalogRepository := modelrepo.NewModelCatalogGormRepository(database)
@@ -94,17 +143,46 @@ func CreateApplication() (*Application, error) {
 	service := user.NewService(repository)
 	authHandler := authhandler.NewAuthHandler(service, zerologLogger)
 	modelRoute := model2.NewModelRoute(modelHandler, modelCatalogHandler, modelProviderRoute, authHandler)
-	inferenceProvider := inference.NewInferenceProvider(config)
-	providerHandler := modelhandler.NewProviderHandler(providerService, providerModelService, inferenceProvider)
-	conversationRepository := conversationrepo.NewConversationGormRepository(database)
-	conversationService := conversation.NewConversationService(conversationRepository)
+	monitor := vllmmetrics.NewMonitor(config)
+	regionConfig := domain.ProvideRegionConfig(config)
+	prober := regionprobe.NewProber(config)
+	selector := region.NewSelector(regionConfig, prober)
+	universalClient, err := infrastructure.ProvideRedisClient(config)
+	if err != nil {
+		return nil, err
+	}
+	featureflagsClient := infrastructure.ProvideFeatureFlags(config, universalClient, zerologLogger)
+	inferenceProvider := inference.NewInferenceProvider(config, monitor, selector, featureflagsClient)
+	modelaliasRepository := modelaliasrepo.NewModelAliasGormRepository(database)
+	modelaliasService := modelalias.NewService(modelaliasRepository, providerModelService)
+	providerHandler := modelhandler.NewProviderHandler(providerService, providerModelService, inferenceProvider, selector, modelaliasService)
+	conversationRepository := conversationrepo.NewConversationGormRepository(database, encryptionService)
+	linkpolicyConfig := domain.ProvideLinkPolicyConfig(config)
+	blocklist, err := infrastructure.ProvideLinkBlocklist(config, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
+	redirectResolver, err := infrastructure.ProvideRedirectResolver(config)
+	if err != nil {
+		return nil, err
+	}
+	policy := linkpolicy.New(linkpolicyConfig, blocklist, redirectResolver)
+	conversationService := conversation.NewConversationService(conversationRepository, policy)
 	messageActionService := conversation.NewMessageActionService(conversationRepository)
 	projectRepository := projectrepo.NewProjectGormRepository(db)
 	projectService := project.NewProjectService(projectRepository)
 	shareRepository := sharerepo.NewShareGormRepository(database)
-	conversationHandler := conversationhandler.NewConversationHandler(conversationService, messageActionService, projectService, shareRepository)
+	promptlibraryRepository := promptlibraryrepo.NewPromptLibraryGormRepository(database)
+	promptlibraryConfig := domain.ProvidePromptLibraryConfig(config)
+	promptlibraryService := promptlibrary.NewService(promptlibraryRepository, promptlibraryConfig)
+	mediaclientClient := infrastructure.ProvideMediaClient(config, zerologLogger)
+	coldstorageRepository := coldstoragerepo.NewColdStorageGormRepository(database)
 	client := infrastructure.ProvideKeycloakClient(config, zerologLogger)
-	processorConfig := domain.ProvidePromptProcessorConfig(config, zerologLogger)
+	mediaColdStore := conversationhandler.NewMediaColdStore(mediaclientClient, client)
+	coldstorageConfig := domain.ProvideColdStorageConfig(config)
+	coldstorageService := coldstorage.NewService(coldstorageRepository, mediaColdStore, coldstorageConfig)
+	conversationHandler := conversationhandler.NewConversationHandler(conversationService, messageActionService, projectService, shareRepository, promptlibraryService, mediaclientClient, coldstorageService)
+	processorConfig := domain.ProvidePromptProcessorConfig(config, featureflagsClient, zerologLogger)
 	promptTemplateRepository := prompttemplaterepo.NewPromptTemplateGormRepository(database)
 	prompttemplateService := prompttemplate.NewService(promptTemplateRepository)
 	modelPromptTemplateRepository := modelprompttemplaterepo.NewModelPromptTemplateGormRepository(database)
@@ -113,12 +191,27 @@ func CreateApplication() (*Application, error) {
 	memoryClient := infrastructure.ProvideMemoryClient(config, zerologLogger)
 	usersettingsRepository := usersettingsrepo.NewUserSettingsGormRepository(db)
 	usersettingsService := usersettings.NewService(usersettingsRepository, modelHandler)
-	memoryHandler := handlers.ProvideMemoryHandler(memoryClient, config, usersettingsService)
-	chatHandler := chathandler.NewChatHandler(inferenceProvider, providerHandler, conversationHandler, conversationService, projectService, processorImpl, memoryHandler, usersettingsService)
-	chatCompletionRoute := chat.NewChatCompletionRoute(chatHandler, authHandler)
+	memoryHandler := handlers.ProvideMemoryHandler(memoryClient, config, featureflagsClient, usersettingsService)
+	analyticsRepository := analyticsrepo.NewAnalyticsGormRepository(database)
+	analyticsService := analytics.NewService(analyticsRepository)
+	personaRepository := personarepo.NewPersonaGormRepository(database)
+	personaConfig := domain.ProvidePersonaConfig(config)
+	personaService := persona.NewService(personaRepository, personaConfig)
+	store, err := infrastructure.ProvideStreamStore(config, universalClient, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
+	admissionConfig := domain.ProvideAdmissionConfig(config)
+	controller := admission.NewController(admissionConfig)
+	ttsClient := infrastructure.ProvideTTSClient(config, zerologLogger)
+	liveKitClient := infrastructure.ProvideLiveKitClient(config, zerologLogger)
+	verifier := infrastructure.ProvideCaptchaVerifier(config, zerologLogger)
+	guestquotaService := infrastructure.ProvideGuestQuota(config, universalClient, verifier, zerologLogger)
+	chatHandler := chathandler.NewChatHandler(inferenceProvider, providerHandler, conversationHandler, conversationService, projectService, processorImpl, memoryHandler, usersettingsService, analyticsService, personaService, controller, store, ttsClient, liveKitClient, guestquotaService)
+	maintenanceService := infrastructure.ProvideMaintenance(config, universalClient, zerologLogger)
+	chatCompletionRoute := chat.NewChatCompletionRoute(chatHandler, authHandler, config, maintenanceService)
 	chatRoute := chat.NewChatRoute(chatCompletionRoute)
 	zImageService := inference.NewZImageService(config)
-	mediaclientClient := infrastructure.ProvideMediaClient(config, zerologLogger)
 	imageHandler := imagehandler.NewImageHandler(config, providerService, zImageService, mediaclientClient, conversationService)
 	imageRoute := image.NewImageRoute(imageHandler, authHandler)
 	conversationRoute := conversation2.NewConversationRoute(conversationHandler, authHandler)
@@ -134,19 +227,63 @@ func CreateApplication() (*Application, error) {
 	adminUserHandler := admin.NewAdminUserHandler(client, adminAuditLogger)
 	adminGroupHandler := admin.NewAdminGroupHandler(client, adminAuditLogger)
 	featureFlagHandler := admin.NewFeatureFlagHandler(database, adminAuditLogger)
+	adminInspectionHandler := admin.NewAdminInspectionHandler(analyticsService, conversationService, service)
+	adminReconcileHandler := admin.NewAdminReconcileHandler(conversationService)
+	adminMaintenanceHandler := admin.NewAdminMaintenanceHandler(maintenanceService, adminAuditLogger)
 	promptTemplateHandler := prompttemplatehandler.NewPromptTemplateHandler(prompttemplateService, adminAuditLogger)
 	mcpToolRepository := mcptoolrepo.NewMCPToolGormRepository(database)
 	mcptoolService := mcptool.NewService(mcpToolRepository)
 	mcpToolHandler := mcptoolhandler.NewMCPToolHandler(mcptoolService, adminAuditLogger)
-	adminRoute := admin2.NewAdminRoute(adminModelRoute, adminProviderRoute, adminUserHandler, adminGroupHandler, featureFlagHandler, promptTemplateHandler, mcpToolHandler)
+	comparisonRepository := comparisonrepo.NewComparisonGormRepository(database)
+	comparisonService := comparison.NewService(comparisonRepository)
+	compareHandler := comparehandler.NewCompareHandler(inferenceProvider, providerHandler, comparisonService, config)
+	evalRepository := evalrepo.NewEvalGormRepository(database)
+	modelCompleter := evalhandler.NewModelCompleter(inferenceProvider, providerHandler)
+	evalConfig := domain.ProvideEvalConfig(config)
+	evalService := eval.NewService(evalRepository, prompttemplateService, modelCompleter, evalConfig)
+	evalHandler := evalhandler.NewEvalHandler(evalService, adminAuditLogger)
+	finetuneRepository := finetunerepo.NewFinetuneGormRepository(database, encryptionService)
+	mediaUploader := finetunehandler.NewMediaUploader(mediaclientClient)
+	finetuneConfig := domain.ProvideFinetuneConfig(config)
+	finetuneService := finetune.NewService(finetuneRepository, mediaUploader, finetuneConfig)
+	finetuneHandler := finetunehandler.NewFinetuneHandler(finetuneService, adminAuditLogger)
+	memoryBackfillRepository := memorybackfillrepo.NewMemoryBackfillGormRepository(database, encryptionService)
+	memoryObserver := memorybackfillhandler.NewMemoryObserver(memoryClient)
+	memorybackfillConfig := domain.ProvideMemoryBackfillConfig(config)
+	memorybackfillService := memorybackfill.NewService(memoryBackfillRepository, memoryObserver, usersettingsService, memorybackfillConfig)
+	memoryBackfillHandler := memorybackfillhandler.NewMemoryBackfillHandler(memorybackfillService, adminAuditLogger)
+	promptLibraryHandler := promptlibraryhandler.NewPromptLibraryHandler(promptlibraryService, adminAuditLogger)
+	modelAliasHandler := modelaliashandler.NewModelAliasHandler(modelaliasService, adminAuditLogger)
+	adminRoute := admin2.NewAdminRoute(adminModelRoute, adminProviderRoute, adminUserHandler, adminGroupHandler, featureFlagHandler, adminInspectionHandler, adminReconcileHandler, adminMaintenanceHandler, promptTemplateHandler, mcpToolHandler, compareHandler, evalHandler, finetuneHandler, memoryBackfillHandler, promptLibraryHandler, modelAliasHandler)
 	userSettingsHandler := usersettingshandler.NewUserSettingsHandler(usersettingsService, providerService, config, zerologLogger)
 	usersRoute := users.NewUsersRoute(userSettingsHandler, authHandler)
-	itemRepository := conversationrepo.NewItemGormRepository(database)
+	itemRepository := conversationrepo.NewItemGormRepository(database, encryptionService)
 	shareService := share.NewShareService(shareRepository, conversationRepository, itemRepository)
 	shareHandler := sharehandler.NewShareHandler(shareService, conversationHandler, config)
 	shareRoute := share2.NewShareRoute(shareHandler, authHandler, conversationHandler)
 	publicShareRoute := public.NewPublicShareRoute(shareHandler)
-	v1Route := v1.NewV1Route(modelRoute, chatRoute, imageRoute, conversationRoute, branchRoute, projectRoute, adminRoute, usersRoute, promptTemplateHandler, mcpToolHandler, shareRoute, publicShareRoute)
+	webhookConfig := domain.ProvideWebhookConfig(config)
+	bus := domain.ProvideEventBus()
+	webhookService := webhook.NewService(webhookConfig, bus)
+	webhookHandler := webhookhandler.NewWebhookHandler(webhookService, zerologLogger)
+	webhookRoute := public.NewWebhookRoute(webhookHandler)
+	analyticsHandler := analyticshandler.NewAnalyticsHandler(analyticsService)
+	analyticsRoute := analytics2.NewAnalyticsRoute(analyticsHandler, authHandler)
+	compareRoute := compare.NewCompareRoute(compareHandler, authHandler, config)
+	promptLibraryRoute := promptlibrary2.NewPromptLibraryRoute(promptLibraryHandler, authHandler)
+	personaHandler := personahandler.NewPersonaHandler(personaService)
+	personaRoute := personas.NewPersonaRoute(personaHandler, authHandler)
+	mcpcredentialRepository := mcpcredentialrepo.NewMCPCredentialGormRepository(database)
+	mcpcredentialConfig := domain.ProvideMCPCredentialConfig(config)
+	mcpcredentialService := mcpcredential.NewService(mcpcredentialRepository, mcpcredentialConfig)
+	mcpCredentialHandler := mcpcredentialhandler.NewMCPCredentialHandler(mcpcredentialService)
+	mcpCredentialRoute := mcpcredentials.NewMCPCredentialRoute(mcpCredentialHandler, authHandler)
+	deltasyncRepository := syncrepo.NewSyncGormRepository(database, encryptionService)
+	deltasyncConfig := domain.ProvideDeltaSyncConfig(config)
+	deltasyncService := deltasync.NewService(deltasyncRepository, deltasyncConfig)
+	syncHandler := synchandler.NewSyncHandler(deltasyncService)
+	syncRoute := sync.NewSyncRoute(syncHandler, authHandler)
+	v1Route := v1.NewV1Route(modelRoute, chatRoute, imageRoute, conversationRoute, branchRoute, projectRoute, adminRoute, usersRoute, promptTemplateHandler, mcpToolHandler, shareRoute, publicShareRoute, webhookRoute, analyticsRoute, compareRoute, promptLibraryRoute, personaRoute, mcpCredentialRoute, syncRoute)
 	guestHandler := guestauth.NewGuestHandler(client, zerologLogger)
 	upgradeHandler := guestauth.NewUpgradeHandler(client, zerologLogger)
 	tokenHandler := authhandler.NewTokenHandler(client, zerologLogger)
@@ -155,14 +292,21 @@ func CreateApplication() (*Application, error) {
 	apikeyService := apikey.NewService(apikeyRepository, repository, client, apikeyConfig, zerologLogger)
 	handler := apikeyhandler.NewHandler(apikeyService, zerologLogger)
 	keycloakOAuthHandler := authhandler.ProvideKeycloakOAuthHandler(config)
-	authRoute := auth.NewAuthRoute(guestHandler, upgradeHandler, tokenHandler, handler, authHandler, keycloakOAuthHandler)
 	keycloakValidator, err := infrastructure.ProvideKeycloakValidator(config, zerologLogger)
 	if err != nil {
 		return nil, err
 	}
+	tokenExchangeHandler := authhandler.NewTokenExchangeHandler(client, keycloakValidator, config, zerologLogger)
+	deviceAuthHandler := authhandler.NewDeviceAuthHandler(client, config, zerologLogger)
+	authRoute := auth.NewAuthRoute(guestHandler, upgradeHandler, tokenHandler, handler, authHandler, keycloakOAuthHandler, tokenExchangeHandler, deviceAuthHandler)
 	infrastructureInfrastructure := infrastructure.NewInfrastructure(db, keycloakValidator, zerologLogger)
-	httpServer := httpserver.NewHttpServer(v1Route, authRoute, infrastructureInfrastructure, config, apikeyService)
-	crontabCrontab := crontab.NewCrontab(providerService, inferenceProvider)
+	checker := infrastructure.ProvideReadinessChecker(config, db, keycloakValidator, memoryClient)
+	httpServer := httpserver.NewHttpServer(v1Route, authRoute, infrastructureInfrastructure, config, apikeyService, checker, maintenanceService)
+	locker, err := infrastructure.ProvideLeaderLocker(config, db, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
+	crontabCrontab := crontab.NewCrontab(providerService, inferenceProvider, analyticsService, evalService, memorybackfillService, deltasyncService, mcpcredentialService, conversationService, coldstorageService, locker, monitor, prober)
 	application := &Application{
 		httpServer: httpServer,
 		crontab:    crontabCrontab,
@@ -180,14 +324,23 @@ func CreateDataInitializer() (*DataInitializer, error) {
 	if err != nil {
 		return nil, err
 	}
-	database := infrastructure.ProvideTransactionDatabase(db)
+	database := infrastructure.ProvideTransactionDatabase(config, db, zerologLogger)
 	providerRepository := modelrepo.NewProviderGormRepository(database)
 	providerModelRepository := modelrepo.NewProviderModelGormRepository(database)
 	modelCatalogRepository := modelrepo.NewModelCatalogGormRepository(database)
 	providerModelService := model.NewProviderModelService(providerModelRepository, modelCatalogRepository)
 	modelCatalogService := model.NewModelCatalogService(modelCatalogRepository)
 	providerService := model.NewProviderService(providerRepository, providerModelService, modelCatalogService)
-	inferenceProvider := inference.NewInferenceProvider(config)
+	monitor := vllmmetrics.NewMonitor(config)
+	regionConfig := domain.ProvideRegionConfig(config)
+	prober := regionprobe.NewProber(config)
+	selector := region.NewSelector(regionConfig, prober)
+	universalClient, err := infrastructure.ProvideRedisClient(config)
+	if err != nil {
+		return nil, err
+	}
+	featureflagsClient := infrastructure.ProvideFeatureFlags(config, universalClient, zerologLogger)
+	inferenceProvider := inference.NewInferenceProvider(config, monitor, selector, featureflagsClient)
 	promptTemplateRepository := prompttemplaterepo.NewPromptTemplateGormRepository(database)
 	service := prompttemplate.NewService(promptTemplateRepository)
 	dataInitializer := &DataInitializer{
@@ -237,6 +237,122 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/device": {
+            "get": {
+                "description": "Page where users approve a device login. Redirects to Keycloak's device page, which signs the user in and asks them to confirm the code.",
+                "tags": [
+                    "Authentication API"
+                ],
+                "summary": "Approve a device login",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Code shown on the device",
+                        "name": "user_code",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "302": {
+                        "description": "Redirect to the Keycloak device page"
+                    }
+                }
+            }
+        },
+        "/auth/device/code": {
+            "post": {
+                "description": "Starts an OAuth 2.0 device authorization grant (RFC 8628) for jan-cli and devices without a browser. Show the user ` + "`" + `user_code` + "`" + ` and ` + "`" + `verification_uri` + "`" + ` (or ` + "`" + `verification_uri_complete` + "`" + `), then poll ` + "`" + `POST /auth/device/token` + "`" + ` every ` + "`" + `interval` + "`" + ` seconds.\nRequest ` + "`" + `offline_access` + "`" + ` in ` + "`" + `scope` + "`" + ` for a refresh token that outlives the browser session.",
+                "consumes": [
+                    "application/x-www-form-urlencoded",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication API"
+                ],
+                "summary": "Start a device login",
+                "parameters": [
+                    {
+                        "description": "Device login request",
+                        "name": "request",
+                        "in": "body",
+                        "required": false,
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.DeviceCodeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Device and user codes",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.DeviceCodeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or scope",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Keycloak device authorization failed",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/device/token": {
+            "post": {
+                "description": "Redeems the device code once the user approved the login. Until then it returns 400 with ` + "`" + `authorization_pending` + "`" + `; ` + "`" + `slow_down` + "`" + ` asks to poll 5 seconds less often, and ` + "`" + `expired_token` + "`" + ` or ` + "`" + `access_denied` + "`" + ` end the login.",
+                "consumes": [
+                    "application/x-www-form-urlencoded",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication API"
+                ],
+                "summary": "Poll a device login",
+                "parameters": [
+                    {
+                        "description": "Device code",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.DeviceTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Access and refresh tokens",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.AccessTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Login pending, denied or expired",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Keycloak token request failed",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/guest-login": {
             "post": {
                 "description": "Creates a temporary guest user account and returns JWT tokens. Guest users have limited access and can be upgraded to full accounts later.",
@@ -450,6 +566,42 @@ const docTemplate = `{
         },
         "/auth/logout": {
             "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deprecated alias of ` + "`" + `POST /auth/logout` + "`" + `, removed on 2027-04-16. Responses carry ` + "`" + `Deprecation` + "`" + `, ` + "`" + `Sunset` + "`" + ` and ` + "`" + `Warning` + "`" + ` headers.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication API"
+                ],
+                "summary": "Logout user (deprecated)",
+                "deprecated": true,
+                "responses": {
+                    "200": {
+                        "description": "Successfully logged out",
+                        "schema": {
+                            "type": "object"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
@@ -629,6 +781,59 @@ const docTemplate = `{
                 }
             }
         },
+        "/auth/token-exchange": {
+            "post": {
+                "description": "OAuth 2.0 token exchange (RFC 8693) for third-party integrations. A Keycloak client listed in INTEGRATION_CLIENTS authenticates with its client credentials (form fields or HTTP Basic) and trades the user's access token for a short-lived token carrying only the requested scopes.\nIntegration tokens only reach the routes their scopes open: ` + "`" + `jan:profile.read` + "`" + ` (GET /auth/me), ` + "`" + `jan:models.read` + "`" + ` (GET /v1/models), ` + "`" + `jan:chat` + "`" + ` (POST /v1/chat/completions), ` + "`" + `jan:conversations.read` + "`" + ` (GET /v1/conversations), ` + "`" + `jan:conversations.write` + "`" + ` (other methods on /v1/conversations) and ` + "`" + `jan:projects.read` + "`" + ` (GET /v1/projects).",
+                "consumes": [
+                    "application/x-www-form-urlencoded",
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Authentication API"
+                ],
+                "summary": "Exchange a user token for an integration token",
+                "parameters": [
+                    {
+                        "description": "Token exchange request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.TokenExchangeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Scoped access token",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.TokenExchangeResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, grant, or scope",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Client is not a registered integration",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Keycloak token exchange failed",
+                        "schema": {
+                            "$ref": "#/definitions/authhandler.OAuthErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/auth/upgrade": {
             "post": {
                 "security": [
@@ -784,50 +989,44 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/mcp-tools": {
+        "/v1/admin/compare/leaderboard": {
             "get": {
-                "description": "Get a paginated list of MCP tools with optional filtering",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Ranks models by win rate over the comparison votes cast in the range. A winner vote counts as a win for the chosen model and a loss for the others; all_bad counts as a loss for every model.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - MCP Tools"
+                    "Admin - Model Comparison"
                 ],
-                "summary": "List MCP tools",
+                "summary": "Model comparison leaderboard",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Filter by category",
-                        "name": "category",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by active status",
-                        "name": "is_active",
+                        "description": "Preset range: 7d, 30d, 90d or 365d (default 30d)",
+                        "name": "range",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Search in name, description, and tool_key",
-                        "name": "search",
+                        "description": "Start date (YYYY-MM-DD); overrides range",
+                        "name": "start_date",
                         "in": "query"
                     },
                     {
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Limit",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "default": 0,
-                        "description": "Offset",
-                        "name": "offset",
+                        "description": "Hide models with fewer votes (default 1)",
+                        "name": "min_votes",
                         "in": "query"
                     }
                 ],
@@ -835,214 +1034,246 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcptoolhandler.ListResponse"
+                            "$ref": "#/definitions/comparehandler.LeaderboardResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid range or dates",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/mcp-tools/{id}": {
+        "/v1/admin/conversations": {
             "get": {
-                "description": "Get an MCP tool by public ID",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists conversation metadata (never content), most recently active first, for operational debugging.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - MCP Tools"
+                    "Admin - Inspection"
                 ],
-                "summary": "Get an MCP tool",
+                "summary": "List conversations across users",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Public ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "User ID, identity provider subject, email or username",
+                        "name": "user",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only conversations active since: 7d, 12h, YYYY-MM-DD or RFC 3339 (default 7d)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum conversations (default 50, max 500)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
+                            "$ref": "#/definitions/admin.AdminConversationsResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Invalid since",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "patch": {
-                "description": "Update an existing MCP tool configuration (Name is read-only)",
-                "consumes": [
-                    "application/json"
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/conversations/{conv_public_id}/reconcile": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Marks the conversation's mcp_call items that have been in_progress for longer than ` + "`" + `older_than` + "`" + ` as failed with a ` + "`" + `timeout` + "`" + ` error, as the scheduled job does across all conversations every 5 minutes. Pass ` + "`" + `older_than=0s` + "`" + ` to fail every call still in progress.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - MCP Tools"
+                    "Admin - Inspection"
                 ],
-                "summary": "Update an MCP tool",
+                "summary": "Reconcile stuck tool calls of a conversation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Public ID",
-                        "name": "id",
+                        "description": "Conversation ID",
+                        "name": "conv_public_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Request body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/mcptool.UpdateMCPToolRequest"
-                        }
+                        "type": "string",
+                        "description": "Minimum time in progress, e.g. 5m (default MCP_CALL_TIMEOUT)",
+                        "name": "older_than",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
+                            "$ref": "#/definitions/admin.ReconcileResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid older_than",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Conversation not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/models/catalogs": {
+        "/v1/admin/evals/datasets": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a paginated list of model catalogs with optional filtering and searching",
+                "description": "Lists datasets, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Evals"
                 ],
-                "summary": "List all model catalogs",
+                "summary": "List eval datasets",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Number of records to return (default: 20, max: 100)",
+                        "default": 20,
+                        "description": "Limit",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Number of records to skip for pagination",
+                        "default": 0,
+                        "description": "Offset",
                         "name": "offset",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order: asc or desc (default: desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by status: init, filled, updated",
-                        "name": "status",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by moderation status",
-                        "name": "is_moderated",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of model catalogs",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
+                            "$ref": "#/definitions/evalhandler.DatasetListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/models/catalogs/bulk-toggle": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Enable or disable provider models for specific catalogs or ALL catalogs, with optional exception list. Supports \"enable/disable all except\" patterns globally or scoped to catalogs.",
+                "description": "Creates a dataset of prompts with expected answers and a default grader. Items may override the grader.\n\nGraders: ` + "`" + `exact_match` + "`" + ` compares the trimmed output with ` + "`" + `expected` + "`" + ` (case-insensitive unless ` + "`" + `case_sensitive` + "`" + `); ` + "`" + `llm_judge` + "`" + ` asks a judge model to score the output against ` + "`" + `expected` + "`" + ` from 0 to 1 and passes items scoring at least ` + "`" + `pass_threshold` + "`" + ` (default 0.5).",
                 "consumes": [
                     "application/json"
                 ],
@@ -1050,41 +1281,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Evals"
                 ],
-                "summary": "Bulk enable/disable provider models by catalog IDs or all catalogs",
+                "summary": "Upload an eval dataset",
                 "parameters": [
                     {
-                        "description": "Bulk toggle request. If catalog_ids is empty, applies to ALL catalogs. Use except_models to exclude specific models.",
+                        "description": "Dataset definition",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestmodels.BulkToggleCatalogsRequest"
+                            "$ref": "#/definitions/evalrequests.CreateDatasetRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Bulk operation result with counts and status",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.BulkOperationResponse"
+                            "$ref": "#/definitions/evalhandler.DatasetResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request - exceeds limits or validation error",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "One or more catalog IDs not found (when catalog_ids provided)",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during bulk operation",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -1092,113 +1329,107 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/models/catalogs/{model_public_id}": {
+        "/v1/admin/evals/datasets/{dataset_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a model catalog entry by its public ID (supports IDs with slashes)",
+                "description": "Returns a dataset with all of its items.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Evals"
                 ],
-                "summary": "Get a model catalog entry",
+                "summary": "Get an eval dataset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID (can contain slashes)",
-                        "name": "model_public_id",
+                        "description": "Dataset ID",
+                        "name": "dataset_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Model catalog details",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
+                            "$ref": "#/definitions/evalhandler.DatasetDetailResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Model catalog not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates metadata for a model catalog entry. Marks it as manually updated to prevent auto-sync overwrites.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Deletes a dataset together with its runs and results.",
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Evals"
                 ],
-                "summary": "Update a model catalog entry",
+                "summary": "Delete an eval dataset",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID (can contain slashes)",
-                        "name": "model_public_id",
+                        "description": "Dataset ID",
+                        "name": "dataset_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requestmodels.UpdateModelCatalogRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated model catalog",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request payload",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Model catalog not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -1206,83 +1437,83 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/models/prompt-templates/assign/{model_id}": {
-            "post": {
-                "description": "Assign or update a prompt template assignment for a model catalog",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/evals/runs": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists runs, newest first, optionally for a single dataset.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Model Prompt Templates"
+                    "Admin - Evals"
                 ],
-                "summary": "Assign a prompt template to a model",
+                "summary": "List eval runs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID",
-                        "name": "model_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only runs of this dataset",
+                        "name": "dataset_id",
+                        "in": "query"
                     },
                     {
-                        "description": "Assignment request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/modelprompthandler.AssignRequest"
-                        }
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
+                            "$ref": "#/definitions/evalhandler.RunListResponse"
                         }
                     },
-                    "201": {
-                        "description": "Created",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Dataset not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/models/prompt-templates/effective/{model_id}": {
-            "get": {
-                "description": "Get all resolved templates for a model, including global defaults",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Queues a run of a dataset against a model, optionally with a prompt template rendered as the system prompt using each item's ` + "`" + `variables` + "`" + `. The template content and version are captured when the run is created, so runs of different template versions can be compared later.\n\nRuns execute in the background; poll ` + "`" + `GET /v1/admin/evals/runs/{run_id}` + "`" + ` until ` + "`" + `status` + "`" + ` is ` + "`" + `completed` + "`" + ` or ` + "`" + `failed` + "`" + `.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1290,64 +1521,80 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Model Prompt Templates"
+                    "Admin - Evals"
                 ],
-                "summary": "Get effective templates for a model",
+                "summary": "Start an eval run",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Model Catalog Public ID",
-                        "name": "model_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Run definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/evalrequests.CreateRunRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/modelprompthandler.EffectiveTemplatesResponse"
+                            "$ref": "#/definitions/evalhandler.RunResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Dataset or prompt template not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/models/prompt-templates/list/{model_id}": {
+        "/v1/admin/evals/runs/{run_id}": {
             "get": {
-                "description": "Get all prompt template assignments for a model catalog",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns a run with its progress and aggregate scores.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Model Prompt Templates"
+                    "Admin - Evals"
                 ],
-                "summary": "List model prompt template assignments",
+                "summary": "Get an eval run",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID",
-                        "name": "model_id",
+                        "description": "Run ID",
+                        "name": "run_id",
                         "in": "path",
                         "required": true
                     }
@@ -1356,248 +1603,236 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelprompthandler.ListResponse"
+                            "$ref": "#/definitions/evalhandler.RunResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/models/prompt-templates/unassign/{template_key}/{model_id}": {
-            "delete": {
-                "description": "Remove a prompt template assignment, reverting to global default",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/evals/runs/{run_id}/compare": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Compares a run with a baseline run of the same dataset, e.g. a new prompt template version against the previous one. Deltas are candidate minus baseline; items that flipped from pass to fail are listed as regressions.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Model Prompt Templates"
+                    "Admin - Evals"
                 ],
-                "summary": "Remove a prompt template assignment from a model",
+                "summary": "Compare two eval runs",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID",
-                        "name": "model_id",
+                        "description": "Candidate run ID",
+                        "name": "run_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Template Key (e.g., deep_research, timing)",
-                        "name": "template_key",
-                        "in": "path",
+                        "description": "Baseline run ID",
+                        "name": "baseline",
+                        "in": "query",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/evalhandler.RunComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Missing baseline or runs of different datasets",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "A run is not completed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/models/prompt-templates/update/{template_key}/{model_id}": {
-            "patch": {
-                "description": "Update an existing prompt template assignment",
-                "consumes": [
-                    "application/json"
+        "/v1/admin/evals/runs/{run_id}/results": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns the output, score and grader verdict of every item evaluated so far, in dataset order.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Model Prompt Templates"
+                    "Admin - Evals"
                 ],
-                "summary": "Update a prompt template assignment",
+                "summary": "Get eval run results",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID",
-                        "name": "model_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Template Key",
-                        "name": "template_key",
+                        "description": "Run ID",
+                        "name": "run_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Update request",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/modelprompthandler.UpdateRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
+                            "$ref": "#/definitions/evalhandler.RunResultListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
                         "description": "Not Found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/models/provider-models": {
+        "/v1/admin/finetune/exports": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a paginated list of provider models with optional filtering",
+                "description": "Lists exports, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Fine-tuning"
                 ],
-                "summary": "List all provider models",
+                "summary": "List fine-tuning dataset exports",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Number of records to return (default: 20, max: 100)",
+                        "default": 20,
+                        "description": "Limit",
                         "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Number of records to skip for pagination",
+                        "default": 0,
+                        "description": "Offset",
                         "name": "offset",
                         "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort order: asc or desc (default: desc)",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by provider public ID",
-                        "name": "provider_id",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by model key",
-                        "name": "model_key",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by active status",
-                        "name": "active",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by image support",
-                        "name": "supports_images",
-                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of provider models",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderModelResponse"
+                            "$ref": "#/definitions/finetunehandler.ExportListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid query parameters",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/models/provider-models/bulk-toggle": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Enables or disables provider models with flexible patterns: enable all, disable all, enable all except, or disable all except. Optionally filter by provider.",
+                "description": "Converts liked assistant turns, each with the messages that preceded it in its conversation branch, into a JSONL training file. PII (emails, phone numbers, card numbers, SSNs and IP addresses) is replaced with placeholders such as ` + "`" + `[EMAIL]` + "`" + ` unless ` + "`" + `redact_pii` + "`" + ` is false.\n\nFormats: ` + "`" + `openai` + "`" + ` writes ` + "`" + `{\"messages\":[...]}` + "`" + ` lines for OpenAI fine-tuning, ` + "`" + `chatml` + "`" + ` writes ` + "`" + `{\"text\":\"<|im_start|>...\"}` + "`" + ` lines for chat-template trainers and ` + "`" + `sharegpt` + "`" + ` writes ` + "`" + `{\"conversations\":[{\"from\",\"value\"}]}` + "`" + ` lines.\n\nExports are built in the background; poll ` + "`" + `GET /v1/admin/finetune/exports/{export_id}` + "`" + ` until ` + "`" + `status` + "`" + ` is ` + "`" + `completed` + "`" + `, then download the file from media-api with ` + "`" + `GET /v1/media/{media_id}` + "`" + `.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1605,35 +1840,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Fine-tuning"
                 ],
-                "summary": "Bulk enable or disable provider models",
+                "summary": "Start a fine-tuning dataset export",
                 "parameters": [
                     {
-                        "description": "Bulk toggle payload with enable flag, optional provider filter, and exception list",
-                        "name": "payload",
+                        "description": "Export definition",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestmodels.BulkEnableModelsRequest"
+                            "$ref": "#/definitions/finetunerequests.CreateExportRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Bulk operation result with counts and status",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.BulkOperationResponse"
+                            "$ref": "#/definitions/finetunehandler.ExportResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -1641,64 +1888,107 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/models/provider-models/{provider_model_public_id}": {
+        "/v1/admin/finetune/exports/{export_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a provider model by its public ID",
+                "description": "Returns an export with its status and, once completed, the media ID of the JSONL file.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Fine-tuning"
                 ],
-                "summary": "Get a provider model",
+                "summary": "Get a fine-tuning dataset export",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider Model Public ID",
-                        "name": "provider_model_public_id",
+                        "description": "Export ID",
+                        "name": "export_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Provider model details",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderModelResponse"
+                            "$ref": "#/definitions/finetunehandler.ExportResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Provider model not found",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/maintenance": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns whether maintenance mode is on. While it is, POST, PUT, PATCH and DELETE requests and WebSocket upgrades are answered with 503 and a ` + "`" + `maintenance` + "`" + ` error, except for this endpoint and the token refresh, logout and validation endpoints; reads and health checks keep working.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Maintenance"
+                ],
+                "summary": "Get maintenance mode",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.State"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates configuration for a provider model including pricing, limits, and feature flags",
+                "description": "Turns maintenance mode on for every replica and service sharing REDIS_URL (only this replica without it); they apply it within MAINTENANCE_REFRESH_INTERVAL. Calling it again while maintenance is on updates the message and expected end.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1706,48 +1996,87 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Model API"
+                    "Admin - Maintenance"
                 ],
-                "summary": "Update a provider model",
+                "summary": "Enable maintenance mode",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Provider Model Public ID",
-                        "name": "provider_model_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Update payload",
-                        "name": "payload",
+                        "description": "Message and expected end",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestmodels.UpdateProviderModelRequest"
+                            "$ref": "#/definitions/admin.EnableMaintenanceRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Updated provider model",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderModelResponse"
+                            "$ref": "#/definitions/maintenance.State"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid request or ends_at in the past",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Provider model not found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal server error",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "The state could not be stored",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Turns maintenance mode off; other replicas and services apply it within MAINTENANCE_REFRESH_INTERVAL.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Maintenance"
+                ],
+                "summary": "Disable maintenance mode",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/maintenance.State"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "The state could not be stored",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -1755,9 +2084,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/prompt-templates": {
+        "/v1/admin/mcp-tools": {
             "get": {
-                "description": "Get a paginated list of prompt templates with optional filtering",
+                "description": "Get a paginated list of MCP tools with optional filtering",
                 "consumes": [
                     "application/json"
                 ],
@@ -1765,9 +2094,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Prompt Templates"
+                    "Admin - MCP Tools"
                 ],
-                "summary": "List prompt templates",
+                "summary": "List MCP tools",
                 "parameters": [
                     {
                         "type": "string",
@@ -1781,15 +2110,9 @@ const docTemplate = `{
                         "name": "is_active",
                         "in": "query"
                     },
-                    {
-                        "type": "boolean",
-                        "description": "Filter by system status",
-                        "name": "is_system",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Search in name and description",
+                        "description": "Search in name, description, and tool_key",
                         "name": "search",
                         "in": "query"
                     },
@@ -1812,7 +2135,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.ListResponse"
+                            "$ref": "#/definitions/mcptoolhandler.ListResponse"
                         }
                     },
                     "500": {
@@ -1825,9 +2148,11 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
-                "description": "Create a new prompt template",
+            }
+        },
+        "/v1/admin/mcp-tools/{id}": {
+            "get": {
+                "description": "Get an MCP tool by public ID",
                 "consumes": [
                     "application/json"
                 ],
@@ -1835,38 +2160,27 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Prompt Templates"
+                    "Admin - MCP Tools"
                 ],
-                "summary": "Create a prompt template",
+                "summary": "Get an MCP tool",
                 "parameters": [
                     {
-                        "description": "Request body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/prompttemplate.CreatePromptTemplateRequest"
-                        }
+                        "type": "string",
+                        "description": "Public ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
-                        "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Bad Request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1884,11 +2198,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/prompt-templates/{id}": {
-            "get": {
-                "description": "Get a prompt template by public ID",
+            },
+            "patch": {
+                "description": "Update an existing MCP tool configuration (Name is read-only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1896,9 +2208,9 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Prompt Templates"
+                    "Admin - MCP Tools"
                 ],
-                "summary": "Get a prompt template",
+                "summary": "Update an MCP tool",
                 "parameters": [
                     {
                         "type": "string",
@@ -1906,62 +2218,26 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcptool.UpdateMCPToolRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
+                            "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
-                    }
-                }
-            },
-            "delete": {
-                "description": "Delete a prompt template (system templates cannot be deleted)",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Admin - Prompt Templates"
-                ],
-                "summary": "Delete a prompt template",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Public ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "204": {
-                        "description": "No Content"
-                    },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -1988,77 +2264,73 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
-                "description": "Update an existing prompt template",
-                "consumes": [
-                    "application/json"
+            }
+        },
+        "/v1/admin/memory/backfills": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Lists backfills, newest first.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Prompt Templates"
+                    "Admin - Memory"
                 ],
-                "summary": "Update a prompt template",
+                "summary": "List memory backfills",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Public ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
                     },
                     {
-                        "description": "Request body",
-                        "name": "body",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/prompttemplate.UpdatePromptTemplateRequest"
-                        }
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
+                            "$ref": "#/definitions/memorybackfillhandler.BackfillListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/admin/prompt-templates/{id}/duplicate": {
+            },
             "post": {
-                "description": "Create a copy of an existing prompt template with a new name (key is auto-generated)",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Replays conversations created before the request through memory observation, so memories are extracted from chat history that predates memory being enabled. Only the latest messages of each conversation's active branch are sent (` + "`" + `MEMORY_BACKFILL_MAX_MESSAGES` + "`" + `), one conversation per ` + "`" + `MEMORY_BACKFILL_INTERVAL` + "`" + ` to keep memory-tools load low.\n\nPrivate conversations and conversations of users with memory or memory observation turned off are skipped. Set ` + "`" + `user_id` + "`" + ` to backfill a single user.\n\nBackfills run in the background; poll ` + "`" + `GET /v1/admin/memory/backfills/{backfill_id}` + "`" + ` for progress. A backfill interrupted by a restart resumes where it stopped.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2066,149 +2338,168 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin - Prompt Templates"
+                    "Admin - Memory"
                 ],
-                "summary": "Duplicate a prompt template",
+                "summary": "Start a memory backfill",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Public ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Request body",
-                        "name": "body",
+                        "description": "Backfill scope",
+                        "name": "request",
                         "in": "body",
-                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.DuplicateRequest"
+                            "$ref": "#/definitions/memorybackfillrequests.CreateBackfillRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
+                            "$ref": "#/definitions/memorybackfillhandler.BackfillResponse"
                         }
                     },
                     "400": {
                         "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "409": {
-                        "description": "Conflict",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/admin/providers": {
+        "/v1/admin/memory/backfills/{backfill_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves all providers with their model counts",
+                "description": "Returns a backfill with its status and progress counters.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Provider API"
+                    "Admin - Memory"
+                ],
+                "summary": "Get a memory backfill",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Backfill ID",
+                        "name": "backfill_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get all providers",
                 "responses": {
                     "200": {
-                        "description": "List of providers with model counts",
+                        "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/modelresponses.ProviderWithModelCountResponse"
-                            }
+                            "$ref": "#/definitions/memorybackfillhandler.BackfillResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve providers",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/admin/memory/backfills/{backfill_id}/cancel": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Registers a new provider and synchronizes its available models.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Stops a queued or running backfill after the conversation in flight. A cancelled backfill can be resumed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Provider API"
+                    "Admin - Memory"
                 ],
-                "summary": "Register a provider",
+                "summary": "Cancel a memory backfill",
                 "parameters": [
                     {
-                        "description": "Provider registration payload",
-                        "name": "payload",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/requestmodels.AddProviderRequest"
-                        }
+                        "type": "string",
+                        "description": "Backfill ID",
+                        "name": "backfill_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Registered provider with synced models",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderWithModelsResponse"
+                            "$ref": "#/definitions/memorybackfillhandler.BackfillResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request payload",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to register provider",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2216,99 +2507,125 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/providers/{provider_public_id}": {
-            "get": {
+        "/v1/admin/memory/backfills/{backfill_id}/resume": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a provider by its public ID",
+                "description": "Queues a failed or cancelled backfill again. It continues after the last conversation it replayed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Provider API"
+                    "Admin - Memory"
                 ],
-                "summary": "Get a provider",
+                "summary": "Resume a memory backfill",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Provider public ID",
-                        "name": "provider_public_id",
+                        "description": "Backfill ID",
+                        "name": "backfill_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Provider details",
+                    "202": {
+                        "description": "Accepted",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderResponse"
+                            "$ref": "#/definitions/memorybackfillhandler.BackfillResponse"
                         }
                     },
-                    "404": {
-                        "description": "Provider not found",
-                        "schema": {
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve provider",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/admin/model-aliases": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Deletes a provider by its public ID along with its provider models",
+                "description": "Lists the model aliases clients can request in place of a model ID, ordered by name.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Provider API"
-                ],
-                "summary": "Delete a provider",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Provider public ID",
-                        "name": "provider_public_id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "Admin - Model Aliases"
                 ],
+                "summary": "List model aliases",
                 "responses": {
-                    "204": {
-                        "description": "Provider deleted"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/modelaliashandler.ModelAliasListResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Provider not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to delete provider",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates an existing provider's configuration",
+                "description": "Creates an alias, e.g. ` + "`" + `jan-large` + "`" + `, that chat completions resolve to ` + "`" + `target_model` + "`" + `. With ` + "`" + `rollout_model` + "`" + ` set, ` + "`" + `rollout_percent` + "`" + ` of users get that model instead; each user stays on the same model while the rollout lasts. Both models must be served by an active provider.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2316,48 +2633,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Admin Provider API"
+                    "Admin - Model Aliases"
                 ],
-                "summary": "Update a provider",
+                "summary": "Create a model alias",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Provider public ID",
-                        "name": "provider_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "description": "Provider update payload",
-                        "name": "payload",
+                        "description": "Alias definition",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/requestmodels.UpdateProviderRequest"
+                            "$ref": "#/definitions/modelaliasrequests.CreateModelAliasRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated provider",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderResponse"
+                            "$ref": "#/definitions/modelaliashandler.ModelAliasResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload",
+                        "description": "Invalid alias or unknown model",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Provider not found",
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Name already used by an alias or model",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to update provider",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2365,121 +2687,120 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/admin/usage": {
+        "/v1/admin/model-aliases/{name}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns total platform token usage including top users and breakdown by model/provider",
+                "description": "Returns a model alias with its target and rollout.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Usage"
+                    "Admin - Model Aliases"
                 ],
-                "summary": "Get platform-wide token usage (Admin only)",
+                "summary": "Get a model alias",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD), defaults to today",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Alias name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/tokenusage.PlatformUsageResponse"
+                            "$ref": "#/definitions/modelaliashandler.ModelAliasResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/auth/logout": {
-            "get": {
-                "description": "Remove refresh tokens to perform logout and invalidate Keycloak session. Accepts refresh token from cookie, Authorization header, or request body.",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Deletes an alias. Completions requesting it then fail with model not found.",
                 "tags": [
-                    "Authentication API"
+                    "Admin - Model Aliases"
                 ],
-                "summary": "Logout",
+                "summary": "Delete a model alias",
                 "parameters": [
-                    {
-                        "description": "Refresh token to revoke",
-                        "name": "refresh_token",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
-                    },
                     {
                         "type": "string",
-                        "description": "Bearer refresh_token",
-                        "name": "Authorization",
-                        "in": "header"
+                        "description": "Alias name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully logged out",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Bad Request",
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
-                "description": "Remove refresh tokens to perform logout and invalidate Keycloak session. Accepts refresh token from cookie, Authorization header, or request body.",
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Changes the target, rollout model or rollout percentage of an alias. Requests resolve the alias on every completion, so the change applies immediately. Set ` + "`" + `rollout_model` + "`" + ` to an empty string to end a rollout.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2487,33 +2808,32 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication API"
+                    "Admin - Model Aliases"
                 ],
-                "summary": "Logout",
+                "summary": "Remap a model alias",
                 "parameters": [
                     {
-                        "description": "Refresh token to revoke",
-                        "name": "refresh_token",
+                        "type": "string",
+                        "description": "Alias name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
                         "in": "body",
+                        "required": true,
                         "schema": {
-                            "type": "string"
+                            "$ref": "#/definitions/modelaliasrequests.UpdateModelAliasRequest"
                         }
-                    },
-                    {
-                        "type": "string",
-                        "description": "Bearer refresh_token",
-                        "name": "Authorization",
-                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully logged out",
+                        "description": "OK",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/modelaliashandler.ModelAliasResponse"
                         }
                     },
                     "400": {
@@ -2521,73 +2841,67 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/v1/auth/me": {
-            "get": {
+        "/v1/admin/model-aliases/{name}/promote": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves the profile of the authenticated user",
+                "description": "Makes the rollout model the alias' target for every user and ends the rollout.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Authentication API"
-                ],
-                "summary": "Get user profile",
-                "responses": {
-                    "200": {
-                        "description": "Successfully retrieved user profile",
-                        "schema": {
-                            "$ref": "#/definitions/authhandler.GetMeResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    }
-                }
-            }
-        },
-        "/v1/auth/refresh-token": {
-            "post": {
-                "description": "Use a valid refresh token to obtain a new access token",
-                "consumes": [
-                    "application/json"
+                    "Admin - Model Aliases"
                 ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Authentication API"
-                ],
-                "summary": "Refresh an access token",
+                "summary": "Promote a model alias rollout",
                 "parameters": [
                     {
-                        "description": "Refresh token (can also be in Authorization header)",
-                        "name": "refresh_token",
-                        "in": "body",
-                        "schema": {
-                            "type": "string"
-                        }
+                        "type": "string",
+                        "description": "Alias name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully refreshed the access token",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/authhandler.AccessTokenResponse"
+                            "$ref": "#/definitions/modelaliashandler.ModelAliasResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "No rollout in progress",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2597,61 +2911,21 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
-                    }
-                }
-            }
-        },
-        "/v1/chat/completions": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Generates a model response for the given chat conversation. This is a standard chat completion API that supports both streaming and non-streaming modes without conversation persistence.\n\n**Streaming Mode (stream=true):**\n- Returns Server-Sent Events (SSE) with real-time streaming\n- Streams completion chunks directly from the inference model\n- Final event contains \"[DONE]\" marker\n\n**Non-Streaming Mode (stream=false or omitted):**\n- Returns single JSON response with complete completion\n- Standard OpenAI ChatCompletionResponse format\n\n**Storage Options:**\n- ` + "`" + `store=true` + "`" + `: Persist the latest input message and assistant response to the active conversation\n- ` + "`" + `store_reasoning=true` + "`" + `: Additionally persist reasoning content provided by the model\n- When ` + "`" + `store` + "`" + ` is omitted or false, the conversation remains read-only\n\n**Features:**\n- Supports all OpenAI ChatCompletionRequest parameters\n- Optional conversation context for conversation persistence\n- User authentication required\n- Direct inference model integration",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json",
-                    "text/event-stream"
-                ],
-                "tags": [
-                    "Chat Completions API"
-                ],
-                "summary": "Create a chat completion",
-                "parameters": [
-                    {
-                        "description": "Chat completion request with streaming options and optional conversation",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/chatrequests.ChatCompletionRequest"
-                        }
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Successful streaming response (when stream=true) - SSE format with data: {json} events",
-                        "schema": {
-                            "type": "string"
-                        }
                     },
-                    "400": {
-                        "description": "Invalid request payload, empty messages, or inference failure",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2659,68 +2933,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/conversations": {
+        "/v1/admin/models/catalogs": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List conversations for the authenticated user with optional referrer filtering.",
+                "description": "Retrieves a paginated list of model catalogs with optional filtering and searching",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin Model API"
                 ],
-                "summary": "List conversations",
+                "summary": "List all model catalogs",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Referrer filter",
-                        "name": "referrer",
+                        "type": "integer",
+                        "description": "Number of records to return (default: 20, max: 100)",
+                        "name": "limit",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Maximum number of conversations to return",
-                        "name": "limit",
+                        "description": "Number of records to skip for pagination",
+                        "name": "offset",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Return conversations created after the given numeric ID",
-                        "name": "after",
+                        "description": "Sort order: asc or desc (default: desc)",
+                        "name": "order",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort order (asc or desc)",
-                        "name": "order",
+                        "description": "Filter by status: init, filled, updated",
+                        "name": "status",
                         "in": "query"
                     },
                     {
-                        "type": "string",
-                        "description": "Set to 'all' to list conversations across the workspace (requires elevated permissions)",
-                        "name": "scope",
+                        "type": "boolean",
+                        "description": "Filter by moderation status",
+                        "name": "is_moderated",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved conversations",
+                        "description": "List of model catalogs",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ConversationListResponse"
+                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request parameters",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2732,14 +3000,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
+            }
+        },
+        "/v1/admin/models/catalogs/bulk-toggle": {
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new conversation to store and retrieve conversation state across Response API calls\n\n**Features:**\n- Create conversation with optional metadata (max 16 key-value pairs)\n- Add up to 20 initial items to the conversation\n- Returns conversation ID with ` + "`" + `conv_` + "`" + ` prefix\n- Supports OpenAI Conversations API format\n\n**Metadata Constraints:**\n- Maximum 16 key-value pairs\n- Keys: max 64 characters\n- Values: max 512 characters",
+                "description": "Enable or disable provider models for specific catalogs or ALL catalogs, with optional exception list. Supports \"enable/disable all except\" patterns globally or scoped to catalogs.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2747,76 +3017,41 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin Model API"
                 ],
-                "summary": "Create a conversation",
+                "summary": "Bulk enable/disable provider models by catalog IDs or all catalogs",
                 "parameters": [
                     {
-                        "description": "Create conversation request with optional items and metadata",
+                        "description": "Bulk toggle request. If catalog_ids is empty, applies to ALL catalogs. Use except_models to exclude specific models.",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/conversationrequests.CreateConversationRequest"
+                            "$ref": "#/definitions/requestmodels.BulkToggleCatalogsRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created conversation",
+                        "description": "Bulk operation result with counts and status",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                            "$ref": "#/definitions/modelresponses.BulkOperationResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request - validation failed or too many items",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error - conversation creation failed",
+                        "description": "Invalid request - exceeds limits or validation error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
-                    }
-                }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Permanently delete all conversations for the authenticated user\n\n**WARNING: This is a destructive operation that cannot be undone.**\n\n**Features:**\n- Deletes ALL conversations owned by the authenticated user\n- Automatically cascades to delete all associated items and shares\n- Returns the count of deleted conversations\n- Requires valid authentication to ensure ownership verification\n\n**Security:**\n- Only deletes conversations owned by the authenticated user\n- Cannot delete other users' conversations\n- Authentication is mandatory",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Conversations API"
-                ],
-                "summary": "Delete all conversations",
-                "responses": {
-                    "200": {
-                        "description": "Successfully deleted all conversations",
-                        "schema": {
-                            "$ref": "#/definitions/conversationresponses.BulkConversationsDeletedResponse"
-                        }
                     },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                    "404": {
+                        "description": "One or more catalog IDs not found (when catalog_ids provided)",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - deletion failed",
+                        "description": "Internal server error during bulk operation",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2824,51 +3059,45 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}": {
+        "/v1/admin/models/catalogs/{model_public_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a conversation by ID with ownership verification\n\n**Features:**\n- Retrieves conversation metadata including creation timestamp\n- Automatic ownership verification (user can only access their own conversations)\n- Returns OpenAI-compatible conversation object\n\n**Response Fields:**\n- ` + "`" + `id` + "`" + `: Conversation ID with ` + "`" + `conv_` + "`" + ` prefix\n- ` + "`" + `object` + "`" + `: Always \"conversation\"\n- ` + "`" + `created_at` + "`" + `: Unix timestamp\n- ` + "`" + `metadata` + "`" + `: User-defined key-value pairs",
+                "description": "Retrieves detailed information about a model catalog entry by its public ID (supports IDs with slashes)",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin Model API"
                 ],
-                "summary": "Get a conversation",
+                "summary": "Get a model catalog entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID (can contain slashes)",
+                        "name": "model_public_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved conversation",
+                        "description": "Model catalog details",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid conversation ID format",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Conversation not found or access denied",
+                        "description": "Model catalog not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -2881,13 +3110,13 @@ const docTemplate = `{
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a conversation's metadata while preserving existing items\n\n**Features:**\n- Update metadata key-value pairs\n- Replaces entire metadata object (not merged)\n- Items remain unchanged\n- Automatic ownership verification\n\n**Metadata Constraints:**\n- Maximum 16 key-value pairs\n- Keys: max 64 characters\n- Values: max 512 characters",
+                "description": "Updates metadata for a model catalog entry. Marks it as manually updated to prevent auto-sync overwrites.",
                 "consumes": [
                     "application/json"
                 ],
@@ -2895,169 +3124,182 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin Model API"
                 ],
-                "summary": "Update a conversation",
+                "summary": "Update a model catalog entry",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID (can contain slashes)",
+                        "name": "model_public_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Update conversation request with new metadata",
-                        "name": "request",
+                        "description": "Update payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/conversationrequests.UpdateConversationRequest"
+                            "$ref": "#/definitions/requestmodels.UpdateModelCatalogRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated conversation",
+                        "description": "Updated model catalog",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request - validation failed or invalid metadata",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Invalid request payload",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Conversation not found or access denied",
+                        "description": "Model catalog not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - update failed",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/v1/admin/models/prompt-templates/assign/{model_id}": {
+            "post": {
+                "description": "Assign or update a prompt template assignment for a model catalog",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Delete a conversation (soft delete). Items in the conversation will not be deleted but will be inaccessible.\n\n**Features:**\n- Soft delete (conversation marked as deleted, not physically removed)\n- Items remain in database but become inaccessible\n- Automatic ownership verification\n- Returns deletion confirmation with conversation ID\n\n**Response:**\n- ` + "`" + `id` + "`" + `: Deleted conversation ID\n- ` + "`" + `object` + "`" + `: Always \"conversation.deleted\"\n- ` + "`" + `deleted` + "`" + `: Always true",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin - Model Prompt Templates"
                 ],
-                "summary": "Delete a conversation",
+                "summary": "Assign a prompt template to a model",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID",
+                        "name": "model_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Assignment request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/modelprompthandler.AssignRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted conversation",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ConversationDeletedResponse"
+                            "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid conversation ID format",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Conversation not found or access denied",
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error - deletion failed",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/branches": {
+        "/v1/admin/models/prompt-templates/effective/{model_id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Get all resolved templates for a model, including global defaults",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "List all branches for a conversation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversation Branches"
+                    "Admin - Model Prompt Templates"
                 ],
-                "summary": "List branches",
+                "summary": "Get effective templates for a model",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID",
+                        "name": "model_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved branches",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/conversationhandler.ListBranchesResponse"
+                            "$ref": "#/definitions/modelprompthandler.EffectiveTemplatesResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Conversation not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Create a new branch in a conversation, optionally forking from an existing item",
+            }
+        },
+        "/v1/admin/models/prompt-templates/list/{model_id}": {
+            "get": {
+                "description": "Get all prompt template assignments for a model catalog",
                 "consumes": [
                     "application/json"
                 ],
@@ -3065,205 +3307,249 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Conversation Branches"
+                    "Admin - Model Prompt Templates"
                 ],
-                "summary": "Create a branch",
+                "summary": "List model prompt template assignments",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID",
+                        "name": "model_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Create branch request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/conversationhandler.CreateBranchRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Successfully created branch",
-                        "schema": {
-                            "$ref": "#/definitions/conversationhandler.BranchResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/modelprompthandler.ListResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Conversation not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/branches/{branch_name}": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/v1/admin/models/prompt-templates/unassign/{template_key}/{model_id}": {
+            "delete": {
+                "description": "Remove a prompt template assignment, reverting to global default",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Get details of a specific branch",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversation Branches"
+                    "Admin - Model Prompt Templates"
                 ],
-                "summary": "Get branch details",
+                "summary": "Remove a prompt template assignment from a model",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID",
+                        "name": "model_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Branch name",
-                        "name": "branch_name",
+                        "description": "Template Key (e.g., deep_research, timing)",
+                        "name": "template_key",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved branch",
-                        "schema": {
-                            "$ref": "#/definitions/conversationhandler.BranchResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Branch not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            },
-            "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+            }
+        },
+        "/v1/admin/models/prompt-templates/update/{template_key}/{model_id}": {
+            "patch": {
+                "description": "Update an existing prompt template assignment",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
                 ],
-                "description": "Delete a branch from a conversation (cannot delete MAIN or active branch)",
                 "tags": [
-                    "Conversation Branches"
+                    "Admin - Model Prompt Templates"
                 ],
-                "summary": "Delete a branch",
+                "summary": "Update a prompt template assignment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Model Catalog Public ID",
+                        "name": "model_id",
                         "in": "path",
                         "required": true
                     },
                     {
                         "type": "string",
-                        "description": "Branch name",
-                        "name": "branch_name",
+                        "description": "Template Key",
+                        "name": "template_key",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Update request",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/modelprompthandler.UpdateRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Branch deleted successfully"
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
+                        }
                     },
                     "400": {
-                        "description": "Cannot delete MAIN or active branch",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Branch not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/branches/{branch_name}/activate": {
-            "post": {
+        "/v1/admin/models/provider-models": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Set a branch as the active branch for a conversation",
+                "description": "Retrieves a paginated list of provider models with optional filtering",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversation Branches"
+                    "Admin Model API"
                 ],
-                "summary": "Activate a branch",
+                "summary": "List all provider models",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of records to return (default: 20, max: 100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Number of records to skip for pagination",
+                        "name": "offset",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Sort order: asc or desc (default: desc)",
+                        "name": "order",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Branch name",
-                        "name": "branch_name",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by provider public ID",
+                        "name": "provider_id",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by model key",
+                        "name": "model_key",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by image support",
+                        "name": "supports_images",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Branch activated successfully",
+                        "description": "List of provider models",
                         "schema": {
-                            "$ref": "#/definitions/conversationhandler.ActivateBranchResponse"
+                            "$ref": "#/definitions/modelresponses.ProviderModelResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid query parameters",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Branch not found",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -3271,106 +3557,115 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/items": {
-            "get": {
+        "/v1/admin/models/provider-models/bulk-toggle": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List all items in a conversation with cursor-based pagination support\n\n**Features:**\n- Cursor-based pagination using item IDs\n- Configurable page size (1-100 items, default 20)\n- Sort order control (ascending or descending)\n- Optional include parameter for additional fields\n- Returns paginated list with navigation cursors\n\n**Pagination:**\n- Use ` + "`" + `after` + "`" + ` cursor from previous response for next page\n- ` + "`" + `has_more` + "`" + ` indicates if more items are available\n- ` + "`" + `first_id` + "`" + ` and ` + "`" + `last_id` + "`" + ` provide cursor references\n\n**Query Parameters:**\n- ` + "`" + `limit` + "`" + `: Number of items (1-100, default 20)\n- ` + "`" + `order` + "`" + `: Sort order (\"asc\" or \"desc\", default \"desc\")\n- ` + "`" + `after` + "`" + `: Item ID cursor for pagination\n- ` + "`" + `include` + "`" + `: Additional fields to include (optional)",
+                "description": "Enables or disables provider models with flexible patterns: enable all, disable all, enable all except, or disable all except. Optionally filter by provider.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin Model API"
                 ],
-                "summary": "List conversation items",
+                "summary": "Bulk enable or disable provider models",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Bulk toggle payload with enable flag, optional provider filter, and exception list",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestmodels.BulkEnableModelsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Bulk operation result with counts and status",
+                        "schema": {
+                            "$ref": "#/definitions/modelresponses.BulkOperationResponse"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Item ID cursor to list items after (pagination)",
-                        "name": "after",
-                        "in": "query"
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/admin/models/provider-models/{provider_model_public_id}": {
+            "get": {
+                "security": [
                     {
-                        "maximum": 100,
-                        "minimum": 1,
-                        "type": "integer",
-                        "default": 20,
-                        "description": "Number of items to return (1-100)",
-                        "name": "limit",
-                        "in": "query"
-                    },
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a provider model by its public ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin Model API"
+                ],
+                "summary": "Get a provider model",
+                "parameters": [
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "default": "desc",
-                        "description": "Sort order: asc or desc",
-                        "name": "order",
-                        "in": "query"
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Additional fields to include in response",
-                        "name": "include",
-                        "in": "query"
+                        "description": "Provider Model Public ID",
+                        "name": "provider_model_public_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved items list",
+                        "description": "Provider model details",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ItemListResponse"
+                            "$ref": "#/definitions/modelresponses.ProviderModelResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request - invalid parameters or conversation ID",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Conversation not found or access denied",
+                        "description": "Provider model not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - listing failed",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             },
-            "post": {
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Add items to a conversation. You may add up to 20 items at a time.\n\n**Features:**\n- Bulk item creation (max 20 items per request)\n- Automatic item ID generation with ` + "`" + `msg_` + "`" + ` prefix\n- Items added to conversation's active branch (default: MAIN)\n- Returns list of created items with generated IDs\n\n**Item Types:**\n- ` + "`" + `message` + "`" + `: User or assistant messages\n- ` + "`" + `tool_call` + "`" + `: Tool/function call items\n- ` + "`" + `tool_response` + "`" + `: Tool/function response items\n- Other OpenAI-compatible item types\n\n**Constraints:**\n- Maximum 20 items per request\n- Each item must have valid type and content\n- Items are immutable after creation",
+                "description": "Updates configuration for a provider model including pricing, limits, and feature flags",
                 "consumes": [
                     "application/json"
                 ],
@@ -3378,64 +3673,48 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin Model API"
                 ],
-                "summary": "Create conversation items",
+                "summary": "Update a provider model",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
+                        "description": "Provider Model Public ID",
+                        "name": "provider_model_public_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Additional fields to include in response",
-                        "name": "include",
-                        "in": "query"
-                    },
-                    {
-                        "description": "Create items request with array of items",
-                        "name": "request",
+                        "description": "Update payload",
+                        "name": "payload",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/conversationrequests.CreateItemsRequest"
+                            "$ref": "#/definitions/requestmodels.UpdateProviderModelRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully created items",
+                        "description": "Updated provider model",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ConversationItemCreatedResponse"
+                            "$ref": "#/definitions/modelresponses.ProviderModelResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request - too many items, invalid format, or validation failed",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Invalid request payload",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Conversation not found or access denied",
+                        "description": "Provider model not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - item creation failed",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -3443,229 +3722,208 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/items/by-call-id/{call_id}": {
-            "patch": {
+        "/v1/admin/prompt-library": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update a conversation item's status and output using its call_id.\nThis endpoint is primarily used by MCP tools to report tool execution results.\n\n**Features:**\n- Find item by call_id (e.g., call_xxx) instead of item_id\n- Update status to completed, failed, or cancelled\n- Store tool output or error message\n- Automatic timestamp for completion\n\n**Use Cases:**\n- MCP tool reports successful execution with output\n- MCP tool reports failure with error message\n- Tool call status tracking and observability",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Lists admin-managed starters, including inactive ones unless ` + "`" + `is_active` + "`" + ` is given.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin - Prompt Library"
                 ],
-                "summary": "Update item by call ID",
+                "summary": "List global conversation starters",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Only starters in this category",
+                        "name": "category",
+                        "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Call ID of the tool call item (format: call_xxxxx)",
-                        "name": "call_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Search in title and description",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
-                        "description": "Update request with status and optional output/error",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/conversationrequests.UpdateItemByCallIDRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Filter by active state",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully updated item",
-                        "schema": {
-                            "$ref": "#/definitions/conversationresponses.ItemResponse"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request - validation failed",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/promptlibraryhandler.StarterListResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Conversation or item not found",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/conversations/{conv_public_id}/items/{item_id}": {
-            "get": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve a single item from a conversation by item ID\n\n**Features:**\n- Retrieve specific item by ID\n- Returns complete item with all content\n- Automatic ownership verification via conversation\n- Optional include parameter for additional fields\n\n**Response Fields:**\n- ` + "`" + `id` + "`" + `: Item ID with ` + "`" + `msg_` + "`" + ` prefix\n- ` + "`" + `type` + "`" + `: Item type (message, tool_call, etc.)\n- ` + "`" + `role` + "`" + `: Role for message items (user, assistant)\n- ` + "`" + `content` + "`" + `: Item content array\n- ` + "`" + `status` + "`" + `: Item status (completed, incomplete, etc.)\n- ` + "`" + `created_at` + "`" + `: Unix timestamp",
+                "description": "Creates a starter visible to every user. Message content uses Go template syntax (` + "`" + `{{.name}}` + "`" + `) and may only reference declared variables.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin - Prompt Library"
                 ],
-                "summary": "Get a conversation item",
+                "summary": "Create a global conversation starter",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item ID (format: msg_xxxxx)",
-                        "name": "item_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Additional fields to include in response",
-                        "name": "include",
-                        "in": "query"
+                        "description": "Starter definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryrequests.CreateStarterRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successfully retrieved item",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/conversationresponses.ItemResponse"
+                            "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid conversation ID or item ID format",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Conversation or item not found, or access denied",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/admin/prompt-library/{starter_id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Delete an item from a conversation by creating a new MAIN branch without it.\nThe old MAIN branch is preserved as a backup.\n\n**Features:**\n- Creates a new branch without the deleted item\n- New branch becomes MAIN, old MAIN becomes backup\n- Automatic ownership verification\n- Preserves conversation history in backup branch\n\n**Important:**\n- The old MAIN branch is renamed to MAIN_YYYYMMDDHHMMSS\n- You can switch back to the backup branch if needed\n- This is a non-destructive delete operation\n\n**Response:**\nReturns branch information including the backup branch name",
+                "description": "Returns an admin-managed starter, active or not.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Conversations API"
+                    "Admin - Prompt Library"
                 ],
-                "summary": "Delete a conversation item",
+                "summary": "Get a global conversation starter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Item ID to delete (format: msg_xxxxx)",
-                        "name": "item_id",
+                        "description": "Starter ID",
+                        "name": "starter_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully deleted item, returns branch info",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/conversationhandler.DeleteItemResponse"
+                            "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid conversation ID or item ID format",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Conversation or item not found, or access denied",
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error - deletion failed",
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/conversations/{conv_public_id}/items/{item_id}/edit": {
-            "post": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Edit a user message and create a new branch with the edited content",
+                "description": "Updates an admin-managed starter. Set ` + "`" + `is_active` + "`" + ` to false to hide it from users without deleting it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -3673,43 +3931,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Message Actions"
+                    "Admin - Prompt Library"
                 ],
-                "summary": "Edit a message",
+                "summary": "Update a global conversation starter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Message item ID (format: msg_xxxxx)",
-                        "name": "item_id",
+                        "description": "Starter ID",
+                        "name": "starter_id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Edit message request",
+                        "description": "Fields to update",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/conversationhandler.EditMessageRequest"
+                            "$ref": "#/definitions/promptlibraryrequests.UpdateStarterRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Message edited successfully",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/conversationhandler.EditMessageResponse"
+                            "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request or not a user message",
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -3720,78 +3971,70 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "404": {
-                        "description": "Message not found",
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/conversations/{conv_public_id}/items/{item_id}/regenerate": {
-            "post": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Regenerate an assistant response by creating a new branch",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Deletes an admin-managed starter. Conversations already started from it are not affected.",
                 "tags": [
-                    "Message Actions"
+                    "Admin - Prompt Library"
                 ],
-                "summary": "Regenerate a response",
+                "summary": "Delete a global conversation starter",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation ID (format: conv_xxxxx)",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Assistant message item ID (format: msg_xxxxx)",
-                        "name": "item_id",
+                        "description": "Starter ID",
+                        "name": "starter_id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Regenerate options",
-                        "name": "request",
-                        "in": "body",
-                        "schema": {
-                            "$ref": "#/definitions/conversationhandler.RegenerateMessageRequest"
-                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Regeneration initiated",
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/conversationhandler.RegenerateMessageResponse"
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request or not an assistant message",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Message not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -3799,14 +4042,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/share": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates a public share link for a conversation or a single message",
+        "/v1/admin/prompt-templates": {
+            "get": {
+                "description": "Get a paginated list of prompt templates with optional filtering",
                 "consumes": [
                     "application/json"
                 ],
@@ -3814,191 +4052,221 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Shares API"
+                    "Admin - Prompt Templates"
                 ],
-                "summary": "Create a share for a conversation",
+                "summary": "List prompt templates",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation public ID",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Filter by category",
+                        "name": "category",
+                        "in": "query"
                     },
                     {
-                        "description": "Share creation request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/sharerequests.CreateShareRequest"
-                        }
+                        "type": "boolean",
+                        "description": "Filter by active status",
+                        "name": "is_active",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Filter by system status",
+                        "name": "is_system",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in name and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Share created successfully",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/shareresponses.ShareResponse"
+                            "$ref": "#/definitions/prompttemplatehandler.ListResponse"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                    }
+                }
+            },
+            "post": {
+                "description": "Create a new prompt template",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Admin - Prompt Templates"
+                ],
+                "summary": "Create a prompt template",
+                "parameters": [
+                    {
+                        "description": "Request body",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/prompttemplate.CreatePromptTemplateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Conversation not found",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "413": {
-                        "description": "Snapshot too large",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/conversations/{conv_public_id}/shares": {
+        "/v1/admin/prompt-templates/{id}": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Get a prompt template by public ID",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Lists all shares (active and revoked) for a conversation",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shares API"
+                    "Admin - Prompt Templates"
                 ],
-                "summary": "List shares for a conversation",
+                "summary": "Get a prompt template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation public ID",
-                        "name": "conv_public_id",
+                        "description": "Public ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of shares",
-                        "schema": {
-                            "$ref": "#/definitions/shareresponses.ShareListResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "404": {
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "404": {
-                        "description": "Conversation not found",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
-            }
-        },
-        "/v1/conversations/{conv_public_id}/shares/{share_id}": {
+            },
             "delete": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+                "description": "Delete a prompt template (system templates cannot be deleted)",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Revokes an active share, making it inaccessible",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shares API"
+                    "Admin - Prompt Templates"
                 ],
-                "summary": "Revoke a share",
+                "summary": "Delete a prompt template",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Conversation public ID",
-                        "name": "conv_public_id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Share public ID",
-                        "name": "share_id",
+                        "description": "Public ID",
+                        "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Share revoked successfully",
-                        "schema": {
-                            "$ref": "#/definitions/shareresponses.ShareDeletedResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
+                    "204": {
+                        "description": "No Content"
                     },
                     "403": {
                         "description": "Forbidden",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "Share not found",
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
-                    }
-                }
-            }
-        },
-        "/v1/healthz": {
-            "get": {
-                "description": "Returns the health status of the API server. Used by orchestrators and monitoring systems.",
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Server API"
-                ],
-                "summary": "Health check endpoint",
-                "responses": {
-                    "200": {
-                        "description": "Health status OK",
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4007,16 +4275,9 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/v1/images/edits": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Creates an edited image given an original image and a prompt.",
+            },
+            "patch": {
+                "description": "Update an existing prompt template",
                 "consumes": [
                     "application/json"
                 ],
@@ -4024,62 +4285,67 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Images API"
+                    "Admin - Prompt Templates"
                 ],
-                "summary": "Create image edit",
+                "summary": "Update a prompt template",
                 "parameters": [
                     {
-                        "description": "Image edit request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Public ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/image.ImageEditRequest"
+                            "$ref": "#/definitions/prompttemplate.UpdatePromptTemplateRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successful image edit response",
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/image.ImageGenerationResponse"
+                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload or validation error",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "No active image provider configured",
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "500": {
-                        "description": "Internal server error or image provider error",
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/images/generations": {
+        "/v1/admin/prompt-templates/{id}/duplicate": {
             "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Generates images from a text prompt using the configured image provider.\nThis endpoint is compatible with the OpenAI Images API format.\n\n**Response Formats:**\n- url: Returns presigned URLs to download images (default, recommended)\n- b64_json: Returns base64-encoded image data\n\n**Size Options:**\n- 1024x1024 (default)\n- 512x512\n- 1792x1024 (landscape)\n- 1024x1792 (portrait)\n",
+                "description": "Create a copy of an existing prompt template with a new name (key is auto-generated)",
                 "consumes": [
                     "application/json"
                 ],
@@ -4087,91 +4353,113 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Images API"
+                    "Admin - Prompt Templates"
                 ],
-                "summary": "Create image generation",
+                "summary": "Duplicate a prompt template",
                 "parameters": [
                     {
-                        "description": "Image generation request",
-                        "name": "request",
+                        "type": "string",
+                        "description": "Public ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Request body",
+                        "name": "body",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/image.ImageGenerationRequest"
+                            "$ref": "#/definitions/prompttemplatehandler.DuplicateRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Successful image generation response",
+                    "201": {
+                        "description": "Created",
                         "schema": {
-                            "$ref": "#/definitions/image.ImageGenerationResponse"
+                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request payload or validation error",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid authentication",
+                        "description": "Bad Request",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
                     "404": {
-                        "description": "No active image provider configured",
+                        "description": "Not Found",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "500": {
-                        "description": "Internal server error or image provider error",
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "501": {
-                        "description": "Feature not implemented",
+                    "500": {
+                        "description": "Internal Server Error",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     }
                 }
             }
         },
-        "/v1/images/variations": {
-            "post": {
+        "/v1/admin/providers": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a variation of a given image.\nNOTE: This endpoint is not yet implemented and will return 501 Not Implemented.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Retrieves all providers with their model counts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Images API"
+                    "Admin Provider API"
                 ],
-                "summary": "Create image variation (Not Implemented)",
+                "summary": "Get all providers",
                 "responses": {
-                    "501": {
-                        "description": "Not implemented",
+                    "200": {
+                        "description": "List of providers with model counts",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/modelresponses.ProviderWithModelCountResponse"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve providers",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/mcp-tools": {
-            "get": {
-                "description": "Get all active MCP tools (public endpoint for mcp-tools service)",
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Registers a new provider and synchronizes its available models.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4179,175 +4467,184 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "MCP Tools"
+                    "Admin Provider API"
+                ],
+                "summary": "Register a provider",
+                "parameters": [
+                    {
+                        "description": "Provider registration payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestmodels.AddProviderRequest"
+                        }
+                    }
                 ],
-                "summary": "List all active MCP tools",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Registered provider with synced models",
                         "schema": {
-                            "$ref": "#/definitions/mcptoolhandler.ActiveToolsResponse"
+                            "$ref": "#/definitions/modelresponses.ProviderWithModelsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to register provider",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/mcp-tools/{key}": {
+        "/v1/admin/providers/{provider_public_id}": {
             "get": {
-                "description": "Get an MCP tool by its unique tool key (public endpoint for mcp-tools service)",
-                "consumes": [
-                    "application/json"
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Retrieves a provider by its public ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "MCP Tools"
+                    "Admin Provider API"
                 ],
-                "summary": "Get an MCP tool by key",
+                "summary": "Get a provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Tool Key",
-                        "name": "key",
+                        "description": "Provider public ID",
+                        "name": "provider_public_id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Provider details",
                         "schema": {
-                            "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
+                            "$ref": "#/definitions/modelresponses.ProviderResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Provider not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Failed to retrieve provider",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/models": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of available models that can be used for chat completions or other tasks. Returns either simple model list or detailed list with provider metadata based on X-PROVIDER-DATA header.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Deletes a provider by its public ID along with its provider models",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Chat Completions API"
+                    "Admin Provider API"
                 ],
-                "summary": "List available models",
+                "summary": "Delete a provider",
                 "parameters": [
                     {
-                        "enum": [
-                            "true",
-                            "false"
-                        ],
                         "type": "string",
-                        "description": "Set to 'true' to include provider metadata in response",
-                        "name": "X-PROVIDER-DATA",
-                        "in": "header"
+                        "description": "Provider public ID",
+                        "name": "provider_public_id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of models with provider metadata (when X-PROVIDER-DATA=true)",
-                        "schema": {
-                            "$ref": "#/definitions/modelresponses.ModelWithProviderResponseList"
-                        }
+                    "204": {
+                        "description": "Provider deleted"
                     },
                     "404": {
-                        "description": "Models or providers not found",
+                        "description": "Provider not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve models",
+                        "description": "Failed to delete provider",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/models/catalogs/{model_public_id}": {
-            "get": {
+            },
+            "patch": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves detailed information about a model catalog entry by its public ID (supports IDs with slashes like openrouter/nova-lite-v1)",
+                "description": "Updates an existing provider's configuration",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Model API"
+                    "Admin Provider API"
                 ],
-                "summary": "Get a model catalog entry",
+                "summary": "Update a provider",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Model Catalog Public ID (can contain slashes)",
-                        "name": "model_public_id",
+                        "description": "Provider public ID",
+                        "name": "provider_public_id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Provider update payload",
+                        "name": "payload",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/requestmodels.UpdateProviderRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Model catalog details",
+                        "description": "Updated provider",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
+                            "$ref": "#/definitions/modelresponses.ProviderResponse"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid request payload",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Model catalog not found",
+                        "description": "Provider not found",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Failed to update provider",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4355,14 +4652,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/models/providers": {
-            "get": {
+        "/v1/admin/providers/{provider_public_id}/test": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieves a list of available model providers that can be used for inference.",
+                "description": "Validates the provider's API key by listing its models, then sends a minimal completion and a streaming completion to one of its models. Each step reports its latency and error; the response is 200 even when a step fails, check ` + "`" + `ok` + "`" + `. Uses the requested model, else an active model of the provider, else the first model the provider lists.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4370,18 +4667,47 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Model API"
+                    "Admin Provider API"
+                ],
+                "summary": "Test a provider's credentials",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Provider public ID",
+                        "name": "provider_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Optional model to test",
+                        "name": "payload",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/requestmodels.TestProviderRequest"
+                        }
+                    }
                 ],
-                "summary": "List model providers",
                 "responses": {
                     "200": {
-                        "description": "List of providers",
+                        "description": "Per-step results and detected capabilities",
                         "schema": {
-                            "$ref": "#/definitions/modelresponses.ProviderResponseList"
+                            "$ref": "#/definitions/modelresponses.ProviderTestResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Failed to retrieve providers",
+                        "description": "Failed to test provider",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4389,38 +4715,38 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projects": {
+        "/v1/admin/usage": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "List all projects for the authenticated user",
+                "description": "Aggregates chat completions across users by model, provider, user or day. Reads raw completion events, so the current day is included.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects API"
+                    "Admin - Inspection"
                 ],
-                "summary": "List projects",
+                "summary": "Platform usage",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "description": "Maximum number of projects to return",
-                        "name": "limit",
+                        "type": "string",
+                        "description": "Grouping: model, provider, user or day (default model)",
+                        "name": "by",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Return projects after the given numeric ID",
-                        "name": "after",
+                        "description": "Only this user: user ID, identity provider subject, email or username",
+                        "name": "user",
                         "in": "query"
                     },
                     {
                         "type": "string",
-                        "description": "Sort order (asc or desc)",
-                        "name": "order",
+                        "description": "Start of the window: 7d, 12h, YYYY-MM-DD or RFC 3339 (default 7d)",
+                        "name": "since",
                         "in": "query"
                     }
                 ],
@@ -4428,7 +4754,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/projectres.ProjectListResponse"
+                            "$ref": "#/definitions/admin.AdminUsageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid grouping or since",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
@@ -4437,6 +4769,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4444,44 +4788,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "post": {
+            }
+        },
+        "/v1/analytics/latency": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Create a new project for grouping conversations",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns the average model response latency of the authenticated user's successful completions in the range, with a daily breakdown and failure counts",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects API"
+                    "Analytics API"
                 ],
-                "summary": "Create project",
+                "summary": "Average response latency",
                 "parameters": [
                     {
-                        "description": "Create project request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/projectreq.CreateProjectRequest"
-                        }
+                        "type": "string",
+                        "description": "Preset range: 7d, 30d, 90d or 365d (default 30d)",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD); overrides range",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/projectres.ProjectResponse"
+                            "$ref": "#/definitions/analyticshandler.LatencyResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid range or dates",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4501,45 +4853,56 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/projects/{project_id}": {
+        "/v1/analytics/messages": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Get a single project by ID",
+                "description": "Returns the number of user and assistant messages in the authenticated user's conversations for each day of the range. Figures come from nightly rollups and cover complete UTC days.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects API"
+                    "Analytics API"
                 ],
-                "summary": "Get project",
+                "summary": "Messages per day",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "project_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Preset range: 7d, 30d, 90d or 365d (default 30d)",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD); overrides range",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/projectres.ProjectResponse"
+                            "$ref": "#/definitions/analyticshandler.MessagesPerDayResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid range or dates",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4551,45 +4914,64 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "delete": {
+            }
+        },
+        "/v1/analytics/models": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Soft-delete a project",
+                "description": "Returns the models the authenticated user called most in the range, with token totals and average response latency",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects API"
+                    "Analytics API"
                 ],
-                "summary": "Delete project",
+                "summary": "Top models",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "project_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Preset range: 7d, 30d, 90d or 365d (default 30d)",
+                        "name": "range",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD); overrides range",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of models (default 10, max 50)",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/projectres.ProjectDeletedResponse"
+                            "$ref": "#/definitions/analyticshandler.TopModelsResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid range or dates",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4601,51 +4983,52 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/v1/analytics/tools": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update project name, instruction, or archived status",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns how often the model called each tool for the authenticated user in the range, with each tool's share of all calls",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Projects API"
+                    "Analytics API"
                 ],
-                "summary": "Update project",
+                "summary": "Tool usage breakdown",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Project ID",
-                        "name": "project_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Preset range: 7d, 30d, 90d or 365d (default 30d)",
+                        "name": "range",
+                        "in": "query"
                     },
                     {
-                        "description": "Update request",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/projectreq.UpdateProjectRequest"
-                        }
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD); overrides range",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/projectres.ProjectResponse"
+                            "$ref": "#/definitions/analyticshandler.ToolUsageResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid range or dates",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4656,12 +5039,6 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Not Found",
-                        "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
-                        }
-                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -4671,9 +5048,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/prompt-templates/{key}": {
+        "/v1/auth/logout": {
             "get": {
-                "description": "Get a prompt template by its unique template key (public endpoint)",
+                "description": "Remove refresh tokens to perform logout and invalidate Keycloak session. Accepts refresh token from cookie, Authorization header, or request body.",
                 "consumes": [
                     "application/json"
                 ],
@@ -4681,27 +5058,28 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Prompt Templates"
+                    "Authentication API"
                 ],
-                "summary": "Get a prompt template by key",
+                "summary": "Logout",
                 "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Template Key",
-                        "name": "key",
-                        "in": "path",
-                        "required": true
+                        "description": "Bearer refresh_token",
+                        "name": "Authorization",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
-                        "schema": {
-                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Not Found",
+                        "description": "Successfully logged out",
                         "schema": {
                             "type": "object",
                             "additionalProperties": {
@@ -4709,148 +5087,130 @@ const docTemplate = `{
                             }
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/public/shares/{slug}": {
-            "get": {
-                "description": "Retrieves a publicly shared conversation or message by its slug",
+            },
+            "post": {
+                "description": "Remove refresh tokens to perform logout and invalidate Keycloak session. Accepts refresh token from cookie, Authorization header, or request body.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Public Shares API"
+                    "Authentication API"
                 ],
-                "summary": "Get a public share by slug",
+                "summary": "Logout",
                 "parameters": [
+                    {
+                        "description": "Refresh token to revoke",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Share slug",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
+                        "description": "Bearer refresh_token",
+                        "name": "Authorization",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Public share content",
-                        "schema": {
-                            "$ref": "#/definitions/shareresponses.PublicShareResponse"
-                        }
-                    },
-                    "404": {
-                        "description": "Share not found or revoked",
+                        "description": "Successfully logged out",
                         "schema": {
-                            "$ref": "#/definitions/responses.ErrorResponse"
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
                         }
                     },
-                    "410": {
-                        "description": "Share has been revoked",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            },
-            "head": {
-                "description": "Checks if a share exists and is accessible (for preloading)",
-                "tags": [
-                    "Public Shares API"
-                ],
-                "summary": "Check if a public share exists",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Share slug",
-                        "name": "slug",
-                        "in": "path",
-                        "required": true
-                    }
-                ],
-                "responses": {
-                    "200": {
-                        "description": "Share exists and is accessible"
-                    },
-                    "404": {
-                        "description": "Share not found"
-                    },
-                    "410": {
-                        "description": "Share has been revoked"
-                    }
-                }
             }
         },
-        "/v1/readyz": {
+        "/v1/auth/me": {
             "get": {
-                "description": "Returns the readiness status of the API server. Indicates if the service is ready to accept traffic.",
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves the profile of the authenticated user",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Server API"
+                    "Authentication API"
                 ],
-                "summary": "Readiness check endpoint",
+                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "Readiness status ready",
+                        "description": "Successfully retrieved user profile",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/authhandler.GetMeResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/shares": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
+        "/v1/auth/refresh-token": {
+            "post": {
+                "description": "Use a valid refresh token to obtain a new access token",
+                "consumes": [
+                    "application/json"
                 ],
-                "description": "Lists all shares (active and revoked) for the authenticated user across all conversations",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Shares API"
+                    "Authentication API"
                 ],
-                "summary": "List all shares for the authenticated user",
+                "summary": "Refresh an access token",
                 "parameters": [
                     {
-                        "type": "boolean",
-                        "default": true,
-                        "description": "Include revoked shares",
-                        "name": "include_revoked",
-                        "in": "query"
+                        "description": "Refresh token (can also be in Authorization header)",
+                        "name": "refresh_token",
+                        "in": "body",
+                        "schema": {
+                            "type": "string"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "List of shares",
+                        "description": "Successfully refreshed the access token",
                         "schema": {
-                            "$ref": "#/definitions/shareresponses.ShareListResponse"
+                            "$ref": "#/definitions/authhandler.AccessTokenResponse"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Bad Request",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4858,51 +5218,99 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/shares/{share_id}": {
-            "delete": {
+        "/v1/chat/completions": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Revokes an active share by its ID, making it inaccessible",
-                "produces": [
+                "description": "Generates a model response for the given chat conversation. This is a standard chat completion API that supports both streaming and non-streaming modes without conversation persistence.\n\n**Streaming Mode (stream=true):**\n- Returns Server-Sent Events (SSE) with real-time streaming\n- Streams completion chunks directly from the inference model\n- Final event contains \"[DONE]\" marker\n\n**Non-Streaming Mode (stream=false or omitted):**\n- Returns single JSON response with complete completion\n- Standard OpenAI ChatCompletionResponse format\n\n**Storage Options:**\n- ` + "`" + `store=true` + "`" + `: Persist the latest input message and assistant response to the active conversation\n- ` + "`" + `store_reasoning=true` + "`" + `: Additionally persist reasoning content provided by the model (as a ` + "`" + `reasoning` + "`" + ` item when ` + "`" + `reasoning.summary` + "`" + ` is set)\n- ` + "`" + `reasoning.effort` + "`" + ` (low, medium, high) is mapped to the provider's reasoning knob; when ` + "`" + `reasoning` + "`" + ` is set without ` + "`" + `summary` + "`" + `, reasoning is not streamed, returned or stored\n- When ` + "`" + `store` + "`" + ` is omitted or false, the conversation remains read-only\n\n**Features:**\n- Supports all OpenAI ChatCompletionRequest parameters\n- Optional conversation context for conversation persistence\n- User authentication required\n- Direct inference model integration",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "text/event-stream"
+                ],
                 "tags": [
-                    "Shares API"
+                    "Chat Completions API"
                 ],
-                "summary": "Revoke a share by share ID",
+                "summary": "Create a chat completion",
                 "parameters": [
+                    {
+                        "description": "Chat completion request with streaming options and optional conversation",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/chatrequests.ChatCompletionRequest"
+                        }
+                    },
                     {
                         "type": "string",
-                        "description": "Share public ID",
-                        "name": "share_id",
-                        "in": "path",
-                        "required": true
+                        "description": "Token of a solved CAPTCHA, sent by guests after a captcha_required error",
+                        "name": "X-Captcha-Token",
+                        "in": "header"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Share revoked successfully",
+                        "description": "Successful streaming response (when stream=true) - SSE format with data: {json} events",
                         "schema": {
-                            "$ref": "#/definitions/shareresponses.ShareDeletedResponse"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload, empty messages, or context length exceeded (type context_length_exceeded)",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - missing or invalid authentication",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "403": {
-                        "description": "Forbidden",
+                        "description": "Model not available to guests, or the guest must solve a CAPTCHA and resend with X-Captcha-Token (type captcha_required)",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "Share not found",
+                    "413": {
+                        "description": "Request body, message count, or an inline image exceeds the configured limits",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Blocked by the model provider's content filter (type content_filtered)",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "429": {
+                        "description": "Model provider rate limit, the model is at its concurrency cap, or a guest daily message limit was reached (type rate_limited); honour the Retry-After header",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "502": {
+                        "description": "Model provider failed; the message carries the provider error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "504": {
+                        "description": "Model provider timed out (type provider_timeout)",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -4910,216 +5318,208 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/usage/me": {
+        "/v1/chat/completions/ws": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns token usage summary for the authenticated user within a date range",
-                "produces": [
-                    "application/json"
-                ],
+                "description": "Upgrades to a WebSocket on which chat completions are requested and streamed, for clients on networks where a dropped SSE connection is costly. The connection stays open across completions, one at a time.\n\n**Client messages** (JSON text):\n- A ` + "`" + `ChatCompletionRequest` + "`" + `, as sent to ` + "`" + `POST /v1/chat/completions` + "`" + `, starts a completion; ` + "`" + `stream` + "`" + ` is implied\n- ` + "`" + `{\"type\": \"cancel\"}` + "`" + ` stops the running completion; add ` + "`" + `audio_end_ms` + "`" + ` when the user talked over its audio, as with the cancel endpoint\n\n**Server messages** (JSON text):\n- ` + "`" + `{\"type\": \"stream.started\"}` + "`" + `, with ` + "`" + `stream_id` + "`" + ` and ` + "`" + `item_id` + "`" + ` for conversation-bound completions\n- Completion chunks, exactly as the ` + "`" + `data:` + "`" + ` events of the SSE stream\n- ` + "`" + `{\"type\": \"stream.done\"}` + "`" + ` ends a completion, with ` + "`" + `cancelled: true` + "`" + ` when it was stopped\n- ` + "`" + `{\"type\": \"error\", \"status\": 429, \"retry_after\": 5, \"error\": {...}}` + "`" + ` ends a completion that failed, or rejects a message; ` + "`" + `status` + "`" + ` and ` + "`" + `error` + "`" + ` are those of the HTTP endpoint\n\nClosing the connection aborts the running completion like a dropped SSE stream; what was generated is stored for conversation-bound completions. The server pings every 30s and drops connections silent for 75s. Messages are capped by CHAT_MAX_REQUEST_BYTES; a larger one closes the connection with code 1009. While maintenance mode is on, requests on open connections are answered with a 503 ` + "`" + `maintenance` + "`" + ` error.",
                 "tags": [
-                    "Usage"
-                ],
-                "summary": "Get current user's token usage",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD), defaults to today",
-                        "name": "end_date",
-                        "in": "query"
-                    }
+                    "Chat Completions API"
                 ],
+                "summary": "Stream chat completions over a WebSocket",
                 "responses": {
-                    "200": {
-                        "description": "OK",
+                    "101": {
+                        "description": "Switching Protocols",
                         "schema": {
-                            "$ref": "#/definitions/tokenusage.UsageResponse"
+                            "type": "string"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Not a WebSocket handshake",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
-                    "500": {
-                        "description": "Internal Server Error",
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Maintenance mode is on",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/usage/me/daily": {
-            "get": {
+        "/v1/chat/completions/{stream_id}/cancel": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns daily aggregated token usage for the authenticated user",
+                "description": "Stops a conversation-bound streaming completion started by the authenticated user, so UIs can implement a server-side Stop button. The stream ID is returned in the ` + "`" + `X-Stream-ID` + "`" + ` response header of ` + "`" + `POST /v1/chat/completions` + "`" + ` when ` + "`" + `stream=true` + "`" + ` and a conversation is set.\n\nThe upstream provider request is aborted, the stream ends with ` + "`" + `data: [DONE]` + "`" + `, and the conversation's ` + "`" + `mcp_call` + "`" + ` items that are still ` + "`" + `in_progress` + "`" + ` are marked ` + "`" + `cancelled` + "`" + `. Tokens generated before the stop are still counted in usage.\n\nWhen the user talks over a spoken reply (` + "`" + `modalities: [\"audio\"]` + "`" + `), send ` + "`" + `audio_end_ms` + "`" + ` with how much audio was played: speech synthesis stops and the reply is stored truncated to what the user heard, as with ` + "`" + `POST /v1/conversations/{conv_public_id}/items/{item_id}/truncate` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Usage"
+                    "Chat Completions API"
                 ],
-                "summary": "Get current user's daily token usage",
+                "summary": "Stop a streaming chat completion",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
-                        "name": "start_date",
-                        "in": "query"
+                        "description": "Stream ID from the X-Stream-ID response header",
+                        "name": "stream_id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD), defaults to today",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Optional interruption point of the reply's audio",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/chatrequests.CancelStreamRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Stream stopped",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/tokenusage.DailyAggregate"
-                            }
+                            "$ref": "#/definitions/chatresponses.StreamCancelResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - missing or invalid authentication",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Stream not found, not owned by the user, or already finished",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/usage/projects/{id}": {
-            "get": {
+        "/v1/compare": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns token usage summary for a specific project",
-                "produces": [
+                "description": "Runs the same messages against 2 to 4 models concurrently (arena mode). The index of a model in ` + "`" + `models` + "`" + ` is its slot.\n\nWith ` + "`" + `stream=true` + "`" + ` the response is Server-Sent Events with one event channel per model: an initial ` + "`" + `comparison` + "`" + ` event with the comparison ID, ` + "`" + `model_<slot>` + "`" + ` events carrying the model's OpenAI-style ` + "`" + `chat.completion.chunk` + "`" + ` payloads, and a ` + "`" + `model_<slot>.done` + "`" + ` event with the model's latency, usage or error. The stream ends with ` + "`" + `data: [DONE]` + "`" + ` once every model has finished.\n\nA failing model does not stop the others; its error is reported in its own result. Use the returned ID to vote with ` + "`" + `POST /v1/compare/{comparison_id}/vote` + "`" + `.",
+                "consumes": [
                     "application/json"
                 ],
+                "produces": [
+                    "application/json",
+                    "text/event-stream"
+                ],
                 "tags": [
-                    "Usage"
+                    "Compare API"
                 ],
-                "summary": "Get project's token usage",
+                "summary": "Compare models side by side",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Project ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
-                        "name": "start_date",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "End date (YYYY-MM-DD), defaults to today",
-                        "name": "end_date",
-                        "in": "query"
+                        "description": "Models and messages to compare",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/comparerequests.CompareRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "SSE stream with one event channel per model (when stream=true)",
                         "schema": {
-                            "$ref": "#/definitions/tokenusage.UsageResponse"
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or model count outside 2-4",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "Not Found",
+                        "description": "Model not found",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request body, message count or inline image exceeds the configured limit",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
         },
-        "/v1/users/me/settings": {
+        "/v1/compare/{comparison_id}": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve current user's settings including memory preferences",
+                "description": "Returns a comparison of the authenticated user with its models and vote",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Settings"
+                    "Compare API"
+                ],
+                "summary": "Get a comparison",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Comparison ID",
+                        "name": "comparison_id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get user settings",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usersettingshandler.UserSettingsResponse"
+                            "$ref": "#/definitions/comparehandler.ComparisonResponse"
                         }
                     },
                     "401": {
@@ -5128,6 +5528,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Comparison not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5135,14 +5541,16 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "patch": {
+            }
+        },
+        "/v1/compare/{comparison_id}/vote": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current user's settings (partial update supported)",
+                "description": "Records which model gave the best answer in a finished comparison of the authenticated user. Voting again replaces the previous vote. Votes feed the internal model leaderboard.",
                 "consumes": [
                     "application/json"
                 ],
@@ -5150,17 +5558,24 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Settings"
+                    "Compare API"
                 ],
-                "summary": "Update user settings",
+                "summary": "Vote on a comparison",
                 "parameters": [
                     {
-                        "description": "Settings to update",
-                        "name": "settings",
+                        "type": "string",
+                        "description": "Comparison ID",
+                        "name": "comparison_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Vote",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usersettings.UpdateRequest"
+                            "$ref": "#/definitions/comparerequests.VoteRequest"
                         }
                     }
                 ],
@@ -5168,11 +5583,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/usersettingshandler.UserSettingsResponse"
+                            "$ref": "#/definitions/comparehandler.ComparisonResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid outcome or winner_slot",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
@@ -5183,6 +5598,18 @@ const docTemplate = `{
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
+                    "404": {
+                        "description": "Comparison not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Comparison is still running",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -5192,49 +5619,87 @@ const docTemplate = `{
                 }
             }
         },
-        "/v1/users/me/settings/preferences": {
+        "/v1/conversations": {
             "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve current user's preferences only",
+                "description": "List conversations for the authenticated user with optional referrer filtering.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "User Settings"
+                    "Conversations API"
+                ],
+                "summary": "List conversations",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Referrer filter",
+                        "name": "referrer",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of conversations to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor of the previous page",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc or desc)",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Set to 'all' to list conversations across the workspace (requires elevated permissions)",
+                        "name": "scope",
+                        "in": "query"
+                    }
                 ],
-                "summary": "Get user preferences",
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successfully retrieved conversations",
                         "schema": {
-                            "$ref": "#/definitions/usersettingshandler.PreferencesResponse"
+                            "$ref": "#/definitions/conversationresponses.ConversationListResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request parameters",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - missing or invalid authentication",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             },
-            "patch": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Update current user's preferences only",
+                "description": "Create a new conversation to store and retrieve conversation state across Response API calls\n\n**Features:**\n- Create conversation with optional metadata (max 16 key-value pairs)\n- Add up to 20 initial items to the conversation\n- Returns conversation ID with ` + "`" + `conv_` + "`" + ` prefix\n- Supports OpenAI Conversations API format\n- Start from a prompt library starter with ` + "`" + `prompt_library_id` + "`" + ` and ` + "`" + `prompt_variables` + "`" + `; its rendered messages come first and count toward the 20 items\n\n**Metadata Constraints:**\n- Maximum 16 key-value pairs\n- Keys: max 64 characters\n- Values: max 512 characters",
                 "consumes": [
                     "application/json"
                 ],
@@ -5242,2046 +5707,8222 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "User Settings"
+                    "Conversations API"
                 ],
-                "summary": "Update user preferences",
+                "summary": "Create a conversation",
                 "parameters": [
                     {
-                        "description": "Preferences to update",
-                        "name": "preferences",
+                        "description": "Create conversation request with optional items and metadata",
+                        "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/usersettingshandler.UpdatePreferencesRequest"
+                            "$ref": "#/definitions/conversationrequests.CreateConversationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "OK",
+                        "description": "Successfully created conversation",
                         "schema": {
-                            "$ref": "#/definitions/usersettingshandler.PreferencesResponse"
+                            "$ref": "#/definitions/conversationresponses.ConversationResponse"
                         }
                     },
                     "400": {
-                        "description": "Bad Request",
+                        "description": "Invalid request - validation failed or too many items",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized",
+                        "description": "Unauthorized - missing or invalid authentication",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     },
                     "500": {
-                        "description": "Internal Server Error",
+                        "description": "Internal server error - conversation creation failed",
                         "schema": {
                             "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/v1/version": {
-            "get": {
-                "description": "Returns the current build version of the API server and environment reload timestamp.",
-                "produces": [
-                    "application/json"
-                ],
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Permanently delete all conversations for the authenticated user\n\n**WARNING: This is a destructive operation that cannot be undone.**\n\n**Features:**\n- Deletes ALL conversations owned by the authenticated user\n- Automatically cascades to delete all associated items and shares\n- Returns the count of deleted conversations\n- Requires valid authentication to ensure ownership verification\n\n**Security:**\n- Only deletes conversations owned by the authenticated user\n- Cannot delete other users' conversations\n- Authentication is mandatory",
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
-                    "Server API"
+                    "Conversations API"
                 ],
-                "summary": "Get API build version",
+                "summary": "Delete all conversations",
                 "responses": {
                     "200": {
-                        "description": "Version information including version number and environment reload timestamp",
+                        "description": "Successfully deleted all conversations",
                         "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
+                            "$ref": "#/definitions/conversationresponses.BulkConversationsDeletedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - deletion failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a conversation by ID with ownership verification\n\n**Features:**\n- Retrieves conversation metadata including creation timestamp\n- Automatic ownership verification (user can only access their own conversations)\n- Returns OpenAI-compatible conversation object\n\n**Response Fields:**\n- ` + "`" + `id` + "`" + `: Conversation ID with ` + "`" + `conv_` + "`" + ` prefix\n- ` + "`" + `object` + "`" + `: Always \"conversation\"\n- ` + "`" + `created_at` + "`" + `: Unix timestamp\n- ` + "`" + `metadata` + "`" + `: User-defined key-value pairs",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Get a conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; an unchanged conversation returns 304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved conversation",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Conversation unchanged since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid conversation ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a conversation's metadata while preserving existing items\n\n**Features:**\n- Update metadata key-value pairs\n- Replaces entire metadata object (not merged)\n- Items remain unchanged\n- Automatic ownership verification\n- Optimistic concurrency: send the ` + "`" + `version` + "`" + ` from the last read; a stale version returns 409\n\n**Metadata Constraints:**\n- Maximum 16 key-value pairs\n- Keys: max 64 characters\n- Values: max 512 characters",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Update a conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update conversation request with new metadata",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationrequests.UpdateConversationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated conversation",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request - validation failed or invalid metadata",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict - conversation was modified since the given version",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - update failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a conversation (soft delete). Items in the conversation will not be deleted but will be inaccessible.\n\n**Features:**\n- Soft delete (conversation marked as deleted, not physically removed)\n- Items remain in database but become inaccessible\n- Automatic ownership verification\n- Returns deletion confirmation with conversation ID\n\n**Response:**\n- ` + "`" + `id` + "`" + `: Deleted conversation ID\n- ` + "`" + `object` + "`" + `: Always \"conversation.deleted\"\n- ` + "`" + `deleted` + "`" + `: Always true",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Delete a conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted conversation",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ConversationDeletedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid conversation ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - deletion failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "authhandler.AccessTokenResponse": {
+        },
+        "/v1/conversations/{conv_public_id}/attachments": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the images and files referenced across a conversation branch, oldest first, so clients can show the files in a chat without walking every item. An upload sent again in later turns is listed once, for the item it first appeared in.\n\nAttachments stored in media-api carry their size, content type and a download URL; presigned URLs report when they expire in ` + "`" + `url_expires_at` + "`" + `. If media-api is unavailable the stored references are returned as-is. Images sent as data URLs are listed as ` + "`" + `inline` + "`" + ` with their size but without a URL.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "List conversation attachments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name (defaults to the active branch)",
+                        "name": "branch",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved attachments",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.AttachmentListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/branches": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all branches for a conversation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversation Branches"
+                ],
+                "summary": "List branches",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved branches",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.ListBranchesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new branch in a conversation, optionally forking from an existing item",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversation Branches"
+                ],
+                "summary": "Create a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Create branch request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.CreateBranchRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Successfully created branch",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.BranchResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/branches/{branch_name}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get details of a specific branch",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversation Branches"
+                ],
+                "summary": "Get branch details",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name",
+                        "name": "branch_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved branch",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.BranchResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Branch not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete a branch from a conversation (cannot delete MAIN or active branch)",
+                "tags": [
+                    "Conversation Branches"
+                ],
+                "summary": "Delete a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name",
+                        "name": "branch_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Branch deleted successfully"
+                    },
+                    "400": {
+                        "description": "Cannot delete MAIN or active branch",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Branch not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/branches/{branch_name}/activate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Set a branch as the active branch for a conversation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversation Branches"
+                ],
+                "summary": "Activate a branch",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Branch name",
+                        "name": "branch_name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Branch activated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.ActivateBranchResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Branch not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/instruction": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get the project instruction a conversation's completions use. A project conversation is pinned to the project's instruction on its first completion and keeps that version when the project is edited; stale reports a newer project version.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Get conversation instruction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved instruction",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.InstructionResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/instruction/refresh": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Pin a project conversation to the project's current instruction, so its next completions pick up edits made since it was pinned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Refresh conversation instruction",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Instruction refreshed",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.InstructionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Conversation does not belong to a project",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all items in a conversation with cursor-based pagination support\n\n**Features:**\n- Cursor-based pagination using item IDs\n- Configurable page size (1-100 items, default 20)\n- Sort order control (ascending or descending)\n- Optional include parameter for additional fields\n- Returns paginated list with navigation cursors\n\n**Pagination:**\n- Use ` + "`" + `after` + "`" + ` cursor from previous response for next page\n- ` + "`" + `has_more` + "`" + ` indicates if more items are available\n- ` + "`" + `first_id` + "`" + ` and ` + "`" + `last_id` + "`" + ` provide cursor references\n\n**Query Parameters:**\n- ` + "`" + `limit` + "`" + `: Number of items (1-100, default 20)\n- ` + "`" + `order` + "`" + `: Sort order (\"asc\" or \"desc\", default \"desc\")\n- ` + "`" + `after` + "`" + `: Item ID cursor for pagination\n- ` + "`" + `include` + "`" + `: Additional fields to include (optional)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "List conversation items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque cursor from next_cursor, or an item ID (OpenAI compatible)",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "maximum": 100,
+                        "minimum": 1,
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Number of items to return (1-100)",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Sort order: asc or desc",
+                        "name": "order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Additional fields to include in response",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; an unchanged page returns 304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved items list",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ItemListResponse"
+                        }
+                    },
+                    "304": {
+                        "description": "Items unchanged since the ETag in If-None-Match"
+                    },
+                    "400": {
+                        "description": "Invalid request - invalid parameters or conversation ID",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - listing failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Add items to a conversation. You may add up to 20 items at a time.\n\n**Features:**\n- Bulk item creation (max 20 items per request)\n- Automatic item ID generation with ` + "`" + `msg_` + "`" + ` prefix\n- Items added to conversation's active branch (default: MAIN)\n- Returns list of created items with generated IDs\n\n**Item Types:**\n- ` + "`" + `message` + "`" + `: User or assistant messages\n- ` + "`" + `tool_call` + "`" + `: Tool/function call items\n- ` + "`" + `tool_response` + "`" + `: Tool/function response items\n- Other OpenAI-compatible item types\n\n**Constraints:**\n- Maximum 20 items per request\n- Each item must have valid type and content\n- Items are immutable after creation",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Create conversation items",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Additional fields to include in response",
+                        "name": "include",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Create items request with array of items",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationrequests.CreateItemsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully created items",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ConversationItemCreatedResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request - too many items, invalid format, or validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - item creation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items/by-call-id/{call_id}": {
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update a conversation item's status and output using its call_id.\nThis endpoint is primarily used by MCP tools to report tool execution results.\n\n**Features:**\n- Find item by call_id (e.g., call_xxx) instead of item_id\n- Update status to completed, failed, or cancelled\n- Store tool output or error message\n- Store a structured error_detail (code, retryable, provider) for failed calls; error defaults to its message\n- Automatic timestamp for completion\n\n**Use Cases:**\n- MCP tool reports successful execution with output\n- MCP tool reports failure with error message\n- Tool call status tracking and observability",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Update item by call ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Call ID of the tool call item (format: call_xxxxx)",
+                        "name": "call_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update request with status and optional output/error",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationrequests.UpdateItemByCallIDRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully updated item",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request - validation failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation or item not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items/{item_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve a single item from a conversation by item ID\n\n**Features:**\n- Retrieve specific item by ID\n- Returns complete item with all content\n- Automatic ownership verification via conversation\n- Optional include parameter for additional fields\n\n**Response Fields:**\n- ` + "`" + `id` + "`" + `: Item ID with ` + "`" + `msg_` + "`" + ` prefix\n- ` + "`" + `type` + "`" + `: Item type (message, tool_call, etc.)\n- ` + "`" + `role` + "`" + `: Role for message items (user, assistant)\n- ` + "`" + `content` + "`" + `: Item content array\n- ` + "`" + `status` + "`" + `: Item status (completed, incomplete, etc.)\n- ` + "`" + `created_at` + "`" + `: Unix timestamp",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Get a conversation item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Additional fields to include in response",
+                        "name": "include",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved item",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid conversation ID or item ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation or item not found, or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Delete an item from a conversation by creating a new MAIN branch without it.\nThe old MAIN branch is preserved as a backup.\n\n**Features:**\n- Creates a new branch without the deleted item\n- New branch becomes MAIN, old MAIN becomes backup\n- Automatic ownership verification\n- Preserves conversation history in backup branch\n\n**Important:**\n- The old MAIN branch is renamed to MAIN_YYYYMMDDHHMMSS\n- You can switch back to the backup branch if needed\n- This is a non-destructive delete operation\n\n**Response:**\nReturns branch information including the backup branch name",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Delete a conversation item",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID to delete (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully deleted item, returns branch info",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.DeleteItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid conversation ID or item ID format",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation or item not found, or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error - deletion failed",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Overwrite the content of an assistant message or the output of a tool item without branching.\nUsed for moderation and for correcting ingestion mistakes.\n\n**Features:**\n- Replaces ` + "`" + `content` + "`" + ` and/or ` + "`" + `output` + "`" + ` on the existing item\n- The replaced values are preserved in the item's edit history\n- Edited items are returned with ` + "`" + `edited: true` + "`" + ` and ` + "`" + `edited_at` + "`" + `\n\n**Restrictions:**\n- Only assistant messages and tool output items can be edited\n- ` + "`" + `output` + "`" + ` is only accepted for tool output items\n- To change a user message, use the branching edit endpoint instead",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Edit a conversation item in place",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Replacement content or output, with an optional reason",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationrequests.EditItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully edited item",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or item type cannot be edited",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation or item not found, or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items/{item_id}/edit": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Edit a user message and create a new branch with the edited content",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Message Actions"
+                ],
+                "summary": "Edit a message",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Message item ID (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Edit message request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.EditMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Message edited successfully",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.EditMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or not a user message",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items/{item_id}/edits": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List the previous versions of a conversation item that was edited in place, oldest first.\nThe first entry holds the item's original content.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "List item edit history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved edit history",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ItemEditListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation or item not found, or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items/{item_id}/regenerate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Regenerate an assistant response by creating a new branch",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Message Actions"
+                ],
+                "summary": "Regenerate a response",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Assistant message item ID (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Regenerate options",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.RegenerateMessageRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Regeneration initiated",
+                        "schema": {
+                            "$ref": "#/definitions/conversationhandler.RegenerateMessageResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or not an assistant message",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Message not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/items/{item_id}/truncate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Cut a spoken assistant message back to what the user heard before interrupting it, like the OpenAI Realtime ` + "`" + `conversation.item.truncate` + "`" + ` event.\nClients that detect the user talking over the reply (voice activity detection) stop playback, stop the stream through ` + "`" + `POST /v1/chat/completions/{stream_id}/cancel` + "`" + `, and send how much audio was played.\n\n**Behavior:**\n- The audio transcript keeps the sentences played in full and the words heard of the interrupted one\n- The message text is cut at the same point, so later turns only see what was said\n- The truncation replaces the content without adding to the item's edit history\n\n**Restrictions:**\n- Only assistant messages streamed with audio output can be truncated\n- ` + "`" + `audio_end_ms` + "`" + ` must not exceed the duration of the audio",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Truncate an assistant message to the audio that was played",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Item ID (format: msg_xxxxx)",
+                        "name": "item_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Audio content part and played duration",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/conversationrequests.TruncateItemRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully truncated item",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.ItemResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request, or the item has no audio to truncate",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation or item not found, or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/rehydrate": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Restore the items of a conversation from cold storage. Items of conversations idle for COLD_STORAGE_AFTER_MONTHS are moved to cold storage and listed as stubs with ` + "`" + `cold_stored` + "`" + ` set and no content; writing to the conversation rehydrates it automatically, and this endpoint does so ahead of a read.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Conversations API"
+                ],
+                "summary": "Rehydrate conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation ID (format: conv_xxxxx)",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Items restored; items_restored is 0 if none were in cold storage",
+                        "schema": {
+                            "$ref": "#/definitions/conversationresponses.RehydrationResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found or access denied",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/share": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a public share link for a conversation or a single message",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares API"
+                ],
+                "summary": "Create a share for a conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation public ID",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Share creation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sharerequests.CreateShareRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Share created successfully",
+                        "schema": {
+                            "$ref": "#/definitions/shareresponses.ShareResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Snapshot too large",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists all shares (active and revoked) for a conversation",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares API"
+                ],
+                "summary": "List shares for a conversation",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation public ID",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of shares",
+                        "schema": {
+                            "$ref": "#/definitions/shareresponses.ShareListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Conversation not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/conversations/{conv_public_id}/shares/{share_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes an active share, making it inaccessible",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares API"
+                ],
+                "summary": "Revoke a share",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Conversation public ID",
+                        "name": "conv_public_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Share public ID",
+                        "name": "share_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/shareresponses.ShareDeletedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/healthz": {
+            "get": {
+                "description": "Returns the health status of the API server. Used by orchestrators and monitoring systems.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Server API"
+                ],
+                "summary": "Health check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Health status OK",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/images/edits": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates an edited image given an original image and a prompt.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images API"
+                ],
+                "summary": "Create image edit",
+                "parameters": [
+                    {
+                        "description": "Image edit request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/image.ImageEditRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful image edit response",
+                        "schema": {
+                            "$ref": "#/definitions/image.ImageGenerationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No active image provider configured",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or image provider error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/images/generations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Generates images from a text prompt using the configured image provider.\nThis endpoint is compatible with the OpenAI Images API format.\n\n**Response Formats:**\n- url: Returns presigned URLs to download images (default, recommended)\n- b64_json: Returns base64-encoded image data\n\n**Size Options:**\n- 1024x1024 (default)\n- 512x512\n- 1792x1024 (landscape)\n- 1024x1792 (portrait)\n",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images API"
+                ],
+                "summary": "Create image generation",
+                "parameters": [
+                    {
+                        "description": "Image generation request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/image.ImageGenerationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successful image generation response",
+                        "schema": {
+                            "$ref": "#/definitions/image.ImageGenerationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request payload or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid authentication",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No active image provider configured",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error or image provider error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "Feature not implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/images/variations": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a variation of a given image.\nNOTE: This endpoint is not yet implemented and will return 501 Not Implemented.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Images API"
+                ],
+                "summary": "Create image variation (Not Implemented)",
+                "responses": {
+                    "501": {
+                        "description": "Not implemented",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/mcp-credentials": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the third-party accounts the authenticated user has connected for MCP tools. Tokens are never returned.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCP Credentials API"
+                ],
+                "summary": "List MCP credentials",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcpcredentialhandler.CredentialListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/mcp-credentials/{provider}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Stores the user's OAuth access token for a provider, replacing any existing one. First-party MCP tools (for example the GitHub tools) use it to act on the user's behalf. Supported providers: ` + "`" + `github` + "`" + `, ` + "`" + `google` + "`" + `, ` + "`" + `microsoft` + "`" + ` and ` + "`" + `caldav` + "`" + `. The ` + "`" + `google` + "`" + `, ` + "`" + `microsoft` + "`" + ` and ` + "`" + `caldav` + "`" + ` connectors are read-only and require explicit consent scopes (` + "`" + `calendar:read` + "`" + `, ` + "`" + `mail:read` + "`" + `); ` + "`" + `caldav` + "`" + ` also takes the calendar collection URL as ` + "`" + `endpoint` + "`" + ` and an app password as ` + "`" + `token` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCP Credentials API"
+                ],
+                "summary": "Connect an MCP credential",
+                "parameters": [
+                    {
+                        "enum": [
+                            "github",
+                            "google",
+                            "microsoft",
+                            "caldav"
+                        ],
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Token to store",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/mcpcredentialrequests.SaveCredentialRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcpcredentialhandler.CredentialResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes the user's stored token for a provider. MCP tools that need it fail until it is connected again.",
+                "tags": [
+                    "MCP Credentials API"
+                ],
+                "summary": "Disconnect an MCP credential",
+                "parameters": [
+                    {
+                        "enum": [
+                            "github",
+                            "google",
+                            "microsoft",
+                            "caldav"
+                        ],
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/mcp-credentials/{provider}/token": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the user's decrypted token for a provider. Called by mcp-tools with the user's forwarded bearer token when a tool needs to act on the user's behalf.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCP Credentials API"
+                ],
+                "summary": "Get an MCP credential token",
+                "parameters": [
+                    {
+                        "enum": [
+                            "github",
+                            "google",
+                            "microsoft",
+                            "caldav"
+                        ],
+                        "type": "string",
+                        "description": "Provider",
+                        "name": "provider",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcpcredentialhandler.CredentialTokenResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Provider not connected or token expired",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/mcp-tools": {
+            "get": {
+                "description": "Get all active MCP tools (public endpoint for mcp-tools service)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCP Tools"
+                ],
+                "summary": "List all active MCP tools",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcptoolhandler.ActiveToolsResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/mcp-tools/{key}": {
+            "get": {
+                "description": "Get an MCP tool by its unique tool key (public endpoint for mcp-tools service)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "MCP Tools"
+                ],
+                "summary": "Get an MCP tool by key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Tool Key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/models": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of available models that can be used for chat completions or other tasks. Returns either simple model list or detailed list with provider metadata based on X-PROVIDER-DATA header.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Chat Completions API"
+                ],
+                "summary": "List available models",
+                "parameters": [
+                    {
+                        "enum": [
+                            "true",
+                            "false"
+                        ],
+                        "type": "string",
+                        "description": "Set to 'true' to include provider metadata in response",
+                        "name": "X-PROVIDER-DATA",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETag from a previous response; an unchanged list returns 304",
+                        "name": "If-None-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of models with provider metadata (when X-PROVIDER-DATA=true)",
+                        "schema": {
+                            "$ref": "#/definitions/modelresponses.ModelWithProviderResponseList"
+                        }
+                    },
+                    "304": {
+                        "description": "Models unchanged since the ETag in If-None-Match"
+                    },
+                    "404": {
+                        "description": "Models or providers not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve models",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/models/catalogs/{model_public_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves detailed information about a model catalog entry by its public ID (supports IDs with slashes like openrouter/nova-lite-v1)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Model API"
+                ],
+                "summary": "Get a model catalog entry",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Model Catalog Public ID (can contain slashes)",
+                        "name": "model_public_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Model catalog details",
+                        "schema": {
+                            "$ref": "#/definitions/modelresponses.ModelCatalogResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Model catalog not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/models/providers": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieves a list of available model providers that can be used for inference.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Model API"
+                ],
+                "summary": "List model providers",
+                "responses": {
+                    "200": {
+                        "description": "List of providers",
+                        "schema": {
+                            "$ref": "#/definitions/modelresponses.ProviderResponseList"
+                        }
+                    },
+                    "500": {
+                        "description": "Failed to retrieve providers",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/personas": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the authenticated user's personas, ordered by name.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personas API"
+                ],
+                "summary": "List personas",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/personahandler.PersonaListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a custom assistant with its own system instructions. Reference it from ` + "`" + `POST /v1/chat/completions` + "`" + ` with ` + "`" + `persona_id` + "`" + ` (or the ` + "`" + `X-Prompt-Persona` + "`" + ` header) to apply its instructions above the user's profile settings, use ` + "`" + `default_model` + "`" + ` when the request leaves ` + "`" + `model` + "`" + ` empty and keep only its ` + "`" + `enabled_tools` + "`" + `.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personas API"
+                ],
+                "summary": "Create a persona",
+                "parameters": [
+                    {
+                        "description": "Persona definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/personarequests.CreatePersonaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/personahandler.PersonaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid persona or per-user limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/personas/{persona_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns one of the authenticated user's personas.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personas API"
+                ],
+                "summary": "Get a persona",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Persona ID",
+                        "name": "persona_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/personahandler.PersonaResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates one of the authenticated user's personas. Omitted fields are left unchanged.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Personas API"
+                ],
+                "summary": "Update a persona",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Persona ID",
+                        "name": "persona_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/personarequests.UpdatePersonaRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/personahandler.PersonaResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes one of the authenticated user's personas. Chat requests that still reference it fail with 404.",
+                "tags": [
+                    "Personas API"
+                ],
+                "summary": "Delete a persona",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Persona ID",
+                        "name": "persona_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List all projects for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects API"
+                ],
+                "summary": "List projects",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Maximum number of projects to return",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Return projects after the given numeric ID",
+                        "name": "after",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort order (asc or desc)",
+                        "name": "order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/projectres.ProjectListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Create a new project for grouping conversations",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects API"
+                ],
+                "summary": "Create project",
+                "parameters": [
+                    {
+                        "description": "Create project request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/projectreq.CreateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/projectres.ProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Get a single project by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects API"
+                ],
+                "summary": "Get project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/projectres.ProjectResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Soft-delete a project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects API"
+                ],
+                "summary": "Delete project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/projectres.ProjectDeletedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update project name, instruction, or archived status",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects API"
+                ],
+                "summary": "Update project",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Update request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/projectreq.UpdateProjectRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/projectres.ProjectResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/projects/{project_id}/instructions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "List every version of a project's instruction, newest first. A new version is recorded whenever an update changes the instruction.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Projects API"
+                ],
+                "summary": "List project instruction versions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "project_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/projectres.InstructionVersionListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompt-library": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the active global starters together with the authenticated user's own starters. Global starters come first, then by category and title.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompt Library API"
+                ],
+                "summary": "List conversation starters",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only starters in this category",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search in title and description",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Limit",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 0,
+                        "description": "Offset",
+                        "name": "offset",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryhandler.StarterListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Creates a starter visible only to the authenticated user. Message content uses Go template syntax (` + "`" + `{{.name}}` + "`" + `) and may only reference declared variables.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompt Library API"
+                ],
+                "summary": "Create a personal conversation starter",
+                "parameters": [
+                    {
+                        "description": "Starter definition",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryrequests.CreateStarterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid starter or per-user limit reached",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompt-library/categories": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists the categories of the starters visible to the authenticated user with the number of starters in each.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompt Library API"
+                ],
+                "summary": "List starter categories",
+                "parameters": [],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryhandler.CategoryListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompt-library/{starter_id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns an active global starter or one of the authenticated user's own starters.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompt Library API"
+                ],
+                "summary": "Get a conversation starter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Starter ID",
+                        "name": "starter_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates one of the authenticated user's own starters. Global starters can only be changed by admins.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompt Library API"
+                ],
+                "summary": "Update a personal conversation starter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Starter ID",
+                        "name": "starter_id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to update",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryrequests.UpdateStarterRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Global starter",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Deletes one of the authenticated user's own starters.",
+                "tags": [
+                    "Prompt Library API"
+                ],
+                "summary": "Delete a personal conversation starter",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Starter ID",
+                        "name": "starter_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Global starter",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/prompt-templates/{key}": {
+            "get": {
+                "description": "Get a prompt template by its unique template key (public endpoint)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Prompt Templates"
+                ],
+                "summary": "Get a prompt template by key",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Template Key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/prompttemplatehandler.PromptTemplateResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/public/shares/{slug}": {
+            "get": {
+                "description": "Retrieves a publicly shared conversation or message by its slug",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Public Shares API"
+                ],
+                "summary": "Get a public share by slug",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Public share content",
+                        "schema": {
+                            "$ref": "#/definitions/shareresponses.PublicShareResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found or revoked",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Share has been revoked",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "head": {
+                "description": "Checks if a share exists and is accessible (for preloading)",
+                "tags": [
+                    "Public Shares API"
+                ],
+                "summary": "Check if a public share exists",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share slug",
+                        "name": "slug",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share exists and is accessible"
+                    },
+                    "404": {
+                        "description": "Share not found"
+                    },
+                    "410": {
+                        "description": "Share has been revoked"
+                    }
+                }
+            }
+        },
+        "/v1/readyz": {
+            "get": {
+                "description": "Returns the readiness status of the API server. Indicates if the service is ready to accept traffic.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Server API"
+                ],
+                "summary": "Readiness check endpoint",
+                "responses": {
+                    "200": {
+                        "description": "Readiness status ready",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shares": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Lists all shares (active and revoked) for the authenticated user across all conversations",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares API"
+                ],
+                "summary": "List all shares for the authenticated user",
+                "parameters": [
+                    {
+                        "type": "boolean",
+                        "default": true,
+                        "description": "Include revoked shares",
+                        "name": "include_revoked",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of shares",
+                        "schema": {
+                            "$ref": "#/definitions/shareresponses.ShareListResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/shares/{share_id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Revokes an active share by its ID, making it inaccessible",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Shares API"
+                ],
+                "summary": "Revoke a share by share ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Share public ID",
+                        "name": "share_id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Share revoked successfully",
+                        "schema": {
+                            "$ref": "#/definitions/shareresponses.ShareDeletedResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Share not found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/sync": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated user's conversation, item and settings changes since a checkpoint, oldest first, so clients can keep a local cache without polling each conversation.\n\nWithout since, no changes are returned and next_cursor marks the current end of the log: take it before downloading the initial state, then pass it as since. Keep calling while has_more is true.\n\nEach entity appears once per page with its latest operation and current state. Deletions may be delivered more than once; apply them idempotently.\n\nA checkpoint older than SYNC_RETENTION returns 410 Gone: download the full state again and restart without since.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Sync API"
+                ],
+                "summary": "Sync changes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Checkpoint from a previous next_cursor",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 100,
+                        "description": "Maximum changes per page (1-500)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/synchandler.SyncResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid checkpoint",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "410": {
+                        "description": "Checkpoint expired",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/usage/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns token usage summary for the authenticated user within a date range",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usage"
+                ],
+                "summary": "Get current user's token usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokenusage.UsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/usage/me/daily": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns daily aggregated token usage for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usage"
+                ],
+                "summary": "Get current user's daily token usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/tokenusage.DailyAggregate"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/usage/projects/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns token usage summary for a specific project",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Usage"
+                ],
+                "summary": "Get project's token usage",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Project ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), defaults to 30 days ago",
+                        "name": "start_date",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date (YYYY-MM-DD), defaults to today",
+                        "name": "end_date",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/tokenusage.UsageResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/settings": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve current user's settings including memory preferences",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Settings"
+                ],
+                "summary": "Get user settings",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersettingshandler.UserSettingsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current user's settings (partial update supported)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Settings"
+                ],
+                "summary": "Update user settings",
+                "parameters": [
+                    {
+                        "description": "Settings to update",
+                        "name": "settings",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usersettings.UpdateRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersettingshandler.UserSettingsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/users/me/settings/preferences": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve current user's preferences only",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Settings"
+                ],
+                "summary": "Get user preferences",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersettingshandler.PreferencesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Update current user's preferences only",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "User Settings"
+                ],
+                "summary": "Update user preferences",
+                "parameters": [
+                    {
+                        "description": "Preferences to update",
+                        "name": "preferences",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/usersettingshandler.UpdatePreferencesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/usersettingshandler.PreferencesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/version": {
+            "get": {
+                "description": "Returns the current build version of the API server and environment reload timestamp.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Server API"
+                ],
+                "summary": "Get API build version",
+                "responses": {
+                    "200": {
+                        "description": "Version information including version number and environment reload timestamp",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    }
+                }
+            }
+        },
+        "/v1/webhooks/{source}": {
+            "post": {
+                "description": "Receives a signed webhook and publishes it on llm-api's event bus as ` + "`" + `{source}.{type}` + "`" + ` (e.g. ` + "`" + `livekit.egress_ended` + "`" + `), where subscribers react to it.\nEach source is verified with its own scheme and accepted only once its secret is configured:\n- ` + "`" + `openai` + "`" + `, ` + "`" + `openrouter` + "`" + `: Standard Webhooks headers (` + "`" + `webhook-id` + "`" + `, ` + "`" + `webhook-timestamp` + "`" + `, ` + "`" + `webhook-signature` + "`" + `) signed with ` + "`" + `OPENAI_WEBHOOK_SECRET` + "`" + ` / ` + "`" + `OPENROUTER_WEBHOOK_SECRET` + "`" + `\n- ` + "`" + `livekit` + "`" + `: ` + "`" + `Authorization` + "`" + ` JWT signed with ` + "`" + `LIVEKIT_API_SECRET` + "`" + `, carrying the body's SHA-256\nRedeliveries of an already received delivery ID are acknowledged with ` + "`" + `duplicate: true` + "`" + ` and not published again.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Webhooks API"
+                ],
+                "summary": "Receive a provider webhook",
+                "parameters": [
+                    {
+                        "enum": [
+                            "openai",
+                            "openrouter",
+                            "livekit"
+                        ],
+                        "type": "string",
+                        "description": "Webhook source",
+                        "name": "source",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Delivery accepted",
+                        "schema": {
+                            "$ref": "#/definitions/webhookhandler.WebhookResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Malformed event",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Missing, invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Unknown or unconfigured source",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Body larger than 1 MiB",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "admin.AdminConversation": {
+            "type": "object",
+            "properties": {
+                "active_branch": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "item_count": {
+                    "description": "Items on the MAIN branch",
+                    "type": "integer"
+                },
+                "project_id": {
+                    "type": "string"
+                },
+                "referrer": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "admin.AdminConversationsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/admin.AdminConversation"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "admin.AdminUsageResponse": {
+            "type": "object",
+            "properties": {
+                "by": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.UsageRow"
+                    }
+                },
+                "since": {
+                    "type": "string"
+                },
+                "until": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "admin.EnableMaintenanceRequest": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "description": "Expected end, sent to clients as Retry-After",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Shown to clients; a generic message when empty",
+                    "type": "string"
+                }
+            }
+        },
+        "admin.ReconcileResponse": {
+            "type": "object",
+            "properties": {
+                "conversation_id": {
+                    "type": "string"
+                },
+                "failed": {
+                    "type": "integer"
+                },
+                "older_than": {
+                    "description": "Calls in progress for longer than this were failed",
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.DailyLatency": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "completions": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "failed_completions": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.DailyMessages": {
+            "type": "object",
+            "properties": {
+                "assistant_messages": {
+                    "type": "integer"
+                },
+                "date": {
+                    "type": "string"
+                },
+                "total_messages": {
+                    "type": "integer"
+                },
+                "user_messages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.ModelUsage": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analytics.ToolUsage": {
+            "type": "object",
+            "properties": {
+                "calls": {
+                    "type": "integer"
+                },
+                "share": {
+                    "description": "Fraction of all tool calls in the range",
+                    "type": "number"
+                },
+                "tool_name": {
+                    "type": "string"
+                }
+            }
+        },
+        "analytics.UsageRow": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "description": "Over successful requests",
+                    "type": "number"
+                },
+                "completion_tokens": {
+                    "type": "integer"
+                },
+                "failed_requests": {
+                    "type": "integer"
+                },
+                "key": {
+                    "description": "Model, provider, user ID or YYYY-MM-DD day",
+                    "type": "string"
+                },
+                "prompt_tokens": {
+                    "type": "integer"
+                },
+                "requests": {
+                    "type": "integer"
+                }
+            }
+        },
+        "analyticshandler.LatencyResponse": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "completions": {
+                    "type": "integer"
+                },
+                "daily": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.DailyLatency"
+                    }
+                },
+                "failed_completions": {
+                    "type": "integer"
+                },
+                "range": {
+                    "$ref": "#/definitions/analyticshandler.RangeResponse"
+                }
+            }
+        },
+        "analyticshandler.MessagesPerDayResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.DailyMessages"
+                    }
+                },
+                "range": {
+                    "$ref": "#/definitions/analyticshandler.RangeResponse"
+                }
+            }
+        },
+        "analyticshandler.RangeResponse": {
+            "type": "object",
+            "properties": {
+                "end_date": {
+                    "type": "string"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "analyticshandler.ToolUsageResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.ToolUsage"
+                    }
+                },
+                "range": {
+                    "$ref": "#/definitions/analyticshandler.RangeResponse"
+                }
+            }
+        },
+        "analyticshandler.TopModelsResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/analytics.ModelUsage"
+                    }
+                },
+                "range": {
+                    "$ref": "#/definitions/analyticshandler.RangeResponse"
+                }
+            }
+        },
+        "authhandler.AccessTokenResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.DeviceCodeRequest": {
+            "type": "object",
+            "properties": {
+                "scope": {
+                    "description": "Extra scopes, e.g. offline_access for a refresh token that outlives the session",
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.DeviceCodeResponse": {
+            "type": "object",
+            "properties": {
+                "device_code": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "interval": {
+                    "type": "integer"
+                },
+                "user_code": {
+                    "type": "string"
+                },
+                "verification_uri": {
+                    "type": "string"
+                },
+                "verification_uri_complete": {
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.DeviceTokenRequest": {
+            "type": "object",
+            "properties": {
+                "device_code": {
+                    "type": "string"
+                },
+                "grant_type": {
+                    "description": "Optional; must be the device_code grant when set",
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.GetMeResponse": {
+            "type": "object",
+            "properties": {
+                "auth_method": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_admin": {
+                    "type": "boolean"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "roles": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "subject": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.OAuthErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                },
+                "error_description": {
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.TokenExchangeRequest": {
+            "type": "object",
+            "properties": {
+                "audience": {
+                    "description": "Optional target client of the new token",
+                    "type": "string"
+                },
+                "client_id": {
+                    "type": "string"
+                },
+                "client_secret": {
+                    "type": "string"
+                },
+                "grant_type": {
+                    "type": "string"
+                },
+                "requested_token_type": {
+                    "type": "string"
+                },
+                "scope": {
+                    "description": "Space-delimited integration scopes",
+                    "type": "string"
+                },
+                "subject_token": {
+                    "type": "string"
+                },
+                "subject_token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "authhandler.TokenExchangeResponse": {
+            "type": "object",
+            "properties": {
+                "access_token": {
+                    "type": "string"
+                },
+                "expires_in": {
+                    "type": "integer"
+                },
+                "issued_token_type": {
+                    "type": "string"
+                },
+                "refresh_token": {
+                    "type": "string"
+                },
+                "scope": {
+                    "type": "string"
+                },
+                "token_type": {
+                    "type": "string"
+                }
+            }
+        },
+        "chatrequests.AudioOutputOptions": {
+            "type": "object",
+            "properties": {
+                "format": {
+                    "description": "Format of the streamed audio: pcm16 (24 kHz 16-bit mono, default) or opus",
+                    "type": "string",
+                    "enum": [
+                        "pcm16",
+                        "opus"
+                    ]
+                },
+                "voice": {
+                    "description": "Voice is the TTS voice; defaults to the server's TTS_VOICE",
+                    "type": "string"
+                }
+            }
+        },
+        "chatrequests.CancelStreamRequest": {
+            "type": "object",
+            "properties": {
+                "audio_end_ms": {
+                    "description": "AudioEndMs is how much of the reply's audio was played when the user\ninterrupted it; the stored reply is truncated to match",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "chatrequests.ChatCompletionRequest": {
+            "type": "object",
+            "properties": {
+                "audio": {
+                    "description": "Audio configures the spoken reply when modalities includes \"audio\".",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/chatrequests.AudioOutputOptions"
+                        }
+                    ]
+                },
+                "chat_template_kwargs": {
+                    "description": "ChatTemplateKwargs provides a way to add non-standard parameters to the request body.\nAdditional kwargs to pass to the template renderer. Will be accessible by the chat template.\nSuch as think mode for qwen3. \"chat_template_kwargs\": {\"enable_thinking\": false}\nhttps://qwen.readthedocs.io/en/latest/deployment/vllm.html#thinking-non-thinking-modes",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "conversation": {
+                    "description": "Conversation can be either a string (conversation ID) or a conversation object\nItems from this conversation are prepended to Messages for this response request.\nInput items and output items from this response are automatically added to this conversation after completion.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/chatrequests.ConversationReference"
+                        }
+                    ]
+                },
+                "deep_research": {
+                    "description": "DeepResearch enables the Deep Research mode which uses a specialized prompt\nfor conducting in-depth investigations with tool usage.\nRequires a model with supports_reasoning: true capability.",
+                    "type": "boolean"
+                },
+                "enable_thinking": {
+                    "description": "EnableThinking controls whether reasoning/thinking capabilities should be used.\nDefaults to true. When set to false for a model with supports_reasoning: true\nand an instruct model configured, the instruct model will be used instead.",
+                    "type": "boolean"
+                },
+                "frequency_penalty": {
+                    "type": "number"
+                },
+                "function_call": {
+                    "description": "Deprecated: use ToolChoice instead."
+                },
+                "functions": {
+                    "description": "Deprecated: use Tools instead.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.FunctionDefinition"
+                    }
+                },
+                "guided_choice": {
+                    "description": "GuidedChoice is a vLLM-specific extension that restricts the model's output\nto one of the predefined string choices provided in this field. This feature\nis used to constrain the model's responses to a controlled set of options,\nensuring predictable and consistent outputs in scenarios where specific\nchoices are required.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "logit_bias": {
+                    "description": "LogitBias is must be a token id string (specified by their token ID in the tokenizer), not a word string.\nincorrect: ` + "`" + `\"logit_bias\":{\"You\": 6}` + "`" + `, correct: ` + "`" + `\"logit_bias\":{\"1639\": 6}` + "`" + `\nrefs: https://platform.openai.com/docs/api-reference/chat/create#chat/create-logit_bias",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "logprobs": {
+                    "description": "LogProbs indicates whether to return log probabilities of the output tokens or not.\nIf true, returns the log probabilities of each output token returned in the content of message.\nThis option is currently not available on the gpt-4-vision-preview model.",
+                    "type": "boolean"
+                },
+                "max_completion_tokens": {
+                    "description": "MaxCompletionTokens An upper bound for the number of tokens that can be generated for a completion,\nincluding visible output tokens and reasoning tokens https://platform.openai.com/docs/guides/reasoning",
+                    "type": "integer"
+                },
+                "max_tokens": {
+                    "description": "MaxTokens The maximum number of tokens that can be generated in the chat completion.\nThis value can be used to control costs for text generated via API.\nDeprecated: use MaxCompletionTokens. Not compatible with o1-series models.\nrefs: https://platform.openai.com/docs/api-reference/chat/create#chat-create-max_tokens",
+                    "type": "integer"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.ChatCompletionMessage"
+                    }
+                },
+                "metadata": {
+                    "description": "Metadata to store with the completion.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "modalities": {
+                    "description": "Modalities lists the outputs to generate: \"text\", and \"audio\" to also stream\nthe reply as speech. Audio requires stream: true and a configured TTS server.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "text",
+                            "audio"
+                        ]
+                    }
+                },
+                "model": {
+                    "type": "string"
+                },
+                "n": {
+                    "type": "integer"
+                },
+                "parallel_tool_calls": {
+                    "description": "Disable the default behavior of parallel tool calls by setting it: false."
+                },
+                "persona_id": {
+                    "description": "PersonaID applies one of the user's personas: its instructions are added to the\nsystem prompt above profile settings, its default model is used when model is\nempty, and only its enabled tools are kept.",
+                    "type": "string"
+                },
+                "prediction": {
+                    "description": "Configuration for a predicted output.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.Prediction"
+                        }
+                    ]
+                },
+                "presence_penalty": {
+                    "type": "number"
+                },
+                "realtime": {
+                    "description": "Realtime also streams the reply's text deltas to the data channel of a realtime\nsession's LiveKit room, so every participant sees it live. Requires stream: true.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/chatrequests.RealtimeOptions"
+                        }
+                    ]
+                },
+                "reasoning": {
+                    "description": "Reasoning sets the reasoning effort of reasoning models, mapped to each\nprovider's own knob, and whether the model's reasoning is returned.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/chatrequests.ReasoningOptions"
+                        }
+                    ]
+                },
+                "reasoning_effort": {
+                    "description": "Controls effort on reasoning for reasoning models. It can be set to \"low\", \"medium\", or \"high\".",
+                    "type": "string"
+                },
+                "repetition_penalty": {
+                    "type": "number"
+                },
+                "response_format": {
+                    "$ref": "#/definitions/openai.ChatCompletionResponseFormat"
+                },
+                "safety_identifier": {
+                    "description": "A stable identifier used to help detect users of your application that may be violating OpenAI's usage policies.\nThe IDs should be a string that uniquely identifies each user.\nWe recommend hashing their username or email address, in order to avoid sending us any identifying information.\nhttps://platform.openai.com/docs/api-reference/chat/create#chat_create-safety_identifier",
+                    "type": "string"
+                },
+                "seed": {
+                    "type": "integer"
+                },
+                "service_tier": {
+                    "description": "Specifies the latency tier to use for processing the request.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.ServiceTier"
+                        }
+                    ]
+                },
+                "stop": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "store": {
+                    "description": "Store controls whether the latest input and generated response should be persisted",
+                    "type": "boolean"
+                },
+                "store_reasoning": {
+                    "description": "StoreReasoning controls whether reasoning content (if present) should also be persisted",
+                    "type": "boolean"
+                },
+                "stream": {
+                    "type": "boolean"
+                },
+                "stream_options": {
+                    "description": "Options for streaming response. Only set this when you set stream: true.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.StreamOptions"
+                        }
+                    ]
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "tool_choice": {
+                    "description": "This can be either a string or an ToolChoice object."
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.Tool"
+                    }
+                },
+                "top_k": {
+                    "type": "integer"
+                },
+                "top_logprobs": {
+                    "description": "TopLogProbs is an integer between 0 and 5 specifying the number of most likely tokens to return at each\ntoken position, each with an associated log probability.\nlogprobs must be set to true if this parameter is used.",
+                    "type": "integer"
+                },
+                "top_p": {
+                    "type": "number"
+                },
+                "user": {
+                    "type": "string"
+                },
+                "verbosity": {
+                    "description": "Verbosity determines how many output tokens are generated. Lowering the number of\ntokens reduces overall latency. It can be set to \"low\", \"medium\", or \"high\".\nNote: This field is only confirmed to work with gpt-5, gpt-5-mini and gpt-5-nano.\nAlso, it is not in the API reference of chat completion at the time of writing,\nthough it is supported by the API.",
+                    "type": "string"
+                }
+            }
+        },
+        "chatrequests.ConversationReference": {
+            "type": "object"
+        },
+        "chatrequests.RealtimeOptions": {
+            "type": "object",
+            "properties": {
+                "room_id": {
+                    "description": "RoomID is the room_id of a realtime session the caller has joined",
+                    "type": "string"
+                }
+            }
+        },
+        "chatrequests.ReasoningOptions": {
+            "type": "object",
+            "properties": {
+                "effort": {
+                    "description": "Effort is how much the model reasons: low, medium or high",
+                    "type": "string",
+                    "enum": [
+                        "low",
+                        "medium",
+                        "high"
+                    ]
+                },
+                "summary": {
+                    "description": "Summary requests the model's reasoning: auto, concise or detailed. When\nreasoning is set without a summary, reasoning is not streamed, returned\nor stored; with store_reasoning it is stored as a reasoning item.",
+                    "type": "string",
+                    "enum": [
+                        "auto",
+                        "concise",
+                        "detailed"
+                    ]
+                }
+            }
+        },
+        "chatresponses.ChatCompletionResponse": {
+            "type": "object",
+            "properties": {
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.ChatCompletionChoice"
+                    }
+                },
+                "conversation": {
+                    "$ref": "#/definitions/chatresponses.ConversationContext"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "prompt_filter_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.PromptFilterResult"
+                    }
+                },
+                "service_tier": {
+                    "$ref": "#/definitions/openai.ServiceTier"
+                },
+                "system_fingerprint": {
+                    "type": "string"
+                },
+                "trimmed": {
+                    "description": "True if messages were trimmed to fit context",
+                    "type": "boolean"
+                },
+                "usage": {
+                    "$ref": "#/definitions/openai.Usage"
+                }
+            }
+        },
+        "chatresponses.ConversationContext": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "description": "The unique ID of the conversation",
+                    "type": "string"
+                },
+                "title": {
+                    "description": "The title of the conversation (optional)",
+                    "type": "string"
+                }
+            }
+        },
+        "chatresponses.StreamCancelResponse": {
+            "type": "object",
+            "properties": {
+                "cancelled_mcp_calls": {
+                    "description": "Pending mcp_call items marked cancelled",
+                    "type": "integer"
+                },
+                "conversation_id": {
+                    "description": "The conversation the stream was writing to",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "The stream ID from the X-Stream-ID header",
+                    "type": "string"
+                },
+                "object": {
+                    "description": "Always \"chat.completion.stream\"",
+                    "type": "string"
+                },
+                "status": {
+                    "description": "Always \"cancelled\"",
+                    "type": "string"
+                }
+            }
+        },
+        "comparehandler.CompareModel": {
+            "type": "object",
+            "properties": {
+                "model": {
+                    "type": "string"
+                },
+                "slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "comparehandler.CompareResponse": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/comparehandler.CompareResult"
+                    }
+                }
+            }
+        },
+        "comparehandler.CompareResult": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "$ref": "#/definitions/responses.ErrorResponse"
+                },
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "response": {
+                    "description": "Non-streaming only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.ChatCompletionResponse"
+                        }
+                    ]
+                },
+                "slot": {
+                    "type": "integer"
+                },
+                "usage": {
+                    "$ref": "#/definitions/openai.Usage"
+                }
+            }
+        },
+        "comparehandler.ComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "completed_at": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/comparehandler.CompareModel"
+                    }
+                },
+                "object": {
+                    "type": "string"
+                },
+                "vote": {
+                    "type": "string"
+                },
+                "voted_at": {
+                    "type": "integer"
+                },
+                "winner_slot": {
+                    "type": "integer"
+                }
+            }
+        },
+        "comparehandler.LeaderboardResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/comparison.LeaderboardEntry"
+                    }
+                },
+                "end_date": {
+                    "type": "string"
+                },
+                "min_votes": {
+                    "type": "integer"
+                },
+                "start_date": {
+                    "type": "string"
+                }
+            }
+        },
+        "comparerequests.CompareRequest": {
+            "type": "object",
+            "required": [
+                "messages",
+                "models"
+            ],
+            "properties": {
+                "max_tokens": {
+                    "type": "integer"
+                },
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.ChatCompletionMessage"
+                    }
+                },
+                "models": {
+                    "description": "Model public IDs; the index is the model's slot",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "stream": {
+                    "type": "boolean"
+                },
+                "temperature": {
+                    "type": "number"
+                },
+                "top_p": {
+                    "type": "number"
+                }
+            }
+        },
+        "comparerequests.VoteRequest": {
+            "type": "object",
+            "required": [
+                "outcome"
+            ],
+            "properties": {
+                "outcome": {
+                    "type": "string",
+                    "enum": [
+                        "winner",
+                        "tie",
+                        "all_bad"
+                    ]
+                },
+                "winner_slot": {
+                    "description": "Required if outcome is \"winner\"",
+                    "type": "integer"
+                }
+            }
+        },
+        "comparison.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "average_latency_ms": {
+                    "type": "number"
+                },
+                "losses": {
+                    "type": "integer"
+                },
+                "model": {
+                    "type": "string"
+                },
+                "ties": {
+                    "type": "integer"
+                },
+                "votes": {
+                    "type": "integer"
+                },
+                "win_rate": {
+                    "type": "number"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "conversation.Annotation": {
+            "type": "object",
+            "properties": {
+                "bounding_box": {
+                    "description": "Bounding box for image/PDF annotations",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.BBox"
+                        }
+                    ]
+                },
+                "confidence": {
+                    "description": "Citation confidence score (0.0-1.0)",
+                    "type": "number"
+                },
+                "container_id": {
+                    "description": "Document container reference",
+                    "type": "string"
+                },
+                "end_index": {
+                    "description": "End position in text",
+                    "type": "integer"
+                },
+                "file_id": {
+                    "description": "For file citations",
+                    "type": "string"
+                },
+                "filename": {
+                    "description": "File name for citations",
+                    "type": "string"
+                },
+                "index": {
+                    "description": "Citation index",
+                    "type": "integer"
+                },
+                "page_number": {
+                    "description": "Page reference for documents",
+                    "type": "integer"
+                },
+                "quote": {
+                    "description": "Actual quoted text from source",
+                    "type": "string"
+                },
+                "start_index": {
+                    "description": "Start position in text",
+                    "type": "integer"
+                },
+                "text": {
+                    "description": "Display text",
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"file_citation\", \"url_citation\", \"file_path\", etc.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "For URL citations",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.AudioContent": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Base64 encoded audio data",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Audio format: mp3, wav, pcm16, etc.",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "segments": {
+                    "description": "Timing of each spoken sentence, used to truncate on interruption",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.AudioSegment"
+                    }
+                },
+                "transcript": {
+                    "description": "Text transcription of audio",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.AudioSegment": {
+            "type": "object",
+            "properties": {
+                "end_ms": {
+                    "type": "integer"
+                },
+                "transcript": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.BBox": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "number"
+                },
+                "width": {
+                    "type": "number"
+                },
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                }
+            }
+        },
+        "conversation.CodeContent": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "Code content",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "Execution error",
+                    "type": "string"
+                },
+                "execution_id": {
+                    "description": "Execution session ID",
+                    "type": "string"
+                },
+                "exit_code": {
+                    "description": "Process exit code",
+                    "type": "integer"
+                },
+                "language": {
+                    "description": "Programming language",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Additional metadata",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "output": {
+                    "description": "Execution output",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.ComputerAction": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "Action type: \"click\", \"type\", \"key\", \"scroll\", \"move\", etc.",
+                    "type": "string"
+                },
+                "coordinates": {
+                    "description": "Screen coordinates for mouse actions",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.Coordinates"
+                        }
+                    ]
+                },
+                "key": {
+                    "description": "Key for keyboard actions",
+                    "type": "string"
+                },
+                "metadata": {
+                    "description": "Additional action metadata",
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "scroll_delta": {
+                    "description": "Scroll amount",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.ScrollDelta"
+                        }
+                    ]
+                },
+                "text": {
+                    "description": "Text for typing actions",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.Content": {
+            "type": "object",
+            "properties": {
+                "audio": {
+                    "description": "Audio content for speech",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.AudioContent"
+                        }
+                    ]
+                },
+                "code": {
+                    "description": "Code block with execution metadata",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.CodeContent"
+                        }
+                    ]
+                },
+                "computer_action": {
+                    "description": "Computer interaction details",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.ComputerAction"
+                        }
+                    ]
+                },
+                "computer_screenshot": {
+                    "description": "Screenshot from computer use",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.ScreenshotContent"
+                        }
+                    ]
+                },
+                "file": {
+                    "description": "File content",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.FileContent"
+                        }
+                    ]
+                },
+                "finish_reason": {
+                    "description": "Finish reason",
+                    "type": "string"
+                },
+                "function_call": {
+                    "description": "Function call content (deprecated, use tool_calls)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.FunctionCall"
+                        }
+                    ]
+                },
+                "function_call_output": {
+                    "description": "Function call output",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.FunctionCallOut"
+                        }
+                    ]
+                },
+                "image": {
+                    "description": "Image content",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.ImageContent"
+                        }
+                    ]
+                },
+                "input_audio": {
+                    "description": "User audio input",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.InputAudio"
+                        }
+                    ]
+                },
+                "output_text": {
+                    "description": "AI output text (with annotations)",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.OutputText"
+                        }
+                    ]
+                },
+                "refusal": {
+                    "description": "Model refusal message",
+                    "type": "string"
+                },
+                "summary_text": {
+                    "description": "Summary content",
+                    "type": "string"
+                },
+                "thinking": {
+                    "description": "Internal reasoning (o1 models)",
+                    "type": "string"
+                },
+                "tool_call_id": {
+                    "description": "Tool call ID (for tool responses)",
+                    "type": "string"
+                },
+                "tool_calls": {
+                    "description": "Tool calls (for assistant messages)",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.ToolCall"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.Coordinates": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "conversation.FileContent": {
+            "type": "object",
+            "properties": {
+                "file_id": {
+                    "type": "string"
+                },
+                "mime_type": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "conversation.FunctionCall": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "description": "JSON-encoded arguments",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "Call ID",
+                    "type": "string"
+                },
+                "name": {
+                    "description": "Function name",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.FunctionCallOut": {
+            "type": "object",
+            "properties": {
+                "call_id": {
+                    "description": "ID of the function call this responds to",
+                    "type": "string"
+                },
+                "output": {
+                    "description": "String output from the function",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.ImageContent": {
+            "type": "object",
+            "properties": {
+                "detail": {
+                    "description": "\"low\", \"high\", \"auto\"",
+                    "type": "string"
+                },
+                "file_id": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.IncompleteDetails": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "description": "Error message if applicable",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "\"max_tokens\", \"content_filter\", \"tool_calls\", etc.",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.InputAudio": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "description": "Base64 encoded audio data",
+                    "type": "string"
+                },
+                "format": {
+                    "description": "Audio format: mp3, wav, pcm16, etc.",
+                    "type": "string"
+                },
+                "transcript": {
+                    "description": "Optional text transcription",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.Item": {
+            "type": "object",
+            "properties": {
+                "acknowledged_safety_checks": {
+                    "description": "For computer call outputs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.SafetyCheck"
+                    }
+                },
+                "action": {
+                    "description": "For computer/shell actions",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "approval_request_id": {
+                    "description": "For MCP approval",
+                    "type": "string"
+                },
+                "approve": {
+                    "description": "For MCP approval response",
+                    "type": "boolean"
+                },
+                "arguments": {
+                    "description": "For tool calls (JSON string)",
+                    "type": "string"
+                },
+                "branch": {
+                    "description": "Branch identifier (MAIN, EDIT_1, etc.)",
+                    "type": "string"
+                },
+                "call_id": {
+                    "description": "OpenAI-compatible fields for specific item types",
+                    "type": "string"
+                },
+                "cold_stored": {
+                    "description": "Cold storage marker: the item is a stub whose content, arguments and output\nwere archived; rehydrating the conversation restores them",
+                    "type": "boolean"
+                },
+                "commands": {
+                    "description": "For shell calls",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "completed_at": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.Content"
+                    }
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "edited": {
+                    "description": "True once the item has been edited in place",
+                    "type": "boolean"
+                },
+                "edited_at": {
+                    "description": "When the item was last edited",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "For failed calls",
+                    "type": "string"
+                },
+                "error_detail": {
+                    "description": "Structured error of failed MCP calls",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.ToolError"
+                        }
+                    ]
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incomplete_at": {
+                    "type": "string"
+                },
+                "incomplete_details": {
+                    "$ref": "#/definitions/conversation.IncompleteDetails"
+                },
+                "max_output_length": {
+                    "description": "For shell calls",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "For MCP tool calls - tool name",
+                    "type": "string"
+                },
+                "object": {
+                    "description": "Always \"conversation.item\" for OpenAI compatibility",
+                    "type": "string"
+                },
+                "operation": {
+                    "description": "For patch operations",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "output": {
+                    "description": "For tool call outputs",
+                    "type": "string"
+                },
+                "pending_safety_checks": {
+                    "description": "For computer calls",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.SafetyCheck"
+                    }
+                },
+                "rated_at": {
+                    "description": "When rating was given",
+                    "type": "string"
+                },
+                "rating": {
+                    "description": "User feedback/rating",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/conversation.ItemRating"
+                        }
+                    ]
+                },
+                "rating_comment": {
+                    "description": "Optional comment with rating",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "For MCP approval reason",
+                    "type": "string"
+                },
+                "role": {
+                    "$ref": "#/definitions/conversation.ItemRole"
+                },
+                "sequence_number": {
+                    "description": "Order within branch",
+                    "type": "integer"
+                },
+                "server_label": {
+                    "description": "For MCP calls",
+                    "type": "string"
+                },
+                "shell_outputs": {
+                    "description": "For shell call outputs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.ShellOutput"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/conversation.ItemStatus"
+                },
+                "tools": {
+                    "description": "For mcp_list_tools",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.McpTool"
+                    }
+                },
+                "type": {
+                    "$ref": "#/definitions/conversation.ItemType"
+                }
+            }
+        },
+        "conversation.ItemEdit": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "previous_content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.Content"
+                    }
+                },
+                "previous_output": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.ItemRating": {
+            "type": "string",
+            "enum": [
+                "like",
+                "unlike"
+            ],
+            "x-enum-comments": {
+                "ItemRatingLike": "Positive feedback (like)",
+                "ItemRatingUnlike": "Negative feedback (unlike)"
+            },
+            "x-enum-varnames": [
+                "ItemRatingLike",
+                "ItemRatingUnlike"
+            ]
+        },
+        "conversation.ItemRole": {
+            "type": "string",
+            "enum": [
+                "system",
+                "user",
+                "assistant",
+                "tool",
+                "developer",
+                "critic",
+                "discriminator",
+                "unknown"
+            ],
+            "x-enum-comments": {
+                "ItemRoleCritic": "For critique/evaluation workflows",
+                "ItemRoleDeveloper": "System-level instructions (OpenAI replacement for system)",
+                "ItemRoleDiscriminator": "For adversarial/validation workflows",
+                "ItemRoleUnknown": "Fallback for unrecognized roles"
+            },
+            "x-enum-varnames": [
+                "ItemRoleSystem",
+                "ItemRoleUser",
+                "ItemRoleAssistant",
+                "ItemRoleTool",
+                "ItemRoleDeveloper",
+                "ItemRoleCritic",
+                "ItemRoleDiscriminator",
+                "ItemRoleUnknown"
+            ]
+        },
+        "conversation.ItemStatus": {
+            "type": "string",
+            "enum": [
+                "incomplete",
+                "in_progress",
+                "completed",
+                "failed",
+                "cancelled",
+                "searching",
+                "generating",
+                "calling",
+                "streaming",
+                "rate_limited"
+            ],
+            "x-enum-comments": {
+                "ItemStatusCalling": "Function/tool call in progress",
+                "ItemStatusCancelled": "Cancelled by user or system",
+                "ItemStatusCompleted": "Successfully finished",
+                "ItemStatusFailed": "Failed with error",
+                "ItemStatusGenerating": "Image generation in progress",
+                "ItemStatusInProgress": "Currently processing",
+                "ItemStatusIncomplete": "Not started or partially complete (OpenAI uses this instead of \"pending\")",
+                "ItemStatusRateLimited": "Rate limit hit",
+                "ItemStatusSearching": "File/web search in progress",
+                "ItemStatusStreaming": "Streaming response in progress"
+            },
+            "x-enum-varnames": [
+                "ItemStatusIncomplete",
+                "ItemStatusInProgress",
+                "ItemStatusCompleted",
+                "ItemStatusFailed",
+                "ItemStatusCancelled",
+                "ItemStatusSearching",
+                "ItemStatusGenerating",
+                "ItemStatusCalling",
+                "ItemStatusStreaming",
+                "ItemStatusRateLimited"
+            ]
+        },
+        "conversation.ItemType": {
+            "type": "string",
+            "enum": [
+                "message",
+                "function_call",
+                "function_call_output",
+                "reasoning",
+                "file_search_call",
+                "web_search_call",
+                "image_generation_call",
+                "image_edit_call",
+                "computer_call",
+                "computer_call_output",
+                "code_interpreter_call",
+                "local_shell_call",
+                "local_shell_call_output",
+                "shell_call",
+                "shell_call_output",
+                "apply_patch_call",
+                "apply_patch_call_output",
+                "mcp_list_tools",
+                "mcp_approval_request",
+                "mcp_approval_response",
+                "mcp_call",
+                "custom_tool_call",
+                "custom_tool_call_output",
+                "file_search",
+                "web_search",
+                "code_interpreter",
+                "computer_use",
+                "mcp_item",
+                "image_generation"
+            ],
+            "x-enum-comments": {
+                "ItemTypeCodeInterpreter": "Legacy: maps to code_interpreter_call",
+                "ItemTypeComputerUse": "Legacy: maps to computer_call",
+                "ItemTypeFileSearch": "Legacy: maps to file_search_call",
+                "ItemTypeImageGeneration": "Legacy: maps to image_generation_call",
+                "ItemTypeMCPItem": "Legacy: maps to mcp_call",
+                "ItemTypeReasoning": "For o1/reasoning models",
+                "ItemTypeWebSearch": "Legacy: maps to web_search_call"
+            },
+            "x-enum-varnames": [
+                "ItemTypeMessage",
+                "ItemTypeFunctionCall",
+                "ItemTypeFunctionCallOut",
+                "ItemTypeReasoning",
+                "ItemTypeFileSearchCall",
+                "ItemTypeWebSearchCall",
+                "ItemTypeImageGenerationCall",
+                "ItemTypeImageEditCall",
+                "ItemTypeComputerCall",
+                "ItemTypeComputerCallOutput",
+                "ItemTypeCodeInterpreterCall",
+                "ItemTypeLocalShellCall",
+                "ItemTypeLocalShellCallOutput",
+                "ItemTypeShellCall",
+                "ItemTypeShellCallOutput",
+                "ItemTypeApplyPatchCall",
+                "ItemTypeApplyPatchCallOutput",
+                "ItemTypeMcpListTools",
+                "ItemTypeMcpApprovalRequest",
+                "ItemTypeMcpApprovalResponse",
+                "ItemTypeMcpCall",
+                "ItemTypeCustomToolCall",
+                "ItemTypeCustomToolCallOutput",
+                "ItemTypeFileSearch",
+                "ItemTypeWebSearch",
+                "ItemTypeCodeInterpreter",
+                "ItemTypeComputerUse",
+                "ItemTypeMCPItem",
+                "ItemTypeImageGeneration"
+            ]
+        },
+        "conversation.LogProb": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "logprob": {
+                    "type": "number"
+                },
+                "token": {
+                    "type": "string"
+                },
+                "top_logprobs": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.TopLogProb"
+                    }
+                }
+            }
+        },
+        "conversation.McpTool": {
+            "type": "object",
+            "properties": {
+                "annotations": {},
+                "description": {
+                    "type": "string"
+                },
+                "input_schema": {},
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.OutputText": {
+            "type": "object",
+            "properties": {
+                "annotations": {
+                    "description": "Required for OpenAI compatibility",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.Annotation"
+                    }
+                },
+                "logprobs": {
+                    "description": "Token probabilities",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.LogProb"
+                    }
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.SafetyCheck": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.ScreenshotContent": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "description": "Optional description",
+                    "type": "string"
+                },
+                "height": {
+                    "description": "Image height in pixels",
+                    "type": "integer"
+                },
+                "image_data": {
+                    "description": "Base64 encoded image data",
+                    "type": "string"
+                },
+                "image_url": {
+                    "description": "URL to screenshot image",
+                    "type": "string"
+                },
+                "timestamp": {
+                    "description": "Unix timestamp when screenshot was taken",
+                    "type": "integer"
+                },
+                "width": {
+                    "description": "Image width in pixels",
+                    "type": "integer"
+                }
+            }
+        },
+        "conversation.ScrollDelta": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "integer"
+                },
+                "y": {
+                    "type": "integer"
+                }
+            }
+        },
+        "conversation.ShellOutput": {
+            "type": "object",
+            "properties": {
+                "type": {
+                    "description": "stdout, stderr, exit_code",
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.ToolCall": {
+            "type": "object",
+            "properties": {
+                "function": {
+                    "$ref": "#/definitions/conversation.FunctionCall"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"function\", \"file_search\", \"code_interpreter\"",
+                    "type": "string"
+                }
+            }
+        },
+        "conversation.ToolError": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "description": "\"invalid_arguments\", \"unauthorized\", \"rate_limited\", \"timeout\", etc.",
+                    "type": "string"
+                },
+                "detail": {
+                    "description": "Provider response, truncated",
+                    "type": "string"
+                },
+                "message": {
+                    "description": "Human-readable error message",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Upstream provider that failed",
+                    "type": "string"
+                },
+                "retryable": {
+                    "description": "Whether calling again unchanged may succeed",
+                    "type": "boolean"
+                }
+            }
+        },
+        "conversation.TopLogProb": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "logprob": {
+                    "type": "number"
+                },
+                "token": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversationhandler.ActivateBranchResponse": {
             "type": "object",
             "properties": {
-                "access_token": {
+                "active_branch": {
                     "type": "string"
                 },
-                "expires_at": {
+                "message": {
                     "type": "string"
+                }
+            }
+        },
+        "conversationhandler.BranchResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "integer"
                 },
-                "expires_in": {
+                "description": {
+                    "type": "string"
+                },
+                "forked_at": {
                     "type": "integer"
                 },
-                "refresh_token": {
+                "forked_from_item_id": {
                     "type": "string"
                 },
-                "token_type": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "item_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "parent_branch": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "authhandler.GetMeResponse": {
+        "conversationhandler.CreateBranchRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "auth_method": {
+                "description": {
                     "type": "string"
                 },
-                "email": {
+                "fork_from_item_id": {
                     "type": "string"
                 },
-                "id": {
+                "name": {
                     "type": "string"
                 },
-                "is_admin": {
+                "parent_branch": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversationhandler.DeleteItemResponse": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Always \"MAIN\" after swap",
+                    "type": "string"
+                },
+                "branch_created": {
                     "type": "boolean"
                 },
-                "name": {
+                "deleted": {
+                    "type": "boolean"
+                },
+                "old_main_backup": {
+                    "description": "Backup name for old MAIN",
+                    "type": "string"
+                }
+            }
+        },
+        "conversationhandler.EditMessageRequest": {
+            "type": "object",
+            "required": [
+                "content"
+            ],
+            "properties": {
+                "content": {
                     "type": "string"
                 },
-                "roles": {
+                "regenerate": {
+                    "description": "Auto-trigger new response (default: true)",
+                    "type": "boolean"
+                }
+            }
+        },
+        "conversationhandler.EditMessageResponse": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Always \"MAIN\" after swap",
+                    "type": "string"
+                },
+                "branch_created": {
+                    "type": "boolean"
+                },
+                "new_branch": {
+                    "$ref": "#/definitions/conversationhandler.BranchResponse"
+                },
+                "old_main_backup": {
+                    "description": "Backup name for old MAIN",
+                    "type": "string"
+                },
+                "user_item": {
+                    "$ref": "#/definitions/conversation.Item"
+                }
+            }
+        },
+        "conversationhandler.ListBranchesResponse": {
+            "type": "object",
+            "properties": {
+                "active_branch": {
+                    "type": "string"
+                },
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/conversationhandler.BranchResponse"
                     }
                 },
-                "subject": {
-                    "type": "string"
-                },
-                "username": {
+                "object": {
+                    "description": "\"list\"",
                     "type": "string"
                 }
             }
         },
-        "chatrequests.ChatCompletionRequest": {
+        "conversationhandler.RegenerateMessageRequest": {
             "type": "object",
             "properties": {
-                "chat_template_kwargs": {
-                    "description": "ChatTemplateKwargs provides a way to add non-standard parameters to the request body.\nAdditional kwargs to pass to the template renderer. Will be accessible by the chat template.\nSuch as think mode for qwen3. \"chat_template_kwargs\": {\"enable_thinking\": false}\nhttps://qwen.readthedocs.io/en/latest/deployment/vllm.html#thinking-non-thinking-modes",
-                    "type": "object",
-                    "additionalProperties": {}
+                "max_tokens": {
+                    "description": "Override max tokens",
+                    "type": "integer"
                 },
-                "conversation": {
-                    "description": "Conversation can be either a string (conversation ID) or a conversation object\nItems from this conversation are prepended to Messages for this response request.\nInput items and output items from this response are automatically added to this conversation after completion.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/chatrequests.ConversationReference"
-                        }
-                    ]
+                "model": {
+                    "description": "Override model",
+                    "type": "string"
                 },
-                "deep_research": {
-                    "description": "DeepResearch enables the Deep Research mode which uses a specialized prompt\nfor conducting in-depth investigations with tool usage.\nRequires a model with supports_reasoning: true capability.",
-                    "type": "boolean"
+                "temperature": {
+                    "description": "Override temperature",
+                    "type": "number"
+                }
+            }
+        },
+        "conversationhandler.RegenerateMessageResponse": {
+            "type": "object",
+            "properties": {
+                "branch": {
+                    "description": "Always \"MAIN\" after swap",
+                    "type": "string"
                 },
-                "enable_thinking": {
-                    "description": "EnableThinking controls whether reasoning/thinking capabilities should be used.\nDefaults to true. When set to false for a model with supports_reasoning: true\nand an instruct model configured, the instruct model will be used instead.",
+                "branch_created": {
                     "type": "boolean"
                 },
-                "frequency_penalty": {
-                    "type": "number"
+                "new_branch": {
+                    "$ref": "#/definitions/conversationhandler.BranchResponse"
                 },
-                "function_call": {
-                    "description": "Deprecated: use ToolChoice instead."
+                "old_main_backup": {
+                    "description": "Backup name for old MAIN",
+                    "type": "string"
                 },
-                "functions": {
-                    "description": "Deprecated: use Tools instead.",
+                "user_item_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "conversationrequests.CreateConversationRequest": {
+            "type": "object",
+            "properties": {
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/openai.FunctionDefinition"
+                        "$ref": "#/definitions/conversation.Item"
                     }
                 },
-                "guided_choice": {
-                    "description": "GuidedChoice is a vLLM-specific extension that restricts the model's output\nto one of the predefined string choices provided in this field. This feature\nis used to constrain the model's responses to a controlled set of options,\nensuring predictable and consistent outputs in scenarios where specific\nchoices are required.",
-                    "type": "array",
-                    "items": {
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "logit_bias": {
-                    "description": "LogitBias is must be a token id string (specified by their token ID in the tokenizer), not a word string.\nincorrect: ` + "`" + `\"logit_bias\":{\"You\": 6}` + "`" + `, correct: ` + "`" + `\"logit_bias\":{\"1639\": 6}` + "`" + `\nrefs: https://platform.openai.com/docs/api-reference/chat/create#chat/create-logit_bias",
+                "project_id": {
+                    "type": "string"
+                },
+                "prompt_library_id": {
+                    "description": "PromptLibraryID starts the conversation from a prompt library starter; its rendered\nmessages are added before Items.",
+                    "type": "string"
+                },
+                "prompt_variables": {
                     "type": "object",
                     "additionalProperties": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 },
-                "logprobs": {
-                    "description": "LogProbs indicates whether to return log probabilities of the output tokens or not.\nIf true, returns the log probabilities of each output token returned in the content of message.\nThis option is currently not available on the gpt-4-vision-preview model.",
-                    "type": "boolean"
-                },
-                "max_completion_tokens": {
-                    "description": "MaxCompletionTokens An upper bound for the number of tokens that can be generated for a completion,\nincluding visible output tokens and reasoning tokens https://platform.openai.com/docs/guides/reasoning",
-                    "type": "integer"
+                "referrer": {
+                    "type": "string"
                 },
-                "max_tokens": {
-                    "description": "MaxTokens The maximum number of tokens that can be generated in the chat completion.\nThis value can be used to control costs for text generated via API.\nDeprecated: use MaxCompletionTokens. Not compatible with o1-series models.\nrefs: https://platform.openai.com/docs/api-reference/chat/create#chat-create-max_tokens",
-                    "type": "integer"
+                "title": {
+                    "type": "string"
                 },
-                "messages": {
+                "title_locked": {
+                    "description": "TitleLocked stops automatic title generation from changing the title.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "conversationrequests.CreateItemsRequest": {
+            "type": "object",
+            "required": [
+                "items"
+            ],
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.Item"
+                    }
+                }
+            }
+        },
+        "conversationrequests.EditItemRequest": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "description": "Replacement content",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/openai.ChatCompletionMessage"
+                        "$ref": "#/definitions/conversation.Content"
                     }
                 },
+                "output": {
+                    "description": "Replacement output (tool output items only)",
+                    "type": "string"
+                },
+                "reason": {
+                    "description": "Why the item was edited, kept for audit",
+                    "type": "string"
+                }
+            }
+        },
+        "conversationrequests.TruncateItemRequest": {
+            "type": "object",
+            "required": [
+                "audio_end_ms",
+                "content_index"
+            ],
+            "properties": {
+                "audio_end_ms": {
+                    "description": "Milliseconds of audio played before the interruption",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "content_index": {
+                    "description": "Index of the audio content part",
+                    "type": "integer",
+                    "minimum": 0
+                }
+            }
+        },
+        "conversationrequests.UpdateConversationRequest": {
+            "type": "object",
+            "properties": {
                 "metadata": {
-                    "description": "Metadata to store with the completion.",
                     "type": "object",
                     "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "model": {
+                "project_id": {
                     "type": "string"
                 },
-                "n": {
+                "referrer": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_locked": {
+                    "description": "TitleLocked locks or unlocks the title. Setting a title without this\nfield locks it automatically.",
+                    "type": "boolean"
+                },
+                "version": {
+                    "description": "Version is the conversation version the client last saw. When set, the\nupdate fails with 409 Conflict if the conversation has changed since.",
                     "type": "integer"
+                }
+            }
+        },
+        "conversationrequests.UpdateItemByCallIDRequest": {
+            "type": "object",
+            "required": [
+                "status"
+            ],
+            "properties": {
+                "arguments": {
+                    "description": "JSON string of arguments",
+                    "type": "string"
                 },
-                "parallel_tool_calls": {
-                    "description": "Disable the default behavior of parallel tool calls by setting it: false."
+                "error": {
+                    "description": "Error message if status is \"failed\"",
+                    "type": "string"
                 },
-                "prediction": {
-                    "description": "Configuration for a predicted output.",
+                "error_detail": {
+                    "description": "Structured error (code, retryable, provider) if status is \"failed\"",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/openai.Prediction"
+                            "$ref": "#/definitions/conversation.ToolError"
                         }
                     ]
                 },
-                "presence_penalty": {
-                    "type": "number"
-                },
-                "reasoning_effort": {
-                    "description": "Controls effort on reasoning for reasoning models. It can be set to \"low\", \"medium\", or \"high\".",
+                "name": {
+                    "description": "Tool info fields (optional - already set on creation, but can be updated)",
                     "type": "string"
                 },
-                "repetition_penalty": {
-                    "type": "number"
-                },
-                "response_format": {
-                    "$ref": "#/definitions/openai.ChatCompletionResponseFormat"
-                },
-                "safety_identifier": {
-                    "description": "A stable identifier used to help detect users of your application that may be violating OpenAI's usage policies.\nThe IDs should be a string that uniquely identifies each user.\nWe recommend hashing their username or email address, in order to avoid sending us any identifying information.\nhttps://platform.openai.com/docs/api-reference/chat/create#chat_create-safety_identifier",
+                "output": {
+                    "description": "Result fields",
                     "type": "string"
                 },
-                "seed": {
-                    "type": "integer"
-                },
-                "service_tier": {
-                    "description": "Specifies the latency tier to use for processing the request.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/openai.ServiceTier"
-                        }
-                    ]
+                "server_label": {
+                    "description": "MCP server label",
+                    "type": "string"
                 },
-                "stop": {
+                "status": {
+                    "description": "Required fields",
+                    "type": "string"
+                }
+            }
+        },
+        "conversationresponses.AttachmentListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/conversationresponses.AttachmentResponse"
                     }
                 },
-                "store": {
-                    "description": "Store controls whether the latest input and generated response should be persisted",
-                    "type": "boolean"
+                "object": {
+                    "type": "string"
                 },
-                "store_reasoning": {
-                    "description": "StoreReasoning controls whether reasoning content (if present) should also be persisted",
-                    "type": "boolean"
+                "total_bytes": {
+                    "description": "Sum of the known sizes",
+                    "type": "integer"
+                }
+            }
+        },
+        "conversationresponses.AttachmentResponse": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "description": "Size in bytes, when known",
+                    "type": "integer"
                 },
-                "stream": {
-                    "type": "boolean"
+                "created_at": {
+                    "description": "When the item was created",
+                    "type": "integer"
                 },
-                "stream_options": {
-                    "description": "Options for streaming response. Only set this when you set stream: true.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/openai.StreamOptions"
-                        }
-                    ]
+                "file_id": {
+                    "description": "Client-provided file ID that is not a media ID",
+                    "type": "string"
                 },
-                "temperature": {
-                    "type": "number"
+                "inline": {
+                    "description": "Embedded in the item as base64 data",
+                    "type": "boolean"
                 },
-                "tool_choice": {
-                    "description": "This can be either a string or an ToolChoice object."
+                "item_id": {
+                    "type": "string"
                 },
-                "tools": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/openai.Tool"
-                    }
+                "media_id": {
+                    "description": "Set for objects stored in media-api",
+                    "type": "string"
                 },
-                "top_k": {
-                    "type": "integer"
+                "mime_type": {
+                    "description": "Content type, when known",
+                    "type": "string"
                 },
-                "top_logprobs": {
-                    "description": "TopLogProbs is an integer between 0 and 5 specifying the number of most likely tokens to return at each\ntoken position, each with an associated log probability.\nlogprobs must be set to true if this parameter is used.",
-                    "type": "integer"
+                "name": {
+                    "description": "File name, when the item gave one",
+                    "type": "string"
                 },
-                "top_p": {
-                    "type": "number"
+                "object": {
+                    "type": "string"
                 },
-                "user": {
+                "role": {
                     "type": "string"
                 },
-                "verbosity": {
-                    "description": "Verbosity determines how many output tokens are generated. Lowering the number of\ntokens reduces overall latency. It can be set to \"low\", \"medium\", or \"high\".\nNote: This field is only confirmed to work with gpt-5, gpt-5-mini and gpt-5-nano.\nAlso, it is not in the API reference of chat completion at the time of writing,\nthough it is supported by the API.",
+                "type": {
+                    "description": "image, file or computer_screenshot",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "Download URL; absent for inline attachments",
                     "type": "string"
+                },
+                "url_expires_at": {
+                    "description": "Set when url is presigned",
+                    "type": "integer"
                 }
             }
         },
-        "chatrequests.ConversationReference": {
-            "type": "object"
-        },
-        "chatresponses.ChatCompletionResponse": {
+        "conversationresponses.BulkConversationsDeletedResponse": {
             "type": "object",
             "properties": {
-                "choices": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/openai.ChatCompletionChoice"
-                    }
-                },
-                "conversation": {
-                    "$ref": "#/definitions/chatresponses.ConversationContext"
+                "deleted": {
+                    "type": "boolean"
                 },
-                "created": {
+                "deleted_count": {
                     "type": "integer"
                 },
-                "id": {
+                "object": {
                     "type": "string"
+                }
+            }
+        },
+        "conversationresponses.ConversationDeletedResponse": {
+            "type": "object",
+            "properties": {
+                "deleted": {
+                    "type": "boolean"
                 },
-                "model": {
+                "id": {
                     "type": "string"
                 },
                 "object": {
                     "type": "string"
-                },
-                "prompt_filter_results": {
+                }
+            }
+        },
+        "conversationresponses.ConversationItemCreatedResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/openai.PromptFilterResult"
+                        "$ref": "#/definitions/conversation.Item"
                     }
                 },
-                "service_tier": {
-                    "$ref": "#/definitions/openai.ServiceTier"
-                },
-                "system_fingerprint": {
+                "first_id": {
                     "type": "string"
                 },
-                "trimmed": {
-                    "description": "True if messages were trimmed to fit context",
+                "has_more": {
                     "type": "boolean"
                 },
-                "usage": {
-                    "$ref": "#/definitions/openai.Usage"
-                }
-            }
-        },
-        "chatresponses.ConversationContext": {
-            "type": "object",
-            "properties": {
-                "id": {
-                    "description": "The unique ID of the conversation",
+                "last_id": {
                     "type": "string"
                 },
-                "title": {
-                    "description": "The title of the conversation (optional)",
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "conversation.Annotation": {
+        "conversationresponses.ConversationListResponse": {
             "type": "object",
             "properties": {
-                "bounding_box": {
-                    "description": "Bounding box for image/PDF annotations",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.BBox"
-                        }
-                    ]
-                },
-                "confidence": {
-                    "description": "Citation confidence score (0.0-1.0)",
-                    "type": "number"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                    }
                 },
-                "container_id": {
-                    "description": "Document container reference",
+                "first_id": {
                     "type": "string"
                 },
-                "end_index": {
-                    "description": "End position in text",
-                    "type": "integer"
+                "has_more": {
+                    "type": "boolean"
+                },
+                "last_id": {
+                    "type": "string"
                 },
-                "file_id": {
-                    "description": "For file citations",
+                "next_cursor": {
+                    "description": "NextCursor is an opaque token for the next page (pass as ` + "`" + `after` + "`" + `)",
                     "type": "string"
                 },
-                "filename": {
-                    "description": "File name for citations",
+                "object": {
                     "type": "string"
                 },
-                "index": {
-                    "description": "Citation index",
+                "total": {
                     "type": "integer"
-                },
-                "page_number": {
-                    "description": "Page reference for documents",
+                }
+            }
+        },
+        "conversationresponses.ConversationResponse": {
+            "type": "object",
+            "properties": {
+                "created_at": {
                     "type": "integer"
                 },
-                "quote": {
-                    "description": "Actual quoted text from source",
+                "id": {
                     "type": "string"
                 },
-                "start_index": {
-                    "description": "Start position in text",
-                    "type": "integer"
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "text": {
-                    "description": "Display text",
+                "object": {
                     "type": "string"
                 },
-                "type": {
-                    "description": "\"file_citation\", \"url_citation\", \"file_path\", etc.",
+                "project_id": {
                     "type": "string"
                 },
-                "url": {
-                    "description": "For URL citations",
+                "referrer": {
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
+                },
+                "title_locked": {
+                    "type": "boolean"
+                },
+                "updated_at": {
+                    "type": "integer"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "conversation.AudioContent": {
+        "conversationresponses.InstructionResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Base64 encoded audio data",
+                "conversation_id": {
                     "type": "string"
                 },
-                "format": {
-                    "description": "Audio format: mp3, wav, pcm16, etc.",
+                "instruction": {
+                    "description": "Pinned instruction text",
                     "type": "string"
                 },
-                "id": {
+                "latest_version": {
+                    "description": "The project's current instruction version",
+                    "type": "integer"
+                },
+                "object": {
                     "type": "string"
                 },
-                "transcript": {
-                    "description": "Text transcription of audio",
+                "pinned": {
+                    "description": "False until the first completion pins the project's instruction",
+                    "type": "boolean"
+                },
+                "project_id": {
                     "type": "string"
+                },
+                "stale": {
+                    "description": "True if the project's instruction changed since it was pinned",
+                    "type": "boolean"
+                },
+                "version": {
+                    "description": "Project instruction version the conversation is pinned to",
+                    "type": "integer"
                 }
             }
         },
-        "conversation.BBox": {
+        "conversationresponses.ItemEditListResponse": {
             "type": "object",
             "properties": {
-                "height": {
-                    "type": "number"
-                },
-                "width": {
-                    "type": "number"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.ItemEdit"
+                    }
                 },
-                "x": {
-                    "type": "number"
+                "item_id": {
+                    "type": "string"
                 },
-                "y": {
-                    "type": "number"
+                "object": {
+                    "type": "string"
                 }
             }
         },
-        "conversation.CodeContent": {
+        "conversationresponses.ItemListResponse": {
             "type": "object",
             "properties": {
-                "code": {
-                    "description": "Code content",
-                    "type": "string"
-                },
-                "error": {
-                    "description": "Execution error",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.Item"
+                    }
                 },
-                "execution_id": {
-                    "description": "Execution session ID",
+                "first_id": {
                     "type": "string"
                 },
-                "exit_code": {
-                    "description": "Process exit code",
-                    "type": "integer"
+                "has_more": {
+                    "type": "boolean"
                 },
-                "language": {
-                    "description": "Programming language",
+                "last_id": {
                     "type": "string"
                 },
-                "metadata": {
-                    "description": "Additional metadata",
-                    "type": "object",
-                    "additionalProperties": {}
+                "next_cursor": {
+                    "description": "NextCursor is an opaque token for the next page (pass as ` + "`" + `after` + "`" + `)",
+                    "type": "string"
                 },
-                "output": {
-                    "description": "Execution output",
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "conversation.ComputerAction": {
+        "conversationresponses.ItemResponse": {
             "type": "object",
             "properties": {
+                "acknowledged_safety_checks": {
+                    "description": "For computer call outputs",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.SafetyCheck"
+                    }
+                },
                 "action": {
-                    "description": "Action type: \"click\", \"type\", \"key\", \"scroll\", \"move\", etc.",
+                    "description": "For computer/shell actions",
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "approval_request_id": {
+                    "description": "For MCP approval",
                     "type": "string"
                 },
-                "coordinates": {
-                    "description": "Screen coordinates for mouse actions",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.Coordinates"
-                        }
-                    ]
+                "approve": {
+                    "description": "For MCP approval response",
+                    "type": "boolean"
                 },
-                "key": {
-                    "description": "Key for keyboard actions",
+                "arguments": {
+                    "description": "For tool calls (JSON string)",
                     "type": "string"
                 },
-                "metadata": {
-                    "description": "Additional action metadata",
-                    "type": "object",
-                    "additionalProperties": {}
+                "branch": {
+                    "description": "Branch identifier (MAIN, EDIT_1, etc.)",
+                    "type": "string"
                 },
-                "scroll_delta": {
-                    "description": "Scroll amount",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.ScrollDelta"
-                        }
-                    ]
+                "call_id": {
+                    "description": "OpenAI-compatible fields for specific item types",
+                    "type": "string"
                 },
-                "text": {
-                    "description": "Text for typing actions",
+                "cold_stored": {
+                    "description": "Cold storage marker: the item is a stub whose content, arguments and output\nwere archived; rehydrating the conversation restores them",
+                    "type": "boolean"
+                },
+                "commands": {
+                    "description": "For shell calls",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "completed_at": {
                     "type": "string"
-                }
-            }
-        },
-        "conversation.Content": {
-            "type": "object",
-            "properties": {
-                "audio": {
-                    "description": "Audio content for speech",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.AudioContent"
-                        }
-                    ]
                 },
-                "code": {
-                    "description": "Code block with execution metadata",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.CodeContent"
-                        }
-                    ]
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.Content"
+                    }
                 },
-                "computer_action": {
-                    "description": "Computer interaction details",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.ComputerAction"
-                        }
-                    ]
+                "created_at": {
+                    "type": "string"
                 },
-                "computer_screenshot": {
-                    "description": "Screenshot from computer use",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.ScreenshotContent"
-                        }
-                    ]
+                "edited": {
+                    "description": "True once the item has been edited in place",
+                    "type": "boolean"
                 },
-                "file": {
-                    "description": "File content",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.FileContent"
-                        }
-                    ]
+                "edited_at": {
+                    "description": "When the item was last edited",
+                    "type": "string"
+                },
+                "error": {
+                    "description": "For failed calls",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "incomplete_at": {
+                    "type": "string"
+                },
+                "incomplete_details": {
+                    "$ref": "#/definitions/conversation.IncompleteDetails"
+                },
+                "max_output_length": {
+                    "description": "For shell calls",
+                    "type": "integer"
+                },
+                "name": {
+                    "description": "For MCP tool calls - tool name",
+                    "type": "string"
                 },
-                "finish_reason": {
-                    "description": "Finish reason",
+                "object": {
+                    "description": "Always \"conversation.item\" for OpenAI compatibility",
                     "type": "string"
                 },
-                "function_call": {
-                    "description": "Function call content (deprecated, use tool_calls)",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.FunctionCall"
-                        }
-                    ]
+                "operation": {
+                    "description": "For patch operations",
+                    "type": "object",
+                    "additionalProperties": true
                 },
-                "function_call_output": {
-                    "description": "Function call output",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.FunctionCallOut"
-                        }
-                    ]
+                "output": {
+                    "description": "For tool call outputs",
+                    "type": "string"
                 },
-                "image": {
-                    "description": "Image content",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.ImageContent"
-                        }
-                    ]
+                "pending_safety_checks": {
+                    "description": "For computer calls",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.SafetyCheck"
+                    }
                 },
-                "input_audio": {
-                    "description": "User audio input",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.InputAudio"
-                        }
-                    ]
+                "rated_at": {
+                    "description": "When rating was given",
+                    "type": "string"
                 },
-                "output_text": {
-                    "description": "AI output text (with annotations)",
+                "rating": {
+                    "description": "User feedback/rating",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/conversation.OutputText"
+                            "$ref": "#/definitions/conversation.ItemRating"
                         }
                     ]
                 },
-                "refusal": {
-                    "description": "Model refusal message",
+                "rating_comment": {
+                    "description": "Optional comment with rating",
                     "type": "string"
                 },
-                "summary_text": {
-                    "description": "Summary content",
+                "reason": {
+                    "description": "For MCP approval reason",
                     "type": "string"
                 },
-                "thinking": {
-                    "description": "Internal reasoning (o1 models)",
-                    "type": "string"
+                "role": {
+                    "$ref": "#/definitions/conversation.ItemRole"
                 },
-                "tool_call_id": {
-                    "description": "Tool call ID (for tool responses)",
+                "sequence_number": {
+                    "description": "Order within branch",
+                    "type": "integer"
+                },
+                "server_label": {
+                    "description": "For MCP calls",
                     "type": "string"
                 },
-                "tool_calls": {
-                    "description": "Tool calls (for assistant messages)",
+                "shell_outputs": {
+                    "description": "For shell call outputs",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.ToolCall"
+                        "$ref": "#/definitions/conversation.ShellOutput"
+                    }
+                },
+                "status": {
+                    "$ref": "#/definitions/conversation.ItemStatus"
+                },
+                "tools": {
+                    "description": "For mcp_list_tools",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/conversation.McpTool"
                     }
                 },
                 "type": {
-                    "type": "string"
+                    "$ref": "#/definitions/conversation.ItemType"
                 }
             }
         },
-        "conversation.Coordinates": {
+        "conversationresponses.RehydrationResponse": {
             "type": "object",
             "properties": {
-                "x": {
-                    "type": "integer"
+                "conversation_id": {
+                    "type": "string"
                 },
-                "y": {
+                "items_restored": {
+                    "description": "0 when no items were in cold storage",
                     "type": "integer"
+                },
+                "object": {
+                    "type": "string"
                 }
             }
         },
-        "conversation.FileContent": {
+        "eval.GraderSpec": {
             "type": "object",
             "properties": {
-                "file_id": {
+                "case_sensitive": {
+                    "description": "exact_match only",
+                    "type": "boolean"
+                },
+                "judge_model": {
+                    "description": "llm_judge only; defaults to EVAL_JUDGE_MODEL",
                     "type": "string"
                 },
-                "mime_type": {
+                "pass_threshold": {
+                    "description": "llm_judge only; 0-1",
+                    "type": "number"
+                },
+                "rubric": {
+                    "description": "llm_judge only; extra grading instructions",
                     "type": "string"
                 },
-                "name": {
+                "type": {
+                    "description": "exact_match or llm_judge",
                     "type": "string"
+                }
+            }
+        },
+        "eval.ItemChange": {
+            "type": "object",
+            "properties": {
+                "baseline_passed": {
+                    "type": "boolean"
                 },
-                "size": {
+                "baseline_score": {
+                    "type": "number"
+                },
+                "candidate_passed": {
+                    "type": "boolean"
+                },
+                "candidate_score": {
+                    "type": "number"
+                },
+                "item_id": {
+                    "type": "integer"
+                },
+                "position": {
                     "type": "integer"
                 }
             }
         },
-        "conversation.FunctionCall": {
+        "evalhandler.DatasetDetailResponse": {
             "type": "object",
             "properties": {
-                "arguments": {
-                    "description": "JSON-encoded arguments",
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
+                "grader": {
+                    "$ref": "#/definitions/eval.GraderSpec"
+                },
                 "id": {
-                    "description": "Call ID",
                     "type": "string"
                 },
+                "item_count": {
+                    "type": "integer"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/evalhandler.DatasetItemResponse"
+                    }
+                },
                 "name": {
-                    "description": "Function name",
-                    "type": "string"
-                }
-            }
-        },
-        "conversation.FunctionCallOut": {
-            "type": "object",
-            "properties": {
-                "call_id": {
-                    "description": "ID of the function call this responds to",
                     "type": "string"
                 },
-                "output": {
-                    "description": "String output from the function",
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "conversation.ImageContent": {
+        "evalhandler.DatasetItemResponse": {
             "type": "object",
             "properties": {
-                "detail": {
-                    "description": "\"low\", \"high\", \"auto\"",
+                "expected": {
                     "type": "string"
                 },
-                "file_id": {
-                    "type": "string"
+                "grader": {
+                    "$ref": "#/definitions/eval.GraderSpec"
                 },
-                "url": {
+                "input": {
                     "type": "string"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "variables": {
+                    "type": "object",
+                    "additionalProperties": {}
                 }
             }
         },
-        "conversation.IncompleteDetails": {
+        "evalhandler.DatasetListResponse": {
             "type": "object",
             "properties": {
-                "error": {
-                    "description": "Error message if applicable",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/evalhandler.DatasetResponse"
+                    }
                 },
-                "reason": {
-                    "description": "\"max_tokens\", \"content_filter\", \"tool_calls\", etc.",
+                "object": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "conversation.InputAudio": {
+        "evalhandler.DatasetResponse": {
             "type": "object",
             "properties": {
-                "data": {
-                    "description": "Base64 encoded audio data",
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "format": {
-                    "description": "Audio format: mp3, wav, pcm16, etc.",
+                "grader": {
+                    "$ref": "#/definitions/eval.GraderSpec"
+                },
+                "id": {
                     "type": "string"
                 },
-                "transcript": {
-                    "description": "Optional text transcription",
+                "item_count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "conversation.Item": {
+        "evalhandler.RunComparisonResponse": {
             "type": "object",
             "properties": {
-                "acknowledged_safety_checks": {
-                    "description": "For computer call outputs",
+                "baseline": {
+                    "$ref": "#/definitions/evalhandler.RunResponse"
+                },
+                "candidate": {
+                    "$ref": "#/definitions/evalhandler.RunResponse"
+                },
+                "improvements": {
+                    "description": "Failed in the baseline, passed in the candidate",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.SafetyCheck"
+                        "$ref": "#/definitions/eval.ItemChange"
                     }
                 },
-                "action": {
-                    "description": "For computer/shell actions",
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "approval_request_id": {
-                    "description": "For MCP approval",
-                    "type": "string"
-                },
-                "approve": {
-                    "description": "For MCP approval response",
-                    "type": "boolean"
-                },
-                "arguments": {
-                    "description": "For tool calls (JSON string)",
+                "object": {
                     "type": "string"
                 },
-                "branch": {
-                    "description": "Branch identifier (MAIN, EDIT_1, etc.)",
-                    "type": "string"
+                "pass_rate_delta": {
+                    "type": "number"
                 },
-                "call_id": {
-                    "description": "OpenAI-compatible fields for specific item types",
-                    "type": "string"
+                "regressions": {
+                    "description": "Passed in the baseline, failed in the candidate",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/eval.ItemChange"
+                    }
                 },
-                "commands": {
-                    "description": "For shell calls",
+                "score_delta": {
+                    "type": "number"
+                }
+            }
+        },
+        "evalhandler.RunListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/evalhandler.RunResponse"
                     }
                 },
+                "object": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                }
+            }
+        },
+        "evalhandler.RunResponse": {
+            "type": "object",
+            "properties": {
+                "average_score": {
+                    "type": "number"
+                },
                 "completed_at": {
-                    "type": "string"
+                    "type": "integer"
                 },
-                "content": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/conversation.Content"
-                    }
+                "completed_items": {
+                    "type": "integer"
                 },
                 "created_at": {
+                    "type": "integer"
+                },
+                "dataset_id": {
                     "type": "string"
                 },
                 "error": {
-                    "description": "For failed calls",
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "errored_items": {
+                    "type": "integer"
                 },
-                "incomplete_at": {
+                "id": {
                     "type": "string"
                 },
-                "incomplete_details": {
-                    "$ref": "#/definitions/conversation.IncompleteDetails"
-                },
-                "max_output_length": {
-                    "description": "For shell calls",
-                    "type": "integer"
-                },
-                "name": {
-                    "description": "For MCP tool calls - tool name",
+                "model": {
                     "type": "string"
                 },
                 "object": {
-                    "description": "Always \"conversation.item\" for OpenAI compatibility",
                     "type": "string"
                 },
-                "operation": {
-                    "description": "For patch operations",
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "output": {
-                    "description": "For tool call outputs",
-                    "type": "string"
+                "pass_rate": {
+                    "type": "number"
                 },
-                "pending_safety_checks": {
-                    "description": "For computer calls",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/conversation.SafetyCheck"
-                    }
+                "passed_items": {
+                    "type": "integer"
                 },
-                "rated_at": {
-                    "description": "When rating was given",
+                "prompt_template_id": {
                     "type": "string"
                 },
-                "rating": {
-                    "description": "User feedback/rating",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/conversation.ItemRating"
-                        }
-                    ]
+                "started_at": {
+                    "type": "integer"
                 },
-                "rating_comment": {
-                    "description": "Optional comment with rating",
+                "status": {
                     "type": "string"
                 },
-                "reason": {
-                    "description": "For MCP approval reason",
+                "template_key": {
                     "type": "string"
                 },
-                "role": {
-                    "$ref": "#/definitions/conversation.ItemRole"
-                },
-                "sequence_number": {
-                    "description": "Order within branch",
+                "template_version": {
                     "type": "integer"
                 },
-                "server_label": {
-                    "description": "For MCP calls",
-                    "type": "string"
-                },
-                "shell_outputs": {
-                    "description": "For shell call outputs",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/conversation.ShellOutput"
-                    }
-                },
-                "status": {
-                    "$ref": "#/definitions/conversation.ItemStatus"
-                },
-                "tools": {
-                    "description": "For mcp_list_tools",
+                "total_items": {
+                    "type": "integer"
+                }
+            }
+        },
+        "evalhandler.RunResultListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.McpTool"
+                        "$ref": "#/definitions/evalhandler.RunResultResponse"
                     }
                 },
-                "type": {
-                    "$ref": "#/definitions/conversation.ItemType"
-                }
-            }
-        },
-        "conversation.ItemRating": {
-            "type": "string",
-            "enum": [
-                "like",
-                "unlike"
-            ],
-            "x-enum-comments": {
-                "ItemRatingLike": "Positive feedback (like)",
-                "ItemRatingUnlike": "Negative feedback (unlike)"
-            },
-            "x-enum-varnames": [
-                "ItemRatingLike",
-                "ItemRatingUnlike"
-            ]
-        },
-        "conversation.ItemRole": {
-            "type": "string",
-            "enum": [
-                "system",
-                "user",
-                "assistant",
-                "tool",
-                "developer",
-                "critic",
-                "discriminator",
-                "unknown"
-            ],
-            "x-enum-comments": {
-                "ItemRoleCritic": "For critique/evaluation workflows",
-                "ItemRoleDeveloper": "System-level instructions (OpenAI replacement for system)",
-                "ItemRoleDiscriminator": "For adversarial/validation workflows",
-                "ItemRoleUnknown": "Fallback for unrecognized roles"
-            },
-            "x-enum-varnames": [
-                "ItemRoleSystem",
-                "ItemRoleUser",
-                "ItemRoleAssistant",
-                "ItemRoleTool",
-                "ItemRoleDeveloper",
-                "ItemRoleCritic",
-                "ItemRoleDiscriminator",
-                "ItemRoleUnknown"
-            ]
-        },
-        "conversation.ItemStatus": {
-            "type": "string",
-            "enum": [
-                "incomplete",
-                "in_progress",
-                "completed",
-                "failed",
-                "cancelled",
-                "searching",
-                "generating",
-                "calling",
-                "streaming",
-                "rate_limited"
-            ],
-            "x-enum-comments": {
-                "ItemStatusCalling": "Function/tool call in progress",
-                "ItemStatusCancelled": "Cancelled by user or system",
-                "ItemStatusCompleted": "Successfully finished",
-                "ItemStatusFailed": "Failed with error",
-                "ItemStatusGenerating": "Image generation in progress",
-                "ItemStatusInProgress": "Currently processing",
-                "ItemStatusIncomplete": "Not started or partially complete (OpenAI uses this instead of \"pending\")",
-                "ItemStatusRateLimited": "Rate limit hit",
-                "ItemStatusSearching": "File/web search in progress",
-                "ItemStatusStreaming": "Streaming response in progress"
-            },
-            "x-enum-varnames": [
-                "ItemStatusIncomplete",
-                "ItemStatusInProgress",
-                "ItemStatusCompleted",
-                "ItemStatusFailed",
-                "ItemStatusCancelled",
-                "ItemStatusSearching",
-                "ItemStatusGenerating",
-                "ItemStatusCalling",
-                "ItemStatusStreaming",
-                "ItemStatusRateLimited"
-            ]
-        },
-        "conversation.ItemType": {
-            "type": "string",
-            "enum": [
-                "message",
-                "function_call",
-                "function_call_output",
-                "reasoning",
-                "file_search_call",
-                "web_search_call",
-                "image_generation_call",
-                "image_edit_call",
-                "computer_call",
-                "computer_call_output",
-                "code_interpreter_call",
-                "local_shell_call",
-                "local_shell_call_output",
-                "shell_call",
-                "shell_call_output",
-                "apply_patch_call",
-                "apply_patch_call_output",
-                "mcp_list_tools",
-                "mcp_approval_request",
-                "mcp_approval_response",
-                "mcp_call",
-                "custom_tool_call",
-                "custom_tool_call_output",
-                "file_search",
-                "web_search",
-                "code_interpreter",
-                "computer_use",
-                "mcp_item",
-                "image_generation"
-            ],
-            "x-enum-comments": {
-                "ItemTypeCodeInterpreter": "Legacy: maps to code_interpreter_call",
-                "ItemTypeComputerUse": "Legacy: maps to computer_call",
-                "ItemTypeFileSearch": "Legacy: maps to file_search_call",
-                "ItemTypeImageGeneration": "Legacy: maps to image_generation_call",
-                "ItemTypeMCPItem": "Legacy: maps to mcp_call",
-                "ItemTypeReasoning": "For o1/reasoning models",
-                "ItemTypeWebSearch": "Legacy: maps to web_search_call"
-            },
-            "x-enum-varnames": [
-                "ItemTypeMessage",
-                "ItemTypeFunctionCall",
-                "ItemTypeFunctionCallOut",
-                "ItemTypeReasoning",
-                "ItemTypeFileSearchCall",
-                "ItemTypeWebSearchCall",
-                "ItemTypeImageGenerationCall",
-                "ItemTypeImageEditCall",
-                "ItemTypeComputerCall",
-                "ItemTypeComputerCallOutput",
-                "ItemTypeCodeInterpreterCall",
-                "ItemTypeLocalShellCall",
-                "ItemTypeLocalShellCallOutput",
-                "ItemTypeShellCall",
-                "ItemTypeShellCallOutput",
-                "ItemTypeApplyPatchCall",
-                "ItemTypeApplyPatchCallOutput",
-                "ItemTypeMcpListTools",
-                "ItemTypeMcpApprovalRequest",
-                "ItemTypeMcpApprovalResponse",
-                "ItemTypeMcpCall",
-                "ItemTypeCustomToolCall",
-                "ItemTypeCustomToolCallOutput",
-                "ItemTypeFileSearch",
-                "ItemTypeWebSearch",
-                "ItemTypeCodeInterpreter",
-                "ItemTypeComputerUse",
-                "ItemTypeMCPItem",
-                "ItemTypeImageGeneration"
-            ]
+                "object": {
+                    "type": "string"
+                },
+                "run_id": {
+                    "type": "string"
+                }
+            }
         },
-        "conversation.LogProb": {
+        "evalhandler.RunResultResponse": {
             "type": "object",
             "properties": {
-                "bytes": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "error": {
+                    "type": "string"
                 },
-                "logprob": {
-                    "type": "number"
+                "grader": {
+                    "type": "string"
                 },
-                "token": {
+                "latency_ms": {
+                    "type": "integer"
+                },
+                "output": {
                     "type": "string"
                 },
-                "top_logprobs": {
+                "passed": {
+                    "type": "boolean"
+                },
+                "position": {
+                    "type": "integer"
+                },
+                "reasoning": {
+                    "type": "string"
+                },
+                "score": {
+                    "type": "number"
+                }
+            }
+        },
+        "evalrequests.CreateDatasetRequest": {
+            "type": "object",
+            "required": [
+                "grader",
+                "items",
+                "name"
+            ],
+            "properties": {
+                "description": {
+                    "type": "string"
+                },
+                "grader": {
+                    "$ref": "#/definitions/evalrequests.GraderSpec"
+                },
+                "items": {
                     "type": "array",
+                    "minItems": 1,
                     "items": {
-                        "$ref": "#/definitions/conversation.TopLogProb"
+                        "$ref": "#/definitions/evalrequests.DatasetItem"
                     }
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 255
                 }
             }
         },
-        "conversation.McpTool": {
+        "evalrequests.CreateRunRequest": {
             "type": "object",
+            "required": [
+                "dataset_id",
+                "model"
+            ],
             "properties": {
-                "annotations": {},
-                "description": {
+                "dataset_id": {
                     "type": "string"
                 },
-                "input_schema": {},
-                "name": {
+                "model": {
+                    "description": "Model public ID",
+                    "type": "string"
+                },
+                "prompt_template_id": {
+                    "description": "Takes precedence over template_key",
+                    "type": "string"
+                },
+                "template_key": {
+                    "description": "Uses the template's current version",
                     "type": "string"
                 }
             }
         },
-        "conversation.OutputText": {
+        "evalrequests.DatasetItem": {
             "type": "object",
+            "required": [
+                "input"
+            ],
             "properties": {
-                "annotations": {
-                    "description": "Required for OpenAI compatibility",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/conversation.Annotation"
-                    }
+                "expected": {
+                    "description": "Required for exact_match grading",
+                    "type": "string"
                 },
-                "logprobs": {
-                    "description": "Token probabilities",
+                "grader": {
+                    "description": "Overrides the dataset grader",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/evalrequests.GraderSpec"
+                        }
+                    ]
+                },
+                "input": {
+                    "type": "string"
+                },
+                "variables": {
+                    "description": "Prompt template variables for this item",
+                    "type": "object",
+                    "additionalProperties": {}
+                }
+            }
+        },
+        "evalrequests.GraderSpec": {
+            "type": "object",
+            "required": [
+                "type"
+            ],
+            "properties": {
+                "case_sensitive": {
+                    "description": "exact_match only",
+                    "type": "boolean"
+                },
+                "judge_model": {
+                    "description": "llm_judge only; defaults to EVAL_JUDGE_MODEL",
+                    "type": "string"
+                },
+                "pass_threshold": {
+                    "description": "llm_judge only; 0-1, default 0.5",
+                    "type": "number"
+                },
+                "rubric": {
+                    "description": "llm_judge only",
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "exact_match",
+                        "llm_judge"
+                    ]
+                }
+            }
+        },
+        "finetunehandler.ExportListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.LogProb"
+                        "$ref": "#/definitions/finetunehandler.ExportResponse"
                     }
                 },
-                "text": {
+                "object": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "conversation.SafetyCheck": {
+        "finetunehandler.ExportResponse": {
             "type": "object",
             "properties": {
-                "reason": {
+                "bytes": {
+                    "type": "integer"
+                },
+                "completed_at": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "type": {
+                "example_count": {
+                    "type": "integer"
+                },
+                "format": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "max_context_messages": {
+                    "type": "integer"
+                },
+                "media_id": {
+                    "description": "Download with GET /v1/media/{media_id} on media-api",
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "rated_after": {
+                    "type": "integer"
+                },
+                "rated_before": {
+                    "type": "integer"
+                },
+                "redact_pii": {
+                    "type": "boolean"
+                },
+                "status": {
                     "type": "string"
                 }
             }
         },
-        "conversation.ScreenshotContent": {
+        "finetunerequests.CreateExportRequest": {
             "type": "object",
+            "required": [
+                "format"
+            ],
             "properties": {
-                "description": {
-                    "description": "Optional description",
+                "format": {
+                    "type": "string",
+                    "enum": [
+                        "openai",
+                        "chatml",
+                        "sharegpt"
+                    ]
+                },
+                "max_context_messages": {
+                    "description": "Messages kept before each liked turn; default 20",
+                    "type": "integer",
+                    "maximum": 200,
+                    "minimum": 1
+                },
+                "rated_after": {
+                    "description": "RFC 3339; inclusive",
+                    "type": "string"
+                },
+                "rated_before": {
+                    "description": "RFC 3339; exclusive",
                     "type": "string"
                 },
-                "height": {
-                    "description": "Image height in pixels",
-                    "type": "integer"
+                "redact_pii": {
+                    "description": "Defaults to true",
+                    "type": "boolean"
+                }
+            }
+        },
+        "image.ImageData": {
+            "description": "Single generated image data",
+            "type": "object",
+            "properties": {
+                "b64_json": {
+                    "description": "B64JSON is the base64-encoded image data.\nPresent when response_format=\"b64_json\".",
+                    "type": "string"
+                },
+                "id": {
+                    "description": "ID is the Jan media ID (jan_xxxxx) for the stored image.",
+                    "type": "string",
+                    "example": "jan_abc123"
+                },
+                "revised_prompt": {
+                    "description": "RevisedPrompt is the revised prompt used for generation, if the provider modified it.",
+                    "type": "string"
+                },
+                "url": {
+                    "description": "URL is the presigned URL to the generated image.\nPresent when response_format=\"url\".",
+                    "type": "string",
+                    "example": "https://media.jan.ai/images/jan_abc123.png?sig=..."
+                }
+            }
+        },
+        "image.ImageEditRequest": {
+            "type": "object",
+            "required": [
+                "image",
+                "prompt"
+            ],
+            "properties": {
+                "cfg_scale": {
+                    "description": "CfgScale is classifier-free guidance scale.",
+                    "type": "number",
+                    "example": 1
+                },
+                "conversation_id": {
+                    "description": "ConversationID optionally links this edit to a conversation.",
+                    "type": "string",
+                    "example": "conv_abc123"
+                },
+                "image": {
+                    "description": "Image is the input image to edit. Required.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/image.ImageInput"
+                        }
+                    ]
+                },
+                "mask": {
+                    "description": "Mask is an optional mask for inpainting.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/image.ImageInput"
+                        }
+                    ]
+                },
+                "model": {
+                    "description": "Model specifies the image edit model. Optional.",
+                    "type": "string",
+                    "example": "qwen-image-edit"
+                },
+                "n": {
+                    "description": "N is the number of images to generate (only 1 supported by most providers).",
+                    "type": "integer",
+                    "example": 1
+                },
+                "negative_prompt": {
+                    "description": "NegativePrompt describes what to avoid.",
+                    "type": "string",
+                    "example": " "
+                },
+                "prompt": {
+                    "description": "Prompt is the text instruction describing the edit. Required.",
+                    "type": "string",
+                    "example": "add golden sunglasses"
+                },
+                "provider_id": {
+                    "description": "ProviderID optionally overrides the default image edit provider.",
+                    "type": "string",
+                    "example": "prov_abc123"
+                },
+                "response_format": {
+                    "description": "ResponseFormat determines output format (\"url\" or \"b64_json\").",
+                    "type": "string",
+                    "example": "b64_json"
+                },
+                "sampler": {
+                    "description": "Sampler selects the sampling algorithm.",
+                    "type": "string",
+                    "example": "euler"
+                },
+                "scheduler": {
+                    "description": "Scheduler selects the scheduler.",
+                    "type": "string",
+                    "example": "simple"
                 },
-                "image_data": {
-                    "description": "Base64 encoded image data",
-                    "type": "string"
+                "seed": {
+                    "description": "Seed sets random seed (-1 for random).",
+                    "type": "integer",
+                    "example": -1
                 },
-                "image_url": {
-                    "description": "URL to screenshot image",
-                    "type": "string"
+                "size": {
+                    "description": "Size specifies the output size (\"original\" or \"WIDTHxHEIGHT\").",
+                    "type": "string",
+                    "example": "original"
                 },
-                "timestamp": {
-                    "description": "Unix timestamp when screenshot was taken",
-                    "type": "integer"
+                "steps": {
+                    "description": "Steps controls sampling steps.",
+                    "type": "integer",
+                    "example": 4
                 },
-                "width": {
-                    "description": "Image width in pixels",
-                    "type": "integer"
-                }
-            }
-        },
-        "conversation.ScrollDelta": {
-            "type": "object",
-            "properties": {
-                "x": {
-                    "type": "integer"
+                "store": {
+                    "description": "Store controls whether to save the result to the conversation.\nnil/true = store (default), false = don't store.",
+                    "type": "boolean",
+                    "example": true
                 },
-                "y": {
-                    "type": "integer"
+                "strength": {
+                    "description": "Strength controls edit intensity (0.0-1.0).",
+                    "type": "number",
+                    "example": 1
                 }
             }
         },
-        "conversation.ShellOutput": {
+        "image.ImageGenerationRequest": {
+            "description": "OpenAI-compatible image generation request",
             "type": "object",
+            "required": [
+                "prompt"
+            ],
             "properties": {
-                "type": {
-                    "description": "stdout, stderr, exit_code",
-                    "type": "string"
+                "cfg_scale": {
+                    "description": "CfgScale is a provider-specific parameter (z-image/Flux) for guidance scale.",
+                    "type": "number",
+                    "example": 7.5
                 },
-                "value": {
-                    "type": "string"
-                }
-            }
-        },
-        "conversation.ToolCall": {
-            "type": "object",
-            "properties": {
-                "function": {
-                    "$ref": "#/definitions/conversation.FunctionCall"
+                "conversation_id": {
+                    "description": "ConversationID optionally links this generation to a conversation.",
+                    "type": "string",
+                    "example": "conv_abc123"
                 },
-                "id": {
-                    "type": "string"
+                "model": {
+                    "description": "Model specifies the image generation model (e.g., \"z-image\", \"flux-dev\", \"dall-e-3\").\nIf omitted, defaults to the configured default model.",
+                    "type": "string",
+                    "example": "z-image"
                 },
-                "type": {
-                    "description": "\"function\", \"file_search\", \"code_interpreter\"",
-                    "type": "string"
-                }
-            }
-        },
-        "conversation.TopLogProb": {
-            "type": "object",
-            "properties": {
-                "bytes": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "n": {
+                    "description": "N is the number of images to generate (1-10, default: 1).",
+                    "type": "integer",
+                    "example": 1
                 },
-                "logprob": {
-                    "type": "number"
+                "num_inference_steps": {
+                    "description": "NumInferenceSteps is a provider-specific parameter (z-image/Flux).",
+                    "type": "integer",
+                    "example": 20
                 },
-                "token": {
-                    "type": "string"
-                }
-            }
-        },
-        "conversationhandler.ActivateBranchResponse": {
-            "type": "object",
-            "properties": {
-                "active_branch": {
-                    "type": "string"
+                "prompt": {
+                    "description": "Prompt is the text description of the desired image. Required.",
+                    "type": "string",
+                    "example": "A serene mountain landscape at sunset"
                 },
-                "message": {
-                    "type": "string"
-                }
-            }
-        },
-        "conversationhandler.BranchResponse": {
-            "type": "object",
-            "properties": {
-                "created_at": {
-                    "type": "integer"
+                "provider_id": {
+                    "description": "ProviderID optionally overrides the default image provider selection.",
+                    "type": "string",
+                    "example": "prov_abc123"
                 },
-                "description": {
-                    "type": "string"
+                "quality": {
+                    "description": "Quality determines image quality. Valid values: \"standard\", \"hd\".\nDefault: \"standard\".",
+                    "type": "string",
+                    "example": "standard"
                 },
-                "forked_at": {
-                    "type": "integer"
+                "response_format": {
+                    "description": "ResponseFormat determines output format. Valid values: \"url\", \"b64_json\".\nDefault: \"url\".",
+                    "type": "string",
+                    "example": "url"
                 },
-                "forked_from_item_id": {
-                    "type": "string"
+                "size": {
+                    "description": "Size specifies the dimensions of the generated image.\nSupported sizes: \"256x256\", \"512x512\", \"1024x1024\", \"1024x1792\", \"1792x1024\".\nDefault: \"1024x1024\".",
+                    "type": "string",
+                    "example": "1024x1024"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "store": {
+                    "description": "Store controls whether to save the result to the conversation.\nnil/true = store (default), false = don't store.",
+                    "type": "boolean",
+                    "example": true
                 },
-                "item_count": {
-                    "type": "integer"
+                "style": {
+                    "description": "Style influences the visual aesthetic. Valid values: \"vivid\", \"natural\".\nDefault: \"natural\".",
+                    "type": "string",
+                    "example": "natural"
                 },
-                "name": {
-                    "type": "string"
+                "user": {
+                    "description": "User is an optional unique identifier representing the end-user for abuse monitoring.",
+                    "type": "string",
+                    "example": "user-123"
+                }
+            }
+        },
+        "image.ImageGenerationResponse": {
+            "description": "OpenAI-compatible image generation response",
+            "type": "object",
+            "properties": {
+                "created": {
+                    "description": "Created is the Unix timestamp of when the response was generated.",
+                    "type": "integer",
+                    "example": 1699000000
                 },
-                "parent_branch": {
-                    "type": "string"
+                "data": {
+                    "description": "Data contains the generated images.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/image.ImageData"
+                    }
                 },
-                "updated_at": {
-                    "type": "integer"
+                "usage": {
+                    "description": "Usage contains token usage information for billing purposes.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/image.ImageUsage"
+                        }
+                    ]
                 }
             }
         },
-        "conversationhandler.CreateBranchRequest": {
+        "image.ImageInput": {
             "type": "object",
-            "required": [
-                "name"
-            ],
             "properties": {
-                "description": {
-                    "type": "string"
-                },
-                "fork_from_item_id": {
+                "b64_json": {
+                    "description": "B64JSON is base64-encoded image data (no data URL prefix).",
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "id": {
+                    "description": "ID is a Jan media ID (jan_*).",
+                    "type": "string",
+                    "example": "jan_abc123"
                 },
-                "parent_branch": {
-                    "type": "string"
+                "url": {
+                    "description": "URL is a remote URL to the image.",
+                    "type": "string",
+                    "example": "https://example.com/image.png"
                 }
             }
         },
-        "conversationhandler.DeleteItemResponse": {
+        "image.ImageUsage": {
+            "description": "Token usage information for billing",
             "type": "object",
             "properties": {
-                "branch": {
-                    "description": "Always \"MAIN\" after swap",
-                    "type": "string"
+                "input_tokens": {
+                    "description": "InputTokens is the estimated tokens for the prompt.",
+                    "type": "integer",
+                    "example": 100
                 },
-                "branch_created": {
-                    "type": "boolean"
+                "input_tokens_details": {
+                    "description": "InputTokensDetails provides a breakdown of input token types.",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/image.InputTokensDetail"
+                        }
+                    ]
                 },
-                "deleted": {
-                    "type": "boolean"
+                "output_tokens": {
+                    "description": "OutputTokens is the estimated tokens for the generated images.",
+                    "type": "integer",
+                    "example": 1400
                 },
-                "old_main_backup": {
-                    "description": "Backup name for old MAIN",
-                    "type": "string"
+                "total_tokens": {
+                    "description": "TotalTokens is the sum of input and output tokens.",
+                    "type": "integer",
+                    "example": 1500
                 }
             }
         },
-        "conversationhandler.EditMessageRequest": {
+        "image.InputTokensDetail": {
+            "description": "Breakdown of input token types",
             "type": "object",
-            "required": [
-                "content"
-            ],
             "properties": {
-                "content": {
-                    "type": "string"
+                "image_tokens": {
+                    "description": "ImageTokens is the number of tokens from input images (for edit/variation operations).",
+                    "type": "integer",
+                    "example": 0
                 },
-                "regenerate": {
-                    "description": "Auto-trigger new response (default: true)",
-                    "type": "boolean"
+                "text_tokens": {
+                    "description": "TextTokens is the number of tokens from the text prompt.",
+                    "type": "integer",
+                    "example": 100
                 }
             }
         },
-        "conversationhandler.EditMessageResponse": {
+        "maintenance.State": {
             "type": "object",
             "properties": {
-                "branch": {
-                    "description": "Always \"MAIN\" after swap",
-                    "type": "string"
-                },
-                "branch_created": {
+                "enabled": {
                     "type": "boolean"
                 },
-                "new_branch": {
-                    "$ref": "#/definitions/conversationhandler.BranchResponse"
+                "ends_at": {
+                    "description": "Expected end, sent to clients as Retry-After",
+                    "type": "string"
                 },
-                "old_main_backup": {
-                    "description": "Backup name for old MAIN",
+                "message": {
                     "type": "string"
                 },
-                "user_item": {
-                    "$ref": "#/definitions/conversation.Item"
+                "started_at": {
+                    "type": "string"
+                },
+                "started_by": {
+                    "description": "Principal that enabled it",
+                    "type": "string"
                 }
             }
         },
-        "conversationhandler.ListBranchesResponse": {
+        "mcpcredentialhandler.CredentialListResponse": {
             "type": "object",
             "properties": {
-                "active_branch": {
-                    "type": "string"
-                },
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversationhandler.BranchResponse"
+                        "$ref": "#/definitions/mcpcredentialhandler.CredentialResponse"
                     }
                 },
                 "object": {
-                    "description": "\"list\"",
-                    "type": "string"
-                }
-            }
-        },
-        "conversationhandler.RegenerateMessageRequest": {
-            "type": "object",
-            "properties": {
-                "max_tokens": {
-                    "description": "Override max tokens",
-                    "type": "integer"
-                },
-                "model": {
-                    "description": "Override model",
                     "type": "string"
-                },
-                "temperature": {
-                    "description": "Override temperature",
-                    "type": "number"
                 }
             }
         },
-        "conversationhandler.RegenerateMessageResponse": {
+        "mcpcredentialhandler.CredentialResponse": {
             "type": "object",
             "properties": {
-                "branch": {
-                    "description": "Always \"MAIN\" after swap",
+                "account": {
                     "type": "string"
                 },
-                "branch_created": {
-                    "type": "boolean"
-                },
-                "new_branch": {
-                    "$ref": "#/definitions/conversationhandler.BranchResponse"
+                "created_at": {
+                    "type": "integer"
                 },
-                "old_main_backup": {
-                    "description": "Backup name for old MAIN",
+                "endpoint": {
                     "type": "string"
                 },
-                "user_item_id": {
+                "expires_at": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "conversationrequests.CreateConversationRequest": {
-            "type": "object",
-            "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/conversation.Item"
-                    }
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
+                "last_used_at": {
+                    "type": "integer"
                 },
-                "project_id": {
+                "object": {
                     "type": "string"
                 },
-                "referrer": {
+                "provider": {
                     "type": "string"
                 },
-                "title": {
-                    "type": "string"
-                }
-            }
-        },
-        "conversationrequests.CreateItemsRequest": {
-            "type": "object",
-            "required": [
-                "items"
-            ],
-            "properties": {
-                "items": {
+                "scopes": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.Item"
-                    }
-                }
-            }
-        },
-        "conversationrequests.UpdateConversationRequest": {
-            "type": "object",
-            "properties": {
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
                         "type": "string"
                     }
                 },
-                "project_id": {
-                    "type": "string"
-                },
-                "referrer": {
-                    "type": "string"
-                },
-                "title": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "conversationrequests.UpdateItemByCallIDRequest": {
+        "mcpcredentialhandler.CredentialTokenResponse": {
             "type": "object",
-            "required": [
-                "status"
-            ],
             "properties": {
-                "arguments": {
-                    "description": "JSON string of arguments",
+                "account": {
                     "type": "string"
                 },
-                "error": {
-                    "description": "Error message if status is \"failed\"",
+                "endpoint": {
                     "type": "string"
                 },
-                "name": {
-                    "description": "Tool info fields (optional - already set on creation, but can be updated)",
-                    "type": "string"
+                "expires_at": {
+                    "type": "integer"
                 },
-                "output": {
-                    "description": "Result fields",
+                "provider": {
                     "type": "string"
                 },
-                "server_label": {
-                    "description": "MCP server label",
-                    "type": "string"
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "status": {
-                    "description": "Required fields",
+                "token": {
                     "type": "string"
                 }
             }
         },
-        "conversationresponses.BulkConversationsDeletedResponse": {
+        "mcpcredentialrequests.SaveCredentialRequest": {
             "type": "object",
+            "required": [
+                "token"
+            ],
             "properties": {
-                "deleted": {
-                    "type": "boolean"
+                "account": {
+                    "description": "Login of the connected account, for display",
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "deleted_count": {
-                    "type": "integer"
+                "endpoint": {
+                    "description": "CalDAV calendar collection URL",
+                    "type": "string",
+                    "maxLength": 2048
                 },
-                "object": {
+                "expires_at": {
+                    "description": "RFC 3339; when the token stops working",
                     "type": "string"
+                },
+                "scopes": {
+                    "description": "Scopes granted to the token; consent scopes for google, microsoft and caldav",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "token": {
+                    "description": "OAuth access token or fine-grained personal access token",
+                    "type": "string",
+                    "maxLength": 4096
                 }
             }
         },
-        "conversationresponses.ConversationDeletedResponse": {
+        "mcptool.UpdateMCPToolRequest": {
             "type": "object",
             "properties": {
-                "deleted": {
-                    "type": "boolean"
-                },
-                "id": {
+                "category": {
                     "type": "string"
                 },
-                "object": {
+                "description": {
                     "type": "string"
+                },
+                "disallowed_keywords": {
+                    "description": "Regex patterns",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
                 }
             }
         },
-        "conversationresponses.ConversationItemCreatedResponse": {
+        "mcptoolhandler.ActiveToolsResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.Item"
+                        "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
                     }
-                },
-                "first_id": {
-                    "type": "string"
-                },
-                "has_more": {
-                    "type": "boolean"
-                },
-                "last_id": {
-                    "type": "string"
-                },
-                "object": {
-                    "type": "string"
                 }
             }
         },
-        "conversationresponses.ConversationListResponse": {
+        "mcptoolhandler.ListResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversationresponses.ConversationResponse"
+                        "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
                     }
                 },
-                "first_id": {
-                    "type": "string"
-                },
-                "has_more": {
-                    "type": "boolean"
-                },
-                "last_id": {
-                    "type": "string"
-                },
-                "object": {
-                    "type": "string"
-                },
                 "total": {
                     "type": "integer"
                 }
             }
         },
-        "conversationresponses.ConversationResponse": {
+        "mcptoolhandler.MCPToolResponse": {
             "type": "object",
             "properties": {
+                "category": {
+                    "type": "string"
+                },
                 "created_at": {
-                    "type": "integer"
+                    "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
+                "disallowed_keywords": {
+                    "type": "array",
+                    "items": {
                         "type": "string"
                     }
                 },
-                "object": {
+                "id": {
                     "type": "string"
                 },
-                "project_id": {
+                "is_active": {
+                    "type": "boolean"
+                },
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "name": {
                     "type": "string"
                 },
-                "referrer": {
+                "public_id": {
                     "type": "string"
                 },
-                "title": {
+                "tool_key": {
                     "type": "string"
                 },
                 "updated_at": {
-                    "type": "integer"
+                    "type": "string"
                 }
             }
         },
-        "conversationresponses.ItemListResponse": {
+        "memorybackfillhandler.BackfillListResponse": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.Item"
+                        "$ref": "#/definitions/memorybackfillhandler.BackfillResponse"
                     }
                 },
-                "first_id": {
-                    "type": "string"
-                },
-                "has_more": {
-                    "type": "boolean"
-                },
-                "last_id": {
-                    "type": "string"
-                },
                 "object": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "conversationresponses.ItemResponse": {
+        "memorybackfillhandler.BackfillResponse": {
             "type": "object",
             "properties": {
-                "acknowledged_safety_checks": {
-                    "description": "For computer call outputs",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/conversation.SafetyCheck"
-                    }
+                "completed_at": {
+                    "type": "integer"
                 },
-                "action": {
-                    "description": "For computer/shell actions",
-                    "type": "object",
-                    "additionalProperties": true
+                "conversations_done": {
+                    "type": "integer"
                 },
-                "approval_request_id": {
-                    "description": "For MCP approval",
-                    "type": "string"
+                "conversations_failed": {
+                    "type": "integer"
                 },
-                "approve": {
-                    "description": "For MCP approval response",
-                    "type": "boolean"
+                "conversations_skipped": {
+                    "type": "integer"
                 },
-                "arguments": {
-                    "description": "For tool calls (JSON string)",
+                "created_at": {
+                    "type": "integer"
+                },
+                "created_before": {
+                    "type": "integer"
+                },
+                "error": {
                     "type": "string"
                 },
-                "branch": {
-                    "description": "Branch identifier (MAIN, EDIT_1, etc.)",
+                "id": {
                     "type": "string"
                 },
-                "call_id": {
-                    "description": "OpenAI-compatible fields for specific item types",
+                "object": {
                     "type": "string"
                 },
-                "commands": {
-                    "description": "For shell calls",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "started_at": {
+                    "type": "integer"
                 },
-                "completed_at": {
+                "status": {
                     "type": "string"
                 },
-                "content": {
+                "user_id": {
+                    "type": "integer"
+                }
+            }
+        },
+        "memorybackfillrequests.CreateBackfillRequest": {
+            "type": "object",
+            "properties": {
+                "user_id": {
+                    "description": "Only this user's conversations; every user when omitted",
+                    "type": "integer",
+                    "minimum": 1
+                }
+            }
+        },
+        "model.Architecture": {
+            "type": "object",
+            "properties": {
+                "input_modalities": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.Content"
+                        "type": "string"
                     }
                 },
-                "created_at": {
+                "instruct_type": {
+                    "description": "nullable",
                     "type": "string"
                 },
-                "error": {
-                    "description": "For failed calls",
+                "modality": {
+                    "description": "\"text+image-\u003etext\"",
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "output_modalities": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
                 },
-                "incomplete_at": {
+                "tokenizer": {
+                    "description": "\"GPT\" / \"SentencePiece\" / etc.",
                     "type": "string"
-                },
-                "incomplete_details": {
-                    "$ref": "#/definitions/conversation.IncompleteDetails"
-                },
-                "max_output_length": {
-                    "description": "For shell calls",
+                }
+            }
+        },
+        "model.ModelCatalogStatus": {
+            "type": "string",
+            "enum": [
+                "init",
+                "filled",
+                "updated"
+            ],
+            "x-enum-comments": {
+                "ModelCatalogStatusFilled": "may update from Provider like OpenRouter",
+                "ModelCatalogStatusInit": "default status when creating entry",
+                "ModelCatalogStatusUpdated": "manually updated by admin (cannot be auto-updated anymore"
+            },
+            "x-enum-varnames": [
+                "ModelCatalogStatusInit",
+                "ModelCatalogStatusFilled",
+                "ModelCatalogStatusUpdated"
+            ]
+        },
+        "model.PriceLine": {
+            "type": "object",
+            "properties": {
+                "amount_micro_usd": {
+                    "description": "e.g., 15000 -\u003e $0.0150",
                     "type": "integer"
                 },
-                "name": {
-                    "description": "For MCP tool calls - tool name",
-                    "type": "string"
-                },
-                "object": {
-                    "description": "Always \"conversation.item\" for OpenAI compatibility",
-                    "type": "string"
-                },
-                "operation": {
-                    "description": "For patch operations",
-                    "type": "object",
-                    "additionalProperties": true
-                },
-                "output": {
-                    "description": "For tool call outputs",
+                "currency": {
+                    "description": "\"USD\" (fixed if you only bill in USD)",
                     "type": "string"
                 },
-                "pending_safety_checks": {
-                    "description": "For computer calls",
+                "unit": {
+                    "$ref": "#/definitions/model.PriceUnit"
+                }
+            }
+        },
+        "model.PriceUnit": {
+            "type": "string",
+            "enum": [
+                "per_1k_prompt_tokens",
+                "per_1k_completion_tokens",
+                "per_request",
+                "per_image",
+                "per_web_search",
+                "per_internal_reasoning"
+            ],
+            "x-enum-varnames": [
+                "Per1KPromptTokens",
+                "Per1KCompletionTokens",
+                "PerRequest",
+                "PerImage",
+                "PerWebSearch",
+                "PerInternalReasoning"
+            ]
+        },
+        "model.Pricing": {
+            "type": "object",
+            "properties": {
+                "lines": {
+                    "description": "flexible: add/remove units without schema churn",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.SafetyCheck"
+                        "$ref": "#/definitions/model.PriceLine"
                     }
+                }
+            }
+        },
+        "model.ReasoningContentPolicy": {
+            "type": "string",
+            "enum": [
+                "store",
+                "stream",
+                "strip"
+            ],
+            "x-enum-comments": {
+                "ReasoningContentStore": "Returned to the client, stored when the request sets store_reasoning (default)",
+                "ReasoningContentStream": "Returned to the client, never stored",
+                "ReasoningContentStrip": "Neither returned nor stored"
+            },
+            "x-enum-varnames": [
+                "ReasoningContentStore",
+                "ReasoningContentStream",
+                "ReasoningContentStrip"
+            ]
+        },
+        "model.ReasoningPolicy": {
+            "type": "object",
+            "properties": {
+                "budget_tokens": {
+                    "description": "Thinking-token budget per completion",
+                    "type": "integer"
                 },
-                "rated_at": {
-                    "description": "When rating was given",
-                    "type": "string"
-                },
-                "rating": {
-                    "description": "User feedback/rating",
+                "content": {
+                    "description": "Empty means store",
                     "allOf": [
                         {
-                            "$ref": "#/definitions/conversation.ItemRating"
+                            "$ref": "#/definitions/model.ReasoningContentPolicy"
                         }
                     ]
+                }
+            }
+        },
+        "model.SupportedParameters": {
+            "type": "object",
+            "properties": {
+                "default": {
+                    "description": "temperature/top_p/frequency_penalty, null allowed",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
                 },
-                "rating_comment": {
-                    "description": "Optional comment with rating",
-                    "type": "string"
-                },
-                "reason": {
-                    "description": "For MCP approval reason",
-                    "type": "string"
-                },
-                "role": {
-                    "$ref": "#/definitions/conversation.ItemRole"
-                },
-                "sequence_number": {
-                    "description": "Order within branch",
-                    "type": "integer"
-                },
-                "server_label": {
-                    "description": "For MCP calls",
-                    "type": "string"
-                },
-                "shell_outputs": {
-                    "description": "For shell call outputs",
+                "names": {
+                    "description": "e.g., [\"include_reasoning\",\"max_tokens\",...]",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.ShellOutput"
+                        "type": "string"
                     }
+                }
+            }
+        },
+        "model.TokenLimits": {
+            "type": "object",
+            "properties": {
+                "context_length": {
+                    "description": "e.g., 400000",
+                    "type": "integer"
                 },
-                "status": {
-                    "$ref": "#/definitions/conversation.ItemStatus"
-                },
-                "tools": {
-                    "description": "For mcp_list_tools",
+                "max_completion_tokens": {
+                    "description": "e.g., 128000",
+                    "type": "integer"
+                }
+            }
+        },
+        "modelaliashandler.ModelAliasListResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/conversation.McpTool"
+                        "$ref": "#/definitions/modelaliashandler.ModelAliasResponse"
                     }
                 },
-                "type": {
-                    "$ref": "#/definitions/conversation.ItemType"
+                "object": {
+                    "type": "string"
                 }
             }
         },
-        "image.ImageData": {
-            "description": "Single generated image data",
+        "modelaliashandler.ModelAliasResponse": {
             "type": "object",
             "properties": {
-                "b64_json": {
-                    "description": "B64JSON is the base64-encoded image data.\nPresent when response_format=\"b64_json\".",
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
                     "type": "string"
                 },
-                "id": {
-                    "description": "ID is the Jan media ID (jan_xxxxx) for the stored image.",
-                    "type": "string",
-                    "example": "jan_abc123"
+                "name": {
+                    "type": "string"
                 },
-                "revised_prompt": {
-                    "description": "RevisedPrompt is the revised prompt used for generation, if the provider modified it.",
+                "object": {
                     "type": "string"
                 },
-                "url": {
-                    "description": "URL is the presigned URL to the generated image.\nPresent when response_format=\"url\".",
-                    "type": "string",
-                    "example": "https://media.jan.ai/images/jan_abc123.png?sig=..."
+                "rollout_model": {
+                    "type": "string"
+                },
+                "rollout_percent": {
+                    "type": "integer"
+                },
+                "target_model": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "image.ImageEditRequest": {
+        "modelaliasrequests.CreateModelAliasRequest": {
             "type": "object",
             "required": [
-                "image",
-                "prompt"
+                "name",
+                "target_model"
             ],
             "properties": {
-                "cfg_scale": {
-                    "description": "CfgScale is classifier-free guidance scale.",
-                    "type": "number",
-                    "example": 1
+                "description": {
+                    "type": "string"
                 },
-                "conversation_id": {
-                    "description": "ConversationID optionally links this edit to a conversation.",
+                "name": {
+                    "description": "e.g. jan-large",
                     "type": "string",
-                    "example": "conv_abc123"
-                },
-                "image": {
-                    "description": "Image is the input image to edit. Required.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/image.ImageInput"
-                        }
-                    ]
-                },
-                "mask": {
-                    "description": "Mask is an optional mask for inpainting.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/image.ImageInput"
-                        }
-                    ]
+                    "maxLength": 64
                 },
-                "model": {
-                    "description": "Model specifies the image edit model. Optional.",
+                "rollout_model": {
+                    "description": "Model ID rolled out to rollout_percent of users",
                     "type": "string",
-                    "example": "qwen-image-edit"
+                    "maxLength": 255
                 },
-                "n": {
-                    "description": "N is the number of images to generate (only 1 supported by most providers).",
+                "rollout_percent": {
                     "type": "integer",
-                    "example": 1
-                },
-                "negative_prompt": {
-                    "description": "NegativePrompt describes what to avoid.",
-                    "type": "string",
-                    "example": " "
-                },
-                "prompt": {
-                    "description": "Prompt is the text instruction describing the edit. Required.",
-                    "type": "string",
-                    "example": "add golden sunglasses"
-                },
-                "provider_id": {
-                    "description": "ProviderID optionally overrides the default image edit provider.",
-                    "type": "string",
-                    "example": "prov_abc123"
-                },
-                "response_format": {
-                    "description": "ResponseFormat determines output format (\"url\" or \"b64_json\").",
-                    "type": "string",
-                    "example": "b64_json"
+                    "maximum": 100,
+                    "minimum": 0
                 },
-                "sampler": {
-                    "description": "Sampler selects the sampling algorithm.",
+                "target_model": {
+                    "description": "Model ID of the stable channel",
                     "type": "string",
-                    "example": "euler"
+                    "maxLength": 255
+                }
+            }
+        },
+        "modelaliasrequests.UpdateModelAliasRequest": {
+            "type": "object",
+            "properties": {
+                "description": {
+                    "type": "string"
                 },
-                "scheduler": {
-                    "description": "Scheduler selects the scheduler.",
+                "rollout_model": {
+                    "description": "An empty string ends the rollout",
                     "type": "string",
-                    "example": "simple"
+                    "maxLength": 255
                 },
-                "seed": {
-                    "description": "Seed sets random seed (-1 for random).",
+                "rollout_percent": {
                     "type": "integer",
-                    "example": -1
+                    "maximum": 100,
+                    "minimum": 0
                 },
-                "size": {
-                    "description": "Size specifies the output size (\"original\" or \"WIDTHxHEIGHT\").",
+                "target_model": {
                     "type": "string",
-                    "example": "original"
-                },
-                "steps": {
-                    "description": "Steps controls sampling steps.",
-                    "type": "integer",
-                    "example": 4
-                },
-                "store": {
-                    "description": "Store controls whether to save the result to the conversation.\nnil/true = store (default), false = don't store.",
-                    "type": "boolean",
-                    "example": true
-                },
-                "strength": {
-                    "description": "Strength controls edit intensity (0.0-1.0).",
-                    "type": "number",
-                    "example": 1
+                    "maxLength": 255
                 }
             }
         },
-        "image.ImageGenerationRequest": {
-            "description": "OpenAI-compatible image generation request",
+        "modelprompthandler.AssignRequest": {
             "type": "object",
             "required": [
-                "prompt"
+                "prompt_template_id",
+                "template_key"
             ],
             "properties": {
-                "cfg_scale": {
-                    "description": "CfgScale is a provider-specific parameter (z-image/Flux) for guidance scale.",
-                    "type": "number",
-                    "example": 7.5
+                "is_active": {
+                    "type": "boolean"
                 },
-                "conversation_id": {
-                    "description": "ConversationID optionally links this generation to a conversation.",
-                    "type": "string",
-                    "example": "conv_abc123"
+                "priority": {
+                    "type": "integer"
                 },
-                "model": {
-                    "description": "Model specifies the image generation model (e.g., \"z-image\", \"flux-dev\", \"dall-e-3\").\nIf omitted, defaults to the configured default model.",
-                    "type": "string",
-                    "example": "z-image"
+                "prompt_template_id": {
+                    "type": "string"
                 },
-                "n": {
-                    "description": "N is the number of images to generate (1-10, default: 1).",
-                    "type": "integer",
-                    "example": 1
+                "template_key": {
+                    "type": "string"
+                }
+            }
+        },
+        "modelprompthandler.EffectiveTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "source": {
+                    "description": "\"model_specific\", \"global_default\", \"hardcoded\"",
+                    "type": "string"
                 },
-                "num_inference_steps": {
-                    "description": "NumInferenceSteps is a provider-specific parameter (z-image/Flux).",
-                    "type": "integer",
-                    "example": 20
+                "template": {
+                    "$ref": "#/definitions/modelprompthandler.FullPromptTemplateResponse"
+                }
+            }
+        },
+        "modelprompthandler.EffectiveTemplatesResponse": {
+            "type": "object",
+            "properties": {
+                "templates": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "$ref": "#/definitions/modelprompthandler.EffectiveTemplateResponse"
+                    }
+                }
+            }
+        },
+        "modelprompthandler.FullPromptTemplateResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
                 },
-                "prompt": {
-                    "description": "Prompt is the text description of the desired image. Required.",
-                    "type": "string",
-                    "example": "A serene mountain landscape at sunset"
+                "content": {
+                    "type": "string"
                 },
-                "provider_id": {
-                    "description": "ProviderID optionally overrides the default image provider selection.",
-                    "type": "string",
-                    "example": "prov_abc123"
+                "description": {
+                    "type": "string"
                 },
-                "quality": {
-                    "description": "Quality determines image quality. Valid values: \"standard\", \"hd\".\nDefault: \"standard\".",
-                    "type": "string",
-                    "example": "standard"
+                "is_active": {
+                    "type": "boolean"
                 },
-                "response_format": {
-                    "description": "ResponseFormat determines output format. Valid values: \"url\", \"b64_json\".\nDefault: \"url\".",
-                    "type": "string",
-                    "example": "url"
+                "is_system": {
+                    "type": "boolean"
                 },
-                "size": {
-                    "description": "Size specifies the dimensions of the generated image.\nSupported sizes: \"256x256\", \"512x512\", \"1024x1024\", \"1024x1792\", \"1792x1024\".\nDefault: \"1024x1024\".",
-                    "type": "string",
-                    "example": "1024x1024"
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {}
                 },
-                "store": {
-                    "description": "Store controls whether to save the result to the conversation.\nnil/true = store (default), false = don't store.",
-                    "type": "boolean",
-                    "example": true
+                "name": {
+                    "type": "string"
                 },
-                "style": {
-                    "description": "Style influences the visual aesthetic. Valid values: \"vivid\", \"natural\".\nDefault: \"natural\".",
-                    "type": "string",
-                    "example": "natural"
+                "public_id": {
+                    "type": "string"
                 },
-                "user": {
-                    "description": "User is an optional unique identifier representing the end-user for abuse monitoring.",
-                    "type": "string",
-                    "example": "user-123"
+                "template_key": {
+                    "type": "string"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
-        },
-        "image.ImageGenerationResponse": {
-            "description": "OpenAI-compatible image generation response",
+        },
+        "modelprompthandler.ListResponse": {
             "type": "object",
             "properties": {
-                "created": {
-                    "description": "Created is the Unix timestamp of when the response was generated.",
-                    "type": "integer",
-                    "example": 1699000000
-                },
                 "data": {
-                    "description": "Data contains the generated images.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/image.ImageData"
+                        "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
                     }
                 },
-                "usage": {
-                    "description": "Usage contains token usage information for billing purposes.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/image.ImageUsage"
-                        }
-                    ]
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "image.ImageInput": {
+        "modelprompthandler.ModelPromptTemplateResponse": {
             "type": "object",
             "properties": {
-                "b64_json": {
-                    "description": "B64JSON is base64-encoded image data (no data URL prefix).",
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
-                    "description": "ID is a Jan media ID (jan_*).",
-                    "type": "string",
-                    "example": "jan_abc123"
+                    "type": "string"
                 },
-                "url": {
-                    "description": "URL is a remote URL to the image.",
-                    "type": "string",
-                    "example": "https://example.com/image.png"
-                }
-            }
-        },
-        "image.ImageUsage": {
-            "description": "Token usage information for billing",
-            "type": "object",
-            "properties": {
-                "input_tokens": {
-                    "description": "InputTokens is the estimated tokens for the prompt.",
-                    "type": "integer",
-                    "example": 100
+                "is_active": {
+                    "type": "boolean"
                 },
-                "input_tokens_details": {
-                    "description": "InputTokensDetails provides a breakdown of input token types.",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/image.InputTokensDetail"
-                        }
-                    ]
+                "model_catalog_id": {
+                    "type": "string"
                 },
-                "output_tokens": {
-                    "description": "OutputTokens is the estimated tokens for the generated images.",
-                    "type": "integer",
-                    "example": 1400
+                "priority": {
+                    "type": "integer"
                 },
-                "total_tokens": {
-                    "description": "TotalTokens is the sum of input and output tokens.",
-                    "type": "integer",
-                    "example": 1500
-                }
-            }
-        },
-        "image.InputTokensDetail": {
-            "description": "Breakdown of input token types",
-            "type": "object",
-            "properties": {
-                "image_tokens": {
-                    "description": "ImageTokens is the number of tokens from input images (for edit/variation operations).",
-                    "type": "integer",
-                    "example": 0
+                "prompt_template": {
+                    "$ref": "#/definitions/modelprompthandler.PromptTemplateResponse"
                 },
-                "text_tokens": {
-                    "description": "TextTokens is the number of tokens from the text prompt.",
-                    "type": "integer",
-                    "example": 100
+                "prompt_template_id": {
+                    "type": "string"
+                },
+                "template_key": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
                 }
             }
         },
-        "mcptool.UpdateMCPToolRequest": {
+        "modelprompthandler.PromptTemplateResponse": {
             "type": "object",
             "properties": {
                 "category": {
@@ -7290,522 +13931,658 @@ const docTemplate = `{
                 "description": {
                     "type": "string"
                 },
-                "disallowed_keywords": {
-                    "description": "Regex patterns",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
                 "is_active": {
                     "type": "boolean"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "name": {
+                    "type": "string"
+                },
+                "public_id": {
+                    "type": "string"
+                },
+                "template_key": {
+                    "type": "string"
                 }
             }
         },
-        "mcptoolhandler.ActiveToolsResponse": {
+        "modelprompthandler.UpdateRequest": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
-                    }
+                "is_active": {
+                    "type": "boolean"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "prompt_template_id": {
+                    "type": "string"
                 }
             }
         },
-        "mcptoolhandler.ListResponse": {
+        "modelresponses.BulkOperationResponse": {
             "type": "object",
             "properties": {
-                "data": {
+                "failed_count": {
+                    "type": "integer"
+                },
+                "failed_models": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/mcptoolhandler.MCPToolResponse"
+                        "type": "string"
                     }
                 },
-                "total": {
+                "skipped_count": {
+                    "type": "integer"
+                },
+                "total_checked": {
+                    "type": "integer"
+                },
+                "updated_count": {
                     "type": "integer"
                 }
             }
         },
-        "mcptoolhandler.MCPToolResponse": {
+        "modelresponses.EndpointResponse": {
             "type": "object",
             "properties": {
-                "category": {
+                "healthy": {
+                    "type": "boolean"
+                },
+                "priority": {
+                    "type": "integer"
+                },
+                "region": {
                     "type": "string"
                 },
-                "created_at": {
+                "url": {
                     "type": "string"
                 },
+                "weight": {
+                    "type": "integer"
+                }
+            }
+        },
+        "modelresponses.ModelCatalogResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "architecture": {
+                    "$ref": "#/definitions/model.Architecture"
+                },
+                "context_length": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
                 "description": {
                     "type": "string"
                 },
-                "disallowed_keywords": {
+                "experimental": {
+                    "type": "boolean"
+                },
+                "extras": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "family": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_moderated": {
+                    "type": "boolean"
+                },
+                "last_synced_at": {
+                    "type": "integer"
+                },
+                "model_display_name": {
+                    "type": "string"
+                },
+                "notes": {
+                    "type": "string"
+                },
+                "public_id": {
+                    "type": "string"
+                },
+                "requires_feature_flag": {
+                    "type": "string"
+                },
+                "status": {
+                    "$ref": "#/definitions/model.ModelCatalogStatus"
+                },
+                "supported_parameters": {
+                    "$ref": "#/definitions/model.SupportedParameters"
+                },
+                "supports_audio": {
+                    "type": "boolean"
+                },
+                "supports_browser": {
+                    "type": "boolean"
+                },
+                "supports_embeddings": {
+                    "type": "boolean"
+                },
+                "supports_images": {
+                    "type": "boolean"
+                },
+                "supports_instruct": {
+                    "type": "boolean"
+                },
+                "supports_reasoning": {
+                    "type": "boolean"
+                },
+                "supports_tools": {
+                    "type": "boolean"
+                },
+                "supports_video": {
+                    "type": "boolean"
+                },
+                "tags": {
                     "type": "array",
                     "items": {
                         "type": "string"
                     }
                 },
-                "id": {
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
+        "modelresponses.ModelResponse": {
+            "type": "object",
+            "properties": {
+                "category": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "category_order_number": {
+                    "type": "integer"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "created": {
+                    "type": "integer"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "public_id": {
+                "model_display_name": {
                     "type": "string"
                 },
-                "tool_key": {
+                "model_order_number": {
+                    "type": "integer"
+                },
+                "object": {
                     "type": "string"
                 },
-                "updated_at": {
+                "owned_by": {
                     "type": "string"
                 }
             }
         },
-        "model.Architecture": {
+        "modelresponses.ModelResponseList": {
             "type": "object",
             "properties": {
-                "input_modalities": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "instruct_type": {
-                    "description": "nullable",
-                    "type": "string"
-                },
-                "modality": {
-                    "description": "\"text+image-\u003etext\"",
-                    "type": "string"
-                },
-                "output_modalities": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/modelresponses.ModelResponse"
                     }
                 },
-                "tokenizer": {
-                    "description": "\"GPT\" / \"SentencePiece\" / etc.",
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "model.ModelCatalogStatus": {
-            "type": "string",
-            "enum": [
-                "init",
-                "filled",
-                "updated"
-            ],
-            "x-enum-comments": {
-                "ModelCatalogStatusFilled": "may update from Provider like OpenRouter",
-                "ModelCatalogStatusInit": "default status when creating entry",
-                "ModelCatalogStatusUpdated": "manually updated by admin (cannot be auto-updated anymore"
-            },
-            "x-enum-varnames": [
-                "ModelCatalogStatusInit",
-                "ModelCatalogStatusFilled",
-                "ModelCatalogStatusUpdated"
-            ]
-        },
-        "model.PriceLine": {
+        "modelresponses.ModelResponseWithProvider": {
             "type": "object",
             "properties": {
-                "amount_micro_usd": {
-                    "description": "e.g., 15000 -\u003e $0.0150",
+                "category": {
+                    "type": "string"
+                },
+                "category_order_number": {
                     "type": "integer"
                 },
-                "currency": {
-                    "description": "\"USD\" (fixed if you only bill in USD)",
+                "created": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "unit": {
-                    "$ref": "#/definitions/model.PriceUnit"
+                "model_display_name": {
+                    "type": "string"
+                },
+                "model_order_number": {
+                    "type": "integer"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "owned_by": {
+                    "type": "string"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "provider_name": {
+                    "type": "string"
+                },
+                "provider_vendor": {
+                    "type": "string"
                 }
             }
         },
-        "model.PriceUnit": {
-            "type": "string",
-            "enum": [
-                "per_1k_prompt_tokens",
-                "per_1k_completion_tokens",
-                "per_request",
-                "per_image",
-                "per_web_search",
-                "per_internal_reasoning"
-            ],
-            "x-enum-varnames": [
-                "Per1KPromptTokens",
-                "Per1KCompletionTokens",
-                "PerRequest",
-                "PerImage",
-                "PerWebSearch",
-                "PerInternalReasoning"
-            ]
-        },
-        "model.Pricing": {
+        "modelresponses.ModelWithProviderResponseList": {
             "type": "object",
             "properties": {
-                "lines": {
-                    "description": "flexible: add/remove units without schema churn",
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.PriceLine"
-                    }
-                }
-            }
-        },
-        "model.SupportedParameters": {
-            "type": "object",
-            "properties": {
-                "default": {
-                    "description": "temperature/top_p/frequency_penalty, null allowed",
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "number"
+                        "$ref": "#/definitions/modelresponses.ModelResponseWithProvider"
                     }
                 },
-                "names": {
-                    "description": "e.g., [\"include_reasoning\",\"max_tokens\",...]",
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
+                "object": {
+                    "type": "string"
                 }
             }
         },
-        "model.TokenLimits": {
+        "modelresponses.ProviderCapabilities": {
             "type": "object",
             "properties": {
+                "cataloged": {
+                    "type": "boolean"
+                },
                 "context_length": {
-                    "description": "e.g., 400000",
                     "type": "integer"
                 },
-                "max_completion_tokens": {
-                    "description": "e.g., 128000",
+                "models_listed": {
                     "type": "integer"
+                },
+                "stream_usage_reported": {
+                    "type": "boolean"
+                },
+                "streaming": {
+                    "type": "boolean"
+                },
+                "supports_audio": {
+                    "type": "boolean"
+                },
+                "supports_images": {
+                    "type": "boolean"
+                },
+                "supports_reasoning": {
+                    "type": "boolean"
+                },
+                "supports_tools": {
+                    "type": "boolean"
+                },
+                "usage_reported": {
+                    "type": "boolean"
                 }
             }
         },
-        "modelprompthandler.AssignRequest": {
+        "modelresponses.ProviderModelResponse": {
             "type": "object",
-            "required": [
-                "prompt_template_id",
-                "template_key"
-            ],
             "properties": {
-                "is_active": {
+                "active": {
                     "type": "boolean"
                 },
-                "priority": {
+                "category": {
+                    "type": "string"
+                },
+                "category_order_number": {
                     "type": "integer"
                 },
-                "prompt_template_id": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "family": {
                     "type": "string"
                 },
-                "template_key": {
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "modelprompthandler.EffectiveTemplateResponse": {
-            "type": "object",
-            "properties": {
-                "source": {
-                    "description": "\"model_specific\", \"global_default\", \"hardcoded\"",
+                },
+                "instruct_model_public_id": {
+                    "description": "Public ID of the instruct model to use when enable_thinking=false",
                     "type": "string"
                 },
-                "template": {
-                    "$ref": "#/definitions/modelprompthandler.FullPromptTemplateResponse"
-                }
-            }
-        },
-        "modelprompthandler.EffectiveTemplatesResponse": {
-            "type": "object",
-            "properties": {
-                "templates": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "$ref": "#/definitions/modelprompthandler.EffectiveTemplateResponse"
-                    }
+                "model_catalog_id": {
+                    "type": "string"
+                },
+                "model_display_name": {
+                    "type": "string"
+                },
+                "model_order_number": {
+                    "type": "integer"
+                },
+                "model_public_id": {
+                    "type": "string"
+                },
+                "pricing": {
+                    "$ref": "#/definitions/model.Pricing"
+                },
+                "provider_id": {
+                    "type": "string"
+                },
+                "provider_original_model_id": {
+                    "type": "string"
+                },
+                "provider_vendor": {
+                    "type": "string"
+                },
+                "reasoning_policy": {
+                    "$ref": "#/definitions/model.ReasoningPolicy"
+                },
+                "supports_audio": {
+                    "type": "boolean"
+                },
+                "supports_embeddings": {
+                    "type": "boolean"
+                },
+                "supports_images": {
+                    "type": "boolean"
+                },
+                "supports_instruct": {
+                    "type": "boolean"
+                },
+                "supports_reasoning": {
+                    "type": "boolean"
+                },
+                "supports_video": {
+                    "type": "boolean"
+                },
+                "token_limits": {
+                    "$ref": "#/definitions/model.TokenLimits"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "modelprompthandler.FullPromptTemplateResponse": {
+        "modelresponses.ProviderResponse": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
+                "active": {
+                    "type": "boolean"
                 },
-                "content": {
+                "base_url": {
                     "type": "string"
                 },
-                "description": {
+                "category": {
                     "type": "string"
                 },
-                "is_active": {
+                "default_provider_image_edit": {
                     "type": "boolean"
                 },
-                "is_system": {
+                "default_provider_image_generate": {
                     "type": "boolean"
                 },
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/modelresponses.EndpointResponse"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
                 "metadata": {
                     "type": "object",
-                    "additionalProperties": {}
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
                 "name": {
                     "type": "string"
                 },
-                "public_id": {
-                    "type": "string"
-                },
-                "template_key": {
+                "vendor": {
                     "type": "string"
-                },
-                "variables": {
-                    "type": "array",
-                    "items": {
-                        "type": "string"
-                    }
-                },
-                "version": {
-                    "type": "integer"
                 }
             }
         },
-        "modelprompthandler.ListResponse": {
+        "modelresponses.ProviderResponseList": {
             "type": "object",
             "properties": {
                 "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/modelprompthandler.ModelPromptTemplateResponse"
+                        "$ref": "#/definitions/modelresponses.ProviderResponse"
                     }
                 },
-                "total": {
-                    "type": "integer"
+                "object": {
+                    "type": "string"
                 }
             }
         },
-        "modelprompthandler.ModelPromptTemplateResponse": {
+        "modelresponses.ProviderTestResponse": {
             "type": "object",
             "properties": {
-                "created_at": {
-                    "type": "string"
-                },
-                "id": {
+                "base_url": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "capabilities": {
+                    "$ref": "#/definitions/modelresponses.ProviderCapabilities"
                 },
-                "model_catalog_id": {
+                "model": {
                     "type": "string"
                 },
-                "priority": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "prompt_template": {
-                    "$ref": "#/definitions/modelprompthandler.PromptTemplateResponse"
+                "ok": {
+                    "type": "boolean"
                 },
-                "prompt_template_id": {
+                "provider_id": {
                     "type": "string"
                 },
-                "template_key": {
-                    "type": "string"
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/modelresponses.ProviderTestStep"
+                    }
                 },
-                "updated_at": {
+                "vendor": {
                     "type": "string"
                 }
             }
         },
-        "modelprompthandler.PromptTemplateResponse": {
+        "modelresponses.ProviderTestStep": {
             "type": "object",
             "properties": {
-                "category": {
+                "detail": {
                     "type": "string"
                 },
-                "description": {
+                "error": {
                     "type": "string"
                 },
-                "is_active": {
-                    "type": "boolean"
+                "latency_ms": {
+                    "type": "integer"
                 },
                 "name": {
+                    "description": "auth, completion or stream",
                     "type": "string"
                 },
-                "public_id": {
-                    "type": "string"
+                "ok": {
+                    "type": "boolean"
                 },
-                "template_key": {
-                    "type": "string"
+                "skipped": {
+                    "type": "boolean"
+                },
+                "time_to_first_token_ms": {
+                    "type": "integer"
                 }
             }
         },
-        "modelprompthandler.UpdateRequest": {
+        "modelresponses.ProviderWithModelCountResponse": {
             "type": "object",
             "properties": {
-                "is_active": {
+                "active": {
                     "type": "boolean"
                 },
-                "priority": {
-                    "type": "integer"
+                "base_url": {
+                    "type": "string"
                 },
-                "prompt_template_id": {
+                "category": {
                     "type": "string"
-                }
-            }
-        },
-        "modelresponses.BulkOperationResponse": {
-            "type": "object",
-            "properties": {
-                "failed_count": {
-                    "type": "integer"
                 },
-                "failed_models": {
+                "default_provider_image_edit": {
+                    "type": "boolean"
+                },
+                "default_provider_image_generate": {
+                    "type": "boolean"
+                },
+                "endpoints": {
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/modelresponses.EndpointResponse"
                     }
                 },
-                "skipped_count": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "total_checked": {
-                    "type": "integer"
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "updated_count": {
+                "model_active_count": {
                     "type": "integer"
-                }
-            }
-        },
-        "modelresponses.EndpointResponse": {
-            "type": "object",
-            "properties": {
-                "healthy": {
-                    "type": "boolean"
                 },
-                "priority": {
+                "model_count": {
                     "type": "integer"
                 },
-                "url": {
+                "name": {
                     "type": "string"
                 },
-                "weight": {
-                    "type": "integer"
+                "vendor": {
+                    "type": "string"
                 }
             }
         },
-        "modelresponses.ModelCatalogResponse": {
+        "modelresponses.ProviderWithModelsResponse": {
             "type": "object",
             "properties": {
                 "active": {
                     "type": "boolean"
                 },
-                "architecture": {
-                    "$ref": "#/definitions/model.Architecture"
-                },
-                "context_length": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "integer"
+                "base_url": {
+                    "type": "string"
                 },
-                "description": {
+                "category": {
                     "type": "string"
                 },
-                "experimental": {
+                "default_provider_image_edit": {
                     "type": "boolean"
                 },
-                "extras": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "default_provider_image_generate": {
+                    "type": "boolean"
                 },
-                "family": {
-                    "type": "string"
+                "endpoints": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/modelresponses.EndpointResponse"
+                    }
                 },
                 "id": {
                     "type": "string"
                 },
-                "is_moderated": {
-                    "type": "boolean"
-                },
-                "last_synced_at": {
-                    "type": "integer"
+                "metadata": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
                 },
-                "model_display_name": {
-                    "type": "string"
+                "models": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/modelresponses.ModelResponse"
+                    }
                 },
-                "notes": {
+                "name": {
                     "type": "string"
                 },
-                "public_id": {
+                "vendor": {
                     "type": "string"
+                }
+            }
+        },
+        "openai.ChatCompletionChoice": {
+            "type": "object",
+            "properties": {
+                "content_filter_results": {
+                    "$ref": "#/definitions/openai.ContentFilterResults"
                 },
-                "requires_feature_flag": {
-                    "type": "string"
+                "finish_reason": {
+                    "description": "FinishReason\nstop: API returned complete message,\nor a message terminated by one of the stop sequences provided via the stop parameter\nlength: Incomplete model output due to max_tokens parameter or token limit\nfunction_call: The model decided to call a function\ncontent_filter: Omitted content due to a flag from our content filters\nnull: API response still in progress or incomplete",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/openai.FinishReason"
+                        }
+                    ]
                 },
-                "status": {
-                    "$ref": "#/definitions/model.ModelCatalogStatus"
+                "index": {
+                    "type": "integer"
                 },
-                "supported_parameters": {
-                    "$ref": "#/definitions/model.SupportedParameters"
+                "logprobs": {
+                    "$ref": "#/definitions/openai.LogProbs"
                 },
-                "supports_audio": {
-                    "type": "boolean"
+                "message": {
+                    "$ref": "#/definitions/openai.ChatCompletionMessage"
+                }
+            }
+        },
+        "openai.ChatCompletionMessage": {
+            "type": "object",
+            "properties": {
+                "content": {
+                    "type": "string"
                 },
-                "supports_browser": {
-                    "type": "boolean"
+                "function_call": {
+                    "$ref": "#/definitions/openai.FunctionCall"
                 },
-                "supports_embeddings": {
-                    "type": "boolean"
+                "multiContent": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.ChatMessagePart"
+                    }
                 },
-                "supports_images": {
-                    "type": "boolean"
+                "name": {
+                    "description": "This property isn't in the official documentation, but it's in\nthe documentation for the official library for python:\n- https://github.com/openai/openai-python/blob/main/chatml.md\n- https://github.com/openai/openai-cookbook/blob/main/examples/How_to_count_tokens_with_tiktoken.ipynb",
+                    "type": "string"
                 },
-                "supports_instruct": {
-                    "type": "boolean"
+                "reasoning_content": {
+                    "description": "This property is used for the \"reasoning\" feature supported by deepseek-reasoner\nwhich is not in the official documentation.\nthe doc from deepseek:\n- https://api-docs.deepseek.com/api/create-chat-completion#responses",
+                    "type": "string"
                 },
-                "supports_reasoning": {
-                    "type": "boolean"
+                "refusal": {
+                    "type": "string"
                 },
-                "supports_tools": {
-                    "type": "boolean"
+                "role": {
+                    "type": "string"
                 },
-                "supports_video": {
-                    "type": "boolean"
+                "tool_call_id": {
+                    "description": "For Role=tool prompts this should be set to the ID given in the assistant's prior request to call a tool.",
+                    "type": "string"
                 },
-                "tags": {
+                "tool_calls": {
+                    "description": "For Role=assistant prompts this may be set to the tool calls generated by the model, such as function calls.",
                     "type": "array",
                     "items": {
-                        "type": "string"
+                        "$ref": "#/definitions/openai.ToolCall"
                     }
-                },
-                "updated_at": {
-                    "type": "integer"
                 }
             }
         },
-        "modelresponses.ModelResponse": {
+        "openai.ChatCompletionResponse": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "category_order_number": {
-                    "type": "integer"
+                "choices": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.ChatCompletionChoice"
+                    }
                 },
                 "created": {
                     "type": "integer"
@@ -7813,891 +14590,994 @@ const docTemplate = `{
                 "id": {
                     "type": "string"
                 },
-                "model_display_name": {
+                "model": {
                     "type": "string"
                 },
-                "model_order_number": {
-                    "type": "integer"
-                },
                 "object": {
                     "type": "string"
                 },
-                "owned_by": {
+                "prompt_filter_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.PromptFilterResult"
+                    }
+                },
+                "service_tier": {
+                    "$ref": "#/definitions/openai.ServiceTier"
+                },
+                "system_fingerprint": {
                     "type": "string"
+                },
+                "usage": {
+                    "$ref": "#/definitions/openai.Usage"
                 }
             }
         },
-        "modelresponses.ModelResponseList": {
+        "openai.ChatCompletionResponseFormat": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/modelresponses.ModelResponse"
-                    }
+                "json_schema": {
+                    "$ref": "#/definitions/openai.ChatCompletionResponseFormatJSONSchema"
                 },
-                "object": {
-                    "type": "string"
+                "type": {
+                    "$ref": "#/definitions/openai.ChatCompletionResponseFormatType"
                 }
             }
         },
-        "modelresponses.ModelResponseWithProvider": {
+        "openai.ChatCompletionResponseFormatJSONSchema": {
             "type": "object",
             "properties": {
-                "category": {
-                    "type": "string"
-                },
-                "category_order_number": {
-                    "type": "integer"
-                },
-                "created": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "model_display_name": {
-                    "type": "string"
-                },
-                "model_order_number": {
-                    "type": "integer"
-                },
-                "object": {
-                    "type": "string"
-                },
-                "owned_by": {
-                    "type": "string"
-                },
-                "provider_id": {
+                "description": {
                     "type": "string"
                 },
-                "provider_name": {
+                "name": {
                     "type": "string"
                 },
-                "provider_vendor": {
-                    "type": "string"
+                "schema": {},
+                "strict": {
+                    "type": "boolean"
                 }
             }
         },
-        "modelresponses.ModelWithProviderResponseList": {
+        "openai.ChatCompletionResponseFormatType": {
+            "type": "string",
+            "enum": [
+                "json_object",
+                "json_schema",
+                "text"
+            ],
+            "x-enum-varnames": [
+                "ChatCompletionResponseFormatTypeJSONObject",
+                "ChatCompletionResponseFormatTypeJSONSchema",
+                "ChatCompletionResponseFormatTypeText"
+            ]
+        },
+        "openai.ChatMessageImageURL": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/modelresponses.ModelResponseWithProvider"
-                    }
+                "detail": {
+                    "$ref": "#/definitions/openai.ImageURLDetail"
                 },
-                "object": {
+                "url": {
                     "type": "string"
                 }
             }
         },
-        "modelresponses.ProviderModelResponse": {
+        "openai.ChatMessagePart": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
+                "image_url": {
+                    "$ref": "#/definitions/openai.ChatMessageImageURL"
                 },
-                "category": {
+                "text": {
                     "type": "string"
                 },
-                "category_order_number": {
+                "type": {
+                    "$ref": "#/definitions/openai.ChatMessagePartType"
+                }
+            }
+        },
+        "openai.ChatMessagePartType": {
+            "type": "string",
+            "enum": [
+                "text",
+                "image_url"
+            ],
+            "x-enum-varnames": [
+                "ChatMessagePartTypeText",
+                "ChatMessagePartTypeImageURL"
+            ]
+        },
+        "openai.CompletionTokensDetails": {
+            "type": "object",
+            "properties": {
+                "accepted_prediction_tokens": {
                     "type": "integer"
                 },
-                "created_at": {
+                "audio_tokens": {
                     "type": "integer"
                 },
-                "family": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
-                },
-                "instruct_model_public_id": {
-                    "description": "Public ID of the instruct model to use when enable_thinking=false",
-                    "type": "string"
-                },
-                "model_catalog_id": {
-                    "type": "string"
-                },
-                "model_display_name": {
-                    "type": "string"
-                },
-                "model_order_number": {
+                "reasoning_tokens": {
                     "type": "integer"
                 },
-                "model_public_id": {
-                    "type": "string"
-                },
-                "pricing": {
-                    "$ref": "#/definitions/model.Pricing"
-                },
-                "provider_id": {
-                    "type": "string"
-                },
-                "provider_original_model_id": {
-                    "type": "string"
-                },
-                "provider_vendor": {
-                    "type": "string"
-                },
-                "supports_audio": {
-                    "type": "boolean"
-                },
-                "supports_embeddings": {
-                    "type": "boolean"
-                },
-                "supports_images": {
-                    "type": "boolean"
-                },
-                "supports_instruct": {
-                    "type": "boolean"
-                },
-                "supports_reasoning": {
-                    "type": "boolean"
-                },
-                "supports_video": {
-                    "type": "boolean"
-                },
-                "token_limits": {
-                    "$ref": "#/definitions/model.TokenLimits"
-                },
-                "updated_at": {
+                "rejected_prediction_tokens": {
                     "type": "integer"
                 }
             }
         },
-        "modelresponses.ProviderResponse": {
+        "openai.ContentFilterResults": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "base_url": {
-                    "type": "string"
+                "hate": {
+                    "$ref": "#/definitions/openai.Hate"
                 },
-                "category": {
-                    "type": "string"
+                "jailbreak": {
+                    "$ref": "#/definitions/openai.JailBreak"
                 },
-                "default_provider_image_edit": {
-                    "type": "boolean"
+                "profanity": {
+                    "$ref": "#/definitions/openai.Profanity"
                 },
-                "default_provider_image_generate": {
-                    "type": "boolean"
+                "self_harm": {
+                    "$ref": "#/definitions/openai.SelfHarm"
                 },
-                "endpoints": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/modelresponses.EndpointResponse"
-                    }
+                "sexual": {
+                    "$ref": "#/definitions/openai.Sexual"
                 },
-                "id": {
+                "violence": {
+                    "$ref": "#/definitions/openai.Violence"
+                }
+            }
+        },
+        "openai.FinishReason": {
+            "type": "string",
+            "enum": [
+                "stop",
+                "length",
+                "function_call",
+                "tool_calls",
+                "content_filter",
+                "null"
+            ],
+            "x-enum-varnames": [
+                "FinishReasonStop",
+                "FinishReasonLength",
+                "FinishReasonFunctionCall",
+                "FinishReasonToolCalls",
+                "FinishReasonContentFilter",
+                "FinishReasonNull"
+            ]
+        },
+        "openai.FunctionCall": {
+            "type": "object",
+            "properties": {
+                "arguments": {
+                    "description": "call function with arguments in JSON format",
                     "type": "string"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
                 "name": {
                     "type": "string"
-                },
-                "vendor": {
-                    "type": "string"
                 }
             }
         },
-        "modelresponses.ProviderResponseList": {
+        "openai.FunctionDefinition": {
             "type": "object",
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/modelresponses.ProviderResponse"
-                    }
+                "description": {
+                    "type": "string"
                 },
-                "object": {
+                "name": {
                     "type": "string"
+                },
+                "parameters": {
+                    "description": "Parameters is an object describing the function.\nYou can pass json.RawMessage to describe the schema,\nor you can pass in a struct which serializes to the proper JSON schema.\nThe jsonschema package is provided for convenience, but you should\nconsider another specialized library if you require more complex schemas."
+                },
+                "strict": {
+                    "type": "boolean"
                 }
             }
         },
-        "modelresponses.ProviderWithModelCountResponse": {
+        "openai.Hate": {
             "type": "object",
             "properties": {
-                "active": {
+                "filtered": {
                     "type": "boolean"
                 },
-                "base_url": {
-                    "type": "string"
-                },
-                "category": {
+                "severity": {
                     "type": "string"
-                },
-                "default_provider_image_edit": {
+                }
+            }
+        },
+        "openai.ImageURLDetail": {
+            "type": "string",
+            "enum": [
+                "high",
+                "low",
+                "auto"
+            ],
+            "x-enum-varnames": [
+                "ImageURLDetailHigh",
+                "ImageURLDetailLow",
+                "ImageURLDetailAuto"
+            ]
+        },
+        "openai.JailBreak": {
+            "type": "object",
+            "properties": {
+                "detected": {
                     "type": "boolean"
                 },
-                "default_provider_image_generate": {
+                "filtered": {
                     "type": "boolean"
-                },
-                "endpoints": {
+                }
+            }
+        },
+        "openai.LogProb": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "description": "Omitting the field if it is null",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/modelresponses.EndpointResponse"
-                    }
-                },
-                "id": {
-                    "type": "string"
-                },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
+                        "type": "integer"
                     }
                 },
-                "model_active_count": {
-                    "type": "integer"
-                },
-                "model_count": {
-                    "type": "integer"
+                "logprob": {
+                    "type": "number"
                 },
-                "name": {
+                "token": {
                     "type": "string"
                 },
-                "vendor": {
-                    "type": "string"
+                "top_logprobs": {
+                    "description": "TopLogProbs is a list of the most likely tokens and their log probability, at this token position.\nIn rare cases, there may be fewer than the number of requested top_logprobs returned.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/openai.TopLogProbs"
+                    }
                 }
             }
         },
-        "modelresponses.ProviderWithModelsResponse": {
+        "openai.LogProbs": {
             "type": "object",
             "properties": {
-                "active": {
-                    "type": "boolean"
-                },
-                "base_url": {
-                    "type": "string"
-                },
-                "category": {
-                    "type": "string"
-                },
-                "default_provider_image_edit": {
-                    "type": "boolean"
-                },
-                "default_provider_image_generate": {
-                    "type": "boolean"
-                },
-                "endpoints": {
+                "content": {
+                    "description": "Content is a list of message content tokens with log probability information.",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/modelresponses.EndpointResponse"
+                        "$ref": "#/definitions/openai.LogProb"
                     }
-                },
-                "id": {
+                }
+            }
+        },
+        "openai.Prediction": {
+            "type": "object",
+            "properties": {
+                "content": {
                     "type": "string"
                 },
-                "metadata": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "string"
-                    }
-                },
-                "models": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/modelresponses.ModelResponse"
-                    }
-                },
-                "name": {
+                "type": {
                     "type": "string"
+                }
+            }
+        },
+        "openai.Profanity": {
+            "type": "object",
+            "properties": {
+                "detected": {
+                    "type": "boolean"
                 },
-                "vendor": {
-                    "type": "string"
+                "filtered": {
+                    "type": "boolean"
                 }
             }
         },
-        "openai.ChatCompletionChoice": {
+        "openai.PromptFilterResult": {
             "type": "object",
             "properties": {
                 "content_filter_results": {
                     "$ref": "#/definitions/openai.ContentFilterResults"
                 },
-                "finish_reason": {
-                    "description": "FinishReason\nstop: API returned complete message,\nor a message terminated by one of the stop sequences provided via the stop parameter\nlength: Incomplete model output due to max_tokens parameter or token limit\nfunction_call: The model decided to call a function\ncontent_filter: Omitted content due to a flag from our content filters\nnull: API response still in progress or incomplete",
-                    "allOf": [
-                        {
-                            "$ref": "#/definitions/openai.FinishReason"
-                        }
-                    ]
-                },
                 "index": {
                     "type": "integer"
-                },
-                "logprobs": {
-                    "$ref": "#/definitions/openai.LogProbs"
-                },
-                "message": {
-                    "$ref": "#/definitions/openai.ChatCompletionMessage"
                 }
             }
         },
-        "openai.ChatCompletionMessage": {
+        "openai.PromptTokensDetails": {
             "type": "object",
             "properties": {
-                "content": {
-                    "type": "string"
-                },
-                "function_call": {
-                    "$ref": "#/definitions/openai.FunctionCall"
-                },
-                "multiContent": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/openai.ChatMessagePart"
-                    }
-                },
-                "name": {
-                    "description": "This property isn't in the official documentation, but it's in\nthe documentation for the official library for python:\n- https://github.com/openai/openai-python/blob/main/chatml.md\n- https://github.com/openai/openai-cookbook/blob/main/examples/How_to_count_tokens_with_tiktoken.ipynb",
-                    "type": "string"
-                },
-                "reasoning_content": {
-                    "description": "This property is used for the \"reasoning\" feature supported by deepseek-reasoner\nwhich is not in the official documentation.\nthe doc from deepseek:\n- https://api-docs.deepseek.com/api/create-chat-completion#responses",
-                    "type": "string"
+                "audio_tokens": {
+                    "type": "integer"
                 },
-                "refusal": {
-                    "type": "string"
+                "cached_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "openai.SelfHarm": {
+            "type": "object",
+            "properties": {
+                "filtered": {
+                    "type": "boolean"
                 },
-                "role": {
+                "severity": {
                     "type": "string"
+                }
+            }
+        },
+        "openai.ServiceTier": {
+            "type": "string",
+            "enum": [
+                "auto",
+                "default",
+                "flex",
+                "priority"
+            ],
+            "x-enum-varnames": [
+                "ServiceTierAuto",
+                "ServiceTierDefault",
+                "ServiceTierFlex",
+                "ServiceTierPriority"
+            ]
+        },
+        "openai.Sexual": {
+            "type": "object",
+            "properties": {
+                "filtered": {
+                    "type": "boolean"
                 },
-                "tool_call_id": {
-                    "description": "For Role=tool prompts this should be set to the ID given in the assistant's prior request to call a tool.",
+                "severity": {
                     "type": "string"
-                },
-                "tool_calls": {
-                    "description": "For Role=assistant prompts this may be set to the tool calls generated by the model, such as function calls.",
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/openai.ToolCall"
-                    }
                 }
             }
         },
-        "openai.ChatCompletionResponseFormat": {
+        "openai.StreamOptions": {
             "type": "object",
             "properties": {
-                "json_schema": {
-                    "$ref": "#/definitions/openai.ChatCompletionResponseFormatJSONSchema"
+                "include_usage": {
+                    "description": "If set, an additional chunk will be streamed before the data: [DONE] message.\nThe usage field on this chunk shows the token usage statistics for the entire request,\nand the choices field will always be an empty array.\nAll other chunks will also include a usage field, but with a null value.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "openai.Tool": {
+            "type": "object",
+            "properties": {
+                "function": {
+                    "$ref": "#/definitions/openai.FunctionDefinition"
                 },
                 "type": {
-                    "$ref": "#/definitions/openai.ChatCompletionResponseFormatType"
+                    "$ref": "#/definitions/openai.ToolType"
                 }
             }
         },
-        "openai.ChatCompletionResponseFormatJSONSchema": {
+        "openai.ToolCall": {
             "type": "object",
             "properties": {
-                "description": {
-                    "type": "string"
+                "function": {
+                    "$ref": "#/definitions/openai.FunctionCall"
                 },
-                "name": {
+                "id": {
                     "type": "string"
                 },
-                "schema": {},
-                "strict": {
-                    "type": "boolean"
+                "index": {
+                    "description": "Index is not nil only in chat completion chunk object",
+                    "type": "integer"
+                },
+                "type": {
+                    "$ref": "#/definitions/openai.ToolType"
                 }
             }
         },
-        "openai.ChatCompletionResponseFormatType": {
+        "openai.ToolType": {
             "type": "string",
             "enum": [
-                "json_object",
-                "json_schema",
-                "text"
+                "function"
             ],
             "x-enum-varnames": [
-                "ChatCompletionResponseFormatTypeJSONObject",
-                "ChatCompletionResponseFormatTypeJSONSchema",
-                "ChatCompletionResponseFormatTypeText"
+                "ToolTypeFunction"
             ]
         },
-        "openai.ChatMessageImageURL": {
+        "openai.TopLogProbs": {
             "type": "object",
             "properties": {
-                "detail": {
-                    "$ref": "#/definitions/openai.ImageURLDetail"
+                "bytes": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
                 },
-                "url": {
-                    "type": "string"
-                }
-            }
-        },
-        "openai.ChatMessagePart": {
-            "type": "object",
-            "properties": {
-                "image_url": {
-                    "$ref": "#/definitions/openai.ChatMessageImageURL"
+                "logprob": {
+                    "type": "number"
                 },
-                "text": {
+                "token": {
                     "type": "string"
-                },
-                "type": {
-                    "$ref": "#/definitions/openai.ChatMessagePartType"
                 }
             }
         },
-        "openai.ChatMessagePartType": {
-            "type": "string",
-            "enum": [
-                "text",
-                "image_url"
-            ],
-            "x-enum-varnames": [
-                "ChatMessagePartTypeText",
-                "ChatMessagePartTypeImageURL"
-            ]
-        },
-        "openai.CompletionTokensDetails": {
+        "openai.Usage": {
             "type": "object",
             "properties": {
-                "accepted_prediction_tokens": {
+                "completion_tokens": {
                     "type": "integer"
                 },
-                "audio_tokens": {
-                    "type": "integer"
+                "completion_tokens_details": {
+                    "$ref": "#/definitions/openai.CompletionTokensDetails"
                 },
-                "reasoning_tokens": {
+                "prompt_tokens": {
                     "type": "integer"
                 },
-                "rejected_prediction_tokens": {
+                "prompt_tokens_details": {
+                    "$ref": "#/definitions/openai.PromptTokensDetails"
+                },
+                "total_tokens": {
                     "type": "integer"
                 }
             }
         },
-        "openai.ContentFilterResults": {
+        "openai.Violence": {
             "type": "object",
             "properties": {
-                "hate": {
-                    "$ref": "#/definitions/openai.Hate"
-                },
-                "jailbreak": {
-                    "$ref": "#/definitions/openai.JailBreak"
-                },
-                "profanity": {
-                    "$ref": "#/definitions/openai.Profanity"
-                },
-                "self_harm": {
-                    "$ref": "#/definitions/openai.SelfHarm"
-                },
-                "sexual": {
-                    "$ref": "#/definitions/openai.Sexual"
+                "filtered": {
+                    "type": "boolean"
                 },
-                "violence": {
-                    "$ref": "#/definitions/openai.Violence"
+                "severity": {
+                    "type": "string"
                 }
             }
         },
-        "openai.FinishReason": {
-            "type": "string",
-            "enum": [
-                "stop",
-                "length",
-                "function_call",
-                "tool_calls",
-                "content_filter",
-                "null"
-            ],
-            "x-enum-varnames": [
-                "FinishReasonStop",
-                "FinishReasonLength",
-                "FinishReasonFunctionCall",
-                "FinishReasonToolCalls",
-                "FinishReasonContentFilter",
-                "FinishReasonNull"
-            ]
-        },
-        "openai.FunctionCall": {
+        "personahandler.PersonaListResponse": {
             "type": "object",
             "properties": {
-                "arguments": {
-                    "description": "call function with arguments in JSON format",
-                    "type": "string"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/personahandler.PersonaResponse"
+                    }
                 },
-                "name": {
+                "object": {
                     "type": "string"
+                },
+                "total": {
+                    "type": "integer"
                 }
             }
         },
-        "openai.FunctionDefinition": {
+        "personahandler.PersonaResponse": {
             "type": "object",
             "properties": {
+                "avatar": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "default_model": {
+                    "type": "string"
+                },
                 "description": {
                     "type": "string"
                 },
+                "enabled_tools": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "instructions": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
-                "parameters": {
-                    "description": "Parameters is an object describing the function.\nYou can pass json.RawMessage to describe the schema,\nor you can pass in a struct which serializes to the proper JSON schema.\nThe jsonschema package is provided for convenience, but you should\nconsider another specialized library if you require more complex schemas."
+                "object": {
+                    "type": "string"
                 },
-                "strict": {
-                    "type": "boolean"
+                "starter_questions": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "openai.Hate": {
+        "personarequests.CreatePersonaRequest": {
             "type": "object",
+            "required": [
+                "instructions",
+                "name"
+            ],
             "properties": {
-                "filtered": {
-                    "type": "boolean"
+                "avatar": {
+                    "description": "Image URL or media ID",
+                    "type": "string",
+                    "maxLength": 2048
                 },
-                "severity": {
+                "default_model": {
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
                     "type": "string"
+                },
+                "enabled_tools": {
+                    "description": "Empty keeps every tool the request sends",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instructions": {
+                    "type": "string",
+                    "maxLength": 20000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "starter_questions": {
+                    "description": "Suggested first questions",
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "openai.ImageURLDetail": {
-            "type": "string",
-            "enum": [
-                "high",
-                "low",
-                "auto"
-            ],
-            "x-enum-varnames": [
-                "ImageURLDetailHigh",
-                "ImageURLDetailLow",
-                "ImageURLDetailAuto"
-            ]
-        },
-        "openai.JailBreak": {
+        "personarequests.UpdatePersonaRequest": {
             "type": "object",
             "properties": {
-                "detected": {
-                    "type": "boolean"
+                "avatar": {
+                    "description": "An empty string removes the avatar",
+                    "type": "string",
+                    "maxLength": 2048
                 },
-                "filtered": {
-                    "type": "boolean"
+                "default_model": {
+                    "description": "An empty string removes the default model",
+                    "type": "string",
+                    "maxLength": 255
+                },
+                "description": {
+                    "type": "string"
+                },
+                "enabled_tools": {
+                    "description": "An empty list allows every tool again",
+                    "type": "array",
+                    "maxItems": 50,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "instructions": {
+                    "type": "string",
+                    "maxLength": 20000
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "starter_questions": {
+                    "type": "array",
+                    "maxItems": 10,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
-        "openai.LogProb": {
+        "projectreq.CreateProjectRequest": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "bytes": {
-                    "description": "Omitting the field if it is null",
+                "default_tools": {
+                    "description": "Tool name -> on/off for completions in the project",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "instruction": {
+                    "type": "string"
+                },
+                "knowledge_collections": {
+                    "description": "Vector store collections searched in the project's conversations",
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "projectreq.UpdateProjectRequest": {
+            "type": "object",
+            "properties": {
+                "default_tools": {
+                    "description": "Replaces the tool defaults; {} clears them",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
                     }
                 },
-                "logprob": {
-                    "type": "number"
-                },
-                "token": {
+                "instruction": {
                     "type": "string"
                 },
-                "top_logprobs": {
-                    "description": "TopLogProbs is a list of the most likely tokens and their log probability, at this token position.\nIn rare cases, there may be fewer than the number of requested top_logprobs returned.",
+                "is_archived": {
+                    "type": "boolean"
+                },
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "knowledge_collections": {
+                    "description": "Replaces the bound collections; [] clears them",
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/openai.TopLogProbs"
+                        "type": "string"
                     }
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "openai.LogProbs": {
+        "projectres.InstructionVersionListResponse": {
             "type": "object",
             "properties": {
-                "content": {
-                    "description": "Content is a list of message content tokens with log probability information.",
+                "data": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/openai.LogProb"
+                        "$ref": "#/definitions/projectres.InstructionVersionResponse"
                     }
+                },
+                "object": {
+                    "type": "string"
+                },
+                "project_id": {
+                    "type": "string"
                 }
             }
         },
-        "openai.Prediction": {
+        "projectres.InstructionVersionResponse": {
             "type": "object",
             "properties": {
-                "content": {
+                "created_at": {
+                    "type": "integer"
+                },
+                "instruction": {
                     "type": "string"
                 },
-                "type": {
+                "object": {
                     "type": "string"
+                },
+                "version": {
+                    "type": "integer"
                 }
             }
         },
-        "openai.Profanity": {
+        "projectres.ProjectDeletedResponse": {
             "type": "object",
             "properties": {
-                "detected": {
+                "deleted": {
                     "type": "boolean"
                 },
-                "filtered": {
-                    "type": "boolean"
+                "id": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
                 }
             }
         },
-        "openai.PromptFilterResult": {
+        "projectres.ProjectListResponse": {
             "type": "object",
             "properties": {
-                "content_filter_results": {
-                    "$ref": "#/definitions/openai.ContentFilterResults"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/projectres.ProjectResponse"
+                    }
                 },
-                "index": {
+                "first_id": {
+                    "type": "string"
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "last_id": {
+                    "type": "string"
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "openai.PromptTokensDetails": {
+        "projectres.ProjectResponse": {
             "type": "object",
             "properties": {
-                "audio_tokens": {
+                "archived_at": {
                     "type": "integer"
                 },
-                "cached_tokens": {
+                "created_at": {
                     "type": "integer"
-                }
-            }
-        },
-        "openai.SelfHarm": {
-            "type": "object",
-            "properties": {
-                "filtered": {
-                    "type": "boolean"
                 },
-                "severity": {
+                "default_tools": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "boolean"
+                    }
+                },
+                "id": {
                     "type": "string"
-                }
-            }
-        },
-        "openai.ServiceTier": {
-            "type": "string",
-            "enum": [
-                "auto",
-                "default",
-                "flex",
-                "priority"
-            ],
-            "x-enum-varnames": [
-                "ServiceTierAuto",
-                "ServiceTierDefault",
-                "ServiceTierFlex",
-                "ServiceTierPriority"
-            ]
-        },
-        "openai.Sexual": {
-            "type": "object",
-            "properties": {
-                "filtered": {
+                },
+                "instruction": {
+                    "type": "string"
+                },
+                "instruction_version": {
+                    "type": "integer"
+                },
+                "is_archived": {
                     "type": "boolean"
                 },
-                "severity": {
+                "is_favorite": {
+                    "type": "boolean"
+                },
+                "knowledge_collections": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "name": {
+                    "type": "string"
+                },
+                "object": {
                     "type": "string"
+                },
+                "updated_at": {
+                    "type": "integer"
                 }
             }
         },
-        "openai.StreamOptions": {
+        "promptlibrary.Category": {
             "type": "object",
             "properties": {
-                "include_usage": {
-                    "description": "If set, an additional chunk will be streamed before the data: [DONE] message.\nThe usage field on this chunk shows the token usage statistics for the entire request,\nand the choices field will always be an empty array.\nAll other chunks will also include a usage field, but with a null value.",
-                    "type": "boolean"
+                "count": {
+                    "type": "integer"
+                },
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "openai.Tool": {
+        "promptlibrary.Message": {
             "type": "object",
             "properties": {
-                "function": {
-                    "$ref": "#/definitions/openai.FunctionDefinition"
+                "content": {
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/openai.ToolType"
+                "role": {
+                    "type": "string"
                 }
             }
         },
-        "openai.ToolCall": {
+        "promptlibrary.Variable": {
             "type": "object",
             "properties": {
-                "function": {
-                    "$ref": "#/definitions/openai.FunctionCall"
+                "default": {
+                    "type": "string"
                 },
-                "id": {
+                "description": {
                     "type": "string"
                 },
-                "index": {
-                    "description": "Index is not nil only in chat completion chunk object",
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 },
-                "type": {
-                    "$ref": "#/definitions/openai.ToolType"
+                "required": {
+                    "type": "boolean"
                 }
             }
         },
-        "openai.ToolType": {
-            "type": "string",
-            "enum": [
-                "function"
-            ],
-            "x-enum-varnames": [
-                "ToolTypeFunction"
-            ]
-        },
-        "openai.TopLogProbs": {
+        "promptlibraryhandler.CategoryListResponse": {
             "type": "object",
             "properties": {
-                "bytes": {
+                "data": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "$ref": "#/definitions/promptlibrary.Category"
                     }
                 },
-                "logprob": {
-                    "type": "number"
-                },
-                "token": {
+                "object": {
                     "type": "string"
                 }
             }
         },
-        "openai.Usage": {
+        "promptlibraryhandler.StarterListResponse": {
             "type": "object",
             "properties": {
-                "completion_tokens": {
-                    "type": "integer"
-                },
-                "completion_tokens_details": {
-                    "$ref": "#/definitions/openai.CompletionTokensDetails"
-                },
-                "prompt_tokens": {
-                    "type": "integer"
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promptlibraryhandler.StarterResponse"
+                    }
                 },
-                "prompt_tokens_details": {
-                    "$ref": "#/definitions/openai.PromptTokensDetails"
+                "object": {
+                    "type": "string"
                 },
-                "total_tokens": {
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "openai.Violence": {
+        "promptlibraryhandler.StarterResponse": {
             "type": "object",
             "properties": {
-                "filtered": {
+                "category": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
                     "type": "boolean"
                 },
-                "severity": {
+                "messages": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promptlibrary.Message"
+                    }
+                },
+                "object": {
                     "type": "string"
-                }
-            }
-        },
-        "projectreq.CreateProjectRequest": {
-            "type": "object",
-            "required": [
-                "name"
-            ],
-            "properties": {
-                "instruction": {
+                },
+                "scope": {
+                    "description": "global (admin-managed) or user (private)",
+                    "type": "string"
+                },
+                "title": {
                     "type": "string"
                 },
-                "name": {
-                    "type": "string"
+                "updated_at": {
+                    "type": "integer"
+                },
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promptlibrary.Variable"
+                    }
                 }
             }
         },
-        "projectreq.UpdateProjectRequest": {
+        "promptlibraryrequests.CreateStarterRequest": {
             "type": "object",
+            "required": [
+                "category",
+                "messages",
+                "title"
+            ],
             "properties": {
-                "instruction": {
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "description": {
                     "type": "string"
                 },
-                "is_archived": {
-                    "type": "boolean"
+                "messages": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/promptlibraryrequests.StarterMessage"
+                    }
                 },
-                "is_favorite": {
-                    "type": "boolean"
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "name": {
-                    "type": "string"
+                "variables": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promptlibraryrequests.StarterVariable"
+                    }
                 }
             }
         },
-        "projectres.ProjectDeletedResponse": {
+        "promptlibraryrequests.StarterMessage": {
             "type": "object",
+            "required": [
+                "content",
+                "role"
+            ],
             "properties": {
-                "deleted": {
-                    "type": "boolean"
-                },
-                "id": {
+                "content": {
+                    "description": "Go template syntax, e.g. \"Explain {{.topic}}\"",
                     "type": "string"
                 },
-                "object": {
-                    "type": "string"
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "system",
+                        "user",
+                        "assistant"
+                    ]
                 }
             }
         },
-        "projectres.ProjectListResponse": {
+        "promptlibraryrequests.StarterVariable": {
             "type": "object",
+            "required": [
+                "name"
+            ],
             "properties": {
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/projectres.ProjectResponse"
-                    }
-                },
-                "first_id": {
-                    "type": "string"
-                },
-                "has_more": {
-                    "type": "boolean"
-                },
-                "last_id": {
+                "default": {
                     "type": "string"
                 },
-                "next_cursor": {
+                "description": {
                     "type": "string"
                 },
-                "object": {
-                    "type": "string"
+                "name": {
+                    "type": "string",
+                    "maxLength": 64
                 },
-                "total": {
-                    "type": "integer"
+                "required": {
+                    "description": "Must be supplied when no default is set",
+                    "type": "boolean"
                 }
             }
         },
-        "projectres.ProjectResponse": {
+        "promptlibraryrequests.UpdateStarterRequest": {
             "type": "object",
             "properties": {
-                "archived_at": {
-                    "type": "integer"
-                },
-                "created_at": {
-                    "type": "integer"
-                },
-                "id": {
-                    "type": "string"
+                "category": {
+                    "type": "string",
+                    "maxLength": 100
                 },
-                "instruction": {
+                "description": {
                     "type": "string"
                 },
-                "is_archived": {
-                    "type": "boolean"
-                },
-                "is_favorite": {
+                "is_active": {
                     "type": "boolean"
                 },
-                "name": {
-                    "type": "string"
+                "messages": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/promptlibraryrequests.StarterMessage"
+                    }
                 },
-                "object": {
-                    "type": "string"
+                "title": {
+                    "type": "string",
+                    "maxLength": 255
                 },
-                "updated_at": {
-                    "type": "integer"
+                "variables": {
+                    "description": "An empty list removes all variables",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/promptlibraryrequests.StarterVariable"
+                    }
                 }
             }
         },
@@ -8958,6 +15838,10 @@ const docTemplate = `{
                 "priority": {
                     "type": "integer"
                 },
+                "region": {
+                    "description": "e.g. eu-west; defaults to the provider's region metadata",
+                    "type": "string"
+                },
                 "url": {
                     "type": "string"
                 },
@@ -8966,6 +15850,14 @@ const docTemplate = `{
                 }
             }
         },
+        "requestmodels.TestProviderRequest": {
+            "type": "object",
+            "properties": {
+                "model": {
+                    "type": "string"
+                }
+            }
+        },
         "requestmodels.UpdateModelCatalogRequest": {
             "type": "object",
             "properties": {
@@ -9066,6 +15958,14 @@ const docTemplate = `{
                 "pricing": {
                     "$ref": "#/definitions/model.Pricing"
                 },
+                "reasoning_policy": {
+                    "description": "Replaces the model's reasoning policy; {} restores the defaults",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/model.ReasoningPolicy"
+                        }
+                    ]
+                },
                 "supports_audio": {
                     "type": "boolean"
                 },
@@ -9139,6 +16039,10 @@ const docTemplate = `{
                 },
                 "request_id": {
                     "type": "string"
+                },
+                "type": {
+                    "description": "Machine-readable error class, e.g. rate_limited, context_length_exceeded",
+                    "type": "string"
                 }
             }
         },
@@ -9412,6 +16316,90 @@ const docTemplate = `{
                 }
             }
         },
+        "synchandler.SyncChangeResponse": {
+            "type": "object",
+            "properties": {
+                "changed_at": {
+                    "type": "integer"
+                },
+                "conversation_id": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "object"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "operation": {
+                    "type": "string",
+                    "enum": [
+                        "created",
+                        "updated",
+                        "deleted"
+                    ]
+                },
+                "type": {
+                    "type": "string",
+                    "enum": [
+                        "conversation",
+                        "conversation.item",
+                        "user_settings"
+                    ]
+                }
+            }
+        },
+        "synchandler.SyncResponse": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/synchandler.SyncChangeResponse"
+                    }
+                },
+                "has_more": {
+                    "type": "boolean"
+                },
+                "next_cursor": {
+                    "description": "Pass as since on the next call",
+                    "type": "string"
+                },
+                "object": {
+                    "type": "string"
+                }
+            }
+        },
+        "synchandler.SyncSettingsResponse": {
+            "type": "object",
+            "properties": {
+                "advanced_settings": {
+                    "$ref": "#/definitions/usersettings.AdvancedSettings"
+                },
+                "enable_tools": {
+                    "type": "boolean"
+                },
+                "enable_trace": {
+                    "type": "boolean"
+                },
+                "memory_config": {
+                    "$ref": "#/definitions/usersettings.MemoryConfig"
+                },
+                "preferences": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "profile_settings": {
+                    "$ref": "#/definitions/usersettings.ProfileSettings"
+                },
+                "updated_at": {
+                    "type": "integer"
+                }
+            }
+        },
         "tokenusage.DailyAggregate": {
             "type": "object",
             "properties": {
@@ -9446,35 +16434,6 @@ const docTemplate = `{
                 }
             }
         },
-        "tokenusage.PlatformUsageResponse": {
-            "type": "object",
-            "properties": {
-                "by_model": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/tokenusage.UsageSummary"
-                    }
-                },
-                "by_provider": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/tokenusage.UsageSummary"
-                    }
-                },
-                "period": {
-                    "$ref": "#/definitions/tokenusage.Period"
-                },
-                "top_users": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/tokenusage.UserUsage"
-                    }
-                },
-                "total_usage": {
-                    "$ref": "#/definitions/tokenusage.UsageSummary"
-                }
-            }
-        },
         "tokenusage.UsageResponse": {
             "type": "object",
             "properties": {
@@ -9524,29 +16483,6 @@ const docTemplate = `{
                 }
             }
         },
-        "tokenusage.UserUsage": {
-            "type": "object",
-            "properties": {
-                "estimated_cost_usd": {
-                    "type": "number"
-                },
-                "request_count": {
-                    "type": "integer"
-                },
-                "total_completion_tokens": {
-                    "type": "integer"
-                },
-                "total_prompt_tokens": {
-                    "type": "integer"
-                },
-                "total_tokens": {
-                    "type": "integer"
-                },
-                "user_id": {
-                    "type": "string"
-                }
-            }
-        },
         "usersettings.AdvancedSettings": {
             "type": "object",
             "properties": {
@@ -9722,6 +16658,21 @@ const docTemplate = `{
                     "type": "integer"
                 }
             }
+        },
+        "webhookhandler.WebhookResponse": {
+            "type": "object",
+            "properties": {
+                "duplicate": {
+                    "description": "Already received; not published again",
+                    "type": "boolean"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
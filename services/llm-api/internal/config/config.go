@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
 	"net/url"
 	"os"
@@ -12,6 +13,26 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v10"
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+
+	"jan-server/services/llm-api/internal/utils/crypto"
+)
+
+// Completion fallback policies (COMPLETION_FALLBACK_POLICY): what a chat completion
+// returns when the provider call fails.
+const (
+	CompletionFallbackError        = "error"         // 502 with the provider error
+	CompletionFallbackNextProvider = "next_provider" // retry on the model's other providers, then 502
+	CompletionFallbackText         = "fallback_text" // canned assistant reply (legacy behaviour)
+)
+
+// Region routing modes (REGION_ROUTING_MODE)
+const (
+	RegionRoutingOff     = "off"     // regions are ignored
+	RegionRoutingPinned  = "pinned"  // prefer the request's X-Jan-Region, else LLM_API_REGION
+	RegionRoutingLatency = "latency" // prefer X-Jan-Region, else the region with the fastest probed endpoint
 )
 
 // Global singleton for backwards compatibility with envs package
@@ -22,10 +43,18 @@ type Config struct {
 	// HTTP Server
 	HTTPPort    int `env:"HTTP_PORT" envDefault:"8080"`
 	MetricsPort int `env:"METRICS_PORT" envDefault:"9091"`
+	// Proxies (IPs or CIDRs) whose X-Forwarded-For is believed when resolving client IPs;
+	// empty trusts none, so the client IP is the address of the peer (e.g. the gateway)
+	TrustedProxies []string `env:"TRUSTED_PROXIES" envSeparator:","`
 
 	// Database - Read/Write Split (required, no defaults)
-	DBPostgresqlWriteDSN string `env:"DB_POSTGRESQL_WRITE_DSN,notEmpty"`
-	DBPostgresqlRead1DSN string `env:"DB_POSTGRESQL_READ1_DSN"` // Optional read replica
+	DBPostgresqlWriteDSN string   `env:"DB_POSTGRESQL_WRITE_DSN,notEmpty"`
+	DBPostgresqlRead1DSN string   `env:"DB_POSTGRESQL_READ1_DSN"`                  // Optional read replica
+	DBPostgresqlReadDSNs []string `env:"DB_POSTGRESQL_READ_DSNS" envSeparator:","` // Optional additional read replicas
+
+	// Database query guardrails
+	DBStatementTimeout   time.Duration `env:"DB_STATEMENT_TIMEOUT" envDefault:"30s"`      // Postgres statement_timeout per connection; 0 disables
+	DBSlowQueryThreshold time.Duration `env:"DB_SLOW_QUERY_THRESHOLD" envDefault:"500ms"` // Log queries slower than this; 0 logs only timeouts
 
 	// Keycloak / Auth
 	KeycloakBaseURL     string        `env:"KEYCLOAK_BASE_URL,notEmpty"`
@@ -48,6 +77,11 @@ type Config struct {
 	RefreshJWKSInterval time.Duration `env:"JWKS_REFRESH_INTERVAL" envDefault:"5m"`
 	AuthClockSkew       time.Duration `env:"AUTH_CLOCK_SKEW" envDefault:"60s"`
 
+	// Token exchange (RFC 8693) for third-party integrations: the listed Keycloak clients trade
+	// a user's access token for a narrowly scoped one, which only reaches the routes its scopes cover
+	TokenExchangeEnabled bool     `env:"TOKEN_EXCHANGE_ENABLED" envDefault:"false"`
+	IntegrationClients   []string `env:"INTEGRATION_CLIENTS" envSeparator:","`
+
 	// API Keys
 	APIKeySecret     []byte        `env:"APIKEY_SECRET"`
 	APIKeyDefaultTTL time.Duration `env:"API_KEY_DEFAULT_TTL" envDefault:"2160h"` // 90 days
@@ -56,6 +90,9 @@ type Config struct {
 	APIKeyPrefix     string        `env:"API_KEY_PREFIX" envDefault:"sk_live"`
 	KongAdminURL     string        `env:"KONG_ADMIN_URL" envDefault:"http://kong:8001"`
 
+	// Pagination cursors (AES-GCM key derived with HKDF; defaults to MODEL_PROVIDER_SECRET)
+	CursorSecret string `env:"CURSOR_SECRET"`
+
 	// Model Provider
 	ModelProviderSecret       string                   `env:"MODEL_PROVIDER_SECRET" envDefault:"jan-model-provider-secret-2024"`
 	JanProviderConfigsEnabled bool                     `env:"JAN_PROVIDER_CONFIGS" envDefault:"true"`
@@ -67,6 +104,86 @@ type Config struct {
 	ModelSyncIntervalMinutes int  `env:"MODEL_SYNC_INTERVAL_MINUTES" envDefault:"60"`
 	ModelSyncEnabled         bool `env:"MODEL_SYNC_ENABLED" envDefault:"true"`
 
+	// Analytics rollups, rebuilt nightly from completion events (schedule is a cron expression)
+	AnalyticsRollupEnabled      bool   `env:"ANALYTICS_ROLLUP_ENABLED" envDefault:"true"`
+	AnalyticsRollupSchedule     string `env:"ANALYTICS_ROLLUP_SCHEDULE" envDefault:"15 0 * * *"`
+	AnalyticsRollupLookbackDays int    `env:"ANALYTICS_ROLLUP_LOOKBACK_DAYS" envDefault:"3"`
+
+	// Cold storage: items of conversations idle for AfterMonths are moved to
+	// media-api and rehydrated when the conversation is written to again
+	ColdStorageEnabled     bool   `env:"COLD_STORAGE_ENABLED" envDefault:"false"`
+	ColdStorageSchedule    string `env:"COLD_STORAGE_SCHEDULE" envDefault:"30 3 * * *"`
+	ColdStorageAfterMonths int    `env:"COLD_STORAGE_AFTER_MONTHS" envDefault:"6"`
+	ColdStorageBatchSize   int    `env:"COLD_STORAGE_BATCH_SIZE" envDefault:"200"` // Conversations archived per run
+
+	// Evaluation harness; queued dataset runs are executed by an in-process worker
+	EvalWorkerEnabled   bool   `env:"EVAL_WORKER_ENABLED" envDefault:"true"`
+	EvalConcurrency     int    `env:"EVAL_CONCURRENCY" envDefault:"4"` // Items evaluated in parallel per run
+	EvalMaxDatasetItems int    `env:"EVAL_MAX_DATASET_ITEMS" envDefault:"1000"`
+	EvalJudgeModel      string `env:"EVAL_JUDGE_MODEL"` // Default model for llm_judge graders
+
+	// Leader election, so model sync and analytics rollups run on one replica
+	LeaderElectionEnabled       bool          `env:"LEADER_ELECTION_ENABLED" envDefault:"true"`
+	LeaderElectionBackend       string        `env:"LEADER_ELECTION_BACKEND" envDefault:"postgres"` // postgres (advisory lock) or kubernetes (Lease)
+	LeaderElectionName          string        `env:"LEADER_ELECTION_NAME" envDefault:"llm-api-crontab"`
+	LeaderElectionRetryPeriod   time.Duration `env:"LEADER_ELECTION_RETRY_PERIOD" envDefault:"5s"`
+	LeaderElectionLeaseDuration time.Duration `env:"LEADER_ELECTION_LEASE_DURATION" envDefault:"15s"` // kubernetes only
+	PodName                     string        `env:"POD_NAME"`                                        // Replica identity; defaults to the hostname
+	PodNamespace                string        `env:"POD_NAMESPACE"`                                   // Lease namespace; defaults to the service account's
+
+	// Redis for stream bookkeeping and guest quotas shared across replicas; empty keeps both process-local
+	RedisURL string `env:"REDIS_URL"`
+
+	// Outbound HTTP clients, tuned per target through <PREFIX>TIMEOUT, <PREFIX>MAX_CONNS_PER_HOST,
+	// <PREFIX>RETRIES, <PREFIX>PROXY_URL, ... (see httpclient.FromEnv)
+	ProviderHTTP httpclient.Config `env:"-"` // PROVIDER_HTTP_*: model providers
+	MediaHTTP    httpclient.Config `env:"-"` // MEDIA_HTTP_*: media-api uploads
+	MemoryHTTP   httpclient.Config `env:"-"` // MEMORY_HTTP_*: memory-tools
+	TTSHTTP      httpclient.Config `env:"-"` // TTS_HTTP_*: text-to-speech server
+	LiveKitHTTP  httpclient.Config `env:"-"` // LIVEKIT_HTTP_*: LiveKit server API
+	CaptchaHTTP  httpclient.Config `env:"-"` // CAPTCHA_HTTP_*: CAPTCHA verification
+
+	// Circuit breakers of the media-api, memory-tools, text-to-speech, LiveKit and CAPTCHA
+	// clients, read from <PREFIX>BREAKER_FAILURES and <PREFIX>BREAKER_OPEN_TIMEOUT of their HTTP prefix
+	MediaBreaker   clients.BreakerConfig `env:"-"`
+	MemoryBreaker  clients.BreakerConfig `env:"-"`
+	TTSBreaker     clients.BreakerConfig `env:"-"`
+	LiveKitBreaker clients.BreakerConfig `env:"-"`
+	CaptchaBreaker clients.BreakerConfig `env:"-"`
+
+	// Fine-tuning dataset exports built from liked assistant turns
+	FinetuneExportMaxExamples int `env:"FINETUNE_EXPORT_MAX_EXAMPLES" envDefault:"5000"` // The file must also fit within media-api MEDIA_MAX_BYTES
+
+	// Prompt library of conversation starters
+	PromptLibraryMaxPerUser int `env:"PROMPT_LIBRARY_MAX_PER_USER" envDefault:"100"` // Private starters per user
+
+	// Custom personas (user-defined assistants)
+	PersonaMaxPerUser int `env:"PERSONA_MAX_PER_USER" envDefault:"50"`
+
+	// Delta sync change log for offline-capable clients
+	SyncRetention time.Duration `env:"SYNC_RETENTION" envDefault:"720h"` // Older checkpoints get 410 and must resync; 0 keeps changes forever
+
+	// mcp_call items still in_progress after this are failed by a reconciliation job,
+	// since the tool result PATCH never arrived; 0 disables the job
+	MCPCallTimeout time.Duration `env:"MCP_CALL_TIMEOUT" envDefault:"15m"`
+
+	// MCP credential store (per-user third-party tokens for first-party MCP tools)
+	MCPCredentialSecret string `env:"MCP_CREDENTIAL_SECRET"` // Falls back to MODEL_PROVIDER_SECRET
+
+	// Versioned master keys for stored secrets (provider API keys, MCP credentials). The first
+	// version:secret entry encrypts; older ones stay listed until the re-encryption job moves
+	// their values over. Empty keeps encrypting with MODEL_PROVIDER_SECRET / MCP_CREDENTIAL_SECRET.
+	SecretKeys    string          `env:"SECRET_KEYS"`
+	SecretKeyring *crypto.Keyring `env:"-"`
+
+	// Encryption at rest of item content with per-user data keys wrapped by a KMS master key
+	EncryptionAtRestEnabled bool   `env:"ENCRYPTION_AT_REST_ENABLED" envDefault:"false"`
+	EncryptionKMSProvider   string `env:"ENCRYPTION_KMS_PROVIDER" envDefault:"local"` // local or vault
+	EncryptionMasterKey     string `env:"ENCRYPTION_MASTER_KEY"`                      // local: base64-encoded 32-byte key
+	EncryptionVaultAddr     string `env:"ENCRYPTION_VAULT_ADDR"`
+	EncryptionVaultToken    string `env:"ENCRYPTION_VAULT_TOKEN"`
+	EncryptionVaultKeyName  string `env:"ENCRYPTION_VAULT_KEY_NAME" envDefault:"jan-llm-api"` // Transit key name
+
 	// Observability / Logging
 	HTTPTimeout      time.Duration `env:"HTTP_TIMEOUT" envDefault:"30s"`
 	OTLPEndpoint     string        `env:"OTEL_EXPORTER_OTLP_ENDPOINT"`
@@ -76,6 +193,15 @@ type Config struct {
 	Environment      string        `env:"ENVIRONMENT" envDefault:"development"`
 	LogLevel         string        `env:"LOG_LEVEL" envDefault:"info"`
 	LogFormat        string        `env:"LOG_FORMAT" envDefault:"console"`
+	LogExporter      string        `env:"OTEL_LOGS_EXPORTER" envDefault:"none"` // otlp: also export logs to OTEL_EXPORTER_OTLP_ENDPOINT
+
+	// Trace sampling: routes without an override keep OTEL_TRACES_SAMPLER_ARG of traces, and unsampled
+	// traces that fail or run slower than their threshold are exported anyway
+	TraceSampleRate    float64                  `env:"OTEL_TRACES_SAMPLER_ARG" envDefault:"1"`
+	TraceRouteRates    map[string]float64       `env:"OTEL_TRACES_SAMPLER_ROUTES" envSeparator:"," envKeyValSeparator:"="` // route_prefix=rate,... e.g. /v1/chat/completions=0.01
+	TraceSlowThreshold time.Duration            `env:"OTEL_TRACES_SLOW_THRESHOLD" envDefault:"5s"`                         // 0 keeps only failed unsampled traces
+	TraceSlowRoutes    map[string]time.Duration `env:"OTEL_TRACES_SLOW_ROUTES" envSeparator:"," envKeyValSeparator:"="`    // route_prefix=threshold,... e.g. /v1/chat/completions=60s
+	TraceTailSampling  bool                     `env:"OTEL_TRACES_TAIL_SAMPLING" envDefault:"false"`                       // Sample every trace, with jan.sampling.* hints for a tail-sampling collector
 
 	// Features
 	AutoMigrate   bool `env:"AUTO_MIGRATE" envDefault:"true"`
@@ -86,9 +212,39 @@ type Config struct {
 	MediaIngestURL      string        `env:"MEDIA_INGEST_URL" envDefault:"http://kong:8000/media/v1/media"`
 	MediaResolveTimeout time.Duration `env:"MEDIA_RESOLVE_TIMEOUT" envDefault:"5s"`
 
+	// Text-to-speech for streamed audio replies (chat completions with "audio"
+	// in modalities); an OpenAI-compatible /audio/speech server, disabled when unset
+	TTSURL    string `env:"TTS_URL"` // e.g. https://api.openai.com/v1
+	TTSAPIKey string `env:"TTS_API_KEY"`
+	TTSModel  string `env:"TTS_MODEL" envDefault:"tts-1"`
+	TTSVoice  string `env:"TTS_VOICE" envDefault:"alloy"` // Used when a request names no voice
+
+	// LiveKit server of realtime sessions, for streaming chat completion deltas to a room's
+	// data channel (chat completions with "realtime"); also needs LIVEKIT_API_KEY and
+	// LIVEKIT_API_SECRET, disabled when unset
+	LiveKitWsURL string `env:"LIVEKIT_WS_URL"` // e.g. wss://your-livekit-server.com
+
 	// Streaming timeout for LLM responses (increase for large/complex requests)
 	StreamTimeout time.Duration `env:"STREAM_TIMEOUT" envDefault:"600s"`
 
+	// Interval for SSE keep-alive comments on idle streams (0 disables)
+	SSEHeartbeatInterval time.Duration `env:"SSE_HEARTBEAT_INTERVAL" envDefault:"15s"`
+
+	// Behaviour when the provider call fails: error, next_provider or fallback_text
+	CompletionFallbackPolicy string `env:"COMPLETION_FALLBACK_POLICY" envDefault:"error"`
+
+	// Chat request limits; oversized requests are rejected with 413 (0 disables a limit)
+	ChatMaxRequestBytes int64 `env:"CHAT_MAX_REQUEST_BYTES" envDefault:"20971520"` // 20MB
+	ChatMaxMessages     int   `env:"CHAT_MAX_MESSAGES" envDefault:"1000"`
+	ChatMaxImageBytes   int64 `env:"CHAT_MAX_IMAGE_BYTES" envDefault:"10485760"` // 10MB per decoded base64 image
+
+	// Feature flags ramping the features below per user; each defaults to its setting.
+	// Later sources override earlier ones: FEATURE_FLAGS, the file, then the Redis hash
+	FeatureFlags                map[string]string `env:"FEATURE_FLAGS" envSeparator:"," envKeyValSeparator:"="` // flag=true|false|N%,... e.g. deep_research=25%
+	FeatureFlagsFile            string            `env:"FEATURE_FLAGS_FILE"`                                    // YAML mapping flags to a value or {enabled, percent, users}
+	FeatureFlagsRedisKey        string            `env:"FEATURE_FLAGS_REDIS_KEY"`                               // Hash on REDIS_URL, e.g. jan:feature-flags
+	FeatureFlagsRefreshInterval time.Duration     `env:"FEATURE_FLAGS_REFRESH_INTERVAL" envDefault:"30s"`
+
 	// Prompt Orchestration
 	PromptOrchestrationEnabled         bool `env:"PROMPT_ORCHESTRATION_ENABLED" envDefault:"false"`
 	PromptOrchestrationEnableMemory    bool `env:"PROMPT_ORCHESTRATION_MEMORY" envDefault:"false"`
@@ -99,10 +255,29 @@ type Config struct {
 	MemoryEnabled bool          `env:"MEMORY_ENABLED" envDefault:"false"`
 	MemoryBaseURL string        `env:"MEMORY_BASE_URL" envDefault:"http://memory-tools:8090"`
 	MemoryTimeout time.Duration `env:"MEMORY_TIMEOUT" envDefault:"5s"`
+	// Deadline for loading memories in the chat path; memory is skipped when it runs out
+	MemoryLoadBudget time.Duration `env:"MEMORY_LOAD_BUDGET" envDefault:"300ms"`
+	// Admin-triggered replays of historical conversations through memory observation;
+	// queued backfills are executed by an in-process worker
+	MemoryBackfillWorkerEnabled bool          `env:"MEMORY_BACKFILL_WORKER_ENABLED" envDefault:"true"`
+	MemoryBackfillInterval      time.Duration `env:"MEMORY_BACKFILL_INTERVAL" envDefault:"2s"`     // Pause between conversations
+	MemoryBackfillMaxMessages   int           `env:"MEMORY_BACKFILL_MAX_MESSAGES" envDefault:"50"` // Latest messages observed per conversation
+
+	// Maintenance mode, switched through /v1/admin/maintenance; shared through REDIS_URL
+	MaintenanceRefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL" envDefault:"5s"` // How soon other replicas apply a switch
 
 	// Conversation Sharing
 	ConversationSharingEnabled bool `env:"CONVERSATION_SHARING_ENABLED" envDefault:"false"`
 
+	// Link policy for citation and tool output URLs stored in conversation items
+	LinkPolicyEnabled    bool          `env:"LINK_POLICY_ENABLED" envDefault:"true"`
+	LinkBlocklistDomains []string      `env:"LINK_BLOCKLIST_DOMAINS" envSeparator:","`
+	LinkBlocklistFile    string        `env:"LINK_BLOCKLIST_FILE"` // One domain per line; hosts-file format is accepted
+	LinkResolveRedirects bool          `env:"LINK_RESOLVE_REDIRECTS" envDefault:"false"`
+	LinkRedirectHosts    []string      `env:"LINK_REDIRECT_HOSTS" envSeparator:"," envDefault:"t.co,bit.ly,tinyurl.com,goo.gl,ow.ly,buff.ly,lnkd.in,is.gd,rebrand.ly"`
+	LinkResolveTimeout   time.Duration `env:"LINK_RESOLVE_TIMEOUT" envDefault:"2s"`
+	LinkResolveMaxHops   int           `env:"LINK_RESOLVE_MAX_HOPS" envDefault:"3"`
+
 	// Conversation Title Generation
 	ConversationTitleGenerationEnabled bool   `env:"CONVERSATION_TITLE_GENERATION_ENABLED" envDefault:"false"`
 	ConversationTitleGenerationModelID string `env:"CONVERSATION_TITLE_GENERATION_MODEL_ID" envDefault:"LFM2-8B-A1B"`
@@ -118,6 +293,44 @@ type Config struct {
 	ImageDefaultResponseFormat string        `env:"IMAGE_DEFAULT_RESPONSE_FORMAT" envDefault:"url"`
 	ImageMediaPresignTTL       time.Duration `env:"IMAGE_MEDIA_PRESIGN_TTL" envDefault:"1h"`
 
+	// Completion admission control; models and providers without a cap are not limited
+	AdmissionModelLimits    map[string]int `env:"ADMISSION_MODEL_LIMITS" envSeparator:"," envKeyValSeparator:"="`    // model_public_id=max_in_flight,...
+	AdmissionProviderLimits map[string]int `env:"ADMISSION_PROVIDER_LIMITS" envSeparator:"," envKeyValSeparator:"="` // provider_public_id=max_in_flight,...
+	AdmissionQueueSize      int            `env:"ADMISSION_QUEUE_SIZE" envDefault:"32"`                              // Requests waiting per model or provider
+	AdmissionQueueTimeout   time.Duration  `env:"ADMISSION_QUEUE_TIMEOUT" envDefault:"30s"`
+	AdmissionRetryAfter     time.Duration  `env:"ADMISSION_RETRY_AFTER" envDefault:"5s"` // Retry-After sent when a request is turned away
+
+	// Abuse controls of the guest tier (accounts from POST /auth/guest-login); days run in UTC
+	// and 0 disables a cap
+	GuestDailyMessages   int      `env:"GUEST_DAILY_MESSAGES" envDefault:"0"`    // Chat completions per guest account and day
+	GuestIPDailyMessages int      `env:"GUEST_IP_DAILY_MESSAGES" envDefault:"0"` // Chat completions of all guests per client IP (IPv6 /64) and day
+	GuestModels          []string `env:"GUEST_MODELS" envSeparator:","`          // Model public IDs guests may use; empty allows every model
+	GuestCaptchaAfter    int      `env:"GUEST_CAPTCHA_AFTER" envDefault:"0"`     // Daily messages of a guest or IP after which a CAPTCHA is required
+
+	// CAPTCHA verification for the guest tier; any siteverify API taking secret, response
+	// and remoteip works (Cloudflare Turnstile, hCaptcha, reCAPTCHA). Disabled when unset
+	CaptchaSecret    string `env:"CAPTCHA_SECRET"`
+	CaptchaVerifyURL string `env:"CAPTCHA_VERIFY_URL" envDefault:"https://challenges.cloudflare.com/turnstile/v0/siteverify"`
+
+	// vLLM load signals, scraped from the /metrics endpoint of providers whose
+	// inference_engine metadata is "vllm"
+	VLLMMetricsInterval          time.Duration `env:"VLLM_METRICS_INTERVAL" envDefault:"15s"`             // 0 disables scraping
+	VLLMRoutingControllerEnabled bool          `env:"VLLM_ROUTING_CONTROLLER_ENABLED" envDefault:"false"` // Route away from saturated vLLM endpoints
+	VLLMSaturationKVCache        float64       `env:"VLLM_SATURATION_KV_CACHE" envDefault:"0.9"`          // KV-cache utilization (0-1) treated as saturated
+	VLLMSaturationQueueDepth     int           `env:"VLLM_SATURATION_QUEUE_DEPTH" envDefault:"8"`         // Waiting requests treated as saturated
+
+	// Multi-region routing of completions to providers and endpoints labelled with a region
+	Region              string        `env:"LLM_API_REGION"`                         // Region this replica runs in, e.g. eu-west
+	RegionRoutingMode   string        `env:"REGION_ROUTING_MODE" envDefault:"off"`   // off | pinned | latency
+	RegionProbeInterval time.Duration `env:"REGION_PROBE_INTERVAL" envDefault:"30s"` // Endpoint latency probes in latency mode
+
+	// Provider webhooks (POST /v1/webhooks/{source}); a source is accepted only once its secret is set
+	OpenAIWebhookSecret     string        `env:"OPENAI_WEBHOOK_SECRET"` // whsec_... signing secret
+	OpenRouterWebhookSecret string        `env:"OPENROUTER_WEBHOOK_SECRET"`
+	LiveKitAPIKey           string        `env:"LIVEKIT_API_KEY"`
+	LiveKitAPISecret        string        `env:"LIVEKIT_API_SECRET"`
+	WebhookTolerance        time.Duration `env:"WEBHOOK_TOLERANCE" envDefault:"5m"` // Maximum age of a signed delivery
+
 	// Internal
 	EnvReloadedAt time.Time
 }
@@ -165,6 +378,19 @@ func Load() (*Config, error) {
 		return nil, errors.New("either JWKS_URL or OIDC_DISCOVERY_URL must be provided")
 	}
 
+	trustedProxies := make([]string, 0, len(cfg.TrustedProxies))
+	for _, proxy := range cfg.TrustedProxies {
+		proxy = strings.TrimSpace(proxy)
+		if proxy == "" {
+			continue
+		}
+		if !validProxy(proxy) {
+			return nil, fmt.Errorf("invalid TRUSTED_PROXIES entry %q: want an IP or CIDR", proxy)
+		}
+		trustedProxies = append(trustedProxies, proxy)
+	}
+	cfg.TrustedProxies = trustedProxies
+
 	if cfg.JWKSURL != "" {
 		if _, err := url.ParseRequestURI(cfg.JWKSURL); err != nil {
 			return nil, fmt.Errorf("invalid JWKS_URL: %w", err)
@@ -210,6 +436,172 @@ func Load() (*Config, error) {
 	cfg.LogFormat = strings.ToLower(cfg.LogFormat)
 	cfg.EnvReloadedAt = time.Now()
 
+	cfg.CompletionFallbackPolicy = strings.ToLower(strings.TrimSpace(cfg.CompletionFallbackPolicy))
+	switch cfg.CompletionFallbackPolicy {
+	case "":
+		cfg.CompletionFallbackPolicy = CompletionFallbackError
+	case CompletionFallbackError, CompletionFallbackNextProvider, CompletionFallbackText:
+	default:
+		return nil, fmt.Errorf("invalid COMPLETION_FALLBACK_POLICY %q: must be error, next_provider or fallback_text", cfg.CompletionFallbackPolicy)
+	}
+
+	cfg.Region = strings.ToLower(strings.TrimSpace(cfg.Region))
+	cfg.RegionRoutingMode = strings.ToLower(strings.TrimSpace(cfg.RegionRoutingMode))
+	switch cfg.RegionRoutingMode {
+	case "":
+		cfg.RegionRoutingMode = RegionRoutingOff
+	case RegionRoutingOff, RegionRoutingPinned, RegionRoutingLatency:
+	default:
+		return nil, fmt.Errorf("invalid REGION_ROUTING_MODE %q: must be off, pinned or latency", cfg.RegionRoutingMode)
+	}
+	if cfg.RegionProbeInterval <= 0 {
+		cfg.RegionProbeInterval = 30 * time.Second
+	}
+
+	if cfg.ChatMaxRequestBytes < 0 {
+		cfg.ChatMaxRequestBytes = 0
+	}
+	if cfg.ChatMaxMessages < 0 {
+		cfg.ChatMaxMessages = 0
+	}
+	if cfg.ChatMaxImageBytes < 0 {
+		cfg.ChatMaxImageBytes = 0
+	}
+
+	cfg.AnalyticsRollupSchedule = strings.TrimSpace(cfg.AnalyticsRollupSchedule)
+	if cfg.AnalyticsRollupLookbackDays < 1 {
+		cfg.AnalyticsRollupLookbackDays = 1
+	}
+
+	cfg.ColdStorageSchedule = strings.TrimSpace(cfg.ColdStorageSchedule)
+	if cfg.ColdStorageAfterMonths < 1 {
+		cfg.ColdStorageAfterMonths = 1
+	}
+	if cfg.ColdStorageBatchSize < 1 {
+		cfg.ColdStorageBatchSize = 1
+	}
+
+	if cfg.EvalConcurrency < 1 {
+		cfg.EvalConcurrency = 1
+	}
+	if cfg.EvalMaxDatasetItems < 1 {
+		cfg.EvalMaxDatasetItems = 1000
+	}
+	cfg.EvalJudgeModel = strings.TrimSpace(cfg.EvalJudgeModel)
+
+	cfg.LeaderElectionBackend = strings.ToLower(strings.TrimSpace(cfg.LeaderElectionBackend))
+	switch cfg.LeaderElectionBackend {
+	case "postgres", "kubernetes":
+	default:
+		return nil, fmt.Errorf("invalid LEADER_ELECTION_BACKEND %q: must be postgres or kubernetes", cfg.LeaderElectionBackend)
+	}
+	if cfg.LeaderElectionRetryPeriod <= 0 {
+		cfg.LeaderElectionRetryPeriod = 5 * time.Second
+	}
+	if cfg.LeaderElectionLeaseDuration <= cfg.LeaderElectionRetryPeriod {
+		// The leader must get at least one renewal in before its lease lapses.
+		cfg.LeaderElectionLeaseDuration = 3 * cfg.LeaderElectionRetryPeriod
+	}
+
+	if err := cfg.loadHTTPClients(); err != nil {
+		return nil, err
+	}
+
+	keyring, err := crypto.ParseKeyring(cfg.SecretKeys)
+	if err != nil {
+		return nil, fmt.Errorf("invalid SECRET_KEYS: %w", err)
+	}
+	cfg.SecretKeyring = keyring
+
+	if cfg.FinetuneExportMaxExamples < 1 {
+		cfg.FinetuneExportMaxExamples = 5000
+	}
+	if cfg.PromptLibraryMaxPerUser < 1 {
+		cfg.PromptLibraryMaxPerUser = 100
+	}
+	if cfg.MemoryBackfillInterval < 0 {
+		cfg.MemoryBackfillInterval = 0
+	}
+	if cfg.MCPCallTimeout < 0 {
+		cfg.MCPCallTimeout = 0
+	}
+	for key, limit := range cfg.AdmissionModelLimits {
+		if limit < 0 {
+			return nil, fmt.Errorf("invalid ADMISSION_MODEL_LIMITS entry %q: limit must be >= 0", key)
+		}
+	}
+	for key, limit := range cfg.AdmissionProviderLimits {
+		if limit < 0 {
+			return nil, fmt.Errorf("invalid ADMISSION_PROVIDER_LIMITS entry %q: limit must be >= 0", key)
+		}
+	}
+	if cfg.AdmissionQueueSize < 0 {
+		cfg.AdmissionQueueSize = 0
+	}
+	if cfg.AdmissionQueueTimeout < 0 {
+		cfg.AdmissionQueueTimeout = 0
+	}
+	if cfg.AdmissionRetryAfter <= 0 {
+		cfg.AdmissionRetryAfter = 5 * time.Second
+	}
+	if cfg.GuestDailyMessages < 0 {
+		cfg.GuestDailyMessages = 0
+	}
+	if cfg.GuestIPDailyMessages < 0 {
+		cfg.GuestIPDailyMessages = 0
+	}
+	if cfg.GuestCaptchaAfter < 0 {
+		cfg.GuestCaptchaAfter = 0
+	}
+	if cfg.VLLMMetricsInterval < 0 {
+		cfg.VLLMMetricsInterval = 0
+	}
+	if cfg.DBStatementTimeout < 0 {
+		cfg.DBStatementTimeout = 0
+	}
+	if cfg.DBSlowQueryThreshold < 0 {
+		cfg.DBSlowQueryThreshold = 0
+	}
+	if cfg.TraceSampleRate < 0 || cfg.TraceSampleRate > 1 {
+		return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ARG %v: must be between 0 and 1", cfg.TraceSampleRate)
+	}
+	for route, rate := range cfg.TraceRouteRates {
+		if rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("invalid OTEL_TRACES_SAMPLER_ROUTES entry %q: rate must be between 0 and 1", route)
+		}
+	}
+	if cfg.TraceSlowThreshold < 0 {
+		cfg.TraceSlowThreshold = 0
+	}
+	for route, threshold := range cfg.TraceSlowRoutes {
+		if threshold < 0 {
+			return nil, fmt.Errorf("invalid OTEL_TRACES_SLOW_ROUTES entry %q: threshold must be >= 0", route)
+		}
+	}
+	for key, value := range cfg.FeatureFlags {
+		if _, err := featureflags.ParseFlag(value); err != nil {
+			return nil, fmt.Errorf("invalid FEATURE_FLAGS entry %q: %w", key, err)
+		}
+	}
+	if cfg.MaintenanceRefreshInterval <= 0 {
+		cfg.MaintenanceRefreshInterval = 5 * time.Second
+	}
+	if cfg.FeatureFlagsRefreshInterval <= 0 {
+		cfg.FeatureFlagsRefreshInterval = featureflags.DefaultRefreshInterval
+	}
+	if cfg.VLLMSaturationKVCache <= 0 || cfg.VLLMSaturationKVCache > 1 {
+		cfg.VLLMSaturationKVCache = 0.9
+	}
+	if cfg.VLLMSaturationQueueDepth < 1 {
+		cfg.VLLMSaturationQueueDepth = 8
+	}
+	if cfg.MemoryBackfillMaxMessages < 1 {
+		cfg.MemoryBackfillMaxMessages = 50
+	}
+	if cfg.PersonaMaxPerUser < 1 {
+		cfg.PersonaMaxPerUser = 50
+	}
+
 	cfg.ConversationTitleGenerationModelID = strings.TrimSpace(cfg.ConversationTitleGenerationModelID)
 	if cfg.ConversationTitleGenerationModelID == "" {
 		cfg.ConversationTitleGenerationModelID = "LFM2-8B-A1B"
@@ -254,6 +646,67 @@ func (c *Config) ResolveJWKSURL(ctx context.Context) (string, error) {
 	return doc.JWKSURL, nil
 }
 
+// loadHTTPClients reads the per-target outbound HTTP client and circuit breaker
+// settings. Model provider calls carry long streams, so they get no overall
+// timeout and rely on STREAM_TIMEOUT instead; MEMORY_TIMEOUT remains the
+// memory-tools default.
+func (c *Config) loadHTTPClients() error {
+	provider := httpclient.DefaultConfig()
+	provider.Timeout = 0
+	provider.MaxIdleConnsPerHost = 32
+
+	media := httpclient.DefaultConfig()
+
+	memory := httpclient.DefaultConfig()
+	if c.MemoryTimeout > 0 {
+		memory.Timeout = c.MemoryTimeout
+	}
+
+	// Deltas go out as they stream, so a stalled LiveKit server must not hold them up for long
+	liveKit := httpclient.DefaultConfig()
+	liveKit.Timeout = 5 * time.Second
+
+	// A CAPTCHA check holds up the guest's message
+	captcha := httpclient.DefaultConfig()
+	captcha.Timeout = 5 * time.Second
+
+	var err error
+	if c.ProviderHTTP, err = httpclient.FromEnv("PROVIDER_HTTP_", provider); err != nil {
+		return err
+	}
+	if c.MediaHTTP, err = httpclient.FromEnv("MEDIA_HTTP_", media); err != nil {
+		return err
+	}
+	if c.MemoryHTTP, err = httpclient.FromEnv("MEMORY_HTTP_", memory); err != nil {
+		return err
+	}
+	if c.TTSHTTP, err = httpclient.FromEnv("TTS_HTTP_", httpclient.DefaultConfig()); err != nil {
+		return err
+	}
+	if c.MediaBreaker, err = clients.BreakerFromEnv("MEDIA_HTTP_", clients.DefaultBreakerConfig()); err != nil {
+		return err
+	}
+	if c.MemoryBreaker, err = clients.BreakerFromEnv("MEMORY_HTTP_", clients.DefaultBreakerConfig()); err != nil {
+		return err
+	}
+	if c.TTSBreaker, err = clients.BreakerFromEnv("TTS_HTTP_", clients.DefaultBreakerConfig()); err != nil {
+		return err
+	}
+	if c.LiveKitHTTP, err = httpclient.FromEnv("LIVEKIT_HTTP_", liveKit); err != nil {
+		return err
+	}
+	if c.LiveKitBreaker, err = clients.BreakerFromEnv("LIVEKIT_HTTP_", clients.DefaultBreakerConfig()); err != nil {
+		return err
+	}
+	if c.CaptchaHTTP, err = httpclient.FromEnv("CAPTCHA_HTTP_", captcha); err != nil {
+		return err
+	}
+	if c.CaptchaBreaker, err = clients.BreakerFromEnv("CAPTCHA_HTTP_", clients.DefaultBreakerConfig()); err != nil {
+		return err
+	}
+	return nil
+}
+
 // GetDatabaseWriteDSN returns the write database connection string.
 func (c *Config) GetDatabaseWriteDSN() string {
 	return c.DBPostgresqlWriteDSN
@@ -269,6 +722,22 @@ func (c *Config) GetDatabaseReadDSN() string {
 	return c.GetDatabaseWriteDSN()
 }
 
+// GetDatabaseReadReplicaDSNs returns the configured read replica DSNs in order,
+// without duplicates or the write DSN. Empty when no replica is configured.
+func (c *Config) GetDatabaseReadReplicaDSNs() []string {
+	seen := map[string]bool{c.GetDatabaseWriteDSN(): true}
+	var dsns []string
+	for _, dsn := range append([]string{c.DBPostgresqlRead1DSN}, c.DBPostgresqlReadDSNs...) {
+		dsn = strings.TrimSpace(dsn)
+		if dsn == "" || seen[dsn] {
+			continue
+		}
+		seen[dsn] = true
+		dsns = append(dsns, dsn)
+	}
+	return dsns
+}
+
 // GetGlobal returns the global config instance for backwards compatibility.
 // Deprecated: Use dependency injection with Load() instead.
 func GetGlobal() *Config {
@@ -284,6 +753,20 @@ func GetEnvReloadedAt() time.Time {
 	return time.Time{}
 }
 
+// ProviderKeyring returns the keyring for model provider API keys
+func (c *Config) ProviderKeyring() *crypto.Keyring {
+	return c.SecretKeyring.WithLegacySecret(c.ModelProviderSecret)
+}
+
+// MCPCredentialKeyring returns the keyring for stored MCP credential tokens
+func (c *Config) MCPCredentialKeyring() *crypto.Keyring {
+	secret := strings.TrimSpace(c.MCPCredentialSecret)
+	if secret == "" {
+		secret = c.ModelProviderSecret
+	}
+	return c.SecretKeyring.WithLegacySecret(secret)
+}
+
 // ProviderBootstrapEntries returns the configured provider definitions for the active set.
 func (c *Config) ProviderBootstrapEntries() []ProviderBootstrapEntry {
 	if c == nil || c.ProviderBootstrap == nil {
@@ -297,3 +780,12 @@ var Version = "dev"
 func IsDev() bool {
 	return strings.HasPrefix(Version, "dev")
 }
+
+// validProxy reports whether proxy is an IP or CIDR, as gin's SetTrustedProxies accepts.
+func validProxy(proxy string) bool {
+	if strings.Contains(proxy, "/") {
+		_, _, err := net.ParseCIDR(proxy)
+		return err == nil
+	}
+	return net.ParseIP(proxy) != nil
+}
@@ -279,6 +279,7 @@ type EndpointConfig struct {
 	URL      string `yaml:"url"`
 	Weight   int    `yaml:"weight"`
 	Priority int    `yaml:"priority"`
+	Region   string `yaml:"region"` // Overrides the provider's region metadata
 }
 
 func parseEndpointConfigs(raw []EndpointConfig) ([]EndpointConfig, error) {
@@ -303,6 +304,7 @@ func parseEndpointConfigs(raw []EndpointConfig) ([]EndpointConfig, error) {
 			URL:      normalized,
 			Weight:   weight,
 			Priority: ep.Priority,
+			Region:   strings.TrimSpace(os.ExpandEnv(ep.Region)),
 		})
 	}
 	return endpoints, nil
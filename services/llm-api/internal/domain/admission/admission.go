@@ -0,0 +1,57 @@
+// Package admission caps the completions running at once per model and per
+// provider. Requests over a cap wait in a queue that serves users in turn, so
+// one user's parallel requests cannot starve everyone else; when the queue is
+// full or the wait too long the request is turned away.
+package admission
+
+import (
+	"fmt"
+	"time"
+)
+
+// Limit scopes
+const (
+	ScopeModel    = "model"
+	ScopeProvider = "provider"
+)
+
+// Config holds the concurrency caps. Models and providers without a cap are
+// admitted without limit.
+type Config struct {
+	ModelLimits    map[string]int // Model public ID to maximum completions in flight
+	ProviderLimits map[string]int // Provider public ID to maximum completions in flight
+	QueueSize      int            // Requests that may wait per model or provider; 0 rejects at once
+	QueueTimeout   time.Duration  // Longest a request waits for a slot
+	RetryAfter     time.Duration  // Backoff suggested to rejected clients
+}
+
+// Request identifies what a completion needs a slot for
+type Request struct {
+	UserKey    string // Requests are queued fairly across users
+	ModelID    string // Model public ID
+	ProviderID string // Provider public ID
+}
+
+// SaturatedError means a model or provider had no free slot for the request
+type SaturatedError struct {
+	Scope      string
+	Key        string
+	TimedOut   bool // The request waited QueueTimeout; otherwise the queue was full
+	RetryAfter time.Duration
+}
+
+func (e *SaturatedError) Error() string {
+	if e.TimedOut {
+		return fmt.Sprintf("%s %s is at capacity: no slot freed within the queue timeout", e.Scope, e.Key)
+	}
+	return fmt.Sprintf("%s %s is at capacity: too many requests waiting", e.Scope, e.Key)
+}
+
+// RetryAfterSeconds is the Retry-After value for the rejected client
+func (e *SaturatedError) RetryAfterSeconds() int {
+	seconds := int((e.RetryAfter + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
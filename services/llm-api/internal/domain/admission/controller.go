@@ -0,0 +1,227 @@
+package admission
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+// Controller admits completions against the configured caps. Slots are
+// counted per instance, so each replica enforces the caps on its own.
+type Controller struct {
+	cfg Config
+
+	mu    sync.Mutex
+	gates map[string]*gate // By scope and key, created on first use
+}
+
+// NewController creates the controller; without caps every request is admitted
+func NewController(cfg Config) *Controller {
+	return &Controller{
+		cfg:   cfg,
+		gates: make(map[string]*gate),
+	}
+}
+
+// Enabled reports whether any cap is configured
+func (c *Controller) Enabled() bool {
+	return len(c.cfg.ModelLimits) > 0 || len(c.cfg.ProviderLimits) > 0
+}
+
+// Acquire waits for a slot on the request's model and provider. The returned
+// function frees the slots and must be called once the completion finishes.
+// A request that cannot be admitted gets a *SaturatedError.
+func (c *Controller) Acquire(ctx context.Context, req Request) (func(), error) {
+	if !c.Enabled() {
+		return func() {}, nil
+	}
+
+	if c.cfg.QueueTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.cfg.QueueTimeout)
+		defer cancel()
+	}
+
+	// Always model before provider, so two requests never hold each other's slot
+	var releases []func()
+	releaseAll := func() {
+		for i := len(releases) - 1; i >= 0; i-- {
+			releases[i]()
+		}
+	}
+	for _, target := range []struct {
+		scope  string
+		key    string
+		limits map[string]int
+	}{
+		{ScopeModel, req.ModelID, c.cfg.ModelLimits},
+		{ScopeProvider, req.ProviderID, c.cfg.ProviderLimits},
+	} {
+		g := c.gate(target.scope, target.key, target.limits)
+		if g == nil {
+			continue
+		}
+		queued, err := g.acquire(ctx, req.UserKey, c.cfg.QueueSize)
+		if err != nil {
+			releaseAll()
+			return nil, c.rejection(ctx, target.scope, target.key, err)
+		}
+		if queued {
+			metrics.AdmissionRequestsTotal.WithLabelValues(target.scope, "queued").Inc()
+		} else {
+			metrics.AdmissionRequestsTotal.WithLabelValues(target.scope, "admitted").Inc()
+		}
+		releases = append(releases, g.release)
+	}
+
+	var once sync.Once
+	return func() { once.Do(releaseAll) }, nil
+}
+
+// rejection turns a failed wait into the error reported to the caller. A
+// caller that went away gets its own context error back.
+func (c *Controller) rejection(ctx context.Context, scope, key string, err error) error {
+	switch {
+	case errors.Is(err, errQueueFull):
+		metrics.AdmissionRequestsTotal.WithLabelValues(scope, "rejected").Inc()
+		return &SaturatedError{Scope: scope, Key: key, RetryAfter: c.cfg.RetryAfter}
+	case errors.Is(ctx.Err(), context.DeadlineExceeded):
+		metrics.AdmissionRequestsTotal.WithLabelValues(scope, "timed_out").Inc()
+		return &SaturatedError{Scope: scope, Key: key, TimedOut: true, RetryAfter: c.cfg.RetryAfter}
+	default:
+		return err
+	}
+}
+
+func (c *Controller) gate(scope, key string, limits map[string]int) *gate {
+	limit := limits[key]
+	if key == "" || limit <= 0 {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	name := scope + ":" + key
+	g, ok := c.gates[name]
+	if !ok {
+		g = newGate(limit, metrics.AdmissionInFlight.WithLabelValues(scope, key))
+		c.gates[name] = g
+	}
+	return g
+}
+
+var errQueueFull = errors.New("admission queue full")
+
+type inFlightGauge interface {
+	Set(float64)
+}
+
+// gate is a counting semaphore whose waiters are served round-robin by user
+type gate struct {
+	limit int
+	gauge inFlightGauge
+
+	mu       sync.Mutex
+	inFlight int
+	waiting  int
+	queues   map[string][]chan struct{} // Waiters per user, oldest first
+	users    []string                   // Users with waiters, in serving order
+}
+
+func newGate(limit int, gauge inFlightGauge) *gate {
+	return &gate{
+		limit:  limit,
+		gauge:  gauge,
+		queues: make(map[string][]chan struct{}),
+	}
+}
+
+// acquire takes a slot, waiting for one if needed; it reports whether it waited
+func (g *gate) acquire(ctx context.Context, user string, queueSize int) (bool, error) {
+	g.mu.Lock()
+	if g.inFlight < g.limit && g.waiting == 0 {
+		g.inFlight++
+		g.gauge.Set(float64(g.inFlight))
+		g.mu.Unlock()
+		return false, nil
+	}
+	if g.waiting >= queueSize {
+		g.mu.Unlock()
+		return false, errQueueFull
+	}
+	ready := make(chan struct{})
+	if len(g.queues[user]) == 0 {
+		g.users = append(g.users, user)
+	}
+	g.queues[user] = append(g.queues[user], ready)
+	g.waiting++
+	g.mu.Unlock()
+
+	select {
+	case <-ready:
+		return true, nil
+	case <-ctx.Done():
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if !g.dequeue(user, ready) {
+		// Granted a slot while giving up; hand it on
+		g.releaseLocked()
+	}
+	return true, ctx.Err()
+}
+
+// release frees a slot, passing it to the next user in turn when any wait
+func (g *gate) release() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.releaseLocked()
+}
+
+func (g *gate) releaseLocked() {
+	if g.waiting == 0 {
+		g.inFlight--
+		g.gauge.Set(float64(g.inFlight))
+		return
+	}
+	user := g.users[0]
+	queue := g.queues[user]
+	next := queue[0]
+	g.users = g.users[1:]
+	if len(queue) > 1 {
+		g.queues[user] = queue[1:]
+		g.users = append(g.users, user)
+	} else {
+		delete(g.queues, user)
+	}
+	g.waiting--
+	close(next)
+}
+
+// dequeue removes a waiter that gave up; it reports false when the waiter was
+// already granted a slot
+func (g *gate) dequeue(user string, ready chan struct{}) bool {
+	queue := g.queues[user]
+	for i, waiter := range queue {
+		if waiter != ready {
+			continue
+		}
+		queue = append(queue[:i:i], queue[i+1:]...)
+		if len(queue) > 0 {
+			g.queues[user] = queue
+		} else {
+			delete(g.queues, user)
+			for j, u := range g.users {
+				if u == user {
+					g.users = append(g.users[:j:j], g.users[j+1:]...)
+					break
+				}
+			}
+		}
+		g.waiting--
+		return true
+	}
+	return false
+}
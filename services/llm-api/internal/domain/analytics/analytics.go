@@ -0,0 +1,252 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+const (
+	// DateLayout is the format of dates in query parameters and responses
+	DateLayout = "2006-01-02"
+
+	// DefaultRangeDays is used when the client selects no range
+	DefaultRangeDays = 30
+
+	// MaxRangeDays caps custom ranges so a query never spans more than a year of rollups
+	MaxRangeDays = 366
+
+	// DefaultTopModelsLimit is the number of models returned by the top models endpoint
+	DefaultTopModelsLimit = 10
+
+	// DefaultUsageSince is how far back the admin usage report looks by default
+	DefaultUsageSince = 7 * 24 * time.Hour
+)
+
+// Groupings of the admin usage report
+const (
+	UsageByModel    = "model"
+	UsageByProvider = "provider"
+	UsageByUser     = "user"
+	UsageByDay      = "day"
+)
+
+// RangePresets maps the selectable range values to their length in days
+var RangePresets = map[string]int{
+	"7d":   7,
+	"30d":  30,
+	"90d":  90,
+	"365d": 365,
+}
+
+// CompletionEvent records one chat completion; rollups are built from these rows
+type CompletionEvent struct {
+	ID               uint
+	UserID           uint
+	ConversationID   *uint
+	Model            string
+	Provider         string
+	PromptTokens     int
+	CompletionTokens int
+	LatencyMs        int64
+	ToolCalls        []string // Names of the tools the model called
+	Stream           bool
+	Succeeded        bool
+	CreatedAt        time.Time
+}
+
+// TimeRange is an inclusive range of UTC calendar days
+type TimeRange struct {
+	From time.Time
+	To   time.Time
+}
+
+// Days returns the number of calendar days covered by the range
+func (r TimeRange) Days() int {
+	return int(r.To.Sub(r.From).Hours()/24) + 1
+}
+
+// StartDate returns the first day of the range formatted with DateLayout
+func (r TimeRange) StartDate() string {
+	return r.From.Format(DateLayout)
+}
+
+// EndDate returns the last day of the range formatted with DateLayout
+func (r TimeRange) EndDate() string {
+	return r.To.Format(DateLayout)
+}
+
+// DailyMessages is the number of messages a user exchanged on one day
+type DailyMessages struct {
+	Date              string `json:"date"`
+	UserMessages      int64  `json:"user_messages"`
+	AssistantMessages int64  `json:"assistant_messages"`
+	TotalMessages     int64  `json:"total_messages"`
+}
+
+// ModelUsage aggregates successful completions for one model
+type ModelUsage struct {
+	Model            string  `json:"model"`
+	Requests         int64   `json:"requests"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+}
+
+// ToolUsage counts the calls the model made to one tool
+type ToolUsage struct {
+	ToolName string  `json:"tool_name"`
+	Calls    int64   `json:"calls"`
+	Share    float64 `json:"share"` // Fraction of all tool calls in the range
+}
+
+// DailyLatency is the average response latency of successful completions on one day
+type DailyLatency struct {
+	Date              string  `json:"date"`
+	Completions       int64   `json:"completions"`
+	FailedCompletions int64   `json:"failed_completions"`
+	AverageLatencyMs  float64 `json:"average_latency_ms"`
+	TotalLatencyMs    int64   `json:"-"`
+}
+
+// LatencySummary is the average response latency over a range with its daily breakdown
+type LatencySummary struct {
+	Completions       int64          `json:"completions"`
+	FailedCompletions int64          `json:"failed_completions"`
+	AverageLatencyMs  float64        `json:"average_latency_ms"`
+	Daily             []DailyLatency `json:"daily"`
+}
+
+// UsageQuery selects the completion events summarised by the admin usage report
+type UsageQuery struct {
+	By     string // One of the UsageBy groupings
+	UserID *uint  // Only this user's completions
+	Since  time.Time
+	Until  time.Time
+}
+
+// UsageRow aggregates the completion events sharing one grouping key. Unlike the
+// per-user dashboard it reads raw events, so today's traffic is included.
+type UsageRow struct {
+	Key              string  `json:"key"` // Model, provider, user ID or YYYY-MM-DD day
+	Requests         int64   `json:"requests"`
+	FailedRequests   int64   `json:"failed_requests"`
+	PromptTokens     int64   `json:"prompt_tokens"`
+	CompletionTokens int64   `json:"completion_tokens"`
+	AverageLatencyMs float64 `json:"average_latency_ms"` // Over successful requests
+}
+
+// Repository defines data access for analytics events and rollups
+type Repository interface {
+	CreateEvent(ctx context.Context, event *CompletionEvent) error
+	// RollupDay rebuilds all rollup rows for the UTC day containing day
+	RollupDay(ctx context.Context, day time.Time) error
+
+	FindDailyMessages(ctx context.Context, userID uint, r TimeRange) ([]DailyMessages, error)
+	FindTopModels(ctx context.Context, userID uint, r TimeRange, limit int) ([]ModelUsage, error)
+	FindToolUsage(ctx context.Context, userID uint, r TimeRange) ([]ToolUsage, error)
+	FindDailyLatency(ctx context.Context, userID uint, r TimeRange) ([]DailyLatency, error)
+
+	// FindUsage aggregates raw completion events across all users
+	FindUsage(ctx context.Context, q UsageQuery) ([]UsageRow, error)
+}
+
+// ParseTimeRange resolves the selected range. An explicit start_date/end_date pair wins
+// over a preset; with neither, the last DefaultRangeDays days ending today are used.
+func ParseTimeRange(ctx context.Context, preset, startDate, endDate string, now time.Time) (TimeRange, error) {
+	today := truncateDay(now)
+
+	if startDate != "" || endDate != "" {
+		to := today
+		if endDate != "" {
+			parsed, err := time.Parse(DateLayout, endDate)
+			if err != nil {
+				return TimeRange{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+					fmt.Sprintf("invalid end_date %q: expected YYYY-MM-DD", endDate), err, "3c7e1a94-5d2b-4f86-a0c3-8e9b6d1f4a27")
+			}
+			to = parsed
+		}
+		if startDate == "" {
+			return TimeRange{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				"start_date is required when end_date is set", nil, "8a1f6c3e-2b7d-4e95-9c04-5f3a7e2d1b68")
+		}
+		from, err := time.Parse(DateLayout, startDate)
+		if err != nil {
+			return TimeRange{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("invalid start_date %q: expected YYYY-MM-DD", startDate), err, "e4b92d17-6f3a-4c58-b1e0-7d2c9a5f3e86")
+		}
+		r := TimeRange{From: from, To: to}
+		if r.To.Before(r.From) {
+			return TimeRange{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				"end_date must not be before start_date", nil, "5f0d3b82-9e4c-4a17-8d6b-2c1e7f9a0b54")
+		}
+		if r.Days() > MaxRangeDays {
+			return TimeRange{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("range must not exceed %d days", MaxRangeDays), nil, "b26e8f40-1c9a-4d73-a5e2-6f8d0b3c7a19")
+		}
+		return r, nil
+	}
+
+	days := DefaultRangeDays
+	if preset != "" {
+		presetDays, ok := RangePresets[preset]
+		if !ok {
+			return TimeRange{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("invalid range %q: must be one of 7d, 30d, 90d, 365d", preset), nil, "d83a5c61-7e2f-4b09-9a4d-1e6c8f2b5d73")
+		}
+		days = presetDays
+	}
+	return TimeRange{From: today.AddDate(0, 0, -(days - 1)), To: today}, nil
+}
+
+// ParseSince resolves the start of a lookback window. It accepts a day count ("7d"),
+// a Go duration ("12h"), a date (YYYY-MM-DD) or an RFC 3339 timestamp; empty means
+// DefaultUsageSince. Windows longer than MaxRangeDays are rejected.
+func ParseSince(ctx context.Context, value string, now time.Time) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	var since time.Time
+	switch {
+	case value == "":
+		since = now.Add(-DefaultUsageSince)
+	case strings.HasSuffix(value, "d"):
+		days, err := strconv.Atoi(strings.TrimSuffix(value, "d"))
+		if err != nil || days <= 0 {
+			return time.Time{}, invalidSince(ctx, value, err)
+		}
+		since = now.AddDate(0, 0, -days)
+	default:
+		if d, err := time.ParseDuration(value); err == nil && d > 0 {
+			since = now.Add(-d)
+		} else if t, err := time.Parse(time.RFC3339, value); err == nil {
+			since = t
+		} else if t, err := time.Parse(DateLayout, value); err == nil {
+			since = t
+		} else {
+			return time.Time{}, invalidSince(ctx, value, err)
+		}
+	}
+
+	if since.After(now) {
+		return time.Time{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"since must not be in the future", nil, "0e7b4d29-6a1f-4c83-b5d2-9f3a8c6e1b47")
+	}
+	if now.Sub(since) > MaxRangeDays*24*time.Hour {
+		return time.Time{}, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("since must not be more than %d days ago", MaxRangeDays), nil, "a4c81f37-2d9e-4b06-8e5a-7b1d3f9c2e60")
+	}
+	return since.UTC(), nil
+}
+
+func invalidSince(ctx context.Context, value string, err error) error {
+	return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+		fmt.Sprintf("invalid since %q: expected e.g. 7d, 12h, YYYY-MM-DD or an RFC 3339 timestamp", value), err, "5d92a6e8-3b1c-4f07-9a4e-2c8f6d0b7e15")
+}
+
+func truncateDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
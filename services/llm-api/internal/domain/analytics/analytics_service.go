@@ -0,0 +1,154 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// Service records completion events and serves the per-user analytics dashboard
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new analytics service
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// RecordCompletion stores one completion event for the next rollup
+func (s *Service) RecordCompletion(ctx context.Context, event *CompletionEvent) error {
+	if event.CreatedAt.IsZero() {
+		event.CreatedAt = time.Now().UTC()
+	}
+	if err := s.repo.CreateEvent(ctx, event); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to record completion event")
+	}
+	return nil
+}
+
+// Rollup rebuilds the rollups for the lookbackDays complete days before now. Each day is
+// rebuilt from scratch, so re-running after a missed or failed night is safe.
+func (s *Service) Rollup(ctx context.Context, now time.Time, lookbackDays int) error {
+	if lookbackDays <= 0 {
+		lookbackDays = 1
+	}
+	today := truncateDay(now)
+	for offset := lookbackDays; offset >= 1; offset-- {
+		day := today.AddDate(0, 0, -offset)
+		if err := s.repo.RollupDay(ctx, day); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to roll up analytics for "+day.Format(DateLayout))
+		}
+	}
+	return nil
+}
+
+// GetMessagesPerDay returns one entry per day of the range, including days without messages
+func (s *Service) GetMessagesPerDay(ctx context.Context, userID uint, r TimeRange) ([]DailyMessages, error) {
+	rows, err := s.repo.FindDailyMessages(ctx, userID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	byDate := make(map[string]DailyMessages, len(rows))
+	for _, row := range rows {
+		byDate[row.Date] = row
+	}
+
+	result := make([]DailyMessages, 0, r.Days())
+	for day := r.From; !day.After(r.To); day = day.AddDate(0, 0, 1) {
+		date := day.Format(DateLayout)
+		entry, ok := byDate[date]
+		if !ok {
+			entry = DailyMessages{Date: date}
+		}
+		entry.TotalMessages = entry.UserMessages + entry.AssistantMessages
+		result = append(result, entry)
+	}
+	return result, nil
+}
+
+// GetTopModels returns the most used models in the range, by request count
+func (s *Service) GetTopModels(ctx context.Context, userID uint, r TimeRange, limit int) ([]ModelUsage, error) {
+	if limit <= 0 {
+		limit = DefaultTopModelsLimit
+	}
+	models, err := s.repo.FindTopModels(ctx, userID, r, limit)
+	if err != nil {
+		return nil, err
+	}
+	if models == nil {
+		models = []ModelUsage{}
+	}
+	return models, nil
+}
+
+// GetToolUsage returns the tool call breakdown for the range, most called first
+func (s *Service) GetToolUsage(ctx context.Context, userID uint, r TimeRange) ([]ToolUsage, error) {
+	tools, err := s.repo.FindToolUsage(ctx, userID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	var total int64
+	for _, tool := range tools {
+		total += tool.Calls
+	}
+	for i := range tools {
+		if total > 0 {
+			tools[i].Share = float64(tools[i].Calls) / float64(total)
+		}
+	}
+	if tools == nil {
+		tools = []ToolUsage{}
+	}
+	return tools, nil
+}
+
+// GetLatency returns the average response latency for the range, weighted by completions
+func (s *Service) GetLatency(ctx context.Context, userID uint, r TimeRange) (*LatencySummary, error) {
+	daily, err := s.repo.FindDailyLatency(ctx, userID, r)
+	if err != nil {
+		return nil, err
+	}
+
+	summary := &LatencySummary{Daily: make([]DailyLatency, 0, len(daily))}
+	var totalLatency int64
+	for _, day := range daily {
+		if day.Completions > 0 {
+			day.AverageLatencyMs = float64(day.TotalLatencyMs) / float64(day.Completions)
+		}
+		summary.Completions += day.Completions
+		summary.FailedCompletions += day.FailedCompletions
+		totalLatency += day.TotalLatencyMs
+		summary.Daily = append(summary.Daily, day)
+	}
+	if summary.Completions > 0 {
+		summary.AverageLatencyMs = float64(totalLatency) / float64(summary.Completions)
+	}
+	return summary, nil
+}
+
+// GetPlatformUsage summarises completions across all users for operators, largest
+// groups first, or chronologically when grouped by day
+func (s *Service) GetPlatformUsage(ctx context.Context, q UsageQuery) ([]UsageRow, error) {
+	switch q.By {
+	case UsageByModel, UsageByProvider, UsageByUser, UsageByDay:
+	default:
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("invalid by %q: must be one of model, provider, user, day", q.By), nil, "e1b7c45a-8f2d-4936-a0c8-3d6e9f1b5a27")
+	}
+	if q.Until.IsZero() {
+		q.Until = time.Now().UTC()
+	}
+	rows, err := s.repo.FindUsage(ctx, q)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to get platform usage")
+	}
+	if rows == nil {
+		rows = []UsageRow{}
+	}
+	return rows, nil
+}
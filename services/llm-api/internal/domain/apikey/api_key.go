@@ -20,10 +20,16 @@ type APIKey struct {
 	UpdatedAt  time.Time
 }
 
+// ListCursor is the last key of a page; keys are listed newest first.
+type ListCursor struct {
+	CreatedAt time.Time
+	ID        string
+}
+
 // Repository defines storage operations for API keys.
 type Repository interface {
 	Create(ctx context.Context, key *APIKey) (*APIKey, error)
-	ListByUser(ctx context.Context, userID uint) ([]APIKey, error)
+	ListByUser(ctx context.Context, userID uint, after *ListCursor, limit int) ([]APIKey, error)
 	FindByID(ctx context.Context, id string) (*APIKey, error)
 	FindByHash(ctx context.Context, hash string) (*APIKey, error)
 	CountActiveByUser(ctx context.Context, userID uint) (int64, error)
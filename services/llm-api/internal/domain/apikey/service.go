@@ -123,9 +123,10 @@ func (s *Service) CreateKey(ctx context.Context, usr *user.User, name string, re
 	return persisted, rawKey, nil
 }
 
-// ListKeys returns API keys for the provided user.
-func (s *Service) ListKeys(ctx context.Context, userID uint) ([]APIKey, error) {
-	items, err := s.repo.ListByUser(ctx, userID)
+// ListKeys returns up to limit API keys for the provided user, newest first,
+// starting after the given cursor.
+func (s *Service) ListKeys(ctx context.Context, userID uint, after *ListCursor, limit int) ([]APIKey, error) {
+	items, err := s.repo.ListByUser(ctx, userID, after, limit)
 	if err != nil {
 		return nil, err
 	}
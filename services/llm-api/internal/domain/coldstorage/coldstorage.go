@@ -0,0 +1,55 @@
+// Package coldstorage moves the items of idle conversations out of the hot
+// conversation_items table. Their payload (content, arguments, output and the
+// other type-specific columns) is gzipped into a media-api object and cleared,
+// leaving stub rows that keep ordering, role, status and ratings. Rehydrating
+// a conversation restores the payload; it runs on demand and before a
+// conversation is written to or continued.
+package coldstorage
+
+import (
+	"context"
+	"time"
+)
+
+// Archive is one stored object holding the payload of archived items
+type Archive struct {
+	ID             uint
+	ConversationID uint
+	MediaID        string // media-api object with the gzipped payload
+	ItemCount      int
+	Bytes          int64 // Size of the gzipped object
+	CreatedAt      time.Time
+}
+
+// Export is the payload of a batch of items about to be archived
+type Export struct {
+	ItemIDs []uint
+	Payload []byte // Encoded by the repository; opaque to the service
+}
+
+// Store keeps archive objects
+type Store interface {
+	// Available reports whether objects can be stored and read
+	Available() bool
+	Put(ctx context.Context, data []byte, filename string) (string, error)
+	Get(ctx context.Context, mediaID string) ([]byte, error)
+}
+
+// Repository defines data access for archived items
+type Repository interface {
+	// FindIdleConversations returns IDs of conversations above afterID, in ID order,
+	// that were last updated before cutoff and still have items in the hot table
+	FindIdleConversations(ctx context.Context, cutoff time.Time, afterID uint, limit int) ([]uint, error)
+	// ExportItems returns the payload of up to limit hot items of a conversation
+	// last updated before cutoff; items still in progress are left out
+	ExportItems(ctx context.Context, conversationID uint, cutoff time.Time, limit int) (*Export, error)
+	// SaveArchive records archive and clears the payload of the exported items it
+	// holds. Items updated since cutoff keep their payload; archive.ItemCount is
+	// set to the number of items archived.
+	SaveArchive(ctx context.Context, archive *Archive, export *Export, cutoff time.Time) error
+	// FindArchives returns the archives of a conversation, oldest first
+	FindArchives(ctx context.Context, conversationID uint) ([]*Archive, error)
+	// RestoreArchive writes payload back to the stubs of archive and deletes it.
+	// It returns the number of items restored.
+	RestoreArchive(ctx context.Context, archive *Archive, payload []byte) (int, error)
+}
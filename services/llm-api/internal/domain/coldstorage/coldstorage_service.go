@@ -0,0 +1,225 @@
+package coldstorage
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+const (
+	// maxItemsPerArchive bounds one archive object, which must fit within
+	// media-api MEDIA_MAX_BYTES once gzipped
+	maxItemsPerArchive = 500
+	// maxPayloadBytes bounds the decompressed payload read back from an archive
+	maxPayloadBytes = 256 << 20
+)
+
+// Operations, as recorded in metrics
+const (
+	operationArchive   = "archive"
+	operationRehydrate = "rehydrate"
+)
+
+// Config configures the Service
+type Config struct {
+	AfterMonths int // Conversations idle this long are archived
+	BatchSize   int // Conversations archived per run
+}
+
+// Service archives the items of idle conversations and rehydrates them
+type Service struct {
+	repo        Repository
+	store       Store
+	afterMonths int
+	batchSize   int
+
+	// archiving guards ArchiveIdle so one run is in flight at a time
+	archiving sync.Mutex
+}
+
+// NewService creates a new cold storage service
+func NewService(repo Repository, store Store, cfg Config) *Service {
+	afterMonths := cfg.AfterMonths
+	if afterMonths < 1 {
+		afterMonths = 1
+	}
+	batchSize := cfg.BatchSize
+	if batchSize < 1 {
+		batchSize = 1
+	}
+	return &Service{
+		repo:        repo,
+		store:       store,
+		afterMonths: afterMonths,
+		batchSize:   batchSize,
+	}
+}
+
+// Result summarizes an archiving run
+type Result struct {
+	Conversations int // Conversations with items archived
+	Items         int
+	Failed        int // Conversations that failed and stay in the hot table
+}
+
+// ArchiveIdle archives the items of up to the batch size of conversations not
+// updated in the configured number of months before now. A conversation that
+// fails is skipped and retried on the next run. Calls made while a run is in
+// flight return an empty result.
+func (s *Service) ArchiveIdle(ctx context.Context, now time.Time) (*Result, error) {
+	result := &Result{}
+	if !s.store.Available() {
+		return result, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"cold storage requires media-api: set MEDIA_INGEST_URL", nil, "2c7e9a41-5b3d-4f86-a0e2-8d1f6b4c9e37")
+	}
+	if !s.archiving.TryLock() {
+		return result, nil
+	}
+	defer s.archiving.Unlock()
+
+	log := logger.GetLogger()
+	cutoff := now.AddDate(0, -s.afterMonths, 0)
+	var afterID uint
+	for result.Conversations+result.Failed < s.batchSize {
+		ids, err := s.repo.FindIdleConversations(ctx, cutoff, afterID, s.batchSize-result.Conversations-result.Failed)
+		if err != nil {
+			return result, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to find idle conversations")
+		}
+		if len(ids) == 0 {
+			break
+		}
+		for _, id := range ids {
+			afterID = id
+			items, err := s.archiveConversation(ctx, id, cutoff)
+			if err != nil {
+				log.Error().Err(err).Uint("conversation_id", id).Msg("failed to archive conversation items")
+				metrics.ColdStorageErrorsTotal.WithLabelValues(operationArchive).Inc()
+				result.Failed++
+				continue
+			}
+			if items > 0 {
+				result.Conversations++
+				result.Items += items
+			}
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// archiveConversation moves the idle items of a conversation to cold storage,
+// one archive per maxItemsPerArchive items
+func (s *Service) archiveConversation(ctx context.Context, conversationID uint, cutoff time.Time) (int, error) {
+	archived := 0
+	for {
+		export, err := s.repo.ExportItems(ctx, conversationID, cutoff, maxItemsPerArchive)
+		if err != nil {
+			return archived, fmt.Errorf("export items: %w", err)
+		}
+		if len(export.ItemIDs) == 0 {
+			return archived, nil
+		}
+
+		data, err := compress(export.Payload)
+		if err != nil {
+			return archived, fmt.Errorf("compress items: %w", err)
+		}
+		mediaID, err := s.store.Put(ctx, data, fmt.Sprintf("conversation-%d-items.json.gz", conversationID))
+		if err != nil {
+			return archived, fmt.Errorf("store archive: %w", err)
+		}
+		archive := &Archive{
+			ConversationID: conversationID,
+			MediaID:        mediaID,
+			Bytes:          int64(len(data)),
+		}
+		if err := s.repo.SaveArchive(ctx, archive, export, cutoff); err != nil {
+			return archived, fmt.Errorf("save archive: %w", err)
+		}
+		archived += archive.ItemCount
+		metrics.ColdStorageItemsTotal.WithLabelValues(operationArchive).Add(float64(archive.ItemCount))
+
+		if len(export.ItemIDs) < maxItemsPerArchive {
+			return archived, nil
+		}
+	}
+}
+
+// Rehydrate restores the archived items of a conversation to the hot table and
+// returns how many were restored. Conversations without archived items return
+// right away.
+func (s *Service) Rehydrate(ctx context.Context, conversationID uint) (int, error) {
+	archives, err := s.repo.FindArchives(ctx, conversationID)
+	if err != nil {
+		return 0, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to find conversation archives")
+	}
+	if len(archives) == 0 {
+		return 0, nil
+	}
+	if !s.store.Available() {
+		return 0, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeInternal,
+			"conversation items are in cold storage but media-api is not configured", nil, "9e4b1f7c-3a2d-4c58-b6e0-7d5a2f8c1b94")
+	}
+
+	restored := 0
+	for _, archive := range archives {
+		n, err := s.restore(ctx, archive)
+		if err != nil {
+			metrics.ColdStorageErrorsTotal.WithLabelValues(operationRehydrate).Inc()
+			return restored, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to rehydrate conversation items")
+		}
+		restored += n
+	}
+	metrics.ColdStorageItemsTotal.WithLabelValues(operationRehydrate).Add(float64(restored))
+	return restored, nil
+}
+
+func (s *Service) restore(ctx context.Context, archive *Archive) (int, error) {
+	data, err := s.store.Get(ctx, archive.MediaID)
+	if err != nil {
+		return 0, fmt.Errorf("read archive %s: %w", archive.MediaID, err)
+	}
+	payload, err := decompress(data)
+	if err != nil {
+		return 0, fmt.Errorf("decompress archive %s: %w", archive.MediaID, err)
+	}
+	return s.repo.RestoreArchive(ctx, archive, payload)
+}
+
+func compress(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func decompress(data []byte) ([]byte, error) {
+	zr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+	payload, err := io.ReadAll(io.LimitReader(zr, maxPayloadBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(payload) > maxPayloadBytes {
+		return nil, fmt.Errorf("payload exceeds %d bytes", maxPayloadBytes)
+	}
+	return payload, nil
+}
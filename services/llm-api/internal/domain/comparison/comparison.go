@@ -0,0 +1,93 @@
+package comparison
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	// MinModels is the smallest number of models a comparison runs
+	MinModels = 2
+
+	// MaxModels is the largest number of models a comparison runs
+	MaxModels = 4
+
+	// DefaultLeaderboardMinVotes hides models with fewer votes from the leaderboard
+	DefaultLeaderboardMinVotes = 1
+)
+
+// VoteOutcome is the user's verdict on a comparison
+type VoteOutcome string
+
+const (
+	// VoteWinner marks one model (WinnerSlot) as the best answer
+	VoteWinner VoteOutcome = "winner"
+	// VoteTie marks all answers as equally good
+	VoteTie VoteOutcome = "tie"
+	// VoteAllBad marks all answers as unacceptable
+	VoteAllBad VoteOutcome = "all_bad"
+)
+
+// IsValid reports whether the outcome is one of the supported values
+func (o VoteOutcome) IsValid() bool {
+	switch o {
+	case VoteWinner, VoteTie, VoteAllBad:
+		return true
+	}
+	return false
+}
+
+// Comparison is one run of the same prompt against several models
+type Comparison struct {
+	ID          uint
+	PublicID    string
+	UserID      uint
+	Models      []string // Model public IDs, indexed by slot
+	Results     []Result
+	Vote        *VoteOutcome
+	WinnerSlot  *int
+	VotedAt     *time.Time
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Result is the outcome of one model in a comparison
+type Result struct {
+	Slot             int
+	Model            string
+	Provider         string
+	PromptTokens     int
+	CompletionTokens int
+	LatencyMs        int64
+	Succeeded        bool
+	Error            string
+}
+
+// LeaderboardEntry aggregates the votes cast on comparisons a model took part in
+type LeaderboardEntry struct {
+	Model            string  `json:"model"`
+	Votes            int64   `json:"votes"`
+	Wins             int64   `json:"wins"`
+	Losses           int64   `json:"losses"`
+	Ties             int64   `json:"ties"`
+	WinRate          float64 `json:"win_rate"`
+	AverageLatencyMs float64 `json:"average_latency_ms"`
+}
+
+// LeaderboardFilter selects the votes counted by the leaderboard
+type LeaderboardFilter struct {
+	From     time.Time // Inclusive
+	To       time.Time // Exclusive
+	MinVotes int
+}
+
+// Repository defines data access for comparisons and their votes
+type Repository interface {
+	Create(ctx context.Context, c *Comparison) error
+	FindByPublicID(ctx context.Context, publicID string) (*Comparison, error)
+	// SaveResults stores the per-model results and marks the comparison completed
+	SaveResults(ctx context.Context, c *Comparison) error
+	SaveVote(ctx context.Context, c *Comparison) error
+	FindLeaderboard(ctx context.Context, filter LeaderboardFilter) ([]LeaderboardEntry, error)
+}
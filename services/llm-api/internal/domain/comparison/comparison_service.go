@@ -0,0 +1,148 @@
+package comparison
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// Service manages model comparisons, their votes and the leaderboard
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new comparison service
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// ValidateModels checks the selected model count and returns the trimmed model IDs.
+// Each model may appear only once so votes map to a single slot.
+func (s *Service) ValidateModels(ctx context.Context, models []string) ([]string, error) {
+	if len(models) < MinModels || len(models) > MaxModels {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("a comparison needs between %d and %d models, got %d", MinModels, MaxModels, len(models)), nil, "2d8f4b17-6a3e-4c95-b0e2-7f1c9d5a3e68")
+	}
+	trimmed := make([]string, 0, len(models))
+	seen := make(map[string]bool, len(models))
+	for i, model := range models {
+		model = strings.TrimSpace(model)
+		if model == "" {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("models[%d] is empty", i), nil, "8c1e5a93-2f7d-4b06-9e4a-3d6b0f8c2a15")
+		}
+		if seen[model] {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("model %q is selected more than once", model), nil, "f4a7c2e9-1b5d-4e83-a6c0-9d2e7b3f5a41")
+		}
+		seen[model] = true
+		trimmed = append(trimmed, model)
+	}
+	return trimmed, nil
+}
+
+// Start validates the selected models and stores a new comparison for the user
+func (s *Service) Start(ctx context.Context, userID uint, models []string) (*Comparison, error) {
+	models, err := s.ValidateModels(ctx, models)
+	if err != nil {
+		return nil, err
+	}
+
+	publicID, err := idgen.GenerateSecureID("cmp", 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate comparison id")
+	}
+
+	c := &Comparison{
+		PublicID: publicID,
+		UserID:   userID,
+		Models:   models,
+	}
+	if err := s.repo.Create(ctx, c); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create comparison")
+	}
+	return c, nil
+}
+
+// Complete stores the per-model results once every model has finished
+func (s *Service) Complete(ctx context.Context, c *Comparison, results []Result) error {
+	now := time.Now().UTC()
+	c.Results = results
+	c.CompletedAt = &now
+	if err := s.repo.SaveResults(ctx, c); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to save comparison results")
+	}
+	return nil
+}
+
+// GetByPublicID returns the user's comparison; comparisons of other users are reported as not found
+func (s *Service) GetByPublicID(ctx context.Context, userID uint, publicID string) (*Comparison, error) {
+	c, err := s.repo.FindByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if c.UserID != userID {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+			"comparison not found", nil, "5b9e3d71-4c8a-4f26-8e1b-0a7d2c6f9e34")
+	}
+	return c, nil
+}
+
+// Vote records the user's verdict on a finished comparison. Voting again replaces the
+// previous vote. winnerSlot is required for VoteWinner and ignored otherwise.
+func (s *Service) Vote(ctx context.Context, userID uint, publicID string, outcome VoteOutcome, winnerSlot *int) (*Comparison, error) {
+	if !outcome.IsValid() {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("invalid outcome %q: must be one of winner, tie, all_bad", outcome), nil, "9a2c6e48-3f1b-4d75-b8e0-6c4a1d9f2b57")
+	}
+
+	c, err := s.GetByPublicID(ctx, userID, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if c.CompletedAt == nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			"comparison is still running", nil, "e7d1b5a3-8c2f-4a69-9d04-2b6e8f1c3a75")
+	}
+
+	if outcome == VoteWinner {
+		if winnerSlot == nil {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				"winner_slot is required when outcome is winner", nil, "3f6a9c12-5e8d-4b37-a1f0-8d2c7e4b6a93")
+		}
+		if *winnerSlot < 0 || *winnerSlot >= len(c.Models) {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("winner_slot must be between 0 and %d", len(c.Models)-1), nil, "c0b84e26-7d3a-4f91-8e5c-1a9f6d2b4e08")
+		}
+	} else {
+		winnerSlot = nil
+	}
+
+	now := time.Now().UTC()
+	c.Vote = &outcome
+	c.WinnerSlot = winnerSlot
+	c.VotedAt = &now
+	if err := s.repo.SaveVote(ctx, c); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to save comparison vote")
+	}
+	return c, nil
+}
+
+// GetLeaderboard returns models ranked by win rate over the votes in the filter window
+func (s *Service) GetLeaderboard(ctx context.Context, filter LeaderboardFilter) ([]LeaderboardEntry, error) {
+	if filter.MinVotes <= 0 {
+		filter.MinVotes = DefaultLeaderboardMinVotes
+	}
+	entries, err := s.repo.FindLeaderboard(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	if entries == nil {
+		entries = []LeaderboardEntry{}
+	}
+	return entries, nil
+}
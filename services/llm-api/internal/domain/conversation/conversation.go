@@ -38,6 +38,7 @@ type Conversation struct {
 	PublicID        string                    `json:"id"`     // OpenAI-compatible string ID like "conv_abc123"
 	Object          string                    `json:"object"` // Always "conversation" for OpenAI compatibility
 	Title           *string                   `json:"title,omitempty"`
+	TitleLocked     bool                      `json:"title_locked"` // True once the user renamed it; automatic title generation skips locked titles
 	UserID          uint                      `json:"-"`
 	ProjectID       *uint                     `json:"-"` // Optional project grouping
 	ProjectPublicID *string                   `json:"-"` // Public ID of the project
@@ -51,8 +52,11 @@ type Conversation struct {
 	IsPrivate       bool                      `json:"is_private"`
 
 	// Project instruction inheritance
-	InstructionVersion           int     `json:"instruction_version"`                      // Version of project instruction when conversation was created
-	EffectiveInstructionSnapshot *string `json:"effective_instruction_snapshot,omitempty"` // Snapshot of merged instruction for reproducibility
+	InstructionVersion           int     `json:"instruction_version"`                      // Project instruction version the snapshot was taken from
+	EffectiveInstructionSnapshot *string `json:"effective_instruction_snapshot,omitempty"` // Pinned project instruction; nil until the first completion
+
+	// Version is incremented on every update; writes with a stale version fail with ErrorTypeConflict
+	Version int `json:"version"`
 
 	CreatedAt time.Time `json:"created_at"` // Unix timestamp for OpenAI compatibility
 	UpdatedAt time.Time `json:"updated_at"` // Unix timestamp for OpenAI compatibility
@@ -75,11 +79,12 @@ type BranchMetadata struct {
 // ===============================================
 
 type ConversationFilter struct {
-	ID        *uint
-	PublicID  *string
-	UserID    *uint
-	ProjectID *uint
-	Referrer  *string
+	ID           *uint
+	PublicID     *string
+	UserID       *uint
+	ProjectID    *uint
+	Referrer     *string
+	UpdatedAfter *time.Time // Only conversations with activity at or after this time
 }
 
 type ConversationRepository interface {
@@ -88,13 +93,18 @@ type ConversationRepository interface {
 	Count(ctx context.Context, filter ConversationFilter) (int64, error)
 	FindByID(ctx context.Context, id uint) (*Conversation, error)
 	FindByPublicID(ctx context.Context, publicID string) (*Conversation, error)
-	Update(ctx context.Context, conversation *Conversation) error
+	Update(ctx context.Context, conversation *Conversation) error    // Fails with a conflict if the stored version moved on
+	Overwrite(ctx context.Context, conversation *Conversation) error // Last write wins, still bumps the version
+	Touch(ctx context.Context, id uint, updatedAt time.Time) error   // Bumps updated_at without a version check
+	// SetInstructionSnapshot pins the project instruction version the conversation runs
+	// with, without a version check; a nil snapshot unpins it
+	SetInstructionSnapshot(ctx context.Context, id uint, version int, snapshot *string) error
 	Delete(ctx context.Context, id uint) error
 	DeleteAllByUserID(ctx context.Context, userID uint) (int64, error)
 
 	// Item operations (legacy - assumes MAIN branch)
 	AddItem(ctx context.Context, conversationID uint, item *Item) error
-	SearchItems(ctx context.Context, conversationID uint, query string) ([]*Item, error) // TODO: Implement search functionality
+	SearchItems(ctx context.Context, conversationID uint, query string) ([]*Item, error) // Full-text search over item content
 	BulkAddItems(ctx context.Context, conversationID uint, items []*Item) error
 	GetItemByID(ctx context.Context, conversationID uint, itemID uint) (*Item, error)
 	GetItemByPublicID(ctx context.Context, conversationID uint, publicID string) (*Item, error)
@@ -114,6 +124,9 @@ type ConversationRepository interface {
 	// Branch item operations
 	AddItemToBranch(ctx context.Context, conversationID uint, branchName string, item *Item) error
 	GetBranchItems(ctx context.Context, conversationID uint, branchName string, pagination *query.Pagination) ([]*Item, error)
+	// GetRecentBranchItems returns the last limit items of a branch in chronological
+	// order together with the branch's total item count, using a single query.
+	GetRecentBranchItems(ctx context.Context, conversationID uint, branchName string, limit int) ([]*Item, int, error)
 	BulkAddItemsToBranch(ctx context.Context, conversationID uint, branchName string, items []*Item) error
 
 	// Fork operation - creates a new branch from an existing branch at a specific item
@@ -129,6 +142,21 @@ type ConversationRepository interface {
 	RateItem(ctx context.Context, conversationID uint, itemID string, rating ItemRating, comment *string) error
 	GetItemRating(ctx context.Context, conversationID uint, itemID string) (*ItemRating, error)
 	RemoveItemRating(ctx context.Context, conversationID uint, itemID string) error
+
+	// Item edit operations
+	// EditItem overwrites the item's content and output in place and records the
+	// values it replaced as a new edit, atomically. edit supplies the editor and reason.
+	EditItem(ctx context.Context, conversationID uint, item *Item, edit *ItemEdit) error
+	ListItemEdits(ctx context.Context, conversationID uint, itemID uint) ([]*ItemEdit, error)
+
+	// CancelPendingItems marks items of the given type that are still in progress
+	// as cancelled and returns how many were updated.
+	CancelPendingItems(ctx context.Context, conversationID uint, itemType ItemType) (int64, error)
+	// FailStaleItems marks up to limit items of the given type that have been in
+	// progress since before startedBefore as failed with toolErr, in one
+	// conversation or in all of them when conversationID is 0, and returns how
+	// many were updated.
+	FailStaleItems(ctx context.Context, conversationID uint, itemType ItemType, startedBefore time.Time, toolErr ToolError, limit int) (int64, error)
 }
 
 // ===============================================
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
 	"jan-server/services/llm-api/internal/utils/idgen"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 )
@@ -14,13 +15,16 @@ import (
 type ConversationService struct {
 	repo      ConversationRepository
 	validator *ConversationValidator
+	links     LinkSanitizer
 }
 
-// NewConversationService creates a new conversation service
-func NewConversationService(repo ConversationRepository) *ConversationService {
+// NewConversationService creates a new conversation service. Links in items
+// are sanitized with links before they are persisted.
+func NewConversationService(repo ConversationRepository, links LinkSanitizer) *ConversationService {
 	return &ConversationService{
 		repo:      repo,
 		validator: NewConversationValidator(nil), // Use default config
+		links:     links,
 	}
 }
 
@@ -112,6 +116,7 @@ func (s *ConversationService) FindConversationsByFilter(ctx context.Context, fil
 type CreateConversationInput struct {
 	UserID          uint
 	Title           *string
+	TitleLocked     bool
 	Metadata        map[string]string
 	Referrer        *string
 	ProjectID       *uint
@@ -121,10 +126,12 @@ type CreateConversationInput struct {
 // UpdateConversationInput represents the input for updating a conversation
 type UpdateConversationInput struct {
 	Title           *string
+	TitleLocked     *bool
 	Metadata        map[string]string
 	Referrer        *string
 	ProjectID       *uint
 	ProjectPublicID *string
+	ExpectedVersion *int // Optional optimistic concurrency check against Conversation.Version
 }
 
 // CreateConversationWithInput creates a new conversation with input validation
@@ -139,6 +146,7 @@ func (s *ConversationService) CreateConversationWithInput(ctx context.Context, i
 	conversation := NewConversationWithProject(publicID, input.UserID, input.Title, input.Metadata, input.ProjectID)
 	conversation.Referrer = input.Referrer               // optional metadata
 	conversation.ProjectPublicID = input.ProjectPublicID // set project public ID
+	conversation.TitleLocked = input.TitleLocked
 
 	// Use core function to create conversation
 	return s.CreateConversation(ctx, conversation)
@@ -152,12 +160,22 @@ func (s *ConversationService) UpdateConversationWithInput(ctx context.Context, u
 		return nil, err
 	}
 
+	if input.ExpectedVersion != nil && *input.ExpectedVersion != conversation.Version {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			fmt.Sprintf("conversation has changed (version %d, expected %d); reload and retry", conversation.Version, *input.ExpectedVersion),
+			nil, "9c4a1e7b-2d5f-4b8a-93e6-7f1d0a3c5b28")
+	}
+
 	// Update fields
 	if input.Title != nil {
 		// Update the title field directly
 		conversation.Title = input.Title
 	}
 
+	if input.TitleLocked != nil {
+		conversation.TitleLocked = *input.TitleLocked
+	}
+
 	if input.Metadata != nil {
 		// Replace metadata entirely (not merged)
 		conversation.Metadata = input.Metadata
@@ -179,8 +197,19 @@ func (s *ConversationService) UpdateConversationWithInput(ctx context.Context, u
 		conversation.EffectiveInstructionSnapshot = nil
 	}
 
-	// Use core function to update conversation
-	return s.UpdateConversation(ctx, conversation)
+	if input.ExpectedVersion != nil {
+		// Use core function to update conversation
+		return s.UpdateConversation(ctx, conversation)
+	}
+
+	// No version from the client: last write wins
+	if err := s.validator.ValidateConversation(conversation); err != nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, "conversation validation failed", err, "a8f3d6b1-5c27-4e94-b0d2-7e9c1f4a6b53")
+	}
+	if err := s.repo.Overwrite(ctx, conversation); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to update conversation")
+	}
+	return conversation, nil
 }
 
 // DeleteConversationByID marks a conversation as deleted (soft delete)
@@ -257,6 +286,7 @@ func (s *ConversationService) AddItemsToConversation(ctx context.Context, conv *
 		items[i].Branch = branchName
 		// Assign sequence number: start from current count + 1, increment for each item
 		items[i].SequenceNumber = currentCount + i + 1
+		items[i].SanitizeLinks(ctx, s.links)
 		itemPtrs[i] = &items[i]
 	}
 
@@ -268,7 +298,7 @@ func (s *ConversationService) AddItemsToConversation(ctx context.Context, conv *
 	// Update conversation's updated_at timestamp
 	if len(items) > 0 {
 		conv.UpdatedAt = items[len(items)-1].CreatedAt
-		if err := s.repo.Update(ctx, conv); err != nil {
+		if err := s.repo.Touch(ctx, conv.ID, conv.UpdatedAt); err != nil {
 			// Log error but don't fail the operation
 			_ = err
 		}
@@ -288,6 +318,22 @@ func (s *ConversationService) GetConversationItems(ctx context.Context, conv *Co
 	return convertItemPtrsToItems(items), nil
 }
 
+// GetRecentConversationItems returns the last limit items of a branch (oldest
+// first) and the branch's total item count, without loading the full history.
+func (s *ConversationService) GetRecentConversationItems(ctx context.Context, conv *Conversation, branchName string, limit int) ([]Item, int, error) {
+	if conv == nil {
+		return nil, 0, nil
+	}
+	if branchName == "" {
+		branchName = BranchMain
+	}
+	items, total, err := s.repo.GetRecentBranchItems(ctx, conv.ID, branchName, limit)
+	if err != nil {
+		return nil, 0, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to get recent items")
+	}
+	return convertItemPtrsToItems(items), total, nil
+}
+
 // CountConversationItems returns the number of items in a conversation branch.
 func (s *ConversationService) CountConversationItems(ctx context.Context, conv *Conversation, branchName string) (int, error) {
 	if conv == nil {
@@ -338,6 +384,8 @@ func (s *ConversationService) GetConversationItemByCallIDAndType(ctx context.Con
 
 // UpdateConversationItem updates an existing item in a conversation
 func (s *ConversationService) UpdateConversationItem(ctx context.Context, conv *Conversation, item *Item) error {
+	item.SanitizeLinks(ctx, s.links)
+
 	// Update the item in the repository
 	if err := s.repo.UpdateItem(ctx, conv.ID, item); err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to update item")
@@ -345,7 +393,7 @@ func (s *ConversationService) UpdateConversationItem(ctx context.Context, conv *
 
 	// Update conversation timestamp
 	conv.UpdatedAt = time.Now()
-	if err := s.repo.Update(ctx, conv); err != nil {
+	if err := s.repo.Touch(ctx, conv.ID, conv.UpdatedAt); err != nil {
 		// Log error but don't fail the update
 		_ = err
 	}
@@ -353,6 +401,181 @@ func (s *ConversationService) UpdateConversationItem(ctx context.Context, conv *
 	return nil
 }
 
+// EditItemInput represents the input for editing an item in place
+type EditItemInput struct {
+	EditorUserID uint
+	Content      []Content // Replacement content; nil keeps the current content
+	Output       *string   // Replacement output for tool output items
+	Reason       *string   // Optional note stored with the edit for audit
+}
+
+// EditConversationItem overwrites an assistant message or tool output in place.
+// The replaced values are kept in the item's edit history and the item is marked as edited.
+func (s *ConversationService) EditConversationItem(ctx context.Context, conv *Conversation, itemPublicID string, input EditItemInput) (*Item, error) {
+	item, err := s.repo.GetItemByPublicID(ctx, conv.ID, itemPublicID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "item not found")
+	}
+	if !item.IsEditable() {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("items of type %s cannot be edited in place", describeItemKind(item)), nil, "943e9eea-b760-48f9-9b95-2a44067efd32")
+	}
+	if input.Content == nil && input.Output == nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"content or output is required", nil, "736c946e-219e-4f25-82d1-3d9659df5387")
+	}
+	if input.Output != nil && item.Type == ItemTypeMessage {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"output can only be edited on tool output items", nil, "6bab29a2-e774-49a4-b81f-152111990f4f")
+	}
+
+	if input.Content != nil {
+		item.Content = input.Content
+	}
+	if input.Output != nil {
+		item.Output = input.Output
+		// mcp_call items mirror their output into content for API responses
+		if input.Content == nil && item.Type == ItemTypeMcpCall {
+			item.Content = []Content{{Type: "mcp_call", ToolCallID: item.CallID, TextString: input.Output}}
+		}
+	}
+
+	item.SanitizeLinks(ctx, s.links)
+
+	editorUserID := input.EditorUserID
+	edit := &ItemEdit{
+		EditorUserID: &editorUserID,
+		Reason:       input.Reason,
+	}
+	if err := s.repo.EditItem(ctx, conv.ID, item, edit); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to edit item")
+	}
+
+	// Update conversation timestamp
+	conv.UpdatedAt = time.Now()
+	if err := s.repo.Touch(ctx, conv.ID, conv.UpdatedAt); err != nil {
+		// Log error but don't fail the edit
+		_ = err
+	}
+
+	return item, nil
+}
+
+// TruncateConversationItem cuts an assistant message back to the audio the
+// user heard before interrupting it. Unlike an edit, a truncation is not kept
+// in the edit history: it records what was said rather than correcting it.
+func (s *ConversationService) TruncateConversationItem(ctx context.Context, conv *Conversation, itemPublicID string, contentIndex, audioEndMs int) (*Item, error) {
+	item, err := s.repo.GetItemByPublicID(ctx, conv.ID, itemPublicID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "item not found")
+	}
+	if err := item.TruncateAudio(contentIndex, audioEndMs); err != nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			err.Error(), err, "4c7a2e91-6b3d-4f58-a0e2-9d1c5b8f3e76")
+	}
+	if err := s.UpdateConversationItem(ctx, conv, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// ListConversationItemEdits returns the edit history of an item, oldest first.
+// The first entry holds the item's original content.
+func (s *ConversationService) ListConversationItemEdits(ctx context.Context, conv *Conversation, itemPublicID string) ([]*ItemEdit, error) {
+	item, err := s.repo.GetItemByPublicID(ctx, conv.ID, itemPublicID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "item not found")
+	}
+	edits, err := s.repo.ListItemEdits(ctx, conv.ID, item.ID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to list item edits")
+	}
+	return edits, nil
+}
+
+// CancelPendingMCPCalls marks the conversation's mcp_call items that are still
+// waiting on tool execution as cancelled, e.g. when the user stops a stream.
+func (s *ConversationService) CancelPendingMCPCalls(ctx context.Context, conv *Conversation) (int64, error) {
+	cancelled, err := s.repo.CancelPendingItems(ctx, conv.ID, ItemTypeMcpCall)
+	if err != nil {
+		return 0, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to cancel pending mcp calls")
+	}
+
+	// Update conversation timestamp
+	if cancelled > 0 {
+		conv.UpdatedAt = time.Now()
+		if err := s.repo.Touch(ctx, conv.ID, conv.UpdatedAt); err != nil {
+			// Log error but don't fail the cancellation
+			_ = err
+		}
+	}
+	return cancelled, nil
+}
+
+// Triggers of a stale item reconciliation, as reported in metrics
+const (
+	ReconcileTriggerScheduled = "scheduled"
+	ReconcileTriggerAdmin     = "admin"
+)
+
+// staleItemBatchSize bounds the items failed by one update
+const staleItemBatchSize = 500
+
+// FailStaleMCPCalls marks mcp_call items that have been in progress for longer
+// than timeout as failed, since their tool result is not coming anymore. It
+// covers conv only, or every conversation when conv is nil, and returns how many
+// items were failed. trigger labels the metrics (ReconcileTriggerScheduled or
+// ReconcileTriggerAdmin).
+func (s *ConversationService) FailStaleMCPCalls(ctx context.Context, conv *Conversation, timeout time.Duration, trigger string) (int64, error) {
+	var conversationID uint
+	if conv != nil {
+		conversationID = conv.ID
+	}
+	toolErr := ToolError{
+		Code:      "timeout",
+		Message:   fmt.Sprintf("tool did not report a result within %s", timeout),
+		Retryable: true,
+	}
+	startedBefore := time.Now().Add(-timeout)
+
+	// Fail in batches so a large backlog does not lock many rows at once
+	var failed int64
+	for {
+		n, err := s.repo.FailStaleItems(ctx, conversationID, ItemTypeMcpCall, startedBefore, toolErr, staleItemBatchSize)
+		failed += n
+		metrics.StaleItemsFailedTotal.WithLabelValues(string(ItemTypeMcpCall), trigger).Add(float64(n))
+		if err != nil {
+			metrics.ItemReconcileRunsTotal.WithLabelValues(trigger, "error").Inc()
+			return failed, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to fail stale mcp calls")
+		}
+		if n < staleItemBatchSize {
+			break
+		}
+	}
+	metrics.ItemReconcileRunsTotal.WithLabelValues(trigger, "ok").Inc()
+	return failed, nil
+}
+
+// PinInstruction pins the project instruction the conversation's completions
+// use to the given version, until the conversation is refreshed or moved to
+// another project.
+func (s *ConversationService) PinInstruction(ctx context.Context, conv *Conversation, version int, instruction string) error {
+	if err := s.repo.SetInstructionSnapshot(ctx, conv.ID, version, &instruction); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to pin project instruction")
+	}
+	conv.InstructionVersion = version
+	conv.EffectiveInstructionSnapshot = &instruction
+	return nil
+}
+
+// describeItemKind names an item for error messages, including the role for messages
+func describeItemKind(item *Item) string {
+	if item.Type == ItemTypeMessage && item.Role != nil {
+		return fmt.Sprintf("%s (%s)", item.Type, *item.Role)
+	}
+	return string(item.Type)
+}
+
 // DeleteConversationItem deletes an item from a conversation
 func (s *ConversationService) DeleteConversationItem(ctx context.Context, conv *Conversation, itemPublicID string) error {
 	// Get the item to find its numeric ID
@@ -368,7 +591,7 @@ func (s *ConversationService) DeleteConversationItem(ctx context.Context, conv *
 
 	// Update conversation timestamp
 	conv.UpdatedAt = time.Now()
-	if err := s.repo.Update(ctx, conv); err != nil {
+	if err := s.repo.Touch(ctx, conv.ID, conv.UpdatedAt); err != nil {
 		// Log error but don't fail the deletion
 		_ = err
 	}
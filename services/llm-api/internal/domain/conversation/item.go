@@ -77,6 +77,23 @@ func ValidateItemType(input string) bool {
 	}
 }
 
+// legacyItemTypeSuccessors maps each legacy item type to the type replacing it
+var legacyItemTypeSuccessors = map[ItemType]ItemType{
+	ItemTypeFileSearch:      ItemTypeFileSearchCall,
+	ItemTypeWebSearch:       ItemTypeWebSearchCall,
+	ItemTypeCodeInterpreter: ItemTypeCodeInterpreterCall,
+	ItemTypeComputerUse:     ItemTypeComputerCall,
+	ItemTypeMCPItem:         ItemTypeMcpCall,
+	ItemTypeImageGeneration: ItemTypeImageGenerationCall,
+}
+
+// LegacyItemTypeSuccessor returns the item type replacing a deprecated legacy
+// type; ok is false for current types.
+func LegacyItemTypeSuccessor(itemType ItemType) (successor ItemType, ok bool) {
+	successor, ok = legacyItemTypeSuccessors[itemType]
+	return successor, ok
+}
+
 // @Enum(system, user, assistant, tool, developer, critic, discriminator, unknown)
 type ItemRole string
 
@@ -442,6 +459,14 @@ type Item struct {
 	RatedAt       *time.Time  `json:"rated_at,omitempty"`       // When rating was given
 	RatingComment *string     `json:"rating_comment,omitempty"` // Optional comment with rating
 
+	// Soft-edit marker (previous versions are kept as ItemEdit records)
+	Edited   bool       `json:"edited,omitempty"`    // True once the item has been edited in place
+	EditedAt *time.Time `json:"edited_at,omitempty"` // When the item was last edited
+
+	// Cold storage marker: the item is a stub whose content, arguments and output
+	// were archived; rehydrating the conversation restores them
+	ColdStored bool `json:"cold_stored,omitempty"`
+
 	// OpenAI-compatible fields for specific item types
 	CallID                   *string                `json:"call_id,omitempty"`                    // For function/tool calls
 	Name                     *string                `json:"name,omitempty"`                       // For MCP tool calls - tool name
@@ -450,6 +475,7 @@ type Item struct {
 	Arguments                *string                `json:"arguments,omitempty"`                  // For tool calls (JSON string)
 	Output                   *string                `json:"output,omitempty"`                     // For tool call outputs
 	Error                    *string                `json:"error,omitempty"`                      // For failed calls
+	ErrorDetail              *ToolError             `json:"error_detail,omitempty"`               // Structured error of failed MCP calls
 	Action                   map[string]interface{} `json:"action,omitempty"`                     // For computer/shell actions
 	Tools                    []McpTool              `json:"tools,omitempty"`                      // For mcp_list_tools
 	PendingSafetyChecks      []SafetyCheck          `json:"pending_safety_checks,omitempty"`      // For computer calls
@@ -500,6 +526,38 @@ func ParseItemRating(s string) (*ItemRating, error) {
 	return &rating, nil
 }
 
+// ===============================================
+// Edit Support
+// ===============================================
+
+// ItemEdit records the state of an item before an in-place edit. The oldest
+// edit of an item holds its original content.
+type ItemEdit struct {
+	ID              uint      `json:"-"`
+	ItemID          uint      `json:"-"`
+	ConversationID  uint      `json:"-"`
+	EditorUserID    *uint     `json:"-"`
+	PreviousContent []Content `json:"previous_content,omitempty"`
+	PreviousOutput  *string   `json:"previous_output,omitempty"`
+	Reason          *string   `json:"reason,omitempty"`
+	CreatedAt       time.Time `json:"created_at"`
+}
+
+// IsEditable reports whether the item can be edited in place. Only assistant
+// messages and tool outputs qualify; user messages are edited by branching.
+func (i *Item) IsEditable() bool {
+	switch i.Type {
+	case ItemTypeMessage:
+		return i.Role != nil && *i.Role == ItemRoleAssistant
+	case ItemTypeFunctionCallOut, ItemTypeComputerCallOutput, ItemTypeLocalShellCallOutput,
+		ItemTypeShellCallOutput, ItemTypeApplyPatchCallOutput, ItemTypeCustomToolCallOutput,
+		ItemTypeMcpCall:
+		return true
+	default:
+		return false
+	}
+}
+
 // ===============================================
 // Content Structures
 // ===============================================
@@ -560,6 +618,8 @@ type AudioContent struct {
 	Transcript *string `json:"transcript,omitempty"` // Text transcription of audio
 	Data       *string `json:"data,omitempty"`       // Base64 encoded audio data
 	Format     *string `json:"format,omitempty"`     // Audio format: mp3, wav, pcm16, etc.
+
+	Segments []AudioSegment `json:"segments,omitempty"` // Timing of each spoken sentence, used to truncate on interruption
 }
 
 // InputAudio for user audio input
@@ -675,6 +735,15 @@ type IncompleteDetails struct {
 	Error  *string `json:"error,omitempty"` // Error message if applicable
 }
 
+// ToolError is the structured error mcp-tools reports for a failed tool call
+type ToolError struct {
+	Code      string `json:"code"`               // "invalid_arguments", "unauthorized", "rate_limited", "timeout", etc.
+	Message   string `json:"message"`            // Human-readable error message
+	Retryable bool   `json:"retryable"`          // Whether calling again unchanged may succeed
+	Provider  string `json:"provider,omitempty"` // Upstream provider that failed
+	Detail    string `json:"detail,omitempty"`   // Provider response, truncated
+}
+
 // ===============================================
 // Item Repository
 // ===============================================
@@ -685,6 +754,8 @@ type ItemFilter struct {
 	CallID         *string // For finding items by tool call ID (e.g., call_xxx)
 	ConversationID *uint
 	Role           *ItemRole
+	Type           *ItemType
+	Status         *ItemStatus
 	ResponseID     *uint
 	Branch         *string // Filter by branch name
 }
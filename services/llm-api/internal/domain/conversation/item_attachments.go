@@ -0,0 +1,127 @@
+package conversation
+
+import (
+	"encoding/base64"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Attachment types reported by Item.Attachments
+const (
+	AttachmentTypeImage      = "image"
+	AttachmentTypeFile       = "file"
+	AttachmentTypeScreenshot = "computer_screenshot"
+)
+
+// mediaIDPattern matches a media-api ID (jan_ followed by a lowercase ULID)
+var mediaIDPattern = regexp.MustCompile(`^jan_[0-9a-z]{26}$`)
+
+// Attachment is an image or file referenced by a conversation item.
+type Attachment struct {
+	ItemID    string
+	Role      *ItemRole
+	Type      string
+	MediaID   string // Set when the reference is a media-api object
+	FileID    string // File ID as given by the client, when it is not a media ID
+	Name      string
+	MimeType  string
+	Bytes     int64
+	URL       string // Empty when Inline
+	Inline    bool   // Embedded in the item as base64 data rather than referenced
+	CreatedAt time.Time
+
+	URLExpiresAt *time.Time // Set when URL is a presigned media-api URL
+}
+
+// Attachments lists the images and files the item references, in content order.
+func (i *Item) Attachments() []Attachment {
+	var attachments []Attachment
+	for _, content := range i.Content {
+		var attachment Attachment
+		switch {
+		case content.Image != nil:
+			attachment = referenceAttachment(AttachmentTypeImage, content.Image.URL, content.Image.FileID)
+		case content.File != nil:
+			attachment = referenceAttachment(AttachmentTypeFile, "", content.File.FileID)
+			attachment.Name = content.File.Name
+			if content.File.MimeType != "" {
+				attachment.MimeType = content.File.MimeType
+			}
+			if content.File.Size > 0 {
+				attachment.Bytes = content.File.Size
+			}
+		case content.ComputerScreenshot != nil:
+			attachment = referenceAttachment(AttachmentTypeScreenshot, content.ComputerScreenshot.ImageURL, "")
+			if attachment.URL == "" && attachment.MimeType == "" && content.ComputerScreenshot.ImageData != nil {
+				attachment.Inline = true
+				attachment.MimeType = "image/png"
+				attachment.Bytes = int64(base64.StdEncoding.DecodedLen(len(*content.ComputerScreenshot.ImageData)))
+			}
+		default:
+			continue
+		}
+		if attachment.URL == "" && attachment.MediaID == "" && attachment.FileID == "" && !attachment.Inline {
+			continue
+		}
+		attachment.ItemID = i.PublicID
+		attachment.Role = i.Role
+		attachment.CreatedAt = i.CreatedAt
+		attachments = append(attachments, attachment)
+	}
+	return attachments
+}
+
+// referenceAttachment describes an image or file given by URL and/or file ID.
+// Data URLs are reported by type and size only, since they can be megabytes long.
+func referenceAttachment(kind, rawURL, fileID string) Attachment {
+	attachment := Attachment{Type: kind}
+	if mediaIDPattern.MatchString(fileID) {
+		attachment.MediaID = fileID
+	} else {
+		attachment.FileID = fileID
+	}
+
+	rawURL = strings.TrimSpace(rawURL)
+	if header, data, ok := strings.Cut(rawURL, ","); ok && strings.HasPrefix(header, "data:") {
+		mimeType, _, _ := strings.Cut(strings.TrimPrefix(header, "data:"), ";")
+		attachment.Inline = true
+		attachment.MimeType = mimeType
+		if strings.HasSuffix(header, ";base64") {
+			attachment.Bytes = int64(base64.StdEncoding.DecodedLen(len(data)))
+		} else {
+			attachment.Bytes = int64(len(data))
+		}
+		return attachment
+	}
+	if rawURL == "" {
+		return attachment
+	}
+	attachment.URL = rawURL
+	if attachment.MediaID == "" {
+		attachment.MediaID = MediaIDFromURL(rawURL)
+	}
+	return attachment
+}
+
+// MediaIDFromURL returns the media ID in a media-api URL such as
+// https://api.example.com/api/media/jan_..., or "" when there is none.
+func MediaIDFromURL(rawURL string) string {
+	if mediaIDPattern.MatchString(rawURL) {
+		return rawURL
+	}
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	for idx := len(segments) - 1; idx >= 0; idx-- {
+		// Stored objects are keyed as images/<id>.<ext> or files/<id>.<ext>
+		segment, _, _ := strings.Cut(segments[idx], ".")
+		if mediaIDPattern.MatchString(segment) {
+			return segment
+		}
+	}
+	return ""
+}
@@ -0,0 +1,122 @@
+package conversation
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// AudioSegment is one synthesized sentence of an AudioContent. EndMs is where
+// its audio ends, in milliseconds from the start of the reply's audio.
+type AudioSegment struct {
+	Transcript string `json:"transcript"`
+	EndMs      int    `json:"end_ms"`
+}
+
+// TruncateAudio cuts an assistant message back to the first audioEndMs of the
+// audio at contentIndex, as when the user interrupts playback. The audio keeps
+// the segments played in full plus the share of the interrupted segment that
+// was heard, cut at a word boundary, and the message text is cut at the same
+// point so the next turn only sees what the user actually heard.
+func (i *Item) TruncateAudio(contentIndex, audioEndMs int) error {
+	if i.Type != ItemTypeMessage || i.Role == nil || *i.Role != ItemRoleAssistant {
+		return fmt.Errorf("only assistant messages can be truncated")
+	}
+	if contentIndex < 0 || contentIndex >= len(i.Content) || i.Content[contentIndex].Audio == nil {
+		return fmt.Errorf("content_index %d is not an audio content part", contentIndex)
+	}
+	audio := i.Content[contentIndex].Audio
+	if len(audio.Segments) == 0 {
+		return fmt.Errorf("audio content has no timing to truncate")
+	}
+	if total := audio.Segments[len(audio.Segments)-1].EndMs; audioEndMs < 0 || audioEndMs > total {
+		return fmt.Errorf("audio_end_ms must be between 0 and %d", total)
+	}
+
+	kept := make([]AudioSegment, 0, len(audio.Segments))
+	start := 0
+	for _, segment := range audio.Segments {
+		if segment.EndMs <= audioEndMs {
+			kept = append(kept, segment)
+			start = segment.EndMs
+			continue
+		}
+		if audioEndMs > start && segment.EndMs > start {
+			share := float64(audioEndMs-start) / float64(segment.EndMs-start)
+			if heard := spokenPrefix(segment.Transcript, share); heard != "" {
+				kept = append(kept, AudioSegment{Transcript: heard, EndMs: audioEndMs})
+			}
+		}
+		break
+	}
+
+	transcripts := make([]string, len(kept))
+	for n, segment := range kept {
+		transcripts[n] = segment.Transcript
+	}
+	transcript := strings.Join(transcripts, " ")
+	audio.Segments = kept
+	audio.Transcript = &transcript
+
+	for n := range i.Content {
+		if text := i.Content[n].textField(); text != nil {
+			*text = cutAtSegments(*text, kept, transcript)
+			if out := i.Content[n].OutputText; out != nil {
+				out.Annotations = nil
+				out.LogProbs = nil
+			}
+			break
+		}
+	}
+	return nil
+}
+
+// textField returns the string holding the content's text, if it has any
+func (c *Content) textField() *string {
+	switch {
+	case c.TextString != nil:
+		return c.TextString
+	case c.OutputText != nil:
+		return &c.OutputText.Text
+	case c.Text != nil:
+		return &c.Text.Text
+	}
+	return nil
+}
+
+// spokenPrefix returns the words of transcript that fit in share (0..1) of
+// its length, without cutting a word. Text written without spaces, such as
+// Chinese, is cut between characters.
+func spokenPrefix(transcript string, share float64) string {
+	cut := int(float64(len(transcript)) * share)
+	if cut >= len(transcript) {
+		return transcript
+	}
+	if !strings.ContainsFunc(transcript, unicode.IsSpace) {
+		for cut > 0 && !utf8.RuneStart(transcript[cut]) {
+			cut--
+		}
+	} else if !unicode.IsSpace(rune(transcript[cut])) {
+		cut = strings.LastIndexFunc(transcript[:cut], unicode.IsSpace)
+	}
+	if cut <= 0 {
+		return ""
+	}
+	return strings.TrimSpace(transcript[:cut])
+}
+
+// cutAtSegments cuts text after the last of segments, found in order. Text the
+// segments cannot be matched against, such as text rewritten since it was
+// spoken, is replaced by the spoken transcript.
+func cutAtSegments(text string, segments []AudioSegment, transcript string) string {
+	pos := 0
+	for _, segment := range segments {
+		idx := strings.Index(text[pos:], segment.Transcript)
+		if idx < 0 {
+			return transcript
+		}
+		pos += idx + len(segment.Transcript)
+	}
+	return text[:pos]
+}
@@ -0,0 +1,74 @@
+package conversation
+
+import "context"
+
+// LinkSanitizer validates and normalizes links before items are persisted and
+// rendered by clients.
+type LinkSanitizer interface {
+	// SanitizeURL returns the normalized URL, or false when it must not be rendered as a link.
+	SanitizeURL(ctx context.Context, rawURL string) (string, bool)
+	// SanitizeText normalizes the links in free text such as tool output.
+	SanitizeText(ctx context.Context, text string) string
+}
+
+// SanitizeLinks rewrites the URLs of the item's citations and, for tool output
+// items, the links in the output text. Citations whose URL is rejected are dropped.
+func (i *Item) SanitizeLinks(ctx context.Context, links LinkSanitizer) {
+	if links == nil {
+		return
+	}
+	toolOutput := i.isToolOutput()
+	for idx := range i.Content {
+		content := &i.Content[idx]
+		if content.Text != nil {
+			content.Text.Annotations = sanitizeAnnotations(ctx, links, content.Text.Annotations)
+		}
+		if content.OutputText != nil {
+			content.OutputText.Annotations = sanitizeAnnotations(ctx, links, content.OutputText.Annotations)
+		}
+		if !toolOutput {
+			continue
+		}
+		if content.TextString != nil {
+			text := links.SanitizeText(ctx, *content.TextString)
+			content.TextString = &text
+		}
+		if content.FunctionCallOut != nil {
+			content.FunctionCallOut.Output = links.SanitizeText(ctx, content.FunctionCallOut.Output)
+		}
+	}
+	if toolOutput && i.Output != nil {
+		output := links.SanitizeText(ctx, *i.Output)
+		i.Output = &output
+	}
+}
+
+// isToolOutput reports whether the item carries the result of a tool call.
+func (i *Item) isToolOutput() bool {
+	switch i.Type {
+	case ItemTypeFunctionCallOut, ItemTypeComputerCallOutput, ItemTypeLocalShellCallOutput,
+		ItemTypeShellCallOutput, ItemTypeApplyPatchCallOutput, ItemTypeCustomToolCallOutput,
+		ItemTypeMcpCall:
+		return true
+	default:
+		return false
+	}
+}
+
+func sanitizeAnnotations(ctx context.Context, links LinkSanitizer, annotations []Annotation) []Annotation {
+	if annotations == nil {
+		return nil
+	}
+	kept := make([]Annotation, 0, len(annotations))
+	for _, annotation := range annotations {
+		if annotation.URL != "" {
+			url, ok := links.SanitizeURL(ctx, annotation.URL)
+			if !ok {
+				continue
+			}
+			annotation.URL = url
+		}
+		kept = append(kept, annotation)
+	}
+	return kept
+}
@@ -304,6 +304,12 @@ func (v *ItemValidator) ValidateContent(content Content) error {
 		}
 		return fmt.Errorf("reasoning_text content type requires text field")
 
+	case "summary_text":
+		if content.SummaryText != nil {
+			return v.validateSummaryText(*content.SummaryText)
+		}
+		return fmt.Errorf("summary_text content type requires summary_text field")
+
 	case "tool_result":
 		if content.TextString != nil {
 			return v.validateSimpleText(*content.TextString, "tool_result")
@@ -417,6 +423,19 @@ func (v *ItemValidator) validateSimpleText(text, fieldName string) error {
 	return nil
 }
 
+func (v *ItemValidator) validateSummaryText(summary string) error {
+	if summary == "" {
+		return fmt.Errorf("summary_text cannot be empty")
+	}
+
+	length := utf8.RuneCountInString(summary)
+	if length > v.config.MaxReasoningLength {
+		return fmt.Errorf("summary_text cannot exceed %d characters (got %d)", v.config.MaxReasoningLength, length)
+	}
+
+	return nil
+}
+
 func (v *ItemValidator) validateThinkingContent(thinking string) error {
 	if thinking == "" {
 		return fmt.Errorf("thinking content cannot be empty")
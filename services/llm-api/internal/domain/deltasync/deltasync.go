@@ -0,0 +1,72 @@
+package deltasync
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/usersettings"
+)
+
+// EntityType names the kind of record a change is about
+type EntityType string
+
+const (
+	EntityConversation EntityType = "conversation"
+	EntityItem         EntityType = "conversation.item"
+	EntityUserSettings EntityType = "user_settings"
+)
+
+// Operation is what happened to the record
+type Operation string
+
+const (
+	OperationCreated Operation = "created"
+	OperationUpdated Operation = "updated"
+	OperationDeleted Operation = "deleted"
+)
+
+// Checkpoint is a position in a user's change log. Changes are ordered by the
+// ID of the transaction that wrote them, then by their own ID.
+type Checkpoint struct {
+	TxID uint64
+	ID   uint64
+}
+
+// Before reports whether c comes before other in the change log
+func (c Checkpoint) Before(other Checkpoint) bool {
+	return c.TxID < other.TxID || (c.TxID == other.TxID && c.ID < other.ID)
+}
+
+// Change is one entry of the change log, recorded by database triggers
+type Change struct {
+	Checkpoint
+	UserID         uint
+	EntityType     EntityType
+	EntityID       string // Public ID; "settings" for user settings
+	ConversationID string // Public ID of the conversation the entity belongs to
+	Operation      Operation
+	ChangedAt      time.Time
+}
+
+// Snapshot holds the current state of changed entities, keyed by public ID.
+// Entities missing from it no longer exist.
+type Snapshot struct {
+	Conversations map[string]*conversation.Conversation
+	Items         map[string]*conversation.Item
+	Settings      *usersettings.UserSettings
+}
+
+// Repository reads the change log and the current state of changed entities
+type Repository interface {
+	// Horizon returns the oldest transaction still running. Every change written
+	// by older transactions is final; no new change will be ordered before it.
+	Horizon(ctx context.Context) (uint64, error)
+	// ListChanges returns up to limit changes of the user after the checkpoint,
+	// written by transactions older than horizon, in log order.
+	ListChanges(ctx context.Context, userID uint, after Checkpoint, horizon uint64, limit int) ([]*Change, error)
+	// LoadSnapshot loads the user's live conversations, items and settings named by changes
+	LoadSnapshot(ctx context.Context, userID uint, changes []*Change) (*Snapshot, error)
+	// DeleteBefore prunes changes recorded before cutoff and returns how many were removed
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
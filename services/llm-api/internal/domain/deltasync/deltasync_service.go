@@ -0,0 +1,160 @@
+package deltasync
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/usersettings"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// pruneGrace keeps changes a little longer than checkpoints stay valid, so
+// changes of transactions that were still running when a checkpoint was
+// issued outlive it.
+const pruneGrace = time.Hour
+
+// Config configures the Service
+type Config struct {
+	Retention time.Duration // How long changes are kept; older checkpoints must resync. 0 keeps them forever
+}
+
+// Service serves a user's changes to offline-capable clients
+type Service struct {
+	repo      Repository
+	retention time.Duration
+}
+
+// NewService creates a new delta sync service
+func NewService(repo Repository, cfg Config) *Service {
+	return &Service{
+		repo:      repo,
+		retention: cfg.Retention,
+	}
+}
+
+// Entry is the latest change of one entity within a page, with the entity's
+// current state unless it was deleted
+type Entry struct {
+	Change       *Change
+	Conversation *conversation.Conversation
+	Item         *conversation.Item
+	Settings     *usersettings.UserSettings
+}
+
+// Page is a batch of entries and the checkpoint to resume from
+type Page struct {
+	Entries []*Entry
+	Next    Checkpoint
+	NextAt  time.Time // When the log reached Next; the checkpoint expires Retention after it
+	HasMore bool
+}
+
+// Head returns an empty page positioned at the end of the log. Clients take it
+// before downloading their initial state; changes made during the download are
+// delivered again by the next sync.
+func (s *Service) Head(ctx context.Context) (*Page, error) {
+	horizon, err := s.repo.Horizon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &Page{Entries: []*Entry{}, Next: Checkpoint{TxID: horizon}, NextAt: time.Now()}, nil
+}
+
+// ChangesSince returns up to limit changes of the user after since, which the
+// log reached at sinceAt. Several changes of one entity within the page
+// collapse into its latest one.
+func (s *Service) ChangesSince(ctx context.Context, userID uint, since Checkpoint, sinceAt time.Time, limit int) (*Page, error) {
+	if s.retention > 0 && time.Since(sinceAt) > s.retention {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeCursorExpired,
+			"sync checkpoint expired: download the current state and start again without since", nil, "4d0f6c2e-8b57-4a1e-9d3c-7e2a5b8f1c64")
+	}
+
+	horizon, err := s.repo.Horizon(ctx)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := s.repo.ListChanges(ctx, userID, since, horizon, limit+1)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &Page{Next: since, NextAt: sinceAt, HasMore: len(changes) > limit}
+	if page.HasMore {
+		changes = changes[:limit]
+	}
+	if len(changes) > 0 {
+		last := changes[len(changes)-1]
+		page.Next, page.NextAt = last.Checkpoint, last.ChangedAt
+	}
+	if !page.HasMore {
+		// Every final change has been returned; skip ahead so the next scan starts at the horizon
+		if head := (Checkpoint{TxID: horizon}); page.Next.Before(head) {
+			page.Next, page.NextAt = head, time.Now()
+		}
+	}
+
+	latest := collapse(changes)
+	snapshot, err := s.repo.LoadSnapshot(ctx, userID, latest)
+	if err != nil {
+		return nil, err
+	}
+	page.Entries = make([]*Entry, 0, len(latest))
+	for _, change := range latest {
+		page.Entries = append(page.Entries, resolve(change, snapshot))
+	}
+	return page, nil
+}
+
+// Prune removes changes older than the retention period
+func (s *Service) Prune(ctx context.Context) (int64, error) {
+	if s.retention <= 0 {
+		return 0, nil
+	}
+	return s.repo.DeleteBefore(ctx, time.Now().Add(-s.retention-pruneGrace))
+}
+
+// collapse keeps the last change of each entity, in log order
+func collapse(changes []*Change) []*Change {
+	type entityKey struct {
+		entityType EntityType
+		entityID   string
+	}
+	last := make(map[entityKey]int, len(changes))
+	for i, change := range changes {
+		last[entityKey{change.EntityType, change.EntityID}] = i
+	}
+	latest := make([]*Change, 0, len(last))
+	for i, change := range changes {
+		if last[entityKey{change.EntityType, change.EntityID}] == i {
+			latest = append(latest, change)
+		}
+	}
+	return latest
+}
+
+// resolve pairs a change with the entity's current state. An entity that no
+// longer exists is reported deleted, even if the deletion itself is not final yet.
+func resolve(change *Change, snapshot *Snapshot) *Entry {
+	entry := &Entry{Change: change}
+	var exists bool
+	switch change.EntityType {
+	case EntityConversation:
+		entry.Conversation, exists = snapshot.Conversations[change.EntityID]
+	case EntityItem:
+		entry.Item, exists = snapshot.Items[change.EntityID]
+	case EntityUserSettings:
+		entry.Settings = snapshot.Settings
+		exists = snapshot.Settings != nil
+	}
+
+	resolved := *change
+	switch {
+	case !exists:
+		resolved.Operation = OperationDeleted
+	case change.Operation == OperationDeleted:
+		resolved.Operation = OperationUpdated
+	}
+	entry.Change = &resolved
+	return entry
+}
@@ -0,0 +1,161 @@
+package eval
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/query"
+)
+
+// Grader types
+const (
+	GraderExactMatch = "exact_match"
+	GraderLLMJudge   = "llm_judge"
+)
+
+// DefaultPassThreshold is the judge score an item needs to pass when the spec sets none
+const DefaultPassThreshold = 0.5
+
+// RunStatus is the lifecycle state of an eval run
+type RunStatus string
+
+const (
+	RunStatusQueued    RunStatus = "queued"
+	RunStatusRunning   RunStatus = "running"
+	RunStatusCompleted RunStatus = "completed"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+// GraderSpec configures how an item's output is scored
+type GraderSpec struct {
+	Type          string   `json:"type"`                     // exact_match or llm_judge
+	CaseSensitive bool     `json:"case_sensitive,omitempty"` // exact_match only
+	JudgeModel    string   `json:"judge_model,omitempty"`    // llm_judge only; defaults to EVAL_JUDGE_MODEL
+	Rubric        string   `json:"rubric,omitempty"`         // llm_judge only; extra grading instructions
+	PassThreshold *float64 `json:"pass_threshold,omitempty"` // llm_judge only; 0-1
+}
+
+// Dataset is a named set of prompts with expected answers
+type Dataset struct {
+	ID          uint
+	PublicID    string
+	Name        string
+	Description *string
+	Grader      GraderSpec // Default grader for items without their own
+	ItemCount   int
+	CreatedBy   *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// DatasetItem is one prompt of a dataset
+type DatasetItem struct {
+	ID        uint
+	DatasetID uint
+	Position  int
+	Input     string         // User message sent to the model
+	Variables map[string]any // Prompt template variables for this item
+	Expected  string
+	Grader    *GraderSpec // Overrides the dataset grader
+}
+
+// Run is one execution of a dataset against a model and optional prompt template
+type Run struct {
+	ID               uint
+	PublicID         string
+	DatasetID        uint
+	Model            string
+	PromptTemplateID *string
+	TemplateKey      *string
+	TemplateVersion  *int
+	SystemPrompt     string // Template content captured when the run was created
+	Status           RunStatus
+	TotalItems       int
+	CompletedItems   int
+	PassedItems      int
+	ErroredItems     int
+	AverageScore     float64
+	Error            string
+	CreatedBy        *string
+	StartedAt        *time.Time
+	CompletedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// PassRate is the fraction of items that passed
+func (r *Run) PassRate() float64 {
+	if r.TotalItems == 0 {
+		return 0
+	}
+	return float64(r.PassedItems) / float64(r.TotalItems)
+}
+
+// RunResult is the graded output of one dataset item in a run
+type RunResult struct {
+	ID        uint
+	RunID     uint
+	ItemID    uint
+	Position  int
+	Output    string
+	Score     float64
+	Passed    bool
+	Grader    string
+	Reasoning string // Judge explanation for llm_judge
+	LatencyMs int64
+	Error     string
+	CreatedAt time.Time
+}
+
+// ItemChange is a dataset item whose outcome differs between two runs
+type ItemChange struct {
+	ItemID          uint    `json:"item_id"`
+	Position        int     `json:"position"`
+	BaselineScore   float64 `json:"baseline_score"`
+	CandidateScore  float64 `json:"candidate_score"`
+	BaselinePassed  bool    `json:"baseline_passed"`
+	CandidatePassed bool    `json:"candidate_passed"`
+}
+
+// RunComparison compares a candidate run with a baseline run of the same dataset
+type RunComparison struct {
+	Baseline      *Run
+	Candidate     *Run
+	ScoreDelta    float64
+	PassRateDelta float64
+	Regressions   []ItemChange // Passed in the baseline, failed in the candidate
+	Improvements  []ItemChange // Failed in the baseline, passed in the candidate
+}
+
+// Message is one chat message sent to a model
+type Message struct {
+	Role    string
+	Content string
+}
+
+// Completer sends messages to a model and returns the reply text
+type Completer interface {
+	Complete(ctx context.Context, model string, messages []Message) (string, error)
+}
+
+// Repository defines data access for datasets, runs and results
+type Repository interface {
+	CreateDataset(ctx context.Context, dataset *Dataset, items []*DatasetItem) error
+	FindDatasetByPublicID(ctx context.Context, publicID string) (*Dataset, error)
+	FindDatasetByID(ctx context.Context, id uint) (*Dataset, error)
+	FindDatasetItems(ctx context.Context, datasetID uint) ([]*DatasetItem, error)
+	ListDatasets(ctx context.Context, p *query.Pagination) ([]*Dataset, int64, error)
+	DeleteDataset(ctx context.Context, id uint) error
+
+	CreateRun(ctx context.Context, run *Run) error
+	FindRunByPublicID(ctx context.Context, publicID string) (*Run, error)
+	ListRuns(ctx context.Context, datasetID *uint, p *query.Pagination) ([]*Run, int64, error)
+	// ClaimRun marks the oldest queued run, or a running run not updated since staleBefore,
+	// as running and returns it; it returns nil when there is nothing to claim
+	ClaimRun(ctx context.Context, staleBefore time.Time) (*Run, error)
+	UpdateRun(ctx context.Context, run *Run) error
+
+	// SaveResult inserts or replaces the result for the run's item
+	SaveResult(ctx context.Context, result *RunResult) error
+	FindRunResults(ctx context.Context, runID uint) ([]*RunResult, error)
+}
@@ -0,0 +1,317 @@
+package eval
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+
+	"jan-server/services/llm-api/internal/domain/prompttemplate"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// Config configures the Service
+type Config struct {
+	WorkerEnabled   bool   // Execute queued runs in this process
+	Concurrency     int    // Items evaluated in parallel per run
+	MaxDatasetItems int    // Upper bound on items per uploaded dataset
+	JudgeModel      string // Default model for llm_judge graders
+}
+
+// Service manages eval datasets and runs, and executes queued runs
+type Service struct {
+	repo            Repository
+	templates       *prompttemplate.Service
+	completer       Completer
+	workerEnabled   bool
+	concurrency     int
+	maxDatasetItems int
+	judgeModel      string
+
+	// processing guards ProcessQueued so one instance drains the queue at a time
+	processing sync.Mutex
+}
+
+// NewService creates a new eval service
+func NewService(repo Repository, templates *prompttemplate.Service, completer Completer, cfg Config) *Service {
+	concurrency := cfg.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	return &Service{
+		repo:            repo,
+		templates:       templates,
+		completer:       completer,
+		workerEnabled:   cfg.WorkerEnabled,
+		concurrency:     concurrency,
+		maxDatasetItems: cfg.MaxDatasetItems,
+		judgeModel:      cfg.JudgeModel,
+	}
+}
+
+// CreateDatasetInput describes a dataset upload
+type CreateDatasetInput struct {
+	Name        string
+	Description *string
+	Grader      GraderSpec
+	Items       []*DatasetItem
+}
+
+// CreateRunInput selects the dataset, model and optional prompt template of a run.
+// PromptTemplateID takes precedence over TemplateKey.
+type CreateRunInput struct {
+	DatasetID        string
+	Model            string
+	PromptTemplateID *string
+	TemplateKey      *string
+}
+
+// CreateDataset validates and stores a dataset with its items
+func (s *Service) CreateDataset(ctx context.Context, input CreateDatasetInput, createdBy *string) (*Dataset, error) {
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"name is required", nil, "cabe741a-1ee1-4683-87f7-71362c34643a")
+	}
+	if len(input.Items) == 0 {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"a dataset needs at least one item", nil, "f32f393a-ffa6-4467-a652-779635fc1cb2")
+	}
+	if s.maxDatasetItems > 0 && len(input.Items) > s.maxDatasetItems {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("a dataset may have at most %d items, got %d", s.maxDatasetItems, len(input.Items)), nil, "51e05934-b110-45cc-a47e-136778343b62")
+	}
+	if err := s.validateGrader(ctx, input.Grader, "grader"); err != nil {
+		return nil, err
+	}
+
+	for i, item := range input.Items {
+		if item == nil || strings.TrimSpace(item.Input) == "" {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("items[%d].input is required", i), nil, "0aa32dd0-14df-447e-a11e-306d8f3b3f22")
+		}
+		grader := input.Grader
+		if item.Grader != nil {
+			if err := s.validateGrader(ctx, *item.Grader, fmt.Sprintf("items[%d].grader", i)); err != nil {
+				return nil, err
+			}
+			grader = *item.Grader
+		}
+		if grader.Type == GraderExactMatch && strings.TrimSpace(item.Expected) == "" {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("items[%d].expected is required for exact_match grading", i), nil, "9ac17f45-0b22-4057-9743-dd8b037d33e5")
+		}
+		item.Position = i
+	}
+
+	publicID, err := idgen.GenerateSecureID("evds", 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate dataset id")
+	}
+
+	dataset := &Dataset{
+		PublicID:    publicID,
+		Name:        name,
+		Description: input.Description,
+		Grader:      input.Grader,
+		ItemCount:   len(input.Items),
+		CreatedBy:   createdBy,
+	}
+	if err := s.repo.CreateDataset(ctx, dataset, input.Items); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create dataset")
+	}
+	return dataset, nil
+}
+
+// GetDataset returns a dataset by its public ID
+func (s *Service) GetDataset(ctx context.Context, publicID string) (*Dataset, error) {
+	return s.repo.FindDatasetByPublicID(ctx, publicID)
+}
+
+// GetDatasetByID returns a dataset by its internal ID
+func (s *Service) GetDatasetByID(ctx context.Context, id uint) (*Dataset, error) {
+	return s.repo.FindDatasetByID(ctx, id)
+}
+
+// GetDatasetItems returns the items of a dataset in upload order
+func (s *Service) GetDatasetItems(ctx context.Context, dataset *Dataset) ([]*DatasetItem, error) {
+	return s.repo.FindDatasetItems(ctx, dataset.ID)
+}
+
+// ListDatasets returns datasets, newest first
+func (s *Service) ListDatasets(ctx context.Context, p *query.Pagination) ([]*Dataset, int64, error) {
+	return s.repo.ListDatasets(ctx, p)
+}
+
+// DeleteDataset removes a dataset together with its runs and results
+func (s *Service) DeleteDataset(ctx context.Context, publicID string) error {
+	dataset, err := s.repo.FindDatasetByPublicID(ctx, publicID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteDataset(ctx, dataset.ID); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to delete dataset")
+	}
+	return nil
+}
+
+// CreateRun queues a run of a dataset and wakes the worker. The prompt template content
+// and version are captured now, so later template edits do not change what the run evaluates.
+func (s *Service) CreateRun(ctx context.Context, input CreateRunInput, createdBy *string) (*Run, error) {
+	model := strings.TrimSpace(input.Model)
+	if model == "" {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"model is required", nil, "826b3558-e676-4239-8406-53eca2b067ad")
+	}
+
+	dataset, err := s.repo.FindDatasetByPublicID(ctx, input.DatasetID)
+	if err != nil {
+		return nil, err
+	}
+
+	run := &Run{
+		DatasetID:  dataset.ID,
+		Model:      model,
+		Status:     RunStatusQueued,
+		TotalItems: dataset.ItemCount,
+		CreatedBy:  createdBy,
+	}
+
+	var template *prompttemplate.PromptTemplate
+	switch {
+	case input.PromptTemplateID != nil && *input.PromptTemplateID != "":
+		template, err = s.templates.GetByPublicID(ctx, *input.PromptTemplateID)
+	case input.TemplateKey != nil && *input.TemplateKey != "":
+		template, err = s.templates.GetByKey(ctx, *input.TemplateKey)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if template != nil {
+		version := template.Version
+		run.PromptTemplateID = &template.PublicID
+		run.TemplateKey = &template.TemplateKey
+		run.TemplateVersion = &version
+		run.SystemPrompt = template.Content
+	}
+
+	publicID, err := idgen.GenerateSecureID("evrun", 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate run id")
+	}
+	run.PublicID = publicID
+
+	if err := s.repo.CreateRun(ctx, run); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create run")
+	}
+	s.Wake()
+	return run, nil
+}
+
+// GetRun returns a run by its public ID
+func (s *Service) GetRun(ctx context.Context, publicID string) (*Run, error) {
+	return s.repo.FindRunByPublicID(ctx, publicID)
+}
+
+// ListRuns returns runs, newest first, optionally limited to one dataset
+func (s *Service) ListRuns(ctx context.Context, datasetPublicID *string, p *query.Pagination) ([]*Run, int64, error) {
+	var datasetID *uint
+	if datasetPublicID != nil && *datasetPublicID != "" {
+		dataset, err := s.repo.FindDatasetByPublicID(ctx, *datasetPublicID)
+		if err != nil {
+			return nil, 0, err
+		}
+		datasetID = &dataset.ID
+	}
+	return s.repo.ListRuns(ctx, datasetID, p)
+}
+
+// GetRunResults returns the graded item results of a run in dataset order
+func (s *Service) GetRunResults(ctx context.Context, run *Run) ([]*RunResult, error) {
+	return s.repo.FindRunResults(ctx, run.ID)
+}
+
+// CompareRuns compares a candidate run with a baseline run of the same dataset,
+// listing the items that flipped between pass and fail.
+func (s *Service) CompareRuns(ctx context.Context, candidatePublicID, baselinePublicID string) (*RunComparison, error) {
+	candidate, err := s.repo.FindRunByPublicID(ctx, candidatePublicID)
+	if err != nil {
+		return nil, err
+	}
+	baseline, err := s.repo.FindRunByPublicID(ctx, baselinePublicID)
+	if err != nil {
+		return nil, err
+	}
+	if candidate.DatasetID != baseline.DatasetID {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"runs belong to different datasets", nil, "b57cf0ee-477b-4eea-8019-77db5551a9cd")
+	}
+	if candidate.Status != RunStatusCompleted || baseline.Status != RunStatusCompleted {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			"both runs must be completed to compare them", nil, "3dfdfb94-8ae9-4efa-9e0e-b6475e3b1dec")
+	}
+
+	baselineResults, err := s.repo.FindRunResults(ctx, baseline.ID)
+	if err != nil {
+		return nil, err
+	}
+	candidateResults, err := s.repo.FindRunResults(ctx, candidate.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	byItem := make(map[uint]*RunResult, len(baselineResults))
+	for _, r := range baselineResults {
+		byItem[r.ItemID] = r
+	}
+
+	comparison := &RunComparison{
+		Baseline:      baseline,
+		Candidate:     candidate,
+		ScoreDelta:    candidate.AverageScore - baseline.AverageScore,
+		PassRateDelta: candidate.PassRate() - baseline.PassRate(),
+		Regressions:   []ItemChange{},
+		Improvements:  []ItemChange{},
+	}
+	for _, c := range candidateResults {
+		b, ok := byItem[c.ItemID]
+		if !ok || b.Passed == c.Passed {
+			continue
+		}
+		change := ItemChange{
+			ItemID:          c.ItemID,
+			Position:        c.Position,
+			BaselineScore:   b.Score,
+			CandidateScore:  c.Score,
+			BaselinePassed:  b.Passed,
+			CandidatePassed: c.Passed,
+		}
+		if b.Passed {
+			comparison.Regressions = append(comparison.Regressions, change)
+		} else {
+			comparison.Improvements = append(comparison.Improvements, change)
+		}
+	}
+	return comparison, nil
+}
+
+func (s *Service) validateGrader(ctx context.Context, spec GraderSpec, field string) error {
+	switch spec.Type {
+	case GraderExactMatch:
+	case GraderLLMJudge:
+		if strings.TrimSpace(spec.JudgeModel) == "" && s.judgeModel == "" {
+			return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("%s.judge_model is required because EVAL_JUDGE_MODEL is not set", field), nil, "a5497d1a-1a5e-41a2-bfc3-e8ae7c1d8294")
+		}
+	default:
+		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("%s.type must be one of exact_match, llm_judge", field), nil, "a67b7586-398b-4ee8-b0ad-43978e2c8039")
+	}
+	if spec.PassThreshold != nil && (*spec.PassThreshold < 0 || *spec.PassThreshold > 1) {
+		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("%s.pass_threshold must be between 0 and 1", field), nil, "c3b52efd-46b3-4166-b10d-ebfc5ca1ff15")
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package eval
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+const judgeSystemPrompt = `You are grading a model's answer against a reference answer.
+Score how well the answer matches the reference in meaning and correctness, from 0 (wrong) to 1 (fully correct).
+Reply with only a JSON object: {"score": <number between 0 and 1>, "reasoning": "<one or two sentences>"}`
+
+// grade holds the outcome of scoring one output
+type grade struct {
+	Score     float64
+	Passed    bool
+	Reasoning string
+}
+
+// judgeVerdict is the JSON reply expected from the judge model
+type judgeVerdict struct {
+	Score     float64 `json:"score"`
+	Reasoning string  `json:"reasoning"`
+}
+
+// gradeOutput scores output against the item's expected answer with the given grader
+func (s *Service) gradeOutput(ctx context.Context, spec GraderSpec, item *DatasetItem, output string) (grade, error) {
+	switch spec.Type {
+	case GraderLLMJudge:
+		return s.gradeWithJudge(ctx, spec, item, output)
+	default:
+		return gradeExactMatch(spec, item.Expected, output), nil
+	}
+}
+
+// gradeExactMatch compares trimmed output with the expected answer, ignoring case unless configured otherwise
+func gradeExactMatch(spec GraderSpec, expected, output string) grade {
+	expected = strings.TrimSpace(expected)
+	output = strings.TrimSpace(output)
+	matched := output == expected
+	if !spec.CaseSensitive {
+		matched = strings.EqualFold(output, expected)
+	}
+	if matched {
+		return grade{Score: 1, Passed: true}
+	}
+	return grade{Score: 0, Passed: false}
+}
+
+// gradeWithJudge asks the judge model to score the output and applies the pass threshold
+func (s *Service) gradeWithJudge(ctx context.Context, spec GraderSpec, item *DatasetItem, output string) (grade, error) {
+	model := strings.TrimSpace(spec.JudgeModel)
+	if model == "" {
+		model = s.judgeModel
+	}
+	if model == "" {
+		return grade{}, fmt.Errorf("no judge model configured")
+	}
+
+	var prompt strings.Builder
+	fmt.Fprintf(&prompt, "Question:\n%s\n\nReference answer:\n%s\n\nModel answer:\n%s\n", item.Input, item.Expected, output)
+	if rubric := strings.TrimSpace(spec.Rubric); rubric != "" {
+		fmt.Fprintf(&prompt, "\nGrading rubric:\n%s\n", rubric)
+	}
+
+	reply, err := s.completer.Complete(ctx, model, []Message{
+		{Role: "system", Content: judgeSystemPrompt},
+		{Role: "user", Content: prompt.String()},
+	})
+	if err != nil {
+		return grade{}, fmt.Errorf("judge model %s: %w", model, err)
+	}
+
+	verdict, err := parseJudgeVerdict(reply)
+	if err != nil {
+		return grade{}, err
+	}
+
+	threshold := DefaultPassThreshold
+	if spec.PassThreshold != nil {
+		threshold = *spec.PassThreshold
+	}
+	return grade{
+		Score:     verdict.Score,
+		Passed:    verdict.Score >= threshold,
+		Reasoning: verdict.Reasoning,
+	}, nil
+}
+
+// parseJudgeVerdict extracts the JSON object from the judge reply, tolerating code fences or
+// surrounding prose, and clamps the score to [0, 1]
+func parseJudgeVerdict(reply string) (judgeVerdict, error) {
+	start := strings.Index(reply, "{")
+	end := strings.LastIndex(reply, "}")
+	if start < 0 || end < start {
+		return judgeVerdict{}, fmt.Errorf("judge reply is not JSON: %q", truncate(reply, 200))
+	}
+
+	var verdict judgeVerdict
+	if err := json.Unmarshal([]byte(reply[start:end+1]), &verdict); err != nil {
+		return judgeVerdict{}, fmt.Errorf("failed to parse judge reply: %w", err)
+	}
+	if verdict.Score < 0 {
+		verdict.Score = 0
+	}
+	if verdict.Score > 1 {
+		verdict.Score = 1
+	}
+	return verdict, nil
+}
+
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	return s[:n] + "..."
+}
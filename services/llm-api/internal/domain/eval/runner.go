@@ -0,0 +1,227 @@
+package eval
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"text/template"
+	"time"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// RunStaleAfter is how long a running run may go without progress before another
+// worker reclaims it; the runner touches the run after every item it finishes
+const RunStaleAfter = 15 * time.Minute
+
+// Wake starts draining the run queue in the background when the worker is enabled
+func (s *Service) Wake() {
+	if !s.workerEnabled {
+		return
+	}
+	go s.ProcessQueued(context.Background())
+}
+
+// ProcessQueued claims and executes queued runs until the queue is empty. Runs are
+// claimed with row locks, so several instances may drain the same queue safely.
+// Calls made while this instance is already draining return immediately.
+func (s *Service) ProcessQueued(ctx context.Context) {
+	if !s.processing.TryLock() {
+		return
+	}
+	defer s.processing.Unlock()
+
+	log := logger.GetLogger()
+	for ctx.Err() == nil {
+		run, err := s.repo.ClaimRun(ctx, time.Now().UTC().Add(-RunStaleAfter))
+		if err != nil {
+			log.Error().Err(err).Msg("failed to claim eval run")
+			return
+		}
+		if run == nil {
+			return
+		}
+		s.executeRun(ctx, run)
+	}
+}
+
+// executeRun evaluates every dataset item that has no result yet, then stores the run totals
+func (s *Service) executeRun(ctx context.Context, run *Run) {
+	log := logger.GetLogger().With().Str("eval_run", run.PublicID).Logger()
+	log.Info().Str("model", run.Model).Msg("starting eval run")
+
+	items, err := s.repo.FindDatasetItems(ctx, run.DatasetID)
+	if err != nil {
+		s.failRun(ctx, run, "failed to load dataset items: "+err.Error())
+		return
+	}
+	dataset, err := s.repo.FindDatasetByID(ctx, run.DatasetID)
+	if err != nil {
+		s.failRun(ctx, run, "failed to load dataset: "+err.Error())
+		return
+	}
+
+	var systemPrompt *template.Template
+	if strings.TrimSpace(run.SystemPrompt) != "" {
+		systemPrompt, err = template.New("system").Parse(run.SystemPrompt)
+		if err != nil {
+			s.failRun(ctx, run, "failed to parse prompt template: "+err.Error())
+			return
+		}
+	}
+
+	// Results saved before a restart are kept; only the remaining items are evaluated
+	existing, err := s.repo.FindRunResults(ctx, run.ID)
+	if err != nil {
+		s.failRun(ctx, run, "failed to load run results: "+err.Error())
+		return
+	}
+	done := make(map[uint]bool, len(existing))
+	for _, r := range existing {
+		done[r.ItemID] = true
+	}
+
+	var (
+		wg  sync.WaitGroup
+		mu  sync.Mutex
+		sem = make(chan struct{}, s.concurrency)
+	)
+	run.TotalItems = len(items)
+	run.CompletedItems = len(existing)
+
+	for _, item := range items {
+		if done[item.ID] {
+			continue
+		}
+		if ctx.Err() != nil {
+			break
+		}
+
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(item *DatasetItem) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			grader := dataset.Grader
+			if item.Grader != nil {
+				grader = *item.Grader
+			}
+			result := s.evaluateItem(ctx, run, systemPrompt, grader, item)
+			if err := s.repo.SaveResult(ctx, result); err != nil {
+				log.Error().Err(err).Int("position", item.Position).Msg("failed to save eval result")
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			run.CompletedItems++
+			if err := s.repo.UpdateRun(ctx, run); err != nil {
+				log.Warn().Err(err).Msg("failed to update eval run progress")
+			}
+		}(item)
+	}
+	wg.Wait()
+
+	if ctx.Err() != nil {
+		// Left as running; the run is reclaimed once it goes stale
+		log.Warn().Msg("eval run interrupted")
+		return
+	}
+
+	results, err := s.repo.FindRunResults(ctx, run.ID)
+	if err != nil {
+		s.failRun(ctx, run, "failed to load run results: "+err.Error())
+		return
+	}
+	s.summarizeRun(run, results)
+
+	now := time.Now().UTC()
+	run.Status = RunStatusCompleted
+	run.CompletedAt = &now
+	if err := s.repo.UpdateRun(ctx, run); err != nil {
+		log.Error().Err(err).Msg("failed to complete eval run")
+		return
+	}
+	log.Info().
+		Int("items", run.TotalItems).
+		Int("passed", run.PassedItems).
+		Int("errored", run.ErroredItems).
+		Float64("average_score", run.AverageScore).
+		Msg("completed eval run")
+}
+
+// evaluateItem sends one item to the run's model and grades the reply. Model and
+// judge failures are recorded on the result rather than failing the whole run.
+func (s *Service) evaluateItem(ctx context.Context, run *Run, systemPrompt *template.Template, grader GraderSpec, item *DatasetItem) *RunResult {
+	result := &RunResult{
+		RunID:    run.ID,
+		ItemID:   item.ID,
+		Position: item.Position,
+		Grader:   grader.Type,
+	}
+
+	messages := make([]Message, 0, 2)
+	if systemPrompt != nil {
+		var rendered strings.Builder
+		if err := systemPrompt.Execute(&rendered, item.Variables); err != nil {
+			result.Error = "failed to render prompt template: " + err.Error()
+			return result
+		}
+		messages = append(messages, Message{Role: "system", Content: rendered.String()})
+	}
+	messages = append(messages, Message{Role: "user", Content: item.Input})
+
+	start := time.Now()
+	output, err := s.completer.Complete(ctx, run.Model, messages)
+	result.LatencyMs = time.Since(start).Milliseconds()
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	result.Output = output
+
+	g, err := s.gradeOutput(ctx, grader, item, output)
+	if err != nil {
+		result.Error = "grading failed: " + err.Error()
+		return result
+	}
+	result.Score = g.Score
+	result.Passed = g.Passed
+	result.Reasoning = g.Reasoning
+	return result
+}
+
+// summarizeRun recomputes the run totals from its stored results
+func (s *Service) summarizeRun(run *Run, results []*RunResult) {
+	run.CompletedItems = len(results)
+	run.PassedItems = 0
+	run.ErroredItems = 0
+	var total float64
+	for _, r := range results {
+		if r.Passed {
+			run.PassedItems++
+		}
+		if r.Error != "" {
+			run.ErroredItems++
+		}
+		total += r.Score
+	}
+	run.AverageScore = 0
+	if run.TotalItems > 0 {
+		run.AverageScore = total / float64(run.TotalItems)
+	}
+}
+
+func (s *Service) failRun(ctx context.Context, run *Run, message string) {
+	log := logger.GetLogger()
+	log.Error().Str("eval_run", run.PublicID).Msg(message)
+
+	now := time.Now().UTC()
+	run.Status = RunStatusFailed
+	run.Error = message
+	run.CompletedAt = &now
+	if err := s.repo.UpdateRun(ctx, run); err != nil {
+		log.Error().Err(err).Str("eval_run", run.PublicID).Msg("failed to mark eval run as failed")
+	}
+}
@@ -0,0 +1,111 @@
+// Package event is llm-api's in-process event bus. Producers such as the provider
+// webhook receiver publish events; subscribers react to them without the producer
+// knowing who listens.
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// handlerTimeout bounds how long one subscriber may take to handle one event
+const handlerTimeout = time.Minute
+
+// Event is something that happened outside the request that reported it
+type Event struct {
+	ID         string          // Unique per source; redeliveries keep the ID
+	Source     string          // e.g. openai, openrouter, livekit
+	Type       string          // Source-qualified type, e.g. livekit.egress_ended
+	OccurredAt time.Time       // When the source says it happened, else when it was received
+	ReceivedAt time.Time       // When llm-api accepted it
+	Payload    json.RawMessage // Body as sent by the source
+}
+
+// Handler reacts to an event. Errors are logged; the event is not redelivered.
+type Handler func(ctx context.Context, e Event) error
+
+type subscription struct {
+	name    string
+	pattern string
+	handler Handler
+}
+
+// Bus fans events out to subscribers. Each subscriber runs in its own goroutine, so
+// Publish returns immediately and a slow subscriber delays no one else.
+type Bus struct {
+	mu            sync.RWMutex
+	subscriptions []subscription
+	wg            sync.WaitGroup
+}
+
+// NewBus creates an event bus without subscribers
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Subscribe registers handler for events whose type matches pattern: an exact type,
+// a prefix ending in ".*" such as "livekit.*", or "*" for every event. The name
+// identifies the subscriber in logs.
+func (b *Bus) Subscribe(name, pattern string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions = append(b.subscriptions, subscription{name: name, pattern: pattern, handler: handler})
+}
+
+// Publish delivers e to every matching subscriber and returns how many matched
+func (b *Bus) Publish(e Event) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	matched := 0
+	for _, sub := range b.subscriptions {
+		if !Matches(sub.pattern, e.Type) {
+			continue
+		}
+		matched++
+		b.wg.Add(1)
+		go b.deliver(sub, e)
+	}
+	return matched
+}
+
+// Wait blocks until every delivery started so far has finished
+func (b *Bus) Wait() {
+	b.wg.Wait()
+}
+
+func (b *Bus) deliver(sub subscription, e Event) {
+	defer b.wg.Done()
+	log := logger.GetLogger()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Error().Str("subscriber", sub.name).Str("event_id", e.ID).Str("event_type", e.Type).
+				Msg(fmt.Sprintf("event subscriber panicked: %v", r))
+		}
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), handlerTimeout)
+	defer cancel()
+	if err := sub.handler(ctx, e); err != nil {
+		log.Error().Err(err).Str("subscriber", sub.name).Str("event_id", e.ID).Str("event_type", e.Type).
+			Msg("event subscriber failed")
+	}
+}
+
+// Matches reports whether an event type matches a subscription pattern
+func Matches(pattern, eventType string) bool {
+	switch {
+	case pattern == "*":
+		return true
+	case strings.HasSuffix(pattern, ".*"):
+		return strings.HasPrefix(eventType, strings.TrimSuffix(pattern, "*"))
+	default:
+		return pattern == eventType
+	}
+}
@@ -0,0 +1,195 @@
+package finetune
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// turnBatchSize is how many liked turns are loaded per query while building an export
+const turnBatchSize = 200
+
+// ExportMimeType is the content type of uploaded export files
+const ExportMimeType = "application/x-ndjson"
+
+// buildExport collects the liked turns, writes them as JSONL in the export's format
+// and uploads the file to media-api, recording the outcome on the export
+func (s *Service) buildExport(ctx context.Context, export Export, authHeader string) {
+	log := logger.GetLogger().With().Str("finetune_export", export.PublicID).Logger()
+
+	export.Status = ExportStatusRunning
+	if err := s.repo.UpdateExport(ctx, &export); err != nil {
+		log.Error().Err(err).Msg("failed to mark export as running")
+		return
+	}
+
+	var (
+		buf  bytes.Buffer
+		seen = make(map[[32]byte]bool)
+	)
+	filter := TurnFilter{
+		RatedAfter:  export.RatedAfter,
+		RatedBefore: export.RatedBefore,
+		Limit:       turnBatchSize,
+	}
+	for s.maxExamples <= 0 || export.ExampleCount < s.maxExamples {
+		turns, err := s.repo.FindLikedTurns(ctx, filter)
+		if err != nil {
+			s.failExport(ctx, &export, "failed to load liked turns: "+err.Error())
+			return
+		}
+		for _, turn := range turns {
+			if s.maxExamples > 0 && export.ExampleCount >= s.maxExamples {
+				break
+			}
+			line, err := s.buildExample(ctx, &export, turn)
+			if err != nil {
+				s.failExport(ctx, &export, err.Error())
+				return
+			}
+			if line == nil {
+				continue
+			}
+			// Forked branches copy their items, so the same liked turn can appear more than once
+			key := sha256.Sum256(line)
+			if seen[key] {
+				continue
+			}
+			seen[key] = true
+			buf.Write(line)
+			buf.WriteByte('\n')
+			export.ExampleCount++
+		}
+		if len(turns) < turnBatchSize {
+			break
+		}
+		filter.AfterID = turns[len(turns)-1].ID
+	}
+
+	if export.ExampleCount == 0 {
+		s.failExport(ctx, &export, "no liked assistant turns matched the export filters")
+		return
+	}
+
+	filename := fmt.Sprintf("finetune_%s_%s.jsonl", export.Format, export.PublicID)
+	mediaID, err := s.uploader.UploadFile(ctx, buf.Bytes(), ExportMimeType, filename, authHeader)
+	if err != nil {
+		s.failExport(ctx, &export, "failed to upload export file: "+err.Error())
+		return
+	}
+
+	now := time.Now().UTC()
+	export.Status = ExportStatusCompleted
+	export.MediaID = &mediaID
+	export.Bytes = int64(buf.Len())
+	export.CompletedAt = &now
+	if err := s.repo.UpdateExport(ctx, &export); err != nil {
+		log.Error().Err(err).Msg("failed to complete export")
+		return
+	}
+	log.Info().
+		Int("examples", export.ExampleCount).
+		Int64("bytes", export.Bytes).
+		Str("media_id", mediaID).
+		Msg("completed fine-tuning export")
+}
+
+// buildExample renders a liked turn and its preceding context as one JSONL line. It
+// returns nil when the turn has no text or no user message to learn from.
+func (s *Service) buildExample(ctx context.Context, export *Export, turn *conversation.Item) ([]byte, error) {
+	reply := itemText(turn)
+	if reply == "" {
+		return nil, nil
+	}
+
+	contextItems, err := s.repo.FindTurnContext(ctx, turn, export.MaxContextMessages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load context of item %s: %w", turn.PublicID, err)
+	}
+
+	messages := make([]Message, 0, len(contextItems)+1)
+	hasUser := false
+	for _, item := range contextItems {
+		role := messageRole(item)
+		text := itemText(item)
+		if role == "" || text == "" {
+			continue
+		}
+		if role == "user" {
+			hasUser = true
+		}
+		messages = append(messages, Message{Role: role, Content: text})
+	}
+	if !hasUser {
+		return nil, nil
+	}
+	messages = append(messages, Message{Role: "assistant", Content: reply})
+
+	if export.RedactPII {
+		for i := range messages {
+			messages[i].Content = RedactPII(messages[i].Content)
+		}
+	}
+
+	line, err := encodeExample(export.Format, messages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode item %s: %w", turn.PublicID, err)
+	}
+	return line, nil
+}
+
+// messageRole maps an item role to a training role; tool and critic turns are dropped
+func messageRole(item *conversation.Item) string {
+	if item.Role == nil {
+		return ""
+	}
+	switch *item.Role {
+	case conversation.ItemRoleSystem, conversation.ItemRoleDeveloper:
+		return "system"
+	case conversation.ItemRoleUser:
+		return "user"
+	case conversation.ItemRoleAssistant:
+		return "assistant"
+	}
+	return ""
+}
+
+// itemText joins the visible text parts of an item, skipping reasoning and tool content
+func itemText(item *conversation.Item) string {
+	parts := make([]string, 0, len(item.Content))
+	for _, c := range item.Content {
+		switch c.Type {
+		case "text", "input_text", "output_text":
+		default:
+			continue
+		}
+		switch {
+		case c.TextString != nil && *c.TextString != "":
+			parts = append(parts, *c.TextString)
+		case c.Text != nil && c.Text.Text != "":
+			parts = append(parts, c.Text.Text)
+		case c.OutputText != nil && c.OutputText.Text != "":
+			parts = append(parts, c.OutputText.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+func (s *Service) failExport(ctx context.Context, export *Export, message string) {
+	log := logger.GetLogger()
+	log.Error().Str("finetune_export", export.PublicID).Msg(message)
+
+	now := time.Now().UTC()
+	export.Status = ExportStatusFailed
+	export.Error = message
+	export.CompletedAt = &now
+	if err := s.repo.UpdateExport(ctx, export); err != nil {
+		log.Error().Err(err).Str("finetune_export", export.PublicID).Msg("failed to mark export as failed")
+	}
+}
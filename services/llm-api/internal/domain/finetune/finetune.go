@@ -0,0 +1,82 @@
+package finetune
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/query"
+)
+
+// Export formats
+const (
+	FormatOpenAI   = "openai"   // {"messages":[{"role","content"}]} as accepted by OpenAI fine-tuning
+	FormatChatML   = "chatml"   // {"text":"<|im_start|>role\n...<|im_end|>"} for chat-template trainers
+	FormatShareGPT = "sharegpt" // {"conversations":[{"from","value"}]}
+)
+
+// DefaultMaxContextMessages is how many messages before a liked turn are kept when the request sets none
+const DefaultMaxContextMessages = 20
+
+// ExportStatus is the lifecycle state of an export
+type ExportStatus string
+
+const (
+	ExportStatusPending   ExportStatus = "pending"
+	ExportStatusRunning   ExportStatus = "running"
+	ExportStatusCompleted ExportStatus = "completed"
+	ExportStatusFailed    ExportStatus = "failed"
+)
+
+// Export is one fine-tuning dataset export job
+type Export struct {
+	ID                 uint
+	PublicID           string
+	Format             string
+	Status             ExportStatus
+	RedactPII          bool
+	RatedAfter         *time.Time // Only turns rated at or after this time
+	RatedBefore        *time.Time // Only turns rated before this time
+	MaxContextMessages int
+	ExampleCount       int
+	MediaID            *string // media-api ID of the JSONL file once completed
+	Bytes              int64
+	Error              string
+	CreatedBy          *string
+	CompletedAt        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// Message is one chat turn of a training example
+type Message struct {
+	Role    string
+	Content string
+}
+
+// TurnFilter selects the liked assistant turns of an export
+type TurnFilter struct {
+	RatedAfter  *time.Time
+	RatedBefore *time.Time
+	AfterID     uint // Keyset cursor; only items with a greater ID are returned
+	Limit       int
+}
+
+// Uploader stores export files and returns their media ID
+type Uploader interface {
+	UploadFile(ctx context.Context, data []byte, mimeType, filename, authHeader string) (string, error)
+}
+
+// Repository defines data access for exports and the rated conversation items they read
+type Repository interface {
+	CreateExport(ctx context.Context, export *Export) error
+	FindExportByPublicID(ctx context.Context, publicID string) (*Export, error)
+	ListExports(ctx context.Context, p *query.Pagination) ([]*Export, int64, error)
+	UpdateExport(ctx context.Context, export *Export) error
+
+	// FindLikedTurns returns liked assistant message items of live conversations, ordered by ID
+	FindLikedTurns(ctx context.Context, filter TurnFilter) ([]*conversation.Item, error)
+	// FindTurnContext returns up to limit message items that precede the item in its
+	// conversation branch, in sequence order
+	FindTurnContext(ctx context.Context, item *conversation.Item, limit int) ([]*conversation.Item, error)
+}
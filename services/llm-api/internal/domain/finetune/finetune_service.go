@@ -0,0 +1,97 @@
+package finetune
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// maxContextMessagesLimit caps the context a request may ask for per example
+const maxContextMessagesLimit = 200
+
+// Config configures the Service
+type Config struct {
+	MaxExamples int // Upper bound on examples written to one export file
+}
+
+// Service creates fine-tuning dataset exports from liked conversation turns
+type Service struct {
+	repo        Repository
+	uploader    Uploader
+	maxExamples int
+}
+
+// NewService creates a new fine-tuning export service
+func NewService(repo Repository, uploader Uploader, cfg Config) *Service {
+	return &Service{
+		repo:        repo,
+		uploader:    uploader,
+		maxExamples: cfg.MaxExamples,
+	}
+}
+
+// CreateExportInput describes an export request
+type CreateExportInput struct {
+	Format             string
+	RedactPII          bool
+	RatedAfter         *time.Time
+	RatedBefore        *time.Time
+	MaxContextMessages int // 0 uses DefaultMaxContextMessages
+}
+
+// CreateExport validates the request, stores a pending export and builds it in the
+// background. authHeader is forwarded to media-api when the file is uploaded.
+func (s *Service) CreateExport(ctx context.Context, input CreateExportInput, createdBy *string, authHeader string) (*Export, error) {
+	if !IsValidFormat(input.Format) {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("unsupported format %q: use openai, chatml or sharegpt", input.Format), nil, "1748d06d-7e53-4bdb-995c-d64c7ceb5941")
+	}
+	maxContext := input.MaxContextMessages
+	if maxContext == 0 {
+		maxContext = DefaultMaxContextMessages
+	}
+	if maxContext < 1 || maxContext > maxContextMessagesLimit {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			fmt.Sprintf("max_context_messages must be between 1 and %d", maxContextMessagesLimit), nil, "0265de5f-3918-4ae2-bbbc-34a3a52d1b5d")
+	}
+	if input.RatedAfter != nil && input.RatedBefore != nil && !input.RatedAfter.Before(*input.RatedBefore) {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"rated_after must be before rated_before", nil, "a2bed94f-9701-459c-ace2-6874e5ccb9ab")
+	}
+
+	publicID, err := idgen.GenerateSecureID("ftx", 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate export id")
+	}
+
+	export := &Export{
+		PublicID:           publicID,
+		Format:             input.Format,
+		Status:             ExportStatusPending,
+		RedactPII:          input.RedactPII,
+		RatedAfter:         input.RatedAfter,
+		RatedBefore:        input.RatedBefore,
+		MaxContextMessages: maxContext,
+		CreatedBy:          createdBy,
+	}
+	if err := s.repo.CreateExport(ctx, export); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create export")
+	}
+
+	go s.buildExport(context.WithoutCancel(ctx), *export, authHeader)
+	return export, nil
+}
+
+// GetExport returns an export by its public ID
+func (s *Service) GetExport(ctx context.Context, publicID string) (*Export, error) {
+	return s.repo.FindExportByPublicID(ctx, publicID)
+}
+
+// ListExports returns exports, newest first
+func (s *Service) ListExports(ctx context.Context, p *query.Pagination) ([]*Export, int64, error) {
+	return s.repo.ListExports(ctx, p)
+}
@@ -0,0 +1,72 @@
+package finetune
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// IsValidFormat reports whether format is a supported export format
+func IsValidFormat(format string) bool {
+	switch format {
+	case FormatOpenAI, FormatChatML, FormatShareGPT:
+		return true
+	}
+	return false
+}
+
+type openAIMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+type openAIExample struct {
+	Messages []openAIMessage `json:"messages"`
+}
+
+type chatMLExample struct {
+	Text string `json:"text"`
+}
+
+type shareGPTTurn struct {
+	From  string `json:"from"`
+	Value string `json:"value"`
+}
+
+type shareGPTExample struct {
+	Conversations []shareGPTTurn `json:"conversations"`
+}
+
+// shareGPTRoles maps chat roles to the speaker names ShareGPT datasets use
+var shareGPTRoles = map[string]string{
+	"system":    "system",
+	"user":      "human",
+	"assistant": "gpt",
+}
+
+// encodeExample renders one training example as a single JSONL line without the trailing newline
+func encodeExample(format string, messages []Message) ([]byte, error) {
+	switch format {
+	case FormatChatML:
+		var b strings.Builder
+		for _, m := range messages {
+			b.WriteString("<|im_start|>")
+			b.WriteString(m.Role)
+			b.WriteString("\n")
+			b.WriteString(m.Content)
+			b.WriteString("<|im_end|>\n")
+		}
+		return json.Marshal(chatMLExample{Text: b.String()})
+	case FormatShareGPT:
+		turns := make([]shareGPTTurn, 0, len(messages))
+		for _, m := range messages {
+			turns = append(turns, shareGPTTurn{From: shareGPTRoles[m.Role], Value: m.Content})
+		}
+		return json.Marshal(shareGPTExample{Conversations: turns})
+	default:
+		msgs := make([]openAIMessage, 0, len(messages))
+		for _, m := range messages {
+			msgs = append(msgs, openAIMessage{Role: m.Role, Content: m.Content})
+		}
+		return json.Marshal(openAIExample{Messages: msgs})
+	}
+}
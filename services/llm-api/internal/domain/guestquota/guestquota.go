@@ -0,0 +1,87 @@
+// Package guestquota keeps the guest tier of public deployments from being
+// farmed. Guests get a daily message allowance per account and per client IP,
+// may only use a set of cheap models, and must solve a CAPTCHA once their
+// account or IP sends many messages in a day.
+package guestquota
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Quota scopes
+const (
+	ScopeGuest = "guest"
+	ScopeIP    = "ip"
+)
+
+// Config holds the guest tier limits. A zero cap is not enforced.
+type Config struct {
+	DailyMessages   int      // Messages per guest account and day
+	IPDailyMessages int      // Messages of all guests per client IP and day
+	Models          []string // Model public IDs guests may use; empty allows every model
+	CaptchaAfter    int      // Daily messages of a guest or IP after which a CAPTCHA is required
+}
+
+// Counter keeps the daily counts. Counts must be shared by every replica for
+// the caps to hold across them.
+type Counter interface {
+	// Get returns the count of key, 0 if it has none.
+	Get(ctx context.Context, key string) (int64, error)
+	// Incr adds one to key, which is dropped at expireAt, and returns the new count.
+	Incr(ctx context.Context, key string, expireAt time.Time) (int64, error)
+}
+
+// CaptchaVerifier checks a CAPTCHA token solved by the client.
+type CaptchaVerifier interface {
+	Verify(ctx context.Context, token, remoteIP string) (bool, error)
+}
+
+// Request identifies a guest message.
+type Request struct {
+	GuestID      string // Subject of the guest account
+	IP           string // Client IP
+	ModelID      string // Model public ID
+	CaptchaToken string // Token of a solved CAPTCHA, if the client sent one
+}
+
+var (
+	// ErrCaptchaRequired means the guest must solve a CAPTCHA before sending more messages
+	ErrCaptchaRequired = errors.New("a CAPTCHA must be solved to keep chatting as a guest")
+	// ErrCaptchaInvalid means the CAPTCHA token was rejected by the verifier
+	ErrCaptchaInvalid = errors.New("CAPTCHA verification failed, solve a new CAPTCHA")
+)
+
+// ModelNotAllowedError means the model is not offered to guests
+type ModelNotAllowedError struct {
+	Model string
+}
+
+func (e *ModelNotAllowedError) Error() string {
+	return fmt.Sprintf("model %s is not available to guests; sign up to use it", e.Model)
+}
+
+// ExceededError means a daily cap was reached
+type ExceededError struct {
+	Scope   string
+	Limit   int
+	ResetAt time.Time // Start of the next UTC day
+}
+
+func (e *ExceededError) Error() string {
+	if e.Scope == ScopeIP {
+		return fmt.Sprintf("guests on this network reached the daily limit of %d messages; sign up to keep chatting", e.Limit)
+	}
+	return fmt.Sprintf("guest accounts are limited to %d messages a day; sign up to keep chatting", e.Limit)
+}
+
+// RetryAfterSeconds is the Retry-After value until the cap resets at now
+func (e *ExceededError) RetryAfterSeconds(now time.Time) int {
+	seconds := int((e.ResetAt.Sub(now) + time.Second - 1) / time.Second)
+	if seconds < 1 {
+		return 1
+	}
+	return seconds
+}
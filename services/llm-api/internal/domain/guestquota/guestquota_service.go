@@ -0,0 +1,145 @@
+package guestquota
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+	"time"
+)
+
+// keyPrefix namespaces the counter keys
+const keyPrefix = "guest-quota:"
+
+// Service admits the messages of guests against the configured limits.
+type Service struct {
+	cfg     Config
+	counter Counter
+	captcha CaptchaVerifier // Nil disables the CAPTCHA escalation
+	now     func() time.Time
+}
+
+// NewService creates the service. Without a captcha verifier guests are never
+// asked to solve a CAPTCHA.
+func NewService(cfg Config, counter Counter, captcha CaptchaVerifier) *Service {
+	return &Service{cfg: cfg, counter: counter, captcha: captcha, now: time.Now}
+}
+
+// Enabled reports whether any guest limit is configured
+func (s *Service) Enabled() bool {
+	return s.cfg.DailyMessages > 0 || s.cfg.IPDailyMessages > 0 || len(s.cfg.Models) > 0 || s.captchaEnabled()
+}
+
+// ModelAllowed reports whether guests may use the model
+func (s *Service) ModelAllowed(modelID string) bool {
+	return len(s.cfg.Models) == 0 || slices.Contains(s.cfg.Models, modelID)
+}
+
+// Admit checks a guest message against the model list, the daily caps and the
+// CAPTCHA escalation, then counts it. It returns a *ModelNotAllowedError, an
+// *ExceededError, ErrCaptchaRequired or ErrCaptchaInvalid when the message is
+// turned away.
+func (s *Service) Admit(ctx context.Context, req Request) error {
+	if !s.ModelAllowed(req.ModelID) {
+		return &ModelNotAllowedError{Model: req.ModelID}
+	}
+
+	now := s.now().UTC()
+	day := now.Format("20060102")
+	resetAt := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, time.UTC)
+	guestKey := keyPrefix + ScopeGuest + ":" + day + ":" + req.GuestID
+	ipKey := keyPrefix + ScopeIP + ":" + day + ":" + networkOf(req.IP)
+
+	guestCount, err := s.counter.Get(ctx, guestKey)
+	if err != nil {
+		return fmt.Errorf("read guest message count: %w", err)
+	}
+	var ipCount int64
+	if req.IP != "" {
+		if ipCount, err = s.counter.Get(ctx, ipKey); err != nil {
+			return fmt.Errorf("read IP message count: %w", err)
+		}
+	}
+	if exceeded := s.exceeded(guestCount, ipCount, resetAt); exceeded != nil {
+		return exceeded
+	}
+
+	if s.captchaEnabled() && (guestCount >= int64(s.cfg.CaptchaAfter) || ipCount >= int64(s.cfg.CaptchaAfter)) {
+		if err := s.checkCaptcha(ctx, req, day, resetAt); err != nil {
+			return err
+		}
+	}
+
+	// Counted after the checks so rejected messages do not use up the allowance;
+	// the new counts settle races between replicas admitting at once
+	if guestCount, err = s.counter.Incr(ctx, guestKey, resetAt); err != nil {
+		return fmt.Errorf("count guest message: %w", err)
+	}
+	if req.IP != "" {
+		if ipCount, err = s.counter.Incr(ctx, ipKey, resetAt); err != nil {
+			return fmt.Errorf("count IP message: %w", err)
+		}
+	}
+	if exceeded := s.exceeded(guestCount-1, ipCount-1, resetAt); exceeded != nil {
+		return exceeded
+	}
+	return nil
+}
+
+// exceeded returns the cap reached by counts of messages already sent, if any
+func (s *Service) exceeded(guestCount, ipCount int64, resetAt time.Time) *ExceededError {
+	if s.cfg.DailyMessages > 0 && guestCount >= int64(s.cfg.DailyMessages) {
+		return &ExceededError{Scope: ScopeGuest, Limit: s.cfg.DailyMessages, ResetAt: resetAt}
+	}
+	if s.cfg.IPDailyMessages > 0 && ipCount >= int64(s.cfg.IPDailyMessages) {
+		return &ExceededError{Scope: ScopeIP, Limit: s.cfg.IPDailyMessages, ResetAt: resetAt}
+	}
+	return nil
+}
+
+// checkCaptcha lets the guest through once it solved a CAPTCHA today, so each
+// guest account solves one a day rather than one per message.
+func (s *Service) checkCaptcha(ctx context.Context, req Request, day string, resetAt time.Time) error {
+	solvedKey := keyPrefix + "captcha:" + day + ":" + req.GuestID
+	solved, err := s.counter.Get(ctx, solvedKey)
+	if err != nil {
+		return fmt.Errorf("read CAPTCHA state: %w", err)
+	}
+	if solved > 0 {
+		return nil
+	}
+
+	token := strings.TrimSpace(req.CaptchaToken)
+	if token == "" {
+		return ErrCaptchaRequired
+	}
+	ok, err := s.captcha.Verify(ctx, token, req.IP)
+	if err != nil {
+		return fmt.Errorf("verify CAPTCHA: %w", err)
+	}
+	if !ok {
+		return ErrCaptchaInvalid
+	}
+	if _, err := s.counter.Incr(ctx, solvedKey, resetAt); err != nil {
+		return fmt.Errorf("record solved CAPTCHA: %w", err)
+	}
+	return nil
+}
+
+func (s *Service) captchaEnabled() bool {
+	return s.captcha != nil && s.cfg.CaptchaAfter > 0
+}
+
+// networkOf returns the IPv4 address, or the /64 network of an IPv6 address,
+// since a single host usually holds a whole IPv6 prefix.
+func networkOf(raw string) string {
+	ip := net.ParseIP(strings.TrimSpace(raw))
+	if ip == nil {
+		return raw
+	}
+	if v4 := ip.To4(); v4 != nil {
+		return v4.String()
+	}
+	return ip.Mask(net.CIDRMask(64, 128)).String() + "/64"
+}
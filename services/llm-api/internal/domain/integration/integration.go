@@ -0,0 +1,100 @@
+// Package integration defines the scopes third-party apps request when they
+// exchange a user's access token for a Jan API token, and the routes each
+// scope opens.
+package integration
+
+import (
+	"net/http"
+	"strings"
+)
+
+// GrantTypeTokenExchange is the OAuth grant type of RFC 8693 token exchange.
+const GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+
+// TokenTypeAccessToken identifies access tokens in token exchange requests and responses.
+const TokenTypeAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
+// Scopes an integration may request. Each must also exist as an optional
+// client scope of the integration's Keycloak client, so Keycloak puts it in
+// the token's scope claim.
+const (
+	ScopeProfileRead        = "jan:profile.read"
+	ScopeModelsRead         = "jan:models.read"
+	ScopeChat               = "jan:chat"
+	ScopeConversationsRead  = "jan:conversations.read"
+	ScopeConversationsWrite = "jan:conversations.write"
+	ScopeProjectsRead       = "jan:projects.read"
+)
+
+// Scopes lists every integration scope.
+var Scopes = []string{
+	ScopeProfileRead,
+	ScopeModelsRead,
+	ScopeChat,
+	ScopeConversationsRead,
+	ScopeConversationsWrite,
+	ScopeProjectsRead,
+}
+
+// IsScope reports whether scope is an integration scope.
+func IsScope(scope string) bool {
+	for _, s := range Scopes {
+		if s == scope {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseScope splits a space-delimited scope parameter, dropping duplicates.
+func ParseScope(raw string) []string {
+	var scopes []string
+	for _, scope := range strings.Fields(raw) {
+		duplicate := false
+		for _, s := range scopes {
+			if s == scope {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
+// routeRule opens the routes under prefix to a scope; an empty method matches
+// every method other than GET.
+type routeRule struct {
+	method string
+	prefix string
+	scope  string
+}
+
+// routeRules are checked in order. Routes no rule matches, such as API key
+// management, settings and admin, stay closed to integrations.
+var routeRules = []routeRule{
+	{http.MethodGet, "/auth/me", ScopeProfileRead},
+	{http.MethodGet, "/v1/models", ScopeModelsRead},
+	{http.MethodPost, "/v1/chat/completions", ScopeChat},
+	{http.MethodGet, "/v1/chat/completions/ws", ScopeChat},
+	{http.MethodGet, "/v1/conversations", ScopeConversationsRead},
+	{"", "/v1/conversations", ScopeConversationsWrite},
+	{http.MethodGet, "/v1/projects", ScopeProjectsRead},
+}
+
+// RequiredScope returns the scope an integration token needs for a route,
+// given as its registered path template, or "" when integrations may not call it.
+func RequiredScope(method, route string) string {
+	route = strings.TrimPrefix(route, "/llm")
+	for _, rule := range routeRules {
+		if route != rule.prefix && !strings.HasPrefix(route, rule.prefix+"/") {
+			continue
+		}
+		if rule.method == "" && method != http.MethodGet || rule.method == method {
+			return rule.scope
+		}
+	}
+	return ""
+}
@@ -0,0 +1,213 @@
+// Package linkpolicy validates and normalizes URLs from citations and tool
+// output before they are persisted into conversation items that clients
+// render as links.
+package linkpolicy
+
+import (
+	"context"
+	"net"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// BlockedLinkText replaces blocked links in free text.
+const BlockedLinkText = "[blocked link]"
+
+const (
+	// maxUnwrapHops bounds nested redirect wrappers such as a Google link to a Facebook link.
+	maxUnwrapHops = 5
+	// maxResolvesPerText bounds the network lookups one text can trigger.
+	maxResolvesPerText = 10
+)
+
+// textLinkPattern matches http(s) links in free text. Closing brackets are
+// excluded so markdown links keep their syntax.
+var textLinkPattern = regexp.MustCompile(`https?://[^\s<>"'\\)\]]+`)
+
+// Blocklist reports hosts known to be malicious.
+type Blocklist interface {
+	// Blocked reports whether host, already lowercased, is blocked.
+	Blocked(host string) bool
+}
+
+// RedirectResolver follows a link through URL shorteners to its destination.
+type RedirectResolver interface {
+	// Resolve returns the destination of rawURL, or rawURL itself when its
+	// host is not a known redirector.
+	Resolve(ctx context.Context, rawURL string) (string, error)
+}
+
+// Config configures a Policy.
+type Config struct {
+	Enabled bool
+}
+
+// Policy normalizes links: only http and https are kept, credentials and
+// default ports are stripped, redirect wrappers are unwrapped and links to
+// blocklisted hosts are rejected. A disabled or nil Policy keeps links as they are.
+type Policy struct {
+	enabled   bool
+	blocklist Blocklist
+	resolver  RedirectResolver
+}
+
+// New creates a link policy. blocklist and resolver may be nil.
+func New(cfg Config, blocklist Blocklist, resolver RedirectResolver) *Policy {
+	return &Policy{
+		enabled:   cfg.Enabled,
+		blocklist: blocklist,
+		resolver:  resolver,
+	}
+}
+
+// SanitizeURL returns the normalized form of rawURL, or false when it must
+// not be rendered as a link.
+func (p *Policy) SanitizeURL(ctx context.Context, rawURL string) (string, bool) {
+	if p == nil || !p.enabled {
+		return rawURL, true
+	}
+	return p.normalize(ctx, rawURL, true)
+}
+
+// SanitizeText normalizes the links in free text, such as tool output, and
+// replaces the rejected ones with BlockedLinkText.
+func (p *Policy) SanitizeText(ctx context.Context, text string) string {
+	if p == nil || !p.enabled || !strings.Contains(text, "://") {
+		return text
+	}
+	resolves := 0
+	return textLinkPattern.ReplaceAllStringFunc(text, func(match string) string {
+		// Sentence punctuation after a link is not part of it
+		link := strings.TrimRight(match, ".,;:!?")
+		trailing := match[len(link):]
+
+		resolve := p.resolver != nil && resolves < maxResolvesPerText
+		if resolve {
+			resolves++
+		}
+		normalized, ok := p.normalize(ctx, link, resolve)
+		if !ok {
+			return BlockedLinkText + trailing
+		}
+		return normalized + trailing
+	})
+}
+
+func (p *Policy) normalize(ctx context.Context, rawURL string, resolve bool) (string, bool) {
+	u, ok := parseWebURL(rawURL)
+	if !ok || p.blocked(u) {
+		return "", false
+	}
+	u = unwrap(u)
+
+	if resolve && p.resolver != nil {
+		resolved, err := p.resolver.Resolve(ctx, u.String())
+		if err != nil {
+			// An unresolved link is still checked against the blocklist
+			log := logger.GetLogger()
+			log.Debug().Err(err).Str("host", u.Hostname()).Msg("failed to resolve redirect")
+		} else if target, ok := parseWebURL(resolved); ok {
+			u = unwrap(target)
+		}
+	}
+
+	if p.blocked(u) {
+		return "", false
+	}
+	return u.String(), true
+}
+
+func (p *Policy) blocked(u *url.URL) bool {
+	return p.blocklist != nil && p.blocklist.Blocked(u.Hostname())
+}
+
+// parseWebURL parses an absolute http(s) URL and normalizes it: the scheme and
+// host are lowercased, and credentials and default ports are dropped.
+func parseWebURL(rawURL string) (*url.URL, bool) {
+	u, err := url.Parse(strings.TrimSpace(rawURL))
+	if err != nil {
+		return nil, false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return nil, false
+	}
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "" {
+		return nil, false
+	}
+
+	u.Scheme = scheme
+	u.User = nil
+	port := u.Port()
+	if (scheme == "http" && port == "80") || (scheme == "https" && port == "443") {
+		port = ""
+	}
+	if port != "" {
+		u.Host = net.JoinHostPort(host, port)
+	} else if strings.Contains(host, ":") {
+		u.Host = "[" + host + "]"
+	} else {
+		u.Host = host
+	}
+	return u, true
+}
+
+// redirectWrapper is a link wrapper that carries its destination in a query parameter.
+type redirectWrapper struct {
+	host   func(string) bool
+	path   string
+	params []string
+}
+
+func hostIs(hosts ...string) func(string) bool {
+	return func(host string) bool {
+		for _, h := range hosts {
+			if host == h {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+var redirectWrappers = []redirectWrapper{
+	{host: hostIs("google.com", "www.google.com"), path: "/url", params: []string{"q", "url"}},
+	{host: hostIs("l.facebook.com", "lm.facebook.com"), path: "/l.php", params: []string{"u"}},
+	{host: hostIs("l.instagram.com"), path: "/", params: []string{"u"}},
+	{host: hostIs("www.youtube.com", "youtube.com"), path: "/redirect", params: []string{"q"}},
+	{host: hostIs("duckduckgo.com"), path: "/l/", params: []string{"uddg"}},
+	{host: func(host string) bool { return strings.HasSuffix(host, ".safelinks.protection.outlook.com") }, path: "/", params: []string{"url"}},
+}
+
+// unwrap replaces known redirect wrappers with the link they point to, without
+// any network lookup.
+func unwrap(u *url.URL) *url.URL {
+	for hop := 0; hop < maxUnwrapHops; hop++ {
+		target, ok := unwrapOnce(u)
+		if !ok {
+			return u
+		}
+		u = target
+	}
+	return u
+}
+
+func unwrapOnce(u *url.URL) (*url.URL, bool) {
+	host := u.Hostname()
+	for _, wrapper := range redirectWrappers {
+		if !wrapper.host(host) || u.Path != wrapper.path {
+			continue
+		}
+		query := u.Query()
+		for _, param := range wrapper.params {
+			if target, ok := parseWebURL(query.Get(param)); ok {
+				return target, true
+			}
+		}
+	}
+	return nil, false
+}
@@ -0,0 +1,78 @@
+package mcpcredential
+
+import (
+	"context"
+	"time"
+)
+
+// Provider identifies the third-party service a credential authenticates against.
+type Provider string
+
+const (
+	ProviderGitHub    Provider = "github"
+	ProviderGoogle    Provider = "google"    // Gmail and Google Calendar
+	ProviderMicrosoft Provider = "microsoft" // Outlook mail and calendar via Microsoft Graph
+	ProviderCalDAV    Provider = "caldav"    // Any CalDAV server, with an app password
+)
+
+// SupportedProviders lists the providers first-party MCP tools can use.
+var SupportedProviders = []Provider{ProviderGitHub, ProviderGoogle, ProviderMicrosoft, ProviderCalDAV}
+
+// Consent scopes for the personal data connectors. Unlike GitHub, where scopes
+// echo the token's own grants, these record what the user agreed to let MCP
+// tools read; a tool refuses to run without its scope.
+const (
+	ScopeCalendarRead = "calendar:read"
+	ScopeMailRead     = "mail:read"
+)
+
+// consentScopes lists the consent scopes each connector provider accepts.
+// Providers not listed here take free-form scopes.
+var consentScopes = map[Provider][]string{
+	ProviderGoogle:    {ScopeCalendarRead, ScopeMailRead},
+	ProviderMicrosoft: {ScopeCalendarRead, ScopeMailRead},
+	ProviderCalDAV:    {ScopeCalendarRead},
+}
+
+// Limits on credential fields
+const (
+	MaxTokenLength    = 4096
+	MaxAccountLength  = 255
+	MaxEndpointLength = 2048
+	MaxScopes         = 50
+)
+
+// Credential is a user's OAuth token for a third-party service, used by MCP tools
+// to act on the user's behalf. The token is stored encrypted.
+type Credential struct {
+	ID             uint
+	PublicID       string
+	UserID         uint
+	Provider       Provider
+	EncryptedToken string
+	Account        *string // Login of the connected account, for display
+	Scopes         []string
+	Endpoint       *string // Server URL for self-hosted providers (CalDAV calendar collection)
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Expired reports whether the token is past its expiry time.
+func (c *Credential) Expired(now time.Time) bool {
+	return c.ExpiresAt != nil && !c.ExpiresAt.After(now)
+}
+
+// Repository defines data access for MCP credentials
+type Repository interface {
+	Upsert(ctx context.Context, credential *Credential) error
+	Delete(ctx context.Context, id uint) error
+	FindByUserAndProvider(ctx context.Context, userID uint, provider Provider) (*Credential, error)
+	FindByUser(ctx context.Context, userID uint) ([]*Credential, error)
+	TouchLastUsed(ctx context.Context, id uint, at time.Time) error
+	// FindAfter returns up to limit credentials with IDs above afterID, in ID order
+	FindAfter(ctx context.Context, afterID uint, limit int) ([]*Credential, error)
+	// ReplaceEncryptedToken swaps the stored token only if it still equals current
+	ReplaceEncryptedToken(ctx context.Context, id uint, current, replacement string) (bool, error)
+}
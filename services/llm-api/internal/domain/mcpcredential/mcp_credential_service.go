@@ -0,0 +1,238 @@
+package mcpcredential
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"jan-server/services/llm-api/internal/utils/crypto"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// IDPrefix prefixes credential public IDs
+const IDPrefix = "mcpc"
+
+// reencryptBatchSize is how many credentials ReencryptTokens loads at a time
+const reencryptBatchSize = 200
+
+// Config configures the Service
+type Config struct {
+	Keyring *crypto.Keyring // Encrypts stored tokens
+}
+
+// Service manages the per-user credential store used by first-party MCP tools
+type Service struct {
+	repo    Repository
+	keyring *crypto.Keyring
+}
+
+// NewService creates a new MCP credential service
+func NewService(repo Repository, cfg Config) *Service {
+	return &Service{
+		repo:    repo,
+		keyring: cfg.Keyring,
+	}
+}
+
+// Input holds a token to store for a provider
+type Input struct {
+	Token     string
+	Account   *string
+	Scopes    []string
+	Endpoint  *string
+	ExpiresAt *time.Time
+}
+
+// ParseProvider validates a provider name
+func ParseProvider(ctx context.Context, value string) (Provider, error) {
+	provider := Provider(strings.ToLower(strings.TrimSpace(value)))
+	for _, supported := range SupportedProviders {
+		if provider == supported {
+			return provider, nil
+		}
+	}
+	return "", platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+		fmt.Sprintf("unsupported credential provider %q", value), nil, "5f6f9b0e-4a8b-4a55-9d0c-2c2f1f0e7b31")
+}
+
+// Save stores or replaces the user's token for a provider
+func (s *Service) Save(ctx context.Context, userID uint, provider Provider, input Input) (*Credential, error) {
+	invalid := func(msg, uuid string) error {
+		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, msg, nil, uuid)
+	}
+	token := strings.TrimSpace(input.Token)
+	if token == "" || len(token) > MaxTokenLength {
+		return nil, invalid(fmt.Sprintf("token is required and must be at most %d characters", MaxTokenLength), "b4a1c0de-3f25-4d7a-9f0b-6e1f0c2d8a47")
+	}
+	account := trimmedOrNil(input.Account)
+	if account != nil && len(*account) > MaxAccountLength {
+		return nil, invalid(fmt.Sprintf("account must be at most %d characters", MaxAccountLength), "e3d6b2a9-71c4-4f0e-8a5d-4b9c2f7e1d60")
+	}
+	if len(input.Scopes) > MaxScopes {
+		return nil, invalid(fmt.Sprintf("at most %d scopes are allowed", MaxScopes), "1c7e9f42-8b3d-4e6a-a2f5-9d0b7c3e5a18")
+	}
+	if allowed, ok := consentScopes[provider]; ok {
+		if len(input.Scopes) == 0 {
+			return nil, invalid(fmt.Sprintf("%s requires explicit consent scopes: %s", provider, strings.Join(allowed, ", ")), "0b5e7d2c-9a41-4f3e-8c6d-1e2f7a9b4c05")
+		}
+		for _, scope := range input.Scopes {
+			if !slices.Contains(allowed, scope) {
+				return nil, invalid(fmt.Sprintf("scope %q is not allowed for %s; allowed: %s", scope, provider, strings.Join(allowed, ", ")), "5c8a1f3e-7d2b-4e9a-b6c0-3f4d8e1a2b97")
+			}
+		}
+	}
+	endpoint := trimmedOrNil(input.Endpoint)
+	if provider == ProviderCalDAV && endpoint == nil {
+		return nil, invalid("endpoint is required for caldav: the URL of the calendar collection", "a7d3e9b1-4c6f-4a2e-9b8d-0e5f1c7a3d26")
+	}
+	if endpoint != nil {
+		parsed, err := url.Parse(*endpoint)
+		if err != nil || parsed.Scheme != "https" || parsed.Host == "" || len(*endpoint) > MaxEndpointLength {
+			return nil, invalid(fmt.Sprintf("endpoint must be an https URL of at most %d characters", MaxEndpointLength), "e1f4b8c2-6a3d-4d7e-a9c5-2b0e8f6d1a43")
+		}
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, invalid("expires_at must be in the future", "7a2d4e8f-5c1b-4b9e-b6a3-0f8e2d1c9b74")
+	}
+
+	encrypted, err := s.encrypt(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	credential := &Credential{
+		UserID:         userID,
+		Provider:       provider,
+		EncryptedToken: encrypted,
+		Account:        account,
+		Scopes:         input.Scopes,
+		Endpoint:       endpoint,
+		ExpiresAt:      input.ExpiresAt,
+	}
+	existing, err := s.repo.FindByUserAndProvider(ctx, userID, provider)
+	switch {
+	case err == nil:
+		credential.ID = existing.ID
+		credential.PublicID = existing.PublicID
+		credential.CreatedAt = existing.CreatedAt
+	case platformerrors.IsErrorType(err, platformerrors.ErrorTypeNotFound):
+		publicID, err := idgen.GenerateSecureID(IDPrefix, 16)
+		if err != nil {
+			return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate credential id")
+		}
+		credential.PublicID = publicID
+	default:
+		return nil, err
+	}
+
+	if err := s.repo.Upsert(ctx, credential); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to save credential")
+	}
+	return credential, nil
+}
+
+// List returns the user's stored credentials. Tokens stay encrypted.
+func (s *Service) List(ctx context.Context, userID uint) ([]*Credential, error) {
+	return s.repo.FindByUser(ctx, userID)
+}
+
+// Delete removes the user's credential for a provider
+func (s *Service) Delete(ctx context.Context, userID uint, provider Provider) error {
+	credential, err := s.repo.FindByUserAndProvider(ctx, userID, provider)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, credential.ID)
+}
+
+// Token returns the user's decrypted token for a provider. It fails with NotFound
+// when the user has not connected the provider or the token has expired.
+func (s *Service) Token(ctx context.Context, userID uint, provider Provider) (string, *Credential, error) {
+	credential, err := s.repo.FindByUserAndProvider(ctx, userID, provider)
+	if err != nil {
+		if platformerrors.IsErrorType(err, platformerrors.ErrorTypeNotFound) {
+			return "", nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+				fmt.Sprintf("no %s credential connected", provider), err, "c2b8e1f7-9d4a-4c3e-8f6b-5a0d7e2c1b93")
+		}
+		return "", nil, err
+	}
+	now := time.Now()
+	if credential.Expired(now) {
+		return "", nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+			fmt.Sprintf("%s credential has expired; reconnect it", provider), nil, "94e0d3b6-2f7c-4a1e-b5d8-6c3a9f0e7d12")
+	}
+
+	token, err := s.keyring.Decrypt(credential.EncryptedToken)
+	if err != nil {
+		return "", nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to decrypt credential")
+	}
+	// Best effort: last-used tracking must not fail the tool call.
+	_ = s.repo.TouchLastUsed(ctx, credential.ID, now)
+	return token, credential, nil
+}
+
+func (s *Service) encrypt(ctx context.Context, token string) (string, error) {
+	if !s.keyring.Configured() {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeInternal,
+			"MCP credential secret not configured", nil, "3e9a7c1d-6b2f-4d8e-a0c5-8f1b4e7d2a69")
+	}
+	encrypted, err := s.keyring.Encrypt(token)
+	if err != nil {
+		return "", platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to encrypt credential")
+	}
+	return encrypted, nil
+}
+
+// ReencryptTokens moves every stored token onto the current master key and
+// returns how many were rewritten. Tokens that fail to re-encrypt are skipped
+// and reported together in the error.
+func (s *Service) ReencryptTokens(ctx context.Context) (int, error) {
+	rotated := 0
+	var errs []error
+	var afterID uint
+	for {
+		batch, err := s.repo.FindAfter(ctx, afterID, reencryptBatchSize)
+		if err != nil {
+			return rotated, err
+		}
+		for _, credential := range batch {
+			afterID = credential.ID
+			if s.keyring.IsCurrent(credential.EncryptedToken) {
+				continue
+			}
+			replacement, err := s.keyring.Rotate(credential.EncryptedToken)
+			if err != nil {
+				errs = append(errs, fmt.Errorf("credential %s: %w", credential.PublicID, err))
+				continue
+			}
+			replaced, err := s.repo.ReplaceEncryptedToken(ctx, credential.ID, credential.EncryptedToken, replacement)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			if replaced {
+				rotated++
+			}
+		}
+		if len(batch) < reencryptBatchSize {
+			return rotated, errors.Join(errs...)
+		}
+	}
+}
+
+// trimmedOrNil trims value and returns nil when it is empty
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -55,12 +55,24 @@ type MCPToolRepository interface {
 
 // Tool keys (must match serper_mcp.go registrations)
 const (
-	ToolKeyGoogleSearch    = "google_search"
-	ToolKeyScrape          = "scrape"
-	ToolKeyFileSearchIndex = "file_search_index"
-	ToolKeyFileSearchQuery = "file_search_query"
-	ToolKeyPythonExec      = "python_exec"
-	ToolKeyMemoryRetrieve  = "memory_retrieve"
+	ToolKeyGoogleSearch       = "google_search"
+	ToolKeyScrape             = "scrape"
+	ToolKeyFileSearchIndex    = "file_search_index"
+	ToolKeyFileSearchQuery    = "file_search_query"
+	ToolKeyPythonExec         = "python_exec"
+	ToolKeyMemoryRetrieve     = "memory_retrieve"
+	ToolKeyCalculate          = "calculate"
+	ToolKeyConvertUnits       = "convert_units"
+	ToolKeyGetWeather         = "get_weather"
+	ToolKeyGetWorldTime       = "get_world_time"
+	ToolKeyListHolidays       = "list_holidays"
+	ToolKeyRenderPage         = "render_page"
+	ToolKeySearchCode         = "search_code"
+	ToolKeyReadFile           = "read_file"
+	ToolKeyListIssues         = "list_issues"
+	ToolKeyCreateIssueComment = "create_issue_comment"
+	ToolKeyListCalendarEvents = "list_calendar_events"
+	ToolKeySearchEmails       = "search_emails"
 )
 
 // Categories
@@ -70,4 +82,8 @@ const (
 	CategoryFileSearch    = "file_search"
 	CategoryCodeExecution = "code_execution"
 	CategoryMemory        = "memory"
+	CategoryMath          = "math"
+	CategoryWorldInfo     = "world_info"
+	CategoryGitHub        = "github"
+	CategoryConnectors    = "connectors"
 )
@@ -0,0 +1,85 @@
+package memorybackfill
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/query"
+)
+
+// Status is the lifecycle state of a backfill
+type Status string
+
+const (
+	StatusQueued    Status = "queued"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+	StatusCancelled Status = "cancelled"
+)
+
+// Backfill replays historical conversations through memory observation, so users who
+// enable memory later still get memories from their existing chat history. It walks
+// conversations in ID order and records the last one replayed, so an interrupted or
+// failed backfill resumes where it stopped.
+type Backfill struct {
+	ID                   uint
+	PublicID             string
+	Status               Status
+	UserID               *uint     // Only this user's conversations; every user when nil
+	CreatedBefore        time.Time // Only conversations created before the backfill; newer ones are observed live
+	Cursor               uint      // ID of the last conversation replayed
+	ConversationsDone    int
+	ConversationsSkipped int // Empty, private or owned by users with memory observation off
+	ConversationsFailed  int
+	Error                string
+	CreatedBy            *string
+	StartedAt            *time.Time
+	CompletedAt          *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// Message is one chat turn sent to memory observation
+type Message struct {
+	ItemID    string // Public ID of the conversation item; memory-tools observes each item once
+	Role      string
+	Content   string
+	CreatedAt time.Time
+}
+
+// ConversationFilter selects the conversations of a backfill
+type ConversationFilter struct {
+	UserID        *uint
+	CreatedBefore time.Time
+	AfterID       uint // Keyset cursor; only conversations with a greater ID are returned
+	Limit         int
+}
+
+// Observer sends a conversation to memory-tools for memory extraction
+type Observer interface {
+	// Available reports whether the memory integration is enabled
+	Available() bool
+	Observe(ctx context.Context, conv *conversation.Conversation, messages []Message) error
+}
+
+// Repository defines data access for backfills and the conversations they replay
+type Repository interface {
+	CreateBackfill(ctx context.Context, backfill *Backfill) error
+	FindBackfillByPublicID(ctx context.Context, publicID string) (*Backfill, error)
+	ListBackfills(ctx context.Context, p *query.Pagination) ([]*Backfill, int64, error)
+	// ClaimBackfill marks the oldest queued backfill, or a running backfill not updated
+	// since staleBefore, as running and returns it; it returns nil when there is nothing to claim
+	ClaimBackfill(ctx context.Context, staleBefore time.Time) (*Backfill, error)
+	UpdateBackfill(ctx context.Context, backfill *Backfill) error
+	// SaveProgress stores the cursor and counters of a running backfill; it returns
+	// false when the backfill is no longer running, e.g. because it was cancelled
+	SaveProgress(ctx context.Context, backfill *Backfill) (bool, error)
+
+	// FindConversations returns live conversations matching the filter, ordered by ID
+	FindConversations(ctx context.Context, filter ConversationFilter) ([]*conversation.Conversation, error)
+	// FindBranchMessages returns the last limit message items of a conversation branch,
+	// in sequence order
+	FindBranchMessages(ctx context.Context, conv *conversation.Conversation, limit int) ([]*conversation.Item, error)
+}
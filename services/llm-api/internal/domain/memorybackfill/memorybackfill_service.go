@@ -0,0 +1,139 @@
+package memorybackfill
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/domain/usersettings"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// Config configures the Service
+type Config struct {
+	WorkerEnabled bool          // Execute queued backfills in this process
+	Interval      time.Duration // Pause between conversations sent to memory-tools
+	MaxMessages   int           // Latest messages of a conversation sent per observation
+}
+
+// Service manages memory backfills and executes queued ones
+type Service struct {
+	repo          Repository
+	observer      Observer
+	settings      *usersettings.Service
+	workerEnabled bool
+	interval      time.Duration
+	maxMessages   int
+
+	// processing guards ProcessQueued so one instance drains the queue at a time
+	processing sync.Mutex
+}
+
+// NewService creates a new memory backfill service
+func NewService(repo Repository, observer Observer, settings *usersettings.Service, cfg Config) *Service {
+	maxMessages := cfg.MaxMessages
+	if maxMessages < 1 {
+		maxMessages = 1
+	}
+	return &Service{
+		repo:          repo,
+		observer:      observer,
+		settings:      settings,
+		workerEnabled: cfg.WorkerEnabled,
+		interval:      cfg.Interval,
+		maxMessages:   maxMessages,
+	}
+}
+
+// CreateBackfillInput describes a backfill request
+type CreateBackfillInput struct {
+	UserID *uint // Only this user's conversations; every user when nil
+}
+
+// CreateBackfill queues a backfill of the conversations created so far and wakes the worker
+func (s *Service) CreateBackfill(ctx context.Context, input CreateBackfillInput, createdBy *string) (*Backfill, error) {
+	if !s.observer.Available() {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"memory integration is disabled: set MEMORY_ENABLED and make memory-tools reachable", nil, "5b0e7d1c-8a3f-4c62-9e4d-1f7a2b6c8d90")
+	}
+
+	publicID, err := idgen.GenerateSecureID("mbf", 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate backfill id")
+	}
+
+	backfill := &Backfill{
+		PublicID:      publicID,
+		Status:        StatusQueued,
+		UserID:        input.UserID,
+		CreatedBefore: time.Now().UTC(),
+		CreatedBy:     createdBy,
+	}
+	if err := s.repo.CreateBackfill(ctx, backfill); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create backfill")
+	}
+
+	s.Wake()
+	return backfill, nil
+}
+
+// GetBackfill returns a backfill by its public ID
+func (s *Service) GetBackfill(ctx context.Context, publicID string) (*Backfill, error) {
+	return s.repo.FindBackfillByPublicID(ctx, publicID)
+}
+
+// ListBackfills returns backfills, newest first
+func (s *Service) ListBackfills(ctx context.Context, p *query.Pagination) ([]*Backfill, int64, error) {
+	return s.repo.ListBackfills(ctx, p)
+}
+
+// CancelBackfill stops a queued or running backfill after the conversation in flight
+func (s *Service) CancelBackfill(ctx context.Context, publicID string) (*Backfill, error) {
+	backfill, err := s.repo.FindBackfillByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if backfill.Status != StatusQueued && backfill.Status != StatusRunning {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			fmt.Sprintf("backfill is %s", backfill.Status), nil, "c3a9f2e1-6b4d-4e8a-a7f0-2d5c1b9e3f48")
+	}
+
+	now := time.Now().UTC()
+	backfill.Status = StatusCancelled
+	backfill.CompletedAt = &now
+	if err := s.repo.UpdateBackfill(ctx, backfill); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to cancel backfill")
+	}
+	return backfill, nil
+}
+
+// ResumeBackfill queues a failed or cancelled backfill again; it continues after the
+// last conversation it replayed
+func (s *Service) ResumeBackfill(ctx context.Context, publicID string) (*Backfill, error) {
+	if !s.observer.Available() {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"memory integration is disabled: set MEMORY_ENABLED and make memory-tools reachable", nil, "8e2d4b6a-1c7f-4a93-b5e0-7f3c9a1d2e64")
+	}
+
+	backfill, err := s.repo.FindBackfillByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if backfill.Status != StatusFailed && backfill.Status != StatusCancelled {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			fmt.Sprintf("backfill is %s", backfill.Status), nil, "f61b8c2d-3e9a-4d05-8b7c-4a2e6d1f9c37")
+	}
+
+	backfill.Status = StatusQueued
+	backfill.Error = ""
+	backfill.CompletedAt = nil
+	if err := s.repo.UpdateBackfill(ctx, backfill); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to resume backfill")
+	}
+
+	s.Wake()
+	return backfill, nil
+}
@@ -0,0 +1,264 @@
+package memorybackfill
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// BackfillStaleAfter is how long a running backfill may go without progress before
+// another worker reclaims it; the runner saves progress after every conversation
+const BackfillStaleAfter = 15 * time.Minute
+
+const (
+	// conversationBatchSize is how many conversations are loaded per query
+	conversationBatchSize = 100
+	// observeAttempts is how often a conversation is sent before it counts as failed
+	observeAttempts = 3
+	// retryBackoff is the pause before the second attempt; it doubles after each failure
+	retryBackoff = 10 * time.Second
+	// maxConsecutiveFailures fails the backfill when memory-tools keeps failing, rather
+	// than counting the rest of the history as failed
+	maxConsecutiveFailures = 5
+)
+
+// Wake starts draining the backfill queue in the background when the worker is enabled
+func (s *Service) Wake() {
+	if !s.workerEnabled || !s.observer.Available() {
+		return
+	}
+	go s.ProcessQueued(context.Background())
+}
+
+// ProcessQueued claims and executes queued backfills until the queue is empty. Backfills
+// are claimed with row locks, so several instances may drain the same queue safely.
+// Calls made while this instance is already draining return immediately.
+func (s *Service) ProcessQueued(ctx context.Context) {
+	if !s.processing.TryLock() {
+		return
+	}
+	defer s.processing.Unlock()
+
+	log := logger.GetLogger()
+	for ctx.Err() == nil {
+		backfill, err := s.repo.ClaimBackfill(ctx, time.Now().UTC().Add(-BackfillStaleAfter))
+		if err != nil {
+			log.Error().Err(err).Msg("failed to claim memory backfill")
+			return
+		}
+		if backfill == nil {
+			return
+		}
+		s.executeBackfill(ctx, backfill)
+	}
+}
+
+// executeBackfill replays the conversations after the backfill's cursor, one at a time
+// and at most one per interval
+func (s *Service) executeBackfill(ctx context.Context, backfill *Backfill) {
+	log := logger.GetLogger().With().Str("memory_backfill", backfill.PublicID).Logger()
+	log.Info().Uint("cursor", backfill.Cursor).Msg("starting memory backfill")
+
+	var (
+		observeEnabled = make(map[uint]bool)
+		streak         int  // Consecutive failed conversations
+		streakCursor   uint // Cursor before the streak began
+	)
+	filter := ConversationFilter{
+		UserID:        backfill.UserID,
+		CreatedBefore: backfill.CreatedBefore,
+		AfterID:       backfill.Cursor,
+		Limit:         conversationBatchSize,
+	}
+	for {
+		convs, err := s.repo.FindConversations(ctx, filter)
+		if err != nil {
+			s.failBackfill(ctx, backfill, "failed to load conversations: "+err.Error())
+			return
+		}
+
+		for _, conv := range convs {
+			replayed, err := s.replayConversation(ctx, conv, observeEnabled)
+			if ctx.Err() != nil {
+				// Left as running; the backfill is reclaimed once it goes stale
+				log.Warn().Msg("memory backfill interrupted")
+				return
+			}
+			switch {
+			case err != nil:
+				log.Warn().Err(err).Str("conversation_id", conv.PublicID).Msg("failed to backfill conversation")
+				if streak == 0 {
+					streakCursor = backfill.Cursor
+				}
+				streak++
+				backfill.ConversationsFailed++
+			case replayed:
+				streak = 0
+				backfill.ConversationsDone++
+			default:
+				streak = 0
+				backfill.ConversationsSkipped++
+			}
+			backfill.Cursor = conv.ID
+
+			if streak >= maxConsecutiveFailures {
+				// Resuming retries the whole streak
+				backfill.Cursor = streakCursor
+				backfill.ConversationsFailed -= streak
+				s.failBackfill(ctx, backfill, fmt.Sprintf("memory-tools failed %d conversations in a row: %v", streak, err))
+				return
+			}
+
+			running, err := s.repo.SaveProgress(ctx, backfill)
+			if err != nil {
+				log.Warn().Err(err).Msg("failed to save memory backfill progress")
+			}
+			if err == nil && !running {
+				log.Info().Msg("memory backfill cancelled")
+				return
+			}
+		}
+
+		if len(convs) < conversationBatchSize {
+			break
+		}
+		filter.AfterID = convs[len(convs)-1].ID
+	}
+
+	now := time.Now().UTC()
+	backfill.Status = StatusCompleted
+	backfill.CompletedAt = &now
+	if err := s.repo.UpdateBackfill(ctx, backfill); err != nil {
+		log.Error().Err(err).Msg("failed to complete memory backfill")
+		return
+	}
+	log.Info().
+		Int("done", backfill.ConversationsDone).
+		Int("skipped", backfill.ConversationsSkipped).
+		Int("failed", backfill.ConversationsFailed).
+		Msg("completed memory backfill")
+}
+
+// replayConversation sends the latest messages of a conversation's active branch to
+// memory observation. It returns false without observing when the conversation is
+// private, has no messages, or its owner turned memory observation off.
+func (s *Service) replayConversation(ctx context.Context, conv *conversation.Conversation, observeEnabled map[uint]bool) (bool, error) {
+	if conv.IsPrivate {
+		return false, nil
+	}
+
+	enabled, ok := observeEnabled[conv.UserID]
+	if !ok {
+		settings, err := s.settings.GetOrCreateSettings(ctx, conv.UserID)
+		if err != nil {
+			return false, fmt.Errorf("load user settings: %w", err)
+		}
+		enabled = settings.MemoryConfig.Enabled && settings.MemoryConfig.ObserveEnabled
+		observeEnabled[conv.UserID] = enabled
+	}
+	if !enabled {
+		return false, nil
+	}
+
+	items, err := s.repo.FindBranchMessages(ctx, conv, s.maxMessages)
+	if err != nil {
+		return false, fmt.Errorf("load messages: %w", err)
+	}
+	messages := make([]Message, 0, len(items))
+	for _, item := range items {
+		role := messageRole(item)
+		text := itemText(item)
+		if role == "" || text == "" {
+			continue
+		}
+		messages = append(messages, Message{ItemID: item.PublicID, Role: role, Content: text, CreatedAt: item.CreatedAt})
+	}
+	if len(messages) == 0 {
+		return false, nil
+	}
+
+	backoff := retryBackoff
+	for attempt := 1; ; attempt++ {
+		if err := s.wait(ctx, s.interval); err != nil {
+			return false, err
+		}
+		err = s.observer.Observe(ctx, conv, messages)
+		if err == nil {
+			return true, nil
+		}
+		if attempt == observeAttempts {
+			return false, err
+		}
+		if err := s.wait(ctx, backoff); err != nil {
+			return false, err
+		}
+		backoff *= 2
+	}
+}
+
+// wait pauses for d or until ctx is done
+func (s *Service) wait(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// messageRole maps an item role to an observed role; system, tool and critic turns are dropped
+func messageRole(item *conversation.Item) string {
+	if item.Role == nil {
+		return ""
+	}
+	switch *item.Role {
+	case conversation.ItemRoleUser:
+		return "user"
+	case conversation.ItemRoleAssistant:
+		return "assistant"
+	}
+	return ""
+}
+
+// itemText joins the visible text parts of an item, skipping reasoning and tool content
+func itemText(item *conversation.Item) string {
+	parts := make([]string, 0, len(item.Content))
+	for _, c := range item.Content {
+		switch c.Type {
+		case "text", "input_text", "output_text":
+		default:
+			continue
+		}
+		switch {
+		case c.TextString != nil && *c.TextString != "":
+			parts = append(parts, *c.TextString)
+		case c.Text != nil && c.Text.Text != "":
+			parts = append(parts, c.Text.Text)
+		case c.OutputText != nil && c.OutputText.Text != "":
+			parts = append(parts, c.OutputText.Text)
+		}
+	}
+	return strings.TrimSpace(strings.Join(parts, "\n"))
+}
+
+func (s *Service) failBackfill(ctx context.Context, backfill *Backfill, message string) {
+	log := logger.GetLogger()
+	log.Error().Str("memory_backfill", backfill.PublicID).Msg(message)
+
+	now := time.Now().UTC()
+	backfill.Status = StatusFailed
+	backfill.Error = message
+	backfill.CompletedAt = &now
+	if err := s.repo.UpdateBackfill(ctx, backfill); err != nil {
+		log.Error().Err(err).Str("memory_backfill", backfill.PublicID).Msg("failed to mark memory backfill as failed")
+	}
+}
@@ -12,14 +12,18 @@ import (
 // Endpoint represents a single backend URL for a provider.
 type Endpoint struct {
 	URL string `json:"url"`
-	// Weight is reserved for future weighted round-robin routing.
-	// Currently ignored by routers; all endpoints are treated equally.
+	// Weight is the endpoint's relative share of traffic. Only the weighted
+	// router used with VLLM_ROUTING_CONTROLLER_ENABLED honors it; round-robin
+	// treats all endpoints equally.
 	Weight int `json:"weight,omitempty"`
 	// Healthy indicates endpoint availability. Read-only: set by health checker only.
 	Healthy bool `json:"healthy,omitempty"`
 	// Priority is reserved for future priority-based routing (lower = higher priority).
 	// Currently ignored by routers.
 	Priority int `json:"priority,omitempty"`
+	// Region labels where the endpoint runs, e.g. "eu-west". Empty inherits the
+	// provider's region metadata.
+	Region string `json:"region,omitempty"`
 }
 
 // EndpointList manages multiple endpoints for a provider.
@@ -56,6 +60,7 @@ func parseEndpointsJSON(input string) (EndpointList, error) {
 			continue
 		}
 		ep.URL = normalized
+		ep.Region = NormalizeRegion(ep.Region)
 		if ep.Weight <= 0 {
 			ep.Weight = 1
 		}
@@ -124,6 +129,23 @@ func (el EndpointList) GetHealthy() EndpointList {
 	return healthy
 }
 
+// InRegion returns the healthy endpoints in region.
+func (el EndpointList) InRegion(region string) EndpointList {
+	var result EndpointList
+	for _, ep := range el {
+		if ep.Healthy && ep.Region == region {
+			result = append(result, ep)
+		}
+	}
+	return result
+}
+
+// NormalizeRegion lower-cases and trims a region label so labels compare equal
+// however they were entered.
+func NormalizeRegion(region string) string {
+	return strings.ToLower(strings.TrimSpace(region))
+}
+
 // URLs returns the URL strings from the list.
 func (el EndpointList) URLs() []string {
 	urls := make([]string, len(el))
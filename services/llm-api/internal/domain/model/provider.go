@@ -70,6 +70,8 @@ const (
 	MetadataKeyAutoEnableModels = "auto_enable_new_models" // "true" to auto-enable new models
 	MetadataKeyToolSupport      = "tool_support"           // "true" if provider supports tools/tool_choice
 	MetadataKeyImageEditPath    = "image_edit_path"        // optional path or full URL override for image edits
+	MetadataKeyInferenceEngine  = "inference_engine"       // e.g., "vllm"; vLLM endpoints have their load scraped
+	MetadataKeyRegion           = "region"                 // e.g., "eu-west"; region of endpoints that set none
 )
 
 // ImageInputCapability describes how a provider supports image input
@@ -201,6 +203,30 @@ func (p *Provider) GetEndpoints() EndpointList {
 	return nil
 }
 
+// Region returns the provider's region label, or "" when it has none.
+func (p *Provider) Region() string {
+	if p.Metadata == nil {
+		return ""
+	}
+	return NormalizeRegion(p.Metadata[MetadataKeyRegion])
+}
+
+// RegionalEndpoints returns GetEndpoints with each endpoint's region resolved,
+// falling back to the provider's region.
+func (p *Provider) RegionalEndpoints() EndpointList {
+	endpoints := p.GetEndpoints()
+	providerRegion := p.Region()
+	result := make(EndpointList, len(endpoints))
+	for i, ep := range endpoints {
+		ep.Region = NormalizeRegion(ep.Region)
+		if ep.Region == "" {
+			ep.Region = providerRegion
+		}
+		result[i] = ep
+	}
+	return result
+}
+
 // SetEndpoints updates endpoints and keeps BaseURL in sync (first endpoint).
 func (p *Provider) SetEndpoints(endpoints EndpointList) {
 	p.Endpoints = endpoints
@@ -242,4 +268,6 @@ type ProviderRepository interface {
 	FindByFilter(ctx context.Context, filter ProviderFilter, p *query.Pagination) ([]*Provider, error)
 	Count(ctx context.Context, filter ProviderFilter) (int64, error)
 	FindByIDs(ctx context.Context, ids []uint) ([]*Provider, error)
+	// ReplaceEncryptedAPIKey swaps the stored key only if it still equals current
+	ReplaceEncryptedAPIKey(ctx context.Context, id uint, current, replacement string) (bool, error)
 }
@@ -57,6 +57,34 @@ type ReasoningConfig struct {
 	ModeDisplay     []ReasoningModeOption `json:"mode_display,omitempty"`     // UI display options
 }
 
+// ReasoningContentPolicy controls what happens to the reasoning content a model returns.
+type ReasoningContentPolicy string
+
+const (
+	ReasoningContentStore  ReasoningContentPolicy = "store"  // Returned to the client, stored when the request sets store_reasoning (default)
+	ReasoningContentStream ReasoningContentPolicy = "stream" // Returned to the client, never stored
+	ReasoningContentStrip  ReasoningContentPolicy = "strip"  // Neither returned nor stored
+)
+
+// ReasoningPolicy is the operator's reasoning configuration for a model. Unlike
+// ReasoningConfig it is not refreshed from provider metadata.
+type ReasoningPolicy struct {
+	BudgetTokens *int                   `json:"budget_tokens,omitempty"` // Thinking-token budget per completion
+	Content      ReasoningContentPolicy `json:"content,omitempty"`       // Empty means store
+}
+
+// Validate checks the budget and content policy values
+func (p *ReasoningPolicy) Validate() error {
+	if p.BudgetTokens != nil && *p.BudgetTokens <= 0 {
+		return &ValidationError{Field: "budget_tokens", Message: "budget_tokens must be positive"}
+	}
+	switch p.Content {
+	case "", ReasoningContentStore, ReasoningContentStream, ReasoningContentStrip:
+		return nil
+	}
+	return &ValidationError{Field: "content", Message: "content must be one of store, stream, strip"}
+}
+
 // ProviderModel describes a specific model under a provider.
 type ProviderModel struct {
 	ID                      uint             `json:"id"`
@@ -76,6 +104,7 @@ type ProviderModel struct {
 	SupportsThinkingMode    bool             `json:"supports_thinking_mode"`
 	DefaultConversationMode string           `json:"default_conversation_mode,omitempty"`
 	ReasoningConfig         *ReasoningConfig `json:"reasoning_config,omitempty"`
+	ReasoningPolicy         *ReasoningPolicy `json:"reasoning_policy,omitempty"`
 	ProviderFlags           map[string]any   `json:"provider_flags,omitempty"`
 	Active                  bool             `json:"active"`
 	InstructModelID         *uint            `json:"instruct_model_id,omitempty"` // Self-referencing FK to the instruct model variant (used when enable_thinking=false)
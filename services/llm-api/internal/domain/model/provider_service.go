@@ -12,7 +12,6 @@ import (
 	"jan-server/services/llm-api/internal/config"
 	"jan-server/services/llm-api/internal/domain/query"
 	"jan-server/services/llm-api/internal/infrastructure/logger"
-	"jan-server/services/llm-api/internal/utils/crypto"
 	"jan-server/services/llm-api/internal/utils/httpclients/chat"
 	"jan-server/services/llm-api/internal/utils/idgen"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
@@ -113,11 +112,11 @@ func (s *ProviderService) RegisterProvider(ctx context.Context, input RegisterPr
 	apiKeyHint := apiKeyHint(plainAPIKey)
 	var encryptedAPIKey string
 	if plainAPIKey != "" {
-		secret := strings.TrimSpace(config.GetGlobal().ModelProviderSecret)
-		if secret == "" {
+		keyring := config.GetGlobal().ProviderKeyring()
+		if !keyring.Configured() {
 			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeInternal, "model provider secret is not configured", nil, "9fd675bb-1471-4dd4-9160-16df36500595")
 		}
-		cipher, err := crypto.EncryptString(secret, plainAPIKey)
+		cipher, err := keyring.Encrypt(plainAPIKey)
 		if err != nil {
 			return nil, err
 		}
@@ -379,11 +378,11 @@ func (s *ProviderService) UpdateProvider(ctx context.Context, provider *Provider
 			provider.EncryptedAPIKey = ""
 			provider.APIKeyHint = nil
 		} else {
-			secret := strings.TrimSpace(config.GetGlobal().ModelProviderSecret)
-			if secret == "" {
+			keyring := config.GetGlobal().ProviderKeyring()
+			if !keyring.Configured() {
 				return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeInternal, "model provider secret is not configured", nil, "b31c3083-4a15-4e86-baf9-35fc557cfa0a")
 			}
-			cipher, err := crypto.EncryptString(secret, key)
+			cipher, err := keyring.Encrypt(key)
 			if err != nil {
 				return nil, err
 			}
@@ -447,6 +446,7 @@ func normalizeAndValidateEndpoints(endpoints EndpointList) (EndpointList, error)
 			ep.Weight = 1
 		}
 		ep.URL = normalizeURL(urlStr)
+		ep.Region = NormalizeRegion(ep.Region)
 		ep.Healthy = true
 		normalized = append(normalized, ep)
 	}
@@ -724,6 +724,38 @@ func (s *ProviderService) findDefaultImageProviderAnyCategory(
 	return defaults[0], nil
 }
 
+// ReencryptAPIKeys moves every stored provider API key onto the current
+// master key and returns how many were rewritten. Keys that fail to
+// re-encrypt are skipped and reported together in the error.
+func (s *ProviderService) ReencryptAPIKeys(ctx context.Context) (int, error) {
+	keyring := config.GetGlobal().ProviderKeyring()
+	providers, err := s.providerRepo.FindByFilter(ctx, ProviderFilter{}, nil)
+	if err != nil {
+		return 0, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to list providers")
+	}
+	rotated := 0
+	var errs []error
+	for _, provider := range providers {
+		if provider.EncryptedAPIKey == "" || keyring.IsCurrent(provider.EncryptedAPIKey) {
+			continue
+		}
+		replacement, err := keyring.Rotate(provider.EncryptedAPIKey)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", provider.PublicID, err))
+			continue
+		}
+		replaced, err := s.providerRepo.ReplaceEncryptedAPIKey(ctx, provider.ID, provider.EncryptedAPIKey, replacement)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("provider %s: %w", provider.PublicID, err))
+			continue
+		}
+		if replaced {
+			rotated++
+		}
+	}
+	return rotated, errors.Join(errs...)
+}
+
 // FindAllActiveProvidersByCategory returns all active providers of a specific category
 func (s *ProviderService) FindAllActiveProvidersByCategory(ctx context.Context, category ProviderCategory) ([]*Provider, error) {
 	filter := ProviderFilter{
@@ -0,0 +1,44 @@
+package modelalias
+
+import (
+	"context"
+	"time"
+)
+
+// Channels an alias resolves through
+const (
+	ChannelStable  = "stable"  // The alias' target model
+	ChannelRollout = "rollout" // The model being rolled out to a share of users
+)
+
+// Alias maps a stable model name clients request, e.g. jan-large, to a concrete
+// model of the catalog. Operators upgrade the default model by moving the alias,
+// optionally rolling the new model out to a percentage of users first.
+type Alias struct {
+	ID             uint
+	Name           string
+	TargetModel    string  // Model public ID of the stable channel
+	RolloutModel   *string // Model public ID of the rollout channel
+	RolloutPercent int     // Share of users, 0-100, resolved to RolloutModel
+	Description    *string
+	CreatedBy      *string
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// Resolution is the model an alias resolved to for one request
+type Resolution struct {
+	Alias   string
+	Model   string // Model public ID
+	Channel string
+}
+
+// Repository defines data access for model aliases
+type Repository interface {
+	Create(ctx context.Context, alias *Alias) error
+	Update(ctx context.Context, alias *Alias) error
+	Delete(ctx context.Context, id uint) error
+	// FindByName returns nil when no alias has the name
+	FindByName(ctx context.Context, name string) (*Alias, error)
+	List(ctx context.Context) ([]*Alias, error)
+}
@@ -0,0 +1,249 @@
+package modelalias
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"strings"
+
+	domainmodel "jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+var namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// Service manages model aliases and resolves requested model names through them
+type Service struct {
+	repo           Repository
+	providerModels *domainmodel.ProviderModelService
+}
+
+// NewService creates a new model alias service
+func NewService(repo Repository, providerModels *domainmodel.ProviderModelService) *Service {
+	return &Service{
+		repo:           repo,
+		providerModels: providerModels,
+	}
+}
+
+// Input holds the fields of a new alias
+type Input struct {
+	Name           string
+	TargetModel    string
+	RolloutModel   *string
+	RolloutPercent int
+	Description    *string
+}
+
+// UpdateInput holds the fields to change on an alias; nil fields are left unchanged
+// and an empty RolloutModel ends the rollout
+type UpdateInput struct {
+	TargetModel    *string
+	RolloutModel   *string
+	RolloutPercent *int
+	Description    *string
+}
+
+// Create creates an alias. The name must not be taken by another alias or model.
+func (s *Service) Create(ctx context.Context, input Input, createdBy *string) (*Alias, error) {
+	name := strings.TrimSpace(input.Name)
+	if !namePattern.MatchString(name) {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"name must be 1-64 lowercase letters, digits, dots, dashes or underscores", nil, "a76c99a6-d1b3-4401-ac89-a7e1a4168f74")
+	}
+	existing, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			fmt.Sprintf("model alias %s already exists", name), nil, "3748dfb5-f69b-453d-ab8a-a22673dd13d3")
+	}
+	models, err := s.providerModels.FindByModelKey(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(models) > 0 {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeConflict,
+			fmt.Sprintf("%s is already a model ID and cannot be used as an alias", name), nil, "98cd8325-6a29-401b-9169-5adca3773934")
+	}
+
+	alias := &Alias{
+		Name:           name,
+		TargetModel:    strings.TrimSpace(input.TargetModel),
+		RolloutModel:   trimmedOrNil(input.RolloutModel),
+		RolloutPercent: input.RolloutPercent,
+		Description:    input.Description,
+		CreatedBy:      createdBy,
+	}
+	if err := s.validate(ctx, alias); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Create(ctx, alias); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create model alias")
+	}
+	return alias, nil
+}
+
+// Get returns an alias by name
+func (s *Service) Get(ctx context.Context, name string) (*Alias, error) {
+	alias, err := s.repo.FindByName(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if alias == nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+			"model alias not found", nil, "8d2a7e28-b5f6-4ea7-9077-4bd13a43871e")
+	}
+	return alias, nil
+}
+
+// List returns every alias ordered by name
+func (s *Service) List(ctx context.Context) ([]*Alias, error) {
+	return s.repo.List(ctx)
+}
+
+// Update remaps an alias. The change applies to the next request resolving it.
+func (s *Service) Update(ctx context.Context, name string, input UpdateInput) (*Alias, error) {
+	alias, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if input.TargetModel != nil {
+		alias.TargetModel = strings.TrimSpace(*input.TargetModel)
+	}
+	if input.RolloutModel != nil {
+		alias.RolloutModel = trimmedOrNil(input.RolloutModel)
+		if alias.RolloutModel == nil && input.RolloutPercent == nil {
+			alias.RolloutPercent = 0
+		}
+	}
+	if input.RolloutPercent != nil {
+		alias.RolloutPercent = *input.RolloutPercent
+	}
+	if input.Description != nil {
+		alias.Description = input.Description
+	}
+	return s.save(ctx, alias)
+}
+
+// Promote finishes a rollout: the rollout model becomes the alias' target for
+// every user.
+func (s *Service) Promote(ctx context.Context, name string) (*Alias, error) {
+	alias, err := s.Get(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if alias.RolloutModel == nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"model alias has no rollout to promote", nil, "6b3bdbab-c3cd-472a-9a80-649642151ca6")
+	}
+	alias.TargetModel = *alias.RolloutModel
+	alias.RolloutModel = nil
+	alias.RolloutPercent = 0
+	return s.save(ctx, alias)
+}
+
+// Delete deletes an alias; requests for it then fail as unknown models
+func (s *Service) Delete(ctx context.Context, name string) error {
+	alias, err := s.Get(ctx, name)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, alias.ID)
+}
+
+// Resolve returns the model requested as model resolves to, or nil when model is
+// not an alias. bucketKey, usually the user ID, decides which channel a request
+// takes during a rollout, so each user keeps getting the same model.
+func (s *Service) Resolve(ctx context.Context, model, bucketKey string) (*Resolution, error) {
+	name := strings.TrimSpace(model)
+	if !namePattern.MatchString(name) {
+		return nil, nil
+	}
+	alias, err := s.repo.FindByName(ctx, name)
+	if err != nil || alias == nil {
+		return nil, err
+	}
+
+	resolution := &Resolution{Alias: alias.Name, Model: alias.TargetModel, Channel: ChannelStable}
+	if alias.RolloutModel != nil && bucket(alias.Name, bucketKey) < alias.RolloutPercent {
+		resolution.Model = *alias.RolloutModel
+		resolution.Channel = ChannelRollout
+	}
+	return resolution, nil
+}
+
+func (s *Service) save(ctx context.Context, alias *Alias) (*Alias, error) {
+	if err := s.validate(ctx, alias); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, alias); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to update model alias")
+	}
+	return alias, nil
+}
+
+// validate checks the rollout settings and that both models can serve requests
+func (s *Service) validate(ctx context.Context, alias *Alias) error {
+	invalid := func(msg, uuid string) error {
+		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, msg, nil, uuid)
+	}
+	if alias.RolloutPercent < 0 || alias.RolloutPercent > 100 {
+		return invalid("rollout_percent must be between 0 and 100", "eb4fc15e-ef82-4213-8041-3607a4eff3f8")
+	}
+	if alias.RolloutModel == nil && alias.RolloutPercent > 0 {
+		return invalid("rollout_percent requires a rollout_model", "30fc1d7b-0ff4-40e7-ba75-a3b712ed51e7")
+	}
+	if alias.RolloutModel != nil && *alias.RolloutModel == alias.TargetModel {
+		return invalid("rollout_model must differ from target_model", "1f74ad85-276e-4f20-a650-6703fea90c83")
+	}
+
+	models := []string{alias.TargetModel}
+	if alias.RolloutModel != nil {
+		models = append(models, *alias.RolloutModel)
+	}
+	for _, model := range models {
+		if model == "" {
+			return invalid("target_model is required", "40ac6ca6-4b99-4e62-9077-ea61a7a378ae")
+		}
+		// Aliases point at concrete models only, so resolution is a single lookup
+		other, err := s.repo.FindByName(ctx, model)
+		if err != nil {
+			return err
+		}
+		if other != nil {
+			return invalid(fmt.Sprintf("%s is a model alias; aliases must point at a model ID", model), "47f9e90b-d249-44e6-8146-28f137eb8c38")
+		}
+		active, err := s.providerModels.FindActiveByModelKey(ctx, model)
+		if err != nil {
+			return err
+		}
+		if len(active) == 0 {
+			return invalid(fmt.Sprintf("model %s is not served by any active provider", model), "8b4dd7b1-13fc-4441-b462-81d921d77686")
+		}
+	}
+	return nil
+}
+
+// bucket maps a user to one of 100 buckets of an alias. Hashing the alias name
+// in lets each alias roll out to a different slice of users.
+func bucket(alias, key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(alias))
+	h.Write([]byte{0})
+	h.Write([]byte(key))
+	return int(h.Sum32() % 100)
+}
+
+func trimmedOrNil(s *string) *string {
+	if s == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*s)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,59 @@
+package persona
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/query"
+)
+
+// Limits on persona fields
+const (
+	MaxNameLength            = 100
+	MaxInstructionsLength    = 20000
+	MaxAvatarLength          = 2048
+	MaxEnabledTools          = 50
+	MaxStarterQuestions      = 10
+	MaxStarterQuestionLength = 500
+)
+
+// Persona is a user-defined assistant. Its instructions are applied to chat requests
+// that reference it, above the user's profile settings.
+type Persona struct {
+	ID               uint
+	PublicID         string
+	UserID           uint
+	Name             string
+	Description      *string
+	Avatar           *string // Image URL or media ID
+	Instructions     string  // System instructions
+	DefaultModel     *string // Model used when a chat request does not name one
+	EnabledTools     []string
+	StarterQuestions []string
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// AllowsTool reports whether the persona lets requests use the named tool. A persona
+// without enabled tools allows every tool.
+func (p *Persona) AllowsTool(name string) bool {
+	if len(p.EnabledTools) == 0 {
+		return true
+	}
+	for _, tool := range p.EnabledTools {
+		if tool == name {
+			return true
+		}
+	}
+	return false
+}
+
+// Repository defines data access for personas
+type Repository interface {
+	Create(ctx context.Context, persona *Persona) error
+	Update(ctx context.Context, persona *Persona) error
+	Delete(ctx context.Context, id uint) error
+	FindByPublicID(ctx context.Context, publicID string) (*Persona, error)
+	FindByUser(ctx context.Context, userID uint, p *query.Pagination) ([]*Persona, int64, error)
+	CountByUser(ctx context.Context, userID uint) (int64, error)
+}
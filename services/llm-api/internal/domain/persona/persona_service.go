@@ -0,0 +1,225 @@
+package persona
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// IDPrefix prefixes persona public IDs
+const IDPrefix = "psn"
+
+// Config configures the Service
+type Config struct {
+	MaxPerUser int // Upper bound on personas per user
+}
+
+// Service manages user-defined personas
+type Service struct {
+	repo       Repository
+	maxPerUser int
+}
+
+// NewService creates a new persona service
+func NewService(repo Repository, cfg Config) *Service {
+	return &Service{
+		repo:       repo,
+		maxPerUser: cfg.MaxPerUser,
+	}
+}
+
+// Input holds the editable fields of a persona
+type Input struct {
+	Name             string
+	Description      *string
+	Avatar           *string
+	Instructions     string
+	DefaultModel     *string
+	EnabledTools     []string
+	StarterQuestions []string
+}
+
+// UpdateInput holds the fields to change on a persona; nil fields are left unchanged
+type UpdateInput struct {
+	Name             *string
+	Description      *string
+	Avatar           *string
+	Instructions     *string
+	DefaultModel     *string
+	EnabledTools     []string
+	StarterQuestions []string
+}
+
+// IsPersonaID reports whether value looks like a persona public ID
+func IsPersonaID(value string) bool {
+	return strings.HasPrefix(value, IDPrefix+"_")
+}
+
+// Create creates a persona owned by userID
+func (s *Service) Create(ctx context.Context, userID uint, input Input) (*Persona, error) {
+	if s.maxPerUser > 0 {
+		count, err := s.repo.CountByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(s.maxPerUser) {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("persona limit reached: at most %d personas per user", s.maxPerUser), nil, "eae0cf72-1525-46e3-aebe-af4299aa35e2")
+		}
+	}
+
+	persona := &Persona{
+		UserID:           userID,
+		Name:             strings.TrimSpace(input.Name),
+		Description:      input.Description,
+		Avatar:           trimmedOrNil(input.Avatar),
+		Instructions:     strings.TrimSpace(input.Instructions),
+		DefaultModel:     trimmedOrNil(input.DefaultModel),
+		EnabledTools:     input.EnabledTools,
+		StarterQuestions: input.StarterQuestions,
+	}
+	if err := s.validate(ctx, persona); err != nil {
+		return nil, err
+	}
+
+	publicID, err := idgen.GenerateSecureID(IDPrefix, 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate persona id")
+	}
+	persona.PublicID = publicID
+
+	if err := s.repo.Create(ctx, persona); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create persona")
+	}
+	return persona, nil
+}
+
+// Get returns one of the user's personas
+func (s *Service) Get(ctx context.Context, publicID string, userID uint) (*Persona, error) {
+	persona, err := s.repo.FindByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if persona.UserID != userID {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+			"persona not found", nil, "0c528746-0697-4dd3-8d64-4dd096948eb2")
+	}
+	return persona, nil
+}
+
+// List returns the user's personas, ordered by name
+func (s *Service) List(ctx context.Context, userID uint, p *query.Pagination) ([]*Persona, int64, error) {
+	return s.repo.FindByUser(ctx, userID, p)
+}
+
+// Update updates one of the user's personas
+func (s *Service) Update(ctx context.Context, publicID string, userID uint, input UpdateInput) (*Persona, error) {
+	persona, err := s.Get(ctx, publicID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Name != nil {
+		persona.Name = strings.TrimSpace(*input.Name)
+	}
+	if input.Description != nil {
+		persona.Description = input.Description
+	}
+	if input.Avatar != nil {
+		persona.Avatar = trimmedOrNil(input.Avatar)
+	}
+	if input.Instructions != nil {
+		persona.Instructions = strings.TrimSpace(*input.Instructions)
+	}
+	if input.DefaultModel != nil {
+		persona.DefaultModel = trimmedOrNil(input.DefaultModel)
+	}
+	if input.EnabledTools != nil {
+		persona.EnabledTools = input.EnabledTools
+	}
+	if input.StarterQuestions != nil {
+		persona.StarterQuestions = input.StarterQuestions
+	}
+	if err := s.validate(ctx, persona); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, persona); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to update persona")
+	}
+	return persona, nil
+}
+
+// Delete deletes one of the user's personas
+func (s *Service) Delete(ctx context.Context, publicID string, userID uint) error {
+	persona, err := s.Get(ctx, publicID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, persona.ID)
+}
+
+// validate checks a persona's fields against the persona limits
+func (s *Service) validate(ctx context.Context, persona *Persona) error {
+	invalid := func(msg, uuid string) error {
+		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, msg, nil, uuid)
+	}
+	if persona.Name == "" || len(persona.Name) > MaxNameLength {
+		return invalid(fmt.Sprintf("name is required and must be at most %d characters", MaxNameLength), "0d97d5ee-f31c-4689-b469-608638a13871")
+	}
+	if persona.Instructions == "" || len(persona.Instructions) > MaxInstructionsLength {
+		return invalid(fmt.Sprintf("instructions are required and must be at most %d characters", MaxInstructionsLength), "464c4cbc-6770-4f01-ad03-9fb746b66003")
+	}
+	if persona.Avatar != nil && len(*persona.Avatar) > MaxAvatarLength {
+		return invalid(fmt.Sprintf("avatar must be at most %d characters", MaxAvatarLength), "a497d08e-207d-4c81-9dea-a1ba804e3aad")
+	}
+	if persona.DefaultModel != nil && len(*persona.DefaultModel) > 255 {
+		return invalid("default_model must be at most 255 characters", "8609d329-f92a-4059-8dde-01399da964c9")
+	}
+
+	if len(persona.EnabledTools) > MaxEnabledTools {
+		return invalid(fmt.Sprintf("at most %d enabled tools are allowed", MaxEnabledTools), "88bc2bd7-e168-4a62-9291-68388edf4bcf")
+	}
+	seen := make(map[string]bool, len(persona.EnabledTools))
+	tools := make([]string, 0, len(persona.EnabledTools))
+	for i, tool := range persona.EnabledTools {
+		tool = strings.TrimSpace(tool)
+		if tool == "" || len(tool) > 100 {
+			return invalid(fmt.Sprintf("enabled_tools[%d] must be a tool name of at most 100 characters", i), "84eac5bc-a8f5-4929-bef0-ac2db8827512")
+		}
+		if seen[tool] {
+			continue
+		}
+		seen[tool] = true
+		tools = append(tools, tool)
+	}
+	persona.EnabledTools = tools
+
+	if len(persona.StarterQuestions) > MaxStarterQuestions {
+		return invalid(fmt.Sprintf("at most %d starter questions are allowed", MaxStarterQuestions), "db5dfc40-1d89-4894-811a-d2a926bf89a4")
+	}
+	questions := make([]string, 0, len(persona.StarterQuestions))
+	for i, question := range persona.StarterQuestions {
+		question = strings.TrimSpace(question)
+		if question == "" || len(question) > MaxStarterQuestionLength {
+			return invalid(fmt.Sprintf("starter_questions[%d] must be non-empty and at most %d characters", i, MaxStarterQuestionLength), "74f54be5-0cb8-434d-b6a1-3d7322dc754e")
+		}
+		questions = append(questions, question)
+	}
+	persona.StarterQuestions = questions
+	return nil
+}
+
+// trimmedOrNil trims value and returns nil when it is empty, so "" clears optional fields
+func trimmedOrNil(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
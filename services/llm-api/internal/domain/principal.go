@@ -25,6 +25,7 @@ type Principal struct {
 	Scopes          []string
 	Attributes      map[string]any
 	Credentials     map[string]string
+	Guest           bool // Guest account that has not been upgraded
 }
 
 // HasScope checks if the principal possesses a scope.
@@ -13,18 +13,45 @@ import (
 
 // Project represents a user's project that groups conversations and inherits instructions
 type Project struct {
-	ID          uint       `json:"-"`
-	PublicID    string     `json:"id"`     // OpenAI-compatible string ID like "proj_abc123"
-	Object      string     `json:"object"` // Always "project" for OpenAI compatibility
-	UserID      uint       `json:"-"`      // Internal user ID
-	Name        string     `json:"name"`
-	Instruction *string    `json:"instruction,omitempty"` // Optional persona/context text
-	Favorite    bool       `json:"favorite"`
-	ArchivedAt  *time.Time `json:"archived_at,omitempty"`
-	DeletedAt   *time.Time `json:"deleted_at,omitempty"`
-	LastUsedAt  *time.Time `json:"last_used_at,omitempty"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID                   uint         `json:"-"`
+	PublicID             string       `json:"id"`     // OpenAI-compatible string ID like "proj_abc123"
+	Object               string       `json:"object"` // Always "project" for OpenAI compatibility
+	UserID               uint         `json:"-"`      // Internal user ID
+	Name                 string       `json:"name"`
+	Instruction          *string      `json:"instruction,omitempty"` // Optional persona/context text
+	InstructionVersion   int          `json:"instruction_version"`   // Bumped whenever Instruction changes
+	DefaultTools         DefaultTools `json:"default_tools,omitempty"`
+	KnowledgeCollections []string     `json:"knowledge_collections,omitempty"` // Vector store collections searched by file_search_query
+	Favorite             bool         `json:"favorite"`
+	ArchivedAt           *time.Time   `json:"archived_at,omitempty"`
+	DeletedAt            *time.Time   `json:"deleted_at,omitempty"`
+	LastUsedAt           *time.Time   `json:"last_used_at,omitempty"`
+	CreatedAt            time.Time    `json:"created_at"`
+	UpdatedAt            time.Time    `json:"updated_at"`
+}
+
+// DefaultTools switches tools on or off by name for completions in a project's
+// conversations. Once any tool is switched on, tools that are not listed are off.
+type DefaultTools map[string]bool
+
+// Allows reports whether completions in the project may use the named tool
+func (d DefaultTools) Allows(name string) bool {
+	if enabled, ok := d[name]; ok {
+		return enabled
+	}
+	for _, enabled := range d {
+		if enabled {
+			return false
+		}
+	}
+	return true
+}
+
+// InstructionVersion is one version of a project's instruction
+type InstructionVersion struct {
+	Version     int       `json:"version"`
+	Instruction *string   `json:"instruction,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
 }
 
 // ===============================================
@@ -45,8 +72,9 @@ type ProjectRepository interface {
 	GetByPublicIDAndUserID(ctx context.Context, publicID string, userID uint) (*Project, error)
 	GetByNameAndUserID(ctx context.Context, name string, userID uint) (*Project, error)
 	ListByUserID(ctx context.Context, userID uint, pagination *query.Pagination) ([]*Project, int64, error)
-	Update(ctx context.Context, project *Project) error
+	Update(ctx context.Context, project *Project) error // Bumps InstructionVersion when the instruction changed
 	Delete(ctx context.Context, publicID string) error
+	ListInstructionVersions(ctx context.Context, projectID uint) ([]*InstructionVersion, error) // Newest first
 }
 
 // ===============================================
@@ -58,16 +86,17 @@ func NewProject(publicID string, userID uint, name string, instruction *string)
 	now := time.Now()
 
 	return &Project{
-		PublicID:    publicID,
-		Object:      "project",
-		UserID:      userID,
-		Name:        name,
-		Instruction: instruction,
-		Favorite:    false,
-		ArchivedAt:  nil,
-		DeletedAt:   nil,
-		LastUsedAt:  nil,
-		CreatedAt:   now,
-		UpdatedAt:   now,
+		PublicID:           publicID,
+		Object:             "project",
+		UserID:             userID,
+		Name:               name,
+		Instruction:        instruction,
+		InstructionVersion: 1,
+		Favorite:           false,
+		ArchivedAt:         nil,
+		DeletedAt:          nil,
+		LastUsedAt:         nil,
+		CreatedAt:          now,
+		UpdatedAt:          now,
 	}
 }
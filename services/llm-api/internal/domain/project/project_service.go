@@ -121,3 +121,18 @@ func (s *ProjectService) ListProjectsByUserID(ctx context.Context, userID uint,
 
 	return projects, total, nil
 }
+
+// ListInstructionVersions returns every version of a project's instruction, newest first
+func (s *ProjectService) ListInstructionVersions(ctx context.Context, publicID string, userID uint) ([]*InstructionVersion, error) {
+	proj, err := s.GetProjectByPublicIDAndUserID(ctx, publicID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	versions, err := s.repo.ListInstructionVersions(ctx, proj.ID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to list instruction versions")
+	}
+
+	return versions, nil
+}
@@ -18,6 +18,10 @@ import (
 type ProjectValidationConfig struct {
 	MaxNameLength        int
 	MaxInstructionLength int
+	MaxDefaultTools      int
+	MaxToolNameLength    int
+	MaxCollections       int
+	MaxCollectionLength  int
 }
 
 // DefaultProjectValidationConfig returns default project validation rules
@@ -25,6 +29,10 @@ func DefaultProjectValidationConfig() *ProjectValidationConfig {
 	return &ProjectValidationConfig{
 		MaxNameLength:        120,
 		MaxInstructionLength: 32768, // 32k chars
+		MaxDefaultTools:      50,
+		MaxToolNameLength:    128,
+		MaxCollections:       20,
+		MaxCollectionLength:  128,
 	}
 }
 
@@ -74,6 +82,16 @@ func (v *ProjectValidator) ValidateProject(proj *Project) error {
 		}
 	}
 
+	// Validate default tools
+	if err := v.validateDefaultTools(proj.DefaultTools); err != nil {
+		return fmt.Errorf("invalid default_tools: %w", err)
+	}
+
+	// Validate knowledge collections
+	if err := v.validateCollections(proj.KnowledgeCollections); err != nil {
+		return fmt.Errorf("invalid knowledge_collections: %w", err)
+	}
+
 	return nil
 }
 
@@ -150,6 +168,53 @@ func (v *ProjectValidator) validateInstruction(instruction string) error {
 	return nil
 }
 
+// validateDefaultTools validates the tool switches (internal use only)
+func (v *ProjectValidator) validateDefaultTools(tools DefaultTools) error {
+	if len(tools) > v.config.MaxDefaultTools {
+		return fmt.Errorf("at most %d tools are allowed", v.config.MaxDefaultTools)
+	}
+
+	for name := range tools {
+		if strings.TrimSpace(name) != name || name == "" {
+			return fmt.Errorf("tool names cannot be empty or padded with whitespace")
+		}
+		if utf8.RuneCountInString(name) > v.config.MaxToolNameLength {
+			return fmt.Errorf("tool name exceeds maximum length of %d characters", v.config.MaxToolNameLength)
+		}
+		if v.invalidCharPattern.MatchString(name) {
+			return fmt.Errorf("tool name contains invalid control characters")
+		}
+	}
+
+	return nil
+}
+
+// validateCollections validates the bound collection names (internal use only)
+func (v *ProjectValidator) validateCollections(collections []string) error {
+	if len(collections) > v.config.MaxCollections {
+		return fmt.Errorf("at most %d collections are allowed", v.config.MaxCollections)
+	}
+
+	seen := make(map[string]struct{}, len(collections))
+	for _, name := range collections {
+		if strings.TrimSpace(name) != name || name == "" {
+			return fmt.Errorf("collection names cannot be empty or padded with whitespace")
+		}
+		if utf8.RuneCountInString(name) > v.config.MaxCollectionLength {
+			return fmt.Errorf("collection name exceeds maximum length of %d characters", v.config.MaxCollectionLength)
+		}
+		if v.invalidCharPattern.MatchString(name) {
+			return fmt.Errorf("collection name contains invalid control characters")
+		}
+		if _, ok := seen[name]; ok {
+			return fmt.Errorf("collection %q is listed more than once", name)
+		}
+		seen[name] = struct{}{}
+	}
+
+	return nil
+}
+
 // ValidateProjectName validates project name independently
 func (v *ProjectValidator) ValidateProjectName(name string) error {
 	return v.validateName(name)
@@ -0,0 +1,75 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const languageModuleName = "language"
+
+// LanguageModule instructs the model to answer in the language chosen by the
+// user's respond_in preference. The value is either a language tag ("de",
+// "pt-BR") or "auto", which follows the request locale.
+type LanguageModule struct{}
+
+// NewLanguageModule creates a new language module.
+func NewLanguageModule() *LanguageModule {
+	return &LanguageModule{}
+}
+
+// Name returns the module identifier.
+func (m *LanguageModule) Name() string {
+	return languageModuleName
+}
+
+// ShouldApply applies when a response language can be resolved for the request.
+func (m *LanguageModule) ShouldApply(ctx context.Context, promptCtx *Context, messages []openai.ChatCompletionMessage) bool {
+	if ctx == nil || ctx.Err() != nil {
+		return false
+	}
+	if promptCtx == nil {
+		return false
+	}
+	if promptCtx.Preferences != nil && isModuleDisabled(promptCtx.Preferences, m.Name()) {
+		return false
+	}
+	return responseLanguage(promptCtx) != ""
+}
+
+// Apply adds the response language instruction as a system message.
+func (m *LanguageModule) Apply(ctx context.Context, promptCtx *Context, messages []openai.ChatCompletionMessage) ([]openai.ChatCompletionMessage, error) {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return messages, err
+		}
+	}
+	language := responseLanguage(promptCtx)
+	if language == "" {
+		return messages, nil
+	}
+
+	name := LanguageName(language)
+	instruction := fmt.Sprintf(
+		"Always respond in %s (%s), regardless of the language used in earlier messages or instructions, "+
+			"unless the user explicitly asks for a different language in their latest message. "+
+			"Keep code, commands, identifiers and quoted source text in their original language.",
+		name, language,
+	)
+	return appendSystemContent(messages, instruction, m.Name(), ""), nil
+}
+
+// responseLanguage resolves the respond_in preference to a normalized language tag.
+func responseLanguage(promptCtx *Context) string {
+	if promptCtx == nil || promptCtx.Preferences == nil {
+		return ""
+	}
+	value, _ := promptCtx.Preferences["respond_in"].(string)
+	value = strings.TrimSpace(value)
+	if strings.EqualFold(value, RespondInAuto) {
+		return promptCtx.Language
+	}
+	return NormalizeLanguage(value)
+}
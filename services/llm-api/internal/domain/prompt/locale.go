@@ -0,0 +1,201 @@
+package prompt
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RespondInAuto asks the language module to answer in the request locale.
+const RespondInAuto = "auto"
+
+// localeInfo holds the per-language data used for date rendering and the
+// language instruction. Languages without an entry fall back to English.
+type localeInfo struct {
+	name   string
+	months [12]string
+	// format renders day, month name and year in the locale's usual order.
+	format func(day int, month string, year int) string
+}
+
+var locales = map[string]localeInfo{
+	"en": {
+		name:   "English",
+		months: [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%s %d, %d", m, d, y) },
+	},
+	"es": {
+		name:   "Spanish",
+		months: [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d de %s de %d", d, m, y) },
+	},
+	"pt": {
+		name:   "Portuguese",
+		months: [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d de %s de %d", d, m, y) },
+	},
+	"fr": {
+		name:   "French",
+		months: [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d %s %d", d, m, y) },
+	},
+	"it": {
+		name:   "Italian",
+		months: [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d %s %d", d, m, y) },
+	},
+	"de": {
+		name:   "German",
+		months: [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d. %s %d", d, m, y) },
+	},
+	"nl": {
+		name:   "Dutch",
+		months: [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d %s %d", d, m, y) },
+	},
+	"ru": {
+		name:   "Russian",
+		months: [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d %s %d г.", d, m, y) },
+	},
+	"vi": {
+		name:   "Vietnamese",
+		months: [12]string{"tháng 1", "tháng 2", "tháng 3", "tháng 4", "tháng 5", "tháng 6", "tháng 7", "tháng 8", "tháng 9", "tháng 10", "tháng 11", "tháng 12"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("ngày %d %s năm %d", d, m, y) },
+	},
+	"ja": {
+		name:   "Japanese",
+		months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d年%s%d日", y, m, d) },
+	},
+	"zh": {
+		name:   "Chinese",
+		months: [12]string{"1月", "2月", "3月", "4月", "5月", "6月", "7月", "8月", "9月", "10月", "11月", "12月"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d年%s%d日", y, m, d) },
+	},
+	"ko": {
+		name:   "Korean",
+		months: [12]string{"1월", "2월", "3월", "4월", "5월", "6월", "7월", "8월", "9월", "10월", "11월", "12월"},
+		format: func(d int, m string, y int) string { return fmt.Sprintf("%d년 %s %d일", y, m, d) },
+	},
+}
+
+// NormalizeLanguage turns a language tag or an Accept-Language header value into
+// a lowercase tag such as "en" or "pt-br". For headers the entry with the highest
+// quality wins. Empty, wildcard and malformed values return "".
+func NormalizeLanguage(value string) string {
+	type candidate struct {
+		tag     string
+		quality float64
+	}
+
+	candidates := make([]candidate, 0, 4)
+	for _, part := range strings.Split(value, ",") {
+		fields := strings.Split(part, ";")
+		tag := strings.ToLower(strings.TrimSpace(fields[0]))
+		tag = strings.ReplaceAll(tag, "_", "-")
+		if tag == "" || tag == "*" || !isLanguageTag(tag) {
+			continue
+		}
+		quality := 1.0
+		for _, param := range fields[1:] {
+			param = strings.TrimSpace(param)
+			if q, ok := strings.CutPrefix(param, "q="); ok {
+				if parsed, err := strconv.ParseFloat(q, 64); err == nil {
+					quality = parsed
+				}
+			}
+		}
+		if quality <= 0 {
+			continue
+		}
+		candidates = append(candidates, candidate{tag: tag, quality: quality})
+	}
+	if len(candidates) == 0 {
+		return ""
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].quality > candidates[j].quality
+	})
+	return candidates[0].tag
+}
+
+// BaseLanguage returns the primary subtag of a normalized language tag ("pt-br" -> "pt").
+func BaseLanguage(language string) string {
+	base, _, _ := strings.Cut(language, "-")
+	return base
+}
+
+// LanguageName returns the English name of the language, or the tag itself when unknown.
+func LanguageName(language string) string {
+	if info, ok := locales[BaseLanguage(language)]; ok {
+		return info.name
+	}
+	return language
+}
+
+// FormatDate renders t as a long date in the given language, falling back to
+// English ("January 2, 2006") for languages without locale data.
+func FormatDate(t time.Time, language string) string {
+	info, ok := locales[BaseLanguage(language)]
+	if !ok {
+		info = locales["en"]
+	}
+	return info.format(t.Day(), info.months[t.Month()-1], t.Year())
+}
+
+// NormalizeTimezone validates an IANA timezone name such as "Asia/Ho_Chi_Minh" and
+// returns it trimmed. Empty and unknown names return "".
+func NormalizeTimezone(value string) string {
+	value = strings.TrimSpace(value)
+	if value == "" || len(value) > maxTimezoneLength {
+		return ""
+	}
+	if _, err := time.LoadLocation(value); err != nil {
+		return ""
+	}
+	return value
+}
+
+// maxTimezoneLength bounds timezone names; the longest IANA name is well below it.
+const maxTimezoneLength = 64
+
+// timezoneLocation returns the location for a normalized timezone name, falling
+// back to the server's local time when the name is empty or cannot be loaded.
+func timezoneLocation(timezone string) *time.Location {
+	if timezone == "" {
+		return time.Local
+	}
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return time.Local
+	}
+	return location
+}
+
+// isLanguageTag performs a loose BCP 47 shape check: letters, digits and hyphens,
+// starting with a 2-3 letter primary subtag.
+func isLanguageTag(tag string) bool {
+	if len(tag) > 35 {
+		return false
+	}
+	primary := BaseLanguage(tag)
+	if len(primary) < 2 || len(primary) > 3 {
+		return false
+	}
+	for _, r := range primary {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+	for _, r := range tag {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '-' {
+			return false
+		}
+	}
+	return true
+}
@@ -340,24 +340,41 @@ func (m *TimingModule) Apply(ctx context.Context, promptCtx *Context, messages [
 		}
 	}
 
-	// Format current date as "Month Day, Year" (e.g., "November 28, 2025")
-	currentDate := time.Now().Format("January 2, 2006")
+	// Format current date as a long date in the request language
+	// (e.g., "November 28, 2025" or "28 de noviembre de 2025")
+	language := ""
+	timezone := ""
+	if promptCtx != nil {
+		language = promptCtx.Language
+		timezone = promptCtx.Timezone
+	}
+
+	// "Today" follows the user's timezone so users far from the server region get the right date
+	now := time.Now().In(timezoneLocation(timezone))
+	currentDate := FormatDate(now, language)
+	currentTime := now.Format("15:04 MST")
+	vars := map[string]any{
+		"CurrentDate": currentDate,
+		"CurrentTime": currentTime,
+		"Timezone":    now.Location().String(),
+	}
 
 	var timingText string
 	var templateSource string
 
+	// Prefer the variant localized for the request language, e.g. "timing_es"
+	templateKey := templateKeyFor(ctx, m.templateService, prompttemplate.TemplateKeyTiming, promptCtx)
+
 	// Try to fetch model-specific template first, then fall back to global
 	if m.modelPromptService != nil && promptCtx != nil && promptCtx.ModelCatalogID != nil && *promptCtx.ModelCatalogID != "" {
 		log.Debug().
 			Str("model_catalog_id", *promptCtx.ModelCatalogID).
 			Msg("TimingModule: Attempting to load model-specific template")
 
-		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, prompttemplate.TemplateKeyTiming)
+		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, templateKey)
 		if err == nil && template != nil && template.IsActive {
-			// Render template with current date variable
-			rendered, renderErr := renderTemplateContent(template.Content, map[string]any{
-				"CurrentDate": currentDate,
-			})
+			// Render template with current date and time variables
+			rendered, renderErr := renderTemplateContent(template.Content, vars)
 			if renderErr == nil {
 				timingText = rendered
 				templateSource = source
@@ -369,6 +386,7 @@ func (m *TimingModule) Apply(ctx context.Context, promptCtx *Context, messages [
 					Str("source", source).
 					Str("model_catalog_id", *promptCtx.ModelCatalogID).
 					Str("current_date", currentDate).
+					Str("timezone", timezone).
 					Int("content_length", len(timingText)).
 					Int("template_version", template.Version).
 					Msg("TimingModule: Loaded and rendered template from database")
@@ -384,12 +402,10 @@ func (m *TimingModule) Apply(ctx context.Context, promptCtx *Context, messages [
 
 	// Fall back to global template if model-specific not found
 	if timingText == "" && m.templateService != nil {
-		template, err := m.templateService.GetByKey(ctx, prompttemplate.TemplateKeyTiming)
+		template, err := m.templateService.GetByKey(ctx, templateKey)
 		if err == nil && template != nil && template.IsActive {
-			// Render template with current date variable
-			rendered, renderErr := m.templateService.RenderTemplate(ctx, prompttemplate.TemplateKeyTiming, map[string]any{
-				"CurrentDate": currentDate,
-			})
+			// Render template with current date and time variables
+			rendered, renderErr := m.templateService.RenderTemplate(ctx, templateKey, vars)
 			if renderErr == nil {
 				timingText = rendered
 				templateSource = "global_default"
@@ -400,6 +416,7 @@ func (m *TimingModule) Apply(ctx context.Context, promptCtx *Context, messages [
 					Str("template_name", template.Name).
 					Str("source", templateSource).
 					Str("current_date", currentDate).
+					Str("timezone", timezone).
 					Int("content_length", len(timingText)).
 					Int("template_version", template.Version).
 					Msg("TimingModule: Loaded and rendered template from database")
@@ -427,6 +444,9 @@ func (m *TimingModule) Apply(ctx context.Context, promptCtx *Context, messages [
 				"Always treat this as the current date.",
 			currentDate,
 		)
+		if timezone != "" {
+			timingText += fmt.Sprintf("\nThe user's local time is %s (%s).", currentTime, timezone)
+		}
 		log.Debug().
 			Str("module", "timing").
 			Str("source", "hardcoded_fallback").
@@ -530,13 +550,15 @@ func (m *UserProfileModule) Apply(ctx context.Context, promptCtx *Context, messa
 		"MoreAboutYou":       promptCtx.Profile.MoreAboutYou,
 	}
 
+	templateKey := templateKeyFor(ctx, m.templateService, prompttemplate.TemplateKeyUserProfile, promptCtx)
+
 	// Try to fetch model-specific template first, then fall back to global
 	if m.modelPromptService != nil && promptCtx != nil && promptCtx.ModelCatalogID != nil && *promptCtx.ModelCatalogID != "" {
 		log.Debug().
 			Str("model_catalog_id", *promptCtx.ModelCatalogID).
 			Msg("UserProfileModule: Attempting to load model-specific template")
 
-		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, prompttemplate.TemplateKeyUserProfile)
+		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, templateKey)
 		if err == nil && template != nil && template.IsActive {
 			// Render template with user profile variables
 			rendered, renderErr := renderTemplateContent(template.Content, vars)
@@ -565,10 +587,10 @@ func (m *UserProfileModule) Apply(ctx context.Context, promptCtx *Context, messa
 
 	// Fall back to global template if model-specific not found
 	if instruction == "" && m.templateService != nil {
-		template, err := m.templateService.GetByKey(ctx, prompttemplate.TemplateKeyUserProfile)
+		template, err := m.templateService.GetByKey(ctx, templateKey)
 		if err == nil && template != nil && template.IsActive {
 			// Render template with user profile variables
-			rendered, renderErr := m.templateService.RenderTemplate(ctx, prompttemplate.TemplateKeyUserProfile, vars)
+			rendered, renderErr := m.templateService.RenderTemplate(ctx, templateKey, vars)
 			if renderErr == nil {
 				instruction = rendered
 				templateSource = "global_default"
@@ -732,13 +754,15 @@ func (m *MemoryModule) Apply(ctx context.Context, promptCtx *Context, messages [
 		"MemoryItems": promptCtx.Memory,
 	}
 
+	templateKey := templateKeyFor(ctx, m.templateService, prompttemplate.TemplateKeyMemory, promptCtx)
+
 	// Try to fetch model-specific template first, then fall back to global
 	if m.modelPromptService != nil && promptCtx != nil && promptCtx.ModelCatalogID != nil && *promptCtx.ModelCatalogID != "" {
 		log.Debug().
 			Str("model_catalog_id", *promptCtx.ModelCatalogID).
 			Msg("MemoryModule: Attempting to load model-specific template")
 
-		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, prompttemplate.TemplateKeyMemory)
+		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, templateKey)
 		if err == nil && template != nil && template.IsActive {
 			rendered, renderErr := renderTemplateContent(template.Content, vars)
 			if renderErr == nil {
@@ -760,9 +784,9 @@ func (m *MemoryModule) Apply(ctx context.Context, promptCtx *Context, messages [
 
 	// Fall back to global template if model-specific not found
 	if memoryText == "" && m.templateService != nil {
-		template, err := m.templateService.GetByKey(ctx, prompttemplate.TemplateKeyMemory)
+		template, err := m.templateService.GetByKey(ctx, templateKey)
 		if err == nil && template != nil && template.IsActive {
-			rendered, renderErr := m.templateService.RenderTemplate(ctx, prompttemplate.TemplateKeyMemory, vars)
+			rendered, renderErr := m.templateService.RenderTemplate(ctx, templateKey, vars)
 			if renderErr == nil {
 				memoryText = rendered
 				log.Debug().
@@ -866,13 +890,15 @@ func (m *ToolInstructionsModule) Apply(ctx context.Context, promptCtx *Context,
 	// Build variables for template rendering
 	vars := buildToolTemplateVars(promptCtx)
 
+	templateKey := templateKeyFor(ctx, m.templateService, prompttemplate.TemplateKeyToolInstructions, promptCtx)
+
 	// Try to fetch model-specific template first, then fall back to global
 	if m.modelPromptService != nil && promptCtx != nil && promptCtx.ModelCatalogID != nil && *promptCtx.ModelCatalogID != "" {
 		log.Debug().
 			Str("model_catalog_id", *promptCtx.ModelCatalogID).
 			Msg("ToolInstructionsModule: Attempting to load model-specific template")
 
-		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, prompttemplate.TemplateKeyToolInstructions)
+		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, templateKey)
 		if err == nil && template != nil && template.IsActive {
 			rendered, renderErr := renderTemplateContent(template.Content, vars)
 			if renderErr == nil {
@@ -899,9 +925,9 @@ func (m *ToolInstructionsModule) Apply(ctx context.Context, promptCtx *Context,
 
 	// Fall back to global template if model-specific not found
 	if toolText == "" && m.templateService != nil {
-		template, err := m.templateService.GetByKey(ctx, prompttemplate.TemplateKeyToolInstructions)
+		template, err := m.templateService.GetByKey(ctx, templateKey)
 		if err == nil && template != nil && template.IsActive {
-			rendered, renderErr := m.templateService.RenderTemplate(ctx, prompttemplate.TemplateKeyToolInstructions, vars)
+			rendered, renderErr := m.templateService.RenderTemplate(ctx, templateKey, vars)
 			if renderErr == nil {
 				toolText = rendered
 				log.Debug().
@@ -974,6 +1000,22 @@ func (m *ToolInstructionsModule) Apply(ctx context.Context, promptCtx *Context,
 			builder.WriteString(vars["ScrapeToolName"].(string))
 			builder.WriteString("\n")
 		}
+		if vars["HasCalculatorTool"].(bool) {
+			builder.WriteString("- When you need to do arithmetic or any numeric calculation: use ")
+			builder.WriteString(vars["CalculatorToolName"].(string))
+			builder.WriteString(" instead of calculating in your head")
+			if vars["HasCodeTool"].(bool) {
+				builder.WriteString(" (it is faster than ")
+				builder.WriteString(vars["CodeToolName"].(string))
+				builder.WriteString(")")
+			}
+			builder.WriteString("\n")
+		}
+		if vars["HasUnitConversionTool"].(bool) {
+			builder.WriteString("- When you need to convert between units: use ")
+			builder.WriteString(vars["UnitConversionToolName"].(string))
+			builder.WriteString("\n")
+		}
 		if vars["HasCodeTool"].(bool) {
 			builder.WriteString("- When you need to execute code: use ")
 			builder.WriteString(vars["CodeToolName"].(string))
@@ -1012,20 +1054,24 @@ func (m *ToolInstructionsModule) Apply(ctx context.Context, promptCtx *Context,
 // buildToolTemplateVars builds template variables for tool instructions
 func buildToolTemplateVars(promptCtx *Context) map[string]any {
 	vars := map[string]any{
-		"Tools":                 []map[string]string{},
-		"HasSearchTool":         false,
-		"SearchToolName":        "",
-		"HasCodeTool":           false,
-		"CodeToolName":          "",
-		"HasBrowserTool":        false,
-		"BrowserToolName":       "",
-		"HasScrapeTool":         false,
-		"ScrapeToolName":        "",
-		"HasImageTool":          false,
-		"HasImageGenerateTool":  false,
-		"ImageGenerateToolName": "",
-		"HasImageEditTool":      false,
-		"ImageEditToolName":     "",
+		"Tools":                  []map[string]string{},
+		"HasSearchTool":          false,
+		"SearchToolName":         "",
+		"HasCodeTool":            false,
+		"CodeToolName":           "",
+		"HasBrowserTool":         false,
+		"BrowserToolName":        "",
+		"HasScrapeTool":          false,
+		"ScrapeToolName":         "",
+		"HasImageTool":           false,
+		"HasImageGenerateTool":   false,
+		"ImageGenerateToolName":  "",
+		"HasImageEditTool":       false,
+		"ImageEditToolName":      "",
+		"HasCalculatorTool":      false,
+		"CalculatorToolName":     "",
+		"HasUnitConversionTool":  false,
+		"UnitConversionToolName": "",
 	}
 
 	if promptCtx == nil || len(promptCtx.Tools) == 0 {
@@ -1114,6 +1160,22 @@ func buildToolTemplateVars(promptCtx *Context) map[string]any {
 			vars["ScrapeToolName"] = toolName
 		}
 
+		// Calculator tools (calculate, calculator, math_eval, etc.)
+		if toolNameLower == "calculate" ||
+			strings.Contains(toolNameLower, "calculator") ||
+			strings.Contains(toolNameLower, "math") ||
+			strings.Contains(toolDescLower, "arithmetic expression") {
+			vars["HasCalculatorTool"] = true
+			vars["CalculatorToolName"] = toolName
+		}
+
+		// Unit conversion tools (convert_units, unit_converter, etc.)
+		if strings.Contains(toolNameLower, "convert_unit") ||
+			strings.Contains(toolNameLower, "unit_conver") {
+			vars["HasUnitConversionTool"] = true
+			vars["UnitConversionToolName"] = toolName
+		}
+
 		// Image generation tools (generate_image)
 		if strings.Contains(toolNameLower, "generate_image") ||
 			(strings.Contains(toolNameLower, "image") && strings.Contains(toolNameLower, "generat")) ||
@@ -1201,13 +1263,15 @@ func (m *CodeAssistantModule) Apply(ctx context.Context, promptCtx *Context, mes
 
 	var codeText string
 
+	templateKey := templateKeyFor(ctx, m.templateService, prompttemplate.TemplateKeyCodeAssistant, promptCtx)
+
 	// Try to fetch model-specific template first, then fall back to global
 	if m.modelPromptService != nil && promptCtx != nil && promptCtx.ModelCatalogID != nil && *promptCtx.ModelCatalogID != "" {
 		log.Debug().
 			Str("model_catalog_id", *promptCtx.ModelCatalogID).
 			Msg("CodeAssistantModule: Attempting to load model-specific template")
 
-		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, prompttemplate.TemplateKeyCodeAssistant)
+		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, templateKey)
 		if err == nil && template != nil && template.IsActive {
 			rendered, renderErr := renderTemplateContent(template.Content, map[string]any{})
 			if renderErr == nil {
@@ -1234,9 +1298,9 @@ func (m *CodeAssistantModule) Apply(ctx context.Context, promptCtx *Context, mes
 
 	// Fall back to global template if model-specific not found
 	if codeText == "" && m.templateService != nil {
-		template, err := m.templateService.GetByKey(ctx, prompttemplate.TemplateKeyCodeAssistant)
+		template, err := m.templateService.GetByKey(ctx, templateKey)
 		if err == nil && template != nil && template.IsActive {
-			rendered, renderErr := m.templateService.RenderTemplate(ctx, prompttemplate.TemplateKeyCodeAssistant, map[string]any{})
+			rendered, renderErr := m.templateService.RenderTemplate(ctx, templateKey, map[string]any{})
 			if renderErr == nil {
 				codeText = rendered
 				log.Debug().
@@ -1342,13 +1406,15 @@ func (m *ChainOfThoughtModule) Apply(ctx context.Context, promptCtx *Context, me
 
 	var cotText string
 
+	templateKey := templateKeyFor(ctx, m.templateService, prompttemplate.TemplateKeyChainOfThought, promptCtx)
+
 	// Try to fetch model-specific template first, then fall back to global
 	if m.modelPromptService != nil && promptCtx != nil && promptCtx.ModelCatalogID != nil && *promptCtx.ModelCatalogID != "" {
 		log.Debug().
 			Str("model_catalog_id", *promptCtx.ModelCatalogID).
 			Msg("ChainOfThoughtModule: Attempting to load model-specific template")
 
-		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, prompttemplate.TemplateKeyChainOfThought)
+		template, source, err := m.modelPromptService.GetTemplateForModelByKey(ctx, *promptCtx.ModelCatalogID, templateKey)
 		if err == nil && template != nil && template.IsActive {
 			rendered, renderErr := renderTemplateContent(template.Content, map[string]any{})
 			if renderErr == nil {
@@ -1375,9 +1441,9 @@ func (m *ChainOfThoughtModule) Apply(ctx context.Context, promptCtx *Context, me
 
 	// Fall back to global template if model-specific not found
 	if cotText == "" && m.templateService != nil {
-		template, err := m.templateService.GetByKey(ctx, prompttemplate.TemplateKeyChainOfThought)
+		template, err := m.templateService.GetByKey(ctx, templateKey)
 		if err == nil && template != nil && template.IsActive {
-			rendered, renderErr := m.templateService.RenderTemplate(ctx, prompttemplate.TemplateKeyChainOfThought, map[string]any{})
+			rendered, renderErr := m.templateService.RenderTemplate(ctx, templateKey, map[string]any{})
 			if renderErr == nil {
 				cotText = rendered
 				log.Debug().
@@ -1447,6 +1513,15 @@ func detectToolUsage(promptCtx *Context, messages []openai.ChatCompletionMessage
 	return false
 }
 
+// templateKeyFor resolves the language-specific variant of a template key for the request,
+// falling back to the base key when no localized template exists.
+func templateKeyFor(ctx context.Context, templateService *prompttemplate.Service, templateKey string, promptCtx *Context) string {
+	if templateService == nil || promptCtx == nil || promptCtx.Language == "" {
+		return templateKey
+	}
+	return templateService.ResolveKeyForLanguage(ctx, templateKey, promptCtx.Language)
+}
+
 // renderTemplateContent renders a template content string with the given variables
 func renderTemplateContent(content string, variables map[string]any) (string, error) {
 	if len(variables) == 0 {
@@ -0,0 +1,61 @@
+package prompt
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const personaModuleName = "persona"
+
+// PersonaModule injects the instructions of the persona a chat request references.
+// It runs after UserProfileModule so the persona message sits above the profile
+// settings, and the instructions state that the persona wins where they conflict.
+type PersonaModule struct{}
+
+// NewPersonaModule creates a new persona module.
+func NewPersonaModule() *PersonaModule {
+	return &PersonaModule{}
+}
+
+// Name returns the module identifier.
+func (m *PersonaModule) Name() string {
+	return personaModuleName
+}
+
+// ShouldApply applies when the request references a persona with instructions.
+func (m *PersonaModule) ShouldApply(ctx context.Context, promptCtx *Context, messages []openai.ChatCompletionMessage) bool {
+	if ctx == nil || ctx.Err() != nil {
+		return false
+	}
+	if promptCtx == nil || promptCtx.Persona == nil {
+		return false
+	}
+	if promptCtx.Preferences != nil && isModuleDisabled(promptCtx.Preferences, m.Name()) {
+		return false
+	}
+	return strings.TrimSpace(promptCtx.Persona.Instructions) != ""
+}
+
+// Apply adds the persona instructions as a system message.
+func (m *PersonaModule) Apply(ctx context.Context, promptCtx *Context, messages []openai.ChatCompletionMessage) ([]openai.ChatCompletionMessage, error) {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return messages, err
+		}
+	}
+	if promptCtx == nil || promptCtx.Persona == nil {
+		return messages, nil
+	}
+
+	instruction := fmt.Sprintf(
+		"You are %s, a persona configured by the user. Follow the persona instructions below. "+
+			"They take precedence over the user's profile settings and style preferences, "+
+			"but not over project or system instructions.\n\n%s",
+		promptCtx.Persona.Name,
+		strings.TrimSpace(promptCtx.Persona.Instructions),
+	)
+	return appendSystemContent(messages, instruction, m.Name(), ""), nil
+}
@@ -10,8 +10,18 @@ import (
 
 	"jan-server/services/llm-api/internal/domain/modelprompttemplate"
 	"jan-server/services/llm-api/internal/domain/prompttemplate"
+	"jan-server/services/llm-api/internal/infrastructure/flagkeys"
 )
 
+// moduleFlags maps the optional modules to the feature flags that ramp them.
+var moduleFlags = map[string]string{
+	"memory":               flagkeys.PromptMemory,
+	"tool_instructions":    flagkeys.PromptToolInstructions,
+	"code_assistant":       flagkeys.PromptCodeAssistant,
+	"chain_of_thought":     flagkeys.PromptChainOfThought,
+	deepResearchModuleName: flagkeys.DeepResearch,
+}
+
 // ProcessorImpl implements the Processor interface
 type ProcessorImpl struct {
 	config  ProcessorConfig
@@ -32,6 +42,8 @@ func modulePriority(module Module) int {
 		return -10
 	case *UserProfileModule:
 		return 5
+	case *PersonaModule:
+		return 7 // After UserProfileModule so the persona message lands above it
 	case *MemoryModule:
 		return 10
 	case *ToolInstructionsModule:
@@ -40,6 +52,8 @@ func modulePriority(module Module) int {
 		return 30
 	case *ChainOfThoughtModule:
 		return 40
+	case *LanguageModule:
+		return 50 // Last so the response language instruction sits right below the base system prompt
 	default:
 		return 100
 	case *DeepResearchModule:
@@ -96,8 +110,12 @@ func NewProcessorWithServices(config ProcessorConfig, log zerolog.Logger, templa
 		processor.RegisterModule(NewUserProfileModule())
 	}
 
-	// Register modules based on configuration
-	if config.EnableMemory {
+	processor.RegisterModule(NewPersonaModule())
+	processor.RegisterModule(NewLanguageModule())
+
+	// Register modules based on configuration; with flags, every optional
+	// module is registered and the flags decide per request
+	if config.EnableMemory || config.Flags != nil {
 		if templateService != nil && modelPromptService != nil {
 			processor.log.Debug().Msg("registering MemoryModule with model-specific template support")
 			processor.RegisterModule(NewMemoryModuleWithModelPrompts(true, templateService, modelPromptService))
@@ -109,7 +127,7 @@ func NewProcessorWithServices(config ProcessorConfig, log zerolog.Logger, templa
 		}
 	}
 
-	if config.EnableTools {
+	if config.EnableTools || config.Flags != nil {
 		if templateService != nil && modelPromptService != nil {
 			processor.log.Debug().Msg("registering ToolInstructionsModule with model-specific template support")
 			processor.RegisterModule(NewToolInstructionsModuleWithModelPrompts(true, templateService, modelPromptService))
@@ -122,7 +140,7 @@ func NewProcessorWithServices(config ProcessorConfig, log zerolog.Logger, templa
 	}
 
 	// Conditional template-based modules (CoT, code assistant)
-	if config.EnableTemplates {
+	if config.EnableTemplates || config.Flags != nil {
 		if templateService != nil && modelPromptService != nil {
 			processor.log.Debug().Msg("registering CodeAssistantModule with model-specific template support")
 			processor.RegisterModule(NewCodeAssistantModuleWithModelPrompts(templateService, modelPromptService))
@@ -191,6 +209,14 @@ func (p *ProcessorImpl) Process(
 			continue
 		}
 
+		if !p.moduleEnabled(ctx, entry.module.Name()) {
+			p.log.Debug().
+				Str("module", entry.module.Name()).
+				Str("conversation_id", promptCtx.ConversationID).
+				Msg("prompt module disabled via feature flag")
+			continue
+		}
+
 		if entry.module.ShouldApply(ctx, promptCtx, result) {
 			before := result
 			var err error
@@ -222,3 +248,13 @@ func (p *ProcessorImpl) Process(
 
 	return result, nil
 }
+
+// moduleEnabled reports whether the feature flag of an optional module is on
+// for the user in ctx. Modules without a flag always run.
+func (p *ProcessorImpl) moduleEnabled(ctx context.Context, name string) bool {
+	key, ok := moduleFlags[name]
+	if !ok || p.config.Flags == nil || ctx == nil {
+		return true
+	}
+	return p.config.Flags.Enabled(ctx, key)
+}
@@ -3,8 +3,10 @@ package prompt
 import (
 	"context"
 
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
 	openai "github.com/sashabaranov/go-openai"
 
+	"jan-server/services/llm-api/internal/domain/persona"
 	"jan-server/services/llm-api/internal/domain/usersettings"
 )
 
@@ -14,6 +16,9 @@ type ProcessorConfig struct {
 	EnableMemory    bool
 	EnableTemplates bool
 	EnableTools     bool
+	// Flags, when set, decides per user whether the optional modules apply.
+	// Its defaults come from the Enable* settings above.
+	Flags *featureflags.Client
 }
 
 // Context contains contextual information for prompt processing
@@ -21,11 +26,13 @@ type Context struct {
 	UserID             uint
 	ConversationID     string
 	Language           string
+	Timezone           string // IANA timezone name used for the current date and time; empty uses server time
 	Preferences        map[string]interface{}
 	Memory             []string
 	ProjectInstruction string
 	AppliedModules     []string
 	Profile            *usersettings.ProfileSettings
+	Persona            *persona.Persona // Persona referenced by the request, applied above Profile
 
 	// Model context for model-specific template resolution
 	ModelCatalogID *string
@@ -0,0 +1,84 @@
+package promptlibrary
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/query"
+)
+
+// Message roles a starter may define
+const (
+	RoleSystem    = "system"
+	RoleUser      = "user"
+	RoleAssistant = "assistant"
+)
+
+// MaxStarterMessages caps the messages of one starter; conversations accept at most
+// 20 initial items
+const MaxStarterMessages = 20
+
+// Starter is a conversation starter template. Global starters (UserID nil) are managed
+// by admins and visible to everyone; user starters are private to their owner.
+type Starter struct {
+	ID          uint
+	PublicID    string
+	UserID      *uint
+	Title       string
+	Description *string
+	Category    string
+	Messages    []Message  // Templated first messages of the conversation
+	Variables   []Variable // Placeholders used by the messages
+	IsActive    bool
+	CreatedBy   *string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// IsGlobal reports whether the starter is an admin-managed starter visible to everyone
+func (s *Starter) IsGlobal() bool {
+	return s.UserID == nil
+}
+
+// Message is one templated message of a starter. Content uses Go template syntax,
+// e.g. "Summarize {{.topic}} for a {{.audience}}".
+type Message struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// Variable describes a placeholder used by a starter's messages
+type Variable struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+}
+
+// Category is a starter category with the number of starters in it
+type Category struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+// Filter selects starters
+type Filter struct {
+	// VisibleTo limits results to global starters plus the starters owned by this user
+	VisibleTo *uint
+	// GlobalOnly limits results to admin-managed starters
+	GlobalOnly bool
+	Category   *string
+	Search     *string // Matches title and description
+	IsActive   *bool
+}
+
+// Repository defines data access for prompt library starters
+type Repository interface {
+	Create(ctx context.Context, starter *Starter) error
+	Update(ctx context.Context, starter *Starter) error
+	Delete(ctx context.Context, id uint) error
+	FindByPublicID(ctx context.Context, publicID string) (*Starter, error)
+	FindByFilter(ctx context.Context, filter Filter, p *query.Pagination) ([]*Starter, int64, error)
+	CountByUser(ctx context.Context, userID uint) (int64, error)
+	ListCategories(ctx context.Context, filter Filter) ([]Category, error)
+}
@@ -0,0 +1,334 @@
+package promptlibrary
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+var variableNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// Config configures the Service
+type Config struct {
+	MaxPerUser int // Upper bound on private starters per user
+}
+
+// Service manages prompt library starters and renders them into conversation messages
+type Service struct {
+	repo       Repository
+	maxPerUser int
+}
+
+// NewService creates a new prompt library service
+func NewService(repo Repository, cfg Config) *Service {
+	return &Service{
+		repo:       repo,
+		maxPerUser: cfg.MaxPerUser,
+	}
+}
+
+// StarterInput holds the editable fields of a starter
+type StarterInput struct {
+	Title       string
+	Description *string
+	Category    string
+	Messages    []Message
+	Variables   []Variable
+}
+
+// UpdateInput holds the fields to change on a starter; nil fields are left unchanged
+type UpdateInput struct {
+	Title       *string
+	Description *string
+	Category    *string
+	Messages    []Message
+	Variables   []Variable
+	IsActive    *bool
+}
+
+// CreateGlobal creates an admin-managed starter visible to every user
+func (s *Service) CreateGlobal(ctx context.Context, input StarterInput, createdBy *string) (*Starter, error) {
+	return s.create(ctx, nil, input, createdBy)
+}
+
+// CreateForUser creates a private starter owned by userID
+func (s *Service) CreateForUser(ctx context.Context, userID uint, input StarterInput) (*Starter, error) {
+	if s.maxPerUser > 0 {
+		count, err := s.repo.CountByUser(ctx, userID)
+		if err != nil {
+			return nil, err
+		}
+		if count >= int64(s.maxPerUser) {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("prompt library limit reached: at most %d starters per user", s.maxPerUser), nil, "c5b0b54e-1600-4e40-ad6b-74268f067392")
+		}
+	}
+	return s.create(ctx, &userID, input, nil)
+}
+
+func (s *Service) create(ctx context.Context, userID *uint, input StarterInput, createdBy *string) (*Starter, error) {
+	starter := &Starter{
+		UserID:      userID,
+		Title:       strings.TrimSpace(input.Title),
+		Description: input.Description,
+		Category:    strings.TrimSpace(input.Category),
+		Messages:    input.Messages,
+		Variables:   input.Variables,
+		IsActive:    true,
+		CreatedBy:   createdBy,
+	}
+	if err := s.validate(ctx, starter); err != nil {
+		return nil, err
+	}
+
+	publicID, err := idgen.GenerateSecureID("pl", 16)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to generate starter id")
+	}
+	starter.PublicID = publicID
+
+	if err := s.repo.Create(ctx, starter); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to create starter")
+	}
+	return starter, nil
+}
+
+// GetForUser returns an active global starter or one of the user's own starters
+func (s *Service) GetForUser(ctx context.Context, publicID string, userID uint) (*Starter, error) {
+	starter, err := s.repo.FindByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if !s.visibleTo(starter, userID) {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+			"prompt library starter not found", nil, "b79c7c12-9df8-407c-8b99-e6f6258ef66d")
+	}
+	return starter, nil
+}
+
+// GetGlobal returns an admin-managed starter, active or not
+func (s *Service) GetGlobal(ctx context.Context, publicID string) (*Starter, error) {
+	starter, err := s.repo.FindByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if !starter.IsGlobal() {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+			"prompt library starter not found", nil, "549aca63-9078-4393-9ddf-b8ba15540999")
+	}
+	return starter, nil
+}
+
+// ListForUser returns active global starters together with the user's own starters
+func (s *Service) ListForUser(ctx context.Context, userID uint, filter Filter, p *query.Pagination) ([]*Starter, int64, error) {
+	filter.VisibleTo = &userID
+	filter.GlobalOnly = false
+	return s.repo.FindByFilter(ctx, filter, p)
+}
+
+// ListGlobal returns admin-managed starters, including inactive ones unless filtered
+func (s *Service) ListGlobal(ctx context.Context, filter Filter, p *query.Pagination) ([]*Starter, int64, error) {
+	filter.VisibleTo = nil
+	filter.GlobalOnly = true
+	return s.repo.FindByFilter(ctx, filter, p)
+}
+
+// ListCategories returns the categories of the starters visible to the user
+func (s *Service) ListCategories(ctx context.Context, userID uint) ([]Category, error) {
+	return s.repo.ListCategories(ctx, Filter{VisibleTo: &userID})
+}
+
+// UpdateForUser updates one of the user's own starters
+func (s *Service) UpdateForUser(ctx context.Context, publicID string, userID uint, input UpdateInput) (*Starter, error) {
+	starter, err := s.ownedStarter(ctx, publicID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, starter, input)
+}
+
+// UpdateGlobal updates an admin-managed starter
+func (s *Service) UpdateGlobal(ctx context.Context, publicID string, input UpdateInput) (*Starter, error) {
+	starter, err := s.GetGlobal(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	return s.update(ctx, starter, input)
+}
+
+func (s *Service) update(ctx context.Context, starter *Starter, input UpdateInput) (*Starter, error) {
+	if input.Title != nil {
+		starter.Title = strings.TrimSpace(*input.Title)
+	}
+	if input.Description != nil {
+		starter.Description = input.Description
+	}
+	if input.Category != nil {
+		starter.Category = strings.TrimSpace(*input.Category)
+	}
+	if input.Messages != nil {
+		starter.Messages = input.Messages
+	}
+	if input.Variables != nil {
+		starter.Variables = input.Variables
+	}
+	if input.IsActive != nil {
+		starter.IsActive = *input.IsActive
+	}
+	if err := s.validate(ctx, starter); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Update(ctx, starter); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to update starter")
+	}
+	return starter, nil
+}
+
+// DeleteForUser deletes one of the user's own starters
+func (s *Service) DeleteForUser(ctx context.Context, publicID string, userID uint) error {
+	starter, err := s.ownedStarter(ctx, publicID, userID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, starter.ID)
+}
+
+// DeleteGlobal deletes an admin-managed starter
+func (s *Service) DeleteGlobal(ctx context.Context, publicID string) error {
+	starter, err := s.GetGlobal(ctx, publicID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, starter.ID)
+}
+
+// Render resolves a starter visible to the user and renders its messages with the
+// given variable values. Defaults fill in omitted values; unknown variables and
+// missing required ones are rejected.
+func (s *Service) Render(ctx context.Context, publicID string, userID uint, values map[string]string) (*Starter, []Message, error) {
+	starter, err := s.GetForUser(ctx, publicID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	known := make(map[string]bool, len(starter.Variables))
+	data := make(map[string]string, len(starter.Variables))
+	for _, v := range starter.Variables {
+		known[v.Name] = true
+		if value, ok := values[v.Name]; ok {
+			data[v.Name] = value
+			continue
+		}
+		if v.Default != nil {
+			data[v.Name] = *v.Default
+			continue
+		}
+		if v.Required {
+			return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("variable %q is required by this starter", v.Name), nil, "72d73d03-23c1-4f09-84ff-2a3a1c314cab")
+		}
+		data[v.Name] = ""
+	}
+	for name := range values {
+		if !known[name] {
+			return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("unknown variable %q", name), nil, "08637390-36b3-4e6f-8bdc-c40ffdf09d22")
+		}
+	}
+
+	rendered := make([]Message, 0, len(starter.Messages))
+	for i, msg := range starter.Messages {
+		tmpl, err := template.New(fmt.Sprintf("messages[%d]", i)).Option("missingkey=error").Parse(msg.Content)
+		if err != nil {
+			return nil, nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to parse starter message")
+		}
+		var b strings.Builder
+		if err := tmpl.Execute(&b, data); err != nil {
+			return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				"failed to render starter message", err, "f7ab3dcd-b250-46a1-9845-0ff1fc283488")
+		}
+		rendered = append(rendered, Message{Role: msg.Role, Content: b.String()})
+	}
+	return starter, rendered, nil
+}
+
+func (s *Service) ownedStarter(ctx context.Context, publicID string, userID uint) (*Starter, error) {
+	starter, err := s.repo.FindByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, err
+	}
+	if starter.UserID != nil && *starter.UserID == userID {
+		return starter, nil
+	}
+	if starter.IsGlobal() && starter.IsActive {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeForbidden,
+			"global starters can only be changed by admins", nil, "82bd2ce1-1595-4df6-8e85-80ea9ac59e52")
+	}
+	return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeNotFound,
+		"prompt library starter not found", nil, "859f00ab-0625-418b-bc62-d16e71f1bedd")
+}
+
+func (s *Service) visibleTo(starter *Starter, userID uint) bool {
+	if starter.IsGlobal() {
+		return starter.IsActive
+	}
+	return *starter.UserID == userID
+}
+
+// validate checks a starter's fields, message roles, variable names and templates
+func (s *Service) validate(ctx context.Context, starter *Starter) error {
+	invalid := func(msg, uuid string) error {
+		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, msg, nil, uuid)
+	}
+	if starter.Title == "" || len(starter.Title) > 255 {
+		return invalid("title is required and must be at most 255 characters", "28ce9e03-b009-460a-a871-dabea76d8544")
+	}
+	if starter.Category == "" || len(starter.Category) > 100 {
+		return invalid("category is required and must be at most 100 characters", "12f06a77-46df-485f-ad6f-bd37f540885c")
+	}
+	if len(starter.Messages) == 0 || len(starter.Messages) > MaxStarterMessages {
+		return invalid(fmt.Sprintf("a starter needs between 1 and %d messages", MaxStarterMessages), "46ccf1b1-4ede-4293-bef9-5a481bb1ebd3")
+	}
+
+	seen := make(map[string]bool, len(starter.Variables))
+	for i, v := range starter.Variables {
+		if !variableNamePattern.MatchString(v.Name) {
+			return invalid(fmt.Sprintf("variables[%d].name must be a letter or underscore followed by letters, digits or underscores", i), "93a0e051-87ee-4c99-8f32-f566dbc351ef")
+		}
+		if seen[v.Name] {
+			return invalid(fmt.Sprintf("variable %q is defined more than once", v.Name), "6d569226-50e9-4daf-b231-37d8592b24cd")
+		}
+		seen[v.Name] = true
+	}
+
+	data := make(map[string]string, len(seen))
+	for name := range seen {
+		data[name] = ""
+	}
+	for i, msg := range starter.Messages {
+		switch msg.Role {
+		case RoleSystem, RoleUser, RoleAssistant:
+		default:
+			return invalid(fmt.Sprintf("messages[%d].role must be system, user or assistant", i), "5ce8fc58-ae96-48fb-bc60-bd7460e4f27e")
+		}
+		if strings.TrimSpace(msg.Content) == "" {
+			return invalid(fmt.Sprintf("messages[%d].content is required", i), "b1e64a9f-df05-4f63-bddd-71a3e7322b06")
+		}
+		// Rendering with empty values catches syntax errors and undeclared variables
+		tmpl, err := template.New("message").Option("missingkey=error").Parse(msg.Content)
+		if err == nil {
+			err = tmpl.Execute(&strings.Builder{}, data)
+		}
+		if err != nil {
+			return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("messages[%d].content is not a valid template: %v", i, err), nil, "fc36c918-c4d3-4044-9d98-f145cbd0765d")
+		}
+	}
+	return nil
+}
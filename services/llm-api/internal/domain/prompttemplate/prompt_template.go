@@ -190,6 +190,8 @@ CRITICAL RULES:
 TOOL USAGE PATTERNS:
 {{if .HasSearchTool}}- When you need to search for information: use {{.SearchToolName}}.
 {{end}}{{if .HasScrapeTool}}- When you need to scrape or extract content from a webpage: use {{.ScrapeToolName}}.
+{{end}}{{if .HasCalculatorTool}}- When you need to do arithmetic or any numeric calculation: use {{.CalculatorToolName}} instead of calculating in your head{{if .HasCodeTool}} (it is faster than {{.CodeToolName}}){{end}}.
+{{end}}{{if .HasUnitConversionTool}}- When you need to convert between units: use {{.UnitConversionToolName}}.
 {{end}}{{if .HasCodeTool}}- When you need to execute code: use {{.CodeToolName}}.
 {{end}}{{if .HasBrowserTool}}- When you need to browse the web: use {{.BrowserToolName}}.
 {{end}}{{if .HasImageGenerateTool}}- When you need to generate NEW images: use {{.ImageGenerateToolName}}.
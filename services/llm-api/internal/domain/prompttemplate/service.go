@@ -35,6 +35,34 @@ func (s *Service) GetByKey(ctx context.Context, templateKey string) (*PromptTemp
 	return template, nil
 }
 
+// LocalizedTemplateKey returns the key of the language-specific variant of a template,
+// e.g. ("timing", "pt-br") -> "timing_pt_br".
+func LocalizedTemplateKey(templateKey, language string) string {
+	return templateKey + "_" + strings.ReplaceAll(strings.ToLower(language), "-", "_")
+}
+
+// ResolveKeyForLanguage returns the most specific active template key for a language.
+// For "pt-br" it tries "<key>_pt_br", then "<key>_pt", and otherwise returns the base key.
+// English and empty languages always resolve to the base key, which holds the default text.
+func (s *Service) ResolveKeyForLanguage(ctx context.Context, templateKey, language string) string {
+	language = strings.ToLower(strings.TrimSpace(language))
+	if language == "" || language == "en" || strings.HasPrefix(language, "en-") {
+		return templateKey
+	}
+
+	candidates := []string{LocalizedTemplateKey(templateKey, language)}
+	if base, _, found := strings.Cut(language, "-"); found {
+		candidates = append(candidates, LocalizedTemplateKey(templateKey, base))
+	}
+	for _, key := range candidates {
+		template, err := s.repo.FindByTemplateKey(ctx, key)
+		if err == nil && template != nil && template.IsActive {
+			return key
+		}
+	}
+	return templateKey
+}
+
 // GetByPublicID retrieves a prompt template by its public ID
 func (s *Service) GetByPublicID(ctx context.Context, publicID string) (*PromptTemplate, error) {
 	if publicID == "" {
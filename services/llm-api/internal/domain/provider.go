@@ -2,25 +2,45 @@ package domain
 
 import (
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/admission"
+	"jan-server/services/llm-api/internal/domain/analytics"
 	"jan-server/services/llm-api/internal/domain/apikey"
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/domain/comparison"
 	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/deltasync"
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/domain/event"
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/domain/linkpolicy"
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
 	"jan-server/services/llm-api/internal/domain/mcptool"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
 	"jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/domain/modelalias"
 	"jan-server/services/llm-api/internal/domain/modelprompttemplate"
+	"jan-server/services/llm-api/internal/domain/persona"
 	"jan-server/services/llm-api/internal/domain/project"
 	"jan-server/services/llm-api/internal/domain/prompt"
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
 	"jan-server/services/llm-api/internal/domain/prompttemplate"
+	"jan-server/services/llm-api/internal/domain/region"
 	"jan-server/services/llm-api/internal/domain/share"
 	"jan-server/services/llm-api/internal/domain/user"
 	"jan-server/services/llm-api/internal/domain/usersettings"
+	"jan-server/services/llm-api/internal/domain/webhook"
 )
 
 // ServiceProvider provides all domain services
 var ServiceProvider = wire.NewSet(
 	// Conversation domain
+	ProvideLinkPolicyConfig,
+	linkpolicy.New,
+	wire.Bind(new(conversation.LinkSanitizer), new(*linkpolicy.Policy)),
 	conversation.NewConversationService,
 	conversation.NewMessageActionService,
 
@@ -57,6 +77,60 @@ var ServiceProvider = wire.NewSet(
 
 	// Share domain
 	share.NewShareService,
+
+	// Analytics
+	analytics.NewService,
+
+	// Model comparison
+	comparison.NewService,
+
+	// Evals
+	ProvideEvalConfig,
+	eval.NewService,
+
+	// Fine-tuning exports
+	ProvideFinetuneConfig,
+	finetune.NewService,
+
+	// Conversation cold storage
+	ProvideColdStorageConfig,
+	coldstorage.NewService,
+
+	// Memory backfills
+	ProvideMemoryBackfillConfig,
+	memorybackfill.NewService,
+
+	// Prompt library
+	ProvidePromptLibraryConfig,
+	promptlibrary.NewService,
+
+	// Model aliases
+	modelalias.NewService,
+
+	// Personas
+	ProvidePersonaConfig,
+	persona.NewService,
+
+	// Delta sync
+	ProvideDeltaSyncConfig,
+	deltasync.NewService,
+
+	// MCP credential store
+	ProvideMCPCredentialConfig,
+	mcpcredential.NewService,
+
+	// Event bus and provider webhooks
+	ProvideEventBus,
+	ProvideWebhookConfig,
+	webhook.NewService,
+
+	// Completion admission control
+	ProvideAdmissionConfig,
+	admission.NewController,
+
+	// Region-aware routing of completions
+	ProvideRegionConfig,
+	region.NewSelector,
 )
 
 func ProvideAPIKeyConfig(cfg *config.Config) apikey.Config {
@@ -68,12 +142,107 @@ func ProvideAPIKeyConfig(cfg *config.Config) apikey.Config {
 	}
 }
 
-func ProvidePromptProcessorConfig(cfg *config.Config, log zerolog.Logger) prompt.ProcessorConfig {
+func ProvideLinkPolicyConfig(cfg *config.Config) linkpolicy.Config {
+	return linkpolicy.Config{
+		Enabled: cfg.LinkPolicyEnabled,
+	}
+}
+
+func ProvideEvalConfig(cfg *config.Config) eval.Config {
+	return eval.Config{
+		WorkerEnabled:   cfg.EvalWorkerEnabled,
+		Concurrency:     cfg.EvalConcurrency,
+		MaxDatasetItems: cfg.EvalMaxDatasetItems,
+		JudgeModel:      cfg.EvalJudgeModel,
+	}
+}
+
+func ProvideFinetuneConfig(cfg *config.Config) finetune.Config {
+	return finetune.Config{
+		MaxExamples: cfg.FinetuneExportMaxExamples,
+	}
+}
+
+func ProvideColdStorageConfig(cfg *config.Config) coldstorage.Config {
+	return coldstorage.Config{
+		AfterMonths: cfg.ColdStorageAfterMonths,
+		BatchSize:   cfg.ColdStorageBatchSize,
+	}
+}
+
+func ProvideMemoryBackfillConfig(cfg *config.Config) memorybackfill.Config {
+	return memorybackfill.Config{
+		WorkerEnabled: cfg.MemoryBackfillWorkerEnabled,
+		Interval:      cfg.MemoryBackfillInterval,
+		MaxMessages:   cfg.MemoryBackfillMaxMessages,
+	}
+}
+
+func ProvidePromptLibraryConfig(cfg *config.Config) promptlibrary.Config {
+	return promptlibrary.Config{
+		MaxPerUser: cfg.PromptLibraryMaxPerUser,
+	}
+}
+
+func ProvidePersonaConfig(cfg *config.Config) persona.Config {
+	return persona.Config{
+		MaxPerUser: cfg.PersonaMaxPerUser,
+	}
+}
+
+func ProvideDeltaSyncConfig(cfg *config.Config) deltasync.Config {
+	return deltasync.Config{
+		Retention: cfg.SyncRetention,
+	}
+}
+
+func ProvideMCPCredentialConfig(cfg *config.Config) mcpcredential.Config {
+	return mcpcredential.Config{
+		Keyring: cfg.MCPCredentialKeyring(),
+	}
+}
+
+func ProvideWebhookConfig(cfg *config.Config) webhook.Config {
+	return webhook.Config{
+		OpenAISecret:     cfg.OpenAIWebhookSecret,
+		OpenRouterSecret: cfg.OpenRouterWebhookSecret,
+		LiveKitAPIKey:    cfg.LiveKitAPIKey,
+		LiveKitAPISecret: cfg.LiveKitAPISecret,
+		Tolerance:        cfg.WebhookTolerance,
+	}
+}
+
+func ProvideAdmissionConfig(cfg *config.Config) admission.Config {
+	return admission.Config{
+		ModelLimits:    cfg.AdmissionModelLimits,
+		ProviderLimits: cfg.AdmissionProviderLimits,
+		QueueSize:      cfg.AdmissionQueueSize,
+		QueueTimeout:   cfg.AdmissionQueueTimeout,
+		RetryAfter:     cfg.AdmissionRetryAfter,
+	}
+}
+
+func ProvideRegionConfig(cfg *config.Config) region.Config {
+	return region.Config{
+		Mode:  cfg.RegionRoutingMode,
+		Local: cfg.Region,
+	}
+}
+
+// ProvideEventBus creates the event bus with the built-in webhook subscribers
+func ProvideEventBus() *event.Bus {
+	bus := event.NewBus()
+	webhook.Subscribe(bus)
+	return bus
+}
+
+func ProvidePromptProcessorConfig(cfg *config.Config, flags *featureflags.Client, log zerolog.Logger) prompt.ProcessorConfig {
 	return prompt.ProcessorConfig{
 		Enabled:         cfg.PromptOrchestrationEnabled,
 		EnableMemory:    cfg.PromptOrchestrationEnableMemory,
 		EnableTemplates: cfg.PromptOrchestrationEnableTemplates,
 		EnableTools:     cfg.PromptOrchestrationEnableTools,
+		Flags:           flags,
 	}
 }
 
@@ -1,8 +1,11 @@
 package query
 
+import "time"
+
 type Pagination struct {
-	Limit  *int
-	Offset *int
-	After  *uint
-	Order  string
+	Limit     *int
+	Offset    *int
+	After     *uint
+	AfterTime *time.Time // Sort timestamp of the After row, for lists ordered by time then ID
+	Order     string
 }
@@ -0,0 +1,120 @@
+// Package region steers completions to providers and endpoints in a preferred
+// region, so deployments spread across regions serve each request from the
+// nearest healthy inference endpoint. A request pins its region with the
+// X-Jan-Region header; otherwise the preference comes from the routing mode:
+// this replica's own region, or the region of the fastest probed endpoint.
+package region
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+// Header pins the region a request is served from
+const Header = "X-Jan-Region"
+
+// Config selects the routing mode
+type Config struct {
+	Mode  string // config.RegionRouting*
+	Local string // Region this replica runs in
+}
+
+// LatencySource reports the latest probed round trip to an endpoint
+type LatencySource interface {
+	EndpointLatency(url string) (time.Duration, bool)
+}
+
+type pinnedKey struct{}
+
+// WithPinned returns ctx carrying the region the caller asked for
+func WithPinned(ctx context.Context, region string) context.Context {
+	region = model.NormalizeRegion(region)
+	if region == "" {
+		return ctx
+	}
+	return context.WithValue(ctx, pinnedKey{}, region)
+}
+
+// Pinned returns the region the caller asked for, or ""
+func Pinned(ctx context.Context) string {
+	region, _ := ctx.Value(pinnedKey{}).(string)
+	return region
+}
+
+// Selector picks the preferred region and narrows candidates to it. A nil or
+// disabled selector leaves every candidate in place.
+type Selector struct {
+	cfg     Config
+	latency LatencySource // nil outside latency mode
+}
+
+// NewSelector creates the selector; latency may be nil unless Mode is latency
+func NewSelector(cfg Config, latency LatencySource) *Selector {
+	cfg.Local = model.NormalizeRegion(cfg.Local)
+	return &Selector{cfg: cfg, latency: latency}
+}
+
+// Enabled reports whether completions are routed by region
+func (s *Selector) Enabled() bool {
+	return s != nil && s.cfg.Mode != "" && s.cfg.Mode != config.RegionRoutingOff
+}
+
+// Sources of a region preference, as recorded in metrics
+const (
+	sourcePinned  = "pinned"
+	sourceLatency = "latency"
+	sourceLocal   = "local"
+)
+
+// Preferred returns the region to serve a request from among endpoints, whose
+// regions must be resolved (see model.Provider.RegionalEndpoints). It returns
+// "" when there is no preference.
+func (s *Selector) Preferred(ctx context.Context, endpoints model.EndpointList) string {
+	region, _ := s.preferred(ctx, endpoints)
+	return region
+}
+
+func (s *Selector) preferred(ctx context.Context, endpoints model.EndpointList) (string, string) {
+	if !s.Enabled() {
+		return "", ""
+	}
+	if pinned := Pinned(ctx); pinned != "" {
+		return pinned, sourcePinned
+	}
+	if s.cfg.Mode == config.RegionRoutingLatency && s.latency != nil {
+		var fastest string
+		var best time.Duration
+		for _, ep := range endpoints {
+			if !ep.Healthy || ep.Region == "" {
+				continue
+			}
+			latency, ok := s.latency.EndpointLatency(ep.URL)
+			if ok && (fastest == "" || latency < best) {
+				fastest, best = ep.Region, latency
+			}
+		}
+		if fastest != "" {
+			return fastest, sourceLatency
+		}
+	}
+	return s.cfg.Local, sourceLocal
+}
+
+// Filter returns the healthy endpoints in the preferred region. When none is
+// there it returns endpoints unchanged, so requests fail over to other regions.
+func (s *Selector) Filter(ctx context.Context, endpoints model.EndpointList) model.EndpointList {
+	region, source := s.preferred(ctx, endpoints)
+	if region == "" {
+		return endpoints
+	}
+	if inRegion := endpoints.InRegion(region); len(inRegion) > 0 {
+		metrics.RegionRoutingTotal.WithLabelValues(source, "in_region").Inc()
+		return inRegion
+	}
+	metrics.RegionRoutingTotal.WithLabelValues(source, "failover").Inc()
+	return endpoints
+}
@@ -4,6 +4,8 @@ package user
 import (
 	"context"
 	"errors"
+	"strconv"
+	"strings"
 	"time"
 )
 
@@ -37,6 +39,8 @@ type Repository interface {
 	FindByIssuerAndSubject(ctx context.Context, issuer, subject string) (*User, error)
 	FindByID(ctx context.Context, id uint) (*User, error)
 	Upsert(ctx context.Context, user *User) (*User, error)
+	// FindByLogin finds a user by identity provider subject, email or username
+	FindByLogin(ctx context.Context, login string) (*User, error)
 }
 
 // ErrInvalidIdentity indicates missing issuer or subject on the identity payload.
@@ -75,3 +79,17 @@ func (s *Service) EnsureUser(ctx context.Context, identity Identity) (*User, err
 
 	return s.repo.Upsert(ctx, user)
 }
+
+// FindByReference resolves a user from an operator-supplied reference: an internal
+// ID, an identity provider subject, an email address or a username. It returns nil
+// when no user matches.
+func (s *Service) FindByReference(ctx context.Context, ref string) (*User, error) {
+	ref = strings.TrimSpace(ref)
+	if ref == "" {
+		return nil, nil
+	}
+	if id, err := strconv.ParseUint(ref, 10, 64); err == nil {
+		return s.repo.FindByID(ctx, uint(id))
+	}
+	return s.repo.FindByLogin(ctx, ref)
+}
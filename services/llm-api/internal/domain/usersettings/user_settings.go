@@ -4,6 +4,8 @@ package usersettings
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"strings"
 	"time"
 )
 
@@ -116,9 +118,67 @@ func DefaultPreferences() map[string]interface{} {
 		"enable_thinking":      false,
 		"enable_search":        true,
 		"enable_browser":       false,
+		"locale":               "", // Language tag for dates and localized prompts; empty uses Accept-Language
+		"respond_in":           "", // Response language tag, or "auto" to follow the locale; empty disables
+		"timezone":             "", // IANA timezone name for the current date and time; empty uses server time
 	}
 }
 
+// maxLanguagePreferenceLength bounds the locale and respond_in preference values.
+const maxLanguagePreferenceLength = 35
+
+// ValidatePreferences checks the preference keys that carry typed values.
+func ValidatePreferences(preferences map[string]interface{}) error {
+	for _, key := range []string{"locale", "respond_in"} {
+		value, exists := preferences[key]
+		if !exists || value == nil {
+			continue
+		}
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("preferences.%s must be a string", key)
+		}
+		if len(strings.TrimSpace(str)) > maxLanguagePreferenceLength {
+			return fmt.Errorf("preferences.%s must be at most %d characters", key, maxLanguagePreferenceLength)
+		}
+	}
+	if value, exists := preferences["timezone"]; exists && value != nil {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("preferences.timezone must be a string")
+		}
+		if str = strings.TrimSpace(str); str != "" {
+			if _, err := time.LoadLocation(str); err != nil {
+				return fmt.Errorf("preferences.timezone must be an IANA timezone name such as \"Europe/Berlin\"")
+			}
+		}
+	}
+	return nil
+}
+
+// Locale returns the user's locale preference, or "" when unset.
+func (s *UserSettings) Locale() string {
+	return s.stringPreference("locale")
+}
+
+// RespondIn returns the user's response language preference, or "" when unset.
+func (s *UserSettings) RespondIn() string {
+	return s.stringPreference("respond_in")
+}
+
+// Timezone returns the user's IANA timezone preference, or "" when unset.
+func (s *UserSettings) Timezone() string {
+	return s.stringPreference("timezone")
+}
+
+func (s *UserSettings) stringPreference(key string) string {
+	if s == nil || s.Preferences == nil {
+		return ""
+	}
+	value, _ := s.Preferences[key].(string)
+	return strings.TrimSpace(value)
+}
+
 // DefaultUserSettings returns settings with safe defaults.
 func DefaultUserSettings(userID uint) *UserSettings {
 	return &UserSettings{
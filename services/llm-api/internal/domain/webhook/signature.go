@@ -0,0 +1,163 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// StandardVerifier checks Standard Webhooks signatures, as sent by OpenAI and
+// OpenRouter: an HMAC-SHA256 of "{webhook-id}.{webhook-timestamp}.{body}"
+type StandardVerifier struct {
+	secret    []byte
+	tolerance time.Duration
+}
+
+// NewStandardVerifier creates a verifier for a whsec_ signing secret; secrets
+// without the prefix are used as raw bytes
+func NewStandardVerifier(secret string, tolerance time.Duration) *StandardVerifier {
+	key := []byte(secret)
+	if encoded, ok := strings.CutPrefix(secret, "whsec_"); ok {
+		if decoded, err := base64.StdEncoding.DecodeString(encoded); err == nil {
+			key = decoded
+		}
+	}
+	return &StandardVerifier{secret: key, tolerance: tolerance}
+}
+
+// Verify implements Verifier
+func (v *StandardVerifier) Verify(header http.Header, body []byte, now time.Time) error {
+	id := header.Get("webhook-id")
+	timestamp := header.Get("webhook-timestamp")
+	signatures := header.Get("webhook-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return fmt.Errorf("%w: missing webhook-id, webhook-timestamp or webhook-signature", ErrInvalidSignature)
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: malformed webhook-timestamp", ErrInvalidSignature)
+	}
+	if err := checkAge(time.Unix(seconds, 0), now, v.tolerance); err != nil {
+		return err
+	}
+
+	mac := hmac.New(sha256.New, v.secret)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	// The header lists space-separated "v1,<base64>" signatures, one per active secret
+	for _, signature := range strings.Fields(signatures) {
+		version, value, ok := strings.Cut(signature, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err == nil && hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no matching signature", ErrInvalidSignature)
+}
+
+// parseStandard reads the id and type of a Standard Webhooks event body
+func parseStandard(header http.Header, body []byte) (Delivery, error) {
+	var payload struct {
+		ID        string `json:"id"`
+		Type      string `json:"type"`
+		CreatedAt int64  `json:"created_at"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Type == "" {
+		return Delivery{}, fmt.Errorf("%w: expected a JSON object with a type", ErrInvalidPayload)
+	}
+	delivery := Delivery{ID: header.Get("webhook-id"), Type: payload.Type}
+	if delivery.ID == "" {
+		delivery.ID = payload.ID
+	}
+	if payload.CreatedAt > 0 {
+		delivery.OccurredAt = time.Unix(payload.CreatedAt, 0).UTC()
+	}
+	return delivery, nil
+}
+
+// LiveKitVerifier checks LiveKit webhooks: the Authorization header carries a JWT
+// signed with the API secret whose sha256 claim is the base64 SHA-256 of the body
+type LiveKitVerifier struct {
+	apiKey    string
+	apiSecret []byte
+	tolerance time.Duration
+}
+
+// NewLiveKitVerifier creates a verifier for a LiveKit API key pair
+func NewLiveKitVerifier(apiKey, apiSecret string, tolerance time.Duration) *LiveKitVerifier {
+	return &LiveKitVerifier{apiKey: apiKey, apiSecret: []byte(apiSecret), tolerance: tolerance}
+}
+
+// Verify implements Verifier
+func (v *LiveKitVerifier) Verify(header http.Header, body []byte, now time.Time) error {
+	raw := strings.TrimSpace(strings.TrimPrefix(header.Get("Authorization"), "Bearer "))
+	if raw == "" {
+		return fmt.Errorf("%w: missing Authorization header", ErrInvalidSignature)
+	}
+
+	claims := struct {
+		jwt.RegisteredClaims
+		SHA256 string `json:"sha256"`
+	}{}
+	_, err := jwt.ParseWithClaims(raw, &claims, func(*jwt.Token) (any, error) {
+		return v.apiSecret, nil
+	},
+		jwt.WithValidMethods([]string{jwt.SigningMethodHS256.Alg()}),
+		jwt.WithIssuer(v.apiKey),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(v.tolerance),
+		jwt.WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidSignature, err)
+	}
+
+	sum := sha256.Sum256(body)
+	if subtle.ConstantTimeCompare([]byte(claims.SHA256), []byte(base64.StdEncoding.EncodeToString(sum[:]))) != 1 {
+		return fmt.Errorf("%w: body does not match the signed hash", ErrInvalidSignature)
+	}
+	return nil
+}
+
+// parseLiveKit reads the id and event name of a LiveKit webhook body
+func parseLiveKit(_ http.Header, body []byte) (Delivery, error) {
+	var payload struct {
+		ID        string          `json:"id"`
+		Event     string          `json:"event"`
+		CreatedAt json.RawMessage `json:"createdAt"` // Seconds; int64 is encoded as a string in protobuf JSON
+	}
+	if err := json.Unmarshal(body, &payload); err != nil || payload.Event == "" {
+		return Delivery{}, fmt.Errorf("%w: expected a JSON object with an event", ErrInvalidPayload)
+	}
+	delivery := Delivery{ID: payload.ID, Type: payload.Event}
+	if seconds, err := strconv.ParseInt(strings.Trim(string(payload.CreatedAt), `"`), 10, 64); err == nil && seconds > 0 {
+		delivery.OccurredAt = time.Unix(seconds, 0).UTC()
+	}
+	return delivery, nil
+}
+
+// checkAge rejects deliveries signed too long ago, or too far in the future
+func checkAge(signedAt, now time.Time, tolerance time.Duration) error {
+	if tolerance <= 0 {
+		return nil
+	}
+	if age := now.Sub(signedAt); age > tolerance || age < -tolerance {
+		return fmt.Errorf("%w: timestamp outside the %s tolerance", ErrInvalidSignature, tolerance)
+	}
+	return nil
+}
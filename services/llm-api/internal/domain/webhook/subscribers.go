@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+
+	"jan-server/services/llm-api/internal/domain/event"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// Subscribe registers the built-in reactions to provider webhooks. Other packages
+// react to webhooks by subscribing to the same bus.
+func Subscribe(bus *event.Bus) {
+	bus.Subscribe("webhook-log", SourceOpenAI+".*", logEvent)
+	bus.Subscribe("webhook-log", SourceOpenRouter+".*", logEvent)
+	bus.Subscribe("livekit-egress", EventLiveKitEgressEnded, logEgressEnded)
+}
+
+// logEvent records provider events, such as usage alerts, in the service log
+func logEvent(_ context.Context, e event.Event) error {
+	log := logger.GetLogger()
+	log.Info().
+		Str("event_id", e.ID).
+		Str("event_type", e.Type).
+		Time("occurred_at", e.OccurredAt).
+		RawJSON("payload", e.Payload).
+		Msg("received provider webhook")
+	return nil
+}
+
+// logEgressEnded reports finished LiveKit recordings, and failed ones as errors
+func logEgressEnded(_ context.Context, e event.Event) error {
+	var payload struct {
+		EgressInfo struct {
+			EgressID    string `json:"egressId"`
+			RoomName    string `json:"roomName"`
+			Status      string `json:"status"`
+			Error       string `json:"error"`
+			FileResults []struct {
+				Location string `json:"location"`
+			} `json:"fileResults"`
+		} `json:"egressInfo"`
+	}
+	if err := json.Unmarshal(e.Payload, &payload); err != nil {
+		return err
+	}
+	info := payload.EgressInfo
+	locations := make([]string, 0, len(info.FileResults))
+	for _, file := range info.FileResults {
+		locations = append(locations, file.Location)
+	}
+
+	log := logger.GetLogger()
+	entry := log.Info()
+	if info.Status != "EGRESS_COMPLETE" {
+		entry = log.Error().Str("error", info.Error)
+	}
+	entry.
+		Str("event_id", e.ID).
+		Str("egress_id", info.EgressID).
+		Str("room", info.RoomName).
+		Str("status", info.Status).
+		Strs("locations", locations).
+		Msg("livekit egress ended")
+	return nil
+}
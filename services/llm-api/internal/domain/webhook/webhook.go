@@ -0,0 +1,57 @@
+// Package webhook receives signed webhooks from model providers and LiveKit,
+// verifies them per source and publishes them on the event bus.
+package webhook
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// Sources that may deliver webhooks
+const (
+	SourceOpenAI     = "openai"
+	SourceOpenRouter = "openrouter"
+	SourceLiveKit    = "livekit"
+)
+
+// Event types the built-in subscribers react to
+const (
+	EventLiveKitEgressEnded = SourceLiveKit + ".egress_ended"
+)
+
+// MaxBodyBytes caps the size of a webhook body
+const MaxBodyBytes = 1 << 20
+
+var (
+	// ErrUnknownSource means the source is not supported or has no secret configured
+	ErrUnknownSource = errors.New("unknown webhook source")
+	// ErrInvalidSignature means the delivery is unsigned, wrongly signed or too old
+	ErrInvalidSignature = errors.New("invalid webhook signature")
+	// ErrInvalidPayload means the body is not an event the source sends
+	ErrInvalidPayload = errors.New("invalid webhook payload")
+)
+
+// Config holds the signing secrets per source; a source without one is rejected
+type Config struct {
+	OpenAISecret     string
+	OpenRouterSecret string
+	LiveKitAPIKey    string
+	LiveKitAPISecret string
+	Tolerance        time.Duration // Maximum age of a signed delivery
+}
+
+// Verifier checks that a delivery was signed by its source
+type Verifier interface {
+	Verify(header http.Header, body []byte, now time.Time) error
+}
+
+// Delivery is a verified webhook before it becomes an event
+type Delivery struct {
+	ID         string
+	Type       string // As named by the source, without the source prefix
+	OccurredAt time.Time
+}
+
+// Parser extracts the delivery ID and type from a verified body
+type Parser func(header http.Header, body []byte) (Delivery, error)
@@ -0,0 +1,138 @@
+package webhook
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/event"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+const (
+	// dedupWindow is how long delivery IDs are remembered; providers retry failed
+	// deliveries for hours, each time with a fresh signature
+	dedupWindow = 24 * time.Hour
+	// dedupMaxEntries caps the remembered IDs
+	dedupMaxEntries = 10000
+)
+
+type source struct {
+	verifier Verifier
+	parse    Parser
+}
+
+// Service verifies provider webhooks and publishes them on the event bus
+type Service struct {
+	sources map[string]source
+	bus     *event.Bus
+
+	mu   sync.Mutex
+	seen map[string]time.Time // Delivery key to expiry, for dropping redeliveries
+}
+
+// NewService creates the webhook service; only sources with secrets are accepted
+func NewService(cfg Config, bus *event.Bus) *Service {
+	sources := make(map[string]source)
+	if cfg.OpenAISecret != "" {
+		sources[SourceOpenAI] = source{NewStandardVerifier(cfg.OpenAISecret, cfg.Tolerance), parseStandard}
+	}
+	if cfg.OpenRouterSecret != "" {
+		sources[SourceOpenRouter] = source{NewStandardVerifier(cfg.OpenRouterSecret, cfg.Tolerance), parseStandard}
+	}
+	if cfg.LiveKitAPIKey != "" && cfg.LiveKitAPISecret != "" {
+		sources[SourceLiveKit] = source{NewLiveKitVerifier(cfg.LiveKitAPIKey, cfg.LiveKitAPISecret, cfg.Tolerance), parseLiveKit}
+	}
+	return &Service{
+		sources: sources,
+		bus:     bus,
+		seen:    make(map[string]time.Time),
+	}
+}
+
+// Sources lists the sources webhooks are accepted from
+func (s *Service) Sources() []string {
+	names := make([]string, 0, len(s.sources))
+	for name := range s.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Receive verifies a delivery from the named source and publishes it. It reports
+// false without publishing when the delivery was already received.
+func (s *Service) Receive(name string, header http.Header, body []byte) (*event.Event, bool, error) {
+	src, ok := s.sources[name]
+	if !ok {
+		metrics.WebhookDeliveriesTotal.WithLabelValues("unknown", "rejected").Inc()
+		return nil, false, ErrUnknownSource
+	}
+
+	now := time.Now().UTC()
+	if err := src.verifier.Verify(header, body, now); err != nil {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(name, "rejected").Inc()
+		return nil, false, err
+	}
+	delivery, err := src.parse(header, body)
+	if err != nil {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(name, "rejected").Inc()
+		return nil, false, err
+	}
+	if delivery.ID == "" {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(name, "rejected").Inc()
+		return nil, false, fmt.Errorf("%w: delivery has no id", ErrInvalidPayload)
+	}
+
+	e := &event.Event{
+		ID:         delivery.ID,
+		Source:     name,
+		Type:       name + "." + delivery.Type,
+		OccurredAt: delivery.OccurredAt,
+		ReceivedAt: now,
+		Payload:    append([]byte(nil), body...),
+	}
+	if e.OccurredAt.IsZero() {
+		e.OccurredAt = now
+	}
+
+	if !s.remember(name+":"+delivery.ID, now) {
+		metrics.WebhookDeliveriesTotal.WithLabelValues(name, "duplicate").Inc()
+		return e, false, nil
+	}
+	metrics.WebhookDeliveriesTotal.WithLabelValues(name, "accepted").Inc()
+	s.bus.Publish(*e)
+	return e, true, nil
+}
+
+// remember records a delivery key and reports whether it is new. Keys are kept per
+// instance, so a redelivery landing on another replica is published again.
+func (s *Service) remember(key string, now time.Time) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if expiry, ok := s.seen[key]; ok && now.Before(expiry) {
+		return false
+	}
+	if len(s.seen) >= dedupMaxEntries {
+		for k, expiry := range s.seen {
+			if !now.Before(expiry) {
+				delete(s.seen, k)
+			}
+		}
+		// Still full: forget the deliveries closest to expiring
+		for len(s.seen) >= dedupMaxEntries {
+			oldestKey, oldest := "", time.Time{}
+			for k, expiry := range s.seen {
+				if oldestKey == "" || expiry.Before(oldest) {
+					oldestKey, oldest = k, expiry
+				}
+			}
+			delete(s.seen, oldestKey)
+		}
+	}
+	s.seen[key] = now.Add(dedupWindow)
+	return true
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"sync/atomic"
 	"time"
@@ -28,6 +29,7 @@ type PrincipalClaims struct {
 	FeatureFlags      []string
 	Scopes            []string
 	Attributes        map[string]any
+	Guest             bool
 	ExpiresAt         time.Time
 	IssuedAt          time.Time
 	NotBefore         time.Time
@@ -40,12 +42,15 @@ type KeycloakValidator struct {
 	issuer          string
 	audience        string
 	authorizedParty string
-	jwksURL         string
-	logger          zerolog.Logger
-	refreshEvery    time.Duration
-	clockSkew       time.Duration
-	jwks            atomic.Pointer[keyfunc.JWKS]
-	lastErr         atomic.Value // stores lastErrWrap
+	// integrationParties are third-party clients whose exchanged tokens are accepted
+	// besides authorizedParty; their routes are limited by scope (see middlewares)
+	integrationParties []string
+	jwksURL            string
+	logger             zerolog.Logger
+	refreshEvery       time.Duration
+	clockSkew          time.Duration
+	jwks               atomic.Pointer[keyfunc.JWKS]
+	lastErr            atomic.Value // stores lastErrWrap
 }
 
 // lastErrWrap is a sentinel wrapper to avoid storing bare nil in atomic.Value.
@@ -64,6 +69,7 @@ func NewKeycloakValidator(
 	issuer,
 	audience,
 	authorizedParty string,
+	integrationParties []string,
 	refreshEvery,
 	clockSkew time.Duration,
 	logger zerolog.Logger,
@@ -73,13 +79,14 @@ func NewKeycloakValidator(
 	}
 
 	validator := &KeycloakValidator{
-		issuer:          issuer,
-		audience:        audience,
-		authorizedParty: authorizedParty,
-		jwksURL:         jwksURL,
-		logger:          logger,
-		refreshEvery:    refreshEvery,
-		clockSkew:       clockSkew,
+		issuer:             issuer,
+		audience:           audience,
+		authorizedParty:    authorizedParty,
+		integrationParties: integrationParties,
+		jwksURL:            jwksURL,
+		logger:             logger,
+		refreshEvery:       refreshEvery,
+		clockSkew:          clockSkew,
 	}
 	// Initialize with a non-nil wrapper value
 	validator.lastErr.Store(lastErrWrap{Err: nil})
@@ -215,7 +222,7 @@ func (v *KeycloakValidator) Validate(_ context.Context, rawToken string) (*Princ
 	name, _ := mapClaims["name"].(string)
 	picture, _ := mapClaims["picture"].(string)
 	azp := claimString(mapClaims["azp"])
-	if v.authorizedParty != "" && azp != "" && azp != v.authorizedParty {
+	if v.authorizedParty != "" && azp != "" && azp != v.authorizedParty && !slices.Contains(v.integrationParties, azp) {
 		return nil, errors.New("authorized party mismatch")
 	}
 
@@ -257,6 +264,9 @@ func (v *KeycloakValidator) Validate(_ context.Context, rawToken string) (*Princ
 		attributes = rawAttrs
 	}
 
+	// The guest claim maps the guest user attribute, set to "false" when the account is upgraded
+	guest := strings.EqualFold(claimString(mapClaims["guest"]), "true")
+
 	expires := jwtNumericTime(mapClaims["exp"])
 	issued := jwtNumericTime(mapClaims["iat"])
 	notBefore := jwtNumericTime(mapClaims["nbf"])
@@ -281,6 +291,7 @@ func (v *KeycloakValidator) Validate(_ context.Context, rawToken string) (*Princ
 		Groups:            groups,
 		FeatureFlags:      featureFlags,
 		Attributes:        attributes,
+		Guest:             guest,
 		ExpiresAt:         expires,
 		IssuedAt:          issued,
 		NotBefore:         notBefore,
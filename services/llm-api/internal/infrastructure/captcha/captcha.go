@@ -0,0 +1,55 @@
+// Package captcha verifies the CAPTCHA tokens guests solve once they send
+// many messages.
+package captcha
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/llm-api/internal/config"
+)
+
+// Verifier checks CAPTCHA tokens through the siteverify API of CAPTCHA_VERIFY_URL.
+type Verifier struct {
+	client *clients.CaptchaClient
+	secret string
+	log    zerolog.Logger
+}
+
+// NewVerifier creates a new CAPTCHA verifier. It returns nil when
+// CAPTCHA_SECRET is not set, which disables the CAPTCHA escalation.
+func NewVerifier(cfg *config.Config, log zerolog.Logger) *Verifier {
+	if cfg.CaptchaSecret == "" {
+		return nil
+	}
+
+	httpClient, err := httpclient.New(cfg.CaptchaHTTP)
+	if err != nil {
+		log.Warn().Err(err).Msg("[CaptchaVerifier] Invalid CAPTCHA_HTTP_* settings, CAPTCHA disabled")
+		return nil
+	}
+
+	return &Verifier{
+		client: clients.NewCaptchaClient(cfg.CaptchaVerifyURL, httpClient, cfg.CaptchaBreaker),
+		secret: cfg.CaptchaSecret,
+		log:    log.With().Str("component", "captcha-verifier").Logger(),
+	}
+}
+
+// Verify reports whether token is a valid, unused CAPTCHA solution.
+func (v *Verifier) Verify(ctx context.Context, token, remoteIP string) (bool, error) {
+	resp, err := v.client.SiteVerify(ctx, v.secret, token, remoteIP)
+	if err != nil {
+		v.log.Warn().Err(err).Int("status", clients.StatusCode(err)).Msg("[CaptchaVerifier] Verification request failed")
+		return false, fmt.Errorf("captcha verification failed: %w", err)
+	}
+	if !resp.Success {
+		v.log.Debug().Str("error_codes", strings.Join(resp.ErrorCodes, ",")).Msg("[CaptchaVerifier] Token rejected")
+	}
+	return resp.Success, nil
+}
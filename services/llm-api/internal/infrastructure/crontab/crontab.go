@@ -7,11 +7,21 @@ import (
 	"time"
 
 	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/analytics"
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/deltasync"
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
 	"jan-server/services/llm-api/internal/domain/model"
 	"jan-server/services/llm-api/internal/infrastructure/inference"
 	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/regionprobe"
+	"jan-server/services/llm-api/internal/infrastructure/vllmmetrics"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 
+	"github.com/janhq/jan-server/packages/go-common/leader"
 	"github.com/mileusna/crontab"
 )
 
@@ -25,26 +35,84 @@ type Crontab struct {
 	ctab              *crontab.Crontab
 	providerService   *model.ProviderService
 	inferenceProvider *inference.InferenceProvider
+	analyticsService  *analytics.Service
+	evalService       *eval.Service
+	backfillService   *memorybackfill.Service
+	syncService       *deltasync.Service
+	mcpCredentials    *mcpcredential.Service
+	conversations     *conversation.ConversationService
+	coldStorage       *coldstorage.Service
+	vllmMonitor       *vllmmetrics.Monitor
+	regionProber      *regionprobe.Prober
+	locker            leader.Locker   // nil when leader election is disabled
+	elector           *leader.Elector // nil elector is always the leader
+
+	reencryptMu      sync.Mutex
+	reencrypted      bool   // Whether stored secrets were moved onto reencryptedToKey
+	reencryptedToKey string // Master key version of the last complete re-encryption pass
 }
 
 func NewCrontab(
 	providerService *model.ProviderService,
 	inferenceProvider *inference.InferenceProvider,
+	analyticsService *analytics.Service,
+	evalService *eval.Service,
+	backfillService *memorybackfill.Service,
+	syncService *deltasync.Service,
+	mcpCredentials *mcpcredential.Service,
+	conversations *conversation.ConversationService,
+	coldStorage *coldstorage.Service,
+	locker leader.Locker,
+	vllmMonitor *vllmmetrics.Monitor,
+	regionProber *regionprobe.Prober,
 ) *Crontab {
 	return &Crontab{
 		ctab:              crontab.New(),
 		providerService:   providerService,
 		inferenceProvider: inferenceProvider,
+		analyticsService:  analyticsService,
+		evalService:       evalService,
+		backfillService:   backfillService,
+		syncService:       syncService,
+		mcpCredentials:    mcpCredentials,
+		conversations:     conversations,
+		coldStorage:       coldStorage,
+		locker:            locker,
+		vllmMonitor:       vllmMonitor,
+		regionProber:      regionProber,
 	}
 }
 
 func (c *Crontab) Run(ctx context.Context) error {
 	log := logger.GetLogger()
-	// execute once on server start
-	c.syncAllProviderModels(ctx)
+	cfg := config.GetGlobal()
+
+	if c.locker != nil {
+		// Only the leader runs model sync and rollups; it syncs once on taking over,
+		// which replaces the sync every replica used to run on server start.
+		c.elector = leader.NewElector(c.locker, leader.Config{
+			Identity:    cfg.PodName,
+			RetryPeriod: cfg.LeaderElectionRetryPeriod,
+			OnStartedLeading: func(leaderCtx context.Context) {
+				log.Info().Str("identity", c.elector.Identity()).Msg("Became crontab leader")
+				c.resetReencryption()
+				c.syncAllProviderModels(leaderCtx)
+			},
+			OnStoppedLeading: func() {
+				log.Warn().Str("identity", c.elector.Identity()).Msg("Lost crontab leadership")
+			},
+			OnError: func(err error) {
+				log.Error().Err(err).Msg("Leader election error")
+			},
+		})
+		go c.elector.Run(ctx)
+		log.Info().Str("backend", cfg.LeaderElectionBackend).Str("identity", c.elector.Identity()).Msg("Leader election started")
+	} else {
+		// execute once on server start
+		c.syncAllProviderModels(ctx)
+	}
 
 	// Schedule model sync job if enabled
-	cfg := config.GetGlobal()
 	if cfg != nil && cfg.ModelSyncEnabled {
 		syncInterval := cfg.ModelSyncIntervalMinutes
 		if syncInterval <= 0 {
@@ -53,15 +121,121 @@ func (c *Crontab) Run(ctx context.Context) error {
 
 		cronExpr := fmt.Sprintf("*/%d * * * *", syncInterval)
 		if err := c.ctab.AddJob(cronExpr, func() {
-			jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
-			defer cancel()
-			c.syncAllProviderModels(jobCtx)
+			c.elector.RunIfLeader(func() {
+				jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
+				defer cancel()
+				c.syncAllProviderModels(jobCtx)
+			})
 		}); err != nil {
 			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add model sync job")
 		}
 		log.Warn().Msgf("Model sync scheduled: every %d minute(s)", syncInterval)
 	}
 
+	// Schedule nightly analytics rollup
+	if cfg != nil && cfg.AnalyticsRollupEnabled {
+		lookbackDays := cfg.AnalyticsRollupLookbackDays
+		if err := c.ctab.AddJob(cfg.AnalyticsRollupSchedule, func() {
+			c.elector.RunIfLeader(func() {
+				jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
+				defer cancel()
+				c.rollupAnalytics(jobCtx, lookbackDays)
+			})
+		}); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add analytics rollup job")
+		}
+		log.Info().Msgf("Analytics rollup scheduled: %s", cfg.AnalyticsRollupSchedule)
+	}
+
+	// Prune the sync change log hourly
+	if cfg != nil && cfg.SyncRetention > 0 {
+		if err := c.ctab.AddJob("15 * * * *", func() {
+			c.elector.RunIfLeader(func() {
+				jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
+				defer cancel()
+				c.pruneSyncChanges(jobCtx)
+			})
+		}); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add sync prune job")
+		}
+		log.Info().Msgf("Sync change pruning scheduled: hourly, keeping %s", cfg.SyncRetention)
+	}
+
+	// Fail mcp_call items whose tool result never arrived
+	if cfg != nil && cfg.MCPCallTimeout > 0 {
+		timeout := cfg.MCPCallTimeout
+		if err := c.ctab.AddJob("*/5 * * * *", func() {
+			c.elector.RunIfLeader(func() {
+				jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
+				defer cancel()
+				c.failStaleMCPCalls(jobCtx, timeout)
+			})
+		}); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add stale mcp call job")
+		}
+		log.Info().Msgf("Stale mcp_call reconciliation scheduled: every 5 minutes, timeout %s", timeout)
+	}
+
+	// Move the items of idle conversations to cold storage
+	if cfg != nil && cfg.ColdStorageEnabled {
+		if err := c.ctab.AddJob(cfg.ColdStorageSchedule, func() {
+			c.elector.RunIfLeader(func() {
+				jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
+				defer cancel()
+				c.archiveIdleConversations(jobCtx)
+			})
+		}); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add cold storage job")
+		}
+		log.Info().Msgf("Cold storage scheduled: %s, archiving conversations idle for %d month(s)", cfg.ColdStorageSchedule, cfg.ColdStorageAfterMonths)
+	}
+
+	// Pick up eval runs queued while no worker was draining, or left stale by a restart.
+	// Every replica wakes its own worker; runs are claimed in the database.
+	if cfg != nil && cfg.EvalWorkerEnabled {
+		if err := c.ctab.AddJob("* * * * *", func() {
+			c.evalService.Wake()
+		}); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add eval worker job")
+		}
+		log.Info().Msg("Eval worker scheduled: every minute")
+	}
+
+	// Likewise for memory backfills; Wake is a no-op while memory is disabled
+	if cfg != nil && cfg.MemoryBackfillWorkerEnabled {
+		if err := c.ctab.AddJob("* * * * *", func() {
+			c.backfillService.Wake()
+		}); err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add memory backfill worker job")
+		}
+		log.Info().Msg("Memory backfill worker scheduled: every minute")
+	}
+
+	// Re-encrypt stored secrets once the master key rotates (and once per leadership,
+	// in case a previous leader stopped midway)
+	if err := c.ctab.AddJob("* * * * *", func() {
+		c.elector.RunIfLeader(func() {
+			jobCtx, cancel := context.WithTimeout(context.Background(), CronJobTimeout)
+			defer cancel()
+			c.reencryptSecrets(jobCtx)
+		})
+	}); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "failed to add secret re-encryption job")
+	}
+
+	// Scrape vLLM load on every replica, since each one routes its own requests.
+	// Cron has minute resolution, so this runs on its own ticker.
+	if c.vllmMonitor != nil && c.vllmMonitor.Interval() > 0 {
+		go c.runVLLMMonitor(ctx, c.vllmMonitor.Interval())
+		log.Info().Msgf("vLLM metrics scraping scheduled: every %s", c.vllmMonitor.Interval())
+	}
+
+	// Likewise probe endpoint latency for region routing; latency is per replica
+	if c.regionProber != nil && c.regionProber.Interval() > 0 {
+		go c.runRegionProber(ctx, c.regionProber.Interval())
+		log.Info().Msgf("Endpoint latency probes scheduled: every %s", c.regionProber.Interval())
+	}
+
 	// Schedule environment reload job
 	if err := c.ctab.AddJob("* * * * *", func() {
 		// Reload config
@@ -127,3 +301,142 @@ func (c *Crontab) syncProviderModels(ctx context.Context, provider *model.Provid
 
 	log.Info().Msgf("Synced %d models", len(models))
 }
+
+func (c *Crontab) rollupAnalytics(ctx context.Context, lookbackDays int) {
+	log := logger.GetLogger()
+
+	if err := c.analyticsService.Rollup(ctx, time.Now(), lookbackDays); err != nil {
+		log.Error().Err(err).Msg("Failed to roll up analytics")
+		return
+	}
+
+	log.Info().Msgf("Rolled up analytics for the last %d day(s)", lookbackDays)
+}
+
+func (c *Crontab) pruneSyncChanges(ctx context.Context) {
+	log := logger.GetLogger()
+
+	pruned, err := c.syncService.Prune(ctx)
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to prune sync changes")
+		return
+	}
+
+	if pruned > 0 {
+		log.Info().Msgf("Pruned %d sync change(s)", pruned)
+	}
+}
+
+func (c *Crontab) failStaleMCPCalls(ctx context.Context, timeout time.Duration) {
+	log := logger.GetLogger()
+
+	failed, err := c.conversations.FailStaleMCPCalls(ctx, nil, timeout, conversation.ReconcileTriggerScheduled)
+	if err != nil {
+		log.Error().Err(err).Int64("failed", failed).Msg("Failed to reconcile stale mcp calls")
+		return
+	}
+
+	if failed > 0 {
+		log.Info().Msgf("Marked %d mcp call(s) failed after %s in progress", failed, timeout)
+	}
+}
+
+func (c *Crontab) archiveIdleConversations(ctx context.Context) {
+	log := logger.GetLogger()
+
+	result, err := c.coldStorage.ArchiveIdle(ctx, time.Now())
+	if err != nil {
+		log.Error().Err(err).Msg("Failed to archive idle conversations")
+		return
+	}
+
+	if result.Conversations > 0 || result.Failed > 0 {
+		log.Info().Msgf("Archived %d item(s) of %d conversation(s) to cold storage, %d failed", result.Items, result.Conversations, result.Failed)
+	}
+}
+
+func (c *Crontab) runVLLMMonitor(ctx context.Context, interval time.Duration) {
+	log := logger.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		scrapeCtx, cancel := context.WithTimeout(ctx, interval)
+		providers, err := c.providerService.FindAllActiveProviders(scrapeCtx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list providers for vLLM metrics")
+		} else {
+			c.vllmMonitor.Scrape(scrapeCtx, providers)
+		}
+		cancel()
+	}
+}
+
+func (c *Crontab) runRegionProber(ctx context.Context, interval time.Duration) {
+	log := logger.GetLogger()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		probeCtx, cancel := context.WithTimeout(ctx, interval)
+		providers, err := c.providerService.FindAllActiveProviders(probeCtx)
+		if err != nil {
+			log.Error().Err(err).Msg("Failed to list providers for latency probes")
+		} else {
+			c.regionProber.Probe(probeCtx, providers)
+		}
+		cancel()
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (c *Crontab) resetReencryption() {
+	c.reencryptMu.Lock()
+	defer c.reencryptMu.Unlock()
+	c.reencrypted = false
+}
+
+// reencryptSecrets moves provider API keys and MCP credential tokens onto the
+// current master key, unless a pass already completed for that key version
+func (c *Crontab) reencryptSecrets(ctx context.Context) {
+	log := logger.GetLogger()
+
+	if !c.reencryptMu.TryLock() {
+		return // The previous pass is still running
+	}
+	defer c.reencryptMu.Unlock()
+
+	version := config.GetGlobal().ProviderKeyring().Version()
+	if c.reencrypted && c.reencryptedToKey == version {
+		return
+	}
+
+	providerKeys, providerErr := c.providerService.ReencryptAPIKeys(ctx)
+	if providerErr != nil {
+		log.Error().Err(providerErr).Msg("Failed to re-encrypt some provider API keys")
+	}
+	tokens, tokenErr := c.mcpCredentials.ReencryptTokens(ctx)
+	if tokenErr != nil {
+		log.Error().Err(tokenErr).Msg("Failed to re-encrypt some MCP credentials")
+	}
+	if providerKeys > 0 || tokens > 0 {
+		log.Info().Str("key_version", version).Msgf("Re-encrypted %d provider API key(s) and %d MCP credential(s)", providerKeys, tokens)
+	}
+
+	// Values that failed need an operator (usually a missing old key), so a
+	// failed pass is not retried every minute; it runs again on the next
+	// rotation or leadership change.
+	c.reencrypted = true
+	c.reencryptedToKey = version
+}
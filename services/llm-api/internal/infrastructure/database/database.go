@@ -6,6 +6,7 @@ import (
 
 	"jan-server/services/llm-api/internal/infrastructure/logger"
 
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
@@ -20,23 +21,56 @@ func RegisterSchemaForAutoMigrate(models ...interface{}) {
 
 var DB *gorm.DB
 
+// Guardrails bound how long a statement may run and which are logged as slow
+type Guardrails struct {
+	StatementTimeout   time.Duration // Postgres statement_timeout; 0 keeps the server default
+	SlowQueryThreshold time.Duration // 0 logs only statements aborted by the timeout
+}
+
 // Config holds database configuration
 type Config struct {
+	Name        string // db_name label of the pool and query metrics
 	DatabaseURL string
 	MaxIdle     int
 	MaxOpen     int
 	MaxLifetime time.Duration
 	LogLevel    gormlogger.LogLevel
+	Guardrails  Guardrails
 }
 
 // Connect creates a new database connection with the given configuration
 func Connect(cfg Config) (*gorm.DB, error) {
-	db, err := gorm.Open(postgres.Open(cfg.DatabaseURL), &gorm.Config{
+	db, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	log := logger.GetLogger()
+	log.Info().Msg("Successfully connected to database")
+	DB = db
+	return DB, nil
+}
+
+// ConnectReplica opens a read replica connection. Unlike Connect it does not
+// replace the global DB handle.
+func ConnectReplica(cfg Config) (*gorm.DB, error) {
+	db, err := open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	log := logger.GetLogger()
+	log.Info().Msg("Successfully connected to read replica")
+	return db, nil
+}
+
+func open(cfg Config) (*gorm.DB, error) {
+	dsn := withStatementTimeout(cfg.DatabaseURL, cfg.Guardrails.StatementTimeout)
+	db, err := gorm.Open(postgres.Open(dsn), &gorm.Config{
 		NamingStrategy: schema.NamingStrategy{
 			TablePrefix:   "llm_api.",
 			SingularTable: false,
 		},
-		Logger: gormlogger.Default.LogMode(cfg.LogLevel),
+		Logger: newSlowQueryLogger(gormlogger.Default, cfg.Guardrails.SlowQueryThreshold).LogMode(cfg.LogLevel),
 	})
 	if err != nil {
 		log := logger.GetLogger()
@@ -56,20 +90,41 @@ func Connect(cfg Config) (*gorm.DB, error) {
 	sqlDB.SetMaxOpenConns(cfg.MaxOpen)
 	sqlDB.SetConnMaxLifetime(cfg.MaxLifetime)
 
-	log := logger.GetLogger()
-	log.Info().Msg("Successfully connected to database")
-	DB = db
-	return DB, nil
+	if cfg.Name != "" {
+		if err := gocommonmetrics.RegisterDBPool(sqlDB, cfg.Name); err != nil {
+			return nil, fmt.Errorf("register db pool metrics: %w", err)
+		}
+		if err := db.Use(gocommonmetrics.NewGormPlugin(cfg.Name)); err != nil {
+			return nil, fmt.Errorf("register db query metrics: %w", err)
+		}
+	}
+	return db, nil
 }
 
 // NewDB creates a new database connection using DSN
-func NewDB(dsn string) (*gorm.DB, error) {
+func NewDB(dsn string, guardrails Guardrails) (*gorm.DB, error) {
 	return Connect(Config{
+		Name:        "llm_api",
+		DatabaseURL: dsn,
+		MaxIdle:     10,
+		MaxOpen:     25,
+		MaxLifetime: 1 * time.Hour,
+		LogLevel:    gormlogger.Silent,
+		Guardrails:  guardrails,
+	})
+}
+
+// NewReplicaDB creates a read replica connection using DSN; name labels its
+// metrics (e.g. llm_api_replica_1)
+func NewReplicaDB(dsn, name string, guardrails Guardrails) (*gorm.DB, error) {
+	return ConnectReplica(Config{
+		Name:        name,
 		DatabaseURL: dsn,
 		MaxIdle:     10,
 		MaxOpen:     25,
 		MaxLifetime: 1 * time.Hour,
 		LogLevel:    gormlogger.Silent,
+		Guardrails:  guardrails,
 	})
 }
 
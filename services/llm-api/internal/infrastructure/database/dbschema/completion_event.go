@@ -0,0 +1,75 @@
+package dbschema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/analytics"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(CompletionEvent{})
+}
+
+// CompletionEvent is the raw analytics record of one chat completion
+type CompletionEvent struct {
+	ID               uint `gorm:"primarykey"`
+	UserID           uint `gorm:"not null"`
+	ConversationID   *uint
+	Model            string         `gorm:"type:varchar(255);not null"`
+	Provider         string         `gorm:"type:varchar(255);not null;default:''"`
+	PromptTokens     int            `gorm:"not null;default:0"`
+	CompletionTokens int            `gorm:"not null;default:0"`
+	LatencyMs        int64          `gorm:"not null;default:0"`
+	ToolCalls        JSONStringList `gorm:"type:jsonb"`
+	Stream           bool           `gorm:"not null;default:false"`
+	Succeeded        bool           `gorm:"not null;default:true"`
+	CreatedAt        time.Time      `gorm:"index:idx_completion_events_created_at;not null"`
+}
+
+// TableName returns the custom table name for completion events
+func (CompletionEvent) TableName() string {
+	return "llm_api.completion_events"
+}
+
+// NewSchemaCompletionEvent creates a database schema from a domain completion event
+func NewSchemaCompletionEvent(e *analytics.CompletionEvent) *CompletionEvent {
+	return &CompletionEvent{
+		ID:               e.ID,
+		UserID:           e.UserID,
+		ConversationID:   e.ConversationID,
+		Model:            e.Model,
+		Provider:         e.Provider,
+		PromptTokens:     e.PromptTokens,
+		CompletionTokens: e.CompletionTokens,
+		LatencyMs:        e.LatencyMs,
+		ToolCalls:        JSONStringList(e.ToolCalls),
+		Stream:           e.Stream,
+		Succeeded:        e.Succeeded,
+		CreatedAt:        e.CreatedAt,
+	}
+}
+
+// JSONStringList is a custom type for a string array stored as JSON
+type JSONStringList []string
+
+func (j JSONStringList) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
+
+func (j *JSONStringList) Scan(value any) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, j)
+}
@@ -22,6 +22,7 @@ type Conversation struct {
 	PublicID        string                          `gorm:"type:varchar(50);uniqueIndex;not null"`
 	Object          string                          `gorm:"type:varchar(50);not null;default:'conversation'"`
 	Title           *string                         `gorm:"type:varchar(256)"`
+	TitleLocked     bool                            `gorm:"not null;default:false"` // Set by manual renames; blocks automatic title generation
 	UserID          uint                            `gorm:"index:idx_conversation_user_referrer;index:idx_conversation_user_status;not null"`
 	User            User                            `gorm:"foreignKey:UserID"`
 	ProjectID       *uint                           `gorm:"index:idx_conversations_project_updated_at"`                 // Optional project grouping
@@ -36,6 +37,9 @@ type Conversation struct {
 	InstructionVersion           int     `gorm:"not null;default:1"` // Version of project instruction when conversation was created
 	EffectiveInstructionSnapshot *string `gorm:"type:text"`          // Snapshot of merged instruction for reproducibility
 
+	// Optimistic concurrency: bumped on every update, compared on write
+	Version int `gorm:"not null;default:1"`
+
 	Items    []ConversationItem   `gorm:"foreignKey:ConversationID"`
 	Branches []ConversationBranch `gorm:"foreignKey:ConversationID"`
 }
@@ -83,6 +87,7 @@ type ConversationItem struct {
 	Arguments                *string      `gorm:"type:text"`
 	Output                   *string      `gorm:"type:text"`
 	Error                    *string      `gorm:"type:text"`
+	ErrorDetail              *JSONToolError `gorm:"type:jsonb"`
 	Action                   JSONAction   `gorm:"type:jsonb"`
 	Tools                    JSONMcpTools `gorm:"type:jsonb"`
 	PendingSafetyChecks      JSONSafetyChecks `gorm:"type:jsonb"`
@@ -93,6 +98,18 @@ type ConversationItem struct {
 	MaxOutputLength          *int64       `gorm:"type:bigint"`
 	ShellOutputs             JSONShellOutputs `gorm:"type:jsonb"`
 	Operation                JSONOperation `gorm:"type:jsonb"`
+
+	// Soft-edit marker; previous values live in conversation_item_edits
+	EditedAt *time.Time `gorm:"type:timestamp"`
+
+	// Encryption at rest: when DataKeyID is set, Content, Arguments and Output
+	// are NULL and live sealed in ContentCiphertext
+	ContentCiphertext []byte `gorm:"type:bytea"`
+	DataKeyID         *uint  `gorm:"index"`
+
+	// Cold storage: when ColdArchiveID is set, the payload columns are NULL and
+	// live in the ConversationItemArchive it names
+	ColdArchiveID *uint `gorm:"index"`
 }
 
 // JSONMap is a custom type for map[string]string stored as JSON
@@ -157,6 +174,24 @@ func (j *JSONIncompleteDetails) Scan(value any) error {
 	return json.Unmarshal(bytes, j)
 }
 
+// JSONToolError is a custom type for ToolError stored as JSON
+type JSONToolError conversation.ToolError
+
+func (j JSONToolError) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
+
+func (j *JSONToolError) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return fmt.Errorf("expected []byte, got %T", value)
+	}
+	return json.Unmarshal(bytes, j)
+}
+
 // JSONAction is a custom type for action map stored as JSON
 type JSONAction map[string]interface{}
 
@@ -301,6 +336,7 @@ func NewSchemaConversation(c *conversation.Conversation) *Conversation {
 		PublicID:                     c.PublicID,
 		Object:                       c.Object,
 		Title:                        c.Title,
+		TitleLocked:                  c.TitleLocked,
 		UserID:                       c.UserID,
 		ProjectID:                    c.ProjectID,
 		ProjectPublicID:              c.ProjectPublicID,
@@ -311,6 +347,7 @@ func NewSchemaConversation(c *conversation.Conversation) *Conversation {
 		IsPrivate:                    &isPrivate,
 		InstructionVersion:           c.InstructionVersion,
 		EffectiveInstructionSnapshot: c.EffectiveInstructionSnapshot,
+		Version:                      c.Version,
 	}
 }
 
@@ -356,6 +393,7 @@ func (c *Conversation) EtoD() *conversation.Conversation {
 		PublicID:                     c.PublicID,
 		Object:                       c.Object,
 		Title:                        c.Title,
+		TitleLocked:                  c.TitleLocked,
 		UserID:                       c.UserID,
 		ProjectID:                    c.ProjectID,
 		ProjectPublicID:              c.ProjectPublicID,
@@ -367,6 +405,7 @@ func (c *Conversation) EtoD() *conversation.Conversation {
 		IsPrivate:                    isPrivate,
 		InstructionVersion:           c.InstructionVersion,
 		EffectiveInstructionSnapshot: c.EffectiveInstructionSnapshot,
+		Version:                      c.Version,
 		CreatedAt:                    c.CreatedAt,
 		UpdatedAt:                    c.UpdatedAt,
 	}
@@ -450,6 +489,7 @@ func NewSchemaConversationItem(item *conversation.Item) *ConversationItem {
 	}
 	schemaItem.RatedAt = item.RatedAt
 	schemaItem.RatingComment = item.RatingComment
+	schemaItem.EditedAt = item.EditedAt
 
 	// Convert OpenAI-compatible fields
 	schemaItem.CallID = item.CallID
@@ -458,6 +498,10 @@ func NewSchemaConversationItem(item *conversation.Item) *ConversationItem {
 	schemaItem.Arguments = item.Arguments
 	schemaItem.Output = item.Output
 	schemaItem.Error = item.Error
+	if item.ErrorDetail != nil {
+		detail := JSONToolError(*item.ErrorDetail)
+		schemaItem.ErrorDetail = &detail
+	}
 	schemaItem.Approve = item.Approve
 	schemaItem.Reason = item.Reason
 	schemaItem.MaxOutputLength = item.MaxOutputLength
@@ -530,6 +574,9 @@ func (i *ConversationItem) EtoD() *conversation.Item {
 	}
 	item.RatedAt = i.RatedAt
 	item.RatingComment = i.RatingComment
+	item.EditedAt = i.EditedAt
+	item.Edited = i.EditedAt != nil
+	item.ColdStored = i.ColdArchiveID != nil
 
 	// Convert OpenAI-compatible fields
 	item.CallID = i.CallID
@@ -538,6 +585,10 @@ func (i *ConversationItem) EtoD() *conversation.Item {
 	item.Arguments = i.Arguments
 	item.Output = i.Output
 	item.Error = i.Error
+	if i.ErrorDetail != nil {
+		detail := conversation.ToolError(*i.ErrorDetail)
+		item.ErrorDetail = &detail
+	}
 	item.Approve = i.Approve
 	item.Reason = i.Reason
 	item.MaxOutputLength = i.MaxOutputLength
@@ -0,0 +1,52 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(ConversationItemArchive{})
+}
+
+// ConversationItemArchive is a media-api object holding the payload of
+// conversation items moved to cold storage
+type ConversationItemArchive struct {
+	ID             uint   `gorm:"primarykey"`
+	ConversationID uint   `gorm:"not null;index"`
+	MediaID        string `gorm:"type:varchar(64);not null"`
+	ItemCount      int    `gorm:"not null;default:0"`
+	Bytes          int64  `gorm:"not null;default:0"` // Size of the gzipped object
+	CreatedAt      time.Time
+}
+
+// TableName returns the custom table name for conversation item archives
+func (ConversationItemArchive) TableName() string {
+	return "llm_api.conversation_item_archives"
+}
+
+// NewSchemaConversationItemArchive creates a database schema from a domain archive
+func NewSchemaConversationItemArchive(a *coldstorage.Archive) *ConversationItemArchive {
+	return &ConversationItemArchive{
+		ID:             a.ID,
+		ConversationID: a.ConversationID,
+		MediaID:        a.MediaID,
+		ItemCount:      a.ItemCount,
+		Bytes:          a.Bytes,
+		CreatedAt:      a.CreatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain archive
+func (a *ConversationItemArchive) EtoD() *coldstorage.Archive {
+	return &coldstorage.Archive{
+		ID:             a.ID,
+		ConversationID: a.ConversationID,
+		MediaID:        a.MediaID,
+		ItemCount:      a.ItemCount,
+		Bytes:          a.Bytes,
+		CreatedAt:      a.CreatedAt,
+	}
+}
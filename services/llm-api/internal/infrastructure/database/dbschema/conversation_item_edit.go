@@ -0,0 +1,61 @@
+package dbschema
+
+import (
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(ConversationItemEdit{})
+}
+
+// ConversationItemEdit stores the state of a conversation item before an in-place edit
+type ConversationItemEdit struct {
+	BaseModel
+	ItemID          uint        `gorm:"index:idx_conversation_item_edits_item_id;not null"`
+	ConversationID  uint        `gorm:"not null"`
+	EditorUserID    *uint       `gorm:"index"`
+	PreviousContent JSONContent `gorm:"type:jsonb"`
+	PreviousOutput  *string     `gorm:"type:text"`
+	Reason          *string     `gorm:"type:text"`
+
+	// Encryption at rest: when DataKeyID is set, the previous values live
+	// sealed in PreviousCiphertext
+	PreviousCiphertext []byte `gorm:"type:bytea"`
+	DataKeyID          *uint
+}
+
+// TableName returns the custom table name for conversation item edits
+func (ConversationItemEdit) TableName() string {
+	return "llm_api.conversation_item_edits"
+}
+
+// NewSchemaConversationItemEdit creates a database schema from a domain item edit
+func NewSchemaConversationItemEdit(e *conversation.ItemEdit) *ConversationItemEdit {
+	return &ConversationItemEdit{
+		BaseModel: BaseModel{
+			ID:        e.ID,
+			CreatedAt: e.CreatedAt,
+		},
+		ItemID:          e.ItemID,
+		ConversationID:  e.ConversationID,
+		EditorUserID:    e.EditorUserID,
+		PreviousContent: JSONContent(e.PreviousContent),
+		PreviousOutput:  e.PreviousOutput,
+		Reason:          e.Reason,
+	}
+}
+
+// EtoD converts a database item edit to a domain item edit
+func (e *ConversationItemEdit) EtoD() *conversation.ItemEdit {
+	return &conversation.ItemEdit{
+		ID:              e.ID,
+		ItemID:          e.ItemID,
+		ConversationID:  e.ConversationID,
+		EditorUserID:    e.EditorUserID,
+		PreviousContent: []conversation.Content(e.PreviousContent),
+		PreviousOutput:  e.PreviousOutput,
+		Reason:          e.Reason,
+		CreatedAt:       e.CreatedAt,
+	}
+}
@@ -0,0 +1,286 @@
+package dbschema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"gorm.io/datatypes"
+
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(EvalDataset{})
+	database.RegisterSchemaForAutoMigrate(EvalDatasetItem{})
+	database.RegisterSchemaForAutoMigrate(EvalRun{})
+	database.RegisterSchemaForAutoMigrate(EvalRunResult{})
+}
+
+// EvalDataset is an uploaded set of eval prompts
+type EvalDataset struct {
+	ID          uint           `gorm:"primarykey"`
+	PublicID    string         `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Name        string         `gorm:"type:varchar(255);not null"`
+	Description *string        `gorm:"type:text"`
+	Grader      JSONGraderSpec `gorm:"type:jsonb;not null"`
+	ItemCount   int            `gorm:"not null;default:0"`
+	CreatedBy   *string        `gorm:"type:varchar(255)"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName returns the custom table name for eval datasets
+func (EvalDataset) TableName() string {
+	return "llm_api.eval_datasets"
+}
+
+// EvalDatasetItem is one prompt of an eval dataset
+type EvalDatasetItem struct {
+	ID        uint            `gorm:"primarykey"`
+	DatasetID uint            `gorm:"uniqueIndex:idx_eval_dataset_items_position;not null"`
+	Position  int             `gorm:"uniqueIndex:idx_eval_dataset_items_position;not null"`
+	Input     string          `gorm:"type:text;not null"`
+	Variables datatypes.JSON  `gorm:"type:jsonb"`
+	Expected  string          `gorm:"type:text;not null;default:''"`
+	Grader    *JSONGraderSpec `gorm:"type:jsonb"`
+}
+
+// TableName returns the custom table name for eval dataset items
+func (EvalDatasetItem) TableName() string {
+	return "llm_api.eval_dataset_items"
+}
+
+// EvalRun is one execution of a dataset against a model
+type EvalRun struct {
+	ID               uint    `gorm:"primarykey"`
+	PublicID         string  `gorm:"type:varchar(64);uniqueIndex;not null"`
+	DatasetID        uint    `gorm:"index:idx_eval_runs_dataset_id;not null"`
+	Model            string  `gorm:"type:varchar(255);not null"`
+	PromptTemplateID *string `gorm:"type:varchar(64)"`
+	TemplateKey      *string `gorm:"type:varchar(100)"`
+	TemplateVersion  *int
+	SystemPrompt     string  `gorm:"type:text;not null;default:''"`
+	Status           string  `gorm:"type:varchar(20);not null;default:'queued'"`
+	TotalItems       int     `gorm:"not null;default:0"`
+	CompletedItems   int     `gorm:"not null;default:0"`
+	PassedItems      int     `gorm:"not null;default:0"`
+	ErroredItems     int     `gorm:"not null;default:0"`
+	AverageScore     float64 `gorm:"not null;default:0"`
+	Error            string  `gorm:"type:text;not null;default:''"`
+	CreatedBy        *string `gorm:"type:varchar(255)"`
+	StartedAt        *time.Time
+	CompletedAt      *time.Time
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// TableName returns the custom table name for eval runs
+func (EvalRun) TableName() string {
+	return "llm_api.eval_runs"
+}
+
+// EvalRunResult is the graded output of one item in a run
+type EvalRunResult struct {
+	ID        uint    `gorm:"primarykey"`
+	RunID     uint    `gorm:"uniqueIndex:idx_eval_run_results_item;not null"`
+	ItemID    uint    `gorm:"uniqueIndex:idx_eval_run_results_item;not null"`
+	Position  int     `gorm:"not null"`
+	Output    string  `gorm:"type:text;not null;default:''"`
+	Score     float64 `gorm:"not null;default:0"`
+	Passed    bool    `gorm:"not null;default:false"`
+	Grader    string  `gorm:"type:varchar(20);not null"`
+	Reasoning string  `gorm:"type:text;not null;default:''"`
+	LatencyMs int64   `gorm:"not null;default:0"`
+	Error     string  `gorm:"type:text;not null;default:''"`
+	CreatedAt time.Time
+}
+
+// TableName returns the custom table name for eval run results
+func (EvalRunResult) TableName() string {
+	return "llm_api.eval_run_results"
+}
+
+// JSONGraderSpec is a grader spec stored as JSON
+type JSONGraderSpec eval.GraderSpec
+
+func (j JSONGraderSpec) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
+
+func (j *JSONGraderSpec) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+// NewSchemaEvalDataset creates a database schema from a domain dataset
+func NewSchemaEvalDataset(d *eval.Dataset) *EvalDataset {
+	return &EvalDataset{
+		ID:          d.ID,
+		PublicID:    d.PublicID,
+		Name:        d.Name,
+		Description: d.Description,
+		Grader:      JSONGraderSpec(d.Grader),
+		ItemCount:   d.ItemCount,
+		CreatedBy:   d.CreatedBy,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain dataset
+func (m *EvalDataset) EtoD() *eval.Dataset {
+	return &eval.Dataset{
+		ID:          m.ID,
+		PublicID:    m.PublicID,
+		Name:        m.Name,
+		Description: m.Description,
+		Grader:      eval.GraderSpec(m.Grader),
+		ItemCount:   m.ItemCount,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
+
+// NewSchemaEvalDatasetItem creates a database schema from a domain dataset item
+func NewSchemaEvalDatasetItem(datasetID uint, item *eval.DatasetItem) (*EvalDatasetItem, error) {
+	var variables datatypes.JSON
+	if len(item.Variables) > 0 {
+		data, err := json.Marshal(item.Variables)
+		if err != nil {
+			return nil, err
+		}
+		variables = datatypes.JSON(data)
+	}
+	var grader *JSONGraderSpec
+	if item.Grader != nil {
+		g := JSONGraderSpec(*item.Grader)
+		grader = &g
+	}
+	return &EvalDatasetItem{
+		DatasetID: datasetID,
+		Position:  item.Position,
+		Input:     item.Input,
+		Variables: variables,
+		Expected:  item.Expected,
+		Grader:    grader,
+	}, nil
+}
+
+// EtoD converts the database schema to a domain dataset item
+func (m *EvalDatasetItem) EtoD() *eval.DatasetItem {
+	var variables map[string]any
+	if len(m.Variables) > 0 {
+		_ = json.Unmarshal(m.Variables, &variables)
+	}
+	var grader *eval.GraderSpec
+	if m.Grader != nil {
+		g := eval.GraderSpec(*m.Grader)
+		grader = &g
+	}
+	return &eval.DatasetItem{
+		ID:        m.ID,
+		DatasetID: m.DatasetID,
+		Position:  m.Position,
+		Input:     m.Input,
+		Variables: variables,
+		Expected:  m.Expected,
+		Grader:    grader,
+	}
+}
+
+// NewSchemaEvalRun creates a database schema from a domain run
+func NewSchemaEvalRun(r *eval.Run) *EvalRun {
+	return &EvalRun{
+		ID:               r.ID,
+		PublicID:         r.PublicID,
+		DatasetID:        r.DatasetID,
+		Model:            r.Model,
+		PromptTemplateID: r.PromptTemplateID,
+		TemplateKey:      r.TemplateKey,
+		TemplateVersion:  r.TemplateVersion,
+		SystemPrompt:     r.SystemPrompt,
+		Status:           string(r.Status),
+		TotalItems:       r.TotalItems,
+		CompletedItems:   r.CompletedItems,
+		PassedItems:      r.PassedItems,
+		ErroredItems:     r.ErroredItems,
+		AverageScore:     r.AverageScore,
+		Error:            r.Error,
+		CreatedBy:        r.CreatedBy,
+		StartedAt:        r.StartedAt,
+		CompletedAt:      r.CompletedAt,
+		CreatedAt:        r.CreatedAt,
+		UpdatedAt:        r.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain run
+func (m *EvalRun) EtoD() *eval.Run {
+	return &eval.Run{
+		ID:               m.ID,
+		PublicID:         m.PublicID,
+		DatasetID:        m.DatasetID,
+		Model:            m.Model,
+		PromptTemplateID: m.PromptTemplateID,
+		TemplateKey:      m.TemplateKey,
+		TemplateVersion:  m.TemplateVersion,
+		SystemPrompt:     m.SystemPrompt,
+		Status:           eval.RunStatus(m.Status),
+		TotalItems:       m.TotalItems,
+		CompletedItems:   m.CompletedItems,
+		PassedItems:      m.PassedItems,
+		ErroredItems:     m.ErroredItems,
+		AverageScore:     m.AverageScore,
+		Error:            m.Error,
+		CreatedBy:        m.CreatedBy,
+		StartedAt:        m.StartedAt,
+		CompletedAt:      m.CompletedAt,
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
+
+// NewSchemaEvalRunResult creates a database schema from a domain run result
+func NewSchemaEvalRunResult(r *eval.RunResult) *EvalRunResult {
+	return &EvalRunResult{
+		ID:        r.ID,
+		RunID:     r.RunID,
+		ItemID:    r.ItemID,
+		Position:  r.Position,
+		Output:    r.Output,
+		Score:     r.Score,
+		Passed:    r.Passed,
+		Grader:    r.Grader,
+		Reasoning: r.Reasoning,
+		LatencyMs: r.LatencyMs,
+		Error:     r.Error,
+		CreatedAt: r.CreatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain run result
+func (m *EvalRunResult) EtoD() *eval.RunResult {
+	return &eval.RunResult{
+		ID:        m.ID,
+		RunID:     m.RunID,
+		ItemID:    m.ItemID,
+		Position:  m.Position,
+		Output:    m.Output,
+		Score:     m.Score,
+		Passed:    m.Passed,
+		Grader:    m.Grader,
+		Reasoning: m.Reasoning,
+		LatencyMs: m.LatencyMs,
+		Error:     m.Error,
+		CreatedAt: m.CreatedAt,
+	}
+}
@@ -0,0 +1,81 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(FinetuneExport{})
+}
+
+// FinetuneExport is a fine-tuning dataset export job
+type FinetuneExport struct {
+	ID                 uint   `gorm:"primarykey"`
+	PublicID           string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Format             string `gorm:"type:varchar(20);not null"`
+	Status             string `gorm:"type:varchar(20);not null;default:'pending'"`
+	RedactPII          bool   `gorm:"column:redact_pii;not null;default:true"`
+	RatedAfter         *time.Time
+	RatedBefore        *time.Time
+	MaxContextMessages int     `gorm:"not null;default:0"`
+	ExampleCount       int     `gorm:"not null;default:0"`
+	MediaID            *string `gorm:"type:varchar(64)"`
+	Bytes              int64   `gorm:"not null;default:0"`
+	Error              string  `gorm:"type:text;not null;default:''"`
+	CreatedBy          *string `gorm:"type:varchar(255)"`
+	CompletedAt        *time.Time
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
+}
+
+// TableName returns the custom table name for fine-tuning exports
+func (FinetuneExport) TableName() string {
+	return "llm_api.finetune_exports"
+}
+
+// NewSchemaFinetuneExport creates a database schema from a domain export
+func NewSchemaFinetuneExport(e *finetune.Export) *FinetuneExport {
+	return &FinetuneExport{
+		ID:                 e.ID,
+		PublicID:           e.PublicID,
+		Format:             e.Format,
+		Status:             string(e.Status),
+		RedactPII:          e.RedactPII,
+		RatedAfter:         e.RatedAfter,
+		RatedBefore:        e.RatedBefore,
+		MaxContextMessages: e.MaxContextMessages,
+		ExampleCount:       e.ExampleCount,
+		MediaID:            e.MediaID,
+		Bytes:              e.Bytes,
+		Error:              e.Error,
+		CreatedBy:          e.CreatedBy,
+		CompletedAt:        e.CompletedAt,
+		CreatedAt:          e.CreatedAt,
+		UpdatedAt:          e.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain export
+func (m *FinetuneExport) EtoD() *finetune.Export {
+	return &finetune.Export{
+		ID:                 m.ID,
+		PublicID:           m.PublicID,
+		Format:             m.Format,
+		Status:             finetune.ExportStatus(m.Status),
+		RedactPII:          m.RedactPII,
+		RatedAfter:         m.RatedAfter,
+		RatedBefore:        m.RatedBefore,
+		MaxContextMessages: m.MaxContextMessages,
+		ExampleCount:       m.ExampleCount,
+		MediaID:            m.MediaID,
+		Bytes:              m.Bytes,
+		Error:              m.Error,
+		CreatedBy:          m.CreatedBy,
+		CompletedAt:        m.CompletedAt,
+		CreatedAt:          m.CreatedAt,
+		UpdatedAt:          m.UpdatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(MCPCredential{})
+}
+
+// MCPCredential is a user's encrypted third-party token for MCP tools
+type MCPCredential struct {
+	ID             uint           `gorm:"primarykey"`
+	PublicID       string         `gorm:"type:varchar(64);uniqueIndex;not null"`
+	UserID         uint           `gorm:"not null;uniqueIndex:idx_mcp_credentials_user_provider"`
+	Provider       string         `gorm:"type:varchar(50);not null;uniqueIndex:idx_mcp_credentials_user_provider"`
+	EncryptedToken string         `gorm:"type:text;not null"`
+	Account        *string        `gorm:"type:varchar(255)"`
+	Scopes         JSONStringList `gorm:"type:jsonb"`
+	Endpoint       *string        `gorm:"type:varchar(2048)"`
+	ExpiresAt      *time.Time
+	LastUsedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName returns the custom table name for MCP credentials
+func (MCPCredential) TableName() string {
+	return "llm_api.mcp_credentials"
+}
+
+// NewSchemaMCPCredential creates a database schema from a domain credential
+func NewSchemaMCPCredential(c *mcpcredential.Credential) *MCPCredential {
+	return &MCPCredential{
+		ID:             c.ID,
+		PublicID:       c.PublicID,
+		UserID:         c.UserID,
+		Provider:       string(c.Provider),
+		EncryptedToken: c.EncryptedToken,
+		Account:        c.Account,
+		Scopes:         JSONStringList(c.Scopes),
+		Endpoint:       c.Endpoint,
+		ExpiresAt:      c.ExpiresAt,
+		LastUsedAt:     c.LastUsedAt,
+		CreatedAt:      c.CreatedAt,
+		UpdatedAt:      c.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain credential
+func (m *MCPCredential) EtoD() *mcpcredential.Credential {
+	return &mcpcredential.Credential{
+		ID:             m.ID,
+		PublicID:       m.PublicID,
+		UserID:         m.UserID,
+		Provider:       mcpcredential.Provider(m.Provider),
+		EncryptedToken: m.EncryptedToken,
+		Account:        m.Account,
+		Scopes:         []string(m.Scopes),
+		Endpoint:       m.Endpoint,
+		ExpiresAt:      m.ExpiresAt,
+		LastUsedAt:     m.LastUsedAt,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
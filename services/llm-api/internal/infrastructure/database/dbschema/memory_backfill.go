@@ -0,0 +1,78 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(MemoryBackfill{})
+}
+
+// MemoryBackfill is a job replaying historical conversations through memory observation
+type MemoryBackfill struct {
+	ID                   uint   `gorm:"primarykey"`
+	PublicID             string `gorm:"type:varchar(64);uniqueIndex;not null"`
+	Status               string `gorm:"type:varchar(20);not null;default:'queued'"`
+	UserID               *uint
+	CreatedBefore        time.Time `gorm:"not null"`
+	Cursor               uint      `gorm:"not null;default:0"`
+	ConversationsDone    int       `gorm:"not null;default:0"`
+	ConversationsSkipped int       `gorm:"not null;default:0"`
+	ConversationsFailed  int       `gorm:"not null;default:0"`
+	Error                string    `gorm:"type:text;not null;default:''"`
+	CreatedBy            *string   `gorm:"type:varchar(255)"`
+	StartedAt            *time.Time
+	CompletedAt          *time.Time
+	CreatedAt            time.Time
+	UpdatedAt            time.Time
+}
+
+// TableName returns the custom table name for memory backfills
+func (MemoryBackfill) TableName() string {
+	return "llm_api.memory_backfills"
+}
+
+// NewSchemaMemoryBackfill creates a database schema from a domain backfill
+func NewSchemaMemoryBackfill(b *memorybackfill.Backfill) *MemoryBackfill {
+	return &MemoryBackfill{
+		ID:                   b.ID,
+		PublicID:             b.PublicID,
+		Status:               string(b.Status),
+		UserID:               b.UserID,
+		CreatedBefore:        b.CreatedBefore,
+		Cursor:               b.Cursor,
+		ConversationsDone:    b.ConversationsDone,
+		ConversationsSkipped: b.ConversationsSkipped,
+		ConversationsFailed:  b.ConversationsFailed,
+		Error:                b.Error,
+		CreatedBy:            b.CreatedBy,
+		StartedAt:            b.StartedAt,
+		CompletedAt:          b.CompletedAt,
+		CreatedAt:            b.CreatedAt,
+		UpdatedAt:            b.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain backfill
+func (m *MemoryBackfill) EtoD() *memorybackfill.Backfill {
+	return &memorybackfill.Backfill{
+		ID:                   m.ID,
+		PublicID:             m.PublicID,
+		Status:               memorybackfill.Status(m.Status),
+		UserID:               m.UserID,
+		CreatedBefore:        m.CreatedBefore,
+		Cursor:               m.Cursor,
+		ConversationsDone:    m.ConversationsDone,
+		ConversationsSkipped: m.ConversationsSkipped,
+		ConversationsFailed:  m.ConversationsFailed,
+		Error:                m.Error,
+		CreatedBy:            m.CreatedBy,
+		StartedAt:            m.StartedAt,
+		CompletedAt:          m.CompletedAt,
+		CreatedAt:            m.CreatedAt,
+		UpdatedAt:            m.UpdatedAt,
+	}
+}
@@ -0,0 +1,60 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/modelalias"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(ModelAlias{})
+}
+
+// ModelAlias maps a requested model name to a catalog model
+type ModelAlias struct {
+	ID             uint    `gorm:"primarykey"`
+	Name           string  `gorm:"type:varchar(64);uniqueIndex;not null"`
+	TargetModel    string  `gorm:"type:varchar(255);not null"`
+	RolloutModel   *string `gorm:"type:varchar(255)"`
+	RolloutPercent int     `gorm:"not null;default:0"`
+	Description    *string `gorm:"type:text"`
+	CreatedBy      *string `gorm:"type:varchar(255)"`
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName returns the custom table name for model aliases
+func (ModelAlias) TableName() string {
+	return "llm_api.model_aliases"
+}
+
+// NewSchemaModelAlias creates a database schema from a domain alias
+func NewSchemaModelAlias(a *modelalias.Alias) *ModelAlias {
+	return &ModelAlias{
+		ID:             a.ID,
+		Name:           a.Name,
+		TargetModel:    a.TargetModel,
+		RolloutModel:   a.RolloutModel,
+		RolloutPercent: a.RolloutPercent,
+		Description:    a.Description,
+		CreatedBy:      a.CreatedBy,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain alias
+func (m *ModelAlias) EtoD() *modelalias.Alias {
+	return &modelalias.Alias{
+		ID:             m.ID,
+		Name:           m.Name,
+		TargetModel:    m.TargetModel,
+		RolloutModel:   m.RolloutModel,
+		RolloutPercent: m.RolloutPercent,
+		Description:    m.Description,
+		CreatedBy:      m.CreatedBy,
+		CreatedAt:      m.CreatedAt,
+		UpdatedAt:      m.UpdatedAt,
+	}
+}
@@ -0,0 +1,124 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/comparison"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(ModelComparison{})
+	database.RegisterSchemaForAutoMigrate(ModelComparisonResult{})
+}
+
+// ModelComparison is one arena run of a prompt against several models
+type ModelComparison struct {
+	ID          uint           `gorm:"primarykey"`
+	PublicID    string         `gorm:"type:varchar(64);uniqueIndex;not null"`
+	UserID      uint           `gorm:"index:idx_model_comparisons_user_id;not null"`
+	Models      JSONStringList `gorm:"type:jsonb;not null"`
+	Vote        *string        `gorm:"type:varchar(20)"`
+	WinnerSlot  *int           `gorm:"type:integer"`
+	VotedAt     *time.Time     `gorm:"index:idx_model_comparisons_voted_at"`
+	CompletedAt *time.Time
+	Results     []ModelComparisonResult `gorm:"foreignKey:ComparisonID"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName returns the custom table name for model comparisons
+func (ModelComparison) TableName() string {
+	return "llm_api.model_comparisons"
+}
+
+// ModelComparisonResult is the outcome of one model in a comparison
+type ModelComparisonResult struct {
+	ID               uint   `gorm:"primarykey"`
+	ComparisonID     uint   `gorm:"uniqueIndex:idx_model_comparison_results_slot;not null"`
+	Slot             int    `gorm:"uniqueIndex:idx_model_comparison_results_slot;not null"`
+	Model            string `gorm:"type:varchar(255);index:idx_model_comparison_results_model;not null"`
+	Provider         string `gorm:"type:varchar(255);not null;default:''"`
+	PromptTokens     int    `gorm:"not null;default:0"`
+	CompletionTokens int    `gorm:"not null;default:0"`
+	LatencyMs        int64  `gorm:"not null;default:0"`
+	Succeeded        bool   `gorm:"not null;default:true"`
+	Error            string `gorm:"type:text;not null;default:''"`
+	CreatedAt        time.Time
+}
+
+// TableName returns the custom table name for model comparison results
+func (ModelComparisonResult) TableName() string {
+	return "llm_api.model_comparison_results"
+}
+
+// NewSchemaModelComparison creates a database schema from a domain comparison, without its results
+func NewSchemaModelComparison(c *comparison.Comparison) *ModelComparison {
+	var vote *string
+	if c.Vote != nil {
+		v := string(*c.Vote)
+		vote = &v
+	}
+	return &ModelComparison{
+		ID:          c.ID,
+		PublicID:    c.PublicID,
+		UserID:      c.UserID,
+		Models:      JSONStringList(c.Models),
+		Vote:        vote,
+		WinnerSlot:  c.WinnerSlot,
+		VotedAt:     c.VotedAt,
+		CompletedAt: c.CompletedAt,
+		CreatedAt:   c.CreatedAt,
+		UpdatedAt:   c.UpdatedAt,
+	}
+}
+
+// NewSchemaModelComparisonResult creates a database schema from a domain comparison result
+func NewSchemaModelComparisonResult(comparisonID uint, r comparison.Result) *ModelComparisonResult {
+	return &ModelComparisonResult{
+		ComparisonID:     comparisonID,
+		Slot:             r.Slot,
+		Model:            r.Model,
+		Provider:         r.Provider,
+		PromptTokens:     r.PromptTokens,
+		CompletionTokens: r.CompletionTokens,
+		LatencyMs:        r.LatencyMs,
+		Succeeded:        r.Succeeded,
+		Error:            r.Error,
+	}
+}
+
+// EtoD converts the database schema to a domain comparison
+func (m *ModelComparison) EtoD() *comparison.Comparison {
+	var vote *comparison.VoteOutcome
+	if m.Vote != nil {
+		v := comparison.VoteOutcome(*m.Vote)
+		vote = &v
+	}
+	results := make([]comparison.Result, 0, len(m.Results))
+	for _, r := range m.Results {
+		results = append(results, comparison.Result{
+			Slot:             r.Slot,
+			Model:            r.Model,
+			Provider:         r.Provider,
+			PromptTokens:     r.PromptTokens,
+			CompletionTokens: r.CompletionTokens,
+			LatencyMs:        r.LatencyMs,
+			Succeeded:        r.Succeeded,
+			Error:            r.Error,
+		})
+	}
+	return &comparison.Comparison{
+		ID:          m.ID,
+		PublicID:    m.PublicID,
+		UserID:      m.UserID,
+		Models:      []string(m.Models),
+		Results:     results,
+		Vote:        vote,
+		WinnerSlot:  m.WinnerSlot,
+		VotedAt:     m.VotedAt,
+		CompletedAt: m.CompletedAt,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
@@ -0,0 +1,69 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/persona"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(Persona{})
+}
+
+// Persona is a user-defined assistant
+type Persona struct {
+	ID               uint           `gorm:"primarykey"`
+	PublicID         string         `gorm:"type:varchar(64);uniqueIndex;not null"`
+	UserID           uint           `gorm:"not null;index:idx_personas_user_id"`
+	Name             string         `gorm:"type:varchar(100);not null"`
+	Description      *string        `gorm:"type:text"`
+	Avatar           *string        `gorm:"type:varchar(2048)"`
+	Instructions     string         `gorm:"type:text;not null"`
+	DefaultModel     *string        `gorm:"type:varchar(255)"`
+	EnabledTools     JSONStringList `gorm:"type:jsonb"`
+	StarterQuestions JSONStringList `gorm:"type:jsonb"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// TableName returns the custom table name for personas
+func (Persona) TableName() string {
+	return "llm_api.personas"
+}
+
+// NewSchemaPersona creates a database schema from a domain persona
+func NewSchemaPersona(p *persona.Persona) *Persona {
+	return &Persona{
+		ID:               p.ID,
+		PublicID:         p.PublicID,
+		UserID:           p.UserID,
+		Name:             p.Name,
+		Description:      p.Description,
+		Avatar:           p.Avatar,
+		Instructions:     p.Instructions,
+		DefaultModel:     p.DefaultModel,
+		EnabledTools:     JSONStringList(p.EnabledTools),
+		StarterQuestions: JSONStringList(p.StarterQuestions),
+		CreatedAt:        p.CreatedAt,
+		UpdatedAt:        p.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain persona
+func (m *Persona) EtoD() *persona.Persona {
+	return &persona.Persona{
+		ID:               m.ID,
+		PublicID:         m.PublicID,
+		UserID:           m.UserID,
+		Name:             m.Name,
+		Description:      m.Description,
+		Avatar:           m.Avatar,
+		Instructions:     m.Instructions,
+		DefaultModel:     m.DefaultModel,
+		EnabledTools:     []string(m.EnabledTools),
+		StarterQuestions: []string(m.StarterQuestions),
+		CreatedAt:        m.CreatedAt,
+		UpdatedAt:        m.UpdatedAt,
+	}
+}
@@ -1,6 +1,8 @@
 package dbschema
 
 import (
+	"database/sql/driver"
+	"encoding/json"
 	"time"
 
 	"jan-server/services/llm-api/internal/domain/project"
@@ -9,6 +11,7 @@ import (
 
 func init() {
 	database.RegisterSchemaForAutoMigrate(Project{})
+	database.RegisterSchemaForAutoMigrate(ProjectInstructionVersion{})
 }
 
 // ===============================================
@@ -18,14 +21,17 @@ func init() {
 // Project represents the database schema for projects
 type Project struct {
 	BaseModel
-	PublicID    string     `gorm:"uniqueIndex;size:64;not null"`
-	UserID      uint       `gorm:"index:idx_projects_user;not null"`
-	Name        string     `gorm:"size:255;not null"`
-	Instruction *string    `gorm:"type:text"`
-	Favorite    bool       `gorm:"not null;default:false"`
-	ArchivedAt  *time.Time `gorm:"index"`
-	DeletedAt   *time.Time `gorm:"index"`
-	LastUsedAt  *time.Time
+	PublicID             string           `gorm:"uniqueIndex;size:64;not null"`
+	UserID               uint             `gorm:"index:idx_projects_user;not null"`
+	Name                 string           `gorm:"size:255;not null"`
+	Instruction          *string          `gorm:"type:text"`
+	InstructionVersion   int              `gorm:"not null;default:1"`
+	DefaultTools         JSONToolSwitches `gorm:"type:jsonb"`
+	KnowledgeCollections JSONStringList   `gorm:"type:jsonb"`
+	Favorite             bool             `gorm:"not null;default:false"`
+	ArchivedAt           *time.Time       `gorm:"index"`
+	DeletedAt            *time.Time       `gorm:"index"`
+	LastUsedAt           *time.Time
 }
 
 // TableName specifies the table name for Project
@@ -33,6 +39,42 @@ func (Project) TableName() string {
 	return "llm_api.projects"
 }
 
+// JSONToolSwitches is a custom type for map[string]bool stored as JSON
+type JSONToolSwitches map[string]bool
+
+func (j JSONToolSwitches) Value() (driver.Value, error) {
+	if len(j) == 0 {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
+
+func (j *JSONToolSwitches) Scan(value any) error {
+	if value == nil {
+		*j = nil
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+// ProjectInstructionVersion keeps one version of a project's instruction
+type ProjectInstructionVersion struct {
+	ID          uint      `gorm:"primarykey"`
+	ProjectID   uint      `gorm:"not null;uniqueIndex:uq_project_instruction_versions,priority:1"`
+	Version     int       `gorm:"not null;uniqueIndex:uq_project_instruction_versions,priority:2"`
+	Instruction *string   `gorm:"type:text"`
+	CreatedAt   time.Time `gorm:"not null;default:now()"`
+}
+
+// TableName specifies the table name for ProjectInstructionVersion
+func (ProjectInstructionVersion) TableName() string {
+	return "llm_api.project_instruction_versions"
+}
+
 // ===============================================
 // Conversion Methods
 // ===============================================
@@ -40,18 +82,21 @@ func (Project) TableName() string {
 // EtoD converts database schema to domain project (Entity to Domain)
 func (p *Project) EtoD() *project.Project {
 	return &project.Project{
-		ID:          p.ID,
-		PublicID:    p.PublicID,
-		Object:      "project",
-		UserID:      p.UserID,
-		Name:        p.Name,
-		Instruction: p.Instruction,
-		Favorite:    p.Favorite,
-		ArchivedAt:  p.ArchivedAt,
-		DeletedAt:   p.DeletedAt,
-		LastUsedAt:  p.LastUsedAt,
-		CreatedAt:   p.CreatedAt,
-		UpdatedAt:   p.UpdatedAt,
+		ID:                   p.ID,
+		PublicID:             p.PublicID,
+		Object:               "project",
+		UserID:               p.UserID,
+		Name:                 p.Name,
+		Instruction:          p.Instruction,
+		InstructionVersion:   p.InstructionVersion,
+		DefaultTools:         project.DefaultTools(p.DefaultTools),
+		KnowledgeCollections: []string(p.KnowledgeCollections),
+		Favorite:             p.Favorite,
+		ArchivedAt:           p.ArchivedAt,
+		DeletedAt:            p.DeletedAt,
+		LastUsedAt:           p.LastUsedAt,
+		CreatedAt:            p.CreatedAt,
+		UpdatedAt:            p.UpdatedAt,
 	}
 }
 
@@ -63,14 +108,17 @@ func ProjectDtoE(p *project.Project) *Project {
 			CreatedAt: p.CreatedAt,
 			UpdatedAt: p.UpdatedAt,
 		},
-		PublicID:    p.PublicID,
-		UserID:      p.UserID,
-		Name:        p.Name,
-		Instruction: p.Instruction,
-		Favorite:    p.Favorite,
-		ArchivedAt:  p.ArchivedAt,
-		DeletedAt:   p.DeletedAt,
-		LastUsedAt:  p.LastUsedAt,
+		PublicID:             p.PublicID,
+		UserID:               p.UserID,
+		Name:                 p.Name,
+		Instruction:          p.Instruction,
+		InstructionVersion:   p.InstructionVersion,
+		DefaultTools:         JSONToolSwitches(p.DefaultTools),
+		KnowledgeCollections: JSONStringList(p.KnowledgeCollections),
+		Favorite:             p.Favorite,
+		ArchivedAt:           p.ArchivedAt,
+		DeletedAt:            p.DeletedAt,
+		LastUsedAt:           p.LastUsedAt,
 	}
 }
 
@@ -82,13 +130,25 @@ func NewSchemaProject(p *project.Project) *Project {
 			CreatedAt: p.CreatedAt,
 			UpdatedAt: p.UpdatedAt,
 		},
-		PublicID:    p.PublicID,
-		UserID:      p.UserID,
-		Name:        p.Name,
-		Instruction: p.Instruction,
-		Favorite:    p.Favorite,
-		ArchivedAt:  p.ArchivedAt,
-		DeletedAt:   p.DeletedAt,
-		LastUsedAt:  p.LastUsedAt,
+		PublicID:             p.PublicID,
+		UserID:               p.UserID,
+		Name:                 p.Name,
+		Instruction:          p.Instruction,
+		InstructionVersion:   p.InstructionVersion,
+		DefaultTools:         JSONToolSwitches(p.DefaultTools),
+		KnowledgeCollections: JSONStringList(p.KnowledgeCollections),
+		Favorite:             p.Favorite,
+		ArchivedAt:           p.ArchivedAt,
+		DeletedAt:            p.DeletedAt,
+		LastUsedAt:           p.LastUsedAt,
+	}
+}
+
+// EtoD converts database schema to a domain instruction version
+func (v *ProjectInstructionVersion) EtoD() *project.InstructionVersion {
+	return &project.InstructionVersion{
+		Version:     v.Version,
+		Instruction: v.Instruction,
+		CreatedAt:   v.CreatedAt,
 	}
 }
@@ -0,0 +1,110 @@
+package dbschema
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(PromptLibraryStarter{})
+}
+
+// PromptLibraryStarter is a conversation starter of the prompt library
+type PromptLibraryStarter struct {
+	ID          uint                 `gorm:"primarykey"`
+	PublicID    string               `gorm:"type:varchar(64);uniqueIndex;not null"`
+	UserID      *uint                `gorm:"index:idx_prompt_library_user_id"`
+	Title       string               `gorm:"type:varchar(255);not null"`
+	Description *string              `gorm:"type:text"`
+	Category    string               `gorm:"type:varchar(100);index:idx_prompt_library_category;not null"`
+	Messages    JSONStarterMessages  `gorm:"type:jsonb;not null"`
+	Variables   JSONStarterVariables `gorm:"type:jsonb"`
+	IsActive    bool                 `gorm:"not null;default:true"`
+	CreatedBy   *string              `gorm:"type:varchar(255)"`
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName returns the custom table name for the prompt library
+func (PromptLibraryStarter) TableName() string {
+	return "llm_api.prompt_library"
+}
+
+// JSONStarterMessages is a list of starter messages stored as JSON
+type JSONStarterMessages []promptlibrary.Message
+
+func (j JSONStarterMessages) Value() (driver.Value, error) {
+	return json.Marshal(j)
+}
+
+func (j *JSONStarterMessages) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+// JSONStarterVariables is a list of starter variables stored as JSON
+type JSONStarterVariables []promptlibrary.Variable
+
+func (j JSONStarterVariables) Value() (driver.Value, error) {
+	if j == nil {
+		return nil, nil
+	}
+	return json.Marshal(j)
+}
+
+func (j *JSONStarterVariables) Scan(value any) error {
+	if value == nil {
+		return nil
+	}
+	bytes, ok := value.([]byte)
+	if !ok {
+		return nil
+	}
+	return json.Unmarshal(bytes, j)
+}
+
+// NewSchemaPromptLibraryStarter creates a database schema from a domain starter
+func NewSchemaPromptLibraryStarter(s *promptlibrary.Starter) *PromptLibraryStarter {
+	return &PromptLibraryStarter{
+		ID:          s.ID,
+		PublicID:    s.PublicID,
+		UserID:      s.UserID,
+		Title:       s.Title,
+		Description: s.Description,
+		Category:    s.Category,
+		Messages:    JSONStarterMessages(s.Messages),
+		Variables:   JSONStarterVariables(s.Variables),
+		IsActive:    s.IsActive,
+		CreatedBy:   s.CreatedBy,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+	}
+}
+
+// EtoD converts the database schema to a domain starter
+func (m *PromptLibraryStarter) EtoD() *promptlibrary.Starter {
+	return &promptlibrary.Starter{
+		ID:          m.ID,
+		PublicID:    m.PublicID,
+		UserID:      m.UserID,
+		Title:       m.Title,
+		Description: m.Description,
+		Category:    m.Category,
+		Messages:    []promptlibrary.Message(m.Messages),
+		Variables:   []promptlibrary.Variable(m.Variables),
+		IsActive:    m.IsActive,
+		CreatedBy:   m.CreatedBy,
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
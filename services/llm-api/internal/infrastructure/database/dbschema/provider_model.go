@@ -31,6 +31,7 @@ type ProviderModel struct {
 	SupportsThinkingMode    *bool          `gorm:"not null;default:false"`
 	DefaultConversationMode string         `gorm:"size:64;not null;default:'standard'"`
 	ReasoningConfig         datatypes.JSON `gorm:"type:jsonb"`
+	ReasoningPolicy         datatypes.JSON `gorm:"type:jsonb"`
 	ProviderFlags           datatypes.JSON `gorm:"type:jsonb"`
 	Active                  *bool          `gorm:"not null;default:true;index;index:idx_provider_model_active,priority:2;index:idx_provider_model_catalog_active,priority:3"`
 	InstructModelID         *uint          `gorm:"index"` // Self-referencing FK to the instruct model variant (used when enable_thinking=false)
@@ -65,6 +66,15 @@ func NewSchemaProviderModel(m *domainmodel.ProviderModel) (*ProviderModel, error
 		reasoningConfig = datatypes.JSON(data)
 	}
 
+	var reasoningPolicy datatypes.JSON
+	if m.ReasoningPolicy != nil {
+		data, err := json.Marshal(m.ReasoningPolicy)
+		if err != nil {
+			return nil, err
+		}
+		reasoningPolicy = datatypes.JSON(data)
+	}
+
 	var providerFlags datatypes.JSON
 	if len(m.ProviderFlags) > 0 {
 		data, err := json.Marshal(m.ProviderFlags)
@@ -97,6 +107,7 @@ func NewSchemaProviderModel(m *domainmodel.ProviderModel) (*ProviderModel, error
 		SupportsThinkingMode:    &supportsThinking,
 		DefaultConversationMode: defaultMode,
 		ReasoningConfig:         reasoningConfig,
+		ReasoningPolicy:         reasoningPolicy,
 		ProviderFlags:           providerFlags,
 		Active:                  &active,
 		InstructModelID:         m.InstructModelID,
@@ -143,6 +154,15 @@ func (m *ProviderModel) EtoD() (*domainmodel.ProviderModel, error) {
 		reasoningConfig = &config
 	}
 
+	var reasoningPolicy *domainmodel.ReasoningPolicy
+	if len(m.ReasoningPolicy) > 0 {
+		var policy domainmodel.ReasoningPolicy
+		if err := json.Unmarshal(m.ReasoningPolicy, &policy); err != nil {
+			return nil, err
+		}
+		reasoningPolicy = &policy
+	}
+
 	var providerFlags map[string]any
 	if len(m.ProviderFlags) > 0 {
 		if err := json.Unmarshal(m.ProviderFlags, &providerFlags); err != nil {
@@ -168,6 +188,7 @@ func (m *ProviderModel) EtoD() (*domainmodel.ProviderModel, error) {
 		SupportsThinkingMode:    supportsThinking,
 		DefaultConversationMode: m.DefaultConversationMode,
 		ReasoningConfig:         reasoningConfig,
+		ReasoningPolicy:         reasoningPolicy,
 		ProviderFlags:           providerFlags,
 		Active:                  active,
 		InstructModelID:         m.InstructModelID,
@@ -0,0 +1,45 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/deltasync"
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(SyncChange{})
+}
+
+// SyncChange is one entry of the change log written by database triggers
+type SyncChange struct {
+	ID             uint64    `gorm:"primarykey"`
+	TxID           uint64    `gorm:"column:txid;not null;default:(pg_current_xact_id()::text::bigint);index:idx_sync_changes_user_cursor,priority:2"`
+	UserID         uint      `gorm:"not null;index:idx_sync_changes_user_cursor,priority:1"`
+	EntityType     string    `gorm:"type:varchar(32);not null"`
+	EntityID       string    `gorm:"type:varchar(64);not null"`
+	ConversationID *string   `gorm:"type:varchar(64)"`
+	Operation      string    `gorm:"type:varchar(16);not null"`
+	ChangedAt      time.Time `gorm:"not null;default:now();index:idx_sync_changes_changed_at"`
+}
+
+// TableName returns the custom table name for sync changes
+func (SyncChange) TableName() string {
+	return "llm_api.sync_changes"
+}
+
+// EtoD converts the database schema to a domain change
+func (s *SyncChange) EtoD() *deltasync.Change {
+	change := &deltasync.Change{
+		Checkpoint: deltasync.Checkpoint{TxID: s.TxID, ID: s.ID},
+		UserID:     s.UserID,
+		EntityType: deltasync.EntityType(s.EntityType),
+		EntityID:   s.EntityID,
+		Operation:  deltasync.Operation(s.Operation),
+		ChangedAt:  s.ChangedAt,
+	}
+	if s.ConversationID != nil {
+		change.ConversationID = *s.ConversationID
+	}
+	return change
+}
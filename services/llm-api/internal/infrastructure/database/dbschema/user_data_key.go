@@ -0,0 +1,25 @@
+package dbschema
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/infrastructure/database"
+)
+
+func init() {
+	database.RegisterSchemaForAutoMigrate(UserDataKey{})
+}
+
+// UserDataKey is a user's content encryption key, wrapped by the KMS master key
+type UserDataKey struct {
+	ID          uint   `gorm:"primarykey"`
+	UserID      uint   `gorm:"not null;uniqueIndex"`
+	WrappedKey  []byte `gorm:"type:bytea;not null"`
+	KeyProvider string `gorm:"type:varchar(20);not null"` // KMS that wrapped the key: local or vault
+	CreatedAt   time.Time
+}
+
+// TableName returns the custom table name for user data keys
+func (UserDataKey) TableName() string {
+	return "llm_api.user_data_keys"
+}
@@ -48,6 +48,27 @@ func newConversationItem(db *gorm.DB, opts ...gen.DOOption) conversationItem {
 	_conversationItem.Rating = field.NewString(tableName, "rating")
 	_conversationItem.RatedAt = field.NewTime(tableName, "rated_at")
 	_conversationItem.RatingComment = field.NewString(tableName, "rating_comment")
+	_conversationItem.CallID = field.NewString(tableName, "call_id")
+	_conversationItem.ServerLabel = field.NewString(tableName, "server_label")
+	_conversationItem.ApprovalRequestID = field.NewString(tableName, "approval_request_id")
+	_conversationItem.Arguments = field.NewString(tableName, "arguments")
+	_conversationItem.Output = field.NewString(tableName, "output")
+	_conversationItem.Error = field.NewString(tableName, "error")
+	_conversationItem.ErrorDetail = field.NewField(tableName, "error_detail")
+	_conversationItem.Action = field.NewField(tableName, "action")
+	_conversationItem.Tools = field.NewField(tableName, "tools")
+	_conversationItem.PendingSafetyChecks = field.NewField(tableName, "pending_safety_checks")
+	_conversationItem.AcknowledgedSafetyChecks = field.NewField(tableName, "acknowledged_safety_checks")
+	_conversationItem.Approve = field.NewBool(tableName, "approve")
+	_conversationItem.Reason = field.NewString(tableName, "reason")
+	_conversationItem.Commands = field.NewField(tableName, "commands")
+	_conversationItem.MaxOutputLength = field.NewInt64(tableName, "max_output_length")
+	_conversationItem.ShellOutputs = field.NewField(tableName, "shell_outputs")
+	_conversationItem.Operation = field.NewField(tableName, "operation")
+	_conversationItem.EditedAt = field.NewTime(tableName, "edited_at")
+	_conversationItem.ContentCiphertext = field.NewBytes(tableName, "content_ciphertext")
+	_conversationItem.DataKeyID = field.NewUint(tableName, "data_key_id")
+	_conversationItem.ColdArchiveID = field.NewUint(tableName, "cold_archive_id")
 	_conversationItem.Conversation = conversationItemBelongsToConversation{
 		db: db.Session(&gorm.Session{}),
 
@@ -93,28 +114,49 @@ func newConversationItem(db *gorm.DB, opts ...gen.DOOption) conversationItem {
 type conversationItem struct {
 	conversationItemDo
 
-	ALL               field.Asterisk
-	ID                field.Uint
-	CreatedAt         field.Time
-	UpdatedAt         field.Time
-	DeletedAt         field.Field
-	ConversationID    field.Uint
-	PublicID          field.String
-	Object            field.String
-	Branch            field.String
-	SequenceNumber    field.Int
-	Type              field.String
-	Role              field.String
-	Content           field.Field
-	Status            field.String
-	IncompleteAt      field.Time
-	IncompleteDetails field.Field
-	CompletedAt       field.Time
-	ResponseID        field.Uint
-	Rating            field.String
-	RatedAt           field.Time
-	RatingComment     field.String
-	Conversation      conversationItemBelongsToConversation
+	ALL                      field.Asterisk
+	ID                       field.Uint
+	CreatedAt                field.Time
+	UpdatedAt                field.Time
+	DeletedAt                field.Field
+	ConversationID           field.Uint
+	PublicID                 field.String
+	Object                   field.String
+	Branch                   field.String
+	SequenceNumber           field.Int
+	Type                     field.String
+	Role                     field.String
+	Content                  field.Field
+	Status                   field.String
+	IncompleteAt             field.Time
+	IncompleteDetails        field.Field
+	CompletedAt              field.Time
+	ResponseID               field.Uint
+	Rating                   field.String
+	RatedAt                  field.Time
+	RatingComment            field.String
+	CallID                   field.String
+	ServerLabel              field.String
+	ApprovalRequestID        field.String
+	Arguments                field.String
+	Output                   field.String
+	Error                    field.String
+	ErrorDetail              field.Field
+	Action                   field.Field
+	Tools                    field.Field
+	PendingSafetyChecks      field.Field
+	AcknowledgedSafetyChecks field.Field
+	Approve                  field.Bool
+	Reason                   field.String
+	Commands                 field.Field
+	MaxOutputLength          field.Int64
+	ShellOutputs             field.Field
+	Operation                field.Field
+	EditedAt                 field.Time
+	ContentCiphertext        field.Bytes
+	DataKeyID                field.Uint
+	ColdArchiveID            field.Uint
+	Conversation             conversationItemBelongsToConversation
 
 	fieldMap map[string]field.Expr
 }
@@ -151,6 +193,27 @@ func (c *conversationItem) updateTableName(table string) *conversationItem {
 	c.Rating = field.NewString(table, "rating")
 	c.RatedAt = field.NewTime(table, "rated_at")
 	c.RatingComment = field.NewString(table, "rating_comment")
+	c.CallID = field.NewString(table, "call_id")
+	c.ServerLabel = field.NewString(table, "server_label")
+	c.ApprovalRequestID = field.NewString(table, "approval_request_id")
+	c.Arguments = field.NewString(table, "arguments")
+	c.Output = field.NewString(table, "output")
+	c.Error = field.NewString(table, "error")
+	c.ErrorDetail = field.NewField(table, "error_detail")
+	c.Action = field.NewField(table, "action")
+	c.Tools = field.NewField(table, "tools")
+	c.PendingSafetyChecks = field.NewField(table, "pending_safety_checks")
+	c.AcknowledgedSafetyChecks = field.NewField(table, "acknowledged_safety_checks")
+	c.Approve = field.NewBool(table, "approve")
+	c.Reason = field.NewString(table, "reason")
+	c.Commands = field.NewField(table, "commands")
+	c.MaxOutputLength = field.NewInt64(table, "max_output_length")
+	c.ShellOutputs = field.NewField(table, "shell_outputs")
+	c.Operation = field.NewField(table, "operation")
+	c.EditedAt = field.NewTime(table, "edited_at")
+	c.ContentCiphertext = field.NewBytes(table, "content_ciphertext")
+	c.DataKeyID = field.NewUint(table, "data_key_id")
+	c.ColdArchiveID = field.NewUint(table, "cold_archive_id")
 
 	c.fillFieldMap()
 
@@ -167,7 +230,7 @@ func (c *conversationItem) GetFieldByName(fieldName string) (field.OrderExpr, bo
 }
 
 func (c *conversationItem) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 21)
+	c.fieldMap = make(map[string]field.Expr, 42)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["created_at"] = c.CreatedAt
 	c.fieldMap["updated_at"] = c.UpdatedAt
@@ -188,6 +251,27 @@ func (c *conversationItem) fillFieldMap() {
 	c.fieldMap["rating"] = c.Rating
 	c.fieldMap["rated_at"] = c.RatedAt
 	c.fieldMap["rating_comment"] = c.RatingComment
+	c.fieldMap["call_id"] = c.CallID
+	c.fieldMap["server_label"] = c.ServerLabel
+	c.fieldMap["approval_request_id"] = c.ApprovalRequestID
+	c.fieldMap["arguments"] = c.Arguments
+	c.fieldMap["output"] = c.Output
+	c.fieldMap["error"] = c.Error
+	c.fieldMap["error_detail"] = c.ErrorDetail
+	c.fieldMap["action"] = c.Action
+	c.fieldMap["tools"] = c.Tools
+	c.fieldMap["pending_safety_checks"] = c.PendingSafetyChecks
+	c.fieldMap["acknowledged_safety_checks"] = c.AcknowledgedSafetyChecks
+	c.fieldMap["approve"] = c.Approve
+	c.fieldMap["reason"] = c.Reason
+	c.fieldMap["commands"] = c.Commands
+	c.fieldMap["max_output_length"] = c.MaxOutputLength
+	c.fieldMap["shell_outputs"] = c.ShellOutputs
+	c.fieldMap["operation"] = c.Operation
+	c.fieldMap["edited_at"] = c.EditedAt
+	c.fieldMap["content_ciphertext"] = c.ContentCiphertext
+	c.fieldMap["data_key_id"] = c.DataKeyID
+	c.fieldMap["cold_archive_id"] = c.ColdArchiveID
 
 }
 
@@ -35,6 +35,7 @@ func newConversation(db *gorm.DB, opts ...gen.DOOption) conversation {
 	_conversation.PublicID = field.NewString(tableName, "public_id")
 	_conversation.Object = field.NewString(tableName, "object")
 	_conversation.Title = field.NewString(tableName, "title")
+	_conversation.TitleLocked = field.NewBool(tableName, "title_locked")
 	_conversation.UserID = field.NewUint(tableName, "user_id")
 	_conversation.ProjectID = field.NewUint(tableName, "project_id")
 	_conversation.ProjectPublicID = field.NewString(tableName, "project_public_id")
@@ -45,6 +46,7 @@ func newConversation(db *gorm.DB, opts ...gen.DOOption) conversation {
 	_conversation.IsPrivate = field.NewBool(tableName, "is_private")
 	_conversation.InstructionVersion = field.NewInt(tableName, "instruction_version")
 	_conversation.EffectiveInstructionSnapshot = field.NewString(tableName, "effective_instruction_snapshot")
+	_conversation.Version = field.NewInt(tableName, "version")
 	_conversation.Items = conversationHasManyItems{
 		db: db.Session(&gorm.Session{}),
 
@@ -119,6 +121,7 @@ type conversation struct {
 	PublicID                     field.String
 	Object                       field.String
 	Title                        field.String
+	TitleLocked                  field.Bool
 	UserID                       field.Uint
 	ProjectID                    field.Uint
 	ProjectPublicID              field.String
@@ -129,6 +132,7 @@ type conversation struct {
 	IsPrivate                    field.Bool
 	InstructionVersion           field.Int
 	EffectiveInstructionSnapshot field.String
+	Version                      field.Int
 	Items                        conversationHasManyItems
 
 	Branches conversationHasManyBranches
@@ -157,6 +161,7 @@ func (c *conversation) updateTableName(table string) *conversation {
 	c.PublicID = field.NewString(table, "public_id")
 	c.Object = field.NewString(table, "object")
 	c.Title = field.NewString(table, "title")
+	c.TitleLocked = field.NewBool(table, "title_locked")
 	c.UserID = field.NewUint(table, "user_id")
 	c.ProjectID = field.NewUint(table, "project_id")
 	c.ProjectPublicID = field.NewString(table, "project_public_id")
@@ -167,6 +172,7 @@ func (c *conversation) updateTableName(table string) *conversation {
 	c.IsPrivate = field.NewBool(table, "is_private")
 	c.InstructionVersion = field.NewInt(table, "instruction_version")
 	c.EffectiveInstructionSnapshot = field.NewString(table, "effective_instruction_snapshot")
+	c.Version = field.NewInt(table, "version")
 
 	c.fillFieldMap()
 
@@ -183,7 +189,7 @@ func (c *conversation) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (c *conversation) fillFieldMap() {
-	c.fieldMap = make(map[string]field.Expr, 20)
+	c.fieldMap = make(map[string]field.Expr, 22)
 	c.fieldMap["id"] = c.ID
 	c.fieldMap["created_at"] = c.CreatedAt
 	c.fieldMap["updated_at"] = c.UpdatedAt
@@ -191,6 +197,7 @@ func (c *conversation) fillFieldMap() {
 	c.fieldMap["public_id"] = c.PublicID
 	c.fieldMap["object"] = c.Object
 	c.fieldMap["title"] = c.Title
+	c.fieldMap["title_locked"] = c.TitleLocked
 	c.fieldMap["user_id"] = c.UserID
 	c.fieldMap["project_id"] = c.ProjectID
 	c.fieldMap["project_public_id"] = c.ProjectPublicID
@@ -201,6 +208,7 @@ func (c *conversation) fillFieldMap() {
 	c.fieldMap["is_private"] = c.IsPrivate
 	c.fieldMap["instruction_version"] = c.InstructionVersion
 	c.fieldMap["effective_instruction_snapshot"] = c.EffectiveInstructionSnapshot
+	c.fieldMap["version"] = c.Version
 
 }
 
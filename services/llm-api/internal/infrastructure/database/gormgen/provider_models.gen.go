@@ -48,6 +48,7 @@ func newProviderModel(db *gorm.DB, opts ...gen.DOOption) providerModel {
 	_providerModel.SupportsThinkingMode = field.NewBool(tableName, "supports_thinking_mode")
 	_providerModel.DefaultConversationMode = field.NewString(tableName, "default_conversation_mode")
 	_providerModel.ReasoningConfig = field.NewField(tableName, "reasoning_config")
+	_providerModel.ReasoningPolicy = field.NewField(tableName, "reasoning_policy")
 	_providerModel.ProviderFlags = field.NewField(tableName, "provider_flags")
 	_providerModel.Active = field.NewBool(tableName, "active")
 
@@ -80,6 +81,7 @@ type providerModel struct {
 	SupportsThinkingMode    field.Bool
 	DefaultConversationMode field.String
 	ReasoningConfig         field.Field
+	ReasoningPolicy         field.Field
 	ProviderFlags           field.Field
 	Active                  field.Bool
 
@@ -118,6 +120,7 @@ func (p *providerModel) updateTableName(table string) *providerModel {
 	p.SupportsThinkingMode = field.NewBool(table, "supports_thinking_mode")
 	p.DefaultConversationMode = field.NewString(table, "default_conversation_mode")
 	p.ReasoningConfig = field.NewField(table, "reasoning_config")
+	p.ReasoningPolicy = field.NewField(table, "reasoning_policy")
 	p.ProviderFlags = field.NewField(table, "provider_flags")
 	p.Active = field.NewBool(table, "active")
 
@@ -136,7 +139,7 @@ func (p *providerModel) GetFieldByName(fieldName string) (field.OrderExpr, bool)
 }
 
 func (p *providerModel) fillFieldMap() {
-	p.fieldMap = make(map[string]field.Expr, 22)
+	p.fieldMap = make(map[string]field.Expr, 23)
 	p.fieldMap["id"] = p.ID
 	p.fieldMap["created_at"] = p.CreatedAt
 	p.fieldMap["updated_at"] = p.UpdatedAt
@@ -157,6 +160,7 @@ func (p *providerModel) fillFieldMap() {
 	p.fieldMap["supports_thinking_mode"] = p.SupportsThinkingMode
 	p.fieldMap["default_conversation_mode"] = p.DefaultConversationMode
 	p.fieldMap["reasoning_config"] = p.ReasoningConfig
+	p.fieldMap["reasoning_policy"] = p.ReasoningPolicy
 	p.fieldMap["provider_flags"] = p.ProviderFlags
 	p.fieldMap["active"] = p.Active
 }
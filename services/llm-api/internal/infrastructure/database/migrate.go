@@ -63,6 +63,15 @@ func AutoMigrate(gormDB *gorm.DB) (err error) {
 		}
 	}()
 
+	// Index builds may outlast DB_STATEMENT_TIMEOUT; lift it on this connection
+	// only, and restore the connection default before it returns to the pool.
+	if _, err := conn.ExecContext(context.Background(), "SET statement_timeout = 0"); err != nil {
+		return fmt.Errorf("lift statement timeout: %w", err)
+	}
+	defer func() {
+		_, _ = conn.ExecContext(context.Background(), "RESET statement_timeout")
+	}()
+
 	source, err := iofs.New(migrations.FS, ".")
 	if err != nil {
 		return fmt.Errorf("load migrations: %w", err)
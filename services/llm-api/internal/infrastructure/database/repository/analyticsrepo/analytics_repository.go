@@ -0,0 +1,225 @@
+package analyticsrepo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jan-server/services/llm-api/internal/domain/analytics"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// AnalyticsGormRepository implements analytics.Repository using GORM
+type AnalyticsGormRepository struct {
+	db *transaction.Database
+}
+
+var _ analytics.Repository = (*AnalyticsGormRepository)(nil)
+
+// NewAnalyticsGormRepository creates a new analytics repository
+func NewAnalyticsGormRepository(db *transaction.Database) analytics.Repository {
+	return &AnalyticsGormRepository{db: db}
+}
+
+// rollupLockKey is the Postgres advisory lock taken while a day is rolled up, so
+// replicas running the nightly job at the same time do not rebuild it twice
+const rollupLockKey int64 = 0x616e6c7974696373
+
+// Rollup statements for one day. Each takes the day and the [start, end) bounds of
+// that day; messages come from stored conversations, everything else from events.
+const (
+	rollupActivitySQL = `
+INSERT INTO llm_api.user_daily_activity
+    (user_id, activity_date, user_messages, assistant_messages, completions, failed_completions, total_latency_ms, updated_at)
+SELECT user_id, ?::date, SUM(user_messages), SUM(assistant_messages), SUM(completions), SUM(failed_completions), SUM(total_latency_ms), NOW()
+FROM (
+    SELECT c.user_id,
+           COUNT(*) FILTER (WHERE i.role = 'user') AS user_messages,
+           COUNT(*) FILTER (WHERE i.role = 'assistant') AS assistant_messages,
+           0 AS completions, 0 AS failed_completions, 0 AS total_latency_ms
+    FROM llm_api.conversation_items i
+    JOIN llm_api.conversations c ON c.id = i.conversation_id
+    WHERE i.type = 'message' AND i.created_at >= ? AND i.created_at < ?
+    GROUP BY c.user_id
+    UNION ALL
+    SELECT user_id, 0, 0,
+           COUNT(*) FILTER (WHERE succeeded),
+           COUNT(*) FILTER (WHERE NOT succeeded),
+           COALESCE(SUM(latency_ms) FILTER (WHERE succeeded), 0)
+    FROM llm_api.completion_events
+    WHERE created_at >= ? AND created_at < ?
+    GROUP BY user_id
+) daily
+GROUP BY user_id`
+
+	rollupModelsSQL = `
+INSERT INTO llm_api.user_daily_model_usage
+    (user_id, activity_date, model, requests, prompt_tokens, completion_tokens, total_latency_ms, updated_at)
+SELECT user_id, ?::date, model, COUNT(*), SUM(prompt_tokens), SUM(completion_tokens), SUM(latency_ms), NOW()
+FROM llm_api.completion_events
+WHERE succeeded AND created_at >= ? AND created_at < ?
+GROUP BY user_id, model`
+
+	rollupToolsSQL = `
+INSERT INTO llm_api.user_daily_tool_usage (user_id, activity_date, tool_name, calls, updated_at)
+SELECT e.user_id, ?::date, LEFT(t.tool_name, 255), COUNT(*), NOW()
+FROM llm_api.completion_events e
+CROSS JOIN LATERAL jsonb_array_elements_text(COALESCE(e.tool_calls, '[]'::jsonb)) AS t(tool_name)
+WHERE e.created_at >= ? AND e.created_at < ?
+GROUP BY e.user_id, LEFT(t.tool_name, 255)`
+)
+
+// CreateEvent implements analytics.Repository.
+func (repo *AnalyticsGormRepository) CreateEvent(ctx context.Context, event *analytics.CompletionEvent) error {
+	model := dbschema.NewSchemaCompletionEvent(event)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create completion event", "6e2a9d14-8b5f-4c37-a0e1-3d7c5b9f2a48")
+	}
+	event.ID = model.ID
+	return nil
+}
+
+// RollupDay implements analytics.Repository.
+func (repo *AnalyticsGormRepository) RollupDay(ctx context.Context, day time.Time) error {
+	day = day.UTC()
+	start := time.Date(day.Year(), day.Month(), day.Day(), 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 1)
+	date := start.Format(analytics.DateLayout)
+
+	err := repo.db.GetTx(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked bool
+		if err := tx.Raw("SELECT pg_try_advisory_xact_lock(?)", rollupLockKey).Scan(&locked).Error; err != nil {
+			return err
+		}
+		if !locked {
+			// Another replica is rolling up; its result is identical
+			return nil
+		}
+		for _, table := range []string{"user_daily_activity", "user_daily_model_usage", "user_daily_tool_usage"} {
+			if err := tx.Exec("DELETE FROM llm_api."+table+" WHERE activity_date = ?::date", date).Error; err != nil {
+				return err
+			}
+		}
+		if err := tx.Exec(rollupActivitySQL, date, start, end, start, end).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec(rollupModelsSQL, date, start, end).Error; err != nil {
+			return err
+		}
+		return tx.Exec(rollupToolsSQL, date, start, end).Error
+	})
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to roll up analytics for "+date, "9b4f1e63-2d8a-4c05-b7e6-0a3c8f5d1e92")
+	}
+	return nil
+}
+
+// FindDailyMessages implements analytics.Repository.
+func (repo *AnalyticsGormRepository) FindDailyMessages(ctx context.Context, userID uint, r analytics.TimeRange) ([]analytics.DailyMessages, error) {
+	var rows []analytics.DailyMessages
+	err := repo.db.GetReadTx(ctx).
+		Table("llm_api.user_daily_activity").
+		Select("TO_CHAR(activity_date, 'YYYY-MM-DD') AS date, user_messages, assistant_messages").
+		Where("user_id = ? AND activity_date BETWEEN ?::date AND ?::date", userID, r.StartDate(), r.EndDate()).
+		Order("activity_date").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find daily messages", "1d7c3f58-a2e9-4b60-8f14-5c0b9e6a2d37")
+	}
+	return rows, nil
+}
+
+// FindTopModels implements analytics.Repository.
+func (repo *AnalyticsGormRepository) FindTopModels(ctx context.Context, userID uint, r analytics.TimeRange, limit int) ([]analytics.ModelUsage, error) {
+	var rows []analytics.ModelUsage
+	err := repo.db.GetReadTx(ctx).
+		Table("llm_api.user_daily_model_usage").
+		Select(`model,
+			SUM(requests) AS requests,
+			SUM(prompt_tokens) AS prompt_tokens,
+			SUM(completion_tokens) AS completion_tokens,
+			COALESCE(SUM(total_latency_ms)::float8 / NULLIF(SUM(requests), 0), 0) AS average_latency_ms`).
+		Where("user_id = ? AND activity_date BETWEEN ?::date AND ?::date", userID, r.StartDate(), r.EndDate()).
+		Group("model").
+		Order("requests DESC, model").
+		Limit(limit).
+		Scan(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find top models", "4a8e2c71-6f3d-4b95-9e07-2b1d5c8f3a64")
+	}
+	return rows, nil
+}
+
+// FindToolUsage implements analytics.Repository.
+func (repo *AnalyticsGormRepository) FindToolUsage(ctx context.Context, userID uint, r analytics.TimeRange) ([]analytics.ToolUsage, error) {
+	var rows []analytics.ToolUsage
+	err := repo.db.GetReadTx(ctx).
+		Table("llm_api.user_daily_tool_usage").
+		Select("tool_name, SUM(calls) AS calls").
+		Where("user_id = ? AND activity_date BETWEEN ?::date AND ?::date", userID, r.StartDate(), r.EndDate()).
+		Group("tool_name").
+		Order("calls DESC, tool_name").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find tool usage", "c5f09b36-1e7a-4d82-a3c4-8d6f2e0b9a15")
+	}
+	return rows, nil
+}
+
+// FindDailyLatency implements analytics.Repository.
+func (repo *AnalyticsGormRepository) FindDailyLatency(ctx context.Context, userID uint, r analytics.TimeRange) ([]analytics.DailyLatency, error) {
+	var rows []analytics.DailyLatency
+	err := repo.db.GetReadTx(ctx).
+		Table("llm_api.user_daily_activity").
+		Select("TO_CHAR(activity_date, 'YYYY-MM-DD') AS date, completions, failed_completions, total_latency_ms").
+		Where("user_id = ? AND activity_date BETWEEN ?::date AND ?::date AND (completions > 0 OR failed_completions > 0)", userID, r.StartDate(), r.EndDate()).
+		Order("activity_date").
+		Scan(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find daily latency", "7b3d6a92-4c1e-4f58-8a0d-9e2f5b7c1d43")
+	}
+	return rows, nil
+}
+
+// usageKeys maps each admin usage grouping to its key expression over completion_events
+var usageKeys = map[string]string{
+	analytics.UsageByModel:    "model",
+	analytics.UsageByProvider: "provider",
+	analytics.UsageByUser:     "user_id::text",
+	analytics.UsageByDay:      "TO_CHAR(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD')",
+}
+
+// FindUsage implements analytics.Repository.
+func (repo *AnalyticsGormRepository) FindUsage(ctx context.Context, q analytics.UsageQuery) ([]analytics.UsageRow, error) {
+	key, ok := usageKeys[q.By]
+	if !ok {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerRepository, platformerrors.ErrorTypeValidation,
+			"unknown usage grouping "+q.By, nil, "3f8a1d6c-5b2e-4c97-8d04-a7e9c2b6f153")
+	}
+	order := "requests DESC, key"
+	if q.By == analytics.UsageByDay {
+		order = "key"
+	}
+
+	sql := repo.db.GetReadTx(ctx).
+		Table("llm_api.completion_events").
+		Select(key+` AS key,
+			COUNT(*) AS requests,
+			COUNT(*) FILTER (WHERE NOT succeeded) AS failed_requests,
+			COALESCE(SUM(prompt_tokens), 0) AS prompt_tokens,
+			COALESCE(SUM(completion_tokens), 0) AS completion_tokens,
+			COALESCE(AVG(latency_ms) FILTER (WHERE succeeded), 0) AS average_latency_ms`).
+		Where("created_at >= ? AND created_at < ?", q.Since, q.Until)
+	if q.UserID != nil {
+		sql = sql.Where("user_id = ?", *q.UserID)
+	}
+
+	var rows []analytics.UsageRow
+	if err := sql.Group(key).Order(order).Scan(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find platform usage", "b9e24c71-0d6a-4f38-95c1-6a2f8e3d7b04")
+	}
+	return rows, nil
+}
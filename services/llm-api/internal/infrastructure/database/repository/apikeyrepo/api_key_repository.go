@@ -27,12 +27,16 @@ func (r *Repository) Create(ctx context.Context, key *apikey.APIKey) (*apikey.AP
 	return model.EtoD(), nil
 }
 
-func (r *Repository) ListByUser(ctx context.Context, userID uint) ([]apikey.APIKey, error) {
+func (r *Repository) ListByUser(ctx context.Context, userID uint, after *apikey.ListCursor, limit int) ([]apikey.APIKey, error) {
 	var models []dbschema.APIKey
-	if err := r.db.WithContext(ctx).
-		Where("user_id = ?", userID).
-		Order("created_at DESC").
-		Find(&models).Error; err != nil {
+	q := r.db.WithContext(ctx).Where("user_id = ?", userID)
+	if after != nil {
+		q = q.Where("(created_at, id) < (?, ?)", after.CreatedAt, after.ID)
+	}
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+	if err := q.Order("created_at DESC, id DESC").Find(&models).Error; err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to list api keys")
 	}
 	result := make([]apikey.APIKey, 0, len(models))
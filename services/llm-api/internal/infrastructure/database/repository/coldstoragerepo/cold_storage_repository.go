@@ -0,0 +1,235 @@
+package coldstoragerepo
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// ColdStorageGormRepository implements coldstorage.Repository using GORM
+type ColdStorageGormRepository struct {
+	db *transaction.Database
+}
+
+var _ coldstorage.Repository = (*ColdStorageGormRepository)(nil)
+
+// NewColdStorageGormRepository creates a new cold storage repository
+func NewColdStorageGormRepository(db *transaction.Database) coldstorage.Repository {
+	return &ColdStorageGormRepository{db: db}
+}
+
+// archivedItem is the payload of one item in an archive. Sealed items keep
+// their ciphertext, so archives hold no plaintext encryption at rest would have
+// protected.
+type archivedItem struct {
+	ID                       uint                      `json:"id"`
+	PublicID                 string                    `json:"public_id"`
+	Content                  dbschema.JSONContent      `json:"content,omitempty"`
+	Arguments                *string                   `json:"arguments,omitempty"`
+	Output                   *string                   `json:"output,omitempty"`
+	Error                    *string                   `json:"error,omitempty"`
+	ErrorDetail              *dbschema.JSONToolError   `json:"error_detail,omitempty"`
+	Action                   dbschema.JSONAction       `json:"action,omitempty"`
+	Tools                    dbschema.JSONMcpTools     `json:"tools,omitempty"`
+	PendingSafetyChecks      dbschema.JSONSafetyChecks `json:"pending_safety_checks,omitempty"`
+	AcknowledgedSafetyChecks dbschema.JSONSafetyChecks `json:"acknowledged_safety_checks,omitempty"`
+	Commands                 dbschema.JSONCommands     `json:"commands,omitempty"`
+	ShellOutputs             dbschema.JSONShellOutputs `json:"shell_outputs,omitempty"`
+	Operation                dbschema.JSONOperation    `json:"operation,omitempty"`
+	ContentCiphertext        []byte                    `json:"content_ciphertext,omitempty"`
+}
+
+// payloadColumns lists the columns an archive holds; they are NULL on stubs
+var payloadColumns = []string{
+	"content", "arguments", "output", "error", "error_detail", "action", "tools",
+	"pending_safety_checks", "acknowledged_safety_checks", "commands", "shell_outputs",
+	"operation", "content_ciphertext",
+}
+
+// FindIdleConversations implements coldstorage.Repository. A conversation is
+// idle when neither it nor any of its hot items changed since cutoff; items
+// restored by a rehydration count as changed, so they stay hot for another
+// period.
+func (repo *ColdStorageGormRepository) FindIdleConversations(ctx context.Context, cutoff time.Time, afterID uint, limit int) ([]uint, error) {
+	var ids []uint
+	err := repo.db.GetReadTx(ctx).
+		Model(&dbschema.Conversation{}).
+		Where("id > ? AND updated_at < ?", afterID, cutoff).
+		Where(`EXISTS (
+			SELECT 1 FROM llm_api.conversation_items i
+			WHERE i.conversation_id = conversations.id AND i.deleted_at IS NULL
+			  AND i.cold_archive_id IS NULL AND i.status IS DISTINCT FROM ?
+		)`, string(conversation.ItemStatusInProgress)).
+		Where(`NOT EXISTS (
+			SELECT 1 FROM llm_api.conversation_items i
+			WHERE i.conversation_id = conversations.id AND i.deleted_at IS NULL
+			  AND i.cold_archive_id IS NULL AND i.updated_at >= ?
+		)`, cutoff).
+		Order("id").
+		Limit(limit).
+		Pluck("id", &ids).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find idle conversations", "6d2f8b14-9e3a-4c71-a5d0-3b7e1f9c2a86")
+	}
+	return ids, nil
+}
+
+// ExportItems implements coldstorage.Repository.
+func (repo *ColdStorageGormRepository) ExportItems(ctx context.Context, conversationID uint, cutoff time.Time, limit int) (*coldstorage.Export, error) {
+	var rows []dbschema.ConversationItem
+	err := repo.db.GetTx(ctx).
+		Where("conversation_id = ? AND cold_archive_id IS NULL AND updated_at < ?", conversationID, cutoff).
+		Where("status IS DISTINCT FROM ?", string(conversation.ItemStatusInProgress)).
+		Order("id").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to load items to archive", "a4c9e2f7-1b6d-4e38-90f5-2d8a7c3b6e41")
+	}
+
+	export := &coldstorage.Export{ItemIDs: make([]uint, 0, len(rows))}
+	items := make([]archivedItem, 0, len(rows))
+	for i := range rows {
+		row := &rows[i]
+		export.ItemIDs = append(export.ItemIDs, row.ID)
+		items = append(items, archivedItem{
+			ID:                       row.ID,
+			PublicID:                 row.PublicID,
+			Content:                  row.Content,
+			Arguments:                row.Arguments,
+			Output:                   row.Output,
+			Error:                    row.Error,
+			ErrorDetail:              row.ErrorDetail,
+			Action:                   row.Action,
+			Tools:                    row.Tools,
+			PendingSafetyChecks:      row.PendingSafetyChecks,
+			AcknowledgedSafetyChecks: row.AcknowledgedSafetyChecks,
+			Commands:                 row.Commands,
+			ShellOutputs:             row.ShellOutputs,
+			Operation:                row.Operation,
+			ContentCiphertext:        row.ContentCiphertext,
+		})
+	}
+	if len(items) == 0 {
+		return export, nil
+	}
+	payload, err := json.Marshal(items)
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to encode items to archive", "f1b7d3a9-6c2e-4f85-b0a4-8e5c2d7f1a63")
+	}
+	export.Payload = payload
+	return export, nil
+}
+
+// SaveArchive implements coldstorage.Repository.
+func (repo *ColdStorageGormRepository) SaveArchive(ctx context.Context, archive *coldstorage.Archive, export *coldstorage.Export, cutoff time.Time) error {
+	err := repo.db.GetTx(ctx).Transaction(func(tx *gorm.DB) error {
+		model := dbschema.NewSchemaConversationItemArchive(archive)
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+
+		updates := make(map[string]interface{}, len(payloadColumns)+1)
+		for _, column := range payloadColumns {
+			updates[column] = nil
+		}
+		updates["cold_archive_id"] = model.ID
+		// Items changed since the export have updated_at past cutoff and stay hot
+		result := tx.Model(&dbschema.ConversationItem{}).
+			Where("conversation_id = ? AND id IN ? AND cold_archive_id IS NULL AND updated_at < ?", archive.ConversationID, export.ItemIDs, cutoff).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return tx.Delete(model).Error
+		}
+		if err := tx.Model(model).Update("item_count", result.RowsAffected).Error; err != nil {
+			return err
+		}
+
+		archive.ID = model.ID
+		archive.ItemCount = int(result.RowsAffected)
+		archive.CreatedAt = model.CreatedAt
+		return nil
+	})
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to save archive", "3e8a5c2d-7f14-4b96-a3e1-9c6d0b4f7e28")
+	}
+	return nil
+}
+
+// FindArchives implements coldstorage.Repository.
+func (repo *ColdStorageGormRepository) FindArchives(ctx context.Context, conversationID uint) ([]*coldstorage.Archive, error) {
+	var rows []dbschema.ConversationItemArchive
+	if err := repo.db.GetTx(ctx).Where("conversation_id = ?", conversationID).Order("id").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find conversation archives", "b2d6f9e3-4a1c-4e75-8d07-5f3b9a2c6e14")
+	}
+	archives := make([]*coldstorage.Archive, 0, len(rows))
+	for i := range rows {
+		archives = append(archives, rows[i].EtoD())
+	}
+	return archives, nil
+}
+
+// RestoreArchive implements coldstorage.Repository. Stubs already restored by
+// a concurrent rehydration are skipped; soft-deleted stubs are restored too, so
+// no item is left pointing at the deleted archive.
+func (repo *ColdStorageGormRepository) RestoreArchive(ctx context.Context, archive *coldstorage.Archive, payload []byte) (int, error) {
+	var items []archivedItem
+	if err := json.Unmarshal(payload, &items); err != nil {
+		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to decode archive", "7c1e4a9f-2d5b-4f03-b8e6-1a9d3c7f5b20")
+	}
+
+	restored := 0
+	err := repo.db.GetTx(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, item := range items {
+			result := tx.Unscoped().Model(&dbschema.ConversationItem{}).
+				Where("id = ? AND public_id = ? AND conversation_id = ? AND cold_archive_id = ?", item.ID, item.PublicID, archive.ConversationID, archive.ID).
+				Updates(map[string]interface{}{
+					"content":                    item.Content,
+					"arguments":                  item.Arguments,
+					"output":                     item.Output,
+					"error":                      item.Error,
+					"error_detail":               item.ErrorDetail,
+					"action":                     item.Action,
+					"tools":                      item.Tools,
+					"pending_safety_checks":      item.PendingSafetyChecks,
+					"acknowledged_safety_checks": item.AcknowledgedSafetyChecks,
+					"commands":                   item.Commands,
+					"shell_outputs":              item.ShellOutputs,
+					"operation":                  item.Operation,
+					"content_ciphertext":         item.ContentCiphertext,
+					"cold_archive_id":            nil,
+				})
+			if result.Error != nil {
+				return fmt.Errorf("restore item %s: %w", item.PublicID, result.Error)
+			}
+			restored += int(result.RowsAffected)
+		}
+		// The archive is only removed once no stub references it
+		var remaining int64
+		if err := tx.Unscoped().Model(&dbschema.ConversationItem{}).
+			Where("cold_archive_id = ?", archive.ID).
+			Count(&remaining).Error; err != nil {
+			return err
+		}
+		if remaining > 0 {
+			return fmt.Errorf("%d item(s) of archive %d were not restored", remaining, archive.ID)
+		}
+		return tx.Delete(&dbschema.ConversationItemArchive{}, archive.ID).Error
+	})
+	if err != nil {
+		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to restore archive", "d5a8c3f1-9b2e-4d64-a7f0-6e1b4c8d2a95")
+	}
+	return restored, nil
+}
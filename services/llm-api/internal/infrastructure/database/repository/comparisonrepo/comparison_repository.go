@@ -0,0 +1,114 @@
+package comparisonrepo
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"jan-server/services/llm-api/internal/domain/comparison"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// ComparisonGormRepository implements comparison.Repository using GORM
+type ComparisonGormRepository struct {
+	db *transaction.Database
+}
+
+var _ comparison.Repository = (*ComparisonGormRepository)(nil)
+
+// NewComparisonGormRepository creates a new comparison repository
+func NewComparisonGormRepository(db *transaction.Database) comparison.Repository {
+	return &ComparisonGormRepository{db: db}
+}
+
+// leaderboardSQL ranks models over voted comparisons. A winner vote counts as a win for
+// the winning slot and a loss for the others; all_bad counts as a loss for every model.
+const leaderboardSQL = `
+SELECT r.model,
+       COUNT(*) AS votes,
+       COUNT(*) FILTER (WHERE c.vote = 'winner' AND c.winner_slot = r.slot) AS wins,
+       COUNT(*) FILTER (WHERE (c.vote = 'winner' AND c.winner_slot <> r.slot) OR c.vote = 'all_bad') AS losses,
+       COUNT(*) FILTER (WHERE c.vote = 'tie') AS ties,
+       COUNT(*) FILTER (WHERE c.vote = 'winner' AND c.winner_slot = r.slot)::float8 / COUNT(*) AS win_rate,
+       COALESCE(AVG(r.latency_ms) FILTER (WHERE r.succeeded), 0) AS average_latency_ms
+FROM llm_api.model_comparison_results r
+JOIN llm_api.model_comparisons c ON c.id = r.comparison_id
+WHERE c.vote IS NOT NULL AND c.voted_at >= ? AND c.voted_at < ?
+GROUP BY r.model
+HAVING COUNT(*) >= ?
+ORDER BY win_rate DESC, votes DESC, r.model`
+
+// Create implements comparison.Repository.
+func (repo *ComparisonGormRepository) Create(ctx context.Context, c *comparison.Comparison) error {
+	model := dbschema.NewSchemaModelComparison(c)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create comparison", "4e7b2a95-1c8d-4f36-a0e9-5d3b8c1f7a24")
+	}
+	c.ID = model.ID
+	c.CreatedAt = model.CreatedAt
+	c.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindByPublicID implements comparison.Repository.
+func (repo *ComparisonGormRepository) FindByPublicID(ctx context.Context, publicID string) (*comparison.Comparison, error) {
+	var model dbschema.ModelComparison
+	err := repo.db.GetReadTx(ctx).
+		Preload("Results", func(db *gorm.DB) *gorm.DB { return db.Order("slot") }).
+		Where("public_id = ?", publicID).
+		First(&model).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find comparison by public ID", "a8d3f160-7b2e-4c59-9e14-2f6c0b8d3a71")
+	}
+	return model.EtoD(), nil
+}
+
+// SaveResults implements comparison.Repository.
+func (repo *ComparisonGormRepository) SaveResults(ctx context.Context, c *comparison.Comparison) error {
+	err := repo.db.GetTx(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(c.Results) > 0 {
+			rows := make([]*dbschema.ModelComparisonResult, 0, len(c.Results))
+			for _, r := range c.Results {
+				rows = append(rows, dbschema.NewSchemaModelComparisonResult(c.ID, r))
+			}
+			if err := tx.Create(&rows).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Model(&dbschema.ModelComparison{}).
+			Where("id = ?", c.ID).
+			Update("completed_at", c.CompletedAt).Error
+	})
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to save comparison results", "6c1f9e3b-2a7d-4d08-b5e6-9f4a3c7d1e52")
+	}
+	return nil
+}
+
+// SaveVote implements comparison.Repository.
+func (repo *ComparisonGormRepository) SaveVote(ctx context.Context, c *comparison.Comparison) error {
+	model := dbschema.NewSchemaModelComparison(c)
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.ModelComparison{}).
+		Where("id = ?", c.ID).
+		Updates(map[string]any{
+			"vote":        model.Vote,
+			"winner_slot": model.WinnerSlot,
+			"voted_at":    model.VotedAt,
+		}).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to save comparison vote", "d2e5a8c7-9b1f-4e63-8a0d-7c4f2b9e6d18")
+	}
+	return nil
+}
+
+// FindLeaderboard implements comparison.Repository.
+func (repo *ComparisonGormRepository) FindLeaderboard(ctx context.Context, filter comparison.LeaderboardFilter) ([]comparison.LeaderboardEntry, error) {
+	var rows []comparison.LeaderboardEntry
+	if err := repo.db.GetReadTx(ctx).Raw(leaderboardSQL, filter.From, filter.To, filter.MinVotes).Scan(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find comparison leaderboard", "f9b3c6d2-4e8a-4a71-b0c5-3e7d1f8a2c96")
+	}
+	return rows, nil
+}
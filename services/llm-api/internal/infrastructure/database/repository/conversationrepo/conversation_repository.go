@@ -4,28 +4,37 @@ import (
 	"context"
 	"time"
 
+	"gorm.io/gen"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
 	"jan-server/services/llm-api/internal/domain/conversation"
 	"jan-server/services/llm-api/internal/domain/query"
 	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
 	"jan-server/services/llm-api/internal/infrastructure/database/gormgen"
 	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/infrastructure/encryption"
 	"jan-server/services/llm-api/internal/utils/functional"
 	"jan-server/services/llm-api/internal/utils/idgen"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 )
 
 type ConversationGormRepository struct {
-	db *transaction.Database
+	db         *transaction.Database
+	encryption *encryption.Service
 }
 
 var _ conversation.ConversationRepository = (*ConversationGormRepository)(nil)
 
-func NewConversationGormRepository(db *transaction.Database) conversation.ConversationRepository {
-	return &ConversationGormRepository{db}
+func NewConversationGormRepository(db *transaction.Database, enc *encryption.Service) conversation.ConversationRepository {
+	return &ConversationGormRepository{db: db, encryption: enc}
 }
 
 // Create implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) Create(ctx context.Context, conv *conversation.Conversation) error {
+	if conv.Version == 0 {
+		conv.Version = 1
+	}
 	model := dbschema.NewSchemaConversation(conv)
 	if err := repo.db.GetQuery(ctx).Conversation.WithContext(ctx).Create(model); err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to create conversation")
@@ -39,7 +48,7 @@ func (repo *ConversationGormRepository) Create(ctx context.Context, conv *conver
 
 // FindByFilter implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) FindByFilter(ctx context.Context, filter conversation.ConversationFilter, pagination *query.Pagination) ([]*conversation.Conversation, error) {
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.Conversation.WithContext(ctx)
 	sql = repo.applyFilter(q, sql, filter)
 	sql = repo.applyPagination(q, sql, pagination)
@@ -57,7 +66,7 @@ func (repo *ConversationGormRepository) FindByFilter(ctx context.Context, filter
 
 // Count implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) Count(ctx context.Context, filter conversation.ConversationFilter) (int64, error) {
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.Conversation.WithContext(ctx)
 	sql = repo.applyFilter(q, sql, filter)
 	return sql.Count()
@@ -88,17 +97,85 @@ func (repo *ConversationGormRepository) FindByPublicID(ctx context.Context, publ
 }
 
 // Update implements conversation.ConversationRepository.
+// The write is a compare-and-swap on conv.Version: if another writer updated the
+// row since conv was loaded, nothing is written and a conflict error is returned.
 func (repo *ConversationGormRepository) Update(ctx context.Context, conv *conversation.Conversation) error {
+	return repo.update(ctx, conv, true)
+}
+
+// Overwrite implements conversation.ConversationRepository.
+// Unlike Update it writes regardless of conv.Version (last write wins), but still
+// bumps the version so conditional writers notice the change.
+func (repo *ConversationGormRepository) Overwrite(ctx context.Context, conv *conversation.Conversation) error {
+	return repo.update(ctx, conv, false)
+}
+
+func (repo *ConversationGormRepository) update(ctx context.Context, conv *conversation.Conversation, checkVersion bool) error {
 	model := dbschema.NewSchemaConversation(conv)
-	q := repo.db.GetQuery(ctx)
+	updatedAt := time.Now()
+
+	sql := repo.db.GetTx(ctx).WithContext(ctx).
+		Model(&dbschema.Conversation{}).
+		Where("id = ?", conv.ID)
+	if checkVersion {
+		sql = sql.Where("version = ?", conv.Version)
+	}
+	result := sql.Updates(map[string]interface{}{
+		"title":                          model.Title,
+		"title_locked":                   model.TitleLocked,
+		"project_id":                     model.ProjectID,
+		"project_public_id":              model.ProjectPublicID,
+		"status":                         model.Status,
+		"active_branch":                  model.ActiveBranch,
+		"referrer":                       model.Referrer,
+		"metadata":                       model.Metadata,
+		"is_private":                     model.IsPrivate,
+		"instruction_version":            model.InstructionVersion,
+		"effective_instruction_snapshot": model.EffectiveInstructionSnapshot,
+		"updated_at":                     updatedAt,
+		"version":                        gorm.Expr("version + 1"),
+	})
+	if result.Error != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, result.Error, "failed to update conversation")
+	}
+	if result.RowsAffected == 0 {
+		if !checkVersion {
+			return platformerrors.NewError(ctx, platformerrors.LayerRepository, platformerrors.ErrorTypeNotFound, "conversation not found", nil, "e2a7c4d9-3f16-4b85-9c0e-6d1b8a5f2e73")
+		}
+		return platformerrors.NewError(ctx, platformerrors.LayerRepository, platformerrors.ErrorTypeConflict, "conversation was modified by another request", nil, "5b8e2f14-7c3a-4d9e-a1f6-0e4b7d2c9a35")
+	}
+
+	if checkVersion {
+		conv.Version++
+	} else if err := repo.db.GetTx(ctx).WithContext(ctx).Model(&dbschema.Conversation{}).
+		Where("id = ?", conv.ID).Pluck("version", &conv.Version).Error; err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to read conversation version")
+	}
+	conv.UpdatedAt = updatedAt
+	return nil
+}
 
-	// Use Save to update all fields
-	if err := q.Conversation.WithContext(ctx).Where(q.Conversation.ID.Eq(conv.ID)).Save(model); err != nil {
-		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to update conversation")
+// Touch implements conversation.ConversationRepository.
+func (repo *ConversationGormRepository) Touch(ctx context.Context, id uint, updatedAt time.Time) error {
+	q := repo.db.GetQuery(ctx)
+	if _, err := q.Conversation.WithContext(ctx).Where(q.Conversation.ID.Eq(id)).Update(q.Conversation.UpdatedAt, updatedAt); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to touch conversation")
 	}
+	return nil
+}
 
-	// Update timestamps
-	conv.UpdatedAt = model.UpdatedAt
+// SetInstructionSnapshot implements conversation.ConversationRepository.
+func (repo *ConversationGormRepository) SetInstructionSnapshot(ctx context.Context, id uint, version int, snapshot *string) error {
+	err := repo.db.GetTx(ctx).WithContext(ctx).
+		Model(&dbschema.Conversation{}).
+		Where("id = ?", id).
+		Updates(map[string]interface{}{
+			"instruction_version":            version,
+			"effective_instruction_snapshot": snapshot,
+		}).Error
+	if err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to set conversation instruction snapshot")
+	}
 	return nil
 }
 
@@ -136,6 +213,9 @@ func (repo *ConversationGormRepository) AddItem(ctx context.Context, conversatio
 
 	// Create the item
 	model := dbschema.NewSchemaConversationItem(item)
+	if err := repo.encryption.SealItem(ctx, model); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to encrypt conversation item")
+	}
 	q := repo.db.GetQuery(ctx)
 
 	if err := q.ConversationItem.WithContext(ctx).Create(model); err != nil {
@@ -151,29 +231,23 @@ func (repo *ConversationGormRepository) AddItem(ctx context.Context, conversatio
 
 // SearchItems implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) SearchItems(ctx context.Context, conversationID uint, searchQuery string) ([]*conversation.Item, error) {
-	// For now, this is a simple implementation
-	// In production, you'd want to use full-text search or a search engine like Elasticsearch
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.ConversationItem.WithContext(ctx)
 	sql = repo.applyItemFilter(q, sql, conversation.ItemFilter{
 		ConversationID: &conversationID,
 	})
 
-	// Search in content JSON field (PostgreSQL JSONB search)
-	// This is a basic implementation - enhance based on your database capabilities
-	// Note: For proper JSON search in PostgreSQL, you might need raw SQL or custom query
-	rows, err := sql.Find()
-
-	if err != nil {
+	var rows []*dbschema.ConversationItem
+	if err := applyContentSearch(sql.UnderlyingDB(), searchQuery).Order("id ASC").Find(&rows).Error; err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to search items")
 	}
+	if err := repo.encryption.OpenItems(ctx, rows); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt items")
+	}
 
 	result := functional.Map(rows, func(item *dbschema.ConversationItem) *conversation.Item {
 		return item.EtoD()
 	})
-
-	// TODO: Implement proper full-text search filtering
-	// For now, returning all items in the conversation
 	return result, nil
 }
 
@@ -198,6 +272,9 @@ func (repo *ConversationGormRepository) BulkAddItems(ctx context.Context, conver
 	models := functional.Map(items, func(item *conversation.Item) *dbschema.ConversationItem {
 		return dbschema.NewSchemaConversationItem(item)
 	})
+	if err := repo.encryption.SealItems(ctx, models); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to encrypt items")
+	}
 
 	// Bulk insert with manual batching to ensure ID population
 	q := repo.db.GetQuery(ctx)
@@ -237,6 +314,9 @@ func (repo *ConversationGormRepository) GetItemByID(ctx context.Context, convers
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find item by ID")
 	}
+	if err := repo.encryption.OpenItem(ctx, result); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item")
+	}
 	return result.EtoD(), nil
 }
 
@@ -252,34 +332,46 @@ func (repo *ConversationGormRepository) GetItemByPublicID(ctx context.Context, c
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find item by public ID")
 	}
+	if err := repo.encryption.OpenItem(ctx, result); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item")
+	}
 	return result.EtoD(), nil
 }
 
 // GetItemByCallID implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) GetItemByCallID(ctx context.Context, conversationID uint, callID string) (*conversation.Item, error) {
 	q := repo.db.GetQuery(ctx)
-	// Use raw SQL since gormgen may not have the call_id field
-	var result dbschema.ConversationItem
-	err := q.ConversationItem.WithContext(ctx).UnderlyingDB().
-		Where("conversation_id = ? AND call_id = ?", conversationID, callID).
-		First(&result).Error
+	sql := q.ConversationItem.WithContext(ctx)
+	sql = repo.applyItemFilter(q, sql, conversation.ItemFilter{
+		ConversationID: &conversationID,
+		CallID:         &callID,
+	})
+	result, err := sql.First()
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find item by call ID")
 	}
+	if err := repo.encryption.OpenItem(ctx, result); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item")
+	}
 	return result.EtoD(), nil
 }
 
 // GetItemByCallIDAndType implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) GetItemByCallIDAndType(ctx context.Context, conversationID uint, callID string, itemType conversation.ItemType) (*conversation.Item, error) {
 	q := repo.db.GetQuery(ctx)
-	// Use raw SQL since gormgen may not have the call_id field
-	var result dbschema.ConversationItem
-	err := q.ConversationItem.WithContext(ctx).UnderlyingDB().
-		Where("conversation_id = ? AND call_id = ? AND type = ?", conversationID, callID, string(itemType)).
-		First(&result).Error
+	sql := q.ConversationItem.WithContext(ctx)
+	sql = repo.applyItemFilter(q, sql, conversation.ItemFilter{
+		ConversationID: &conversationID,
+		CallID:         &callID,
+		Type:           &itemType,
+	})
+	result, err := sql.First()
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find item by call ID and type")
 	}
+	if err := repo.encryption.OpenItem(ctx, result); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item")
+	}
 	return result.EtoD(), nil
 }
 
@@ -287,6 +379,10 @@ func (repo *ConversationGormRepository) GetItemByCallIDAndType(ctx context.Conte
 func (repo *ConversationGormRepository) UpdateItem(ctx context.Context, conversationID uint, item *conversation.Item) error {
 	q := repo.db.GetQuery(ctx)
 	entity := dbschema.NewSchemaConversationItem(item)
+	entity.ConversationID = conversationID
+	if err := repo.encryption.SealItem(ctx, entity); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to encrypt item")
+	}
 	
 	_, err := q.ConversationItem.WithContext(ctx).
 		Where(q.ConversationItem.ID.Eq(item.ID)).
@@ -295,6 +391,17 @@ func (repo *ConversationGormRepository) UpdateItem(ctx context.Context, conversa
 	if err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to update item")
 	}
+	if entity.DataKeyID != nil {
+		// Updates skips nil fields, so plaintext the row held before sealing
+		// was enabled has to be cleared explicitly
+		_, err = q.ConversationItem.WithContext(ctx).
+			Where(q.ConversationItem.ID.Eq(item.ID)).
+			Where(q.ConversationItem.ConversationID.Eq(conversationID)).
+			Updates(map[string]interface{}{"content": nil, "arguments": nil, "output": nil})
+		if err != nil {
+			return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to update item")
+		}
+	}
 	return nil
 }
 
@@ -369,7 +476,7 @@ func (repo *ConversationGormRepository) GetBranch(ctx context.Context, conversat
 
 // ListBranches implements conversation.ConversationRepository.
 func (repo *ConversationGormRepository) ListBranches(ctx context.Context, conversationID uint) ([]*conversation.BranchMetadata, error) {
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	branches, err := q.ConversationBranch.WithContext(ctx).
 		Where(q.ConversationBranch.ConversationID.Eq(conversationID)).
 		Order(q.ConversationBranch.CreatedAt.Asc()).
@@ -420,7 +527,7 @@ func (repo *ConversationGormRepository) SetActiveBranch(ctx context.Context, con
 	q := repo.db.GetQuery(ctx)
 	_, err := q.Conversation.WithContext(ctx).
 		Where(q.Conversation.ID.Eq(conversationID)).
-		Update(q.Conversation.ActiveBranch, branchName)
+		UpdateSimple(q.Conversation.ActiveBranch.Value(branchName), q.Conversation.Version.Add(1))
 	if err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to set active branch")
 	}
@@ -460,12 +567,55 @@ func (repo *ConversationGormRepository) GetBranchItems(ctx context.Context, conv
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to get branch items")
 	}
+	if err := repo.encryption.OpenItems(ctx, rows); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt branch items")
+	}
 
 	return functional.Map(rows, func(item *dbschema.ConversationItem) *conversation.Item {
 		return item.EtoD()
 	}), nil
 }
 
+// recentItemRow carries the window-function branch count alongside each item.
+type recentItemRow struct {
+	dbschema.ConversationItem
+	TotalCount int64 `gorm:"column:total_count"`
+}
+
+// GetRecentBranchItems implements conversation.ConversationRepository.
+func (repo *ConversationGormRepository) GetRecentBranchItems(ctx context.Context, conversationID uint, branchName string, limit int) ([]*conversation.Item, int, error) {
+	if branchName == "" {
+		branchName = "MAIN"
+	}
+
+	// COUNT(*) OVER() is evaluated before LIMIT, so the total branch size comes
+	// back with the tail rows instead of needing a second round trip.
+	var rows []recentItemRow
+	sql := repo.db.GetTx(ctx).WithContext(ctx).
+		Model(&dbschema.ConversationItem{}).
+		Select("*, COUNT(*) OVER() AS total_count").
+		Where("conversation_id = ? AND branch = ?", conversationID, branchName).
+		Order("id DESC")
+	if limit > 0 {
+		sql = sql.Limit(limit)
+	}
+	if err := sql.Scan(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to get recent branch items")
+	}
+	if len(rows) == 0 {
+		return []*conversation.Item{}, 0, nil
+	}
+
+	items := make([]*conversation.Item, len(rows))
+	for i := range rows {
+		if err := repo.encryption.OpenItem(ctx, &rows[i].ConversationItem); err != nil {
+			return nil, 0, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt recent branch items")
+		}
+		items[len(rows)-1-i] = rows[i].ConversationItem.EtoD()
+	}
+	return items, int(rows[0].TotalCount), nil
+}
+
 // applyItemPagination applies pagination to item queries
 func (repo *ConversationGormRepository) applyItemPagination(q *gormgen.Query, sql gormgen.IConversationItemDo, p *query.Pagination) gormgen.IConversationItemDo {
 	if p != nil {
@@ -685,7 +835,7 @@ func (repo *ConversationGormRepository) SwapBranchToMain(ctx context.Context, co
 	// Set MAIN as active branch
 	_, err = q.Conversation.WithContext(ctx).
 		Where(q.Conversation.ID.Eq(conversationID)).
-		Update(q.Conversation.ActiveBranch, "MAIN")
+		UpdateSimple(q.Conversation.ActiveBranch.Value("MAIN"), q.Conversation.Version.Add(1))
 	if err != nil {
 		return "", platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to set active branch to MAIN")
 	}
@@ -753,7 +903,162 @@ func (repo *ConversationGormRepository) RemoveItemRating(ctx context.Context, co
 	return nil
 }
 
+// Item edit operations
+// EditItem implements conversation.ConversationRepository.
+// The current row is locked and snapshotted inside the transaction so that
+// concurrent edits each record the exact values they replaced.
+func (repo *ConversationGormRepository) EditItem(ctx context.Context, conversationID uint, item *conversation.Item, edit *conversation.ItemEdit) error {
+	editedAt := time.Now()
+	var record *dbschema.ConversationItemEdit
+
+	err := repo.db.GetTx(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current dbschema.ConversationItem
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("id = ? AND conversation_id = ?", item.ID, conversationID).
+			First(&current).Error; err != nil {
+			return err
+		}
+		if err := repo.encryption.OpenItem(ctx, &current); err != nil {
+			return err
+		}
+
+		edit.ItemID = current.ID
+		edit.ConversationID = conversationID
+		edit.PreviousContent = []conversation.Content(current.Content)
+		edit.PreviousOutput = current.Output
+		record = dbschema.NewSchemaConversationItemEdit(edit)
+		if err := repo.encryption.SealItemEdit(ctx, record); err != nil {
+			return err
+		}
+		if err := tx.Create(record).Error; err != nil {
+			return err
+		}
+
+		updates := map[string]interface{}{
+			"content":   dbschema.JSONContent(item.Content),
+			"output":    item.Output,
+			"edited_at": editedAt,
+		}
+		if repo.encryption.Enabled() {
+			sealed := &dbschema.ConversationItem{
+				ConversationID: conversationID,
+				PublicID:       current.PublicID,
+				Content:        dbschema.JSONContent(item.Content),
+				Arguments:      current.Arguments,
+				Output:         item.Output,
+			}
+			if err := repo.encryption.SealItem(ctx, sealed); err != nil {
+				return err
+			}
+			updates = map[string]interface{}{
+				"content":            nil,
+				"arguments":          nil,
+				"output":             nil,
+				"content_ciphertext": sealed.ContentCiphertext,
+				"data_key_id":        sealed.DataKeyID,
+				"edited_at":          editedAt,
+			}
+		}
+		return tx.Model(&dbschema.ConversationItem{}).
+			Where("id = ?", current.ID).
+			Updates(updates).Error
+	})
+	if err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to edit item")
+	}
+
+	edit.ID = record.ID
+	edit.CreatedAt = record.CreatedAt
+	item.Edited = true
+	item.EditedAt = &editedAt
+	return nil
+}
+
+// ListItemEdits implements conversation.ConversationRepository.
+func (repo *ConversationGormRepository) ListItemEdits(ctx context.Context, conversationID uint, itemID uint) ([]*conversation.ItemEdit, error) {
+	var rows []dbschema.ConversationItemEdit
+	err := repo.db.GetReadTx(ctx).WithContext(ctx).
+		Where("item_id = ? AND conversation_id = ?", itemID, conversationID).
+		Order("id ASC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to list item edits")
+	}
+	for i := range rows {
+		if err := repo.encryption.OpenItemEdit(ctx, &rows[i]); err != nil {
+			return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item edits")
+		}
+	}
+	return functional.Map(rows, func(row dbschema.ConversationItemEdit) *conversation.ItemEdit {
+		return row.EtoD()
+	}), nil
+}
+
 // applyFilter applies filter conditions to the query
+// CancelPendingItems implements conversation.ConversationRepository.
+func (repo *ConversationGormRepository) CancelPendingItems(ctx context.Context, conversationID uint, itemType conversation.ItemType) (int64, error) {
+	q := repo.db.GetQuery(ctx)
+	result, err := q.ConversationItem.WithContext(ctx).
+		Where(q.ConversationItem.ConversationID.Eq(conversationID)).
+		Where(q.ConversationItem.Type.Eq(string(itemType))).
+		Where(q.ConversationItem.Status.Eq(string(conversation.ItemStatusInProgress))).
+		Update(q.ConversationItem.Status, string(conversation.ItemStatusCancelled))
+	if err != nil {
+		return 0, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to cancel pending items")
+	}
+	return result.RowsAffected, nil
+}
+
+// FailStaleItems implements conversation.ConversationRepository.
+// The status is checked again by the update, so a result reported meanwhile is kept.
+func (repo *ConversationGormRepository) FailStaleItems(ctx context.Context, conversationID uint, itemType conversation.ItemType, startedBefore time.Time, toolErr conversation.ToolError, limit int) (int64, error) {
+	db := repo.db.GetTx(ctx).WithContext(ctx)
+	inProgress := string(conversation.ItemStatusInProgress)
+
+	find := db.Model(&dbschema.ConversationItem{}).
+		Select("id", "conversation_id").
+		Where("type = ? AND status = ? AND created_at < ?", string(itemType), inProgress, startedBefore)
+	if conversationID != 0 {
+		find = find.Where("conversation_id = ?", conversationID)
+	}
+	var stale []dbschema.ConversationItem
+	if err := find.Order("id ASC").Limit(limit).Find(&stale).Error; err != nil {
+		return 0, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find stale items")
+	}
+	if len(stale) == 0 {
+		return 0, nil
+	}
+	itemIDs := make([]uint, 0, len(stale))
+	conversationIDs := make([]uint, 0, len(stale))
+	for _, item := range stale {
+		itemIDs = append(itemIDs, item.ID)
+		conversationIDs = append(conversationIDs, item.ConversationID)
+	}
+
+	now := time.Now()
+	detail := dbschema.JSONToolError(toolErr)
+	result := db.Model(&dbschema.ConversationItem{}).
+		Where("id IN ? AND status = ?", itemIDs, inProgress).
+		Updates(map[string]interface{}{
+			"status":       string(conversation.ItemStatusFailed),
+			"error":        toolErr.Message,
+			"error_detail": &detail,
+			"completed_at": now,
+			"updated_at":   now,
+		})
+	if result.Error != nil {
+		return 0, platformerrors.AsError(ctx, platformerrors.LayerRepository, result.Error, "failed to fail stale items")
+	}
+
+	// Touch the conversations afterwards so their ETags change
+	if err := db.Model(&dbschema.Conversation{}).
+		Where("id IN ?", conversationIDs).
+		Update("updated_at", now).Error; err != nil {
+		return result.RowsAffected, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to touch conversations")
+	}
+	return result.RowsAffected, nil
+}
+
 func (repo *ConversationGormRepository) applyFilter(q *gormgen.Query, sql gormgen.IConversationDo, filter conversation.ConversationFilter) gormgen.IConversationDo {
 	if filter.ID != nil {
 		sql = sql.Where(q.Conversation.ID.Eq(*filter.ID))
@@ -767,6 +1072,9 @@ func (repo *ConversationGormRepository) applyFilter(q *gormgen.Query, sql gormge
 	if filter.Referrer != nil && *filter.Referrer != "" {
 		sql = sql.Where(q.Conversation.Referrer.Eq(*filter.Referrer))
 	}
+	if filter.UpdatedAfter != nil {
+		sql = sql.Where(q.Conversation.UpdatedAt.Gte(*filter.UpdatedAfter))
+	}
 	return sql
 }
 
@@ -778,7 +1086,9 @@ func (repo *ConversationGormRepository) applyItemFilter(q *gormgen.Query, sql go
 	if filter.PublicID != nil {
 		sql = sql.Where(q.ConversationItem.PublicID.Eq(*filter.PublicID))
 	}
-	// Note: CallID filtering is done via raw SQL in GetItemByCallID since gormgen may not have the field
+	if filter.CallID != nil {
+		sql = sql.Where(q.ConversationItem.CallID.Eq(*filter.CallID))
+	}
 	if filter.ConversationID != nil {
 		sql = sql.Where(q.ConversationItem.ConversationID.Eq(*filter.ConversationID))
 	}
@@ -786,6 +1096,12 @@ func (repo *ConversationGormRepository) applyItemFilter(q *gormgen.Query, sql go
 		roleStr := string(*filter.Role)
 		sql = sql.Where(q.ConversationItem.Role.Eq(roleStr))
 	}
+	if filter.Type != nil {
+		sql = sql.Where(q.ConversationItem.Type.Eq(string(*filter.Type)))
+	}
+	if filter.Status != nil {
+		sql = sql.Where(q.ConversationItem.Status.Eq(string(*filter.Status)))
+	}
 	if filter.ResponseID != nil {
 		sql = sql.Where(q.ConversationItem.ResponseID.Eq(*filter.ResponseID))
 	}
@@ -803,16 +1119,22 @@ func (repo *ConversationGormRepository) applyPagination(q *gormgen.Query, sql go
 			sql = sql.Limit(*p.Limit)
 		}
 		if p.After != nil {
+			// Rows are ordered by (updated_at, id); cursors issued before they
+			// carried the timestamp take it from the row they point at
+			op := ">"
 			if p.Order == "desc" {
-				sql = sql.Where(q.Conversation.ID.Lt(*p.After))
-			} else {
-				sql = sql.Where(q.Conversation.ID.Gt(*p.After))
+				op = "<"
+			}
+			cond := clause.Expr{SQL: "(updated_at, id) " + op + " (SELECT updated_at, id FROM llm_api.conversations WHERE id = ?)", Vars: []any{*p.After}}
+			if p.AfterTime != nil {
+				cond = clause.Expr{SQL: "(updated_at, id) " + op + " (?, ?)", Vars: []any{*p.AfterTime, *p.After}}
 			}
+			sql = sql.Where(gen.Cond(cond)...)
 		}
 		if p.Order == "desc" {
-			sql = sql.Order(q.Conversation.UpdatedAt.Desc())
+			sql = sql.Order(q.Conversation.UpdatedAt.Desc(), q.Conversation.ID.Desc())
 		} else {
-			sql = sql.Order(q.Conversation.UpdatedAt.Asc())
+			sql = sql.Order(q.Conversation.UpdatedAt.Asc(), q.Conversation.ID.Asc())
 		}
 	}
 	return sql
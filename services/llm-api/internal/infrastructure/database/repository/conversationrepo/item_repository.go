@@ -2,29 +2,37 @@ package conversationrepo
 
 import (
 	"context"
+	"strings"
+
+	"gorm.io/gorm"
 
 	"jan-server/services/llm-api/internal/domain/conversation"
 	"jan-server/services/llm-api/internal/domain/query"
 	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
 	"jan-server/services/llm-api/internal/infrastructure/database/gormgen"
 	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/infrastructure/encryption"
 	"jan-server/services/llm-api/internal/utils/functional"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 )
 
 type ItemGormRepository struct {
-	db *transaction.Database
+	db         *transaction.Database
+	encryption *encryption.Service
 }
 
 var _ conversation.ItemRepository = (*ItemGormRepository)(nil)
 
-func NewItemGormRepository(db *transaction.Database) conversation.ItemRepository {
-	return &ItemGormRepository{db}
+func NewItemGormRepository(db *transaction.Database, enc *encryption.Service) conversation.ItemRepository {
+	return &ItemGormRepository{db: db, encryption: enc}
 }
 
 // Create implements conversation.ItemRepository.
 func (repo *ItemGormRepository) Create(ctx context.Context, item *conversation.Item) error {
 	model := dbschema.NewSchemaConversationItem(item)
+	if err := repo.encryption.SealItem(ctx, model); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to encrypt item")
+	}
 	if err := repo.db.GetQuery(ctx).ConversationItem.WithContext(ctx).Create(model); err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to create item")
 	}
@@ -43,6 +51,9 @@ func (repo *ItemGormRepository) FindByID(ctx context.Context, id uint) (*convers
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find item by ID")
 	}
+	if err := repo.encryption.OpenItem(ctx, result); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item")
+	}
 	return result.EtoD(), nil
 }
 
@@ -55,6 +66,9 @@ func (repo *ItemGormRepository) FindByPublicID(ctx context.Context, publicID str
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find item by public ID")
 	}
+	if err := repo.encryption.OpenItem(ctx, result); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt item")
+	}
 	return result.EtoD(), nil
 }
 
@@ -67,6 +81,9 @@ func (repo *ItemGormRepository) FindByConversationID(ctx context.Context, conver
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find items by conversation ID")
 	}
+	if err := repo.encryption.OpenItems(ctx, rows); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt items")
+	}
 
 	result := functional.Map(rows, func(item *dbschema.ConversationItem) *conversation.Item {
 		return item.EtoD()
@@ -76,24 +93,21 @@ func (repo *ItemGormRepository) FindByConversationID(ctx context.Context, conver
 
 // Search implements conversation.ItemRepository.
 func (repo *ItemGormRepository) Search(ctx context.Context, conversationID uint, searchQuery string) ([]*conversation.Item, error) {
-	// For now, this is a simple implementation
-	// In production, you'd want to use full-text search or a search engine
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.ConversationItem.WithContext(ctx)
 	sql = repo.applyFilter(q, sql, conversation.ItemFilter{ConversationID: &conversationID})
 
-	// Basic search - in production, enhance with proper full-text search
-	rows, err := sql.Order(q.ConversationItem.CreatedAt.Asc()).Find()
-
-	if err != nil {
+	var rows []*dbschema.ConversationItem
+	if err := applyContentSearch(sql.UnderlyingDB(), searchQuery).Order("created_at ASC").Find(&rows).Error; err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to search items")
 	}
+	if err := repo.encryption.OpenItems(ctx, rows); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt items")
+	}
 
 	result := functional.Map(rows, func(item *dbschema.ConversationItem) *conversation.Item {
 		return item.EtoD()
 	})
-
-	// TODO: Implement proper full-text search filtering based on searchQuery
 	return result, nil
 }
 
@@ -119,6 +133,9 @@ func (repo *ItemGormRepository) BulkCreate(ctx context.Context, items []*convers
 	models := functional.Map(items, func(item *conversation.Item) *dbschema.ConversationItem {
 		return dbschema.NewSchemaConversationItem(item)
 	})
+	if err := repo.encryption.SealItems(ctx, models); err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to encrypt items")
+	}
 
 	// Bulk insert
 	q := repo.db.GetQuery(ctx)
@@ -137,7 +154,7 @@ func (repo *ItemGormRepository) BulkCreate(ctx context.Context, items []*convers
 
 // CountByConversation implements conversation.ItemRepository.
 func (repo *ItemGormRepository) CountByConversation(ctx context.Context, conversationID uint) (int64, error) {
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.ConversationItem.WithContext(ctx)
 	sql = repo.applyFilter(q, sql, conversation.ItemFilter{ConversationID: &conversationID})
 	count, err := sql.Count()
@@ -164,7 +181,7 @@ func (repo *ItemGormRepository) ExistsByIDAndConversation(ctx context.Context, i
 
 // FindByFilter implements conversation.ItemRepository.
 func (repo *ItemGormRepository) FindByFilter(ctx context.Context, filter conversation.ItemFilter, pagination *query.Pagination) ([]*conversation.Item, error) {
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.ConversationItem.WithContext(ctx)
 	sql = repo.applyFilter(q, sql, filter)
 	sql = repo.applyPagination(q, sql, pagination)
@@ -173,6 +190,9 @@ func (repo *ItemGormRepository) FindByFilter(ctx context.Context, filter convers
 	if err != nil {
 		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to find items by filter")
 	}
+	if err := repo.encryption.OpenItems(ctx, rows); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to decrypt items")
+	}
 
 	result := functional.Map(rows, func(item *dbschema.ConversationItem) *conversation.Item {
 		return item.EtoD()
@@ -182,7 +202,7 @@ func (repo *ItemGormRepository) FindByFilter(ctx context.Context, filter convers
 
 // Count implements conversation.ItemRepository.
 func (repo *ItemGormRepository) Count(ctx context.Context, filter conversation.ItemFilter) (int64, error) {
-	q := repo.db.GetQuery(ctx)
+	q := repo.db.GetReadQuery(ctx)
 	sql := q.ConversationItem.WithContext(ctx)
 	sql = repo.applyFilter(q, sql, filter)
 	count, err := sql.Count()
@@ -194,9 +214,15 @@ func (repo *ItemGormRepository) Count(ctx context.Context, filter conversation.I
 
 // applyFilter applies filter conditions to the query
 func (repo *ItemGormRepository) applyFilter(q *gormgen.Query, sql gormgen.IConversationItemDo, filter conversation.ItemFilter) gormgen.IConversationItemDo {
+	if filter.ID != nil {
+		sql = sql.Where(q.ConversationItem.ID.Eq(*filter.ID))
+	}
 	if filter.PublicID != nil {
 		sql = sql.Where(q.ConversationItem.PublicID.Eq(*filter.PublicID))
 	}
+	if filter.CallID != nil {
+		sql = sql.Where(q.ConversationItem.CallID.Eq(*filter.CallID))
+	}
 	if filter.ConversationID != nil {
 		sql = sql.Where(q.ConversationItem.ConversationID.Eq(*filter.ConversationID))
 	}
@@ -204,12 +230,36 @@ func (repo *ItemGormRepository) applyFilter(q *gormgen.Query, sql gormgen.IConve
 		roleStr := string(*filter.Role)
 		sql = sql.Where(q.ConversationItem.Role.Eq(roleStr))
 	}
+	if filter.Type != nil {
+		sql = sql.Where(q.ConversationItem.Type.Eq(string(*filter.Type)))
+	}
+	if filter.Status != nil {
+		sql = sql.Where(q.ConversationItem.Status.Eq(string(*filter.Status)))
+	}
 	if filter.ResponseID != nil {
 		sql = sql.Where(q.ConversationItem.ResponseID.Eq(*filter.ResponseID))
 	}
+	if filter.Branch != nil && *filter.Branch != "" {
+		sql = sql.Where(q.ConversationItem.Branch.Eq(*filter.Branch))
+	}
 	return sql
 }
 
+// contentSearchCondition matches the idx_conversation_items_content_fts
+// expression exactly so Postgres can use the GIN index. Items sealed by
+// encryption at rest have no plaintext content and never match.
+const contentSearchCondition = `jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]') @@ plainto_tsquery('simple'::regconfig, ?)`
+
+// applyContentSearch filters items whose content strings match searchQuery.
+// An empty query leaves the scope unchanged.
+func applyContentSearch(db *gorm.DB, searchQuery string) *gorm.DB {
+	searchQuery = strings.TrimSpace(searchQuery)
+	if searchQuery == "" {
+		return db
+	}
+	return db.Where(contentSearchCondition, searchQuery)
+}
+
 // applyPagination applies pagination to the query
 func (repo *ItemGormRepository) applyPagination(q *gormgen.Query, sql gormgen.IConversationItemDo, p *query.Pagination) gormgen.IConversationItemDo {
 	if p != nil {
@@ -0,0 +1,264 @@
+package evalrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// EvalGormRepository implements eval.Repository using GORM
+type EvalGormRepository struct {
+	db *transaction.Database
+}
+
+var _ eval.Repository = (*EvalGormRepository)(nil)
+
+// NewEvalGormRepository creates a new eval repository
+func NewEvalGormRepository(db *transaction.Database) eval.Repository {
+	return &EvalGormRepository{db: db}
+}
+
+// CreateDataset implements eval.Repository.
+func (repo *EvalGormRepository) CreateDataset(ctx context.Context, dataset *eval.Dataset, items []*eval.DatasetItem) error {
+	model := dbschema.NewSchemaEvalDataset(dataset)
+	err := repo.db.GetTx(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			return err
+		}
+		rows := make([]*dbschema.EvalDatasetItem, 0, len(items))
+		for _, item := range items {
+			row, err := dbschema.NewSchemaEvalDatasetItem(model.ID, item)
+			if err != nil {
+				return err
+			}
+			rows = append(rows, row)
+		}
+		return tx.CreateInBatches(&rows, 500).Error
+	})
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create eval dataset", "57d37016-e717-4f87-92e5-241e36c64da4")
+	}
+	dataset.ID = model.ID
+	dataset.CreatedAt = model.CreatedAt
+	dataset.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindDatasetByPublicID implements eval.Repository.
+func (repo *EvalGormRepository) FindDatasetByPublicID(ctx context.Context, publicID string) (*eval.Dataset, error) {
+	var model dbschema.EvalDataset
+	if err := repo.db.GetReadTx(ctx).Where("public_id = ?", publicID).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find eval dataset by public ID", "69026a06-fa4a-4afb-867f-30411c00fe3f")
+	}
+	return model.EtoD(), nil
+}
+
+// FindDatasetByID implements eval.Repository.
+func (repo *EvalGormRepository) FindDatasetByID(ctx context.Context, id uint) (*eval.Dataset, error) {
+	var model dbschema.EvalDataset
+	if err := repo.db.GetReadTx(ctx).Where("id = ?", id).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find eval dataset", "0a1db6bd-7dab-4629-a9e4-6f4cc2a71128")
+	}
+	return model.EtoD(), nil
+}
+
+// FindDatasetItems implements eval.Repository.
+func (repo *EvalGormRepository) FindDatasetItems(ctx context.Context, datasetID uint) ([]*eval.DatasetItem, error) {
+	var rows []dbschema.EvalDatasetItem
+	if err := repo.db.GetReadTx(ctx).Where("dataset_id = ?", datasetID).Order("position").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find eval dataset items", "d76028ef-4727-4f88-8a44-2597ffadbe80")
+	}
+	items := make([]*eval.DatasetItem, 0, len(rows))
+	for i := range rows {
+		items = append(items, rows[i].EtoD())
+	}
+	return items, nil
+}
+
+// ListDatasets implements eval.Repository.
+func (repo *EvalGormRepository) ListDatasets(ctx context.Context, p *query.Pagination) ([]*eval.Dataset, int64, error) {
+	q := repo.db.GetReadTx(ctx).Model(&dbschema.EvalDataset{})
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count eval datasets", "a8d975a8-1e84-434a-a41c-6e589978ecc8")
+	}
+
+	var rows []dbschema.EvalDataset
+	if err := applyPagination(q, p).Order("created_at DESC, id DESC").Find(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list eval datasets", "6b0409c6-330a-4e3e-bdc9-b25faea15839")
+	}
+	datasets := make([]*eval.Dataset, 0, len(rows))
+	for i := range rows {
+		datasets = append(datasets, rows[i].EtoD())
+	}
+	return datasets, total, nil
+}
+
+// DeleteDataset implements eval.Repository. Items, runs and results are removed by cascade.
+func (repo *EvalGormRepository) DeleteDataset(ctx context.Context, id uint) error {
+	if err := repo.db.GetTx(ctx).Where("id = ?", id).Delete(&dbschema.EvalDataset{}).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to delete eval dataset", "f717f60b-85f4-4169-93be-944ee1255c60")
+	}
+	return nil
+}
+
+// CreateRun implements eval.Repository.
+func (repo *EvalGormRepository) CreateRun(ctx context.Context, run *eval.Run) error {
+	model := dbschema.NewSchemaEvalRun(run)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create eval run", "6ac50d0b-0b36-44b4-89a1-dc9710868bed")
+	}
+	run.ID = model.ID
+	run.CreatedAt = model.CreatedAt
+	run.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindRunByPublicID implements eval.Repository.
+func (repo *EvalGormRepository) FindRunByPublicID(ctx context.Context, publicID string) (*eval.Run, error) {
+	var model dbschema.EvalRun
+	if err := repo.db.GetReadTx(ctx).Where("public_id = ?", publicID).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find eval run by public ID", "6517f121-0a9c-4599-ab4d-6c7f928df406")
+	}
+	return model.EtoD(), nil
+}
+
+// ListRuns implements eval.Repository.
+func (repo *EvalGormRepository) ListRuns(ctx context.Context, datasetID *uint, p *query.Pagination) ([]*eval.Run, int64, error) {
+	q := repo.db.GetReadTx(ctx).Model(&dbschema.EvalRun{})
+	if datasetID != nil {
+		q = q.Where("dataset_id = ?", *datasetID)
+	}
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count eval runs", "b60bae92-f393-4f37-b6b8-bbf164eb1779")
+	}
+
+	var rows []dbschema.EvalRun
+	if err := applyPagination(q, p).Order("created_at DESC, id DESC").Find(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list eval runs", "29ac7839-859f-4e6c-8348-8e881403ddcb")
+	}
+	runs := make([]*eval.Run, 0, len(rows))
+	for i := range rows {
+		runs = append(runs, rows[i].EtoD())
+	}
+	return runs, total, nil
+}
+
+// ClaimRun implements eval.Repository. The row is locked with SKIP LOCKED so concurrent
+// workers never claim the same run.
+func (repo *EvalGormRepository) ClaimRun(ctx context.Context, staleBefore time.Time) (*eval.Run, error) {
+	var claimed *eval.Run
+	err := repo.db.GetTx(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model dbschema.EvalRun
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", eval.RunStatusQueued, eval.RunStatusRunning, staleBefore).
+			Order("created_at, id").
+			First(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if model.StartedAt == nil {
+			model.StartedAt = &now
+		}
+		model.Status = string(eval.RunStatusRunning)
+		model.UpdatedAt = now
+		if err := tx.Model(&dbschema.EvalRun{}).Where("id = ?", model.ID).Updates(map[string]any{
+			"status":     model.Status,
+			"started_at": model.StartedAt,
+			"updated_at": model.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		claimed = model.EtoD()
+		return nil
+	})
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to claim eval run", "17fb06ba-317e-4cd2-b4c3-22117107ca3f")
+	}
+	return claimed, nil
+}
+
+// UpdateRun implements eval.Repository. Every call refreshes updated_at, which keeps a
+// running run from being reclaimed as stale.
+func (repo *EvalGormRepository) UpdateRun(ctx context.Context, run *eval.Run) error {
+	run.UpdatedAt = time.Now().UTC()
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.EvalRun{}).
+		Where("id = ?", run.ID).
+		Updates(map[string]any{
+			"status":          string(run.Status),
+			"total_items":     run.TotalItems,
+			"completed_items": run.CompletedItems,
+			"passed_items":    run.PassedItems,
+			"errored_items":   run.ErroredItems,
+			"average_score":   run.AverageScore,
+			"error":           run.Error,
+			"started_at":      run.StartedAt,
+			"completed_at":    run.CompletedAt,
+			"updated_at":      run.UpdatedAt,
+		}).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update eval run", "2ee3ff7e-10e1-4a27-be4c-b249828c2978")
+	}
+	return nil
+}
+
+// SaveResult implements eval.Repository.
+func (repo *EvalGormRepository) SaveResult(ctx context.Context, result *eval.RunResult) error {
+	model := dbschema.NewSchemaEvalRunResult(result)
+	err := repo.db.GetTx(ctx).
+		Clauses(clause.OnConflict{
+			Columns:   []clause.Column{{Name: "run_id"}, {Name: "item_id"}},
+			DoUpdates: clause.AssignmentColumns([]string{"output", "score", "passed", "grader", "reasoning", "latency_ms", "error"}),
+		}).
+		Create(model).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to save eval result", "8dae4863-825c-454a-b5e5-6eb3a9020c65")
+	}
+	result.ID = model.ID
+	result.CreatedAt = model.CreatedAt
+	return nil
+}
+
+// FindRunResults implements eval.Repository.
+func (repo *EvalGormRepository) FindRunResults(ctx context.Context, runID uint) ([]*eval.RunResult, error) {
+	var rows []dbschema.EvalRunResult
+	if err := repo.db.GetReadTx(ctx).Where("run_id = ?", runID).Order("position").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find eval results", "33358d59-16c5-411e-a784-795ee739cb8d")
+	}
+	results := make([]*eval.RunResult, 0, len(rows))
+	for i := range rows {
+		results = append(results, rows[i].EtoD())
+	}
+	return results, nil
+}
+
+func applyPagination(q *gorm.DB, p *query.Pagination) *gorm.DB {
+	if p == nil {
+		return q
+	}
+	if p.Limit != nil && *p.Limit > 0 {
+		q = q.Limit(*p.Limit)
+	}
+	if p.Offset != nil && *p.Offset > 0 {
+		q = q.Offset(*p.Offset)
+	}
+	return q
+}
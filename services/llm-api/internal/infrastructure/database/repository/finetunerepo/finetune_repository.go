@@ -0,0 +1,153 @@
+package finetunerepo
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/infrastructure/encryption"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// FinetuneGormRepository implements finetune.Repository using GORM
+type FinetuneGormRepository struct {
+	db         *transaction.Database
+	encryption *encryption.Service
+}
+
+var _ finetune.Repository = (*FinetuneGormRepository)(nil)
+
+// NewFinetuneGormRepository creates a new fine-tuning export repository
+func NewFinetuneGormRepository(db *transaction.Database, enc *encryption.Service) finetune.Repository {
+	return &FinetuneGormRepository{db: db, encryption: enc}
+}
+
+// CreateExport implements finetune.Repository.
+func (repo *FinetuneGormRepository) CreateExport(ctx context.Context, export *finetune.Export) error {
+	model := dbschema.NewSchemaFinetuneExport(export)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create finetune export", "2378ffe8-bc13-4b28-be9f-a09c97de5a8b")
+	}
+	export.ID = model.ID
+	export.CreatedAt = model.CreatedAt
+	export.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindExportByPublicID implements finetune.Repository.
+func (repo *FinetuneGormRepository) FindExportByPublicID(ctx context.Context, publicID string) (*finetune.Export, error) {
+	var model dbschema.FinetuneExport
+	if err := repo.db.GetReadTx(ctx).Where("public_id = ?", publicID).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find finetune export by public ID", "9304b412-466f-4045-8605-0883b088c65b")
+	}
+	return model.EtoD(), nil
+}
+
+// ListExports implements finetune.Repository.
+func (repo *FinetuneGormRepository) ListExports(ctx context.Context, p *query.Pagination) ([]*finetune.Export, int64, error) {
+	q := repo.db.GetReadTx(ctx).Model(&dbschema.FinetuneExport{})
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count finetune exports", "d1db6dcc-fe25-4370-bab6-e3620491325e")
+	}
+
+	if p != nil {
+		if p.Limit != nil && *p.Limit > 0 {
+			q = q.Limit(*p.Limit)
+		}
+		if p.Offset != nil && *p.Offset > 0 {
+			q = q.Offset(*p.Offset)
+		}
+	}
+	var rows []dbschema.FinetuneExport
+	if err := q.Order("created_at DESC, id DESC").Find(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list finetune exports", "6d0dda46-8b43-4921-b65b-41e53fe82694")
+	}
+	exports := make([]*finetune.Export, 0, len(rows))
+	for i := range rows {
+		exports = append(exports, rows[i].EtoD())
+	}
+	return exports, total, nil
+}
+
+// UpdateExport implements finetune.Repository.
+func (repo *FinetuneGormRepository) UpdateExport(ctx context.Context, export *finetune.Export) error {
+	export.UpdatedAt = time.Now().UTC()
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.FinetuneExport{}).
+		Where("id = ?", export.ID).
+		Updates(map[string]any{
+			"status":        string(export.Status),
+			"example_count": export.ExampleCount,
+			"media_id":      export.MediaID,
+			"bytes":         export.Bytes,
+			"error":         export.Error,
+			"completed_at":  export.CompletedAt,
+			"updated_at":    export.UpdatedAt,
+		}).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update finetune export", "dbff6760-df04-428b-b365-ca6f71d0de9d")
+	}
+	return nil
+}
+
+// FindLikedTurns implements finetune.Repository.
+func (repo *FinetuneGormRepository) FindLikedTurns(ctx context.Context, filter finetune.TurnFilter) ([]*conversation.Item, error) {
+	q := repo.db.GetReadTx(ctx).
+		Model(&dbschema.ConversationItem{}).
+		Where("rating = ?", string(conversation.ItemRatingLike)).
+		Where("type = ? AND role = ?", string(conversation.ItemTypeMessage), string(conversation.ItemRoleAssistant)).
+		Where("conversation_id IN (SELECT id FROM llm_api.conversations WHERE deleted_at IS NULL)").
+		Where("id > ?", filter.AfterID)
+	if filter.RatedAfter != nil {
+		q = q.Where("rated_at >= ?", *filter.RatedAfter)
+	}
+	if filter.RatedBefore != nil {
+		q = q.Where("rated_at < ?", *filter.RatedBefore)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+
+	var rows []dbschema.ConversationItem
+	if err := q.Order("id").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find liked turns", "ec832c6f-6054-4549-add7-fa1d625ca2d1")
+	}
+	return repo.toDomainItems(ctx, rows)
+}
+
+// FindTurnContext implements finetune.Repository.
+func (repo *FinetuneGormRepository) FindTurnContext(ctx context.Context, item *conversation.Item, limit int) ([]*conversation.Item, error) {
+	var rows []dbschema.ConversationItem
+	err := repo.db.GetReadTx(ctx).
+		Where("conversation_id = ? AND branch = ? AND sequence_number < ?", item.ConversationID, item.Branch, item.SequenceNumber).
+		Where("type = ?", string(conversation.ItemTypeMessage)).
+		Order("sequence_number DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find turn context", "3b4f9b37-59e7-4892-9e50-aa027d1917bf")
+	}
+	// Loaded newest first so the limit keeps the closest messages; return them in order
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+	return repo.toDomainItems(ctx, rows)
+}
+
+// toDomainItems decrypts sealed rows and converts them to domain items
+func (repo *FinetuneGormRepository) toDomainItems(ctx context.Context, rows []dbschema.ConversationItem) ([]*conversation.Item, error) {
+	items := make([]*conversation.Item, 0, len(rows))
+	for i := range rows {
+		if err := repo.encryption.OpenItem(ctx, &rows[i]); err != nil {
+			return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to decrypt conversation item", "9a41c6e2-5d3b-4f87-b0c2-7e8d1f6a3b59")
+		}
+		items = append(items, rows[i].EtoD())
+	}
+	return items, nil
+}
@@ -0,0 +1,121 @@
+package mcpcredentialrepo
+
+import (
+	"context"
+	"time"
+
+	"gorm.io/gorm/clause"
+
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// MCPCredentialGormRepository implements mcpcredential.Repository using GORM
+type MCPCredentialGormRepository struct {
+	db *transaction.Database
+}
+
+var _ mcpcredential.Repository = (*MCPCredentialGormRepository)(nil)
+
+// NewMCPCredentialGormRepository creates a new MCP credential repository
+func NewMCPCredentialGormRepository(db *transaction.Database) mcpcredential.Repository {
+	return &MCPCredentialGormRepository{db: db}
+}
+
+// Upsert implements mcpcredential.Repository. A user has at most one credential per provider.
+func (repo *MCPCredentialGormRepository) Upsert(ctx context.Context, c *mcpcredential.Credential) error {
+	now := time.Now().UTC()
+	if c.CreatedAt.IsZero() {
+		c.CreatedAt = now
+	}
+	c.UpdatedAt = now
+	model := dbschema.NewSchemaMCPCredential(c)
+	err := repo.db.GetTx(ctx).
+		Clauses(clause.OnConflict{
+			Columns: []clause.Column{{Name: "user_id"}, {Name: "provider"}},
+			DoUpdates: clause.Assignments(map[string]any{
+				"encrypted_token": model.EncryptedToken,
+				"account":         model.Account,
+				"scopes":          model.Scopes,
+				"endpoint":        model.Endpoint,
+				"expires_at":      model.ExpiresAt,
+				"updated_at":      model.UpdatedAt,
+			}),
+		}).
+		Create(model).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to save MCP credential", "6d1f8a3c-2e7b-4c9d-b0a4-7e5c3f9d1b28")
+	}
+	c.ID = model.ID
+	return nil
+}
+
+// Delete implements mcpcredential.Repository.
+func (repo *MCPCredentialGormRepository) Delete(ctx context.Context, id uint) error {
+	if err := repo.db.GetTx(ctx).Where("id = ?", id).Delete(&dbschema.MCPCredential{}).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to delete MCP credential", "a8e2c7f1-4d9b-4e3a-9c6f-1b5d8e0a7c43")
+	}
+	return nil
+}
+
+// FindByUserAndProvider implements mcpcredential.Repository.
+func (repo *MCPCredentialGormRepository) FindByUserAndProvider(ctx context.Context, userID uint, provider mcpcredential.Provider) (*mcpcredential.Credential, error) {
+	var model dbschema.MCPCredential
+	if err := repo.db.GetReadTx(ctx).Where("user_id = ? AND provider = ?", userID, string(provider)).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find MCP credential", "f3b9d6e2-8a1c-4f7e-a5d0-2c9e4b7f1a86")
+	}
+	return model.EtoD(), nil
+}
+
+// FindByUser implements mcpcredential.Repository.
+func (repo *MCPCredentialGormRepository) FindByUser(ctx context.Context, userID uint) ([]*mcpcredential.Credential, error) {
+	var rows []dbschema.MCPCredential
+	if err := repo.db.GetReadTx(ctx).Where("user_id = ?", userID).Order("provider").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list MCP credentials", "0b7e4a9d-3c6f-4d2b-8e1a-5f9c2d7b4e60")
+	}
+	credentials := make([]*mcpcredential.Credential, 0, len(rows))
+	for i := range rows {
+		credentials = append(credentials, rows[i].EtoD())
+	}
+	return credentials, nil
+}
+
+// TouchLastUsed implements mcpcredential.Repository.
+func (repo *MCPCredentialGormRepository) TouchLastUsed(ctx context.Context, id uint, at time.Time) error {
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.MCPCredential{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", at.UTC()).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update MCP credential last use", "d5a0c8e3-7f2b-4b6e-9d1c-3e8f0a5b2c71")
+	}
+	return nil
+}
+
+// FindAfter implements mcpcredential.Repository.
+func (repo *MCPCredentialGormRepository) FindAfter(ctx context.Context, afterID uint, limit int) ([]*mcpcredential.Credential, error) {
+	var rows []dbschema.MCPCredential
+	if err := repo.db.GetReadTx(ctx).Where("id > ?", afterID).Order("id").Limit(limit).Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list MCP credentials", "4c2e8b7a-1f9d-4a63-b5e0-8d3f6c1a9e27")
+	}
+	credentials := make([]*mcpcredential.Credential, 0, len(rows))
+	for i := range rows {
+		credentials = append(credentials, rows[i].EtoD())
+	}
+	return credentials, nil
+}
+
+// ReplaceEncryptedToken implements mcpcredential.Repository. The compare on
+// the current value keeps a token saved meanwhile from being overwritten.
+func (repo *MCPCredentialGormRepository) ReplaceEncryptedToken(ctx context.Context, id uint, current, replacement string) (bool, error) {
+	result := repo.db.GetTx(ctx).
+		Model(&dbschema.MCPCredential{}).
+		Where("id = ? AND encrypted_token = ?", id, current).
+		UpdateColumn("encrypted_token", replacement)
+	if result.Error != nil {
+		return false, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, result.Error, "failed to re-encrypt MCP credential", "9a5d3f1c-7e2b-4b8a-a6d4-2c0e9f7b3d15")
+	}
+	return result.RowsAffected > 0, nil
+}
@@ -67,7 +67,7 @@ func (r *MCPToolGormRepository) FindByToolKey(ctx context.Context, toolKey strin
 
 // FindByFilter finds MCP tools matching the given filter
 func (r *MCPToolGormRepository) FindByFilter(ctx context.Context, filter mcptool.MCPToolFilter, p *query.Pagination) ([]*mcptool.MCPTool, error) {
-	tx := r.db.GetTx(ctx)
+	tx := r.db.GetReadTx(ctx)
 	q := tx.Model(&dbschema.AdminMCPTool{})
 
 	// Apply filters
@@ -150,7 +150,7 @@ func (r *MCPToolGormRepository) Update(ctx context.Context, tool *mcptool.MCPToo
 
 // Count returns the count of MCP tools matching the given filter
 func (r *MCPToolGormRepository) Count(ctx context.Context, filter mcptool.MCPToolFilter) (int64, error) {
-	tx := r.db.GetTx(ctx)
+	tx := r.db.GetReadTx(ctx)
 	q := tx.Model(&dbschema.AdminMCPTool{})
 
 	// Apply filters
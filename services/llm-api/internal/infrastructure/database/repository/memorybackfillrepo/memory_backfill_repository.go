@@ -0,0 +1,215 @@
+package memorybackfillrepo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/infrastructure/encryption"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// MemoryBackfillGormRepository implements memorybackfill.Repository using GORM
+type MemoryBackfillGormRepository struct {
+	db         *transaction.Database
+	encryption *encryption.Service
+}
+
+var _ memorybackfill.Repository = (*MemoryBackfillGormRepository)(nil)
+
+// NewMemoryBackfillGormRepository creates a new memory backfill repository
+func NewMemoryBackfillGormRepository(db *transaction.Database, enc *encryption.Service) memorybackfill.Repository {
+	return &MemoryBackfillGormRepository{db: db, encryption: enc}
+}
+
+// CreateBackfill implements memorybackfill.Repository.
+func (repo *MemoryBackfillGormRepository) CreateBackfill(ctx context.Context, backfill *memorybackfill.Backfill) error {
+	model := dbschema.NewSchemaMemoryBackfill(backfill)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create memory backfill", "9722780c-c6af-4935-b164-f89495720253")
+	}
+	backfill.ID = model.ID
+	backfill.CreatedAt = model.CreatedAt
+	backfill.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// FindBackfillByPublicID implements memorybackfill.Repository.
+func (repo *MemoryBackfillGormRepository) FindBackfillByPublicID(ctx context.Context, publicID string) (*memorybackfill.Backfill, error) {
+	var model dbschema.MemoryBackfill
+	if err := repo.db.GetReadTx(ctx).Where("public_id = ?", publicID).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find memory backfill by public ID", "742053e2-bef0-40e1-a68c-d702bfd60d31")
+	}
+	return model.EtoD(), nil
+}
+
+// ListBackfills implements memorybackfill.Repository.
+func (repo *MemoryBackfillGormRepository) ListBackfills(ctx context.Context, p *query.Pagination) ([]*memorybackfill.Backfill, int64, error) {
+	q := repo.db.GetReadTx(ctx).Model(&dbschema.MemoryBackfill{})
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count memory backfills", "5a3a3353-f54a-4ed4-adb3-ae14660cb318")
+	}
+
+	if p != nil {
+		if p.Limit != nil && *p.Limit > 0 {
+			q = q.Limit(*p.Limit)
+		}
+		if p.Offset != nil && *p.Offset > 0 {
+			q = q.Offset(*p.Offset)
+		}
+	}
+	var rows []dbschema.MemoryBackfill
+	if err := q.Order("created_at DESC, id DESC").Find(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list memory backfills", "037b472e-6070-4fbe-b7dd-dbd4e7eb85f6")
+	}
+	backfills := make([]*memorybackfill.Backfill, 0, len(rows))
+	for i := range rows {
+		backfills = append(backfills, rows[i].EtoD())
+	}
+	return backfills, total, nil
+}
+
+// ClaimBackfill implements memorybackfill.Repository. The row is locked with SKIP LOCKED
+// so concurrent workers never claim the same backfill.
+func (repo *MemoryBackfillGormRepository) ClaimBackfill(ctx context.Context, staleBefore time.Time) (*memorybackfill.Backfill, error) {
+	var claimed *memorybackfill.Backfill
+	err := repo.db.GetTx(ctx).WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var model dbschema.MemoryBackfill
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("status = ? OR (status = ? AND updated_at < ?)", memorybackfill.StatusQueued, memorybackfill.StatusRunning, staleBefore).
+			Order("created_at, id").
+			First(&model).Error
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		now := time.Now().UTC()
+		if model.StartedAt == nil {
+			model.StartedAt = &now
+		}
+		model.Status = string(memorybackfill.StatusRunning)
+		model.UpdatedAt = now
+		if err := tx.Model(&dbschema.MemoryBackfill{}).Where("id = ?", model.ID).Updates(map[string]any{
+			"status":     model.Status,
+			"started_at": model.StartedAt,
+			"updated_at": model.UpdatedAt,
+		}).Error; err != nil {
+			return err
+		}
+		claimed = model.EtoD()
+		return nil
+	})
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to claim memory backfill", "663e4764-5069-401c-92b6-6e66b5aa3d76")
+	}
+	return claimed, nil
+}
+
+// UpdateBackfill implements memorybackfill.Repository.
+func (repo *MemoryBackfillGormRepository) UpdateBackfill(ctx context.Context, backfill *memorybackfill.Backfill) error {
+	backfill.UpdatedAt = time.Now().UTC()
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.MemoryBackfill{}).
+		Where("id = ?", backfill.ID).
+		Updates(progressColumns(backfill, map[string]any{
+			"status":       string(backfill.Status),
+			"error":        backfill.Error,
+			"completed_at": backfill.CompletedAt,
+		})).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update memory backfill", "93b54c04-cbe6-4d8e-8fc3-cf91b6797b8b")
+	}
+	return nil
+}
+
+// SaveProgress implements memorybackfill.Repository. Every call refreshes updated_at,
+// which keeps a running backfill from being reclaimed as stale.
+func (repo *MemoryBackfillGormRepository) SaveProgress(ctx context.Context, backfill *memorybackfill.Backfill) (bool, error) {
+	backfill.UpdatedAt = time.Now().UTC()
+	result := repo.db.GetTx(ctx).
+		Model(&dbschema.MemoryBackfill{}).
+		Where("id = ? AND status = ?", backfill.ID, string(memorybackfill.StatusRunning)).
+		Updates(progressColumns(backfill, map[string]any{}))
+	if result.Error != nil {
+		return false, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, result.Error, "failed to save memory backfill progress", "5891593a-1f3f-47ab-8a2f-da1714d1553d")
+	}
+	return result.RowsAffected > 0, nil
+}
+
+// progressColumns adds the cursor and counters of a backfill to columns
+func progressColumns(backfill *memorybackfill.Backfill, columns map[string]any) map[string]any {
+	columns["cursor"] = backfill.Cursor
+	columns["conversations_done"] = backfill.ConversationsDone
+	columns["conversations_skipped"] = backfill.ConversationsSkipped
+	columns["conversations_failed"] = backfill.ConversationsFailed
+	columns["updated_at"] = backfill.UpdatedAt
+	return columns
+}
+
+// FindConversations implements memorybackfill.Repository.
+func (repo *MemoryBackfillGormRepository) FindConversations(ctx context.Context, filter memorybackfill.ConversationFilter) ([]*conversation.Conversation, error) {
+	q := repo.db.GetReadTx(ctx).
+		Model(&dbschema.Conversation{}).
+		Where("id > ? AND created_at < ?", filter.AfterID, filter.CreatedBefore)
+	if filter.UserID != nil {
+		q = q.Where("user_id = ?", *filter.UserID)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+
+	var rows []dbschema.Conversation
+	if err := q.Order("id").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find conversations to backfill", "abaabd73-78bc-4164-babd-63aacf76bfd4")
+	}
+	convs := make([]*conversation.Conversation, 0, len(rows))
+	for i := range rows {
+		convs = append(convs, rows[i].EtoD())
+	}
+	return convs, nil
+}
+
+// FindBranchMessages implements memorybackfill.Repository.
+func (repo *MemoryBackfillGormRepository) FindBranchMessages(ctx context.Context, conv *conversation.Conversation, limit int) ([]*conversation.Item, error) {
+	branch := conv.ActiveBranch
+	if branch == "" {
+		branch = conversation.BranchMain
+	}
+
+	var rows []dbschema.ConversationItem
+	err := repo.db.GetReadTx(ctx).
+		Where("conversation_id = ? AND branch = ?", conv.ID, branch).
+		Where("type = ?", string(conversation.ItemTypeMessage)).
+		Order("sequence_number DESC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find conversation messages", "ade47e93-bf16-42bc-b0f2-4940b01f52dd")
+	}
+	// Loaded newest first so the limit keeps the latest messages; return them in order
+	for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+		rows[i], rows[j] = rows[j], rows[i]
+	}
+
+	items := make([]*conversation.Item, 0, len(rows))
+	for i := range rows {
+		if err := repo.encryption.OpenItem(ctx, &rows[i]); err != nil {
+			return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to decrypt conversation item", "5445f070-a98b-471a-93f9-4537ceab1f0e")
+		}
+		items = append(items, rows[i].EtoD())
+	}
+	return items, nil
+}
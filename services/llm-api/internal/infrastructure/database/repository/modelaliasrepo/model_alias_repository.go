@@ -0,0 +1,87 @@
+package modelaliasrepo
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/modelalias"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// ModelAliasGormRepository implements modelalias.Repository using GORM
+type ModelAliasGormRepository struct {
+	db *transaction.Database
+}
+
+var _ modelalias.Repository = (*ModelAliasGormRepository)(nil)
+
+// NewModelAliasGormRepository creates a new model alias repository
+func NewModelAliasGormRepository(db *transaction.Database) modelalias.Repository {
+	return &ModelAliasGormRepository{db: db}
+}
+
+// Create implements modelalias.Repository.
+func (repo *ModelAliasGormRepository) Create(ctx context.Context, alias *modelalias.Alias) error {
+	model := dbschema.NewSchemaModelAlias(alias)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create model alias", "14ab697d-ec40-446c-83cf-8f52d311dcc8")
+	}
+	alias.ID = model.ID
+	alias.CreatedAt = model.CreatedAt
+	alias.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update implements modelalias.Repository.
+func (repo *ModelAliasGormRepository) Update(ctx context.Context, alias *modelalias.Alias) error {
+	alias.UpdatedAt = time.Now().UTC()
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.ModelAlias{}).
+		Where("id = ?", alias.ID).
+		Updates(map[string]any{
+			"target_model":    alias.TargetModel,
+			"rollout_model":   alias.RolloutModel,
+			"rollout_percent": alias.RolloutPercent,
+			"description":     alias.Description,
+			"updated_at":      alias.UpdatedAt,
+		}).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update model alias", "04b7f35a-1d88-4a0e-bc13-3ef2d5b774c6")
+	}
+	return nil
+}
+
+// Delete implements modelalias.Repository.
+func (repo *ModelAliasGormRepository) Delete(ctx context.Context, id uint) error {
+	if err := repo.db.GetTx(ctx).Where("id = ?", id).Delete(&dbschema.ModelAlias{}).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to delete model alias", "2d7f37e5-4951-4a36-924d-057349bc8441")
+	}
+	return nil
+}
+
+// FindByName implements modelalias.Repository.
+func (repo *ModelAliasGormRepository) FindByName(ctx context.Context, name string) (*modelalias.Alias, error) {
+	var rows []dbschema.ModelAlias
+	if err := repo.db.GetReadTx(ctx).Where("name = ?", name).Limit(1).Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find model alias", "a8ac66f5-821b-4363-8de6-fa221e2fc456")
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return rows[0].EtoD(), nil
+}
+
+// List implements modelalias.Repository.
+func (repo *ModelAliasGormRepository) List(ctx context.Context) ([]*modelalias.Alias, error) {
+	var rows []dbschema.ModelAlias
+	if err := repo.db.GetReadTx(ctx).Order("name").Find(&rows).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list model aliases", "741eeb62-2e81-4ce8-ac59-1abb3651dcbe")
+	}
+	aliases := make([]*modelalias.Alias, 0, len(rows))
+	for i := range rows {
+		aliases = append(aliases, rows[i].EtoD())
+	}
+	return aliases, nil
+}
@@ -174,7 +174,7 @@ func (r *ModelPromptTemplateGormRepository) FindByModelWithTemplates(ctx context
 
 // FindByFilter finds model prompt templates matching the given filter
 func (r *ModelPromptTemplateGormRepository) FindByFilter(ctx context.Context, filter modelprompttemplate.ModelPromptTemplateFilter) ([]*modelprompttemplate.ModelPromptTemplate, error) {
-	tx := r.db.GetTx(ctx)
+	tx := r.db.GetReadTx(ctx)
 	q := tx.Model(&dbschema.ModelPromptTemplate{})
 
 	if filter.ModelCatalogID != nil {
@@ -208,7 +208,7 @@ func (r *ModelPromptTemplateGormRepository) FindByFilter(ctx context.Context, fi
 
 // Count returns the count of model prompt templates matching the given filter
 func (r *ModelPromptTemplateGormRepository) Count(ctx context.Context, filter modelprompttemplate.ModelPromptTemplateFilter) (int64, error) {
-	tx := r.db.GetTx(ctx)
+	tx := r.db.GetReadTx(ctx)
 	q := tx.Model(&dbschema.ModelPromptTemplate{})
 
 	if filter.ModelCatalogID != nil {
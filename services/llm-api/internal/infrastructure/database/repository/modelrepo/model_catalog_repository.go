@@ -187,7 +187,7 @@ func (repo *ModelCatalogGormRepository) FindByFilter(ctx context.Context, filter
 }
 
 func (repo *ModelCatalogGormRepository) Count(ctx context.Context, filter domainmodel.ModelCatalogFilter) (int64, error) {
-	query := repo.db.GetQuery(ctx)
+	query := repo.db.GetReadQuery(ctx)
 	sql := query.ModelCatalog.WithContext(ctx)
 	sql = repo.applyFilter(query, sql, filter)
 	count, err := sql.Count()
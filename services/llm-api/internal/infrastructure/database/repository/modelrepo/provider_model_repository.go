@@ -158,7 +158,7 @@ func (repo *ProviderModelGormRepository) FindByFilter(ctx context.Context, filte
 }
 
 func (repo *ProviderModelGormRepository) Count(ctx context.Context, filter domainmodel.ProviderModelFilter) (int64, error) {
-	query := repo.db.GetQuery(ctx)
+	query := repo.db.GetReadQuery(ctx)
 	sql := query.ProviderModel.WithContext(ctx)
 	sql = repo.applyFilter(query, sql, filter)
 	return sql.Count()
@@ -141,7 +141,7 @@ func (repo *ProviderGormRepository) FindByFilter(ctx context.Context, filter dom
 }
 
 func (repo *ProviderGormRepository) Count(ctx context.Context, filter domainmodel.ProviderFilter) (int64, error) {
-	query := repo.db.GetQuery(ctx)
+	query := repo.db.GetReadQuery(ctx)
 	sql := query.Provider.WithContext(ctx)
 	sql = repo.applyFilter(query, sql, filter)
 	return sql.Count()
@@ -157,3 +157,14 @@ func (repo *ProviderGormRepository) FindByIDs(ctx context.Context, ids []uint) (
 	}
 	return repo.FindByFilter(ctx, filter, nil)
 }
+
+func (repo *ProviderGormRepository) ReplaceEncryptedAPIKey(ctx context.Context, id uint, current, replacement string) (bool, error) {
+	query := repo.db.GetQuery(ctx)
+	result, err := query.Provider.WithContext(ctx).
+		Where(query.Provider.ID.Eq(id), query.Provider.EncryptedAPIKey.Eq(current)).
+		UpdateSimple(query.Provider.EncryptedAPIKey.Value(replacement))
+	if err != nil {
+		return false, err
+	}
+	return result.RowsAffected > 0, nil
+}
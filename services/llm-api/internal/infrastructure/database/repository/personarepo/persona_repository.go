@@ -0,0 +1,113 @@
+package personarepo
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/persona"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// PersonaGormRepository implements persona.Repository using GORM
+type PersonaGormRepository struct {
+	db *transaction.Database
+}
+
+var _ persona.Repository = (*PersonaGormRepository)(nil)
+
+// NewPersonaGormRepository creates a new persona repository
+func NewPersonaGormRepository(db *transaction.Database) persona.Repository {
+	return &PersonaGormRepository{db: db}
+}
+
+// Create implements persona.Repository.
+func (repo *PersonaGormRepository) Create(ctx context.Context, p *persona.Persona) error {
+	model := dbschema.NewSchemaPersona(p)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create persona", "9a5f0fce-1dfb-4290-9385-60053917bb63")
+	}
+	p.ID = model.ID
+	p.CreatedAt = model.CreatedAt
+	p.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update implements persona.Repository.
+func (repo *PersonaGormRepository) Update(ctx context.Context, p *persona.Persona) error {
+	p.UpdatedAt = time.Now().UTC()
+	model := dbschema.NewSchemaPersona(p)
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.Persona{}).
+		Where("id = ?", p.ID).
+		Updates(map[string]any{
+			"name":              model.Name,
+			"description":       model.Description,
+			"avatar":            model.Avatar,
+			"instructions":      model.Instructions,
+			"default_model":     model.DefaultModel,
+			"enabled_tools":     model.EnabledTools,
+			"starter_questions": model.StarterQuestions,
+			"updated_at":        model.UpdatedAt,
+		}).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update persona", "ecb88d8a-48ee-4beb-9f86-244effef7562")
+	}
+	return nil
+}
+
+// Delete implements persona.Repository.
+func (repo *PersonaGormRepository) Delete(ctx context.Context, id uint) error {
+	if err := repo.db.GetTx(ctx).Where("id = ?", id).Delete(&dbschema.Persona{}).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to delete persona", "989a7a94-e1d1-471e-a213-01b01d8242ed")
+	}
+	return nil
+}
+
+// FindByPublicID implements persona.Repository.
+func (repo *PersonaGormRepository) FindByPublicID(ctx context.Context, publicID string) (*persona.Persona, error) {
+	var model dbschema.Persona
+	if err := repo.db.GetReadTx(ctx).Where("public_id = ?", publicID).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find persona by public ID", "d10065e5-e313-4f8f-90df-08cb35ead962")
+	}
+	return model.EtoD(), nil
+}
+
+// FindByUser implements persona.Repository.
+func (repo *PersonaGormRepository) FindByUser(ctx context.Context, userID uint, p *query.Pagination) ([]*persona.Persona, int64, error) {
+	q := repo.db.GetReadTx(ctx).Model(&dbschema.Persona{}).Where("user_id = ?", userID)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count personas", "e1895405-2947-4fb2-aca1-8e13572accfc")
+	}
+
+	if p != nil {
+		if p.Limit != nil && *p.Limit > 0 {
+			q = q.Limit(*p.Limit)
+		}
+		if p.Offset != nil && *p.Offset > 0 {
+			q = q.Offset(*p.Offset)
+		}
+	}
+	var rows []dbschema.Persona
+	if err := q.Order("name, id").Find(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list personas", "39ff943f-0fdb-41ab-ab0a-547f2370a010")
+	}
+	personas := make([]*persona.Persona, 0, len(rows))
+	for i := range rows {
+		personas = append(personas, rows[i].EtoD())
+	}
+	return personas, total, nil
+}
+
+// CountByUser implements persona.Repository.
+func (repo *PersonaGormRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := repo.db.GetReadTx(ctx).Model(&dbschema.Persona{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count user personas", "bf98d28e-f6a2-4406-8e2c-1c8675cae3d6")
+	}
+	return count, nil
+}
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	"jan-server/services/llm-api/internal/domain/project"
 	"jan-server/services/llm-api/internal/domain/query"
@@ -24,12 +25,27 @@ func NewProjectGormRepository(db *gorm.DB) project.ProjectRepository {
 }
 
 // Create implements project.ProjectRepository.
+// The project's instruction is recorded as its first version.
 func (repo *ProjectGormRepository) Create(ctx context.Context, proj *project.Project) error {
 	dbProject := dbschema.NewSchemaProject(proj)
-	if err := repo.db.WithContext(ctx).Create(dbProject).Error; err != nil {
+	if dbProject.InstructionVersion < 1 {
+		dbProject.InstructionVersion = 1
+	}
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(dbProject).Error; err != nil {
+			return err
+		}
+		return tx.Create(&dbschema.ProjectInstructionVersion{
+			ProjectID:   dbProject.ID,
+			Version:     dbProject.InstructionVersion,
+			Instruction: dbProject.Instruction,
+		}).Error
+	})
+	if err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to create project")
 	}
 	proj.ID = dbProject.ID
+	proj.InstructionVersion = dbProject.InstructionVersion
 	proj.CreatedAt = dbProject.CreatedAt
 	proj.UpdatedAt = dbProject.UpdatedAt
 	return nil
@@ -128,26 +144,52 @@ func (repo *ProjectGormRepository) ListByUserID(ctx context.Context, userID uint
 }
 
 // Update implements project.ProjectRepository.
+// A changed instruction is recorded as a new version in the same transaction.
 func (repo *ProjectGormRepository) Update(ctx context.Context, proj *project.Project) error {
 	dbProject := dbschema.ProjectDtoE(proj)
 	dbProject.UpdatedAt = time.Now()
 
-	// Update only specified fields
-	err := repo.db.WithContext(ctx).Model(&dbschema.Project{}).
-		Where("public_id = ?", proj.PublicID).
-		Updates(map[string]interface{}{
-			"name":         dbProject.Name,
-			"instruction":  dbProject.Instruction,
-			"favorite":     dbProject.Favorite,
-			"archived_at":  dbProject.ArchivedAt,
-			"last_used_at": dbProject.LastUsedAt,
-			"updated_at":   dbProject.UpdatedAt,
-		}).Error
+	err := repo.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var current dbschema.Project
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("public_id = ?", proj.PublicID).
+			First(&current).Error; err != nil {
+			return err
+		}
 
+		// Update only specified fields
+		updates := map[string]interface{}{
+			"name":                  dbProject.Name,
+			"instruction":           dbProject.Instruction,
+			"default_tools":         dbProject.DefaultTools,
+			"knowledge_collections": dbProject.KnowledgeCollections,
+			"favorite":              dbProject.Favorite,
+			"archived_at":           dbProject.ArchivedAt,
+			"last_used_at":          dbProject.LastUsedAt,
+			"updated_at":            dbProject.UpdatedAt,
+		}
+		dbProject.InstructionVersion = current.InstructionVersion
+		if !sameInstruction(current.Instruction, dbProject.Instruction) {
+			dbProject.InstructionVersion++
+			updates["instruction_version"] = dbProject.InstructionVersion
+			if err := tx.Create(&dbschema.ProjectInstructionVersion{
+				ProjectID:   current.ID,
+				Version:     dbProject.InstructionVersion,
+				Instruction: dbProject.Instruction,
+			}).Error; err != nil {
+				return err
+			}
+		}
+
+		return tx.Model(&dbschema.Project{}).
+			Where("id = ?", current.ID).
+			Updates(updates).Error
+	})
 	if err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to update project")
 	}
 
+	proj.InstructionVersion = dbProject.InstructionVersion
 	proj.UpdatedAt = dbProject.UpdatedAt
 	return nil
 }
@@ -170,3 +212,33 @@ func (repo *ProjectGormRepository) Delete(ctx context.Context, publicID string)
 
 	return nil
 }
+
+// ListInstructionVersions implements project.ProjectRepository.
+func (repo *ProjectGormRepository) ListInstructionVersions(ctx context.Context, projectID uint) ([]*project.InstructionVersion, error) {
+	var rows []dbschema.ProjectInstructionVersion
+	err := repo.db.WithContext(ctx).
+		Where("project_id = ?", projectID).
+		Order("version DESC").
+		Find(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerRepository, err, "failed to list project instruction versions")
+	}
+
+	result := make([]*project.InstructionVersion, len(rows))
+	for i := range rows {
+		result[i] = rows[i].EtoD()
+	}
+	return result, nil
+}
+
+// sameInstruction treats a missing instruction and an empty one as equal
+func sameInstruction(a, b *string) bool {
+	var left, right string
+	if a != nil {
+		left = *a
+	}
+	if b != nil {
+		right = *b
+	}
+	return left == right
+}
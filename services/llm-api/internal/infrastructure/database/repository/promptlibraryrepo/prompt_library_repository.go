@@ -0,0 +1,151 @@
+package promptlibraryrepo
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// PromptLibraryGormRepository implements promptlibrary.Repository using GORM
+type PromptLibraryGormRepository struct {
+	db *transaction.Database
+}
+
+var _ promptlibrary.Repository = (*PromptLibraryGormRepository)(nil)
+
+// NewPromptLibraryGormRepository creates a new prompt library repository
+func NewPromptLibraryGormRepository(db *transaction.Database) promptlibrary.Repository {
+	return &PromptLibraryGormRepository{db: db}
+}
+
+// Create implements promptlibrary.Repository.
+func (repo *PromptLibraryGormRepository) Create(ctx context.Context, starter *promptlibrary.Starter) error {
+	model := dbschema.NewSchemaPromptLibraryStarter(starter)
+	if err := repo.db.GetTx(ctx).Create(model).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to create prompt library starter", "4090cfb4-804a-4e95-a08f-c147de25c172")
+	}
+	starter.ID = model.ID
+	starter.CreatedAt = model.CreatedAt
+	starter.UpdatedAt = model.UpdatedAt
+	return nil
+}
+
+// Update implements promptlibrary.Repository.
+func (repo *PromptLibraryGormRepository) Update(ctx context.Context, starter *promptlibrary.Starter) error {
+	starter.UpdatedAt = time.Now().UTC()
+	model := dbschema.NewSchemaPromptLibraryStarter(starter)
+	err := repo.db.GetTx(ctx).
+		Model(&dbschema.PromptLibraryStarter{}).
+		Where("id = ?", starter.ID).
+		Updates(map[string]any{
+			"title":       model.Title,
+			"description": model.Description,
+			"category":    model.Category,
+			"messages":    model.Messages,
+			"variables":   model.Variables,
+			"is_active":   model.IsActive,
+			"updated_at":  model.UpdatedAt,
+		}).Error
+	if err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to update prompt library starter", "525e932e-b0f5-4256-9bf5-6f1ffbfe0a8e")
+	}
+	return nil
+}
+
+// Delete implements promptlibrary.Repository.
+func (repo *PromptLibraryGormRepository) Delete(ctx context.Context, id uint) error {
+	if err := repo.db.GetTx(ctx).Where("id = ?", id).Delete(&dbschema.PromptLibraryStarter{}).Error; err != nil {
+		return platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to delete prompt library starter", "a669d145-4bf8-47dc-b3b9-7065745804bf")
+	}
+	return nil
+}
+
+// FindByPublicID implements promptlibrary.Repository.
+func (repo *PromptLibraryGormRepository) FindByPublicID(ctx context.Context, publicID string) (*promptlibrary.Starter, error) {
+	var model dbschema.PromptLibraryStarter
+	if err := repo.db.GetReadTx(ctx).Where("public_id = ?", publicID).First(&model).Error; err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to find prompt library starter by public ID", "567f3c93-dca8-4c16-8a9d-46370d0a0f64")
+	}
+	return model.EtoD(), nil
+}
+
+// FindByFilter implements promptlibrary.Repository. Global starters are listed before
+// user starters, then by category and title.
+func (repo *PromptLibraryGormRepository) FindByFilter(ctx context.Context, filter promptlibrary.Filter, p *query.Pagination) ([]*promptlibrary.Starter, int64, error) {
+	q := applyFilter(repo.db.GetReadTx(ctx).Model(&dbschema.PromptLibraryStarter{}), filter)
+
+	var total int64
+	if err := q.Count(&total).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count prompt library starters", "b6c8f55b-3cff-443e-8d83-8d87bf4c0b2f")
+	}
+
+	if p != nil {
+		if p.Limit != nil && *p.Limit > 0 {
+			q = q.Limit(*p.Limit)
+		}
+		if p.Offset != nil && *p.Offset > 0 {
+			q = q.Offset(*p.Offset)
+		}
+	}
+	var rows []dbschema.PromptLibraryStarter
+	if err := q.Order("user_id NULLS FIRST, category, title, id").Find(&rows).Error; err != nil {
+		return nil, 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list prompt library starters", "8c8144e2-9b4c-4f74-9cf4-74702f92dd3b")
+	}
+	starters := make([]*promptlibrary.Starter, 0, len(rows))
+	for i := range rows {
+		starters = append(starters, rows[i].EtoD())
+	}
+	return starters, total, nil
+}
+
+// CountByUser implements promptlibrary.Repository.
+func (repo *PromptLibraryGormRepository) CountByUser(ctx context.Context, userID uint) (int64, error) {
+	var count int64
+	if err := repo.db.GetReadTx(ctx).Model(&dbschema.PromptLibraryStarter{}).Where("user_id = ?", userID).Count(&count).Error; err != nil {
+		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count user prompt library starters", "a218a30d-128f-4960-8063-607738e33fa2")
+	}
+	return count, nil
+}
+
+// ListCategories implements promptlibrary.Repository.
+func (repo *PromptLibraryGormRepository) ListCategories(ctx context.Context, filter promptlibrary.Filter) ([]promptlibrary.Category, error) {
+	var categories []promptlibrary.Category
+	err := applyFilter(repo.db.GetReadTx(ctx).Model(&dbschema.PromptLibraryStarter{}), filter).
+		Select("category AS name, COUNT(*) AS count").
+		Group("category").
+		Order("category").
+		Scan(&categories).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list prompt library categories", "b56bc4a4-ed59-47d5-b22e-6edaa85d867b")
+	}
+	return categories, nil
+}
+
+func applyFilter(q *gorm.DB, filter promptlibrary.Filter) *gorm.DB {
+	switch {
+	case filter.GlobalOnly:
+		q = q.Where("user_id IS NULL")
+	case filter.VisibleTo != nil:
+		// Users see active global starters and all of their own
+		q = q.Where("(user_id IS NULL AND is_active = ?) OR user_id = ?", true, *filter.VisibleTo)
+	}
+	if filter.Category != nil && *filter.Category != "" {
+		q = q.Where("category = ?", *filter.Category)
+	}
+	if filter.IsActive != nil {
+		q = q.Where("is_active = ?", *filter.IsActive)
+	}
+	if filter.Search != nil && strings.TrimSpace(*filter.Search) != "" {
+		pat := "%" + strings.TrimSpace(*filter.Search) + "%"
+		q = q.Where("title ILIKE ? OR description ILIKE ?", pat, pat)
+	}
+	return q
+}
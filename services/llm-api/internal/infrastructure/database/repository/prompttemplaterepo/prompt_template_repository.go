@@ -133,7 +133,7 @@ func (r *PromptTemplateGormRepository) FindByTemplateKey(ctx context.Context, te
 
 // FindByFilter finds prompt templates matching the given filter
 func (r *PromptTemplateGormRepository) FindByFilter(ctx context.Context, filter prompttemplate.PromptTemplateFilter, p *query.Pagination) ([]*prompttemplate.PromptTemplate, error) {
-	tx := r.db.GetTx(ctx)
+	tx := r.db.GetReadTx(ctx)
 	q := tx.Model(&dbschema.PromptTemplate{})
 
 	// Apply filters
@@ -192,7 +192,7 @@ func (r *PromptTemplateGormRepository) FindByFilter(ctx context.Context, filter
 
 // Count returns the count of prompt templates matching the given filter
 func (r *PromptTemplateGormRepository) Count(ctx context.Context, filter prompttemplate.PromptTemplateFilter) (int64, error) {
-	tx := r.db.GetTx(ctx)
+	tx := r.db.GetReadTx(ctx)
 	q := tx.Model(&dbschema.PromptTemplate{})
 
 	// Apply filters
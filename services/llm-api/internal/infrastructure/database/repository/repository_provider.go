@@ -1,14 +1,25 @@
 package repository
 
 import (
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/analyticsrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/apikeyrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/coldstoragerepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/comparisonrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/conversationrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/evalrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/finetunerepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/memorybackfillrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/mcpcredentialrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/mcptoolrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/modelaliasrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/modelrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/modelprompttemplaterepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/personarepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/projectrepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/promptlibraryrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/prompttemplaterepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/sharerepo"
+	"jan-server/services/llm-api/internal/infrastructure/database/repository/syncrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/userrepo"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository/usersettingsrepo"
 
@@ -29,4 +40,15 @@ var RepositoryProvider = wire.NewSet(
 	modelprompttemplaterepo.NewModelPromptTemplateGormRepository,
 	sharerepo.NewShareGormRepository,
 	mcptoolrepo.NewMCPToolGormRepository,
+	analyticsrepo.NewAnalyticsGormRepository,
+	comparisonrepo.NewComparisonGormRepository,
+	evalrepo.NewEvalGormRepository,
+	finetunerepo.NewFinetuneGormRepository,
+	memorybackfillrepo.NewMemoryBackfillGormRepository,
+	coldstoragerepo.NewColdStorageGormRepository,
+	promptlibraryrepo.NewPromptLibraryGormRepository,
+	modelaliasrepo.NewModelAliasGormRepository,
+	personarepo.NewPersonaGormRepository,
+	mcpcredentialrepo.NewMCPCredentialGormRepository,
+	syncrepo.NewSyncGormRepository,
 )
@@ -41,7 +41,7 @@ func (repo *ShareGormRepository) Create(ctx context.Context, s *share.Share) err
 
 // FindByFilter implements share.ShareRepository.
 func (repo *ShareGormRepository) FindByFilter(ctx context.Context, filter share.ShareFilter, pagination *query.Pagination) ([]*share.Share, error) {
-	db := repo.applyFilter(repo.getDB(ctx), filter)
+	db := repo.applyFilter(repo.getReadDB(ctx), filter)
 	db = repo.applyPagination(db, pagination)
 
 	var rows []dbschema.ConversationShare
@@ -57,7 +57,7 @@ func (repo *ShareGormRepository) FindByFilter(ctx context.Context, filter share.
 
 // Count implements share.ShareRepository.
 func (repo *ShareGormRepository) Count(ctx context.Context, filter share.ShareFilter) (int64, error) {
-	db := repo.applyFilter(repo.getDB(ctx).Model(&dbschema.ConversationShare{}), filter)
+	db := repo.applyFilter(repo.getReadDB(ctx).Model(&dbschema.ConversationShare{}), filter)
 	var count int64
 	if err := db.Count(&count).Error; err != nil {
 		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to count shares", "6c7d8e9f-0a1b-4c2d-3e4f-5a6b7c8d9e0f")
@@ -184,6 +184,10 @@ func (repo *ShareGormRepository) getDB(ctx context.Context) *gorm.DB {
 	return repo.db.GetTx(ctx)
 }
 
+func (repo *ShareGormRepository) getReadDB(ctx context.Context) *gorm.DB {
+	return repo.db.GetReadTx(ctx)
+}
+
 // applyFilter applies filter criteria to the query
 func (repo *ShareGormRepository) applyFilter(db *gorm.DB, filter share.ShareFilter) *gorm.DB {
 	if filter.ID != nil {
@@ -0,0 +1,139 @@
+package syncrepo
+
+import (
+	"context"
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/deltasync"
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/infrastructure/encryption"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// SyncGormRepository implements deltasync.Repository using GORM
+type SyncGormRepository struct {
+	db         *transaction.Database
+	encryption *encryption.Service
+}
+
+var _ deltasync.Repository = (*SyncGormRepository)(nil)
+
+// NewSyncGormRepository creates a new sync repository
+func NewSyncGormRepository(db *transaction.Database, enc *encryption.Service) deltasync.Repository {
+	return &SyncGormRepository{db: db, encryption: enc}
+}
+
+// Horizon implements deltasync.Repository. It always reads the primary: a
+// replica's horizon says nothing about transactions running on the primary.
+func (repo *SyncGormRepository) Horizon(ctx context.Context) (uint64, error) {
+	var horizon uint64
+	err := repo.db.GetTx(ctx).
+		Raw("SELECT pg_snapshot_xmin(pg_current_snapshot())::text::bigint").
+		Scan(&horizon).Error
+	if err != nil {
+		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to read sync horizon", "b7e1f0a4-3c92-4d5e-8a6b-1f4c9d2e7a30")
+	}
+	return horizon, nil
+}
+
+// ListChanges implements deltasync.Repository. It reads the primary so that
+// changes below the horizon are guaranteed to be visible.
+func (repo *SyncGormRepository) ListChanges(ctx context.Context, userID uint, after deltasync.Checkpoint, horizon uint64, limit int) ([]*deltasync.Change, error) {
+	var rows []dbschema.SyncChange
+	err := repo.db.GetTx(ctx).
+		Where("user_id = ?", userID).
+		Where("(txid, id) > (?, ?)", after.TxID, after.ID).
+		Where("txid < ?", horizon).
+		Order("txid ASC, id ASC").
+		Limit(limit).
+		Find(&rows).Error
+	if err != nil {
+		return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to list sync changes", "2f8c5d1e-6a47-4b39-9e0d-7c3a1b5f8e62")
+	}
+
+	changes := make([]*deltasync.Change, len(rows))
+	for i := range rows {
+		changes[i] = rows[i].EtoD()
+	}
+	return changes, nil
+}
+
+// LoadSnapshot implements deltasync.Repository
+func (repo *SyncGormRepository) LoadSnapshot(ctx context.Context, userID uint, changes []*deltasync.Change) (*deltasync.Snapshot, error) {
+	snapshot := &deltasync.Snapshot{
+		Conversations: make(map[string]*conversation.Conversation),
+		Items:         make(map[string]*conversation.Item),
+	}
+
+	var conversationIDs, itemIDs []string
+	var wantSettings bool
+	for _, change := range changes {
+		switch change.EntityType {
+		case deltasync.EntityConversation:
+			conversationIDs = append(conversationIDs, change.EntityID)
+		case deltasync.EntityItem:
+			itemIDs = append(itemIDs, change.EntityID)
+		case deltasync.EntityUserSettings:
+			wantSettings = true
+		}
+	}
+
+	if len(conversationIDs) > 0 {
+		var conversations []dbschema.Conversation
+		err := repo.db.GetTx(ctx).
+			Where("user_id = ? AND public_id IN ?", userID, conversationIDs).
+			Find(&conversations).Error
+		if err != nil {
+			return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to load synced conversations", "8d4a2e6f-1b73-4c58-a0e9-5f2c7b3d9e14")
+		}
+		for i := range conversations {
+			snapshot.Conversations[conversations[i].PublicID] = conversations[i].EtoD()
+		}
+	}
+
+	if len(itemIDs) > 0 {
+		var items []dbschema.ConversationItem
+		err := repo.db.GetTx(ctx).
+			Joins("JOIN llm_api.conversations ON llm_api.conversations.id = llm_api.conversation_items.conversation_id AND llm_api.conversations.deleted_at IS NULL").
+			Where("llm_api.conversations.user_id = ? AND llm_api.conversation_items.public_id IN ?", userID, itemIDs).
+			Find(&items).Error
+		if err != nil {
+			return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to load synced conversation items", "5c9e3b7a-2d61-4f08-b4a3-9e1d6c8f2a75")
+		}
+		for i := range items {
+			if err := repo.encryption.OpenItem(ctx, &items[i]); err != nil {
+				return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to decrypt synced conversation item", "2f7b9d3e-6a14-4c85-9e20-b3d8a5c1f476")
+			}
+			snapshot.Items[items[i].PublicID] = items[i].EtoD()
+		}
+	}
+
+	if wantSettings {
+		var settings []dbschema.UserSettings
+		err := repo.db.GetTx(ctx).
+			Where("user_id = ?", userID).
+			Limit(1).
+			Find(&settings).Error
+		if err != nil {
+			return nil, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, err, "failed to load synced user settings", "e3a7c1f9-4b28-4d6e-8f05-2a9b7d4c1e83")
+		}
+		if len(settings) > 0 {
+			snapshot.Settings = settings[0].EtoD()
+		}
+	}
+
+	return snapshot, nil
+}
+
+// DeleteBefore implements deltasync.Repository
+func (repo *SyncGormRepository) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := repo.db.GetTx(ctx).
+		Where("changed_at < ?", cutoff).
+		Delete(&dbschema.SyncChange{})
+	if result.Error != nil {
+		return 0, platformerrors.AsErrorWithUUID(ctx, platformerrors.LayerRepository, result.Error, "failed to prune sync changes", "a1d6f3b8-7e24-4c95-b0a7-3e8c2f5d9b16")
+	}
+	return result.RowsAffected, nil
+}
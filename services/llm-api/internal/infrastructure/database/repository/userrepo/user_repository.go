@@ -66,6 +66,29 @@ func (repo *UserGormRepository) FindByID(ctx context.Context, id uint) (*user.Us
 	return entity.EtoD(), nil
 }
 
+func (repo *UserGormRepository) FindByLogin(ctx context.Context, login string) (*user.User, error) {
+	var entity dbschema.User
+	err := repo.db.WithContext(ctx).
+		Where("subject = ? OR LOWER(email) = LOWER(?) OR username = ?", login, login, login).
+		Order("id").
+		First(&entity).
+		Error
+	if err == gorm.ErrRecordNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, platformerrors.NewError(
+			ctx,
+			platformerrors.LayerRepository,
+			platformerrors.ErrorTypeDatabaseError,
+			"failed to find user by login",
+			err,
+			"6c1e8f42-9a3d-4b57-8e20-d4f7a5b9c613",
+		)
+	}
+	return entity.EtoD(), nil
+}
+
 func (repo *UserGormRepository) Upsert(ctx context.Context, usr *user.User) (*user.User, error) {
 	// Prepare schema model from domain user
 	schemaUser := dbschema.NewSchemaUser(usr)
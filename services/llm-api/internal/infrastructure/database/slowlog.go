@@ -0,0 +1,101 @@
+package database
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+)
+
+// pgQueryCanceled is the SQLSTATE Postgres returns when statement_timeout
+// aborts a statement.
+const pgQueryCanceled = "57014"
+
+const maxLoggedSQLLength = 2048
+
+// slowQueryLogger wraps the GORM logger and reports statements slower than
+// threshold, and every statement aborted by statement_timeout, with the trace
+// of the request that issued them. Bound values are dropped so conversation
+// content never reaches the logs.
+type slowQueryLogger struct {
+	gormlogger.Interface
+	threshold time.Duration // 0 reports only timeouts
+}
+
+func newSlowQueryLogger(base gormlogger.Interface, threshold time.Duration) *slowQueryLogger {
+	return &slowQueryLogger{Interface: base, threshold: threshold}
+}
+
+func (l *slowQueryLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	return &slowQueryLogger{Interface: l.Interface.LogMode(level), threshold: l.threshold}
+}
+
+// ParamsFilter implements gorm.ParamsFilter, leaving $n placeholders in the
+// logged SQL instead of the values bound to them.
+func (l *slowQueryLogger) ParamsFilter(ctx context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	return sql, nil
+}
+
+func (l *slowQueryLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	l.Interface.Trace(ctx, begin, fc, err)
+
+	elapsed := time.Since(begin)
+	timedOut := isStatementTimeout(err)
+	if !timedOut && (l.threshold <= 0 || elapsed < l.threshold) {
+		return
+	}
+
+	sql, rows := fc()
+	if len(sql) > maxLoggedSQLLength {
+		sql = sql[:maxLoggedSQLLength] + "..."
+	}
+	log := logger.GetLogger()
+	event := log.Warn()
+	if timedOut {
+		event = log.Error()
+	}
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		event = event.Err(err)
+	}
+	event.
+		Ctx(ctx).
+		Str("sql", sql).
+		Int64("rows", rows).
+		Dur("elapsed", elapsed).
+		Bool("statement_timeout", timedOut).
+		Msg("slow database query")
+}
+
+func isStatementTimeout(err error) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == pgQueryCanceled
+}
+
+// withStatementTimeout sets statement_timeout on every connection opened from
+// dsn, unless the DSN already sets one. Postgres then aborts any statement
+// running longer and frees its connection.
+func withStatementTimeout(dsn string, timeout time.Duration) string {
+	if timeout <= 0 || strings.Contains(dsn, "statement_timeout") {
+		return dsn
+	}
+	ms := strconv.FormatInt(timeout.Milliseconds(), 10)
+	if strings.HasPrefix(dsn, "postgres://") || strings.HasPrefix(dsn, "postgresql://") {
+		u, err := url.Parse(dsn)
+		if err != nil {
+			return dsn
+		}
+		query := u.Query()
+		query.Set("statement_timeout", ms)
+		u.RawQuery = query.Encode()
+		return u.String()
+	}
+	return strings.TrimSpace(dsn) + " statement_timeout=" + ms
+}
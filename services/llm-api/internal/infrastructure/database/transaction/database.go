@@ -2,6 +2,7 @@ package transaction
 
 import (
 	"context"
+	"sync/atomic"
 
 	"gorm.io/gorm"
 	"jan-server/services/llm-api/internal/infrastructure/database/gormgen"
@@ -9,19 +10,31 @@ import (
 
 type TransactionContextKey struct{}
 
+type primaryContextKey struct{}
+
 func WithTx(ctx context.Context, tx *gorm.DB) context.Context {
 	return context.WithValue(ctx, TransactionContextKey{}, tx)
 }
 
+// WithPrimary pins read queries in ctx to the primary, for flows that must
+// read their own writes despite replica lag.
+func WithPrimary(ctx context.Context) context.Context {
+	return context.WithValue(ctx, primaryContextKey{}, true)
+}
+
 type Database struct {
-	db *gorm.DB
+	db       *gorm.DB
+	replicas []*gorm.DB
+	next     atomic.Uint64
 }
 
+// GetTx returns the transaction in ctx, or the primary. Statements run with
+// ctx, so they stop when the request ends and slow-query logs carry its trace.
 func (t *Database) GetTx(ctx context.Context) *gorm.DB {
 	if tx, ok := ctx.Value(TransactionContextKey{}).(*gorm.DB); ok {
-		return tx
+		return tx.WithContext(ctx)
 	}
-	return t.db
+	return t.db.WithContext(ctx)
 }
 
 func (t *Database) GetQuery(ctx context.Context) *gormgen.Query {
@@ -29,6 +42,29 @@ func (t *Database) GetQuery(ctx context.Context) *gormgen.Query {
 	return gormgen.Use(db)
 }
 
-func NewDatabase(db *gorm.DB) *Database {
-	return &Database{db}
+// GetReadTx returns a read replica (round-robin) for read-only queries. It
+// falls back to GetTx inside a transaction, when ctx is pinned with
+// WithPrimary, or when no replica is configured.
+func (t *Database) GetReadTx(ctx context.Context) *gorm.DB {
+	if len(t.replicas) == 0 {
+		return t.GetTx(ctx)
+	}
+	if _, ok := ctx.Value(TransactionContextKey{}).(*gorm.DB); ok {
+		return t.GetTx(ctx)
+	}
+	if pinned, _ := ctx.Value(primaryContextKey{}).(bool); pinned {
+		return t.db.WithContext(ctx)
+	}
+	idx := t.next.Add(1) % uint64(len(t.replicas))
+	return t.replicas[idx].WithContext(ctx)
+}
+
+// GetReadQuery is GetQuery for list, filter and count calls that tolerate
+// replica lag. Lookups feeding a write should keep using GetQuery.
+func (t *Database) GetReadQuery(ctx context.Context) *gormgen.Query {
+	return gormgen.Use(t.GetReadTx(ctx))
+}
+
+func NewDatabase(db *gorm.DB, replicas ...*gorm.DB) *Database {
+	return &Database{db: db, replicas: replicas}
 }
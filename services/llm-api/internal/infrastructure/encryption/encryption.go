@@ -0,0 +1,323 @@
+// Package encryption seals conversation content at rest with per-user data
+// keys. Each user's AES-256 data key is stored wrapped by a master key held in
+// a KMS, so a copy of the database reveals neither the content nor the keys,
+// and deleting a user's key makes their content unreadable.
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/janhq/jan-server/packages/go-common/kms"
+	"gorm.io/gorm/clause"
+
+	"jan-server/services/llm-api/internal/infrastructure/database/dbschema"
+	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+)
+
+// maxCachedKeys bounds the unwrapped data keys kept in memory
+const maxCachedKeys = 10000
+
+// errNoKMS is returned when reading sealed content without a configured KMS
+var errNoKMS = errors.New("content is encrypted but no KMS is configured")
+
+// sealedItem is the plaintext behind an item's content ciphertext
+type sealedItem struct {
+	Content   dbschema.JSONContent `json:"content,omitempty"`
+	Arguments *string              `json:"arguments,omitempty"`
+	Output    *string              `json:"output,omitempty"`
+}
+
+// Service seals item content on write and opens it on read. Sealing only
+// happens while enabled; opening works whenever a wrapper is configured, so
+// content written while encryption was on stays readable after turning it off.
+type Service struct {
+	enabled bool
+	wrapper kms.KeyWrapper
+	db      *transaction.Database
+
+	mu       sync.RWMutex
+	keys     map[uint]cipher.AEAD // Data key ID to cipher
+	userKeys map[uint]uint        // User ID to data key ID
+	owners   map[uint]uint        // Conversation ID to owner user ID
+}
+
+// NewService creates the encryption service. wrapper may be nil when no KMS
+// is configured, in which case enabled must be false.
+func NewService(enabled bool, wrapper kms.KeyWrapper, db *transaction.Database) (*Service, error) {
+	if enabled && wrapper == nil {
+		return nil, errors.New("encryption at rest needs a configured KMS")
+	}
+	return &Service{
+		enabled:  enabled,
+		wrapper:  wrapper,
+		db:       db,
+		keys:     make(map[uint]cipher.AEAD),
+		userKeys: make(map[uint]uint),
+		owners:   make(map[uint]uint),
+	}, nil
+}
+
+// Enabled reports whether new content is sealed
+func (s *Service) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// SealItem encrypts the content, arguments and output of an item about to be
+// written with its conversation owner's data key, clearing the plaintext fields
+func (s *Service) SealItem(ctx context.Context, item *dbschema.ConversationItem) error {
+	if !s.Enabled() || item == nil {
+		return nil
+	}
+	userID, err := s.conversationOwner(ctx, item.ConversationID)
+	if err != nil {
+		return err
+	}
+	keyID, aead, err := s.userKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(sealedItem{Content: item.Content, Arguments: item.Arguments, Output: item.Output})
+	if err != nil {
+		return err
+	}
+	ciphertext, err := kms.Seal(aead, plaintext, []byte(item.PublicID))
+	if err != nil {
+		return fmt.Errorf("seal item: %w", err)
+	}
+	item.ContentCiphertext = ciphertext
+	item.DataKeyID = &keyID
+	item.Content, item.Arguments, item.Output = nil, nil, nil
+	return nil
+}
+
+// SealItems seals a batch of items
+func (s *Service) SealItems(ctx context.Context, items []*dbschema.ConversationItem) error {
+	for _, item := range items {
+		if err := s.SealItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// OpenItem restores the plaintext fields of a sealed item. Items written in
+// plaintext, and cold storage stubs whose ciphertext is archived, are left
+// untouched.
+func (s *Service) OpenItem(ctx context.Context, item *dbschema.ConversationItem) error {
+	if item == nil || item.DataKeyID == nil || item.ColdArchiveID != nil {
+		return nil
+	}
+	aead, err := s.keyByID(ctx, *item.DataKeyID)
+	if err != nil {
+		return err
+	}
+	plaintext, err := kms.Open(aead, item.ContentCiphertext, []byte(item.PublicID))
+	if err != nil {
+		return fmt.Errorf("open item %s: %w", item.PublicID, err)
+	}
+	var sealed sealedItem
+	if err := json.Unmarshal(plaintext, &sealed); err != nil {
+		return fmt.Errorf("decode item %s: %w", item.PublicID, err)
+	}
+	item.Content, item.Arguments, item.Output = sealed.Content, sealed.Arguments, sealed.Output
+	item.ContentCiphertext = nil
+	return nil
+}
+
+// OpenItems opens a batch of items
+func (s *Service) OpenItems(ctx context.Context, items []*dbschema.ConversationItem) error {
+	for _, item := range items {
+		if err := s.OpenItem(ctx, item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// SealItemEdit encrypts the previous content and output of an item edit
+func (s *Service) SealItemEdit(ctx context.Context, edit *dbschema.ConversationItemEdit) error {
+	if !s.Enabled() || edit == nil {
+		return nil
+	}
+	userID, err := s.conversationOwner(ctx, edit.ConversationID)
+	if err != nil {
+		return err
+	}
+	keyID, aead, err := s.userKey(ctx, userID)
+	if err != nil {
+		return err
+	}
+	plaintext, err := json.Marshal(sealedItem{Content: edit.PreviousContent, Output: edit.PreviousOutput})
+	if err != nil {
+		return err
+	}
+	ciphertext, err := kms.Seal(aead, plaintext, editAdditionalData(edit))
+	if err != nil {
+		return fmt.Errorf("seal item edit: %w", err)
+	}
+	edit.PreviousCiphertext = ciphertext
+	edit.DataKeyID = &keyID
+	edit.PreviousContent, edit.PreviousOutput = nil, nil
+	return nil
+}
+
+// OpenItemEdit restores the previous content and output of a sealed item edit
+func (s *Service) OpenItemEdit(ctx context.Context, edit *dbschema.ConversationItemEdit) error {
+	if edit == nil || edit.DataKeyID == nil {
+		return nil
+	}
+	aead, err := s.keyByID(ctx, *edit.DataKeyID)
+	if err != nil {
+		return err
+	}
+	plaintext, err := kms.Open(aead, edit.PreviousCiphertext, editAdditionalData(edit))
+	if err != nil {
+		return fmt.Errorf("open item edit %d: %w", edit.ID, err)
+	}
+	var sealed sealedItem
+	if err := json.Unmarshal(plaintext, &sealed); err != nil {
+		return fmt.Errorf("decode item edit %d: %w", edit.ID, err)
+	}
+	edit.PreviousContent, edit.PreviousOutput = sealed.Content, sealed.Output
+	edit.PreviousCiphertext = nil
+	return nil
+}
+
+func editAdditionalData(edit *dbschema.ConversationItemEdit) []byte {
+	return []byte(fmt.Sprintf("item-edit:%d", edit.ItemID))
+}
+
+// conversationOwner returns the user whose key seals a conversation's items
+func (s *Service) conversationOwner(ctx context.Context, conversationID uint) (uint, error) {
+	s.mu.RLock()
+	userID, ok := s.owners[conversationID]
+	s.mu.RUnlock()
+	if ok {
+		return userID, nil
+	}
+
+	err := s.db.GetTx(ctx).WithContext(ctx).
+		Model(&dbschema.Conversation{}).
+		Unscoped().
+		Select("user_id").
+		Where("id = ?", conversationID).
+		Scan(&userID).Error
+	if err != nil {
+		return 0, fmt.Errorf("look up conversation owner: %w", err)
+	}
+	if userID == 0 {
+		return 0, fmt.Errorf("conversation %d has no owner", conversationID)
+	}
+
+	s.mu.Lock()
+	if len(s.owners) >= maxCachedKeys {
+		s.owners = make(map[uint]uint)
+	}
+	s.owners[conversationID] = userID
+	s.mu.Unlock()
+	return userID, nil
+}
+
+// userKey returns the user's data key, creating it on first use
+func (s *Service) userKey(ctx context.Context, userID uint) (uint, cipher.AEAD, error) {
+	s.mu.RLock()
+	keyID, ok := s.userKeys[userID]
+	aead := s.keys[keyID]
+	s.mu.RUnlock()
+	if ok && aead != nil {
+		return keyID, aead, nil
+	}
+
+	// Keys are read and created outside the caller's transaction: the cache
+	// outlives a rollback, and sealed content must never reference a key
+	// that was rolled back.
+	db := s.db.GetTx(context.Background()).WithContext(ctx)
+	var row dbschema.UserDataKey
+	if err := db.Where("user_id = ?", userID).Limit(1).Find(&row).Error; err != nil {
+		return 0, nil, fmt.Errorf("load data key: %w", err)
+	}
+	if row.ID == 0 {
+		dataKey := make([]byte, kms.KeySize)
+		if _, err := rand.Read(dataKey); err != nil {
+			return 0, nil, err
+		}
+		wrapped, err := s.wrapper.Wrap(ctx, dataKey)
+		if err != nil {
+			return 0, nil, fmt.Errorf("wrap data key: %w", err)
+		}
+		candidate := dbschema.UserDataKey{UserID: userID, WrappedKey: wrapped, KeyProvider: s.wrapper.Name()}
+		err = db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "user_id"}}, DoNothing: true}).
+			Create(&candidate).Error
+		if err != nil {
+			return 0, nil, fmt.Errorf("create data key: %w", err)
+		}
+		// A concurrent request may have won the insert; everyone uses its key
+		if err := db.Where("user_id = ?", userID).Limit(1).Find(&row).Error; err != nil {
+			return 0, nil, fmt.Errorf("load data key: %w", err)
+		}
+	}
+
+	aead, err := s.unwrap(ctx, &row)
+	if err != nil {
+		return 0, nil, err
+	}
+	s.mu.Lock()
+	if len(s.userKeys) >= maxCachedKeys {
+		s.userKeys = make(map[uint]uint)
+	}
+	s.userKeys[userID] = row.ID
+	s.mu.Unlock()
+	return row.ID, aead, nil
+}
+
+// keyByID returns the data key a row was sealed with
+func (s *Service) keyByID(ctx context.Context, keyID uint) (cipher.AEAD, error) {
+	if s == nil || s.wrapper == nil {
+		return nil, errNoKMS
+	}
+	s.mu.RLock()
+	aead, ok := s.keys[keyID]
+	s.mu.RUnlock()
+	if ok {
+		return aead, nil
+	}
+
+	var row dbschema.UserDataKey
+	err := s.db.GetTx(ctx).WithContext(ctx).Where("id = ?", keyID).Limit(1).Find(&row).Error
+	if err != nil {
+		return nil, fmt.Errorf("load data key: %w", err)
+	}
+	if row.ID == 0 {
+		return nil, fmt.Errorf("data key %d not found", keyID)
+	}
+	return s.unwrap(ctx, &row)
+}
+
+// unwrap decrypts a stored data key with the KMS and caches the cipher
+func (s *Service) unwrap(ctx context.Context, row *dbschema.UserDataKey) (cipher.AEAD, error) {
+	if row.KeyProvider != s.wrapper.Name() {
+		return nil, fmt.Errorf("data key %d was wrapped by %s, but the configured KMS is %s", row.ID, row.KeyProvider, s.wrapper.Name())
+	}
+	dataKey, err := s.wrapper.Unwrap(ctx, row.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key %d: %w", row.ID, err)
+	}
+	aead, err := kms.NewAEAD(dataKey)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	if len(s.keys) >= maxCachedKeys {
+		s.keys = make(map[uint]cipher.AEAD)
+	}
+	s.keys[row.ID] = aead
+	s.mu.Unlock()
+	return aead, nil
+}
@@ -0,0 +1,16 @@
+// Package flagkeys names the feature flags of llm-api, evaluated with
+// packages/go-common/featureflags.
+package flagkeys
+
+// Flags of llm-api. Each defaults to the setting named next to it, which
+// remains the switch for the infrastructure behind the feature; the flag then
+// decides which users get it.
+const (
+	Memory                 = "memory"                   // MEMORY_ENABLED: memories loaded into and observed from chats
+	PromptMemory           = "prompt_memory"            // PROMPT_ORCHESTRATION_MEMORY
+	PromptToolInstructions = "prompt_tool_instructions" // PROMPT_ORCHESTRATION_TOOLS
+	PromptCodeAssistant    = "prompt_code_assistant"    // PROMPT_ORCHESTRATION_TEMPLATES
+	PromptChainOfThought   = "prompt_chain_of_thought"  // PROMPT_ORCHESTRATION_TEMPLATES
+	DeepResearch           = "deep_research"            // PROMPT_ORCHESTRATION_ENABLED: the deep_research request option
+	VLLMRouting            = "vllm_routing"             // VLLM_ROUTING_CONTROLLER_ENABLED: routing away from saturated vLLM endpoints
+)
@@ -3,15 +3,20 @@ package inference
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
 	"github.com/rs/zerolog/log"
 
 	"jan-server/services/llm-api/internal/config"
 	domainmodel "jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/domain/region"
+	"jan-server/services/llm-api/internal/infrastructure/flagkeys"
 	"jan-server/services/llm-api/internal/infrastructure/router"
-	"jan-server/services/llm-api/internal/utils/crypto"
+	"jan-server/services/llm-api/internal/infrastructure/vllmmetrics"
 	httpclients "jan-server/services/llm-api/internal/utils/httpclients"
 	chatclient "jan-server/services/llm-api/internal/utils/httpclients/chat"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
@@ -20,21 +25,58 @@ import (
 )
 
 type InferenceProvider struct {
-	streamTimeout time.Duration
-	router        domainmodel.EndpointRouter
+	streamTimeout     time.Duration
+	heartbeatInterval time.Duration
+	router            domainmodel.EndpointRouter
+	weightedRouter    domainmodel.EndpointRouter // Set while the vLLM routing controller is enabled
+	flags             *featureflags.Client
+	regions           *region.Selector
+	httpClient        *http.Client
 }
 
-func NewInferenceProvider(cfg *config.Config) *InferenceProvider {
+// NewInferenceProvider creates the provider registry. Endpoints are picked
+// round-robin, or by the weights monitor derives from vLLM load while its
+// routing controller is enabled and the vllm_routing flag is on for the
+// request, among those in the region regions prefers.
+func NewInferenceProvider(cfg *config.Config, monitor *vllmmetrics.Monitor, regions *region.Selector, flags *featureflags.Client) *InferenceProvider {
 	timeout := 300 * time.Second // default 5 minutes
 	if cfg != nil && cfg.StreamTimeout > 0 {
 		timeout = cfg.StreamTimeout
 	}
+	var heartbeat time.Duration
+	if cfg != nil {
+		heartbeat = cfg.SSEHeartbeatInterval
+	}
+	var weightedRouter domainmodel.EndpointRouter
+	if monitor != nil && monitor.ControllerEnabled() {
+		weightedRouter = router.NewWeightedRouter(monitor)
+	}
 	return &InferenceProvider{
-		streamTimeout: timeout,
-		router:        router.NewRoundRobinRouter(),
+		streamTimeout:     timeout,
+		heartbeatInterval: heartbeat,
+		router:            router.NewRoundRobinRouter(),
+		weightedRouter:    weightedRouter,
+		flags:             flags,
+		regions:           regions,
+		httpClient:        newProviderHTTPClient(cfg),
 	}
 }
 
+// newProviderHTTPClient builds the pooled client shared by all model provider
+// calls from the PROVIDER_HTTP_* settings, so connections are reused across
+// requests. A nil client falls back to resty's defaults.
+func newProviderHTTPClient(cfg *config.Config) *http.Client {
+	if cfg == nil {
+		return nil
+	}
+	client, err := httpclient.New(cfg.ProviderHTTP)
+	if err != nil {
+		log.Error().Err(err).Msg("invalid PROVIDER_HTTP_* settings, using default HTTP client")
+		return nil
+	}
+	return client
+}
+
 func (ip *InferenceProvider) GetChatCompletionClient(ctx context.Context, provider *domainmodel.Provider) (*chatclient.ChatCompletionClient, error) {
 	log.Debug().
 		Str("provider_id", provider.PublicID).
@@ -58,7 +100,7 @@ func (ip *InferenceProvider) GetChatCompletionClient(ctx context.Context, provid
 		Str("base_url", selectedURL).
 		Msg("[DEBUG] GetChatCompletionClient: client created successfully")
 
-	return chatclient.NewChatCompletionClient(client, clientName, selectedURL, chatclient.WithStreamTimeout(ip.streamTimeout)), nil
+	return chatclient.NewChatCompletionClient(client, clientName, selectedURL, chatclient.WithStreamTimeout(ip.streamTimeout), chatclient.WithHeartbeatInterval(ip.heartbeatInterval)), nil
 }
 
 func (ip *InferenceProvider) GetChatModelClient(ctx context.Context, provider *domainmodel.Provider) (*chatclient.ChatModelClient, error) {
@@ -85,6 +127,15 @@ func (ip *InferenceProvider) ListModels(ctx context.Context, provider *domainmod
 	return resp.Data, nil
 }
 
+// endpointRouter returns the weighted router when the vllm_routing flag is on
+// for the request, and the round-robin router otherwise.
+func (ip *InferenceProvider) endpointRouter(ctx context.Context) domainmodel.EndpointRouter {
+	if ip.weightedRouter != nil && (ip.flags == nil || ip.flags.Enabled(ctx, flagkeys.VLLMRouting)) {
+		return ip.weightedRouter
+	}
+	return ip.router
+}
+
 func (ip *InferenceProvider) createRestyClient(ctx context.Context, provider *domainmodel.Provider) (*resty.Client, string, error) {
 	clientName := fmt.Sprintf("%sClient", provider.PublicID)
 
@@ -94,7 +145,10 @@ func (ip *InferenceProvider) createRestyClient(ctx context.Context, provider *do
 	}
 
 	endpoints := provider.GetEndpoints()
-	selectedURL, err := ip.router.NextEndpoint(provider.PublicID, endpoints)
+	if ip.regions.Enabled() {
+		endpoints = ip.regions.Filter(ctx, provider.RegionalEndpoints())
+	}
+	selectedURL, err := ip.endpointRouter(ctx).NextEndpoint(provider.PublicID, endpoints)
 	if err != nil {
 		switch err {
 		case domainmodel.ErrNoEndpoints:
@@ -132,7 +186,7 @@ func (ip *InferenceProvider) createRestyClient(ctx context.Context, provider *do
 		Bool("has_multiple", provider.HasMultipleEndpoints()).
 		Msg("selected endpoint for request")
 
-	client := httpclients.NewClient(clientName)
+	client := httpclients.NewClient(clientName, ip.httpClient)
 	client.SetBaseURL(selectedURL)
 
 	// Set authorization header if API key exists
@@ -164,12 +218,12 @@ func (ip *InferenceProvider) decryptAPIKey(ctx context.Context, encryptedAPIKey
 		return "", nil
 	}
 
-	secret := strings.TrimSpace(config.GetGlobal().ModelProviderSecret)
-	if secret == "" {
+	keyring := config.GetGlobal().ProviderKeyring()
+	if !keyring.Configured() {
 		return "", platformerrors.NewError(ctx, platformerrors.LayerInfrastructure, platformerrors.ErrorTypeInternal, "MODEL_PROVIDER_SECRET not configured", nil, "8f07ea41-1096-405b-ae2e-cde06564e5bc")
 	}
 
-	plainText, err := crypto.DecryptString(secret, encryptedAPIKey)
+	plainText, err := keyring.Decrypt(encryptedAPIKey)
 	if err != nil {
 		return "", err
 	}
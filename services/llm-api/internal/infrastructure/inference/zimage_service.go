@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
@@ -14,16 +15,16 @@ import (
 	"jan-server/services/llm-api/internal/config"
 	domainmodel "jan-server/services/llm-api/internal/domain/model"
 	"jan-server/services/llm-api/internal/infrastructure/router"
-	"jan-server/services/llm-api/internal/utils/crypto"
 	httpclients "jan-server/services/llm-api/internal/utils/httpclients"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 )
 
 // ZImageService implements ImageService for z-image (Flux) providers.
 type ZImageService struct {
-	cfg     *config.Config
-	timeout time.Duration
-	router  domainmodel.EndpointRouter
+	cfg        *config.Config
+	timeout    time.Duration
+	router     domainmodel.EndpointRouter
+	httpClient *http.Client
 }
 
 // NewZImageService creates a new ZImageService instance.
@@ -33,9 +34,10 @@ func NewZImageService(cfg *config.Config) *ZImageService {
 		timeout = cfg.ImageGenerationTimeout
 	}
 	return &ZImageService{
-		cfg:     cfg,
-		timeout: timeout,
-		router:  router.NewRoundRobinRouter(),
+		cfg:        cfg,
+		timeout:    timeout,
+		router:     router.NewRoundRobinRouter(),
+		httpClient: newProviderHTTPClient(cfg),
 	}
 }
 
@@ -447,15 +449,15 @@ func (s *ZImageService) createRestyClient(ctx context.Context, provider *domainm
 	}
 
 	clientName := fmt.Sprintf("zimage-%s", provider.PublicID)
-	client := httpclients.NewClient(clientName)
+	client := httpclients.NewClient(clientName, s.httpClient)
 	client.SetTimeout(s.timeout)
 	client.SetRetryCount(0) // We handle retries at a higher level
 
 	// Set API key if available
 	if provider.EncryptedAPIKey != "" {
-		secret := strings.TrimSpace(s.cfg.ModelProviderSecret)
-		if secret != "" {
-			decrypted, err := crypto.DecryptString(secret, provider.EncryptedAPIKey)
+		keyring := s.cfg.ProviderKeyring()
+		if keyring.Configured() {
+			decrypted, err := keyring.Decrypt(provider.EncryptedAPIKey)
 			if err != nil {
 				log.Warn().Err(err).Str("provider_id", provider.PublicID).
 					Msg("[ZImageService] Failed to decrypt API key")
@@ -2,26 +2,49 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+	"github.com/janhq/jan-server/packages/go-common/kms"
+	"github.com/janhq/jan-server/packages/go-common/leader"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 
 	"jan-server/services/llm-api/internal/application/audit"
 	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/guestquota"
+	"jan-server/services/llm-api/internal/domain/linkpolicy"
+	"jan-server/services/llm-api/internal/domain/region"
 	"jan-server/services/llm-api/internal/infrastructure/auth"
+	"jan-server/services/llm-api/internal/infrastructure/captcha"
 	"jan-server/services/llm-api/internal/infrastructure/crontab"
 	"jan-server/services/llm-api/internal/infrastructure/database"
 	"jan-server/services/llm-api/internal/infrastructure/database/repository"
 	"jan-server/services/llm-api/internal/infrastructure/database/transaction"
+	"jan-server/services/llm-api/internal/infrastructure/encryption"
+	"jan-server/services/llm-api/internal/infrastructure/flagkeys"
 	"jan-server/services/llm-api/internal/infrastructure/inference"
 	"jan-server/services/llm-api/internal/infrastructure/keycloak"
 	"jan-server/services/llm-api/internal/infrastructure/kong"
+	"jan-server/services/llm-api/internal/infrastructure/linkcheck"
+	"jan-server/services/llm-api/internal/infrastructure/livekit"
 	"jan-server/services/llm-api/internal/infrastructure/logger"
 	"jan-server/services/llm-api/internal/infrastructure/mediaclient"
 	memclient "jan-server/services/llm-api/internal/infrastructure/memory"
+	"jan-server/services/llm-api/internal/infrastructure/quotacounter"
+	"jan-server/services/llm-api/internal/infrastructure/regionprobe"
+	"jan-server/services/llm-api/internal/infrastructure/streamstate"
+	"jan-server/services/llm-api/internal/infrastructure/tts"
+	"jan-server/services/llm-api/internal/infrastructure/vllmmetrics"
 )
 
 // ProvideConfig loads and provides the application configuration
@@ -63,6 +86,7 @@ func ProvideKeycloakValidator(cfg *config.Config, log zerolog.Logger) (*auth.Key
 		cfg.Issuer,
 		cfg.Account,
 		cfg.Client,
+		cfg.IntegrationClients,
 		cfg.RefreshJWKSInterval,
 		cfg.AuthClockSkew,
 		log,
@@ -74,7 +98,12 @@ func ProvideMemoryClient(cfg *config.Config, log zerolog.Logger) *memclient.Clie
 	if !cfg.MemoryEnabled {
 		return nil
 	}
-	client := memclient.NewClient(cfg.MemoryBaseURL, cfg.MemoryTimeout)
+	httpClient, err := httpclient.New(cfg.MemoryHTTP)
+	if err != nil {
+		log.Warn().Err(err).Msg("invalid MEMORY_HTTP_* settings, disabling memory integration")
+		return nil
+	}
+	client := memclient.NewClient(cfg.MemoryBaseURL, httpClient, cfg.MemoryBreaker)
 	ctx, cancel := context.WithTimeout(context.Background(), cfg.MemoryTimeout)
 	defer cancel()
 	if err := client.Health(ctx); err != nil {
@@ -86,7 +115,7 @@ func ProvideMemoryClient(cfg *config.Config, log zerolog.Logger) *memclient.Clie
 
 // ProvideDatabase provides a database connection
 func ProvideDatabase(cfg *config.Config, log zerolog.Logger) (*gorm.DB, error) {
-	db, err := database.NewDB(cfg.GetDatabaseWriteDSN())
+	db, err := database.NewDB(cfg.GetDatabaseWriteDSN(), databaseGuardrails(cfg))
 	if err != nil {
 		return nil, err
 	}
@@ -104,9 +133,147 @@ func ProvideDatabase(cfg *config.Config, log zerolog.Logger) (*gorm.DB, error) {
 	return db, nil
 }
 
-// ProvideTransactionDatabase provides a transaction database wrapper
-func ProvideTransactionDatabase(db *gorm.DB) *transaction.Database {
-	return transaction.NewDatabase(db)
+// ProvideLeaderLocker provides the lock the crontab campaigns on so periodic
+// jobs run on one replica. It returns nil when leader election is disabled,
+// in which case every replica runs them.
+func ProvideLeaderLocker(cfg *config.Config, db *gorm.DB, log zerolog.Logger) (leader.Locker, error) {
+	if !cfg.LeaderElectionEnabled {
+		log.Warn().Msg("Leader election disabled; every replica runs periodic jobs")
+		return nil, nil
+	}
+	if cfg.LeaderElectionBackend == "kubernetes" {
+		return leader.NewKubernetesLeaseLocker(leader.KubernetesLeaseConfig{
+			Name:          cfg.LeaderElectionName,
+			Namespace:     cfg.PodNamespace,
+			LeaseDuration: cfg.LeaderElectionLeaseDuration,
+		})
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return leader.NewPostgresLocker(sqlDB, cfg.LeaderElectionName), nil
+}
+
+// ProvideRedisClient connects to the Redis shared by the replicas. It returns
+// nil when REDIS_URL is unset.
+func ProvideRedisClient(cfg *config.Config) (redis.UniversalClient, error) {
+	if cfg.RedisURL == "" {
+		return nil, nil
+	}
+	return streamstate.NewRedisClient(cfg.RedisURL)
+}
+
+// ProvideStreamStore provides the Redis-backed bookkeeping of in-flight streams
+// so any replica can cancel a stream started on another. It returns nil when
+// REDIS_URL is unset, which keeps stream cancellation process-local.
+func ProvideStreamStore(cfg *config.Config, client redis.UniversalClient, log zerolog.Logger) (*streamstate.Store, error) {
+	if client == nil {
+		log.Warn().Msg("REDIS_URL not set; streams can only be cancelled on the replica serving them")
+		return nil, nil
+	}
+	instance := cfg.PodName
+	if instance == "" {
+		instance, _ = os.Hostname()
+	}
+	return streamstate.NewStore(client, instance), nil
+}
+
+// ProvideFeatureFlags provides the per-user rollout of features. Each flag
+// defaults to the setting that enables its feature, so nothing changes until
+// FEATURE_FLAGS, FEATURE_FLAGS_FILE or the FEATURE_FLAGS_REDIS_KEY hash
+// overrides it; the file and hash are reloaded in the background.
+func ProvideFeatureFlags(cfg *config.Config, client redis.UniversalClient, log zerolog.Logger) *featureflags.Client {
+	defaults := featureflags.Static{
+		flagkeys.Memory:                 featureflags.Bool(cfg.MemoryEnabled),
+		flagkeys.PromptMemory:           featureflags.Bool(cfg.PromptOrchestrationEnableMemory),
+		flagkeys.PromptToolInstructions: featureflags.Bool(cfg.PromptOrchestrationEnableTools),
+		flagkeys.PromptCodeAssistant:    featureflags.Bool(cfg.PromptOrchestrationEnableTemplates),
+		flagkeys.PromptChainOfThought:   featureflags.Bool(cfg.PromptOrchestrationEnableTemplates),
+		flagkeys.DeepResearch:           featureflags.Bool(cfg.PromptOrchestrationEnabled),
+		flagkeys.VLLMRouting:            featureflags.Bool(cfg.VLLMRoutingControllerEnabled),
+	}
+	for key, value := range cfg.FeatureFlags {
+		flag, _ := featureflags.ParseFlag(value) // Validated by config.Load
+		defaults[key] = flag
+	}
+
+	sources := []featureflags.Source{defaults}
+	if cfg.FeatureFlagsFile != "" {
+		sources = append(sources, featureflags.File(cfg.FeatureFlagsFile))
+	}
+	if cfg.FeatureFlagsRedisKey != "" {
+		if client == nil {
+			log.Warn().Msg("FEATURE_FLAGS_REDIS_KEY is set but REDIS_URL is not; ignoring the Redis feature flags")
+		} else {
+			sources = append(sources, featureflags.Hash{
+				Key: cfg.FeatureFlagsRedisKey,
+				HGetAll: func(ctx context.Context, key string) (map[string]string, error) {
+					return client.HGetAll(ctx, key).Result()
+				},
+			})
+		}
+	}
+
+	flags := featureflags.NewClient(context.Background(), featureflags.Config{
+		RefreshInterval: cfg.FeatureFlagsRefreshInterval,
+		OnError: func(err error) {
+			log.Warn().Err(err).Msg("failed to load feature flags, keeping the last ones")
+		},
+	}, sources...)
+	if len(sources) > 1 {
+		go flags.Run(context.Background())
+	}
+	return flags
+}
+
+func databaseGuardrails(cfg *config.Config) database.Guardrails {
+	return database.Guardrails{
+		StatementTimeout:   cfg.DBStatementTimeout,
+		SlowQueryThreshold: cfg.DBSlowQueryThreshold,
+	}
+}
+
+// ProvideTransactionDatabase provides a transaction database wrapper, routing
+// read-only repository calls to any configured read replicas. A replica that
+// cannot be reached at startup is skipped so reads fall back to the primary.
+func ProvideTransactionDatabase(cfg *config.Config, db *gorm.DB, log zerolog.Logger) *transaction.Database {
+	var replicas []*gorm.DB
+	for i, dsn := range cfg.GetDatabaseReadReplicaDSNs() {
+		replica, err := database.NewReplicaDB(dsn, fmt.Sprintf("llm_api_replica_%d", i+1), databaseGuardrails(cfg))
+		if err != nil {
+			log.Warn().Err(err).Int("replica", i+1).Msg("read replica unavailable, skipping")
+			continue
+		}
+		replicas = append(replicas, replica)
+	}
+	if len(replicas) > 0 {
+		log.Info().Int("replicas", len(replicas)).Msg("routing read-only queries to read replicas")
+	}
+	return transaction.NewDatabase(db, replicas...)
+}
+
+// ProvideEncryptionService provides the encryption at rest of conversation
+// content. A configured KMS is kept even while encryption is disabled, so
+// content sealed earlier stays readable.
+func ProvideEncryptionService(cfg *config.Config, db *transaction.Database, log zerolog.Logger) (*encryption.Service, error) {
+	wrapper, err := kms.NewKeyWrapper(kms.Config{
+		Provider:     cfg.EncryptionKMSProvider,
+		MasterKey:    cfg.EncryptionMasterKey,
+		VaultAddr:    cfg.EncryptionVaultAddr,
+		VaultToken:   cfg.EncryptionVaultToken,
+		VaultKeyName: cfg.EncryptionVaultKeyName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encryption at rest: %w", err)
+	}
+	if cfg.EncryptionAtRestEnabled && wrapper == nil {
+		return nil, errors.New("ENCRYPTION_AT_REST_ENABLED requires ENCRYPTION_MASTER_KEY or ENCRYPTION_VAULT_ADDR")
+	}
+	if cfg.EncryptionAtRestEnabled {
+		log.Info().Str("kms", wrapper.Name()).Msg("encrypting conversation content at rest")
+	}
+	return encryption.NewService(cfg.EncryptionAtRestEnabled, wrapper, db)
 }
 
 // ProvideMediaClient wires the media client for uploading images.
@@ -114,11 +281,154 @@ func ProvideMediaClient(cfg *config.Config, log zerolog.Logger) *mediaclient.Cli
 	return mediaclient.NewClient(cfg, log)
 }
 
+// ProvideTTSClient wires the text-to-speech client for spoken replies. It
+// returns nil unless TTS_URL is set.
+func ProvideTTSClient(cfg *config.Config, log zerolog.Logger) *tts.Client {
+	client := tts.NewClient(cfg, log)
+	if client != nil {
+		log.Info().Str("url", cfg.TTSURL).Str("model", cfg.TTSModel).Msg("audio output enabled")
+	}
+	return client
+}
+
+// ProvideLiveKitClient wires the LiveKit client that streams completions to
+// realtime rooms. It returns nil unless LIVEKIT_WS_URL and the API key pair
+// are set.
+func ProvideLiveKitClient(cfg *config.Config, log zerolog.Logger) *livekit.Client {
+	client := livekit.NewClient(cfg, log)
+	if client != nil {
+		log.Info().Str("url", cfg.LiveKitWsURL).Msg("realtime room streaming enabled")
+	}
+	return client
+}
+
+// ProvideCaptchaVerifier wires the CAPTCHA verifier of the guest tier. It
+// returns nil unless CAPTCHA_SECRET is set.
+func ProvideCaptchaVerifier(cfg *config.Config, log zerolog.Logger) *captcha.Verifier {
+	return captcha.NewVerifier(cfg, log)
+}
+
+// ProvideGuestQuota wires the guest tier limits. Counts are kept in Redis when
+// REDIS_URL is set and per replica otherwise.
+func ProvideGuestQuota(cfg *config.Config, client redis.UniversalClient, verifier *captcha.Verifier, log zerolog.Logger) *guestquota.Service {
+	var counter guestquota.Counter
+	if client != nil {
+		counter = quotacounter.NewRedisCounter(client)
+	} else {
+		counter = quotacounter.NewMemoryCounter()
+	}
+	// A nil *Verifier must not become a non-nil interface
+	var captchaVerifier guestquota.CaptchaVerifier
+	if verifier != nil {
+		captchaVerifier = verifier
+	}
+
+	quota := guestquota.NewService(guestquota.Config{
+		DailyMessages:   cfg.GuestDailyMessages,
+		IPDailyMessages: cfg.GuestIPDailyMessages,
+		Models:          cfg.GuestModels,
+		CaptchaAfter:    cfg.GuestCaptchaAfter,
+	}, counter, captchaVerifier)
+	if cfg.GuestCaptchaAfter > 0 && verifier == nil {
+		log.Warn().Msg("GUEST_CAPTCHA_AFTER is set but CAPTCHA_SECRET is not; guests are never asked for a CAPTCHA")
+	}
+	if quota.Enabled() {
+		log.Info().
+			Int("daily_messages", cfg.GuestDailyMessages).
+			Int("ip_daily_messages", cfg.GuestIPDailyMessages).
+			Strs("models", cfg.GuestModels).
+			Int("captcha_after", cfg.GuestCaptchaAfter).
+			Bool("shared", client != nil).
+			Msg("guest tier limits enabled")
+	}
+	return quota
+}
+
+// ProvideMaintenance provides the maintenance mode switch. It is shared by
+// every replica and service through Redis when REDIS_URL is set and applies
+// to this replica only otherwise.
+func ProvideMaintenance(cfg *config.Config, client redis.UniversalClient, log zerolog.Logger) *maintenance.Service {
+	var store maintenance.Store
+	if client != nil {
+		store = maintenance.NewRedisStore(client)
+	} else {
+		log.Warn().Msg("REDIS_URL not set; maintenance mode only applies to the replica it is switched on")
+		store = maintenance.NewMemoryStore()
+	}
+	service := maintenance.NewService(store, cfg.MaintenanceRefreshInterval, log)
+	if client != nil {
+		go service.Run(context.Background())
+	}
+	return service
+}
+
+// ProvideLinkBlocklist loads the domains whose links are never rendered. It
+// returns nil when none are configured.
+func ProvideLinkBlocklist(cfg *config.Config, log zerolog.Logger) (linkpolicy.Blocklist, error) {
+	blocklist, err := linkcheck.NewDomainBlocklist(cfg.LinkBlocklistDomains, cfg.LinkBlocklistFile)
+	if err != nil {
+		return nil, err
+	}
+	if blocklist == nil {
+		return nil, nil
+	}
+	log.Info().Int("domains", blocklist.Len()).Msg("link blocklist loaded")
+	return blocklist, nil
+}
+
+// ProvideRedirectResolver provides the lookup of URL shortener destinations.
+// It returns nil unless LINK_RESOLVE_REDIRECTS is set.
+func ProvideRedirectResolver(cfg *config.Config) (linkpolicy.RedirectResolver, error) {
+	if !cfg.LinkResolveRedirects {
+		return nil, nil
+	}
+	httpCfg := httpclient.DefaultConfig()
+	httpCfg.Timeout = cfg.LinkResolveTimeout
+	httpClient, err := httpclient.New(httpCfg)
+	if err != nil {
+		return nil, err
+	}
+	resolver := linkcheck.NewRedirectResolver(httpClient, cfg.LinkRedirectHosts, cfg.LinkResolveMaxHops)
+	if resolver == nil {
+		return nil, nil
+	}
+	return resolver, nil
+}
+
 // ProvideAdminAuditLogger supplies audit logging helper.
 func ProvideAdminAuditLogger(db *gorm.DB, logger zerolog.Logger) *audit.AdminAuditLogger {
 	return audit.NewAdminAuditLogger(db, logger)
 }
 
+// ProvideReadinessChecker registers the dependency probes served by /readyz.
+func ProvideReadinessChecker(
+	cfg *config.Config,
+	db *gorm.DB,
+	keycloakValidator *auth.KeycloakValidator,
+	memoryClient *memclient.Client,
+) *health.Checker {
+	checker := health.NewChecker(cfg.ServiceName, 3*time.Second)
+	checker.Register("database", true, health.DatabaseCheck(db))
+	checker.Register("keycloak_jwks", true, func(ctx context.Context) error {
+		if !keycloakValidator.Ready() {
+			return errors.New("jwks not loaded")
+		}
+		return nil
+	})
+	if memoryClient != nil {
+		checker.Register("memory_tools", false, memoryClient.Health)
+	}
+
+	probeClient := &http.Client{Timeout: 3 * time.Second}
+	for _, entry := range cfg.ProviderBootstrapEntries() {
+		if !entry.Active || entry.BaseURL == "" {
+			continue
+		}
+		checker.Register("provider:"+entry.Name, false, health.HTTPCheck(probeClient, entry.BaseURL))
+	}
+	return checker
+}
+
 // Infrastructure holds all infrastructure dependencies
 type Infrastructure struct {
 	DB                *gorm.DB
@@ -147,11 +457,15 @@ var InfrastructureProvider = wire.NewSet(
 	// Database
 	ProvideDatabase,
 	ProvideTransactionDatabase,
+	ProvideEncryptionService,
 
 	// Repositories
 	repository.RepositoryProvider,
 
-	// Provider registry
+	// Provider registry, routing by vLLM load and region when enabled
+	vllmmetrics.NewMonitor,
+	regionprobe.NewProber,
+	wire.Bind(new(region.LatencySource), new(*regionprobe.Prober)),
 	inference.NewInferenceProvider,
 
 	// Image generation service
@@ -161,6 +475,10 @@ var InfrastructureProvider = wire.NewSet(
 
 	// Media client for uploading images
 	ProvideMediaClient,
+	ProvideTTSClient,
+	ProvideLiveKitClient,
+	ProvideCaptchaVerifier,
+	ProvideGuestQuota,
 
 	// Logger
 	logger.GetLogger,
@@ -175,9 +493,27 @@ var InfrastructureProvider = wire.NewSet(
 	// Memory
 	ProvideMemoryClient,
 
-	// Crontab for model sync
+	// Per-user rollout of features
+	ProvideFeatureFlags,
+
+	// Link policy lookups
+	ProvideLinkBlocklist,
+	ProvideRedirectResolver,
+
+	// Shared stream bookkeeping
+	ProvideRedisClient,
+	ProvideStreamStore,
+
+	// Maintenance mode
+	ProvideMaintenance,
+
+	// Leader election and crontab for model sync
+	ProvideLeaderLocker,
 	crontab.NewCrontab,
 
+	// Readiness probes
+	ProvideReadinessChecker,
+
 	// Infrastructure struct
 	NewInfrastructure,
 
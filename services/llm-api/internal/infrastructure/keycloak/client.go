@@ -197,6 +197,17 @@ func (c *Client) UpgradeUser(ctx context.Context, userID string, payload Upgrade
 	return nil
 }
 
+// ServiceAuthorization returns an Authorization header carrying a token of the
+// backend client's service account, for calls to other services made outside
+// a user request.
+func (c *Client) ServiceAuthorization(ctx context.Context) (string, error) {
+	token, err := c.serviceAccountToken(ctx)
+	if err != nil {
+		return "", err
+	}
+	return "Bearer " + token.AccessToken, nil
+}
+
 func (c *Client) serviceAccountToken(ctx context.Context) (*TokenSet, error) {
 	values := url.Values{}
 	values.Set("grant_type", "client_credentials")
@@ -556,6 +567,10 @@ func (c *Client) realmTokenEndpoint(realm string) string {
 	return c.baseURL + "/realms/" + url.PathEscape(realm) + "/protocol/openid-connect/token"
 }
 
+func (c *Client) deviceAuthorizationEndpoint() string {
+	return c.baseURL + "/realms/" + url.PathEscape(c.realm) + "/protocol/openid-connect/auth/device"
+}
+
 func (c *Client) adminTokenEndpoint() string {
 	return c.baseURL + "/realms/master/protocol/openid-connect/token"
 }
@@ -595,6 +610,154 @@ func (c *Client) RefreshToken(ctx context.Context, refreshToken string) (*TokenS
 	return &token, nil
 }
 
+// TokenExchangeRequest describes an RFC 8693 exchange made with the credentials of
+// a third-party client.
+type TokenExchangeRequest struct {
+	ClientID     string
+	ClientSecret string
+	SubjectToken string // The user's access token
+	Scope        string // Space-delimited scopes requested for the new token
+	Audience     string // Optional target client of the new token
+}
+
+// TokenError is an OAuth error returned by the Keycloak token endpoint.
+type TokenError struct {
+	StatusCode  int
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *TokenError) Error() string {
+	if e.Description != "" {
+		return fmt.Sprintf("%s: %s", e.Code, e.Description)
+	}
+	return e.Code
+}
+
+// ExchangeToken trades a user's access token for one issued to the requesting client,
+// carrying the requested scopes. Keycloak rejections are returned as *TokenError.
+func (c *Client) ExchangeToken(ctx context.Context, exchange TokenExchangeRequest) (*TokenSet, error) {
+	values := url.Values{}
+	values.Set("grant_type", "urn:ietf:params:oauth:grant-type:token-exchange")
+	values.Set("client_id", exchange.ClientID)
+	if exchange.ClientSecret != "" {
+		values.Set("client_secret", exchange.ClientSecret)
+	}
+	values.Set("subject_token", exchange.SubjectToken)
+	values.Set("subject_token_type", "urn:ietf:params:oauth:token-type:access_token")
+	values.Set("requested_token_type", "urn:ietf:params:oauth:token-type:access_token")
+	if exchange.Scope != "" {
+		values.Set("scope", exchange.Scope)
+	}
+	if exchange.Audience != "" {
+		values.Set("audience", exchange.Audience)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenEndpoint(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, readTokenError(resp, "token exchange failed")
+	}
+
+	var tokens TokenSet
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, err
+	}
+	return &tokens, nil
+}
+
+// DeviceAuthorization is Keycloak's answer to a device authorization request (RFC 8628).
+type DeviceAuthorization struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// StartDeviceAuthorization begins a device authorization grant for the public client,
+// for devices that cannot open a browser themselves.
+func (c *Client) StartDeviceAuthorization(ctx context.Context, scope string) (*DeviceAuthorization, error) {
+	values := url.Values{}
+	values.Set("client_id", c.clientID)
+	values.Set("scope", strings.TrimSpace("openid "+scope))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.deviceAuthorizationEndpoint(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, readTokenError(resp, "device authorization failed")
+	}
+
+	var authorization DeviceAuthorization
+	if err := json.NewDecoder(resp.Body).Decode(&authorization); err != nil {
+		return nil, err
+	}
+	return &authorization, nil
+}
+
+// PollDeviceToken redeems a device code once the user approved it. Until then Keycloak
+// answers with a *TokenError such as authorization_pending or slow_down.
+func (c *Client) PollDeviceToken(ctx context.Context, deviceCode string) (*TokenSet, error) {
+	values := url.Values{}
+	values.Set("grant_type", "urn:ietf:params:oauth:grant-type:device_code")
+	values.Set("client_id", c.clientID)
+	values.Set("device_code", deviceCode)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.tokenEndpoint(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 300 {
+		return nil, readTokenError(resp, "device token request failed")
+	}
+
+	var tokens TokenSet
+	if err := json.NewDecoder(resp.Body).Decode(&tokens); err != nil {
+		return nil, err
+	}
+	return &tokens, nil
+}
+
+// readTokenError returns the OAuth error of a failed token endpoint response as a
+// *TokenError, or a plain error when the body is not one
+func readTokenError(resp *http.Response, message string) error {
+	payload, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+	tokenErr := &TokenError{StatusCode: resp.StatusCode}
+	if err := json.Unmarshal(payload, tokenErr); err != nil || tokenErr.Code == "" {
+		return fmt.Errorf("%s: %s", message, strings.TrimSpace(string(payload)))
+	}
+	return tokenErr
+}
+
 // LogoutUser logs out a user from Keycloak by calling the logout endpoint
 // This will invalidate the user's session on the Keycloak server
 func (c *Client) LogoutUser(ctx context.Context, refreshToken string) error {
@@ -0,0 +1,99 @@
+// Package linkcheck provides the domain blocklist and redirect resolver used
+// by the link policy.
+package linkcheck
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+)
+
+// DomainBlocklist blocks a set of domains and all of their subdomains.
+type DomainBlocklist struct {
+	domains map[string]struct{}
+}
+
+// NewDomainBlocklist builds a blocklist from domains and, when path is set,
+// the file at path. The file holds one domain per line; hosts-file lines such
+// as "0.0.0.0 example.com" are accepted and lines starting with # are comments.
+// It returns nil when no domain is configured.
+func NewDomainBlocklist(domains []string, path string) (*DomainBlocklist, error) {
+	list := &DomainBlocklist{domains: make(map[string]struct{})}
+	for _, domain := range domains {
+		list.add(domain)
+	}
+	if path != "" {
+		if err := list.load(path); err != nil {
+			return nil, err
+		}
+	}
+	if len(list.domains) == 0 {
+		return nil, nil
+	}
+	return list, nil
+}
+
+// Len returns the number of blocked domains.
+func (b *DomainBlocklist) Len() int {
+	return len(b.domains)
+}
+
+// Blocked implements linkpolicy.Blocklist.
+func (b *DomainBlocklist) Blocked(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	for host != "" {
+		if _, ok := b.domains[host]; ok {
+			return true
+		}
+		// IP addresses have no parent domain
+		if net.ParseIP(host) != nil {
+			return false
+		}
+		dot := strings.IndexByte(host, '.')
+		if dot < 0 {
+			return false
+		}
+		host = host[dot+1:]
+	}
+	return false
+}
+
+func (b *DomainBlocklist) add(domain string) {
+	domain = strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+	domain = strings.TrimPrefix(domain, "*.")
+	if domain != "" {
+		b.domains[domain] = struct{}{}
+	}
+}
+
+func (b *DomainBlocklist) load(path string) error {
+	file, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("open link blocklist: %w", err)
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '#'); i >= 0 {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		switch {
+		case len(fields) == 0:
+		case len(fields) >= 2 && net.ParseIP(fields[0]) != nil:
+			for _, domain := range fields[1:] {
+				b.add(domain)
+			}
+		default:
+			b.add(fields[0])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("read link blocklist: %w", err)
+	}
+	return nil
+}
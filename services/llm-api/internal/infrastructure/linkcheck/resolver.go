@@ -0,0 +1,84 @@
+package linkcheck
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// RedirectResolver follows the redirects of URL shorteners with HEAD requests,
+// without fetching any body. Only links on the configured hosts are looked up,
+// so ordinary citations never cause an outbound request.
+type RedirectResolver struct {
+	httpClient *http.Client
+	hosts      map[string]struct{}
+	maxHops    int
+}
+
+// NewRedirectResolver creates a resolver for the shortener hosts. It returns
+// nil when no host is configured.
+func NewRedirectResolver(httpClient *http.Client, hosts []string, maxHops int) *RedirectResolver {
+	set := make(map[string]struct{}, len(hosts))
+	for _, host := range hosts {
+		if host = strings.ToLower(strings.TrimSpace(host)); host != "" {
+			set[host] = struct{}{}
+		}
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	// Each hop is inspected so lookups stop at the first host that is not a shortener
+	client := *httpClient
+	client.CheckRedirect = func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}
+	return &RedirectResolver{httpClient: &client, hosts: set, maxHops: maxHops}
+}
+
+// Resolve implements linkpolicy.RedirectResolver.
+func (r *RedirectResolver) Resolve(ctx context.Context, rawURL string) (string, error) {
+	current, err := url.Parse(rawURL)
+	if err != nil {
+		return "", err
+	}
+	for hop := 0; hop < r.maxHops && r.isShortener(current.Hostname()); hop++ {
+		next, err := r.next(ctx, current)
+		if err != nil {
+			return "", err
+		}
+		if next == nil {
+			break
+		}
+		current = next
+	}
+	return current.String(), nil
+}
+
+func (r *RedirectResolver) isShortener(host string) bool {
+	_, ok := r.hosts[strings.ToLower(host)]
+	return ok
+}
+
+// next returns the redirect target of u, or nil when u does not redirect.
+func (r *RedirectResolver) next(ctx context.Context, u *url.URL) (*url.URL, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := r.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("resolve %s: %w", u.Hostname(), err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 300 || resp.StatusCode >= 400 {
+		return nil, nil
+	}
+	location := resp.Header.Get("Location")
+	if location == "" {
+		return nil, nil
+	}
+	return u.Parse(location)
+}
@@ -0,0 +1,116 @@
+// Package livekit streams chat completions to the data channel of the LiveKit
+// rooms of realtime sessions.
+package livekit
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/llm-api/internal/config"
+)
+
+// tokenTTL is the lifetime of the admin tokens signed for each API call
+const tokenTTL = 5 * time.Minute
+
+// Client calls the LiveKit server API with tokens signed by the API key pair.
+type Client struct {
+	rooms     *clients.RoomServiceClient
+	apiKey    string
+	apiSecret []byte
+	log       zerolog.Logger
+}
+
+// NewClient creates a new LiveKit client. It returns nil unless
+// LIVEKIT_WS_URL, LIVEKIT_API_KEY and LIVEKIT_API_SECRET are set, which
+// disables streaming to rooms.
+func NewClient(cfg *config.Config, log zerolog.Logger) *Client {
+	if cfg.LiveKitWsURL == "" || cfg.LiveKitAPIKey == "" || cfg.LiveKitAPISecret == "" {
+		return nil
+	}
+
+	httpClient, err := httpclient.New(cfg.LiveKitHTTP)
+	if err != nil {
+		log.Warn().Err(err).Msg("[LiveKitClient] Invalid LIVEKIT_HTTP_* settings, room streaming disabled")
+		return nil
+	}
+
+	return &Client{
+		rooms:     clients.NewRoomServiceClient(apiURL(cfg.LiveKitWsURL), httpClient, cfg.LiveKitBreaker),
+		apiKey:    cfg.LiveKitAPIKey,
+		apiSecret: []byte(cfg.LiveKitAPISecret),
+		log:       log.With().Str("component", "livekit-client").Logger(),
+	}
+}
+
+// IsParticipant reports whether one of identities is connected to room.
+func (c *Client) IsParticipant(ctx context.Context, room string, identities ...string) (bool, error) {
+	token, err := c.adminToken(room)
+	if err != nil {
+		return false, err
+	}
+	participants, err := c.rooms.ListParticipants(ctx, token, room)
+	if err != nil {
+		return false, fmt.Errorf("list participants of %s: %w", room, err)
+	}
+	for _, participant := range participants {
+		if participant.Identity != "" && slices.Contains(identities, participant.Identity) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// Publish sends payload reliably to every participant of room on topic.
+func (c *Client) Publish(ctx context.Context, room, topic string, payload []byte) error {
+	token, err := c.adminToken(room)
+	if err != nil {
+		return err
+	}
+	return c.rooms.SendData(ctx, token, clients.SendDataRequest{
+		Room:  room,
+		Data:  payload,
+		Kind:  "RELIABLE",
+		Topic: topic,
+	})
+}
+
+// adminToken signs a short-lived token granting roomAdmin on room, the grant
+// the RoomService API requires.
+func (c *Client) adminToken(room string) (string, error) {
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": c.apiKey,
+		"nbf": now.Unix(),
+		"exp": now.Add(tokenTTL).Unix(),
+		"video": map[string]any{
+			"room":      room,
+			"roomAdmin": true,
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(c.apiSecret)
+	if err != nil {
+		return "", fmt.Errorf("sign livekit token: %w", err)
+	}
+	return token, nil
+}
+
+// apiURL turns the ws(s):// URL clients connect to into the http(s):// URL of
+// the server API.
+func apiURL(wsURL string) string {
+	wsURL = strings.TrimSpace(wsURL)
+	switch {
+	case strings.HasPrefix(wsURL, "wss://"):
+		return "https://" + strings.TrimPrefix(wsURL, "wss://")
+	case strings.HasPrefix(wsURL, "ws://"):
+		return "http://" + strings.TrimPrefix(wsURL, "ws://")
+	}
+	return wsURL
+}
@@ -0,0 +1,159 @@
+package livekit
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"jan-server/services/llm-api/internal/utils/idgen"
+)
+
+// DataTopic is the data channel topic completion events are published on
+const DataTopic = "jan.chat.completion"
+
+const (
+	// flushInterval batches deltas so a fast model does not send a packet per token
+	flushInterval = 100 * time.Millisecond
+	// publishTimeout bounds each packet; events are still sent after the request ends
+	publishTimeout = 5 * time.Second
+	// maxDeltaBytes keeps each packet well under LiveKit's reliable data limit
+	maxDeltaBytes = 8 << 10
+)
+
+// Event types published on DataTopic
+const (
+	EventTextDelta = "response.text.delta"
+	EventDone      = "response.done"
+)
+
+// Statuses of the response.done event
+const (
+	StatusCompleted = "completed"
+	StatusCancelled = "cancelled"
+	StatusFailed    = "failed"
+)
+
+// Event is a chat completion event published to a room. Participants join
+// the deltas of a response_id in sequence order.
+type Event struct {
+	Type           string `json:"type"`
+	ResponseID     string `json:"response_id"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	ItemID         string `json:"item_id,omitempty"`
+	Sequence       int    `json:"sequence"`
+	Delta          string `json:"delta,omitempty"`
+	Status         string `json:"status,omitempty"`
+}
+
+// RoomStream publishes the content deltas of one completion to a room. Deltas
+// are batched and sent in order from a background goroutine, so a slow
+// LiveKit server never holds up the completion's own stream.
+type RoomStream struct {
+	client *Client
+	room   string
+	event  Event // Identifies the response in every event
+
+	mu      sync.Mutex
+	pending strings.Builder
+	status  string
+
+	closed    chan struct{}
+	closeOnce sync.Once
+	sequence  int
+	failed    bool
+}
+
+// StartRoomStream starts streaming a completion to room. conversationID and
+// itemID, when set, tell participants where the reply is stored. Close must
+// be called once the completion ends.
+func (c *Client) StartRoomStream(room, conversationID, itemID string) *RoomStream {
+	responseID, _ := idgen.GenerateSecureID("resp", 16)
+	s := &RoomStream{
+		client: c,
+		room:   room,
+		event:  Event{ResponseID: responseID, ConversationID: conversationID, ItemID: itemID},
+		closed: make(chan struct{}),
+	}
+	go s.run()
+	return s
+}
+
+// WriteDelta queues a content delta for the room.
+func (s *RoomStream) WriteDelta(content string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.status == "" {
+		s.pending.WriteString(content)
+	}
+}
+
+// Close publishes the queued deltas and a response.done event with status in
+// the background. Later deltas are dropped.
+func (s *RoomStream) Close(status string) {
+	s.mu.Lock()
+	if s.status == "" {
+		s.status = status
+	}
+	s.mu.Unlock()
+	s.closeOnce.Do(func() { close(s.closed) })
+}
+
+func (s *RoomStream) run() {
+	ticker := time.NewTicker(flushInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			s.flush()
+		case <-s.closed:
+			s.flush()
+			s.mu.Lock()
+			status := s.status
+			s.mu.Unlock()
+			s.publish(EventDone, "", status)
+			return
+		}
+	}
+}
+
+// flush publishes the queued deltas, split into packets of at most maxDeltaBytes
+func (s *RoomStream) flush() {
+	s.mu.Lock()
+	delta := s.pending.String()
+	s.pending.Reset()
+	s.mu.Unlock()
+
+	for delta != "" {
+		cut := min(len(delta), maxDeltaBytes)
+		for cut < len(delta) && !utf8.RuneStart(delta[cut]) {
+			cut--
+		}
+		s.publish(EventTextDelta, delta[:cut], "")
+		delta = delta[cut:]
+	}
+}
+
+func (s *RoomStream) publish(eventType, delta, status string) {
+	s.sequence++
+	event := s.event
+	event.Type = eventType
+	event.Sequence = s.sequence
+	event.Delta = delta
+	event.Status = status
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), publishTimeout)
+	defer cancel()
+	if err := s.client.Publish(ctx, s.room, DataTopic, payload); err != nil && !s.failed {
+		// Logged once per response; the completion itself carries on
+		s.failed = true
+		s.client.log.Warn().Err(err).Str("room", s.room).Str("response_id", s.event.ResponseID).Msg("[LiveKitClient] Failed to publish completion to room")
+	}
+}
@@ -1,17 +1,22 @@
 package logger
 
 import (
+	"context"
 	"errors"
+	"io"
 	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/logging"
 	"github.com/rs/zerolog"
 )
 
 var (
 	globalLogger zerolog.Logger
+	globalWriter io.Writer
+	exporter     *logging.OTLPExporter
 	once         sync.Once
 )
 
@@ -19,11 +24,11 @@ var (
 func GetLogger() zerolog.Logger {
 	once.Do(func() {
 		// Default to console output with info level
-		consoleWriter := zerolog.ConsoleWriter{
+		globalWriter = zerolog.ConsoleWriter{
 			Out:        os.Stdout,
 			TimeFormat: time.RFC3339,
 		}
-		globalLogger = zerolog.New(consoleWriter).With().Timestamp().Logger().Level(zerolog.InfoLevel)
+		globalLogger = newLogger(globalWriter, zerolog.InfoLevel)
 		zerolog.SetGlobalLevel(zerolog.InfoLevel)
 	})
 	return globalLogger
@@ -36,24 +41,51 @@ func New(level, format string) (zerolog.Logger, error) {
 		return zerolog.Logger{}, err
 	}
 
-	var writer zerolog.Logger
+	var writer io.Writer
 	switch strings.ToLower(format) {
 	case "json":
-		writer = zerolog.New(os.Stdout).With().Timestamp().Logger()
+		writer = os.Stdout
 	case "console":
-		consoleWriter := zerolog.ConsoleWriter{
+		writer = zerolog.ConsoleWriter{
 			Out:        os.Stdout,
 			TimeFormat: time.RFC3339,
 		}
-		writer = zerolog.New(consoleWriter).With().Timestamp().Logger()
 	default:
 		return zerolog.Logger{}, errors.New("unsupported log format")
 	}
 
 	zerolog.SetGlobalLevel(lvl)
 
-	// Update global logger
-	globalLogger = writer.Level(lvl)
+	// Update global logger; GetLogger must not replace it with the default
+	once.Do(func() {})
+	globalWriter = writer
+	if exporter != nil {
+		writer = zerolog.MultiLevelWriter(writer, exporter)
+	}
+	globalLogger = newLogger(writer, lvl)
 
 	return globalLogger, nil
 }
+
+// EnableOTLPExport exports the events of the global logger to an OTLP/HTTP
+// collector in addition to stdout. Call Shutdown before exiting to flush them.
+func EnableOTLPExport(cfg logging.OTLPConfig) zerolog.Logger {
+	GetLogger()
+	if exporter == nil {
+		exporter = logging.NewOTLPExporter(cfg)
+	}
+	globalLogger = newLogger(zerolog.MultiLevelWriter(globalWriter, exporter), globalLogger.GetLevel())
+	return globalLogger
+}
+
+// Shutdown flushes the events queued for OTLP export.
+func Shutdown(ctx context.Context) error {
+	if exporter == nil {
+		return nil
+	}
+	return exporter.Shutdown(ctx)
+}
+
+func newLogger(writer io.Writer, level zerolog.Level) zerolog.Logger {
+	return zerolog.New(writer).With().Timestamp().Logger().Level(level).Hook(logging.TraceHook{})
+}
@@ -2,11 +2,11 @@ package mediaclient
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"time"
 
-	"github.com/imroc/req/v3"
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/llm-api/internal/config"
@@ -14,32 +14,20 @@ import (
 
 // Client handles media uploads to the media-api service.
 type Client struct {
-	cfg    *config.Config
-	client *req.Client
-	log    zerolog.Logger
+	media *clients.MediaClient
+	log   zerolog.Logger
 }
 
-// Source describes the media source.
-type Source struct {
-	Type    string `json:"type"`
-	DataURL string `json:"data_url,omitempty"`
-	URL     string `json:"url,omitempty"`
-}
-
-// IngestRequest is the request format for media ingestion.
-type IngestRequest struct {
-	Source   Source `json:"source"`
-	Filename string `json:"filename,omitempty"`
-	UserID   string `json:"user_id,omitempty"`
-}
-
-// IngestResponse is the response from media ingestion.
-type IngestResponse struct {
-	Mime    string `json:"mime"`  // MIME type
-	Bytes   int64  `json:"bytes"` // Size in bytes
-	Deduped bool   `json:"deduped"`
-	URL     string `json:"url"` // Direct media URL
-}
+type (
+	// Source describes the media source.
+	Source = clients.MediaSource
+	// IngestRequest is the request format for media ingestion.
+	IngestRequest = clients.MediaIngestRequest
+	// IngestResponse is the response from media ingestion.
+	IngestResponse = clients.MediaObject
+	// ResolvedMedia is a stored object with its download URL.
+	ResolvedMedia = clients.ResolvedMedia
+)
 
 // NewClient creates a new media client.
 func NewClient(cfg *config.Config, log zerolog.Logger) *Client {
@@ -48,14 +36,15 @@ func NewClient(cfg *config.Config, log zerolog.Logger) *Client {
 		return nil
 	}
 
-	client := req.C().
-		SetTimeout(30 * time.Second).
-		SetCommonContentType("application/json")
+	httpClient, err := httpclient.New(cfg.MediaHTTP)
+	if err != nil {
+		log.Warn().Err(err).Msg("[MediaClient] Invalid MEDIA_HTTP_* settings, media uploads disabled")
+		return nil
+	}
 
 	return &Client{
-		cfg:    cfg,
-		client: client,
-		log:    log.With().Str("component", "media-client").Logger(),
+		media: clients.NewMediaClient(cfg.MediaIngestURL, httpClient, cfg.MediaBreaker),
+		log:   log.With().Str("component", "media-client").Logger(),
 	}
 }
 
@@ -70,12 +59,10 @@ func (c *Client) UploadBase64Image(ctx context.Context, base64Data string, mimeT
 	if mimeType == "" {
 		mimeType = "image/png"
 	}
-	dataURL := fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data)
-
 	req := IngestRequest{
 		Source: Source{
 			Type:    "data_url",
-			DataURL: dataURL,
+			DataURL: fmt.Sprintf("data:%s;base64,%s", mimeType, base64Data),
 		},
 		Filename: fmt.Sprintf("generated_%d.png", time.Now().UnixNano()),
 	}
@@ -85,34 +72,85 @@ func (c *Client) UploadBase64Image(ctx context.Context, base64Data string, mimeT
 		Int("data_length", len(base64Data)).
 		Msg("[MediaClient] Uploading image to media-api")
 
-	resp, err := c.client.R().
-		SetContext(ctx).
-		SetHeader("Authorization", authHeader).
-		SetBody(req).
-		Post(c.cfg.MediaIngestURL)
-
+	result, err := c.media.Ingest(ctx, authHeader, req)
 	if err != nil {
 		c.log.Error().Err(err).Msg("[MediaClient] Failed to upload image")
 		return nil, fmt.Errorf("media upload failed: %w", err)
 	}
 
-	if resp.StatusCode >= 400 {
-		c.log.Error().
-			Int("status", resp.StatusCode).
-			Str("body", resp.String()).
-			Msg("[MediaClient] Media API returned error")
-		return nil, fmt.Errorf("media API returned status %d: %s", resp.StatusCode, resp.String())
+	c.log.Debug().
+		Str("media_url", result.URL).
+		Msg("[MediaClient] Image uploaded successfully")
+
+	return result, nil
+}
+
+// UploadFile uploads raw file bytes of any MIME type accepted by media-api.
+func (c *Client) UploadFile(ctx context.Context, data []byte, mimeType string, filename string, authHeader string) (*IngestResponse, error) {
+	if c == nil {
+		return nil, fmt.Errorf("media client not configured")
 	}
 
-	var result IngestResponse
-	if err := json.Unmarshal(resp.Bytes(), &result); err != nil {
-		c.log.Error().Err(err).Str("body", resp.String()).Msg("[MediaClient] Failed to parse response")
-		return nil, fmt.Errorf("failed to parse media response: %w", err)
+	c.log.Debug().
+		Str("mime_type", mimeType).
+		Str("filename", filename).
+		Int("bytes", len(data)).
+		Msg("[MediaClient] Uploading file to media-api")
+
+	result, err := c.media.Upload(ctx, authHeader, data, mimeType, filename)
+	if err != nil {
+		c.log.Error().Err(err).Msg("[MediaClient] Failed to upload file")
+		return nil, fmt.Errorf("media upload failed: %w", err)
 	}
 
 	c.log.Debug().
-		Str("media_url", result.URL).
-		Msg("[MediaClient] Image uploaded successfully")
+		Str("media_id", result.ID).
+		Msg("[MediaClient] File uploaded successfully")
+
+	return result, nil
+}
+
+// maxResolveIDs is the most IDs media-api resolves per request.
+const maxResolveIDs = 100
+
+// Resolve returns the stored objects with the given media IDs, keyed by ID.
+// On error it returns the objects resolved before the failing request.
+func (c *Client) Resolve(ctx context.Context, ids []string, authHeader string) (map[string]ResolvedMedia, error) {
+	if c == nil {
+		return nil, fmt.Errorf("media client not configured")
+	}
+	if len(ids) == 0 {
+		return map[string]ResolvedMedia{}, nil
+	}
+
+	resolved := make(map[string]ResolvedMedia, len(ids))
+	for start := 0; start < len(ids); start += maxResolveIDs {
+		end := min(start+maxResolveIDs, len(ids))
+		objects, err := c.media.Resolve(ctx, authHeader, ids[start:end])
+		if err != nil {
+			c.log.Error().Err(err).Int("ids", len(ids)).Msg("[MediaClient] Failed to resolve media")
+			return resolved, fmt.Errorf("media resolve failed: %w", err)
+		}
+		for _, obj := range objects {
+			resolved[obj.ID] = obj
+		}
+	}
+	return resolved, nil
+}
+
+// maxDownloadBytes bounds objects read back from media-api.
+const maxDownloadBytes = 64 << 20
 
-	return &result, nil
+// Download returns the bytes of a stored object.
+func (c *Client) Download(ctx context.Context, id string, authHeader string) ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("media client not configured")
+	}
+
+	data, err := c.media.Download(ctx, authHeader, id, maxDownloadBytes)
+	if err != nil {
+		c.log.Error().Err(err).Str("media_id", id).Msg("[MediaClient] Failed to download media")
+		return nil, fmt.Errorf("media download failed: %w", err)
+	}
+	return data, nil
 }
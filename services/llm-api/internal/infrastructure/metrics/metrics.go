@@ -103,7 +103,31 @@ var (
 			Subsystem: "llm_api",
 			Name:      "first_token_seconds",
 			Help:      "Time to first token for streaming requests",
-			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5},
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+		},
+		[]string{"model", "provider"},
+	)
+
+	// Output throughput after the first token (streaming)
+	TokensPerSecond = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "completion_tokens_per_second",
+			Help:      "Completion tokens per second measured from the first token for streaming requests",
+			Buckets:   []float64{1, 5, 10, 20, 40, 80, 160, 320},
+		},
+		[]string{"model", "provider"},
+	)
+
+	// Mean gap between consecutive tokens (streaming)
+	InterTokenLatency = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "inter_token_latency_seconds",
+			Help:      "Average time between completion tokens for streaming requests",
+			Buckets:   []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1},
 		},
 		[]string{"model", "provider"},
 	)
@@ -131,6 +155,30 @@ var (
 		[]string{"model"},
 	)
 
+	// Response body bytes per route and response kind (sse, json, other)
+	ResponseBytesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "response_bytes_total",
+			Help:      "Total response body bytes written by route and response kind",
+		},
+		[]string{"endpoint", "kind"},
+	)
+
+	// Time until the response body is complete; for SSE this is how long the
+	// stream stayed open, so rate(_sum) is the average number of open streams
+	ResponseStreamDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "response_stream_duration_seconds",
+			Help:      "Time until the response body is complete by route and response kind",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
+		},
+		[]string{"endpoint", "kind"},
+	)
+
 	// Provider health gauge
 	ProviderHealth = promauto.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -163,6 +211,191 @@ var (
 		[]string{"method", "status"},
 	)
 
+	// Provider webhooks by source and outcome (accepted, duplicate, rejected)
+	WebhookDeliveriesTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "webhook_deliveries_total",
+			Help:      "Provider webhook deliveries by source and outcome",
+		},
+		[]string{"source", "outcome"},
+	)
+
+	// Reconciliation of items stuck in_progress, by trigger (scheduled, admin)
+	StaleItemsFailedTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "stale_items_failed_total",
+			Help:      "Conversation items failed after staying in progress past their timeout",
+		},
+		[]string{"item_type", "trigger"},
+	)
+
+	ItemReconcileRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "item_reconcile_runs_total",
+			Help:      "Stale item reconciliation runs by trigger and outcome",
+		},
+		[]string{"trigger", "outcome"},
+	)
+
+	// Admission control of completions, by scope (model, provider) and outcome
+	// (admitted, queued, rejected, timed_out)
+	AdmissionRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "admission_requests_total",
+			Help:      "Completion admission decisions by scope and outcome",
+		},
+		[]string{"scope", "outcome"},
+	)
+
+	AdmissionInFlight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "admission_in_flight",
+			Help:      "Completions holding a slot on a capped model or provider",
+		},
+		[]string{"scope", "key"},
+	)
+
+	// vLLM load scraped from each endpoint's /metrics, by provider, endpoint and
+	// served model
+	VLLMRequestsWaiting = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "vllm_requests_waiting",
+			Help:      "Requests queued on a vLLM endpoint",
+		},
+		[]string{"provider", "endpoint", "model"},
+	)
+
+	VLLMRequestsRunning = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "vllm_requests_running",
+			Help:      "Requests being decoded on a vLLM endpoint",
+		},
+		[]string{"provider", "endpoint", "model"},
+	)
+
+	VLLMKVCacheUtilization = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "vllm_kv_cache_utilization",
+			Help:      "Fraction of a vLLM endpoint's KV cache in use (0-1)",
+		},
+		[]string{"provider", "endpoint", "model"},
+	)
+
+	VLLMScrapeErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "vllm_scrape_errors_total",
+			Help:      "Failed scrapes of vLLM metrics endpoints",
+		},
+		[]string{"provider"},
+	)
+
+	// Relative routing weight of an endpoint in percent; saturated vLLM
+	// endpoints are lowered while VLLM_ROUTING_CONTROLLER_ENABLED is set
+	EndpointRoutingWeight = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "endpoint_routing_weight",
+			Help:      "Routing weight applied to a provider endpoint, in percent",
+		},
+		[]string{"provider", "endpoint"},
+	)
+
+	// Region routing of completions, by where the preference came from (pinned,
+	// latency, local) and outcome (in_region, failover)
+	RegionRoutingTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "region_routing_total",
+			Help:      "Completions routed by region preference and outcome",
+		},
+		[]string{"source", "outcome"},
+	)
+
+	EndpointProbeLatency = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "endpoint_probe_latency_seconds",
+			Help:      "Smoothed round trip to a provider endpoint, probed in latency routing mode",
+		},
+		[]string{"provider", "endpoint", "region"},
+	)
+
+	// Conversation items moved to and from cold storage, by operation
+	// (archive, rehydrate)
+	ColdStorageItemsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "cold_storage_items_total",
+			Help:      "Conversation items archived to or rehydrated from cold storage",
+		},
+		[]string{"operation"},
+	)
+
+	ColdStorageErrorsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "cold_storage_errors_total",
+			Help:      "Conversations that failed to archive or rehydrate",
+		},
+		[]string{"operation"},
+	)
+
+	// Speech synthesized for streamed audio replies, by status (success, error)
+	TTSRequestsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "tts_requests_total",
+			Help:      "Text-to-speech requests by status",
+		},
+		[]string{"status"},
+	)
+
+	TTSDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "tts_duration_seconds",
+			Help:      "Time to synthesize one sentence of speech",
+			Buckets:   []float64{0.1, 0.25, 0.5, 1, 2, 5, 10, 30},
+		},
+	)
+
+	// Guest messages turned away, by reason (model, guest_limit, ip_limit,
+	// captcha_required, captcha_invalid)
+	GuestQuotaRejectionsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "guest_quota_rejections_total",
+			Help:      "Guest chat completions rejected by the guest tier limits",
+		},
+		[]string{"reason"},
+	)
+
 	// User agent metrics (normalized to keep low cardinality)
 	UserAgentsTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
@@ -183,6 +416,17 @@ var (
 		},
 		[]string{"family"},
 	)
+
+	// Deprecated API usage
+	DeprecatedUsageTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "llm_api",
+			Name:      "deprecated_usage_total",
+			Help:      "Requests using a deprecated route or item type, by client family",
+		},
+		[]string{"kind", "feature", "family"},
+	)
 )
 
 // RecordRequest records an HTTP request with all relevant labels
@@ -195,6 +439,13 @@ func RecordRequest(method, endpoint, status, model string, stream bool, duration
 	RequestDuration.WithLabelValues(method, endpoint, status).Observe(durationSec)
 }
 
+// RecordResponseStream records the bytes and duration of a response body.
+// kind is "sse", "json" or "other".
+func RecordResponseStream(endpoint, kind string, bytes int, durationSec float64) {
+	ResponseBytesTotal.WithLabelValues(endpoint, kind).Add(float64(bytes))
+	ResponseStreamDuration.WithLabelValues(endpoint, kind).Observe(durationSec)
+}
+
 // RecordTokens records token usage for a completion request
 func RecordTokens(model, provider string, promptTokens, completionTokens int) {
 	TokensPromptTotal.WithLabelValues(model, provider).Add(float64(promptTokens))
@@ -217,6 +468,18 @@ func RecordFirstToken(model, provider string, durationSec float64) {
 	FirstTokenDuration.WithLabelValues(model, provider).Observe(durationSec)
 }
 
+// RecordStreamThroughput records tokens/sec and inter-token latency for a
+// stream that produced completionTokens over generation (first token to end).
+func RecordStreamThroughput(model, provider string, completionTokens int, generationSec float64) {
+	if completionTokens <= 0 || generationSec <= 0 {
+		return
+	}
+	TokensPerSecond.WithLabelValues(model, provider).Observe(float64(completionTokens) / generationSec)
+	if completionTokens > 1 {
+		InterTokenLatency.WithLabelValues(model, provider).Observe(generationSec / float64(completionTokens-1))
+	}
+}
+
 // RecordProviderError records a provider error
 func RecordProviderError(provider, errorType string) {
 	ProviderErrorsTotal.WithLabelValues(provider, errorType).Inc()
@@ -271,6 +534,12 @@ func RecordUserAgent(ua string) {
 	UserAgentFamilyTotal.WithLabelValues(family).Inc()
 }
 
+// RecordDeprecatedUsage records a use of a deprecated feature. kind is
+// "route" or "item_type"; the client family shows who still depends on it.
+func RecordDeprecatedUsage(kind, feature, ua string) {
+	DeprecatedUsageTotal.WithLabelValues(kind, feature, userAgentFamily(normalizeUserAgent(ua))).Inc()
+}
+
 func normalizeUserAgent(ua string) string {
 	ua = strings.TrimSpace(strings.ToLower(ua))
 	if ua == "" {
@@ -68,9 +68,17 @@ func Setup(ctx context.Context, cfg *config.Config, logger zerolog.Logger) (func
 			return nil, err
 		}
 
+		sampling := SamplingConfig{
+			Rate:          cfg.TraceSampleRate,
+			RouteRates:    cfg.TraceRouteRates,
+			SlowThreshold: cfg.TraceSlowThreshold,
+			SlowRoutes:    cfg.TraceSlowRoutes,
+			TailSampling:  cfg.TraceTailSampling,
+		}
 		tracerProvider = sdktrace.NewTracerProvider(
 			sdktrace.WithResource(res),
-			sdktrace.WithBatcher(traceExporter),
+			sdktrace.WithSampler(NewSampler(sampling)),
+			sdktrace.WithSpanProcessor(NewRetainingProcessor(sdktrace.NewBatchSpanProcessor(traceExporter), sampling)),
 		)
 
 		reader := sdkmetric.NewPeriodicReader(meterExporter, sdkmetric.WithInterval(30*time.Second))
@@ -0,0 +1,284 @@
+package observability
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Sampling hint attributes. The sampler sets the first three on the local
+// root span of every trace, so a tail-sampling collector can apply the
+// service's per-route decision once it has seen the whole trace.
+const (
+	SamplingRouteKey    = attribute.Key("jan.sampling.route")    // Matched route prefix, or "default"
+	SamplingRateKey     = attribute.Key("jan.sampling.rate")     // Rate applied to the route
+	SamplingSampledKey  = attribute.Key("jan.sampling.sampled")  // Head decision at that rate
+	SamplingRetainedKey = attribute.Key("jan.sampling.retained") // error or slow, on spans exported because the trace failed or was slow
+)
+
+// Reasons an unsampled trace is exported, values of SamplingRetainedKey
+const (
+	RetainedError = "error"
+	RetainedSlow  = "slow"
+)
+
+const (
+	defaultSamplingRoute   = "default"
+	retainMaxTraces        = 4096
+	retainMaxSpansPerTrace = 256
+	retainTTL              = 5 * time.Minute
+)
+
+// SamplingConfig configures trace sampling.
+type SamplingConfig struct {
+	// Rate is the share of traces sampled on routes without an override
+	Rate float64
+	// RouteRates overrides Rate for routes starting with a prefix, e.g.
+	// "/v1/chat/completions": 0.01; the longest matching prefix wins
+	RouteRates map[string]float64
+	// Unsampled traces that fail or last at least SlowThreshold are still
+	// exported; 0 keeps only failed ones. SlowRoutes overrides it per
+	// route prefix, e.g. for streamed completions
+	SlowThreshold time.Duration
+	SlowRoutes    map[string]time.Duration
+	// TailSampling samples every trace and leaves the decision to the
+	// collector, which reads the jan.sampling.* hints
+	TailSampling bool
+}
+
+// NewSampler returns a sampler applying the per-route rates to new traces.
+// Spans with a parent follow the parent's decision. Unsampled traces are
+// still recorded, so NewRetainingProcessor can export them if they fail or
+// are slow.
+func NewSampler(cfg SamplingConfig) sdktrace.Sampler {
+	root := routeSampler{
+		cfg:     cfg,
+		ratio:   sdktrace.TraceIDRatioBased(cfg.Rate),
+		byRoute: make(map[string]sdktrace.Sampler, len(cfg.RouteRates)),
+	}
+	for prefix, rate := range cfg.RouteRates {
+		root.byRoute[prefix] = sdktrace.TraceIDRatioBased(rate)
+	}
+	if cfg.TailSampling {
+		return sdktrace.ParentBased(root)
+	}
+	return sdktrace.ParentBased(root,
+		sdktrace.WithRemoteParentNotSampled(recordOnlySampler{}),
+		sdktrace.WithLocalParentNotSampled(recordOnlySampler{}),
+	)
+}
+
+type routeSampler struct {
+	cfg     SamplingConfig
+	ratio   sdktrace.Sampler
+	byRoute map[string]sdktrace.Sampler
+}
+
+func (s routeSampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	route, rate, ratio := defaultSamplingRoute, s.cfg.Rate, s.ratio
+	if prefix, ok := longestPrefix(s.cfg.RouteRates, routeOf(p.Attributes)); ok {
+		route, rate, ratio = prefix, s.cfg.RouteRates[prefix], s.byRoute[prefix]
+	}
+
+	result := ratio.ShouldSample(p)
+	sampled := result.Decision == sdktrace.RecordAndSample
+	result.Attributes = append(result.Attributes,
+		SamplingRouteKey.String(route),
+		SamplingRateKey.Float64(rate),
+		SamplingSampledKey.Bool(sampled),
+	)
+	switch {
+	case s.cfg.TailSampling:
+		result.Decision = sdktrace.RecordAndSample
+	case !sampled:
+		result.Decision = sdktrace.RecordOnly
+	}
+	return result
+}
+
+func (s routeSampler) Description() string {
+	return fmt.Sprintf("RouteSampler{rate:%g,routes:%d,tail:%t}", s.cfg.Rate, len(s.cfg.RouteRates), s.cfg.TailSampling)
+}
+
+// recordOnlySampler records the spans of unsampled traces without sampling them.
+type recordOnlySampler struct{}
+
+func (recordOnlySampler) ShouldSample(p sdktrace.SamplingParameters) sdktrace.SamplingResult {
+	return sdktrace.SamplingResult{
+		Decision:   sdktrace.RecordOnly,
+		Tracestate: trace.SpanContextFromContext(p.ParentContext).TraceState(),
+	}
+}
+
+func (recordOnlySampler) Description() string {
+	return "RecordOnly"
+}
+
+// NewRetainingProcessor passes sampled spans to next and buffers the spans of
+// unsampled traces until their local root ends. The buffered spans are then
+// exported as sampled, tagged with SamplingRetainedKey, if a span failed or
+// the root was slow, and dropped otherwise.
+func NewRetainingProcessor(next sdktrace.SpanProcessor, cfg SamplingConfig) sdktrace.SpanProcessor {
+	return &retainingProcessor{
+		next:   next,
+		cfg:    cfg,
+		traces: make(map[trace.TraceID]*pendingTrace),
+	}
+}
+
+type retainingProcessor struct {
+	next sdktrace.SpanProcessor
+	cfg  SamplingConfig
+
+	mu     sync.Mutex
+	traces map[trace.TraceID]*pendingTrace
+}
+
+type pendingTrace struct {
+	started time.Time
+	spans   []sdktrace.ReadOnlySpan
+	failed  bool
+}
+
+func (p *retainingProcessor) OnStart(ctx context.Context, s sdktrace.ReadWriteSpan) {
+	p.next.OnStart(ctx, s)
+}
+
+func (p *retainingProcessor) OnEnd(s sdktrace.ReadOnlySpan) {
+	if s.SpanContext().IsSampled() {
+		// The collector's latency policy has a single threshold, so slow
+		// roots are tagged for it with their route's threshold
+		if p.cfg.TailSampling && isLocalRoot(s) && p.isSlow(s) {
+			s = retainedSpan{ReadOnlySpan: s, reason: RetainedSlow}
+		}
+		p.next.OnEnd(s)
+		return
+	}
+	spans, reason := p.collect(s)
+	for _, span := range spans {
+		p.next.OnEnd(retainedSpan{ReadOnlySpan: span, reason: reason})
+	}
+}
+
+// collect buffers s and returns the spans of its trace to export, if any.
+func (p *retainingProcessor) collect(s sdktrace.ReadOnlySpan) ([]sdktrace.ReadOnlySpan, string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	traceID := s.SpanContext().TraceID()
+	pending, ok := p.traces[traceID]
+	if !ok {
+		if len(p.traces) >= retainMaxTraces && !p.evictExpired() {
+			return nil, ""
+		}
+		pending = &pendingTrace{started: time.Now()}
+		p.traces[traceID] = pending
+	}
+	if len(pending.spans) < retainMaxSpansPerTrace {
+		pending.spans = append(pending.spans, s)
+	}
+	if s.Status().Code == codes.Error {
+		pending.failed = true
+	}
+
+	// Spans ending after their local root, e.g. of detached goroutines, are
+	// buffered until evicted.
+	if !isLocalRoot(s) {
+		return nil, ""
+	}
+	delete(p.traces, traceID)
+	switch {
+	case pending.failed:
+		return pending.spans, RetainedError
+	case p.isSlow(s):
+		return pending.spans, RetainedSlow
+	default:
+		return nil, ""
+	}
+}
+
+func (p *retainingProcessor) isSlow(root sdktrace.ReadOnlySpan) bool {
+	threshold := p.cfg.SlowThreshold
+	if prefix, ok := longestPrefix(p.cfg.SlowRoutes, routeOf(root.Attributes())); ok {
+		threshold = p.cfg.SlowRoutes[prefix]
+	}
+	return threshold > 0 && root.EndTime().Sub(root.StartTime()) >= threshold
+}
+
+// evictExpired drops the traces buffered for longer than retainTTL and
+// reports whether there is room for a new one.
+func (p *retainingProcessor) evictExpired() bool {
+	cutoff := time.Now().Add(-retainTTL)
+	for traceID, pending := range p.traces {
+		if pending.started.Before(cutoff) {
+			delete(p.traces, traceID)
+		}
+	}
+	return len(p.traces) < retainMaxTraces
+}
+
+func (p *retainingProcessor) Shutdown(ctx context.Context) error {
+	return p.next.Shutdown(ctx)
+}
+
+func (p *retainingProcessor) ForceFlush(ctx context.Context) error {
+	return p.next.ForceFlush(ctx)
+}
+
+func isLocalRoot(s sdktrace.ReadOnlySpan) bool {
+	return !s.Parent().IsValid() || s.Parent().IsRemote()
+}
+
+// retainedSpan exports a span of an unsampled trace as sampled.
+type retainedSpan struct {
+	sdktrace.ReadOnlySpan
+	reason string
+}
+
+func (s retainedSpan) SpanContext() trace.SpanContext {
+	sc := s.ReadOnlySpan.SpanContext()
+	return sc.WithTraceFlags(sc.TraceFlags().WithSampled(true))
+}
+
+func (s retainedSpan) Attributes() []attribute.KeyValue {
+	return append(slices.Clone(s.ReadOnlySpan.Attributes()), SamplingRetainedKey.String(s.reason))
+}
+
+// routeOf returns the HTTP route of a span, falling back to the request path
+// for requests that matched no route.
+func routeOf(attrs []attribute.KeyValue) string {
+	var target string
+	for _, kv := range attrs {
+		switch kv.Key {
+		case "http.route":
+			if route := kv.Value.AsString(); route != "" {
+				return route
+			}
+		case "http.target", "url.path":
+			target = kv.Value.AsString()
+		}
+	}
+	return target
+}
+
+// longestPrefix returns the longest key of rules that route starts with.
+func longestPrefix[T any](rules map[string]T, route string) (string, bool) {
+	if route == "" {
+		return "", false
+	}
+	match, found := "", false
+	for prefix := range rules {
+		if strings.HasPrefix(route, prefix) && (!found || len(prefix) > len(match)) {
+			match, found = prefix, true
+		}
+	}
+	return match, found
+}
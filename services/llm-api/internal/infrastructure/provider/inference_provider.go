@@ -4,12 +4,14 @@ package provider
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+
 	"jan-server/services/llm-api/internal/config"
 	domainmodel "jan-server/services/llm-api/internal/domain/model"
-	"jan-server/services/llm-api/internal/utils/crypto"
 	httpclients "jan-server/services/llm-api/internal/utils/httpclients"
 	chatclient "jan-server/services/llm-api/internal/utils/httpclients/chat"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
@@ -18,7 +20,9 @@ import (
 )
 
 type InferenceProvider struct {
-	streamTimeout time.Duration
+	streamTimeout     time.Duration
+	heartbeatInterval time.Duration
+	httpClient        *http.Client
 }
 
 func NewInferenceProvider(cfg *config.Config) *InferenceProvider {
@@ -26,8 +30,19 @@ func NewInferenceProvider(cfg *config.Config) *InferenceProvider {
 	if cfg != nil && cfg.StreamTimeout > 0 {
 		timeout = cfg.StreamTimeout
 	}
+	var heartbeat time.Duration
+	if cfg != nil {
+		heartbeat = cfg.SSEHeartbeatInterval
+	}
+	var httpClient *http.Client
+	if cfg != nil {
+		// Settings are validated when config loads; on error resty's defaults apply
+		httpClient, _ = httpclient.New(cfg.ProviderHTTP)
+	}
 	return &InferenceProvider{
-		streamTimeout: timeout,
+		streamTimeout:     timeout,
+		heartbeatInterval: heartbeat,
+		httpClient:        httpClient,
 	}
 }
 
@@ -38,7 +53,7 @@ func (ip *InferenceProvider) GetChatCompletionClient(ctx context.Context, provid
 	}
 
 	clientName := provider.DisplayName
-	return chatclient.NewChatCompletionClient(client, clientName, provider.BaseURL, chatclient.WithStreamTimeout(ip.streamTimeout)), nil
+	return chatclient.NewChatCompletionClient(client, clientName, provider.BaseURL, chatclient.WithStreamTimeout(ip.streamTimeout), chatclient.WithHeartbeatInterval(ip.heartbeatInterval)), nil
 }
 
 func (ip *InferenceProvider) GetChatModelClient(ctx context.Context, provider *domainmodel.Provider) (*chatclient.ChatModelClient, error) {
@@ -67,7 +82,7 @@ func (ip *InferenceProvider) ListModels(ctx context.Context, provider *domainmod
 
 func (ip *InferenceProvider) createRestyClient(ctx context.Context, provider *domainmodel.Provider) (*resty.Client, error) {
 	clientName := fmt.Sprintf("%sClient", provider.PublicID)
-	client := httpclients.NewClient(clientName)
+	client := httpclients.NewClient(clientName, ip.httpClient)
 	client.SetBaseURL(provider.BaseURL)
 
 	// Set authorization header if API key exists
@@ -99,12 +114,12 @@ func (ip *InferenceProvider) decryptAPIKey(ctx context.Context, encryptedAPIKey
 		return "", nil
 	}
 
-	secret := strings.TrimSpace(config.GetGlobal().ModelProviderSecret)
-	if secret == "" {
+	keyring := config.GetGlobal().ProviderKeyring()
+	if !keyring.Configured() {
 		return "", platformerrors.NewError(ctx, platformerrors.LayerInfrastructure, platformerrors.ErrorTypeInternal, "MODEL_PROVIDER_SECRET not configured", nil, "8f07ea41-1096-405b-ae2e-cde06564e5bc")
 	}
 
-	plainText, err := crypto.DecryptString(secret, encryptedAPIKey)
+	plainText, err := keyring.Decrypt(encryptedAPIKey)
 	if err != nil {
 		return "", err
 	}
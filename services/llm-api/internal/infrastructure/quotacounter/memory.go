@@ -0,0 +1,66 @@
+package quotacounter
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often expired counters are dropped
+const sweepInterval = time.Hour
+
+type memoryEntry struct {
+	count    int64
+	expireAt time.Time
+}
+
+// MemoryCounter keeps the counters of one replica in memory.
+type MemoryCounter struct {
+	mu        sync.Mutex
+	entries   map[string]*memoryEntry
+	lastSweep time.Time
+}
+
+// NewMemoryCounter creates an empty in-memory counter.
+func NewMemoryCounter() *MemoryCounter {
+	return &MemoryCounter{entries: make(map[string]*memoryEntry), lastSweep: time.Now()}
+}
+
+// Get returns the count of key, 0 if it has none.
+func (c *MemoryCounter) Get(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if entry, ok := c.entries[key]; ok && time.Now().Before(entry.expireAt) {
+		return entry.count, nil
+	}
+	return 0, nil
+}
+
+// Incr adds one to key, which expires at expireAt, and returns the new count.
+func (c *MemoryCounter) Incr(_ context.Context, key string, expireAt time.Time) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	now := time.Now()
+	c.sweep(now)
+	entry, ok := c.entries[key]
+	if !ok || !now.Before(entry.expireAt) {
+		entry = &memoryEntry{}
+		c.entries[key] = entry
+	}
+	entry.count++
+	entry.expireAt = expireAt
+	return entry.count, nil
+}
+
+// sweep drops the expired counters, at most once per sweepInterval
+func (c *MemoryCounter) sweep(now time.Time) {
+	if now.Sub(c.lastSweep) < sweepInterval {
+		return
+	}
+	c.lastSweep = now
+	for key, entry := range c.entries {
+		if !now.Before(entry.expireAt) {
+			delete(c.entries, key)
+		}
+	}
+}
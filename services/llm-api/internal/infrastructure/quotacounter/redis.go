@@ -0,0 +1,44 @@
+// Package quotacounter keeps the daily counters of the guest quotas, in Redis
+// when REDIS_URL is set so every replica shares them, and in memory otherwise.
+package quotacounter
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// keyPrefix namespaces the counters in a Redis shared with other data
+const keyPrefix = "llm-api:"
+
+// RedisCounter keeps the counters in Redis.
+type RedisCounter struct {
+	client redis.UniversalClient
+}
+
+// NewRedisCounter creates a counter on client.
+func NewRedisCounter(client redis.UniversalClient) *RedisCounter {
+	return &RedisCounter{client: client}
+}
+
+// Get returns the count of key, 0 if it has none.
+func (c *RedisCounter) Get(ctx context.Context, key string) (int64, error) {
+	count, err := c.client.Get(ctx, keyPrefix+key).Int64()
+	if errors.Is(err, redis.Nil) {
+		return 0, nil
+	}
+	return count, err
+}
+
+// Incr adds one to key, which expires at expireAt, and returns the new count.
+func (c *RedisCounter) Incr(ctx context.Context, key string, expireAt time.Time) (int64, error) {
+	pipe := c.client.TxPipeline()
+	incr := pipe.Incr(ctx, keyPrefix+key)
+	pipe.ExpireAt(ctx, keyPrefix+key, expireAt)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, err
+	}
+	return incr.Val(), nil
+}
@@ -0,0 +1,155 @@
+// Package regionprobe measures the round trip from this replica to every
+// provider endpoint, for routing completions to the nearest region.
+package regionprobe
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+const (
+	maxProbeTimeout = 5 * time.Second
+	smoothing       = 0.3 // Weight of the newest sample in the moving average
+)
+
+type seriesKey struct {
+	provider string
+	endpoint string
+	region   string
+}
+
+// Prober keeps a moving average of each endpoint's round trip. Any HTTP
+// response counts, since only the network path is measured; endpoints that
+// do not answer are dropped until they do.
+type Prober struct {
+	interval time.Duration
+	client   *http.Client
+
+	mu        sync.RWMutex
+	latencies map[string]time.Duration // By endpoint URL
+	series    map[seriesKey]struct{}
+}
+
+// NewProber creates the prober. It only probes in latency routing mode.
+func NewProber(cfg *config.Config) *Prober {
+	var interval time.Duration
+	if cfg != nil && cfg.RegionRoutingMode == config.RegionRoutingLatency {
+		interval = cfg.RegionProbeInterval
+	}
+	timeout := maxProbeTimeout
+	if interval > 0 && interval < timeout {
+		timeout = interval
+	}
+	return &Prober{
+		interval: interval,
+		client: &http.Client{
+			Timeout: timeout,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+		latencies: make(map[string]time.Duration),
+		series:    make(map[seriesKey]struct{}),
+	}
+}
+
+// Interval is how often Probe should run; 0 means probing is disabled
+func (p *Prober) Interval() time.Duration {
+	return p.interval
+}
+
+// EndpointLatency returns the smoothed round trip to url, if it answered the
+// last probe
+func (p *Prober) EndpointLatency(url string) (time.Duration, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	latency, ok := p.latencies[url]
+	return latency, ok
+}
+
+type probeResult struct {
+	key     seriesKey
+	latency time.Duration
+	err     error
+}
+
+// Probe times a request to every endpoint of providers that carry a region.
+func (p *Prober) Probe(ctx context.Context, providers []*model.Provider) {
+	log := logger.GetLogger()
+
+	var wg sync.WaitGroup
+	results := make(chan probeResult)
+	seen := make(map[string]bool)
+	for _, provider := range providers {
+		if !provider.Active {
+			continue
+		}
+		for _, ep := range provider.RegionalEndpoints() {
+			if ep.Region == "" || seen[ep.URL] {
+				continue
+			}
+			seen[ep.URL] = true
+			wg.Add(1)
+			go func(key seriesKey) {
+				defer wg.Done()
+				latency, err := p.probe(ctx, key.endpoint)
+				results <- probeResult{key: key, latency: latency, err: err}
+			}(seriesKey{provider: provider.PublicID, endpoint: ep.URL, region: ep.Region})
+		}
+	}
+	go func() {
+		wg.Wait()
+		close(results)
+	}()
+	var probed []probeResult
+	for result := range results {
+		probed = append(probed, result)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	latencies := make(map[string]time.Duration)
+	series := make(map[seriesKey]struct{})
+	for _, result := range probed {
+		if result.err != nil {
+			log.Debug().Err(result.err).Str("endpoint", result.key.endpoint).Msg("endpoint latency probe failed")
+			continue
+		}
+		latency := result.latency
+		if previous, ok := p.latencies[result.key.endpoint]; ok {
+			latency = time.Duration(smoothing*float64(latency) + (1-smoothing)*float64(previous))
+		}
+		latencies[result.key.endpoint] = latency
+		series[result.key] = struct{}{}
+		metrics.EndpointProbeLatency.WithLabelValues(result.key.provider, result.key.endpoint, result.key.region).Set(latency.Seconds())
+	}
+	for key := range p.series {
+		if _, ok := series[key]; !ok {
+			metrics.EndpointProbeLatency.DeleteLabelValues(key.provider, key.endpoint, key.region)
+		}
+	}
+	p.latencies = latencies
+	p.series = series
+}
+
+func (p *Prober) probe(ctx context.Context, url string) (time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodHead, url, nil)
+	if err != nil {
+		return 0, err
+	}
+	start := time.Now()
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	latency := time.Since(start)
+	resp.Body.Close()
+	return latency, nil
+}
@@ -0,0 +1,75 @@
+package router
+
+import (
+	"math/rand/v2"
+
+	"github.com/rs/zerolog/log"
+
+	"jan-server/services/llm-api/internal/domain/model"
+)
+
+// EndpointWeigher scales an endpoint's configured weight, in percent.
+type EndpointWeigher interface {
+	EndpointWeight(url string) int
+}
+
+// WeightedRouter picks a healthy endpoint at random in proportion to its
+// configured weight scaled by the weigher, so endpoints reporting saturation
+// receive less traffic.
+type WeightedRouter struct {
+	weigher EndpointWeigher
+}
+
+func NewWeightedRouter(weigher EndpointWeigher) *WeightedRouter {
+	return &WeightedRouter{weigher: weigher}
+}
+
+// NextEndpoint returns an endpoint chosen by weight.
+func (r *WeightedRouter) NextEndpoint(providerID string, endpoints model.EndpointList) (string, error) {
+	if len(endpoints) == 0 {
+		return "", model.ErrNoEndpoints
+	}
+
+	if len(endpoints) == 1 {
+		return endpoints[0].URL, nil
+	}
+
+	healthy := endpoints.GetHealthy()
+	if len(healthy) == 0 {
+		return endpoints[0].URL, model.ErrNoHealthyEndpoints
+	}
+
+	weights := make([]int, len(healthy))
+	total := 0
+	for i, ep := range healthy {
+		weights[i] = max(ep.Weight, 1) * max(r.weigher.EndpointWeight(ep.URL), 0)
+		total += weights[i]
+	}
+
+	var selected model.Endpoint
+	if total == 0 {
+		selected = healthy[rand.IntN(len(healthy))]
+	} else {
+		pick := rand.IntN(total)
+		for i, weight := range weights {
+			if pick < weight {
+				selected = healthy[i]
+				break
+			}
+			pick -= weight
+		}
+	}
+
+	log.Info().
+		Str("provider_id", providerID).
+		Str("endpoint_url", selected.URL).
+		Int("healthy_count", len(healthy)).
+		Int("total_count", len(endpoints)).
+		Int("total_weight", total).
+		Msg("selected endpoint for request")
+
+	return selected.URL, nil
+}
+
+// Reset is a no-op; weights live in the weigher, which rescrapes on its own.
+func (r *WeightedRouter) Reset() {}
@@ -0,0 +1,135 @@
+// Package streamstate keeps the bookkeeping of in-flight streaming completions
+// in Redis, so a cancel request that reaches any replica can be routed to the
+// replica serving the stream.
+package streamstate
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	keyPrefix     = "llm-api:stream:"
+	channelPrefix = "llm-api:stream-cancel:"
+
+	// EntryTTL bounds how long the entry of a replica that died mid-stream
+	// lingers. Replicas refresh their entries every RefreshInterval.
+	EntryTTL        = 60 * time.Second
+	RefreshInterval = EntryTTL / 3
+)
+
+// Entry describes a stream running on some replica.
+type Entry struct {
+	StreamID       string    `json:"stream_id"`
+	UserID         uint      `json:"user_id"`
+	ConversationID string    `json:"conversation_id"` // Public ID
+	Instance       string    `json:"instance"`        // Replica serving the stream
+	StartedAt      time.Time `json:"started_at"`
+}
+
+// CancelMessage asks the replica serving a stream to stop it.
+type CancelMessage struct {
+	StreamID   string `json:"stream_id"`
+	UserID     uint   `json:"user_id"`
+	AudioEndMs *int   `json:"audio_end_ms,omitempty"` // Set when the user interrupted the stream's audio
+}
+
+// Store reads and writes stream entries and routes cancel messages between
+// replicas. Each replica listens on its own channel.
+type Store struct {
+	client   redis.UniversalClient
+	instance string
+}
+
+// NewStore creates a store for the replica identified by instance.
+func NewStore(client redis.UniversalClient, instance string) *Store {
+	return &Store{client: client, instance: instance}
+}
+
+// Instance returns this replica's identity.
+func (s *Store) Instance() string {
+	return s.instance
+}
+
+// Register records a stream served by this replica.
+func (s *Store) Register(ctx context.Context, entry Entry) error {
+	entry.Instance = s.instance
+	payload, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(ctx, keyPrefix+entry.StreamID, payload, EntryTTL).Err()
+}
+
+// Refresh extends the TTL of a stream entry.
+func (s *Store) Refresh(ctx context.Context, streamID string) error {
+	return s.client.Expire(ctx, keyPrefix+streamID, EntryTTL).Err()
+}
+
+// Remove deletes a stream entry.
+func (s *Store) Remove(ctx context.Context, streamID string) error {
+	return s.client.Del(ctx, keyPrefix+streamID).Err()
+}
+
+// Get returns the entry of a running stream, or nil if there is none.
+func (s *Store) Get(ctx context.Context, streamID string) (*Entry, error) {
+	payload, err := s.client.Get(ctx, keyPrefix+streamID).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var entry Entry
+	if err := json.Unmarshal(payload, &entry); err != nil {
+		return nil, fmt.Errorf("decode stream entry: %w", err)
+	}
+	return &entry, nil
+}
+
+// PublishCancel sends a cancel message to the replica serving the stream. It
+// reports false when no replica is listening on that channel, meaning the
+// replica is gone and its stream with it.
+func (s *Store) PublishCancel(ctx context.Context, instance string, msg CancelMessage) (bool, error) {
+	payload, err := json.Marshal(msg)
+	if err != nil {
+		return false, err
+	}
+	receivers, err := s.client.Publish(ctx, channelPrefix+instance, payload).Result()
+	if err != nil {
+		return false, err
+	}
+	return receivers > 0, nil
+}
+
+// ListenCancels delivers cancel messages addressed to this replica until ctx
+// is cancelled. The subscription reconnects on its own after Redis outages.
+func (s *Store) ListenCancels(ctx context.Context, handle func(CancelMessage)) error {
+	pubsub := s.client.Subscribe(ctx, channelPrefix+s.instance)
+	defer pubsub.Close()
+
+	if _, err := pubsub.Receive(ctx); err != nil {
+		return fmt.Errorf("subscribe to stream cancels: %w", err)
+	}
+	messages := pubsub.Channel()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case message, ok := <-messages:
+			if !ok {
+				return nil
+			}
+			var msg CancelMessage
+			if err := json.Unmarshal([]byte(message.Payload), &msg); err != nil {
+				continue
+			}
+			handle(msg)
+		}
+	}
+}
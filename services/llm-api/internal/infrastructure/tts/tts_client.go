@@ -0,0 +1,82 @@
+package tts
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+// Audio formats streamed to clients, as named by the AudioContent model
+const (
+	FormatPCM16 = "pcm16" // 24 kHz 16-bit little-endian mono PCM
+	FormatOpus  = "opus"  // Opus in an Ogg container
+)
+
+// Client synthesizes speech through an OpenAI-compatible text-to-speech server.
+type Client struct {
+	speech       *clients.SpeechClient
+	apiKey       string
+	model        string
+	defaultVoice string
+	log          zerolog.Logger
+}
+
+// NewClient creates a new text-to-speech client. It returns nil when TTS_URL
+// is not set, which disables audio output.
+func NewClient(cfg *config.Config, log zerolog.Logger) *Client {
+	if cfg.TTSURL == "" {
+		return nil
+	}
+
+	httpClient, err := httpclient.New(cfg.TTSHTTP)
+	if err != nil {
+		log.Warn().Err(err).Msg("[TTSClient] Invalid TTS_HTTP_* settings, audio output disabled")
+		return nil
+	}
+
+	return &Client{
+		speech:       clients.NewSpeechClient(cfg.TTSURL, httpClient, cfg.TTSBreaker),
+		apiKey:       cfg.TTSAPIKey,
+		model:        cfg.TTSModel,
+		defaultVoice: cfg.TTSVoice,
+		log:          log.With().Str("component", "tts-client").Logger(),
+	}
+}
+
+// Synthesize returns text spoken by voice in format (pcm16 or opus). An empty
+// voice uses TTS_VOICE.
+func (c *Client) Synthesize(ctx context.Context, text, voice, format string) ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("tts client not configured")
+	}
+	if voice == "" {
+		voice = c.defaultVoice
+	}
+	responseFormat := "pcm"
+	if format == FormatOpus {
+		responseFormat = "opus"
+	}
+
+	start := time.Now()
+	audio, err := c.speech.Speech(ctx, c.apiKey, clients.SpeechRequest{
+		Model:          c.model,
+		Input:          text,
+		Voice:          voice,
+		ResponseFormat: responseFormat,
+	})
+	metrics.TTSDuration.Observe(time.Since(start).Seconds())
+	if err != nil {
+		metrics.TTSRequestsTotal.WithLabelValues("error").Inc()
+		c.log.Warn().Err(err).Int("status", clients.StatusCode(err)).Int("chars", len(text)).Msg("[TTSClient] Speech synthesis failed")
+		return nil, fmt.Errorf("speech synthesis failed: %w", err)
+	}
+	metrics.TTSRequestsTotal.WithLabelValues("success").Inc()
+	return audio, nil
+}
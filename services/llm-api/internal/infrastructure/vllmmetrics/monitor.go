@@ -0,0 +1,221 @@
+// Package vllmmetrics scrapes the load vLLM servers report on /metrics: queue
+// depth, running requests and KV-cache utilization per served model. The
+// values are re-exported as llm-api gauges, so recording rules can aggregate
+// them across endpoints, and optionally steer routing away from endpoints that
+// report saturation.
+package vllmmetrics
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+// EngineVLLM is the inference_engine metadata value of vLLM providers
+const EngineVLLM = "vllm"
+
+// Routing weights, in percent of an endpoint's configured weight
+const (
+	FullWeight      = 100
+	SaturatedWeight = 10 // Kept above zero so a saturated endpoint still drains and reports recovery
+)
+
+const maxScrapeTimeout = 5 * time.Second
+
+// Config controls scraping and the routing controller
+type Config struct {
+	Interval             time.Duration // How often endpoints are scraped; 0 disables scraping
+	ControllerEnabled    bool          // Lower the routing weight of saturated endpoints
+	SaturationKVCache    float64       // KV-cache utilization at which an endpoint is saturated
+	SaturationQueueDepth int           // Waiting requests at which an endpoint is saturated
+}
+
+type seriesKey struct {
+	provider string
+	endpoint string
+	model    string
+}
+
+// Monitor holds the latest load of every scraped vLLM endpoint
+type Monitor struct {
+	cfg    Config
+	client *http.Client
+
+	mu      sync.RWMutex
+	weights map[string]int         // Routing weight percent by endpoint URL
+	series  map[seriesKey]struct{} // Gauge label sets written by the last scrape
+}
+
+// NewMonitor creates the monitor from the VLLM_* settings
+func NewMonitor(cfg *config.Config) *Monitor {
+	monitorCfg := Config{SaturationKVCache: 0.9, SaturationQueueDepth: 8}
+	if cfg != nil {
+		monitorCfg = Config{
+			Interval:             cfg.VLLMMetricsInterval,
+			ControllerEnabled:    cfg.VLLMRoutingControllerEnabled,
+			SaturationKVCache:    cfg.VLLMSaturationKVCache,
+			SaturationQueueDepth: cfg.VLLMSaturationQueueDepth,
+		}
+	}
+	timeout := maxScrapeTimeout
+	if monitorCfg.Interval > 0 && monitorCfg.Interval < timeout {
+		timeout = monitorCfg.Interval
+	}
+	return &Monitor{
+		cfg:     monitorCfg,
+		client:  &http.Client{Timeout: timeout},
+		weights: make(map[string]int),
+		series:  make(map[seriesKey]struct{}),
+	}
+}
+
+// Interval is how often Scrape should run; 0 means scraping is disabled
+func (m *Monitor) Interval() time.Duration {
+	return m.cfg.Interval
+}
+
+// ControllerEnabled reports whether routing should follow EndpointWeight
+func (m *Monitor) ControllerEnabled() bool {
+	return m.cfg.ControllerEnabled
+}
+
+// EndpointWeight returns the routing weight of an endpoint in percent.
+// Endpoints that were not scraped, or failed to answer, keep FullWeight.
+func (m *Monitor) EndpointWeight(url string) int {
+	if !m.cfg.ControllerEnabled {
+		return FullWeight
+	}
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	if weight, ok := m.weights[url]; ok {
+		return weight
+	}
+	return FullWeight
+}
+
+// IsVLLM reports whether provider is served by vLLM
+func IsVLLM(provider *model.Provider) bool {
+	if provider == nil || provider.Metadata == nil {
+		return false
+	}
+	return strings.EqualFold(strings.TrimSpace(provider.Metadata[model.MetadataKeyInferenceEngine]), EngineVLLM)
+}
+
+type endpointResult struct {
+	provider string
+	url      string
+	loads    map[string]*ModelLoad
+	err      error
+}
+
+// Scrape reads the load of every endpoint of the vLLM providers among
+// providers, updates the gauges and recomputes routing weights.
+func (m *Monitor) Scrape(ctx context.Context, providers []*model.Provider) {
+	log := logger.GetLogger()
+
+	var wg sync.WaitGroup
+	var resultsMu sync.Mutex
+	var results []endpointResult
+	for _, provider := range providers {
+		if !provider.Active || !IsVLLM(provider) {
+			continue
+		}
+		for _, ep := range provider.GetEndpoints() {
+			wg.Add(1)
+			go func(providerID, url string) {
+				defer wg.Done()
+				loads, err := m.scrapeEndpoint(ctx, url)
+				resultsMu.Lock()
+				results = append(results, endpointResult{provider: providerID, url: url, loads: loads, err: err})
+				resultsMu.Unlock()
+			}(provider.PublicID, ep.URL)
+		}
+	}
+	wg.Wait()
+
+	weights := make(map[string]int, len(results))
+	series := make(map[seriesKey]struct{})
+	for _, result := range results {
+		if result.err != nil {
+			metrics.VLLMScrapeErrorsTotal.WithLabelValues(result.provider).Inc()
+			log.Warn().Err(result.err).Str("provider_id", result.provider).Str("endpoint", result.url).Msg("failed to scrape vLLM metrics")
+			continue
+		}
+
+		weight := FullWeight
+		for modelName, load := range result.loads {
+			key := seriesKey{provider: result.provider, endpoint: result.url, model: modelName}
+			series[key] = struct{}{}
+			metrics.VLLMRequestsWaiting.WithLabelValues(key.provider, key.endpoint, key.model).Set(load.Waiting)
+			metrics.VLLMRequestsRunning.WithLabelValues(key.provider, key.endpoint, key.model).Set(load.Running)
+			metrics.VLLMKVCacheUtilization.WithLabelValues(key.provider, key.endpoint, key.model).Set(load.KVCache)
+			if m.saturated(load) {
+				weight = SaturatedWeight
+			}
+		}
+		if !m.cfg.ControllerEnabled {
+			weight = FullWeight
+		}
+		weights[result.url] = weight
+		metrics.EndpointRoutingWeight.WithLabelValues(result.provider, result.url).Set(float64(weight))
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for key := range m.series {
+		if _, ok := series[key]; !ok {
+			metrics.VLLMRequestsWaiting.DeleteLabelValues(key.provider, key.endpoint, key.model)
+			metrics.VLLMRequestsRunning.DeleteLabelValues(key.provider, key.endpoint, key.model)
+			metrics.VLLMKVCacheUtilization.DeleteLabelValues(key.provider, key.endpoint, key.model)
+		}
+	}
+	for url, weight := range weights {
+		previous, ok := m.weights[url]
+		if !ok {
+			previous = FullWeight
+		}
+		if weight != previous {
+			log.Info().Str("endpoint", url).Int("weight", weight).Msg("vLLM endpoint routing weight changed")
+		}
+	}
+	m.series = series
+	m.weights = weights
+}
+
+// saturated reports whether a model's load crosses either threshold
+func (m *Monitor) saturated(load *ModelLoad) bool {
+	return load.KVCache >= m.cfg.SaturationKVCache ||
+		load.Waiting >= float64(m.cfg.SaturationQueueDepth)
+}
+
+func (m *Monitor) scrapeEndpoint(ctx context.Context, endpointURL string) (map[string]*ModelLoad, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, metricsURL(endpointURL), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("metrics endpoint returned %d", resp.StatusCode)
+	}
+	return parseLoad(resp.Body)
+}
+
+// metricsURL maps an OpenAI-compatible base URL (http://host:8101/v1) to the
+// server's Prometheus endpoint (http://host:8101/metrics)
+func metricsURL(endpointURL string) string {
+	base := strings.TrimSuffix(endpointURL, "/")
+	base = strings.TrimSuffix(base, "/v1")
+	return base + "/metrics"
+}
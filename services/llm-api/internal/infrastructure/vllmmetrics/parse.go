@@ -0,0 +1,136 @@
+package vllmmetrics
+
+import (
+	"bufio"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// vLLM metric names. vLLM 0.10 renamed gpu_cache_usage_perc to
+// kv_cache_usage_perc; both report a fraction between 0 and 1.
+const (
+	metricRequestsWaiting = "vllm:num_requests_waiting"
+	metricRequestsRunning = "vllm:num_requests_running"
+	metricKVCacheUsage    = "vllm:kv_cache_usage_perc"
+	metricGPUCacheUsage   = "vllm:gpu_cache_usage_perc"
+)
+
+// ModelLoad is the load a vLLM server reports for one served model
+type ModelLoad struct {
+	Waiting float64 // Requests queued for a free slot
+	Running float64 // Requests being decoded
+	KVCache float64 // Fraction of the KV cache in use
+}
+
+// parseLoad reads the load of each served model, keyed by the model_name
+// label, from a Prometheus text exposition. Other metrics are skipped.
+func parseLoad(r io.Reader) (map[string]*ModelLoad, error) {
+	loads := make(map[string]*ModelLoad)
+	hasKVCache := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || line[0] == '#' || !strings.HasPrefix(line, "vllm:") {
+			continue
+		}
+		name, labels, value, ok := parseSample(line)
+		if !ok {
+			continue
+		}
+		switch name {
+		case metricRequestsWaiting, metricRequestsRunning, metricKVCacheUsage, metricGPUCacheUsage:
+		default:
+			continue
+		}
+
+		modelName := labelValue(labels, "model_name")
+		load, exists := loads[modelName]
+		if !exists {
+			load = &ModelLoad{}
+			loads[modelName] = load
+		}
+		switch name {
+		case metricRequestsWaiting:
+			load.Waiting += value
+		case metricRequestsRunning:
+			load.Running += value
+		case metricKVCacheUsage:
+			// Several engines may serve one model; the fullest cache decides
+			if !hasKVCache[modelName] || value > load.KVCache {
+				load.KVCache = value
+			}
+			hasKVCache[modelName] = true
+		case metricGPUCacheUsage:
+			if !hasKVCache[modelName] && value > load.KVCache {
+				load.KVCache = value
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return loads, nil
+}
+
+// parseSample splits `name{labels} value [timestamp]`
+func parseSample(line string) (name, labels string, value float64, ok bool) {
+	rest := line
+	if open := strings.IndexByte(line, '{'); open >= 0 {
+		closing := strings.LastIndexByte(line, '}')
+		if closing < open {
+			return "", "", 0, false
+		}
+		name = line[:open]
+		labels = line[open+1 : closing]
+		rest = line[closing+1:]
+	} else {
+		space := strings.IndexAny(line, " \t")
+		if space < 0 {
+			return "", "", 0, false
+		}
+		name = line[:space]
+		rest = line[space:]
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", "", 0, false
+	}
+	value, err := strconv.ParseFloat(fields[0], 64)
+	if err != nil {
+		return "", "", 0, false
+	}
+	return name, labels, value, true
+}
+
+// labelValue returns the value of key in a `k="v",...` label list
+func labelValue(labels, key string) string {
+	for labels != "" {
+		eq := strings.IndexByte(labels, '=')
+		if eq < 0 || eq+1 >= len(labels) || labels[eq+1] != '"' {
+			return ""
+		}
+		name := strings.TrimSpace(labels[:eq])
+
+		var value strings.Builder
+		i := eq + 2
+		for ; i < len(labels) && labels[i] != '"'; i++ {
+			if labels[i] == '\\' && i+1 < len(labels) {
+				i++
+				if labels[i] == 'n' {
+					value.WriteByte('\n')
+					continue
+				}
+			}
+			value.WriteByte(labels[i])
+		}
+		if name == key {
+			return value.String()
+		}
+		labels = strings.TrimLeft(labels[min(i+1, len(labels)):], ", ")
+	}
+	return ""
+}
@@ -0,0 +1,193 @@
+package admin
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/domain/analytics"
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/domain/user"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+const (
+	defaultAdminConversationsLimit = 50
+	maxAdminConversationsLimit     = 500
+)
+
+// AdminInspectionHandler serves read-only operational views across all users.
+// Conversation content is never returned, only metadata.
+type AdminInspectionHandler struct {
+	analyticsService    *analytics.Service
+	conversationService *conversation.ConversationService
+	userService         *user.Service
+}
+
+func NewAdminInspectionHandler(analyticsService *analytics.Service, conversationService *conversation.ConversationService, userService *user.Service) *AdminInspectionHandler {
+	return &AdminInspectionHandler{
+		analyticsService:    analyticsService,
+		conversationService: conversationService,
+		userService:         userService,
+	}
+}
+
+// AdminConversation is the metadata of a conversation shown to operators
+type AdminConversation struct {
+	ID           string    `json:"id"`
+	UserID       uint      `json:"user_id"`
+	ProjectID    *string   `json:"project_id,omitempty"`
+	Status       string    `json:"status"`
+	Referrer     *string   `json:"referrer,omitempty"`
+	ActiveBranch string    `json:"active_branch,omitempty"`
+	ItemCount    int       `json:"item_count"` // Items on the MAIN branch
+	Version      int       `json:"version"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// AdminConversationsResponse lists conversations active since a point in time
+type AdminConversationsResponse struct {
+	Since  time.Time           `json:"since"`
+	UserID *uint               `json:"user_id,omitempty"`
+	Total  int64               `json:"total"`
+	Data   []AdminConversation `json:"data"`
+}
+
+// AdminUsageResponse is the platform usage report
+type AdminUsageResponse struct {
+	By     string               `json:"by"`
+	Since  time.Time            `json:"since"`
+	Until  time.Time            `json:"until"`
+	UserID *uint                `json:"user_id,omitempty"`
+	Data   []analytics.UsageRow `json:"data"`
+}
+
+// ListConversations godoc
+// @Summary List conversations across users
+// @Description Lists conversation metadata (never content), most recently active first, for operational debugging.
+// @Tags Admin - Inspection
+// @Security BearerAuth
+// @Produce json
+// @Param user query string false "User ID, identity provider subject, email or username"
+// @Param since query string false "Only conversations active since: 7d, 12h, YYYY-MM-DD or RFC 3339 (default 7d)"
+// @Param limit query int false "Maximum conversations (default 50, max 500)"
+// @Success 200 {object} AdminConversationsResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid since"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/conversations [get]
+func (h *AdminInspectionHandler) ListConversations(c *gin.Context) {
+	ctx := c.Request.Context()
+	since, err := analytics.ParseSince(ctx, c.Query("since"), time.Now())
+	if err != nil {
+		responses.HandleError(c, err, "invalid since")
+		return
+	}
+	userID, ok := h.resolveUser(c)
+	if !ok {
+		return
+	}
+
+	limit := defaultAdminConversationsLimit
+	if raw := c.Query("limit"); raw != "" {
+		if parsed, parseErr := strconv.Atoi(raw); parseErr == nil && parsed > 0 {
+			limit = min(parsed, maxAdminConversationsLimit)
+		}
+	}
+
+	conversations, total, err := h.conversationService.FindConversationsByFilter(ctx,
+		conversation.ConversationFilter{UserID: userID, UpdatedAfter: &since},
+		&query.Pagination{Limit: &limit, Order: "desc"})
+	if err != nil {
+		responses.HandleError(c, err, "failed to list conversations")
+		return
+	}
+
+	data := make([]AdminConversation, 0, len(conversations))
+	for _, conv := range conversations {
+		count, err := h.conversationService.CountConversationItems(ctx, conv, conversation.BranchMain)
+		if err != nil {
+			responses.HandleError(c, err, "failed to count conversation items")
+			return
+		}
+		data = append(data, AdminConversation{
+			ID:           conv.PublicID,
+			UserID:       conv.UserID,
+			ProjectID:    conv.ProjectPublicID,
+			Status:       string(conv.Status),
+			Referrer:     conv.Referrer,
+			ActiveBranch: conv.ActiveBranch,
+			ItemCount:    count,
+			Version:      conv.Version,
+			CreatedAt:    conv.CreatedAt,
+			UpdatedAt:    conv.UpdatedAt,
+		})
+	}
+
+	c.JSON(http.StatusOK, AdminConversationsResponse{Since: since, UserID: userID, Total: total, Data: data})
+}
+
+// GetUsage godoc
+// @Summary Platform usage
+// @Description Aggregates chat completions across users by model, provider, user or day. Reads raw completion events, so the current day is included.
+// @Tags Admin - Inspection
+// @Security BearerAuth
+// @Produce json
+// @Param by query string false "Grouping: model, provider, user or day (default model)"
+// @Param user query string false "Only this user: user ID, identity provider subject, email or username"
+// @Param since query string false "Start of the window: 7d, 12h, YYYY-MM-DD or RFC 3339 (default 7d)"
+// @Success 200 {object} AdminUsageResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid grouping or since"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "User not found"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/usage [get]
+func (h *AdminInspectionHandler) GetUsage(c *gin.Context) {
+	ctx := c.Request.Context()
+	now := time.Now().UTC()
+	since, err := analytics.ParseSince(ctx, c.Query("since"), now)
+	if err != nil {
+		responses.HandleError(c, err, "invalid since")
+		return
+	}
+	userID, ok := h.resolveUser(c)
+	if !ok {
+		return
+	}
+
+	by := strings.ToLower(strings.TrimSpace(c.DefaultQuery("by", analytics.UsageByModel)))
+	rows, err := h.analyticsService.GetPlatformUsage(ctx, analytics.UsageQuery{By: by, UserID: userID, Since: since, Until: now})
+	if err != nil {
+		responses.HandleError(c, err, "failed to get usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, AdminUsageResponse{By: by, Since: since, Until: now, UserID: userID, Data: rows})
+}
+
+// resolveUser resolves the optional user query parameter. It writes the error
+// response and returns false when the user does not exist.
+func (h *AdminInspectionHandler) resolveUser(c *gin.Context) (*uint, bool) {
+	ref := strings.TrimSpace(c.Query("user"))
+	if ref == "" {
+		return nil, true
+	}
+	usr, err := h.userService.FindByReference(c.Request.Context(), ref)
+	if err != nil {
+		responses.HandleError(c, err, "failed to find user")
+		return nil, false
+	}
+	if usr == nil {
+		responses.HandleErrorWithStatus(c, http.StatusNotFound, nil, "user not found: "+ref)
+		return nil, false
+	}
+	return &usr.ID, true
+}
@@ -0,0 +1,132 @@
+package admin
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+
+	"jan-server/services/llm-api/internal/application/audit"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// AdminMaintenanceHandler switches maintenance mode on and off.
+type AdminMaintenanceHandler struct {
+	service *maintenance.Service
+	audit   *audit.AdminAuditLogger
+}
+
+func NewAdminMaintenanceHandler(service *maintenance.Service, auditLogger *audit.AdminAuditLogger) *AdminMaintenanceHandler {
+	return &AdminMaintenanceHandler{service: service, audit: auditLogger}
+}
+
+// EnableMaintenanceRequest turns maintenance mode on
+type EnableMaintenanceRequest struct {
+	Message string     `json:"message,omitempty"` // Shown to clients; a generic message when empty
+	EndsAt  *time.Time `json:"ends_at,omitempty"` // Expected end, sent to clients as Retry-After
+}
+
+// GetMaintenance godoc
+// @Summary Get maintenance mode
+// @Description Returns whether maintenance mode is on. While it is, POST, PUT, PATCH and DELETE requests and WebSocket upgrades are answered with 503 and a `maintenance` error, except for this endpoint and the token refresh, logout and validation endpoints; reads and health checks keep working.
+// @Tags Admin - Maintenance
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} maintenance.State
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Router /v1/admin/maintenance [get]
+func (h *AdminMaintenanceHandler) GetMaintenance(c *gin.Context) {
+	if err := h.service.Refresh(c.Request.Context()); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusServiceUnavailable, err, "failed to load maintenance state: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, h.service.Current())
+}
+
+// EnableMaintenance godoc
+// @Summary Enable maintenance mode
+// @Description Turns maintenance mode on for every replica and service sharing REDIS_URL (only this replica without it); they apply it within MAINTENANCE_REFRESH_INTERVAL. Calling it again while maintenance is on updates the message and expected end.
+// @Tags Admin - Maintenance
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body EnableMaintenanceRequest false "Message and expected end"
+// @Success 200 {object} maintenance.State
+// @Failure 400 {object} responses.ErrorResponse "Invalid request or ends_at in the past"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 503 {object} responses.ErrorResponse "The state could not be stored"
+// @Router /v1/admin/maintenance [put]
+func (h *AdminMaintenanceHandler) EnableMaintenance(c *gin.Context) {
+	var req EnableMaintenanceRequest
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body: "+err.Error())
+			return
+		}
+	}
+
+	principal, _ := middleware.PrincipalFromContext(c)
+	startedBy := principal.Email
+	if startedBy == "" {
+		startedBy = principal.ID
+	}
+	state, err := h.service.Enable(c.Request.Context(), req.Message, req.EndsAt, startedBy)
+	if errors.Is(err, maintenance.ErrEndsInPast) {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, err.Error())
+		return
+	}
+	h.logAudit(c, "enable_maintenance", req, err)
+	if err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusServiceUnavailable, err, "failed to enable maintenance mode: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, state)
+}
+
+// DisableMaintenance godoc
+// @Summary Disable maintenance mode
+// @Description Turns maintenance mode off; other replicas and services apply it within MAINTENANCE_REFRESH_INTERVAL.
+// @Tags Admin - Maintenance
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} maintenance.State
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 503 {object} responses.ErrorResponse "The state could not be stored"
+// @Router /v1/admin/maintenance [delete]
+func (h *AdminMaintenanceHandler) DisableMaintenance(c *gin.Context) {
+	state, err := h.service.Disable(c.Request.Context())
+	h.logAudit(c, "disable_maintenance", nil, err)
+	if err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusServiceUnavailable, err, "failed to disable maintenance mode: "+err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, state)
+}
+
+func (h *AdminMaintenanceHandler) logAudit(c *gin.Context, action string, payload any, err error) {
+	if h.audit == nil {
+		return
+	}
+	status := http.StatusOK
+	if err != nil {
+		status = http.StatusServiceUnavailable
+	}
+	principal, _ := middleware.PrincipalFromContext(c)
+	h.audit.Log(c.Request.Context(), audit.AdminAuditEntry{
+		AdminUserID: principal.ID,
+		AdminEmail:  principal.Email,
+		Action:      action,
+		Resource:    "maintenance",
+		Payload:     payload,
+		StatusCode:  status,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+		Error:       err,
+	})
+}
@@ -0,0 +1,87 @@
+package admin
+
+import (
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/query"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// AdminReconcileHandler repairs conversation items left in progress by tool
+// runs that never reported back.
+type AdminReconcileHandler struct {
+	conversationService *conversation.ConversationService
+}
+
+func NewAdminReconcileHandler(conversationService *conversation.ConversationService) *AdminReconcileHandler {
+	return &AdminReconcileHandler{conversationService: conversationService}
+}
+
+// ReconcileResponse reports the items a reconciliation failed
+type ReconcileResponse struct {
+	ConversationID string `json:"conversation_id"`
+	OlderThan      string `json:"older_than"` // Calls in progress for longer than this were failed
+	Failed         int64  `json:"failed"`
+}
+
+// ReconcileConversation godoc
+// @Summary Reconcile stuck tool calls of a conversation
+// @Description Marks the conversation's mcp_call items that have been in_progress for longer than `older_than` as failed with a `timeout` error, as the scheduled job does across all conversations every 5 minutes. Pass `older_than=0s` to fail every call still in progress.
+// @Tags Admin - Inspection
+// @Security BearerAuth
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID"
+// @Param older_than query string false "Minimum time in progress, e.g. 5m (default MCP_CALL_TIMEOUT)"
+// @Success 200 {object} ReconcileResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid older_than"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/conversations/{conv_public_id}/reconcile [post]
+func (h *AdminReconcileHandler) ReconcileConversation(c *gin.Context) {
+	ctx := c.Request.Context()
+
+	var olderThan time.Duration
+	if cfg := config.GetGlobal(); cfg != nil {
+		olderThan = cfg.MCPCallTimeout
+	}
+	if raw := strings.TrimSpace(c.Query("older_than")); raw != "" {
+		parsed, err := time.ParseDuration(raw)
+		if err != nil || parsed < 0 {
+			responses.HandleErrorWithStatus(c, http.StatusBadRequest, nil, "older_than must be a duration such as 5m")
+			return
+		}
+		olderThan = parsed
+	} else if olderThan <= 0 {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, nil, "older_than is required while MCP_CALL_TIMEOUT is 0")
+		return
+	}
+
+	publicID := c.Param("conv_public_id")
+	limit := 1
+	conversations, _, err := h.conversationService.FindConversationsByFilter(ctx,
+		conversation.ConversationFilter{PublicID: &publicID}, &query.Pagination{Limit: &limit})
+	if err != nil {
+		responses.HandleError(c, err, "failed to find conversation")
+		return
+	}
+	if len(conversations) == 0 {
+		responses.HandleErrorWithStatus(c, http.StatusNotFound, nil, "conversation not found: "+publicID)
+		return
+	}
+
+	failed, err := h.conversationService.FailStaleMCPCalls(ctx, conversations[0], olderThan, conversation.ReconcileTriggerAdmin)
+	if err != nil {
+		responses.HandleError(c, err, "failed to reconcile conversation items")
+		return
+	}
+
+	c.JSON(http.StatusOK, ReconcileResponse{ConversationID: publicID, OlderThan: olderThan.String(), Failed: failed})
+}
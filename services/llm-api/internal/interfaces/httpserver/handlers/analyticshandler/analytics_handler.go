@@ -0,0 +1,202 @@
+package analyticshandler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/domain/analytics"
+	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// maxTopModelsLimit caps the limit query parameter of the top models endpoint
+const maxTopModelsLimit = 50
+
+// AnalyticsHandler serves the account-level analytics dashboard
+type AnalyticsHandler struct {
+	service *analytics.Service
+}
+
+// NewAnalyticsHandler creates a new analytics handler
+func NewAnalyticsHandler(service *analytics.Service) *AnalyticsHandler {
+	return &AnalyticsHandler{service: service}
+}
+
+// RangeResponse is the resolved time range of an analytics query
+type RangeResponse struct {
+	StartDate string `json:"start_date"`
+	EndDate   string `json:"end_date"`
+}
+
+// MessagesPerDayResponse lists message counts for every day of the range
+type MessagesPerDayResponse struct {
+	Range RangeResponse             `json:"range"`
+	Data  []analytics.DailyMessages `json:"data"`
+}
+
+// TopModelsResponse lists the most used models of the range
+type TopModelsResponse struct {
+	Range RangeResponse          `json:"range"`
+	Data  []analytics.ModelUsage `json:"data"`
+}
+
+// ToolUsageResponse lists tool calls per tool for the range
+type ToolUsageResponse struct {
+	Range RangeResponse         `json:"range"`
+	Data  []analytics.ToolUsage `json:"data"`
+}
+
+// LatencyResponse holds the average response latency for the range
+type LatencyResponse struct {
+	Range RangeResponse `json:"range"`
+	analytics.LatencySummary
+}
+
+// GetMessagesPerDay godoc
+// @Summary Messages per day
+// @Description Returns the number of user and assistant messages in the authenticated user's conversations for each day of the range. Figures come from nightly rollups and cover complete UTC days.
+// @Tags Analytics API
+// @Security BearerAuth
+// @Produce json
+// @Param range query string false "Preset range: 7d, 30d, 90d or 365d (default 30d)"
+// @Param start_date query string false "Start date (YYYY-MM-DD); overrides range"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} MessagesPerDayResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid range or dates"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/analytics/messages [get]
+func (h *AnalyticsHandler) GetMessagesPerDay(c *gin.Context) {
+	userID, r, ok := h.resolveRequest(c)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.GetMessagesPerDay(c.Request.Context(), userID, r)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get messages per day")
+		return
+	}
+
+	c.JSON(http.StatusOK, MessagesPerDayResponse{Range: toRangeResponse(r), Data: data})
+}
+
+// GetTopModels godoc
+// @Summary Top models
+// @Description Returns the models the authenticated user called most in the range, with token totals and average response latency
+// @Tags Analytics API
+// @Security BearerAuth
+// @Produce json
+// @Param range query string false "Preset range: 7d, 30d, 90d or 365d (default 30d)"
+// @Param start_date query string false "Start date (YYYY-MM-DD); overrides range"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param limit query int false "Maximum number of models (default 10, max 50)"
+// @Success 200 {object} TopModelsResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid range or dates"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/analytics/models [get]
+func (h *AnalyticsHandler) GetTopModels(c *gin.Context) {
+	userID, r, ok := h.resolveRequest(c)
+	if !ok {
+		return
+	}
+
+	limit := analytics.DefaultTopModelsLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxTopModelsLimit)
+		}
+	}
+
+	data, err := h.service.GetTopModels(c.Request.Context(), userID, r, limit)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get top models")
+		return
+	}
+
+	c.JSON(http.StatusOK, TopModelsResponse{Range: toRangeResponse(r), Data: data})
+}
+
+// GetToolUsage godoc
+// @Summary Tool usage breakdown
+// @Description Returns how often the model called each tool for the authenticated user in the range, with each tool's share of all calls
+// @Tags Analytics API
+// @Security BearerAuth
+// @Produce json
+// @Param range query string false "Preset range: 7d, 30d, 90d or 365d (default 30d)"
+// @Param start_date query string false "Start date (YYYY-MM-DD); overrides range"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} ToolUsageResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid range or dates"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/analytics/tools [get]
+func (h *AnalyticsHandler) GetToolUsage(c *gin.Context) {
+	userID, r, ok := h.resolveRequest(c)
+	if !ok {
+		return
+	}
+
+	data, err := h.service.GetToolUsage(c.Request.Context(), userID, r)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get tool usage")
+		return
+	}
+
+	c.JSON(http.StatusOK, ToolUsageResponse{Range: toRangeResponse(r), Data: data})
+}
+
+// GetLatency godoc
+// @Summary Average response latency
+// @Description Returns the average model response latency of the authenticated user's successful completions in the range, with a daily breakdown and failure counts
+// @Tags Analytics API
+// @Security BearerAuth
+// @Produce json
+// @Param range query string false "Preset range: 7d, 30d, 90d or 365d (default 30d)"
+// @Param start_date query string false "Start date (YYYY-MM-DD); overrides range"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Success 200 {object} LatencyResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid range or dates"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/analytics/latency [get]
+func (h *AnalyticsHandler) GetLatency(c *gin.Context) {
+	userID, r, ok := h.resolveRequest(c)
+	if !ok {
+		return
+	}
+
+	summary, err := h.service.GetLatency(c.Request.Context(), userID, r)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get latency")
+		return
+	}
+
+	c.JSON(http.StatusOK, LatencyResponse{Range: toRangeResponse(r), LatencySummary: *summary})
+}
+
+// resolveRequest returns the authenticated user's ID and the selected range, writing
+// the error response itself when either is missing or invalid.
+func (h *AnalyticsHandler) resolveRequest(c *gin.Context) (uint, analytics.TimeRange, bool) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return 0, analytics.TimeRange{}, false
+	}
+
+	r, err := analytics.ParseTimeRange(c.Request.Context(), c.Query("range"), c.Query("start_date"), c.Query("end_date"), time.Now())
+	if err != nil {
+		responses.HandleError(c, err, "invalid time range")
+		return 0, analytics.TimeRange{}, false
+	}
+
+	return user.ID, r, true
+}
+
+func toRangeResponse(r analytics.TimeRange) RangeResponse {
+	return RangeResponse{StartDate: r.StartDate(), EndDate: r.EndDate()}
+}
@@ -3,6 +3,7 @@ package apikeyhandler
 import (
 	"errors"
 	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -10,6 +11,7 @@ import (
 
 	"jan-server/services/llm-api/internal/domain/apikey"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/requests"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
 )
 
@@ -95,12 +97,42 @@ func (h *Handler) List(c *gin.Context) {
 		return
 	}
 
-	items, err := h.service.ListKeys(c.Request.Context(), user.ID)
+	limit := 20
+	if raw := c.Query("limit"); raw != "" {
+		parsed, err := strconv.Atoi(raw)
+		if err != nil || parsed < 1 || parsed > 100 {
+			responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "limit must be between 1 and 100")
+			return
+		}
+		limit = parsed
+	}
+
+	scope := requests.APIKeyCursorScope(user.ID)
+	var after *apikey.ListCursor
+	token := c.Query("after")
+	if token == "" {
+		token = c.Query("cursor")
+	}
+	if token != "" {
+		cursor, err := requests.DecodeCursor(scope, token)
+		if err != nil || cursor.Key == "" {
+			responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid pagination cursor")
+			return
+		}
+		after = &apikey.ListCursor{CreatedAt: time.Unix(0, cursor.Time), ID: cursor.Key}
+	}
+
+	// Fetch one extra key to know whether another page exists
+	items, err := h.service.ListKeys(c.Request.Context(), user.ID, after, limit+1)
 	if err != nil {
 		h.logger.Error().Err(err).Msg("failed to list api keys")
 		responses.HandleError(c, err, "failed to list api keys")
 		return
 	}
+	hasMore := len(items) > limit
+	if hasMore {
+		items = items[:limit]
+	}
 
 	resp := make([]apiKeyResponse, 0, len(items))
 	for _, item := range items {
@@ -117,7 +149,22 @@ func (h *Handler) List(c *gin.Context) {
 		})
 	}
 
-	c.JSON(http.StatusOK, gin.H{"items": resp})
+	var nextCursor string
+	if hasMore {
+		last := items[len(items)-1]
+		nextCursor, err = requests.EncodeCursor(scope, requests.Cursor{Time: last.CreatedAt.UnixNano(), Key: last.ID, Order: "desc"})
+		if err != nil {
+			h.logger.Error().Err(err).Msg("failed to encode api key cursor")
+			responses.HandleError(c, err, "failed to list api keys")
+			return
+		}
+	}
+
+	body := gin.H{"items": resp, "has_more": hasMore}
+	if nextCursor != "" {
+		body["next_cursor"] = nextCursor
+	}
+	c.JSON(http.StatusOK, body)
 }
 
 // Delete revokes the specified API key.
@@ -0,0 +1,152 @@
+package authhandler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/infrastructure/keycloak"
+)
+
+// GrantTypeDeviceCode is the OAuth grant type of RFC 8628 device authorization.
+const GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+// DeviceAuthHandler runs the device authorization grant (RFC 8628) for jan-cli and
+// headless devices: the device shows a code, the user approves it in any browser,
+// and the device polls until tokens are issued
+type DeviceAuthHandler struct {
+	kc                *keycloak.Client
+	keycloakBaseURL   string
+	keycloakPublicURL string
+	realm             string
+	logger            zerolog.Logger
+}
+
+// NewDeviceAuthHandler creates a new device authorization handler
+func NewDeviceAuthHandler(kc *keycloak.Client, cfg *config.Config, logger zerolog.Logger) *DeviceAuthHandler {
+	return &DeviceAuthHandler{
+		kc:                kc,
+		keycloakBaseURL:   strings.TrimRight(cfg.KeycloakBaseURL, "/"),
+		keycloakPublicURL: strings.TrimRight(cfg.KeycloakPublicURL, "/"),
+		realm:             cfg.KeycloakRealm,
+		logger:            logger,
+	}
+}
+
+// DeviceCodeRequest starts a device login
+type DeviceCodeRequest struct {
+	Scope string `form:"scope" json:"scope"` // Extra scopes, e.g. offline_access for a refresh token that outlives the session
+}
+
+// DeviceCodeResponse tells the device what to show the user and how often to poll
+type DeviceCodeResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete,omitempty"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceTokenRequest polls for the tokens of a device login
+type DeviceTokenRequest struct {
+	GrantType  string `form:"grant_type" json:"grant_type"` // Optional; must be the device_code grant when set
+	DeviceCode string `form:"device_code" json:"device_code"`
+}
+
+// StartDeviceLogin handles POST /auth/device/code
+func (h *DeviceAuthHandler) StartDeviceLogin(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	var req DeviceCodeRequest
+	if err := c.ShouldBind(&req); err != nil && !errors.Is(err, io.EOF) {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "invalid request body")
+		return
+	}
+
+	authorization, err := h.kc.StartDeviceAuthorization(c.Request.Context(), req.Scope)
+	if err != nil {
+		h.keycloakError(c, err, "device authorization failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, DeviceCodeResponse{
+		DeviceCode:              authorization.DeviceCode,
+		UserCode:                authorization.UserCode,
+		VerificationURI:         h.publicURL(authorization.VerificationURI),
+		VerificationURIComplete: h.publicURL(authorization.VerificationURIComplete),
+		ExpiresIn:               authorization.ExpiresIn,
+		Interval:                authorization.Interval,
+	})
+}
+
+// PollDeviceLogin handles POST /auth/device/token. Until the user approves, it answers
+// 400 with authorization_pending (or slow_down when polled too often).
+func (h *DeviceAuthHandler) PollDeviceLogin(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+
+	var req DeviceTokenRequest
+	if err := c.ShouldBind(&req); err != nil {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "invalid request body")
+		return
+	}
+	if req.GrantType != "" && req.GrantType != GrantTypeDeviceCode {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "grant_type must be "+GrantTypeDeviceCode)
+		return
+	}
+	if req.DeviceCode == "" {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "device_code is required")
+		return
+	}
+
+	tokens, err := h.kc.PollDeviceToken(c.Request.Context(), req.DeviceCode)
+	if err != nil {
+		h.keycloakError(c, err, "device token request failed")
+		return
+	}
+
+	c.JSON(http.StatusOK, AccessTokenResponse{
+		AccessToken:  tokens.AccessToken,
+		RefreshToken: tokens.RefreshToken,
+		TokenType:    tokens.TokenType,
+		ExpiresIn:    tokens.ExpiresIn,
+		ExpiresAt:    time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second),
+	})
+}
+
+// ApproveDeviceLogin handles GET /auth/device, the page users open to approve a device.
+// It sends them to Keycloak's device page, which signs them in and asks for consent.
+func (h *DeviceAuthHandler) ApproveDeviceLogin(c *gin.Context) {
+	target := h.keycloakPublicURL + "/realms/" + url.PathEscape(h.realm) + "/device"
+	if userCode := strings.TrimSpace(c.Query("user_code")); userCode != "" {
+		target += "?" + url.Values{"user_code": {userCode}}.Encode()
+	}
+	c.Redirect(http.StatusFound, target)
+}
+
+// publicURL rewrites a Keycloak URL built from the internal base URL to the one
+// browsers reach
+func (h *DeviceAuthHandler) publicURL(raw string) string {
+	if raw == "" || h.keycloakPublicURL == h.keycloakBaseURL || !strings.HasPrefix(raw, h.keycloakBaseURL) {
+		return raw
+	}
+	return h.keycloakPublicURL + strings.TrimPrefix(raw, h.keycloakBaseURL)
+}
+
+// keycloakError relays OAuth errors from Keycloak and hides everything else behind a 502
+func (h *DeviceAuthHandler) keycloakError(c *gin.Context, err error, message string) {
+	var tokenErr *keycloak.TokenError
+	if errors.As(err, &tokenErr) && tokenErr.StatusCode < http.StatusInternalServerError {
+		oauthError(c, tokenErr.StatusCode, tokenErr.Code, tokenErr.Description)
+		return
+	}
+	h.logger.Error().Err(err).Msg(message)
+	oauthError(c, http.StatusBadGateway, "server_error", message)
+}
@@ -0,0 +1,164 @@
+package authhandler
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/integration"
+	authvalidator "jan-server/services/llm-api/internal/infrastructure/auth"
+	"jan-server/services/llm-api/internal/infrastructure/keycloak"
+)
+
+// TokenExchangeHandler lets registered third-party integrations exchange a user's access
+// token for a narrowly scoped Jan API token (RFC 8693), so users never hand them API keys
+type TokenExchangeHandler struct {
+	kc        *keycloak.Client
+	validator *authvalidator.KeycloakValidator
+	enabled   bool
+	clients   []string
+	logger    zerolog.Logger
+}
+
+// NewTokenExchangeHandler creates a new token exchange handler
+func NewTokenExchangeHandler(kc *keycloak.Client, validator *authvalidator.KeycloakValidator, cfg *config.Config, logger zerolog.Logger) *TokenExchangeHandler {
+	return &TokenExchangeHandler{
+		kc:        kc,
+		validator: validator,
+		enabled:   cfg.TokenExchangeEnabled,
+		clients:   cfg.IntegrationClients,
+		logger:    logger,
+	}
+}
+
+// TokenExchangeRequest is an RFC 8693 token exchange request, sent form-encoded or as JSON.
+// Client credentials may also be sent with HTTP Basic authentication.
+type TokenExchangeRequest struct {
+	GrantType          string `form:"grant_type" json:"grant_type"`
+	ClientID           string `form:"client_id" json:"client_id"`
+	ClientSecret       string `form:"client_secret" json:"client_secret"`
+	SubjectToken       string `form:"subject_token" json:"subject_token"`
+	SubjectTokenType   string `form:"subject_token_type" json:"subject_token_type"`
+	RequestedTokenType string `form:"requested_token_type" json:"requested_token_type"`
+	Scope              string `form:"scope" json:"scope"`       // Space-delimited integration scopes
+	Audience           string `form:"audience" json:"audience"` // Optional target client of the new token
+}
+
+// TokenExchangeResponse is the token issued to the integration
+type TokenExchangeResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	Scope           string `json:"scope"`
+	RefreshToken    string `json:"refresh_token,omitempty"`
+}
+
+// ExchangeToken handles POST /auth/token-exchange
+func (h *TokenExchangeHandler) ExchangeToken(c *gin.Context) {
+	c.Header("Cache-Control", "no-store")
+	if !h.enabled {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "token exchange is disabled")
+		return
+	}
+
+	var req TokenExchangeRequest
+	if err := c.ShouldBind(&req); err != nil {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "invalid request body")
+		return
+	}
+	if id, secret, ok := c.Request.BasicAuth(); ok {
+		if req.ClientID != "" && req.ClientID != id {
+			oauthError(c, http.StatusBadRequest, "invalid_request", "client_id does not match the basic authentication credentials")
+			return
+		}
+		req.ClientID, req.ClientSecret = id, secret
+	}
+
+	if req.GrantType != integration.GrantTypeTokenExchange {
+		oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "grant_type must be "+integration.GrantTypeTokenExchange)
+		return
+	}
+	if req.ClientID == "" || !slices.Contains(h.clients, req.ClientID) {
+		oauthError(c, http.StatusUnauthorized, "invalid_client", "client is not a registered integration")
+		return
+	}
+	if req.SubjectToken == "" {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "subject_token is required")
+		return
+	}
+	if req.SubjectTokenType != "" && req.SubjectTokenType != integration.TokenTypeAccessToken {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "subject_token_type must be "+integration.TokenTypeAccessToken)
+		return
+	}
+	if req.RequestedTokenType != "" && req.RequestedTokenType != integration.TokenTypeAccessToken {
+		oauthError(c, http.StatusBadRequest, "invalid_request", "requested_token_type must be "+integration.TokenTypeAccessToken)
+		return
+	}
+
+	scopes := integration.ParseScope(req.Scope)
+	if len(scopes) == 0 {
+		oauthError(c, http.StatusBadRequest, "invalid_scope", "scope is required")
+		return
+	}
+	for _, scope := range scopes {
+		if !integration.IsScope(scope) {
+			oauthError(c, http.StatusBadRequest, "invalid_scope", "unknown scope "+scope)
+			return
+		}
+	}
+
+	claims, err := h.validator.Validate(c.Request.Context(), req.SubjectToken)
+	if err != nil {
+		oauthError(c, http.StatusBadRequest, "invalid_grant", "subject_token is invalid or expired")
+		return
+	}
+	// An integration token only trades down: it cannot gain scopes it was not granted
+	if slices.Contains(h.clients, claims.AuthorizedParty) {
+		for _, scope := range scopes {
+			if !slices.Contains(claims.Scopes, scope) {
+				oauthError(c, http.StatusBadRequest, "invalid_scope", "subject_token was not granted "+scope)
+				return
+			}
+		}
+	}
+
+	tokens, err := h.kc.ExchangeToken(c.Request.Context(), keycloak.TokenExchangeRequest{
+		ClientID:     req.ClientID,
+		ClientSecret: req.ClientSecret,
+		SubjectToken: req.SubjectToken,
+		Scope:        strings.Join(scopes, " "),
+		Audience:     req.Audience,
+	})
+	if err != nil {
+		var tokenErr *keycloak.TokenError
+		if errors.As(err, &tokenErr) && tokenErr.StatusCode < http.StatusInternalServerError {
+			h.logger.Warn().Str("client_id", req.ClientID).Str("error", tokenErr.Code).Msg("token exchange rejected by keycloak")
+			oauthError(c, tokenErr.StatusCode, tokenErr.Code, tokenErr.Description)
+			return
+		}
+		h.logger.Error().Err(err).Str("client_id", req.ClientID).Msg("token exchange failed")
+		oauthError(c, http.StatusBadGateway, "server_error", "token exchange failed")
+		return
+	}
+
+	h.logger.Info().
+		Str("client_id", req.ClientID).
+		Str("subject", claims.Subject).
+		Strs("scopes", scopes).
+		Msg("issued integration token")
+
+	c.JSON(http.StatusOK, TokenExchangeResponse{
+		AccessToken:     tokens.AccessToken,
+		IssuedTokenType: integration.TokenTypeAccessToken,
+		TokenType:       tokens.TokenType,
+		ExpiresIn:       tokens.ExpiresIn,
+		Scope:           tokens.Scope,
+		RefreshToken:    tokens.RefreshToken,
+	})
+}
@@ -41,6 +41,13 @@ type AccessTokenResponse struct {
 	ExpiresAt    time.Time `json:"expires_at"`
 }
 
+// OAuthErrorResponse is an OAuth error (RFC 6749 section 5.2), returned by the
+// OAuth endpoints so client libraries can parse it
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
 type GetMeResponse struct {
 	ID         string   `json:"id"`
 	Username   string   `json:"username,omitempty"`
@@ -280,3 +287,8 @@ func (h *TokenHandler) RefreshToken(c *gin.Context) {
 		ExpiresAt:    time.Now().Add(time.Duration(tokens.ExpiresIn) * time.Second),
 	})
 }
+
+// oauthError aborts with an OAuth error response
+func oauthError(c *gin.Context, status int, code, description string) {
+	c.AbortWithStatusJSON(status, OAuthErrorResponse{Error: code, ErrorDescription: description})
+}
@@ -0,0 +1,68 @@
+package chathandler
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/infrastructure/livekit"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// roomForBroadcast validates the request's realtime options and returns the
+// LiveKit room its deltas are streamed to, or "" when it asks for none. The
+// caller must be connected to the room, under the identity the realtime API
+// gave their session.
+func (h *ChatHandler) roomForBroadcast(ctx context.Context, reqCtx *gin.Context, request chatrequests.ChatCompletionRequest) (string, error) {
+	if request.Realtime == nil {
+		return "", nil
+	}
+	if !request.Stream {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+			"realtime room streaming requires stream: true", nil, "2b50350d-25f7-4e30-8f69-4f0f774f97b3")
+	}
+	if h.liveKit == nil {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+			"realtime room streaming is not available: LiveKit is not configured", nil, "24e7ec49-518f-4bbe-9a55-43bc7e1dda61")
+	}
+	room := strings.TrimSpace(request.Realtime.RoomID)
+	if !idgen.ValidateIDFormat(room, "room") {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+			"realtime.room_id must be the room_id of a realtime session", nil, "3593d125-e177-41fd-aa0d-b85834fcf68f")
+	}
+
+	var identities []string
+	if principal, ok := middleware.PrincipalFromContext(reqCtx); ok {
+		identities = append(identities, principal.ID, principal.Subject)
+	}
+	joined, err := h.liveKit.IsParticipant(ctx, room, identities...)
+	if err != nil {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeExternal,
+			"failed to look up the participants of the realtime room", err, "c3550725-3ebf-4136-988a-b56f4179dbd1")
+	}
+	if !joined {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeForbidden,
+			"you must be connected to the realtime room to stream to it", nil, "c2d9e819-5807-4844-b93a-04ce6a184b6b")
+	}
+	return room, nil
+}
+
+// closeRoomStream ends the room stream with the status matching how the
+// completion ended.
+func closeRoomStream(reqCtx *gin.Context, stream *livekit.RoomStream, err error) {
+	if stream == nil {
+		return
+	}
+	switch {
+	case err == nil:
+		stream.Close(livekit.StatusCompleted)
+	case reqCtx.Request.Context().Err() != nil:
+		stream.Close(livekit.StatusCancelled)
+	default:
+		stream.Close(livekit.StatusFailed)
+	}
+}
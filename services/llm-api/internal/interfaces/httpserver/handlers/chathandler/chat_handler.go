@@ -2,7 +2,9 @@ package chathandler
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -12,15 +14,24 @@ import (
 	"go.opentelemetry.io/otel/codes"
 
 	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/admission"
+	"jan-server/services/llm-api/internal/domain/analytics"
 	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/guestquota"
+	"jan-server/services/llm-api/internal/domain/mcptool"
 	domainmodel "jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/domain/persona"
 	"jan-server/services/llm-api/internal/domain/project"
 	"jan-server/services/llm-api/internal/domain/prompt"
 	"jan-server/services/llm-api/internal/domain/usersettings"
 	"jan-server/services/llm-api/internal/infrastructure/inference"
+	"jan-server/services/llm-api/internal/infrastructure/livekit"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
 	memclient "jan-server/services/llm-api/internal/infrastructure/memory"
 	"jan-server/services/llm-api/internal/infrastructure/metrics"
 	"jan-server/services/llm-api/internal/infrastructure/observability"
+	"jan-server/services/llm-api/internal/infrastructure/streamstate"
+	"jan-server/services/llm-api/internal/infrastructure/tts"
 	conversationHandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/conversationhandler"
 	modelHandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelhandler"
 	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
@@ -34,6 +45,13 @@ import (
 
 const ConversationReferrerContextKey = "conversation_referrer"
 
+// ProjectInstructionVersionHeader reports the project instruction version a completion used
+const ProjectInstructionVersionHeader = "X-Project-Instruction-Version"
+
+// MaxConversationHistoryItems caps how many trailing branch items are loaded as
+// chat history per turn. Older items would be dropped by context trimming anyway.
+const MaxConversationHistoryItems = 200
+
 // ChatCompletionResult wraps the response with conversation context
 type ChatCompletionResult struct {
 	Response          *openai.ChatCompletionResponse
@@ -52,6 +70,13 @@ type ChatHandler struct {
 	promptProcessor     *prompt.ProcessorImpl
 	memoryHandler       *MemoryHandler
 	userSettingsService *usersettings.Service
+	analyticsService    *analytics.Service
+	personaService      *persona.Service
+	admission           *admission.Controller
+	streams             *streamRegistry
+	ttsClient           *tts.Client
+	liveKit             *livekit.Client
+	guestQuota          *guestquota.Service
 }
 
 // NewChatHandler creates a new chat handler
@@ -64,6 +89,13 @@ func NewChatHandler(
 	promptProcessor *prompt.ProcessorImpl,
 	memoryHandler *MemoryHandler,
 	userSettingsService *usersettings.Service,
+	analyticsService *analytics.Service,
+	personaService *persona.Service,
+	admissionController *admission.Controller,
+	streamStore *streamstate.Store,
+	ttsClient *tts.Client,
+	liveKitClient *livekit.Client,
+	guestQuota *guestquota.Service,
 ) *ChatHandler {
 	return &ChatHandler{
 		inferenceProvider:   inferenceProvider,
@@ -74,6 +106,13 @@ func NewChatHandler(
 		promptProcessor:     promptProcessor,
 		memoryHandler:       memoryHandler,
 		userSettingsService: userSettingsService,
+		analyticsService:    analyticsService,
+		personaService:      personaService,
+		admission:           admissionController,
+		streams:             newStreamRegistry(streamStore),
+		ttsClient:           ttsClient,
+		liveKit:             liveKitClient,
+		guestQuota:          guestQuota,
 	}
 }
 
@@ -102,6 +141,7 @@ func (h *ChatHandler) CreateChatCompletion(
 	var conv *conversation.Conversation
 	var conversationID string
 	var projectInstruction string
+	var activeProject *project.Project
 	var err error
 	newMessages := append([]openai.ChatCompletionMessage(nil), request.Messages...)
 
@@ -130,10 +170,15 @@ func (h *ChatHandler) CreateChatCompletion(
 		observability.AddSpanAttributes(ctx,
 			attribute.String("conversation.id", conversationID),
 		)
-		request.Messages = h.prependConversationItems(conv, request.Messages)
+		request.Messages = h.prependConversationItems(ctx, conv, request.Messages)
 
 		// Load project instruction for this conversation (if any)
-		projectInstruction = h.getProjectInstruction(ctx, userID, conv)
+		var instructionVersion int
+		activeProject = h.getConversationProject(ctx, userID, conv)
+		projectInstruction, instructionVersion = h.getProjectInstruction(ctx, conv, activeProject)
+		if instructionVersion > 0 {
+			reqCtx.Header(ProjectInstructionVersionHeader, strconv.Itoa(instructionVersion))
+		}
 	}
 	// If no conversation.id exists, bypass as non-conversation completion
 
@@ -165,6 +210,52 @@ func (h *ChatHandler) CreateChatCompletion(
 		}
 	}
 
+	// Apply the persona's default model and tool selection before the model is resolved
+	activePersona, err := h.resolvePersona(ctx, reqCtx, userID, request.PersonaID)
+	if err != nil {
+		observability.RecordError(ctx, err)
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to load persona")
+	}
+	if activePersona != nil {
+		observability.AddSpanAttributes(ctx, attribute.String("persona.id", activePersona.PublicID))
+		if strings.TrimSpace(request.Model) == "" && activePersona.DefaultModel != nil {
+			request.Model = *activePersona.DefaultModel
+		}
+		request.Tools = filterPersonaTools(request.Tools, activePersona)
+		if len(request.Tools) == 0 {
+			request.ToolChoice = nil
+		}
+	}
+
+	// Apply the project's tool defaults, so clients can send every tool they offer
+	if activeProject != nil && len(activeProject.DefaultTools) > 0 {
+		request.Tools = filterProjectTools(request.Tools, activeProject.DefaultTools)
+		if len(request.Tools) == 0 {
+			request.ToolChoice = nil
+		}
+	}
+
+	// Advertise a file_search_query tool over the project's knowledge base
+	if activeProject != nil && len(activeProject.KnowledgeCollections) > 0 &&
+		activeProject.DefaultTools.Allows(mcptool.ToolKeyFileSearchQuery) &&
+		(activePersona == nil || activePersona.AllowsTool(mcptool.ToolKeyFileSearchQuery)) {
+		request.Tools = withKnowledgeTool(request.Tools, activeProject.KnowledgeCollections)
+	}
+
+	// Resolve model aliases; each user stays on one channel while an alias rolls out
+	aliasResolution, err := h.providerHandler.ResolveModelAlias(ctx, request.Model, strconv.FormatUint(uint64(userID), 10))
+	if err != nil {
+		observability.RecordError(ctx, err)
+		return nil, err
+	}
+	if aliasResolution != nil {
+		observability.AddSpanAttributes(ctx,
+			attribute.String("model.alias", aliasResolution.Alias),
+			attribute.String("model.alias_channel", aliasResolution.Channel),
+		)
+		request.Model = aliasResolution.Model
+	}
+
 	// Get provider based on the requested model
 	observability.AddSpanEvent(ctx, "selecting_provider")
 	selectedProviderModel, selectedProvider, err := h.providerHandler.SelectProviderModelForModelPublicID(ctx, request.Model)
@@ -185,6 +276,9 @@ func (h *ChatHandler) CreateChatCompletion(
 		return nil, err
 	}
 
+	// Guest model limits apply to the model asked for, not the instruct model it may switch to
+	requestedModelID := selectedProviderModel.ModelPublicID
+
 	imageRequested := request.Image != nil && *request.Image
 
 	if imageRequested && request.EnableThinking != nil && !*request.EnableThinking {
@@ -206,6 +300,26 @@ func (h *ChatHandler) CreateChatCompletion(
 		}
 	}
 
+	reasoning, err := newReasoningSettings(ctx, request, selectedProviderModel)
+	if err != nil {
+		observability.RecordError(ctx, err)
+		return nil, err
+	}
+	speech, err := h.newSpeechOutput(ctx, request)
+	if err != nil {
+		observability.RecordError(ctx, err)
+		return nil, err
+	}
+	broadcastRoom, err := h.roomForBroadcast(ctx, reqCtx, request)
+	if err != nil {
+		observability.RecordError(ctx, err)
+		return nil, err
+	}
+	if err := h.admitGuest(ctx, reqCtx, requestedModelID); err != nil {
+		observability.RecordError(ctx, err)
+		return nil, err
+	}
+
 	// Add provider information to span
 	observability.AddSpanAttributes(ctx,
 		attribute.String("provider.display_name", selectedProvider.DisplayName),
@@ -263,6 +377,26 @@ func (h *ChatHandler) CreateChatCompletion(
 			profileSettings = &userSettings.ProfileSettings
 		}
 
+		// Locale comes from the user's locale setting, falling back to Accept-Language
+		language := prompt.NormalizeLanguage(reqCtx.GetHeader("Accept-Language"))
+		if userSettings != nil {
+			if locale := prompt.NormalizeLanguage(userSettings.Locale()); locale != "" {
+				language = locale
+			}
+			if respondIn := userSettings.RespondIn(); respondIn != "" {
+				preferences["respond_in"] = respondIn
+			}
+		}
+		if respondIn := strings.TrimSpace(reqCtx.GetHeader("X-Respond-In")); respondIn != "" {
+			preferences["respond_in"] = respondIn
+		}
+
+		// Timezone comes from X-Timezone, falling back to the user's timezone setting
+		timezone := prompt.NormalizeTimezone(reqCtx.GetHeader("X-Timezone"))
+		if timezone == "" && userSettings != nil {
+			timezone = prompt.NormalizeTimezone(userSettings.Timezone())
+		}
+
 		// Get model catalog ID for model-specific template resolution
 		var modelCatalogID *string
 		if modelCatalog != nil && modelCatalog.PublicID != "" {
@@ -272,11 +406,13 @@ func (h *ChatHandler) CreateChatCompletion(
 		promptCtx := &prompt.Context{
 			UserID:             userID,
 			ConversationID:     conversationID,
-			Language:           strings.TrimSpace(reqCtx.GetHeader("Accept-Language")),
+			Language:           language,
+			Timezone:           timezone,
 			Preferences:        preferences,
 			Memory:             loadedMemory,
 			ProjectInstruction: projectInstruction,
 			Profile:            profileSettings,
+			Persona:            activePersona,
 			ModelCatalogID:     modelCatalogID,
 			Tools:              request.Tools,
 		}
@@ -382,26 +518,161 @@ func (h *ChatHandler) CreateChatCompletion(
 		ChatCompletionRequest: request.ChatCompletionRequest,
 		TopK:                  request.TopK,
 		RepetitionPenalty:     request.RepetitionPenalty,
+		Speech:                speech,
 	}
 	if modelCatalog != nil {
 		h.applyModelDefaultsFromCatalog(&llmRequest, modelCatalog)
 	}
+	reasoning.apply(ctx, &llmRequest, selectedProvider, selectedProviderModel)
 
 	observability.AddSpanEvent(ctx, "calling_llm")
 
 	llmStartTime := time.Now()
-	if request.Stream {
-		response, err = h.streamCompletion(ctx, reqCtx, chatClient, conv, llmRequest)
-	} else {
-		response, err = h.callCompletion(ctx, chatClient, llmRequest)
+	var completionItemID string
+	if request.Stream && conv != nil {
+		// Conversation-bound streams can be stopped through CancelStream
+		if streamID, genErr := idgen.GenerateSecureID("strm", 16); genErr == nil {
+			reqCtx.Header(StreamIDHeader, streamID)
+			streamCtx, release := h.streams.track(reqCtx.Request.Context(), streamID, userID, conv.PublicID)
+			defer release()
+			reqCtx.Request = reqCtx.Request.WithContext(streamCtx)
+			observability.AddSpanAttributes(ctx, attribute.String("completion.stream_id", streamID))
+		}
+		// Announced up front so clients can truncate the reply once it is stored
+		if id, genErr := idgen.GenerateSecureID("msg", 16); genErr == nil {
+			completionItemID = id
+			reqCtx.Header(ItemIDHeader, id)
+		}
+	}
+	var roomStream *livekit.RoomStream
+	if broadcastRoom != "" {
+		// Participants of the shared room see the reply as it is generated
+		conversationPublicID := ""
+		if conv != nil {
+			conversationPublicID = conv.PublicID
+		}
+		roomStream = h.liveKit.StartRoomStream(broadcastRoom, conversationPublicID, completionItemID)
+		llmRequest.Broadcast = roomStream
+		observability.AddSpanAttributes(ctx, attribute.String("completion.realtime_room", broadcastRoom))
+	}
+
+	callLLM := func(client *chat.ChatCompletionClient, providerModel *domainmodel.ProviderModel, provider *domainmodel.Provider) (*openai.ChatCompletionResponse, error) {
+		release, admitErr := h.admit(ctx, userID, providerModel, provider)
+		if admitErr != nil {
+			return nil, admitErr
+		}
+		defer release()
+		if request.Stream {
+			return h.streamCompletion(ctx, reqCtx, client, conv, llmRequest)
+		}
+		return h.callCompletion(ctx, client, llmRequest)
+	}
+	response, err = callLLM(chatClient, selectedProviderModel, selectedProvider)
+
+	fallbackPolicy := completionFallbackPolicy()
+	if err != nil && fallbackPolicy == config.CompletionFallbackNextProvider {
+		// Retry on the model's other providers while nothing has reached the client yet
+		triedProviderIDs := []uint{selectedProvider.ID}
+		for err != nil && isRetryableOnOtherProvider(err) && reqCtx.Request.Context().Err() == nil && (!request.Stream || reqCtx.Writer.Size() <= 0) {
+			nextModel, nextProvider, selectErr := h.providerHandler.SelectAlternateProviderModel(ctx, selectedProviderModel.ModelPublicID, triedProviderIDs)
+			if selectErr != nil || nextModel == nil || nextProvider == nil {
+				break
+			}
+			triedProviderIDs = append(triedProviderIDs, nextProvider.ID)
+
+			nextClient, clientErr := h.inferenceProvider.GetChatCompletionClient(ctx, nextProvider)
+			if clientErr != nil {
+				continue
+			}
+
+			observability.RecordError(ctx, err)
+			if !isAdmissionRejection(err) {
+				metrics.RecordProviderError(selectedProvider.DisplayName, providerErrorKind(err))
+			}
+			observability.AddSpanEvent(ctx, "completion_retry_next_provider",
+				attribute.String("failed_provider", selectedProvider.PublicID),
+				attribute.String("next_provider", nextProvider.PublicID),
+				attribute.String("error", err.Error()),
+			)
+
+			selectedProviderModel, selectedProvider = nextModel, nextProvider
+			request.Model = nextModel.ProviderOriginalModelID
+			llmRequest.ChatCompletionRequest.Model = nextModel.ProviderOriginalModelID
+			reasoning.apply(ctx, &llmRequest, nextProvider, nextModel)
+			response, err = callLLM(nextClient, nextModel, nextProvider)
+		}
 	}
 	llmDuration := time.Since(llmStartTime)
+	closeRoomStream(reqCtx, roomStream, err)
+
+	if isAdmissionRejection(err) {
+		// Nothing reached the provider; tell the client to back off
+		observability.AddSpanAttributes(ctx, attribute.String("completion.status", "admission_rejected"))
+		return nil, err
+	}
+
+	storeConversation := true
+	if request.Store != nil {
+		storeConversation = *request.Store
+	}
+	storeReasoning := reasoning.storage(request.StoreReasoning != nil && *request.StoreReasoning)
+
+	// The client already saw what was streamed before the failure: keep it as an
+	// incomplete assistant turn rather than dropping it or storing the fallback
+	partialStored := false
+	if err != nil && request.Stream && conv != nil && storeConversation {
+		partialStored = h.storePartialCompletion(ctx, reqCtx, conv, newMessages, response, storeReasoning, completionItemID, speech, err)
+	}
+
+	if err != nil && reqCtx != nil && reqCtx.Request.Context().Err() != nil {
+		// The client disconnected or the stream was stopped through CancelStream,
+		// and the upstream request was cancelled. Record the tokens generated so far.
+		stopped := errors.Is(context.Cause(reqCtx.Request.Context()), ErrStreamCancelled)
+		if stopped {
+			// The client is still listening: end the stream the way a finished one ends
+			_, _ = reqCtx.Writer.Write([]byte("data: [DONE]\n\n"))
+			reqCtx.Writer.Flush()
+		}
+		if response != nil {
+			observability.AddSpanAttributes(ctx,
+				attribute.Int("completion.prompt_tokens", response.Usage.PromptTokens),
+				attribute.Int("completion.completion_tokens", response.Usage.CompletionTokens),
+				attribute.String("completion.status", "client_aborted"),
+			)
+			metrics.RecordTokens(request.Model, selectedProvider.DisplayName, response.Usage.PromptTokens, response.Usage.CompletionTokens)
+			metrics.RecordLLMDuration(request.Model, selectedProvider.DisplayName, request.Stream, llmDuration.Seconds())
+		}
+		observability.AddSpanEvent(ctx, "client_aborted", attribute.Bool("stopped", stopped))
+		return nil, err
+	}
 
+	providerFailed := err != nil
 	if err != nil {
-		observability.AddSpanEvent(ctx, "completion_fallback",
-			attribute.String("error", err.Error()),
-		)
-		response = h.BuildFallbackResponse(request.Model)
+		// Keep the original provider error on the span whatever the client receives
+		observability.RecordError(ctx, err)
+		metrics.RecordProviderError(selectedProvider.DisplayName, providerErrorKind(err))
+		h.recordCompletionEvent(ctx, userID, conv, selectedProviderModel.ModelPublicID, selectedProvider.DisplayName, request.Stream, nil, llmDuration)
+
+		if fallbackPolicy != config.CompletionFallbackText {
+			observability.AddSpanAttributes(ctx, attribute.String("completion.status", "provider_error"))
+			if platformerrors.IsProviderError(err) {
+				// Keep the classified type (rate limit, context length, ...) so clients get its status
+				return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, fmt.Sprintf("model provider %s failed", selectedProvider.DisplayName))
+			}
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeExternal,
+				fmt.Sprintf("model provider %s failed: %s", selectedProvider.DisplayName, err.Error()), err, "5b7e2d94-3c1a-4f8e-a6d0-9e4b1c7f2a38")
+		}
+
+		if partialStored {
+			observability.AddSpanEvent(ctx, "completion_incomplete",
+				attribute.String("error", err.Error()),
+			)
+		} else {
+			observability.AddSpanEvent(ctx, "completion_fallback",
+				attribute.String("error", err.Error()),
+			)
+			response = h.BuildFallbackResponse(request.Model)
+		}
 		err = nil
 	}
 
@@ -424,28 +695,24 @@ func (h *ChatHandler) CreateChatCompletion(
 		metrics.RecordTokens(request.Model, selectedProvider.DisplayName, response.Usage.PromptTokens, response.Usage.CompletionTokens)
 		metrics.RecordLLMDuration(request.Model, selectedProvider.DisplayName, request.Stream, llmDuration.Seconds())
 	}
-
-	// Add request and response to conversation if conversation context was provided
-	storeConversation := true
-	if request.Store != nil {
-		storeConversation = *request.Store
+	if !providerFailed {
+		h.recordCompletionEvent(ctx, userID, conv, selectedProviderModel.ModelPublicID, selectedProvider.DisplayName, request.Stream, response, llmDuration)
 	}
 
-	if conv != nil && response != nil && storeConversation {
+	// Add request and response to conversation if conversation context was provided
+	if conv != nil && response != nil && storeConversation && !partialStored {
 		observability.AddSpanEvent(ctx, "storing_conversation")
-		var askItemID, completionItemID string
+		var askItemID string
 		if id, genErr := idgen.GenerateSecureID("msg", 16); genErr == nil {
 			askItemID = id
 		}
-		if id, genErr := idgen.GenerateSecureID("msg", 16); genErr == nil {
-			completionItemID = id
-		}
-		storeReasoning := false
-		if request.StoreReasoning != nil {
-			storeReasoning = *request.StoreReasoning
+		if completionItemID == "" {
+			if id, genErr := idgen.GenerateSecureID("msg", 16); genErr == nil {
+				completionItemID = id
+			}
 		}
 
-		if err := h.addCompletionToConversation(ctx, conv, newMessages, response, askItemID, completionItemID, storeReasoning); err != nil {
+		if err := h.addCompletionToConversation(ctx, conv, newMessages, response, askItemID, completionItemID, storeReasoning, nil, speech, interruptedAt(reqCtx.Request.Context())); err != nil {
 			// Don't fail the request
 			observability.AddSpanEvent(ctx, "conversation_storage_failed",
 				attribute.String("error", err.Error()),
@@ -461,7 +728,7 @@ func (h *ChatHandler) CreateChatCompletion(
 				observability.AddSpanEvent(ctx, "observing_for_memory",
 					attribute.String("finish_reason", string(finishReason)),
 				)
-				go h.memoryHandler.ObserveConversation(conv, userID, newMessages, response, finishReason)
+				go h.memoryHandler.ObserveConversation(ctx, conv, userID, newMessages, response, finishReason, askItemID, completionItemID)
 			}
 		}
 	}
@@ -507,7 +774,8 @@ func (h *ChatHandler) callCompletion(
 	return chatCompletion, nil
 }
 
-// streamCompletion handles streaming chat completion
+// streamCompletion handles streaming chat completion.
+// If the client disconnects, the partial response is returned alongside the error.
 func (h *ChatHandler) streamCompletion(
 	ctx context.Context,
 	reqCtx *gin.Context,
@@ -518,12 +786,166 @@ func (h *ChatHandler) streamCompletion(
 	// Stream completion response to context with callback
 	resp, err := chatClient.StreamChatCompletionToContextWithCallback(reqCtx, "", request, nil)
 	if err != nil {
-		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "streaming completion failed")
+		return resp, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "streaming completion failed")
 	}
 
 	return resp, nil
 }
 
+// StreamCancelResult describes a stream stopped through CancelStream
+type StreamCancelResult struct {
+	StreamID          string
+	ConversationID    string
+	CancelledMCPCalls int64
+}
+
+// CancelStream stops a conversation-bound streaming completion owned by the user,
+// aborting the provider request, and marks the conversation's pending mcp_call
+// items as cancelled. Streams running on another replica are reached through
+// the shared stream store when REDIS_URL is configured. A non-nil audioEndMs
+// reports that the user talked over the reply's audio after that much of it
+// was played; the stored reply is truncated to match.
+func (h *ChatHandler) CancelStream(ctx context.Context, userID uint, streamID string, audioEndMs *int) (*StreamCancelResult, error) {
+	conversationID, ok, err := h.streams.cancel(ctx, streamID, userID, audioEndMs)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to look up stream")
+	}
+	if !ok {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound, "stream not found or already finished", nil, "6f0e2c1a-8b4d-4f57-9c3e-2a7d5b1e9f40")
+	}
+
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to load stream conversation")
+	}
+
+	cancelled, err := h.conversationService.CancelPendingMCPCalls(ctx, conv)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to cancel pending mcp calls")
+	}
+
+	return &StreamCancelResult{
+		StreamID:          streamID,
+		ConversationID:    conv.PublicID,
+		CancelledMCPCalls: cancelled,
+	}, nil
+}
+
+// admit takes a concurrency slot on the model and provider. A request turned
+// away is reported as a rate limit carrying the Retry-After to send.
+func (h *ChatHandler) admit(ctx context.Context, userID uint, providerModel *domainmodel.ProviderModel, provider *domainmodel.Provider) (func(), error) {
+	if h.admission == nil {
+		return func() {}, nil
+	}
+	release, err := h.admission.Acquire(ctx, admission.Request{
+		UserKey:    strconv.FormatUint(uint64(userID), 10),
+		ModelID:    providerModel.ModelPublicID,
+		ProviderID: provider.PublicID,
+	})
+	var saturated *admission.SaturatedError
+	if errors.As(err, &saturated) {
+		observability.AddSpanEvent(ctx, "admission_rejected",
+			attribute.String("admission.scope", saturated.Scope),
+			attribute.String("admission.key", saturated.Key),
+			attribute.Bool("admission.timed_out", saturated.TimedOut),
+		)
+		return nil, platformerrors.NewErrorWithContext(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeRateLimited,
+			saturated.Error(), err, "6f2d9a41-8c3e-4b75-9e1a-d4c07b3f5e28",
+			map[string]any{platformerrors.ContextKeyRetryAfter: saturated.RetryAfterSeconds()})
+	}
+	return release, err
+}
+
+// isAdmissionRejection reports whether err means the request was turned away
+// before reaching the provider.
+func isAdmissionRejection(err error) bool {
+	var saturated *admission.SaturatedError
+	return errors.As(err, &saturated)
+}
+
+// isRetryableOnOtherProvider reports whether another provider may succeed where this
+// one failed; oversized or filtered requests fail the same way everywhere, as does
+// a model at its concurrency cap.
+func isRetryableOnOtherProvider(err error) bool {
+	var saturated *admission.SaturatedError
+	if errors.As(err, &saturated) && saturated.Scope == admission.ScopeModel {
+		return false
+	}
+	return !platformerrors.IsErrorType(err, platformerrors.ErrorTypeContextLengthExceeded) &&
+		!platformerrors.IsErrorType(err, platformerrors.ErrorTypeContentFiltered)
+}
+
+// providerErrorKind labels a provider failure for metrics by its classified type.
+func providerErrorKind(err error) string {
+	var platformErr *platformerrors.PlatformError
+	if platformerrors.IsProviderError(err) && errors.As(err, &platformErr) {
+		return strings.ToLower(string(platformErr.Type))
+	}
+	return "completion_failed"
+}
+
+// completionFallbackPolicy returns the configured COMPLETION_FALLBACK_POLICY.
+func completionFallbackPolicy() string {
+	if cfg := config.GetGlobal(); cfg != nil && cfg.CompletionFallbackPolicy != "" {
+		return cfg.CompletionFallbackPolicy
+	}
+	return config.CompletionFallbackError
+}
+
+// UsesFallbackText reports whether failed completions are answered with the canned
+// fallback reply instead of an error.
+func (h *ChatHandler) UsesFallbackText() bool {
+	return completionFallbackPolicy() == config.CompletionFallbackText
+}
+
+// recordCompletionEvent stores the completion for the analytics rollups. A nil response
+// records a failed completion. It runs in the background and never fails the request.
+func (h *ChatHandler) recordCompletionEvent(
+	ctx context.Context,
+	userID uint,
+	conv *conversation.Conversation,
+	model string,
+	provider string,
+	stream bool,
+	response *openai.ChatCompletionResponse,
+	latency time.Duration,
+) {
+	if h.analyticsService == nil {
+		return
+	}
+
+	event := &analytics.CompletionEvent{
+		UserID:    userID,
+		Model:     model,
+		Provider:  provider,
+		LatencyMs: latency.Milliseconds(),
+		Stream:    stream,
+		Succeeded: response != nil,
+	}
+	if conv != nil {
+		event.ConversationID = &conv.ID
+	}
+	if response != nil {
+		event.PromptTokens = response.Usage.PromptTokens
+		event.CompletionTokens = response.Usage.CompletionTokens
+		for _, choice := range response.Choices {
+			for _, toolCall := range choice.Message.ToolCalls {
+				if toolCall.Function.Name != "" {
+					event.ToolCalls = append(event.ToolCalls, toolCall.Function.Name)
+				}
+			}
+		}
+	}
+
+	recordCtx := context.WithoutCancel(ctx)
+	go func() {
+		if err := h.analyticsService.RecordCompletion(recordCtx, event); err != nil {
+			log := logger.GetLogger()
+			log.Warn().Err(err).Uint("user_id", userID).Msg("failed to record completion event")
+		}
+	}()
+}
+
 // BuildFallbackResponse constructs a minimal assistant reply when upstream completion fails.
 func (h *ChatHandler) BuildFallbackResponse(model string) *openai.ChatCompletionResponse {
 	now := time.Now().Unix()
@@ -608,40 +1030,155 @@ func decimalToInt(val *decimal.Decimal) (int, bool) {
 	return int(val.IntPart()), true
 }
 
-// getProjectInstruction loads the project instruction for the conversation, falling back to the stored snapshot.
-func (h *ChatHandler) getProjectInstruction(ctx context.Context, userID uint, conv *conversation.Conversation) string {
+// getConversationProject loads the project the conversation belongs to, or nil if it has none
+func (h *ChatHandler) getConversationProject(ctx context.Context, userID uint, conv *conversation.Conversation) *project.Project {
 	if conv == nil || h.projectService == nil {
-		return ""
+		return nil
 	}
 	if ctx != nil && ctx.Err() != nil {
-		return ""
-	}
-
-	if conv.EffectiveInstructionSnapshot != nil {
-		if snapshot := strings.TrimSpace(*conv.EffectiveInstructionSnapshot); snapshot != "" {
-			return snapshot
-		}
+		return nil
 	}
 
 	if conv.ProjectPublicID == nil {
-		return ""
+		return nil
 	}
 
 	projectID := strings.TrimSpace(*conv.ProjectPublicID)
 	if projectID == "" {
-		return ""
+		return nil
 	}
 
 	proj, err := h.projectService.GetProjectByPublicIDAndUserID(ctx, projectID, userID)
 	if err != nil {
-		return ""
+		return nil
 	}
+	return proj
+}
 
-	if proj.Instruction == nil {
-		return ""
+// getProjectInstruction returns the project instruction the conversation is pinned to and its
+// version. A project conversation without a snapshot is pinned to the project's current
+// instruction, so later edits of the project do not change it until it is refreshed.
+func (h *ChatHandler) getProjectInstruction(ctx context.Context, conv *conversation.Conversation, proj *project.Project) (string, int) {
+	if conv == nil || conv.ProjectPublicID == nil {
+		return "", 0
+	}
+
+	if conv.EffectiveInstructionSnapshot != nil {
+		return strings.TrimSpace(*conv.EffectiveInstructionSnapshot), conv.InstructionVersion
+	}
+	if proj == nil {
+		return "", 0
+	}
+
+	instruction := ""
+	if proj.Instruction != nil {
+		instruction = strings.TrimSpace(*proj.Instruction)
+	}
+	if err := h.conversationService.PinInstruction(ctx, conv, proj.InstructionVersion, instruction); err != nil {
+		log := logger.GetLogger()
+		log.Warn().Err(err).Str("conversation_id", conv.PublicID).Msg("failed to pin project instruction")
 	}
 
-	return strings.TrimSpace(*proj.Instruction)
+	return instruction, proj.InstructionVersion
+}
+
+// resolvePersona loads the persona referenced by persona_id, falling back to a persona ID
+// passed as the persona preference (persona query parameter or X-Prompt-Persona header).
+// Other persona preference values are plain style hints and resolve to nil.
+func (h *ChatHandler) resolvePersona(ctx context.Context, reqCtx *gin.Context, userID uint, personaID *string) (*persona.Persona, error) {
+	if h.personaService == nil {
+		return nil, nil
+	}
+
+	publicID := ""
+	if personaID != nil {
+		publicID = strings.TrimSpace(*personaID)
+	}
+	if publicID == "" {
+		for _, candidate := range []string{reqCtx.Query("persona"), reqCtx.GetHeader("X-Prompt-Persona")} {
+			if candidate = strings.TrimSpace(candidate); persona.IsPersonaID(candidate) {
+				publicID = candidate
+				break
+			}
+		}
+	}
+	if publicID == "" {
+		return nil, nil
+	}
+	return h.personaService.Get(ctx, publicID, userID)
+}
+
+// filterPersonaTools drops function tools the persona does not enable
+func filterPersonaTools(tools []openai.Tool, p *persona.Persona) []openai.Tool {
+	if len(tools) == 0 || len(p.EnabledTools) == 0 {
+		return tools
+	}
+	filtered := make([]openai.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if tool.Function != nil && !p.AllowsTool(tool.Function.Name) {
+			continue
+		}
+		filtered = append(filtered, tool)
+	}
+	return filtered
+}
+
+// filterProjectTools drops function tools the project's defaults switch off
+func filterProjectTools(tools []openai.Tool, defaults project.DefaultTools) []openai.Tool {
+	if len(tools) == 0 {
+		return tools
+	}
+	filtered := make([]openai.Tool, 0, len(tools))
+	for _, tool := range tools {
+		if tool.Function != nil && !defaults.Allows(tool.Function.Name) {
+			continue
+		}
+		filtered = append(filtered, tool)
+	}
+	return filtered
+}
+
+// withKnowledgeTool replaces any client-sent file_search_query tool with one
+// scoped to the given vector store collections. The client still executes the
+// call through mcp-tools, which restricts the search to the collections.
+func withKnowledgeTool(tools []openai.Tool, collections []string) []openai.Tool {
+	withTool := make([]openai.Tool, 0, len(tools)+1)
+	for _, tool := range tools {
+		if tool.Function != nil && tool.Function.Name == mcptool.ToolKeyFileSearchQuery {
+			continue
+		}
+		withTool = append(withTool, tool)
+	}
+	return append(withTool, openai.Tool{
+		Type: openai.ToolTypeFunction,
+		Function: &openai.FunctionDefinition{
+			Name:        mcptool.ToolKeyFileSearchQuery,
+			Description: "Search the project's knowledge base and return the most relevant passages with their document IDs for citation.",
+			Parameters: map[string]any{
+				"type": "object",
+				"properties": map[string]any{
+					"query": map[string]any{
+						"type":        "string",
+						"description": "Natural language search query",
+					},
+					"top_k": map[string]any{
+						"type":        "integer",
+						"description": "Number of passages to return (default 5, max 20)",
+					},
+					"collections": map[string]any{
+						"type":        "array",
+						"description": "Collections to search; pass all of them unless the question concerns specific ones",
+						"items": map[string]any{
+							"type": "string",
+							"enum": collections,
+						},
+						"default": collections,
+					},
+				},
+				"required": []string{"query", "collections"},
+			},
+		},
+	})
 }
 
 // collectPromptMemory gathers memory hints from request headers, conversation metadata, or recent turns.
@@ -853,13 +1390,14 @@ func (h *ChatHandler) updateConversationTitleFromMessages(ctx context.Context, u
 	}
 
 	// Only update if title is not set or is empty
-	if conv.Title == nil || *conv.Title == "" {
+	if !isTitleLocked(conv) && (conv.Title == nil || *conv.Title == "") {
 		newTitle := h.generateTitleFromMessages(ctx, messages)
 		if newTitle != "" {
 			// Update the conversation title
 			titleCopy := newTitle
 			updateInput := conversation.UpdateConversationInput{
-				Title: &titleCopy,
+				Title:           &titleCopy,
+				ExpectedVersion: &conv.Version,
 			}
 			updatedConv, err := h.conversationService.UpdateConversationWithInput(ctx, userID, conv.PublicID, updateInput)
 			if err != nil {
@@ -899,8 +1437,11 @@ func (h *ChatHandler) updateConversationTitleFromCompletion(ctx context.Context,
 	}
 
 	titleCopy := newTitle
+	// Pin the version loaded at the start of the turn so a user rename made while
+	// the title was being generated wins instead of being overwritten.
 	updateInput := conversation.UpdateConversationInput{
-		Title: &titleCopy,
+		Title:           &titleCopy,
+		ExpectedVersion: &conv.Version,
 	}
 	updatedConv, err := h.conversationService.UpdateConversationWithInput(ctx, userID, conv.PublicID, updateInput)
 	if err != nil {
@@ -928,14 +1469,7 @@ func countUserMessages(messages []openai.ChatCompletionMessage) int {
 }
 
 func isTitleLocked(conv *conversation.Conversation) bool {
-	if conv == nil || conv.Metadata == nil {
-		return false
-	}
-	value, ok := conv.Metadata["title_locked"]
-	if !ok {
-		return false
-	}
-	return strings.EqualFold(strings.TrimSpace(value), "true")
+	return conv != nil && conv.TitleLocked
 }
 
 func (h *ChatHandler) generateTitleWithModel(ctx context.Context, modelPublicID string, messages []openai.ChatCompletionMessage, maxLen int) (string, error) {
@@ -1110,6 +1644,10 @@ func (h *ChatHandler) getOrCreateConversation(
 		if err != nil {
 			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "conversation not found for this user")
 		}
+		// The completion reads and extends the history, so it cannot be in cold storage
+		if err := h.conversationHandler.RehydrateIfCold(ctx, conv); err != nil {
+			return nil, err
+		}
 
 		// Return existing conversation with its original referrer
 		// Note: Referrer is immutable after creation - it represents the conversation's origin
@@ -1132,22 +1670,26 @@ func (h *ChatHandler) getOrCreateConversation(
 	return conv, nil
 }
 
-// prependConversationItems prepends conversation items to the request messages
+// prependConversationItems prepends the tail of the active branch to the request messages
 func (h *ChatHandler) prependConversationItems(
+	ctx context.Context,
 	conv *conversation.Conversation,
 	messages []openai.ChatCompletionMessage,
 ) []openai.ChatCompletionMessage {
-	if conv == nil {
+	if conv == nil || conv.ID == 0 {
 		return messages
 	}
 
-	// Get items from the active branch or main branch
-	var items []conversation.Item
-	if conv.Branches != nil && conv.ActiveBranch != "" {
-		items = conv.Branches[conv.ActiveBranch]
-	} else {
-		items = conv.Items
+	// Load only the most recent items of the active branch instead of the whole history
+	items, total, err := h.conversationService.GetRecentConversationItems(ctx, conv, conv.ActiveBranch, MaxConversationHistoryItems)
+	if err != nil {
+		observability.RecordError(ctx, err)
+		return messages
 	}
+	observability.AddSpanAttributes(ctx,
+		attribute.Int("conversation.item_count", total),
+		attribute.Int("conversation.history_items", len(items)),
+	)
 
 	if len(items) == 0 {
 		return messages
@@ -1162,14 +1704,36 @@ func (h *ChatHandler) prependConversationItems(
 		}
 	}
 
+	// A truncated history can start with tool results whose assistant tool call
+	// was cut off; providers reject those, so drop them.
+	if len(items) < total {
+		for len(conversationMessages) > 0 && conversationMessages[0].Role == openai.ChatMessageRoleTool {
+			conversationMessages = conversationMessages[1:]
+		}
+	}
+
 	// Prepend conversation messages to request messages
 	return append(conversationMessages, messages...)
 }
 
+// isIncompleteAssistantItem reports whether the item is an assistant message stored
+// from an interrupted stream
+func isIncompleteAssistantItem(item conversation.Item) bool {
+	return item.Status != nil && *item.Status == conversation.ItemStatusIncomplete &&
+		item.Role != nil && *item.Role == conversation.ItemRoleAssistant &&
+		item.Type == conversation.ItemTypeMessage
+}
+
 // itemToMessage converts a conversation item to a chat completion message
 func (h *ChatHandler) itemToMessage(item conversation.Item) *openai.ChatCompletionMessage {
-	// Skip items that aren't in completed status
-	if item.Status != nil && *item.Status != conversation.ItemStatusCompleted {
+	// Reasoning is the model's own output and is not replayed to it
+	if item.Type == conversation.ItemTypeReasoning {
+		return nil
+	}
+
+	// Skip items that aren't in completed status, except assistant turns cut off
+	// mid-stream: the user saw that text, so follow-ups should too
+	if item.Status != nil && *item.Status != conversation.ItemStatusCompleted && !isIncompleteAssistantItem(item) {
 		return nil
 	}
 
@@ -1259,7 +1823,85 @@ func (h *ChatHandler) itemRoleToOpenAI(role conversation.ItemRole) string {
 	}
 }
 
-// addCompletionToConversation persists the latest input and assistant response to the conversation
+// Reasons recorded in IncompleteDetails when a stream ends before the provider finished
+const (
+	incompleteReasonClientDisconnected = "client_disconnected"
+	incompleteReasonCancelled          = "cancelled"
+	incompleteReasonStreamTimeout      = "stream_timeout"
+	incompleteReasonStreamError        = "stream_error"
+)
+
+// storePartialCompletion persists the content streamed before a stream failed as an
+// incomplete assistant item, so the conversation shows what the user saw and a
+// follow-up or regenerate can build on it. Partial tool calls are dropped since
+// they were never executed. A stream stopped because the user talked over its
+// audio is stored truncated to the audio that was played. It reports whether
+// the partial turn was stored.
+func (h *ChatHandler) storePartialCompletion(
+	ctx context.Context,
+	reqCtx *gin.Context,
+	conv *conversation.Conversation,
+	newMessages []openai.ChatCompletionMessage,
+	response *openai.ChatCompletionResponse,
+	storeReasoning reasoningStorage,
+	completionItemID string,
+	speech *chat.SpeechOutput,
+	streamErr error,
+) bool {
+	if response == nil || len(response.Choices) == 0 || response.Choices[0].Message.Content == "" {
+		return false
+	}
+
+	partial := *response
+	choice := partial.Choices[0]
+	choice.Message.ToolCalls = nil
+	choice.Message.FunctionCall = nil
+	choice.FinishReason = ""
+	partial.Choices = []openai.ChatCompletionChoice{choice}
+
+	details := &conversation.IncompleteDetails{Reason: incompleteReasonStreamError}
+	switch {
+	case errors.Is(context.Cause(reqCtx.Request.Context()), ErrStreamCancelled):
+		details.Reason = incompleteReasonCancelled
+	case reqCtx.Request.Context().Err() != nil:
+		details.Reason = incompleteReasonClientDisconnected
+	case errors.Is(streamErr, context.DeadlineExceeded):
+		details.Reason = incompleteReasonStreamTimeout
+	}
+	if details.Reason == incompleteReasonStreamError || details.Reason == incompleteReasonStreamTimeout {
+		errMsg := streamErr.Error()
+		details.Error = &errMsg
+	}
+
+	var askItemID string
+	if id, genErr := idgen.GenerateSecureID("msg", 16); genErr == nil {
+		askItemID = id
+	}
+	if completionItemID == "" {
+		if id, genErr := idgen.GenerateSecureID("msg", 16); genErr == nil {
+			completionItemID = id
+		}
+	}
+
+	// The request context is already cancelled when the client went away
+	storeCtx := context.WithoutCancel(ctx)
+	if err := h.addCompletionToConversation(storeCtx, conv, newMessages, &partial, askItemID, completionItemID, storeReasoning, details, speech, interruptedAt(reqCtx.Request.Context())); err != nil {
+		observability.AddSpanEvent(ctx, "conversation_storage_failed",
+			attribute.String("error", err.Error()),
+		)
+		return false
+	}
+
+	observability.AddSpanAttributes(ctx,
+		attribute.Bool("completion.stored", true),
+		attribute.String("completion.incomplete_reason", details.Reason),
+	)
+	return true
+}
+
+// addCompletionToConversation persists the latest input and assistant response to the conversation.
+// A non-nil incomplete marks the assistant item as incomplete and skips its tool calls. A non-nil
+// speech adds the spoken audio's timing to the assistant item, truncated at audioEndMs when set.
 func (h *ChatHandler) addCompletionToConversation(
 	ctx context.Context,
 	conv *conversation.Conversation,
@@ -1267,7 +1909,10 @@ func (h *ChatHandler) addCompletionToConversation(
 	response *openai.ChatCompletionResponse,
 	askItemID string,
 	completionItemID string,
-	storeReasoning bool,
+	storeReasoning reasoningStorage,
+	incomplete *conversation.IncompleteDetails,
+	speech *chat.SpeechOutput,
+	audioEndMs *int,
 ) error {
 	if conv == nil || response == nil || len(response.Choices) == 0 {
 		return nil
@@ -1282,7 +1927,7 @@ func (h *ChatHandler) addCompletionToConversation(
 	items := make([]conversation.Item, 0, 2)
 
 	// Build the user input item
-	userItem := h.buildInputConversationItem(newMessages, storeReasoning, askItemID)
+	userItem := h.buildInputConversationItem(newMessages, storeReasoning != reasoningNotStored, askItemID)
 
 	// Check if we should skip adding the user message (avoid duplicates after regenerate)
 	// This happens when regenerate creates a branch with the user message, then frontend
@@ -1291,7 +1936,7 @@ func (h *ChatHandler) addCompletionToConversation(
 		skipUserItem := false
 
 		// Get the last item in the branch to check for duplicates
-		existingItems, err := h.conversationService.GetConversationItems(ctx, conv, branchName, nil)
+		existingItems, _, err := h.conversationService.GetRecentConversationItems(ctx, conv, branchName, 1)
 		if err == nil && len(existingItems) > 0 {
 			lastItem := existingItems[len(existingItems)-1]
 			// If the last item is a user message, check if it has the same content
@@ -1308,13 +1953,26 @@ func (h *ChatHandler) addCompletionToConversation(
 		}
 	}
 
-	if item := h.buildAssistantConversationItem(response, storeReasoning, completionItemID); item != nil {
+	if storeReasoning == reasoningAsItem {
+		if item := h.buildReasoningConversationItem(response); item != nil {
+			items = append(items, *item)
+		}
+	}
+
+	if item := h.buildAssistantConversationItem(response, storeReasoning == reasoningInMessage, completionItemID); item != nil {
+		if incomplete != nil {
+			status := conversation.ItemStatusIncomplete
+			item.Status = &status
+			item.IncompleteAt = &item.CreatedAt
+			item.IncompleteDetails = incomplete
+		}
+		addSpokenAudio(item, speech, audioEndMs)
 		items = append(items, *item)
 	}
 
 	// Create mcp_call items (with status in_progress) for each tool_call
 	// These items will be updated by mcp-tools service via PATCH when execution completes
-	if len(response.Choices) > 0 && len(response.Choices[0].Message.ToolCalls) > 0 {
+	if incomplete == nil && len(response.Choices) > 0 && len(response.Choices[0].Message.ToolCalls) > 0 {
 		for _, toolCall := range response.Choices[0].Message.ToolCalls {
 			mcpItems := h.buildMCPCallItems(toolCall)
 			items = append(items, mcpItems...)
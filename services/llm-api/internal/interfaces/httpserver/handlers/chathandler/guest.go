@@ -0,0 +1,72 @@
+package chathandler
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.opentelemetry.io/otel/attribute"
+
+	"jan-server/services/llm-api/internal/domain/guestquota"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+	"jan-server/services/llm-api/internal/infrastructure/observability"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// CaptchaTokenHeader carries the token of the CAPTCHA a guest solved after a
+// completion was turned away with a captcha_required error.
+const CaptchaTokenHeader = "X-Captcha-Token"
+
+// admitGuest applies the guest tier limits when the caller is a guest and
+// counts the message. modelID is the public ID of the requested model. A
+// failing counter store lets the message through rather than locking every
+// guest out.
+func (h *ChatHandler) admitGuest(ctx context.Context, reqCtx *gin.Context, modelID string) error {
+	if h.guestQuota == nil || !h.guestQuota.Enabled() {
+		return nil
+	}
+	principal, ok := middleware.PrincipalFromContext(reqCtx)
+	if !ok || !principal.Guest {
+		return nil
+	}
+
+	err := h.guestQuota.Admit(ctx, guestquota.Request{
+		GuestID:      principal.Subject,
+		IP:           reqCtx.ClientIP(),
+		ModelID:      modelID,
+		CaptchaToken: reqCtx.GetHeader(CaptchaTokenHeader),
+	})
+	if err == nil {
+		return nil
+	}
+
+	var notAllowed *guestquota.ModelNotAllowedError
+	var exceeded *guestquota.ExceededError
+	switch {
+	case errors.As(err, &notAllowed):
+		metrics.GuestQuotaRejectionsTotal.WithLabelValues("model").Inc()
+		return platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeForbidden,
+			notAllowed.Error(), err, "def380df-b021-4384-b878-03f3d50d4f61")
+	case errors.As(err, &exceeded):
+		metrics.GuestQuotaRejectionsTotal.WithLabelValues(exceeded.Scope + "_limit").Inc()
+		observability.AddSpanEvent(ctx, "guest_quota_exceeded", attribute.String("guest_quota.scope", exceeded.Scope))
+		return platformerrors.NewErrorWithContext(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeRateLimited,
+			exceeded.Error(), err, "28726831-9550-45c6-8f1c-5cb4bf932222",
+			map[string]any{platformerrors.ContextKeyRetryAfter: exceeded.RetryAfterSeconds(time.Now())})
+	case errors.Is(err, guestquota.ErrCaptchaRequired):
+		metrics.GuestQuotaRejectionsTotal.WithLabelValues("captcha_required").Inc()
+		return platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeCaptchaRequired,
+			err.Error(), err, "73fbc9a6-5c73-44da-a095-9700e6f27808")
+	case errors.Is(err, guestquota.ErrCaptchaInvalid):
+		metrics.GuestQuotaRejectionsTotal.WithLabelValues("captcha_invalid").Inc()
+		return platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeCaptchaRequired,
+			err.Error(), err, "b0b27941-88c0-4c6f-ac22-949b75135e94")
+	}
+
+	log := logger.GetLogger()
+	log.Warn().Err(err).Str("guest_id", principal.Subject).Msg("guest quota check failed; admitting the message")
+	return nil
+}
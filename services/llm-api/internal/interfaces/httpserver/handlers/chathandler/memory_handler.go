@@ -2,15 +2,19 @@ package chathandler
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
 	openai "github.com/sashabaranov/go-openai"
 	"go.opentelemetry.io/otel/attribute"
 
 	"jan-server/services/llm-api/internal/domain/conversation"
 	"jan-server/services/llm-api/internal/domain/usersettings"
+	"jan-server/services/llm-api/internal/infrastructure/flagkeys"
 	"jan-server/services/llm-api/internal/infrastructure/logger"
 	memclient "jan-server/services/llm-api/internal/infrastructure/memory"
 	"jan-server/services/llm-api/internal/infrastructure/observability"
@@ -19,7 +23,9 @@ import (
 // MemoryHandler handles memory-related operations for chat conversations
 type MemoryHandler struct {
 	memoryClient        *memclient.Client
-	memoryEnabled       bool // Application-level config
+	memoryEnabled       bool                 // Application-level config
+	flags               *featureflags.Client // Decides which users get memory; nil for all
+	loadBudget          time.Duration        // Deadline for loading memories in the chat path
 	userSettingsService *usersettings.Service
 }
 
@@ -27,17 +33,22 @@ type MemoryHandler struct {
 func NewMemoryHandler(
 	memoryClient *memclient.Client,
 	memoryEnabled bool,
+	flags *featureflags.Client,
+	loadBudget time.Duration,
 	userSettingsService *usersettings.Service,
 ) *MemoryHandler {
 	return &MemoryHandler{
 		memoryClient:        memoryClient,
 		memoryEnabled:       memoryEnabled,
+		flags:               flags,
+		loadBudget:          loadBudget,
 		userSettingsService: userSettingsService,
 	}
 }
 
 // LoadMemoryContext loads memory for a conversation based on application config and user settings
-// Returns memory array for prompt context, respecting both MEMORY_ENABLED and user settings.
+// Returns memory array for prompt context, respecting MEMORY_ENABLED, the memory feature flag
+// and user settings.
 // If settings are provided, they are reused; otherwise the handler fetches them.
 func (m *MemoryHandler) LoadMemoryContext(
 	ctx context.Context,
@@ -48,7 +59,7 @@ func (m *MemoryHandler) LoadMemoryContext(
 	settings *usersettings.UserSettings,
 ) ([]string, error) {
 	// Check application-level config first
-	if !m.memoryEnabled || m.memoryClient == nil || conversationID == "" {
+	if !m.enabledFor(ctx) || conversationID == "" {
 		return nil, nil
 	}
 
@@ -78,11 +89,26 @@ func (m *MemoryHandler) LoadMemoryContext(
 		attribute.Int("memory.max_user_items", settings.MemoryConfig.MaxUserItems),
 	)
 
-	// Load memory from memory-tools service
-	memoryResp, memErr := m.loadConversationMemory(ctx, userID, conversationID, conv, messages, settings)
+	// Load memory from memory-tools service within the load budget, so a slow or
+	// unavailable memory-tools adds at most the budget to chat latency
+	loadCtx := ctx
+	if m.loadBudget > 0 {
+		var cancel context.CancelFunc
+		loadCtx, cancel = context.WithTimeout(ctx, m.loadBudget)
+		defer cancel()
+	}
+	memoryResp, memErr := m.loadConversationMemory(loadCtx, userID, conversationID, conv, messages, settings)
 	if memErr != nil {
+		reason := "error"
+		switch {
+		case errors.Is(memErr, clients.ErrCircuitOpen):
+			reason = "circuit_open"
+		case ctx.Err() == nil && errors.Is(loadCtx.Err(), context.DeadlineExceeded):
+			reason = "budget_exceeded"
+		}
+		observability.AddSpanEvent(ctx, "memories_skipped", attribute.String("reason", reason))
 		log := logger.GetLogger()
-		log.Warn().Err(memErr).Str("conversation_id", conversationID).Msg("failed to load memories, continuing without memory")
+		log.Warn().Err(memErr).Str("conversation_id", conversationID).Str("reason", reason).Msg("failed to load memories, continuing without memory")
 		return nil, nil
 	}
 
@@ -104,20 +130,26 @@ func (m *MemoryHandler) LoadMemoryContext(
 }
 
 // ObserveConversation observes a conversation for memory extraction
-// Respects both MEMORY_ENABLED and user settings for observation
+// Respects MEMORY_ENABLED, the memory feature flag and user settings for observation.
+// askItemID and completionItemID are the items the turn was stored as; they
+// let memory-tools skip the turn when it is observed again.
 func (m *MemoryHandler) ObserveConversation(
+	ctx context.Context,
 	conv *conversation.Conversation,
 	userID uint,
 	messages []openai.ChatCompletionMessage,
 	response *openai.ChatCompletionResponse,
 	finishReason openai.FinishReason,
+	askItemID string,
+	completionItemID string,
 ) {
 	// Check application-level config first
-	if !m.memoryEnabled || m.memoryClient == nil {
+	if !m.enabledFor(ctx) {
 		return
 	}
 
-	ctx := context.Background()
+	// Runs after the request, so only the values of ctx are kept
+	ctx = context.WithoutCancel(ctx)
 
 	// Load user settings
 	settings, err := m.userSettingsService.GetOrCreateSettings(ctx, userID)
@@ -142,7 +174,7 @@ func (m *MemoryHandler) ObserveConversation(
 	defer cancel()
 
 	// Build conversation items for observation
-	conversationItems := buildMemoryConversationItems(messages, response)
+	conversationItems := buildMemoryConversationItems(messages, response, askItemID, completionItemID)
 	if len(conversationItems) == 0 {
 		return
 	}
@@ -166,6 +198,14 @@ func (m *MemoryHandler) ObserveConversation(
 	}
 }
 
+// enabledFor reports whether memory is enabled and rolled out to the user in ctx.
+func (m *MemoryHandler) enabledFor(ctx context.Context) bool {
+	if !m.memoryEnabled || m.memoryClient == nil {
+		return false
+	}
+	return m.flags == nil || m.flags.Enabled(ctx, flagkeys.Memory)
+}
+
 // loadConversationMemory loads memory using the memory-tools service
 func (m *MemoryHandler) loadConversationMemory(
 	ctx context.Context,
@@ -268,20 +308,27 @@ func extractQueryFromMessages(messages []openai.ChatCompletionMessage) string {
 	return ""
 }
 
-// buildMemoryConversationItems converts OpenAI messages to memory client format
-func buildMemoryConversationItems(messages []openai.ChatCompletionMessage, response *openai.ChatCompletionResponse) []memclient.ConversationItem {
+// buildMemoryConversationItems converts OpenAI messages to memory client format.
+// Only the last request message is stored as a conversation item (askItemID);
+// earlier ones are sent without an item ID and memory-tools keys them by content.
+func buildMemoryConversationItems(messages []openai.ChatCompletionMessage, response *openai.ChatCompletionResponse, askItemID, completionItemID string) []memclient.ConversationItem {
 	items := make([]memclient.ConversationItem, 0, len(messages)+1)
 
-	for _, msg := range messages {
-		items = append(items, memclient.ConversationItem{
+	for i, msg := range messages {
+		item := memclient.ConversationItem{
 			Role:      string(msg.Role),
 			Content:   msg.Content,
 			CreatedAt: time.Now(),
-		})
+		}
+		if i == len(messages)-1 {
+			item.ItemID = askItemID
+		}
+		items = append(items, item)
 	}
 
 	if response != nil && len(response.Choices) > 0 {
 		items = append(items, memclient.ConversationItem{
+			ItemID:    completionItemID,
 			Role:      "assistant",
 			Content:   response.Choices[0].Message.Content,
 			CreatedAt: time.Now(),
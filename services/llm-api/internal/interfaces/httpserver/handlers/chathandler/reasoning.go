@@ -0,0 +1,195 @@
+package chathandler
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+	"go.opentelemetry.io/otel/attribute"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	domainmodel "jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/infrastructure/observability"
+	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
+	"jan-server/services/llm-api/internal/utils/httpclients/chat"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+var (
+	reasoningEfforts   = []string{"low", "medium", "high"}
+	reasoningSummaries = []string{"auto", "concise", "detailed"}
+)
+
+// thinkingBudgets are the extended thinking budgets sent to Anthropic per effort
+var thinkingBudgets = map[string]int{"low": 1024, "medium": 4096, "high": 16384}
+
+// reasoningStorage is how a completion's reasoning is persisted
+type reasoningStorage int
+
+const (
+	reasoningNotStored reasoningStorage = iota
+	reasoningInMessage                  // reasoning_text content of the assistant message
+	reasoningAsItem                     // a reasoning item before the assistant message
+)
+
+// reasoningSettings is what a request and the model's reasoning policy ask of
+// the model's reasoning
+type reasoningSettings struct {
+	effort      string
+	summary     string
+	thinkingOff bool // enable_thinking was set to false
+	hidden      bool // reasoning is neither streamed, returned nor stored
+	neverStored bool // the model's policy keeps reasoning out of conversations
+}
+
+// newReasoningSettings validates the request's reasoning options and applies the
+// model's content policy. Without a reasoning object the top-level
+// reasoning_effort is used as the effort.
+func newReasoningSettings(ctx context.Context, request chatrequests.ChatCompletionRequest, pm *domainmodel.ProviderModel) (reasoningSettings, error) {
+	settings := reasoningSettings{
+		effort:      strings.ToLower(strings.TrimSpace(request.ReasoningEffort)),
+		thinkingOff: request.EnableThinking != nil && !*request.EnableThinking,
+	}
+	if request.Reasoning != nil {
+		if effort := strings.ToLower(strings.TrimSpace(request.Reasoning.Effort)); effort != "" {
+			if !slices.Contains(reasoningEfforts, effort) {
+				return settings, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+					fmt.Sprintf("reasoning.effort must be one of %s", strings.Join(reasoningEfforts, ", ")), nil, "6f2d8b41-9c3e-4a7f-b150-2e8d4c6a9f13")
+			}
+			settings.effort = effort
+		}
+		settings.summary = strings.ToLower(strings.TrimSpace(request.Reasoning.Summary))
+		if settings.summary != "" && !slices.Contains(reasoningSummaries, settings.summary) {
+			return settings, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("reasoning.summary must be one of %s", strings.Join(reasoningSummaries, ", ")), nil, "b84e1c07-3d5a-4f92-8e6b-a1c7d3f05e28")
+		}
+	}
+	settings.hidden = settings.thinkingOff || (request.Reasoning != nil && settings.summary == "")
+
+	if policy := pm.ReasoningPolicy; policy != nil {
+		switch policy.Content {
+		case domainmodel.ReasoningContentStrip:
+			settings.hidden = true
+		case domainmodel.ReasoningContentStream:
+			settings.neverStored = true
+		}
+	}
+	return settings, nil
+}
+
+// apply sets the provider's reasoning knobs on req: reasoning_effort for
+// OpenAI-compatible APIs, the reasoning object for OpenRouter, a thinking budget
+// for Anthropic and the chat template's enable_thinking for Jan models. An
+// effort the model does not list falls back to the model's default effort. The
+// budget of the model's reasoning policy caps Anthropic's thinking budget and
+// is sent to OpenRouter as reasoning.max_tokens; other providers have no
+// budget knob.
+func (s reasoningSettings) apply(ctx context.Context, req *chat.CompletionRequest, provider *domainmodel.Provider, pm *domainmodel.ProviderModel) {
+	req.HideReasoning = s.hidden
+	req.Reasoning = nil
+	req.Thinking = nil
+
+	effort := s.effort
+	if cfg := pm.ReasoningConfig; cfg != nil && effort != "" && len(cfg.EffortLevels) > 0 && !slices.Contains(cfg.EffortLevels, effort) {
+		observability.AddSpanEvent(ctx, "reasoning_effort_unsupported",
+			attribute.String("requested_effort", effort),
+			attribute.String("default_effort", cfg.DefaultEffort),
+		)
+		effort = cfg.DefaultEffort
+	}
+	if s.thinkingOff {
+		effort = ""
+	}
+	req.ReasoningEffort = ""
+
+	budget := policyReasoningBudget(pm)
+
+	switch provider.Kind {
+	case domainmodel.ProviderAnthropic:
+		effortBudget, ok := thinkingBudgets[effort]
+		if !ok {
+			return
+		}
+		if budget > 0 {
+			effortBudget = min(effortBudget, budget)
+		}
+		if cfg := pm.ReasoningConfig; cfg != nil && cfg.MaxTokens != nil && *cfg.MaxTokens > 0 {
+			effortBudget = min(effortBudget, *cfg.MaxTokens)
+		}
+		req.Thinking = &chat.ThinkingParams{Type: "enabled", BudgetTokens: effortBudget}
+	case domainmodel.ProviderOpenRouter:
+		switch {
+		case s.thinkingOff:
+			if s.hidden {
+				req.Reasoning = &chat.ReasoningParams{Exclude: true}
+			}
+		case budget > 0:
+			req.Reasoning = &chat.ReasoningParams{MaxTokens: budget, Exclude: s.hidden}
+		case effort != "" || s.hidden:
+			req.Reasoning = &chat.ReasoningParams{Effort: effort, Exclude: s.hidden}
+		}
+	case domainmodel.ProviderJan:
+		req.ReasoningEffort = effort
+		if effort != "" || s.thinkingOff {
+			if req.ChatTemplateKwargs == nil {
+				req.ChatTemplateKwargs = map[string]any{}
+			}
+			req.ChatTemplateKwargs["enable_thinking"] = !s.thinkingOff
+		}
+	default:
+		req.ReasoningEffort = effort
+	}
+}
+
+// storage reports how reasoning is persisted when store_reasoning is storeReasoning
+func (s reasoningSettings) storage(storeReasoning bool) reasoningStorage {
+	switch {
+	case s.hidden || s.neverStored || !storeReasoning:
+		return reasoningNotStored
+	case s.summary != "":
+		return reasoningAsItem
+	default:
+		return reasoningInMessage
+	}
+}
+
+// policyReasoningBudget is the thinking-token budget of the model's reasoning
+// policy, or 0 without one
+func policyReasoningBudget(pm *domainmodel.ProviderModel) int {
+	if policy := pm.ReasoningPolicy; policy != nil && policy.BudgetTokens != nil {
+		return *policy.BudgetTokens
+	}
+	return 0
+}
+
+// buildReasoningConversationItem stores the model's reasoning as a reasoning item
+// with a summary_text content, as OpenAI returns reasoning summaries
+func (h *ChatHandler) buildReasoningConversationItem(response *openai.ChatCompletionResponse) *conversation.Item {
+	if response == nil || len(response.Choices) == 0 {
+		return nil
+	}
+	reasoning := strings.TrimSpace(response.Choices[0].Message.ReasoningContent)
+	if reasoning == "" {
+		return nil
+	}
+
+	role := conversation.ItemRoleAssistant
+	status := conversation.ItemStatusCompleted
+	item := &conversation.Item{
+		Type:   conversation.ItemTypeReasoning,
+		Role:   &role,
+		Status: &status,
+		Content: []conversation.Content{
+			{Type: "summary_text", SummaryText: &reasoning},
+		},
+		CreatedAt: time.Now().UTC(),
+	}
+	if id, err := idgen.GenerateSecureID("rs", 16); err == nil {
+		item.PublicID = id
+	}
+	return item
+}
@@ -0,0 +1,107 @@
+package chathandler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/infrastructure/tts"
+	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
+	"jan-server/services/llm-api/internal/utils/httpclients/chat"
+	"jan-server/services/llm-api/internal/utils/idgen"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+var (
+	outputModalities = []string{"text", "audio"}
+	audioFormats     = []string{tts.FormatPCM16, tts.FormatOpus}
+)
+
+// maxVoiceLength bounds the voice name forwarded to the TTS server
+const maxVoiceLength = 64
+
+// newSpeechOutput validates the request's modalities and audio options. It
+// returns nil when the request does not ask for audio. The audio itself is not
+// stored with the conversation: the assistant message keeps the text it was
+// spoken from and an audio part with the timing of each sentence.
+func (h *ChatHandler) newSpeechOutput(ctx context.Context, request chatrequests.ChatCompletionRequest) (*chat.SpeechOutput, error) {
+	for _, modality := range request.Modalities {
+		if !slices.Contains(outputModalities, strings.ToLower(strings.TrimSpace(modality))) {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("modalities must only contain %s", strings.Join(outputModalities, ", ")), nil, "3b9e6f21-7c4d-4a58-9e13-d2f8a6c05b74")
+		}
+	}
+	if !request.WantsAudio() {
+		return nil, nil
+	}
+	if !request.Stream {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+			"audio output requires stream: true", nil, "c5f18a3e-2d7b-4e96-a041-8b3d9e6f2c17")
+	}
+	if h.ttsClient == nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+			"audio output is not available: no text-to-speech server is configured", nil, "7e2a4c96-1f5b-4d38-b7e0-5a9c3d1f8e42")
+	}
+
+	out := &chat.SpeechOutput{Synthesizer: h.ttsClient, Format: tts.FormatPCM16}
+	if audio := request.Audio; audio != nil {
+		if format := strings.ToLower(strings.TrimSpace(audio.Format)); format != "" {
+			if !slices.Contains(audioFormats, format) {
+				return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+					fmt.Sprintf("audio.format must be one of %s", strings.Join(audioFormats, ", ")), nil, "a8d3f5b2-6e19-4c07-8f4a-1b7e2c9d6a35")
+			}
+			out.Format = format
+		}
+		out.Voice = strings.TrimSpace(audio.Voice)
+		if len(out.Voice) > maxVoiceLength {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("audio.voice must be at most %d characters", maxVoiceLength), nil, "f4b71c8d-9a2e-4f63-b5d0-6c3e8a1f7b29")
+		}
+	}
+	if id, err := idgen.GenerateSecureID("audio", 16); err == nil {
+		out.ID = id
+	}
+	return out, nil
+}
+
+// addSpokenAudio appends an audio part to the assistant item listing the
+// sentences whose audio reached the client and where each one ends, so the
+// item can be truncated when the user interrupts playback. A non-nil
+// audioEndMs truncates it right away.
+func addSpokenAudio(item *conversation.Item, speech *chat.SpeechOutput, audioEndMs *int) {
+	if item == nil || speech == nil || speech.ID == "" || len(speech.Spoken) == 0 {
+		return
+	}
+
+	segments := make([]conversation.AudioSegment, len(speech.Spoken))
+	transcripts := make([]string, len(speech.Spoken))
+	for i, spoken := range speech.Spoken {
+		segments[i] = conversation.AudioSegment{Transcript: spoken.Transcript, EndMs: spoken.EndMs}
+		transcripts[i] = spoken.Transcript
+	}
+	transcript := strings.Join(transcripts, " ")
+	format := speech.Format
+	content := conversation.NewAudioContent(speech.ID, &transcript, &format)
+	content.Audio.Segments = segments
+	item.Content = append(item.Content, content)
+
+	if audioEndMs != nil {
+		// The client may report more than was sent when playback ran to the end
+		end := min(*audioEndMs, segments[len(segments)-1].EndMs)
+		_ = item.TruncateAudio(len(item.Content)-1, end)
+	}
+}
+
+// interruptedAt returns how much of the reply's audio was played when its
+// stream was stopped because the user talked over it, or nil.
+func interruptedAt(streamCtx context.Context) *int {
+	var interruption *streamInterruption
+	if !errors.As(context.Cause(streamCtx), &interruption) {
+		return nil
+	}
+	audioEndMs := interruption.audioEndMs
+	return &audioEndMs
+}
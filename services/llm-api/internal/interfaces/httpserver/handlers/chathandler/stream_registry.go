@@ -0,0 +1,189 @@
+package chathandler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/streamstate"
+)
+
+// StreamIDHeader carries the ID of a conversation-bound streaming completion so
+// clients can stop it through the cancel endpoint.
+const StreamIDHeader = "X-Stream-ID"
+
+// ItemIDHeader carries the ID the assistant item of a conversation-bound
+// stream is stored under, so clients can truncate it once stored.
+const ItemIDHeader = "X-Item-ID"
+
+// ErrStreamCancelled is the cancellation cause of a stream stopped through
+// CancelStream, as opposed to the client disconnecting.
+var ErrStreamCancelled = errors.New("stream cancelled")
+
+// streamInterruption is the cancellation cause of a stream stopped because the
+// user talked over its audio. It matches ErrStreamCancelled.
+type streamInterruption struct {
+	audioEndMs int // audio played before the interruption
+}
+
+func (e *streamInterruption) Error() string        { return ErrStreamCancelled.Error() }
+func (e *streamInterruption) Is(target error) bool { return target == ErrStreamCancelled }
+
+// streamStoreTimeout bounds each Redis call made on behalf of a stream.
+const streamStoreTimeout = 2 * time.Second
+
+type activeStream struct {
+	userID         uint
+	conversationID string
+	cancel         context.CancelCauseFunc
+}
+
+// streamRegistry tracks the conversation-bound streams running in this process.
+// With a shared store, streams are also recorded in Redis and a cancel request
+// for a stream running on another replica is forwarded to that replica;
+// without one, cancellation is process-local.
+type streamRegistry struct {
+	mu      sync.Mutex
+	streams map[string]*activeStream
+	shared  *streamstate.Store
+}
+
+func newStreamRegistry(shared *streamstate.Store) *streamRegistry {
+	r := &streamRegistry{streams: make(map[string]*activeStream), shared: shared}
+	if shared != nil {
+		go r.listen(context.Background())
+	}
+	return r
+}
+
+// track derives a cancellable context for the stream and registers it. The
+// returned release func must be called once the stream finishes.
+func (r *streamRegistry) track(ctx context.Context, streamID string, userID uint, conversationID string) (context.Context, func()) {
+	streamCtx, cancel := context.WithCancelCause(ctx)
+
+	r.mu.Lock()
+	r.streams[streamID] = &activeStream{userID: userID, conversationID: conversationID, cancel: cancel}
+	r.mu.Unlock()
+
+	if r.shared != nil {
+		r.share(streamCtx, streamstate.Entry{
+			StreamID:       streamID,
+			UserID:         userID,
+			ConversationID: conversationID,
+			StartedAt:      time.Now().UTC(),
+		})
+	}
+
+	return streamCtx, func() {
+		r.mu.Lock()
+		delete(r.streams, streamID)
+		r.mu.Unlock()
+		cancel(nil)
+
+		if r.shared != nil {
+			storeCtx, storeCancel := context.WithTimeout(context.Background(), streamStoreTimeout)
+			defer storeCancel()
+			if err := r.shared.Remove(storeCtx, streamID); err != nil {
+				log := logger.GetLogger()
+				log.Warn().Err(err).Str("stream_id", streamID).Msg("failed to remove shared stream entry")
+			}
+		}
+	}
+}
+
+// share records the stream in the shared store and keeps its entry alive until
+// streamCtx ends. A store outage only costs cross-replica cancellation.
+func (r *streamRegistry) share(streamCtx context.Context, entry streamstate.Entry) {
+	log := logger.GetLogger()
+
+	storeCtx, cancel := context.WithTimeout(context.Background(), streamStoreTimeout)
+	err := r.shared.Register(storeCtx, entry)
+	cancel()
+	if err != nil {
+		log.Warn().Err(err).Str("stream_id", entry.StreamID).Msg("failed to record shared stream entry")
+	}
+
+	go func() {
+		ticker := time.NewTicker(streamstate.RefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-streamCtx.Done():
+				return
+			case <-ticker.C:
+				storeCtx, cancel := context.WithTimeout(context.Background(), streamStoreTimeout)
+				if err := r.shared.Refresh(storeCtx, entry.StreamID); err != nil {
+					log.Warn().Err(err).Str("stream_id", entry.StreamID).Msg("failed to refresh shared stream entry")
+				}
+				cancel()
+			}
+		}
+	}()
+}
+
+// cancel stops the user's stream, wherever it runs, and returns the public ID
+// of its conversation, or false if no such stream is running. A non-nil
+// audioEndMs reports the stream as interrupted after that much audio.
+func (r *streamRegistry) cancel(ctx context.Context, streamID string, userID uint, audioEndMs *int) (string, bool, error) {
+	if conversationID, ok := r.cancelLocal(streamID, userID, audioEndMs); ok {
+		return conversationID, true, nil
+	}
+	if r.shared == nil {
+		return "", false, nil
+	}
+
+	entry, err := r.shared.Get(ctx, streamID)
+	if err != nil {
+		return "", false, err
+	}
+	if entry == nil || entry.UserID != userID || entry.Instance == r.shared.Instance() {
+		// Our own entries are only left behind by streams that already finished
+		return "", false, nil
+	}
+	delivered, err := r.shared.PublishCancel(ctx, entry.Instance, streamstate.CancelMessage{StreamID: streamID, UserID: userID, AudioEndMs: audioEndMs})
+	if err != nil {
+		return "", false, err
+	}
+	if !delivered {
+		// The replica that served the stream is gone; drop its stale entry
+		_ = r.shared.Remove(ctx, streamID)
+		return "", false, nil
+	}
+	return entry.ConversationID, true, nil
+}
+
+// cancelLocal stops the user's stream if it runs in this process.
+func (r *streamRegistry) cancelLocal(streamID string, userID uint, audioEndMs *int) (string, bool) {
+	r.mu.Lock()
+	stream, ok := r.streams[streamID]
+	r.mu.Unlock()
+
+	if !ok || stream.userID != userID {
+		return "", false
+	}
+	if audioEndMs != nil {
+		stream.cancel(&streamInterruption{audioEndMs: *audioEndMs})
+	} else {
+		stream.cancel(ErrStreamCancelled)
+	}
+	return stream.conversationID, true
+}
+
+// listen stops streams on behalf of cancel requests received by other replicas.
+func (r *streamRegistry) listen(ctx context.Context) {
+	log := logger.GetLogger()
+	for ctx.Err() == nil {
+		err := r.shared.ListenCancels(ctx, func(msg streamstate.CancelMessage) {
+			r.cancelLocal(msg.StreamID, msg.UserID, msg.AudioEndMs)
+		})
+		if err != nil {
+			log.Warn().Err(err).Msg("stream cancel subscription failed, retrying")
+			select {
+			case <-ctx.Done():
+			case <-time.After(5 * time.Second):
+			}
+		}
+	}
+}
@@ -0,0 +1,560 @@
+package comparehandler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	openai "github.com/sashabaranov/go-openai"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/domain/analytics"
+	"jan-server/services/llm-api/internal/domain/comparison"
+	"jan-server/services/llm-api/internal/infrastructure/inference"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	modelHandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelhandler"
+	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
+	comparerequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/compare"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+	"jan-server/services/llm-api/internal/utils/httpclients/chat"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+const (
+	// scannerMaxBuffer bounds a single upstream SSE line
+	scannerMaxBuffer = 10 * 1024 * 1024
+
+	// maxLeaderboardMinVotes caps the min_votes query parameter of the leaderboard
+	maxLeaderboardMinVotes = 10000
+)
+
+// CompareHandler runs prompts against several models side by side and records votes
+type CompareHandler struct {
+	inferenceProvider *inference.InferenceProvider
+	providerHandler   *modelHandler.ProviderHandler
+	comparisonService *comparison.Service
+	cfg               *config.Config
+}
+
+// NewCompareHandler creates a new compare handler
+func NewCompareHandler(
+	inferenceProvider *inference.InferenceProvider,
+	providerHandler *modelHandler.ProviderHandler,
+	comparisonService *comparison.Service,
+	cfg *config.Config,
+) *CompareHandler {
+	return &CompareHandler{
+		inferenceProvider: inferenceProvider,
+		providerHandler:   providerHandler,
+		comparisonService: comparisonService,
+		cfg:               cfg,
+	}
+}
+
+// CompareModel identifies the model running in one slot
+type CompareModel struct {
+	Slot  int    `json:"slot"`
+	Model string `json:"model"`
+}
+
+// CompareStartEvent is the first event of a streamed comparison
+type CompareStartEvent struct {
+	ID     string         `json:"id"`
+	Object string         `json:"object"`
+	Models []CompareModel `json:"models"`
+}
+
+// CompareResult is the outcome of one model. In a stream it is sent as the
+// model_<slot>.done event once the model has finished.
+type CompareResult struct {
+	Slot      int                            `json:"slot"`
+	Model     string                         `json:"model"`
+	LatencyMs int64                          `json:"latency_ms"`
+	Usage     *openai.Usage                  `json:"usage,omitempty"`
+	Response  *openai.ChatCompletionResponse `json:"response,omitempty"` // Non-streaming only
+	Error     *responses.ErrorResponse       `json:"error,omitempty"`
+}
+
+// CompareResponse is the non-streaming response of POST /v1/compare
+type CompareResponse struct {
+	ID      string          `json:"id"`
+	Object  string          `json:"object"`
+	Results []CompareResult `json:"results"`
+}
+
+// ComparisonResponse is a stored comparison with its vote
+type ComparisonResponse struct {
+	ID          string         `json:"id"`
+	Object      string         `json:"object"`
+	Models      []CompareModel `json:"models"`
+	Vote        *string        `json:"vote,omitempty"`
+	WinnerSlot  *int           `json:"winner_slot,omitempty"`
+	VotedAt     *int64         `json:"voted_at,omitempty"`
+	CompletedAt *int64         `json:"completed_at,omitempty"`
+	CreatedAt   int64          `json:"created_at"`
+}
+
+// LeaderboardResponse ranks models by win rate over the range
+type LeaderboardResponse struct {
+	StartDate string                        `json:"start_date"`
+	EndDate   string                        `json:"end_date"`
+	MinVotes  int                           `json:"min_votes"`
+	Data      []comparison.LeaderboardEntry `json:"data"`
+}
+
+// slotTarget is a resolved model ready to be called
+type slotTarget struct {
+	slot     int
+	model    string
+	provider string
+	client   *chat.ChatCompletionClient
+	request  chat.CompletionRequest
+}
+
+// Compare godoc
+// @Summary Compare models side by side
+// @Description Runs the same messages against 2 to 4 models concurrently (arena mode). The index of a model in `models` is its slot.
+// @Description
+// @Description With `stream=true` the response is Server-Sent Events with one event channel per model: an initial `comparison` event with the comparison ID, `model_<slot>` events carrying the model's OpenAI-style `chat.completion.chunk` payloads, and a `model_<slot>.done` event with the model's latency, usage or error. The stream ends with `data: [DONE]` once every model has finished.
+// @Description
+// @Description A failing model does not stop the others; its error is reported in its own result. Use the returned ID to vote with `POST /v1/compare/{comparison_id}/vote`.
+// @Tags Compare API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Produce text/event-stream
+// @Param request body comparerequests.CompareRequest true "Models and messages to compare"
+// @Success 200 {object} CompareResponse "Results of all models (when stream=false)"
+// @Success 200 {string} string "SSE stream with one event channel per model (when stream=true)"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request or model count outside 2-4"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Model not found"
+// @Failure 413 {object} responses.ErrorResponse "Request body, message count or inline image exceeds the configured limit"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/compare [post]
+func (h *CompareHandler) Compare(c *gin.Context) {
+	ctx := c.Request.Context()
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	var request comparerequests.CompareRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			responses.HandleNewError(c, platformerrors.ErrorTypePayloadTooLarge, fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytesErr.Limit), "7a2e9c41-5d8b-4f13-b6a0-3c9e1d7f4b82")
+			return
+		}
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	limits := chatrequests.ChatCompletionRequest{ChatCompletionRequest: openai.ChatCompletionRequest{Messages: request.Messages}}
+	if reason := limits.ExceededLimit(h.cfg.ChatMaxMessages, h.cfg.ChatMaxImageBytes); reason != "" {
+		responses.HandleNewError(c, platformerrors.ErrorTypePayloadTooLarge, reason, "c3f81b6d-2e4a-4d97-8a05-6b1d9e3c7f20")
+		return
+	}
+
+	models, err := h.comparisonService.ValidateModels(ctx, request.Models)
+	if err != nil {
+		responses.HandleError(c, err, "invalid models")
+		return
+	}
+
+	targets := make([]slotTarget, 0, len(models))
+	for slot, model := range models {
+		target, err := h.resolveTarget(ctx, slot, model, request)
+		if err != nil {
+			responses.HandleError(c, err, "failed to select model")
+			return
+		}
+		targets = append(targets, target)
+	}
+
+	cmp, err := h.comparisonService.Start(ctx, user.ID, models)
+	if err != nil {
+		responses.HandleError(c, err, "failed to start comparison")
+		return
+	}
+
+	var results []CompareResult
+	if request.Stream {
+		results = h.runStreaming(c, cmp, targets)
+	} else {
+		results = h.runBlocking(ctx, targets)
+	}
+
+	h.completeComparison(ctx, cmp, targets, results)
+
+	if !request.Stream {
+		c.JSON(http.StatusOK, CompareResponse{ID: cmp.PublicID, Object: "comparison", Results: results})
+	}
+}
+
+// Vote godoc
+// @Summary Vote on a comparison
+// @Description Records which model gave the best answer in a finished comparison of the authenticated user. Voting again replaces the previous vote. Votes feed the internal model leaderboard.
+// @Tags Compare API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param comparison_id path string true "Comparison ID"
+// @Param request body comparerequests.VoteRequest true "Vote"
+// @Success 200 {object} ComparisonResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid outcome or winner_slot"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Comparison not found"
+// @Failure 409 {object} responses.ErrorResponse "Comparison is still running"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/compare/{comparison_id}/vote [post]
+func (h *CompareHandler) Vote(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	var request comparerequests.VoteRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	cmp, err := h.comparisonService.Vote(c.Request.Context(), user.ID, c.Param("comparison_id"), request.ToVoteOutcome(), request.WinnerSlot)
+	if err != nil {
+		responses.HandleError(c, err, "failed to record vote")
+		return
+	}
+
+	c.JSON(http.StatusOK, toComparisonResponse(cmp))
+}
+
+// GetComparison godoc
+// @Summary Get a comparison
+// @Description Returns a comparison of the authenticated user with its models and vote
+// @Tags Compare API
+// @Security BearerAuth
+// @Produce json
+// @Param comparison_id path string true "Comparison ID"
+// @Success 200 {object} ComparisonResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Comparison not found"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/compare/{comparison_id} [get]
+func (h *CompareHandler) GetComparison(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	cmp, err := h.comparisonService.GetByPublicID(c.Request.Context(), user.ID, c.Param("comparison_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get comparison")
+		return
+	}
+
+	c.JSON(http.StatusOK, toComparisonResponse(cmp))
+}
+
+// GetLeaderboard godoc
+// @Summary Model comparison leaderboard
+// @Description Ranks models by win rate over the comparison votes cast in the range. A winner vote counts as a win for the chosen model and a loss for the others; all_bad counts as a loss for every model.
+// @Tags Admin - Model Comparison
+// @Security BearerAuth
+// @Produce json
+// @Param range query string false "Preset range: 7d, 30d, 90d or 365d (default 30d)"
+// @Param start_date query string false "Start date (YYYY-MM-DD); overrides range"
+// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
+// @Param min_votes query int false "Hide models with fewer votes (default 1)"
+// @Success 200 {object} LeaderboardResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid range or dates"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/compare/leaderboard [get]
+func (h *CompareHandler) GetLeaderboard(c *gin.Context) {
+	ctx := c.Request.Context()
+	r, err := analytics.ParseTimeRange(ctx, c.Query("range"), c.Query("start_date"), c.Query("end_date"), time.Now())
+	if err != nil {
+		responses.HandleError(c, err, "invalid time range")
+		return
+	}
+
+	minVotes := comparison.DefaultLeaderboardMinVotes
+	if minVotesStr := c.Query("min_votes"); minVotesStr != "" {
+		if parsed, parseErr := strconv.Atoi(minVotesStr); parseErr == nil && parsed > 0 {
+			minVotes = min(parsed, maxLeaderboardMinVotes)
+		}
+	}
+
+	entries, err := h.comparisonService.GetLeaderboard(ctx, comparison.LeaderboardFilter{
+		From:     r.From,
+		To:       r.To.AddDate(0, 0, 1),
+		MinVotes: minVotes,
+	})
+	if err != nil {
+		responses.HandleError(c, err, "failed to get leaderboard")
+		return
+	}
+
+	c.JSON(http.StatusOK, LeaderboardResponse{
+		StartDate: r.StartDate(),
+		EndDate:   r.EndDate(),
+		MinVotes:  minVotes,
+		Data:      entries,
+	})
+}
+
+// resolveTarget selects the provider for a model and builds its upstream request
+func (h *CompareHandler) resolveTarget(ctx context.Context, slot int, model string, request comparerequests.CompareRequest) (slotTarget, error) {
+	providerModel, provider, err := h.providerHandler.SelectProviderModelForModelPublicID(ctx, model)
+	if err != nil {
+		return slotTarget{}, err
+	}
+	if providerModel == nil || provider == nil {
+		return slotTarget{}, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound,
+			fmt.Sprintf("model not found: %s", model), nil, "e5a0c7d3-9b2f-4e68-a41d-8f3b6c0e2d95")
+	}
+
+	client, err := h.inferenceProvider.GetChatCompletionClient(ctx, provider)
+	if err != nil {
+		return slotTarget{}, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to create chat client")
+	}
+
+	llmRequest := chat.CompletionRequest{
+		ChatCompletionRequest: openai.ChatCompletionRequest{
+			Model:     providerModel.ProviderOriginalModelID,
+			Messages:  request.Messages,
+			MaxTokens: request.MaxTokens,
+		},
+	}
+	if request.Temperature != nil {
+		llmRequest.ChatCompletionRequest.Temperature = *request.Temperature
+	}
+	if request.TopP != nil {
+		llmRequest.ChatCompletionRequest.TopP = *request.TopP
+	}
+
+	return slotTarget{
+		slot:     slot,
+		model:    model,
+		provider: provider.DisplayName,
+		client:   client,
+		request:  llmRequest,
+	}, nil
+}
+
+// runBlocking calls every model concurrently and waits for all of them
+func (h *CompareHandler) runBlocking(ctx context.Context, targets []slotTarget) []CompareResult {
+	results := make([]CompareResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target slotTarget) {
+			defer wg.Done()
+			start := time.Now()
+			response, err := target.client.CreateChatCompletion(ctx, "", target.request)
+			result := CompareResult{Slot: target.slot, Model: target.model, LatencyMs: time.Since(start).Milliseconds()}
+			if err != nil {
+				result.Error = toErrorResponse(err)
+			} else {
+				result.Response = response
+				result.Usage = &response.Usage
+			}
+			results[i] = result
+		}(i, target)
+	}
+	wg.Wait()
+	return results
+}
+
+// runStreaming streams every model concurrently to the client, each on its own event channel
+func (h *CompareHandler) runStreaming(c *gin.Context, cmp *comparison.Comparison, targets []slotTarget) []CompareResult {
+	ctx := c.Request.Context()
+	writer := &eventWriter{c: c}
+	writer.setupHeaders()
+
+	start := CompareStartEvent{ID: cmp.PublicID, Object: "comparison", Models: make([]CompareModel, 0, len(targets))}
+	for _, target := range targets {
+		start.Models = append(start.Models, CompareModel{Slot: target.slot, Model: target.model})
+	}
+	writer.writeJSON("comparison", start)
+
+	results := make([]CompareResult, len(targets))
+	var wg sync.WaitGroup
+	for i, target := range targets {
+		wg.Add(1)
+		go func(i int, target slotTarget) {
+			defer wg.Done()
+			results[i] = h.streamTarget(ctx, writer, target)
+			writer.writeJSON(fmt.Sprintf("model_%d.done", target.slot), results[i])
+		}(i, target)
+	}
+	wg.Wait()
+
+	writer.writeData("[DONE]")
+	return results
+}
+
+// streamTarget forwards one model's upstream chunks on its model_<slot> channel
+func (h *CompareHandler) streamTarget(ctx context.Context, writer *eventWriter, target slotTarget) CompareResult {
+	result := CompareResult{Slot: target.slot, Model: target.model}
+	startedAt := time.Now()
+
+	request := target.request
+	request.ChatCompletionRequest.Stream = true
+	request.ChatCompletionRequest.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	body, err := target.client.CreateChatCompletionStream(ctx, "", request)
+	if err != nil {
+		result.Error = toErrorResponse(err)
+		result.LatencyMs = time.Since(startedAt).Milliseconds()
+		return result
+	}
+	defer body.Close()
+
+	event := fmt.Sprintf("model_%d", target.slot)
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), scannerMaxBuffer)
+	for scanner.Scan() {
+		data, found := strings.CutPrefix(scanner.Text(), "data:")
+		if !found {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "" || data == "[DONE]" {
+			continue
+		}
+
+		var chunk struct {
+			Usage *openai.Usage `json:"usage"`
+		}
+		if json.Unmarshal([]byte(data), &chunk) == nil && chunk.Usage != nil {
+			result.Usage = chunk.Usage
+		}
+		writer.writeEvent(event, data)
+	}
+	if err := scanner.Err(); err != nil {
+		result.Error = toErrorResponse(platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "streaming completion failed"))
+	}
+	result.LatencyMs = time.Since(startedAt).Milliseconds()
+	return result
+}
+
+// completeComparison stores the results even when the client has already disconnected
+func (h *CompareHandler) completeComparison(ctx context.Context, cmp *comparison.Comparison, targets []slotTarget, results []CompareResult) {
+	domainResults := make([]comparison.Result, 0, len(results))
+	for i, result := range results {
+		r := comparison.Result{
+			Slot:      result.Slot,
+			Model:     result.Model,
+			Provider:  targets[i].provider,
+			LatencyMs: result.LatencyMs,
+			Succeeded: result.Error == nil,
+		}
+		if result.Usage != nil {
+			r.PromptTokens = result.Usage.PromptTokens
+			r.CompletionTokens = result.Usage.CompletionTokens
+		}
+		if result.Error != nil {
+			r.Error = result.Error.Message
+		}
+		domainResults = append(domainResults, r)
+	}
+
+	if err := h.comparisonService.Complete(context.WithoutCancel(ctx), cmp, domainResults); err != nil {
+		log := logger.GetLogger()
+		log.Error().Err(err).Str("comparison_id", cmp.PublicID).Msg("failed to store comparison results")
+	}
+}
+
+// eventWriter serialises SSE writes from the per-model goroutines
+type eventWriter struct {
+	mu sync.Mutex
+	c  *gin.Context
+}
+
+func (w *eventWriter) setupHeaders() {
+	w.c.Header("Content-Type", "text/event-stream")
+	w.c.Header("Cache-Control", "no-cache")
+	w.c.Header("Connection", "keep-alive")
+	w.c.Header("X-Accel-Buffering", "no")
+	w.c.Writer.WriteHeaderNow()
+}
+
+func (w *eventWriter) writeJSON(event string, payload any) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	w.writeEvent(event, string(data))
+}
+
+func (w *eventWriter) writeEvent(event, data string) {
+	w.write("event: " + event + "\ndata: " + data + "\n\n")
+}
+
+func (w *eventWriter) writeData(data string) {
+	w.write("data: " + data + "\n\n")
+}
+
+func (w *eventWriter) write(frame string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.c.Request.Context().Err() != nil {
+		return
+	}
+	_, _ = w.c.Writer.Write([]byte(frame))
+	w.c.Writer.Flush()
+}
+
+// toErrorResponse renders a model failure with the same body as a JSON error response
+func toErrorResponse(err error) *responses.ErrorResponse {
+	errResp := &responses.ErrorResponse{Error: "chat completion failed", Message: err.Error()}
+	var platformErr *platformerrors.PlatformError
+	if errors.As(err, &platformErr) {
+		errResp.Code = platformErr.GetUUID()
+		errResp.Type = strings.ToLower(string(platformErr.GetErrorType()))
+		errResp.Message = platformErr.Message
+		errResp.RequestID = platformErr.GetRequestID()
+	}
+	return errResp
+}
+
+func toComparisonResponse(cmp *comparison.Comparison) ComparisonResponse {
+	resp := ComparisonResponse{
+		ID:         cmp.PublicID,
+		Object:     "comparison",
+		Models:     make([]CompareModel, 0, len(cmp.Models)),
+		WinnerSlot: cmp.WinnerSlot,
+		CreatedAt:  cmp.CreatedAt.Unix(),
+	}
+	for slot, model := range cmp.Models {
+		resp.Models = append(resp.Models, CompareModel{Slot: slot, Model: model})
+	}
+	if cmp.Vote != nil {
+		vote := string(*cmp.Vote)
+		resp.Vote = &vote
+	}
+	if cmp.VotedAt != nil {
+		votedAt := cmp.VotedAt.Unix()
+		resp.VotedAt = &votedAt
+	}
+	if cmp.CompletedAt != nil {
+		completedAt := cmp.CompletedAt.Unix()
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
@@ -2,6 +2,7 @@ package conversationhandler
 
 import (
 	"context"
+	"time"
 
 	"jan-server/services/llm-api/internal/domain/conversation"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
@@ -165,6 +166,7 @@ func (h *BranchHandler) CreateBranch(ctx context.Context, conv *conversation.Con
 			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to create branch")
 		}
 	}
+	h.touch(ctx, conv)
 
 	// Get the created branch
 	branch, err := h.repo.GetBranch(ctx, conv.ID, req.Name)
@@ -205,10 +207,18 @@ func (h *BranchHandler) DeleteBranch(ctx context.Context, conv *conversation.Con
 	if err := h.repo.DeleteBranch(ctx, conv.ID, branchName); err != nil {
 		return platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to delete branch")
 	}
+	h.touch(ctx, conv)
 
 	return nil
 }
 
+// touch bumps the conversation's updated_at after a branch change, so item
+// list ETags derived from it change too. Failures are not fatal.
+func (h *BranchHandler) touch(ctx context.Context, conv *conversation.Conversation) {
+	conv.UpdatedAt = time.Now()
+	_ = h.repo.Touch(ctx, conv.ID, conv.UpdatedAt)
+}
+
 // ActivateBranch sets a branch as active
 func (h *BranchHandler) ActivateBranch(ctx context.Context, conv *conversation.Conversation, branchName string) (*ActivateBranchResponse, error) {
 	// Normalize "main" to "MAIN" for case-insensitive matching
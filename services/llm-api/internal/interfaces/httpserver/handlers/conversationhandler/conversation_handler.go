@@ -3,16 +3,22 @@ package conversationhandler
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
+	"jan-server/services/llm-api/internal/domain/coldstorage"
 	"jan-server/services/llm-api/internal/domain/conversation"
 	"jan-server/services/llm-api/internal/domain/project"
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
 	"jan-server/services/llm-api/internal/domain/query"
 	"jan-server/services/llm-api/internal/domain/share"
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/mediaclient"
 	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/requests"
 	conversationrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/conversation"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
 	conversationresponses "jan-server/services/llm-api/internal/interfaces/httpserver/responses/conversation"
@@ -36,6 +42,9 @@ type ConversationHandler struct {
 	projectService       *project.ProjectService
 	itemValidator        *conversation.ItemValidator
 	shareRepo            share.ShareRepository
+	promptLibraryService *promptlibrary.Service
+	mediaClient          *mediaclient.Client
+	coldStorage          *coldstorage.Service
 }
 
 // NewConversationHandler creates a new conversation handler
@@ -44,6 +53,9 @@ func NewConversationHandler(
 	messageActionService *conversation.MessageActionService,
 	projectService *project.ProjectService,
 	shareRepo share.ShareRepository,
+	promptLibraryService *promptlibrary.Service,
+	mediaClient *mediaclient.Client,
+	coldStorage *coldstorage.Service,
 ) *ConversationHandler {
 	return &ConversationHandler{
 		conversationService:  conversationService,
@@ -51,6 +63,9 @@ func NewConversationHandler(
 		projectService:       projectService,
 		itemValidator:        conversation.NewItemValidator(conversation.DefaultItemValidationConfig()),
 		shareRepo:            shareRepo,
+		promptLibraryService: promptLibraryService,
+		mediaClient:          mediaClient,
+		coldStorage:          coldStorage,
 	}
 }
 
@@ -60,14 +75,27 @@ func (h *ConversationHandler) CreateConversation(
 	userID uint,
 	req conversationrequests.CreateConversationRequest,
 ) (*conversationresponses.ConversationResponse, error) {
+	// Render the prompt library starter into the first items
+	items := req.Items
+	if req.PromptLibraryID != nil && *req.PromptLibraryID != "" {
+		starter, messages, err := h.promptLibraryService.Render(ctx, *req.PromptLibraryID, userID, req.PromptVariables)
+		if err != nil {
+			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to render prompt library starter")
+		}
+		items = append(starterItems(messages), req.Items...)
+		if req.Title == nil || *req.Title == "" {
+			req.Title = &starter.Title
+		}
+	}
+
 	// Validate item count (max 20 for initial creation per OpenAI spec)
-	if len(req.Items) > 20 {
+	if len(items) > 20 {
 		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
 			"cannot add more than 20 items at a time", nil, "items")
 	}
 
 	// Validate items before creating conversation
-	for i, item := range req.Items {
+	for i, item := range items {
 		if err := h.itemValidator.ValidateItem(item); err != nil {
 			return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation,
 				fmt.Sprintf("item validation failed at index %d", i), err, fmt.Sprintf("items[%d]", i))
@@ -104,15 +132,16 @@ func (h *ConversationHandler) CreateConversation(
 	}
 
 	metadata := req.Metadata
-	if metadata == nil {
-		metadata = map[string]string{}
+	titleLocked := req.TitleLocked
+	if legacy := takeLegacyTitleLock(metadata); titleLocked == nil {
+		titleLocked = legacy
 	}
-	metadata["title_locked"] = "false"
 
 	// Create conversation
 	input := conversation.CreateConversationInput{
 		UserID:          userID,
 		Title:           sanitizedTitle,
+		TitleLocked:     titleLocked != nil && *titleLocked,
 		Metadata:        metadata,
 		Referrer:        req.Referrer,
 		ProjectID:       projectID,
@@ -125,8 +154,8 @@ func (h *ConversationHandler) CreateConversation(
 	}
 
 	// Add items if provided
-	if len(req.Items) > 0 {
-		if err := h.addItemsToConversation(ctx, conv, req.Items); err != nil {
+	if len(items) > 0 {
+		if err := h.addItemsToConversation(ctx, conv, items); err != nil {
 			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to add items")
 		}
 	}
@@ -148,21 +177,6 @@ func (h *ConversationHandler) GetConversation(
 	return conversationresponses.NewConversationResponse(conv), nil
 }
 
-// ResolveConversationPublicIDToNumericID resolves a conversation public ID to its numeric ID
-// This is used for cursor-based pagination where the API exposes public IDs but the
-// underlying pagination system uses numeric IDs
-func (h *ConversationHandler) ResolveConversationPublicIDToNumericID(
-	ctx context.Context,
-	userID uint,
-	publicID string,
-) (*uint, error) {
-	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, publicID, userID)
-	if err != nil {
-		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to resolve conversation ID")
-	}
-	return &conv.ID, nil
-}
-
 // UpdateConversation updates a conversation
 func (h *ConversationHandler) UpdateConversation(
 	ctx context.Context,
@@ -187,31 +201,23 @@ func (h *ConversationHandler) UpdateConversation(
 	}
 
 	metadata := req.Metadata
-	if sanitizedTitle != nil {
-		conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
-		if err != nil {
-			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
-		}
-		branchName := conv.ActiveBranch
-		if branchName == "" {
-			branchName = conversation.BranchMain
-		}
-		itemCount, err := h.conversationService.CountConversationItems(ctx, conv, branchName)
-		if err != nil {
-			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to count conversation items")
-		}
-		if metadata == nil {
-			metadata = map[string]string{}
-		}
-		if itemCount > 1 {
-			metadata["title_locked"] = "true"
-		}
+	titleLocked := req.TitleLocked
+	if legacy := takeLegacyTitleLock(metadata); titleLocked == nil {
+		titleLocked = legacy
+	}
+	// A manual rename locks the title so the title generator stops overwriting it,
+	// unless the client explicitly asks to keep it unlocked.
+	if sanitizedTitle != nil && titleLocked == nil {
+		locked := true
+		titleLocked = &locked
 	}
 
 	input := conversation.UpdateConversationInput{
-		Title:    sanitizedTitle,
-		Metadata: metadata,
-		Referrer: req.Referrer,
+		Title:           sanitizedTitle,
+		TitleLocked:     titleLocked,
+		Metadata:        metadata,
+		Referrer:        req.Referrer,
+		ExpectedVersion: req.Version,
 	}
 
 	// Resolve and update project when provided
@@ -244,6 +250,18 @@ func (h *ConversationHandler) UpdateConversation(
 	return conversationresponses.NewConversationResponse(conv), nil
 }
 
+// takeLegacyTitleLock removes the legacy metadata["title_locked"] flag from
+// metadata and returns its value, or nil if it was not set.
+func takeLegacyTitleLock(metadata map[string]string) *bool {
+	value, ok := metadata["title_locked"]
+	if !ok {
+		return nil
+	}
+	delete(metadata, "title_locked")
+	locked := strings.EqualFold(strings.TrimSpace(value), "true")
+	return &locked
+}
+
 // ListConversations lists conversations with flexible filtering
 func (h *ConversationHandler) ListConversations(
 	ctx context.Context,
@@ -286,7 +304,12 @@ func (h *ConversationHandler) ListConversations(
 		conversations = conversations[:*requestedLimit]
 	}
 
-	return conversationresponses.NewConversationListResponse(conversations, hasMore, total), nil
+	response := conversationresponses.NewConversationListResponse(conversations, hasMore, total)
+	if userID != nil && len(conversations) > 0 && pagination != nil {
+		last := conversations[len(conversations)-1]
+		response.NextCursor = requests.NextTimeCursor(requests.ConversationCursorScope(*userID), hasMore, last.ID, last.UpdatedAt, pagination.Order)
+	}
+	return response, nil
 }
 
 // DeleteConversation deletes a conversation
@@ -374,6 +397,83 @@ func (h *ConversationHandler) ListItems(
 	return items, nil
 }
 
+// ListAttachments lists the images and files referenced across a conversation
+// branch, each once, with media-api objects resolved to a download URL and size.
+// When media-api cannot be reached the attachments are returned as stored.
+func (h *ConversationHandler) ListAttachments(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+	branchName *string,
+	authHeader string,
+) (*conversationresponses.AttachmentListResponse, error) {
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+
+	branch := conv.ActiveBranch
+	if branchName != nil && *branchName != "" {
+		branch = *branchName
+	}
+
+	items, err := h.conversationService.GetConversationItems(ctx, conv, branch, nil)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to list items")
+	}
+
+	var attachments []conversation.Attachment
+	seen := make(map[string]bool)
+	for i := range items {
+		for _, attachment := range items[i].Attachments() {
+			// The same upload is often sent again in later turns; list it where it first appeared
+			key := attachment.MediaID
+			if key == "" {
+				key = attachment.URL
+			}
+			if key != "" {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			attachments = append(attachments, attachment)
+		}
+	}
+
+	var mediaIDs []string
+	for _, attachment := range attachments {
+		if attachment.MediaID != "" {
+			mediaIDs = append(mediaIDs, attachment.MediaID)
+		}
+	}
+	if len(mediaIDs) > 0 && h.mediaClient != nil {
+		resolved, err := h.mediaClient.Resolve(ctx, mediaIDs, authHeader)
+		if err != nil {
+			log := logger.GetLogger()
+			log.Warn().Err(err).Str("conversation_id", conv.PublicID).
+				Msg("failed to resolve conversation attachments, returning stored references")
+		}
+		for i := range attachments {
+			obj, ok := resolved[attachments[i].MediaID]
+			if !ok {
+				continue
+			}
+			attachments[i].URL = obj.URL
+			if obj.Mime != "" {
+				attachments[i].MimeType = obj.Mime
+			}
+			attachments[i].Bytes = obj.Bytes
+			if obj.ExpiresAt != nil {
+				expiresAt := time.Unix(*obj.ExpiresAt, 0)
+				attachments[i].URLExpiresAt = &expiresAt
+			}
+		}
+	}
+
+	return conversationresponses.NewAttachmentListResponse(attachments), nil
+}
+
 // ResolveItemPublicIDToNumericID resolves an item public ID to its numeric ID
 // This is used for cursor-based pagination where the API exposes public IDs but the
 // underlying pagination system uses numeric IDs
@@ -487,6 +587,158 @@ func (h *ConversationHandler) DeleteItem(
 	}, nil
 }
 
+// EditItem edits an assistant message or tool output in place, keeping the
+// previous content in the item's edit history
+func (h *ConversationHandler) EditItem(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+	itemID string,
+	req conversationrequests.EditItemRequest,
+) (*conversationresponses.ItemResponse, error) {
+	// Verify conversation ownership
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+
+	item, err := h.conversationService.EditConversationItem(ctx, conv, itemID, conversation.EditItemInput{
+		EditorUserID: userID,
+		Content:      req.Content,
+		Output:       req.Output,
+		Reason:       req.Reason,
+	})
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to edit item")
+	}
+
+	return item, nil
+}
+
+// TruncateItem cuts an assistant message back to the audio the user heard
+func (h *ConversationHandler) TruncateItem(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+	itemID string,
+	req conversationrequests.TruncateItemRequest,
+) (*conversationresponses.ItemResponse, error) {
+	// Verify conversation ownership
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+
+	item, err := h.conversationService.TruncateConversationItem(ctx, conv, itemID, *req.ContentIndex, *req.AudioEndMs)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to truncate item")
+	}
+
+	return item, nil
+}
+
+// ListItemEdits returns the edit history of an item
+func (h *ConversationHandler) ListItemEdits(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+	itemID string,
+) (*conversationresponses.ItemEditListResponse, error) {
+	// Verify conversation ownership
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+
+	edits, err := h.conversationService.ListConversationItemEdits(ctx, conv, itemID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to list item edits")
+	}
+
+	return conversationresponses.NewItemEditListResponse(itemID, edits), nil
+}
+
+// GetInstruction returns the project instruction the conversation is pinned to
+// and whether the project's instruction changed since
+func (h *ConversationHandler) GetInstruction(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+) (*conversationresponses.InstructionResponse, error) {
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+	if conv.ProjectPublicID == nil || *conv.ProjectPublicID == "" {
+		return conversationresponses.NewInstructionResponse(conv, 0), nil
+	}
+
+	proj, err := h.projectService.GetProjectByPublicIDAndUserID(ctx, *conv.ProjectPublicID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation project")
+	}
+	return conversationresponses.NewInstructionResponse(conv, proj.InstructionVersion), nil
+}
+
+// RefreshInstruction pins the conversation to its project's current instruction
+func (h *ConversationHandler) RefreshInstruction(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+) (*conversationresponses.InstructionResponse, error) {
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+	if conv.ProjectPublicID == nil || *conv.ProjectPublicID == "" {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "conversation does not belong to a project", nil, "5b2e8f1a-7c43-4d96-a0b5-3e9c6f2d8a17")
+	}
+
+	proj, err := h.projectService.GetProjectByPublicIDAndUserID(ctx, *conv.ProjectPublicID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation project")
+	}
+	instruction := ""
+	if proj.Instruction != nil {
+		instruction = strings.TrimSpace(*proj.Instruction)
+	}
+	if err := h.conversationService.PinInstruction(ctx, conv, proj.InstructionVersion, instruction); err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to refresh instruction")
+	}
+	return conversationresponses.NewInstructionResponse(conv, proj.InstructionVersion), nil
+}
+
+// Rehydrate restores the items of a conversation from cold storage
+func (h *ConversationHandler) Rehydrate(
+	ctx context.Context,
+	userID uint,
+	conversationID string,
+) (*conversationresponses.RehydrationResponse, error) {
+	conv, err := h.conversationService.GetConversationByPublicIDAndUserID(ctx, conversationID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get conversation")
+	}
+	restored, err := h.coldStorage.Rehydrate(ctx, conv.ID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to rehydrate conversation")
+	}
+	return conversationresponses.NewRehydrationResponse(conv, restored), nil
+}
+
+// RehydrateIfCold restores the items of a conversation from cold storage before
+// it is written to, so edits, branches and completions see the full history
+func (h *ConversationHandler) RehydrateIfCold(ctx context.Context, conv *conversation.Conversation) error {
+	restored, err := h.coldStorage.Rehydrate(ctx, conv.ID)
+	if err != nil {
+		return platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to rehydrate conversation")
+	}
+	if restored > 0 {
+		log := logger.GetLogger()
+		log.Info().Str("conversation_id", conv.PublicID).Int("items", restored).Msg("rehydrated conversation from cold storage")
+	}
+	return nil
+}
+
 // UpdateItemByCallID updates an existing mcp_call item with tool execution results
 // The mcp_call item was already created (with in_progress status) when the LLM returned tool_calls
 // This is used by MCP tools to report tool execution results
@@ -519,6 +771,10 @@ func (h *ConversationHandler) UpdateItemByCallID(
 	mcpItem.Status = &status
 	mcpItem.Output = req.Output
 	mcpItem.Error = req.Error
+	mcpItem.ErrorDetail = req.ErrorDetail
+	if mcpItem.Error == nil && req.ErrorDetail != nil {
+		mcpItem.Error = &req.ErrorDetail.Message
+	}
 	now := time.Now()
 	mcpItem.CompletedAt = &now
 
@@ -542,13 +798,13 @@ func (h *ConversationHandler) UpdateItemByCallID(
 				TextString: req.Output,
 			},
 		}
-	} else if req.Error != nil {
+	} else if mcpItem.Error != nil {
 		// If there's an error, include it in the content
 		mcpItem.Content = []conversation.Content{
 			{
 				Type:       "mcp_call",
 				ToolCallID: &callID,
-				TextString: req.Error,
+				TextString: mcpItem.Error,
 			},
 		}
 	}
@@ -589,6 +845,26 @@ func (h *ConversationHandler) addItemsToConversation(ctx context.Context, conv *
 	}
 }
 
+// starterItems converts rendered prompt library messages into completed message items
+func starterItems(messages []promptlibrary.Message) []conversation.Item {
+	items := make([]conversation.Item, 0, len(messages))
+	for _, msg := range messages {
+		role := conversation.ItemRole(msg.Role)
+		status := conversation.ItemStatusCompleted
+		content := conversation.NewInputTextContent(msg.Content)
+		if role == conversation.ItemRoleAssistant {
+			content = conversation.NewOutputTextContent(msg.Content, nil)
+		}
+		items = append(items, conversation.Item{
+			Type:    conversation.ItemTypeMessage,
+			Role:    &role,
+			Status:  &status,
+			Content: []conversation.Content{content},
+		})
+	}
+	return items
+}
+
 // ===============================================
 // Middleware Functions
 // ===============================================
@@ -615,7 +891,19 @@ func (h *ConversationHandler) ConversationMiddleware() gin.HandlerFunc {
 		if err != nil {
 			responses.HandleError(reqCtx, err, "Failed to retrieve conversation")
 			return
-		} // Store conversation in context
+		}
+
+		// Reads return cold items as stubs; anything else works on the full history
+		switch reqCtx.Request.Method {
+		case http.MethodGet, http.MethodHead, http.MethodDelete:
+		default:
+			if err := h.RehydrateIfCold(ctx, conv); err != nil {
+				responses.HandleError(reqCtx, err, "Failed to rehydrate conversation")
+				return
+			}
+		}
+
+		// Store conversation in context
 		SetConversationToContext(reqCtx, conv)
 		reqCtx.Next()
 	}
@@ -0,0 +1,54 @@
+package conversationhandler
+
+import (
+	"context"
+
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/infrastructure/keycloak"
+	"jan-server/services/llm-api/internal/infrastructure/mediaclient"
+)
+
+// archiveMimeType is the type of the gzipped archives media-api stores
+const archiveMimeType = "application/gzip"
+
+// MediaColdStore implements coldstorage.Store by keeping archives in media-api,
+// authenticated as the backend service account
+type MediaColdStore struct {
+	mediaClient    *mediaclient.Client
+	keycloakClient *keycloak.Client
+}
+
+var _ coldstorage.Store = (*MediaColdStore)(nil)
+
+// NewMediaColdStore creates a new media cold store; mediaClient is nil when
+// media-api is not configured
+func NewMediaColdStore(mediaClient *mediaclient.Client, keycloakClient *keycloak.Client) *MediaColdStore {
+	return &MediaColdStore{mediaClient: mediaClient, keycloakClient: keycloakClient}
+}
+
+// Available reports whether media-api is configured
+func (s *MediaColdStore) Available() bool {
+	return s.mediaClient != nil && s.keycloakClient != nil
+}
+
+// Put uploads an archive and returns its media ID
+func (s *MediaColdStore) Put(ctx context.Context, data []byte, filename string) (string, error) {
+	authHeader, err := s.keycloakClient.ServiceAuthorization(ctx)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.mediaClient.UploadFile(ctx, data, archiveMimeType, filename, authHeader)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
+
+// Get downloads the archive with the given media ID
+func (s *MediaColdStore) Get(ctx context.Context, mediaID string) ([]byte, error) {
+	authHeader, err := s.keycloakClient.ServiceAuthorization(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.mediaClient.Download(ctx, mediaID, authHeader)
+}
@@ -0,0 +1,544 @@
+package evalhandler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/application/audit"
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/domain/query"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	evalrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/eval"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// EvalHandler manages eval datasets and runs for admins
+type EvalHandler struct {
+	evalService *eval.Service
+	audit       *audit.AdminAuditLogger
+}
+
+// NewEvalHandler creates a new eval handler
+func NewEvalHandler(evalService *eval.Service, auditLogger *audit.AdminAuditLogger) *EvalHandler {
+	return &EvalHandler{
+		evalService: evalService,
+		audit:       auditLogger,
+	}
+}
+
+// DatasetResponse is an eval dataset
+type DatasetResponse struct {
+	ID          string          `json:"id"`
+	Object      string          `json:"object"`
+	Name        string          `json:"name"`
+	Description *string         `json:"description,omitempty"`
+	Grader      eval.GraderSpec `json:"grader"`
+	ItemCount   int             `json:"item_count"`
+	CreatedAt   int64           `json:"created_at"`
+}
+
+// DatasetItemResponse is one prompt of a dataset
+type DatasetItemResponse struct {
+	Position  int              `json:"position"`
+	Input     string           `json:"input"`
+	Variables map[string]any   `json:"variables,omitempty"`
+	Expected  string           `json:"expected,omitempty"`
+	Grader    *eval.GraderSpec `json:"grader,omitempty"`
+}
+
+// DatasetDetailResponse is a dataset with its items
+type DatasetDetailResponse struct {
+	DatasetResponse
+	Items []DatasetItemResponse `json:"items"`
+}
+
+// DatasetListResponse is a page of datasets
+type DatasetListResponse struct {
+	Object string            `json:"object"`
+	Data   []DatasetResponse `json:"data"`
+	Total  int64             `json:"total"`
+}
+
+// RunResponse is an eval run with its aggregate scores
+type RunResponse struct {
+	ID               string  `json:"id"`
+	Object           string  `json:"object"`
+	DatasetID        string  `json:"dataset_id"`
+	Model            string  `json:"model"`
+	PromptTemplateID *string `json:"prompt_template_id,omitempty"`
+	TemplateKey      *string `json:"template_key,omitempty"`
+	TemplateVersion  *int    `json:"template_version,omitempty"`
+	Status           string  `json:"status"`
+	TotalItems       int     `json:"total_items"`
+	CompletedItems   int     `json:"completed_items"`
+	PassedItems      int     `json:"passed_items"`
+	ErroredItems     int     `json:"errored_items"`
+	PassRate         float64 `json:"pass_rate"`
+	AverageScore     float64 `json:"average_score"`
+	Error            string  `json:"error,omitempty"`
+	StartedAt        *int64  `json:"started_at,omitempty"`
+	CompletedAt      *int64  `json:"completed_at,omitempty"`
+	CreatedAt        int64   `json:"created_at"`
+}
+
+// RunListResponse is a page of runs
+type RunListResponse struct {
+	Object string        `json:"object"`
+	Data   []RunResponse `json:"data"`
+	Total  int64         `json:"total"`
+}
+
+// RunResultResponse is the graded output of one dataset item
+type RunResultResponse struct {
+	Position  int     `json:"position"`
+	Output    string  `json:"output"`
+	Score     float64 `json:"score"`
+	Passed    bool    `json:"passed"`
+	Grader    string  `json:"grader"`
+	Reasoning string  `json:"reasoning,omitempty"`
+	LatencyMs int64   `json:"latency_ms"`
+	Error     string  `json:"error,omitempty"`
+}
+
+// RunResultListResponse is the per-item results of a run
+type RunResultListResponse struct {
+	Object string              `json:"object"`
+	RunID  string              `json:"run_id"`
+	Data   []RunResultResponse `json:"data"`
+}
+
+// RunComparisonResponse compares a candidate run with a baseline run
+type RunComparisonResponse struct {
+	Object        string            `json:"object"`
+	Baseline      RunResponse       `json:"baseline"`
+	Candidate     RunResponse       `json:"candidate"`
+	ScoreDelta    float64           `json:"score_delta"`
+	PassRateDelta float64           `json:"pass_rate_delta"`
+	Regressions   []eval.ItemChange `json:"regressions"`  // Passed in the baseline, failed in the candidate
+	Improvements  []eval.ItemChange `json:"improvements"` // Failed in the baseline, passed in the candidate
+}
+
+// CreateDataset godoc
+// @Summary Upload an eval dataset
+// @Description Creates a dataset of prompts with expected answers and a default grader. Items may override the grader.
+// @Description
+// @Description Graders: `exact_match` compares the trimmed output with `expected` (case-insensitive unless `case_sensitive`); `llm_judge` asks a judge model to score the output against `expected` from 0 to 1 and passes items scoring at least `pass_threshold` (default 0.5).
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body evalrequests.CreateDatasetRequest true "Dataset definition"
+// @Success 201 {object} DatasetResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/datasets [post]
+func (h *EvalHandler) CreateDataset(c *gin.Context) {
+	var request evalrequests.CreateDatasetRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	dataset, err := h.evalService.CreateDataset(c.Request.Context(), request.ToInput(), principalID(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to create dataset")
+		return
+	}
+
+	h.logAudit(c, "create_eval_dataset", "eval_dataset", dataset.PublicID, gin.H{"name": dataset.Name, "items": dataset.ItemCount}, http.StatusCreated)
+	c.JSON(http.StatusCreated, toDatasetResponse(dataset))
+}
+
+// ListDatasets godoc
+// @Summary List eval datasets
+// @Description Lists datasets, newest first.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} DatasetListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/datasets [get]
+func (h *EvalHandler) ListDatasets(c *gin.Context) {
+	datasets, total, err := h.evalService.ListDatasets(c.Request.Context(), parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list datasets")
+		return
+	}
+
+	data := make([]DatasetResponse, 0, len(datasets))
+	for _, dataset := range datasets {
+		data = append(data, toDatasetResponse(dataset))
+	}
+	c.JSON(http.StatusOK, DatasetListResponse{Object: "list", Data: data, Total: total})
+}
+
+// GetDataset godoc
+// @Summary Get an eval dataset
+// @Description Returns a dataset with all of its items.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Produce json
+// @Param dataset_id path string true "Dataset ID"
+// @Success 200 {object} DatasetDetailResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/datasets/{dataset_id} [get]
+func (h *EvalHandler) GetDataset(c *gin.Context) {
+	ctx := c.Request.Context()
+	dataset, err := h.evalService.GetDataset(ctx, c.Param("dataset_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get dataset")
+		return
+	}
+	items, err := h.evalService.GetDatasetItems(ctx, dataset)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get dataset items")
+		return
+	}
+
+	detail := DatasetDetailResponse{
+		DatasetResponse: toDatasetResponse(dataset),
+		Items:           make([]DatasetItemResponse, 0, len(items)),
+	}
+	for _, item := range items {
+		detail.Items = append(detail.Items, DatasetItemResponse{
+			Position:  item.Position,
+			Input:     item.Input,
+			Variables: item.Variables,
+			Expected:  item.Expected,
+			Grader:    item.Grader,
+		})
+	}
+	c.JSON(http.StatusOK, detail)
+}
+
+// DeleteDataset godoc
+// @Summary Delete an eval dataset
+// @Description Deletes a dataset together with its runs and results.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Param dataset_id path string true "Dataset ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/datasets/{dataset_id} [delete]
+func (h *EvalHandler) DeleteDataset(c *gin.Context) {
+	datasetID := c.Param("dataset_id")
+	if err := h.evalService.DeleteDataset(c.Request.Context(), datasetID); err != nil {
+		responses.HandleError(c, err, "failed to delete dataset")
+		return
+	}
+
+	h.logAudit(c, "delete_eval_dataset", "eval_dataset", datasetID, nil, http.StatusNoContent)
+	c.Status(http.StatusNoContent)
+}
+
+// CreateRun godoc
+// @Summary Start an eval run
+// @Description Queues a run of a dataset against a model, optionally with a prompt template rendered as the system prompt using each item's `variables`. The template content and version are captured when the run is created, so runs of different template versions can be compared later.
+// @Description
+// @Description Runs execute in the background; poll `GET /v1/admin/evals/runs/{run_id}` until `status` is `completed` or `failed`.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body evalrequests.CreateRunRequest true "Run definition"
+// @Success 202 {object} RunResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Dataset or prompt template not found"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/runs [post]
+func (h *EvalHandler) CreateRun(c *gin.Context) {
+	var request evalrequests.CreateRunRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	ctx := c.Request.Context()
+	run, err := h.evalService.CreateRun(ctx, request.ToInput(), principalID(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to create run")
+		return
+	}
+
+	h.logAudit(c, "create_eval_run", "eval_run", run.PublicID, request, http.StatusAccepted)
+	c.JSON(http.StatusAccepted, toRunResponse(run, request.DatasetID))
+}
+
+// ListRuns godoc
+// @Summary List eval runs
+// @Description Lists runs, newest first, optionally for a single dataset.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Produce json
+// @Param dataset_id query string false "Only runs of this dataset"
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} RunListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Dataset not found"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/runs [get]
+func (h *EvalHandler) ListRuns(c *gin.Context) {
+	ctx := c.Request.Context()
+	var datasetID *string
+	if id := c.Query("dataset_id"); id != "" {
+		datasetID = &id
+	}
+
+	runs, total, err := h.evalService.ListRuns(ctx, datasetID, parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list runs")
+		return
+	}
+
+	datasetIDs := make(map[uint]string)
+	data := make([]RunResponse, 0, len(runs))
+	for _, run := range runs {
+		publicID, err := h.datasetPublicID(c, datasetIDs, run.DatasetID)
+		if err != nil {
+			responses.HandleError(c, err, "failed to list runs")
+			return
+		}
+		data = append(data, toRunResponse(run, publicID))
+	}
+	c.JSON(http.StatusOK, RunListResponse{Object: "list", Data: data, Total: total})
+}
+
+// GetRun godoc
+// @Summary Get an eval run
+// @Description Returns a run with its progress and aggregate scores.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Produce json
+// @Param run_id path string true "Run ID"
+// @Success 200 {object} RunResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/runs/{run_id} [get]
+func (h *EvalHandler) GetRun(c *gin.Context) {
+	run, err := h.evalService.GetRun(c.Request.Context(), c.Param("run_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get run")
+		return
+	}
+	datasetID, err := h.datasetPublicID(c, nil, run.DatasetID)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get run")
+		return
+	}
+	c.JSON(http.StatusOK, toRunResponse(run, datasetID))
+}
+
+// GetRunResults godoc
+// @Summary Get eval run results
+// @Description Returns the output, score and grader verdict of every item evaluated so far, in dataset order.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Produce json
+// @Param run_id path string true "Run ID"
+// @Success 200 {object} RunResultListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/runs/{run_id}/results [get]
+func (h *EvalHandler) GetRunResults(c *gin.Context) {
+	ctx := c.Request.Context()
+	run, err := h.evalService.GetRun(ctx, c.Param("run_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get run")
+		return
+	}
+	results, err := h.evalService.GetRunResults(ctx, run)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get run results")
+		return
+	}
+
+	data := make([]RunResultResponse, 0, len(results))
+	for _, r := range results {
+		data = append(data, RunResultResponse{
+			Position:  r.Position,
+			Output:    r.Output,
+			Score:     r.Score,
+			Passed:    r.Passed,
+			Grader:    r.Grader,
+			Reasoning: r.Reasoning,
+			LatencyMs: r.LatencyMs,
+			Error:     r.Error,
+		})
+	}
+	c.JSON(http.StatusOK, RunResultListResponse{Object: "list", RunID: run.PublicID, Data: data})
+}
+
+// CompareRuns godoc
+// @Summary Compare two eval runs
+// @Description Compares a run with a baseline run of the same dataset, e.g. a new prompt template version against the previous one. Deltas are candidate minus baseline; items that flipped from pass to fail are listed as regressions.
+// @Tags Admin - Evals
+// @Security BearerAuth
+// @Produce json
+// @Param run_id path string true "Candidate run ID"
+// @Param baseline query string true "Baseline run ID"
+// @Success 200 {object} RunComparisonResponse
+// @Failure 400 {object} responses.ErrorResponse "Missing baseline or runs of different datasets"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse "A run is not completed"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/evals/runs/{run_id}/compare [get]
+func (h *EvalHandler) CompareRuns(c *gin.Context) {
+	baseline := c.Query("baseline")
+	if baseline == "" {
+		responses.HandleNewError(c, platformerrors.ErrorTypeValidation, "baseline query parameter is required", "ddac3079-6eee-4a4f-8f05-69be68c32165")
+		return
+	}
+
+	comparison, err := h.evalService.CompareRuns(c.Request.Context(), c.Param("run_id"), baseline)
+	if err != nil {
+		responses.HandleError(c, err, "failed to compare runs")
+		return
+	}
+	datasetID, err := h.datasetPublicID(c, nil, comparison.Candidate.DatasetID)
+	if err != nil {
+		responses.HandleError(c, err, "failed to compare runs")
+		return
+	}
+
+	c.JSON(http.StatusOK, RunComparisonResponse{
+		Object:        "eval.run_comparison",
+		Baseline:      toRunResponse(comparison.Baseline, datasetID),
+		Candidate:     toRunResponse(comparison.Candidate, datasetID),
+		ScoreDelta:    comparison.ScoreDelta,
+		PassRateDelta: comparison.PassRateDelta,
+		Regressions:   comparison.Regressions,
+		Improvements:  comparison.Improvements,
+	})
+}
+
+// datasetPublicID resolves the public ID of a dataset, caching lookups in cache when given
+func (h *EvalHandler) datasetPublicID(c *gin.Context, cache map[uint]string, datasetID uint) (string, error) {
+	if publicID, ok := cache[datasetID]; ok {
+		return publicID, nil
+	}
+	dataset, err := h.evalService.GetDatasetByID(c.Request.Context(), datasetID)
+	if err != nil {
+		return "", err
+	}
+	if cache != nil {
+		cache[datasetID] = dataset.PublicID
+	}
+	return dataset.PublicID, nil
+}
+
+func (h *EvalHandler) logAudit(c *gin.Context, action, resourceType, resourceID string, payload any, status int) {
+	if h.audit == nil {
+		return
+	}
+	principal, hasPrincipal := middleware.PrincipalFromContext(c)
+	if !hasPrincipal {
+		return
+	}
+	h.audit.Log(c.Request.Context(), audit.AdminAuditEntry{
+		AdminUserID: principal.ID,
+		AdminEmail:  principal.Email,
+		Action:      action,
+		Resource:    resourceType,
+		ResourceID:  resourceID,
+		Payload:     payload,
+		StatusCode:  status,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+}
+
+func principalID(c *gin.Context) *string {
+	principal, ok := middleware.PrincipalFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &principal.ID
+}
+
+func parsePagination(c *gin.Context) *query.Pagination {
+	limit := defaultListLimit
+	offset := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxListLimit)
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return &query.Pagination{Limit: &limit, Offset: &offset}
+}
+
+func toDatasetResponse(dataset *eval.Dataset) DatasetResponse {
+	return DatasetResponse{
+		ID:          dataset.PublicID,
+		Object:      "eval.dataset",
+		Name:        dataset.Name,
+		Description: dataset.Description,
+		Grader:      dataset.Grader,
+		ItemCount:   dataset.ItemCount,
+		CreatedAt:   dataset.CreatedAt.Unix(),
+	}
+}
+
+func toRunResponse(run *eval.Run, datasetID string) RunResponse {
+	resp := RunResponse{
+		ID:               run.PublicID,
+		Object:           "eval.run",
+		DatasetID:        datasetID,
+		Model:            run.Model,
+		PromptTemplateID: run.PromptTemplateID,
+		TemplateKey:      run.TemplateKey,
+		TemplateVersion:  run.TemplateVersion,
+		Status:           string(run.Status),
+		TotalItems:       run.TotalItems,
+		CompletedItems:   run.CompletedItems,
+		PassedItems:      run.PassedItems,
+		ErroredItems:     run.ErroredItems,
+		PassRate:         run.PassRate(),
+		AverageScore:     run.AverageScore,
+		Error:            run.Error,
+		CreatedAt:        run.CreatedAt.Unix(),
+	}
+	if run.StartedAt != nil {
+		startedAt := run.StartedAt.Unix()
+		resp.StartedAt = &startedAt
+	}
+	if run.CompletedAt != nil {
+		completedAt := run.CompletedAt.Unix()
+		resp.CompletedAt = &completedAt
+	}
+	return resp
+}
@@ -0,0 +1,70 @@
+package evalhandler
+
+import (
+	"context"
+	"fmt"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/infrastructure/inference"
+	modelHandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelhandler"
+	"jan-server/services/llm-api/internal/utils/httpclients/chat"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// ModelCompleter implements eval.Completer by routing requests through the configured providers
+type ModelCompleter struct {
+	inferenceProvider *inference.InferenceProvider
+	providerHandler   *modelHandler.ProviderHandler
+}
+
+var _ eval.Completer = (*ModelCompleter)(nil)
+
+// NewModelCompleter creates a new model completer
+func NewModelCompleter(
+	inferenceProvider *inference.InferenceProvider,
+	providerHandler *modelHandler.ProviderHandler,
+) *ModelCompleter {
+	return &ModelCompleter{
+		inferenceProvider: inferenceProvider,
+		providerHandler:   providerHandler,
+	}
+}
+
+// Complete sends a non-streaming chat completion to the model and returns the reply text
+func (m *ModelCompleter) Complete(ctx context.Context, model string, messages []eval.Message) (string, error) {
+	providerModel, provider, err := m.providerHandler.SelectProviderModelForModelPublicID(ctx, model)
+	if err != nil {
+		return "", err
+	}
+	if providerModel == nil || provider == nil {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound,
+			fmt.Sprintf("model not found: %s", model), nil, "527fff71-f1a6-44a7-b34e-30d5b103b213")
+	}
+
+	client, err := m.inferenceProvider.GetChatCompletionClient(ctx, provider)
+	if err != nil {
+		return "", platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to create chat client")
+	}
+
+	chatMessages := make([]openai.ChatCompletionMessage, 0, len(messages))
+	for _, msg := range messages {
+		chatMessages = append(chatMessages, openai.ChatCompletionMessage{Role: msg.Role, Content: msg.Content})
+	}
+
+	response, err := client.CreateChatCompletion(ctx, "", chat.CompletionRequest{
+		ChatCompletionRequest: openai.ChatCompletionRequest{
+			Model:    providerModel.ProviderOriginalModelID,
+			Messages: chatMessages,
+		},
+	})
+	if err != nil {
+		return "", err
+	}
+	if response == nil || len(response.Choices) == 0 {
+		return "", platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeExternal,
+			fmt.Sprintf("model %s returned no choices", model), nil, "7e3ed044-c2ae-45be-b563-a233245eb028")
+	}
+	return response.Choices[0].Message.Content, nil
+}
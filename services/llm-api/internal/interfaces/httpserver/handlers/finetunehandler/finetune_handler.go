@@ -0,0 +1,216 @@
+package finetunehandler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/application/audit"
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/domain/query"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	finetunerequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/finetune"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// FinetuneHandler manages fine-tuning dataset exports for admins
+type FinetuneHandler struct {
+	finetuneService *finetune.Service
+	audit           *audit.AdminAuditLogger
+}
+
+// NewFinetuneHandler creates a new fine-tuning export handler
+func NewFinetuneHandler(finetuneService *finetune.Service, auditLogger *audit.AdminAuditLogger) *FinetuneHandler {
+	return &FinetuneHandler{
+		finetuneService: finetuneService,
+		audit:           auditLogger,
+	}
+}
+
+// ExportResponse is a fine-tuning dataset export
+type ExportResponse struct {
+	ID                 string  `json:"id"`
+	Object             string  `json:"object"`
+	Format             string  `json:"format"`
+	Status             string  `json:"status"`
+	RedactPII          bool    `json:"redact_pii"`
+	RatedAfter         *int64  `json:"rated_after,omitempty"`
+	RatedBefore        *int64  `json:"rated_before,omitempty"`
+	MaxContextMessages int     `json:"max_context_messages"`
+	ExampleCount       int     `json:"example_count"`
+	MediaID            *string `json:"media_id,omitempty"` // Download with GET /v1/media/{media_id} on media-api
+	Bytes              int64   `json:"bytes"`
+	Error              string  `json:"error,omitempty"`
+	CompletedAt        *int64  `json:"completed_at,omitempty"`
+	CreatedAt          int64   `json:"created_at"`
+}
+
+// ExportListResponse is a page of exports
+type ExportListResponse struct {
+	Object string           `json:"object"`
+	Data   []ExportResponse `json:"data"`
+	Total  int64            `json:"total"`
+}
+
+// CreateExport godoc
+// @Summary Start a fine-tuning dataset export
+// @Description Converts liked assistant turns, each with the messages that preceded it in its conversation branch, into a JSONL training file. PII (emails, phone numbers, card numbers, SSNs and IP addresses) is replaced with placeholders such as `[EMAIL]` unless `redact_pii` is false.
+// @Description
+// @Description Formats: `openai` writes `{"messages":[...]}` lines for OpenAI fine-tuning, `chatml` writes `{"text":"<|im_start|>..."}` lines for chat-template trainers and `sharegpt` writes `{"conversations":[{"from","value"}]}` lines.
+// @Description
+// @Description Exports are built in the background; poll `GET /v1/admin/finetune/exports/{export_id}` until `status` is `completed`, then download the file from media-api with `GET /v1/media/{media_id}`.
+// @Tags Admin - Fine-tuning
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body finetunerequests.CreateExportRequest true "Export definition"
+// @Success 202 {object} ExportResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/finetune/exports [post]
+func (h *FinetuneHandler) CreateExport(c *gin.Context) {
+	var request finetunerequests.CreateExportRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	export, err := h.finetuneService.CreateExport(c.Request.Context(), request.ToInput(), principalID(c), c.GetHeader("Authorization"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to create export")
+		return
+	}
+
+	h.logAudit(c, "create_finetune_export", "finetune_export", export.PublicID, request, http.StatusAccepted)
+	c.JSON(http.StatusAccepted, toExportResponse(export))
+}
+
+// ListExports godoc
+// @Summary List fine-tuning dataset exports
+// @Description Lists exports, newest first.
+// @Tags Admin - Fine-tuning
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} ExportListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/finetune/exports [get]
+func (h *FinetuneHandler) ListExports(c *gin.Context) {
+	exports, total, err := h.finetuneService.ListExports(c.Request.Context(), parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list exports")
+		return
+	}
+
+	data := make([]ExportResponse, 0, len(exports))
+	for _, export := range exports {
+		data = append(data, toExportResponse(export))
+	}
+	c.JSON(http.StatusOK, ExportListResponse{Object: "list", Data: data, Total: total})
+}
+
+// GetExport godoc
+// @Summary Get a fine-tuning dataset export
+// @Description Returns an export with its status and, once completed, the media ID of the JSONL file.
+// @Tags Admin - Fine-tuning
+// @Security BearerAuth
+// @Produce json
+// @Param export_id path string true "Export ID"
+// @Success 200 {object} ExportResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/finetune/exports/{export_id} [get]
+func (h *FinetuneHandler) GetExport(c *gin.Context) {
+	export, err := h.finetuneService.GetExport(c.Request.Context(), c.Param("export_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get export")
+		return
+	}
+	c.JSON(http.StatusOK, toExportResponse(export))
+}
+
+func parsePagination(c *gin.Context) *query.Pagination {
+	limit := defaultListLimit
+	offset := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxListLimit)
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return &query.Pagination{Limit: &limit, Offset: &offset}
+}
+
+func toExportResponse(export *finetune.Export) ExportResponse {
+	return ExportResponse{
+		ID:                 export.PublicID,
+		Object:             "finetune.export",
+		Format:             export.Format,
+		Status:             string(export.Status),
+		RedactPII:          export.RedactPII,
+		RatedAfter:         unixPtr(export.RatedAfter),
+		RatedBefore:        unixPtr(export.RatedBefore),
+		MaxContextMessages: export.MaxContextMessages,
+		ExampleCount:       export.ExampleCount,
+		MediaID:            export.MediaID,
+		Bytes:              export.Bytes,
+		Error:              export.Error,
+		CompletedAt:        unixPtr(export.CompletedAt),
+		CreatedAt:          export.CreatedAt.Unix(),
+	}
+}
+
+func unixPtr(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	v := t.Unix()
+	return &v
+}
+
+func (h *FinetuneHandler) logAudit(c *gin.Context, action, resourceType, resourceID string, payload any, status int) {
+	if h.audit == nil {
+		return
+	}
+	principal, hasPrincipal := middleware.PrincipalFromContext(c)
+	if !hasPrincipal {
+		return
+	}
+	h.audit.Log(c.Request.Context(), audit.AdminAuditEntry{
+		AdminUserID: principal.ID,
+		AdminEmail:  principal.Email,
+		Action:      action,
+		Resource:    resourceType,
+		ResourceID:  resourceID,
+		Payload:     payload,
+		StatusCode:  status,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+}
+
+func principalID(c *gin.Context) *string {
+	principal, ok := middleware.PrincipalFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &principal.ID
+}
@@ -0,0 +1,29 @@
+package finetunehandler
+
+import (
+	"context"
+
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/infrastructure/mediaclient"
+)
+
+// MediaUploader implements finetune.Uploader by storing export files in media-api
+type MediaUploader struct {
+	mediaClient *mediaclient.Client
+}
+
+var _ finetune.Uploader = (*MediaUploader)(nil)
+
+// NewMediaUploader creates a new media uploader
+func NewMediaUploader(mediaClient *mediaclient.Client) *MediaUploader {
+	return &MediaUploader{mediaClient: mediaClient}
+}
+
+// UploadFile uploads the file and returns its media ID
+func (u *MediaUploader) UploadFile(ctx context.Context, data []byte, mimeType, filename, authHeader string) (string, error) {
+	resp, err := u.mediaClient.UploadFile(ctx, data, mimeType, filename, authHeader)
+	if err != nil {
+		return "", err
+	}
+	return resp.ID, nil
+}
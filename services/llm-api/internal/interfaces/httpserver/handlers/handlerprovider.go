@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
 
 	"jan-server/services/llm-api/internal/config"
 	"jan-server/services/llm-api/internal/domain/usersettings"
@@ -19,9 +20,10 @@ import (
 func ProvideMemoryHandler(
 	memoryClient *memory.Client,
 	cfg *config.Config,
+	flags *featureflags.Client,
 	userSettingsService *usersettings.Service,
 ) *chathandler.MemoryHandler {
-	return chathandler.NewMemoryHandler(memoryClient, cfg.MemoryEnabled, userSettingsService)
+	return chathandler.NewMemoryHandler(memoryClient, cfg.MemoryEnabled, flags, cfg.MemoryLoadBudget, userSettingsService)
 }
 
 var HandlerProvider = wire.NewSet(
@@ -40,4 +42,7 @@ var HandlerProvider = wire.NewSet(
 	adminhandler.NewAdminUserHandler,
 	adminhandler.NewAdminGroupHandler,
 	adminhandler.NewFeatureFlagHandler,
+	adminhandler.NewAdminInspectionHandler,
+	adminhandler.NewAdminReconcileHandler,
+	adminhandler.NewAdminMaintenanceHandler,
 )
@@ -0,0 +1,224 @@
+package mcpcredentialhandler
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
+	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	mcpcredentialrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/mcpcredential"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// MCPCredentialHandler serves the authenticated user's MCP credential store
+type MCPCredentialHandler struct {
+	service *mcpcredential.Service
+}
+
+// NewMCPCredentialHandler creates a new MCP credential handler
+func NewMCPCredentialHandler(service *mcpcredential.Service) *MCPCredentialHandler {
+	return &MCPCredentialHandler{service: service}
+}
+
+// CredentialResponse describes a stored credential without its token
+type CredentialResponse struct {
+	ID         string   `json:"id"`
+	Object     string   `json:"object"`
+	Provider   string   `json:"provider"`
+	Account    *string  `json:"account,omitempty"`
+	Scopes     []string `json:"scopes"`
+	Endpoint   *string  `json:"endpoint,omitempty"`
+	ExpiresAt  *int64   `json:"expires_at,omitempty"`
+	LastUsedAt *int64   `json:"last_used_at,omitempty"`
+	CreatedAt  int64    `json:"created_at"`
+	UpdatedAt  int64    `json:"updated_at"`
+}
+
+// CredentialListResponse lists the user's credentials
+type CredentialListResponse struct {
+	Object string               `json:"object"`
+	Data   []CredentialResponse `json:"data"`
+}
+
+// CredentialTokenResponse carries a decrypted token for MCP tools
+type CredentialTokenResponse struct {
+	Provider  string   `json:"provider"`
+	Token     string   `json:"token"`
+	Account   *string  `json:"account,omitempty"`
+	Scopes    []string `json:"scopes"`
+	Endpoint  *string  `json:"endpoint,omitempty"`
+	ExpiresAt *int64   `json:"expires_at,omitempty"`
+}
+
+// List godoc
+// @Summary List MCP credentials
+// @Description Lists the third-party accounts the authenticated user has connected for MCP tools. Tokens are never returned.
+// @Tags MCP Credentials API
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} CredentialListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/mcp-credentials [get]
+func (h *MCPCredentialHandler) List(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	credentials, err := h.service.List(c.Request.Context(), user.ID)
+	if err != nil {
+		responses.HandleError(c, err, "failed to list MCP credentials")
+		return
+	}
+	data := make([]CredentialResponse, 0, len(credentials))
+	for _, credential := range credentials {
+		data = append(data, toCredentialResponse(credential))
+	}
+	c.JSON(http.StatusOK, CredentialListResponse{Object: "list", Data: data})
+}
+
+// Save godoc
+// @Summary Connect an MCP credential
+// @Description Stores the user's OAuth access token for a provider, replacing any existing one. First-party MCP tools (for example the GitHub tools) use it to act on the user's behalf. Supported providers: `github`, `google`, `microsoft` and `caldav`. The `google`, `microsoft` and `caldav` connectors are read-only and require explicit consent scopes (`calendar:read`, `mail:read`); `caldav` also takes the calendar collection URL as `endpoint` and an app password as `token`.
+// @Tags MCP Credentials API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param provider path string true "Provider" Enums(github, google, microsoft, caldav)
+// @Param request body mcpcredentialrequests.SaveCredentialRequest true "Token to store"
+// @Success 200 {object} CredentialResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/mcp-credentials/{provider} [put]
+func (h *MCPCredentialHandler) Save(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+	provider, err := mcpcredential.ParseProvider(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		responses.HandleError(c, err, "invalid provider")
+		return
+	}
+
+	var request mcpcredentialrequests.SaveCredentialRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	credential, err := h.service.Save(c.Request.Context(), user.ID, provider, request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to save MCP credential")
+		return
+	}
+	c.JSON(http.StatusOK, toCredentialResponse(credential))
+}
+
+// Delete godoc
+// @Summary Disconnect an MCP credential
+// @Description Deletes the user's stored token for a provider. MCP tools that need it fail until it is connected again.
+// @Tags MCP Credentials API
+// @Security BearerAuth
+// @Param provider path string true "Provider" Enums(github, google, microsoft, caldav)
+// @Success 204 "No Content"
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/mcp-credentials/{provider} [delete]
+func (h *MCPCredentialHandler) Delete(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+	provider, err := mcpcredential.ParseProvider(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		responses.HandleError(c, err, "invalid provider")
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), user.ID, provider); err != nil {
+		responses.HandleError(c, err, "failed to delete MCP credential")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// GetToken godoc
+// @Summary Get an MCP credential token
+// @Description Returns the user's decrypted token for a provider. Called by mcp-tools with the user's forwarded bearer token when a tool needs to act on the user's behalf.
+// @Tags MCP Credentials API
+// @Security BearerAuth
+// @Produce json
+// @Param provider path string true "Provider" Enums(github, google, microsoft, caldav)
+// @Success 200 {object} CredentialTokenResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse "Provider not connected or token expired"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/mcp-credentials/{provider}/token [get]
+func (h *MCPCredentialHandler) GetToken(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+	provider, err := mcpcredential.ParseProvider(c.Request.Context(), c.Param("provider"))
+	if err != nil {
+		responses.HandleError(c, err, "invalid provider")
+		return
+	}
+
+	token, credential, err := h.service.Token(c.Request.Context(), user.ID, provider)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get MCP credential")
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, CredentialTokenResponse{
+		Provider:  string(credential.Provider),
+		Token:     token,
+		Account:   credential.Account,
+		Scopes:    nonNilScopes(credential.Scopes),
+		Endpoint:  credential.Endpoint,
+		ExpiresAt: unixOrNil(credential.ExpiresAt),
+	})
+}
+
+func toCredentialResponse(credential *mcpcredential.Credential) CredentialResponse {
+	return CredentialResponse{
+		ID:         credential.PublicID,
+		Object:     "mcp_credential",
+		Provider:   string(credential.Provider),
+		Account:    credential.Account,
+		Scopes:     nonNilScopes(credential.Scopes),
+		Endpoint:   credential.Endpoint,
+		ExpiresAt:  unixOrNil(credential.ExpiresAt),
+		LastUsedAt: unixOrNil(credential.LastUsedAt),
+		CreatedAt:  credential.CreatedAt.Unix(),
+		UpdatedAt:  credential.UpdatedAt.Unix(),
+	}
+}
+
+func nonNilScopes(scopes []string) []string {
+	if scopes == nil {
+		return []string{}
+	}
+	return scopes
+}
+
+func unixOrNil(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	unix := t.Unix()
+	return &unix
+}
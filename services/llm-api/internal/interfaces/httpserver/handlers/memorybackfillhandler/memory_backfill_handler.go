@@ -0,0 +1,265 @@
+package memorybackfillhandler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/application/audit"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
+	"jan-server/services/llm-api/internal/domain/query"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	memorybackfillrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/memorybackfill"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// MemoryBackfillHandler manages conversation-to-memory backfills for admins
+type MemoryBackfillHandler struct {
+	backfillService *memorybackfill.Service
+	audit           *audit.AdminAuditLogger
+}
+
+// NewMemoryBackfillHandler creates a new memory backfill handler
+func NewMemoryBackfillHandler(backfillService *memorybackfill.Service, auditLogger *audit.AdminAuditLogger) *MemoryBackfillHandler {
+	return &MemoryBackfillHandler{
+		backfillService: backfillService,
+		audit:           auditLogger,
+	}
+}
+
+// BackfillResponse is a memory backfill
+type BackfillResponse struct {
+	ID                   string `json:"id"`
+	Object               string `json:"object"`
+	Status               string `json:"status"`
+	UserID               *uint  `json:"user_id,omitempty"`
+	CreatedBefore        int64  `json:"created_before"`
+	ConversationsDone    int    `json:"conversations_done"`
+	ConversationsSkipped int    `json:"conversations_skipped"`
+	ConversationsFailed  int    `json:"conversations_failed"`
+	Error                string `json:"error,omitempty"`
+	StartedAt            *int64 `json:"started_at,omitempty"`
+	CompletedAt          *int64 `json:"completed_at,omitempty"`
+	CreatedAt            int64  `json:"created_at"`
+}
+
+// BackfillListResponse is a page of backfills
+type BackfillListResponse struct {
+	Object string             `json:"object"`
+	Data   []BackfillResponse `json:"data"`
+	Total  int64              `json:"total"`
+}
+
+// CreateBackfill godoc
+// @Summary Start a memory backfill
+// @Description Replays conversations created before the request through memory observation, so memories are extracted from chat history that predates memory being enabled. Only the latest messages of each conversation's active branch are sent (`MEMORY_BACKFILL_MAX_MESSAGES`), one conversation per `MEMORY_BACKFILL_INTERVAL` to keep memory-tools load low.
+// @Description
+// @Description Private conversations and conversations of users with memory or memory observation turned off are skipped. Set `user_id` to backfill a single user.
+// @Description
+// @Description Backfills run in the background; poll `GET /v1/admin/memory/backfills/{backfill_id}` for progress. A backfill interrupted by a restart resumes where it stopped.
+// @Tags Admin - Memory
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body memorybackfillrequests.CreateBackfillRequest false "Backfill scope"
+// @Success 202 {object} BackfillResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/memory/backfills [post]
+func (h *MemoryBackfillHandler) CreateBackfill(c *gin.Context) {
+	var request memorybackfillrequests.CreateBackfillRequest
+	if err := c.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	backfill, err := h.backfillService.CreateBackfill(c.Request.Context(), request.ToInput(), principalID(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to create backfill")
+		return
+	}
+
+	h.logAudit(c, "create_memory_backfill", "memory_backfill", backfill.PublicID, request, http.StatusAccepted)
+	c.JSON(http.StatusAccepted, toBackfillResponse(backfill))
+}
+
+// ListBackfills godoc
+// @Summary List memory backfills
+// @Description Lists backfills, newest first.
+// @Tags Admin - Memory
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit" default(20)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} BackfillListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/memory/backfills [get]
+func (h *MemoryBackfillHandler) ListBackfills(c *gin.Context) {
+	backfills, total, err := h.backfillService.ListBackfills(c.Request.Context(), parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list backfills")
+		return
+	}
+
+	data := make([]BackfillResponse, 0, len(backfills))
+	for _, backfill := range backfills {
+		data = append(data, toBackfillResponse(backfill))
+	}
+	c.JSON(http.StatusOK, BackfillListResponse{Object: "list", Data: data, Total: total})
+}
+
+// GetBackfill godoc
+// @Summary Get a memory backfill
+// @Description Returns a backfill with its status and progress counters.
+// @Tags Admin - Memory
+// @Security BearerAuth
+// @Produce json
+// @Param backfill_id path string true "Backfill ID"
+// @Success 200 {object} BackfillResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/memory/backfills/{backfill_id} [get]
+func (h *MemoryBackfillHandler) GetBackfill(c *gin.Context) {
+	backfill, err := h.backfillService.GetBackfill(c.Request.Context(), c.Param("backfill_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get backfill")
+		return
+	}
+	c.JSON(http.StatusOK, toBackfillResponse(backfill))
+}
+
+// CancelBackfill godoc
+// @Summary Cancel a memory backfill
+// @Description Stops a queued or running backfill after the conversation in flight. A cancelled backfill can be resumed.
+// @Tags Admin - Memory
+// @Security BearerAuth
+// @Produce json
+// @Param backfill_id path string true "Backfill ID"
+// @Success 200 {object} BackfillResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/memory/backfills/{backfill_id}/cancel [post]
+func (h *MemoryBackfillHandler) CancelBackfill(c *gin.Context) {
+	backfill, err := h.backfillService.CancelBackfill(c.Request.Context(), c.Param("backfill_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to cancel backfill")
+		return
+	}
+
+	h.logAudit(c, "cancel_memory_backfill", "memory_backfill", backfill.PublicID, nil, http.StatusOK)
+	c.JSON(http.StatusOK, toBackfillResponse(backfill))
+}
+
+// ResumeBackfill godoc
+// @Summary Resume a memory backfill
+// @Description Queues a failed or cancelled backfill again. It continues after the last conversation it replayed.
+// @Tags Admin - Memory
+// @Security BearerAuth
+// @Produce json
+// @Param backfill_id path string true "Backfill ID"
+// @Success 202 {object} BackfillResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/memory/backfills/{backfill_id}/resume [post]
+func (h *MemoryBackfillHandler) ResumeBackfill(c *gin.Context) {
+	backfill, err := h.backfillService.ResumeBackfill(c.Request.Context(), c.Param("backfill_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to resume backfill")
+		return
+	}
+
+	h.logAudit(c, "resume_memory_backfill", "memory_backfill", backfill.PublicID, nil, http.StatusAccepted)
+	c.JSON(http.StatusAccepted, toBackfillResponse(backfill))
+}
+
+func parsePagination(c *gin.Context) *query.Pagination {
+	limit := defaultListLimit
+	offset := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxListLimit)
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return &query.Pagination{Limit: &limit, Offset: &offset}
+}
+
+func toBackfillResponse(backfill *memorybackfill.Backfill) BackfillResponse {
+	return BackfillResponse{
+		ID:                   backfill.PublicID,
+		Object:               "memory.backfill",
+		Status:               string(backfill.Status),
+		UserID:               backfill.UserID,
+		CreatedBefore:        backfill.CreatedBefore.Unix(),
+		ConversationsDone:    backfill.ConversationsDone,
+		ConversationsSkipped: backfill.ConversationsSkipped,
+		ConversationsFailed:  backfill.ConversationsFailed,
+		Error:                backfill.Error,
+		StartedAt:            unixPtr(backfill.StartedAt),
+		CompletedAt:          unixPtr(backfill.CompletedAt),
+		CreatedAt:            backfill.CreatedAt.Unix(),
+	}
+}
+
+func unixPtr(t *time.Time) *int64 {
+	if t == nil {
+		return nil
+	}
+	v := t.Unix()
+	return &v
+}
+
+func (h *MemoryBackfillHandler) logAudit(c *gin.Context, action, resourceType, resourceID string, payload any, status int) {
+	if h.audit == nil {
+		return
+	}
+	principal, hasPrincipal := middleware.PrincipalFromContext(c)
+	if !hasPrincipal {
+		return
+	}
+	h.audit.Log(c.Request.Context(), audit.AdminAuditEntry{
+		AdminUserID: principal.ID,
+		AdminEmail:  principal.Email,
+		Action:      action,
+		Resource:    resourceType,
+		ResourceID:  resourceID,
+		Payload:     payload,
+		StatusCode:  status,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+}
+
+func principalID(c *gin.Context) *string {
+	principal, ok := middleware.PrincipalFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &principal.ID
+}
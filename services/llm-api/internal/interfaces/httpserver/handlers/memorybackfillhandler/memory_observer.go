@@ -0,0 +1,49 @@
+package memorybackfillhandler
+
+import (
+	"context"
+	"fmt"
+
+	"jan-server/services/llm-api/internal/domain/conversation"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
+	memclient "jan-server/services/llm-api/internal/infrastructure/memory"
+)
+
+// MemoryObserver implements memorybackfill.Observer by calling memory-tools observe
+type MemoryObserver struct {
+	memoryClient *memclient.Client
+}
+
+var _ memorybackfill.Observer = (*MemoryObserver)(nil)
+
+// NewMemoryObserver creates a new memory observer; memoryClient is nil when the
+// memory integration is disabled
+func NewMemoryObserver(memoryClient *memclient.Client) *MemoryObserver {
+	return &MemoryObserver{memoryClient: memoryClient}
+}
+
+// Available reports whether memory-tools is configured
+func (o *MemoryObserver) Available() bool {
+	return o.memoryClient != nil
+}
+
+// Observe sends the messages of a conversation to memory-tools
+func (o *MemoryObserver) Observe(ctx context.Context, conv *conversation.Conversation, messages []memorybackfill.Message) error {
+	req := memclient.ObserveRequest{
+		UserID:         fmt.Sprintf("%d", conv.UserID),
+		ConversationID: conv.PublicID,
+		Messages:       make([]memclient.ConversationItem, 0, len(messages)),
+	}
+	if conv.ProjectPublicID != nil {
+		req.ProjectID = *conv.ProjectPublicID
+	}
+	for _, msg := range messages {
+		req.Messages = append(req.Messages, memclient.ConversationItem{
+			ItemID:    msg.ItemID,
+			Role:      msg.Role,
+			Content:   msg.Content,
+			CreatedAt: msg.CreatedAt,
+		})
+	}
+	return o.memoryClient.Observe(ctx, req)
+}
@@ -0,0 +1,246 @@
+package modelaliashandler
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/application/audit"
+	"jan-server/services/llm-api/internal/domain/modelalias"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	modelaliasrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/modelalias"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// ModelAliasHandler serves the admin API of model aliases
+type ModelAliasHandler struct {
+	service *modelalias.Service
+	audit   *audit.AdminAuditLogger
+}
+
+// NewModelAliasHandler creates a new model alias handler
+func NewModelAliasHandler(service *modelalias.Service, auditLogger *audit.AdminAuditLogger) *ModelAliasHandler {
+	return &ModelAliasHandler{
+		service: service,
+		audit:   auditLogger,
+	}
+}
+
+// ModelAliasResponse is a model alias
+type ModelAliasResponse struct {
+	Name           string  `json:"name"`
+	Object         string  `json:"object"`
+	TargetModel    string  `json:"target_model"`
+	RolloutModel   *string `json:"rollout_model,omitempty"`
+	RolloutPercent int     `json:"rollout_percent"`
+	Description    *string `json:"description,omitempty"`
+	CreatedAt      int64   `json:"created_at"`
+	UpdatedAt      int64   `json:"updated_at"`
+}
+
+// ModelAliasListResponse lists model aliases
+type ModelAliasListResponse struct {
+	Object string               `json:"object"`
+	Data   []ModelAliasResponse `json:"data"`
+}
+
+// List godoc
+// @Summary List model aliases
+// @Description Lists the model aliases clients can request in place of a model ID, ordered by name.
+// @Tags Admin - Model Aliases
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} ModelAliasListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/model-aliases [get]
+func (h *ModelAliasHandler) List(c *gin.Context) {
+	aliases, err := h.service.List(c.Request.Context())
+	if err != nil {
+		responses.HandleError(c, err, "failed to list model aliases")
+		return
+	}
+	data := make([]ModelAliasResponse, 0, len(aliases))
+	for _, alias := range aliases {
+		data = append(data, toResponse(alias))
+	}
+	c.JSON(http.StatusOK, ModelAliasListResponse{Object: "list", Data: data})
+}
+
+// Get godoc
+// @Summary Get a model alias
+// @Description Returns a model alias with its target and rollout.
+// @Tags Admin - Model Aliases
+// @Security BearerAuth
+// @Produce json
+// @Param name path string true "Alias name"
+// @Success 200 {object} ModelAliasResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/model-aliases/{name} [get]
+func (h *ModelAliasHandler) Get(c *gin.Context) {
+	alias, err := h.service.Get(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get model alias")
+		return
+	}
+	c.JSON(http.StatusOK, toResponse(alias))
+}
+
+// Create godoc
+// @Summary Create a model alias
+// @Description Creates an alias, e.g. `jan-large`, that chat completions resolve to `target_model`. With `rollout_model` set, `rollout_percent` of users get that model instead; each user stays on the same model while the rollout lasts. Both models must be served by an active provider.
+// @Tags Admin - Model Aliases
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body modelaliasrequests.CreateModelAliasRequest true "Alias definition"
+// @Success 201 {object} ModelAliasResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid alias or unknown model"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 409 {object} responses.ErrorResponse "Name already used by an alias or model"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/model-aliases [post]
+func (h *ModelAliasHandler) Create(c *gin.Context) {
+	var request modelaliasrequests.CreateModelAliasRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	alias, err := h.service.Create(c.Request.Context(), request.ToInput(), principalID(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to create model alias")
+		return
+	}
+
+	h.logAudit(c, "create_model_alias", alias.Name, request, http.StatusCreated)
+	c.JSON(http.StatusCreated, toResponse(alias))
+}
+
+// Update godoc
+// @Summary Remap a model alias
+// @Description Changes the target, rollout model or rollout percentage of an alias. Requests resolve the alias on every completion, so the change applies immediately. Set `rollout_model` to an empty string to end a rollout.
+// @Tags Admin - Model Aliases
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param name path string true "Alias name"
+// @Param request body modelaliasrequests.UpdateModelAliasRequest true "Fields to update"
+// @Success 200 {object} ModelAliasResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/model-aliases/{name} [patch]
+func (h *ModelAliasHandler) Update(c *gin.Context) {
+	var request modelaliasrequests.UpdateModelAliasRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	alias, err := h.service.Update(c.Request.Context(), c.Param("name"), request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to update model alias")
+		return
+	}
+
+	h.logAudit(c, "update_model_alias", alias.Name, request, http.StatusOK)
+	c.JSON(http.StatusOK, toResponse(alias))
+}
+
+// Promote godoc
+// @Summary Promote a model alias rollout
+// @Description Makes the rollout model the alias' target for every user and ends the rollout.
+// @Tags Admin - Model Aliases
+// @Security BearerAuth
+// @Produce json
+// @Param name path string true "Alias name"
+// @Success 200 {object} ModelAliasResponse
+// @Failure 400 {object} responses.ErrorResponse "No rollout in progress"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/model-aliases/{name}/promote [post]
+func (h *ModelAliasHandler) Promote(c *gin.Context) {
+	alias, err := h.service.Promote(c.Request.Context(), c.Param("name"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to promote model alias")
+		return
+	}
+
+	h.logAudit(c, "promote_model_alias", alias.Name, nil, http.StatusOK)
+	c.JSON(http.StatusOK, toResponse(alias))
+}
+
+// Delete godoc
+// @Summary Delete a model alias
+// @Description Deletes an alias. Completions requesting it then fail with model not found.
+// @Tags Admin - Model Aliases
+// @Security BearerAuth
+// @Param name path string true "Alias name"
+// @Success 204 "No Content"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/model-aliases/{name} [delete]
+func (h *ModelAliasHandler) Delete(c *gin.Context) {
+	name := c.Param("name")
+	if err := h.service.Delete(c.Request.Context(), name); err != nil {
+		responses.HandleError(c, err, "failed to delete model alias")
+		return
+	}
+
+	h.logAudit(c, "delete_model_alias", name, nil, http.StatusNoContent)
+	c.Status(http.StatusNoContent)
+}
+
+func toResponse(alias *modelalias.Alias) ModelAliasResponse {
+	return ModelAliasResponse{
+		Name:           alias.Name,
+		Object:         "model.alias",
+		TargetModel:    alias.TargetModel,
+		RolloutModel:   alias.RolloutModel,
+		RolloutPercent: alias.RolloutPercent,
+		Description:    alias.Description,
+		CreatedAt:      alias.CreatedAt.Unix(),
+		UpdatedAt:      alias.UpdatedAt.Unix(),
+	}
+}
+
+func (h *ModelAliasHandler) logAudit(c *gin.Context, action, resourceID string, payload any, status int) {
+	if h.audit == nil {
+		return
+	}
+	principal, hasPrincipal := middleware.PrincipalFromContext(c)
+	if !hasPrincipal {
+		return
+	}
+	h.audit.Log(c.Request.Context(), audit.AdminAuditEntry{
+		AdminUserID: principal.ID,
+		AdminEmail:  principal.Email,
+		Action:      action,
+		Resource:    "model_alias",
+		ResourceID:  resourceID,
+		Payload:     payload,
+		StatusCode:  status,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+}
+
+func principalID(c *gin.Context) *string {
+	principal, ok := middleware.PrincipalFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &principal.ID
+}
@@ -0,0 +1,269 @@
+package modelhandler
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+
+	domainmodel "jan-server/services/llm-api/internal/domain/model"
+	modelresponses "jan-server/services/llm-api/internal/interfaces/httpserver/responses/model"
+	"jan-server/services/llm-api/internal/utils/httpclients/chat"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+	"jan-server/services/llm-api/internal/utils/ptr"
+)
+
+const (
+	providerCheckStepTimeout = 60 * time.Second
+	providerCheckMaxTokens   = 16
+	providerCheckPrompt      = "Reply with the single word OK."
+	providerCheckScanBuffer  = 1024 * 1024
+)
+
+// TestProvider checks a provider's credentials end to end: listing models
+// validates the API key, then a minimal completion and a streaming completion
+// are sent to one of its models. Failures are reported per step rather than as
+// an error so operators see exactly which stage broke.
+func (providerHandler *ProviderHandler) TestProvider(ctx context.Context, publicID string, modelID string) (*modelresponses.ProviderTestResponse, error) {
+	if strings.TrimSpace(publicID) == "" {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "provider public ID is required", nil, "6f0e5f5c-2b8e-4a57-93c4-0a3f4d7e91b2")
+	}
+
+	provider, err := providerHandler.providerService.FindByPublicID(ctx, publicID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to find provider")
+	}
+	if provider == nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound, "provider not found", nil, "c51d8e0a-7a43-4c4b-8f6e-2d9b0e3a6c17")
+	}
+
+	result := &modelresponses.ProviderTestResponse{
+		ProviderID: provider.PublicID,
+		Name:       provider.DisplayName,
+		Vendor:     strings.ToLower(string(provider.Kind)),
+		BaseURL:    provider.BaseURL,
+	}
+
+	listed, auth := providerHandler.checkProviderAuth(ctx, provider)
+	result.Steps = append(result.Steps, auth)
+	result.Capabilities.ModelsListed = len(listed)
+	if !auth.OK {
+		result.Steps = append(result.Steps, skippedStep("completion", "authentication failed"), skippedStep("stream", "authentication failed"))
+		return result, nil
+	}
+
+	model, providerModel, err := providerHandler.pickTestModel(ctx, provider, strings.TrimSpace(modelID), listed)
+	if err != nil {
+		return nil, err
+	}
+	if model == "" {
+		result.Steps = append(result.Steps, skippedStep("completion", "no model to test"), skippedStep("stream", "no model to test"))
+		return result, nil
+	}
+	result.Model = model
+	if providerModel != nil && providerModel.ModelCatalogID != nil {
+		catalog, err := providerHandler.providerModelService.FindCatalogByID(ctx, *providerModel.ModelCatalogID)
+		if err != nil {
+			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to find model catalog")
+		}
+		if catalog != nil {
+			result.Capabilities.Cataloged = true
+			result.Capabilities.SupportsTools = catalog.SupportsTools
+			result.Capabilities.SupportsImages = catalog.SupportsImages
+			result.Capabilities.SupportsReasoning = catalog.SupportsReasoning
+			result.Capabilities.SupportsAudio = catalog.SupportsAudio
+			result.Capabilities.ContextLength = catalog.ContextLength
+		}
+	}
+
+	client, err := providerHandler.inferenceProvider.GetChatCompletionClient(ctx, provider)
+	if err != nil {
+		result.Steps = append(result.Steps,
+			modelresponses.ProviderTestStep{Name: "completion", Error: providerCheckError(err)},
+			skippedStep("stream", "no completion client"))
+		return result, nil
+	}
+
+	completion, usageReported := checkProviderCompletion(ctx, client, model)
+	result.Steps = append(result.Steps, completion)
+	result.Capabilities.UsageReported = usageReported
+
+	stream, streamUsage := checkProviderStream(ctx, client, model)
+	result.Steps = append(result.Steps, stream)
+	result.Capabilities.Streaming = stream.OK
+	result.Capabilities.StreamUsageReported = streamUsage
+
+	result.OK = auth.OK && completion.OK && stream.OK
+	return result, nil
+}
+
+// checkProviderAuth lists the provider's models, which every supported vendor
+// rejects when the API key is wrong
+func (providerHandler *ProviderHandler) checkProviderAuth(ctx context.Context, provider *domainmodel.Provider) ([]chat.Model, modelresponses.ProviderTestStep) {
+	step := modelresponses.ProviderTestStep{Name: "auth"}
+	stepCtx, cancel := context.WithTimeout(ctx, providerCheckStepTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	models, err := providerHandler.inferenceProvider.ListModels(stepCtx, provider)
+	step.LatencyMs = time.Since(startedAt).Milliseconds()
+	if err != nil {
+		step.Error = providerCheckError(err)
+		return nil, step
+	}
+	step.OK = true
+	step.Detail = strconv.Itoa(len(models)) + " model(s) listed"
+	return models, step
+}
+
+// pickTestModel resolves the model to send completions to: the requested one,
+// else an active model of the provider, else the first model it listed
+func (providerHandler *ProviderHandler) pickTestModel(ctx context.Context, provider *domainmodel.Provider, requested string, listed []chat.Model) (string, *domainmodel.ProviderModel, error) {
+	filter := domainmodel.ProviderModelFilter{ProviderID: &provider.ID}
+	if requested != "" {
+		filter.ProviderOriginalModelID = &requested
+	} else {
+		filter.Active = ptr.ToBool(true)
+	}
+	providerModels, err := providerHandler.providerModelService.FindByFilter(ctx, filter)
+	if err != nil {
+		return "", nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to find provider models")
+	}
+	if len(providerModels) > 0 {
+		return providerModels[0].ProviderOriginalModelID, providerModels[0], nil
+	}
+	if requested != "" {
+		return requested, nil, nil
+	}
+	if len(listed) > 0 {
+		return listed[0].ID, nil, nil
+	}
+	return "", nil, nil
+}
+
+func checkProviderCompletion(ctx context.Context, client *chat.ChatCompletionClient, model string) (modelresponses.ProviderTestStep, bool) {
+	step := modelresponses.ProviderTestStep{Name: "completion"}
+	stepCtx, cancel := context.WithTimeout(ctx, providerCheckStepTimeout)
+	defer cancel()
+
+	startedAt := time.Now()
+	resp, err := client.CreateChatCompletion(stepCtx, "", providerCheckRequest(model))
+	step.LatencyMs = time.Since(startedAt).Milliseconds()
+	if err != nil {
+		step.Error = providerCheckError(err)
+		return step, false
+	}
+	if len(resp.Choices) == 0 {
+		step.Error = "response contained no choices"
+		return step, false
+	}
+	step.OK = true
+	step.Detail = "finish_reason=" + string(resp.Choices[0].FinishReason)
+	usageReported := resp.Usage.TotalTokens > 0
+	if usageReported {
+		step.Detail += ", " + strconv.Itoa(resp.Usage.TotalTokens) + " tokens"
+	}
+	return step, usageReported
+}
+
+func checkProviderStream(ctx context.Context, client *chat.ChatCompletionClient, model string) (modelresponses.ProviderTestStep, bool) {
+	step := modelresponses.ProviderTestStep{Name: "stream"}
+	stepCtx, cancel := context.WithTimeout(ctx, providerCheckStepTimeout)
+	defer cancel()
+
+	request := providerCheckRequest(model)
+	request.ChatCompletionRequest.Stream = true
+	request.ChatCompletionRequest.StreamOptions = &openai.StreamOptions{IncludeUsage: true}
+
+	startedAt := time.Now()
+	body, err := client.CreateChatCompletionStream(stepCtx, "", request)
+	if err != nil {
+		step.LatencyMs = time.Since(startedAt).Milliseconds()
+		step.Error = providerCheckError(err)
+		return step, false
+	}
+	defer body.Close()
+
+	var chunks int
+	var usageReported, done bool
+	scanner := bufio.NewScanner(body)
+	scanner.Buffer(make([]byte, 0, 64*1024), providerCheckScanBuffer)
+	for scanner.Scan() {
+		data, found := strings.CutPrefix(scanner.Text(), "data:")
+		if !found {
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			done = true
+			continue
+		}
+		if data == "" {
+			continue
+		}
+
+		var chunk openai.ChatCompletionStreamResponse
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if chunk.Usage != nil {
+			usageReported = true
+		}
+		if len(chunk.Choices) == 0 {
+			continue
+		}
+		if chunks == 0 {
+			ttft := time.Since(startedAt).Milliseconds()
+			step.TimeToFirstToken = &ttft
+		}
+		chunks++
+	}
+	step.LatencyMs = time.Since(startedAt).Milliseconds()
+	if err := scanner.Err(); err != nil {
+		step.Error = providerCheckError(err)
+		return step, usageReported
+	}
+	if chunks == 0 {
+		step.Error = "stream ended without any completion chunks"
+		return step, usageReported
+	}
+
+	step.OK = true
+	step.Detail = strconv.Itoa(chunks) + " chunk(s)"
+	if !done {
+		step.Detail += ", no [DONE] marker"
+	}
+	return step, usageReported
+}
+
+func providerCheckRequest(model string) chat.CompletionRequest {
+	return chat.CompletionRequest{ChatCompletionRequest: openai.ChatCompletionRequest{
+		Model:     model,
+		MaxTokens: providerCheckMaxTokens,
+		Messages: []openai.ChatCompletionMessage{
+			{Role: openai.ChatMessageRoleUser, Content: providerCheckPrompt},
+		},
+	}}
+}
+
+func skippedStep(name string, reason string) modelresponses.ProviderTestStep {
+	return modelresponses.ProviderTestStep{Name: name, Skipped: true, Detail: reason}
+}
+
+// providerCheckError keeps the upstream message (e.g. "invalid API key") and
+// drops the platform error's layer and UUID prefix
+func providerCheckError(err error) string {
+	var platformErr *platformerrors.PlatformError
+	if errors.As(err, &platformErr) {
+		if platformErr.Err != nil {
+			return platformErr.Message + ": " + platformErr.Err.Error()
+		}
+		return platformErr.Message
+	}
+	return err.Error()
+}
@@ -2,9 +2,12 @@ package modelhandler
 
 import (
 	"context"
+	"slices"
 	"strings"
 
 	domainmodel "jan-server/services/llm-api/internal/domain/model"
+	"jan-server/services/llm-api/internal/domain/modelalias"
+	"jan-server/services/llm-api/internal/domain/region"
 	"jan-server/services/llm-api/internal/infrastructure/inference"
 	requestmodels "jan-server/services/llm-api/internal/interfaces/httpserver/requests/models"
 	modelresponses "jan-server/services/llm-api/internal/interfaces/httpserver/responses/model"
@@ -15,17 +18,23 @@ type ProviderHandler struct {
 	providerService      *domainmodel.ProviderService
 	providerModelService *domainmodel.ProviderModelService
 	inferenceProvider    *inference.InferenceProvider
+	regions              *region.Selector
+	aliases              *modelalias.Service
 }
 
 func NewProviderHandler(
 	providerService *domainmodel.ProviderService,
 	providerModelService *domainmodel.ProviderModelService,
 	inferenceProvider *inference.InferenceProvider,
+	regions *region.Selector,
+	aliases *modelalias.Service,
 ) *ProviderHandler {
 	return &ProviderHandler{
 		providerService:      providerService,
 		providerModelService: providerModelService,
 		inferenceProvider:    inferenceProvider,
+		regions:              regions,
+		aliases:              aliases,
 	}
 }
 
@@ -155,7 +164,7 @@ func (providerHandler *ProviderHandler) SelectProviderModelForModelPublicID(ctx
 		return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound, "model not found in accessible providers", nil, "caa8476d-1b95-42a7-a96b-18b0c11b2f64")
 	}
 
-	selectedProviderModel := providerHandler.selectBestProvider(providerModels)
+	selectedProviderModel := providerHandler.selectBestProvider(providerHandler.preferRegion(ctx, providerModels))
 	if selectedProviderModel == nil {
 		return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound, "no valid provider found for model", nil, "265747b1-0aee-4a99-863e-99a7af8ada5e")
 	}
@@ -167,6 +176,47 @@ func (providerHandler *ProviderHandler) SelectProviderModelForModelPublicID(ctx
 	return selectedProviderModel, selectedProvider, nil
 }
 
+// ResolveModelAlias returns the model a model alias such as jan-large resolves
+// to for bucketKey, or nil when model is not an alias.
+func (providerHandler *ProviderHandler) ResolveModelAlias(ctx context.Context, model, bucketKey string) (*modelalias.Resolution, error) {
+	if providerHandler.aliases == nil {
+		return nil, nil
+	}
+	resolution, err := providerHandler.aliases.Resolve(ctx, model, bucketKey)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to resolve model alias")
+	}
+	return resolution, nil
+}
+
+// SelectAlternateProviderModel picks the best active provider model for the model
+// among providers not in excludeProviderIDs, for retrying a failed completion.
+// It returns nil when no other provider serves the model.
+func (providerHandler *ProviderHandler) SelectAlternateProviderModel(ctx context.Context, modelPublicID string, excludeProviderIDs []uint) (*domainmodel.ProviderModel, *domainmodel.Provider, error) {
+	providerModels, err := providerHandler.providerModelService.FindActiveByModelKey(ctx, modelPublicID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	candidates := make([]*domainmodel.ProviderModel, 0, len(providerModels))
+	for _, providerModel := range providerModels {
+		if providerModel != nil && !slices.Contains(excludeProviderIDs, providerModel.ProviderID) {
+			candidates = append(candidates, providerModel)
+		}
+	}
+
+	selectedProviderModel := providerHandler.selectBestProvider(providerHandler.preferRegion(ctx, candidates))
+	if selectedProviderModel == nil {
+		return nil, nil, nil
+	}
+
+	selectedProvider, err := providerHandler.providerService.GetByID(ctx, selectedProviderModel.ProviderID)
+	if err != nil {
+		return nil, nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to get provider details")
+	}
+	return selectedProviderModel, selectedProvider, nil
+}
+
 func (providerHandler *ProviderHandler) SelectProviderModelForProviderOriginalModelID(ctx context.Context, modelID string) (*domainmodel.ProviderModel, *domainmodel.Provider, error) {
 	if strings.TrimSpace(modelID) == "" {
 		return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "provider original model id is required", nil, "d4d3a5a9-9cb2-4c28-8d1c-5ce0b5b0e02c")
@@ -181,7 +231,7 @@ func (providerHandler *ProviderHandler) SelectProviderModelForProviderOriginalMo
 		return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound, "model not found in accessible providers", nil, "7ad98cc6-2d1d-4ed6-9e1b-7b74855958ee")
 	}
 
-	selectedProviderModel := providerHandler.selectBestProvider(providerModels)
+	selectedProviderModel := providerHandler.selectBestProvider(providerHandler.preferRegion(ctx, providerModels))
 	if selectedProviderModel == nil {
 		return nil, nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeNotFound, "no valid provider found for model", nil, "ca2e22b9-7c5b-42f6-a8b7-22b9c6a533a2")
 	}
@@ -249,6 +299,48 @@ func (providerHandler *ProviderHandler) SelectProviderModelForProviderOriginalMo
 // 1. LOWEST PRICING (if pricing data exists)
 // 2. MENLO PROVIDER (if prices are equal or no pricing)
 // 3. FIRST PROVIDER (if all criteria equal)
+// preferRegion narrows providerModels to those whose provider has a healthy
+// endpoint in the preferred region. Without region routing, or when no
+// provider serves the model there, every candidate is kept.
+func (providerHandler *ProviderHandler) preferRegion(ctx context.Context, providerModels []*domainmodel.ProviderModel) []*domainmodel.ProviderModel {
+	if !providerHandler.regions.Enabled() || len(providerModels) < 2 {
+		return providerModels
+	}
+
+	providerIDs := make([]uint, 0, len(providerModels))
+	for _, providerModel := range providerModels {
+		if providerModel != nil {
+			providerIDs = append(providerIDs, providerModel.ProviderID)
+		}
+	}
+	providers, err := providerHandler.providerService.GetByIDs(ctx, providerIDs)
+	if err != nil {
+		return providerModels
+	}
+
+	endpointsByProvider := make(map[uint]domainmodel.EndpointList, len(providers))
+	var all domainmodel.EndpointList
+	for id, provider := range providers {
+		endpointsByProvider[id] = provider.RegionalEndpoints()
+		all = append(all, endpointsByProvider[id]...)
+	}
+	preferred := providerHandler.regions.Preferred(ctx, all)
+	if preferred == "" {
+		return providerModels
+	}
+
+	inRegion := make([]*domainmodel.ProviderModel, 0, len(providerModels))
+	for _, providerModel := range providerModels {
+		if providerModel != nil && len(endpointsByProvider[providerModel.ProviderID].InRegion(preferred)) > 0 {
+			inRegion = append(inRegion, providerModel)
+		}
+	}
+	if len(inRegion) == 0 {
+		return providerModels
+	}
+	return inRegion
+}
+
 func (providerHandler *ProviderHandler) selectBestProvider(
 	providerModels []*domainmodel.ProviderModel,
 ) *domainmodel.ProviderModel {
@@ -224,6 +224,12 @@ func (h *ProviderModelHandler) UpdateProviderModel(
 	if req.TokenLimits != nil {
 		providerModel.TokenLimits = req.TokenLimits
 	}
+	if req.ReasoningPolicy != nil {
+		if err := req.ReasoningPolicy.Validate(); err != nil {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, err.Error(), err, "5d9c2e78-1b4a-4f36-a8e0-7c3f6b2d9a14")
+		}
+		providerModel.ReasoningPolicy = req.ReasoningPolicy
+	}
 	if req.Active != nil {
 		providerModel.Active = *req.Active
 	}
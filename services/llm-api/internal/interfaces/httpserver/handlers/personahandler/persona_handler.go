@@ -0,0 +1,244 @@
+package personahandler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/domain/persona"
+	"jan-server/services/llm-api/internal/domain/query"
+	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	personarequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/persona"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 100
+)
+
+// PersonaHandler serves the authenticated user's custom personas
+type PersonaHandler struct {
+	service *persona.Service
+}
+
+// NewPersonaHandler creates a new persona handler
+func NewPersonaHandler(service *persona.Service) *PersonaHandler {
+	return &PersonaHandler{service: service}
+}
+
+// PersonaResponse is a custom persona
+type PersonaResponse struct {
+	ID               string   `json:"id"`
+	Object           string   `json:"object"`
+	Name             string   `json:"name"`
+	Description      *string  `json:"description,omitempty"`
+	Avatar           *string  `json:"avatar,omitempty"`
+	Instructions     string   `json:"instructions"`
+	DefaultModel     *string  `json:"default_model,omitempty"`
+	EnabledTools     []string `json:"enabled_tools"`
+	StarterQuestions []string `json:"starter_questions"`
+	CreatedAt        int64    `json:"created_at"`
+	UpdatedAt        int64    `json:"updated_at"`
+}
+
+// PersonaListResponse is a page of personas
+type PersonaListResponse struct {
+	Object string            `json:"object"`
+	Data   []PersonaResponse `json:"data"`
+	Total  int64             `json:"total"`
+}
+
+// List godoc
+// @Summary List personas
+// @Description Lists the authenticated user's personas, ordered by name.
+// @Tags Personas API
+// @Security BearerAuth
+// @Produce json
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} PersonaListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/personas [get]
+func (h *PersonaHandler) List(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	personas, total, err := h.service.List(c.Request.Context(), user.ID, parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list personas")
+		return
+	}
+	data := make([]PersonaResponse, 0, len(personas))
+	for _, p := range personas {
+		data = append(data, toPersonaResponse(p))
+	}
+	c.JSON(http.StatusOK, PersonaListResponse{Object: "list", Data: data, Total: total})
+}
+
+// Get godoc
+// @Summary Get a persona
+// @Description Returns one of the authenticated user's personas.
+// @Tags Personas API
+// @Security BearerAuth
+// @Produce json
+// @Param persona_id path string true "Persona ID"
+// @Success 200 {object} PersonaResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/personas/{persona_id} [get]
+func (h *PersonaHandler) Get(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	p, err := h.service.Get(c.Request.Context(), c.Param("persona_id"), user.ID)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get persona")
+		return
+	}
+	c.JSON(http.StatusOK, toPersonaResponse(p))
+}
+
+// Create godoc
+// @Summary Create a persona
+// @Description Creates a custom assistant with its own system instructions. Reference it from `POST /v1/chat/completions` with `persona_id` (or the `X-Prompt-Persona` header) to apply its instructions above the user's profile settings, use `default_model` when the request leaves `model` empty and keep only its `enabled_tools`.
+// @Tags Personas API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body personarequests.CreatePersonaRequest true "Persona definition"
+// @Success 201 {object} PersonaResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid persona or per-user limit reached"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/personas [post]
+func (h *PersonaHandler) Create(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	var request personarequests.CreatePersonaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	p, err := h.service.Create(c.Request.Context(), user.ID, request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to create persona")
+		return
+	}
+	c.JSON(http.StatusCreated, toPersonaResponse(p))
+}
+
+// Update godoc
+// @Summary Update a persona
+// @Description Updates one of the authenticated user's personas. Omitted fields are left unchanged.
+// @Tags Personas API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param persona_id path string true "Persona ID"
+// @Param request body personarequests.UpdatePersonaRequest true "Fields to update"
+// @Success 200 {object} PersonaResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/personas/{persona_id} [patch]
+func (h *PersonaHandler) Update(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	var request personarequests.UpdatePersonaRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	p, err := h.service.Update(c.Request.Context(), c.Param("persona_id"), user.ID, request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to update persona")
+		return
+	}
+	c.JSON(http.StatusOK, toPersonaResponse(p))
+}
+
+// Delete godoc
+// @Summary Delete a persona
+// @Description Deletes one of the authenticated user's personas. Chat requests that still reference it fail with 404.
+// @Tags Personas API
+// @Security BearerAuth
+// @Param persona_id path string true "Persona ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/personas/{persona_id} [delete]
+func (h *PersonaHandler) Delete(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	if err := h.service.Delete(c.Request.Context(), c.Param("persona_id"), user.ID); err != nil {
+		responses.HandleError(c, err, "failed to delete persona")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+func parsePagination(c *gin.Context) *query.Pagination {
+	limit := defaultListLimit
+	offset := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxListLimit)
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return &query.Pagination{Limit: &limit, Offset: &offset}
+}
+
+func toPersonaResponse(p *persona.Persona) PersonaResponse {
+	tools := p.EnabledTools
+	if tools == nil {
+		tools = []string{}
+	}
+	questions := p.StarterQuestions
+	if questions == nil {
+		questions = []string{}
+	}
+	return PersonaResponse{
+		ID:               p.PublicID,
+		Object:           "persona",
+		Name:             p.Name,
+		Description:      p.Description,
+		Avatar:           p.Avatar,
+		Instructions:     p.Instructions,
+		DefaultModel:     p.DefaultModel,
+		EnabledTools:     tools,
+		StarterQuestions: questions,
+		CreatedAt:        p.CreatedAt.Unix(),
+		UpdatedAt:        p.UpdatedAt.Unix(),
+	}
+}
@@ -45,6 +45,8 @@ func (h *ProjectHandler) CreateProject(
 
 	// Create project entity
 	proj := project.NewProject(publicID, userID, req.Name, req.Instruction)
+	proj.DefaultTools = req.DefaultTools
+	proj.KnowledgeCollections = req.KnowledgeCollections
 
 	// Persist project
 	proj, err = h.projectService.CreateProject(ctx, proj)
@@ -126,6 +128,10 @@ func (h *ProjectHandler) UpdateProject(
 		trimmed := strings.TrimSpace(*req.Instruction)
 		proj.Instruction = &trimmed
 	}
+	if req.DefaultTools != nil {
+		proj.DefaultTools = req.DefaultTools
+	proj.KnowledgeCollections = req.KnowledgeCollections
+	}
 	if req.Favorite != nil {
 		proj.Favorite = *req.Favorite
 	}
@@ -165,3 +171,17 @@ func (h *ProjectHandler) DeleteProject(
 
 	return projectres.NewProjectDeletedResponse(projectID), nil
 }
+
+// ListInstructionVersions lists the instruction history of a project
+func (h *ProjectHandler) ListInstructionVersions(
+	ctx context.Context,
+	userID uint,
+	projectID string,
+) (*projectres.InstructionVersionListResponse, error) {
+	versions, err := h.projectService.ListInstructionVersions(ctx, projectID, userID)
+	if err != nil {
+		return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "failed to list project instruction versions")
+	}
+
+	return projectres.NewInstructionVersionListResponse(projectID, versions), nil
+}
@@ -0,0 +1,479 @@
+package promptlibraryhandler
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/application/audit"
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
+	"jan-server/services/llm-api/internal/domain/query"
+	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	promptlibraryrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/promptlibrary"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+const (
+	defaultListLimit = 50
+	maxListLimit     = 200
+)
+
+// Starter scopes
+const (
+	scopeGlobal = "global"
+	scopeUser   = "user"
+)
+
+// PromptLibraryHandler serves the prompt library of conversation starters
+type PromptLibraryHandler struct {
+	service *promptlibrary.Service
+	audit   *audit.AdminAuditLogger
+}
+
+// NewPromptLibraryHandler creates a new prompt library handler
+func NewPromptLibraryHandler(service *promptlibrary.Service, auditLogger *audit.AdminAuditLogger) *PromptLibraryHandler {
+	return &PromptLibraryHandler{
+		service: service,
+		audit:   auditLogger,
+	}
+}
+
+// StarterResponse is a prompt library starter
+type StarterResponse struct {
+	ID          string                   `json:"id"`
+	Object      string                   `json:"object"`
+	Scope       string                   `json:"scope"` // global (admin-managed) or user (private)
+	Title       string                   `json:"title"`
+	Description *string                  `json:"description,omitempty"`
+	Category    string                   `json:"category"`
+	Messages    []promptlibrary.Message  `json:"messages"`
+	Variables   []promptlibrary.Variable `json:"variables"`
+	IsActive    bool                     `json:"is_active"`
+	CreatedAt   int64                    `json:"created_at"`
+	UpdatedAt   int64                    `json:"updated_at"`
+}
+
+// StarterListResponse is a page of starters
+type StarterListResponse struct {
+	Object string            `json:"object"`
+	Data   []StarterResponse `json:"data"`
+	Total  int64             `json:"total"`
+}
+
+// CategoryListResponse lists starter categories
+type CategoryListResponse struct {
+	Object string                   `json:"object"`
+	Data   []promptlibrary.Category `json:"data"`
+}
+
+// List godoc
+// @Summary List conversation starters
+// @Description Lists the active global starters together with the authenticated user's own starters. Global starters come first, then by category and title.
+// @Tags Prompt Library API
+// @Security BearerAuth
+// @Produce json
+// @Param category query string false "Only starters in this category"
+// @Param search query string false "Search in title and description"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} StarterListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/prompt-library [get]
+func (h *PromptLibraryHandler) List(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	starters, total, err := h.service.ListForUser(c.Request.Context(), user.ID, parseFilter(c), parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list prompt library")
+		return
+	}
+	c.JSON(http.StatusOK, toListResponse(starters, total))
+}
+
+// ListCategories godoc
+// @Summary List starter categories
+// @Description Lists the categories of the starters visible to the authenticated user with the number of starters in each.
+// @Tags Prompt Library API
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} CategoryListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/prompt-library/categories [get]
+func (h *PromptLibraryHandler) ListCategories(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	categories, err := h.service.ListCategories(c.Request.Context(), user.ID)
+	if err != nil {
+		responses.HandleError(c, err, "failed to list prompt library categories")
+		return
+	}
+	if categories == nil {
+		categories = []promptlibrary.Category{}
+	}
+	c.JSON(http.StatusOK, CategoryListResponse{Object: "list", Data: categories})
+}
+
+// Get godoc
+// @Summary Get a conversation starter
+// @Description Returns an active global starter or one of the authenticated user's own starters.
+// @Tags Prompt Library API
+// @Security BearerAuth
+// @Produce json
+// @Param starter_id path string true "Starter ID"
+// @Success 200 {object} StarterResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/prompt-library/{starter_id} [get]
+func (h *PromptLibraryHandler) Get(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	starter, err := h.service.GetForUser(c.Request.Context(), c.Param("starter_id"), user.ID)
+	if err != nil {
+		responses.HandleError(c, err, "failed to get starter")
+		return
+	}
+	c.JSON(http.StatusOK, toStarterResponse(starter))
+}
+
+// Create godoc
+// @Summary Create a personal conversation starter
+// @Description Creates a starter visible only to the authenticated user. Message content uses Go template syntax (`{{.name}}`) and may only reference declared variables.
+// @Tags Prompt Library API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body promptlibraryrequests.CreateStarterRequest true "Starter definition"
+// @Success 201 {object} StarterResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid starter or per-user limit reached"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/prompt-library [post]
+func (h *PromptLibraryHandler) Create(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	var request promptlibraryrequests.CreateStarterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	starter, err := h.service.CreateForUser(c.Request.Context(), user.ID, request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to create starter")
+		return
+	}
+	c.JSON(http.StatusCreated, toStarterResponse(starter))
+}
+
+// Update godoc
+// @Summary Update a personal conversation starter
+// @Description Updates one of the authenticated user's own starters. Global starters can only be changed by admins.
+// @Tags Prompt Library API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param starter_id path string true "Starter ID"
+// @Param request body promptlibraryrequests.UpdateStarterRequest true "Fields to update"
+// @Success 200 {object} StarterResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse "Global starter"
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/prompt-library/{starter_id} [patch]
+func (h *PromptLibraryHandler) Update(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	var request promptlibraryrequests.UpdateStarterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	starter, err := h.service.UpdateForUser(c.Request.Context(), c.Param("starter_id"), user.ID, request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to update starter")
+		return
+	}
+	c.JSON(http.StatusOK, toStarterResponse(starter))
+}
+
+// Delete godoc
+// @Summary Delete a personal conversation starter
+// @Description Deletes one of the authenticated user's own starters.
+// @Tags Prompt Library API
+// @Security BearerAuth
+// @Param starter_id path string true "Starter ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse "Global starter"
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/prompt-library/{starter_id} [delete]
+func (h *PromptLibraryHandler) Delete(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+
+	if err := h.service.DeleteForUser(c.Request.Context(), c.Param("starter_id"), user.ID); err != nil {
+		responses.HandleError(c, err, "failed to delete starter")
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// AdminList godoc
+// @Summary List global conversation starters
+// @Description Lists admin-managed starters, including inactive ones unless `is_active` is given.
+// @Tags Admin - Prompt Library
+// @Security BearerAuth
+// @Produce json
+// @Param category query string false "Only starters in this category"
+// @Param search query string false "Search in title and description"
+// @Param is_active query bool false "Filter by active state"
+// @Param limit query int false "Limit" default(50)
+// @Param offset query int false "Offset" default(0)
+// @Success 200 {object} StarterListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/prompt-library [get]
+func (h *PromptLibraryHandler) AdminList(c *gin.Context) {
+	filter := parseFilter(c)
+	if activeStr := c.Query("is_active"); activeStr != "" {
+		if active, err := strconv.ParseBool(activeStr); err == nil {
+			filter.IsActive = &active
+		}
+	}
+
+	starters, total, err := h.service.ListGlobal(c.Request.Context(), filter, parsePagination(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to list prompt library")
+		return
+	}
+	c.JSON(http.StatusOK, toListResponse(starters, total))
+}
+
+// AdminGet godoc
+// @Summary Get a global conversation starter
+// @Description Returns an admin-managed starter, active or not.
+// @Tags Admin - Prompt Library
+// @Security BearerAuth
+// @Produce json
+// @Param starter_id path string true "Starter ID"
+// @Success 200 {object} StarterResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/prompt-library/{starter_id} [get]
+func (h *PromptLibraryHandler) AdminGet(c *gin.Context) {
+	starter, err := h.service.GetGlobal(c.Request.Context(), c.Param("starter_id"))
+	if err != nil {
+		responses.HandleError(c, err, "failed to get starter")
+		return
+	}
+	c.JSON(http.StatusOK, toStarterResponse(starter))
+}
+
+// AdminCreate godoc
+// @Summary Create a global conversation starter
+// @Description Creates a starter visible to every user. Message content uses Go template syntax (`{{.name}}`) and may only reference declared variables.
+// @Tags Admin - Prompt Library
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param request body promptlibraryrequests.CreateStarterRequest true "Starter definition"
+// @Success 201 {object} StarterResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/prompt-library [post]
+func (h *PromptLibraryHandler) AdminCreate(c *gin.Context) {
+	var request promptlibraryrequests.CreateStarterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	starter, err := h.service.CreateGlobal(c.Request.Context(), request.ToInput(), principalID(c))
+	if err != nil {
+		responses.HandleError(c, err, "failed to create starter")
+		return
+	}
+
+	h.logAudit(c, "create_prompt_library_starter", starter.PublicID, request, http.StatusCreated)
+	c.JSON(http.StatusCreated, toStarterResponse(starter))
+}
+
+// AdminUpdate godoc
+// @Summary Update a global conversation starter
+// @Description Updates an admin-managed starter. Set `is_active` to false to hide it from users without deleting it.
+// @Tags Admin - Prompt Library
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param starter_id path string true "Starter ID"
+// @Param request body promptlibraryrequests.UpdateStarterRequest true "Fields to update"
+// @Success 200 {object} StarterResponse
+// @Failure 400 {object} responses.ErrorResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/prompt-library/{starter_id} [patch]
+func (h *PromptLibraryHandler) AdminUpdate(c *gin.Context) {
+	var request promptlibraryrequests.UpdateStarterRequest
+	if err := c.ShouldBindJSON(&request); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "invalid request body")
+		return
+	}
+
+	starter, err := h.service.UpdateGlobal(c.Request.Context(), c.Param("starter_id"), request.ToInput())
+	if err != nil {
+		responses.HandleError(c, err, "failed to update starter")
+		return
+	}
+
+	h.logAudit(c, "update_prompt_library_starter", starter.PublicID, request, http.StatusOK)
+	c.JSON(http.StatusOK, toStarterResponse(starter))
+}
+
+// AdminDelete godoc
+// @Summary Delete a global conversation starter
+// @Description Deletes an admin-managed starter. Conversations already started from it are not affected.
+// @Tags Admin - Prompt Library
+// @Security BearerAuth
+// @Param starter_id path string true "Starter ID"
+// @Success 204 "No Content"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 403 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/admin/prompt-library/{starter_id} [delete]
+func (h *PromptLibraryHandler) AdminDelete(c *gin.Context) {
+	publicID := c.Param("starter_id")
+	if err := h.service.DeleteGlobal(c.Request.Context(), publicID); err != nil {
+		responses.HandleError(c, err, "failed to delete starter")
+		return
+	}
+
+	h.logAudit(c, "delete_prompt_library_starter", publicID, nil, http.StatusNoContent)
+	c.Status(http.StatusNoContent)
+}
+
+func parseFilter(c *gin.Context) promptlibrary.Filter {
+	var filter promptlibrary.Filter
+	if category := c.Query("category"); category != "" {
+		filter.Category = &category
+	}
+	if search := c.Query("search"); search != "" {
+		filter.Search = &search
+	}
+	return filter
+}
+
+func parsePagination(c *gin.Context) *query.Pagination {
+	limit := defaultListLimit
+	offset := 0
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxListLimit)
+		}
+	}
+	if offsetStr := c.Query("offset"); offsetStr != "" {
+		if o, err := strconv.Atoi(offsetStr); err == nil && o >= 0 {
+			offset = o
+		}
+	}
+	return &query.Pagination{Limit: &limit, Offset: &offset}
+}
+
+func toListResponse(starters []*promptlibrary.Starter, total int64) StarterListResponse {
+	data := make([]StarterResponse, 0, len(starters))
+	for _, starter := range starters {
+		data = append(data, toStarterResponse(starter))
+	}
+	return StarterListResponse{Object: "list", Data: data, Total: total}
+}
+
+func toStarterResponse(starter *promptlibrary.Starter) StarterResponse {
+	scope := scopeUser
+	if starter.IsGlobal() {
+		scope = scopeGlobal
+	}
+	variables := starter.Variables
+	if variables == nil {
+		variables = []promptlibrary.Variable{}
+	}
+	return StarterResponse{
+		ID:          starter.PublicID,
+		Object:      "prompt_library.starter",
+		Scope:       scope,
+		Title:       starter.Title,
+		Description: starter.Description,
+		Category:    starter.Category,
+		Messages:    starter.Messages,
+		Variables:   variables,
+		IsActive:    starter.IsActive,
+		CreatedAt:   starter.CreatedAt.Unix(),
+		UpdatedAt:   starter.UpdatedAt.Unix(),
+	}
+}
+
+func (h *PromptLibraryHandler) logAudit(c *gin.Context, action, resourceID string, payload any, status int) {
+	if h.audit == nil {
+		return
+	}
+	principal, hasPrincipal := middleware.PrincipalFromContext(c)
+	if !hasPrincipal {
+		return
+	}
+	h.audit.Log(c.Request.Context(), audit.AdminAuditEntry{
+		AdminUserID: principal.ID,
+		AdminEmail:  principal.Email,
+		Action:      action,
+		Resource:    "prompt_library_starter",
+		ResourceID:  resourceID,
+		Payload:     payload,
+		StatusCode:  status,
+		IPAddress:   c.ClientIP(),
+		UserAgent:   c.Request.UserAgent(),
+	})
+}
+
+func principalID(c *gin.Context) *string {
+	principal, ok := middleware.PrincipalFromContext(c)
+	if !ok {
+		return nil
+	}
+	return &principal.ID
+}
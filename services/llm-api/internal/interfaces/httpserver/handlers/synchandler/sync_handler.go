@@ -0,0 +1,180 @@
+package synchandler
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/domain/deltasync"
+	"jan-server/services/llm-api/internal/domain/usersettings"
+	authhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/requests"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+	conversationresponses "jan-server/services/llm-api/internal/interfaces/httpserver/responses/conversation"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+const (
+	defaultPageLimit = 100
+	maxPageLimit     = 500
+)
+
+// SyncHandler serves the authenticated user's change log to offline-capable clients
+type SyncHandler struct {
+	service *deltasync.Service
+}
+
+// NewSyncHandler creates a new sync handler
+func NewSyncHandler(service *deltasync.Service) *SyncHandler {
+	return &SyncHandler{service: service}
+}
+
+// SyncResponse is a page of changes
+type SyncResponse struct {
+	Object     string               `json:"object"`
+	Data       []SyncChangeResponse `json:"data"`
+	NextCursor string               `json:"next_cursor"` // Pass as since on the next call
+	HasMore    bool                 `json:"has_more"`
+}
+
+// SyncChangeResponse is the latest change of one conversation, item or the
+// user's settings. Data holds the current state and is omitted for deletions.
+type SyncChangeResponse struct {
+	Object         string `json:"object"`
+	Type           string `json:"type" enums:"conversation,conversation.item,user_settings"`
+	ID             string `json:"id"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	Operation      string `json:"operation" enums:"created,updated,deleted"`
+	ChangedAt      int64  `json:"changed_at"`
+	Data           any    `json:"data,omitempty" swaggertype:"object"`
+}
+
+// SyncSettingsResponse is the state of the user's settings in a change
+type SyncSettingsResponse struct {
+	MemoryConfig     usersettings.MemoryConfig     `json:"memory_config"`
+	ProfileSettings  usersettings.ProfileSettings  `json:"profile_settings"`
+	AdvancedSettings usersettings.AdvancedSettings `json:"advanced_settings"`
+	EnableTrace      bool                          `json:"enable_trace"`
+	EnableTools      bool                          `json:"enable_tools"`
+	Preferences      map[string]interface{}        `json:"preferences"`
+	UpdatedAt        int64                         `json:"updated_at"`
+}
+
+// Sync godoc
+// @Summary Sync changes
+// @Description Returns the authenticated user's conversation, item and settings changes since a checkpoint, oldest first, so clients can keep a local cache without polling each conversation.
+// @Description
+// @Description Without since, no changes are returned and next_cursor marks the current end of the log: take it before downloading the initial state, then pass it as since. Keep calling while has_more is true.
+// @Description
+// @Description Each entity appears once per page with its latest operation and current state. Deletions may be delivered more than once; apply them idempotently.
+// @Description
+// @Description A checkpoint older than SYNC_RETENTION returns 410 Gone: download the full state again and restart without since.
+// @Tags Sync API
+// @Security BearerAuth
+// @Produce json
+// @Param since query string false "Checkpoint from a previous next_cursor"
+// @Param limit query int false "Maximum changes per page (1-500)" default(100)
+// @Success 200 {object} SyncResponse
+// @Failure 400 {object} responses.ErrorResponse "Invalid checkpoint"
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 410 {object} responses.ErrorResponse "Checkpoint expired"
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/sync [get]
+func (h *SyncHandler) Sync(c *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(c)
+	if !ok {
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, nil, "user not authenticated")
+		return
+	}
+	ctx := c.Request.Context()
+	scope := requests.SyncCursorScope(user.ID)
+
+	var page *deltasync.Page
+	var err error
+	if since := c.Query("since"); since == "" {
+		page, err = h.service.Head(ctx)
+	} else {
+		cursor, decodeErr := requests.DecodeCursor(scope, since)
+		var txID uint64
+		if decodeErr == nil {
+			txID, decodeErr = strconv.ParseUint(cursor.Key, 10, 64)
+		}
+		if decodeErr != nil {
+			responses.HandleNewError(c, platformerrors.ErrorTypeValidation, "invalid sync checkpoint", "6e1b9d4f-3a72-4c85-b0e6-9d2f7a1c4b38")
+			return
+		}
+		checkpoint := deltasync.Checkpoint{TxID: txID, ID: uint64(cursor.ID)}
+		page, err = h.service.ChangesSince(ctx, user.ID, checkpoint, time.Unix(0, cursor.Time), parseLimit(c))
+	}
+	if err != nil {
+		responses.HandleError(c, err, "failed to sync changes")
+		return
+	}
+
+	nextCursor, err := requests.EncodeCursor(scope, requests.Cursor{
+		ID:    uint(page.Next.ID),
+		Key:   strconv.FormatUint(page.Next.TxID, 10),
+		Time:  page.NextAt.UnixNano(),
+		Order: "asc",
+	})
+	if err != nil {
+		responses.HandleError(c, err, "failed to encode sync checkpoint")
+		return
+	}
+
+	data := make([]SyncChangeResponse, 0, len(page.Entries))
+	for _, entry := range page.Entries {
+		data = append(data, toSyncChangeResponse(entry))
+	}
+	c.JSON(http.StatusOK, SyncResponse{
+		Object:     "sync.page",
+		Data:       data,
+		NextCursor: nextCursor,
+		HasMore:    page.HasMore,
+	})
+}
+
+func parseLimit(c *gin.Context) int {
+	limit := defaultPageLimit
+	if limitStr := c.Query("limit"); limitStr != "" {
+		if l, err := strconv.Atoi(limitStr); err == nil && l > 0 {
+			limit = min(l, maxPageLimit)
+		}
+	}
+	return limit
+}
+
+func toSyncChangeResponse(entry *deltasync.Entry) SyncChangeResponse {
+	change := entry.Change
+	resp := SyncChangeResponse{
+		Object:         "sync.change",
+		Type:           string(change.EntityType),
+		ID:             change.EntityID,
+		ConversationID: change.ConversationID,
+		Operation:      string(change.Operation),
+		ChangedAt:      change.ChangedAt.Unix(),
+	}
+	if change.Operation == deltasync.OperationDeleted {
+		return resp
+	}
+
+	switch {
+	case entry.Conversation != nil:
+		resp.Data = conversationresponses.NewConversationResponse(entry.Conversation)
+	case entry.Item != nil:
+		resp.Data = entry.Item
+	case entry.Settings != nil:
+		resp.Data = SyncSettingsResponse{
+			MemoryConfig:     entry.Settings.MemoryConfig,
+			ProfileSettings:  entry.Settings.ProfileSettings,
+			AdvancedSettings: entry.Settings.AdvancedSettings,
+			EnableTrace:      entry.Settings.EnableTrace,
+			EnableTools:      entry.Settings.EnableTools,
+			Preferences:      entry.Settings.Preferences,
+			UpdatedAt:        entry.Settings.UpdatedAt.Unix(),
+		}
+	}
+	return resp
+}
@@ -114,19 +114,8 @@ func (h *UsageHandler) GetProjectUsage(c *gin.Context) {
 	c.JSON(http.StatusOK, usage)
 }
 
-// GetPlatformUsage godoc
-// @Summary Get platform-wide token usage (Admin only)
-// @Description Returns total platform token usage including top users and breakdown by model/provider
-// @Tags Usage
-// @Produce json
-// @Security BearerAuth
-// @Param start_date query string false "Start date (YYYY-MM-DD), defaults to 30 days ago"
-// @Param end_date query string false "End date (YYYY-MM-DD), defaults to today"
-// @Success 200 {object} tokenusage.PlatformUsageResponse
-// @Failure 401 {object} map[string]string
-// @Failure 403 {object} map[string]string
-// @Failure 500 {object} map[string]string
-// @Router /v1/admin/usage [get]
+// GetPlatformUsage returns platform-wide token usage. It is not routed: /v1/admin/usage
+// is served from completion events by the admin inspection handler.
 func (h *UsageHandler) GetPlatformUsage(c *gin.Context) {
 	startDate, endDate := parseDateRange(c)
 
@@ -115,6 +115,13 @@ func (h *UserSettingsHandler) UpdateSettings(c *gin.Context) {
 		}
 	}
 
+	if req.Preferences != nil {
+		if err := usersettings.ValidatePreferences(req.Preferences); err != nil {
+			responses.HandleErrorWithStatus(c, http.StatusBadRequest, nil, err.Error())
+			return
+		}
+	}
+
 	// Validate memory config ranges if provided
 	if req.MemoryConfig != nil {
 		if req.MemoryConfig.MaxUserItems < 0 || req.MemoryConfig.MaxUserItems > 20 {
@@ -208,6 +215,10 @@ func (h *UserSettingsHandler) UpdatePreferences(c *gin.Context) {
 		responses.HandleErrorWithStatus(c, http.StatusBadRequest, nil, "preferences field is required")
 		return
 	}
+	if err := usersettings.ValidatePreferences(req.Preferences); err != nil {
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, nil, err.Error())
+		return
+	}
 
 	updateReq := usersettings.UpdateRequest{
 		Preferences: req.Preferences,
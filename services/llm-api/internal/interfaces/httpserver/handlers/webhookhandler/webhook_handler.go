@@ -0,0 +1,69 @@
+package webhookhandler
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/llm-api/internal/domain/webhook"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// WebhookHandler receives signed webhooks from providers and LiveKit
+type WebhookHandler struct {
+	webhookService *webhook.Service
+	logger         zerolog.Logger
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *webhook.Service, logger zerolog.Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+		logger:         logger,
+	}
+}
+
+// WebhookResponse acknowledges a delivery
+type WebhookResponse struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	Duplicate bool   `json:"duplicate"` // Already received; not published again
+}
+
+// Receive handles POST /v1/webhooks/:source
+func (h *WebhookHandler) Receive(c *gin.Context) {
+	source := c.Param("source")
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, webhook.MaxBodyBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			responses.HandleErrorWithStatus(c, http.StatusRequestEntityTooLarge, err, "webhook body too large")
+			return
+		}
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, "failed to read webhook body")
+		return
+	}
+
+	e, published, err := h.webhookService.Receive(source, c.Request.Header, body)
+	switch {
+	case errors.Is(err, webhook.ErrUnknownSource):
+		responses.HandleErrorWithStatus(c, http.StatusNotFound, err, "unknown webhook source")
+		return
+	case errors.Is(err, webhook.ErrInvalidSignature):
+		h.logger.Warn().Err(err).Str("source", source).Str("client_ip", c.ClientIP()).Msg("rejected webhook with invalid signature")
+		responses.HandleErrorWithStatus(c, http.StatusUnauthorized, err, "invalid webhook signature")
+		return
+	case errors.Is(err, webhook.ErrInvalidPayload):
+		responses.HandleErrorWithStatus(c, http.StatusBadRequest, err, err.Error())
+		return
+	case err != nil:
+		responses.HandleError(c, err, "failed to receive webhook")
+		return
+	}
+
+	c.JSON(http.StatusOK, WebhookResponse{ID: e.ID, Type: e.Type, Duplicate: !published})
+}
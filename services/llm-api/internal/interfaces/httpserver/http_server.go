@@ -12,6 +12,9 @@ import (
 	v1 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -26,6 +29,7 @@ type HTTPServer struct {
 	authRoute     *auth.AuthRoute
 	config        *config.Config
 	apiKeyService *apikey.Service
+	readiness     *health.Checker
 }
 
 func (s *HTTPServer) bindSwagger() {
@@ -49,6 +53,8 @@ func NewHttpServer(
 	infra *infrastructure.Infrastructure,
 	cfg *config.Config,
 	apiKeyService *apikey.Service,
+	readiness *health.Checker,
+	maintenanceService *maintenance.Service,
 ) *HTTPServer {
 	gin.SetMode(gin.ReleaseMode)
 	server := HTTPServer{
@@ -58,23 +64,36 @@ func NewHttpServer(
 		authRoute,
 		cfg,
 		apiKeyService,
+		readiness,
+	}
+	// gin trusts every proxy unless told otherwise; without TRUSTED_PROXIES trust
+	// none, so clients cannot choose their IP through X-Forwarded-For
+	var trustedProxies []string
+	if len(cfg.TrustedProxies) > 0 {
+		trustedProxies = cfg.TrustedProxies
+	}
+	if err := server.engine.SetTrustedProxies(trustedProxies); err != nil {
+		// Load validates TRUSTED_PROXIES, so this only fails on a programming error
+		infra.Logger.Fatal().Err(err).Msg("invalid TRUSTED_PROXIES")
 	}
 	server.engine.Use(middleware.RequestID())
+	server.engine.Use(middleware.RegionPreference())
 	server.engine.Use(middleware.TracingMiddleware(cfg.ServiceName))
 	server.engine.Use(middleware.LoggingMiddleware(infra.Logger))
 	server.engine.Use(middleware.CORSMiddleware())
 	server.engine.Use(middleware.MetricsMiddleware())
+	server.engine.Use(middleware.StreamMetricsMiddleware())
+	server.engine.Use(middleware.MaintenanceMiddleware(maintenanceService))
 
 	// Root health check (for backwards compatibility)
 	server.engine.GET("/healthz", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
-	server.engine.GET("/readyz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ready"})
-	})
+	// Readiness actively probes dependencies (DB, JWKS, memory-tools, providers)
+	server.engine.GET("/readyz", gin.WrapH(readiness))
 
-	server.engine.GET("/healthcheck", func(c *gin.Context) {
+	server.engine.GET("/healthcheck", middleware.Deprecated(middleware.HealthcheckDeprecation), func(c *gin.Context) {
 		c.JSON(200, "ok")
 	})
 
@@ -93,6 +112,7 @@ func (httpServer *HTTPServer) Run() error {
 	protected := httpServer.engine.Group("/")
 	protected.Use(
 		middleware.AuthMiddleware(httpServer.infra.KeycloakValidator, httpServer.apiKeyService, httpServer.infra.Logger, httpServer.config.Issuer),
+		middleware.IntegrationScopeMiddleware(httpServer.config.IntegrationClients),
 		middleware.CORSMiddleware(),
 	)
 
@@ -101,6 +121,7 @@ func (httpServer *HTTPServer) Run() error {
 	llmProtected := llmRoot.Group("/")
 	llmProtected.Use(
 		middleware.AuthMiddleware(httpServer.infra.KeycloakValidator, httpServer.apiKeyService, httpServer.infra.Logger, httpServer.config.Issuer),
+		middleware.IntegrationScopeMiddleware(httpServer.config.IntegrationClients),
 		middleware.CORSMiddleware(),
 	)
 
@@ -113,7 +134,12 @@ func (httpServer *HTTPServer) Run() error {
 	httpServer.v1Route.RegisterRouter(llmProtected)
 	httpServer.v1Route.RegisterPublicRouter(root)
 
-	if err := httpServer.engine.Run(fmt.Sprintf(":%d", httpServer.config.HTTPPort)); err != nil {
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		return err
+	}
+	server := serverbuilder.New(fmt.Sprintf(":%d", httpServer.config.HTTPPort), httpServer.engine, serverCfg)
+	if err := server.ListenAndServe(); err != nil {
 		return err
 	}
 	return nil
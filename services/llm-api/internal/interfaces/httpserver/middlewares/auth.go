@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/featureflags"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/llm-api/internal/domain"
@@ -85,6 +86,11 @@ func setPrincipal(c *gin.Context, principal domain.Principal) {
 	if len(principal.Roles) > 0 {
 		c.Set("realm_roles", principal.Roles)
 	}
+	// Feature flag rollouts are evaluated on the principal
+	c.Request = c.Request.WithContext(featureflags.WithUser(c.Request.Context(), featureflags.User{
+		Key:   principal.ID,
+		Flags: principal.FeatureFlags,
+	}))
 	c.Request.Header.Set("X-Principal-Id", principal.ID)
 	c.Request.Header.Set("X-Auth-Method", string(principal.AuthMethod))
 	if principal.ID != "" {
@@ -277,6 +283,7 @@ func principalFromJWT(c *gin.Context, validator *authvalidator.KeycloakValidator
 		Attributes:      claims.Attributes,
 		Scopes:          claims.Scopes,
 		Credentials:     credentials,
+		Guest:           claims.Guest,
 	}, true, nil
 }
 
@@ -0,0 +1,34 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// BodyLimitMiddleware rejects request bodies larger than maxBytes with 413. A declared
+// Content-Length over the limit is refused before reading; otherwise the body is capped
+// so decoding stops with *http.MaxBytesError once the limit is crossed instead of
+// buffering the whole payload. A maxBytes of 0 disables the limit.
+func BodyLimitMiddleware(maxBytes int64) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if maxBytes <= 0 || c.Request.Body == nil {
+			c.Next()
+			return
+		}
+
+		if c.Request.ContentLength > maxBytes {
+			responses.HandleNewError(c, platformerrors.ErrorTypePayloadTooLarge,
+				fmt.Sprintf("request body of %d bytes exceeds the limit of %d bytes", c.Request.ContentLength, maxBytes),
+				"7d2f9a41-3b6e-4c58-a1d7-0e8b5c2f9a63")
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxBytes)
+		c.Next()
+	}
+}
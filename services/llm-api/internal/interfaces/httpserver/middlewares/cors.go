@@ -24,8 +24,8 @@ func CORSMiddleware() gin.HandlerFunc {
 		}
 
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-API-Key, Idempotency-Key, X-Request-Id, Mcp-Session-Id")
-		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-Id")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-API-Key, Idempotency-Key, X-Request-Id, Mcp-Session-Id, If-None-Match, X-Jan-Region")
+		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-Id, X-Stream-ID, X-Item-ID, ETag, X-Project-Instruction-Version")
 		c.Writer.Header().Set("Access-Control-Max-Age", "3600")
 
 		// Handle preflight requests
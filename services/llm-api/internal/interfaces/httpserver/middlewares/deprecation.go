@@ -0,0 +1,108 @@
+package middlewares
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/infrastructure/logger"
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+// Deprecation kinds, used as the metrics label of deprecated usage.
+const (
+	DeprecationKindRoute    = "route"
+	DeprecationKindItemType = "item_type"
+)
+
+// Deprecation describes a route or request feature scheduled for removal.
+type Deprecation struct {
+	Kind    string
+	Feature string // e.g. "GET /healthcheck" or "file_search"
+	Since   time.Time
+	Sunset  time.Time
+	// Successor replaces the feature; SuccessorLink is set when it is a URL
+	// path, and is advertised with a Link rel="successor-version" header.
+	Successor     string
+	SuccessorLink string
+}
+
+var (
+	legacyDeprecatedSince = time.Date(2026, time.October, 16, 0, 0, 0, 0, time.UTC)
+	legacySunset          = time.Date(2027, time.April, 16, 0, 0, 0, 0, time.UTC)
+)
+
+// Known deprecations
+var (
+	HealthcheckDeprecation = Deprecation{
+		Kind:          DeprecationKindRoute,
+		Feature:       "GET /healthcheck",
+		Since:         legacyDeprecatedSince,
+		Sunset:        legacySunset,
+		Successor:     "GET /healthz",
+		SuccessorLink: "/healthz",
+	}
+	LogoutGetDeprecation = Deprecation{
+		Kind:      DeprecationKindRoute,
+		Feature:   "GET /auth/logout",
+		Since:     legacyDeprecatedSince,
+		Sunset:    legacySunset,
+		Successor: "POST /auth/logout",
+	}
+)
+
+// LegacyItemTypeDeprecation describes a legacy conversation item type, such
+// as file_search, replaced by its *_call counterpart.
+func LegacyItemTypeDeprecation(itemType, successor string) Deprecation {
+	return Deprecation{
+		Kind:      DeprecationKindItemType,
+		Feature:   itemType,
+		Since:     legacyDeprecatedSince,
+		Sunset:    legacySunset,
+		Successor: successor,
+	}
+}
+
+// Deprecated marks every request of a route as deprecated.
+func Deprecated(deprecation Deprecation) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		MarkDeprecated(c, deprecation)
+		c.Next()
+	}
+}
+
+// MarkDeprecated sets the Deprecation (RFC 9745) and Sunset (RFC 8594)
+// headers and a Warning naming the successor, then logs and counts the use.
+// It can be called several times per request, once per deprecated feature.
+func MarkDeprecated(c *gin.Context, deprecation Deprecation) {
+	header := c.Writer.Header()
+	header.Set("Deprecation", fmt.Sprintf("@%d", deprecation.Since.Unix()))
+	header.Set("Sunset", deprecation.Sunset.UTC().Format(http.TimeFormat))
+	if deprecation.SuccessorLink != "" {
+		header.Add("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", deprecation.SuccessorLink))
+	}
+	header.Add("Warning", fmt.Sprintf("299 - %q", deprecation.message()))
+
+	log := logger.GetLogger()
+	log.Warn().
+		Str("deprecation_kind", deprecation.Kind).
+		Str("feature", deprecation.Feature).
+		Str("successor", deprecation.Successor).
+		Time("sunset", deprecation.Sunset).
+		Str("path", c.Request.URL.Path).
+		Str("user_agent", c.Request.UserAgent()).
+		Msg("deprecated feature used")
+
+	metrics.RecordDeprecatedUsage(deprecation.Kind, deprecation.Feature, c.Request.UserAgent())
+}
+
+func (d Deprecation) message() string {
+	subject := d.Feature
+	if d.Kind == DeprecationKindItemType {
+		subject = fmt.Sprintf("item type %s", d.Feature)
+	}
+	return fmt.Sprintf("%s is deprecated and will be removed on %s; use %s instead",
+		subject, d.Sunset.UTC().Format("2006-01-02"), d.Successor)
+}
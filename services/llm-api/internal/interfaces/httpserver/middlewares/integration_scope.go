@@ -0,0 +1,43 @@
+package middlewares
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	commonauth "github.com/janhq/jan-server/packages/go-common/auth"
+
+	"jan-server/services/llm-api/internal/domain/integration"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+)
+
+// IntegrationScopeMiddleware limits tokens issued to third-party integration clients
+// (their azp claim) to the routes their scopes open. Other callers pass through.
+func IntegrationScopeMiddleware(integrationClients []string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if len(integrationClients) == 0 {
+			c.Next()
+			return
+		}
+
+		principal, ok := PrincipalFromContext(c)
+		if !ok || !commonauth.IsIntegrationClient(principal.AuthorizedParty, integrationClients) {
+			c.Next()
+			return
+		}
+
+		scope := integration.RequiredScope(c.Request.Method, c.FullPath())
+		if scope == "" {
+			responses.HandleErrorWithStatus(c, http.StatusForbidden, errors.New("route not available to integrations"), "integration tokens cannot access this endpoint")
+			return
+		}
+		if !principal.HasScope(scope) {
+			c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope=%q`, scope))
+			responses.HandleErrorWithStatus(c, http.StatusForbidden, errors.New("insufficient scope"), "token lacks the "+scope+" scope")
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -5,7 +5,6 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog"
-	"go.opentelemetry.io/otel/trace"
 )
 
 // LoggingMiddleware logs HTTP requests with OpenTelemetry trace context
@@ -33,13 +32,8 @@ func LoggingMiddleware(logger zerolog.Logger) gin.HandlerFunc {
 			logEvent = logger.Warn()
 		}
 
-		// Add OpenTelemetry trace context if available
-		span := trace.SpanFromContext(c.Request.Context())
-		if span.SpanContext().IsValid() {
-			logEvent = logEvent.
-				Str("trace_id", span.SpanContext().TraceID().String()).
-				Str("span_id", span.SpanContext().SpanID().String())
-		}
+		// The logger's trace hook adds the OpenTelemetry trace context if available
+		logEvent = logEvent.Ctx(c.Request.Context())
 
 		// Add request ID if available
 		if requestID := c.GetString("request_id"); requestID != "" {
@@ -0,0 +1,52 @@
+package middlewares
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// maintenanceExemptPaths keep working in maintenance mode: the switch itself
+// and the auth calls that keep sessions and API keys working without
+// changing data. Paths under /llm are matched without the prefix.
+var maintenanceExemptPaths = map[string]bool{
+	"/v1/admin/maintenance":  true,
+	"/auth/refresh-token":    true,
+	"/auth/logout":           true,
+	"/auth/validate":         true,
+	"/auth/validate-api-key": true, // Called by Kong on every API key request
+	"/auth/revoke":           true,
+}
+
+// MaintenanceMiddleware turns away requests that change data with 503 and a
+// maintenance error while maintenance mode is on, as well as WebSocket
+// upgrades, whose messages can. Reads, health checks and the exempt paths are
+// served as usual.
+func MaintenanceMiddleware(service *maintenance.Service) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		state := service.Current()
+		if !maintenance.Blocks(state, c.Request) || maintenanceExemptPaths[strings.TrimPrefix(c.Request.URL.Path, "/llm")] {
+			c.Next()
+			return
+		}
+
+		responses.HandleError(c, MaintenanceError(c.Request.Context(), state), "service is in maintenance mode")
+	}
+}
+
+// MaintenanceError is the error requests are turned away with during
+// maintenance, carrying Retry-After when the expected end is known.
+func MaintenanceError(ctx context.Context, state maintenance.State) error {
+	var fields map[string]any
+	if retryAfter := state.RetryAfterSeconds(time.Now()); retryAfter > 0 {
+		fields = map[string]any{platformerrors.ContextKeyRetryAfter: retryAfter}
+	}
+	return platformerrors.NewErrorWithContext(ctx, platformerrors.LayerRoute,
+		platformerrors.ErrorTypeMaintenance, state.Message, nil, "5c1e8f3a-9d27-4b6e-a0f4-7e2b9c61d853", fields)
+}
@@ -0,0 +1,18 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/domain/region"
+)
+
+// RegionPreference carries the region pinned with the X-Jan-Region header into
+// the request context, where provider and endpoint selection read it.
+func RegionPreference() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if pinned := c.GetHeader(region.Header); pinned != "" {
+			c.Request = c.Request.WithContext(region.WithPinned(c.Request.Context(), pinned))
+		}
+		c.Next()
+	}
+}
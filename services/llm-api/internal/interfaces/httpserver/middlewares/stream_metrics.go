@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
+)
+
+// StreamMetricsMiddleware records how many bytes each route writes and how long
+// its response body takes, telling SSE streams apart from JSON responses.
+func StreamMetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		start := time.Now()
+		c.Next()
+
+		endpoint := c.FullPath()
+		if endpoint == "" {
+			endpoint = "unmatched"
+		}
+		metrics.RecordResponseStream(endpoint, responseKind(c.Writer.Header().Get("Content-Type")),
+			max(c.Writer.Size(), 0), time.Since(start).Seconds())
+	}
+}
+
+// responseKind maps a Content-Type to the kind label of the response metrics
+func responseKind(contentType string) string {
+	switch {
+	case strings.HasPrefix(contentType, "text/event-stream"):
+		return "sse"
+	case strings.Contains(contentType, "json"):
+		return "json"
+	default:
+		return "other"
+	}
+}
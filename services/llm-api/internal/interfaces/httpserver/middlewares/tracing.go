@@ -64,13 +64,15 @@ func TracingMiddleware(serviceName string) gin.HandlerFunc {
 		status := c.Writer.Status()
 		span.SetAttributes(semconv.HTTPStatusCode(status))
 
-		// Set span status based on HTTP status code
-		if status >= 400 {
+		// Set span status based on HTTP status code. Client errors leave it
+		// unset, so failed traces kept by the sampler are server failures.
+		switch {
+		case status >= 500:
 			span.SetStatus(codes.Error, c.Errors.String())
 			if len(c.Errors) > 0 {
 				span.RecordError(c.Errors.Last())
 			}
-		} else {
+		case status < 400:
 			span.SetStatus(codes.Ok, "")
 		}
 	}
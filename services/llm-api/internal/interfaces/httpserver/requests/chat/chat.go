@@ -1,7 +1,10 @@
 package chatrequests
 
 import (
+	"encoding/base64"
 	"encoding/json"
+	"fmt"
+	"strings"
 
 	"jan-server/services/llm-api/internal/domain/conversation"
 
@@ -132,9 +135,92 @@ type ChatCompletionRequest struct {
 	// Defaults to true. When set to false for a model with supports_reasoning: true
 	// and an instruct model configured, the instruct model will be used instead.
 	EnableThinking *bool `json:"enable_thinking,omitempty"`
+	// Reasoning sets the reasoning effort of reasoning models, mapped to each
+	// provider's own knob, and whether the model's reasoning is returned.
+	Reasoning *ReasoningOptions `json:"reasoning,omitempty"`
 	// Image indicates the user wants to generate images.
 	// When true, image generation tools will be made available.
 	Image *bool `json:"image,omitempty"`
+	// PersonaID applies one of the user's personas: its instructions are added to the
+	// system prompt above profile settings, its default model is used when model is
+	// empty, and only its enabled tools are kept.
+	PersonaID *string `json:"persona_id,omitempty"`
+	// Modalities lists the outputs to generate: "text", and "audio" to also stream
+	// the reply as speech. Audio requires stream: true and a configured TTS server.
+	Modalities []string `json:"modalities,omitempty" enums:"text,audio"`
+	// Audio configures the spoken reply when modalities includes "audio".
+	Audio *AudioOutputOptions `json:"audio,omitempty"`
+	// Realtime also streams the reply's text deltas to the data channel of a realtime
+	// session's LiveKit room, so every participant sees it live. Requires stream: true.
+	Realtime *RealtimeOptions `json:"realtime,omitempty"`
+}
+
+// RealtimeOptions names the realtime session room a reply is streamed to.
+type RealtimeOptions struct {
+	// RoomID is the room_id of a realtime session the caller has joined
+	RoomID string `json:"room_id"`
+}
+
+// AudioOutputOptions follows the audio parameter of OpenAI's chat completions.
+type AudioOutputOptions struct {
+	// Voice is the TTS voice; defaults to the server's TTS_VOICE
+	Voice string `json:"voice,omitempty"`
+	// Format of the streamed audio: pcm16 (24 kHz 16-bit mono, default) or opus
+	Format string `json:"format,omitempty" enums:"pcm16,opus"`
+}
+
+// WantsAudio reports whether the request asks for a spoken reply.
+func (r *ChatCompletionRequest) WantsAudio() bool {
+	for _, modality := range r.Modalities {
+		if strings.EqualFold(strings.TrimSpace(modality), "audio") {
+			return true
+		}
+	}
+	return false
+}
+
+// CancelStreamRequest is the optional body of the stream cancel endpoint.
+type CancelStreamRequest struct {
+	// AudioEndMs is how much of the reply's audio was played when the user
+	// interrupted it; the stored reply is truncated to match
+	AudioEndMs *int `json:"audio_end_ms,omitempty" binding:"omitempty,min=0"`
+}
+
+// ReasoningOptions follows the reasoning parameter of OpenAI's Responses API.
+type ReasoningOptions struct {
+	// Effort is how much the model reasons: low, medium or high
+	Effort string `json:"effort,omitempty" enums:"low,medium,high"`
+	// Summary requests the model's reasoning: auto, concise or detailed. When
+	// reasoning is set without a summary, reasoning is not streamed, returned
+	// or stored; with store_reasoning it is stored as a reasoning item.
+	Summary string `json:"summary,omitempty" enums:"auto,concise,detailed"`
+}
+
+// ExceededLimit returns a description of the first size limit the request breaks, or ""
+// when it fits. Only inline base64 (data URL) images count toward maxImageBytes, by
+// their decoded size. A zero limit is not enforced.
+func (r *ChatCompletionRequest) ExceededLimit(maxMessages int, maxImageBytes int64) string {
+	if maxMessages > 0 && len(r.Messages) > maxMessages {
+		return fmt.Sprintf("request has %d messages, exceeding the limit of %d", len(r.Messages), maxMessages)
+	}
+	if maxImageBytes <= 0 {
+		return ""
+	}
+	for i, msg := range r.Messages {
+		for _, part := range msg.MultiContent {
+			if part.ImageURL == nil {
+				continue
+			}
+			_, payload, found := strings.Cut(part.ImageURL.URL, ";base64,")
+			if !found || !strings.HasPrefix(part.ImageURL.URL, "data:") {
+				continue
+			}
+			if size := int64(base64.StdEncoding.DecodedLen(len(payload))); size > maxImageBytes {
+				return fmt.Sprintf("image in message %d is %d bytes, exceeding the limit of %d bytes", i, size, maxImageBytes)
+			}
+		}
+	}
+	return ""
 }
 
 // ConversationReference can unmarshal from either a string (ID) or an object
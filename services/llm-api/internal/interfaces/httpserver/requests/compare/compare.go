@@ -0,0 +1,28 @@
+package comparerequests
+
+import (
+	openai "github.com/sashabaranov/go-openai"
+
+	"jan-server/services/llm-api/internal/domain/comparison"
+)
+
+// CompareRequest runs the same messages against 2-4 models
+type CompareRequest struct {
+	Models      []string                       `json:"models" binding:"required"` // Model public IDs; the index is the model's slot
+	Messages    []openai.ChatCompletionMessage `json:"messages" binding:"required"`
+	Temperature *float32                       `json:"temperature,omitempty"`
+	TopP        *float32                       `json:"top_p,omitempty"`
+	MaxTokens   int                            `json:"max_tokens,omitempty"`
+	Stream      bool                           `json:"stream,omitempty"`
+}
+
+// VoteRequest records the user's preference for a finished comparison
+type VoteRequest struct {
+	Outcome    string `json:"outcome" binding:"required,oneof=winner tie all_bad"`
+	WinnerSlot *int   `json:"winner_slot,omitempty"` // Required if outcome is "winner"
+}
+
+// ToVoteOutcome converts the outcome string to a VoteOutcome
+func (r *VoteRequest) ToVoteOutcome() comparison.VoteOutcome {
+	return comparison.VoteOutcome(r.Outcome)
+}
@@ -9,6 +9,12 @@ type CreateConversationRequest struct {
 	Metadata  map[string]string   `json:"metadata,omitempty"`
 	Referrer  *string             `json:"referrer,omitempty"`
 	ProjectID *string             `json:"project_id,omitempty"`
+	// TitleLocked stops automatic title generation from changing the title.
+	TitleLocked *bool `json:"title_locked,omitempty"`
+	// PromptLibraryID starts the conversation from a prompt library starter; its rendered
+	// messages are added before Items.
+	PromptLibraryID *string           `json:"prompt_library_id,omitempty"`
+	PromptVariables map[string]string `json:"prompt_variables,omitempty"`
 }
 
 // UpdateConversationRequest represents the request to update a conversation
@@ -17,6 +23,12 @@ type UpdateConversationRequest struct {
 	Metadata  map[string]string `json:"metadata,omitempty"`
 	Referrer  *string           `json:"referrer,omitempty"`
 	ProjectID *string           `json:"project_id,omitempty"`
+	// TitleLocked locks or unlocks the title. Setting a title without this
+	// field locks it automatically.
+	TitleLocked *bool `json:"title_locked,omitempty"`
+	// Version is the conversation version the client last saw. When set, the
+	// update fails with 409 Conflict if the conversation has changed since.
+	Version *int `json:"version,omitempty"`
 }
 
 // CreateItemsRequest represents the request to create items in a conversation
@@ -54,11 +66,27 @@ type UpdateItemByCallIDRequest struct {
 	Status *string `json:"status" binding:"required"` // "completed", "failed", "cancelled"
 
 	// Result fields
-	Output *string `json:"output,omitempty"` // Result of the tool execution (JSON string)
-	Error  *string `json:"error,omitempty"`  // Error message if status is "failed"
+	Output      *string                 `json:"output,omitempty"`       // Result of the tool execution (JSON string)
+	Error       *string                 `json:"error,omitempty"`        // Error message if status is "failed"
+	ErrorDetail *conversation.ToolError `json:"error_detail,omitempty"` // Structured error (code, retryable, provider) if status is "failed"
 
 	// Tool info fields (optional - already set on creation, but can be updated)
 	Name        *string `json:"name,omitempty"`         // Tool name
 	Arguments   *string `json:"arguments,omitempty"`    // JSON string of arguments
 	ServerLabel *string `json:"server_label,omitempty"` // MCP server label
 }
+
+// EditItemRequest represents the request to edit an assistant message or tool output in place.
+// The replaced content is preserved in the item's edit history.
+type EditItemRequest struct {
+	Content []conversation.Content `json:"content,omitempty"` // Replacement content
+	Output  *string                `json:"output,omitempty"`  // Replacement output (tool output items only)
+	Reason  *string                `json:"reason,omitempty"`  // Why the item was edited, kept for audit
+}
+
+// TruncateItemRequest cuts an assistant message back to the audio the user heard
+// before interrupting it, like the OpenAI Realtime conversation.item.truncate event.
+type TruncateItemRequest struct {
+	ContentIndex *int `json:"content_index" binding:"required,min=0"` // Index of the audio content part
+	AudioEndMs   *int `json:"audio_end_ms" binding:"required,min=0"`  // Milliseconds of audio played before the interruption
+}
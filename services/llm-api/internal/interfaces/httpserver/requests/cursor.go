@@ -0,0 +1,100 @@
+package requests
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/utils/crypto"
+)
+
+// Cursor is the position a page ends at. It is returned to clients only as an
+// opaque token from EncodeCursor, so internal IDs are never exposed.
+type Cursor struct {
+	Scope string `json:"s"`
+	ID    uint   `json:"i,omitempty"`
+	Time  int64  `json:"t,omitempty"` // unix nanos, for lists keyed by a timestamp
+	Key   string `json:"k,omitempty"` // tiebreaker for non-numeric keys
+	Order string `json:"o"`
+}
+
+var errInvalidCursor = errors.New("invalid cursor")
+
+// Cursor scopes bind a token to the list it was issued for.
+func ConversationCursorScope(userID uint) string {
+	return fmt.Sprintf("conversations:%d", userID)
+}
+
+func ItemCursorScope(conversationPublicID string) string {
+	return "items:" + conversationPublicID
+}
+
+func APIKeyCursorScope(userID uint) string {
+	return fmt.Sprintf("api_keys:%d", userID)
+}
+
+func SyncCursorScope(userID uint) string {
+	return fmt.Sprintf("sync:%d", userID)
+}
+
+// EncodeCursor seals cur for scope (e.g. "conversations:42"). The token is
+// AES-GCM encrypted, so it cannot be read, forged or replayed on another list.
+func EncodeCursor(scope string, cur Cursor) (string, error) {
+	cur.Scope = scope
+	payload, err := json.Marshal(cur)
+	if err != nil {
+		return "", err
+	}
+	key, err := cursorKey()
+	if err != nil {
+		return "", err
+	}
+	sealed, err := crypto.Seal(key, payload)
+	if err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(sealed), nil
+}
+
+// DecodeCursor opens a token produced by EncodeCursor for the same scope.
+func DecodeCursor(scope, token string) (*Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	key, err := cursorKey()
+	if err != nil {
+		return nil, err
+	}
+	payload, err := crypto.Open(key, raw)
+	if err != nil {
+		return nil, errInvalidCursor
+	}
+	var cur Cursor
+	if err := json.Unmarshal(payload, &cur); err != nil || cur.Scope != scope {
+		return nil, errInvalidCursor
+	}
+	if cur.Order != "asc" && cur.Order != "desc" {
+		return nil, errInvalidCursor
+	}
+	return &cur, nil
+}
+
+// cursorKey derives the cursor key from CURSOR_SECRET, falling back to
+// MODEL_PROVIDER_SECRET so cursors work across replicas without extra
+// configuration. The "cursor" label keeps the key unrelated to the one that
+// encrypts provider API keys with the same secret.
+func cursorKey() ([]byte, error) {
+	cfg := config.GetGlobal()
+	if cfg == nil {
+		return nil, errors.New("cursor secret is not configured")
+	}
+	secret := strings.TrimSpace(cfg.CursorSecret)
+	if secret == "" {
+		secret = strings.TrimSpace(cfg.ModelProviderSecret)
+	}
+	return crypto.DeriveKey(secret, "cursor")
+}
@@ -0,0 +1,83 @@
+package evalrequests
+
+import (
+	"jan-server/services/llm-api/internal/domain/eval"
+)
+
+// GraderSpec configures how item outputs are scored
+type GraderSpec struct {
+	Type          string   `json:"type" binding:"required,oneof=exact_match llm_judge"`
+	CaseSensitive bool     `json:"case_sensitive,omitempty"` // exact_match only
+	JudgeModel    string   `json:"judge_model,omitempty"`    // llm_judge only; defaults to EVAL_JUDGE_MODEL
+	Rubric        string   `json:"rubric,omitempty"`         // llm_judge only
+	PassThreshold *float64 `json:"pass_threshold,omitempty"` // llm_judge only; 0-1, default 0.5
+}
+
+// DatasetItem is one prompt of an uploaded dataset
+type DatasetItem struct {
+	Input     string         `json:"input" binding:"required"`
+	Variables map[string]any `json:"variables,omitempty"` // Prompt template variables for this item
+	Expected  string         `json:"expected,omitempty"`  // Required for exact_match grading
+	Grader    *GraderSpec    `json:"grader,omitempty"`    // Overrides the dataset grader
+}
+
+// CreateDatasetRequest uploads a dataset of prompts with expected answers
+type CreateDatasetRequest struct {
+	Name        string        `json:"name" binding:"required,max=255"`
+	Description *string       `json:"description,omitempty"`
+	Grader      GraderSpec    `json:"grader" binding:"required"`
+	Items       []DatasetItem `json:"items" binding:"required,min=1,dive"`
+}
+
+// CreateRunRequest queues a dataset run against a model and optional prompt template
+type CreateRunRequest struct {
+	DatasetID        string  `json:"dataset_id" binding:"required"`
+	Model            string  `json:"model" binding:"required"`     // Model public ID
+	PromptTemplateID *string `json:"prompt_template_id,omitempty"` // Takes precedence over template_key
+	TemplateKey      *string `json:"template_key,omitempty"`       // Uses the template's current version
+}
+
+// ToGraderSpec converts the request grader to the domain spec
+func (g GraderSpec) ToGraderSpec() eval.GraderSpec {
+	return eval.GraderSpec{
+		Type:          g.Type,
+		CaseSensitive: g.CaseSensitive,
+		JudgeModel:    g.JudgeModel,
+		Rubric:        g.Rubric,
+		PassThreshold: g.PassThreshold,
+	}
+}
+
+// ToInput converts the request to the domain dataset input
+func (r *CreateDatasetRequest) ToInput() eval.CreateDatasetInput {
+	items := make([]*eval.DatasetItem, 0, len(r.Items))
+	for _, item := range r.Items {
+		var grader *eval.GraderSpec
+		if item.Grader != nil {
+			g := item.Grader.ToGraderSpec()
+			grader = &g
+		}
+		items = append(items, &eval.DatasetItem{
+			Input:     item.Input,
+			Variables: item.Variables,
+			Expected:  item.Expected,
+			Grader:    grader,
+		})
+	}
+	return eval.CreateDatasetInput{
+		Name:        r.Name,
+		Description: r.Description,
+		Grader:      r.Grader.ToGraderSpec(),
+		Items:       items,
+	}
+}
+
+// ToInput converts the request to the domain run input
+func (r *CreateRunRequest) ToInput() eval.CreateRunInput {
+	return eval.CreateRunInput{
+		DatasetID:        r.DatasetID,
+		Model:            r.Model,
+		PromptTemplateID: r.PromptTemplateID,
+		TemplateKey:      r.TemplateKey,
+	}
+}
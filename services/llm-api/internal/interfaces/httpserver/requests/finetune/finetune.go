@@ -0,0 +1,31 @@
+package finetunerequests
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/finetune"
+)
+
+// CreateExportRequest starts a fine-tuning dataset export of liked assistant turns
+type CreateExportRequest struct {
+	Format             string     `json:"format" binding:"required,oneof=openai chatml sharegpt"`
+	RedactPII          *bool      `json:"redact_pii,omitempty"`                                             // Defaults to true
+	RatedAfter         *time.Time `json:"rated_after,omitempty"`                                            // RFC 3339; inclusive
+	RatedBefore        *time.Time `json:"rated_before,omitempty"`                                           // RFC 3339; exclusive
+	MaxContextMessages int        `json:"max_context_messages,omitempty" binding:"omitempty,min=1,max=200"` // Messages kept before each liked turn; default 20
+}
+
+// ToInput converts the request to the domain export input
+func (r *CreateExportRequest) ToInput() finetune.CreateExportInput {
+	redact := true
+	if r.RedactPII != nil {
+		redact = *r.RedactPII
+	}
+	return finetune.CreateExportInput{
+		Format:             r.Format,
+		RedactPII:          redact,
+		RatedAfter:         r.RatedAfter,
+		RatedBefore:        r.RatedBefore,
+		MaxContextMessages: r.MaxContextMessages,
+	}
+}
@@ -0,0 +1,27 @@
+package mcpcredentialrequests
+
+import (
+	"time"
+
+	"jan-server/services/llm-api/internal/domain/mcpcredential"
+)
+
+// SaveCredentialRequest stores a third-party token for MCP tools
+type SaveCredentialRequest struct {
+	Token     string     `json:"token" binding:"required,max=4096"`               // OAuth access token or fine-grained personal access token
+	Account   *string    `json:"account,omitempty" binding:"omitempty,max=255"`   // Login of the connected account, for display
+	Scopes    []string   `json:"scopes,omitempty" binding:"omitempty,max=50"`     // Scopes granted to the token; consent scopes for google, microsoft and caldav
+	Endpoint  *string    `json:"endpoint,omitempty" binding:"omitempty,max=2048"` // CalDAV calendar collection URL
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`                            // RFC 3339; when the token stops working
+}
+
+// ToInput converts the request to the domain input
+func (r *SaveCredentialRequest) ToInput() mcpcredential.Input {
+	return mcpcredential.Input{
+		Token:     r.Token,
+		Account:   r.Account,
+		Scopes:    r.Scopes,
+		Endpoint:  r.Endpoint,
+		ExpiresAt: r.ExpiresAt,
+	}
+}
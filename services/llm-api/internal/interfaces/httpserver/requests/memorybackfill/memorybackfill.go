@@ -0,0 +1,15 @@
+package memorybackfillrequests
+
+import (
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
+)
+
+// CreateBackfillRequest starts a replay of historical conversations through memory observation
+type CreateBackfillRequest struct {
+	UserID *uint `json:"user_id,omitempty" binding:"omitempty,min=1"` // Only this user's conversations; every user when omitted
+}
+
+// ToInput converts the request to the domain backfill input
+func (r *CreateBackfillRequest) ToInput() memorybackfill.CreateBackfillInput {
+	return memorybackfill.CreateBackfillInput{UserID: r.UserID}
+}
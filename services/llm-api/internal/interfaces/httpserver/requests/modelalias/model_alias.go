@@ -0,0 +1,43 @@
+package modelaliasrequests
+
+import (
+	"jan-server/services/llm-api/internal/domain/modelalias"
+)
+
+// CreateModelAliasRequest creates a model alias
+type CreateModelAliasRequest struct {
+	Name           string  `json:"name" binding:"required,max=64"`                      // e.g. jan-large
+	TargetModel    string  `json:"target_model" binding:"required,max=255"`             // Model ID of the stable channel
+	RolloutModel   *string `json:"rollout_model,omitempty" binding:"omitempty,max=255"` // Model ID rolled out to rollout_percent of users
+	RolloutPercent int     `json:"rollout_percent,omitempty" binding:"omitempty,min=0,max=100"`
+	Description    *string `json:"description,omitempty"`
+}
+
+// UpdateModelAliasRequest remaps a model alias; omitted fields are left unchanged
+type UpdateModelAliasRequest struct {
+	TargetModel    *string `json:"target_model,omitempty" binding:"omitempty,max=255"`
+	RolloutModel   *string `json:"rollout_model,omitempty" binding:"omitempty,max=255"` // An empty string ends the rollout
+	RolloutPercent *int    `json:"rollout_percent,omitempty" binding:"omitempty,min=0,max=100"`
+	Description    *string `json:"description,omitempty"`
+}
+
+// ToInput converts the request to the domain alias input
+func (r *CreateModelAliasRequest) ToInput() modelalias.Input {
+	return modelalias.Input{
+		Name:           r.Name,
+		TargetModel:    r.TargetModel,
+		RolloutModel:   r.RolloutModel,
+		RolloutPercent: r.RolloutPercent,
+		Description:    r.Description,
+	}
+}
+
+// ToInput converts the request to the domain update input
+func (r *UpdateModelAliasRequest) ToInput() modelalias.UpdateInput {
+	return modelalias.UpdateInput{
+		TargetModel:    r.TargetModel,
+		RolloutModel:   r.RolloutModel,
+		RolloutPercent: r.RolloutPercent,
+		Description:    r.Description,
+	}
+}
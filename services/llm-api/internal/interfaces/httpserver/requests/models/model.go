@@ -33,10 +33,16 @@ type UpdateProviderRequest struct {
 	DefaultProviderImageEdit     *bool `json:"default_provider_image_edit"`
 }
 
+// TestProviderRequest optionally picks the model used for the completion checks
+type TestProviderRequest struct {
+	Model string `json:"model"`
+}
+
 type EndpointDTO struct {
 	URL      string `json:"url"`
 	Weight   int    `json:"weight,omitempty"`
 	Priority int    `json:"priority,omitempty"`
+	Region   string `json:"region,omitempty"` // e.g. eu-west; defaults to the provider's region metadata
 }
 
 // GetEndpointList resolves endpoints with precedence: explicit endpoints array > URL/base_url fields.
@@ -97,6 +103,7 @@ func buildEndpointList(dtos []EndpointDTO) (domainmodel.EndpointList, error) {
 				URL:      ep.URL,
 				Weight:   weight,
 				Priority: dto.Priority,
+				Region:   domainmodel.NormalizeRegion(dto.Region),
 				Healthy:  true,
 			})
 		}
@@ -138,20 +145,21 @@ type UpdateModelCatalogRequest struct {
 }
 
 type UpdateProviderModelRequest struct {
-	ModelDisplayName      *string                  `json:"model_display_name"`
-	Category              *string                  `json:"category"`
-	CategoryOrderNumber   *int                     `json:"category_order_number"`
-	ModelOrderNumber      *int                     `json:"model_order_number"`
-	Pricing               *domainmodel.Pricing     `json:"pricing"`
-	TokenLimits           *domainmodel.TokenLimits `json:"token_limits"`
-	Family                *string                  `json:"family"`
-	SupportsImages        *bool                    `json:"supports_images"`
-	SupportsEmbeddings    *bool                    `json:"supports_embeddings"`
-	SupportsReasoning     *bool                    `json:"supports_reasoning"`
-	SupportsAudio         *bool                    `json:"supports_audio"`
-	SupportsVideo         *bool                    `json:"supports_video"`
-	Active                *bool                    `json:"active"`
-	InstructModelPublicID *string                  `json:"instruct_model_public_id"` // Public ID of the instruct model to use when enable_thinking=false
+	ModelDisplayName      *string                      `json:"model_display_name"`
+	Category              *string                      `json:"category"`
+	CategoryOrderNumber   *int                         `json:"category_order_number"`
+	ModelOrderNumber      *int                         `json:"model_order_number"`
+	Pricing               *domainmodel.Pricing         `json:"pricing"`
+	TokenLimits           *domainmodel.TokenLimits     `json:"token_limits"`
+	ReasoningPolicy       *domainmodel.ReasoningPolicy `json:"reasoning_policy"` // Replaces the model's reasoning policy; {} restores the defaults
+	Family                *string                      `json:"family"`
+	SupportsImages        *bool                        `json:"supports_images"`
+	SupportsEmbeddings    *bool                        `json:"supports_embeddings"`
+	SupportsReasoning     *bool                        `json:"supports_reasoning"`
+	SupportsAudio         *bool                        `json:"supports_audio"`
+	SupportsVideo         *bool                        `json:"supports_video"`
+	Active                *bool                        `json:"active"`
+	InstructModelPublicID *string                      `json:"instruct_model_public_id"` // Public ID of the instruct model to use when enable_thinking=false
 }
 
 type BulkEnableModelsRequest struct {
@@ -0,0 +1,53 @@
+package personarequests
+
+import (
+	"jan-server/services/llm-api/internal/domain/persona"
+)
+
+// CreatePersonaRequest creates a persona
+type CreatePersonaRequest struct {
+	Name             string   `json:"name" binding:"required,max=100"`
+	Description      *string  `json:"description,omitempty"`
+	Avatar           *string  `json:"avatar,omitempty" binding:"omitempty,max=2048"` // Image URL or media ID
+	Instructions     string   `json:"instructions" binding:"required,max=20000"`
+	DefaultModel     *string  `json:"default_model,omitempty" binding:"omitempty,max=255"`
+	EnabledTools     []string `json:"enabled_tools,omitempty" binding:"omitempty,max=50"`     // Empty keeps every tool the request sends
+	StarterQuestions []string `json:"starter_questions,omitempty" binding:"omitempty,max=10"` // Suggested first questions
+}
+
+// UpdatePersonaRequest updates a persona; omitted fields are left unchanged
+type UpdatePersonaRequest struct {
+	Name             *string  `json:"name,omitempty" binding:"omitempty,max=100"`
+	Description      *string  `json:"description,omitempty"`
+	Avatar           *string  `json:"avatar,omitempty" binding:"omitempty,max=2048"` // An empty string removes the avatar
+	Instructions     *string  `json:"instructions,omitempty" binding:"omitempty,max=20000"`
+	DefaultModel     *string  `json:"default_model,omitempty" binding:"omitempty,max=255"` // An empty string removes the default model
+	EnabledTools     []string `json:"enabled_tools,omitempty" binding:"omitempty,max=50"`  // An empty list allows every tool again
+	StarterQuestions []string `json:"starter_questions,omitempty" binding:"omitempty,max=10"`
+}
+
+// ToInput converts the request to the domain persona input
+func (r *CreatePersonaRequest) ToInput() persona.Input {
+	return persona.Input{
+		Name:             r.Name,
+		Description:      r.Description,
+		Avatar:           r.Avatar,
+		Instructions:     r.Instructions,
+		DefaultModel:     r.DefaultModel,
+		EnabledTools:     r.EnabledTools,
+		StarterQuestions: r.StarterQuestions,
+	}
+}
+
+// ToInput converts the request to the domain update input
+func (r *UpdatePersonaRequest) ToInput() persona.UpdateInput {
+	return persona.UpdateInput{
+		Name:             r.Name,
+		Description:      r.Description,
+		Avatar:           r.Avatar,
+		Instructions:     r.Instructions,
+		DefaultModel:     r.DefaultModel,
+		EnabledTools:     r.EnabledTools,
+		StarterQuestions: r.StarterQuestions,
+	}
+}
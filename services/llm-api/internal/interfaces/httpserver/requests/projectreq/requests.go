@@ -2,14 +2,18 @@ package projectreq
 
 // CreateProjectRequest represents the request to create a project
 type CreateProjectRequest struct {
-	Name        string  `json:"name" binding:"required"`
-	Instruction *string `json:"instruction,omitempty"`
+	Name                 string          `json:"name" binding:"required"`
+	Instruction          *string         `json:"instruction,omitempty"`
+	DefaultTools         map[string]bool `json:"default_tools,omitempty"`         // Tool name -> on/off for completions in the project
+	KnowledgeCollections []string        `json:"knowledge_collections,omitempty"` // Vector store collections searched in the project's conversations
 }
 
 // UpdateProjectRequest represents the request to update a project
 type UpdateProjectRequest struct {
-	Name        *string `json:"name,omitempty"`
-	Instruction *string `json:"instruction,omitempty"`
-	Archived    *bool   `json:"is_archived,omitempty"`
-	Favorite    *bool   `json:"is_favorite,omitempty"`
+	Name                 *string         `json:"name,omitempty"`
+	Instruction          *string         `json:"instruction,omitempty"`
+	DefaultTools         map[string]bool `json:"default_tools,omitempty"`         // Replaces the tool defaults; {} clears them
+	KnowledgeCollections []string        `json:"knowledge_collections,omitempty"` // Replaces the bound collections; [] clears them
+	Archived             *bool           `json:"is_archived,omitempty"`
+	Favorite             *bool           `json:"is_favorite,omitempty"`
 }
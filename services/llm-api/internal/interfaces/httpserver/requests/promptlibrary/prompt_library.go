@@ -0,0 +1,88 @@
+package promptlibraryrequests
+
+import (
+	"jan-server/services/llm-api/internal/domain/promptlibrary"
+)
+
+// StarterMessage is one templated first message of a conversation
+type StarterMessage struct {
+	Role    string `json:"role" binding:"required,oneof=system user assistant"`
+	Content string `json:"content" binding:"required"` // Go template syntax, e.g. "Explain {{.topic}}"
+}
+
+// StarterVariable describes a placeholder used by the messages
+type StarterVariable struct {
+	Name        string  `json:"name" binding:"required,max=64"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+	Required    bool    `json:"required,omitempty"` // Must be supplied when no default is set
+}
+
+// CreateStarterRequest creates a prompt library starter
+type CreateStarterRequest struct {
+	Title       string            `json:"title" binding:"required,max=255"`
+	Description *string           `json:"description,omitempty"`
+	Category    string            `json:"category" binding:"required,max=100"`
+	Messages    []StarterMessage  `json:"messages" binding:"required,min=1,max=20,dive"`
+	Variables   []StarterVariable `json:"variables,omitempty" binding:"omitempty,dive"`
+}
+
+// UpdateStarterRequest updates a prompt library starter; omitted fields are left unchanged
+type UpdateStarterRequest struct {
+	Title       *string           `json:"title,omitempty" binding:"omitempty,max=255"`
+	Description *string           `json:"description,omitempty"`
+	Category    *string           `json:"category,omitempty" binding:"omitempty,max=100"`
+	Messages    []StarterMessage  `json:"messages,omitempty" binding:"omitempty,min=1,max=20,dive"`
+	Variables   []StarterVariable `json:"variables,omitempty" binding:"omitempty,dive"` // An empty list removes all variables
+	IsActive    *bool             `json:"is_active,omitempty"`
+}
+
+// ToInput converts the request to the domain starter input
+func (r *CreateStarterRequest) ToInput() promptlibrary.StarterInput {
+	return promptlibrary.StarterInput{
+		Title:       r.Title,
+		Description: r.Description,
+		Category:    r.Category,
+		Messages:    toMessages(r.Messages),
+		Variables:   toVariables(r.Variables),
+	}
+}
+
+// ToInput converts the request to the domain update input
+func (r *UpdateStarterRequest) ToInput() promptlibrary.UpdateInput {
+	return promptlibrary.UpdateInput{
+		Title:       r.Title,
+		Description: r.Description,
+		Category:    r.Category,
+		Messages:    toMessages(r.Messages),
+		Variables:   toVariables(r.Variables),
+		IsActive:    r.IsActive,
+	}
+}
+
+func toMessages(messages []StarterMessage) []promptlibrary.Message {
+	if messages == nil {
+		return nil
+	}
+	out := make([]promptlibrary.Message, 0, len(messages))
+	for _, m := range messages {
+		out = append(out, promptlibrary.Message{Role: m.Role, Content: m.Content})
+	}
+	return out
+}
+
+func toVariables(variables []StarterVariable) []promptlibrary.Variable {
+	if variables == nil {
+		return nil
+	}
+	out := make([]promptlibrary.Variable, 0, len(variables))
+	for _, v := range variables {
+		out = append(out, promptlibrary.Variable{
+			Name:        v.Name,
+			Description: v.Description,
+			Default:     v.Default,
+			Required:    v.Required,
+		})
+	}
+	return out
+}
@@ -2,13 +2,19 @@ package requests
 
 import (
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"jan-server/services/llm-api/internal/domain/query"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 )
 
-func GetCursorPaginationFromQuery(reqCtx *gin.Context, findByLastID func(string) (*uint, error)) (*query.Pagination, error) {
+// GetCursorPaginationFromQuery parses limit, offset, order and the page cursor
+// (`after` or `cursor`). With a scope the cursor must be a token from
+// EncodeCursor for that scope; legacy, when set, resolves any other value (for
+// OpenAI-compatible lists that page by public ID). Without a scope, legacy or a
+// numeric ID is used.
+func GetCursorPaginationFromQuery(reqCtx *gin.Context, scope string, legacy func(string) (*uint, error)) (*query.Pagination, error) {
 	limitStr := reqCtx.DefaultQuery("limit", "20")
 	offsetStr := reqCtx.Query("offset")
 	order := reqCtx.DefaultQuery("order", "desc")
@@ -30,6 +36,7 @@ func GetCursorPaginationFromQuery(reqCtx *gin.Context, findByLastID func(string)
 
 	var offset *int
 	var after *uint
+	var afterTime *time.Time
 	if offsetStr != "" {
 		offsetInt, err := strconv.Atoi(offsetStr)
 		if err != nil {
@@ -37,13 +44,30 @@ func GetCursorPaginationFromQuery(reqCtx *gin.Context, findByLastID func(string)
 		}
 		offset = &offsetInt
 	} else if afterStr != "" {
-		if findByLastID != nil {
-			lastID, err := findByLastID(afterStr)
+		var cursor *Cursor
+		if scope != "" {
+			cursor, _ = DecodeCursor(scope, afterStr)
+		}
+		switch {
+		case cursor != nil:
+			if reqCtx.Query("order") != "" && order != cursor.Order {
+				return nil, platformerrors.NewError(reqCtx.Request.Context(), platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "order does not match pagination cursor", nil, "3c1f7d2a-8e4b-4f6a-9d0c-5b2e7a1f4c83")
+			}
+			order = cursor.Order
+			after = &cursor.ID
+			if cursor.Time != 0 {
+				t := time.Unix(0, cursor.Time)
+				afterTime = &t
+			}
+		case legacy != nil:
+			lastID, err := legacy(afterStr)
 			if err != nil {
 				return nil, platformerrors.NewError(reqCtx.Request.Context(), platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "invalid offset number", nil, "1f9ee4ee-56ed-448e-9296-d978c9a03726")
 			}
 			after = lastID
-		} else {
+		case scope != "":
+			return nil, platformerrors.NewError(reqCtx.Request.Context(), platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "invalid pagination cursor", nil, "7e2d9b41-6a3c-4f8e-b5d7-0c9a2e4f6b18")
+		default:
 			parsedID, err := strconv.ParseUint(afterStr, 10, 64)
 			if err != nil {
 				return nil, platformerrors.NewError(reqCtx.Request.Context(), platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "invalid pagination cursor", err, "9a5c2c48-5c59-4f40-9f27-5861e9c62d2f")
@@ -58,15 +82,43 @@ func GetCursorPaginationFromQuery(reqCtx *gin.Context, findByLastID func(string)
 	}
 
 	return &query.Pagination{
-		Limit:  limit,
-		Offset: offset,
-		Order:  order,
-		After:  after,
+		Limit:     limit,
+		Offset:    offset,
+		Order:     order,
+		After:     after,
+		AfterTime: afterTime,
 	}, nil
 }
 
+// NextCursor returns the token for the page after lastID, or "" when there is
+// no further page.
+func NextCursor(scope string, hasMore bool, lastID uint, order string) string {
+	if !hasMore || lastID == 0 {
+		return ""
+	}
+	token, err := EncodeCursor(scope, Cursor{ID: lastID, Order: order})
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
+// NextTimeCursor is NextCursor for lists ordered by a timestamp, then ID. The
+// cursor carries both, so rows sharing the timestamp are neither skipped nor
+// repeated.
+func NextTimeCursor(scope string, hasMore bool, lastID uint, lastTime time.Time, order string) string {
+	if !hasMore || lastID == 0 {
+		return ""
+	}
+	token, err := EncodeCursor(scope, Cursor{ID: lastID, Time: lastTime.UnixNano(), Order: order})
+	if err != nil {
+		return ""
+	}
+	return token
+}
+
 func GetPaginationFromQuery(reqCtx *gin.Context) (*query.Pagination, error) {
-	return GetCursorPaginationFromQuery(reqCtx, func(s string) (*uint, error) {
+	return GetCursorPaginationFromQuery(reqCtx, "", func(s string) (*uint, error) {
 		return nil, platformerrors.NewError(reqCtx.Request.Context(), platformerrors.LayerHandler, platformerrors.ErrorTypeValidation, "invalid query parameter: last", nil, "6b72a4af-ea95-4fbc-b141-486f4da86e79")
 	})
 }
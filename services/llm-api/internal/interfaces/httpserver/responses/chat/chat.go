@@ -33,3 +33,23 @@ func NewChatCompletionResponse(openaiResp *openai.ChatCompletionResponse, conver
 
 	return resp
 }
+
+// StreamCancelResponse reports a streaming completion stopped through the cancel endpoint
+type StreamCancelResponse struct {
+	ID                string `json:"id"`                  // The stream ID from the X-Stream-ID header
+	Object            string `json:"object"`              // Always "chat.completion.stream"
+	Status            string `json:"status"`              // Always "cancelled"
+	ConversationID    string `json:"conversation_id"`     // The conversation the stream was writing to
+	CancelledMCPCalls int64  `json:"cancelled_mcp_calls"` // Pending mcp_call items marked cancelled
+}
+
+// NewStreamCancelResponse creates the response for a stopped stream
+func NewStreamCancelResponse(streamID, conversationID string, cancelledMCPCalls int64) *StreamCancelResponse {
+	return &StreamCancelResponse{
+		ID:                streamID,
+		Object:            "chat.completion.stream",
+		Status:            "cancelled",
+		ConversationID:    conversationID,
+		CancelledMCPCalls: cancelledMCPCalls,
+	}
+}
@@ -6,14 +6,16 @@ import (
 
 // ConversationResponse represents the OpenAI-compatible conversation response
 type ConversationResponse struct {
-	ID        string            `json:"id"`
-	Object    string            `json:"object"`
-	Title     *string           `json:"title,omitempty"`
-	CreatedAt int64             `json:"created_at"`
-	UpdatedAt int64             `json:"updated_at"`
-	Metadata  map[string]string `json:"metadata,omitempty"`
-	Referrer  *string           `json:"referrer,omitempty"`
-	ProjectID *string           `json:"project_id,omitempty"`
+	ID          string            `json:"id"`
+	Object      string            `json:"object"`
+	Title       *string           `json:"title,omitempty"`
+	TitleLocked bool              `json:"title_locked"`
+	CreatedAt   int64             `json:"created_at"`
+	UpdatedAt   int64             `json:"updated_at"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+	Referrer    *string           `json:"referrer,omitempty"`
+	ProjectID   *string           `json:"project_id,omitempty"`
+	Version     int               `json:"version"`
 }
 
 // ConversationListResponse represents a paginated list of conversations
@@ -24,6 +26,8 @@ type ConversationListResponse struct {
 	LastID  string                 `json:"last_id"`
 	HasMore bool                   `json:"has_more"`
 	Total   int64                  `json:"total"`
+	// NextCursor is an opaque token for the next page (pass as `after`)
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // ConversationDeletedResponse represents the delete confirmation response
@@ -47,19 +51,23 @@ type ItemListResponse struct {
 	FirstID string              `json:"first_id"`
 	LastID  string              `json:"last_id"`
 	HasMore bool                `json:"has_more"`
+	// NextCursor is an opaque token for the next page (pass as `after`)
+	NextCursor string `json:"next_cursor,omitempty"`
 }
 
 // NewConversationResponse creates a response from a domain conversation
 func NewConversationResponse(conv *conversation.Conversation) *ConversationResponse {
 	response := &ConversationResponse{
-		ID:        conv.PublicID,
-		Object:    "conversation",
-		Title:     conv.Title,
-		CreatedAt: conv.CreatedAt.Unix(),
-		UpdatedAt: conv.UpdatedAt.Unix(),
-		Metadata:  conv.Metadata,
-		Referrer:  conv.Referrer,
-		ProjectID: conv.ProjectPublicID,
+		ID:          conv.PublicID,
+		Object:      "conversation",
+		Title:       conv.Title,
+		TitleLocked: conv.TitleLocked,
+		CreatedAt:   conv.CreatedAt.Unix(),
+		UpdatedAt:   conv.UpdatedAt.Unix(),
+		Metadata:    conv.Metadata,
+		Referrer:    conv.Referrer,
+		ProjectID:   conv.ProjectPublicID,
+		Version:     conv.Version,
 	}
 	return response
 }
@@ -165,3 +173,126 @@ func NewConversationItemCreatedResponse(items []conversation.Item) *Conversation
 		HasMore: false,
 	}
 }
+
+// ItemEditListResponse represents the edit history of a conversation item
+type ItemEditListResponse struct {
+	Object string                   `json:"object"`
+	ItemID string                   `json:"item_id"`
+	Data   []*conversation.ItemEdit `json:"data"`
+}
+
+// NewItemEditListResponse creates an edit history response, oldest edit first
+func NewItemEditListResponse(itemID string, edits []*conversation.ItemEdit) *ItemEditListResponse {
+	if edits == nil {
+		edits = []*conversation.ItemEdit{}
+	}
+	return &ItemEditListResponse{
+		Object: "list",
+		ItemID: itemID,
+		Data:   edits,
+	}
+}
+
+// InstructionResponse describes the project instruction a conversation's completions use
+type InstructionResponse struct {
+	Object         string  `json:"object"`
+	ConversationID string  `json:"conversation_id"`
+	ProjectID      *string `json:"project_id,omitempty"`
+	Pinned         bool    `json:"pinned"`                   // False until the first completion pins the project's instruction
+	Version        int     `json:"version,omitempty"`        // Project instruction version the conversation is pinned to
+	Instruction    *string `json:"instruction,omitempty"`    // Pinned instruction text
+	LatestVersion  int     `json:"latest_version,omitempty"` // The project's current instruction version
+	Stale          bool    `json:"stale"`                    // True if the project's instruction changed since it was pinned
+}
+
+// NewInstructionResponse creates an instruction response; latestVersion is 0
+// for conversations outside a project
+func NewInstructionResponse(conv *conversation.Conversation, latestVersion int) *InstructionResponse {
+	resp := &InstructionResponse{
+		Object:         "conversation.instruction",
+		ConversationID: conv.PublicID,
+		ProjectID:      conv.ProjectPublicID,
+		LatestVersion:  latestVersion,
+	}
+	if conv.EffectiveInstructionSnapshot != nil {
+		resp.Pinned = true
+		resp.Version = conv.InstructionVersion
+		resp.Instruction = conv.EffectiveInstructionSnapshot
+		resp.Stale = conv.InstructionVersion < latestVersion
+	}
+	return resp
+}
+
+// RehydrationResponse reports the items of a conversation restored from cold storage
+type RehydrationResponse struct {
+	Object         string `json:"object"`
+	ConversationID string `json:"conversation_id"`
+	ItemsRestored  int    `json:"items_restored"` // 0 when no items were in cold storage
+}
+
+// NewRehydrationResponse creates a rehydration response
+func NewRehydrationResponse(conv *conversation.Conversation, restored int) *RehydrationResponse {
+	return &RehydrationResponse{
+		Object:         "conversation.rehydration",
+		ConversationID: conv.PublicID,
+		ItemsRestored:  restored,
+	}
+}
+
+// AttachmentResponse is an image or file referenced by a conversation item
+type AttachmentResponse struct {
+	Object       string  `json:"object"`
+	ItemID       string  `json:"item_id"`
+	Role         *string `json:"role,omitempty"`
+	Type         string  `json:"type"`                     // image, file or computer_screenshot
+	MediaID      string  `json:"media_id,omitempty"`       // Set for objects stored in media-api
+	FileID       string  `json:"file_id,omitempty"`        // Client-provided file ID that is not a media ID
+	Name         string  `json:"name,omitempty"`           // File name, when the item gave one
+	MimeType     string  `json:"mime_type,omitempty"`      // Content type, when known
+	Bytes        int64   `json:"bytes,omitempty"`          // Size in bytes, when known
+	URL          string  `json:"url,omitempty"`            // Download URL; absent for inline attachments
+	URLExpiresAt *int64  `json:"url_expires_at,omitempty"` // Set when url is presigned
+	Inline       bool    `json:"inline,omitempty"`         // Embedded in the item as base64 data
+	CreatedAt    int64   `json:"created_at"`               // When the item was created
+}
+
+// AttachmentListResponse lists the attachments of a conversation
+type AttachmentListResponse struct {
+	Object     string               `json:"object"`
+	Data       []AttachmentResponse `json:"data"`
+	TotalBytes int64                `json:"total_bytes"` // Sum of the known sizes
+}
+
+// NewAttachmentListResponse creates an attachment list response
+func NewAttachmentListResponse(attachments []conversation.Attachment) *AttachmentListResponse {
+	resp := &AttachmentListResponse{
+		Object: "list",
+		Data:   make([]AttachmentResponse, 0, len(attachments)),
+	}
+	for _, attachment := range attachments {
+		item := AttachmentResponse{
+			Object:    "conversation.attachment",
+			ItemID:    attachment.ItemID,
+			Type:      attachment.Type,
+			MediaID:   attachment.MediaID,
+			FileID:    attachment.FileID,
+			Name:      attachment.Name,
+			MimeType:  attachment.MimeType,
+			Bytes:     attachment.Bytes,
+			URL:       attachment.URL,
+			Inline:    attachment.Inline,
+			CreatedAt: attachment.CreatedAt.Unix(),
+		}
+		if attachment.Role != nil {
+			role := string(*attachment.Role)
+			item.Role = &role
+		}
+		if attachment.URLExpiresAt != nil {
+			expiresAt := attachment.URLExpiresAt.Unix()
+			item.URLExpiresAt = &expiresAt
+		}
+		resp.TotalBytes += attachment.Bytes
+		resp.Data = append(resp.Data, item)
+	}
+	return resp
+}
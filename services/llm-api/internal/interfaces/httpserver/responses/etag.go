@@ -0,0 +1,68 @@
+package responses
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// JSONWithETag writes obj as a 200 JSON response tagged with a weak ETag of its
+// encoding. When the request's If-None-Match already names that ETag, an empty
+// 304 is sent instead, so polling clients only download what changed. The tag
+// is weak because compression may re-encode the body.
+func JSONWithETag(reqCtx *gin.Context, obj any) {
+	body, err := json.Marshal(obj)
+	if err != nil {
+		HandleError(reqCtx, err, "Failed to encode response")
+		return
+	}
+
+	sum := sha256.Sum256(body)
+	if NotModified(reqCtx, `W/"`+hex.EncodeToString(sum[:16])+`"`) {
+		return
+	}
+	reqCtx.Data(http.StatusOK, "application/json; charset=utf-8", body)
+}
+
+// RevisionETag returns a weak ETag for a resource at a known revision, such as
+// a conversation's version and updated_at plus the query that selects a page
+// of it. Unlike JSONWithETag it needs nothing loaded, so a handler can answer
+// a conditional request before reading what it would return.
+func RevisionETag(parts ...any) string {
+	hash := sha256.New()
+	for _, part := range parts {
+		fmt.Fprintf(hash, "%v\x00", part)
+	}
+	return `W/"` + hex.EncodeToString(hash.Sum(nil)[:16]) + `"`
+}
+
+// NotModified sets the ETag header and, when the request's If-None-Match
+// already names etag, writes an empty 304 and returns true.
+func NotModified(reqCtx *gin.Context, etag string) bool {
+	reqCtx.Header("ETag", etag)
+	if etagMatches(reqCtx.GetHeader("If-None-Match"), etag) {
+		reqCtx.Status(http.StatusNotModified)
+		return true
+	}
+	return false
+}
+
+// etagMatches applies the weak comparison of RFC 9110 to an If-None-Match list.
+func etagMatches(ifNoneMatch string, etag string) bool {
+	if ifNoneMatch == "" {
+		return false
+	}
+	want := strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == want {
+			return true
+		}
+	}
+	return false
+}
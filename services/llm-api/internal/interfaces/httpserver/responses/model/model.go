@@ -259,7 +259,7 @@ func BuildProviderResponse(provider *domainmodel.Provider) ProviderResponse {
 		Name:      provider.DisplayName,
 		Vendor:    strings.ToLower(string(provider.Kind)),
 		BaseURL:   provider.BaseURL,
-		Endpoints: buildEndpointResponses(provider.GetEndpoints()),
+		Endpoints: buildEndpointResponses(provider.RegionalEndpoints()),
 		Active:    provider.Active,
 		Category:  string(provider.Category),
 		DefaultProviderImageGenerate: provider.DefaultImageGenerate,
@@ -278,7 +278,7 @@ func BuildProviderWithModelCountResponse(
 		Name:             provider.DisplayName,
 		Vendor:           strings.ToLower(string(provider.Kind)),
 		BaseURL:          provider.BaseURL,
-		Endpoints:        buildEndpointResponses(provider.GetEndpoints()),
+		Endpoints:        buildEndpointResponses(provider.RegionalEndpoints()),
 		Active:           provider.Active,
 		Category:         string(provider.Category),
 		DefaultProviderImageGenerate: provider.DefaultImageGenerate,
@@ -318,7 +318,7 @@ func BuildProviderWithModelsResponse(
 		Name:      provider.DisplayName,
 		Vendor:    strings.ToLower(string(provider.Kind)),
 		BaseURL:   provider.BaseURL,
-		Endpoints: buildEndpointResponses(provider.GetEndpoints()),
+		Endpoints: buildEndpointResponses(provider.RegionalEndpoints()),
 		Models:    modelResponses,
 		Active:    provider.Active,
 		Category:  string(provider.Category),
@@ -352,6 +352,7 @@ type EndpointResponse struct {
 	URL      string `json:"url"`
 	Weight   int    `json:"weight"`
 	Priority int    `json:"priority"`
+	Region   string `json:"region,omitempty"`
 	Healthy  bool   `json:"healthy"`
 }
 
@@ -365,6 +366,7 @@ func buildEndpointResponses(endpoints domainmodel.EndpointList) []EndpointRespon
 			URL:      ep.URL,
 			Weight:   ep.Weight,
 			Priority: ep.Priority,
+			Region:   ep.Region,
 			Healthy:  ep.Healthy,
 		})
 	}
@@ -402,29 +404,30 @@ type ModelCatalogResponse struct {
 }
 
 type ProviderModelResponse struct {
-	ID                      string                   `json:"id"`
-	ProviderID              string                   `json:"provider_id"`
-	ProviderVendor          string                   `json:"provider_vendor"`
-	ModelCatalogID          *string                  `json:"model_catalog_id,omitempty"`
-	ModelPublicID           string                   `json:"model_public_id"`
-	ProviderOriginalModelID string                   `json:"provider_original_model_id"`
-	ModelDisplayName        string                   `json:"model_display_name"`
-	Category                string                   `json:"category"`
-	CategoryOrderNumber     int                      `json:"category_order_number"`
-	ModelOrderNumber        int                      `json:"model_order_number"`
-	Pricing                 domainmodel.Pricing      `json:"pricing"`
-	TokenLimits             *domainmodel.TokenLimits `json:"token_limits,omitempty"`
-	Family                  *string                  `json:"family,omitempty"`
-	SupportsImages          bool                     `json:"supports_images"`
-	SupportsEmbeddings      bool                     `json:"supports_embeddings"`
-	SupportsReasoning       bool                     `json:"supports_reasoning"`
-	SupportsInstruct        bool                     `json:"supports_instruct"`
-	SupportsAudio           bool                     `json:"supports_audio"`
-	SupportsVideo           bool                     `json:"supports_video"`
-	Active                  bool                     `json:"active"`
-	InstructModelPublicID   *string                  `json:"instruct_model_public_id,omitempty"` // Public ID of the instruct model to use when enable_thinking=false
-	CreatedAt               int64                    `json:"created_at"`
-	UpdatedAt               int64                    `json:"updated_at"`
+	ID                      string                       `json:"id"`
+	ProviderID              string                       `json:"provider_id"`
+	ProviderVendor          string                       `json:"provider_vendor"`
+	ModelCatalogID          *string                      `json:"model_catalog_id,omitempty"`
+	ModelPublicID           string                       `json:"model_public_id"`
+	ProviderOriginalModelID string                       `json:"provider_original_model_id"`
+	ModelDisplayName        string                       `json:"model_display_name"`
+	Category                string                       `json:"category"`
+	CategoryOrderNumber     int                          `json:"category_order_number"`
+	ModelOrderNumber        int                          `json:"model_order_number"`
+	Pricing                 domainmodel.Pricing          `json:"pricing"`
+	TokenLimits             *domainmodel.TokenLimits     `json:"token_limits,omitempty"`
+	ReasoningPolicy         *domainmodel.ReasoningPolicy `json:"reasoning_policy,omitempty"`
+	Family                  *string                      `json:"family,omitempty"`
+	SupportsImages          bool                         `json:"supports_images"`
+	SupportsEmbeddings      bool                         `json:"supports_embeddings"`
+	SupportsReasoning       bool                         `json:"supports_reasoning"`
+	SupportsInstruct        bool                         `json:"supports_instruct"`
+	SupportsAudio           bool                         `json:"supports_audio"`
+	SupportsVideo           bool                         `json:"supports_video"`
+	Active                  bool                         `json:"active"`
+	InstructModelPublicID   *string                      `json:"instruct_model_public_id,omitempty"` // Public ID of the instruct model to use when enable_thinking=false
+	CreatedAt               int64                        `json:"created_at"`
+	UpdatedAt               int64                        `json:"updated_at"`
 }
 
 func BuildModelCatalogResponse(catalog *domainmodel.ModelCatalog) ModelCatalogResponse {
@@ -508,6 +511,7 @@ func BuildProviderModelResponse(
 		ModelOrderNumber:        providerModel.ModelOrderNumber,
 		Pricing:                 providerModel.Pricing,
 		TokenLimits:             providerModel.TokenLimits,
+		ReasoningPolicy:         providerModel.ReasoningPolicy,
 		Family:                  family,
 		SupportsImages:          supportsImages,
 		SupportsEmbeddings:      supportsEmbeddings,
@@ -529,3 +533,41 @@ type BulkOperationResponse struct {
 	TotalChecked int      `json:"total_checked,omitempty"`
 	FailedModels []string `json:"failed_models,omitempty"`
 }
+
+// ProviderTestStep is the outcome of one provider check. Skipped steps were not
+// attempted because an earlier step failed.
+type ProviderTestStep struct {
+	Name             string `json:"name"` // auth, completion or stream
+	OK               bool   `json:"ok"`
+	Skipped          bool   `json:"skipped,omitempty"`
+	LatencyMs        int64  `json:"latency_ms"`
+	TimeToFirstToken *int64 `json:"time_to_first_token_ms,omitempty"`
+	Detail           string `json:"detail,omitempty"`
+	Error            string `json:"error,omitempty"`
+}
+
+// ProviderCapabilities combines what the checks observed with the catalog entry of the tested model
+type ProviderCapabilities struct {
+	ModelsListed        int  `json:"models_listed"`
+	Streaming           bool `json:"streaming"`
+	UsageReported       bool `json:"usage_reported"`
+	StreamUsageReported bool `json:"stream_usage_reported"`
+	Cataloged           bool `json:"cataloged"`
+	SupportsTools       bool `json:"supports_tools"`
+	SupportsImages      bool `json:"supports_images"`
+	SupportsReasoning   bool `json:"supports_reasoning"`
+	SupportsAudio       bool `json:"supports_audio"`
+	ContextLength       *int `json:"context_length,omitempty"`
+}
+
+// ProviderTestResponse reports a credential and connectivity check of a provider
+type ProviderTestResponse struct {
+	ProviderID   string               `json:"provider_id"`
+	Name         string               `json:"name"`
+	Vendor       string               `json:"vendor"`
+	BaseURL      string               `json:"base_url"`
+	Model        string               `json:"model,omitempty"`
+	OK           bool                 `json:"ok"`
+	Steps        []ProviderTestStep   `json:"steps"`
+	Capabilities ProviderCapabilities `json:"capabilities"`
+}
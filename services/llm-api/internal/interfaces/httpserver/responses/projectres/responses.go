@@ -6,15 +6,18 @@ import (
 
 // ProjectResponse represents a single project response
 type ProjectResponse struct {
-	ID          string  `json:"id"`
-	Object      string  `json:"object"`
-	Name        string  `json:"name"`
-	Instruction *string `json:"instruction,omitempty"`
-	Favorite    bool    `json:"is_favorite"`
-	IsArchived  bool    `json:"is_archived"`
-	ArchivedAt  *int64  `json:"archived_at,omitempty"`
-	CreatedAt   int64   `json:"created_at"`
-	UpdatedAt   int64   `json:"updated_at"`
+	ID                   string          `json:"id"`
+	Object               string          `json:"object"`
+	Name                 string          `json:"name"`
+	Instruction          *string         `json:"instruction,omitempty"`
+	InstructionVersion   int             `json:"instruction_version"`
+	DefaultTools         map[string]bool `json:"default_tools,omitempty"`
+	KnowledgeCollections []string        `json:"knowledge_collections,omitempty"`
+	Favorite             bool            `json:"is_favorite"`
+	IsArchived           bool            `json:"is_archived"`
+	ArchivedAt           *int64          `json:"archived_at,omitempty"`
+	CreatedAt            int64           `json:"created_at"`
+	UpdatedAt            int64           `json:"updated_at"`
 }
 
 // ProjectListResponse represents a paginated list of projects
@@ -28,6 +31,21 @@ type ProjectListResponse struct {
 	Total   int64             `json:"total"`
 }
 
+// InstructionVersionResponse represents one version of a project's instruction
+type InstructionVersionResponse struct {
+	Object      string  `json:"object"`
+	Version     int     `json:"version"`
+	Instruction *string `json:"instruction,omitempty"`
+	CreatedAt   int64   `json:"created_at"`
+}
+
+// InstructionVersionListResponse represents a project's instruction history
+type InstructionVersionListResponse struct {
+	Object    string                       `json:"object"`
+	ProjectID string                       `json:"project_id"`
+	Data      []InstructionVersionResponse `json:"data"`
+}
+
 // ProjectDeletedResponse represents the delete confirmation response
 type ProjectDeletedResponse struct {
 	ID      string `json:"id"`
@@ -38,14 +56,17 @@ type ProjectDeletedResponse struct {
 // NewProjectResponse creates a response from a domain project
 func NewProjectResponse(proj *project.Project) *ProjectResponse {
 	resp := &ProjectResponse{
-		ID:          proj.PublicID,
-		Object:      "project",
-		Name:        proj.Name,
-		Instruction: proj.Instruction,
-		Favorite:    proj.Favorite,
-		IsArchived:  proj.ArchivedAt != nil,
-		CreatedAt:   proj.CreatedAt.Unix(),
-		UpdatedAt:   proj.UpdatedAt.Unix(),
+		ID:                   proj.PublicID,
+		Object:               "project",
+		Name:                 proj.Name,
+		Instruction:          proj.Instruction,
+		InstructionVersion:   proj.InstructionVersion,
+		DefaultTools:         proj.DefaultTools,
+		KnowledgeCollections: proj.KnowledgeCollections,
+		Favorite:             proj.Favorite,
+		IsArchived:           proj.ArchivedAt != nil,
+		CreatedAt:            proj.CreatedAt.Unix(),
+		UpdatedAt:            proj.UpdatedAt.Unix(),
 	}
 
 	if proj.ArchivedAt != nil {
@@ -79,6 +100,25 @@ func NewProjectListResponse(projects []*project.Project, hasMore bool, nextCurso
 	return resp
 }
 
+// NewInstructionVersionListResponse creates an instruction history response, newest first
+func NewInstructionVersionListResponse(projectID string, versions []*project.InstructionVersion) *InstructionVersionListResponse {
+	data := make([]InstructionVersionResponse, len(versions))
+	for i, v := range versions {
+		data[i] = InstructionVersionResponse{
+			Object:      "project.instruction_version",
+			Version:     v.Version,
+			Instruction: v.Instruction,
+			CreatedAt:   v.CreatedAt.Unix(),
+		}
+	}
+
+	return &InstructionVersionListResponse{
+		Object:    "list",
+		ProjectID: projectID,
+		Data:      data,
+	}
+}
+
 // NewProjectDeletedResponse creates a delete response
 func NewProjectDeletedResponse(publicID string) *ProjectDeletedResponse {
 	return &ProjectDeletedResponse{
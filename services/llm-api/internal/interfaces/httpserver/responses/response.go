@@ -2,7 +2,9 @@ package responses
 
 import (
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"jan-server/services/llm-api/internal/config"
@@ -12,7 +14,8 @@ import (
 )
 
 type ErrorResponse struct {
-	Code          string `json:"code"` // UUID from PlatformError
+	Code          string `json:"code"`           // UUID from PlatformError
+	Type          string `json:"type,omitempty"` // Machine-readable error class, e.g. rate_limited, context_length_exceeded
 	Error         string `json:"error"`
 	Message       string `json:"message,omitempty"`
 	ErrorInstance error  `json:"-"`
@@ -45,12 +48,18 @@ func HandleError(reqCtx *gin.Context, err error, message string) {
 
 		errResp := ErrorResponse{
 			Code:          domainErr.GetUUID(),
+			Type:          strings.ToLower(string(domainErr.GetErrorType())),
 			Error:         message,
 			Message:       responseMessage,
 			ErrorInstance: domainErr,
 			RequestID:     domainErr.GetRequestID(),
 		}
 
+		// Pass the provider's backoff hint on to rate limited clients
+		if retryAfter, ok := platformerrors.ContextValue(err, platformerrors.ContextKeyRetryAfter); ok {
+			reqCtx.Header("Retry-After", fmt.Sprint(retryAfter))
+		}
+
 		reqCtx.AbortWithStatusJSON(statusCode, errResp)
 		return
 	} else {
@@ -71,6 +80,7 @@ func HandleErrorWithStatus(reqCtx *gin.Context, statusCode int, err error, messa
 	if errors.As(err, &domainErr) {
 		errResp := ErrorResponse{
 			Code:          domainErr.GetUUID(),
+			Type:          strings.ToLower(string(domainErr.GetErrorType())),
 			Error:         message,
 			Message:       message,
 			ErrorInstance: domainErr,
@@ -102,6 +112,7 @@ func HandleNewError(reqCtx *gin.Context, errorType platformerrors.ErrorType, mes
 
 	errResp := ErrorResponse{
 		Code:          err.GetUUID(),
+		Type:          strings.ToLower(string(err.GetErrorType())),
 		Error:         message,
 		Message:       message,
 		ErrorInstance: err,
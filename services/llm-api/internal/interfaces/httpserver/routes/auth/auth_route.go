@@ -6,6 +6,7 @@ import (
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/apikeyhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
 	guestauth "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/guesthandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
 )
 
 // AuthRoute handles authentication routes
@@ -16,6 +17,8 @@ type AuthRoute struct {
 	apiKeyHandler        *apikeyhandler.Handler
 	authHandler          *authhandler.AuthHandler
 	keycloakOAuthHandler *authhandler.KeycloakOAuthHandler
+	tokenExchangeHandler *authhandler.TokenExchangeHandler
+	deviceAuthHandler    *authhandler.DeviceAuthHandler
 }
 
 // NewAuthRoute creates a new auth route
@@ -26,6 +29,8 @@ func NewAuthRoute(
 	apiKeyHandler *apikeyhandler.Handler,
 	authHandler *authhandler.AuthHandler,
 	keycloakOAuthHandler *authhandler.KeycloakOAuthHandler,
+	tokenExchangeHandler *authhandler.TokenExchangeHandler,
+	deviceAuthHandler *authhandler.DeviceAuthHandler,
 ) *AuthRoute {
 	return &AuthRoute{
 		guestHandler:         guestHandler,
@@ -34,6 +39,8 @@ func NewAuthRoute(
 		apiKeyHandler:        apiKeyHandler,
 		authHandler:          authHandler,
 		keycloakOAuthHandler: keycloakOAuthHandler,
+		tokenExchangeHandler: tokenExchangeHandler,
+		deviceAuthHandler:    deviceAuthHandler,
 	}
 }
 
@@ -42,8 +49,8 @@ func (a *AuthRoute) RegisterRouter(router gin.IRouter, protectedRouter gin.IRout
 	// Public routes - Guest login
 	router.POST("/auth/guest-login", a.CreateGuestLogin)
 	router.POST("/auth/refresh-token", a.RefreshToken)
-	router.GET("/auth/logout", a.Logout)
-	router.POST("/auth/logout", a.Logout) // Support both GET and POST for logout
+	router.GET("/auth/logout", middlewares.Deprecated(middlewares.LogoutGetDeprecation), a.LogoutGet)
+	router.POST("/auth/logout", a.Logout)
 
 	// Public routes - Keycloak OAuth2/OIDC (simplified)
 	router.GET("/auth/login", a.KeycloakLogin)
@@ -51,6 +58,14 @@ func (a *AuthRoute) RegisterRouter(router gin.IRouter, protectedRouter gin.IRout
 	router.POST("/auth/validate", a.ValidateKeycloakToken)
 	router.POST("/auth/revoke", a.RevokeKeycloakToken)
 
+	// Public routes - Token exchange for third-party integrations (client-authenticated)
+	router.POST("/auth/token-exchange", a.ExchangeToken)
+
+	// Public routes - Device authorization for jan-cli and headless devices
+	router.POST("/auth/device/code", a.StartDeviceLogin)
+	router.POST("/auth/device/token", a.PollDeviceLogin)
+	router.GET("/auth/device", a.ApproveDeviceLogin)
+
 	// API key validation endpoint (for Kong plugin)
 	router.POST("/auth/validate-api-key", a.ValidateAPIKey)
 
@@ -100,11 +115,26 @@ func (a *AuthRoute) RefreshToken(c *gin.Context) {
 // @Success 200 {object} object "Successfully logged out"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid token"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
-// @Router /auth/logout [get]
+// @Router /auth/logout [post]
 func (a *AuthRoute) Logout(c *gin.Context) {
 	a.tokenHandler.Logout(c)
 }
 
+// LogoutGet godoc
+// @Summary Logout user (deprecated)
+// @Description Deprecated alias of `POST /auth/logout`, removed on 2027-04-16. Responses carry `Deprecation`, `Sunset` and `Warning` headers.
+// @Tags Authentication API
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} object "Successfully logged out"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid token"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Deprecated
+// @Router /auth/logout [get]
+func (a *AuthRoute) LogoutGet(c *gin.Context) {
+	a.tokenHandler.Logout(c)
+}
+
 // UpgradeAccount godoc
 // @Summary Upgrade guest to permanent account
 // @Description Converts a guest user account to a permanent account with email/password credentials. Guest flag is removed and user gains full access.
@@ -156,11 +186,14 @@ func (a *AuthRoute) CreateAPIKey(c *gin.Context) {
 
 // ListAPIKeys godoc
 // @Summary List user's API keys
-// @Description Returns all API keys created by the authenticated user. Key values are not returned, only metadata.
+// @Description Returns API keys created by the authenticated user, newest first. Key values are not returned, only metadata.
+// @Description Pass `next_cursor` from the previous page as `after` to fetch the next page.
 // @Tags Authentication API
 // @Security BearerAuth
 // @Accept json
 // @Produce json
+// @Param limit query int false "Page size (1-100, default 20)"
+// @Param after query string false "Opaque cursor from next_cursor"
 // @Success 200 {object} object "List of API keys with metadata"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized - invalid or expired token"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
@@ -277,3 +310,65 @@ func (a *AuthRoute) RevokeKeycloakToken(c *gin.Context) {
 		c.JSON(500, gin.H{"error": "Keycloak OAuth is not configured"})
 	}
 }
+
+// ExchangeToken godoc
+// @Summary Exchange a user token for an integration token
+// @Description OAuth 2.0 token exchange (RFC 8693) for third-party integrations. A Keycloak client listed in INTEGRATION_CLIENTS authenticates with its client credentials (form fields or HTTP Basic) and trades the user's access token for a short-lived token carrying only the requested scopes.
+// @Description Integration tokens only reach the routes their scopes open: `jan:profile.read` (GET /auth/me), `jan:models.read` (GET /v1/models), `jan:chat` (POST /v1/chat/completions and its WebSocket, GET /v1/chat/completions/ws), `jan:conversations.read` (GET /v1/conversations), `jan:conversations.write` (other methods on /v1/conversations) and `jan:projects.read` (GET /v1/projects).
+// @Tags Authentication API
+// @Accept x-www-form-urlencoded
+// @Accept json
+// @Produce json
+// @Param request body authhandler.TokenExchangeRequest true "Token exchange request"
+// @Success 200 {object} authhandler.TokenExchangeResponse "Scoped access token"
+// @Failure 400 {object} authhandler.OAuthErrorResponse "Invalid request, grant, or scope"
+// @Failure 401 {object} authhandler.OAuthErrorResponse "Client is not a registered integration"
+// @Failure 502 {object} authhandler.OAuthErrorResponse "Keycloak token exchange failed"
+// @Router /auth/token-exchange [post]
+func (a *AuthRoute) ExchangeToken(c *gin.Context) {
+	a.tokenExchangeHandler.ExchangeToken(c)
+}
+
+// StartDeviceLogin godoc
+// @Summary Start a device login
+// @Description Starts an OAuth 2.0 device authorization grant (RFC 8628) for jan-cli and devices without a browser. Show the user `user_code` and `verification_uri` (or `verification_uri_complete`), then poll `POST /auth/device/token` every `interval` seconds.
+// @Description Request `offline_access` in `scope` for a refresh token that outlives the browser session.
+// @Tags Authentication API
+// @Accept x-www-form-urlencoded
+// @Accept json
+// @Produce json
+// @Param request body authhandler.DeviceCodeRequest false "Device login request"
+// @Success 200 {object} authhandler.DeviceCodeResponse "Device and user codes"
+// @Failure 400 {object} authhandler.OAuthErrorResponse "Invalid request or scope"
+// @Failure 502 {object} authhandler.OAuthErrorResponse "Keycloak device authorization failed"
+// @Router /auth/device/code [post]
+func (a *AuthRoute) StartDeviceLogin(c *gin.Context) {
+	a.deviceAuthHandler.StartDeviceLogin(c)
+}
+
+// PollDeviceLogin godoc
+// @Summary Poll a device login
+// @Description Redeems the device code once the user approved the login. Until then it returns 400 with `authorization_pending`; `slow_down` asks to poll 5 seconds less often, and `expired_token` or `access_denied` end the login.
+// @Tags Authentication API
+// @Accept x-www-form-urlencoded
+// @Accept json
+// @Produce json
+// @Param request body authhandler.DeviceTokenRequest true "Device code"
+// @Success 200 {object} authhandler.AccessTokenResponse "Access and refresh tokens"
+// @Failure 400 {object} authhandler.OAuthErrorResponse "Login pending, denied or expired"
+// @Failure 502 {object} authhandler.OAuthErrorResponse "Keycloak token request failed"
+// @Router /auth/device/token [post]
+func (a *AuthRoute) PollDeviceLogin(c *gin.Context) {
+	a.deviceAuthHandler.PollDeviceLogin(c)
+}
+
+// ApproveDeviceLogin godoc
+// @Summary Approve a device login
+// @Description Page where users approve a device login. Redirects to Keycloak's device page, which signs the user in and asks them to confirm the code.
+// @Tags Authentication API
+// @Param user_code query string false "Code shown on the device"
+// @Success 302 "Redirect to the Keycloak device page"
+// @Router /auth/device [get]
+func (a *AuthRoute) ApproveDeviceLogin(c *gin.Context) {
+	a.deviceAuthHandler.ApproveDeviceLogin(c)
+}
@@ -0,0 +1,52 @@
+package public
+
+import (
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/webhookhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+
+	"github.com/gin-gonic/gin"
+)
+
+// WebhookRoute handles routing for provider webhooks (signed, no auth token)
+type WebhookRoute struct {
+	handler *webhookhandler.WebhookHandler
+}
+
+// NewWebhookRoute creates a new webhook route handler
+func NewWebhookRoute(handler *webhookhandler.WebhookHandler) *WebhookRoute {
+	return &WebhookRoute{
+		handler: handler,
+	}
+}
+
+// RegisterRouter registers webhook routes
+// Deliveries authenticate with their source's signature instead of a token
+func (route *WebhookRoute) RegisterRouter(router gin.IRouter) {
+	webhooks := router.Group("/webhooks")
+
+	// Apply rate limiting middleware (300 req/min per IP)
+	webhooks.Use(middlewares.RateLimitMiddleware(300))
+
+	webhooks.POST("/:source", route.receiveWebhook)
+}
+
+// receiveWebhook godoc
+// @Summary Receive a provider webhook
+// @Description Receives a signed webhook and publishes it on llm-api's event bus as `{source}.{type}` (e.g. `livekit.egress_ended`), where subscribers react to it.
+// @Description Each source is verified with its own scheme and accepted only once its secret is configured:
+// @Description - `openai`, `openrouter`: Standard Webhooks headers (`webhook-id`, `webhook-timestamp`, `webhook-signature`) signed with `OPENAI_WEBHOOK_SECRET` / `OPENROUTER_WEBHOOK_SECRET`
+// @Description - `livekit`: `Authorization` JWT signed with `LIVEKIT_API_SECRET`, carrying the body's SHA-256
+// @Description Redeliveries of an already received delivery ID are acknowledged with `duplicate: true` and not published again.
+// @Tags Webhooks API
+// @Accept json
+// @Produce json
+// @Param source path string true "Webhook source" Enums(openai, openrouter, livekit)
+// @Success 200 {object} webhookhandler.WebhookResponse "Delivery accepted"
+// @Failure 400 {object} responses.ErrorResponse "Malformed event"
+// @Failure 401 {object} responses.ErrorResponse "Missing, invalid or expired signature"
+// @Failure 404 {object} responses.ErrorResponse "Unknown or unconfigured source"
+// @Failure 413 {object} responses.ErrorResponse "Body larger than 1 MiB"
+// @Router /v1/webhooks/{source} [post]
+func (route *WebhookRoute) receiveWebhook(reqCtx *gin.Context) {
+	route.handler.Receive(reqCtx)
+}
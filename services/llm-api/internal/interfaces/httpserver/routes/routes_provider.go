@@ -3,35 +3,56 @@ package routes
 import (
 	"github.com/google/wire"
 
+	"jan-server/services/llm-api/internal/domain/coldstorage"
+	"jan-server/services/llm-api/internal/domain/eval"
+	"jan-server/services/llm-api/internal/domain/finetune"
+	"jan-server/services/llm-api/internal/domain/memorybackfill"
 	"jan-server/services/llm-api/internal/domain/usersettings"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers"
 	adminhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/admin"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/analyticshandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/apikeyhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/chathandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/comparehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/conversationhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/evalhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/finetunehandler"
 	guestauth "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/guesthandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/imagehandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/mcpcredentialhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/mcptoolhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/memorybackfillhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelaliashandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelprompthandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/personahandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/projecthandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/promptlibraryhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/prompttemplatehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/sharehandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/synchandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/usersettingshandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/webhookhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/auth"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/public"
 	v1 "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin"
 	adminModel "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin/model"
 	adminProvider "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin/provider"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/analytics"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/chat"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/compare"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/conversation"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/image"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/llm/projects"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/mcpcredentials"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/model"
 	modelProvider "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/model/provider"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/personas"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/promptlibrary"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/share"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/sync"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/users"
 )
 
@@ -39,6 +60,8 @@ var RouteProvider = wire.NewSet(
 	// Handlers
 	authhandler.NewAuthHandler,
 	authhandler.NewTokenHandler,
+	authhandler.NewTokenExchangeHandler,
+	authhandler.NewDeviceAuthHandler,
 	authhandler.ProvideKeycloakOAuthHandler,
 	apikeyhandler.NewHandler,
 	handlers.ProvideMemoryHandler,
@@ -61,10 +84,37 @@ var RouteProvider = wire.NewSet(
 	sharehandler.NewShareHandler,
 	mcptoolhandler.NewMCPToolHandler,
 	imagehandler.NewImageHandler,
+	analyticshandler.NewAnalyticsHandler,
+	comparehandler.NewCompareHandler,
+	evalhandler.NewEvalHandler,
+	evalhandler.NewModelCompleter,
+	finetunehandler.NewFinetuneHandler,
+	finetunehandler.NewMediaUploader,
+	memorybackfillhandler.NewMemoryBackfillHandler,
+	memorybackfillhandler.NewMemoryObserver,
+	conversationhandler.NewMediaColdStore,
+	promptlibraryhandler.NewPromptLibraryHandler,
+	modelaliashandler.NewModelAliasHandler,
+	personahandler.NewPersonaHandler,
+	synchandler.NewSyncHandler,
+	mcpcredentialhandler.NewMCPCredentialHandler,
+	webhookhandler.NewWebhookHandler,
 
 	// Bind ModelHandler to ModelProvider interface for usersettings
 	wire.Bind(new(usersettings.ModelProvider), new(*modelhandler.ModelHandler)),
 
+	// Bind ModelCompleter to Completer interface for evals
+	wire.Bind(new(eval.Completer), new(*evalhandler.ModelCompleter)),
+
+	// Bind MediaUploader to Uploader interface for fine-tuning exports
+	wire.Bind(new(finetune.Uploader), new(*finetunehandler.MediaUploader)),
+
+	// Bind MemoryObserver to Observer interface for memory backfills
+	wire.Bind(new(memorybackfill.Observer), new(*memorybackfillhandler.MemoryObserver)),
+
+	// Bind MediaColdStore to Store interface for conversation cold storage
+	wire.Bind(new(coldstorage.Store), new(*conversationhandler.MediaColdStore)),
+
 	// Routes
 	auth.NewAuthRoute,
 	v1.NewV1Route,
@@ -81,5 +131,12 @@ var RouteProvider = wire.NewSet(
 	users.NewUsersRoute,
 	share.NewShareRoute,
 	public.NewPublicShareRoute,
+	public.NewWebhookRoute,
 	image.NewImageRoute,
+	analytics.NewAnalyticsRoute,
+	compare.NewCompareRoute,
+	promptlibrary.NewPromptLibraryRoute,
+	personas.NewPersonaRoute,
+	sync.NewSyncRoute,
+	mcpcredentials.NewMCPCredentialRoute,
 )
@@ -2,7 +2,13 @@ package admin
 
 import (
 	adminhandler "jan-server/services/llm-api/internal/interfaces/httpserver/handlers/admin"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/comparehandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/evalhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/finetunehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/mcptoolhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/memorybackfillhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/modelaliashandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/promptlibraryhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/prompttemplatehandler"
 	middleware "jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
 	adminmodel "jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin/model"
@@ -18,8 +24,17 @@ type AdminRoute struct {
 	userHandler             *adminhandler.AdminUserHandler
 	groupHandler            *adminhandler.AdminGroupHandler
 	featureFlagHandler      *adminhandler.FeatureFlagHandler
+	inspectionHandler       *adminhandler.AdminInspectionHandler
+	reconcileHandler        *adminhandler.AdminReconcileHandler
+	maintenanceHandler      *adminhandler.AdminMaintenanceHandler
 	promptTemplateHandler   *prompttemplatehandler.PromptTemplateHandler
 	mcpToolHandler          *mcptoolhandler.MCPToolHandler
+	compareHandler          *comparehandler.CompareHandler
+	evalHandler             *evalhandler.EvalHandler
+	finetuneHandler         *finetunehandler.FinetuneHandler
+	memoryBackfillHandler   *memorybackfillhandler.MemoryBackfillHandler
+	promptLibraryHandler    *promptlibraryhandler.PromptLibraryHandler
+	modelAliasHandler       *modelaliashandler.ModelAliasHandler
 }
 
 // NewAdminRoute creates a new AdminRoute
@@ -29,8 +44,17 @@ func NewAdminRoute(
 	userHandler *adminhandler.AdminUserHandler,
 	groupHandler *adminhandler.AdminGroupHandler,
 	featureFlagHandler *adminhandler.FeatureFlagHandler,
+	inspectionHandler *adminhandler.AdminInspectionHandler,
+	reconcileHandler *adminhandler.AdminReconcileHandler,
+	maintenanceHandler *adminhandler.AdminMaintenanceHandler,
 	promptTemplateHandler *prompttemplatehandler.PromptTemplateHandler,
 	mcpToolHandler *mcptoolhandler.MCPToolHandler,
+	compareHandler *comparehandler.CompareHandler,
+	evalHandler *evalhandler.EvalHandler,
+	finetuneHandler *finetunehandler.FinetuneHandler,
+	memoryBackfillHandler *memorybackfillhandler.MemoryBackfillHandler,
+	promptLibraryHandler *promptlibraryhandler.PromptLibraryHandler,
+	modelAliasHandler *modelaliashandler.ModelAliasHandler,
 ) *AdminRoute {
 	return &AdminRoute{
 		adminModelRoute:         adminModelRoute,
@@ -38,8 +62,17 @@ func NewAdminRoute(
 		userHandler:             userHandler,
 		groupHandler:            groupHandler,
 		featureFlagHandler:      featureFlagHandler,
+		inspectionHandler:       inspectionHandler,
+		reconcileHandler:        reconcileHandler,
+		maintenanceHandler:      maintenanceHandler,
 		promptTemplateHandler:   promptTemplateHandler,
 		mcpToolHandler:          mcpToolHandler,
+		compareHandler:          compareHandler,
+		evalHandler:             evalHandler,
+		finetuneHandler:         finetuneHandler,
+		memoryBackfillHandler:   memoryBackfillHandler,
+		promptLibraryHandler:    promptLibraryHandler,
+		modelAliasHandler:       modelAliasHandler,
 	}
 }
 
@@ -82,6 +115,18 @@ func (r *AdminRoute) RegisterRouter(router gin.IRouter) {
 		adminGroup.PATCH("/feature-flags/:id", r.featureFlagHandler.UpdateFeatureFlag)
 		adminGroup.DELETE("/feature-flags/:id", r.featureFlagHandler.DeleteFeatureFlag)
 
+		// Read-only operational views
+		adminGroup.GET("/conversations", r.inspectionHandler.ListConversations)
+		adminGroup.GET("/usage", r.inspectionHandler.GetUsage)
+
+		// Repair of conversation items stuck in progress
+		adminGroup.POST("/conversations/:conv_public_id/reconcile", r.reconcileHandler.ReconcileConversation)
+
+		// Maintenance mode, which turns away requests that change data
+		adminGroup.GET("/maintenance", r.maintenanceHandler.GetMaintenance)
+		adminGroup.PUT("/maintenance", r.maintenanceHandler.EnableMaintenance)
+		adminGroup.DELETE("/maintenance", r.maintenanceHandler.DisableMaintenance)
+
 		// Prompt template management
 		adminGroup.GET("/prompt-templates", r.promptTemplateHandler.List)
 		adminGroup.POST("/prompt-templates", r.promptTemplateHandler.Create)
@@ -94,5 +139,46 @@ func (r *AdminRoute) RegisterRouter(router gin.IRouter) {
 		adminGroup.GET("/mcp-tools", r.mcpToolHandler.List)
 		adminGroup.GET("/mcp-tools/:id", r.mcpToolHandler.Get)
 		adminGroup.PATCH("/mcp-tools/:id", r.mcpToolHandler.Update)
+
+		// Model comparison stats
+		adminGroup.GET("/compare/leaderboard", r.compareHandler.GetLeaderboard)
+
+		// Evals
+		adminGroup.POST("/evals/datasets", r.evalHandler.CreateDataset)
+		adminGroup.GET("/evals/datasets", r.evalHandler.ListDatasets)
+		adminGroup.GET("/evals/datasets/:dataset_id", r.evalHandler.GetDataset)
+		adminGroup.DELETE("/evals/datasets/:dataset_id", r.evalHandler.DeleteDataset)
+		adminGroup.POST("/evals/runs", r.evalHandler.CreateRun)
+		adminGroup.GET("/evals/runs", r.evalHandler.ListRuns)
+		adminGroup.GET("/evals/runs/:run_id", r.evalHandler.GetRun)
+		adminGroup.GET("/evals/runs/:run_id/results", r.evalHandler.GetRunResults)
+		adminGroup.GET("/evals/runs/:run_id/compare", r.evalHandler.CompareRuns)
+
+		// Fine-tuning dataset exports
+		adminGroup.POST("/finetune/exports", r.finetuneHandler.CreateExport)
+		adminGroup.GET("/finetune/exports", r.finetuneHandler.ListExports)
+		adminGroup.GET("/finetune/exports/:export_id", r.finetuneHandler.GetExport)
+
+		// Conversation-to-memory backfills
+		adminGroup.POST("/memory/backfills", r.memoryBackfillHandler.CreateBackfill)
+		adminGroup.GET("/memory/backfills", r.memoryBackfillHandler.ListBackfills)
+		adminGroup.GET("/memory/backfills/:backfill_id", r.memoryBackfillHandler.GetBackfill)
+		adminGroup.POST("/memory/backfills/:backfill_id/cancel", r.memoryBackfillHandler.CancelBackfill)
+		adminGroup.POST("/memory/backfills/:backfill_id/resume", r.memoryBackfillHandler.ResumeBackfill)
+
+		// Global conversation starters
+		adminGroup.POST("/prompt-library", r.promptLibraryHandler.AdminCreate)
+		adminGroup.GET("/prompt-library", r.promptLibraryHandler.AdminList)
+		adminGroup.GET("/prompt-library/:starter_id", r.promptLibraryHandler.AdminGet)
+		adminGroup.PATCH("/prompt-library/:starter_id", r.promptLibraryHandler.AdminUpdate)
+		adminGroup.DELETE("/prompt-library/:starter_id", r.promptLibraryHandler.AdminDelete)
+
+		// Model aliases
+		adminGroup.GET("/model-aliases", r.modelAliasHandler.List)
+		adminGroup.POST("/model-aliases", r.modelAliasHandler.Create)
+		adminGroup.GET("/model-aliases/:name", r.modelAliasHandler.Get)
+		adminGroup.PATCH("/model-aliases/:name", r.modelAliasHandler.Update)
+		adminGroup.DELETE("/model-aliases/:name", r.modelAliasHandler.Delete)
+		adminGroup.POST("/model-aliases/:name/promote", r.modelAliasHandler.Promote)
 	}
 }
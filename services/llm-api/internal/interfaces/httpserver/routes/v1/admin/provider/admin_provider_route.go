@@ -33,6 +33,7 @@ func (AdminProviderRoute *AdminProviderRoute) RegisterRouter(router *gin.RouterG
 	providerRoute.GET("/:provider_public_id", AdminProviderRoute.GetProvider)
 	providerRoute.PATCH("/:provider_public_id", AdminProviderRoute.UpdateProvider)
 	providerRoute.DELETE("/:provider_public_id", AdminProviderRoute.DeleteProvider)
+	providerRoute.POST("/:provider_public_id/test", AdminProviderRoute.TestProvider)
 
 }
 
@@ -150,6 +151,41 @@ func (route *AdminProviderRoute) UpdateProvider(reqCtx *gin.Context) {
 	reqCtx.JSON(http.StatusOK, providerResponse)
 }
 
+// TestProvider
+// @Summary Test a provider's credentials
+// @Description Validates the provider's API key by listing its models, then sends a minimal completion and a streaming completion to one of its models. Each step reports its latency and error; the response is 200 even when a step fails, check `ok`. Uses the requested model, else an active model of the provider, else the first model the provider lists.
+// @Tags Admin Provider API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param provider_public_id path string true "Provider public ID"
+// @Param payload body requestmodels.TestProviderRequest false "Optional model to test"
+// @Success 200 {object} modelresponses.ProviderTestResponse "Per-step results and detected capabilities"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request payload"
+// @Failure 404 {object} responses.ErrorResponse "Provider not found"
+// @Failure 500 {object} responses.ErrorResponse "Failed to test provider"
+// @Router /v1/admin/providers/{provider_public_id}/test [post]
+func (route *AdminProviderRoute) TestProvider(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+	publicID := reqCtx.Param("provider_public_id")
+
+	var request requestmodels.TestProviderRequest
+	if reqCtx.Request.ContentLength > 0 {
+		if err := reqCtx.ShouldBindJSON(&request); err != nil {
+			responses.HandleError(reqCtx, err, "Invalid request body")
+			return
+		}
+	}
+
+	result, err := route.providerHandler.TestProvider(ctx, publicID, request.Model)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to test provider")
+		return
+	}
+
+	reqCtx.JSON(http.StatusOK, result)
+}
+
 // DeleteProvider
 // @Summary Delete a provider
 // @Description Deletes a provider by its public ID along with its provider models
@@ -0,0 +1,36 @@
+package analytics
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/analyticshandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+)
+
+// AnalyticsRoute handles /v1/analytics routes
+type AnalyticsRoute struct {
+	handler     *analyticshandler.AnalyticsHandler
+	authHandler *authhandler.AuthHandler
+}
+
+// NewAnalyticsRoute creates a new analytics route
+func NewAnalyticsRoute(
+	handler *analyticshandler.AnalyticsHandler,
+	authHandler *authhandler.AuthHandler,
+) *AnalyticsRoute {
+	return &AnalyticsRoute{
+		handler:     handler,
+		authHandler: authHandler,
+	}
+}
+
+// RegisterRouter registers the authenticated user's analytics endpoints
+func (r *AnalyticsRoute) RegisterRouter(router gin.IRouter) {
+	analyticsGroup := router.Group("/analytics")
+	{
+		analyticsGroup.GET("/messages", r.authHandler.WithAppUserAuthChain(r.handler.GetMessagesPerDay)...)
+		analyticsGroup.GET("/models", r.authHandler.WithAppUserAuthChain(r.handler.GetTopModels)...)
+		analyticsGroup.GET("/tools", r.authHandler.WithAppUserAuthChain(r.handler.GetToolUsage)...)
+		analyticsGroup.GET("/latency", r.authHandler.WithAppUserAuthChain(r.handler.GetLatency)...)
+	}
+}
@@ -1,12 +1,20 @@
 package chat
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
+	"strings"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
 
+	"jan-server/services/llm-api/internal/config"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/chathandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
 	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
 	chatresponses "jan-server/services/llm-api/internal/interfaces/httpserver/responses/chat"
@@ -17,22 +25,45 @@ import (
 type ChatCompletionRoute struct {
 	chatHandler *chathandler.ChatHandler
 	authHandler *authhandler.AuthHandler
+	cfg         *config.Config
+	maintenance *maintenance.Service
 }
 
 func NewChatCompletionRoute(
 	chatHandler *chathandler.ChatHandler,
 	authHandler *authhandler.AuthHandler,
+	cfg *config.Config,
+	maintenanceService *maintenance.Service,
 ) *ChatCompletionRoute {
 	return &ChatCompletionRoute{
 		chatHandler: chatHandler,
 		authHandler: authHandler,
+		cfg:         cfg,
+		maintenance: maintenanceService,
 	}
 }
 
 func (chatCompletionRoute *ChatCompletionRoute) RegisterRouter(router *gin.RouterGroup) {
+	// Cap the body before authentication so oversized payloads are never buffered
 	router.POST("/completions",
+		append(
+			[]gin.HandlerFunc{middlewares.BodyLimitMiddleware(chatCompletionRoute.cfg.ChatMaxRequestBytes)},
+			chatCompletionRoute.authHandler.WithAppUserAuthChain(
+				chatCompletionRoute.PostCompletion,
+			)...,
+		)...,
+	)
+	// The same completions streamed over a WebSocket, which also carries cancellation.
+	// No BodyLimitMiddleware: a handshake has no body, and each message is capped
+	// at ChatMaxRequestBytes by the connection's read limit instead
+	router.GET("/completions/ws",
 		chatCompletionRoute.authHandler.WithAppUserAuthChain(
-			chatCompletionRoute.PostCompletion,
+			chatCompletionRoute.StreamCompletionWS,
+		)...,
+	)
+	router.POST("/completions/:stream_id/cancel",
+		chatCompletionRoute.authHandler.WithAppUserAuthChain(
+			chatCompletionRoute.CancelStream,
 		)...,
 	)
 }
@@ -52,7 +83,8 @@ func (chatCompletionRoute *ChatCompletionRoute) RegisterRouter(router *gin.Route
 // @Description
 // @Description **Storage Options:**
 // @Description - `store=true`: Persist the latest input message and assistant response to the active conversation
-// @Description - `store_reasoning=true`: Additionally persist reasoning content provided by the model
+// @Description - `store_reasoning=true`: Additionally persist reasoning content provided by the model (as a `reasoning` item when `reasoning.summary` is set)
+// @Description - `reasoning.effort` (low, medium, high) is mapped to the provider's reasoning knob; when `reasoning` is set without `summary`, reasoning is not streamed, returned or stored
 // @Description - When `store` is omitted or false, the conversation remains read-only
 // @Description
 // @Description **Features:**
@@ -66,11 +98,18 @@ func (chatCompletionRoute *ChatCompletionRoute) RegisterRouter(router *gin.Route
 // @Produce json
 // @Produce text/event-stream
 // @Param request body chatrequests.ChatCompletionRequest true "Chat completion request with streaming options and optional conversation"
+// @Param X-Captcha-Token header string false "Token of a solved CAPTCHA, sent by guests after a captcha_required error"
 // @Success 200 {object} chatresponses.ChatCompletionResponse "Successful non-streaming response (when stream=false)"
 // @Success 200 {string} string "Successful streaming response (when stream=true) - SSE format with data: {json} events"
-// @Failure 400 {object} responses.ErrorResponse "Invalid request payload, empty messages, or inference failure"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request payload, empty messages, or context length exceeded (type context_length_exceeded)"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 403 {object} responses.ErrorResponse "Model not available to guests, or the guest must solve a CAPTCHA and resend with X-Captcha-Token (type captcha_required)"
+// @Failure 413 {object} responses.ErrorResponse "Request body, message count, or an inline image exceeds the configured limits"
+// @Failure 422 {object} responses.ErrorResponse "Blocked by the model provider's content filter (type content_filtered)"
+// @Failure 429 {object} responses.ErrorResponse "Model provider rate limit, the model is at its concurrency cap, or a guest daily message limit was reached (type rate_limited); honour the Retry-After header"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Failure 502 {object} responses.ErrorResponse "Model provider failed; the message carries the provider error"
+// @Failure 504 {object} responses.ErrorResponse "Model provider timed out (type provider_timeout)"
 // @Router /v1/chat/completions [post]
 func (chatCompletionRoute *ChatCompletionRoute) PostCompletion(reqCtx *gin.Context) {
 	// Get authenticated user ID
@@ -82,12 +121,25 @@ func (chatCompletionRoute *ChatCompletionRoute) PostCompletion(reqCtx *gin.Conte
 
 	var request chatrequests.ChatCompletionRequest
 	if err := reqCtx.ShouldBindJSON(&request); err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			responses.HandleNewError(reqCtx, platformerrors.ErrorTypePayloadTooLarge, fmt.Sprintf("request body exceeds the limit of %d bytes", maxBytesErr.Limit), "3e9b7c15-8a2d-4f60-b4c1-6d0e2a9f7b38")
+			return
+		}
 		responses.HandleError(reqCtx, err, "Invalid request body")
 		return
 	}
 
+	if reason := request.ExceededLimit(chatCompletionRoute.cfg.ChatMaxMessages, chatCompletionRoute.cfg.ChatMaxImageBytes); reason != "" {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypePayloadTooLarge, reason, "a4c8e2f6-1d3b-4e97-8f5a-2b6d0c9e4a71")
+		return
+	}
+
+	// The handler may swap reqCtx.Request for a cancellable stream context, so keep the client's own
+	requestCtx := reqCtx.Request.Context()
+
 	// Delegate to chat handler
-	result, err := chatCompletionRoute.chatHandler.CreateChatCompletion(reqCtx.Request.Context(), reqCtx, user.ID, request)
+	result, err := chatCompletionRoute.chatHandler.CreateChatCompletion(requestCtx, reqCtx, user.ID, request)
 	if err != nil {
 		// Check if it's a validation error (user input too large)
 		if platformerrors.IsValidationError(err) {
@@ -103,16 +155,39 @@ func (chatCompletionRoute *ChatCompletionRoute) PostCompletion(reqCtx *gin.Conte
 
 		// Check if it's an internal/server error that's NOT from LLM - return proper error
 		if platformerrors.IsErrorType(err, platformerrors.ErrorTypeForbidden) ||
+			platformerrors.IsErrorType(err, platformerrors.ErrorTypeCaptchaRequired) ||
 			platformerrors.IsErrorType(err, platformerrors.ErrorTypeUnauthorized) ||
 			platformerrors.IsErrorType(err, platformerrors.ErrorTypeConflict) {
 			responses.HandleError(reqCtx, err, err.Error())
 			return
 		}
 
-		// Only for LLM/model communication errors, return fallback response
-		fallback := chatCompletionRoute.chatHandler.BuildFallbackResponse(request.Model)
-		chatResponse := chatresponses.NewChatCompletionResponse(fallback, "", nil, false)
-		reqCtx.JSON(http.StatusOK, chatResponse)
+		// Turned away by admission control or the guest tier limits before reaching a provider
+		if platformerrors.IsErrorType(err, platformerrors.ErrorTypeRateLimited) && !reqCtx.Writer.Written() {
+			responses.HandleError(reqCtx, err, "too many requests")
+			return
+		}
+
+		// The client disconnected mid-stream; nothing left to write
+		if requestCtx.Err() != nil {
+			return
+		}
+
+		// The stream already sent its 200 headers: report the failure as an SSE error event
+		if request.Stream && reqCtx.Writer.Written() {
+			writeStreamError(reqCtx, err)
+			return
+		}
+
+		if chatCompletionRoute.chatHandler.UsesFallbackText() {
+			fallback := chatCompletionRoute.chatHandler.BuildFallbackResponse(request.Model)
+			chatResponse := chatresponses.NewChatCompletionResponse(fallback, "", nil, false)
+			reqCtx.JSON(http.StatusOK, chatResponse)
+			return
+		}
+
+		// Model provider failures map to 502 with the provider error in the message
+		responses.HandleError(reqCtx, err, "chat completion failed")
 		return
 	}
 
@@ -124,3 +199,67 @@ func (chatCompletionRoute *ChatCompletionRoute) PostCompletion(reqCtx *gin.Conte
 	}
 
 }
+
+// CancelStream
+// @Summary Stop a streaming chat completion
+// @Description Stops a conversation-bound streaming completion started by the authenticated user, so UIs can implement a server-side Stop button. The stream ID is returned in the `X-Stream-ID` response header of `POST /v1/chat/completions` when `stream=true` and a conversation is set.
+// @Description
+// @Description The upstream provider request is aborted, the stream ends with `data: [DONE]`, and the conversation's `mcp_call` items that are still `in_progress` are marked `cancelled`. Tokens generated before the stop are still counted in usage.
+// @Description
+// @Description When the user talks over a spoken reply (`modalities: ["audio"]`), send `audio_end_ms` with how much audio was played: speech synthesis stops and the reply is stored truncated to what the user heard, as with `POST /v1/conversations/{conv_public_id}/items/{item_id}/truncate`.
+// @Tags Chat Completions API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param stream_id path string true "Stream ID from the X-Stream-ID response header"
+// @Param request body chatrequests.CancelStreamRequest false "Optional interruption point of the reply's audio"
+// @Success 200 {object} chatresponses.StreamCancelResponse "Stream stopped"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Stream not found, not owned by the user, or already finished"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/chat/completions/{stream_id}/cancel [post]
+func (chatCompletionRoute *ChatCompletionRoute) CancelStream(reqCtx *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "3c8a5e71-4d2b-4a9f-b6e0-7f1d2c9a8e53")
+		return
+	}
+
+	// The body is optional: a plain stop sends none
+	var request chatrequests.CancelStreamRequest
+	if err := reqCtx.ShouldBindJSON(&request); err != nil && !errors.Is(err, io.EOF) {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeValidation, "invalid request body: audio_end_ms must be a non-negative integer", "8e4b1d7a-5c92-4f36-b0a8-2d6e9f3c1a57")
+		return
+	}
+
+	result, err := chatCompletionRoute.chatHandler.CancelStream(reqCtx.Request.Context(), user.ID, reqCtx.Param("stream_id"), request.AudioEndMs)
+	if err != nil {
+		responses.HandleError(reqCtx, err, err.Error())
+		return
+	}
+
+	reqCtx.JSON(http.StatusOK, chatresponses.NewStreamCancelResponse(result.StreamID, result.ConversationID, result.CancelledMCPCalls))
+}
+
+// writeStreamError ends an SSE stream with an error event carrying the same body as a JSON error response.
+func writeStreamError(reqCtx *gin.Context, err error) {
+	payload, marshalErr := json.Marshal(gin.H{"error": streamErrorResponse(err)})
+	if marshalErr != nil {
+		return
+	}
+	_, _ = reqCtx.Writer.Write([]byte("data: " + string(payload) + "\n\n"))
+	reqCtx.Writer.Flush()
+}
+
+// streamErrorResponse is the body of an error reported once a stream has started.
+func streamErrorResponse(err error) responses.ErrorResponse {
+	errResp := responses.ErrorResponse{Error: "chat completion failed", Message: err.Error()}
+	var platformErr *platformerrors.PlatformError
+	if errors.As(err, &platformErr) {
+		errResp.Code = platformErr.GetUUID()
+		errResp.Type = strings.ToLower(string(platformErr.GetErrorType()))
+		errResp.Message = platformErr.Message
+		errResp.RequestID = platformErr.GetRequestID()
+	}
+	return errResp
+}
@@ -0,0 +1,461 @@
+package chat
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/chathandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+	chatrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/chat"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
+	chatresponses "jan-server/services/llm-api/internal/interfaces/httpserver/responses/chat"
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// Message types of the WebSocket transport. Completion chunks are sent as they
+// are in the SSE stream, without a type.
+const (
+	wsMessageCancel  = "cancel"         // client: stop the running completion
+	wsMessageStarted = "stream.started" // server: the completion started streaming
+	wsMessageDone    = "stream.done"    // server: the completion finished or was stopped
+	wsMessageError   = "error"          // server: the completion or a message failed
+)
+
+const (
+	wsWriteTimeout = 10 * time.Second
+	wsPingInterval = 30 * time.Second
+	wsPongTimeout  = 75 * time.Second // Connections silent for longer are dropped
+)
+
+var wsUpgrader = websocket.Upgrader{
+	ReadBufferSize:  4096,
+	WriteBufferSize: 4096,
+	// Connections authenticate with a bearer token, not cookies, so a
+	// cross-origin page gains nothing it could not do with fetch
+	CheckOrigin: func(*http.Request) bool { return true },
+}
+
+// wsClientMessage is a message from the client: a cancel, or a chat completion
+// request when it has no type.
+type wsClientMessage struct {
+	Type       string `json:"type"`
+	AudioEndMs *int   `json:"audio_end_ms,omitempty"` // With cancel: audio played before the user interrupted
+}
+
+// wsStreamMessage announces the start or end of a completion.
+type wsStreamMessage struct {
+	Type      string `json:"type"`
+	StreamID  string `json:"stream_id,omitempty"` // Conversation-bound completions only
+	ItemID    string `json:"item_id,omitempty"`   // Conversation-bound completions only
+	Cancelled bool   `json:"cancelled,omitempty"`
+}
+
+// wsErrorMessage carries the body and status of the equivalent HTTP error.
+type wsErrorMessage struct {
+	Type       string                  `json:"type"`
+	Status     int                     `json:"status"`
+	RetryAfter any                     `json:"retry_after,omitempty"`
+	Error      responses.ErrorResponse `json:"error"`
+}
+
+// StreamCompletionWS
+// @Summary Stream chat completions over a WebSocket
+// @Description Upgrades to a WebSocket on which chat completions are requested and streamed, for clients on networks where a dropped SSE connection is costly. The connection stays open across completions, one at a time.
+// @Description
+// @Description **Client messages** (JSON text):
+// @Description - A `ChatCompletionRequest`, as sent to `POST /v1/chat/completions`, starts a completion; `stream` is implied
+// @Description - `{"type": "cancel"}` stops the running completion; add `audio_end_ms` when the user talked over its audio, as with the cancel endpoint
+// @Description
+// @Description **Server messages** (JSON text):
+// @Description - `{"type": "stream.started"}`, with `stream_id` and `item_id` for conversation-bound completions
+// @Description - Completion chunks, exactly as the `data:` events of the SSE stream
+// @Description - `{"type": "stream.done"}` ends a completion, with `cancelled: true` when it was stopped
+// @Description - `{"type": "error", "status": 429, "retry_after": 5, "error": {...}}` ends a completion that failed, or rejects a message; `status` and `error` are those of the HTTP endpoint
+// @Description
+// @Description Closing the connection aborts the running completion like a dropped SSE stream; what was generated is stored for conversation-bound completions. The server pings every 30s and drops connections silent for 75s. Messages are capped by CHAT_MAX_REQUEST_BYTES; a larger one closes the connection with code 1009. While maintenance mode is on, requests on open connections are answered with a 503 `maintenance` error.
+// @Tags Chat Completions API
+// @Security BearerAuth
+// @Success 101 {string} string "Switching Protocols"
+// @Failure 400 {object} responses.ErrorResponse "Not a WebSocket handshake"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 503 {object} responses.ErrorResponse "Maintenance mode is on"
+// @Router /v1/chat/completions/ws [get]
+func (chatCompletionRoute *ChatCompletionRoute) StreamCompletionWS(reqCtx *gin.Context) {
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "2b7e4c91-6f3a-4d58-9e12-8a5c0d7f3b64")
+		return
+	}
+
+	conn, err := wsUpgrader.Upgrade(reqCtx.Writer, reqCtx.Request, nil)
+	if err != nil {
+		return // The upgrader already answered with 400
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithCancel(reqCtx.Request.Context())
+	defer cancel()
+	session := &wsSession{route: chatCompletionRoute, reqCtx: reqCtx, userID: user.ID, conn: conn}
+	session.serve(ctx)
+}
+
+// wsSession serves the completions requested on one connection.
+type wsSession struct {
+	route  *ChatCompletionRoute
+	reqCtx *gin.Context
+	userID uint
+	conn   *websocket.Conn
+
+	writeMu sync.Mutex // Serialises writes, which the connection requires
+
+	mu      sync.Mutex
+	active  *wsStreamWriter // Running completion, nil when idle
+	running sync.WaitGroup
+}
+
+// serve reads client messages until the connection closes, then aborts the
+// running completion.
+func (s *wsSession) serve(ctx context.Context) {
+	// The route has no BodyLimitMiddleware, so this read limit is what caps
+	// requests at CHAT_MAX_REQUEST_BYTES; keep it in step with the POST route
+	if limit := s.route.cfg.ChatMaxRequestBytes; limit > 0 {
+		s.conn.SetReadLimit(limit)
+	}
+	extend := func(string) error { return s.conn.SetReadDeadline(time.Now().Add(wsPongTimeout)) }
+	_ = extend("")
+	s.conn.SetPongHandler(extend)
+	go s.keepAlive(ctx)
+
+	for {
+		_, data, err := s.conn.ReadMessage()
+		if err != nil {
+			if errors.Is(err, websocket.ErrReadLimit) {
+				_ = s.conn.WriteControl(websocket.CloseMessage,
+					websocket.FormatCloseMessage(websocket.CloseMessageTooBig, "message exceeds CHAT_MAX_REQUEST_BYTES"), time.Now().Add(wsWriteTimeout))
+			}
+			break
+		}
+		_ = extend("")
+		s.handleMessage(ctx, data)
+	}
+
+	s.mu.Lock()
+	if s.active != nil {
+		// The client went away: abort the completion like a dropped SSE stream
+		s.active.cancel(context.Canceled)
+	}
+	s.mu.Unlock()
+	s.running.Wait()
+}
+
+func (s *wsSession) handleMessage(ctx context.Context, data []byte) {
+	var msg wsClientMessage
+	if err := json.Unmarshal(data, &msg); err != nil {
+		s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypeValidation,
+			"message is not a JSON object: "+err.Error(), nil, "9a3f6d20-4e8b-4c17-b5a9-1d7e2f8c6b43"))
+		return
+	}
+
+	switch msg.Type {
+	case wsMessageCancel:
+		if msg.AudioEndMs != nil && *msg.AudioEndMs < 0 {
+			s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypeValidation,
+				"audio_end_ms must be a non-negative integer", nil, "4d8c1e7a-2b5f-4a96-8e03-6f9b3c1d7a52"))
+			return
+		}
+		s.cancel(ctx, msg.AudioEndMs)
+	case "":
+		var request chatrequests.ChatCompletionRequest
+		if err := json.Unmarshal(data, &request); err != nil {
+			s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypeValidation,
+				"invalid chat completion request: "+err.Error(), nil, "6e1b9f34-7c2d-4a80-9d5e-3b8a0f6c2e17"))
+			return
+		}
+		s.start(ctx, request)
+	default:
+		s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypeValidation,
+			"unknown message type "+msg.Type, nil, "1f7a4c8e-9b3d-4e62-a5c0-8d2e6b1f9a34"))
+	}
+}
+
+// start runs a completion in the background, so cancel messages are read while it streams.
+func (s *wsSession) start(ctx context.Context, request chatrequests.ChatCompletionRequest) {
+	// Only the upgrade goes through MaintenanceMiddleware; a connection opened
+	// before maintenance started must not write either
+	if s.route.maintenance != nil {
+		if state := s.route.maintenance.Current(); state.Enabled {
+			s.writeError(middlewares.MaintenanceError(ctx, state))
+			return
+		}
+	}
+	if reason := request.ExceededLimit(s.route.cfg.ChatMaxMessages, s.route.cfg.ChatMaxImageBytes); reason != "" {
+		s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypePayloadTooLarge,
+			reason, nil, "8c2e5a17-3f9b-4d64-b1e8-7a0d4c6f2b95"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.active != nil {
+		s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypeConflict,
+			"a completion is already streaming on this connection; cancel it or wait for stream.done", nil, "5b9d2f68-1a4e-4c73-8f0b-2e6c9a3d7f81"))
+		return
+	}
+
+	streamCtx, cancel := context.WithCancelCause(ctx)
+	c := s.reqCtx.Copy()
+	c.Request = s.reqCtx.Request.WithContext(streamCtx)
+	writer := newWSStreamWriter(s, c, cancel)
+	c.Writer = writer
+	s.active = writer
+	s.running.Add(1)
+
+	go func() {
+		defer s.running.Done()
+		defer func() {
+			s.mu.Lock()
+			s.active = nil
+			s.mu.Unlock()
+			cancel(nil)
+		}()
+		request.Stream = true
+		s.complete(streamCtx, c, writer, request)
+	}()
+}
+
+// complete runs the completion and ends it with stream.done or an error,
+// mirroring the error handling of PostCompletion.
+func (s *wsSession) complete(ctx context.Context, c *gin.Context, writer *wsStreamWriter, request chatrequests.ChatCompletionRequest) {
+	_, err := s.route.chatHandler.CreateChatCompletion(ctx, c, s.userID, request)
+	if writer.done {
+		return
+	}
+	if err == nil {
+		writer.finish(false)
+		return
+	}
+	switch cause := context.Cause(ctx); {
+	case errors.Is(cause, chathandler.ErrStreamCancelled):
+		writer.finish(true) // Stopped before it started streaming
+		return
+	case errors.Is(cause, context.Canceled):
+		return // The client disconnected
+	}
+
+	if !writer.Written() && s.route.chatHandler.UsesFallbackText() && !rejectedBeforeProvider(err) {
+		fallback := s.route.chatHandler.BuildFallbackResponse(request.Model)
+		_ = s.writeJSON(chatresponses.NewChatCompletionResponse(fallback, "", nil, false))
+		writer.finish(false)
+		return
+	}
+	s.writeError(err)
+}
+
+// cancel stops the running completion. Conversation-bound completions are
+// stopped through CancelStream, like with the cancel endpoint, so pending
+// mcp_call items are settled and spoken replies truncated.
+func (s *wsSession) cancel(ctx context.Context, audioEndMs *int) {
+	s.mu.Lock()
+	writer := s.active
+	s.mu.Unlock()
+	if writer == nil {
+		s.writeError(platformerrors.NewError(ctx, platformerrors.LayerRoute, platformerrors.ErrorTypeNotFound,
+			"no completion is streaming on this connection", nil, "3a6f8d41-5c2e-4b97-9e10-7d4b2a8f6c53"))
+		return
+	}
+
+	if streamID := writer.StreamID(); streamID != "" {
+		if _, err := s.route.chatHandler.CancelStream(ctx, s.userID, streamID, audioEndMs); err == nil {
+			return
+		}
+	}
+	writer.cancel(chathandler.ErrStreamCancelled)
+}
+
+func (s *wsSession) keepAlive(ctx context.Context) {
+	ticker := time.NewTicker(wsPingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := s.conn.WriteControl(websocket.PingMessage, nil, time.Now().Add(wsWriteTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (s *wsSession) write(data []byte) error {
+	s.writeMu.Lock()
+	defer s.writeMu.Unlock()
+	_ = s.conn.SetWriteDeadline(time.Now().Add(wsWriteTimeout))
+	return s.conn.WriteMessage(websocket.TextMessage, data)
+}
+
+func (s *wsSession) writeJSON(v any) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return s.write(data)
+}
+
+func (s *wsSession) writeError(err error) {
+	msg := wsErrorMessage{Type: wsMessageError, Status: http.StatusInternalServerError, Error: streamErrorResponse(err)}
+	var platformErr *platformerrors.PlatformError
+	if errors.As(err, &platformErr) {
+		msg.Status = platformerrors.ErrorTypeToHTTPStatus(platformErr.GetErrorType())
+	}
+	if retryAfter, ok := platformerrors.ContextValue(err, platformerrors.ContextKeyRetryAfter); ok {
+		msg.RetryAfter = retryAfter
+	}
+	_ = s.writeJSON(msg)
+}
+
+// rejectedBeforeProvider reports errors PostCompletion returns as they are
+// rather than replacing them with the fallback text.
+func rejectedBeforeProvider(err error) bool {
+	if platformerrors.IsValidationError(err) {
+		return true
+	}
+	for _, errorType := range []platformerrors.ErrorType{
+		platformerrors.ErrorTypeNotFound,
+		platformerrors.ErrorTypeForbidden,
+		platformerrors.ErrorTypeCaptchaRequired,
+		platformerrors.ErrorTypeUnauthorized,
+		platformerrors.ErrorTypeConflict,
+		platformerrors.ErrorTypeRateLimited,
+	} {
+		if platformerrors.IsErrorType(err, errorType) {
+			return true
+		}
+	}
+	return false
+}
+
+// wsStreamWriter stands in for the response of one completion: the SSE stream
+// the chat handler writes is sent as WebSocket messages, one per data event.
+// It is written by the completion's goroutine only, except for StreamID.
+type wsStreamWriter struct {
+	session *wsSession
+	c       *gin.Context
+	cancel  context.CancelCauseFunc
+
+	header  http.Header
+	status  int
+	size    int
+	started bool
+	done    bool
+	pending []byte // Incomplete SSE line
+	err     error  // First failed write; the stream is aborted with it
+
+	mu       sync.Mutex
+	streamID string
+}
+
+var _ gin.ResponseWriter = (*wsStreamWriter)(nil)
+
+func newWSStreamWriter(session *wsSession, c *gin.Context, cancel context.CancelCauseFunc) *wsStreamWriter {
+	return &wsStreamWriter{session: session, c: c, cancel: cancel, header: make(http.Header), status: http.StatusOK}
+}
+
+// StreamID returns the ID of a conversation-bound completion once it started.
+func (w *wsStreamWriter) StreamID() string {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.streamID
+}
+
+func (w *wsStreamWriter) Header() http.Header { return w.header }
+
+func (w *wsStreamWriter) WriteHeader(code int) {
+	if !w.started && code > 0 {
+		w.status = code
+	}
+}
+
+// WriteHeaderNow announces the completion, with the IDs the SSE stream sends as headers.
+func (w *wsStreamWriter) WriteHeaderNow() {
+	if w.started {
+		return
+	}
+	w.started = true
+	streamID := w.header.Get(chathandler.StreamIDHeader)
+	w.mu.Lock()
+	w.streamID = streamID
+	w.mu.Unlock()
+	w.send(wsStreamMessage{Type: wsMessageStarted, StreamID: streamID, ItemID: w.header.Get(chathandler.ItemIDHeader)})
+}
+
+func (w *wsStreamWriter) Write(p []byte) (int, error) {
+	w.WriteHeaderNow()
+	w.size += len(p)
+	w.pending = append(w.pending, p...)
+	for {
+		i := bytes.IndexByte(w.pending, '\n')
+		if i < 0 {
+			break
+		}
+		line := strings.TrimSpace(string(w.pending[:i]))
+		w.pending = w.pending[i+1:]
+		data, ok := strings.CutPrefix(line, "data:")
+		if !ok {
+			continue // Blank separators and comments
+		}
+		data = strings.TrimSpace(data)
+		if data == "[DONE]" {
+			w.finish(errors.Is(context.Cause(w.c.Request.Context()), chathandler.ErrStreamCancelled))
+			continue
+		}
+		if w.err == nil {
+			w.err = w.session.write([]byte(data))
+		}
+	}
+	if w.err != nil {
+		return 0, w.err
+	}
+	return len(p), nil
+}
+
+func (w *wsStreamWriter) WriteString(s string) (int, error) { return w.Write([]byte(s)) }
+
+// finish ends the completion with stream.done, once.
+func (w *wsStreamWriter) finish(cancelled bool) {
+	if w.done {
+		return
+	}
+	w.done = true
+	w.send(wsStreamMessage{Type: wsMessageDone, StreamID: w.StreamID(), Cancelled: cancelled})
+}
+
+func (w *wsStreamWriter) send(msg wsStreamMessage) {
+	if w.err == nil {
+		w.err = w.session.writeJSON(msg)
+	}
+}
+
+func (w *wsStreamWriter) Status() int         { return w.status }
+func (w *wsStreamWriter) Size() int           { return w.size }
+func (w *wsStreamWriter) Written() bool       { return w.started }
+func (w *wsStreamWriter) Flush()              {} // Every event is sent as it is written
+func (w *wsStreamWriter) Pusher() http.Pusher { return nil }
+
+func (w *wsStreamWriter) CloseNotify() <-chan bool { return make(chan bool) }
+
+func (w *wsStreamWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	return nil, nil, errors.New("the completion response of a WebSocket cannot be hijacked")
+}
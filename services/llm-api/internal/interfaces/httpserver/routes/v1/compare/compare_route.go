@@ -0,0 +1,46 @@
+package compare
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/config"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/comparehandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
+)
+
+// CompareRoute handles /v1/compare routes
+type CompareRoute struct {
+	handler     *comparehandler.CompareHandler
+	authHandler *authhandler.AuthHandler
+	cfg         *config.Config
+}
+
+// NewCompareRoute creates a new compare route
+func NewCompareRoute(
+	handler *comparehandler.CompareHandler,
+	authHandler *authhandler.AuthHandler,
+	cfg *config.Config,
+) *CompareRoute {
+	return &CompareRoute{
+		handler:     handler,
+		authHandler: authHandler,
+		cfg:         cfg,
+	}
+}
+
+// RegisterRouter registers the model comparison (arena) endpoints
+func (r *CompareRoute) RegisterRouter(router gin.IRouter) {
+	compareGroup := router.Group("/compare")
+	{
+		// Compare requests carry chat messages, so they share the chat body limit
+		compareGroup.POST("",
+			append(
+				[]gin.HandlerFunc{middlewares.BodyLimitMiddleware(r.cfg.ChatMaxRequestBytes)},
+				r.authHandler.WithAppUserAuthChain(r.handler.Compare)...,
+			)...,
+		)
+		compareGroup.GET("/:comparison_id", r.authHandler.WithAppUserAuthChain(r.handler.GetComparison)...)
+		compareGroup.POST("/:comparison_id/vote", r.authHandler.WithAppUserAuthChain(r.handler.Vote)...)
+	}
+}
@@ -4,8 +4,10 @@ import (
 	"net/http"
 	"strings"
 
+	"jan-server/services/llm-api/internal/domain/conversation"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/conversationhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/middlewares"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/requests"
 	conversationrequests "jan-server/services/llm-api/internal/interfaces/httpserver/requests/conversation"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/responses"
@@ -42,6 +44,13 @@ func (route *ConversationRoute) RegisterRouter(router gin.IRouter) {
 	conversations.POST("/:conv_public_id/items", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.createItems)...)
 	conversations.GET("/:conv_public_id/items/:item_id", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.getItem)...)
 	conversations.DELETE("/:conv_public_id/items/:item_id", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.deleteItem)...)
+	conversations.PATCH("/:conv_public_id/items/:item_id", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.editItem)...)
+	conversations.GET("/:conv_public_id/items/:item_id/edits", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.listItemEdits)...)
+	conversations.POST("/:conv_public_id/items/:item_id/truncate", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.truncateItem)...)
+	conversations.GET("/:conv_public_id/attachments", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.listAttachments)...)
+	conversations.GET("/:conv_public_id/instruction", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.getInstruction)...)
+	conversations.POST("/:conv_public_id/instruction/refresh", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.refreshInstruction)...)
+	conversations.POST("/:conv_public_id/rehydrate", route.authHandler.WithAppUserAuthChain(route.rehydrateConversation)...)
 	// MCP tool tracking: update item by call_id
 	conversations.PATCH("/:conv_public_id/items/by-call-id/:call_id", route.authHandler.WithAppUserAuthChain(route.handler.ConversationMiddleware(), route.updateItemByCallID)...)
 }
@@ -54,7 +63,7 @@ func (route *ConversationRoute) RegisterRouter(router gin.IRouter) {
 // @Produce json
 // @Param referrer query string false "Referrer filter"
 // @Param limit query int false "Maximum number of conversations to return"
-// @Param after query string false "Return conversations created after the given numeric ID"
+// @Param after query string false "Opaque cursor from next_cursor of the previous page"
 // @Param order query string false "Sort order (asc or desc)"
 // @Param scope query string false "Set to 'all' to list conversations across the workspace (requires elevated permissions)"
 // @Success 200 {object} conversationresponses.ConversationListResponse "Successfully retrieved conversations"
@@ -77,17 +86,8 @@ func (route *ConversationRoute) listConversations(reqCtx *gin.Context) {
 		return
 	}
 
-	// Use the standard cursor pagination helper that properly resolves public IDs to numeric IDs
-	// This follows the same pattern as API keys route (see apikeys_route.go:84)
-	pagination, err := requests.GetCursorPaginationFromQuery(reqCtx, func(publicID string) (*uint, error) {
-		// Resolve conversation public ID to numeric ID for cursor pagination
-		// We need to call the handler's method which internally uses the service
-		id, err := route.handler.ResolveConversationPublicIDToNumericID(ctx, user.ID, publicID)
-		if err != nil {
-			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "invalid cursor: conversation not found or not accessible")
-		}
-		return id, nil
-	})
+	// The cursor is the opaque next_cursor token from the previous page
+	pagination, err := requests.GetCursorPaginationFromQuery(reqCtx, requests.ConversationCursorScope(user.ID), nil)
 	if err != nil {
 		responses.HandleError(reqCtx, err, "Failed to process pagination")
 		return
@@ -135,6 +135,7 @@ func (route *ConversationRoute) listConversations(reqCtx *gin.Context) {
 // @Description - Add up to 20 initial items to the conversation
 // @Description - Returns conversation ID with `conv_` prefix
 // @Description - Supports OpenAI Conversations API format
+// @Description - Start from a prompt library starter with `prompt_library_id` and `prompt_variables`; its rendered messages come first and count toward the 20 items
 // @Description
 // @Description **Metadata Constraints:**
 // @Description - Maximum 16 key-value pairs
@@ -164,6 +165,7 @@ func (route *ConversationRoute) createConversation(reqCtx *gin.Context) {
 		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeValidation, "invalid request body", "b9c8d7e6-f5a4-4d3e-a1b2-0c9d8e7f6g5h")
 		return
 	}
+	markLegacyItemTypes(reqCtx, req.Items)
 	response, err := route.handler.CreateConversation(ctx, user.ID, req)
 	if err != nil {
 		responses.HandleError(reqCtx, err, "Failed to create conversation")
@@ -230,7 +232,9 @@ func (route *ConversationRoute) deleteAllConversations(reqCtx *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Param If-None-Match header string false "ETag from a previous response; an unchanged conversation returns 304"
 // @Success 200 {object} conversationresponses.ConversationResponse "Successfully retrieved conversation"
+// @Success 304 "Conversation unchanged since the ETag in If-None-Match"
 // @Failure 400 {object} responses.ErrorResponse "Invalid conversation ID format"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
@@ -244,8 +248,10 @@ func (route *ConversationRoute) getConversation(reqCtx *gin.Context) {
 		return
 	}
 
-	response := conversationresponses.NewConversationResponse(conv)
-	reqCtx.JSON(http.StatusOK, response)
+	if responses.NotModified(reqCtx, conversationETag(conv, "conversation")) {
+		return
+	}
+	reqCtx.JSON(http.StatusOK, conversationresponses.NewConversationResponse(conv))
 }
 
 // updateConversation godoc
@@ -257,6 +263,7 @@ func (route *ConversationRoute) getConversation(reqCtx *gin.Context) {
 // @Description - Replaces entire metadata object (not merged)
 // @Description - Items remain unchanged
 // @Description - Automatic ownership verification
+// @Description - Optimistic concurrency: send the `version` from the last read; a stale version returns 409
 // @Description
 // @Description **Metadata Constraints:**
 // @Description - Maximum 16 key-value pairs
@@ -272,6 +279,7 @@ func (route *ConversationRoute) getConversation(reqCtx *gin.Context) {
 // @Failure 400 {object} responses.ErrorResponse "Invalid request - validation failed or invalid metadata"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
+// @Failure 409 {object} responses.ErrorResponse "Conflict - conversation was modified since the given version"
 // @Failure 500 {object} responses.ErrorResponse "Internal server error - update failed"
 // @Router /v1/conversations/{conv_public_id} [post]
 func (route *ConversationRoute) updateConversation(reqCtx *gin.Context) {
@@ -377,11 +385,13 @@ func (route *ConversationRoute) deleteConversation(reqCtx *gin.Context) {
 // @Security BearerAuth
 // @Produce json
 // @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
-// @Param after query string false "Item ID cursor to list items after (pagination)"
+// @Param after query string false "Opaque cursor from next_cursor, or an item ID (OpenAI compatible)"
 // @Param limit query integer false "Number of items to return (1-100)" default(20) minimum(1) maximum(100)
 // @Param order query string false "Sort order: asc or desc" default(desc) Enums(asc, desc)
 // @Param include query []string false "Additional fields to include in response"
+// @Param If-None-Match header string false "ETag from a previous response; an unchanged page returns 304"
 // @Success 200 {object} conversationresponses.ItemListResponse "Successfully retrieved items list"
+// @Success 304 "Items unchanged since the ETag in If-None-Match"
 // @Failure 400 {object} responses.ErrorResponse "Invalid request - invalid parameters or conversation ID"
 // @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
 // @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
@@ -403,14 +413,20 @@ func (route *ConversationRoute) listItems(reqCtx *gin.Context) {
 		return
 	}
 
+	// Answer a poll for an unchanged page before resolving cursors or loading items
+	if responses.NotModified(reqCtx, conversationETag(conv, "items", reqCtx.Request.URL.Query().Encode())) {
+		return
+	}
+
 	var params conversationrequests.ListItemsQueryParams
 	if err := reqCtx.ShouldBindQuery(&params); err != nil {
 		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeValidation, "invalid query parameters", "e9f0a1b2-c3d4-4e5f-6g7h-8i9j0k1l2m3n")
 		return
 	}
 
-	// Build pagination using standard cursor helper for query parameter parsing
-	pagination, err := requests.GetCursorPaginationFromQuery(reqCtx, func(itemPublicID string) (*uint, error) {
+	// Accept the opaque next_cursor token, or an item ID (OpenAI `after=last_id`)
+	cursorScope := requests.ItemCursorScope(conv.PublicID)
+	pagination, err := requests.GetCursorPaginationFromQuery(reqCtx, cursorScope, func(itemPublicID string) (*uint, error) {
 		id, err := route.handler.ResolveItemPublicIDToNumericID(ctx, user.ID, conv.PublicID, itemPublicID)
 		if err != nil {
 			return nil, platformerrors.AsError(ctx, platformerrors.LayerHandler, err, "invalid cursor: item not found or not accessible")
@@ -461,23 +477,34 @@ func (route *ConversationRoute) listItems(reqCtx *gin.Context) {
 
 	// Calculate cursor IDs
 	var firstID, lastID string
+	var lastNumericID uint
 	if len(items) > 0 {
 		firstID = items[0].PublicID
 		lastID = items[len(items)-1].PublicID
+		lastNumericID = items[len(items)-1].ID
 	}
 
 	// Build response matching OpenAI format
 	response := conversationresponses.ItemListResponse{
-		Object:  "list",
-		Data:    items,
-		FirstID: firstID,
-		LastID:  lastID,
-		HasMore: hasMore,
+		Object:     "list",
+		Data:       items,
+		FirstID:    firstID,
+		LastID:     lastID,
+		HasMore:    hasMore,
+		NextCursor: requests.NextCursor(cursorScope, hasMore, lastNumericID, pagination.Order),
 	}
 
 	reqCtx.JSON(http.StatusOK, response)
 }
 
+// conversationETag tags a read of conv and its items at the conversation's
+// current revision: every write to either bumps its version or updated_at.
+// Archiving idle items to cold storage does not, so clients that polled
+// before keep the full items they already have.
+func conversationETag(conv *conversation.Conversation, parts ...any) string {
+	return responses.RevisionETag(append([]any{conv.PublicID, conv.Version, conv.UpdatedAt.UnixNano()}, parts...)...)
+}
+
 // createItems godoc
 // @Summary Create conversation items
 // @Description Add items to a conversation. You may add up to 20 items at a time.
@@ -532,6 +559,7 @@ func (route *ConversationRoute) createItems(reqCtx *gin.Context) {
 		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeValidation, "invalid request body", "b2c3d4e5-f6g7-4h8i-9j0k-1l2m3n4o5p6q")
 		return
 	}
+	markLegacyItemTypes(reqCtx, req.Items)
 
 	response, err := route.handler.CreateItems(ctx, user.ID, conv.PublicID, req)
 	if err != nil {
@@ -541,6 +569,20 @@ func (route *ConversationRoute) createItems(reqCtx *gin.Context) {
 	reqCtx.JSON(http.StatusOK, response)
 }
 
+// markLegacyItemTypes flags each legacy item type in items, e.g. file_search
+// instead of file_search_call, as deprecated on the response.
+func markLegacyItemTypes(reqCtx *gin.Context, items []conversation.Item) {
+	seen := make(map[conversation.ItemType]bool)
+	for _, item := range items {
+		successor, ok := conversation.LegacyItemTypeSuccessor(item.Type)
+		if !ok || seen[item.Type] {
+			continue
+		}
+		seen[item.Type] = true
+		middlewares.MarkDeprecated(reqCtx, middlewares.LegacyItemTypeDeprecation(string(item.Type), string(successor)))
+	}
+}
+
 // getItem godoc
 // @Summary Get a conversation item
 // @Description Retrieve a single item from a conversation by item ID
@@ -649,6 +691,306 @@ func (route *ConversationRoute) deleteItem(reqCtx *gin.Context) {
 	reqCtx.JSON(http.StatusOK, response)
 }
 
+// editItem godoc
+// @Summary Edit a conversation item in place
+// @Description Overwrite the content of an assistant message or the output of a tool item without branching.
+// @Description Used for moderation and for correcting ingestion mistakes.
+// @Description
+// @Description **Features:**
+// @Description - Replaces `content` and/or `output` on the existing item
+// @Description - The replaced values are preserved in the item's edit history
+// @Description - Edited items are returned with `edited: true` and `edited_at`
+// @Description
+// @Description **Restrictions:**
+// @Description - Only assistant messages and tool output items can be edited
+// @Description - `output` is only accepted for tool output items
+// @Description - To change a user message, use the branching edit endpoint instead
+// @Tags Conversations API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Param item_id path string true "Item ID (format: msg_xxxxx)"
+// @Param request body conversationrequests.EditItemRequest true "Replacement content or output, with an optional reason"
+// @Success 200 {object} conversationresponses.ItemResponse "Successfully edited item"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request or item type cannot be edited"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation or item not found, or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/items/{item_id} [patch]
+func (route *ConversationRoute) editItem(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	// Get conversation from context (set by middleware)
+	conv, ok := conversationhandler.GetConversationFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeInternal, "conversation not found in context", "5bc52a3c-ef72-44ea-87a6-057889727bec")
+		return
+	}
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "9d111794-6beb-4fdb-b20c-5b8c0913b60a")
+		return
+	}
+
+	var req conversationrequests.EditItemRequest
+	if err := reqCtx.ShouldBindJSON(&req); err != nil {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeValidation, "invalid request body", "8da6fa91-29e3-4b98-86fa-dcf0af532ba2")
+		return
+	}
+
+	itemID := reqCtx.Param("item_id")
+	response, err := route.handler.EditItem(ctx, user.ID, conv.PublicID, itemID, req)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to edit item")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
+// truncateItem godoc
+// @Summary Truncate an assistant message to the audio that was played
+// @Description Cut a spoken assistant message back to what the user heard before interrupting it, like the OpenAI Realtime `conversation.item.truncate` event.
+// @Description Clients that detect the user talking over the reply (voice activity detection) stop playback, stop the stream through `POST /v1/chat/completions/{stream_id}/cancel`, and send how much audio was played.
+// @Description
+// @Description **Behavior:**
+// @Description - The audio transcript keeps the sentences played in full and the words heard of the interrupted one
+// @Description - The message text is cut at the same point, so later turns only see what was said
+// @Description - The truncation replaces the content without adding to the item's edit history
+// @Description
+// @Description **Restrictions:**
+// @Description - Only assistant messages streamed with audio output can be truncated
+// @Description - `audio_end_ms` must not exceed the duration of the audio
+// @Tags Conversations API
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Param item_id path string true "Item ID (format: msg_xxxxx)"
+// @Param request body conversationrequests.TruncateItemRequest true "Audio content part and played duration"
+// @Success 200 {object} conversationresponses.ItemResponse "Successfully truncated item"
+// @Failure 400 {object} responses.ErrorResponse "Invalid request, or the item has no audio to truncate"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation or item not found, or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/items/{item_id}/truncate [post]
+func (route *ConversationRoute) truncateItem(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	// Get conversation from context (set by middleware)
+	conv, ok := conversationhandler.GetConversationFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeInternal, "conversation not found in context", "e3b8d1f6-2a7c-4e94-9f05-7c1a6d3b8e42")
+		return
+	}
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "1f6c9a3e-8d24-4b57-a7e1-5b0d2c8f6a93")
+		return
+	}
+
+	var req conversationrequests.TruncateItemRequest
+	if err := reqCtx.ShouldBindJSON(&req); err != nil {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeValidation, "content_index and audio_end_ms are required and must not be negative", "9a2d5f7c-3e18-4b6a-8c40-d7f1e9b2a563")
+		return
+	}
+
+	itemID := reqCtx.Param("item_id")
+	response, err := route.handler.TruncateItem(ctx, user.ID, conv.PublicID, itemID, req)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to truncate item")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
+// listItemEdits godoc
+// @Summary List item edit history
+// @Description List the previous versions of a conversation item that was edited in place, oldest first.
+// @Description The first entry holds the item's original content.
+// @Tags Conversations API
+// @Security BearerAuth
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Param item_id path string true "Item ID (format: msg_xxxxx)"
+// @Success 200 {object} conversationresponses.ItemEditListResponse "Successfully retrieved edit history"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation or item not found, or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/items/{item_id}/edits [get]
+func (route *ConversationRoute) listItemEdits(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	// Get conversation from context (set by middleware)
+	conv, ok := conversationhandler.GetConversationFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeInternal, "conversation not found in context", "a742aa63-522e-4349-83a1-a4b18201216e")
+		return
+	}
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "6bad98ec-2776-4451-9a25-872136ba5e18")
+		return
+	}
+
+	itemID := reqCtx.Param("item_id")
+	response, err := route.handler.ListItemEdits(ctx, user.ID, conv.PublicID, itemID)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to list item edits")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
+// listAttachments godoc
+// @Summary List conversation attachments
+// @Description List the images and files referenced across a conversation branch, oldest first, so clients can show the files in a chat without walking every item. An upload sent again in later turns is listed once, for the item it first appeared in.
+// @Description
+// @Description Attachments stored in media-api carry their size, content type and a download URL; presigned URLs report when they expire in `url_expires_at`. If media-api is unavailable the stored references are returned as-is. Images sent as data URLs are listed as `inline` with their size but without a URL.
+// @Tags Conversations API
+// @Security BearerAuth
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Param branch query string false "Branch name (defaults to the active branch)"
+// @Success 200 {object} conversationresponses.AttachmentListResponse "Successfully retrieved attachments"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/attachments [get]
+func (route *ConversationRoute) listAttachments(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	// Get conversation from context (set by middleware)
+	conv, ok := conversationhandler.GetConversationFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeInternal, "conversation not found in context", "5d2a8f14-7c3e-4b91-a6d0-e8f2b4c7193a")
+		return
+	}
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "a41c6e92-0b7d-4f58-93e2-6d1f8a5c2b07")
+		return
+	}
+
+	var branch *string
+	if value := strings.TrimSpace(reqCtx.Query("branch")); value != "" {
+		branch = &value
+	}
+
+	response, err := route.handler.ListAttachments(ctx, user.ID, conv.PublicID, branch, reqCtx.GetHeader("Authorization"))
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to list conversation attachments")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
+// getInstruction godoc
+// @Summary Get conversation instruction
+// @Description Get the project instruction a conversation's completions use. A project conversation is pinned to the project's instruction on its first completion and keeps that version when the project is edited; stale reports a newer project version.
+// @Tags Conversations API
+// @Security BearerAuth
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Success 200 {object} conversationresponses.InstructionResponse "Successfully retrieved instruction"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/instruction [get]
+func (route *ConversationRoute) getInstruction(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	// Get conversation from context (set by middleware)
+	conv, ok := conversationhandler.GetConversationFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeInternal, "conversation not found in context", "3f7a9c2e-1d58-4b60-8e4a-6c2b9d0f5e71")
+		return
+	}
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "c8e1b4d7-9a26-4f3e-b5c0-2d7f1a8e6b94")
+		return
+	}
+
+	response, err := route.handler.GetInstruction(ctx, user.ID, conv.PublicID)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to get conversation instruction")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
+// refreshInstruction godoc
+// @Summary Refresh conversation instruction
+// @Description Pin a project conversation to the project's current instruction, so its next completions pick up edits made since it was pinned.
+// @Tags Conversations API
+// @Security BearerAuth
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Success 200 {object} conversationresponses.InstructionResponse "Instruction refreshed"
+// @Failure 400 {object} responses.ErrorResponse "Conversation does not belong to a project"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/instruction/refresh [post]
+func (route *ConversationRoute) refreshInstruction(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	// Get conversation from context (set by middleware)
+	conv, ok := conversationhandler.GetConversationFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeInternal, "conversation not found in context", "7d4c0e9b-6f12-4a85-93b7-e1a5c8f2d036")
+		return
+	}
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "1a9f5d3c-4e87-4b2a-a6d8-0c3e7b5f9a42")
+		return
+	}
+
+	response, err := route.handler.RefreshInstruction(ctx, user.ID, conv.PublicID)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to refresh conversation instruction")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
+// rehydrateConversation godoc
+// @Summary Rehydrate conversation
+// @Description Restore the items of a conversation from cold storage. Items of conversations idle for COLD_STORAGE_AFTER_MONTHS are moved to cold storage and listed as stubs with `cold_stored` set and no content; writing to the conversation rehydrates it automatically, and this endpoint does so ahead of a read.
+// @Tags Conversations API
+// @Security BearerAuth
+// @Produce json
+// @Param conv_public_id path string true "Conversation ID (format: conv_xxxxx)"
+// @Success 200 {object} conversationresponses.RehydrationResponse "Items restored; items_restored is 0 if none were in cold storage"
+// @Failure 401 {object} responses.ErrorResponse "Unauthorized - missing or invalid authentication"
+// @Failure 404 {object} responses.ErrorResponse "Conversation not found or access denied"
+// @Failure 500 {object} responses.ErrorResponse "Internal server error"
+// @Router /v1/conversations/{conv_public_id}/rehydrate [post]
+func (route *ConversationRoute) rehydrateConversation(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "4f8b2d6e-1a93-4c57-b0e8-7d3a9c5f2e61")
+		return
+	}
+
+	response, err := route.handler.Rehydrate(ctx, user.ID, reqCtx.Param("conv_public_id"))
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to rehydrate conversation")
+		return
+	}
+	reqCtx.JSON(http.StatusOK, response)
+}
+
 // updateItemByCallID godoc
 // @Summary Update item by call ID
 // @Description Update a conversation item's status and output using its call_id.
@@ -658,6 +1000,7 @@ func (route *ConversationRoute) deleteItem(reqCtx *gin.Context) {
 // @Description - Find item by call_id (e.g., call_xxx) instead of item_id
 // @Description - Update status to completed, failed, or cancelled
 // @Description - Store tool output or error message
+// @Description - Store a structured error_detail (code, retryable, provider) for failed calls; error defaults to its message
 // @Description - Automatic timestamp for completion
 // @Description
 // @Description **Use Cases:**
@@ -31,6 +31,7 @@ func (r *ProjectRoute) RegisterRoutes(rg *gin.RouterGroup) {
 	projects.GET("/:project_id", r.authHandler.WithAppUserAuthChain(r.getProject)...)
 	projects.PATCH("/:project_id", r.authHandler.WithAppUserAuthChain(r.updateProject)...)
 	projects.DELETE("/:project_id", r.authHandler.WithAppUserAuthChain(r.deleteProject)...)
+	projects.GET("/:project_id/instructions", r.authHandler.WithAppUserAuthChain(r.listInstructionVersions)...)
 }
 
 // createProject godoc
@@ -92,7 +93,7 @@ func (r *ProjectRoute) listProjects(reqCtx *gin.Context) {
 		return
 	}
 
-	pagination, err := requests.GetCursorPaginationFromQuery(reqCtx, nil)
+	pagination, err := requests.GetCursorPaginationFromQuery(reqCtx, "", nil)
 	if err != nil {
 		responses.HandleError(reqCtx, err, "Failed to process pagination")
 		return
@@ -211,3 +212,35 @@ func (r *ProjectRoute) deleteProject(reqCtx *gin.Context) {
 
 	reqCtx.JSON(200, response)
 }
+
+// listInstructionVersions godoc
+// @Summary List project instruction versions
+// @Description List every version of a project's instruction, newest first. A new version is recorded whenever an update changes the instruction.
+// @Tags Projects API
+// @Security BearerAuth
+// @Produce json
+// @Param project_id path string true "Project ID"
+// @Success 200 {object} projectres.InstructionVersionListResponse
+// @Failure 401 {object} responses.ErrorResponse
+// @Failure 404 {object} responses.ErrorResponse
+// @Failure 500 {object} responses.ErrorResponse
+// @Router /v1/projects/{project_id}/instructions [get]
+func (r *ProjectRoute) listInstructionVersions(reqCtx *gin.Context) {
+	ctx := reqCtx.Request.Context()
+
+	user, ok := authhandler.GetUserFromContext(reqCtx)
+	if !ok {
+		responses.HandleNewError(reqCtx, platformerrors.ErrorTypeUnauthorized, "authentication required", "proj-instructions-001")
+		return
+	}
+
+	projectID := reqCtx.Param("project_id")
+
+	response, err := r.handler.ListInstructionVersions(ctx, user.ID, projectID)
+	if err != nil {
+		responses.HandleError(reqCtx, err, "Failed to list project instruction versions")
+		return
+	}
+
+	reqCtx.JSON(200, response)
+}
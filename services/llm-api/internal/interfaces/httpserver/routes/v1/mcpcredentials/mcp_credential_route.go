@@ -0,0 +1,36 @@
+package mcpcredentials
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/mcpcredentialhandler"
+)
+
+// MCPCredentialRoute handles /v1/mcp-credentials routes
+type MCPCredentialRoute struct {
+	handler     *mcpcredentialhandler.MCPCredentialHandler
+	authHandler *authhandler.AuthHandler
+}
+
+// NewMCPCredentialRoute creates a new MCP credential route
+func NewMCPCredentialRoute(
+	handler *mcpcredentialhandler.MCPCredentialHandler,
+	authHandler *authhandler.AuthHandler,
+) *MCPCredentialRoute {
+	return &MCPCredentialRoute{
+		handler:     handler,
+		authHandler: authHandler,
+	}
+}
+
+// RegisterRouter registers the authenticated user's MCP credential endpoints
+func (r *MCPCredentialRoute) RegisterRouter(router gin.IRouter) {
+	credentialGroup := router.Group("/mcp-credentials")
+	{
+		credentialGroup.GET("", r.authHandler.WithAppUserAuthChain(r.handler.List)...)
+		credentialGroup.PUT("/:provider", r.authHandler.WithAppUserAuthChain(r.handler.Save)...)
+		credentialGroup.DELETE("/:provider", r.authHandler.WithAppUserAuthChain(r.handler.Delete)...)
+		credentialGroup.GET("/:provider/token", r.authHandler.WithAppUserAuthChain(r.handler.GetToken)...)
+	}
+}
@@ -59,8 +59,10 @@ func (ModelRoute *ModelRoute) RegisterRouter(router *gin.RouterGroup) {
 // @Accept json
 // @Produce json
 // @Param X-PROVIDER-DATA header string false "Set to 'true' to include provider metadata in response" Enums(true, false)
+// @Param If-None-Match header string false "ETag from a previous response; an unchanged list returns 304"
 // @Success 200 {object} modelresponses.ModelResponseList "List of models (when X-PROVIDER-DATA header is not true)"
 // @Success 200 {object} modelresponses.ModelWithProviderResponseList "List of models with provider metadata (when X-PROVIDER-DATA=true)"
+// @Success 304 "Models unchanged since the ETag in If-None-Match"
 // @Failure 404 {object} responses.ErrorResponse "Models or providers not found"
 // @Failure 500 {object} responses.ErrorResponse "Failed to retrieve models"
 // @Router /v1/models [get]
@@ -89,7 +91,7 @@ func (ModelRoute *ModelRoute) GetModels(reqCtx *gin.Context) {
 
 	if includeProviderData {
 		models := modelresponses.BuildModelResponseListWithProvider(accessibleModels.ProviderModels, providerByID)
-		reqCtx.JSON(http.StatusOK, modelresponses.ModelWithProviderResponseList{
+		responses.JSONWithETag(reqCtx, modelresponses.ModelWithProviderResponseList{
 			Object: "list",
 			Data:   models,
 		})
@@ -97,7 +99,7 @@ func (ModelRoute *ModelRoute) GetModels(reqCtx *gin.Context) {
 	} else {
 		mergedProviderModels := ModelRoute.modelHandler.MergeModels(accessibleModels.ProviderModels, providerByID)
 		mergedModels := modelresponses.BuildModelResponseList(mergedProviderModels, providerByID)
-		reqCtx.JSON(http.StatusOK, modelresponses.ModelResponseList{
+		responses.JSONWithETag(reqCtx, modelresponses.ModelResponseList{
 			Object: "list",
 			Data:   mergedModels,
 		})
@@ -0,0 +1,37 @@
+package personas
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/personahandler"
+)
+
+// PersonaRoute handles /v1/personas routes
+type PersonaRoute struct {
+	handler     *personahandler.PersonaHandler
+	authHandler *authhandler.AuthHandler
+}
+
+// NewPersonaRoute creates a new persona route
+func NewPersonaRoute(
+	handler *personahandler.PersonaHandler,
+	authHandler *authhandler.AuthHandler,
+) *PersonaRoute {
+	return &PersonaRoute{
+		handler:     handler,
+		authHandler: authHandler,
+	}
+}
+
+// RegisterRouter registers the authenticated user's persona endpoints
+func (r *PersonaRoute) RegisterRouter(router gin.IRouter) {
+	personaGroup := router.Group("/personas")
+	{
+		personaGroup.GET("", r.authHandler.WithAppUserAuthChain(r.handler.List)...)
+		personaGroup.POST("", r.authHandler.WithAppUserAuthChain(r.handler.Create)...)
+		personaGroup.GET("/:persona_id", r.authHandler.WithAppUserAuthChain(r.handler.Get)...)
+		personaGroup.PATCH("/:persona_id", r.authHandler.WithAppUserAuthChain(r.handler.Update)...)
+		personaGroup.DELETE("/:persona_id", r.authHandler.WithAppUserAuthChain(r.handler.Delete)...)
+	}
+}
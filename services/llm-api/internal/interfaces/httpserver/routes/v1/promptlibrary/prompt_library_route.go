@@ -0,0 +1,38 @@
+package promptlibrary
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/promptlibraryhandler"
+)
+
+// PromptLibraryRoute handles /v1/prompt-library routes
+type PromptLibraryRoute struct {
+	handler     *promptlibraryhandler.PromptLibraryHandler
+	authHandler *authhandler.AuthHandler
+}
+
+// NewPromptLibraryRoute creates a new prompt library route
+func NewPromptLibraryRoute(
+	handler *promptlibraryhandler.PromptLibraryHandler,
+	authHandler *authhandler.AuthHandler,
+) *PromptLibraryRoute {
+	return &PromptLibraryRoute{
+		handler:     handler,
+		authHandler: authHandler,
+	}
+}
+
+// RegisterRouter registers the conversation starter endpoints
+func (r *PromptLibraryRoute) RegisterRouter(router gin.IRouter) {
+	libraryGroup := router.Group("/prompt-library")
+	{
+		libraryGroup.GET("", r.authHandler.WithAppUserAuthChain(r.handler.List)...)
+		libraryGroup.POST("", r.authHandler.WithAppUserAuthChain(r.handler.Create)...)
+		libraryGroup.GET("/categories", r.authHandler.WithAppUserAuthChain(r.handler.ListCategories)...)
+		libraryGroup.GET("/:starter_id", r.authHandler.WithAppUserAuthChain(r.handler.Get)...)
+		libraryGroup.PATCH("/:starter_id", r.authHandler.WithAppUserAuthChain(r.handler.Update)...)
+		libraryGroup.DELETE("/:starter_id", r.authHandler.WithAppUserAuthChain(r.handler.Delete)...)
+	}
+}
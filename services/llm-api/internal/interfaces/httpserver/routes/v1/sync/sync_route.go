@@ -0,0 +1,30 @@
+package sync
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/authhandler"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/synchandler"
+)
+
+// SyncRoute handles /v1/sync routes
+type SyncRoute struct {
+	handler     *synchandler.SyncHandler
+	authHandler *authhandler.AuthHandler
+}
+
+// NewSyncRoute creates a new sync route
+func NewSyncRoute(
+	handler *synchandler.SyncHandler,
+	authHandler *authhandler.AuthHandler,
+) *SyncRoute {
+	return &SyncRoute{
+		handler:     handler,
+		authHandler: authHandler,
+	}
+}
+
+// RegisterRouter registers the authenticated user's delta sync endpoint
+func (r *SyncRoute) RegisterRouter(router gin.IRouter) {
+	router.GET("/sync", r.authHandler.WithAppUserAuthChain(r.handler.Sync)...)
+}
@@ -8,12 +8,18 @@ import (
 	"jan-server/services/llm-api/internal/interfaces/httpserver/handlers/prompttemplatehandler"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/public"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/admin"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/analytics"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/chat"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/compare"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/conversation"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/image"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/llm/projects"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/mcpcredentials"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/model"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/personas"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/promptlibrary"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/share"
+	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/sync"
 	"jan-server/services/llm-api/internal/interfaces/httpserver/routes/v1/users"
 
 	"github.com/gin-gonic/gin"
@@ -32,6 +38,13 @@ type V1Route struct {
 	mcpToolHandler        *mcptoolhandler.MCPToolHandler
 	share                 *share.ShareRoute
 	publicShare           *public.PublicShareRoute
+	webhooks              *public.WebhookRoute
+	analytics             *analytics.AnalyticsRoute
+	compare               *compare.CompareRoute
+	promptLibrary         *promptlibrary.PromptLibraryRoute
+	personas              *personas.PersonaRoute
+	mcpCredentials        *mcpcredentials.MCPCredentialRoute
+	sync                  *sync.SyncRoute
 }
 
 func NewV1Route(
@@ -47,6 +60,13 @@ func NewV1Route(
 	mcpToolHandler *mcptoolhandler.MCPToolHandler,
 	share *share.ShareRoute,
 	publicShare *public.PublicShareRoute,
+	webhooks *public.WebhookRoute,
+	analytics *analytics.AnalyticsRoute,
+	compare *compare.CompareRoute,
+	promptLibrary *promptlibrary.PromptLibraryRoute,
+	personas *personas.PersonaRoute,
+	mcpCredentials *mcpcredentials.MCPCredentialRoute,
+	sync *sync.SyncRoute,
 ) *V1Route {
 	return &V1Route{
 		model,
@@ -61,6 +81,13 @@ func NewV1Route(
 		mcpToolHandler,
 		share,
 		publicShare,
+		webhooks,
+		analytics,
+		compare,
+		promptLibrary,
+		personas,
+		mcpCredentials,
+		sync,
 	}
 }
 
@@ -78,6 +105,12 @@ func (v1Route *V1Route) RegisterRouter(router gin.IRouter) {
 	v1Route.branch.RegisterRouter(v1Router)
 	v1Route.project.RegisterRoutes(v1Router)
 	v1Route.users.RegisterRouter(v1Router)
+	v1Route.analytics.RegisterRouter(v1Router)
+	v1Route.compare.RegisterRouter(v1Router)
+	v1Route.promptLibrary.RegisterRouter(v1Router)
+	v1Route.personas.RegisterRouter(v1Router)
+	v1Route.mcpCredentials.RegisterRouter(v1Router)
+	v1Route.sync.RegisterRouter(v1Router)
 
 	// Share routes (authenticated, under /conversations)
 	conversations := v1Router.Group("/conversations")
@@ -101,6 +134,9 @@ func (v1Route *V1Route) RegisterPublicRouter(router gin.IRouter) {
 
 	// Public share routes (no auth required)
 	v1Route.publicShare.RegisterRouter(v1Router)
+
+	// Provider webhooks (signed by their source, no auth token)
+	v1Route.webhooks.RegisterRouter(v1Router)
 }
 
 // GetVersion godoc
@@ -3,7 +3,9 @@ package crypto
 import (
 	"crypto/aes"
 	"crypto/cipher"
+	"crypto/hkdf"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/base64"
 	"errors"
 	"io"
@@ -92,3 +94,30 @@ func DecryptString(secret, ciphertext string) (string, error) {
 
 	return string(plaintext), nil
 }
+
+// DeriveKey derives a 32-byte AES-256 key from secret with HKDF-SHA256.
+// Different labels yield unrelated keys, so one secret can serve several uses.
+func DeriveKey(secret, label string) ([]byte, error) {
+	if secret == "" {
+		return nil, errors.New("secret key cannot be empty")
+	}
+	return hkdf.Key(sha256.New, []byte(secret), nil, label, 32)
+}
+
+// Seal encrypts plaintext using AES-GCM with key and prepends the nonce
+func Seal(key, plaintext []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return sealGCM(aead, plaintext)
+}
+
+// Open decrypts data made by Seal with the same key
+func Open(key, data []byte) ([]byte, error) {
+	aead, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	return openGCM(aead, data)
+}
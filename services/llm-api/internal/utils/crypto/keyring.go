@@ -0,0 +1,248 @@
+package crypto
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// envelopePrefix marks ciphertexts produced by a Keyring. Legacy EncryptString
+// output is plain base64 and never contains a colon.
+const envelopePrefix = "enc:"
+
+// Keyring encrypts secrets stored in the database with envelope encryption:
+// every value gets a fresh random data key, and the data key is stored
+// alongside it wrapped by a versioned master key. The version recorded in each
+// ciphertext tells which values still use an old key after a rotation, and
+// moving them to the new key only rewraps their data keys.
+type Keyring struct {
+	current      string
+	masterKeys   map[string]cipher.AEAD
+	legacySecret string
+}
+
+// ParseKeyring parses a comma-separated list of version:secret master keys,
+// e.g. "2025-06:new-secret,2024-11:old-secret". The first entry encrypts new
+// values; the others only decrypt values not yet re-encrypted. Each secret
+// becomes an AES-256 key through DeriveKey. An empty spec yields a keyring
+// that only handles legacy ciphertexts.
+func ParseKeyring(spec string) (*Keyring, error) {
+	k := &Keyring{masterKeys: make(map[string]cipher.AEAD)}
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		version, secret, ok := strings.Cut(entry, ":")
+		version, secret = strings.TrimSpace(version), strings.TrimSpace(secret)
+		if !ok || version == "" || secret == "" {
+			return nil, fmt.Errorf("master key %q must be written as version:secret", entry)
+		}
+		if _, exists := k.masterKeys[version]; exists {
+			return nil, fmt.Errorf("master key version %q is listed twice", version)
+		}
+		key, err := DeriveKey(secret, "keyring")
+		if err != nil {
+			return nil, err
+		}
+		aead, err := newGCM(key)
+		if err != nil {
+			return nil, err
+		}
+		k.masterKeys[version] = aead
+		if k.current == "" {
+			k.current = version
+		}
+	}
+	return k, nil
+}
+
+// WithLegacySecret returns a copy of the keyring that reads, and while no
+// master key is configured also writes, ciphertexts made by EncryptString
+// with secret
+func (k *Keyring) WithLegacySecret(secret string) *Keyring {
+	copied := Keyring{masterKeys: map[string]cipher.AEAD{}}
+	if k != nil {
+		copied = *k
+	}
+	copied.legacySecret = strings.TrimSpace(secret)
+	return &copied
+}
+
+// Version returns the version of the master key new values are encrypted
+// with, or "" when only the legacy secret is configured
+func (k *Keyring) Version() string {
+	if k == nil {
+		return ""
+	}
+	return k.current
+}
+
+// Configured reports whether the keyring can encrypt
+func (k *Keyring) Configured() bool {
+	return k != nil && (k.current != "" || k.legacySecret != "")
+}
+
+// IsCurrent reports whether ciphertext is encrypted the way Encrypt would
+// encrypt it now, so re-encrypting it would change nothing
+func (k *Keyring) IsCurrent(ciphertext string) bool {
+	version, ok := envelopeVersion(ciphertext)
+	if !ok {
+		return k.Version() == ""
+	}
+	return version == k.Version()
+}
+
+// Encrypt encrypts plaintext with a fresh data key wrapped by the current
+// master key, or with the legacy secret when no master key is configured
+func (k *Keyring) Encrypt(plaintext string) (string, error) {
+	if !k.Configured() {
+		return "", errors.New("no encryption key configured")
+	}
+	if k.current == "" {
+		return EncryptString(k.legacySecret, plaintext)
+	}
+
+	dataKey := make([]byte, 32)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", err
+	}
+	wrapped, err := sealGCM(k.masterKeys[k.current], dataKey)
+	if err != nil {
+		return "", err
+	}
+	dataAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := sealGCM(dataAEAD, []byte(plaintext))
+	if err != nil {
+		return "", err
+	}
+	return formatEnvelope(k.current, wrapped, sealed), nil
+}
+
+// Decrypt decrypts a value made by Encrypt with any configured master key,
+// or a legacy EncryptString value
+func (k *Keyring) Decrypt(ciphertext string) (string, error) {
+	if _, ok := envelopeVersion(ciphertext); !ok {
+		if k == nil || k.legacySecret == "" {
+			return "", errors.New("value uses the legacy format but no legacy secret is configured")
+		}
+		return DecryptString(k.legacySecret, ciphertext)
+	}
+
+	dataKey, sealed, err := k.unwrap(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	dataAEAD, err := newGCM(dataKey)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := openGCM(dataAEAD, sealed)
+	if err != nil {
+		return "", err
+	}
+	return string(plaintext), nil
+}
+
+// Rotate re-encrypts ciphertext for the current master key. Envelope values
+// only have their data key rewrapped; legacy values are encrypted afresh.
+func (k *Keyring) Rotate(ciphertext string) (string, error) {
+	if k.IsCurrent(ciphertext) {
+		return ciphertext, nil
+	}
+	if _, ok := envelopeVersion(ciphertext); !ok || k.current == "" {
+		plaintext, err := k.Decrypt(ciphertext)
+		if err != nil {
+			return "", err
+		}
+		return k.Encrypt(plaintext)
+	}
+
+	dataKey, sealed, err := k.unwrap(ciphertext)
+	if err != nil {
+		return "", err
+	}
+	wrapped, err := sealGCM(k.masterKeys[k.current], dataKey)
+	if err != nil {
+		return "", err
+	}
+	return formatEnvelope(k.current, wrapped, sealed), nil
+}
+
+// unwrap splits an envelope value into its data key and sealed payload
+func (k *Keyring) unwrap(ciphertext string) ([]byte, []byte, error) {
+	parts := strings.Split(strings.TrimPrefix(ciphertext, envelopePrefix), ":")
+	if len(parts) != 3 {
+		return nil, nil, errors.New("malformed encrypted value")
+	}
+	var master cipher.AEAD
+	if k != nil {
+		master = k.masterKeys[parts[0]]
+	}
+	if master == nil {
+		return nil, nil, fmt.Errorf("master key version %q is not configured", parts[0])
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, nil, err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, nil, err
+	}
+	dataKey, err := openGCM(master, wrapped)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unwrap data key: %w", err)
+	}
+	return dataKey, sealed, nil
+}
+
+// formatEnvelope encodes an envelope value as enc:<version>:<wrapped key>:<payload>
+func formatEnvelope(version string, wrapped, sealed []byte) string {
+	return envelopePrefix + version + ":" +
+		base64.StdEncoding.EncodeToString(wrapped) + ":" +
+		base64.StdEncoding.EncodeToString(sealed)
+}
+
+// envelopeVersion returns the master key version of a Keyring ciphertext
+func envelopeVersion(ciphertext string) (string, bool) {
+	if !strings.HasPrefix(ciphertext, envelopePrefix) {
+		return "", false
+	}
+	version, _, _ := strings.Cut(strings.TrimPrefix(ciphertext, envelopePrefix), ":")
+	return version, true
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// sealGCM encrypts plaintext and prepends the nonce
+func sealGCM(aead cipher.AEAD, plaintext []byte) ([]byte, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+// openGCM reverses sealGCM
+func openGCM(aead cipher.AEAD, data []byte) ([]byte, error) {
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, sealed := data[:aead.NonceSize()], data[aead.NonceSize():]
+	return aead.Open(nil, nonce, sealed, nil)
+}
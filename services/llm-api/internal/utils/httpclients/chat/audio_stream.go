@@ -0,0 +1,310 @@
+package chat
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// minSpeechSegment merges very short sentences ("Sure.") into the next one,
+	// saving a synthesis round trip per fragment
+	minSpeechSegment = 12
+	// maxSpeechSegment cuts text without sentence ends so audio keeps flowing
+	maxSpeechSegment = 400
+	// maxAudioChunkBytes bounds the raw audio carried by one pcm16 chunk; opus
+	// clips are sent whole because each is a self-contained Ogg stream
+	maxAudioChunkBytes = 32 << 10
+	// pcm16BytesPerMs is the byte rate of 24 kHz 16-bit mono PCM
+	pcm16BytesPerMs = 48
+	// opusSamplesPerMs is the rate of Ogg Opus granule positions, always 48 kHz
+	opusSamplesPerMs = 48
+
+	audioFormatPCM16 = "pcm16"
+)
+
+// SpeechSynthesizer turns text into audio in one of the AudioContent formats
+// (pcm16 or opus).
+type SpeechSynthesizer interface {
+	Synthesize(ctx context.Context, text, voice, format string) ([]byte, error)
+}
+
+// SpeechOutput asks for a streamed reply to be spoken as well as written. The
+// content is synthesized one sentence at a time and its audio is interleaved
+// with the text deltas as delta.audio chunks.
+type SpeechOutput struct {
+	Synthesizer SpeechSynthesizer
+	ID          string // AudioContent id shared by every audio chunk of the reply
+	Voice       string
+	Format      string // pcm16 or opus
+
+	// Spoken lists the sentences whose audio reached the client, in order; it
+	// is filled in as the stream runs.
+	Spoken []SpokenSegment
+}
+
+// SpokenSegment is a sentence of the reply that was sent as audio. EndMs is
+// where its audio ends, in milliseconds from the start of the reply's audio.
+type SpokenSegment struct {
+	Transcript string
+	EndMs      int
+}
+
+// AudioDelta is the delta.audio field of a streamed audio chunk, shaped like
+// the AudioContent of conversation items. Transcript is set on the first chunk
+// of each sentence.
+type AudioDelta struct {
+	ID         string `json:"id"`
+	Data       string `json:"data"` // base64 encoded audio
+	Transcript string `json:"transcript,omitempty"`
+	Format     string `json:"format"`
+}
+
+// audioClip is the audio of one sentence, or the error that ended synthesis
+type audioClip struct {
+	transcript string
+	data       []byte
+	err        error
+}
+
+// speechStream buffers streamed content into sentences and synthesizes them in
+// order on one worker, so audio arrives in the order the text was written.
+type speechStream struct {
+	out    *SpeechOutput
+	text   strings.Builder // content not yet queued for synthesis
+	clips  chan audioClip
+	wake   chan struct{}
+	cancel context.CancelFunc
+
+	mu     sync.Mutex
+	queue  []string
+	closed bool
+}
+
+func newSpeechStream(ctx context.Context, out *SpeechOutput) *speechStream {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &speechStream{
+		out:    out,
+		clips:  make(chan audioClip, 4),
+		wake:   make(chan struct{}, 1),
+		cancel: cancel,
+	}
+	go s.run(ctx)
+	return s
+}
+
+// write adds streamed content and queues every sentence it completes.
+func (s *speechStream) write(content string) {
+	s.text.WriteString(content)
+	pending := s.text.String()
+	queued := false
+	for {
+		segment, rest, ok := cutSentence(pending)
+		if !ok {
+			break
+		}
+		s.enqueue(segment)
+		pending = rest
+		queued = true
+	}
+	if queued {
+		s.text.Reset()
+		s.text.WriteString(pending)
+	}
+}
+
+// finish queues the remaining content; clips is closed once it is spoken.
+func (s *speechStream) finish() {
+	s.enqueue(s.text.String())
+	s.text.Reset()
+	s.mu.Lock()
+	s.closed = true
+	s.mu.Unlock()
+	s.signal()
+}
+
+// stop abandons synthesis, as when the stream fails.
+func (s *speechStream) stop() {
+	s.cancel()
+}
+
+func (s *speechStream) enqueue(segment string) {
+	segment = strings.TrimSpace(segment)
+	if segment == "" {
+		return
+	}
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.queue = append(s.queue, segment)
+	s.mu.Unlock()
+	s.signal()
+}
+
+func (s *speechStream) signal() {
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// run synthesizes queued sentences until the stream is finished. The first
+// failure ends synthesis: the text keeps streaming without audio.
+func (s *speechStream) run(ctx context.Context) {
+	defer close(s.clips)
+	for {
+		s.mu.Lock()
+		if len(s.queue) == 0 {
+			closed := s.closed
+			s.mu.Unlock()
+			if closed {
+				return
+			}
+			select {
+			case <-s.wake:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+		segment := s.queue[0]
+		s.queue = s.queue[1:]
+		s.mu.Unlock()
+
+		data, err := s.out.Synthesizer.Synthesize(ctx, segment, s.out.Voice, s.out.Format)
+		select {
+		case s.clips <- audioClip{transcript: segment, data: data, err: err}:
+		case <-ctx.Done():
+			return
+		}
+		if err != nil {
+			s.mu.Lock()
+			s.closed = true
+			s.queue = nil
+			s.mu.Unlock()
+			return
+		}
+	}
+}
+
+// cutSentence splits the first sentence of at least minSpeechSegment
+// characters off text. A sentence ends at a newline, at CJK end punctuation,
+// or at '.', '!' or '?' followed by whitespace, so "3.14" is not cut. Text
+// longer than maxSpeechSegment without an end is cut at its last space.
+func cutSentence(text string) (string, string, bool) {
+	for i, r := range text {
+		end := -1
+		switch r {
+		case '\n', '。', '！', '？':
+			end = i + utf8.RuneLen(r)
+		case '.', '!', '?':
+			if next := i + 1; next < len(text) && unicode.IsSpace(rune(text[next])) {
+				end = next
+			}
+		}
+		if end > 0 && len(strings.TrimSpace(text[:end])) >= minSpeechSegment {
+			return text[:end], text[end:], true
+		}
+	}
+	if len(text) < maxSpeechSegment {
+		return "", text, false
+	}
+	cut := strings.LastIndexFunc(text[:maxSpeechSegment], unicode.IsSpace)
+	if cut <= 0 {
+		cut = maxSpeechSegment
+		for cut > 0 && !utf8.RuneStart(text[cut]) {
+			cut--
+		}
+	}
+	return text[:cut], text[cut:], true
+}
+
+// audioDurationMs returns the playing time of a clip in format.
+func audioDurationMs(format string, data []byte) int {
+	if format == audioFormatPCM16 {
+		return len(data) / pcm16BytesPerMs
+	}
+	return oggOpusDurationMs(data)
+}
+
+// oggOpusDurationMs reads the playing time of an Ogg Opus stream from the
+// granule position of its last page, which counts samples including the
+// encoder pre-skip declared in the OpusHead header.
+func oggOpusDurationMs(data []byte) int {
+	last := bytes.LastIndex(data, []byte("OggS"))
+	if last < 0 || len(data) < last+14 {
+		return 0
+	}
+	granule := int64(binary.LittleEndian.Uint64(data[last+6 : last+14]))
+	var preSkip int64
+	if head := bytes.Index(data, []byte("OpusHead")); head >= 0 && len(data) >= head+12 {
+		preSkip = int64(binary.LittleEndian.Uint16(data[head+10 : head+12]))
+	}
+	if granule <= preSkip {
+		return 0
+	}
+	return int((granule - preSkip) / opusSamplesPerMs)
+}
+
+// audioChunkLines renders the audio of clip as chat.completion.chunk events.
+// pcm16 audio is split on sample boundaries into chunks of at most
+// maxAudioChunkBytes.
+func audioChunkLines(id, model, fallbackModel string, out *SpeechOutput, clip audioClip) []string {
+	if model == "" {
+		model = fallbackModel
+	}
+	parts := [][]byte{clip.data}
+	if out.Format == audioFormatPCM16 && len(clip.data) > maxAudioChunkBytes {
+		parts = parts[:0]
+		for data := clip.data; len(data) > 0; {
+			n := min(len(data), maxAudioChunkBytes)
+			parts = append(parts, data[:n])
+			data = data[n:]
+		}
+	}
+
+	type audioChoice struct {
+		Index int `json:"index"`
+		Delta struct {
+			Audio AudioDelta `json:"audio"`
+		} `json:"delta"`
+		FinishReason *string `json:"finish_reason"`
+	}
+	lines := make([]string, 0, len(parts))
+	for i, part := range parts {
+		choice := audioChoice{}
+		choice.Delta.Audio = AudioDelta{
+			ID:     out.ID,
+			Data:   base64.StdEncoding.EncodeToString(part),
+			Format: out.Format,
+		}
+		if i == 0 {
+			choice.Delta.Audio.Transcript = clip.transcript
+		}
+		chunk := struct {
+			ID      string        `json:"id"`
+			Object  string        `json:"object"`
+			Created int64         `json:"created"`
+			Model   string        `json:"model"`
+			Choices []audioChoice `json:"choices"`
+		}{
+			ID:      id,
+			Object:  "chat.completion.chunk",
+			Created: time.Now().Unix(),
+			Model:   model,
+			Choices: []audioChoice{choice},
+		}
+		payload, _ := json.Marshal(chunk)
+		lines = append(lines, dataPrefix+string(payload)+newlineChar)
+	}
+	return lines
+}
@@ -10,6 +10,7 @@ import (
 	"sync"
 	"time"
 
+	"jan-server/services/llm-api/internal/infrastructure/metrics"
 	"jan-server/services/llm-api/internal/utils/platformerrors"
 
 	"github.com/gin-gonic/gin"
@@ -23,6 +24,7 @@ import (
 
 const (
 	defaultStreamTimeout = 600 * time.Second // Default to 10 minutes for long requests
+	heartbeatComment     = ": ping"
 	channelBufferSize    = 100
 	errorBufferSize      = 10
 	dataPrefix           = "data: "
@@ -54,6 +56,15 @@ type StreamChoice struct {
 	Delta ChoiceDelta `json:"delta"`
 }
 
+// streamChunk is the parsed form of one upstream SSE data payload
+type streamChunk struct {
+	ID         string
+	Model      string
+	Choice     *StreamChoice
+	Usage      *TokenUsage
+	HasChoices bool
+}
+
 func WithHeader(key, value string) StreamOption {
 	return func(r *resty.Request) {
 		if strings.TrimSpace(key) == "" {
@@ -72,19 +83,52 @@ func WithAcceptEncodingIdentity() StreamOption {
 }
 
 type ChatCompletionClient struct {
-	client        *resty.Client
-	baseURL       string
-	name          string
-	streamTimeout time.Duration
+	client            *resty.Client
+	baseURL           string
+	name              string
+	streamTimeout     time.Duration
+	heartbeatInterval time.Duration
 }
 
 // CompletionRequest extends the OpenAI chat request with provider-specific fields.
 type CompletionRequest struct {
 	openai.ChatCompletionRequest
-	TopK              *int     `json:"top_k,omitempty"`
-	RepetitionPenalty *float32 `json:"repetition_penalty,omitempty"`
+	TopK              *int             `json:"top_k,omitempty"`
+	RepetitionPenalty *float32         `json:"repetition_penalty,omitempty"`
+	Reasoning         *ReasoningParams `json:"reasoning,omitempty"` // OpenRouter reasoning controls
+	Thinking          *ThinkingParams  `json:"thinking,omitempty"`  // Anthropic extended thinking
+
+	// HideReasoning drops reasoning from the response and the streamed chunks
+	HideReasoning bool `json:"-"`
+	// Speech interleaves synthesized audio of the content with the streamed chunks
+	Speech *SpeechOutput `json:"-"`
+	// Broadcast receives the content deltas as they stream, e.g. to mirror them to a realtime room
+	Broadcast DeltaSink `json:"-"`
+}
+
+// DeltaSink receives the content deltas of a streamed completion. WriteDelta
+// is called from the stream loop and must not block.
+type DeltaSink interface {
+	WriteDelta(content string)
+}
+
+// ReasoningParams is OpenRouter's unified reasoning parameter. Effort and
+// MaxTokens are alternatives; only one is sent.
+type ReasoningParams struct {
+	Effort    string `json:"effort,omitempty"`
+	MaxTokens int    `json:"max_tokens,omitempty"`
+	Exclude   bool   `json:"exclude,omitempty"`
+}
+
+// ThinkingParams enables Anthropic extended thinking with a token budget.
+type ThinkingParams struct {
+	Type         string `json:"type"`
+	BudgetTokens int    `json:"budget_tokens"`
 }
 
+// reasoningDeltaFields are the stream delta fields providers put reasoning in
+var reasoningDeltaFields = []string{"reasoning_content", "reasoning", "reasoning_details"}
+
 type functionCallAccumulator struct {
 	Name      string
 	Arguments string
@@ -114,6 +158,16 @@ func WithStreamTimeout(timeout time.Duration) ClientOption {
 	}
 }
 
+// WithHeartbeatInterval sends an SSE comment whenever the stream has been idle
+// for the given interval. Zero (the default) disables heartbeats.
+func WithHeartbeatInterval(interval time.Duration) ClientOption {
+	return func(c *ChatCompletionClient) {
+		if interval > 0 {
+			c.heartbeatInterval = interval
+		}
+	}
+}
+
 func NewChatCompletionClient(client *resty.Client, name, baseURL string, opts ...ClientOption) *ChatCompletionClient {
 	c := &ChatCompletionClient{
 		client:        client,
@@ -211,6 +265,12 @@ func (c *ChatCompletionClient) CreateChatCompletion(ctx context.Context, apiKey
 		span.SetAttributes(attribute.Int("llm.usage.reasoning_tokens", respBody.Usage.CompletionTokensDetails.ReasoningTokens))
 	}
 
+	if request.HideReasoning {
+		for i := range respBody.Choices {
+			respBody.Choices[i].Message.ReasoningContent = ""
+		}
+	}
+
 	span.SetStatus(codes.Ok, "completion successful")
 	span.AddEvent("chat_completion_completed", trace.WithAttributes(
 		attribute.Int("response.choice_count", len(respBody.Choices)),
@@ -279,7 +339,8 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 
 	start := time.Now()
 
-	// force to true to collect tokens
+	// Remember whether the caller asked for a usage chunk, then force it upstream to collect tokens
+	includeUsage := request.StreamOptions != nil && request.StreamOptions.IncludeUsage
 	request.StreamOptions = &openai.StreamOptions{
 		IncludeUsage: true,
 	}
@@ -305,6 +366,108 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 	// Track streaming metrics
 	var chunksReceived int
 	var totalUsage *TokenUsage
+	var firstTokenAt time.Time
+	var chunkID, chunkModel string
+
+	// Heartbeats keep proxies from closing the connection while the provider is
+	// silent; they are only written between events so no data line is split.
+	var heartbeat <-chan time.Time
+	if c.heartbeatInterval > 0 {
+		ticker := time.NewTicker(c.heartbeatInterval)
+		defer ticker.Stop()
+		heartbeat = ticker.C
+	}
+	lastWrite := time.Now()
+	atEventBoundary := true
+	writeLine := func(line string) error {
+		if err := c.writeSSELine(reqCtx, line); err != nil {
+			return err
+		}
+		lastWrite = time.Now()
+		atEventBoundary = line == "" || strings.HasSuffix(line, newlineChar)
+		return nil
+	}
+
+	// Audio of the reply is synthesized sentence by sentence while the text
+	// streams; its chunks wait in pendingAudio for the next event boundary. A
+	// sentence counts as spoken once its last chunk is written.
+	type audioLine struct {
+		line   string
+		spoken *SpokenSegment
+	}
+	var speech *speechStream
+	var speechClips <-chan audioClip
+	var pendingAudio []audioLine
+	var audioChunks, audioMs int
+	if request.Speech != nil {
+		speech = newSpeechStream(ctx, request.Speech)
+		defer speech.stop()
+		speechClips = speech.clips
+	}
+	takeClip := func(clip audioClip, ok bool) {
+		if !ok {
+			speechClips = nil
+			return
+		}
+		if clip.err != nil {
+			span.AddEvent("speech_synthesis_failed", trace.WithAttributes(attribute.String("error", clip.err.Error())))
+			return
+		}
+		lines := audioChunkLines(chunkID, chunkModel, request.Model, request.Speech, clip)
+		audioMs += audioDurationMs(request.Speech.Format, clip.data)
+		for i, line := range lines {
+			pending := audioLine{line: line}
+			if i == len(lines)-1 {
+				pending.spoken = &SpokenSegment{Transcript: clip.transcript, EndMs: audioMs}
+			}
+			pendingAudio = append(pendingAudio, pending)
+		}
+	}
+	writeAudio := func() error {
+		for len(pendingAudio) > 0 && atEventBoundary {
+			if err := writeLine(pendingAudio[0].line); err != nil {
+				return err
+			}
+			if spoken := pendingAudio[0].spoken; spoken != nil {
+				request.Speech.Spoken = append(request.Speech.Spoken, *spoken)
+			}
+			pendingAudio = pendingAudio[1:]
+			audioChunks++
+		}
+		return nil
+	}
+
+	// partialResponse returns what was streamed so far, so a caller handling a
+	// mid-stream failure can account for the generated tokens (estimated when no
+	// usage chunk arrived) and keep the content the client already received.
+	partialResponse := func() *openai.ChatCompletionResponse {
+		partial := c.buildCompleteResponse(contentBuilder.String(), reasoningBuilder.String(), functionCallAccumulator, toolCallAccumulator, request.Model, request)
+		if totalUsage != nil {
+			partial.Usage = openai.Usage{
+				PromptTokens:     totalUsage.PromptTokens,
+				CompletionTokens: totalUsage.CompletionTokens,
+				TotalTokens:      totalUsage.TotalTokens,
+			}
+		}
+		return &partial
+	}
+
+	// clientAborted stops the upstream request once the caller disconnects.
+	clientAborted := func() (*openai.ChatCompletionResponse, error) {
+		cancel()
+		wg.Wait()
+		abortErr := reqCtx.Request.Context().Err()
+		span.RecordError(abortErr)
+		span.SetStatus(codes.Error, "client request cancelled")
+
+		partial := partialResponse()
+		span.SetAttributes(
+			attribute.Bool("llm.streaming.client_aborted", true),
+			attribute.Int("llm.usage.prompt_tokens", partial.Usage.PromptTokens),
+			attribute.Int("llm.usage.completion_tokens", partial.Usage.CompletionTokens),
+		)
+		return partial, platformerrors.AsError(ctx, platformerrors.LayerDomain, abortErr, "client request cancelled")
+	}
 
 	streamingComplete := false
 
@@ -318,21 +481,59 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 
 			chunksReceived++
 
-			// Check if this is the [DONE] marker BEFORE writing it
 			// Check if this is the [DONE] marker BEFORE writing it
 			if data, found := strings.CutPrefix(line, dataPrefix); found {
 				if data == doneMarker {
+					// Speak the rest of the reply before closing the stream
+					if speech != nil {
+						speech.finish()
+						for speechClips != nil {
+							select {
+							case clip, ok := <-speechClips:
+								takeClip(clip, ok)
+							case <-reqCtx.Request.Context().Done():
+								return clientAborted()
+							}
+							if err := writeAudio(); err != nil {
+								if reqCtx.Request.Context().Err() != nil {
+									return clientAborted()
+								}
+								cancel()
+								wg.Wait()
+								span.RecordError(err)
+								span.SetStatus(codes.Error, "failed to write SSE audio chunk")
+								return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+							}
+						}
+					}
+					// The provider did not report usage: send an estimate as the final chunk
+					if includeUsage && totalUsage == nil {
+						estimated := c.buildCompleteResponse(contentBuilder.String(), reasoningBuilder.String(), functionCallAccumulator, toolCallAccumulator, request.Model, request)
+						if err := writeLine(usageChunkLine(chunkID, chunkModel, request.Model, estimated.Usage)); err != nil {
+							if reqCtx.Request.Context().Err() != nil {
+								return clientAborted()
+							}
+							cancel()
+							wg.Wait()
+							span.RecordError(err)
+							span.SetStatus(codes.Error, "failed to write SSE usage chunk")
+							return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+						}
+					}
 					// Call the beforeDone callback BEFORE sending [DONE]
 					if beforeDone != nil {
 						_ = beforeDone(reqCtx)
 					}
 					// Now write the [DONE] marker
-					if err := c.writeSSELine(reqCtx, line); err != nil {
+					if err := writeLine(line); err != nil {
+						if reqCtx.Request.Context().Err() != nil {
+							return clientAborted()
+						}
 						cancel()
 						wg.Wait()
 						span.RecordError(err)
 						span.SetStatus(codes.Error, "failed to write SSE done marker")
-						return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+						return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
 					}
 					streamingComplete = true
 					cancel()
@@ -340,20 +541,43 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 				}
 			}
 
+			var chunk *streamChunk
+			if data, found := strings.CutPrefix(line, dataPrefix); found {
+				chunk = c.processStreamChunkForChannel(data)
+			}
+
+			// Usage-only chunks are forwarded only when the caller set stream_options.include_usage
+			skipLine := chunk != nil && chunk.Usage != nil && !chunk.HasChoices && !includeUsage
+			if request.HideReasoning && !skipLine {
+				line, skipLine = redactReasoningLine(line)
+			}
+
 			// Write the line for non-[DONE] events
-			if err := c.writeSSELine(reqCtx, line); err != nil {
-				cancel()
-				wg.Wait()
-				span.RecordError(err)
-				span.SetStatus(codes.Error, "failed to write SSE line")
-				return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+			if !skipLine {
+				if err := writeLine(line); err != nil {
+					if reqCtx.Request.Context().Err() != nil {
+						return clientAborted()
+					}
+					cancel()
+					wg.Wait()
+					span.RecordError(err)
+					span.SetStatus(codes.Error, "failed to write SSE line")
+					return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+				}
 			}
 
 			// Process the data chunk
-			if data, found := strings.CutPrefix(line, dataPrefix); found {
+			if chunk != nil {
 				chunksReceived++
 
-				choice, usage := c.processStreamChunkForChannel(data)
+				if chunk.ID != "" {
+					chunkID = chunk.ID
+				}
+				if chunk.Model != "" {
+					chunkModel = chunk.Model
+				}
+
+				choice, usage := chunk.Choice, chunk.Usage
 
 				// Capture final usage if available
 				if usage != nil {
@@ -361,11 +585,22 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 				}
 
 				if choice != nil {
+					if firstTokenAt.IsZero() && hasTokenDelta(choice.Delta) {
+						firstTokenAt = time.Now()
+						span.AddEvent("first_token")
+					}
+
 					if choice.Delta.Content != "" {
 						contentBuilder.WriteString(choice.Delta.Content)
+						if speech != nil {
+							speech.write(choice.Delta.Content)
+						}
+						if request.Broadcast != nil {
+							request.Broadcast.WriteDelta(choice.Delta.Content)
+						}
 					}
 
-					if choice.Delta.ReasoningContent != "" {
+					if choice.Delta.ReasoningContent != "" && !request.HideReasoning {
 						reasoningBuilder.WriteString(choice.Delta.ReasoningContent)
 					}
 
@@ -379,27 +614,68 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 				}
 			}
 
+			if err := writeAudio(); err != nil {
+				if reqCtx.Request.Context().Err() != nil {
+					return clientAborted()
+				}
+				cancel()
+				wg.Wait()
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to write SSE audio chunk")
+				return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+			}
+
+		case clip, ok := <-speechClips:
+			takeClip(clip, ok)
+			if err := writeAudio(); err != nil {
+				if reqCtx.Request.Context().Err() != nil {
+					return clientAborted()
+				}
+				cancel()
+				wg.Wait()
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to write SSE audio chunk")
+				return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+			}
+
+		case <-heartbeat:
+			if !atEventBoundary || time.Since(lastWrite) < c.heartbeatInterval {
+				break
+			}
+			if err := writeLine(heartbeatComment + newlineChar); err != nil {
+				if reqCtx.Request.Context().Err() != nil {
+					return clientAborted()
+				}
+				cancel()
+				wg.Wait()
+				span.RecordError(err)
+				span.SetStatus(codes.Error, "failed to write SSE heartbeat")
+				return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "unable to write SSE line")
+			}
+
 		case err, ok := <-errChan:
 			if ok && err != nil {
+				if reqCtx.Request.Context().Err() != nil {
+					return clientAborted()
+				}
 				cancel()
 				wg.Wait()
 				span.RecordError(err)
 				span.SetStatus(codes.Error, "streaming error")
-				return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "streaming error")
+				return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, err, "streaming error")
 			}
 
 		case <-streamCtx.Done():
+			if reqCtx.Request.Context().Err() != nil {
+				return clientAborted()
+			}
 			wg.Wait()
 			span.RecordError(streamCtx.Err())
 			span.SetStatus(codes.Error, "streaming context cancelled")
-			return nil, platformerrors.AsError(ctx, platformerrors.LayerDomain, streamCtx.Err(), "streaming context cancelled")
+			return partialResponse(), platformerrors.AsError(ctx, platformerrors.LayerDomain, streamCtx.Err(), "streaming context cancelled")
 
 		case <-reqCtx.Request.Context().Done():
-			cancel()
-			wg.Wait()
-			span.RecordError(reqCtx.Request.Context().Err())
-			span.SetStatus(codes.Error, "client request cancelled")
-			return nil, platformerrors.AsError(reqCtx.Request.Context(), platformerrors.LayerDomain, reqCtx.Request.Context().Err(), "client request cancelled")
+			return clientAborted()
 		}
 	}
 
@@ -428,6 +704,11 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 
 	// Add token usage if available from streaming
 	if totalUsage != nil {
+		response.Usage = openai.Usage{
+			PromptTokens:     totalUsage.PromptTokens,
+			CompletionTokens: totalUsage.CompletionTokens,
+			TotalTokens:      totalUsage.TotalTokens,
+		}
 		span.SetAttributes(
 			attribute.Int("llm.usage.prompt_tokens", totalUsage.PromptTokens),
 			attribute.Int("llm.usage.completion_tokens", totalUsage.CompletionTokens),
@@ -442,6 +723,12 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 		)
 	}
 
+	completionTokens := response.Usage.CompletionTokens
+	if totalUsage != nil {
+		completionTokens = totalUsage.CompletionTokens
+	}
+	c.recordStreamTiming(span, request.Model, start, firstTokenAt, start.Add(duration), completionTokens)
+
 	// Add finish reason if available
 	if len(response.Choices) > 0 {
 		span.SetAttributes(attribute.String("llm.finish_reason", string(response.Choices[0].FinishReason)))
@@ -451,11 +738,43 @@ func (c *ChatCompletionClient) StreamChatCompletionToContextWithCallback(reqCtx
 	span.AddEvent("streaming_completed", trace.WithAttributes(
 		attribute.Int("chunks.total", chunksReceived),
 		attribute.Int("content.length", len(contentBuilder.String())),
+		attribute.Int("audio_chunks.total", audioChunks),
 	))
 
 	return &response, nil
 }
 
+// hasTokenDelta reports whether a stream chunk carries generated output, as
+// opposed to role-only or usage-only chunks.
+func hasTokenDelta(delta ChoiceDelta) bool {
+	return delta.Content != "" || delta.ReasoningContent != "" || delta.FunctionCall != nil || len(delta.ToolCalls) > 0
+}
+
+// recordStreamTiming records time-to-first-token and output throughput as
+// Prometheus histograms and span attributes. Total duration hides the latency
+// users perceive, so both are measured separately per model and provider.
+func (c *ChatCompletionClient) recordStreamTiming(span trace.Span, model string, start, firstTokenAt, end time.Time, completionTokens int) {
+	if firstTokenAt.IsZero() {
+		return
+	}
+
+	ttft := firstTokenAt.Sub(start)
+	generation := end.Sub(firstTokenAt)
+	metrics.RecordFirstToken(model, c.name, ttft.Seconds())
+	metrics.RecordStreamThroughput(model, c.name, completionTokens, generation.Seconds())
+
+	span.SetAttributes(
+		attribute.Int64("llm.streaming.time_to_first_token_ms", ttft.Milliseconds()),
+		attribute.Int64("llm.streaming.generation_ms", generation.Milliseconds()),
+	)
+	if completionTokens > 0 && generation > 0 {
+		span.SetAttributes(attribute.Float64("llm.streaming.tokens_per_second", float64(completionTokens)/generation.Seconds()))
+	}
+	if completionTokens > 1 {
+		span.SetAttributes(attribute.Float64("llm.streaming.inter_token_latency_ms", float64(generation.Milliseconds())/float64(completionTokens-1)))
+	}
+}
+
 func (c *ChatCompletionClient) SetupSSEHeaders(reqCtx *gin.Context) {
 	if reqCtx == nil {
 		return
@@ -464,6 +783,7 @@ func (c *ChatCompletionClient) SetupSSEHeaders(reqCtx *gin.Context) {
 	reqCtx.Header("Content-Type", "text/event-stream")
 	reqCtx.Header("Cache-Control", "no-cache")
 	reqCtx.Header("Connection", "keep-alive")
+	reqCtx.Header("X-Accel-Buffering", "no")
 	reqCtx.Header("Access-Control-Allow-Origin", "*")
 	reqCtx.Header("Access-Control-Allow-Headers", "Cache-Control")
 	reqCtx.Header("Transfer-Encoding", "chunked")
@@ -505,10 +825,19 @@ func (c *ChatCompletionClient) errorFromResponse(ctx context.Context, resp *rest
 		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeExternal, message, nil, "8cd2cae7-9ad9-40fe-ac00-8f9b24251064")
 	}
 	trimmed := strings.TrimSpace(string(body))
-	if trimmed == "" {
-		return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeExternal, message, nil, "b8797de4-38cb-4bd9-9ae8-b9a04e70f6ab")
+	errorType, errorUUID := classifyProviderError(resp.StatusCode(), trimmed)
+	if errorType == platformerrors.ErrorTypeExternal && trimmed == "" {
+		errorUUID = "b8797de4-38cb-4bd9-9ae8-b9a04e70f6ab"
+	}
+	if trimmed != "" {
+		message = fmt.Sprintf("%s: %s", message, trimmed)
+	}
+
+	contextFields := map[string]any{"provider_status": resp.StatusCode()}
+	if retryAfter := strings.TrimSpace(resp.RawResponse.Header.Get("Retry-After")); retryAfter != "" {
+		contextFields[platformerrors.ContextKeyRetryAfter] = retryAfter
 	}
-	return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeExternal, fmt.Sprintf("%s: %s", message, trimmed), nil, "a1f46e0d-4017-4411-ac05-987946c3066d")
+	return platformerrors.NewErrorWithContext(ctx, platformerrors.LayerDomain, errorType, message, nil, errorUUID, contextFields)
 }
 
 func (c *ChatCompletionClient) doStreamingRequest(ctx context.Context, apiKey string, request CompletionRequest, opts ...StreamOption) (*resty.Response, error) {
@@ -601,14 +930,16 @@ func (c *ChatCompletionClient) writeSSELine(reqCtx *gin.Context, line string) er
 	return nil
 }
 
-func (c *ChatCompletionClient) processStreamChunkForChannel(data string) (*StreamChoice, *TokenUsage) {
+func (c *ChatCompletionClient) processStreamChunkForChannel(data string) *streamChunk {
 	var streamData struct {
+		ID      string         `json:"id"`
+		Model   string         `json:"model"`
 		Choices []StreamChoice `json:"choices"`
 		Usage   *TokenUsage    `json:"usage"`
 	}
 
 	if err := json.Unmarshal([]byte(data), &streamData); err != nil {
-		return nil, nil
+		return nil
 	}
 
 	result := &StreamChoice{
@@ -634,7 +965,84 @@ func (c *ChatCompletionClient) processStreamChunkForChannel(data string) (*Strea
 		}
 	}
 
-	return result, streamData.Usage
+	return &streamChunk{
+		ID:         streamData.ID,
+		Model:      streamData.Model,
+		Choice:     result,
+		Usage:      streamData.Usage,
+		HasChoices: len(streamData.Choices) > 0,
+	}
+}
+
+// redactReasoningLine removes reasoning deltas from an SSE data line. It
+// reports true when nothing else is left in the chunk, so the line is skipped.
+func redactReasoningLine(line string) (string, bool) {
+	data, found := strings.CutPrefix(line, dataPrefix)
+	if !found || !strings.Contains(data, "reasoning") {
+		return line, false
+	}
+
+	decoder := json.NewDecoder(strings.NewReader(data))
+	decoder.UseNumber()
+	var chunk map[string]any
+	if err := decoder.Decode(&chunk); err != nil {
+		return line, false
+	}
+
+	choices, _ := chunk["choices"].([]any)
+	empty := len(choices) > 0 && chunk["usage"] == nil
+	for _, raw := range choices {
+		choice, _ := raw.(map[string]any)
+		if reason, _ := choice["finish_reason"].(string); reason != "" {
+			empty = false
+		}
+		delta, _ := choice["delta"].(map[string]any)
+		for _, field := range reasoningDeltaFields {
+			delete(delta, field)
+		}
+		for key, value := range delta {
+			if key != "role" && value != nil && value != "" {
+				empty = false
+			}
+		}
+	}
+	if empty {
+		return "", true
+	}
+
+	redacted, err := json.Marshal(chunk)
+	if err != nil {
+		return "", true
+	}
+	return dataPrefix + string(redacted), false
+}
+
+// usageChunkLine renders an OpenAI-style final usage chunk (empty choices) as an SSE data line.
+func usageChunkLine(id, model, fallbackModel string, usage openai.Usage) string {
+	if model == "" {
+		model = fallbackModel
+	}
+	chunk := struct {
+		ID      string     `json:"id"`
+		Object  string     `json:"object"`
+		Created int64      `json:"created"`
+		Model   string     `json:"model"`
+		Choices []struct{} `json:"choices"`
+		Usage   TokenUsage `json:"usage"`
+	}{
+		ID:      id,
+		Object:  "chat.completion.chunk",
+		Created: time.Now().Unix(),
+		Model:   model,
+		Choices: []struct{}{},
+		Usage: TokenUsage{
+			PromptTokens:     usage.PromptTokens,
+			CompletionTokens: usage.CompletionTokens,
+			TotalTokens:      usage.TotalTokens,
+		},
+	}
+	payload, _ := json.Marshal(chunk)
+	return dataPrefix + string(payload) + newlineChar
 }
 
 func (c *ChatCompletionClient) handleStreamingFunctionCall(functionCall *openai.FunctionCall, accumulator map[int]*functionCallAccumulator) {
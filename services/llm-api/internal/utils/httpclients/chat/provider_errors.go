@@ -0,0 +1,69 @@
+package chat
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"jan-server/services/llm-api/internal/utils/platformerrors"
+)
+
+// providerErrorBody is the OpenAI-compatible error envelope most providers return
+type providerErrorBody struct {
+	Error struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+		Code    any    `json:"code"`
+	} `json:"error"`
+}
+
+// Phrases providers use when the error body carries no usable code
+var (
+	contextLengthPhrases = []string{
+		"context_length_exceeded",
+		"maximum context length",
+		"context window",
+		"prompt is too long",
+		"too many tokens",
+	}
+	contentFilterPhrases = []string{
+		"content_filter",
+		"content_policy_violation",
+		"content management policy",
+		"safety system",
+	}
+)
+
+// classifyProviderError maps a failed provider response to a platform error type and
+// the UUID identifying that failure class.
+func classifyProviderError(status int, body string) (platformerrors.ErrorType, string) {
+	var parsed providerErrorBody
+	_ = json.Unmarshal([]byte(body), &parsed)
+
+	code, _ := parsed.Error.Code.(string)
+	signals := strings.ToLower(strings.Join([]string{code, parsed.Error.Type, parsed.Error.Message, body}, " "))
+
+	switch {
+	case status == http.StatusTooManyRequests || code == "rate_limit_exceeded":
+		return platformerrors.ErrorTypeRateLimited, "4e8f1a27-6b3c-4d95-a0e2-7c1b9f5d3a86"
+	case status == http.StatusUnauthorized || status == http.StatusForbidden:
+		return platformerrors.ErrorTypeProviderAuth, "9a2c6e41-0d7b-4f38-b5e9-1c4a8d2f6b07"
+	case status == http.StatusRequestTimeout || status == http.StatusGatewayTimeout:
+		return platformerrors.ErrorTypeProviderTimeout, "c7d03b5e-2f91-4a6c-8e14-5b9a0d3c7f62"
+	case status == http.StatusRequestEntityTooLarge || containsAny(signals, contextLengthPhrases):
+		return platformerrors.ErrorTypeContextLengthExceeded, "2b5d8f13-a4e6-4c07-9f3b-6d1e0a8c4b95"
+	case containsAny(signals, contentFilterPhrases):
+		return platformerrors.ErrorTypeContentFiltered, "e6a91c04-5b2d-4f7e-8c3a-0f9d6b1e2a73"
+	default:
+		return platformerrors.ErrorTypeExternal, "a1f46e0d-4017-4411-ac05-987946c3066d"
+	}
+}
+
+func containsAny(s string, phrases []string) bool {
+	for _, phrase := range phrases {
+		if strings.Contains(s, phrase) {
+			return true
+		}
+	}
+	return false
+}
@@ -2,6 +2,7 @@ package httpclients
 
 import (
 	"context"
+	"net/http"
 	"time"
 
 	"jan-server/services/llm-api/internal/infrastructure/logger"
@@ -13,8 +14,13 @@ type RequestID struct{}
 type HTTPClientStartsAt struct{}
 type HTTPClientRequestBody struct{}
 
-func NewClient(clientName string) *resty.Client {
+// NewClient creates a logging resty client named clientName for debug logs. A
+// non-nil httpClient supplies the pooled transport shared by its target.
+func NewClient(clientName string, httpClient *http.Client) *resty.Client {
 	client := resty.New()
+	if httpClient != nil {
+		client = resty.NewWithClient(httpClient)
+	}
 	client.AddRequestMiddleware(func(c *resty.Client, r *resty.Request) error {
 		start := time.Now()
 		ctx := context.WithValue(r.Context(), HTTPClientStartsAt{}, start)
@@ -24,18 +24,34 @@ func getRequestIDFromContext(ctx context.Context) string {
 type ErrorType string
 
 const (
-	ErrorTypeNotFound       ErrorType = "NOT_FOUND"
-	ErrorTypeTooManyRecords ErrorType = "TOO_MANY_RECORDS"
-	ErrorTypeValidation     ErrorType = "VALIDATION"
-	ErrorTypeConflict       ErrorType = "CONFLICT"
-	ErrorTypeUnauthorized   ErrorType = "UNAUTHORIZED"
-	ErrorTypeForbidden      ErrorType = "FORBIDDEN"
-	ErrorTypeInternal       ErrorType = "INTERNAL"
-	ErrorTypeExternal       ErrorType = "EXTERNAL"
-	ErrorTypeDatabaseError  ErrorType = "DATABASE_ERROR"
-	ErrorTypeNotImplemented ErrorType = "NOT_IMPLEMENTED"
+	ErrorTypeNotFound        ErrorType = "NOT_FOUND"
+	ErrorTypeTooManyRecords  ErrorType = "TOO_MANY_RECORDS"
+	ErrorTypeValidation      ErrorType = "VALIDATION"
+	ErrorTypeConflict        ErrorType = "CONFLICT"
+	ErrorTypeUnauthorized    ErrorType = "UNAUTHORIZED"
+	ErrorTypeForbidden       ErrorType = "FORBIDDEN"
+	ErrorTypeInternal        ErrorType = "INTERNAL"
+	ErrorTypeExternal        ErrorType = "EXTERNAL"
+	ErrorTypeDatabaseError   ErrorType = "DATABASE_ERROR"
+	ErrorTypeNotImplemented  ErrorType = "NOT_IMPLEMENTED"
+	ErrorTypePayloadTooLarge ErrorType = "PAYLOAD_TOO_LARGE"
+	ErrorTypeCursorExpired   ErrorType = "CURSOR_EXPIRED"
+
+	// The guest must solve a CAPTCHA and resend the request with its token
+	ErrorTypeCaptchaRequired ErrorType = "CAPTCHA_REQUIRED"
+
+	// Model provider failures, classified so clients can decide whether to retry
+	ErrorTypeRateLimited           ErrorType = "RATE_LIMITED"
+	ErrorTypeContextLengthExceeded ErrorType = "CONTEXT_LENGTH_EXCEEDED"
+	ErrorTypeContentFiltered       ErrorType = "CONTENT_FILTERED"
+	ErrorTypeProviderAuth          ErrorType = "PROVIDER_AUTH"
+	ErrorTypeProviderTimeout       ErrorType = "PROVIDER_TIMEOUT"
+	ErrorTypeMaintenance           ErrorType = "MAINTENANCE" // Maintenance mode is on and the request would change data
 )
 
+// ContextKeyRetryAfter holds the provider's Retry-After value (seconds) on rate limit errors
+const ContextKeyRetryAfter = "retry_after"
+
 // Layer represents the application layer where the error occurred
 type Layer string
 
@@ -175,16 +191,30 @@ func ErrorTypeToHTTPStatus(errorType ErrorType) int {
 		return http.StatusConflict
 	case ErrorTypeUnauthorized:
 		return http.StatusUnauthorized
-	case ErrorTypeForbidden:
+	case ErrorTypeForbidden, ErrorTypeCaptchaRequired:
 		return http.StatusForbidden
 	case ErrorTypeNotImplemented:
 		return http.StatusNotImplemented
+	case ErrorTypePayloadTooLarge:
+		return http.StatusRequestEntityTooLarge
+	case ErrorTypeCursorExpired:
+		return http.StatusGone
 	case ErrorTypeTooManyRecords:
 		return http.StatusInternalServerError
 	case ErrorTypeDatabaseError:
 		return http.StatusInternalServerError
-	case ErrorTypeExternal:
+	case ErrorTypeExternal, ErrorTypeProviderAuth:
 		return http.StatusBadGateway
+	case ErrorTypeRateLimited:
+		return http.StatusTooManyRequests
+	case ErrorTypeContextLengthExceeded:
+		return http.StatusBadRequest
+	case ErrorTypeContentFiltered:
+		return http.StatusUnprocessableEntity
+	case ErrorTypeProviderTimeout:
+		return http.StatusGatewayTimeout
+	case ErrorTypeMaintenance:
+		return http.StatusServiceUnavailable
 	case ErrorTypeInternal:
 		fallthrough
 	default:
@@ -211,6 +241,31 @@ func IsValidationError(err error) bool {
 	return IsErrorType(err, ErrorTypeValidation)
 }
 
+// IsProviderError checks if an error is a classified model provider failure
+func IsProviderError(err error) bool {
+	return IsErrorType(err, ErrorTypeRateLimited) ||
+		IsErrorType(err, ErrorTypeContextLengthExceeded) ||
+		IsErrorType(err, ErrorTypeContentFiltered) ||
+		IsErrorType(err, ErrorTypeProviderAuth) ||
+		IsErrorType(err, ErrorTypeProviderTimeout)
+}
+
+// ContextValue returns the first context field stored under key along the error chain.
+// AsError does not copy context fields, so wrapped errors are searched as well.
+func ContextValue(err error, key string) (any, bool) {
+	for err != nil {
+		var platformErr *PlatformError
+		if !errors.As(err, &platformErr) {
+			return nil, false
+		}
+		if value, ok := platformErr.Context[key]; ok {
+			return value, true
+		}
+		err = platformErr.Err
+	}
+	return nil, false
+}
+
 // LogError logs a platform error with proper structure
 func LogError(logger zerolog.Logger, err *PlatformError) {
 	if err == nil {
@@ -0,0 +1,8 @@
+-- Add composite index for loading the most recent items of a conversation branch.
+-- Chat completions read only the tail of the active branch (ORDER BY id DESC LIMIT n)
+-- instead of materializing the whole conversation on every turn.
+CREATE INDEX IF NOT EXISTS idx_conversation_items_conv_branch_id
+ON llm_api.conversation_items(conversation_id, branch, id DESC)
+WHERE deleted_at IS NULL;
+
+COMMENT ON INDEX llm_api.idx_conversation_items_conv_branch_id IS 'Composite index for loading the latest items of a conversation branch';
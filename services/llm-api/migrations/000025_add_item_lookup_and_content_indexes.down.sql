@@ -0,0 +1,12 @@
+-- Migration: 000025_add_item_lookup_and_content_indexes (DOWN)
+
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_content_path;
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_content_fts;
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_conv_status;
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_conv_branch_type;
+
+CREATE INDEX IF NOT EXISTS idx_conversation_items_conv_call_id
+ON llm_api.conversation_items(conversation_id, call_id)
+WHERE call_id IS NOT NULL;
+
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_conv_call_id_type;
@@ -0,0 +1,38 @@
+-- Migration: 000025_add_item_lookup_and_content_indexes
+-- Purpose: Index the item columns used by tool-call PATCH, type/status filters and
+-- content search. call_id, type, branch and status are already first-class columns;
+-- this adds the composite and GIN indexes those queries need.
+-- Benchmark: scripts/benchmarks/conversation_items_indexes.sql (run before and after).
+
+-- PATCH /conversations/{id}/items/by-call-id/{call_id} filters on conversation, call_id and type.
+-- Supersedes idx_conversation_items_conv_call_id, which is a prefix of this index.
+CREATE INDEX IF NOT EXISTS idx_conversation_items_conv_call_id_type
+ON llm_api.conversation_items(conversation_id, call_id, type)
+WHERE call_id IS NOT NULL AND deleted_at IS NULL;
+
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_conv_call_id;
+
+-- Type and status filters within a conversation branch.
+CREATE INDEX IF NOT EXISTS idx_conversation_items_conv_branch_type
+ON llm_api.conversation_items(conversation_id, branch, type)
+WHERE deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_conversation_items_conv_status
+ON llm_api.conversation_items(conversation_id, status)
+WHERE deleted_at IS NULL;
+
+-- Full-text search over every string value in content (item search).
+CREATE INDEX IF NOT EXISTS idx_conversation_items_content_fts
+ON llm_api.conversation_items
+USING GIN (jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]'));
+
+-- Containment queries on content (content @> '[{"type": "input_image"}]').
+CREATE INDEX IF NOT EXISTS idx_conversation_items_content_path
+ON llm_api.conversation_items
+USING GIN (content jsonb_path_ops);
+
+COMMENT ON INDEX llm_api.idx_conversation_items_conv_call_id_type IS 'Tool call lookup by conversation, call_id and item type';
+COMMENT ON INDEX llm_api.idx_conversation_items_conv_branch_type IS 'Item type filter within a conversation branch';
+COMMENT ON INDEX llm_api.idx_conversation_items_conv_status IS 'Item status filter within a conversation';
+COMMENT ON INDEX llm_api.idx_conversation_items_content_fts IS 'Full-text search over item content strings';
+COMMENT ON INDEX llm_api.idx_conversation_items_content_path IS 'JSONB containment queries on item content';
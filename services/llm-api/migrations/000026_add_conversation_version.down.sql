@@ -0,0 +1,2 @@
+ALTER TABLE llm_api.conversations
+    DROP COLUMN IF EXISTS version;
@@ -0,0 +1,5 @@
+-- Add optimistic concurrency version to conversations.
+-- Updates compare-and-swap on this column so concurrent writers (background title
+-- generation vs. user rename) fail with 409 instead of silently overwriting each other.
+ALTER TABLE llm_api.conversations
+    ADD COLUMN IF NOT EXISTS version INTEGER NOT NULL DEFAULT 1;
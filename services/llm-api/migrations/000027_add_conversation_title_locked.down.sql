@@ -0,0 +1,5 @@
+UPDATE llm_api.conversations
+SET metadata = COALESCE(metadata, '{}'::jsonb) || jsonb_build_object('title_locked', CASE WHEN title_locked THEN 'true' ELSE 'false' END);
+
+ALTER TABLE llm_api.conversations
+    DROP COLUMN IF EXISTS title_locked;
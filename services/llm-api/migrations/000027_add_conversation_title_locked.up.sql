@@ -0,0 +1,12 @@
+-- Promote title_locked from a metadata string to a first-class column.
+-- A locked title is never overwritten by automatic title generation.
+ALTER TABLE llm_api.conversations
+    ADD COLUMN IF NOT EXISTS title_locked BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE llm_api.conversations
+SET title_locked = true
+WHERE metadata->>'title_locked' = 'true';
+
+UPDATE llm_api.conversations
+SET metadata = metadata - 'title_locked'
+WHERE metadata ? 'title_locked';
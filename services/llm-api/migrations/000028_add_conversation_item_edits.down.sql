@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS llm_api.idx_conversation_item_edits_item_id;
+DROP TABLE IF EXISTS llm_api.conversation_item_edits;
+
+ALTER TABLE llm_api.conversation_items
+    DROP COLUMN IF EXISTS edited_at;
@@ -0,0 +1,21 @@
+-- Soft-edit support for conversation items.
+-- Edits overwrite content/output in place; the previous values are kept in
+-- conversation_item_edits so the original is always recoverable for audit.
+ALTER TABLE llm_api.conversation_items
+    ADD COLUMN IF NOT EXISTS edited_at TIMESTAMP;
+
+CREATE TABLE IF NOT EXISTS llm_api.conversation_item_edits (
+    id SERIAL PRIMARY KEY,
+    item_id INTEGER NOT NULL REFERENCES llm_api.conversation_items(id) ON DELETE CASCADE,
+    conversation_id INTEGER NOT NULL REFERENCES llm_api.conversations(id) ON DELETE CASCADE,
+    editor_user_id INTEGER REFERENCES llm_api.users(id) ON DELETE SET NULL,
+    previous_content JSONB,
+    previous_output TEXT,
+    reason TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_item_edits_item_id
+    ON llm_api.conversation_item_edits (item_id, id);
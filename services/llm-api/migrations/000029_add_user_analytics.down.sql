@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS llm_api.user_daily_tool_usage;
+DROP TABLE IF EXISTS llm_api.user_daily_model_usage;
+DROP TABLE IF EXISTS llm_api.user_daily_activity;
+DROP TABLE IF EXISTS llm_api.completion_events;
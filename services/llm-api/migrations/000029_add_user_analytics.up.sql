@@ -0,0 +1,54 @@
+-- Per-user analytics.
+-- completion_events records one row per chat completion; the user_daily_* rollup
+-- tables are rebuilt from it (and from conversation_items) by the nightly job so
+-- dashboard queries never scan raw events.
+CREATE TABLE IF NOT EXISTS llm_api.completion_events (
+    id BIGSERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL,
+    conversation_id INTEGER,
+    model VARCHAR(255) NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    tool_calls JSONB,
+    stream BOOLEAN NOT NULL DEFAULT FALSE,
+    succeeded BOOLEAN NOT NULL DEFAULT TRUE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_completion_events_created_at
+    ON llm_api.completion_events (created_at);
+
+CREATE TABLE IF NOT EXISTS llm_api.user_daily_activity (
+    user_id INTEGER NOT NULL,
+    activity_date DATE NOT NULL,
+    user_messages INTEGER NOT NULL DEFAULT 0,
+    assistant_messages INTEGER NOT NULL DEFAULT 0,
+    completions INTEGER NOT NULL DEFAULT 0,
+    failed_completions INTEGER NOT NULL DEFAULT 0,
+    total_latency_ms BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, activity_date)
+);
+
+CREATE TABLE IF NOT EXISTS llm_api.user_daily_model_usage (
+    user_id INTEGER NOT NULL,
+    activity_date DATE NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    requests INTEGER NOT NULL DEFAULT 0,
+    prompt_tokens BIGINT NOT NULL DEFAULT 0,
+    completion_tokens BIGINT NOT NULL DEFAULT 0,
+    total_latency_ms BIGINT NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, activity_date, model)
+);
+
+CREATE TABLE IF NOT EXISTS llm_api.user_daily_tool_usage (
+    user_id INTEGER NOT NULL,
+    activity_date DATE NOT NULL,
+    tool_name VARCHAR(255) NOT NULL,
+    calls INTEGER NOT NULL DEFAULT 0,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (user_id, activity_date, tool_name)
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS llm_api.model_comparison_results;
+DROP TABLE IF EXISTS llm_api.model_comparisons;
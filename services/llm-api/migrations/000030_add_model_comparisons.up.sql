@@ -0,0 +1,41 @@
+-- Model comparison (arena mode).
+-- model_comparisons holds one row per POST /v1/compare run and the user's vote;
+-- model_comparison_results holds the outcome of each model in the run. The
+-- leaderboard is computed from voted comparisons.
+CREATE TABLE IF NOT EXISTS llm_api.model_comparisons (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL,
+    models JSONB NOT NULL,
+    vote VARCHAR(20),
+    winner_slot INTEGER,
+    voted_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_model_comparisons_user_id
+    ON llm_api.model_comparisons (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_model_comparisons_voted_at
+    ON llm_api.model_comparisons (voted_at)
+    WHERE vote IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS llm_api.model_comparison_results (
+    id SERIAL PRIMARY KEY,
+    comparison_id INTEGER NOT NULL REFERENCES llm_api.model_comparisons(id) ON DELETE CASCADE,
+    slot INTEGER NOT NULL,
+    model VARCHAR(255) NOT NULL,
+    provider VARCHAR(255) NOT NULL DEFAULT '',
+    prompt_tokens INTEGER NOT NULL DEFAULT 0,
+    completion_tokens INTEGER NOT NULL DEFAULT 0,
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    succeeded BOOLEAN NOT NULL DEFAULT TRUE,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (comparison_id, slot)
+);
+
+CREATE INDEX IF NOT EXISTS idx_model_comparison_results_model
+    ON llm_api.model_comparison_results (model);
@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS llm_api.eval_run_results;
+DROP TABLE IF EXISTS llm_api.eval_runs;
+DROP TABLE IF EXISTS llm_api.eval_dataset_items;
+DROP TABLE IF EXISTS llm_api.eval_datasets;
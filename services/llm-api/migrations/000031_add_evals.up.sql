@@ -0,0 +1,73 @@
+-- Evaluation harness.
+-- eval_datasets/eval_dataset_items hold uploaded prompts with their expected answers
+-- and grader specs; eval_runs is a queue of dataset runs against a model and prompt
+-- template (claimed by workers with SKIP LOCKED), and eval_run_results stores the
+-- graded output of every item so runs can be compared across template versions.
+CREATE TABLE IF NOT EXISTS llm_api.eval_datasets (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    grader JSONB NOT NULL,
+    item_count INTEGER NOT NULL DEFAULT 0,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE TABLE IF NOT EXISTS llm_api.eval_dataset_items (
+    id SERIAL PRIMARY KEY,
+    dataset_id INTEGER NOT NULL REFERENCES llm_api.eval_datasets(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    input TEXT NOT NULL,
+    variables JSONB,
+    expected TEXT NOT NULL DEFAULT '',
+    grader JSONB,
+    UNIQUE (dataset_id, position)
+);
+
+CREATE TABLE IF NOT EXISTS llm_api.eval_runs (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    dataset_id INTEGER NOT NULL REFERENCES llm_api.eval_datasets(id) ON DELETE CASCADE,
+    model VARCHAR(255) NOT NULL,
+    prompt_template_id VARCHAR(64),
+    template_key VARCHAR(100),
+    template_version INTEGER,
+    system_prompt TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    total_items INTEGER NOT NULL DEFAULT 0,
+    completed_items INTEGER NOT NULL DEFAULT 0,
+    passed_items INTEGER NOT NULL DEFAULT 0,
+    errored_items INTEGER NOT NULL DEFAULT 0,
+    average_score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_eval_runs_dataset_id
+    ON llm_api.eval_runs (dataset_id);
+
+CREATE INDEX IF NOT EXISTS idx_eval_runs_status
+    ON llm_api.eval_runs (status)
+    WHERE status IN ('queued', 'running');
+
+CREATE TABLE IF NOT EXISTS llm_api.eval_run_results (
+    id SERIAL PRIMARY KEY,
+    run_id INTEGER NOT NULL REFERENCES llm_api.eval_runs(id) ON DELETE CASCADE,
+    item_id INTEGER NOT NULL,
+    position INTEGER NOT NULL,
+    output TEXT NOT NULL DEFAULT '',
+    score DOUBLE PRECISION NOT NULL DEFAULT 0,
+    passed BOOLEAN NOT NULL DEFAULT FALSE,
+    grader VARCHAR(20) NOT NULL,
+    reasoning TEXT NOT NULL DEFAULT '',
+    latency_ms INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (run_id, item_id)
+);
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_rating_like;
+DROP TABLE IF EXISTS llm_api.finetune_exports;
@@ -0,0 +1,27 @@
+-- Fine-tuning dataset exports.
+-- Each row is one export job that converts liked assistant turns and their preceding
+-- context into a JSONL training file; the file itself is stored in media-api and
+-- referenced by media_id once the job completes.
+CREATE TABLE IF NOT EXISTS llm_api.finetune_exports (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    format VARCHAR(20) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    redact_pii BOOLEAN NOT NULL DEFAULT TRUE,
+    rated_after TIMESTAMPTZ,
+    rated_before TIMESTAMPTZ,
+    max_context_messages INTEGER NOT NULL DEFAULT 0,
+    example_count INTEGER NOT NULL DEFAULT 0,
+    media_id VARCHAR(64),
+    bytes BIGINT NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255),
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Liked items are looked up by rating when an export runs
+CREATE INDEX IF NOT EXISTS idx_conversation_items_rating_like
+    ON llm_api.conversation_items (id)
+    WHERE rating = 'like' AND deleted_at IS NULL;
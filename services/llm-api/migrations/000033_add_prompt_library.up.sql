@@ -0,0 +1,24 @@
+-- Prompt library of conversation starters.
+-- Rows without user_id are global starters managed by admins; rows with user_id are
+-- private starters of that user. messages holds the templated first messages of a
+-- conversation and variables describes the placeholders they use.
+CREATE TABLE IF NOT EXISTS llm_api.prompt_library (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER REFERENCES llm_api.users(id) ON DELETE CASCADE,
+    title VARCHAR(255) NOT NULL,
+    description TEXT,
+    category VARCHAR(100) NOT NULL,
+    messages JSONB NOT NULL,
+    variables JSONB,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_prompt_library_user_id
+    ON llm_api.prompt_library (user_id);
+
+CREATE INDEX IF NOT EXISTS idx_prompt_library_category
+    ON llm_api.prompt_library (category);
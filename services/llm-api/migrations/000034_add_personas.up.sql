@@ -0,0 +1,20 @@
+-- Custom personas: user-defined assistants with their own system instructions.
+-- enabled_tools and starter_questions are JSON string arrays; an empty enabled_tools
+-- list leaves the request's tools untouched.
+CREATE TABLE IF NOT EXISTS llm_api.personas (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES llm_api.users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    avatar VARCHAR(2048),
+    instructions TEXT NOT NULL,
+    default_model VARCHAR(255),
+    enabled_tools JSONB,
+    starter_questions JSONB,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_personas_user_id
+    ON llm_api.personas (user_id);
@@ -0,0 +1,41 @@
+-- Seed built-in weather, world time and holiday MCP tools into admin_mcp_tools
+SET search_path TO llm_api;
+
+INSERT INTO llm_api.admin_mcp_tools (
+    public_id,
+    tool_key,
+    name,
+    description,
+    category,
+    is_active,
+    disallowed_keywords
+)
+VALUES
+(
+    'mcp_get_weather_001',
+    'get_weather',
+    'get_weather',
+    'Get the current weather for a city or place (params: location, units).',
+    'world_info',
+    true,
+    ARRAY[]::TEXT[]
+),
+(
+    'mcp_get_world_time_001',
+    'get_world_time',
+    'get_world_time',
+    'Get the current local date and time in a timezone or place (params: timezone, location).',
+    'world_info',
+    true,
+    ARRAY[]::TEXT[]
+),
+(
+    'mcp_list_holidays_001',
+    'list_holidays',
+    'list_holidays',
+    'List the public holidays of a country for a year (params: country_code, year, upcoming_only).',
+    'world_info',
+    true,
+    ARRAY[]::TEXT[]
+)
+ON CONFLICT (tool_key) DO NOTHING;
@@ -0,0 +1,23 @@
+-- Seed the headless browser render_page MCP tool into admin_mcp_tools
+SET search_path TO llm_api;
+
+INSERT INTO llm_api.admin_mcp_tools (
+    public_id,
+    tool_key,
+    name,
+    description,
+    category,
+    is_active,
+    disallowed_keywords
+)
+VALUES
+(
+    'mcp_render_page_001',
+    'render_page',
+    'render_page',
+    'Render a JavaScript-heavy web page in a headless browser and return its visible text, optionally with a screenshot (params: url, wait_for_selector, timeout_seconds, screenshot, full_page).',
+    'scrape',
+    true,
+    ARRAY[]::TEXT[]
+)
+ON CONFLICT (tool_key) DO NOTHING;
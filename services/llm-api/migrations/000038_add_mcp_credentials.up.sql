@@ -0,0 +1,18 @@
+-- Per-user credential store for first-party MCP tools (e.g. GitHub).
+-- Tokens are AES-GCM encrypted with MCP_CREDENTIAL_SECRET; one credential per user and provider.
+CREATE TABLE IF NOT EXISTS llm_api.mcp_credentials (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    user_id INTEGER NOT NULL REFERENCES llm_api.users(id) ON DELETE CASCADE,
+    provider VARCHAR(50) NOT NULL,
+    encrypted_token TEXT NOT NULL,
+    account VARCHAR(255),
+    scopes JSONB,
+    expires_at TIMESTAMPTZ,
+    last_used_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_mcp_credentials_user_provider
+    ON llm_api.mcp_credentials (user_id, provider);
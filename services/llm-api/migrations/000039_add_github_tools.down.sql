@@ -0,0 +1,4 @@
+SET search_path TO llm_api;
+
+DELETE FROM llm_api.admin_mcp_tools
+WHERE tool_key IN ('search_code', 'read_file', 'list_issues', 'create_issue_comment');
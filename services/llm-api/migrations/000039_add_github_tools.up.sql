@@ -0,0 +1,50 @@
+-- Seed the GitHub MCP tools into admin_mcp_tools
+SET search_path TO llm_api;
+
+INSERT INTO llm_api.admin_mcp_tools (
+    public_id,
+    tool_key,
+    name,
+    description,
+    category,
+    is_active,
+    disallowed_keywords
+)
+VALUES
+(
+    'mcp_search_code_001',
+    'search_code',
+    'search_code',
+    'Search code on GitHub as the user, including their private repositories (params: query, repo, limit). Requires a GitHub credential connected via /v1/mcp-credentials/github.',
+    'github',
+    true,
+    ARRAY[]::TEXT[]
+),
+(
+    'mcp_read_file_001',
+    'read_file',
+    'read_file',
+    'Read a file or list a directory in a GitHub repository the user can access (params: repo, path, ref).',
+    'github',
+    true,
+    ARRAY[]::TEXT[]
+),
+(
+    'mcp_list_issues_001',
+    'list_issues',
+    'list_issues',
+    'List issues and pull requests of a GitHub repository (params: repo, state, labels, limit).',
+    'github',
+    true,
+    ARRAY[]::TEXT[]
+),
+(
+    'mcp_create_issue_comment_001',
+    'create_issue_comment',
+    'create_issue_comment',
+    'Post a comment on a GitHub issue or pull request as the user (params: repo, issue_number, body). Only use when the user explicitly asks.',
+    'github',
+    true,
+    ARRAY[]::TEXT[]
+)
+ON CONFLICT (tool_key) DO NOTHING;
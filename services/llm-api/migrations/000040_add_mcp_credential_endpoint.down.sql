@@ -0,0 +1 @@
+ALTER TABLE llm_api.mcp_credentials DROP COLUMN IF EXISTS endpoint;
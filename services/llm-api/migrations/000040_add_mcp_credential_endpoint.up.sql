@@ -0,0 +1,2 @@
+-- Server URL for self-hosted MCP credential providers (CalDAV calendar collection)
+ALTER TABLE llm_api.mcp_credentials ADD COLUMN IF NOT EXISTS endpoint VARCHAR(2048);
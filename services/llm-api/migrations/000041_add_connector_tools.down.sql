@@ -0,0 +1,4 @@
+SET search_path TO llm_api;
+
+DELETE FROM llm_api.admin_mcp_tools
+WHERE tool_key IN ('list_calendar_events', 'search_emails');
@@ -0,0 +1,32 @@
+-- Seed the read-only calendar and email connector MCP tools into admin_mcp_tools
+SET search_path TO llm_api;
+
+INSERT INTO llm_api.admin_mcp_tools (
+    public_id,
+    tool_key,
+    name,
+    description,
+    category,
+    is_active,
+    disallowed_keywords
+)
+VALUES
+(
+    'mcp_list_calendar_events_001',
+    'list_calendar_events',
+    'list_calendar_events',
+    'List the user''s upcoming calendar events from their connected Google, Outlook or CalDAV calendar (params: provider, start_date, days, timezone, limit). Read-only; requires calendar:read consent.',
+    'connectors',
+    true,
+    ARRAY[]::TEXT[]
+),
+(
+    'mcp_search_emails_001',
+    'search_emails',
+    'search_emails',
+    'Search the user''s recent emails in their connected Gmail or Outlook mailbox and return senders, subjects and redacted snippets (params: query, provider, days, limit). Read-only; requires mail:read consent.',
+    'connectors',
+    true,
+    ARRAY[]::TEXT[]
+)
+ON CONFLICT (tool_key) DO NOTHING;
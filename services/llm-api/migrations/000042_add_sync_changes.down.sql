@@ -0,0 +1,8 @@
+DROP TRIGGER IF EXISTS trg_user_settings_sync ON llm_api.user_settings;
+DROP TRIGGER IF EXISTS trg_conversation_items_sync ON llm_api.conversation_items;
+DROP TRIGGER IF EXISTS trg_conversations_sync ON llm_api.conversations;
+DROP FUNCTION IF EXISTS llm_api.record_user_settings_change();
+DROP FUNCTION IF EXISTS llm_api.record_conversation_item_change();
+DROP FUNCTION IF EXISTS llm_api.record_conversation_change();
+DROP FUNCTION IF EXISTS llm_api.sync_operation(TEXT, TIMESTAMPTZ, TIMESTAMPTZ);
+DROP TABLE IF EXISTS llm_api.sync_changes;
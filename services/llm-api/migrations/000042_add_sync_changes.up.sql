@@ -0,0 +1,133 @@
+-- Change log behind GET /v1/sync. Triggers record every write to conversations, their
+-- items and user settings, whichever code path makes it, so offline-capable clients can
+-- catch up from a checkpoint instead of polling each conversation.
+--
+-- txid is the writing transaction's ID. Readers only return changes of transactions
+-- older than their snapshot's xmin and page by (txid, id), so a change committed late
+-- by a long transaction is never skipped by a cursor that already moved past its id.
+CREATE TABLE IF NOT EXISTS llm_api.sync_changes (
+    id BIGSERIAL PRIMARY KEY,
+    txid BIGINT NOT NULL DEFAULT (pg_current_xact_id()::text::bigint),
+    user_id INTEGER NOT NULL, -- No foreign key: the user_settings trigger still fires while a user is deleted
+    entity_type VARCHAR(32) NOT NULL,
+    entity_id VARCHAR(64) NOT NULL,
+    conversation_id VARCHAR(64),
+    operation VARCHAR(16) NOT NULL,
+    changed_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_sync_changes_user_cursor
+    ON llm_api.sync_changes (user_id, txid, id);
+CREATE INDEX IF NOT EXISTS idx_sync_changes_changed_at
+    ON llm_api.sync_changes (changed_at);
+
+-- Soft deletes (deleted_at being set) are recorded as deletions
+CREATE OR REPLACE FUNCTION llm_api.sync_operation(op TEXT, old_deleted TIMESTAMPTZ, new_deleted TIMESTAMPTZ)
+RETURNS TEXT AS $$
+BEGIN
+    IF op = 'INSERT' THEN
+        RETURN 'created';
+    ELSIF op = 'DELETE' THEN
+        IF old_deleted IS NOT NULL THEN
+            RETURN NULL;
+        END IF;
+        RETURN 'deleted';
+    ELSIF new_deleted IS NOT NULL THEN
+        IF old_deleted IS NOT NULL THEN
+            RETURN NULL;
+        END IF;
+        RETURN 'deleted';
+    ELSIF old_deleted IS NOT NULL THEN
+        RETURN 'created';
+    END IF;
+    RETURN 'updated';
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+CREATE OR REPLACE FUNCTION llm_api.record_conversation_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    row_data llm_api.conversations%ROWTYPE;
+    operation TEXT;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        row_data := OLD;
+        operation := llm_api.sync_operation(TG_OP, OLD.deleted_at, NULL);
+    ELSIF TG_OP = 'UPDATE' THEN
+        row_data := NEW;
+        operation := llm_api.sync_operation(TG_OP, OLD.deleted_at, NEW.deleted_at);
+    ELSE
+        row_data := NEW;
+        operation := llm_api.sync_operation(TG_OP, NULL, NEW.deleted_at);
+    END IF;
+
+    IF operation IS NOT NULL THEN
+        INSERT INTO llm_api.sync_changes (user_id, entity_type, entity_id, conversation_id, operation)
+        VALUES (row_data.user_id, 'conversation', row_data.public_id, row_data.public_id, operation);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION llm_api.record_conversation_item_change()
+RETURNS TRIGGER AS $$
+DECLARE
+    row_data llm_api.conversation_items%ROWTYPE;
+    operation TEXT;
+    owner_id INTEGER;
+    conversation_public_id VARCHAR(50);
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        row_data := OLD;
+        operation := llm_api.sync_operation(TG_OP, OLD.deleted_at, NULL);
+    ELSIF TG_OP = 'UPDATE' THEN
+        row_data := NEW;
+        operation := llm_api.sync_operation(TG_OP, OLD.deleted_at, NEW.deleted_at);
+    ELSE
+        row_data := NEW;
+        operation := llm_api.sync_operation(TG_OP, NULL, NEW.deleted_at);
+    END IF;
+    IF operation IS NULL THEN
+        RETURN NULL;
+    END IF;
+
+    -- Items removed together with their conversation are covered by its deletion
+    SELECT c.user_id, c.public_id INTO owner_id, conversation_public_id
+    FROM llm_api.conversations c
+    WHERE c.id = row_data.conversation_id;
+    IF owner_id IS NOT NULL THEN
+        INSERT INTO llm_api.sync_changes (user_id, entity_type, entity_id, conversation_id, operation)
+        VALUES (owner_id, 'conversation.item', row_data.public_id, conversation_public_id, operation);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION llm_api.record_user_settings_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        INSERT INTO llm_api.sync_changes (user_id, entity_type, entity_id, operation)
+        VALUES (OLD.user_id, 'user_settings', 'settings', 'deleted');
+    ELSE
+        INSERT INTO llm_api.sync_changes (user_id, entity_type, entity_id, operation)
+        VALUES (NEW.user_id, 'user_settings', 'settings', CASE WHEN TG_OP = 'INSERT' THEN 'created' ELSE 'updated' END);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_conversations_sync ON llm_api.conversations;
+CREATE TRIGGER trg_conversations_sync
+    AFTER INSERT OR UPDATE OR DELETE ON llm_api.conversations
+    FOR EACH ROW EXECUTE FUNCTION llm_api.record_conversation_change();
+
+DROP TRIGGER IF EXISTS trg_conversation_items_sync ON llm_api.conversation_items;
+CREATE TRIGGER trg_conversation_items_sync
+    AFTER INSERT OR UPDATE OR DELETE ON llm_api.conversation_items
+    FOR EACH ROW EXECUTE FUNCTION llm_api.record_conversation_item_change();
+
+DROP TRIGGER IF EXISTS trg_user_settings_sync ON llm_api.user_settings;
+CREATE TRIGGER trg_user_settings_sync
+    AFTER INSERT OR UPDATE OR DELETE ON llm_api.user_settings
+    FOR EACH ROW EXECUTE FUNCTION llm_api.record_user_settings_change();
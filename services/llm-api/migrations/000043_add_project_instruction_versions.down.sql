@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS llm_api.project_instruction_versions;
+
+ALTER TABLE llm_api.projects
+    DROP COLUMN IF EXISTS instruction_version;
@@ -0,0 +1,23 @@
+-- Project instruction history. Every change to a project's instruction bumps
+-- projects.instruction_version and keeps the new text, so conversations that pinned
+-- an older version (conversations.instruction_version) can show what they ran with.
+ALTER TABLE llm_api.projects
+    ADD COLUMN IF NOT EXISTS instruction_version INT NOT NULL DEFAULT 1;
+
+CREATE TABLE IF NOT EXISTS llm_api.project_instruction_versions (
+    id BIGSERIAL PRIMARY KEY,
+    project_id BIGINT NOT NULL REFERENCES llm_api.projects(id) ON DELETE CASCADE,
+    version INT NOT NULL,
+    instruction TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    CONSTRAINT uq_project_instruction_versions UNIQUE (project_id, version)
+);
+
+-- Existing instructions become version 1
+INSERT INTO llm_api.project_instruction_versions (project_id, version, instruction, created_at)
+SELECT id, instruction_version, instruction, updated_at
+FROM llm_api.projects
+ON CONFLICT (project_id, version) DO NOTHING;
+
+COMMENT ON COLUMN llm_api.projects.instruction_version IS 'Current version of the project instruction';
+COMMENT ON TABLE llm_api.project_instruction_versions IS 'Every version of each project instruction';
@@ -0,0 +1,6 @@
+-- Per-project tool defaults: tool name -> on/off, applied to chat completions in the
+-- project's conversations so clients don't pick tools per request.
+ALTER TABLE llm_api.projects
+    ADD COLUMN IF NOT EXISTS default_tools JSONB;
+
+COMMENT ON COLUMN llm_api.projects.default_tools IS 'Tools switched on or off by name for completions in the project';
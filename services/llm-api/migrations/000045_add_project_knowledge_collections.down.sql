@@ -0,0 +1,2 @@
+ALTER TABLE llm_api.projects
+    DROP COLUMN IF EXISTS knowledge_collections;
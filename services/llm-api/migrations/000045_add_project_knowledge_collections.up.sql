@@ -0,0 +1,6 @@
+-- Per-project knowledge base: vector store collections searched by the
+-- file_search_query tool advertised to completions in the project's conversations.
+ALTER TABLE llm_api.projects
+    ADD COLUMN IF NOT EXISTS knowledge_collections JSONB;
+
+COMMENT ON COLUMN llm_api.projects.knowledge_collections IS 'Vector store collections bound to the project as its knowledge base';
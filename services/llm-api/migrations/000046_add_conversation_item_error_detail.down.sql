@@ -0,0 +1,2 @@
+ALTER TABLE llm_api.conversation_items
+    DROP COLUMN IF EXISTS error_detail;
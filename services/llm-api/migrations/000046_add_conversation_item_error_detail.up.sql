@@ -0,0 +1,6 @@
+-- Structured error (code, retryable, provider, detail) reported by mcp-tools
+-- for failed mcp_call items, next to the plain error message.
+ALTER TABLE llm_api.conversation_items
+    ADD COLUMN IF NOT EXISTS error_detail JSONB;
+
+COMMENT ON COLUMN llm_api.conversation_items.error_detail IS 'Structured tool error of failed mcp_call items';
@@ -0,0 +1,11 @@
+ALTER TABLE llm_api.conversation_item_edits
+    DROP COLUMN IF EXISTS data_key_id,
+    DROP COLUMN IF EXISTS previous_ciphertext;
+
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_data_key_id;
+
+ALTER TABLE llm_api.conversation_items
+    DROP COLUMN IF EXISTS data_key_id,
+    DROP COLUMN IF EXISTS content_ciphertext;
+
+DROP TABLE IF EXISTS llm_api.user_data_keys;
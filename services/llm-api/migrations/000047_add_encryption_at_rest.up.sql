@@ -0,0 +1,26 @@
+-- Encryption at rest: per-user AES-256 data keys, wrapped by the KMS master key
+-- (ENCRYPTION_KMS_PROVIDER). Deleting a user's key makes their sealed content unreadable.
+CREATE TABLE IF NOT EXISTS llm_api.user_data_keys (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL UNIQUE REFERENCES llm_api.users(id) ON DELETE CASCADE,
+    wrapped_key BYTEA NOT NULL,
+    key_provider VARCHAR(20) NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+-- Sealed items keep content, arguments and output in content_ciphertext and
+-- leave the plaintext columns NULL; data_key_id names the key that sealed them.
+ALTER TABLE llm_api.conversation_items
+    ADD COLUMN IF NOT EXISTS content_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS data_key_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_conversation_items_data_key_id
+    ON llm_api.conversation_items (data_key_id)
+    WHERE data_key_id IS NOT NULL;
+
+ALTER TABLE llm_api.conversation_item_edits
+    ADD COLUMN IF NOT EXISTS previous_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS data_key_id INTEGER;
+
+COMMENT ON COLUMN llm_api.conversation_items.content_ciphertext IS 'Content, arguments and output sealed with the owner''s data key';
+COMMENT ON COLUMN llm_api.conversation_item_edits.previous_ciphertext IS 'Previous content and output sealed with the owner''s data key';
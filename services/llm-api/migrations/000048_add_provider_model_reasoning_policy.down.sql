@@ -0,0 +1,2 @@
+ALTER TABLE llm_api.provider_models
+    DROP COLUMN IF EXISTS reasoning_policy;
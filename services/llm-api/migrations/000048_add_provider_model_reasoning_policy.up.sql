@@ -0,0 +1,7 @@
+-- Operator reasoning policy per provider model: thinking-token budget and
+-- whether reasoning content is returned, stored or stripped. Kept apart from
+-- reasoning_config, which model sync overwrites from provider metadata.
+ALTER TABLE llm_api.provider_models
+    ADD COLUMN IF NOT EXISTS reasoning_policy JSONB;
+
+COMMENT ON COLUMN llm_api.provider_models.reasoning_policy IS 'Reasoning budget_tokens and content policy (store, stream, strip)';
@@ -0,0 +1,25 @@
+-- Memory backfills.
+-- Each row is a job that replays conversations created before it was queued through
+-- memory observation, optionally for one user. Jobs are claimed by workers with SKIP
+-- LOCKED; cursor is the ID of the last conversation replayed, so failed, cancelled or
+-- interrupted jobs resume where they stopped.
+CREATE TABLE IF NOT EXISTS llm_api.memory_backfills (
+    id SERIAL PRIMARY KEY,
+    public_id VARCHAR(64) NOT NULL UNIQUE,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued',
+    user_id INTEGER,
+    created_before TIMESTAMPTZ NOT NULL,
+    cursor INTEGER NOT NULL DEFAULT 0,
+    conversations_done INTEGER NOT NULL DEFAULT 0,
+    conversations_skipped INTEGER NOT NULL DEFAULT 0,
+    conversations_failed INTEGER NOT NULL DEFAULT 0,
+    error TEXT NOT NULL DEFAULT '',
+    created_by VARCHAR(255),
+    started_at TIMESTAMPTZ,
+    completed_at TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_memory_backfills_status
+    ON llm_api.memory_backfills (status, created_at);
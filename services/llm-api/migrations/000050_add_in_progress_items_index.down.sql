@@ -0,0 +1 @@
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_in_progress;
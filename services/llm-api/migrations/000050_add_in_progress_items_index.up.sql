@@ -0,0 +1,6 @@
+-- Partial index for the reconciliation job that fails items stuck in progress
+-- (mcp_call items whose tool result never arrived). Only in-progress rows are
+-- indexed, so the index stays small.
+CREATE INDEX IF NOT EXISTS idx_conversation_items_in_progress
+    ON llm_api.conversation_items (type, created_at)
+    WHERE status = 'in_progress';
@@ -0,0 +1,6 @@
+DROP INDEX IF EXISTS llm_api.idx_conversation_items_cold_archive_id;
+
+ALTER TABLE llm_api.conversation_items
+    DROP COLUMN IF EXISTS cold_archive_id;
+
+DROP TABLE IF EXISTS llm_api.conversation_item_archives;
@@ -0,0 +1,26 @@
+-- Cold storage for items of idle conversations.
+-- The payload of archived items (content, arguments, output, ...) is gzipped into a
+-- media-api object and cleared from conversation_items, leaving a stub row that keeps
+-- ordering, role, status and rating. cold_archive_id points at the archive holding the
+-- payload until the conversation is rehydrated, which restores the payload and deletes
+-- the archive row.
+CREATE TABLE IF NOT EXISTS llm_api.conversation_item_archives (
+    id SERIAL PRIMARY KEY,
+    conversation_id INTEGER NOT NULL REFERENCES llm_api.conversations(id) ON DELETE CASCADE,
+    media_id VARCHAR(64) NOT NULL,
+    item_count INTEGER NOT NULL DEFAULT 0,
+    bytes BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_conversation_item_archives_conversation_id
+    ON llm_api.conversation_item_archives (conversation_id);
+
+ALTER TABLE llm_api.conversation_items
+    ADD COLUMN IF NOT EXISTS cold_archive_id INTEGER;
+
+CREATE INDEX IF NOT EXISTS idx_conversation_items_cold_archive_id
+    ON llm_api.conversation_items (cold_archive_id)
+    WHERE cold_archive_id IS NOT NULL;
+
+COMMENT ON COLUMN llm_api.conversation_items.cold_archive_id IS 'Archive holding the payload of this stub; NULL for items in the hot table';
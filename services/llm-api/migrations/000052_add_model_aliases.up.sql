@@ -0,0 +1,16 @@
+-- Model aliases, e.g. jan-large, that clients request instead of a concrete model.
+-- target_model is the model public ID every request resolves to (the stable channel);
+-- rollout_model, when set, receives rollout_percent of the users (the rollout channel)
+-- so operators can move an alias to a new model gradually. Aliases are resolved on
+-- every request, so changes apply immediately on all replicas.
+CREATE TABLE IF NOT EXISTS llm_api.model_aliases (
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE,
+    target_model VARCHAR(255) NOT NULL,
+    rollout_model VARCHAR(255),
+    rollout_percent INTEGER NOT NULL DEFAULT 0 CHECK (rollout_percent BETWEEN 0 AND 100),
+    description TEXT,
+    created_by VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,134 @@
+-- Before/after benchmark for migrations 000024 and 000025 (conversation item indexes).
+--
+-- Self-contained: seeds a scratch copy of conversation_items in the llm_api_bench
+-- schema, runs the hot queries without the new indexes, creates them, and runs the
+-- same queries again. Compare the "Execution Time" lines of each pair.
+--
+--   psql "$DB_POSTGRESQL_WRITE_DSN" -f scripts/benchmarks/conversation_items_indexes.sql
+--
+-- Tune the seed size with -v items=... -v conversations=... (defaults 500000 / 5000).
+
+\set ON_ERROR_STOP on
+\if :{?items}
+\else
+  \set items 500000
+\endif
+\if :{?conversations}
+\else
+  \set conversations 5000
+\endif
+\timing on
+
+DROP SCHEMA IF EXISTS llm_api_bench CASCADE;
+CREATE SCHEMA llm_api_bench;
+
+CREATE TABLE llm_api_bench.conversation_items (
+    id SERIAL PRIMARY KEY,
+    conversation_id INTEGER NOT NULL,
+    public_id VARCHAR(50) NOT NULL,
+    branch VARCHAR(50) NOT NULL DEFAULT 'MAIN',
+    sequence_number INTEGER NOT NULL,
+    type VARCHAR(50) NOT NULL,
+    role VARCHAR(20),
+    content JSONB,
+    status VARCHAR(20),
+    call_id VARCHAR(50),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    deleted_at TIMESTAMPTZ
+);
+
+INSERT INTO llm_api_bench.conversation_items
+    (conversation_id, public_id, branch, sequence_number, type, role, content, status, call_id)
+SELECT
+    (g % :conversations) + 1,
+    'msg_' || g,
+    CASE WHEN g % 10 = 0 THEN 'EDIT_1' ELSE 'MAIN' END,
+    g / :conversations,
+    CASE WHEN g % 4 = 3 THEN 'mcp_call' ELSE 'message' END,
+    CASE WHEN g % 2 = 0 THEN 'user' ELSE 'assistant' END,
+    jsonb_build_array(jsonb_build_object(
+        'type', 'input_text',
+        'text', 'benchmark message ' || g || ' about ' || (ARRAY['weather','pricing','deployment','billing','latency'])[(g % 5) + 1]
+    )),
+    CASE WHEN g % 50 = 0 THEN 'in_progress' ELSE 'completed' END,
+    CASE WHEN g % 4 = 3 THEN 'call_' || g ELSE NULL END
+FROM generate_series(1, :items) AS g;
+
+-- Existing indexes from 000001 / 000010
+CREATE INDEX ON llm_api_bench.conversation_items(conversation_id, branch);
+CREATE INDEX ON llm_api_bench.conversation_items(conversation_id, sequence_number);
+CREATE INDEX bench_conv_call_id ON llm_api_bench.conversation_items(conversation_id, call_id) WHERE call_id IS NOT NULL;
+ANALYZE llm_api_bench.conversation_items;
+
+\echo '==================== BEFORE ===================='
+
+\echo '-- chat history tail (GetRecentBranchItems)'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT *, COUNT(*) OVER() AS total_count FROM llm_api_bench.conversation_items
+WHERE conversation_id = 42 AND branch = 'MAIN' AND deleted_at IS NULL
+ORDER BY id DESC LIMIT 200;
+
+\echo '-- PATCH by call_id (GetItemByCallIDAndType)'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT * FROM llm_api_bench.conversation_items
+WHERE conversation_id = 4 AND call_id = 'call_4003' AND type = 'mcp_call' AND deleted_at IS NULL
+LIMIT 1;
+
+\echo '-- item status filter'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT * FROM llm_api_bench.conversation_items
+WHERE conversation_id = 42 AND status = 'in_progress' AND deleted_at IS NULL;
+
+\echo '-- content search (SearchItems)'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT * FROM llm_api_bench.conversation_items
+WHERE conversation_id = 42 AND deleted_at IS NULL
+  AND jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]') @@ plainto_tsquery('simple'::regconfig, 'deployment');
+
+\echo '-- content search across conversations'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT id FROM llm_api_bench.conversation_items
+WHERE jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]') @@ plainto_tsquery('simple'::regconfig, 'message 123456');
+
+-- Indexes from 000024 / 000025
+CREATE INDEX ON llm_api_bench.conversation_items(conversation_id, branch, id DESC) WHERE deleted_at IS NULL;
+CREATE INDEX ON llm_api_bench.conversation_items(conversation_id, call_id, type) WHERE call_id IS NOT NULL AND deleted_at IS NULL;
+DROP INDEX llm_api_bench.bench_conv_call_id;
+CREATE INDEX ON llm_api_bench.conversation_items(conversation_id, branch, type) WHERE deleted_at IS NULL;
+CREATE INDEX ON llm_api_bench.conversation_items(conversation_id, status) WHERE deleted_at IS NULL;
+CREATE INDEX ON llm_api_bench.conversation_items
+    USING GIN (jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]'));
+CREATE INDEX ON llm_api_bench.conversation_items USING GIN (content jsonb_path_ops);
+ANALYZE llm_api_bench.conversation_items;
+
+\echo '==================== AFTER ===================='
+
+\echo '-- chat history tail (GetRecentBranchItems)'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT *, COUNT(*) OVER() AS total_count FROM llm_api_bench.conversation_items
+WHERE conversation_id = 42 AND branch = 'MAIN' AND deleted_at IS NULL
+ORDER BY id DESC LIMIT 200;
+
+\echo '-- PATCH by call_id (GetItemByCallIDAndType)'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT * FROM llm_api_bench.conversation_items
+WHERE conversation_id = 4 AND call_id = 'call_4003' AND type = 'mcp_call' AND deleted_at IS NULL
+LIMIT 1;
+
+\echo '-- item status filter'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT * FROM llm_api_bench.conversation_items
+WHERE conversation_id = 42 AND status = 'in_progress' AND deleted_at IS NULL;
+
+\echo '-- content search (SearchItems)'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT * FROM llm_api_bench.conversation_items
+WHERE conversation_id = 42 AND deleted_at IS NULL
+  AND jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]') @@ plainto_tsquery('simple'::regconfig, 'deployment');
+
+\echo '-- content search across conversations'
+EXPLAIN (ANALYZE, BUFFERS)
+SELECT id FROM llm_api_bench.conversation_items
+WHERE jsonb_to_tsvector('simple'::regconfig, COALESCE(content, '[]'::jsonb), '["string"]') @@ plainto_tsquery('simple'::regconfig, 'message 123456');
+
+DROP SCHEMA llm_api_bench CASCADE;
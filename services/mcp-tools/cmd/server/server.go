@@ -2,20 +2,26 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
+	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/logging"
 	"github.com/rs/zerolog/log"
 
 	"jan-server/services/mcp-tools/internal/infrastructure/config"
 	"jan-server/services/mcp-tools/internal/infrastructure/logger"
 	_ "jan-server/services/mcp-tools/internal/infrastructure/metrics" // Register Prometheus metrics
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
 	"jan-server/services/mcp-tools/internal/interfaces/httpserver"
 	"jan-server/services/mcp-tools/internal/interfaces/httpserver/routes/mcp"
 )
 
 type Application struct {
-	httpServer  *httpserver.HTTPServer
-	providerMCP *mcp.ProviderMCP
+	httpServer   *httpserver.HTTPServer
+	providerMCP  *mcp.ProviderMCP
+	browserProxy *http.Server // Egress proxy for the headless browser; nil when unused
 }
 
 func init() {
@@ -35,11 +41,22 @@ func (app *Application) Start(ctx context.Context) error {
 		log.Error().Err(err).Msg("Failed to initialize MCP providers")
 	}
 
+	if app.browserProxy != nil {
+		go serveBrowserProxy(app.browserProxy)
+	}
+
 	// Start HTTP server
 	log.Info().Str("address", fmt.Sprintf(":%s", "3014")).Msg("Server listening")
 	return app.httpServer.Run()
 }
 
+func serveBrowserProxy(server *http.Server) {
+	log.Info().Str("address", server.Addr).Msg("Browser egress proxy listening")
+	if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal().Err(err).Msg("Browser egress proxy failed")
+	}
+}
+
 func main() {
 	ctx := context.Background()
 
@@ -51,11 +68,32 @@ func main() {
 
 	// Re-initialize logger with config settings
 	logger.Init(cfg.LogLevel, cfg.LogFormat)
+	if cfg.LogExporter == "otlp" && cfg.OTLPEndpoint != "" {
+		logger.EnableOTLPExport(logging.OTLPConfig{
+			Endpoint:    cfg.OTLPEndpoint,
+			Headers:     cfg.OTLPHeaders,
+			ServiceName: observability.ServiceName,
+			Environment: cfg.Environment,
+		})
+	}
+	defer func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = logger.Shutdown(shutdownCtx)
+	}()
 	log.Info().
 		Str("http_port", cfg.HTTPPort).
 		Str("log_level", cfg.LogLevel).
 		Msg("Starting MCP Tools service")
 
+	shutdownTracing, err := observability.Setup(ctx, cfg, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize tracing")
+	}
+	defer func() {
+		_ = shutdownTracing(context.Background())
+	}()
+
 	// Create application with dependency injection
 	application, err := CreateApplication(ctx)
 	if err != nil {
@@ -24,29 +24,49 @@ func CreateApplication(ctx context.Context) (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	searchClient := infrastructure.ProvideSearchClient(config)
+	policy := infrastructure.ProvideEgressPolicy(config)
+	browserClient := infrastructure.ProvideBrowserClient(config, policy)
+	searchClient := infrastructure.ProvideSearchClient(config, browserClient, policy)
 	searchService := search.NewSearchService(searchClient)
 	client := infrastructure.ProvideVectorStoreClient(config)
 	searchMCPConfig := routes.ProvideSearchMCPConfig(config)
 	searchMCP := mcp.NewSearchMCP(searchService, client, searchMCPConfig)
 	mcpproviderConfig := infrastructure.ProvideMCPProviderConfig()
-	providerMCP := mcp.NewProviderMCP(mcpproviderConfig)
+	providerMCP := mcp.NewProviderMCP(mcpproviderConfig, policy)
 	sandboxfusionClient := infrastructure.ProvideSandboxFusionClient(config)
 	sandboxFusionMCP := routes.ProvideSandboxFusionMCP(sandboxfusionClient, config)
 	memoryMCP := routes.ProvideMemoryMCP(config)
 	imageGenerateMCP := routes.ProvideImageGenerateMCP(config)
 	imageEditMCP := routes.ProvideImageEditMCP(config)
+	calculatorMCP := routes.ProvideCalculatorMCP(config)
+	weatherClient := infrastructure.ProvideWeatherClient(config)
+	holidaysClient := infrastructure.ProvideHolidaysClient(config)
+	worldInfoMCP := routes.ProvideWorldInfoMCP(weatherClient, holidaysClient, config)
+	mediaClient := infrastructure.ProvideMediaClient(config)
+	browserMCP := routes.ProvideBrowserMCP(browserClient, mediaClient, config)
+	githubClient := infrastructure.ProvideGitHubClient(config)
+	gitHubMCP := routes.ProvideGitHubMCP(githubClient, config)
+	connectorsClient := infrastructure.ProvideConnectorsClient(config)
+	connectorsMCP := routes.ProvideConnectorsMCP(connectorsClient, config)
 	llmapiClient := infrastructure.ProvideLLMAPIClient(config)
 	cache := routes.ProvideToolConfigCache(config, llmapiClient)
-	mcpRoute := routes.ProvideMCPRoute(searchMCP, providerMCP, sandboxFusionMCP, memoryMCP, imageGenerateMCP, imageEditMCP, llmapiClient, cache)
+	guard := infrastructure.ProvidePromptGuard(config)
+	mcpRoute := routes.ProvideMCPRoute(searchMCP, providerMCP, sandboxFusionMCP, memoryMCP, imageGenerateMCP, imageEditMCP, calculatorMCP, worldInfoMCP, browserMCP, gitHubMCP, connectorsMCP, llmapiClient, cache, guard)
 	validator, err := infrastructure.ProvideAuthValidator(ctx, config)
 	if err != nil {
 		return nil, err
 	}
-	httpServer := httpserver.NewHTTPServer(config, mcpRoute, validator)
+	checker := infrastructure.ProvideReadinessChecker(config, validator)
+	service, err := infrastructure.ProvideMaintenance(config)
+	if err != nil {
+		return nil, err
+	}
+	httpServer := httpserver.NewHTTPServer(config, mcpRoute, validator, checker, service)
+	server := infrastructure.ProvideBrowserEgressProxy(config, browserClient, policy)
 	application := &Application{
-		httpServer:  httpServer,
-		providerMCP: providerMCP,
+		httpServer:   httpServer,
+		providerMCP:  providerMCP,
+		browserProxy: server,
 	}
 	return application, nil
 }
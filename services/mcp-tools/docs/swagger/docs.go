@@ -21,7 +21,7 @@ const docTemplate = `{
     "paths": {
         "/v1/mcp": {
             "post": {
-                "description": "Handles Model Context Protocol (MCP) requests over HTTP. Supports MCP methods: initialize, ping, tools/list, tools/call, prompts/list, prompts/call, resources/list, resources/read.\n\n**Available Tools:**\n- ` + "`" + `google_search` + "`" + `: Web search via pluggable engines (Serper/SearXNG) with params: q, gl, hl, location, num, tbs, page, autocorrect, domain_allow_list, location_hint, offline_mode. Returns structured citations.\n- ` + "`" + `scrape` + "`" + `: Web page scraping (params: url, includeMarkdown) returning text, preview, cache_status, and metadata.\n- ` + "`" + `file_search_index` + "`" + ` / ` + "`" + `file_search_query` + "`" + `: Index arbitrary text and run similarity queries against the lightweight vector store.\n- ` + "`" + `python_exec` + "`" + `: Execute trusted code through SandboxFusion (params: code, language, session_id, approved) to retrieve stdout/stderr/artifacts.\n- ` + "`" + `memory_retrieve` + "`" + `: Retrieve relevant user preferences, project context, or conversation history (params: query, user_id, project_id, max_user_items, max_project_items, min_similarity). Returns personalized context.\n- ` + "`" + `generate_image` + "`" + `: Generate images from a text prompt via LLM API /v1/images/generations (params: prompt, size, n, num_inference_steps, cfg_scale).\n- ` + "`" + `edit_image` + "`" + `: Edit images with a prompt + input image via LLM API /v1/images/edits (params: prompt, image, mask, size, strength, steps, seed, cfg_scale).\n\n**MCP Protocol:**\n- Request format: JSON-RPC 2.0 with method and params\n- Response format: Server-Sent Events (SSE) stream\n- Stateless mode (no session management)",
+                "description": "Handles Model Context Protocol (MCP) requests over HTTP. Supports MCP methods: initialize, ping, tools/list, tools/call, prompts/list, prompts/call, resources/list, resources/read.\n\n**Available Tools:**\n- ` + "`" + `google_search` + "`" + `: Web search via pluggable engines (Serper/SearXNG) with params: q, gl, hl, location, num, tbs, page, autocorrect, domain_allow_list, location_hint, offline_mode. Returns structured citations.\n- ` + "`" + `scrape` + "`" + `: Web page scraping (params: url, includeMarkdown) returning text, preview, cache_status, and metadata.\n- ` + "`" + `file_search_index` + "`" + ` / ` + "`" + `file_search_query` + "`" + `: Index arbitrary text and run similarity queries against the lightweight vector store.\n- ` + "`" + `python_exec` + "`" + `: Execute trusted code through SandboxFusion (params: code, language, session_id, approved) to retrieve stdout/stderr/artifacts.\n- ` + "`" + `memory_retrieve` + "`" + `: Retrieve relevant user preferences, project context, or conversation history (params: query, user_id, project_id, max_user_items, max_project_items, min_similarity). Returns personalized context.\n- ` + "`" + `generate_image` + "`" + `: Generate images from a text prompt via LLM API /v1/images/generations (params: prompt, size, n, num_inference_steps, cfg_scale).\n- ` + "`" + `edit_image` + "`" + `: Edit images with a prompt + input image via LLM API /v1/images/edits (params: prompt, image, mask, size, strength, steps, seed, cfg_scale).\n- ` + "`" + `calculate` + "`" + `: Evaluate an arithmetic expression in-process (params: expression) returning result and formatted value.\n- ` + "`" + `convert_units` + "`" + `: Convert a value between units of the same dimension (params: value, from_unit, to_unit).\n- ` + "`" + `get_weather` + "`" + `: Current weather for a place via the configured provider (params: location, units).\n- ` + "`" + `get_world_time` + "`" + `: Current local time in a timezone or place (params: timezone, location).\n- ` + "`" + `list_holidays` + "`" + `: Public holidays of a country (params: country_code, year, upcoming_only).\n- ` + "`" + `render_page` + "`" + `: Render a JavaScript-heavy page in a headless browser (params: url, wait_for_selector, timeout_seconds, screenshot, full_page) returning visible text and an optional media-api screenshot.\n- ` + "`" + `search_code` + "`" + ` / ` + "`" + `read_file` + "`" + ` / ` + "`" + `list_issues` + "`" + ` / ` + "`" + `create_issue_comment` + "`" + `: GitHub tools acting as the user with the token connected via LLM API /v1/mcp-credentials/github (params: query, repo, path, ref, state, labels, limit, issue_number, body).\n- ` + "`" + `list_calendar_events` + "`" + ` / ` + "`" + `search_emails` + "`" + `: Read-only calendar and mailbox connectors (Google, Outlook, CalDAV) using credentials and consent scopes from LLM API /v1/mcp-credentials, with redacted output (params: provider, start_date, days, timezone, query, limit).\n\n**MCP Protocol:**\n- Request format: JSON-RPC 2.0 with method and params\n- Response format: Server-Sent Events (SSE) stream\n- Stateless mode (no session management)",
                 "consumes": [
                     "application/json"
                 ],
@@ -0,0 +1,430 @@
+// Package calculator evaluates arithmetic expressions and converts between units
+// without executing code, so models can get exact answers to numeric questions.
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxExpressionLength bounds the input accepted by Evaluate.
+	MaxExpressionLength = 1024
+	// maxDepth bounds parenthesis and function nesting.
+	maxDepth = 64
+)
+
+// ErrInvalidExpression is returned for syntax errors and unsupported input.
+var ErrInvalidExpression = errors.New("invalid expression")
+
+var constants = map[string]float64{
+	"pi":  math.Pi,
+	"e":   math.E,
+	"tau": 2 * math.Pi,
+	"phi": math.Phi,
+}
+
+type function struct {
+	minArgs, maxArgs int // maxArgs < 0 means variadic
+	eval             func(args []float64) (float64, error)
+}
+
+func unary(f func(float64) float64) function {
+	return function{minArgs: 1, maxArgs: 1, eval: func(args []float64) (float64, error) {
+		return f(args[0]), nil
+	}}
+}
+
+var functions = map[string]function{
+	"sqrt":  unary(math.Sqrt),
+	"cbrt":  unary(math.Cbrt),
+	"abs":   unary(math.Abs),
+	"exp":   unary(math.Exp),
+	"ln":    unary(math.Log),
+	"log10": unary(math.Log10),
+	"log2":  unary(math.Log2),
+	"sin":   unary(math.Sin),
+	"cos":   unary(math.Cos),
+	"tan":   unary(math.Tan),
+	"asin":  unary(math.Asin),
+	"acos":  unary(math.Acos),
+	"atan":  unary(math.Atan),
+	"floor": unary(math.Floor),
+	"ceil":  unary(math.Ceil),
+	"trunc": unary(math.Trunc),
+	"log": {minArgs: 1, maxArgs: 2, eval: func(args []float64) (float64, error) {
+		// log(x) is base 10, log(x, b) uses base b
+		if len(args) == 1 {
+			return math.Log10(args[0]), nil
+		}
+		return math.Log(args[0]) / math.Log(args[1]), nil
+	}},
+	"round": {minArgs: 1, maxArgs: 2, eval: func(args []float64) (float64, error) {
+		if len(args) == 1 {
+			return math.Round(args[0]), nil
+		}
+		if args[1] != math.Trunc(args[1]) || args[1] < 0 || args[1] > 15 {
+			return 0, fmt.Errorf("%w: round digits must be an integer between 0 and 15", ErrInvalidExpression)
+		}
+		scale := math.Pow(10, args[1])
+		return math.Round(args[0]*scale) / scale, nil
+	}},
+	"pow": {minArgs: 2, maxArgs: 2, eval: func(args []float64) (float64, error) {
+		return math.Pow(args[0], args[1]), nil
+	}},
+	"min": {minArgs: 1, maxArgs: -1, eval: func(args []float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Min(result, arg)
+		}
+		return result, nil
+	}},
+	"max": {minArgs: 1, maxArgs: -1, eval: func(args []float64) (float64, error) {
+		result := args[0]
+		for _, arg := range args[1:] {
+			result = math.Max(result, arg)
+		}
+		return result, nil
+	}},
+}
+
+// Evaluate computes the value of an arithmetic expression. It supports
+// + - * / % ^ (and ** for powers), parentheses, unary signs, scientific
+// notation, the constants pi, e, tau and phi, and the functions in
+// SupportedFunctions. Thousands separators ("1,234") are not accepted because
+// commas separate function arguments.
+func Evaluate(expression string) (float64, error) {
+	expression = strings.TrimSpace(expression)
+	if expression == "" {
+		return 0, fmt.Errorf("%w: expression is empty", ErrInvalidExpression)
+	}
+	if len(expression) > MaxExpressionLength {
+		return 0, fmt.Errorf("%w: expression exceeds %d characters", ErrInvalidExpression, MaxExpressionLength)
+	}
+
+	tokens, err := tokenize(expression)
+	if err != nil {
+		return 0, err
+	}
+	p := &parser{tokens: tokens}
+	value, err := p.parseExpression()
+	if err != nil {
+		return 0, err
+	}
+	if tok := p.peek(); tok.kind != tokenEOF {
+		return 0, fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidExpression, tok.text, tok.pos+1)
+	}
+	if math.IsNaN(value) {
+		return 0, fmt.Errorf("%w: result is not a number", ErrInvalidExpression)
+	}
+	if math.IsInf(value, 0) {
+		return 0, fmt.Errorf("%w: result is infinite", ErrInvalidExpression)
+	}
+	return value, nil
+}
+
+// SupportedFunctions lists the function names accepted by Evaluate.
+func SupportedFunctions() []string {
+	return []string{"sqrt", "cbrt", "abs", "exp", "ln", "log", "log10", "log2", "sin", "cos", "tan", "asin", "acos", "atan", "floor", "ceil", "trunc", "round", "pow", "min", "max"}
+}
+
+// FormatNumber renders a result without float noise, e.g. 0.30000000000000004 -> "0.3".
+func FormatNumber(value float64) string {
+	if value == math.Trunc(value) && math.Abs(value) < 1e15 {
+		return strconv.FormatFloat(value, 'f', -1, 64)
+	}
+	rounded, err := strconv.ParseFloat(strconv.FormatFloat(value, 'g', 12, 64), 64)
+	if err != nil {
+		return strconv.FormatFloat(value, 'g', -1, 64)
+	}
+	return strconv.FormatFloat(rounded, 'g', -1, 64)
+}
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenNumber
+	tokenIdent
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+)
+
+type token struct {
+	kind  tokenKind
+	text  string
+	value float64
+	pos   int
+}
+
+func tokenize(input string) ([]token, error) {
+	tokens := make([]token, 0, len(input)/2+1)
+	for i := 0; i < len(input); {
+		c, size := utf8.DecodeRuneInString(input[i:])
+		switch {
+		case unicode.IsSpace(c):
+			i += size
+		case isDigit(c) || c == '.':
+			start := i
+			for i < len(input) && (isDigit(rune(input[i])) || input[i] == '.') {
+				i++
+			}
+			// Scientific notation: 1e3, 2.5E-4
+			if i < len(input) && (input[i] == 'e' || input[i] == 'E') {
+				j := i + 1
+				if j < len(input) && (input[j] == '+' || input[j] == '-') {
+					j++
+				}
+				if j < len(input) && isDigit(rune(input[j])) {
+					for j < len(input) && isDigit(rune(input[j])) {
+						j++
+					}
+					i = j
+				}
+			}
+			text := input[start:i]
+			value, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				return nil, fmt.Errorf("%w: invalid number %q at position %d", ErrInvalidExpression, text, start+1)
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: text, value: value, pos: start})
+		case isLetter(c) || c == '_':
+			start := i
+			for i < len(input) && (isLetter(rune(input[i])) || isDigit(rune(input[i])) || input[i] == '_') {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: strings.ToLower(input[start:i]), pos: start})
+		case c == '*' && i+1 < len(input) && input[i+1] == '*':
+			tokens = append(tokens, token{kind: tokenOperator, text: "^", pos: i})
+			i += 2
+		case strings.ContainsRune("+-*/%^", c):
+			tokens = append(tokens, token{kind: tokenOperator, text: string(c), pos: i})
+			i++
+		case c == '×' || c == '÷' || c == '−':
+			operator := map[rune]string{'×': "*", '÷': "/", '−': "-"}[c]
+			tokens = append(tokens, token{kind: tokenOperator, text: operator, pos: i})
+			i += size
+		case c == '(':
+			tokens = append(tokens, token{kind: tokenLParen, text: "(", pos: i})
+			i++
+		case c == ')':
+			tokens = append(tokens, token{kind: tokenRParen, text: ")", pos: i})
+			i++
+		case c == ',':
+			tokens = append(tokens, token{kind: tokenComma, text: ",", pos: i})
+			i++
+		default:
+			return nil, fmt.Errorf("%w: unexpected character %q at position %d", ErrInvalidExpression, string(c), i+1)
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, text: "end of expression", pos: len(input)}), nil
+}
+
+func isDigit(r rune) bool  { return r >= '0' && r <= '9' }
+func isLetter(r rune) bool { return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') }
+
+// parser is a recursive-descent parser over the grammar:
+//
+//	expression = term { ("+" | "-") term }
+//	term       = unary { ("*" | "/" | "%") unary }
+//	unary      = ("+" | "-") unary | power
+//	power      = primary [ "^" unary ]
+//	primary    = number | constant | function "(" args ")" | "(" expression ")"
+type parser struct {
+	tokens []token
+	pos    int
+	depth  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	tok := p.tokens[p.pos]
+	if tok.kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *parser) enter() error {
+	p.depth++
+	if p.depth > maxDepth {
+		return fmt.Errorf("%w: nesting deeper than %d levels", ErrInvalidExpression, maxDepth)
+	}
+	return nil
+}
+
+func (p *parser) leave() {
+	p.depth--
+}
+
+func (p *parser) parseExpression() (float64, error) {
+	left, err := p.parseTerm()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOperator || (tok.text != "+" && tok.text != "-") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseTerm()
+		if err != nil {
+			return 0, err
+		}
+		if tok.text == "+" {
+			left += right
+		} else {
+			left -= right
+		}
+	}
+}
+
+func (p *parser) parseTerm() (float64, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	for {
+		tok := p.peek()
+		if tok.kind != tokenOperator || (tok.text != "*" && tok.text != "/" && tok.text != "%") {
+			return left, nil
+		}
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		switch tok.text {
+		case "*":
+			left *= right
+		case "/":
+			if right == 0 {
+				return 0, fmt.Errorf("%w: division by zero", ErrInvalidExpression)
+			}
+			left /= right
+		case "%":
+			if right == 0 {
+				return 0, fmt.Errorf("%w: modulo by zero", ErrInvalidExpression)
+			}
+			left = math.Mod(left, right)
+		}
+	}
+}
+
+func (p *parser) parseUnary() (float64, error) {
+	tok := p.peek()
+	if tok.kind == tokenOperator && (tok.text == "+" || tok.text == "-") {
+		p.next()
+		if err := p.enter(); err != nil {
+			return 0, err
+		}
+		defer p.leave()
+		value, err := p.parseUnary()
+		if err != nil {
+			return 0, err
+		}
+		if tok.text == "-" {
+			return -value, nil
+		}
+		return value, nil
+	}
+	return p.parsePower()
+}
+
+func (p *parser) parsePower() (float64, error) {
+	base, err := p.parsePrimary()
+	if err != nil {
+		return 0, err
+	}
+	tok := p.peek()
+	if tok.kind != tokenOperator || tok.text != "^" {
+		return base, nil
+	}
+	p.next()
+	if err := p.enter(); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+	// Right-associative, and binds tighter than a leading minus: -2^2 = -4
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return 0, err
+	}
+	return math.Pow(base, exponent), nil
+}
+
+func (p *parser) parsePrimary() (float64, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokenNumber:
+		return tok.value, nil
+	case tokenLParen:
+		if err := p.enter(); err != nil {
+			return 0, err
+		}
+		defer p.leave()
+		value, err := p.parseExpression()
+		if err != nil {
+			return 0, err
+		}
+		if closing := p.next(); closing.kind != tokenRParen {
+			return 0, fmt.Errorf("%w: expected \")\" at position %d", ErrInvalidExpression, closing.pos+1)
+		}
+		return value, nil
+	case tokenIdent:
+		if p.peek().kind == tokenLParen {
+			return p.parseCall(tok)
+		}
+		if value, ok := constants[tok.text]; ok {
+			return value, nil
+		}
+		return 0, fmt.Errorf("%w: unknown name %q at position %d", ErrInvalidExpression, tok.text, tok.pos+1)
+	default:
+		return 0, fmt.Errorf("%w: unexpected %q at position %d", ErrInvalidExpression, tok.text, tok.pos+1)
+	}
+}
+
+func (p *parser) parseCall(name token) (float64, error) {
+	fn, ok := functions[name.text]
+	if !ok {
+		return 0, fmt.Errorf("%w: unknown function %q at position %d", ErrInvalidExpression, name.text, name.pos+1)
+	}
+	p.next() // "("
+	if err := p.enter(); err != nil {
+		return 0, err
+	}
+	defer p.leave()
+
+	var args []float64
+	if p.peek().kind != tokenRParen {
+		for {
+			arg, err := p.parseExpression()
+			if err != nil {
+				return 0, err
+			}
+			args = append(args, arg)
+			if p.peek().kind != tokenComma {
+				break
+			}
+			p.next()
+		}
+	}
+	if closing := p.next(); closing.kind != tokenRParen {
+		return 0, fmt.Errorf("%w: expected \")\" at position %d", ErrInvalidExpression, closing.pos+1)
+	}
+	if len(args) < fn.minArgs || (fn.maxArgs >= 0 && len(args) > fn.maxArgs) {
+		return 0, fmt.Errorf("%w: wrong number of arguments for %s", ErrInvalidExpression, name.text)
+	}
+	return fn.eval(args)
+}
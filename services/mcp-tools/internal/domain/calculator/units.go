@@ -0,0 +1,240 @@
+package calculator
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// ErrUnknownUnit is returned when a unit name is not recognized.
+var ErrUnknownUnit = errors.New("unknown unit")
+
+// ErrIncompatibleUnits is returned when converting between different dimensions.
+var ErrIncompatibleUnits = errors.New("incompatible units")
+
+// Unit categories
+const (
+	CategoryLength      = "length"
+	CategoryMass        = "mass"
+	CategoryVolume      = "volume"
+	CategoryArea        = "area"
+	CategoryTime        = "time"
+	CategorySpeed       = "speed"
+	CategoryData        = "data"
+	CategoryEnergy      = "energy"
+	CategoryPressure    = "pressure"
+	CategoryTemperature = "temperature"
+)
+
+// unit converts to and from its category's base unit: base = value*factor + offset.
+// Only temperatures use an offset.
+type unit struct {
+	symbol   string
+	category string
+	factor   float64
+	offset   float64
+}
+
+// units is keyed by lowercase name; each entry lists its accepted aliases.
+var units = buildUnits([]struct {
+	aliases  []string
+	category string
+	factor   float64
+	offset   float64
+}{
+	// Length (base: meter)
+	{[]string{"m", "meter", "meters", "metre", "metres"}, CategoryLength, 1, 0},
+	{[]string{"km", "kilometer", "kilometers", "kilometre", "kilometres"}, CategoryLength, 1000, 0},
+	{[]string{"cm", "centimeter", "centimeters", "centimetre", "centimetres"}, CategoryLength, 0.01, 0},
+	{[]string{"mm", "millimeter", "millimeters", "millimetre", "millimetres"}, CategoryLength, 0.001, 0},
+	{[]string{"um", "µm", "micrometer", "micrometers", "micron", "microns"}, CategoryLength, 1e-6, 0},
+	{[]string{"nm", "nanometer", "nanometers"}, CategoryLength, 1e-9, 0},
+	{[]string{"in", "inch", "inches"}, CategoryLength, 0.0254, 0},
+	{[]string{"ft", "foot", "feet"}, CategoryLength, 0.3048, 0},
+	{[]string{"yd", "yard", "yards"}, CategoryLength, 0.9144, 0},
+	{[]string{"mi", "mile", "miles"}, CategoryLength, 1609.344, 0},
+	{[]string{"nmi", "nautical_mile", "nautical_miles"}, CategoryLength, 1852, 0},
+	{[]string{"au", "astronomical_unit", "astronomical_units"}, CategoryLength, 149597870700, 0},
+	{[]string{"ly", "light_year", "light_years"}, CategoryLength, 9460730472580800, 0},
+
+	// Mass (base: kilogram)
+	{[]string{"kg", "kilogram", "kilograms"}, CategoryMass, 1, 0},
+	{[]string{"g", "gram", "grams"}, CategoryMass, 0.001, 0},
+	{[]string{"mg", "milligram", "milligrams"}, CategoryMass, 1e-6, 0},
+	{[]string{"ug", "µg", "microgram", "micrograms"}, CategoryMass, 1e-9, 0},
+	{[]string{"t", "tonne", "tonnes", "metric_ton", "metric_tons"}, CategoryMass, 1000, 0},
+	{[]string{"lb", "lbs", "pound", "pounds"}, CategoryMass, 0.45359237, 0},
+	{[]string{"oz", "ounce", "ounces"}, CategoryMass, 0.028349523125, 0},
+	{[]string{"st", "stone", "stones"}, CategoryMass, 6.35029318, 0},
+	{[]string{"short_ton", "short_tons", "us_ton", "us_tons"}, CategoryMass, 907.18474, 0},
+
+	// Volume (base: liter)
+	{[]string{"l", "liter", "liters", "litre", "litres"}, CategoryVolume, 1, 0},
+	{[]string{"ml", "milliliter", "milliliters", "millilitre", "millilitres"}, CategoryVolume, 0.001, 0},
+	{[]string{"cl", "centiliter", "centiliters"}, CategoryVolume, 0.01, 0},
+	{[]string{"m3", "cubic_meter", "cubic_meters"}, CategoryVolume, 1000, 0},
+	{[]string{"cm3", "cc", "cubic_centimeter", "cubic_centimeters"}, CategoryVolume, 0.001, 0},
+	{[]string{"gal", "gallon", "gallons", "us_gallon", "us_gallons"}, CategoryVolume, 3.785411784, 0},
+	{[]string{"imp_gal", "imperial_gallon", "imperial_gallons"}, CategoryVolume, 4.54609, 0},
+	{[]string{"qt", "quart", "quarts"}, CategoryVolume, 0.946352946, 0},
+	{[]string{"pt", "pint", "pints"}, CategoryVolume, 0.473176473, 0},
+	{[]string{"cup", "cups"}, CategoryVolume, 0.2365882365, 0},
+	{[]string{"fl_oz", "floz", "fluid_ounce", "fluid_ounces"}, CategoryVolume, 0.0295735295625, 0},
+	{[]string{"tbsp", "tablespoon", "tablespoons"}, CategoryVolume, 0.01478676478125, 0},
+	{[]string{"tsp", "teaspoon", "teaspoons"}, CategoryVolume, 0.00492892159375, 0},
+
+	// Area (base: square meter)
+	{[]string{"m2", "sq_m", "square_meter", "square_meters"}, CategoryArea, 1, 0},
+	{[]string{"km2", "sq_km", "square_kilometer", "square_kilometers"}, CategoryArea, 1e6, 0},
+	{[]string{"cm2", "sq_cm", "square_centimeter", "square_centimeters"}, CategoryArea, 1e-4, 0},
+	{[]string{"ha", "hectare", "hectares"}, CategoryArea, 10000, 0},
+	{[]string{"acre", "acres"}, CategoryArea, 4046.8564224, 0},
+	{[]string{"ft2", "sq_ft", "square_foot", "square_feet"}, CategoryArea, 0.09290304, 0},
+	{[]string{"in2", "sq_in", "square_inch", "square_inches"}, CategoryArea, 0.00064516, 0},
+	{[]string{"mi2", "sq_mi", "square_mile", "square_miles"}, CategoryArea, 2589988.110336, 0},
+
+	// Time (base: second)
+	{[]string{"s", "sec", "secs", "second", "seconds"}, CategoryTime, 1, 0},
+	{[]string{"ms", "millisecond", "milliseconds"}, CategoryTime, 0.001, 0},
+	{[]string{"min", "mins", "minute", "minutes"}, CategoryTime, 60, 0},
+	{[]string{"h", "hr", "hrs", "hour", "hours"}, CategoryTime, 3600, 0},
+	{[]string{"d", "day", "days"}, CategoryTime, 86400, 0},
+	{[]string{"wk", "week", "weeks"}, CategoryTime, 604800, 0},
+	{[]string{"yr", "year", "years"}, CategoryTime, 31557600, 0}, // Julian year, 365.25 days
+
+	// Speed (base: meter per second)
+	{[]string{"m/s", "mps", "meters_per_second"}, CategorySpeed, 1, 0},
+	{[]string{"km/h", "kmh", "kph", "kilometers_per_hour"}, CategorySpeed, 1000.0 / 3600.0, 0},
+	{[]string{"mph", "mi/h", "miles_per_hour"}, CategorySpeed, 1609.344 / 3600.0, 0},
+	{[]string{"kn", "kt", "knot", "knots"}, CategorySpeed, 1852.0 / 3600.0, 0},
+	{[]string{"ft/s", "fps", "feet_per_second"}, CategorySpeed, 0.3048, 0},
+
+	// Data (base: byte)
+	{[]string{"b", "byte", "bytes"}, CategoryData, 1, 0},
+	{[]string{"bit", "bits"}, CategoryData, 0.125, 0},
+	{[]string{"kb", "kilobyte", "kilobytes"}, CategoryData, 1e3, 0},
+	{[]string{"mb", "megabyte", "megabytes"}, CategoryData, 1e6, 0},
+	{[]string{"gb", "gigabyte", "gigabytes"}, CategoryData, 1e9, 0},
+	{[]string{"tb", "terabyte", "terabytes"}, CategoryData, 1e12, 0},
+	{[]string{"kib", "kibibyte", "kibibytes"}, CategoryData, 1024, 0},
+	{[]string{"mib", "mebibyte", "mebibytes"}, CategoryData, 1 << 20, 0},
+	{[]string{"gib", "gibibyte", "gibibytes"}, CategoryData, 1 << 30, 0},
+	{[]string{"tib", "tebibyte", "tebibytes"}, CategoryData, 1 << 40, 0},
+
+	// Energy (base: joule)
+	{[]string{"j", "joule", "joules"}, CategoryEnergy, 1, 0},
+	{[]string{"kj", "kilojoule", "kilojoules"}, CategoryEnergy, 1000, 0},
+	{[]string{"cal", "calorie", "calories"}, CategoryEnergy, 4.184, 0},
+	{[]string{"kcal", "kilocalorie", "kilocalories"}, CategoryEnergy, 4184, 0},
+	{[]string{"wh", "watt_hour", "watt_hours"}, CategoryEnergy, 3600, 0},
+	{[]string{"kwh", "kilowatt_hour", "kilowatt_hours"}, CategoryEnergy, 3.6e6, 0},
+	{[]string{"btu", "btus"}, CategoryEnergy, 1055.05585262, 0},
+
+	// Pressure (base: pascal)
+	{[]string{"pa", "pascal", "pascals"}, CategoryPressure, 1, 0},
+	{[]string{"kpa", "kilopascal", "kilopascals"}, CategoryPressure, 1000, 0},
+	{[]string{"bar", "bars"}, CategoryPressure, 100000, 0},
+	{[]string{"atm", "atmosphere", "atmospheres"}, CategoryPressure, 101325, 0},
+	{[]string{"psi"}, CategoryPressure, 6894.757293168, 0},
+	{[]string{"mmhg"}, CategoryPressure, 133.322387415, 0},
+
+	// Temperature (base: kelvin)
+	{[]string{"k", "kelvin"}, CategoryTemperature, 1, 0},
+	{[]string{"c", "°c", "celsius"}, CategoryTemperature, 1, 273.15},
+	{[]string{"f", "°f", "fahrenheit"}, CategoryTemperature, 5.0 / 9.0, 273.15 - 32*5.0/9.0},
+})
+
+func buildUnits(defs []struct {
+	aliases  []string
+	category string
+	factor   float64
+	offset   float64
+}) map[string]unit {
+	result := make(map[string]unit)
+	for _, def := range defs {
+		u := unit{symbol: def.aliases[0], category: def.category, factor: def.factor, offset: def.offset}
+		for _, alias := range def.aliases {
+			result[alias] = u
+		}
+	}
+	return result
+}
+
+// Conversion is the result of Convert.
+type Conversion struct {
+	Value    float64
+	From     string // Canonical symbol of the source unit
+	To       string // Canonical symbol of the target unit
+	Category string
+	Result   float64
+}
+
+// Convert converts value between two units of the same category. Unit names are
+// case-insensitive and accept symbols ("km"), singular and plural names ("mile",
+// "miles"); spaces in multi-word names may be written as underscores or spaces.
+func Convert(value float64, from, to string) (*Conversion, error) {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return nil, fmt.Errorf("%w: value must be a finite number", ErrInvalidExpression)
+	}
+	fromUnit, err := lookupUnit(from)
+	if err != nil {
+		return nil, err
+	}
+	toUnit, err := lookupUnit(to)
+	if err != nil {
+		return nil, err
+	}
+	if fromUnit.category != toUnit.category {
+		return nil, fmt.Errorf("%w: cannot convert %s (%s) to %s (%s)", ErrIncompatibleUnits, fromUnit.symbol, fromUnit.category, toUnit.symbol, toUnit.category)
+	}
+
+	base := value*fromUnit.factor + fromUnit.offset
+	if fromUnit.category == CategoryTemperature && base < 0 {
+		return nil, fmt.Errorf("%w: temperature is below absolute zero", ErrInvalidExpression)
+	}
+	result := (base - toUnit.offset) / toUnit.factor
+
+	return &Conversion{
+		Value:    value,
+		From:     fromUnit.symbol,
+		To:       toUnit.symbol,
+		Category: fromUnit.category,
+		Result:   result,
+	}, nil
+}
+
+// SupportedUnits returns the canonical unit symbols grouped by category.
+func SupportedUnits() map[string][]string {
+	seen := make(map[string]bool)
+	result := make(map[string][]string)
+	for _, u := range units {
+		if seen[u.symbol] {
+			continue
+		}
+		seen[u.symbol] = true
+		result[u.category] = append(result[u.category], u.symbol)
+	}
+	for category := range result {
+		sort.Strings(result[category])
+	}
+	return result
+}
+
+func lookupUnit(name string) (unit, error) {
+	key := strings.ToLower(strings.TrimSpace(name))
+	key = strings.Join(strings.Fields(key), "_")
+	if u, ok := units[key]; ok {
+		return u, nil
+	}
+	// Accept "degrees celsius", "deg f" and similar
+	for _, prefix := range []string{"degrees_", "degree_", "deg_"} {
+		if trimmed, ok := strings.CutPrefix(key, prefix); ok {
+			if u, ok := units[trimmed]; ok {
+				return u, nil
+			}
+		}
+	}
+	return unit{}, fmt.Errorf("%w: %q", ErrUnknownUnit, name)
+}
@@ -0,0 +1,229 @@
+// Package promptguard sanitizes untrusted tool output, such as search results
+// and scraped pages, before it re-enters a model's context, to mitigate
+// indirect prompt injection.
+package promptguard
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+	"unicode"
+)
+
+const (
+	sourcePlaceholder  = "{source}"
+	contentPlaceholder = "{content}"
+
+	strippedMarker = "[removed]"
+	withheldNotice = "[content withheld: flagged as a possible prompt injection]"
+)
+
+// instructionPatterns match text that tries to steer the model rather than inform it.
+var instructionPatterns = []*regexp.Regexp{
+	regexp.MustCompile(`(?i)\b(ignore|disregard|forget|override)\s+(all\s+|any\s+|the\s+)?(previous|prior|above|earlier|preceding|your)\s+(instructions?|prompts?|rules|directions|context)\b`),
+	regexp.MustCompile(`(?i)\byou\s+are\s+now\s+(a|an|acting|my|no\s+longer)\b`),
+	regexp.MustCompile(`(?i)\bfrom\s+now\s+on,?\s+you\s+(will|must|are|should)\b`),
+	regexp.MustCompile(`(?i)\bnew\s+(system\s+)?instructions?\s*:`),
+	regexp.MustCompile(`(?i)\b(system|developer)\s+(prompt|message|instructions?)\s*:`),
+	regexp.MustCompile(`(?im)^\s*#{1,3}\s*(system|instructions?)\s*:?\s*$`),
+	// Chat template tokens and role tags
+	regexp.MustCompile(`<\|[a-z_]+\|>`),
+	regexp.MustCompile(`\[/?INST\]|<</?SYS>>`),
+	regexp.MustCompile(`(?i)</?(system|assistant|developer)>`),
+}
+
+// Classifier scores texts for prompt injection.
+type Classifier interface {
+	// Score returns, for each text, the probability from 0 to 1 that it is a
+	// prompt injection.
+	Score(ctx context.Context, texts []string) ([]float64, error)
+}
+
+// Config configures a Guard.
+type Config struct {
+	Enabled             bool
+	Tools               []string // Tools whose output is guarded
+	StripInstructions   bool
+	WrapTemplate        string // {source} and {content} placeholders; empty disables wrapping
+	ClassifierThreshold float64
+	ClassifierTimeout   time.Duration
+}
+
+// Document is one untrusted item of a tool result, such as a search hit or a page.
+type Document struct {
+	Source string    // Where the content came from, e.g. the page URL
+	Title  *string   // Stripped, but not wrapped
+	Body   []*string // Stripped and wrapped
+}
+
+// Report summarizes what a Sanitize call changed.
+type Report struct {
+	Stripped      int   // Documents with instruction-like text removed
+	Flagged       int   // Documents withheld by the classifier
+	ClassifierErr error // The classifier failed; its documents were kept
+}
+
+// Guard sanitizes untrusted tool output. A nil Guard leaves output unchanged.
+type Guard struct {
+	tools      map[string]bool
+	strip      bool
+	wrap       string
+	delimiters []string
+	classifier Classifier
+	threshold  float64
+	timeout    time.Duration
+}
+
+// New creates a guard, or returns nil when it is disabled. classifier may be nil.
+func New(cfg Config, classifier Classifier) *Guard {
+	if !cfg.Enabled || len(cfg.Tools) == 0 {
+		return nil
+	}
+	tools := make(map[string]bool, len(cfg.Tools))
+	for _, tool := range cfg.Tools {
+		if tool = strings.TrimSpace(tool); tool != "" {
+			tools[tool] = true
+		}
+	}
+	return &Guard{
+		tools:      tools,
+		strip:      cfg.StripInstructions,
+		wrap:       cfg.WrapTemplate,
+		delimiters: templateDelimiters(cfg.WrapTemplate),
+		classifier: classifier,
+		threshold:  cfg.ClassifierThreshold,
+		timeout:    cfg.ClassifierTimeout,
+	}
+}
+
+// Applies reports whether the output of tool is guarded.
+func (g *Guard) Applies(tool string) bool {
+	return g != nil && g.tools[tool]
+}
+
+// Sanitize rewrites the documents of a result of tool in place. Documents the
+// classifier scores at or above the threshold are withheld; the rest have
+// instruction-like text removed and their bodies wrapped in the template.
+func (g *Guard) Sanitize(ctx context.Context, tool string, docs []Document) Report {
+	var report Report
+	if !g.Applies(tool) || len(docs) == 0 {
+		return report
+	}
+
+	flagged, err := g.classify(ctx, docs)
+	report.ClassifierErr = err
+
+	for i, doc := range docs {
+		if flagged[i] {
+			report.Flagged++
+			for _, body := range doc.Body {
+				if body != nil {
+					*body = withheldNotice
+				}
+			}
+			if doc.Title != nil {
+				*doc.Title = g.stripText(*doc.Title)
+			}
+			continue
+		}
+
+		stripped := false
+		if doc.Title != nil {
+			title := g.stripText(*doc.Title)
+			stripped = stripped || title != *doc.Title
+			*doc.Title = title
+		}
+		for _, body := range doc.Body {
+			if body == nil || *body == "" {
+				continue
+			}
+			text := g.stripText(*body)
+			stripped = stripped || text != *body
+			*body = g.wrapText(doc.Source, text)
+		}
+		if stripped {
+			report.Stripped++
+		}
+	}
+	return report
+}
+
+// classify marks the documents the classifier flags. Without a classifier, or
+// when it fails, nothing is flagged.
+func (g *Guard) classify(ctx context.Context, docs []Document) ([]bool, error) {
+	flagged := make([]bool, len(docs))
+	if g.classifier == nil {
+		return flagged, nil
+	}
+
+	texts := make([]string, len(docs))
+	for i, doc := range docs {
+		parts := make([]string, 0, len(doc.Body)+1)
+		if doc.Title != nil && *doc.Title != "" {
+			parts = append(parts, *doc.Title)
+		}
+		for _, body := range doc.Body {
+			if body != nil && *body != "" {
+				parts = append(parts, *body)
+			}
+		}
+		texts[i] = strings.Join(parts, "\n")
+	}
+
+	if g.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, g.timeout)
+		defer cancel()
+	}
+	scores, err := g.classifier.Score(ctx, texts)
+	if err != nil {
+		return flagged, err
+	}
+	for i := range flagged {
+		flagged[i] = i < len(scores) && scores[i] >= g.threshold
+	}
+	return flagged, nil
+}
+
+func (g *Guard) stripText(text string) string {
+	if !g.strip || text == "" {
+		return text
+	}
+	for _, pattern := range instructionPatterns {
+		text = pattern.ReplaceAllString(text, strippedMarker)
+	}
+	return text
+}
+
+func (g *Guard) wrapText(source, text string) string {
+	if g.wrap == "" {
+		return text
+	}
+	// Content must not be able to close the wrapper early
+	for _, delimiter := range g.delimiters {
+		text = strings.ReplaceAll(text, delimiter, "")
+	}
+	source = strings.Map(func(r rune) rune {
+		switch r {
+		case '"', '<', '>', '{', '}', '\n', '\r':
+			return -1
+		}
+		return r
+	}, source)
+	wrapped := strings.ReplaceAll(g.wrap, sourcePlaceholder, source)
+	return strings.Replace(wrapped, contentPlaceholder, text, 1)
+}
+
+// templateDelimiters returns the literal parts of a template around its
+// placeholders. Parts without letters, such as `">`, are too common in
+// ordinary text to remove.
+func templateDelimiters(template string) []string {
+	var delimiters []string
+	for _, part := range strings.Split(strings.ReplaceAll(template, sourcePlaceholder, contentPlaceholder), contentPlaceholder) {
+		if part = strings.TrimSpace(part); strings.IndexFunc(part, unicode.IsLetter) >= 0 {
+			delimiters = append(delimiters, part)
+		}
+	}
+	return delimiters
+}
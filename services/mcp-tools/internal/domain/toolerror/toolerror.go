@@ -0,0 +1,129 @@
+// Package toolerror defines the structured error every MCP tool reports when it fails,
+// so models can decide whether to retry and clients can show a useful message.
+package toolerror
+
+import (
+	"context"
+	"errors"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"jan-server/services/mcp-tools/internal/domain/calculator"
+)
+
+// Error codes
+const (
+	CodeInvalidArguments = "invalid_arguments" // The call can't succeed as made; fix the arguments
+	CodeUnauthorized     = "unauthorized"      // Missing or rejected credentials
+	CodeNotFound         = "not_found"         // The requested resource doesn't exist
+	CodeRateLimited      = "rate_limited"      // The upstream provider throttled the call
+	CodeTimeout          = "timeout"           // The call ran out of time
+	CodeUnavailable      = "unavailable"       // The tool or its provider is disabled or unreachable
+	CodeUpstream         = "upstream_error"    // The upstream provider failed
+	CodeCancelled        = "cancelled"         // The caller went away
+	CodeInternal         = "internal_error"    // Anything else
+)
+
+// ErrDisabled is reported by tools an operator switched off
+var ErrDisabled = New(CodeUnavailable, "tool is disabled", false)
+
+// maxDetailChars caps the provider detail so a verbose upstream body can't flood the context
+const maxDetailChars = 500
+
+// ToolError is the error payload of a failed tool call
+type ToolError struct {
+	Code      string `json:"code"`
+	Message   string `json:"message"`
+	Retryable bool   `json:"retryable"`          // Whether calling again unchanged may succeed
+	Provider  string `json:"provider,omitempty"` // Upstream provider that failed, e.g. serper or github
+	Detail    string `json:"detail,omitempty"`   // Provider response, truncated
+}
+
+// Error implements error
+func (e *ToolError) Error() string {
+	return e.Message
+}
+
+// New creates a tool error
+func New(code, message string, retryable bool) *ToolError {
+	return &ToolError{Code: code, Message: message, Retryable: retryable}
+}
+
+// statusPattern finds the HTTP status the infrastructure clients put in their
+// errors, e.g. "browser error (503): ..." or "Serper search API error (status 429): ..."
+var statusPattern = regexp.MustCompile(`(?:\((?:status )?|HTTP )([1-5]\d\d)\b`)
+
+// Classify turns any error a tool returned into a ToolError. Errors that are
+// already ToolErrors keep their code; provider fills in a missing Provider.
+func Classify(err error, provider string) *ToolError {
+	if err == nil {
+		return nil
+	}
+
+	var toolErr *ToolError
+	if errors.As(err, &toolErr) {
+		classified := *toolErr
+		if classified.Provider == "" {
+			classified.Provider = provider
+		}
+		return &classified
+	}
+
+	classified := &ToolError{Code: CodeInternal, Message: err.Error(), Provider: provider}
+	var netErr net.Error
+	switch {
+	case errors.Is(err, context.Canceled):
+		classified.Code = CodeCancelled
+	case errors.Is(err, context.DeadlineExceeded):
+		classified.Code, classified.Retryable = CodeTimeout, true
+	case errors.As(err, &netErr) && netErr.Timeout():
+		classified.Code, classified.Retryable = CodeTimeout, true
+	case errors.As(err, &netErr):
+		classified.Code, classified.Retryable = CodeUnavailable, true
+	case errors.Is(err, calculator.ErrInvalidExpression),
+		errors.Is(err, calculator.ErrUnknownUnit),
+		errors.Is(err, calculator.ErrIncompatibleUnits):
+		classified.Code = CodeInvalidArguments
+	default:
+		if match := statusPattern.FindStringSubmatch(err.Error()); match != nil {
+			status, _ := strconv.Atoi(match[1])
+			classified.Code, classified.Retryable = codeForStatus(status)
+			classified.Detail = truncate(err.Error())
+		}
+	}
+	return classified
+}
+
+// FromText classifies a failure known only by its message
+func FromText(message string) *ToolError {
+	return Classify(errors.New(strings.TrimSpace(message)), "")
+}
+
+func codeForStatus(status int) (string, bool) {
+	switch {
+	case status == 401 || status == 403:
+		return CodeUnauthorized, false
+	case status == 404:
+		return CodeNotFound, false
+	case status == 408 || status == 504:
+		return CodeTimeout, true
+	case status == 429:
+		return CodeRateLimited, true
+	case status >= 500:
+		return CodeUpstream, true
+	case status >= 400:
+		return CodeInvalidArguments, false
+	default:
+		return CodeUpstream, false
+	}
+}
+
+func truncate(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maxDetailChars {
+		return text
+	}
+	return string(runes[:maxDetailChars]) + "…"
+}
@@ -0,0 +1,257 @@
+package browser
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/janhq/jan-server/packages/go-common/egress"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+const (
+	defaultTimeout         = 30 * time.Second
+	defaultMaxMemoryMB     = 512
+	defaultMaxContentBytes = 5 << 20
+)
+
+// ClientConfig configures the headless browser client. The browser runs in a
+// separate browserless/Playwright compatible service so a misbehaving page can
+// never take the MCP process down with it.
+type ClientConfig struct {
+	URL             string
+	Token           string         // Optional browserless API token
+	Timeout         time.Duration  // Upper bound for a single render, including navigation
+	MaxMemoryMB     int            // V8 heap cap passed to the browser per session
+	MaxContentBytes int64          // Rendered HTML and screenshots larger than this are rejected
+	Egress          *egress.Policy // Checks page URLs before navigation and the URL the page ends up on
+	EgressProxyURL  string         // Forward proxy serving Egress.Proxy(); the browser sends all its traffic through it
+}
+
+// RenderRequest describes a page to render.
+type RenderRequest struct {
+	URL             string
+	WaitForSelector string        // Optional CSS selector that must appear before capture
+	Timeout         time.Duration // Optional, clamped to the client timeout
+}
+
+// ScreenshotRequest describes a screenshot capture.
+type ScreenshotRequest struct {
+	RenderRequest
+	FullPage bool
+}
+
+// Client renders JavaScript-heavy pages through a headless browser service.
+type Client struct {
+	httpClient      *resty.Client
+	token           string
+	timeout         time.Duration
+	maxMemoryMB     int
+	maxContentBytes int64
+	egress          *egress.Policy
+	egressProxyURL  string
+}
+
+// NewClient creates a browser client. An empty URL returns nil.
+func NewClient(cfg ClientConfig) *Client {
+	baseURL := strings.TrimRight(cfg.URL, "/")
+	if baseURL == "" {
+		return nil
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	maxMemoryMB := cfg.MaxMemoryMB
+	if maxMemoryMB <= 0 {
+		maxMemoryMB = defaultMaxMemoryMB
+	}
+	maxContentBytes := cfg.MaxContentBytes
+	if maxContentBytes <= 0 {
+		maxContentBytes = defaultMaxContentBytes
+	}
+	egressPolicy := cfg.Egress
+	if egressPolicy == nil {
+		egressPolicy = egress.NewPolicy(egress.Config{})
+	}
+
+	return &Client{
+		httpClient: resty.New().
+			SetBaseURL(baseURL).
+			SetHeader("User-Agent", "Jan-MCP-Browser/1.0").
+			// Leave headroom over the render budget for the service to respond.
+			SetTimeout(timeout + 5*time.Second),
+		token:           cfg.Token,
+		timeout:         timeout,
+		maxMemoryMB:     maxMemoryMB,
+		maxContentBytes: maxContentBytes,
+		egress:          egressPolicy,
+		egressProxyURL:  strings.TrimSpace(cfg.EgressProxyURL),
+	}
+}
+
+// Timeout returns the maximum render time.
+func (c *Client) Timeout() time.Duration {
+	return c.timeout
+}
+
+// Render loads the page, runs its JavaScript and returns the resulting DOM as HTML.
+func (c *Client) Render(ctx context.Context, req RenderRequest) ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("browser client is not configured")
+	}
+	if strings.TrimSpace(req.URL) == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	// Fail fast here; the egress proxy checks the navigation and everything the page loads.
+	if err := c.egress.CheckURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+
+	ctx, span := observability.StartSpan(ctx, "browser.render",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("browser.url", req.URL)),
+	)
+	defer span.End()
+
+	body, err := c.post(ctx, "/content", c.pageOptions(req), req.Timeout)
+	if err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("browser.html_bytes", len(body)))
+	return body, nil
+}
+
+// RenderHTML implements the scrape pipeline's page renderer.
+func (c *Client) RenderHTML(ctx context.Context, url string) ([]byte, error) {
+	return c.Render(ctx, RenderRequest{URL: url})
+}
+
+// Screenshot renders the page and captures it as a PNG.
+func (c *Client) Screenshot(ctx context.Context, req ScreenshotRequest) ([]byte, error) {
+	if c == nil {
+		return nil, fmt.Errorf("browser client is not configured")
+	}
+	if strings.TrimSpace(req.URL) == "" {
+		return nil, fmt.Errorf("url is required")
+	}
+	// Fail fast here; the egress proxy checks the navigation and everything the page loads.
+	if err := c.egress.CheckURL(ctx, req.URL); err != nil {
+		return nil, err
+	}
+
+	ctx, span := observability.StartSpan(ctx, "browser.screenshot",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("browser.url", req.URL),
+			attribute.Bool("browser.full_page", req.FullPage),
+		),
+	)
+	defer span.End()
+
+	payload := c.pageOptions(req.RenderRequest)
+	payload["options"] = map[string]any{
+		"type":     "png",
+		"fullPage": req.FullPage,
+	}
+	// Screenshots need images; only skip heavyweight media.
+	payload["rejectResourceTypes"] = []string{"media"}
+
+	body, err := c.post(ctx, "/screenshot", payload, req.Timeout)
+	if err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	span.SetAttributes(attribute.Int("browser.png_bytes", len(body)))
+	return body, nil
+}
+
+func (c *Client) pageOptions(req RenderRequest) map[string]any {
+	timeout := c.effectiveTimeout(req.Timeout)
+	payload := map[string]any{
+		"url": req.URL,
+		"gotoOptions": map[string]any{
+			"waitUntil": "networkidle2",
+			"timeout":   timeout.Milliseconds(),
+		},
+		// Text extraction does not need images, fonts or video.
+		"rejectResourceTypes": []string{"image", "media", "font"},
+	}
+	if selector := strings.TrimSpace(req.WaitForSelector); selector != "" {
+		payload["waitForSelector"] = map[string]any{
+			"selector": selector,
+			"timeout":  timeout.Milliseconds(),
+		}
+	}
+	return payload
+}
+
+func (c *Client) effectiveTimeout(requested time.Duration) time.Duration {
+	if requested <= 0 || requested > c.timeout {
+		return c.timeout
+	}
+	return requested
+}
+
+func (c *Client) post(ctx context.Context, path string, payload map[string]any, requested time.Duration) ([]byte, error) {
+	timeout := c.effectiveTimeout(requested)
+	ctx, cancel := context.WithTimeout(ctx, timeout+5*time.Second)
+	defer cancel()
+
+	args := []string{"--js-flags=--max-old-space-size=" + strconv.Itoa(c.maxMemoryMB)}
+	if c.egressProxyURL != "" {
+		// Chrome reaches loopback directly unless told otherwise.
+		args = append(args, "--proxy-server="+c.egressProxyURL, "--proxy-bypass-list=<-loopback>")
+	}
+	launch, _ := json.Marshal(map[string]any{
+		"headless": true,
+		"args":     args,
+	})
+	r := c.httpClient.R().
+		SetContext(ctx).
+		SetDoNotParseResponse(true).
+		SetQueryParam("timeout", strconv.FormatInt(timeout.Milliseconds(), 10)).
+		SetQueryParam("launch", string(launch)).
+		SetBody(payload)
+	if c.token != "" {
+		r.SetQueryParam("token", c.token)
+	}
+
+	resp, err := r.Post(path)
+	if err != nil {
+		return nil, fmt.Errorf("browser request failed: %w", err)
+	}
+	raw := resp.RawBody()
+	defer raw.Close()
+
+	body, err := io.ReadAll(io.LimitReader(raw, c.maxContentBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("browser response read failed: %w", err)
+	}
+	if resp.IsError() {
+		msg := strings.TrimSpace(string(body))
+		if len(msg) > 512 {
+			msg = msg[:512]
+		}
+		return nil, fmt.Errorf("browser error (%d): %s", resp.StatusCode(), msg)
+	}
+	if int64(len(body)) > c.maxContentBytes {
+		return nil, fmt.Errorf("rendered page exceeds %d bytes", c.maxContentBytes)
+	}
+	// browserless reports where the navigation ended after redirects.
+	if finalURL := resp.Header().Get("X-Response-URL"); finalURL != "" {
+		if err := c.egress.CheckURL(ctx, finalURL); err != nil {
+			return nil, err
+		}
+	}
+	return body, nil
+}
@@ -16,6 +16,13 @@ type Config struct {
 	LogLevel  string `env:"MCP_TOOLS_LOG_LEVEL" envDefault:"debug"`
 	LogFormat string `env:"MCP_TOOLS_LOG_FORMAT" envDefault:"json"` // json or console
 
+	// Tracing
+	Environment   string `env:"ENVIRONMENT" envDefault:"development"`
+	EnableTracing bool   `env:"ENABLE_TRACING" envDefault:"false"`
+	OTLPEndpoint  string `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:""`
+	OTLPHeaders   string `env:"OTEL_EXPORTER_OTLP_HEADERS"`           // Sent with exported logs
+	LogExporter   string `env:"OTEL_LOGS_EXPORTER" envDefault:"none"` // otlp: also export logs to OTLPEndpoint
+
 	// Search Configuration
 	SerperAPIKey       string   `env:"SERPER_API_KEY"`
 	SerperEnabled      bool     `env:"SERPER_ENABLED" envDefault:"true"`
@@ -77,12 +84,71 @@ type Config struct {
 	EnableFileSearch             bool `env:"MCP_ENABLE_FILE_SEARCH" envDefault:"false"`
 	EnableImageGenerate          bool `env:"MCP_ENABLE_IMAGE_GENERATE" envDefault:"true"`
 	EnableImageEdit              bool `env:"MCP_ENABLE_IMAGE_EDIT" envDefault:"true"`
+	EnableCalculator             bool `env:"MCP_ENABLE_CALCULATOR" envDefault:"true"`
+	EnableWeather                bool `env:"MCP_ENABLE_WEATHER" envDefault:"true"`
+	EnableWorldTime              bool `env:"MCP_ENABLE_WORLD_TIME" envDefault:"true"`
+	EnableHolidays               bool `env:"MCP_ENABLE_HOLIDAYS" envDefault:"true"`
+	EnableRenderPage             bool `env:"MCP_ENABLE_RENDER_PAGE" envDefault:"false"`
+	EnableGitHub                 bool `env:"MCP_ENABLE_GITHUB" envDefault:"false"`
+	EnableCalendar               bool `env:"MCP_ENABLE_CALENDAR" envDefault:"false"`
+	EnableEmail                  bool `env:"MCP_ENABLE_EMAIL" envDefault:"false"`
+
+	// World info tools (get_weather, get_world_time, list_holidays)
+	WeatherProvider string `env:"MCP_WEATHER_PROVIDER" envDefault:"open-meteo"` // open-meteo or openweathermap
+	WeatherAPIKey   string `env:"MCP_WEATHER_API_KEY"`                          // Required for openweathermap
+	WeatherBaseURL  string `env:"MCP_WEATHER_BASE_URL"`                         // Optional provider API override
+	GeocodingURL    string `env:"MCP_GEOCODING_URL" envDefault:"https://geocoding-api.open-meteo.com"`
+	HolidaysAPIURL  string `env:"MCP_HOLIDAYS_API_URL" envDefault:"https://date.nager.at"`
+
+	// Headless browser (browserless/Playwright compatible) for render_page and the scrape fallback
+	BrowserURL             string        `env:"MCP_BROWSER_URL" envDefault:"http://browserless:3000"`
+	BrowserToken           string        `env:"MCP_BROWSER_TOKEN"`
+	BrowserScrapeFallback  bool          `env:"MCP_BROWSER_SCRAPE_FALLBACK" envDefault:"false"`                  // Render pages the HTTP scraper can't read
+	BrowserTimeout         time.Duration `env:"MCP_BROWSER_TIMEOUT" envDefault:"30s"`                            // Per-request render cap
+	BrowserMaxMemoryMB     int           `env:"MCP_BROWSER_MAX_MEMORY_MB" envDefault:"512"`                      // V8 heap cap per page
+	BrowserMaxContentBytes int64         `env:"MCP_BROWSER_MAX_CONTENT_BYTES" envDefault:"5242880"`              // Max rendered HTML/screenshot size
+	BrowserEgressProxyAddr string        `env:"MCP_BROWSER_EGRESS_PROXY_ADDR" envDefault:":8092"`                // Listen address of the egress proxy for the browser
+	BrowserEgressProxyURL  string        `env:"MCP_BROWSER_EGRESS_PROXY_URL" envDefault:"http://mcp-tools:8092"` // The proxy as the browser reaches it; empty sends browser traffic unchecked
+
+	// Egress policy for requests to model-chosen URLs (scrape, render_page) and MCP provider redirects
+	EgressAllowedSchemes []string `env:"MCP_EGRESS_ALLOWED_SCHEMES" envSeparator:"," envDefault:"http,https"`
+	EgressAllowedHosts   []string `env:"MCP_EGRESS_ALLOWED_HOSTS" envSeparator:","`   // Exempt from the address checks; ".example.com" matches subdomains
+	EgressAllowPrivate   bool     `env:"MCP_EGRESS_ALLOW_PRIVATE" envDefault:"false"` // Allow private, loopback and metadata addresses (local development only)
+	EgressMaxRedirects   int      `env:"MCP_EGRESS_MAX_REDIRECTS" envDefault:"5"`
+
+	// Media API ingest endpoint for screenshots
+	MediaIngestURL string `env:"MEDIA_INGEST_URL" envDefault:"http://kong:8000/media/v1/media"`
+
+	// GitHub REST API for the GitHub tools; point at <host>/api/v3 for GitHub Enterprise
+	GitHubAPIURL string `env:"GITHUB_API_URL" envDefault:"https://api.github.com"`
+
+	// Read-only calendar and email connectors (list_calendar_events, search_emails)
+	CalendarRedact bool   `env:"MCP_CALENDAR_REDACT" envDefault:"true"` // Mask attendee addresses, numbers and link secrets
+	EmailRedact    bool   `env:"MCP_EMAIL_REDACT" envDefault:"true"`    // Mask sender addresses, numbers, codes and link secrets
+	GoogleAPIURL   string `env:"GOOGLE_API_URL" envDefault:"https://www.googleapis.com"`
+	GraphAPIURL    string `env:"MSGRAPH_API_URL" envDefault:"https://graph.microsoft.com"`
+
+	// Prompt-injection defenses for untrusted web tool output, applied before it reaches the model
+	PromptGuardEnabled             bool          `env:"MCP_PROMPT_GUARD_ENABLED" envDefault:"true"`
+	PromptGuardTools               []string      `env:"MCP_PROMPT_GUARD_TOOLS" envSeparator:"," envDefault:"google_search,scrape,render_page"`
+	PromptGuardStrip               bool          `env:"MCP_PROMPT_GUARD_STRIP" envDefault:"true"`                                                                             // Remove instruction-like patterns
+	PromptGuardWrapTemplate        string        `env:"MCP_PROMPT_GUARD_WRAP_TEMPLATE" envDefault:"<untrusted_content source=\"{source}\">\n{content}\n</untrusted_content>"` // Empty disables wrapping
+	PromptGuardClassifierURL       string        `env:"MCP_PROMPT_GUARD_CLASSIFIER_URL"`                                                                                      // Optional classifier; empty disables it
+	PromptGuardClassifierAPIKey    string        `env:"MCP_PROMPT_GUARD_CLASSIFIER_API_KEY"`
+	PromptGuardClassifierThreshold float64       `env:"MCP_PROMPT_GUARD_CLASSIFIER_THRESHOLD" envDefault:"0.8"`
+	PromptGuardClassifierTimeout   time.Duration `env:"MCP_PROMPT_GUARD_CLASSIFIER_TIMEOUT" envDefault:"2s"`
 
 	// Authentication
 	AuthEnabled bool   `env:"AUTH_ENABLED" envDefault:"false"`
 	AuthIssuer  string `env:"AUTH_ISSUER"`
 	Account     string `env:"ACCOUNT"`
 	AuthJWKSURL string `env:"AUTH_JWKS_URL"`
+	// Third-party clients whose exchanged tokens only llm-api accepts; rejected here
+	IntegrationClients []string `env:"INTEGRATION_CLIENTS" envSeparator:","`
+
+	// Maintenance mode switched on llm-api, shared through this Redis; off when unset
+	RedisURL                   string        `env:"REDIS_URL"`
+	MaintenanceRefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL" envDefault:"5s"`
 }
 
 // LoadConfig loads configuration from environment variables
@@ -142,6 +208,14 @@ func LoadConfig() (*Config, error) {
 	if cfg.TavilyEnabled && strings.TrimSpace(cfg.TavilyAPIKey) == "" {
 		return nil, fmt.Errorf("TAVILY_API_KEY is required when TAVILY_ENABLED is true")
 	}
+	if cfg.EnableWeather && strings.EqualFold(strings.TrimSpace(cfg.WeatherProvider), "openweathermap") && strings.TrimSpace(cfg.WeatherAPIKey) == "" {
+		return nil, fmt.Errorf("MCP_WEATHER_API_KEY is required when MCP_WEATHER_PROVIDER is openweathermap")
+	}
+	// Literal \n in the env value stands for a newline
+	cfg.PromptGuardWrapTemplate = strings.ReplaceAll(cfg.PromptGuardWrapTemplate, `\n`, "\n")
+	if cfg.PromptGuardWrapTemplate != "" && strings.Count(cfg.PromptGuardWrapTemplate, "{content}") != 1 {
+		return nil, fmt.Errorf("MCP_PROMPT_GUARD_WRAP_TEMPLATE must contain {content} exactly once")
+	}
 	if cfg.SearxngEnabled && strings.TrimSpace(cfg.SearxngURL) == "" {
 		return nil, fmt.Errorf("SEARXNG_URL is required when SEARXNG_ENABLED is true")
 	}
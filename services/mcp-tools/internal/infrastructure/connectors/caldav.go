@@ -0,0 +1,233 @@
+package connectors
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const caldavTimeLayout = "20060102T150405Z"
+
+// caldavClient reads events from a CalDAV calendar collection with a
+// calendar-query REPORT (RFC 4791).
+type caldavClient struct {
+	httpClient *resty.Client
+	maxBytes   int64
+}
+
+func newCalDAVClient(cfg ClientConfig) *caldavClient {
+	maxBytes := cfg.MaxEventBytes
+	if maxBytes <= 0 {
+		maxBytes = 5 << 20
+	}
+	return &caldavClient{
+		httpClient: resty.New().
+			SetHeader("User-Agent", cfg.UserAgent).
+			SetTimeout(cfg.Timeout),
+		maxBytes: maxBytes,
+	}
+}
+
+type caldavMultistatus struct {
+	Responses []struct {
+		Propstat []struct {
+			CalendarData string `xml:"prop>calendar-data"`
+			Status       string `xml:"status"`
+		} `xml:"propstat"`
+	} `xml:"response"`
+}
+
+func (c *caldavClient) listEvents(ctx context.Context, cred Credential, query EventQuery) ([]Event, error) {
+	endpoint, err := url.Parse(cred.Endpoint)
+	if err != nil || endpoint.Scheme != "https" || endpoint.Host == "" {
+		return nil, fmt.Errorf("caldav endpoint must be an https calendar collection URL")
+	}
+
+	start := query.Start.UTC().Format(caldavTimeLayout)
+	end := query.End.UTC().Format(caldavTimeLayout)
+	// expand asks the server to return recurring events as individual
+	// occurrences inside the window, so no RRULE handling is needed here.
+	body := fmt.Sprintf(`<?xml version="1.0" encoding="utf-8"?>
+<C:calendar-query xmlns:D="DAV:" xmlns:C="urn:ietf:params:xml:ns:caldav">
+  <D:prop>
+    <C:calendar-data><C:expand start="%[1]s" end="%[2]s"/></C:calendar-data>
+  </D:prop>
+  <C:filter>
+    <C:comp-filter name="VCALENDAR">
+      <C:comp-filter name="VEVENT"><C:time-range start="%[1]s" end="%[2]s"/></C:comp-filter>
+    </C:comp-filter>
+  </C:filter>
+</C:calendar-query>`, start, end)
+
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBasicAuth(cred.Account, cred.Token).
+		SetHeader("Depth", "1").
+		SetHeader("Content-Type", "application/xml; charset=utf-8").
+		SetBody(body).
+		Execute("REPORT", endpoint.String())
+	if err := checkResponse("caldav", resp, err); err != nil {
+		return nil, err
+	}
+	if int64(len(resp.Body())) > c.maxBytes {
+		return nil, fmt.Errorf("caldav response exceeds %d bytes; narrow the time window", c.maxBytes)
+	}
+
+	var multistatus caldavMultistatus
+	if err := xml.Unmarshal(resp.Body(), &multistatus); err != nil {
+		return nil, fmt.Errorf("decode caldav response: %w", err)
+	}
+
+	var events []Event
+	for _, response := range multistatus.Responses {
+		for _, propstat := range response.Propstat {
+			if propstat.CalendarData == "" {
+				continue
+			}
+			for _, event := range parseICalendarEvents(propstat.CalendarData) {
+				// Servers that ignore expand still return the master event.
+				if event.End.After(query.Start) && event.Start.Before(query.End) {
+					events = append(events, event)
+				}
+			}
+		}
+	}
+	sort.SliceStable(events, func(i, j int) bool { return events[i].Start.Before(events[j].Start) })
+	if len(events) > query.Limit {
+		events = events[:query.Limit]
+	}
+	return events, nil
+}
+
+// parseICalendarEvents extracts VEVENTs from iCalendar text (RFC 5545). Only
+// the properties the calendar tool returns are read.
+func parseICalendarEvents(data string) []Event {
+	var (
+		events  []Event
+		current *Event
+		status  string
+	)
+	for _, line := range unfoldICalendar(data) {
+		name, params, value := splitICalendarLine(line)
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &Event{}
+			status = ""
+		case name == "END" && value == "VEVENT":
+			if current != nil && !current.Start.IsZero() && status != "CANCELLED" {
+				if current.End.IsZero() {
+					current.End = current.Start
+					if current.AllDay {
+						current.End = current.Start.AddDate(0, 0, 1)
+					}
+				}
+				events = append(events, *current)
+			}
+			current = nil
+		case current == nil:
+			continue
+		case name == "SUMMARY":
+			current.Title = unescapeICalendar(value)
+		case name == "LOCATION":
+			current.Location = unescapeICalendar(value)
+		case name == "DESCRIPTION":
+			current.Description = unescapeICalendar(value)
+		case name == "URL":
+			current.URL = value
+		case name == "STATUS":
+			status = strings.ToUpper(value)
+		case name == "DTSTART":
+			current.Start, current.AllDay = parseICalendarTime(value, params)
+		case name == "DTEND":
+			current.End, _ = parseICalendarTime(value, params)
+		case name == "ORGANIZER":
+			person := icalendarPerson(value, params)
+			current.Organizer = &person
+		case name == "ATTENDEE":
+			current.Attendees = append(current.Attendees, icalendarPerson(value, params))
+		}
+	}
+	return events
+}
+
+// unfoldICalendar joins folded continuation lines.
+func unfoldICalendar(data string) []string {
+	raw := strings.Split(strings.ReplaceAll(data, "\r\n", "\n"), "\n")
+	lines := make([]string, 0, len(raw))
+	for _, line := range raw {
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
+
+// splitICalendarLine splits `NAME;PARAM=x;PARAM2="y":value`.
+func splitICalendarLine(line string) (string, map[string]string, string) {
+	params := map[string]string{}
+	inQuotes := false
+	colon := -1
+	for i, r := range line {
+		if r == '"' {
+			inQuotes = !inQuotes
+		}
+		if r == ':' && !inQuotes {
+			colon = i
+			break
+		}
+	}
+	if colon < 0 {
+		return strings.ToUpper(line), params, ""
+	}
+	parts := strings.Split(line[:colon], ";")
+	for _, part := range parts[1:] {
+		if key, val, ok := strings.Cut(part, "="); ok {
+			params[strings.ToUpper(key)] = strings.Trim(val, `"`)
+		}
+	}
+	return strings.ToUpper(parts[0]), params, line[colon+1:]
+}
+
+func parseICalendarTime(value string, params map[string]string) (time.Time, bool) {
+	if params["VALUE"] == "DATE" || len(value) == 8 {
+		t, err := time.Parse("20060102", value)
+		if err != nil {
+			return time.Time{}, false
+		}
+		return t, true
+	}
+	if strings.HasSuffix(value, "Z") {
+		t, _ := time.Parse(caldavTimeLayout, value)
+		return t, false
+	}
+	loc := time.UTC
+	if tzid := params["TZID"]; tzid != "" {
+		if l, err := time.LoadLocation(tzid); err == nil {
+			loc = l
+		}
+	}
+	t, _ := time.ParseInLocation("20060102T150405", value, loc)
+	return t, false
+}
+
+func icalendarPerson(value string, params map[string]string) Person {
+	email := value
+	if len(email) > 7 && strings.EqualFold(email[:7], "mailto:") {
+		email = email[7:]
+	}
+	return Person{Name: params["CN"], Email: email}
+}
+
+func unescapeICalendar(value string) string {
+	return strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
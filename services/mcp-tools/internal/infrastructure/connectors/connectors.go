@@ -0,0 +1,215 @@
+// Package connectors reads calendars and mailboxes the user connected in the
+// LLM-API MCP credential store. All access is read-only.
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+// Provider names match the LLM-API MCP credential providers.
+const (
+	ProviderGoogle    = "google"
+	ProviderMicrosoft = "microsoft"
+	ProviderCalDAV    = "caldav"
+)
+
+// Consent scopes the user must have granted when connecting the provider.
+const (
+	ScopeCalendarRead = "calendar:read"
+	ScopeMailRead     = "mail:read"
+)
+
+// CalendarProviders and MailProviders list the providers in the order they
+// are tried when the caller does not pick one.
+var (
+	CalendarProviders = []string{ProviderGoogle, ProviderMicrosoft, ProviderCalDAV}
+	MailProviders     = []string{ProviderGoogle, ProviderMicrosoft}
+)
+
+// Credential is the user's connected account for a provider.
+type Credential struct {
+	Token    string // OAuth access token, or the app password for CalDAV
+	Account  string // Login; the CalDAV username
+	Endpoint string // CalDAV calendar collection URL
+}
+
+// Person is an event organizer or attendee, or an email sender.
+type Person struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// Event is a calendar event.
+type Event struct {
+	Title       string    `json:"title"`
+	Start       time.Time `json:"start"`
+	End         time.Time `json:"end"`
+	AllDay      bool      `json:"all_day"`
+	Location    string    `json:"location,omitempty"`
+	Description string    `json:"description,omitempty"`
+	Organizer   *Person   `json:"organizer,omitempty"`
+	Attendees   []Person  `json:"attendees,omitempty"`
+	URL         string    `json:"url,omitempty"`
+}
+
+// Email is a message summary. Bodies are never fetched; Snippet is the
+// provider's short preview.
+type Email struct {
+	ID         string    `json:"id"`
+	From       Person    `json:"from"`
+	Subject    string    `json:"subject"`
+	Snippet    string    `json:"snippet,omitempty"`
+	ReceivedAt time.Time `json:"received_at"`
+	Unread     bool      `json:"unread"`
+	URL        string    `json:"url,omitempty"`
+}
+
+// EventQuery bounds a calendar listing.
+type EventQuery struct {
+	Start time.Time
+	End   time.Time
+	Limit int
+}
+
+// EmailQuery narrows a mailbox search.
+type EmailQuery struct {
+	Query string // Free text; provider search syntax is passed through
+	Since time.Time
+	Limit int
+}
+
+// Client dispatches reads to the provider APIs.
+type Client struct {
+	google    *googleClient
+	microsoft *microsoftClient
+	caldav    *caldavClient
+}
+
+// ClientConfig configures the provider API endpoints.
+type ClientConfig struct {
+	GoogleAPIURL  string // Defaults to https://www.googleapis.com
+	GraphAPIURL   string // Defaults to https://graph.microsoft.com
+	Timeout       time.Duration
+	UserAgent     string
+	MaxEventBytes int64 // Cap on CalDAV responses
+}
+
+// NewClient creates a connectors client.
+func NewClient(cfg ClientConfig) *Client {
+	if cfg.Timeout <= 0 {
+		cfg.Timeout = 15 * time.Second
+	}
+	if cfg.UserAgent == "" {
+		cfg.UserAgent = "Jan-MCP-Connectors/1.0"
+	}
+	return &Client{
+		google:    newGoogleClient(cfg),
+		microsoft: newMicrosoftClient(cfg),
+		caldav:    newCalDAVClient(cfg),
+	}
+}
+
+// ListEvents lists events overlapping the query window, ordered by start time.
+func (c *Client) ListEvents(ctx context.Context, provider string, cred Credential, query EventQuery) ([]Event, error) {
+	if !query.End.After(query.Start) {
+		return nil, fmt.Errorf("end of the time window must be after its start")
+	}
+	query.Limit = clampLimit(query.Limit)
+
+	ctx, span := startSpan(ctx, "connectors.list_events", provider)
+	defer span.End()
+
+	var (
+		events []Event
+		err    error
+	)
+	switch provider {
+	case ProviderGoogle:
+		events, err = c.google.listEvents(ctx, cred, query)
+	case ProviderMicrosoft:
+		events, err = c.microsoft.listEvents(ctx, cred, query)
+	case ProviderCalDAV:
+		events, err = c.caldav.listEvents(ctx, cred, query)
+	default:
+		err = fmt.Errorf("calendar provider %q is not supported", provider)
+	}
+	if err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	return events, nil
+}
+
+// SearchEmails returns the most recent messages matching the query.
+func (c *Client) SearchEmails(ctx context.Context, provider string, cred Credential, query EmailQuery) ([]Email, error) {
+	query.Limit = clampLimit(query.Limit)
+
+	ctx, span := startSpan(ctx, "connectors.search_emails", provider)
+	defer span.End()
+
+	var (
+		emails []Email
+		err    error
+	)
+	switch provider {
+	case ProviderGoogle:
+		emails, err = c.google.searchEmails(ctx, cred, query)
+	case ProviderMicrosoft:
+		emails, err = c.microsoft.searchEmails(ctx, cred, query)
+	default:
+		err = fmt.Errorf("mail provider %q is not supported", provider)
+	}
+	if err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	return emails, nil
+}
+
+func startSpan(ctx context.Context, name, provider string) (context.Context, trace.Span) {
+	return observability.StartSpan(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("connectors.provider", provider)),
+	)
+}
+
+func checkResponse(provider string, resp *resty.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("%s request failed: %w", provider, err)
+	}
+	switch resp.StatusCode() {
+	case 401:
+		return fmt.Errorf("%s rejected the connected credential; reconnect it", provider)
+	case 403:
+		return fmt.Errorf("%s denied access; the connected token may lack the read scope", provider)
+	case 404:
+		return fmt.Errorf("%s resource not found", provider)
+	}
+	if resp.IsError() {
+		body := resp.String()
+		if len(body) > 300 {
+			body = body[:300]
+		}
+		return fmt.Errorf("%s error (%d): %s", provider, resp.StatusCode(), body)
+	}
+	return nil
+}
+
+func clampLimit(limit int) int {
+	switch {
+	case limit <= 0:
+		return 10
+	case limit > 50:
+		return 50
+	default:
+		return limit
+	}
+}
@@ -0,0 +1,221 @@
+package connectors
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const defaultGoogleAPIURL = "https://www.googleapis.com"
+
+// googleClient reads Google Calendar and Gmail.
+type googleClient struct {
+	httpClient *resty.Client
+}
+
+func newGoogleClient(cfg ClientConfig) *googleClient {
+	baseURL := strings.TrimRight(strings.TrimSpace(cfg.GoogleAPIURL), "/")
+	if baseURL == "" {
+		baseURL = defaultGoogleAPIURL
+	}
+	return &googleClient{
+		httpClient: resty.New().
+			SetBaseURL(baseURL).
+			SetHeader("User-Agent", cfg.UserAgent).
+			SetTimeout(cfg.Timeout),
+	}
+}
+
+type googleEventTime struct {
+	DateTime string `json:"dateTime"`
+	Date     string `json:"date"`
+}
+
+type googlePerson struct {
+	Email       string `json:"email"`
+	DisplayName string `json:"displayName"`
+}
+
+func (g *googleClient) listEvents(ctx context.Context, cred Credential, query EventQuery) ([]Event, error) {
+	var result struct {
+		Items []struct {
+			Status      string          `json:"status"`
+			Summary     string          `json:"summary"`
+			Location    string          `json:"location"`
+			Description string          `json:"description"`
+			HTMLLink    string          `json:"htmlLink"`
+			Start       googleEventTime `json:"start"`
+			End         googleEventTime `json:"end"`
+			Organizer   *googlePerson   `json:"organizer"`
+			Attendees   []googlePerson  `json:"attendees"`
+		} `json:"items"`
+	}
+	resp, err := g.httpClient.R().
+		SetContext(ctx).
+		SetAuthToken(cred.Token).
+		SetQueryParams(map[string]string{
+			"timeMin":      query.Start.UTC().Format(time.RFC3339),
+			"timeMax":      query.End.UTC().Format(time.RFC3339),
+			"singleEvents": "true",
+			"orderBy":      "startTime",
+			"maxResults":   strconv.Itoa(query.Limit),
+		}).
+		SetResult(&result).
+		Get("/calendar/v3/calendars/primary/events")
+	if err := checkResponse("google", resp, err); err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(result.Items))
+	for _, item := range result.Items {
+		if item.Status == "cancelled" {
+			continue
+		}
+		start, allDay, err := parseGoogleTime(item.Start)
+		if err != nil {
+			continue
+		}
+		end, _, err := parseGoogleTime(item.End)
+		if err != nil {
+			end = start
+		}
+		event := Event{
+			Title:       item.Summary,
+			Start:       start,
+			End:         end,
+			AllDay:      allDay,
+			Location:    item.Location,
+			Description: item.Description,
+			URL:         item.HTMLLink,
+		}
+		if item.Organizer != nil {
+			event.Organizer = &Person{Name: item.Organizer.DisplayName, Email: item.Organizer.Email}
+		}
+		for _, attendee := range item.Attendees {
+			event.Attendees = append(event.Attendees, Person{Name: attendee.DisplayName, Email: attendee.Email})
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func parseGoogleTime(t googleEventTime) (time.Time, bool, error) {
+	if t.DateTime != "" {
+		parsed, err := time.Parse(time.RFC3339, t.DateTime)
+		return parsed, false, err
+	}
+	parsed, err := time.Parse("2006-01-02", t.Date)
+	return parsed, true, err
+}
+
+func (g *googleClient) searchEmails(ctx context.Context, cred Credential, query EmailQuery) ([]Email, error) {
+	q := strings.TrimSpace(query.Query)
+	if !query.Since.IsZero() {
+		q = strings.TrimSpace(q + " after:" + strconv.FormatInt(query.Since.Unix(), 10))
+	}
+
+	var list struct {
+		Messages []struct {
+			ID string `json:"id"`
+		} `json:"messages"`
+	}
+	resp, err := g.httpClient.R().
+		SetContext(ctx).
+		SetAuthToken(cred.Token).
+		SetQueryParam("q", q).
+		SetQueryParam("maxResults", strconv.Itoa(query.Limit)).
+		SetResult(&list).
+		Get("/gmail/v1/users/me/messages")
+	if err := checkResponse("google", resp, err); err != nil {
+		return nil, err
+	}
+
+	// Gmail only returns IDs from a search; fetch the headers concurrently.
+	emails := make([]Email, len(list.Messages))
+	errs := make([]error, len(list.Messages))
+	var wg sync.WaitGroup
+	for i, message := range list.Messages {
+		wg.Add(1)
+		go func(i int, id string) {
+			defer wg.Done()
+			emails[i], errs[i] = g.messageMetadata(ctx, cred, id)
+		}(i, message.ID)
+	}
+	wg.Wait()
+
+	found := make([]Email, 0, len(emails))
+	for i, email := range emails {
+		if errs[i] != nil {
+			return nil, errs[i]
+		}
+		found = append(found, email)
+	}
+	return found, nil
+}
+
+func (g *googleClient) messageMetadata(ctx context.Context, cred Credential, id string) (Email, error) {
+	var message struct {
+		ID           string   `json:"id"`
+		ThreadID     string   `json:"threadId"`
+		Snippet      string   `json:"snippet"`
+		LabelIDs     []string `json:"labelIds"`
+		InternalDate string   `json:"internalDate"`
+		Payload      struct {
+			Headers []struct {
+				Name  string `json:"name"`
+				Value string `json:"value"`
+			} `json:"headers"`
+		} `json:"payload"`
+	}
+	resp, err := g.httpClient.R().
+		SetContext(ctx).
+		SetAuthToken(cred.Token).
+		SetQueryParam("format", "metadata").
+		SetQueryParamsFromValues(map[string][]string{"metadataHeaders": {"From", "Subject"}}).
+		SetResult(&message).
+		Get("/gmail/v1/users/me/messages/" + id)
+	if err := checkResponse("google", resp, err); err != nil {
+		return Email{}, err
+	}
+
+	email := Email{
+		ID:      message.ID,
+		Snippet: message.Snippet,
+		URL:     fmt.Sprintf("https://mail.google.com/mail/u/0/#all/%s", message.ThreadID),
+	}
+	for _, header := range message.Payload.Headers {
+		switch strings.ToLower(header.Name) {
+		case "from":
+			email.From = parseAddress(header.Value)
+		case "subject":
+			email.Subject = header.Value
+		}
+	}
+	for _, label := range message.LabelIDs {
+		if label == "UNREAD" {
+			email.Unread = true
+		}
+	}
+	if ms, err := strconv.ParseInt(message.InternalDate, 10, 64); err == nil {
+		email.ReceivedAt = time.UnixMilli(ms).UTC()
+	}
+	return email, nil
+}
+
+// parseAddress splits `"Name" <addr@example.com>` into a Person.
+func parseAddress(value string) Person {
+	value = strings.TrimSpace(value)
+	open := strings.LastIndex(value, "<")
+	if open < 0 || !strings.HasSuffix(value, ">") {
+		return Person{Email: value}
+	}
+	return Person{
+		Name:  strings.Trim(strings.TrimSpace(value[:open]), `"`),
+		Email: value[open+1 : len(value)-1],
+	}
+}
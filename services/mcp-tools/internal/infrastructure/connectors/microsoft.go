@@ -0,0 +1,168 @@
+package connectors
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const defaultGraphAPIURL = "https://graph.microsoft.com"
+
+// graphTimeLayout is the dateTime format of Graph dateTimeTimeZone values.
+const graphTimeLayout = "2006-01-02T15:04:05.9999999"
+
+// microsoftClient reads Outlook mail and calendars through Microsoft Graph.
+type microsoftClient struct {
+	httpClient *resty.Client
+}
+
+func newMicrosoftClient(cfg ClientConfig) *microsoftClient {
+	baseURL := strings.TrimRight(strings.TrimSpace(cfg.GraphAPIURL), "/")
+	if baseURL == "" {
+		baseURL = defaultGraphAPIURL
+	}
+	return &microsoftClient{
+		httpClient: resty.New().
+			SetBaseURL(baseURL).
+			SetHeader("User-Agent", cfg.UserAgent).
+			SetTimeout(cfg.Timeout),
+	}
+}
+
+type graphEmailAddress struct {
+	EmailAddress struct {
+		Name    string `json:"name"`
+		Address string `json:"address"`
+	} `json:"emailAddress"`
+}
+
+func (a graphEmailAddress) person() Person {
+	return Person{Name: a.EmailAddress.Name, Email: a.EmailAddress.Address}
+}
+
+func (m *microsoftClient) listEvents(ctx context.Context, cred Credential, query EventQuery) ([]Event, error) {
+	var result struct {
+		Value []struct {
+			Subject     string `json:"subject"`
+			BodyPreview string `json:"bodyPreview"`
+			IsAllDay    bool   `json:"isAllDay"`
+			IsCancelled bool   `json:"isCancelled"`
+			WebLink     string `json:"webLink"`
+			Start       struct {
+				DateTime string `json:"dateTime"`
+			} `json:"start"`
+			End struct {
+				DateTime string `json:"dateTime"`
+			} `json:"end"`
+			Location struct {
+				DisplayName string `json:"displayName"`
+			} `json:"location"`
+			Organizer *graphEmailAddress  `json:"organizer"`
+			Attendees []graphEmailAddress `json:"attendees"`
+		} `json:"value"`
+	}
+	resp, err := m.httpClient.R().
+		SetContext(ctx).
+		SetAuthToken(cred.Token).
+		// Ask for UTC so dateTime values can be parsed without a zone lookup.
+		SetHeader("Prefer", `outlook.timezone="UTC"`).
+		SetQueryParams(map[string]string{
+			"startDateTime": query.Start.UTC().Format(time.RFC3339),
+			"endDateTime":   query.End.UTC().Format(time.RFC3339),
+			"$orderby":      "start/dateTime",
+			"$top":          strconv.Itoa(query.Limit),
+			"$select":       "subject,bodyPreview,isAllDay,isCancelled,webLink,start,end,location,organizer,attendees",
+		}).
+		SetResult(&result).
+		Get("/v1.0/me/calendarView")
+	if err := checkResponse("microsoft", resp, err); err != nil {
+		return nil, err
+	}
+
+	events := make([]Event, 0, len(result.Value))
+	for _, item := range result.Value {
+		if item.IsCancelled {
+			continue
+		}
+		start, err := time.ParseInLocation(graphTimeLayout, item.Start.DateTime, time.UTC)
+		if err != nil {
+			continue
+		}
+		end, err := time.ParseInLocation(graphTimeLayout, item.End.DateTime, time.UTC)
+		if err != nil {
+			end = start
+		}
+		event := Event{
+			Title:       item.Subject,
+			Start:       start,
+			End:         end,
+			AllDay:      item.IsAllDay,
+			Location:    item.Location.DisplayName,
+			Description: item.BodyPreview,
+			URL:         item.WebLink,
+		}
+		if item.Organizer != nil {
+			organizer := item.Organizer.person()
+			event.Organizer = &organizer
+		}
+		for _, attendee := range item.Attendees {
+			event.Attendees = append(event.Attendees, attendee.person())
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+func (m *microsoftClient) searchEmails(ctx context.Context, cred Credential, query EmailQuery) ([]Email, error) {
+	var result struct {
+		Value []struct {
+			ID               string            `json:"id"`
+			Subject          string            `json:"subject"`
+			BodyPreview      string            `json:"bodyPreview"`
+			ReceivedDateTime time.Time         `json:"receivedDateTime"`
+			IsRead           bool              `json:"isRead"`
+			WebLink          string            `json:"webLink"`
+			From             graphEmailAddress `json:"from"`
+		} `json:"value"`
+	}
+	r := m.httpClient.R().
+		SetContext(ctx).
+		SetAuthToken(cred.Token).
+		SetQueryParam("$top", strconv.Itoa(query.Limit)).
+		SetQueryParam("$select", "id,subject,bodyPreview,receivedDateTime,isRead,webLink,from").
+		SetResult(&result)
+	// Graph does not allow $search together with $filter or $orderby on
+	// messages, so a text search is filtered by date afterwards.
+	if q := strings.TrimSpace(query.Query); q != "" {
+		r.SetQueryParam("$search", `"`+strings.ReplaceAll(q, `"`, "")+`"`)
+	} else {
+		r.SetQueryParam("$orderby", "receivedDateTime desc")
+		if !query.Since.IsZero() {
+			r.SetQueryParam("$filter", "receivedDateTime ge "+query.Since.UTC().Format(time.RFC3339))
+		}
+	}
+	resp, err := r.Get("/v1.0/me/messages")
+	if err := checkResponse("microsoft", resp, err); err != nil {
+		return nil, err
+	}
+
+	emails := make([]Email, 0, len(result.Value))
+	for _, item := range result.Value {
+		if !query.Since.IsZero() && item.ReceivedDateTime.Before(query.Since) {
+			continue
+		}
+		emails = append(emails, Email{
+			ID:         item.ID,
+			From:       item.From.person(),
+			Subject:    item.Subject,
+			Snippet:    item.BodyPreview,
+			ReceivedAt: item.ReceivedDateTime,
+			Unread:     !item.IsRead,
+			URL:        item.WebLink,
+		})
+	}
+	return emails, nil
+}
@@ -0,0 +1,77 @@
+package connectors
+
+import (
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+var (
+	emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// Digit runs with optional separators: phone, account and card numbers, one-time codes.
+	numberPattern = regexp.MustCompile(`\+?\d[\d ().\-]*\d`)
+	// Dates are left alone even though they look like digit runs.
+	datePattern = regexp.MustCompile(`^(\d{4}-\d{1,2}-\d{1,2}|\d{1,2}\.\d{1,2}\.\d{2,4})`)
+	// Query strings and fragments carry meeting passcodes and sign-in tokens.
+	urlSecretPattern = regexp.MustCompile(`(https?://[^\s?#<>"]+)[?#][^\s<>"]*`)
+)
+
+// Redactor masks personal and secret data in connector output before it
+// reaches the model. A disabled Redactor returns its input unchanged.
+type Redactor struct {
+	enabled bool
+}
+
+// NewRedactor creates a redactor.
+func NewRedactor(enabled bool) *Redactor {
+	return &Redactor{enabled: enabled}
+}
+
+// Enabled reports whether redaction is on.
+func (r *Redactor) Enabled() bool {
+	return r != nil && r.enabled
+}
+
+// Text masks email addresses, phone and account numbers, one-time codes and
+// URL query strings in free text.
+func (r *Redactor) Text(text string) string {
+	if !r.Enabled() || text == "" {
+		return text
+	}
+	text = urlSecretPattern.ReplaceAllString(text, "$1")
+	text = emailPattern.ReplaceAllString(text, "[email]")
+	return numberPattern.ReplaceAllStringFunc(text, func(match string) string {
+		if datePattern.MatchString(match) {
+			return match
+		}
+		digits := 0
+		for _, r := range match {
+			if unicode.IsDigit(r) {
+				digits++
+			}
+		}
+		switch {
+		case digits >= 9:
+			return "[number]"
+		case digits >= 6 && !strings.ContainsAny(match, " ().-"):
+			// Bare 6-8 digit runs are almost always verification codes.
+			return "[code]"
+		default:
+			return match
+		}
+	})
+}
+
+// Person keeps a person's display name and masks their address down to the
+// domain, e.g. "Alice <…@example.com>".
+func (r *Redactor) Person(p Person) Person {
+	if !r.Enabled() {
+		return p
+	}
+	if _, domain, ok := strings.Cut(p.Email, "@"); ok {
+		p.Email = "…@" + domain
+	} else if p.Email != "" {
+		p.Email = "[email]"
+	}
+	return p
+}
@@ -0,0 +1,385 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+const defaultBaseURL = "https://api.github.com"
+
+// CodeMatch is a code search hit.
+type CodeMatch struct {
+	Repository string   `json:"repository"`
+	Path       string   `json:"path"`
+	URL        string   `json:"url"`
+	Fragments  []string `json:"fragments,omitempty"`
+}
+
+// File is a file read from a repository.
+type File struct {
+	Repository string `json:"repository"`
+	Path       string `json:"path"`
+	Ref        string `json:"ref,omitempty"`
+	SHA        string `json:"sha"`
+	Size       int    `json:"size"`
+	URL        string `json:"url"`
+	Content    string `json:"content"`
+}
+
+// DirEntry is an entry of a directory listing.
+type DirEntry struct {
+	Name string `json:"name"`
+	Path string `json:"path"`
+	Type string `json:"type"` // file, dir, symlink or submodule
+	Size int    `json:"size"`
+}
+
+// Issue is an issue or pull request.
+type Issue struct {
+	Number        int       `json:"number"`
+	Title         string    `json:"title"`
+	State         string    `json:"state"`
+	Author        string    `json:"author"`
+	Labels        []string  `json:"labels,omitempty"`
+	Comments      int       `json:"comments"`
+	IsPullRequest bool      `json:"is_pull_request"`
+	URL           string    `json:"url"`
+	Body          string    `json:"body,omitempty"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Comment is an issue comment.
+type Comment struct {
+	ID        int64     `json:"id"`
+	URL       string    `json:"url"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// IssueFilter narrows ListIssues.
+type IssueFilter struct {
+	State   string // open (default), closed or all
+	Labels  []string
+	PerPage int
+}
+
+// Client calls the GitHub REST API with the caller's token. It holds no
+// credentials itself; every call takes the user's OAuth token.
+type Client struct {
+	httpClient *resty.Client
+}
+
+// NewClient creates a GitHub client. baseURL may point at a GitHub Enterprise
+// API (https://ghe.example.com/api/v3); empty uses api.github.com.
+func NewClient(baseURL string) *Client {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	return &Client{
+		httpClient: resty.New().
+			SetBaseURL(baseURL).
+			SetHeader("User-Agent", "Jan-MCP-GitHub/1.0").
+			SetHeader("Accept", "application/vnd.github+json").
+			SetHeader("X-GitHub-Api-Version", "2022-11-28").
+			SetTimeout(15 * time.Second),
+	}
+}
+
+// SearchCode searches code with GitHub's code search syntax (e.g. "Client repo:owner/name language:go").
+func (c *Client) SearchCode(ctx context.Context, token, query string, perPage int) ([]CodeMatch, int, error) {
+	ctx, span := c.startSpan(ctx, "github.search_code", attribute.String("github.query", query))
+	defer span.End()
+
+	var result struct {
+		TotalCount int `json:"total_count"`
+		Items      []struct {
+			Path       string `json:"path"`
+			HTMLURL    string `json:"html_url"`
+			Repository struct {
+				FullName string `json:"full_name"`
+			} `json:"repository"`
+			TextMatches []struct {
+				Fragment string `json:"fragment"`
+			} `json:"text_matches"`
+		} `json:"items"`
+	}
+	resp, err := c.request(ctx, token).
+		SetHeader("Accept", "application/vnd.github.text-match+json").
+		SetQueryParam("q", query).
+		SetQueryParam("per_page", strconv.Itoa(clampPerPage(perPage))).
+		SetResult(&result).
+		Get("/search/code")
+	if err := checkResponse(resp, err); err != nil {
+		observability.RecordError(span, err)
+		return nil, 0, err
+	}
+
+	matches := make([]CodeMatch, 0, len(result.Items))
+	for _, item := range result.Items {
+		match := CodeMatch{
+			Repository: item.Repository.FullName,
+			Path:       item.Path,
+			URL:        item.HTMLURL,
+		}
+		for _, tm := range item.TextMatches {
+			match.Fragments = append(match.Fragments, tm.Fragment)
+		}
+		matches = append(matches, match)
+	}
+	return matches, result.TotalCount, nil
+}
+
+// ReadFile reads a file, or lists a directory when path names one. Exactly one
+// of the returned file and entries is set.
+func (c *Client) ReadFile(ctx context.Context, token, repo, path, ref string) (*File, []DirEntry, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, span := c.startSpan(ctx, "github.read_file",
+		attribute.String("github.repo", repo),
+		attribute.String("github.path", path),
+	)
+	defer span.End()
+
+	r := c.request(ctx, token)
+	if ref = strings.TrimSpace(ref); ref != "" {
+		r.SetQueryParam("ref", ref)
+	}
+	resp, err := r.Get(fmt.Sprintf("/repos/%s/%s/contents/%s", url.PathEscape(owner), url.PathEscape(name), escapePath(path)))
+	if err := checkResponse(resp, err); err != nil {
+		observability.RecordError(span, err)
+		return nil, nil, err
+	}
+
+	body := resp.Body()
+	if len(body) > 0 && body[0] == '[' {
+		var entries []DirEntry
+		if err := json.Unmarshal(body, &entries); err != nil {
+			return nil, nil, fmt.Errorf("decode directory listing: %w", err)
+		}
+		return nil, entries, nil
+	}
+
+	var content struct {
+		Type     string `json:"type"`
+		Path     string `json:"path"`
+		SHA      string `json:"sha"`
+		Size     int    `json:"size"`
+		HTMLURL  string `json:"html_url"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+	}
+	if err := json.Unmarshal(body, &content); err != nil {
+		return nil, nil, fmt.Errorf("decode file: %w", err)
+	}
+	if content.Type != "file" {
+		return nil, nil, fmt.Errorf("%s is a %s, not a file", path, content.Type)
+	}
+	if content.Encoding != "base64" {
+		// GitHub omits the content of files over 1 MB.
+		return nil, nil, fmt.Errorf("%s is too large to read through the contents API (%d bytes)", path, content.Size)
+	}
+	decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+	if err != nil {
+		return nil, nil, fmt.Errorf("decode file content: %w", err)
+	}
+	return &File{
+		Repository: repo,
+		Path:       content.Path,
+		Ref:        ref,
+		SHA:        content.SHA,
+		Size:       content.Size,
+		URL:        content.HTMLURL,
+		Content:    string(decoded),
+	}, nil, nil
+}
+
+// ListIssues lists issues and pull requests of a repository, most recently updated first.
+func (c *Client) ListIssues(ctx context.Context, token, repo string, filter IssueFilter) ([]Issue, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	ctx, span := c.startSpan(ctx, "github.list_issues", attribute.String("github.repo", repo))
+	defer span.End()
+
+	state := strings.ToLower(strings.TrimSpace(filter.State))
+	switch state {
+	case "":
+		state = "open"
+	case "open", "closed", "all":
+	default:
+		return nil, fmt.Errorf("state must be open, closed or all")
+	}
+
+	var result []struct {
+		Number    int       `json:"number"`
+		Title     string    `json:"title"`
+		State     string    `json:"state"`
+		HTMLURL   string    `json:"html_url"`
+		Body      string    `json:"body"`
+		Comments  int       `json:"comments"`
+		CreatedAt time.Time `json:"created_at"`
+		UpdatedAt time.Time `json:"updated_at"`
+		User      struct {
+			Login string `json:"login"`
+		} `json:"user"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
+		PullRequest *struct{} `json:"pull_request"`
+	}
+	r := c.request(ctx, token).
+		SetQueryParam("state", state).
+		SetQueryParam("sort", "updated").
+		SetQueryParam("per_page", strconv.Itoa(clampPerPage(filter.PerPage))).
+		SetResult(&result)
+	if len(filter.Labels) > 0 {
+		r.SetQueryParam("labels", strings.Join(filter.Labels, ","))
+	}
+	resp, err := r.Get(fmt.Sprintf("/repos/%s/%s/issues", url.PathEscape(owner), url.PathEscape(name)))
+	if err := checkResponse(resp, err); err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+
+	issues := make([]Issue, 0, len(result))
+	for _, item := range result {
+		issue := Issue{
+			Number:        item.Number,
+			Title:         item.Title,
+			State:         item.State,
+			Author:        item.User.Login,
+			Comments:      item.Comments,
+			IsPullRequest: item.PullRequest != nil,
+			URL:           item.HTMLURL,
+			Body:          item.Body,
+			CreatedAt:     item.CreatedAt,
+			UpdatedAt:     item.UpdatedAt,
+		}
+		for _, label := range item.Labels {
+			issue.Labels = append(issue.Labels, label.Name)
+		}
+		issues = append(issues, issue)
+	}
+	return issues, nil
+}
+
+// CreateIssueComment comments on an issue or pull request.
+func (c *Client) CreateIssueComment(ctx context.Context, token, repo string, number int, body string) (*Comment, error) {
+	owner, name, err := splitRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	if number <= 0 {
+		return nil, fmt.Errorf("issue_number must be positive")
+	}
+	if strings.TrimSpace(body) == "" {
+		return nil, fmt.Errorf("body is required")
+	}
+	ctx, span := c.startSpan(ctx, "github.create_issue_comment",
+		attribute.String("github.repo", repo),
+		attribute.Int("github.issue", number),
+	)
+	defer span.End()
+
+	var result struct {
+		ID        int64     `json:"id"`
+		HTMLURL   string    `json:"html_url"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+	resp, err := c.request(ctx, token).
+		SetBody(map[string]string{"body": body}).
+		SetResult(&result).
+		Post(fmt.Sprintf("/repos/%s/%s/issues/%d/comments", url.PathEscape(owner), url.PathEscape(name), number))
+	if err := checkResponse(resp, err); err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	return &Comment{ID: result.ID, URL: result.HTMLURL, CreatedAt: result.CreatedAt}, nil
+}
+
+func (c *Client) request(ctx context.Context, token string) *resty.Request {
+	return c.httpClient.R().
+		SetContext(ctx).
+		SetAuthToken(token)
+}
+
+func (c *Client) startSpan(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return observability.StartSpan(ctx, name,
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attrs...),
+	)
+}
+
+func checkResponse(resp *resty.Response, err error) error {
+	if err != nil {
+		return fmt.Errorf("github request failed: %w", err)
+	}
+	switch resp.StatusCode() {
+	case 401:
+		return fmt.Errorf("github rejected the connected token; reconnect GitHub")
+	case 403:
+		if resp.Header().Get("X-RateLimit-Remaining") == "0" {
+			return fmt.Errorf("github rate limit exceeded; try again after %s", resp.Header().Get("X-RateLimit-Reset"))
+		}
+		return fmt.Errorf("github denied access: %s", githubMessage(resp))
+	case 404:
+		return fmt.Errorf("not found on github (or the connected token cannot see it)")
+	}
+	if resp.IsError() {
+		return fmt.Errorf("github error (%d): %s", resp.StatusCode(), githubMessage(resp))
+	}
+	return nil
+}
+
+func githubMessage(resp *resty.Response) string {
+	var body struct {
+		Message string `json:"message"`
+	}
+	if err := json.Unmarshal(resp.Body(), &body); err == nil && body.Message != "" {
+		return body.Message
+	}
+	return resp.String()
+}
+
+func splitRepo(repo string) (string, string, error) {
+	owner, name, ok := strings.Cut(strings.TrimSpace(repo), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return "", "", fmt.Errorf("repo must be in owner/name form, got %q", repo)
+	}
+	return owner, name, nil
+}
+
+func escapePath(path string) string {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func clampPerPage(perPage int) int {
+	switch {
+	case perPage <= 0:
+		return 10
+	case perPage > 50:
+		return 50
+	default:
+		return perPage
+	}
+}
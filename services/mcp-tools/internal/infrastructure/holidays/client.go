@@ -0,0 +1,103 @@
+package holidays
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+// Holiday is a public holiday in a country.
+type Holiday struct {
+	Date      string   `json:"date"` // YYYY-MM-DD
+	Name      string   `json:"name"`
+	LocalName string   `json:"local_name"`
+	Global    bool     `json:"global"`            // false when only some regions observe it
+	Regions   []string `json:"regions,omitempty"` // ISO 3166-2 codes when not global
+	Types     []string `json:"types,omitempty"`
+}
+
+// Client fetches public holidays from a Nager.Date compatible API.
+type Client struct {
+	httpClient *resty.Client
+}
+
+// NewClient creates a holidays client. An empty baseURL returns nil.
+func NewClient(baseURL string) *Client {
+	baseURL = strings.TrimRight(baseURL, "/")
+	if baseURL == "" {
+		return nil
+	}
+	return &Client{
+		httpClient: resty.New().
+			SetBaseURL(baseURL).
+			SetHeader("User-Agent", "Jan-MCP-Holidays/1.0").
+			SetTimeout(10 * time.Second),
+	}
+}
+
+// PublicHolidays lists the public holidays for an ISO 3166-1 alpha-2 country code and year.
+func (c *Client) PublicHolidays(ctx context.Context, countryCode string, year int) ([]Holiday, error) {
+	if c == nil {
+		return nil, fmt.Errorf("holidays client is not configured")
+	}
+	countryCode = strings.ToUpper(strings.TrimSpace(countryCode))
+	if len(countryCode) != 2 {
+		return nil, fmt.Errorf("country_code must be an ISO 3166-1 alpha-2 code such as \"US\"")
+	}
+
+	ctx, span := observability.StartSpan(ctx, "holidays.public_holidays",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("holidays.country", countryCode),
+			attribute.Int("holidays.year", year),
+		),
+	)
+	defer span.End()
+
+	var result []struct {
+		Date      string   `json:"date"`
+		LocalName string   `json:"localName"`
+		Name      string   `json:"name"`
+		Global    bool     `json:"global"`
+		Counties  []string `json:"counties"`
+		Types     []string `json:"types"`
+	}
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetResult(&result).
+		Get("/api/v3/PublicHolidays/" + strconv.Itoa(year) + "/" + countryCode)
+	if err != nil {
+		err = fmt.Errorf("holidays request failed: %w", err)
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	if resp.StatusCode() == 404 {
+		return nil, fmt.Errorf("no holiday data for country %q", countryCode)
+	}
+	if resp.IsError() {
+		err = fmt.Errorf("holidays error (%d): %s", resp.StatusCode(), resp.String())
+		observability.RecordError(span, err)
+		return nil, err
+	}
+
+	holidays := make([]Holiday, 0, len(result))
+	for _, h := range result {
+		holidays = append(holidays, Holiday{
+			Date:      h.Date,
+			Name:      h.Name,
+			LocalName: h.LocalName,
+			Global:    h.Global,
+			Regions:   h.Counties,
+			Types:     h.Types,
+		})
+	}
+	return holidays, nil
+}
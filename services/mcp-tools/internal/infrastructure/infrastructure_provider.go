@@ -2,18 +2,33 @@ package infrastructure
 
 import (
 	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
 
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/egress"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
 	"github.com/rs/zerolog/log"
 
+	"jan-server/services/mcp-tools/internal/domain/promptguard"
 	"jan-server/services/mcp-tools/internal/domain/search"
 	"jan-server/services/mcp-tools/internal/infrastructure/auth"
+	"jan-server/services/mcp-tools/internal/infrastructure/browser"
 	"jan-server/services/mcp-tools/internal/infrastructure/config"
+	"jan-server/services/mcp-tools/internal/infrastructure/connectors"
+	"jan-server/services/mcp-tools/internal/infrastructure/github"
+	"jan-server/services/mcp-tools/internal/infrastructure/holidays"
+	"jan-server/services/mcp-tools/internal/infrastructure/injectionclassifier"
 	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
 	"jan-server/services/mcp-tools/internal/infrastructure/mcpprovider"
+	"jan-server/services/mcp-tools/internal/infrastructure/media"
 	sandboxfusionclient "jan-server/services/mcp-tools/internal/infrastructure/sandboxfusion"
 	searchclient "jan-server/services/mcp-tools/internal/infrastructure/search"
 	vectorstoreclient "jan-server/services/mcp-tools/internal/infrastructure/vectorstore"
+	"jan-server/services/mcp-tools/internal/infrastructure/weather"
 )
 
 // InfrastructureProvider provides all infrastructure dependencies
@@ -21,15 +36,36 @@ var InfrastructureProvider = wire.NewSet(
 	// Config
 	ProvideConfig,
 
+	// Egress policy for model-chosen URLs
+	ProvideEgressPolicy,
+
 	// Search client
 	ProvideSearchClient,
 
+	// Headless browser and media-api clients
+	ProvideBrowserClient,
+	ProvideBrowserEgressProxy,
+	ProvideMediaClient,
+
 	// Vector store client
 	ProvideVectorStoreClient,
 
 	// Sandbox Fusion client
 	ProvideSandboxFusionClient,
 
+	// World info clients
+	ProvideWeatherClient,
+	ProvideHolidaysClient,
+
+	// GitHub client
+	ProvideGitHubClient,
+
+	// Calendar and email connectors client
+	ProvideConnectorsClient,
+
+	// Prompt-injection defenses for web tool output
+	ProvidePromptGuard,
+
 	// MCP Provider config
 	ProvideMCPProviderConfig,
 
@@ -38,6 +74,12 @@ var InfrastructureProvider = wire.NewSet(
 
 	// LLM-API client for tool tracking
 	ProvideLLMAPIClient,
+
+	// Readiness probes
+	ProvideReadinessChecker,
+
+	// Maintenance mode switched on llm-api
+	ProvideMaintenance,
 )
 
 // ProvideConfig loads and provides the application configuration
@@ -45,9 +87,19 @@ func ProvideConfig() (*config.Config, error) {
 	return config.LoadConfig()
 }
 
+// ProvideEgressPolicy provides the policy guarding requests to model-chosen URLs
+func ProvideEgressPolicy(cfg *config.Config) *egress.Policy {
+	return egress.NewPolicy(egress.Config{
+		AllowedSchemes:       cfg.EgressAllowedSchemes,
+		AllowedHosts:         cfg.EgressAllowedHosts,
+		AllowPrivateNetworks: cfg.EgressAllowPrivate,
+		MaxRedirects:         cfg.EgressMaxRedirects,
+	})
+}
+
 // ProvideSearchClient provides the search client
-func ProvideSearchClient(cfg *config.Config) search.SearchClient {
-	return searchclient.NewSearchClient(searchclient.ClientConfig{
+func ProvideSearchClient(cfg *config.Config, browserClient *browser.Client, egressPolicy *egress.Policy) search.SearchClient {
+	client := searchclient.NewSearchClient(searchclient.ClientConfig{
 		Engine:        searchclient.Engine(cfg.SearchEngine),
 		SerperAPIKey:  cfg.SerperAPIKey,
 		SearxngURL:    cfg.SearxngURL,
@@ -55,7 +107,51 @@ func ProvideSearchClient(cfg *config.Config) search.SearchClient {
 		LocationHint:  cfg.SerperLocationHint,
 		OfflineMode:   cfg.SerperOfflineMode,
 		CBEnabled:     cfg.SearchCBEnabled,
+		Egress:        egressPolicy,
 	})
+	if cfg.BrowserScrapeFallback && browserClient != nil {
+		client.SetRenderer(browserClient)
+	}
+	return client
+}
+
+// ProvideBrowserClient provides the headless browser client used by render_page
+// and, when enabled, as the last scrape fallback
+func ProvideBrowserClient(cfg *config.Config, egressPolicy *egress.Policy) *browser.Client {
+	if !cfg.EnableRenderPage && !cfg.BrowserScrapeFallback {
+		return nil
+	}
+	return browser.NewClient(browser.ClientConfig{
+		URL:             cfg.BrowserURL,
+		Token:           cfg.BrowserToken,
+		Timeout:         cfg.BrowserTimeout,
+		MaxMemoryMB:     cfg.BrowserMaxMemoryMB,
+		MaxContentBytes: cfg.BrowserMaxContentBytes,
+		Egress:          egressPolicy,
+		EgressProxyURL:  cfg.BrowserEgressProxyURL,
+	})
+}
+
+// ProvideBrowserEgressProxy provides the forward proxy that applies the egress
+// policy to every request the headless browser makes, or nil when the browser
+// is not in use or the proxy is not configured
+func ProvideBrowserEgressProxy(cfg *config.Config, browserClient *browser.Client, egressPolicy *egress.Policy) *http.Server {
+	if browserClient == nil || cfg.BrowserEgressProxyAddr == "" || cfg.BrowserEgressProxyURL == "" {
+		return nil
+	}
+	return &http.Server{
+		Addr:              cfg.BrowserEgressProxyAddr,
+		Handler:           egressPolicy.Proxy(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+}
+
+// ProvideMediaClient provides the media-api client used to store screenshots
+func ProvideMediaClient(cfg *config.Config) *media.Client {
+	if !cfg.EnableRenderPage {
+		return nil
+	}
+	return media.NewClient(cfg.MediaIngestURL)
 }
 
 // ProvideVectorStoreClient provides the vector store client
@@ -74,6 +170,72 @@ func ProvideSandboxFusionClient(cfg *config.Config) *sandboxfusionclient.Client
 	return sandboxfusionclient.NewClient(cfg.SandboxFusionURL)
 }
 
+// ProvideWeatherClient provides the weather client used by get_weather and
+// for place lookups in get_world_time
+func ProvideWeatherClient(cfg *config.Config) *weather.Client {
+	if !cfg.EnableWeather && !cfg.EnableWorldTime {
+		return nil
+	}
+	client, err := weather.NewClient(weather.ClientConfig{
+		Provider:     weather.Provider(cfg.WeatherProvider),
+		APIKey:       cfg.WeatherAPIKey,
+		BaseURL:      cfg.WeatherBaseURL,
+		GeocodingURL: cfg.GeocodingURL,
+	})
+	if err != nil {
+		log.Warn().Err(err).Msg("Weather client not available")
+		return nil
+	}
+	return client
+}
+
+// ProvideHolidaysClient provides the public holidays client
+func ProvideHolidaysClient(cfg *config.Config) *holidays.Client {
+	if !cfg.EnableHolidays {
+		return nil
+	}
+	return holidays.NewClient(cfg.HolidaysAPIURL)
+}
+
+// ProvideGitHubClient provides the GitHub REST client used by the GitHub tools
+func ProvideGitHubClient(cfg *config.Config) *github.Client {
+	if !cfg.EnableGitHub {
+		return nil
+	}
+	return github.NewClient(cfg.GitHubAPIURL)
+}
+
+// ProvideConnectorsClient provides the read-only calendar and email client
+func ProvideConnectorsClient(cfg *config.Config) *connectors.Client {
+	if !cfg.EnableCalendar && !cfg.EnableEmail {
+		return nil
+	}
+	return connectors.NewClient(connectors.ClientConfig{
+		GoogleAPIURL: cfg.GoogleAPIURL,
+		GraphAPIURL:  cfg.GraphAPIURL,
+	})
+}
+
+// ProvidePromptGuard provides the guard that sanitizes untrusted web tool output
+func ProvidePromptGuard(cfg *config.Config) *promptguard.Guard {
+	var classifier promptguard.Classifier
+	if client := injectionclassifier.NewClient(cfg.PromptGuardClassifierURL, cfg.PromptGuardClassifierAPIKey); client != nil {
+		classifier = client
+	}
+	guard := promptguard.New(promptguard.Config{
+		Enabled:             cfg.PromptGuardEnabled,
+		Tools:               cfg.PromptGuardTools,
+		StripInstructions:   cfg.PromptGuardStrip,
+		WrapTemplate:        cfg.PromptGuardWrapTemplate,
+		ClassifierThreshold: cfg.PromptGuardClassifierThreshold,
+		ClassifierTimeout:   cfg.PromptGuardClassifierTimeout,
+	}, classifier)
+	if guard == nil {
+		log.Warn().Msg("Prompt guard for web tool output disabled via config")
+	}
+	return guard
+}
+
 // ProvideMCPProviderConfig loads the MCP provider configuration
 func ProvideMCPProviderConfig() *mcpprovider.Config {
 	providerConfig, err := mcpprovider.LoadConfig("configs/mcp-providers.yml")
@@ -105,3 +267,38 @@ func ProvideLLMAPIClient(cfg *config.Config) *llmapi.Client {
 		Msg("LLM-API client initialized for tool tracking")
 	return llmapi.NewClient(cfg.LLMAPIBaseURL)
 }
+
+// ProvideReadinessChecker registers the dependency probes served by /readyz.
+// Tool backends are optional: an outage degrades the affected tools but the
+// service keeps serving the rest.
+func ProvideReadinessChecker(cfg *config.Config, authValidator *auth.Validator) *health.Checker {
+	checker := health.NewChecker("mcp-tools", 3*time.Second)
+	checker.Register("auth_jwks", true, func(ctx context.Context) error {
+		if authValidator != nil && !authValidator.Ready() {
+			return errors.New("jwks not loaded")
+		}
+		return nil
+	})
+
+	probeClient := &http.Client{Timeout: 3 * time.Second}
+	if cfg.SearxngEnabled {
+		checker.Register("searxng", false, health.HTTPCheck(probeClient, cfg.SearxngURL))
+	}
+	if cfg.VectorStoreURL != "" {
+		checker.Register("vector_store", false, health.HTTPCheck(probeClient, strings.TrimSuffix(cfg.VectorStoreURL, "/")+"/healthz"))
+	}
+	checker.Register("sandbox_fusion", false, health.HTTPCheck(probeClient, cfg.SandboxFusionURL))
+	if cfg.MemoryToolsURL != "" {
+		checker.Register("memory_tools", false, health.HTTPCheck(probeClient, strings.TrimSuffix(cfg.MemoryToolsURL, "/")+"/healthz"))
+	}
+	if cfg.LLMAPIBaseURL != "" {
+		checker.Register("llm_api", false, health.HTTPCheck(probeClient, strings.TrimSuffix(cfg.LLMAPIBaseURL, "/")+"/healthz"))
+	}
+	return checker
+}
+
+// ProvideMaintenance follows the maintenance mode switched on llm-api. It
+// returns nil, never in maintenance, when REDIS_URL is not set.
+func ProvideMaintenance(cfg *config.Config) (*maintenance.Service, error) {
+	return maintenance.FromRedisURL(cfg.RedisURL, cfg.MaintenanceRefreshInterval, log.Logger)
+}
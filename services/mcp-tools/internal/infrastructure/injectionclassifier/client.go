@@ -0,0 +1,68 @@
+package injectionclassifier
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+// Client scores texts with an HTTP prompt-injection classifier. The endpoint
+// takes {"texts": [...]} and answers {"scores": [...]}, one probability from
+// 0 to 1 per text.
+type Client struct {
+	httpClient *resty.Client
+	url        string
+}
+
+// NewClient creates a classifier client. An empty url returns nil.
+func NewClient(url, apiKey string) *Client {
+	url = strings.TrimSpace(url)
+	if url == "" {
+		return nil
+	}
+	httpClient := resty.New().SetHeader("User-Agent", "Jan-MCP-PromptGuard/1.0")
+	if apiKey = strings.TrimSpace(apiKey); apiKey != "" {
+		httpClient.SetAuthToken(apiKey)
+	}
+	return &Client{httpClient: httpClient, url: url}
+}
+
+// Score implements promptguard.Classifier.
+func (c *Client) Score(ctx context.Context, texts []string) ([]float64, error) {
+	ctx, span := observability.StartSpan(ctx, "promptguard.classify",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.Int("promptguard.texts", len(texts))),
+	)
+	defer span.End()
+
+	var result struct {
+		Scores []float64 `json:"scores"`
+	}
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetBody(map[string]any{"texts": texts}).
+		SetResult(&result).
+		Post(c.url)
+	if err != nil {
+		err = fmt.Errorf("classifier request failed: %w", err)
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	if resp.IsError() {
+		err = fmt.Errorf("classifier error (%d): %s", resp.StatusCode(), resp.String())
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	if len(result.Scores) != len(texts) {
+		err = fmt.Errorf("classifier returned %d scores for %d texts", len(result.Scores), len(texts))
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	return result.Scores, nil
+}
@@ -1,57 +1,43 @@
 package llmapi
 
 import (
-	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/clients"
 	"github.com/rs/zerolog/log"
+
+	"jan-server/services/mcp-tools/internal/domain/toolerror"
 )
 
 // Client handles communication with LLM-API for tool tracking
 type Client struct {
-	baseURL    string
-	httpClient *http.Client
+	baseURL       string
+	httpClient    *http.Client
+	conversations *clients.ConversationsClient
 }
 
 // NewClient creates a new LLM-API client
 func NewClient(baseURL string) *Client {
+	httpClient := &http.Client{
+		Timeout: 30 * time.Second,
+	}
 	return &Client{
-		baseURL: baseURL,
-		httpClient: &http.Client{
-			Timeout: 30 * time.Second,
-		},
+		baseURL:       baseURL,
+		httpClient:    httpClient,
+		conversations: clients.NewConversationsClient(baseURL, httpClient, clients.DefaultBreakerConfig()),
 	}
 }
 
-// UpdateItemRequest represents the PATCH request body for updating mcp_call items
-type UpdateItemRequest struct {
-	// Required
-	Status string `json:"status"`
-
-	// Result fields
-	Output *string `json:"output,omitempty"`
-	Error  *string `json:"error,omitempty"`
-
-	// Tool info fields (for updating mcp_call item)
-	Name        *string `json:"name,omitempty"`
-	Arguments   *string `json:"arguments,omitempty"`
-	ServerLabel *string `json:"server_label,omitempty"`
-}
-
 // ItemResponse represents the response from the PATCH endpoint
-type ItemResponse struct {
-	ID        string `json:"id"`
-	Object    string `json:"object"`
-	Type      string `json:"type"`
-	CallID    string `json:"call_id"`
-	Status    string `json:"status"`
-	CreatedAt int64  `json:"created_at"`
-}
+type ItemResponse = clients.ConversationItem
 
 // PatchResult represents the result of a PATCH operation
 type PatchResult struct {
@@ -77,43 +63,21 @@ func (c *Client) UpdateToolCallResult(
 	arguments string,
 	serverLabel string,
 	output string,
-	toolError *string,
+	toolError *toolerror.ToolError,
 ) *PatchResult {
-	// Use call_id to find and update the in_progress item
-	endpoint := fmt.Sprintf("%s/v1/conversations/%s/items/by-call-id/%s", c.baseURL, conversationID, toolCallID)
-
-	status := "completed"
-	if toolError != nil && *toolError != "" {
-		status = "failed"
-	}
-
-	reqBody := UpdateItemRequest{
-		Status:      status,
+	reqBody := clients.UpdateItemRequest{
+		Status:      "completed",
 		Output:      &output,
-		Error:       toolError,
 		Name:        &toolName,
 		Arguments:   &arguments,
 		ServerLabel: &serverLabel,
 	}
-
-	bodyBytes, err := json.Marshal(reqBody)
-	if err != nil {
-		return &PatchResult{
-			Success: false,
-			Error:   fmt.Errorf("failed to marshal request: %w", err),
-		}
-	}
-
-	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, endpoint, bytes.NewReader(bodyBytes))
-	if err != nil {
-		return &PatchResult{
-			Success: false,
-			Error:   fmt.Errorf("failed to create request: %w", err),
-		}
+	if toolError != nil {
+		reqBody.Status = "failed"
+		reqBody.Error = &toolError.Message
+		reqBody.ErrorDetail = toolError
 	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("Authorization", authToken)
+	status := reqBody.Status
 
 	log.Info().
 		Str("conv_id", conversationID).
@@ -123,10 +87,22 @@ func (c *Client) UpdateToolCallResult(
 		Msg("Updating mcp_call item in LLM-API")
 
 	startTime := time.Now()
-	resp, err := c.httpClient.Do(req)
+	item, err := c.conversations.UpdateItemByCallID(ctx, authToken, conversationID, toolCallID, reqBody)
 	patchDuration := time.Since(startTime)
 
-	if err != nil {
+	if err == nil {
+		log.Info().
+			Str("conv_id", conversationID).
+			Str("call_id", toolCallID).
+			Str("tool_name", toolName).
+			Str("status", status).
+			Int64("patch_duration_ms", patchDuration.Milliseconds()).
+			Msg("Tool result saved to LLM-API")
+		return &PatchResult{Success: true, StatusCode: http.StatusOK, Item: item}
+	}
+
+	var apiErr *clients.APIError
+	if !errors.As(err, &apiErr) {
 		log.Error().
 			Err(err).
 			Str("conv_id", conversationID).
@@ -139,28 +115,12 @@ func (c *Client) UpdateToolCallResult(
 			Error:   fmt.Errorf("failed to call LLM-API: %w", err),
 		}
 	}
-	defer resp.Body.Close()
 
 	result := &PatchResult{
-		StatusCode: resp.StatusCode,
+		StatusCode: apiErr.StatusCode,
 	}
 
-	switch resp.StatusCode {
-	case http.StatusOK, http.StatusCreated:
-		result.Success = true
-		// Parse response body
-		var itemResp ItemResponse
-		if err := json.NewDecoder(resp.Body).Decode(&itemResp); err == nil {
-			result.Item = &itemResp
-		}
-		log.Info().
-			Str("conv_id", conversationID).
-			Str("call_id", toolCallID).
-			Str("tool_name", toolName).
-			Str("status", status).
-			Int64("patch_duration_ms", patchDuration.Milliseconds()).
-			Msg("Tool result saved to LLM-API")
-
+	switch apiErr.StatusCode {
 	case http.StatusConflict:
 		// Item already processed - this is idempotent, log as info
 		result.Success = true // Idempotent success
@@ -168,38 +128,35 @@ func (c *Client) UpdateToolCallResult(
 			Str("conv_id", conversationID).
 			Str("call_id", toolCallID).
 			Str("tool_name", toolName).
-			Int("status_code", resp.StatusCode).
+			Int("status_code", apiErr.StatusCode).
 			Msg("PATCH idempotent - item already processed")
 
 	case http.StatusNotFound:
-		body, _ := io.ReadAll(resp.Body)
-		result.Error = fmt.Errorf("call_id not found in conversation: %s", string(body))
+		result.Error = fmt.Errorf("call_id not found in conversation: %s", apiErr.Body)
 		log.Warn().
 			Str("conv_id", conversationID).
 			Str("call_id", toolCallID).
 			Str("tool_name", toolName).
-			Int("status_code", resp.StatusCode).
+			Int("status_code", apiErr.StatusCode).
 			Msg("PATCH failed - call_id not found")
 
 	case http.StatusForbidden:
-		body, _ := io.ReadAll(resp.Body)
-		result.Error = fmt.Errorf("access denied: %s", string(body))
+		result.Error = fmt.Errorf("access denied: %s", apiErr.Body)
 		log.Error().
 			Str("conv_id", conversationID).
 			Str("call_id", toolCallID).
 			Str("tool_name", toolName).
-			Int("status_code", resp.StatusCode).
+			Int("status_code", apiErr.StatusCode).
 			Msg("PATCH failed - access denied (security event)")
 
 	default:
-		body, _ := io.ReadAll(resp.Body)
-		result.Error = fmt.Errorf("LLM-API returned status %d: %s", resp.StatusCode, string(body))
+		result.Error = fmt.Errorf("LLM-API returned status %d: %s", apiErr.StatusCode, apiErr.Body)
 		log.Error().
 			Str("conv_id", conversationID).
 			Str("call_id", toolCallID).
 			Str("tool_name", toolName).
-			Int("status_code", resp.StatusCode).
-			Str("response_body", string(body)).
+			Int("status_code", apiErr.StatusCode).
+			Str("response_body", apiErr.Body).
 			Int64("patch_duration_ms", patchDuration.Milliseconds()).
 			Msg("Failed to save tool result to LLM-API")
 	}
@@ -303,3 +260,51 @@ func (c *Client) GetMCPToolByKey(ctx context.Context, toolKey string) (*MCPToolC
 
 	return &toolResp.Data, nil
 }
+
+// CredentialToken is a user's third-party token from the LLM-API MCP credential store
+type CredentialToken struct {
+	Provider  string   `json:"provider"`
+	Token     string   `json:"token"`
+	Account   *string  `json:"account,omitempty"`
+	Scopes    []string `json:"scopes"`
+	Endpoint  *string  `json:"endpoint,omitempty"`
+	ExpiresAt *int64   `json:"expires_at,omitempty"`
+}
+
+// ErrCredentialNotConnected is returned when the user has not connected the provider
+var ErrCredentialNotConnected = errors.New("credential not connected")
+
+// GetCredentialToken fetches the calling user's token for a provider (e.g. "github").
+// authToken is the user's forwarded Authorization header.
+func (c *Client) GetCredentialToken(ctx context.Context, authToken string, provider string) (*CredentialToken, error) {
+	if strings.TrimSpace(authToken) == "" {
+		return nil, fmt.Errorf("%w: request is not authenticated", ErrCredentialNotConnected)
+	}
+	endpoint := fmt.Sprintf("%s/v1/mcp-credentials/%s/token", c.baseURL, url.PathEscape(provider))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", authToken)
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to call LLM-API: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, ErrCredentialNotConnected
+	}
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(resp.Body)
+		return nil, fmt.Errorf("LLM-API returned status %d: %s", resp.StatusCode, string(body))
+	}
+
+	var token CredentialToken
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+	return &token, nil
+}
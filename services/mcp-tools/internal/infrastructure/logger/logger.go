@@ -1,14 +1,22 @@
 package logger
 
 import (
+	"context"
+	"io"
 	"os"
 	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/logging"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 )
 
+var (
+	output   io.Writer = os.Stdout
+	exporter *logging.OTLPExporter
+)
+
 // Init initializes the global logger
 func Init(level string, format string) {
 	// Set log level
@@ -27,13 +35,39 @@ func Init(level string, format string) {
 
 	// Set output format
 	if strings.ToLower(format) == "console" {
-		log.Logger = log.Output(zerolog.ConsoleWriter{
+		output = zerolog.ConsoleWriter{
 			Out:        os.Stdout,
 			TimeFormat: time.RFC3339,
-		})
+		}
 	} else {
-		log.Logger = zerolog.New(os.Stdout).With().Timestamp().Logger()
+		output = os.Stdout
+	}
+	setOutput()
+}
+
+// EnableOTLPExport exports the events of the global logger to an OTLP/HTTP
+// collector in addition to stdout. Call Shutdown before exiting to flush them.
+func EnableOTLPExport(cfg logging.OTLPConfig) {
+	if exporter == nil {
+		exporter = logging.NewOTLPExporter(cfg)
+	}
+	setOutput()
+}
+
+// Shutdown flushes the events queued for OTLP export.
+func Shutdown(ctx context.Context) error {
+	if exporter == nil {
+		return nil
+	}
+	return exporter.Shutdown(ctx)
+}
+
+func setOutput() {
+	writer := output
+	if exporter != nil {
+		writer = zerolog.MultiLevelWriter(output, exporter)
 	}
+	log.Logger = zerolog.New(writer).With().Timestamp().Logger().Hook(logging.TraceHook{})
 }
 
 // Get returns the global logger
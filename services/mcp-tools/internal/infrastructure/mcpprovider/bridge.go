@@ -11,6 +11,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/egress"
 	"github.com/rs/zerolog/log"
 )
 
@@ -44,14 +45,21 @@ type Bridge struct {
 	sessionID  string // MCP session ID for stateful connections
 }
 
-// NewBridge creates a new MCP provider bridge
-func NewBridge(provider Provider) *Bridge {
+// NewBridge creates a new MCP provider bridge. The provider's own endpoint is
+// trusted; redirects to any other host must pass the egress policy.
+func NewBridge(provider Provider, policy *egress.Policy) *Bridge {
 	timeout := provider.TimeoutDuration()
 
+	var endpointHost string
+	if parsed, err := url.Parse(provider.Endpoint); err == nil {
+		endpointHost = parsed.Hostname()
+	}
+
 	return &Bridge{
 		provider: provider,
 		httpClient: &http.Client{
-			Timeout: timeout,
+			Timeout:       timeout,
+			CheckRedirect: policy.WithAllowedHosts(endpointHost).CheckRedirect,
 		},
 	}
 }
@@ -0,0 +1,58 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+// Upload is the media-api record for an uploaded object.
+type Upload = clients.MediaObject
+
+// Client uploads generated artifacts to media-api.
+type Client struct {
+	media *clients.MediaClient
+}
+
+// NewClient creates a media-api client for the ingest endpoint
+// (e.g. http://kong:8000/media/v1/media). An empty URL returns nil.
+func NewClient(ingestURL string) *Client {
+	ingestURL = strings.TrimSpace(ingestURL)
+	if ingestURL == "" {
+		return nil
+	}
+	httpClient := &http.Client{Timeout: 30 * time.Second}
+	return &Client{media: clients.NewMediaClient(ingestURL, httpClient, clients.DefaultBreakerConfig())}
+}
+
+// Upload stores data in media-api on behalf of the caller identified by authToken.
+func (c *Client) Upload(ctx context.Context, data []byte, mimeType, filename, authToken string) (*Upload, error) {
+	if c == nil {
+		return nil, fmt.Errorf("media client is not configured")
+	}
+
+	ctx, span := observability.StartSpan(ctx, "media.upload",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("media.mime", mimeType),
+			attribute.Int("media.bytes", len(data)),
+		),
+	)
+	defer span.End()
+
+	result, err := c.media.Upload(ctx, authToken, data, mimeType, filename)
+	if err != nil {
+		err = fmt.Errorf("media upload failed: %w", err)
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	return result, nil
+}
@@ -27,6 +27,9 @@ var (
 
 	// External provider requests
 	ProviderRequestsTotal *prometheus.CounterVec
+
+	// Prompt guard actions on untrusted tool output
+	ToolOutputGuardTotal *prometheus.CounterVec
 )
 
 // init creates and registers all metrics with the default registry
@@ -103,6 +106,16 @@ func init() {
 		[]string{"operation", "provider", "status"},
 	)
 
+	ToolOutputGuardTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "mcp",
+			Name:      "tool_output_guard_total",
+			Help:      "Untrusted tool output documents changed by the prompt guard",
+		},
+		[]string{"tool_name", "action"},
+	)
+
 	prometheus.MustRegister(RequestsTotal)
 	prometheus.MustRegister(ToolCallsTotal)
 	prometheus.MustRegister(ToolTokensTotal)
@@ -110,6 +123,7 @@ func init() {
 	prometheus.MustRegister(CircuitBreakerState)
 	prometheus.MustRegister(ExternalProviderLatency)
 	prometheus.MustRegister(ProviderRequestsTotal)
+	prometheus.MustRegister(ToolOutputGuardTotal)
 	log.Info().Msg("MCP metrics registered with Prometheus")
 }
 
@@ -173,3 +187,12 @@ func RecordProviderRequest(operation, provider, status string) {
 	}
 	ProviderRequestsTotal.WithLabelValues(operation, provider, status).Inc()
 }
+
+// RecordToolOutputGuard records count documents of a tool result the prompt
+// guard stripped, flagged or could not classify
+func RecordToolOutputGuard(toolName, action string, count int) {
+	if count <= 0 {
+		return
+	}
+	ToolOutputGuardTotal.WithLabelValues(toolName, action).Add(float64(count))
+}
@@ -0,0 +1,76 @@
+package observability
+
+import (
+	"context"
+	"strings"
+
+	"github.com/rs/zerolog"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/config"
+)
+
+// ServiceName is reported as the OpenTelemetry service.name resource attribute.
+const ServiceName = "mcp-tools"
+
+// Shutdown is a function that releases telemetry resources.
+type Shutdown func(ctx context.Context) error
+
+// Setup configures OpenTelemetry tracing if enabled.
+func Setup(ctx context.Context, cfg *config.Config, log zerolog.Logger) (Shutdown, error) {
+	// Always propagate W3C trace context so tool spans join the caller's trace
+	// (response-api / llm-api) and downstream calls carry it further.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
+	if !cfg.EnableTracing || cfg.OTLPEndpoint == "" {
+		log.Info().Msg("Tracing disabled")
+		return func(context.Context) error { return nil }, nil
+	}
+
+	// Accept both "otel-collector:4318" and "http://otel-collector:4318".
+	endpoint := cfg.OTLPEndpoint
+	opts := []otlptracehttp.Option{}
+	if strings.HasPrefix(endpoint, "https://") {
+		endpoint = strings.TrimPrefix(endpoint, "https://")
+	} else {
+		endpoint = strings.TrimPrefix(endpoint, "http://")
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithAttributes(
+			semconv.ServiceName(ServiceName),
+			semconv.DeploymentEnvironment(cfg.Environment),
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	tp := sdktrace.NewTracerProvider(
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.AlwaysSample())),
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+	)
+	otel.SetTracerProvider(tp)
+
+	log.Info().Str("endpoint", cfg.OTLPEndpoint).Msg("Tracing enabled")
+
+	return func(ctx context.Context) error {
+		return tp.Shutdown(ctx)
+	}, nil
+}
@@ -7,6 +7,10 @@ import (
 	"time"
 
 	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
 )
 
 type Client struct {
@@ -74,6 +78,13 @@ func (c *Client) RunCode(ctx context.Context, req RunCodeRequest) (*RunCodeRespo
 	if !c.IsEnabled() {
 		return nil, fmt.Errorf("sandboxfusion client is not configured")
 	}
+
+	ctx, span := observability.StartSpan(ctx, "sandboxfusion.run_code",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("sandbox.language", req.Language)),
+	)
+	defer span.End()
+
 	var apiResp SandboxFusionAPIResponse
 	httpResp, err := c.httpClient.R().
 		SetContext(ctx).
@@ -82,11 +93,16 @@ func (c *Client) RunCode(ctx context.Context, req RunCodeRequest) (*RunCodeRespo
 		SetResult(&apiResp).
 		Post("/run_code")
 	if err != nil {
-		return nil, fmt.Errorf("sandboxfusion request failed: %w", err)
+		err = fmt.Errorf("sandboxfusion request failed: %w", err)
+		observability.RecordError(span, err)
+		return nil, err
 	}
 	if httpResp.IsError() {
-		return nil, fmt.Errorf("sandboxfusion error (%d): %s", httpResp.StatusCode(), httpResp.String())
+		err = fmt.Errorf("sandboxfusion error (%d): %s", httpResp.StatusCode(), httpResp.String())
+		observability.RecordError(span, err)
+		return nil, err
 	}
+	span.SetAttributes(attribute.String("sandbox.status", apiResp.Status))
 
 	// Map the API response to our expected format
 	resp := &RunCodeResponse{
@@ -11,9 +11,13 @@ import (
 
 	domainsearch "jan-server/services/mcp-tools/internal/domain/search"
 	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
 
 	"github.com/go-resty/resty/v2"
+	"github.com/janhq/jan-server/packages/go-common/egress"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"golang.org/x/net/html"
 )
 
@@ -81,6 +85,9 @@ type ClientConfig struct {
 	RetryInitialDelay  time.Duration
 	RetryMaxDelay      time.Duration
 	RetryBackoffFactor float64
+
+	// Egress checks scrape URLs and guards the direct HTTP fetch; defaults to the strict policy
+	Egress *egress.Policy
 }
 
 // SearchClient implements domainsearch.SearchClient with pluggable backends.
@@ -97,6 +104,7 @@ type SearchClient struct {
 	exaCB          *CircuitBreaker
 	tavilyCB       *CircuitBreaker
 	searxCB        *CircuitBreaker
+	renderer       PageRenderer // Optional headless browser for JavaScript-rendered pages
 }
 
 var _ domainsearch.SearchClient = (*SearchClient)(nil)
@@ -115,6 +123,9 @@ func NewSearchClient(cfg ClientConfig) *SearchClient {
 	if strings.TrimSpace(cfg.TavilyEndpoint) == "" {
 		cfg.TavilyEndpoint = tavilySearchEndpointDefault
 	}
+	if cfg.Egress == nil {
+		cfg.Egress = egress.NewPolicy(egress.Config{})
+	}
 
 	// Set default HTTP timeout if not configured
 	httpTimeout := 15 * time.Second
@@ -181,7 +192,9 @@ func NewSearchClient(cfg ClientConfig) *SearchClient {
 		SetRetryCount(0).
 		SetTransport(transport)
 
-	// Fallback client with browser-like headers to avoid basic bot detection
+	// Fallback client with browser-like headers to avoid basic bot detection. It
+	// fetches model-chosen URLs directly, so every connection and redirect goes
+	// through the egress policy.
 	fallbackHTTP := resty.New().
 		SetHeader("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/120.0.0.0 Safari/537.36").
 		SetHeader("Accept", "text/html,application/xhtml+xml,application/xml;q=0.9,image/webp,*/*;q=0.8").
@@ -190,7 +203,9 @@ func NewSearchClient(cfg ClientConfig) *SearchClient {
 		SetHeader("Connection", "keep-alive").
 		SetHeader("Upgrade-Insecure-Requests", "1").
 		SetTimeout(15 * time.Second).
-		SetRetryCount(0)
+		SetRetryCount(0).
+		SetTransport(cfg.Egress.Transport(nil)).
+		SetRedirectPolicy(resty.RedirectPolicyFunc(cfg.Egress.CheckRedirect))
 
 	searxHTTP := resty.New().
 		SetHeader("User-Agent", "Jan-MCP-Tools/1.0").
@@ -352,6 +367,10 @@ func (c *SearchClient) FetchWebpage(ctx context.Context, query domainsearch.Fetc
 	if offline {
 		return nil, fmt.Errorf("scrape unavailable: offline mode is enabled")
 	}
+	if err := c.cfg.Egress.CheckURL(ctx, query.Url); err != nil {
+		log.Warn().Err(err).Str("url", query.Url).Msg("scrape blocked by egress policy")
+		return nil, err
+	}
 
 	var lastErr error
 	providersTried := make([]string, 0, 4)
@@ -406,6 +425,19 @@ func (c *SearchClient) FetchWebpage(ctx context.Context, query domainsearch.Fetc
 		log.Debug().Err(err).Str("provider", "direct-http").Msg("scrape provider failed")
 	}
 
+	if c.renderer != nil {
+		providersTried = append(providersTried, "headless-browser")
+		log.Debug().Str("provider", "headless-browser").Str("url", query.Url).Msg("trying scrape provider")
+		if res, err := c.fetchViaBrowser(ctx, query); err == nil {
+			log.Info().Str("engine", "headless-browser").Str("url", query.Url).Int("text_length", len(res.Text)).Msg("scrape completed using engine")
+			res.Status = "success"
+			return res, nil
+		} else {
+			lastErr = err
+			log.Debug().Err(err).Str("provider", "headless-browser").Msg("scrape provider failed")
+		}
+	}
+
 	if lastErr != nil {
 		return nil, fmt.Errorf("all scrape methods failed (tried: %v): %w", strings.Join(providersTried, ", "), lastErr)
 	}
@@ -465,11 +497,17 @@ func (c *SearchClient) searchViaSerper(ctx context.Context, query domainsearch.S
 		return nil, fmt.Errorf("serper circuit breaker is open")
 	}
 
+	ctx, span := observability.StartSpan(ctx, "serper.search",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("provider", "serper")),
+	)
 	startTime := time.Now()
 	status := "success"
 	defer func() {
 		metrics.RecordProviderRequest("search", "serper", status)
 		metrics.RecordExternalProviderLatency("serper", time.Since(startTime).Seconds())
+		span.SetAttributes(attribute.String("provider.status", status))
+		span.End()
 	}()
 
 	body := map[string]any{
@@ -541,6 +579,7 @@ func (c *SearchClient) searchViaSerper(ctx context.Context, query domainsearch.S
 
 	if opErr != nil {
 		status = "error"
+		observability.RecordError(span, opErr)
 		log.Error().Err(opErr).Str("service", "serper").Str("operation", "search").Msg("serper search failed after retries")
 		return nil, opErr
 	}
@@ -920,11 +959,17 @@ func (c *SearchClient) fetchViaSerper(ctx context.Context, query domainsearch.Fe
 		return nil, fmt.Errorf("serper circuit breaker is open")
 	}
 
+	ctx, span := observability.StartSpan(ctx, "serper.scrape",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("provider", "serper")),
+	)
 	startTime := time.Now()
 	status := "success"
 	defer func() {
 		metrics.RecordProviderRequest("scrape", "serper", status)
 		metrics.RecordExternalProviderLatency("serper", time.Since(startTime).Seconds())
+		span.SetAttributes(attribute.String("provider.status", status))
+		span.End()
 	}()
 
 	body := map[string]any{
@@ -974,6 +1019,7 @@ func (c *SearchClient) fetchViaSerper(ctx context.Context, query domainsearch.Fe
 
 	if opErr != nil {
 		status = "error"
+		observability.RecordError(span, opErr)
 		log.Error().Err(opErr).Str("service", "serper").Str("operation", "scrape").Str("url", query.Url).Msg("serper scrape failed after retries")
 		return nil, opErr
 	}
@@ -1168,7 +1214,7 @@ func (c *SearchClient) fetchFallback(ctx context.Context, query domainsearch.Fet
 		}
 
 		bodyBytes := resp.Body()
-		text := extractVisibleText(bodyBytes)
+		text := ExtractVisibleText(bodyBytes)
 		if text == "" {
 			text = string(bodyBytes)
 		}
@@ -1378,7 +1424,8 @@ func firstNonEmpty(values ...string) string {
 	return ""
 }
 
-func extractVisibleText(htmlBytes []byte) string {
+// ExtractVisibleText returns the text content of an HTML document, skipping scripts and styles.
+func ExtractVisibleText(htmlBytes []byte) string {
 	doc, err := html.Parse(strings.NewReader(string(htmlBytes)))
 	if err != nil {
 		return ""
@@ -0,0 +1,54 @@
+package search
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+
+	domainsearch "jan-server/services/mcp-tools/internal/domain/search"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+)
+
+// PageRenderer renders a page in a headless browser and returns the resulting HTML.
+type PageRenderer interface {
+	RenderHTML(ctx context.Context, url string) ([]byte, error)
+}
+
+// SetRenderer enables the headless browser as the last step of the scrape
+// chain, for single-page apps whose content the plain HTTP fetch can't see.
+func (c *SearchClient) SetRenderer(renderer PageRenderer) {
+	c.renderer = renderer
+}
+
+func (c *SearchClient) fetchViaBrowser(ctx context.Context, query domainsearch.FetchWebpageRequest) (*domainsearch.FetchWebpageResponse, error) {
+	startTime := time.Now()
+	status := "success"
+	defer func() {
+		metrics.RecordProviderRequest("scrape", "headless-browser", status)
+		metrics.RecordExternalProviderLatency("headless-browser", time.Since(startTime).Seconds())
+	}()
+
+	htmlBytes, err := c.renderer.RenderHTML(ctx, query.Url)
+	if err != nil {
+		status = "error"
+		log.Error().Err(err).Str("service", "headless-browser").Str("url", query.Url).Msg("browser render failed")
+		return nil, fmt.Errorf("browser render failed: %w", err)
+	}
+
+	result := &domainsearch.FetchWebpageResponse{
+		Text: ExtractVisibleText(htmlBytes),
+		Metadata: map[string]any{
+			"source":        query.Url,
+			"rendered":      true,
+			"fallback_mode": true,
+		},
+	}
+	if validationErr := ValidateFetchResponse(result, 50); validationErr != nil {
+		status = "error"
+		log.Warn().Err(validationErr).Msg("browser render returned invalid response")
+		return nil, fmt.Errorf("browser render invalid response: %w", validationErr)
+	}
+	return result, nil
+}
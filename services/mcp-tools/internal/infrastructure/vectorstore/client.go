@@ -16,6 +16,7 @@ type Client struct {
 
 type IndexRequest struct {
 	DocumentID string         `json:"document_id"`
+	Collection string         `json:"collection,omitempty"`
 	Text       string         `json:"text"`
 	Metadata   map[string]any `json:"metadata,omitempty"`
 	Tags       []string       `json:"tags,omitempty"`
@@ -32,10 +33,12 @@ type QueryRequest struct {
 	Text        string   `json:"text"`
 	TopK        int      `json:"top_k,omitempty"`
 	DocumentIDs []string `json:"document_ids,omitempty"`
+	Collections []string `json:"collections,omitempty"`
 }
 
 type QueryResult struct {
 	DocumentID  string         `json:"document_id"`
+	Collection  string         `json:"collection,omitempty"`
 	Score       float64        `json:"score"`
 	TextPreview string         `json:"text_preview"`
 	Metadata    map[string]any `json:"metadata"`
@@ -0,0 +1,381 @@
+package weather
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
+)
+
+// Provider identifies the upstream weather API.
+type Provider string
+
+const (
+	ProviderOpenMeteo      Provider = "open-meteo"
+	ProviderOpenWeatherMap Provider = "openweathermap"
+)
+
+// Units selects metric (°C, km/h, mm) or imperial (°F, mph, inch) output.
+type Units string
+
+const (
+	UnitsMetric   Units = "metric"
+	UnitsImperial Units = "imperial"
+)
+
+const (
+	defaultOpenMeteoURL      = "https://api.open-meteo.com"
+	defaultOpenWeatherMapURL = "https://api.openweathermap.org"
+	defaultGeocodingURL      = "https://geocoding-api.open-meteo.com"
+)
+
+// ClientConfig configures the weather client.
+type ClientConfig struct {
+	Provider     Provider
+	APIKey       string // Required for openweathermap
+	BaseURL      string // Optional override of the provider's API URL
+	GeocodingURL string // Open-Meteo compatible geocoding API, used for all providers
+	Timeout      time.Duration
+}
+
+// Location is a geocoded place.
+type Location struct {
+	Name      string  `json:"name"`
+	Region    string  `json:"region,omitempty"`
+	Country   string  `json:"country,omitempty"`
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+	Timezone  string  `json:"timezone,omitempty"`
+}
+
+// Conditions are the current weather conditions at a location.
+type Conditions struct {
+	Location            Location  `json:"location"`
+	Temperature         float64   `json:"temperature"`
+	ApparentTemperature float64   `json:"apparent_temperature"`
+	Humidity            float64   `json:"humidity"`
+	WindSpeed           float64   `json:"wind_speed"`
+	Precipitation       float64   `json:"precipitation"`
+	Description         string    `json:"description"`
+	Units               Units     `json:"units"`
+	ObservedAt          time.Time `json:"observed_at"`
+	Provider            Provider  `json:"provider"`
+}
+
+// Client fetches current weather and geocodes place names.
+type Client struct {
+	provider   Provider
+	apiKey     string
+	httpClient *resty.Client
+	geocoder   *resty.Client
+}
+
+// NewClient creates a weather client. It returns an error for unknown
+// providers or when the provider requires an API key that is missing.
+func NewClient(cfg ClientConfig) (*Client, error) {
+	provider := Provider(strings.ToLower(strings.TrimSpace(string(cfg.Provider))))
+	if provider == "" {
+		provider = ProviderOpenMeteo
+	}
+
+	baseURL := strings.TrimRight(cfg.BaseURL, "/")
+	switch provider {
+	case ProviderOpenMeteo:
+		if baseURL == "" {
+			baseURL = defaultOpenMeteoURL
+		}
+	case ProviderOpenWeatherMap:
+		if strings.TrimSpace(cfg.APIKey) == "" {
+			return nil, fmt.Errorf("weather provider %s requires an API key", provider)
+		}
+		if baseURL == "" {
+			baseURL = defaultOpenWeatherMapURL
+		}
+	default:
+		return nil, fmt.Errorf("unsupported weather provider %q", cfg.Provider)
+	}
+
+	geocodingURL := strings.TrimRight(cfg.GeocodingURL, "/")
+	if geocodingURL == "" {
+		geocodingURL = defaultGeocodingURL
+	}
+	timeout := cfg.Timeout
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &Client{
+		provider: provider,
+		apiKey:   cfg.APIKey,
+		httpClient: resty.New().
+			SetBaseURL(baseURL).
+			SetHeader("User-Agent", "Jan-MCP-Weather/1.0").
+			SetTimeout(timeout),
+		geocoder: resty.New().
+			SetBaseURL(geocodingURL).
+			SetHeader("User-Agent", "Jan-MCP-Weather/1.0").
+			SetTimeout(timeout),
+	}, nil
+}
+
+// Provider returns the configured upstream provider.
+func (c *Client) Provider() Provider {
+	return c.provider
+}
+
+// Geocode resolves a place name such as "Paris" or "Hanoi, Vietnam" to the best match.
+func (c *Client) Geocode(ctx context.Context, query string) (*Location, error) {
+	name, qualifier, _ := strings.Cut(query, ",")
+	name = strings.TrimSpace(name)
+	qualifier = strings.ToLower(strings.TrimSpace(qualifier))
+	if name == "" {
+		return nil, fmt.Errorf("location is required")
+	}
+
+	ctx, span := observability.StartSpan(ctx, "weather.geocode",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(attribute.String("weather.query", query)),
+	)
+	defer span.End()
+
+	var result struct {
+		Results []struct {
+			Name        string  `json:"name"`
+			Admin1      string  `json:"admin1"`
+			Country     string  `json:"country"`
+			CountryCode string  `json:"country_code"`
+			Latitude    float64 `json:"latitude"`
+			Longitude   float64 `json:"longitude"`
+			Timezone    string  `json:"timezone"`
+		} `json:"results"`
+	}
+	resp, err := c.geocoder.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{"name": name, "count": "10", "format": "json"}).
+		SetResult(&result).
+		Get("/v1/search")
+	if err != nil {
+		err = fmt.Errorf("geocoding request failed: %w", err)
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	if resp.IsError() {
+		err = fmt.Errorf("geocoding error (%d): %s", resp.StatusCode(), resp.String())
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	if len(result.Results) == 0 {
+		return nil, fmt.Errorf("location %q not found", query)
+	}
+
+	// "Paris, US" or "Portland, Oregon" narrows the match by country or region
+	match := result.Results[0]
+	if qualifier != "" {
+		for _, candidate := range result.Results {
+			if strings.ToLower(candidate.Country) == qualifier ||
+				strings.ToLower(candidate.CountryCode) == qualifier ||
+				strings.ToLower(candidate.Admin1) == qualifier {
+				match = candidate
+				break
+			}
+		}
+	}
+
+	return &Location{
+		Name:      match.Name,
+		Region:    match.Admin1,
+		Country:   match.Country,
+		Latitude:  match.Latitude,
+		Longitude: match.Longitude,
+		Timezone:  match.Timezone,
+	}, nil
+}
+
+// Current returns the current conditions for a place name.
+func (c *Client) Current(ctx context.Context, query string, units Units) (*Conditions, error) {
+	if units != UnitsImperial {
+		units = UnitsMetric
+	}
+	location, err := c.Geocode(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, span := observability.StartSpan(ctx, "weather.current",
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("weather.provider", string(c.provider)),
+			attribute.String("weather.location", location.Name),
+		),
+	)
+	defer span.End()
+
+	var conditions *Conditions
+	switch c.provider {
+	case ProviderOpenWeatherMap:
+		conditions, err = c.currentOpenWeatherMap(ctx, location, units)
+	default:
+		conditions, err = c.currentOpenMeteo(ctx, location, units)
+	}
+	if err != nil {
+		observability.RecordError(span, err)
+		return nil, err
+	}
+	return conditions, nil
+}
+
+func (c *Client) currentOpenMeteo(ctx context.Context, location *Location, units Units) (*Conditions, error) {
+	params := map[string]string{
+		"latitude":  strconv.FormatFloat(location.Latitude, 'f', 4, 64),
+		"longitude": strconv.FormatFloat(location.Longitude, 'f', 4, 64),
+		"current":   "temperature_2m,relative_humidity_2m,apparent_temperature,precipitation,weather_code,wind_speed_10m",
+		"timezone":  "UTC",
+	}
+	if units == UnitsImperial {
+		params["temperature_unit"] = "fahrenheit"
+		params["wind_speed_unit"] = "mph"
+		params["precipitation_unit"] = "inch"
+	}
+
+	var result struct {
+		Current struct {
+			Time                string  `json:"time"`
+			Temperature         float64 `json:"temperature_2m"`
+			RelativeHumidity    float64 `json:"relative_humidity_2m"`
+			ApparentTemperature float64 `json:"apparent_temperature"`
+			Precipitation       float64 `json:"precipitation"`
+			WeatherCode         int     `json:"weather_code"`
+			WindSpeed           float64 `json:"wind_speed_10m"`
+		} `json:"current"`
+	}
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParams(params).
+		SetResult(&result).
+		Get("/v1/forecast")
+	if err != nil {
+		return nil, fmt.Errorf("open-meteo request failed: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("open-meteo error (%d): %s", resp.StatusCode(), resp.String())
+	}
+
+	observedAt, err := time.Parse("2006-01-02T15:04", result.Current.Time)
+	if err != nil {
+		observedAt = time.Now().UTC()
+	}
+	return &Conditions{
+		Location:            *location,
+		Temperature:         result.Current.Temperature,
+		ApparentTemperature: result.Current.ApparentTemperature,
+		Humidity:            result.Current.RelativeHumidity,
+		WindSpeed:           result.Current.WindSpeed,
+		Precipitation:       result.Current.Precipitation,
+		Description:         describeWMOCode(result.Current.WeatherCode),
+		Units:               units,
+		ObservedAt:          observedAt,
+		Provider:            ProviderOpenMeteo,
+	}, nil
+}
+
+func (c *Client) currentOpenWeatherMap(ctx context.Context, location *Location, units Units) (*Conditions, error) {
+	var result struct {
+		Dt      int64 `json:"dt"`
+		Weather []struct {
+			Description string `json:"description"`
+		} `json:"weather"`
+		Main struct {
+			Temp      float64 `json:"temp"`
+			FeelsLike float64 `json:"feels_like"`
+			Humidity  float64 `json:"humidity"`
+		} `json:"main"`
+		Wind struct {
+			Speed float64 `json:"speed"`
+		} `json:"wind"`
+		Rain struct {
+			OneHour float64 `json:"1h"`
+		} `json:"rain"`
+		Snow struct {
+			OneHour float64 `json:"1h"`
+		} `json:"snow"`
+	}
+	resp, err := c.httpClient.R().
+		SetContext(ctx).
+		SetQueryParams(map[string]string{
+			"lat":   strconv.FormatFloat(location.Latitude, 'f', 4, 64),
+			"lon":   strconv.FormatFloat(location.Longitude, 'f', 4, 64),
+			"units": string(units),
+			"appid": c.apiKey,
+		}).
+		SetResult(&result).
+		Get("/data/2.5/weather")
+	if err != nil {
+		return nil, fmt.Errorf("openweathermap request failed: %w", err)
+	}
+	if resp.IsError() {
+		return nil, fmt.Errorf("openweathermap error (%d): %s", resp.StatusCode(), resp.String())
+	}
+
+	windSpeed := result.Wind.Speed
+	precipitation := result.Rain.OneHour + result.Snow.OneHour // millimeters in both unit systems
+	if units == UnitsMetric {
+		windSpeed *= 3.6 // m/s -> km/h, matching open-meteo
+	} else {
+		precipitation /= 25.4
+	}
+	description := ""
+	if len(result.Weather) > 0 {
+		description = result.Weather[0].Description
+	}
+	return &Conditions{
+		Location:            *location,
+		Temperature:         result.Main.Temp,
+		ApparentTemperature: result.Main.FeelsLike,
+		Humidity:            result.Main.Humidity,
+		WindSpeed:           windSpeed,
+		Precipitation:       precipitation,
+		Description:         description,
+		Units:               units,
+		ObservedAt:          time.Unix(result.Dt, 0).UTC(),
+		Provider:            ProviderOpenWeatherMap,
+	}, nil
+}
+
+// describeWMOCode maps WMO weather interpretation codes used by Open-Meteo to text.
+func describeWMOCode(code int) string {
+	switch {
+	case code == 0:
+		return "clear sky"
+	case code == 1:
+		return "mainly clear"
+	case code == 2:
+		return "partly cloudy"
+	case code == 3:
+		return "overcast"
+	case code == 45 || code == 48:
+		return "fog"
+	case code >= 51 && code <= 57:
+		return "drizzle"
+	case code >= 61 && code <= 67:
+		return "rain"
+	case code >= 71 && code <= 77:
+		return "snow"
+	case code >= 80 && code <= 82:
+		return "rain showers"
+	case code == 85 || code == 86:
+		return "snow showers"
+	case code == 95:
+		return "thunderstorm"
+	case code == 96 || code == 99:
+		return "thunderstorm with hail"
+	default:
+		return "unknown"
+	}
+}
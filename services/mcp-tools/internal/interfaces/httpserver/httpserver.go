@@ -1,9 +1,13 @@
 package httpserver
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 
 	"jan-server/services/mcp-tools/internal/infrastructure/auth"
@@ -17,18 +21,23 @@ type HTTPServer struct {
 	config        *config.Config
 	mcpRoute      *mcp.MCPRoute
 	authValidator *auth.Validator
+	readiness     *health.Checker
+	maintenance   *maintenance.Service
 }
 
 func NewHTTPServer(
 	cfg *config.Config,
 	mcpRoute *mcp.MCPRoute,
 	authValidator *auth.Validator,
+	readiness *health.Checker,
+	maintenanceService *maintenance.Service,
 ) *HTTPServer {
 	router := gin.New()
 	router.Use(gin.Recovery())
 	router.Use(middlewares.RequestLogger())
 	router.Use(middlewares.CORS())
 	router.Use(middlewares.MetricsRecorder())
+	router.Use(middlewares.Tracing())
 
 	// Apply auth middleware (will skip health checks internally)
 	if authValidator != nil {
@@ -40,6 +49,8 @@ func NewHTTPServer(
 		config:        cfg,
 		mcpRoute:      mcpRoute,
 		authValidator: authValidator,
+		readiness:     readiness,
+		maintenance:   maintenanceService,
 	}
 }
 
@@ -49,9 +60,7 @@ func (s *HTTPServer) setupRoutes() {
 		c.JSON(200, gin.H{"status": "ok", "service": "mcp-tools"})
 	})
 
-	s.router.GET("/readyz", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ready", "service": "mcp-tools"})
-	})
+	s.router.GET("/readyz", gin.WrapH(s.readiness))
 
 	s.router.GET("/health/auth", func(c *gin.Context) {
 		if s.authValidator == nil || s.authValidator.Ready() {
@@ -71,6 +80,12 @@ func (s *HTTPServer) setupRoutes() {
 
 func (s *HTTPServer) Run() error {
 	s.setupRoutes()
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		return err
+	}
 	addr := fmt.Sprintf(":%s", s.config.HTTPPort)
-	return s.router.Run(addr)
+	// Maintenance mode turns away tool calls before they reach the router
+	go s.maintenance.Run(context.Background())
+	return serverbuilder.New(addr, s.maintenance.Middleware()(s.router), serverCfg).ListenAndServe()
 }
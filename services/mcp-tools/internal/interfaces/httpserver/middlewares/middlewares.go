@@ -1,12 +1,17 @@
 package middlewares
 
 import (
+	"fmt"
 	"strconv"
 
 	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
 
 	"github.com/gin-gonic/gin"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 )
 
 // RequestLogger logs HTTP requests
@@ -24,6 +29,7 @@ func RequestLogger() gin.HandlerFunc {
 		if len(c.Errors) > 0 {
 			for _, e := range c.Errors {
 				log.Error().
+					Ctx(c.Request.Context()).
 					Str("method", c.Request.Method).
 					Str("path", c.Request.URL.Path).
 					Int("status", c.Writer.Status()).
@@ -44,7 +50,7 @@ func RequestLogger() gin.HandlerFunc {
 				Int("status", c.Writer.Status())
 		}
 
-		logEvent.Msg("request completed")
+		logEvent.Ctx(c.Request.Context()).Msg("request completed")
 	}
 }
 
@@ -54,7 +60,7 @@ func CORS() gin.HandlerFunc {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
 		// Allow MCP tracking/context headers through preflight
-		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-API-Key, Idempotency-Key, X-Request-Id, Mcp-Session-Id, mcp-protocol-version, X-Tool-Call-ID, X-Conversation-ID")
+		c.Writer.Header().Set("Access-Control-Allow-Headers", "Authorization, Content-Type, X-API-Key, Idempotency-Key, X-Request-Id, Mcp-Session-Id, mcp-protocol-version, X-Tool-Call-ID, X-Conversation-ID, X-Context-Budget-Tokens")
 		c.Writer.Header().Set("Access-Control-Expose-Headers", "X-Request-Id")
 		c.Writer.Header().Set("Access-Control-Max-Age", "3600")
 
@@ -83,3 +89,38 @@ func MetricsRecorder() gin.HandlerFunc {
 		metrics.RecordRequest(c.Request.Method, status)
 	}
 }
+
+// Tracing starts a server span per request, continuing the trace context
+// propagated by the caller (response-api or llm-api tool loops).
+func Tracing() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if path == "/healthz" || path == "/readyz" || path == "/metrics" {
+			c.Next()
+			return
+		}
+
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		ctx := observability.ExtractHeaders(c.Request.Context(), c.Request.Header)
+		ctx, span := observability.StartSpan(ctx, fmt.Sprintf("%s %s", c.Request.Method, route),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				attribute.String("http.method", c.Request.Method),
+				attribute.String("http.route", route),
+			),
+		)
+		defer span.End()
+
+		c.Request = c.Request.WithContext(ctx)
+		c.Next()
+
+		status := c.Writer.Status()
+		span.SetAttributes(attribute.Int("http.status_code", status))
+		if status >= 500 {
+			span.SetStatus(codes.Error, fmt.Sprintf("HTTP %d", status))
+		}
+	}
+}
@@ -0,0 +1,205 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"jan-server/services/mcp-tools/internal/domain/promptguard"
+	"jan-server/services/mcp-tools/internal/infrastructure/browser"
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/media"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+	searchclient "jan-server/services/mcp-tools/internal/infrastructure/search"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// RenderPageArgs defines the arguments for the render_page tool
+type RenderPageArgs struct {
+	URL             string `json:"url"`
+	WaitForSelector string `json:"wait_for_selector,omitempty"`
+	TimeoutSeconds  *int   `json:"timeout_seconds,omitempty"`
+	Screenshot      bool   `json:"screenshot,omitempty"`
+	FullPage        bool   `json:"full_page,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// BrowserMCP serves the render_page tool, which loads a page in a headless
+// browser for sites that only render their content with JavaScript.
+type BrowserMCP struct {
+	browserClient *browser.Client
+	mediaClient   *media.Client
+	llmClient     *llmapi.Client // LLM-API client for tool tracking
+	promptGuard   *promptguard.Guard
+	maxTextChars  int
+	enabled       bool
+}
+
+// NewBrowserMCP creates a new browser MCP handler. Screenshots are only
+// offered when mediaClient is configured.
+func NewBrowserMCP(browserClient *browser.Client, mediaClient *media.Client, maxTextChars int, enabled bool) *BrowserMCP {
+	return &BrowserMCP{
+		browserClient: browserClient,
+		mediaClient:   mediaClient,
+		maxTextChars:  maxTextChars,
+		enabled:       enabled,
+	}
+}
+
+// SetLLMClient sets the LLM-API client for tool call tracking
+func (b *BrowserMCP) SetLLMClient(client *llmapi.Client) {
+	b.llmClient = client
+}
+
+// SetPromptGuard sets the guard that sanitizes rendered page text
+func (b *BrowserMCP) SetPromptGuard(guard *promptguard.Guard) {
+	b.promptGuard = guard
+}
+
+// RegisterTools registers the render_page tool with the MCP server.
+func (b *BrowserMCP) RegisterTools(server *mcp.Server) {
+	if b == nil {
+		return
+	}
+	if !b.enabled {
+		log.Warn().Msg("render_page MCP tool disabled via config")
+		return
+	}
+	if b.browserClient == nil {
+		log.Warn().Msg("Browser service not configured, render_page tool will not be available")
+		return
+	}
+
+	maxTimeout := int(b.browserClient.Timeout().Seconds())
+	properties := map[string]any{
+		"url": map[string]any{
+			"type":        "string",
+			"description": "Absolute http(s) URL of the page to render",
+		},
+		"wait_for_selector": map[string]any{
+			"type":        "string",
+			"description": "Optional CSS selector to wait for before capturing, e.g. \"#app .results\"",
+		},
+		"timeout_seconds": map[string]any{
+			"type":        "integer",
+			"description": fmt.Sprintf("Render time limit in seconds (max %d)", maxTimeout),
+			"minimum":     1,
+			"maximum":     maxTimeout,
+		},
+	}
+	description := "Render a web page in a headless browser and return its visible text. " +
+		"Use this only for JavaScript-heavy pages (single-page apps, dashboards) that scrape returns empty or incomplete; it is slower than scrape."
+	if b.mediaClient != nil {
+		properties["screenshot"] = map[string]any{
+			"type":        "boolean",
+			"description": "Also capture a PNG screenshot and return its media URL",
+			"default":     false,
+		}
+		properties["full_page"] = map[string]any{
+			"type":        "boolean",
+			"description": "Capture the full scrollable page instead of the viewport (only with screenshot)",
+			"default":     false,
+		}
+		description += " Can also capture a screenshot of the page."
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "render_page",
+		Description: description,
+		InputSchema: map[string]any{
+			"type":       "object",
+			"properties": properties,
+			"required":   []string{"url"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RenderPageArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("render_page", req)
+
+		payload, err := b.renderPage(ctx, input)
+		if err != nil {
+			metrics.RecordToolCall("render_page", "browser", "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, b.llmClient, "render_page", input, nil, err)
+			return nil, nil, err
+		}
+
+		metrics.RecordToolCall("render_page", "browser", "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, b.llmClient, "render_page", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	log.Info().Bool("screenshots", b.mediaClient != nil).Msg("Registered render_page MCP tool")
+}
+
+func (b *BrowserMCP) renderPage(ctx context.Context, input RenderPageArgs) (map[string]any, error) {
+	pageURL := strings.TrimSpace(input.URL)
+	parsed, err := url.Parse(pageURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, fmt.Errorf("url must be an absolute http(s) URL")
+	}
+
+	renderReq := browser.RenderRequest{
+		URL:             pageURL,
+		WaitForSelector: input.WaitForSelector,
+	}
+	if input.TimeoutSeconds != nil && *input.TimeoutSeconds > 0 {
+		renderReq.Timeout = time.Duration(*input.TimeoutSeconds) * time.Second
+	}
+
+	htmlBytes, err := b.browserClient.Render(ctx, renderReq)
+	if err != nil {
+		return nil, err
+	}
+
+	text := searchclient.ExtractVisibleText(htmlBytes)
+	textLength := len([]rune(text))
+	truncated := false
+	if maxText := budgetedChars(ctx, b.maxTextChars); maxText > 0 && textLength > maxText {
+		text = truncateSnippet(text, maxText)
+		truncated = true
+	}
+	guardToolOutput(ctx, b.promptGuard, "render_page", []promptguard.Document{{
+		Source: pageURL,
+		Body:   []*string{&text},
+	}})
+
+	payload := map[string]any{
+		"url":         pageURL,
+		"text":        text,
+		"text_length": textLength,
+		"truncated":   truncated,
+		"rendered":    true,
+	}
+
+	if input.Screenshot {
+		if b.mediaClient == nil {
+			return nil, fmt.Errorf("screenshots are not available: media-api is not configured")
+		}
+		png, err := b.browserClient.Screenshot(ctx, browser.ScreenshotRequest{
+			RenderRequest: renderReq,
+			FullPage:      input.FullPage,
+		})
+		if err != nil {
+			return nil, err
+		}
+		tracking, _ := GetToolTracking(ctx)
+		upload, err := b.mediaClient.Upload(ctx, png, "image/png", fmt.Sprintf("screenshot_%d.png", time.Now().UnixNano()), tracking.AuthToken)
+		if err != nil {
+			return nil, err
+		}
+		payload["screenshot"] = map[string]any{
+			"id":    upload.ID,
+			"url":   upload.URL,
+			"mime":  upload.Mime,
+			"bytes": upload.Bytes,
+		}
+	}
+	return payload, nil
+}
@@ -0,0 +1,148 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"jan-server/services/mcp-tools/internal/domain/calculator"
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// CalculateArgs defines the arguments for the calculate tool
+type CalculateArgs struct {
+	Expression string `json:"expression"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// ConvertUnitsArgs defines the arguments for the convert_units tool
+type ConvertUnitsArgs struct {
+	Value    float64 `json:"value"`
+	FromUnit string  `json:"from_unit"`
+	ToUnit   string  `json:"to_unit"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// CalculatorMCP serves the calculate and convert_units tools. They run in-process
+// with a bounded expression parser, so unlike python_exec they need no sandbox.
+type CalculatorMCP struct {
+	llmClient *llmapi.Client // LLM-API client for tool tracking
+	enabled   bool
+}
+
+// NewCalculatorMCP creates a new calculator MCP handler.
+func NewCalculatorMCP(enabled bool) *CalculatorMCP {
+	return &CalculatorMCP{enabled: enabled}
+}
+
+// SetLLMClient sets the LLM-API client for tool call tracking
+func (c *CalculatorMCP) SetLLMClient(client *llmapi.Client) {
+	c.llmClient = client
+}
+
+// RegisterTools registers the calculate and convert_units tools with the MCP server.
+func (c *CalculatorMCP) RegisterTools(server *mcp.Server) {
+	if c == nil {
+		return
+	}
+	if !c.enabled {
+		log.Warn().Msg("calculate/convert_units MCP tools disabled via config")
+		return
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "calculate",
+		Description: "Evaluate an arithmetic expression exactly. Use this for any arithmetic instead of computing in your head. " +
+			"Supports + - * / % ^, parentheses, scientific notation (1.5e3), constants pi and e, and functions " +
+			strings.Join(calculator.SupportedFunctions(), ", ") + ".",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"expression": map[string]any{
+					"type":        "string",
+					"description": "Expression to evaluate, e.g. \"(1299 * 0.85) + 4.99\" or \"sqrt(2) * 10^3\"",
+				},
+			},
+			"required": []string{"expression"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input CalculateArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("calculate", req)
+
+		value, err := calculator.Evaluate(input.Expression)
+		if err != nil {
+			metrics.RecordToolCall("calculate", "builtin", "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, c.llmClient, "calculate", input, nil, err)
+			return nil, nil, err
+		}
+
+		payload := map[string]any{
+			"expression": input.Expression,
+			"result":     value,
+			"formatted":  calculator.FormatNumber(value),
+		}
+		metrics.RecordToolCall("calculate", "builtin", "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, c.llmClient, "calculate", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "convert_units",
+		Description: "Convert a value between units of length, mass, volume, area, time, speed, data, energy, pressure or temperature (e.g. km to miles, °F to °C, GB to MiB).",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"value": map[string]any{
+					"type":        "number",
+					"description": "Numeric value to convert",
+				},
+				"from_unit": map[string]any{
+					"type":        "string",
+					"description": "Source unit symbol or name, e.g. \"km\", \"pounds\", \"fahrenheit\"",
+				},
+				"to_unit": map[string]any{
+					"type":        "string",
+					"description": "Target unit symbol or name, e.g. \"mi\", \"kg\", \"celsius\"",
+				},
+			},
+			"required": []string{"value", "from_unit", "to_unit"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ConvertUnitsArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("convert_units", req)
+
+		conversion, err := calculator.Convert(input.Value, input.FromUnit, input.ToUnit)
+		if err != nil {
+			metrics.RecordToolCall("convert_units", "builtin", "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, c.llmClient, "convert_units", input, nil, err)
+			return nil, nil, err
+		}
+
+		payload := map[string]any{
+			"value":     conversion.Value,
+			"from_unit": conversion.From,
+			"to_unit":   conversion.To,
+			"category":  conversion.Category,
+			"result":    conversion.Result,
+			"formatted": fmt.Sprintf("%s %s = %s %s", calculator.FormatNumber(conversion.Value), conversion.From, calculator.FormatNumber(conversion.Result), conversion.To),
+		}
+		metrics.RecordToolCall("convert_units", "builtin", "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, c.llmClient, "convert_units", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	log.Info().Msg("Registered calculate and convert_units MCP tools")
+}
@@ -0,0 +1,372 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/connectors"
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// ListCalendarEventsArgs defines the arguments for the list_calendar_events tool
+type ListCalendarEventsArgs struct {
+	Provider  string `json:"provider,omitempty"`
+	StartDate string `json:"start_date,omitempty"`
+	Days      *int   `json:"days,omitempty"`
+	Timezone  string `json:"timezone,omitempty"`
+	Limit     *int   `json:"limit,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// SearchEmailsArgs defines the arguments for the search_emails tool
+type SearchEmailsArgs struct {
+	Query    string `json:"query,omitempty"`
+	Provider string `json:"provider,omitempty"`
+	Days     *int   `json:"days,omitempty"`
+	Limit    *int   `json:"limit,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// ConnectorsMCPConfig toggles the connector tools and their redaction.
+type ConnectorsMCPConfig struct {
+	EnableCalendar bool
+	EnableEmail    bool
+	RedactCalendar bool // Mask attendee addresses, numbers and link secrets in events
+	RedactEmail    bool // Mask sender addresses, numbers, codes and link secrets in emails
+}
+
+// ConnectorsMCP serves read-only calendar and email tools over the accounts
+// the user connected in the LLM-API MCP credential store. A tool only runs
+// when the user granted its consent scope for that provider.
+type ConnectorsMCP struct {
+	client           *connectors.Client
+	credentials      *llmapi.Client // LLM-API client for credential lookups
+	llmClient        *llmapi.Client // LLM-API client for tool tracking
+	calendarRedactor *connectors.Redactor
+	emailRedactor    *connectors.Redactor
+	config           ConnectorsMCPConfig
+}
+
+// NewConnectorsMCP creates a new connectors MCP handler. llmAPIBaseURL is used
+// to look up the user's credentials, independently of tool tracking.
+func NewConnectorsMCP(client *connectors.Client, llmAPIBaseURL string, cfg ConnectorsMCPConfig) *ConnectorsMCP {
+	return &ConnectorsMCP{
+		client:           client,
+		credentials:      llmapi.NewClient(llmAPIBaseURL),
+		calendarRedactor: connectors.NewRedactor(cfg.RedactCalendar),
+		emailRedactor:    connectors.NewRedactor(cfg.RedactEmail),
+		config:           cfg,
+	}
+}
+
+// SetLLMClient sets the LLM-API client for tool call tracking
+func (c *ConnectorsMCP) SetLLMClient(client *llmapi.Client) {
+	c.llmClient = client
+}
+
+// RegisterTools registers the enabled connector tools with the MCP server.
+func (c *ConnectorsMCP) RegisterTools(server *mcp.Server) {
+	if c == nil {
+		return
+	}
+	if c.config.EnableCalendar {
+		c.registerCalendar(server)
+	} else {
+		log.Warn().Msg("list_calendar_events MCP tool disabled via config")
+	}
+	if c.config.EnableEmail {
+		c.registerEmail(server)
+	} else {
+		log.Warn().Msg("search_emails MCP tool disabled via config")
+	}
+}
+
+func (c *ConnectorsMCP) registerCalendar(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "list_calendar_events",
+		Description: "List the user's calendar events (read-only) from their connected Google, Outlook or CalDAV calendar. " +
+			"Use for questions like \"what's on my calendar today?\". Defaults to today in the given timezone.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"provider": map[string]any{
+					"type":        "string",
+					"description": "Calendar to read; defaults to the first connected one",
+					"enum":        connectors.CalendarProviders,
+				},
+				"start_date": map[string]any{
+					"type":        "string",
+					"description": "First day to list, YYYY-MM-DD (default today)",
+				},
+				"days": map[string]any{
+					"type":        "integer",
+					"description": "Number of days to list (default 1, max 31)",
+					"minimum":     1,
+					"maximum":     31,
+				},
+				"timezone": map[string]any{
+					"type":        "string",
+					"description": "IANA timezone of the user, e.g. \"Europe/Berlin\" (default UTC)",
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": "Maximum number of events (default 10, max 50)",
+					"minimum":     1,
+					"maximum":     50,
+				},
+			},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ListCalendarEventsArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("list_calendar_events", req)
+
+		provider, payload, err := c.listCalendarEvents(ctx, input)
+		if err != nil {
+			metrics.RecordToolCall("list_calendar_events", provider, "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, c.llmClient, "list_calendar_events", input, nil, err)
+			return nil, nil, err
+		}
+
+		metrics.RecordToolCall("list_calendar_events", provider, "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, c.llmClient, "list_calendar_events", input, payload, nil)
+		return nil, payload, nil
+	})
+	log.Info().Bool("redact", c.calendarRedactor.Enabled()).Msg("Registered list_calendar_events MCP tool")
+}
+
+func (c *ConnectorsMCP) registerEmail(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "search_emails",
+		Description: "Search the user's recent emails (read-only) in their connected Gmail or Outlook mailbox. " +
+			"Returns senders, subjects, dates and short snippets, never full message bodies.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "Words to search for; empty lists the most recent emails",
+				},
+				"provider": map[string]any{
+					"type":        "string",
+					"description": "Mailbox to search; defaults to the first connected one",
+					"enum":        connectors.MailProviders,
+				},
+				"days": map[string]any{
+					"type":        "integer",
+					"description": "Only emails received in the last N days (default 7, max 90)",
+					"minimum":     1,
+					"maximum":     90,
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": "Maximum number of emails (default 10, max 50)",
+					"minimum":     1,
+					"maximum":     50,
+				},
+			},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input SearchEmailsArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("search_emails", req)
+
+		provider, payload, err := c.searchEmails(ctx, input)
+		if err != nil {
+			metrics.RecordToolCall("search_emails", provider, "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, c.llmClient, "search_emails", input, nil, err)
+			return nil, nil, err
+		}
+
+		metrics.RecordToolCall("search_emails", provider, "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, c.llmClient, "search_emails", input, payload, nil)
+		return nil, payload, nil
+	})
+	log.Info().Bool("redact", c.emailRedactor.Enabled()).Msg("Registered search_emails MCP tool")
+}
+
+func (c *ConnectorsMCP) listCalendarEvents(ctx context.Context, input ListCalendarEventsArgs) (string, map[string]any, error) {
+	loc := time.UTC
+	if tz := strings.TrimSpace(input.Timezone); tz != "" {
+		l, err := time.LoadLocation(tz)
+		if err != nil {
+			return "connectors", nil, fmt.Errorf("unknown timezone %q; use an IANA name such as \"America/New_York\"", tz)
+		}
+		loc = l
+	}
+	now := time.Now().In(loc)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, loc)
+	if date := strings.TrimSpace(input.StartDate); date != "" {
+		parsed, err := time.ParseInLocation("2006-01-02", date, loc)
+		if err != nil {
+			return "connectors", nil, fmt.Errorf("start_date must be YYYY-MM-DD")
+		}
+		start = parsed
+	}
+	days := 1
+	if input.Days != nil {
+		days = min(max(*input.Days, 1), 31)
+	}
+	end := start.AddDate(0, 0, days)
+
+	provider, cred, err := c.resolveCredential(ctx, input.Provider, connectors.CalendarProviders, connectors.ScopeCalendarRead)
+	if err != nil {
+		return provider, nil, err
+	}
+	events, err := c.client.ListEvents(ctx, provider, cred, connectors.EventQuery{
+		Start: start,
+		End:   end,
+		Limit: intOrZero(input.Limit),
+	})
+	if err != nil {
+		return provider, nil, err
+	}
+
+	redactor := c.calendarRedactor
+	items := make([]map[string]any, 0, len(events))
+	for _, event := range events {
+		item := map[string]any{
+			"title":   redactor.Text(event.Title),
+			"all_day": event.AllDay,
+		}
+		if event.AllDay {
+			// All-day events are calendar dates, not instants; don't shift them.
+			item["start"] = event.Start.Format("2006-01-02")
+			item["end"] = event.End.Format("2006-01-02")
+		} else {
+			item["start"] = event.Start.In(loc).Format(time.RFC3339)
+			item["end"] = event.End.In(loc).Format(time.RFC3339)
+		}
+		if event.Location != "" {
+			item["location"] = redactor.Text(event.Location)
+		}
+		if event.Description != "" {
+			item["description"] = truncateSnippet(redactor.Text(event.Description), 300)
+		}
+		if event.Organizer != nil {
+			item["organizer"] = redactor.Person(*event.Organizer)
+		}
+		if len(event.Attendees) > 0 {
+			attendees := make([]connectors.Person, 0, len(event.Attendees))
+			for _, attendee := range event.Attendees {
+				attendees = append(attendees, redactor.Person(attendee))
+			}
+			item["attendees"] = attendees
+		}
+		if event.URL != "" && !redactor.Enabled() {
+			item["url"] = event.URL
+		}
+		items = append(items, item)
+	}
+	return provider, map[string]any{
+		"provider": provider,
+		"timezone": loc.String(),
+		"start":    start.Format("2006-01-02"),
+		"end":      end.AddDate(0, 0, -1).Format("2006-01-02"),
+		"count":    len(items),
+		"events":   items,
+		"redacted": redactor.Enabled(),
+	}, nil
+}
+
+func (c *ConnectorsMCP) searchEmails(ctx context.Context, input SearchEmailsArgs) (string, map[string]any, error) {
+	days := 7
+	if input.Days != nil {
+		days = min(max(*input.Days, 1), 90)
+	}
+	since := time.Now().AddDate(0, 0, -days)
+
+	provider, cred, err := c.resolveCredential(ctx, input.Provider, connectors.MailProviders, connectors.ScopeMailRead)
+	if err != nil {
+		return provider, nil, err
+	}
+	emails, err := c.client.SearchEmails(ctx, provider, cred, connectors.EmailQuery{
+		Query: input.Query,
+		Since: since,
+		Limit: intOrZero(input.Limit),
+	})
+	if err != nil {
+		return provider, nil, err
+	}
+
+	redactor := c.emailRedactor
+	items := make([]map[string]any, 0, len(emails))
+	for _, email := range emails {
+		item := map[string]any{
+			"id":          email.ID,
+			"from":        redactor.Person(email.From),
+			"subject":     redactor.Text(email.Subject),
+			"snippet":     truncateSnippet(redactor.Text(email.Snippet), 300),
+			"received_at": email.ReceivedAt.Format(time.RFC3339),
+			"unread":      email.Unread,
+		}
+		if email.URL != "" {
+			item["url"] = email.URL
+		}
+		items = append(items, item)
+	}
+	return provider, map[string]any{
+		"provider": provider,
+		"query":    input.Query,
+		"days":     days,
+		"count":    len(items),
+		"emails":   items,
+		"redacted": redactor.Enabled(),
+	}, nil
+}
+
+// resolveCredential loads the user's credential for the requested provider, or
+// for the first connected provider in order, and checks its consent scope.
+func (c *ConnectorsMCP) resolveCredential(ctx context.Context, requested string, providers []string, scope string) (string, connectors.Credential, error) {
+	tracking, _ := GetToolTracking(ctx)
+
+	candidates := providers
+	if requested = strings.ToLower(strings.TrimSpace(requested)); requested != "" {
+		if !slices.Contains(providers, requested) {
+			return "connectors", connectors.Credential{}, fmt.Errorf("provider must be one of %s", strings.Join(providers, ", "))
+		}
+		candidates = []string{requested}
+	}
+
+	for _, provider := range candidates {
+		token, err := c.credentials.GetCredentialToken(ctx, tracking.AuthToken, provider)
+		if errors.Is(err, llmapi.ErrCredentialNotConnected) {
+			continue
+		}
+		if err != nil {
+			return provider, connectors.Credential{}, fmt.Errorf("failed to load %s credential: %w", provider, err)
+		}
+		if !slices.Contains(token.Scopes, scope) {
+			if requested == "" {
+				// Connected for something else (e.g. mail only); try the next provider.
+				continue
+			}
+			return provider, connectors.Credential{}, fmt.Errorf("the user has not granted %s access for %s; reconnect it with the %q scope via PUT /v1/mcp-credentials/%s", scope, provider, scope, provider)
+		}
+		cred := connectors.Credential{Token: token.Token}
+		if token.Account != nil {
+			cred.Account = *token.Account
+		}
+		if token.Endpoint != nil {
+			cred.Endpoint = *token.Endpoint
+		}
+		return provider, cred, nil
+	}
+
+	return "connectors", connectors.Credential{}, fmt.Errorf("no %s account is connected with consent; connect one of %s via PUT /v1/mcp-credentials/{provider} with the %q scope",
+		strings.TrimSuffix(scope, ":read"), strings.Join(candidates, ", "), scope)
+}
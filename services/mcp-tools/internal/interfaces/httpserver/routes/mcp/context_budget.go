@@ -0,0 +1,58 @@
+package mcp
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ContextBudgetHeader carries the calling model's remaining context window, in tokens
+const ContextBudgetHeader = "X-Context-Budget-Tokens"
+
+const (
+	charsPerToken    = 4   // Same approximation the tool token metrics use
+	budgetShare      = 0.5 // One tool result may fill at most half of the remaining context
+	minBudgetedChars = 1000
+	maxBudgetGrowth  = 4 // A roomy context may raise a configured limit up to this factor
+)
+
+// ContextBudgetContextKey is the context key for the caller's token budget
+type ContextBudgetContextKey struct{}
+
+// ExtractContextBudget reads the X-Context-Budget-Tokens header and injects the
+// budget into the request context. Missing or invalid values leave the
+// configured output limits in place.
+func ExtractContextBudget() gin.HandlerFunc {
+	return func(reqCtx *gin.Context) {
+		if raw := strings.TrimSpace(reqCtx.GetHeader(ContextBudgetHeader)); raw != "" {
+			if tokens, err := strconv.Atoi(raw); err == nil && tokens > 0 {
+				ctx := context.WithValue(reqCtx.Request.Context(), ContextBudgetContextKey{}, tokens)
+				reqCtx.Request = reqCtx.Request.WithContext(ctx)
+			}
+		}
+		reqCtx.Next()
+	}
+}
+
+// GetContextBudget returns the caller's remaining context in tokens, or 0 when unknown
+func GetContextBudget(ctx context.Context) int {
+	if tokens, ok := ctx.Value(ContextBudgetContextKey{}).(int); ok {
+		return tokens
+	}
+	return 0
+}
+
+// budgetedChars sizes an output limit from the caller's remaining context.
+// Without a budget the configured limit applies unchanged; with one, the limit
+// follows the budget, but never below minBudgetedChars or above maxBudgetGrowth
+// times the configured limit.
+func budgetedChars(ctx context.Context, configured int) int {
+	tokens := GetContextBudget(ctx)
+	if tokens <= 0 || configured <= 0 {
+		return configured
+	}
+	limit := int(float64(tokens) * charsPerToken * budgetShare)
+	return max(minBudgetedChars, min(limit, configured*maxBudgetGrowth))
+}
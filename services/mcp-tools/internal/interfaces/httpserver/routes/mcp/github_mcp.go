@@ -0,0 +1,346 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"jan-server/services/mcp-tools/internal/domain/toolerror"
+	"jan-server/services/mcp-tools/internal/infrastructure/github"
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// githubCredentialProvider is the MCP credential store key for GitHub tokens
+const githubCredentialProvider = "github"
+
+// SearchCodeArgs defines the arguments for the search_code tool
+type SearchCodeArgs struct {
+	Query string `json:"query"`
+	Repo  string `json:"repo,omitempty"`
+	Limit *int   `json:"limit,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// ReadFileArgs defines the arguments for the read_file tool
+type ReadFileArgs struct {
+	Repo string `json:"repo"`
+	Path string `json:"path"`
+	Ref  string `json:"ref,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// ListIssuesArgs defines the arguments for the list_issues tool
+type ListIssuesArgs struct {
+	Repo   string   `json:"repo"`
+	State  string   `json:"state,omitempty"`
+	Labels []string `json:"labels,omitempty"`
+	Limit  *int     `json:"limit,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// CreateIssueCommentArgs defines the arguments for the create_issue_comment tool
+type CreateIssueCommentArgs struct {
+	Repo        string `json:"repo"`
+	IssueNumber int    `json:"issue_number"`
+	Body        string `json:"body"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// GitHubMCP serves the GitHub tools. They act as the calling user with the
+// OAuth token the user connected in the LLM-API MCP credential store.
+type GitHubMCP struct {
+	githubClient *github.Client
+	credentials  *llmapi.Client // LLM-API client for credential lookups
+	llmClient    *llmapi.Client // LLM-API client for tool tracking
+	maxTextChars int
+	enabled      bool
+}
+
+// NewGitHubMCP creates a new GitHub MCP handler. llmAPIBaseURL is used to look
+// up the user's GitHub token, independently of tool tracking.
+func NewGitHubMCP(githubClient *github.Client, llmAPIBaseURL string, maxTextChars int, enabled bool) *GitHubMCP {
+	return &GitHubMCP{
+		githubClient: githubClient,
+		credentials:  llmapi.NewClient(llmAPIBaseURL),
+		maxTextChars: maxTextChars,
+		enabled:      enabled,
+	}
+}
+
+// SetLLMClient sets the LLM-API client for tool call tracking
+func (g *GitHubMCP) SetLLMClient(client *llmapi.Client) {
+	g.llmClient = client
+}
+
+// RegisterTools registers the GitHub tools with the MCP server.
+func (g *GitHubMCP) RegisterTools(server *mcp.Server) {
+	if g == nil {
+		return
+	}
+	if !g.enabled {
+		log.Warn().Msg("GitHub MCP tools disabled via config")
+		return
+	}
+
+	repoProperty := map[string]any{
+		"type":        "string",
+		"description": "Repository in owner/name form, e.g. \"janhq/jan\"",
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "search_code",
+		Description: "Search code on GitHub as the user, including their private repositories. " +
+			"Returns matching files with short text fragments; use read_file to see a whole file.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "GitHub code search query, e.g. \"NewClient language:go\"",
+				},
+				"repo": map[string]any{
+					"type":        "string",
+					"description": "Optional repository (owner/name) to restrict the search to",
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": "Maximum number of results (default 10, max 50)",
+					"minimum":     1,
+					"maximum":     50,
+				},
+			},
+			"required": []string{"query"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input SearchCodeArgs) (*mcp.CallToolResult, map[string]any, error) {
+		return g.run(ctx, req, "search_code", input, func(token string) (map[string]any, error) {
+			return g.searchCode(ctx, token, input)
+		})
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "read_file",
+		Description: "Read a file from a GitHub repository the user can access, or list a directory when path names one.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"repo": repoProperty,
+				"path": map[string]any{
+					"type":        "string",
+					"description": "Path of the file or directory within the repository; empty for the root",
+				},
+				"ref": map[string]any{
+					"type":        "string",
+					"description": "Optional branch, tag or commit SHA (defaults to the default branch)",
+				},
+			},
+			"required": []string{"repo", "path"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ReadFileArgs) (*mcp.CallToolResult, map[string]any, error) {
+		return g.run(ctx, req, "read_file", input, func(token string) (map[string]any, error) {
+			return g.readFile(ctx, token, input)
+		})
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_issues",
+		Description: "List issues and pull requests of a GitHub repository, most recently updated first.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"repo": repoProperty,
+				"state": map[string]any{
+					"type":        "string",
+					"description": "Issue state filter",
+					"enum":        []string{"open", "closed", "all"},
+					"default":     "open",
+				},
+				"labels": map[string]any{
+					"type":        "array",
+					"items":       map[string]any{"type": "string"},
+					"description": "Only issues carrying all of these labels",
+				},
+				"limit": map[string]any{
+					"type":        "integer",
+					"description": "Maximum number of issues (default 10, max 50)",
+					"minimum":     1,
+					"maximum":     50,
+				},
+			},
+			"required": []string{"repo"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ListIssuesArgs) (*mcp.CallToolResult, map[string]any, error) {
+		return g.run(ctx, req, "list_issues", input, func(token string) (map[string]any, error) {
+			return g.listIssues(ctx, token, input)
+		})
+	})
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name: "create_issue_comment",
+		Description: "Post a comment on a GitHub issue or pull request as the user. " +
+			"This is visible to others; only use it when the user explicitly asks you to comment.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"repo": repoProperty,
+				"issue_number": map[string]any{
+					"type":        "integer",
+					"description": "Issue or pull request number",
+					"minimum":     1,
+				},
+				"body": map[string]any{
+					"type":        "string",
+					"description": "Comment text (GitHub-flavored Markdown)",
+				},
+			},
+			"required": []string{"repo", "issue_number", "body"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateIssueCommentArgs) (*mcp.CallToolResult, map[string]any, error) {
+		return g.run(ctx, req, "create_issue_comment", input, func(token string) (map[string]any, error) {
+			comment, err := g.githubClient.CreateIssueComment(ctx, token, input.Repo, input.IssueNumber, input.Body)
+			if err != nil {
+				return nil, err
+			}
+			return map[string]any{
+				"repo":         input.Repo,
+				"issue_number": input.IssueNumber,
+				"comment_id":   comment.ID,
+				"url":          comment.URL,
+				"created_at":   comment.CreatedAt,
+			}, nil
+		})
+	})
+
+	log.Info().Msg("Registered GitHub MCP tools (search_code, read_file, list_issues, create_issue_comment)")
+}
+
+// run resolves the user's GitHub token and records metrics and tracking around a tool call
+func (g *GitHubMCP) run(ctx context.Context, req *mcp.CallToolRequest, toolName string, input any, call func(token string) (map[string]any, error)) (*mcp.CallToolResult, map[string]any, error) {
+	startTime := time.Now()
+	logToolCall(toolName, req)
+
+	payload, err := g.withToken(ctx, call)
+	if err != nil {
+		metrics.RecordToolCall(toolName, "github", "error", time.Since(startTime).Seconds())
+		recordToolError(ctx, "github", err)
+		trackToolResult(ctx, g.llmClient, toolName, input, nil, err)
+		return nil, nil, err
+	}
+
+	metrics.RecordToolCall(toolName, "github", "success", time.Since(startTime).Seconds())
+	trackToolResult(ctx, g.llmClient, toolName, input, payload, nil)
+	return nil, payload, nil
+}
+
+func (g *GitHubMCP) withToken(ctx context.Context, call func(token string) (map[string]any, error)) (map[string]any, error) {
+	tracking, _ := GetToolTracking(ctx)
+	credential, err := g.credentials.GetCredentialToken(ctx, tracking.AuthToken, githubCredentialProvider)
+	if err != nil {
+		if errors.Is(err, llmapi.ErrCredentialNotConnected) {
+			return nil, toolerror.New(toolerror.CodeUnauthorized, "GitHub is not connected for this user; connect it with PUT /v1/mcp-credentials/github and try again", false)
+		}
+		return nil, fmt.Errorf("failed to load GitHub credential: %w", err)
+	}
+	return call(credential.Token)
+}
+
+func (g *GitHubMCP) searchCode(ctx context.Context, token string, input SearchCodeArgs) (map[string]any, error) {
+	query := strings.TrimSpace(input.Query)
+	if query == "" {
+		return nil, fmt.Errorf("query is required")
+	}
+	if repo := strings.TrimSpace(input.Repo); repo != "" {
+		query += " repo:" + repo
+	}
+
+	matches, total, err := g.githubClient.SearchCode(ctx, token, query, intOrZero(input.Limit))
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{
+		"query":       query,
+		"total_count": total,
+		"results":     matches,
+	}, nil
+}
+
+func (g *GitHubMCP) readFile(ctx context.Context, token string, input ReadFileArgs) (map[string]any, error) {
+	file, entries, err := g.githubClient.ReadFile(ctx, token, input.Repo, input.Path, input.Ref)
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return map[string]any{
+			"repo":    input.Repo,
+			"path":    input.Path,
+			"type":    "dir",
+			"entries": entries,
+		}, nil
+	}
+
+	content := file.Content
+	contentLength := len([]rune(content))
+	truncated := false
+	if maxText := budgetedChars(ctx, g.maxTextChars); maxText > 0 && contentLength > maxText {
+		content = truncateSnippet(content, maxText)
+		truncated = true
+	}
+	return map[string]any{
+		"repo":           file.Repository,
+		"path":           file.Path,
+		"type":           "file",
+		"ref":            file.Ref,
+		"sha":            file.SHA,
+		"url":            file.URL,
+		"content":        content,
+		"content_length": contentLength,
+		"truncated":      truncated,
+	}, nil
+}
+
+func (g *GitHubMCP) listIssues(ctx context.Context, token string, input ListIssuesArgs) (map[string]any, error) {
+	issues, err := g.githubClient.ListIssues(ctx, token, input.Repo, github.IssueFilter{
+		State:   input.State,
+		Labels:  input.Labels,
+		PerPage: intOrZero(input.Limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+	for i := range issues {
+		issues[i].Body = truncateSnippet(issues[i].Body, 500)
+	}
+	return map[string]any{
+		"repo":   input.Repo,
+		"count":  len(issues),
+		"issues": issues,
+	}, nil
+}
+
+func intOrZero(value *int) int {
+	if value == nil {
+		return 0
+	}
+	return *value
+}
@@ -12,8 +12,13 @@ import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
 
+	"jan-server/services/mcp-tools/internal/domain/promptguard"
 	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
 	"jan-server/services/mcp-tools/internal/infrastructure/toolconfig"
 	"jan-server/services/mcp-tools/internal/interfaces/httpserver/responses"
 	"jan-server/services/mcp-tools/utils/platformerrors"
@@ -47,6 +52,8 @@ type MCPRoute struct {
 	memoryMCP       *MemoryMCP
 	imageMCP        *ImageGenerateMCP
 	imageEditMCP    *ImageEditMCP
+	calculatorMCP   *CalculatorMCP
+	worldInfoMCP    *WorldInfoMCP
 	llmClient       *llmapi.Client    // LLM-API client for tool call tracking
 	toolConfigCache *toolconfig.Cache // Cache for dynamic tool descriptions
 	mcpServer       *mcp.Server
@@ -60,18 +67,31 @@ func NewMCPRoute(
 	memoryMCP *MemoryMCP,
 	imageMCP *ImageGenerateMCP,
 	imageEditMCP *ImageEditMCP,
+	calculatorMCP *CalculatorMCP,
+	worldInfoMCP *WorldInfoMCP,
+	browserMCP *BrowserMCP,
+	githubMCP *GitHubMCP,
+	connectorsMCP *ConnectorsMCP,
 	llmClient *llmapi.Client,
 	toolConfigCache *toolconfig.Cache,
+	promptGuard *promptguard.Guard,
 ) *MCPRoute {
 	impl := &mcp.Implementation{
 		Name:    "menlo-platform",
 		Version: "1.0.0",
 	}
 	server := mcp.NewServer(impl, nil)
+	server.AddReceivingMiddleware(toolTracingMiddleware, toolErrorMiddleware)
 
 	// Pass LLM client to tool handlers for tracking
 	searchMCP.SetLLMClient(llmClient)
 
+	// Sanitize untrusted web output before it reaches the model
+	searchMCP.SetPromptGuard(promptGuard)
+	if browserMCP != nil {
+		browserMCP.SetPromptGuard(promptGuard)
+	}
+
 	if sandboxMCP != nil {
 		sandboxMCP.SetLLMClient(llmClient)
 	}
@@ -81,6 +101,26 @@ func NewMCPRoute(
 		memoryMCP.SetLLMClient(llmClient)
 	}
 
+	if calculatorMCP != nil {
+		calculatorMCP.SetLLMClient(llmClient)
+	}
+
+	if worldInfoMCP != nil {
+		worldInfoMCP.SetLLMClient(llmClient)
+	}
+
+	if browserMCP != nil {
+		browserMCP.SetLLMClient(llmClient)
+	}
+
+	if githubMCP != nil {
+		githubMCP.SetLLMClient(llmClient)
+	}
+
+	if connectorsMCP != nil {
+		connectorsMCP.SetLLMClient(llmClient)
+	}
+
 	searchMCP.RegisterTools(server)
 	if imageMCP != nil {
 		imageMCP.RegisterTools(server)
@@ -98,6 +138,31 @@ func NewMCPRoute(
 		memoryMCP.RegisterTools(server)
 	}
 
+	// Register built-in calculator tools
+	if calculatorMCP != nil {
+		calculatorMCP.RegisterTools(server)
+	}
+
+	// Register built-in weather, world time and holiday tools
+	if worldInfoMCP != nil {
+		worldInfoMCP.RegisterTools(server)
+	}
+
+	// Register headless browser tool
+	if browserMCP != nil {
+		browserMCP.RegisterTools(server)
+	}
+
+	// Register GitHub tools
+	if githubMCP != nil {
+		githubMCP.RegisterTools(server)
+	}
+
+	// Register read-only calendar and email connector tools
+	if connectorsMCP != nil {
+		connectorsMCP.RegisterTools(server)
+	}
+
 	// Register tools from external MCP providers
 	if providerMCP != nil {
 		if err := providerMCP.RegisterTools(server); err != nil {
@@ -113,6 +178,8 @@ func NewMCPRoute(
 		memoryMCP:       memoryMCP,
 		imageMCP:        imageMCP,
 		imageEditMCP:    imageEditMCP,
+		calculatorMCP:   calculatorMCP,
+		worldInfoMCP:    worldInfoMCP,
 		llmClient:       llmClient,
 		toolConfigCache: toolConfigCache,
 		mcpServer:       server,
@@ -126,7 +193,8 @@ func (route *MCPRoute) RegisterRouter(router *gin.RouterGroup) {
 	router.POST("/mcp",
 		MCPMethodGuard(allowedMCPMethods),
 		InjectUserContext(),
-		ExtractToolTracking(), // Extract tracking headers for tool call tracking
+		ExtractToolTracking(),  // Extract tracking headers for tool call tracking
+		ExtractContextBudget(), // Size tool outputs to the caller's remaining context
 		route.serveMCP,
 	)
 }
@@ -143,6 +211,14 @@ func (route *MCPRoute) RegisterRouter(router *gin.RouterGroup) {
 // @Description - `memory_retrieve`: Retrieve relevant user preferences, project context, or conversation history (params: query, user_id, project_id, max_user_items, max_project_items, min_similarity). Returns personalized context.
 // @Description - `generate_image`: Generate images from a text prompt via LLM API /v1/images/generations (params: prompt, size, n, num_inference_steps, cfg_scale).
 // @Description - `edit_image`: Edit images with a prompt + input image via LLM API /v1/images/edits (params: prompt, image, mask, size, strength, steps, seed, cfg_scale).
+// @Description - `calculate`: Evaluate an arithmetic expression in-process (params: expression) returning result and formatted value.
+// @Description - `convert_units`: Convert a value between units of the same dimension (params: value, from_unit, to_unit).
+// @Description - `get_weather`: Current weather for a place via the configured provider (params: location, units).
+// @Description - `get_world_time`: Current local time in a timezone or place (params: timezone, location).
+// @Description - `list_holidays`: Public holidays of a country (params: country_code, year, upcoming_only).
+// @Description - `render_page`: Render a JavaScript-heavy page in a headless browser (params: url, wait_for_selector, timeout_seconds, screenshot, full_page) returning visible text and an optional media-api screenshot.
+// @Description - `search_code` / `read_file` / `list_issues` / `create_issue_comment`: GitHub tools acting as the user with the token connected via LLM API /v1/mcp-credentials/github (params: query, repo, path, ref, state, labels, limit, issue_number, body).
+// @Description - `list_calendar_events` / `search_emails`: Read-only calendar and mailbox connectors (Google, Outlook, CalDAV) using credentials and consent scopes from LLM API /v1/mcp-credentials, with redacted output (params: provider, start_date, days, timezone, query, limit).
 // @Description
 // @Description **MCP Protocol:**
 // @Description - Request format: JSON-RPC 2.0 with method and params
@@ -337,6 +413,41 @@ func extractJSONFromSSE(data []byte) []byte {
 	return nil
 }
 
+// toolTracingMiddleware wraps every tools/call in a span named after the tool,
+// parented to the HTTP server span (and thus to the caller's tool-loop span).
+func toolTracingMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		callReq, ok := req.(*mcp.CallToolRequest)
+		if method != "tools/call" || !ok || callReq.Params == nil {
+			return next(ctx, method, req)
+		}
+
+		toolName := callReq.Params.Name
+		attrs := []attribute.KeyValue{attribute.String("tool.name", toolName)}
+		if budget := GetContextBudget(ctx); budget > 0 {
+			attrs = append(attrs, attribute.Int("tool.context_budget_tokens", budget))
+		}
+		if tracking, enabled := GetToolTracking(ctx); enabled {
+			attrs = append(attrs,
+				attribute.String("tool.call_id", tracking.ToolCallID),
+				attribute.String("conversation.id", tracking.ConversationID),
+			)
+		}
+		ctx, span := observability.StartSpan(ctx, "mcp.tool "+toolName, trace.WithAttributes(attrs...))
+		defer span.End()
+
+		result, err := next(ctx, method, req)
+		if err != nil {
+			observability.RecordError(span, err)
+			return result, err
+		}
+		if toolResult, ok := result.(*mcp.CallToolResult); ok && toolResult.IsError {
+			span.SetStatus(codes.Error, "tool returned an error result")
+		}
+		return result, nil
+	}
+}
+
 // InjectUserContext extracts user_id from JWT token and injects it into request context
 func InjectUserContext() gin.HandlerFunc {
 	return func(reqCtx *gin.Context) {
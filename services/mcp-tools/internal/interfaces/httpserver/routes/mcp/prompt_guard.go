@@ -0,0 +1,42 @@
+package mcp
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+
+	"jan-server/services/mcp-tools/internal/domain/promptguard"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+)
+
+// guardToolOutput sanitizes the untrusted documents of a tool result in place
+// and records what the guard changed. It runs before the result is tracked, so
+// the output stored in LLM-API is the sanitized one.
+func guardToolOutput(ctx context.Context, guard *promptguard.Guard, toolName string, docs []promptguard.Document) {
+	if !guard.Applies(toolName) {
+		return
+	}
+	report := guard.Sanitize(ctx, toolName, docs)
+
+	metrics.RecordToolOutputGuard(toolName, "stripped", report.Stripped)
+	metrics.RecordToolOutputGuard(toolName, "flagged", report.Flagged)
+	if report.ClassifierErr != nil {
+		metrics.RecordToolOutputGuard(toolName, "classifier_error", len(docs))
+		log.Warn().Err(report.ClassifierErr).Str("tool", toolName).Msg("prompt injection classifier failed, output kept")
+	}
+	if report.Stripped > 0 || report.Flagged > 0 {
+		log.Info().
+			Str("tool", toolName).
+			Int("documents", len(docs)).
+			Int("stripped", report.Stripped).
+			Int("flagged", report.Flagged).
+			Msg("prompt guard sanitized tool output")
+	}
+	// The tool span started by toolTracingMiddleware
+	trace.SpanFromContext(ctx).SetAttributes(
+		attribute.Int("tool.guard.stripped", report.Stripped),
+		attribute.Int("tool.guard.flagged", report.Flagged),
+	)
+}
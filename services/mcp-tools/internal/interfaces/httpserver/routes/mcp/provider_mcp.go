@@ -7,6 +7,7 @@ import (
 
 	"jan-server/services/mcp-tools/internal/infrastructure/mcpprovider"
 
+	"github.com/janhq/jan-server/packages/go-common/egress"
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/rs/zerolog/log"
 )
@@ -15,13 +16,15 @@ import (
 type ProviderMCP struct {
 	bridges map[string]*mcpprovider.Bridge
 	config  *mcpprovider.Config
+	egress  *egress.Policy
 }
 
 // NewProviderMCP creates a new Provider MCP handler
-func NewProviderMCP(config *mcpprovider.Config) *ProviderMCP {
+func NewProviderMCP(config *mcpprovider.Config, egressPolicy *egress.Policy) *ProviderMCP {
 	return &ProviderMCP{
 		bridges: make(map[string]*mcpprovider.Bridge),
 		config:  config,
+		egress:  egressPolicy,
 	}
 }
 
@@ -42,7 +45,7 @@ func (p *ProviderMCP) Initialize(ctx context.Context) error {
 			continue
 		}
 
-		bridge := mcpprovider.NewBridge(provider)
+		bridge := mcpprovider.NewBridge(provider, p.egress)
 
 		// Try to initialize the provider
 		if err := bridge.Initialize(ctx); err != nil {
@@ -139,6 +139,8 @@ func (s *SandboxFusionMCP) RegisterTools(server *mcp.Server) {
 			estimatedTokens = estimateTokensFromStrings("hello from sandbox (stub)", "")
 		}
 
+		classified := recordToolError(ctx, "sandboxfusion", toolErr)
+
 		// If tracking is enabled, save result to LLM-API
 		if trackingEnabled && s.llmClient != nil {
 			// Capture input for async goroutine
@@ -154,12 +156,6 @@ func (s *SandboxFusionMCP) RegisterTools(server *mcp.Server) {
 				argsBytes, _ := json.Marshal(inputCopy)
 				argsStr := string(argsBytes)
 
-				var errStr *string
-				if toolErr != nil {
-					e := toolErr.Error()
-					errStr = &e
-				}
-
 				result := s.llmClient.UpdateToolCallResult(
 					saveCtx,
 					tracking.AuthToken,
@@ -169,7 +165,7 @@ func (s *SandboxFusionMCP) RegisterTools(server *mcp.Server) {
 					argsStr,
 					"Jan MCP Server",
 					outputStr,
-					errStr,
+					classified,
 				)
 
 				if !result.Success && result.Error != nil {
@@ -3,11 +3,14 @@ package mcp
 import (
 	"context"
 	"encoding/json"
+	"slices"
 	"strings"
 	"sync"
 	"time"
 
+	"jan-server/services/mcp-tools/internal/domain/promptguard"
 	domainsearch "jan-server/services/mcp-tools/internal/domain/search"
+	"jan-server/services/mcp-tools/internal/domain/toolerror"
 	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
 	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
 	"jan-server/services/mcp-tools/internal/infrastructure/toolconfig"
@@ -51,6 +54,7 @@ type ScrapeArgs struct {
 
 type FileSearchIndexArgs struct {
 	DocumentID string         `json:"document_id"`
+	Collection string         `json:"collection,omitempty"` // Knowledge base to add the document to
 	Text       string         `json:"text"`
 	Metadata   map[string]any `json:"metadata,omitempty"`
 	Tags       []string       `json:"tags,omitempty"`
@@ -65,6 +69,7 @@ type FileSearchQueryArgs struct {
 	Query       string   `json:"query"`
 	TopK        *int     `json:"top_k,omitempty"`
 	DocumentIDs []string `json:"document_ids,omitempty"`
+	Collections []string `json:"collections,omitempty"` // Restrict the search to these knowledge bases
 	// Context passthrough
 	ToolCallID     string `json:"tool_call_id,omitempty"`
 	RequestID      string `json:"request_id,omitempty"`
@@ -107,6 +112,7 @@ type SearchMCP struct {
 	vectorStore           *vectorstore.Client
 	llmClient             *llmapi.Client    // LLM-API client for tool tracking
 	toolConfigCache       *toolconfig.Cache // Cache for dynamic tool configurations
+	promptGuard           *promptguard.Guard
 	fileIndexMu           sync.Mutex
 	fileIndex             map[string]FileSearchIndexArgs
 	maxSnippetChars       int
@@ -155,6 +161,11 @@ func (s *SearchMCP) SetLLMClient(client *llmapi.Client) {
 	s.llmClient = client
 }
 
+// SetPromptGuard sets the guard that sanitizes search results and scraped pages
+func (s *SearchMCP) SetPromptGuard(guard *promptguard.Guard) {
+	s.promptGuard = guard
+}
+
 // SetToolConfigCache sets the tool config cache for dynamic descriptions
 func (s *SearchMCP) SetToolConfigCache(cache *toolconfig.Cache) {
 	s.toolConfigCache = cache
@@ -236,6 +247,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 				Results:   []searchToolResult{},
 				Citations: []string{},
 			}
+			recordToolError(ctx, "", toolerror.ErrDisabled)
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{&mcp.TextContent{Text: "tool is disabled"}},
 				IsError: true,
@@ -331,11 +343,14 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 				Interface("engine", searchResp.SearchParameters["engine"]).
 				Bool("live", searchResp.SearchParameters["live"] == true).
 				Msg("google_search response received")
-			payload = s.buildSearchPayload(searchReq.Q, searchReq, searchResp)
+			payload = s.buildSearchPayload(ctx, searchReq.Q, searchReq, searchResp)
 			// Apply disallowed keyword filtering
 			payload = s.filterSearchResults(ctx, ToolKeyGoogleSearch, payload)
+			payload = s.guardSearchPayload(ctx, payload)
 		}
 
+		classified := recordToolError(ctx, "", toolErr)
+
 		// If tracking is enabled, save result to LLM-API (single PATCH call)
 		if trackingEnabled && s.llmClient != nil {
 			// Capture input for async goroutine
@@ -352,12 +367,6 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 				argsBytes, _ := json.Marshal(inputCopy)
 				argsStr := string(argsBytes)
 
-				var errStr *string
-				if toolErr != nil {
-					e := toolErr.Error()
-					errStr = &e
-				}
-
 				// Update the in_progress item to completed
 				result := s.llmClient.UpdateToolCallResult(
 					saveCtx,
@@ -368,7 +377,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 					argsStr,
 					"Jan MCP Server",
 					outputStr,
-					errStr,
+					classified,
 				)
 
 				if !result.Success && result.Error != nil {
@@ -442,6 +451,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 				CacheStatus: "disabled",
 				FetchedAt:   time.Now().UTC().Format(time.RFC3339),
 			}
+			recordToolError(ctx, "", toolerror.ErrDisabled)
 			return &mcp.CallToolResult{
 				Content: []mcp.Content{&mcp.TextContent{Text: "tool is disabled"}},
 				IsError: true,
@@ -506,9 +516,15 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 				Int("text_length", len(scrapeResp.Text)).
 				Interface("metadata", scrapeResp.Metadata).
 				Msg("scrape response received")
-			payload = s.buildScrapePayload(scrapeReq.Url, scrapeResp)
+			payload = s.buildScrapePayload(ctx, scrapeReq.Url, scrapeResp)
+			guardToolOutput(ctx, s.promptGuard, ToolKeyScrape, []promptguard.Document{{
+				Source: payload.SourceURL,
+				Body:   []*string{&payload.Text, &payload.TextPreview},
+			}})
 		}
 
+		classified := recordToolError(ctx, "", toolErr)
+
 		// If tracking is enabled, save result to LLM-API
 		if trackingEnabled && s.llmClient != nil {
 			// Capture input for async goroutine
@@ -524,12 +540,6 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 				argsBytes, _ := json.Marshal(inputCopy)
 				argsStr := string(argsBytes)
 
-				var errStr *string
-				if toolErr != nil {
-					e := toolErr.Error()
-					errStr = &e
-				}
-
 				result := s.llmClient.UpdateToolCallResult(
 					saveCtx,
 					tracking.AuthToken,
@@ -539,7 +549,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 					argsStr,
 					"Jan MCP Server",
 					outputStr,
-					errStr,
+					classified,
 				)
 
 				if !result.Success && result.Error != nil {
@@ -598,6 +608,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 		}, func(ctx context.Context, req *mcp.CallToolRequest, input FileSearchIndexArgs) (*mcp.CallToolResult, map[string]any, error) {
 			// Check if tool is active
 			if !s.isToolActive(ctx, ToolKeyFileSearchIndex) {
+				recordToolError(ctx, "", toolerror.ErrDisabled)
 				return &mcp.CallToolResult{
 					Content: []mcp.Content{&mcp.TextContent{Text: "tool is disabled"}},
 					IsError: true,
@@ -620,6 +631,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 			if s.vectorStore != nil && s.vectorStore.IsEnabled() {
 				resp, err := s.vectorStore.IndexDocument(ctx, vectorstore.IndexRequest{
 					DocumentID: input.DocumentID,
+					Collection: input.Collection,
 					Text:       input.Text,
 					Metadata:   input.Metadata,
 					Tags:       input.Tags,
@@ -665,6 +677,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 		}, func(ctx context.Context, req *mcp.CallToolRequest, input FileSearchQueryArgs) (*mcp.CallToolResult, map[string]any, error) {
 			// Check if tool is active
 			if !s.isToolActive(ctx, ToolKeyFileSearchQuery) {
+				recordToolError(ctx, "", toolerror.ErrDisabled)
 				return &mcp.CallToolResult{
 					Content: []mcp.Content{&mcp.TextContent{Text: "tool is disabled"}},
 					IsError: true,
@@ -697,6 +710,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 					Text:        input.Query,
 					TopK:        topK,
 					DocumentIDs: input.DocumentIDs,
+					Collections: input.Collections,
 				})
 				if err == nil {
 					for _, r := range resp.Results {
@@ -733,9 +747,13 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 						continue
 					}
 				}
+				if len(input.Collections) > 0 && !slices.Contains(input.Collections, doc.Collection) {
+					continue
+				}
 				preview := truncateSnippet(doc.Text, 200)
 				results = append(results, map[string]any{
 					"document_id":  docID,
+					"collection":   doc.Collection,
 					"text_preview": preview,
 					"score":        1.0,
 					"metadata":     doc.Metadata,
@@ -766,7 +784,7 @@ func (s *SearchMCP) RegisterTools(server *mcp.Server) {
 	} // end if enableFileSearch
 }
 
-func (s *SearchMCP) buildSearchPayload(query string, req domainsearch.SearchRequest, resp *domainsearch.SearchResponse) searchToolPayload {
+func (s *SearchMCP) buildSearchPayload(ctx context.Context, query string, req domainsearch.SearchRequest, resp *domainsearch.SearchResponse) searchToolPayload {
 	now := time.Now().UTC().Format(time.RFC3339)
 
 	metadata := map[string]any{}
@@ -799,6 +817,11 @@ func (s *SearchMCP) buildSearchPayload(query string, req domainsearch.SearchRequ
 	citations := make([]string, 0)
 
 	if resp != nil {
+		// The snippets share the caller's context budget
+		maxSnippet := s.maxSnippetChars
+		if n := len(resp.Organic); n > 0 {
+			maxSnippet = budgetedChars(ctx, s.maxSnippetChars*n) / n
+		}
 		for idx, item := range resp.Organic {
 			sourceURL := stringFromMap(item, "link")
 			snippet := firstNonEmpty(
@@ -813,7 +836,7 @@ func (s *SearchMCP) buildSearchPayload(query string, req domainsearch.SearchRequ
 				Position:    idx + 1,
 				Title:       stringFromMap(item, "title"),
 				SourceURL:   sourceURL,
-				Snippet:     truncateSnippet(snippet, s.maxSnippetChars),
+				Snippet:     truncateSnippet(snippet, maxSnippet),
 				CacheStatus: cacheStatus,
 				FetchedAt:   now,
 			})
@@ -838,7 +861,7 @@ func (s *SearchMCP) buildSearchPayload(query string, req domainsearch.SearchRequ
 	return payload
 }
 
-func (s *SearchMCP) buildScrapePayload(url string, resp *domainsearch.FetchWebpageResponse) scrapeToolPayload {
+func (s *SearchMCP) buildScrapePayload(ctx context.Context, url string, resp *domainsearch.FetchWebpageResponse) scrapeToolPayload {
 	metadata := map[string]any{}
 	if resp != nil && resp.Metadata != nil {
 		metadata = resp.Metadata
@@ -852,24 +875,45 @@ func (s *SearchMCP) buildScrapePayload(url string, resp *domainsearch.FetchWebpa
 	}
 
 	text := ""
+	maxText := budgetedChars(ctx, s.maxScrapeTextChars)
 	if resp != nil {
 		text = resp.Text
 		// Truncate full text if it exceeds the max limit
-		if len([]rune(text)) > s.maxScrapeTextChars {
-			text = truncateSnippet(text, s.maxScrapeTextChars)
+		if len([]rune(text)) > maxText {
+			text = truncateSnippet(text, maxText)
 		}
 	}
 
 	return scrapeToolPayload{
 		SourceURL:   url,
 		Text:        text,
-		TextPreview: truncateSnippet(text, s.maxScrapePreviewChars),
+		TextPreview: truncateSnippet(text, min(s.maxScrapePreviewChars, maxText)),
 		Metadata:    metadata,
 		CacheStatus: cacheStatus,
 		FetchedAt:   time.Now().UTC().Format(time.RFC3339),
 	}
 }
 
+// guardSearchPayload sanitizes the titles and snippets of search results.
+func (s *SearchMCP) guardSearchPayload(ctx context.Context, payload searchToolPayload) searchToolPayload {
+	if !s.promptGuard.Applies(ToolKeyGoogleSearch) {
+		return payload
+	}
+	docs := make([]promptguard.Document, len(payload.Results))
+	for i := range payload.Results {
+		result := &payload.Results[i]
+		docs[i] = promptguard.Document{
+			Source: result.SourceURL,
+			Title:  &result.Title,
+			Body:   []*string{&result.Snippet},
+		}
+	}
+	guardToolOutput(ctx, s.promptGuard, ToolKeyGoogleSearch, docs)
+	// The raw upstream response repeats the results unsanitized
+	payload.Raw = nil
+	return payload
+}
+
 func estimateTokensFromSearchPayload(payload searchToolPayload) float64 {
 	charCount := len(payload.Query)
 	for _, result := range payload.Results {
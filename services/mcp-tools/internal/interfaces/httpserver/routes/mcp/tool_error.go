@@ -0,0 +1,81 @@
+package mcp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/janhq/jan-server/packages/go-common/egress"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+
+	"jan-server/services/mcp-tools/internal/domain/toolerror"
+)
+
+// toolErrorSlotKey is the context key for the error of the tool call being served
+type toolErrorSlotKey struct{}
+
+type toolErrorSlot struct {
+	err *toolerror.ToolError
+}
+
+// recordToolError classifies err and keeps it for the tool call's result. The
+// first recorded error wins, so handlers that know the upstream provider can
+// record it before trackToolResult does.
+func recordToolError(ctx context.Context, provider string, err error) *toolerror.ToolError {
+	if err == nil {
+		return nil
+	}
+	slot, _ := ctx.Value(toolErrorSlotKey{}).(*toolErrorSlot)
+	if slot != nil && slot.err != nil {
+		return slot.err
+	}
+	if errors.Is(err, egress.ErrDenied) {
+		// A blocked URL won't be reachable on a retry either
+		err = toolerror.New(toolerror.CodeInvalidArguments, err.Error(), false)
+	}
+	classified := toolerror.Classify(err, provider)
+	if slot != nil {
+		slot.err = classified
+	}
+	return classified
+}
+
+// toolErrorMiddleware replaces the text of failed tools/call results with the
+// structured error, so models see a code and whether calling again may help.
+// Failures no handler recorded are classified from their message.
+func toolErrorMiddleware(next mcp.MethodHandler) mcp.MethodHandler {
+	return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+		if method != "tools/call" {
+			return next(ctx, method, req)
+		}
+
+		slot := &toolErrorSlot{}
+		result, err := next(context.WithValue(ctx, toolErrorSlotKey{}, slot), method, req)
+		toolResult, ok := result.(*mcp.CallToolResult)
+		if err != nil || !ok || toolResult == nil || !toolResult.IsError {
+			return result, err
+		}
+
+		toolErr := slot.err
+		if toolErr == nil {
+			toolErr = toolerror.FromText(resultText(toolResult))
+		}
+		body, marshalErr := json.Marshal(map[string]any{"error": toolErr})
+		if marshalErr != nil {
+			return result, nil
+		}
+		toolResult.Content = []mcp.Content{&mcp.TextContent{Text: string(body)}}
+		return toolResult, nil
+	}
+}
+
+func resultText(result *mcp.CallToolResult) string {
+	parts := make([]string, 0, len(result.Content))
+	for _, content := range result.Content {
+		if text, ok := content.(*mcp.TextContent); ok {
+			parts = append(parts, text.Text)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
@@ -2,9 +2,14 @@ package mcp
 
 import (
 	"context"
+	"encoding/json"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/rs/zerolog/log"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
 )
 
 // ToolTrackingContextKey is the context key for tool tracking data
@@ -67,3 +72,52 @@ func GetToolTracking(ctx context.Context) (ToolTrackingContext, bool) {
 func GetToolTrackingFromGin(reqCtx *gin.Context) (ToolTrackingContext, bool) {
 	return GetToolTracking(reqCtx.Request.Context())
 }
+
+// logToolCall logs an incoming tool call with its context passthrough fields.
+func logToolCall(toolName string, req *mcp.CallToolRequest) {
+	callCtx := extractAllContext(req)
+	log.Info().
+		Str("tool", toolName).
+		Str("tool_call_id", callCtx["tool_call_id"]).
+		Str("request_id", callCtx["request_id"]).
+		Str("conversation_id", callCtx["conversation_id"]).
+		Str("user_id", callCtx["user_id"]).
+		Msg("MCP tool call received")
+}
+
+// trackToolResult saves the tool result to LLM-API in the background when tracking headers are present.
+func trackToolResult(ctx context.Context, llmClient *llmapi.Client, toolName string, args any, payload map[string]any, toolErr error) {
+	classified := recordToolError(ctx, "", toolErr)
+	tracking, trackingEnabled := GetToolTracking(ctx)
+	if !trackingEnabled || llmClient == nil {
+		return
+	}
+
+	go func() {
+		saveCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		argsBytes, _ := json.Marshal(args)
+		outputBytes, _ := json.Marshal(payload)
+
+		result := llmClient.UpdateToolCallResult(
+			saveCtx,
+			tracking.AuthToken,
+			tracking.ConversationID,
+			tracking.ToolCallID,
+			toolName,
+			string(argsBytes),
+			"Jan MCP Server",
+			string(outputBytes),
+			classified,
+		)
+		if !result.Success && result.Error != nil {
+			log.Error().
+				Err(result.Error).
+				Str("tool", toolName).
+				Str("call_id", tracking.ToolCallID).
+				Str("conv_id", tracking.ConversationID).
+				Msg("Failed to update tool result in LLM-API")
+		}
+	}()
+}
@@ -0,0 +1,333 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"jan-server/services/mcp-tools/internal/infrastructure/holidays"
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+	"jan-server/services/mcp-tools/internal/infrastructure/weather"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// WeatherArgs defines the arguments for the get_weather tool
+type WeatherArgs struct {
+	Location string  `json:"location"`
+	Units    *string `json:"units,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// WorldTimeArgs defines the arguments for the get_world_time tool
+type WorldTimeArgs struct {
+	Timezone *string `json:"timezone,omitempty"`
+	Location *string `json:"location,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// HolidaysArgs defines the arguments for the list_holidays tool
+type HolidaysArgs struct {
+	CountryCode  string `json:"country_code"`
+	Year         *int   `json:"year,omitempty"`
+	UpcomingOnly *bool  `json:"upcoming_only,omitempty"`
+	// Context passthrough
+	ToolCallID     string `json:"tool_call_id,omitempty"`
+	RequestID      string `json:"request_id,omitempty"`
+	ConversationID string `json:"conversation_id,omitempty"`
+	UserID         string `json:"user_id,omitempty"`
+}
+
+// WorldInfoMCPConfig toggles the individual world info tools.
+type WorldInfoMCPConfig struct {
+	EnableWeather   bool
+	EnableWorldTime bool
+	EnableHolidays  bool
+}
+
+// WorldInfoMCP serves small built-in tools for weather, world time and public
+// holidays, so deployments don't need an external MCP provider for the basics.
+type WorldInfoMCP struct {
+	weatherClient  *weather.Client
+	holidaysClient *holidays.Client
+	llmClient      *llmapi.Client // LLM-API client for tool tracking
+	config         WorldInfoMCPConfig
+}
+
+// NewWorldInfoMCP creates a new world info MCP handler. Tools whose client is nil
+// are not registered; world time needs no client but uses the weather geocoder for
+// place names when one is available.
+func NewWorldInfoMCP(weatherClient *weather.Client, holidaysClient *holidays.Client, cfg WorldInfoMCPConfig) *WorldInfoMCP {
+	return &WorldInfoMCP{
+		weatherClient:  weatherClient,
+		holidaysClient: holidaysClient,
+		config:         cfg,
+	}
+}
+
+// SetLLMClient sets the LLM-API client for tool call tracking
+func (w *WorldInfoMCP) SetLLMClient(client *llmapi.Client) {
+	w.llmClient = client
+}
+
+// RegisterTools registers the enabled world info tools with the MCP server.
+func (w *WorldInfoMCP) RegisterTools(server *mcp.Server) {
+	if w == nil {
+		return
+	}
+
+	switch {
+	case !w.config.EnableWeather:
+		log.Warn().Msg("get_weather MCP tool disabled via config")
+	case w.weatherClient == nil:
+		log.Warn().Msg("Weather provider not configured; skipping get_weather tool registration")
+	default:
+		w.registerWeather(server)
+	}
+
+	if w.config.EnableWorldTime {
+		w.registerWorldTime(server)
+	} else {
+		log.Warn().Msg("get_world_time MCP tool disabled via config")
+	}
+
+	switch {
+	case !w.config.EnableHolidays:
+		log.Warn().Msg("list_holidays MCP tool disabled via config")
+	case w.holidaysClient == nil:
+		log.Warn().Msg("Holidays API not configured; skipping list_holidays tool registration")
+	default:
+		w.registerHolidays(server)
+	}
+}
+
+func (w *WorldInfoMCP) registerWeather(server *mcp.Server) {
+	provider := string(w.weatherClient.Provider())
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_weather",
+		Description: "Get the current weather (temperature, feels-like, humidity, wind, precipitation, conditions) for a city or place.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"location": map[string]any{
+					"type":        "string",
+					"description": "City or place name, optionally qualified by country or region, e.g. \"Paris\" or \"Portland, Oregon\"",
+				},
+				"units": map[string]any{
+					"type":        []string{"string", "null"},
+					"description": "metric (°C, km/h, mm) or imperial (°F, mph, inch)",
+					"enum":        []any{"metric", "imperial", nil},
+					"default":     "metric",
+				},
+			},
+			"required": []string{"location"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input WeatherArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("get_weather", req)
+
+		units := weather.UnitsMetric
+		if input.Units != nil && strings.EqualFold(strings.TrimSpace(*input.Units), string(weather.UnitsImperial)) {
+			units = weather.UnitsImperial
+		}
+
+		conditions, err := w.weatherClient.Current(ctx, input.Location, units)
+		if err != nil {
+			log.Warn().Err(err).Str("tool", "get_weather").Str("location", input.Location).Msg("weather lookup failed")
+			metrics.RecordToolCall("get_weather", provider, "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, w.llmClient, "get_weather", input, nil, err)
+			return nil, nil, err
+		}
+
+		payload := map[string]any{
+			"location":             conditions.Location,
+			"temperature":          conditions.Temperature,
+			"apparent_temperature": conditions.ApparentTemperature,
+			"humidity_percent":     conditions.Humidity,
+			"wind_speed":           conditions.WindSpeed,
+			"precipitation":        conditions.Precipitation,
+			"conditions":           conditions.Description,
+			"units":                conditions.Units,
+			"observed_at":          conditions.ObservedAt.Format(time.RFC3339),
+			"provider":             conditions.Provider,
+		}
+		metrics.RecordToolCall("get_weather", provider, "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, w.llmClient, "get_weather", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	log.Info().Str("provider", provider).Msg("Registered get_weather MCP tool")
+}
+
+func (w *WorldInfoMCP) registerWorldTime(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "get_world_time",
+		Description: "Get the current local date and time in a timezone or place, with its UTC offset.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"timezone": map[string]any{
+					"type":        []string{"string", "null"},
+					"description": "IANA timezone name, e.g. \"Asia/Tokyo\" or \"America/New_York\"",
+				},
+				"location": map[string]any{
+					"type":        []string{"string", "null"},
+					"description": "City or place name, used when timezone is not given, e.g. \"Sydney\"",
+				},
+			},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input WorldTimeArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("get_world_time", req)
+
+		payload, err := w.worldTime(ctx, input)
+		if err != nil {
+			metrics.RecordToolCall("get_world_time", "builtin", "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, w.llmClient, "get_world_time", input, nil, err)
+			return nil, nil, err
+		}
+
+		metrics.RecordToolCall("get_world_time", "builtin", "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, w.llmClient, "get_world_time", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	log.Info().Msg("Registered get_world_time MCP tool")
+}
+
+func (w *WorldInfoMCP) worldTime(ctx context.Context, input WorldTimeArgs) (map[string]any, error) {
+	timezone := ""
+	if input.Timezone != nil {
+		timezone = strings.TrimSpace(*input.Timezone)
+	}
+
+	var place *weather.Location
+	if timezone == "" {
+		if input.Location == nil || strings.TrimSpace(*input.Location) == "" {
+			return nil, fmt.Errorf("timezone or location is required")
+		}
+		if w.weatherClient == nil {
+			return nil, fmt.Errorf("location lookup is not available; pass an IANA timezone such as \"Europe/London\"")
+		}
+		location, err := w.weatherClient.Geocode(ctx, *input.Location)
+		if err != nil {
+			return nil, err
+		}
+		if location.Timezone == "" {
+			return nil, fmt.Errorf("no timezone known for %q", *input.Location)
+		}
+		place = location
+		timezone = location.Timezone
+	}
+
+	tz, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown timezone %q; use an IANA name such as \"Europe/London\"", timezone)
+	}
+
+	now := time.Now().In(tz)
+	abbreviation, offsetSeconds := now.Zone()
+	payload := map[string]any{
+		"timezone":     tz.String(),
+		"abbreviation": abbreviation,
+		"utc_offset":   formatUTCOffset(offsetSeconds),
+		"datetime":     now.Format(time.RFC3339),
+		"date":         now.Format("2006-01-02"),
+		"time":         now.Format("15:04:05"),
+		"weekday":      now.Weekday().String(),
+		"is_dst":       now.IsDST(),
+	}
+	if place != nil {
+		payload["location"] = place
+	}
+	return payload, nil
+}
+
+func formatUTCOffset(seconds int) string {
+	sign := '+'
+	if seconds < 0 {
+		sign = '-'
+		seconds = -seconds
+	}
+	return fmt.Sprintf("%c%02d:%02d", sign, seconds/3600, (seconds%3600)/60)
+}
+
+func (w *WorldInfoMCP) registerHolidays(server *mcp.Server) {
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "list_holidays",
+		Description: "List the public holidays of a country for a year, or only the upcoming ones.",
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"country_code": map[string]any{
+					"type":        "string",
+					"description": "ISO 3166-1 alpha-2 country code, e.g. \"US\", \"DE\", \"VN\"",
+				},
+				"year": map[string]any{
+					"type":        []string{"integer", "null"},
+					"description": "Year to list; defaults to the current year",
+				},
+				"upcoming_only": map[string]any{
+					"type":        []string{"boolean", "null"},
+					"description": "Only return holidays from today onwards",
+					"default":     false,
+				},
+			},
+			"required": []string{"country_code"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input HolidaysArgs) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("list_holidays", req)
+
+		now := time.Now().UTC()
+		year := now.Year()
+		if input.Year != nil {
+			year = *input.Year
+		}
+		if year < 1900 || year > 2200 {
+			return nil, nil, fmt.Errorf("year must be between 1900 and 2200")
+		}
+
+		list, err := w.holidaysClient.PublicHolidays(ctx, input.CountryCode, year)
+		if err != nil {
+			log.Warn().Err(err).Str("tool", "list_holidays").Str("country_code", input.CountryCode).Msg("holidays lookup failed")
+			metrics.RecordToolCall("list_holidays", "nager-date", "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, w.llmClient, "list_holidays", input, nil, err)
+			return nil, nil, err
+		}
+
+		if input.UpcomingOnly != nil && *input.UpcomingOnly {
+			today := now.Format("2006-01-02")
+			upcoming := make([]holidays.Holiday, 0, len(list))
+			for _, holiday := range list {
+				if holiday.Date >= today {
+					upcoming = append(upcoming, holiday)
+				}
+			}
+			list = upcoming
+		}
+
+		payload := map[string]any{
+			"country_code": strings.ToUpper(strings.TrimSpace(input.CountryCode)),
+			"year":         year,
+			"holidays":     list,
+		}
+		metrics.RecordToolCall("list_holidays", "nager-date", "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, w.llmClient, "list_holidays", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	log.Info().Msg("Registered list_holidays MCP tool")
+}
@@ -4,10 +4,17 @@ import (
 	"github.com/google/wire"
 	"github.com/rs/zerolog/log"
 
+	"jan-server/services/mcp-tools/internal/domain/promptguard"
+	"jan-server/services/mcp-tools/internal/infrastructure/browser"
 	"jan-server/services/mcp-tools/internal/infrastructure/config"
+	"jan-server/services/mcp-tools/internal/infrastructure/connectors"
+	"jan-server/services/mcp-tools/internal/infrastructure/github"
+	"jan-server/services/mcp-tools/internal/infrastructure/holidays"
 	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/media"
 	sandboxfusionclient "jan-server/services/mcp-tools/internal/infrastructure/sandboxfusion"
 	"jan-server/services/mcp-tools/internal/infrastructure/toolconfig"
+	"jan-server/services/mcp-tools/internal/infrastructure/weather"
 	"jan-server/services/mcp-tools/internal/interfaces/httpserver/routes/mcp"
 )
 
@@ -19,6 +26,11 @@ var RoutesProvider = wire.NewSet(
 	ProvideMemoryMCP,
 	ProvideImageGenerateMCP,
 	ProvideImageEditMCP,
+	ProvideCalculatorMCP,
+	ProvideWorldInfoMCP,
+	ProvideBrowserMCP,
+	ProvideGitHubMCP,
+	ProvideConnectorsMCP,
 	ProvideToolConfigCache,
 	ProvideMCPRoute,
 	ProvideSearchMCPConfig,
@@ -84,6 +96,81 @@ func ProvideImageEditMCP(cfg *config.Config) *mcp.ImageEditMCP {
 	return mcp.NewImageEditMCP(cfg.LLMAPIBaseURL, cfg.EnableImageEdit)
 }
 
+// ProvideCalculatorMCP creates a CalculatorMCP unless disabled
+func ProvideCalculatorMCP(cfg *config.Config) *mcp.CalculatorMCP {
+	if !cfg.EnableCalculator {
+		log.Warn().Msg("calculate/convert_units MCP tools disabled via config")
+		return nil
+	}
+	return mcp.NewCalculatorMCP(cfg.EnableCalculator)
+}
+
+// ProvideWorldInfoMCP creates a WorldInfoMCP unless all of its tools are disabled
+func ProvideWorldInfoMCP(
+	weatherClient *weather.Client,
+	holidaysClient *holidays.Client,
+	cfg *config.Config,
+) *mcp.WorldInfoMCP {
+	if !cfg.EnableWeather && !cfg.EnableWorldTime && !cfg.EnableHolidays {
+		log.Warn().Msg("get_weather/get_world_time/list_holidays MCP tools disabled via config")
+		return nil
+	}
+	return mcp.NewWorldInfoMCP(weatherClient, holidaysClient, mcp.WorldInfoMCPConfig{
+		EnableWeather:   cfg.EnableWeather,
+		EnableWorldTime: cfg.EnableWorldTime,
+		EnableHolidays:  cfg.EnableHolidays,
+	})
+}
+
+// ProvideBrowserMCP creates a BrowserMCP if enabled and a browser service is configured
+func ProvideBrowserMCP(
+	browserClient *browser.Client,
+	mediaClient *media.Client,
+	cfg *config.Config,
+) *mcp.BrowserMCP {
+	if !cfg.EnableRenderPage {
+		log.Warn().Msg("render_page MCP tool disabled via config")
+		return nil
+	}
+	if browserClient == nil {
+		log.Warn().Msg("MCP_BROWSER_URL not configured; skipping render_page tool registration")
+		return nil
+	}
+	return mcp.NewBrowserMCP(browserClient, mediaClient, cfg.MaxScrapeTextChars, cfg.EnableRenderPage)
+}
+
+// ProvideGitHubMCP creates a GitHubMCP if enabled. The tools read each user's
+// GitHub token from the LLM-API MCP credential store.
+func ProvideGitHubMCP(githubClient *github.Client, cfg *config.Config) *mcp.GitHubMCP {
+	if !cfg.EnableGitHub {
+		log.Warn().Msg("GitHub MCP tools disabled via config")
+		return nil
+	}
+	if githubClient == nil || cfg.LLMAPIBaseURL == "" {
+		log.Warn().Msg("LLM_API_BASE_URL not configured; skipping GitHub tool registration")
+		return nil
+	}
+	return mcp.NewGitHubMCP(githubClient, cfg.LLMAPIBaseURL, cfg.MaxScrapeTextChars, cfg.EnableGitHub)
+}
+
+// ProvideConnectorsMCP creates a ConnectorsMCP unless both connector tools are disabled
+func ProvideConnectorsMCP(connectorsClient *connectors.Client, cfg *config.Config) *mcp.ConnectorsMCP {
+	if !cfg.EnableCalendar && !cfg.EnableEmail {
+		log.Warn().Msg("list_calendar_events/search_emails MCP tools disabled via config")
+		return nil
+	}
+	if connectorsClient == nil || cfg.LLMAPIBaseURL == "" {
+		log.Warn().Msg("LLM_API_BASE_URL not configured; skipping calendar and email tool registration")
+		return nil
+	}
+	return mcp.NewConnectorsMCP(connectorsClient, cfg.LLMAPIBaseURL, mcp.ConnectorsMCPConfig{
+		EnableCalendar: cfg.EnableCalendar,
+		EnableEmail:    cfg.EnableEmail,
+		RedactCalendar: cfg.CalendarRedact,
+		RedactEmail:    cfg.EmailRedact,
+	})
+}
+
 // ProvideToolConfigCache creates a tool config cache if LLM-API is configured
 func ProvideToolConfigCache(cfg *config.Config, llmClient *llmapi.Client) *toolconfig.Cache {
 	if cfg.LLMAPIBaseURL == "" {
@@ -105,12 +192,18 @@ func ProvideMCPRoute(
 	memoryMCP *mcp.MemoryMCP,
 	imageMCP *mcp.ImageGenerateMCP,
 	imageEditMCP *mcp.ImageEditMCP,
+	calculatorMCP *mcp.CalculatorMCP,
+	worldInfoMCP *mcp.WorldInfoMCP,
+	browserMCP *mcp.BrowserMCP,
+	githubMCP *mcp.GitHubMCP,
+	connectorsMCP *mcp.ConnectorsMCP,
 	llmClient *llmapi.Client,
 	toolConfigCache *toolconfig.Cache,
+	promptGuard *promptguard.Guard,
 ) *mcp.MCPRoute {
 	// Set tool config cache on searchMCP for dynamic descriptions
 	if toolConfigCache != nil {
 		searchMCP.SetToolConfigCache(toolConfigCache)
 	}
-	return mcp.NewMCPRoute(searchMCP, providerMCP, sandboxMCP, memoryMCP, imageMCP, imageEditMCP, llmClient, toolConfigCache)
+	return mcp.NewMCPRoute(searchMCP, providerMCP, sandboxMCP, memoryMCP, imageMCP, imageEditMCP, calculatorMCP, worldInfoMCP, browserMCP, githubMCP, connectorsMCP, llmClient, toolConfigCache, promptGuard)
 }
@@ -2,21 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog/log"
 
 	domainsearch "jan-server/services/mcp-tools/internal/domain/search"
+	"jan-server/services/mcp-tools/internal/infrastructure"
 	"jan-server/services/mcp-tools/internal/infrastructure/auth"
 	"jan-server/services/mcp-tools/internal/infrastructure/config"
 	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
 	"jan-server/services/mcp-tools/internal/infrastructure/logger"
 	"jan-server/services/mcp-tools/internal/infrastructure/mcpprovider"
 	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+	"jan-server/services/mcp-tools/internal/infrastructure/observability"
 	sandboxfusionclient "jan-server/services/mcp-tools/internal/infrastructure/sandboxfusion"
 	searchclient "jan-server/services/mcp-tools/internal/infrastructure/search"
 	"jan-server/services/mcp-tools/internal/infrastructure/toolconfig"
@@ -57,7 +61,16 @@ func main() {
 		Str("log_level", cfg.LogLevel).
 		Msg("Starting MCP Tools service")
 
+	shutdownTracing, err := observability.Setup(context.Background(), cfg, log.Logger)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize tracing")
+	}
+	defer func() {
+		_ = shutdownTracing(context.Background())
+	}()
+
 	// Initialize infrastructure
+	egressPolicy := infrastructure.ProvideEgressPolicy(cfg)
 	searchClient := searchclient.NewSearchClient(searchclient.ClientConfig{
 		Engine:             searchclient.Engine(cfg.SearchEngine),
 		SerperAPIKey:       cfg.SerperAPIKey,
@@ -89,7 +102,24 @@ func main() {
 		RetryInitialDelay:  time.Duration(cfg.SerperRetryInitialDelay) * time.Millisecond,
 		RetryMaxDelay:      time.Duration(cfg.SerperRetryMaxDelay) * time.Millisecond,
 		RetryBackoffFactor: cfg.SerperRetryBackoffFactor,
+		Egress:             egressPolicy,
 	})
+	browserClient := infrastructure.ProvideBrowserClient(cfg, egressPolicy)
+	if proxy := infrastructure.ProvideBrowserEgressProxy(cfg, browserClient, egressPolicy); proxy != nil {
+		// The browser loads redirects and subresources itself; route them through the policy too
+		go func() {
+			log.Info().Str("address", proxy.Addr).Msg("Browser egress proxy listening")
+			if err := proxy.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+				log.Fatal().Err(err).Msg("Browser egress proxy failed")
+			}
+		}()
+	} else if browserClient != nil {
+		log.Warn().Msg("MCP_BROWSER_EGRESS_PROXY_URL not configured, only the headless browser's first and final URLs are checked against the egress policy")
+	}
+	if cfg.BrowserScrapeFallback && browserClient != nil {
+		searchClient.SetRenderer(browserClient)
+		log.Info().Str("browser_url", cfg.BrowserURL).Msg("Headless browser scrape fallback enabled")
+	}
 	searchService := domainsearch.NewSearchService(searchClient)
 
 	var vectorClient *vectorstoreclient.Client
@@ -158,9 +188,74 @@ func main() {
 		log.Warn().Msg("LLM_API_BASE_URL not configured, edit_image tool will not be available")
 	}
 
+	// Initialize built-in calculator MCP
+	var calculatorMCP *mcp.CalculatorMCP
+	if cfg.EnableCalculator {
+		calculatorMCP = mcp.NewCalculatorMCP(cfg.EnableCalculator)
+	} else {
+		log.Warn().Msg("calculate/convert_units MCP tools disabled via config")
+	}
+
+	// Initialize built-in weather, world time and holiday MCP tools
+	var worldInfoMCP *mcp.WorldInfoMCP
+	if cfg.EnableWeather || cfg.EnableWorldTime || cfg.EnableHolidays {
+		worldInfoMCP = mcp.NewWorldInfoMCP(
+			infrastructure.ProvideWeatherClient(cfg),
+			infrastructure.ProvideHolidaysClient(cfg),
+			mcp.WorldInfoMCPConfig{
+				EnableWeather:   cfg.EnableWeather,
+				EnableWorldTime: cfg.EnableWorldTime,
+				EnableHolidays:  cfg.EnableHolidays,
+			},
+		)
+	} else {
+		log.Warn().Msg("get_weather/get_world_time/list_holidays MCP tools disabled via config")
+	}
+
+	// Initialize headless browser MCP
+	var browserMCP *mcp.BrowserMCP
+	switch {
+	case !cfg.EnableRenderPage:
+		log.Warn().Msg("render_page MCP tool disabled via config")
+	case browserClient != nil:
+		browserMCP = mcp.NewBrowserMCP(browserClient, infrastructure.ProvideMediaClient(cfg), cfg.MaxScrapeTextChars, cfg.EnableRenderPage)
+		log.Info().Str("browser_url", cfg.BrowserURL).Msg("Headless browser MCP tool enabled")
+	default:
+		log.Warn().Msg("MCP_BROWSER_URL not configured, render_page tool will not be available")
+	}
+
+	// Initialize GitHub MCP (tokens come from the LLM-API MCP credential store)
+	var githubMCP *mcp.GitHubMCP
+	switch {
+	case !cfg.EnableGitHub:
+		log.Warn().Msg("GitHub MCP tools disabled via config")
+	case cfg.LLMAPIBaseURL != "":
+		githubMCP = mcp.NewGitHubMCP(infrastructure.ProvideGitHubClient(cfg), cfg.LLMAPIBaseURL, cfg.MaxScrapeTextChars, cfg.EnableGitHub)
+		log.Info().Str("github_api_url", cfg.GitHubAPIURL).Msg("GitHub MCP tools enabled")
+	default:
+		log.Warn().Msg("LLM_API_BASE_URL not configured, GitHub tools will not be available")
+	}
+
+	// Initialize read-only calendar and email connectors MCP
+	var connectorsMCP *mcp.ConnectorsMCP
+	switch {
+	case !cfg.EnableCalendar && !cfg.EnableEmail:
+		log.Warn().Msg("list_calendar_events/search_emails MCP tools disabled via config")
+	case cfg.LLMAPIBaseURL != "":
+		connectorsMCP = mcp.NewConnectorsMCP(infrastructure.ProvideConnectorsClient(cfg), cfg.LLMAPIBaseURL, mcp.ConnectorsMCPConfig{
+			EnableCalendar: cfg.EnableCalendar,
+			EnableEmail:    cfg.EnableEmail,
+			RedactCalendar: cfg.CalendarRedact,
+			RedactEmail:    cfg.EmailRedact,
+		})
+		log.Info().Bool("calendar", cfg.EnableCalendar).Bool("email", cfg.EnableEmail).Msg("Calendar and email connector MCP tools enabled")
+	default:
+		log.Warn().Msg("LLM_API_BASE_URL not configured, calendar and email tools will not be available")
+	}
+
 	// Initialize external MCP providers
 	ctx := context.Background()
-	providerMCP := mcp.NewProviderMCP(providerConfig)
+	providerMCP := mcp.NewProviderMCP(providerConfig, egressPolicy)
 	if err := providerMCP.Initialize(ctx); err != nil {
 		log.Error().Err(err).Msg("Failed to initialize MCP providers")
 	}
@@ -196,7 +291,10 @@ func main() {
 		searchMCP.SetToolConfigCache(toolConfigCache)
 	}
 
-	mcpRoute := mcp.NewMCPRoute(searchMCP, providerMCP, sandboxMCP, memoryMCP, imageMCP, imageEditMCP, llmClient, toolConfigCache)
+	// Screen web tool output for injected instructions before it reaches the model
+	promptGuard := infrastructure.ProvidePromptGuard(cfg)
+
+	mcpRoute := mcp.NewMCPRoute(searchMCP, providerMCP, sandboxMCP, memoryMCP, imageMCP, imageEditMCP, calculatorMCP, worldInfoMCP, browserMCP, githubMCP, connectorsMCP, llmClient, toolConfigCache, promptGuard)
 
 	authValidator, err := auth.NewValidator(ctx, cfg, log.Logger)
 	if err != nil {
@@ -209,6 +307,7 @@ func main() {
 	router.Use(middlewares.RequestLogger())
 	router.Use(middlewares.CORS())
 	router.Use(middlewares.MetricsRecorder())
+	router.Use(middlewares.Tracing())
 
 	// Apply auth middleware (will skip health checks internally)
 	if authValidator != nil {
@@ -220,9 +319,8 @@ func main() {
 		c.JSON(200, gin.H{"status": "ok", "service": "mcp-tools"})
 	})
 
-	router.GET("/readyz", func(c *gin.Context) {
-		c.JSON(200, gin.H{"status": "ready", "service": "mcp-tools"})
-	})
+	readiness := infrastructure.ProvideReadinessChecker(cfg, authValidator)
+	router.GET("/readyz", gin.WrapH(readiness))
 
 	router.GET("/health/auth", func(c *gin.Context) {
 		if authValidator == nil || authValidator.Ready() {
@@ -241,7 +339,16 @@ func main() {
 	addr := fmt.Sprintf(":%s", cfg.HTTPPort)
 	log.Info().Str("address", addr).Msg("Server listening")
 
-	if err := router.Run(addr); err != nil {
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		log.Fatal().Err(err).Msg("Invalid HTTP server configuration")
+	}
+	maintenanceService, err := infrastructure.ProvideMaintenance(cfg)
+	if err != nil {
+		log.Fatal().Err(err).Msg("Failed to initialize maintenance mode")
+	}
+	go maintenanceService.Run(context.Background())
+	if err := serverbuilder.New(addr, maintenanceService.Middleware()(router), serverCfg).ListenAndServe(); err != nil {
 		log.Fatal().Err(err).Msg("Failed to start server")
 	}
 }
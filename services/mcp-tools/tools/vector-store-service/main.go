@@ -24,15 +24,17 @@ func loadConfig() config {
 
 type indexRequest struct {
 	DocumentID string         `json:"document_id" binding:"required"`
+	Collection string         `json:"collection"`
 	Text       string         `json:"text" binding:"required"`
 	Metadata   map[string]any `json:"metadata"`
 	Tags       []string       `json:"tags"`
 }
 
 type queryRequest struct {
-	Text   string   `json:"text" binding:"required"`
-	TopK   int      `json:"top_k"`
-	Filter []string `json:"document_ids"`
+	Text        string   `json:"text" binding:"required"`
+	TopK        int      `json:"top_k"`
+	Filter      []string `json:"document_ids"`
+	Collections []string `json:"collections"`
 }
 
 func main() {
@@ -57,13 +59,14 @@ func main() {
 		}
 
 		doc := store.Document{
-			ID:        req.DocumentID,
-			Text:      req.Text,
-			Tags:      req.Tags,
-			Metadata:  req.Metadata,
-			Embedding: store.BuildEmbedding(req.Text),
-			CreatedAt: time.Now().UTC(),
-			UpdatedAt: time.Now().UTC(),
+			ID:         req.DocumentID,
+			Collection: req.Collection,
+			Text:       req.Text,
+			Tags:       req.Tags,
+			Metadata:   req.Metadata,
+			Embedding:  store.BuildEmbedding(req.Text),
+			CreatedAt:  time.Now().UTC(),
+			UpdatedAt:  time.Now().UTC(),
 		}
 		memStore.Upsert(doc)
 
@@ -90,11 +93,12 @@ func main() {
 			topK = 20
 		}
 
-		results := memStore.Query(store.BuildEmbedding(req.Text), topK, req.Filter)
+		results := memStore.Query(store.BuildEmbedding(req.Text), topK, req.Filter, req.Collections)
 		response := make([]map[string]any, 0, len(results))
 		for _, result := range results {
 			response = append(response, map[string]any{
 				"document_id":  result.Document.ID,
+				"collection":   result.Document.Collection,
 				"score":        result.Score,
 				"text_preview": previewText(result.Document.Text),
 				"metadata":     result.Document.Metadata,
@@ -10,13 +10,14 @@ import (
 )
 
 type Document struct {
-	ID        string
-	Text      string
-	Metadata  map[string]any
-	Tags      []string
-	Embedding map[string]float64
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID         string
+	Collection string // Optional knowledge base the document belongs to
+	Text       string
+	Metadata   map[string]any
+	Tags       []string
+	Embedding  map[string]float64
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
 }
 
 type Result struct {
@@ -51,7 +52,9 @@ func (s *MemoryStore) Upsert(doc Document) {
 	s.docs[doc.ID] = doc
 }
 
-func (s *MemoryStore) Query(queryEmbedding map[string]float64, topK int, filter []string) []Result {
+// Query returns the topK documents closest to the embedding, restricted to the
+// filter document IDs and collections when they are given.
+func (s *MemoryStore) Query(queryEmbedding map[string]float64, topK int, filter []string, collections []string) []Result {
 	s.mu.RLock()
 	defer s.mu.RUnlock()
 
@@ -66,6 +69,11 @@ func (s *MemoryStore) Query(queryEmbedding map[string]float64, topK int, filter
 		}
 	}
 
+	collectionSet := make(map[string]struct{}, len(collections))
+	for _, collection := range collections {
+		collectionSet[collection] = struct{}{}
+	}
+
 	results := make([]Result, 0, len(s.docs))
 	for _, doc := range s.docs {
 		if len(filterSet) > 0 {
@@ -73,6 +81,11 @@ func (s *MemoryStore) Query(queryEmbedding map[string]float64, topK int, filter
 				continue
 			}
 		}
+		if len(collectionSet) > 0 {
+			if _, ok := collectionSet[doc.Collection]; !ok {
+				continue
+			}
+		}
 		score := cosineSimilarity(queryEmbedding, doc.Embedding)
 		if score <= 0 {
 			continue
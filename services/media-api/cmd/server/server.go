@@ -2,13 +2,18 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
+	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
 	"jan-server/services/media-api/internal/config"
@@ -54,6 +59,12 @@ func main() {
 	}
 
 	log := logger.New(cfg)
+	defer func() {
+		// Flush exported logs last, after the shutdown errors are logged
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		_ = logger.Shutdown(shutdownCtx)
+	}()
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -98,7 +109,13 @@ func main() {
 		log.Fatal().Err(err).Msg("failed to initialize auth validator")
 	}
 
-	httpServer := httpserver.New(cfg, log, mediaService, authValidator)
+	maintenanceService, err := newMaintenance(cfg, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("initialize maintenance mode")
+	}
+
+	readiness := newReadinessChecker(cfg, db, authValidator, storageClient)
+	httpServer := httpserver.New(cfg, log, mediaService, authValidator, readiness, maintenanceService)
 	app := NewApplication(httpServer, log)
 
 	if err := app.Start(ctx); err != nil {
@@ -108,6 +125,28 @@ func main() {
 	log.Info().Msg("application exited cleanly")
 }
 
+// newReadinessChecker registers the dependency probes served by /readyz.
+func newReadinessChecker(cfg *config.Config, db *gorm.DB, authValidator *auth.Validator, storageClient *storage.S3Storage) *health.Checker {
+	checker := health.NewChecker(cfg.ServiceName, 3*time.Second)
+	checker.Register("database", true, health.DatabaseCheck(db))
+	if authValidator != nil {
+		checker.Register("auth_jwks", true, func(ctx context.Context) error {
+			if !authValidator.Ready() {
+				return errors.New("jwks not loaded")
+			}
+			return nil
+		})
+	}
+	checker.Register("object_storage", true, storageClient.Health)
+	return checker
+}
+
+// newMaintenance follows the maintenance mode switched on llm-api. It
+// returns nil, never in maintenance, when REDIS_URL is not set.
+func newMaintenance(cfg *config.Config, log zerolog.Logger) (*maintenance.Service, error) {
+	return maintenance.FromRedisURL(cfg.RedisURL, cfg.MaintenanceRefreshInterval, log)
+}
+
 func loadEnvFiles() {
 	paths := []string{".env", "../.env"}
 	for _, path := range paths {
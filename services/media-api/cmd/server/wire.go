@@ -35,6 +35,8 @@ func BuildApplication(ctx context.Context) (*Application, error) {
 		newDatabaseConfig,
 		newGormDB,
 		mediaSet,
+		newReadinessChecker,
+		newMaintenance,
 		httpserver.New,
 		NewApplication,
 	)
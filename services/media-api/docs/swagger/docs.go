@@ -146,7 +146,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Replaces pseudo data URLs with short-lived signed URLs.",
+                "description": "Looks up stored objects by media ID and returns their type, size and a download URL. The URL is presigned when MEDIA_S3_URL_ENABLED is set without a public endpoint, and expires after MEDIA_S3_PRESIGN_TTL; otherwise it is the storage or media-api URL returned on upload. Unknown IDs are listed in missing. At most 100 IDs per request.",
                 "consumes": [
                     "application/json"
                 ],
@@ -156,10 +156,10 @@ const docTemplate = `{
                 "tags": [
                     "media"
                 ],
-                "summary": "Resolve jan_* placeholders",
+                "summary": "Resolve media IDs",
                 "parameters": [
                     {
-                        "description": "Payload to resolve",
+                        "description": "Media IDs",
                         "name": "request",
                         "in": "body",
                         "required": true,
@@ -183,15 +183,6 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
-                    },
-                    "500": {
-                        "description": "Internal Server Error",
-                        "schema": {
-                            "type": "object",
-                            "additionalProperties": {
-                                "type": "string"
-                            }
-                        }
                     }
                 }
             }
@@ -203,7 +194,7 @@ const docTemplate = `{
                         "ApiKeyAuth": []
                     }
                 ],
-                "description": "Accepts multipart file upload for local storage. Alternative to presigned uploads.",
+                "description": "Accepts multipart file upload for local storage. Alternative to presigned uploads. Files larger than MEDIA_MAX_BYTES are rejected with 413.",
                 "consumes": [
                     "multipart/form-data"
                 ],
@@ -244,6 +235,15 @@ const docTemplate = `{
                                 "type": "string"
                             }
                         }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
                     }
                 }
             }
@@ -371,13 +371,13 @@ const docTemplate = `{
         "handlers.resolveRequest": {
             "type": "object",
             "required": [
-                "payload"
+                "ids"
             ],
             "properties": {
-                "payload": {
+                "ids": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 }
             }
@@ -385,14 +385,41 @@ const docTemplate = `{
         "handlers.resolveResponse": {
             "type": "object",
             "properties": {
-                "payload": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/handlers.resolvedMedia"
+                    }
+                },
+                "missing": {
                     "type": "array",
                     "items": {
-                        "type": "integer"
+                        "type": "string"
                     }
                 }
             }
         },
+        "handlers.resolvedMedia": {
+            "type": "object",
+            "properties": {
+                "bytes": {
+                    "type": "integer"
+                },
+                "expires_at": {
+                    "description": "Unix seconds; omitted when the URL does not expire",
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "mime": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
         "media.IngestRequest": {
             "type": "object",
             "required": [
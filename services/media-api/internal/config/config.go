@@ -18,6 +18,8 @@ type Config struct {
 	LogLevel        string        `env:"MEDIA_LOG_LEVEL" envDefault:"info"`
 	EnableTracing   bool          `env:"ENABLE_TRACING" envDefault:"false"`
 	OTLPEndpoint    string        `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:""`
+	OTLPHeaders     string        `env:"OTEL_EXPORTER_OTLP_HEADERS"`           // Sent with exported logs
+	LogExporter     string        `env:"OTEL_LOGS_EXPORTER" envDefault:"none"` // otlp: also export logs to OTLPEndpoint
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
 
 	// Database - Read/Write Split (required, no defaults)
@@ -57,6 +59,12 @@ type Config struct {
 	RetentionDays      int           `env:"MEDIA_RETENTION_DAYS" envDefault:"30"`
 	RemoteFetchTimeout time.Duration `env:"MEDIA_REMOTE_FETCH_TIMEOUT" envDefault:"15s"`
 
+	// Egress policy for remote_url ingestion: private, loopback and metadata
+	// addresses are refused unless the host is allowed or private networks are
+	RemoteFetchAllowedHosts []string `env:"MEDIA_REMOTE_FETCH_ALLOWED_HOSTS" envSeparator:","`   // ".example.com" matches subdomains
+	RemoteFetchAllowPrivate bool     `env:"MEDIA_REMOTE_FETCH_ALLOW_PRIVATE" envDefault:"false"` // Local development only
+	RemoteFetchMaxRedirects int      `env:"MEDIA_REMOTE_FETCH_MAX_REDIRECTS" envDefault:"5"`
+
 	// GCS Storage (alternative to S3)
 	GCSBucket string `env:"MEDIA_GCS_BUCKET"`
 
@@ -65,6 +73,12 @@ type Config struct {
 	AuthIssuer  string `env:"AUTH_ISSUER"`
 	Account     string `env:"ACCOUNT"`
 	AuthJWKSURL string `env:"AUTH_JWKS_URL"`
+	// Third-party clients whose exchanged tokens only llm-api accepts; rejected here
+	IntegrationClients []string `env:"INTEGRATION_CLIENTS" envSeparator:","`
+
+	// Maintenance mode switched on llm-api, shared through this Redis; off when unset
+	RedisURL                   string        `env:"REDIS_URL"`
+	MaintenanceRefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL" envDefault:"5s"`
 }
 
 // Load parses environment variables into Config.
@@ -13,6 +13,7 @@ import (
 	"time"
 
 	"github.com/gabriel-vasile/mimetype"
+	"github.com/janhq/jan-server/packages/go-common/egress"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/media-api/internal/config"
@@ -27,6 +28,14 @@ var allowedMIMEs = map[string]string{
 	"image/gif":  "gif",
 	"image/bmp":  "bmp",
 	"image/tiff": "tiff",
+
+	// JSON Lines files such as fine-tuning dataset exports; a single-line file is
+	// detected as plain JSON
+	"application/x-ndjson": "jsonl",
+	"application/json":     "json",
+
+	// Gzipped archives, such as conversations moved to cold storage by llm-api
+	"application/gzip": "gz",
 }
 
 // Repository defines persistence operations needed by the service.
@@ -42,24 +51,35 @@ type Storage interface {
 	Download(ctx context.Context, key string) (io.ReadCloser, string, error)
 }
 
+// Presigner is implemented by storage backends that can hand out time-limited
+// download URLs.
+type Presigner interface {
+	PresignDownload(ctx context.Context, key string, ttl time.Duration) (string, error)
+}
+
 // Service orchestrates media ingestion and retrieval.
 type Service struct {
 	cfg        *config.Config
 	repo       Repository
 	storage    Storage
 	log        zerolog.Logger
+	egress     *egress.Policy
 	httpClient *http.Client
 }
 
 func NewService(cfg *config.Config, repo Repository, storage Storage, log zerolog.Logger) *Service {
+	policy := egress.NewPolicy(egress.Config{
+		AllowedHosts:         cfg.RemoteFetchAllowedHosts,
+		AllowPrivateNetworks: cfg.RemoteFetchAllowPrivate,
+		MaxRedirects:         cfg.RemoteFetchMaxRedirects,
+	})
 	return &Service{
-		cfg:     cfg,
-		repo:    repo,
-		storage: storage,
-		log:     log.With().Str("component", "media-service").Logger(),
-		httpClient: &http.Client{
-			Timeout: cfg.RemoteFetchTimeout,
-		},
+		cfg:        cfg,
+		repo:       repo,
+		storage:    storage,
+		log:        log.With().Str("component", "media-service").Logger(),
+		egress:     policy,
+		httpClient: policy.NewHTTPClient(cfg.RemoteFetchTimeout),
 	}
 }
 
@@ -69,7 +89,11 @@ func (s *Service) Ingest(ctx context.Context, req IngestRequest) (*MediaObject,
 	if err != nil {
 		return nil, false, err
 	}
+	return s.IngestBytes(ctx, data, req.UserID)
+}
 
+// IngestBytes stores already loaded media content, such as a streamed upload.
+func (s *Service) IngestBytes(ctx context.Context, data []byte, userID string) (*MediaObject, bool, error) {
 	if int64(len(data)) == 0 {
 		return nil, false, errors.New("file is empty")
 	}
@@ -93,7 +117,11 @@ func (s *Service) Ingest(ctx context.Context, req IngestRequest) (*MediaObject,
 	}
 
 	id := mediaid.New()
-	key := fmt.Sprintf("images/%s.%s", id, ext)
+	prefix := "images"
+	if !strings.HasPrefix(mimeType, "image/") {
+		prefix = "files"
+	}
+	key := fmt.Sprintf("%s/%s.%s", prefix, id, ext)
 
 	if err := s.storage.Upload(ctx, key, bytes.NewReader(data), int64(len(data)), mimeType); err != nil {
 		return nil, false, err
@@ -106,7 +134,7 @@ func (s *Service) Ingest(ctx context.Context, req IngestRequest) (*MediaObject,
 		MimeType:        mimeType,
 		Bytes:           int64(len(data)),
 		Sha256:          hash,
-		CreatedBy:       req.UserID,
+		CreatedBy:       userID,
 		RetentionUntil:  time.Now().Add(time.Duration(s.cfg.RetentionDays) * 24 * time.Hour),
 	}
 
@@ -148,6 +176,21 @@ func (s *Service) Get(ctx context.Context, id string) (*MediaObject, error) {
 	return obj, nil
 }
 
+// PresignDownload returns a download URL for obj that expires after
+// MEDIA_S3_PRESIGN_TTL. ok is false when the storage backend cannot presign.
+func (s *Service) PresignDownload(ctx context.Context, obj *MediaObject) (url string, expiresAt time.Time, ok bool, err error) {
+	presigner, ok := s.storage.(Presigner)
+	if !ok || s.cfg.S3PresignTTL <= 0 {
+		return "", time.Time{}, false, nil
+	}
+	expiresAt = time.Now().Add(s.cfg.S3PresignTTL)
+	url, err = presigner.PresignDownload(ctx, obj.StorageKey, s.cfg.S3PresignTTL)
+	if err != nil {
+		return "", time.Time{}, false, err
+	}
+	return url, expiresAt, true, nil
+}
+
 func (s *Service) loadBytes(ctx context.Context, source Source) ([]byte, error) {
 	switch strings.ToLower(source.Type) {
 	case "data_url", "datauri", "dataurl":
@@ -177,12 +220,18 @@ func (s *Service) fetchRemote(ctx context.Context, url string) ([]byte, error) {
 	if url == "" {
 		return nil, errors.New("url is required")
 	}
+	if err := s.egress.CheckURL(ctx, url); err != nil {
+		return nil, s.egressError(ctx, url, err)
+	}
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
 	resp, err := s.httpClient.Do(req)
 	if err != nil {
+		if errors.Is(err, egress.ErrDenied) {
+			return nil, s.egressError(ctx, url, err)
+		}
 		return nil, err
 	}
 	defer resp.Body.Close()
@@ -197,3 +246,8 @@ func (s *Service) fetchRemote(ctx context.Context, url string) ([]byte, error) {
 	}
 	return data, nil
 }
+
+func (s *Service) egressError(ctx context.Context, url string, err error) error {
+	s.log.Warn().Err(err).Str("url", url).Msg("remote fetch blocked by egress policy")
+	return platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, "remote_url is not allowed: "+err.Error(), err, "")
+}
@@ -8,6 +8,7 @@ import (
 	"strings"
 	"time"
 
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
 	_ "github.com/lib/pq"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
@@ -74,6 +75,13 @@ func Connect(cfg Config) (*gorm.DB, error) {
 		sqlDB.SetConnMaxLifetime(cfg.ConnMaxLifetime)
 	}
 
+	if err := gocommonmetrics.RegisterDBPool(sqlDB, "media_api"); err != nil {
+		return nil, fmt.Errorf("register db pool metrics: %w", err)
+	}
+	if err := db.Use(gocommonmetrics.NewGormPlugin("media_api")); err != nil {
+		return nil, fmt.Errorf("register db query metrics: %w", err)
+	}
+
 	return db, nil
 }
 
@@ -1,33 +1,69 @@
 package logger
 
 import (
+	"context"
+	"io"
 	"os"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/logging"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
 
 	"jan-server/services/media-api/internal/config"
 )
 
+var (
+	exporter     *logging.OTLPExporter
+	exporterOnce sync.Once
+)
+
 // New creates a zerolog.Logger configured for the template service.
+// With OTEL_LOGS_EXPORTER=otlp, events are also exported to the OTLP endpoint.
 func New(cfg *config.Config) zerolog.Logger {
 	level := parseLevel(cfg.LogLevel)
-	output := zerolog.ConsoleWriter{
+	var output io.Writer = zerolog.ConsoleWriter{
 		Out:        os.Stdout,
 		TimeFormat: time.RFC3339,
 	}
+	if cfg.LogExporter == "otlp" && cfg.OTLPEndpoint != "" {
+		output = zerolog.MultiLevelWriter(output, otlpExporter(cfg))
+	}
 	base := log.Output(output).
 		With().
 		Timestamp().
 		Str("service", cfg.ServiceName).
 		Str("environment", cfg.Environment).
 		Logger().
-		Level(level)
+		Level(level).
+		Hook(logging.TraceHook{})
 	return base
 }
 
+// Shutdown flushes the events queued for OTLP export.
+func Shutdown(ctx context.Context) error {
+	if exporter == nil {
+		return nil
+	}
+	return exporter.Shutdown(ctx)
+}
+
+// otlpExporter returns the process-wide exporter, so loggers built more than
+// once share one export queue.
+func otlpExporter(cfg *config.Config) *logging.OTLPExporter {
+	exporterOnce.Do(func() {
+		exporter = logging.NewOTLPExporter(logging.OTLPConfig{
+			Endpoint:    cfg.OTLPEndpoint,
+			Headers:     cfg.OTLPHeaders,
+			ServiceName: cfg.ServiceName,
+			Environment: cfg.Environment,
+		})
+	})
+	return exporter
+}
+
 func parseLevel(levelString string) zerolog.Level {
 	if levelString == "" {
 		return zerolog.InfoLevel
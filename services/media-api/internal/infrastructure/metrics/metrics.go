@@ -1,34 +1,15 @@
 package metrics
 
 import (
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 // Media-API Metrics
 var (
-	// Request counters
-	RequestsTotal = promauto.NewCounterVec(
-		prometheus.CounterOpts{
-			Namespace: "jan",
-			Subsystem: "media_api",
-			Name:      "requests_total",
-			Help:      "Total number of HTTP requests",
-		},
-		[]string{"method", "endpoint", "status"},
-	)
-
-	// Request duration histogram
-	RequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Namespace: "jan",
-			Subsystem: "media_api",
-			Name:      "request_duration_seconds",
-			Help:      "HTTP request duration in seconds",
-			Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 30},
-		},
-		[]string{"method", "endpoint"},
-	)
+	// HTTP records request counts, duration/status histograms and in-flight requests
+	HTTP = gocommonmetrics.NewHTTPMetrics("jan", "media_api", []float64{0.1, 0.5, 1, 2, 5, 10, 30})
 
 	// Upload counters
 	UploadsTotal = promauto.NewCounterVec(
@@ -87,12 +68,6 @@ var (
 	)
 )
 
-// RecordRequest records an HTTP request
-func RecordRequest(method, endpoint, status string, durationSec float64) {
-	RequestsTotal.WithLabelValues(method, endpoint, status).Inc()
-	RequestDuration.WithLabelValues(method, endpoint).Observe(durationSec)
-}
-
 // RecordUpload records a file upload
 func RecordUpload(contentType, status string, bytes int64) {
 	UploadsTotal.WithLabelValues(contentType, status).Inc()
@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws"
 	awsconfig "github.com/aws/aws-sdk-go-v2/config"
@@ -14,6 +15,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"jan-server/services/media-api/internal/config"
+	"jan-server/services/media-api/internal/infrastructure/metrics"
 )
 
 var errStorageDisabled = errors.New("media storage backend is not configured; set MEDIA_S3_* to enable uploads")
@@ -22,6 +24,7 @@ var errStorageDisabled = errors.New("media storage backend is not configured; se
 type S3Storage struct {
 	bucket   string
 	client   *s3.Client
+	presign  *s3.PresignClient
 	log      zerolog.Logger
 	disabled bool
 }
@@ -66,6 +69,7 @@ func NewS3Storage(ctx context.Context, cfg *config.Config, log zerolog.Logger) (
 	})
 
 	storage.client = client
+	storage.presign = s3.NewPresignClient(client)
 	return storage, nil
 }
 
@@ -110,6 +114,23 @@ func (s *S3Storage) Download(ctx context.Context, key string) (io.ReadCloser, st
 	return out.Body, mime, nil
 }
 
+// PresignDownload returns a URL that downloads the object without credentials until ttl passes.
+func (s *S3Storage) PresignDownload(ctx context.Context, key string, ttl time.Duration) (string, error) {
+	if err := s.ensureEnabled(); err != nil {
+		return "", err
+	}
+	start := time.Now()
+	req, err := s.presign.PresignGetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	}, s3.WithPresignExpires(ttl))
+	if err != nil {
+		return "", err
+	}
+	metrics.RecordPresign(time.Since(start).Seconds())
+	return req.URL, nil
+}
+
 // Health performs a simple HeadObject request.
 func (s *S3Storage) Health(ctx context.Context) error {
 	if s.disabled {
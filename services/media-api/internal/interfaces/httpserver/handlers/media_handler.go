@@ -1,7 +1,7 @@
 package handlers
 
 import (
-	"encoding/base64"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -31,6 +31,14 @@ func NewMediaHandler(cfg *config.Config, service *domain.Service, log zerolog.Lo
 	}
 }
 
+const (
+	// maxResolveIDs caps how many objects one resolve request may look up
+	maxResolveIDs = 100
+	// Room for multipart boundaries, part headers and small form fields on top of the file itself
+	multipartOverheadBytes = 64 * 1024
+	maxFormFieldBytes      = 1024
+)
+
 type ingestResponse struct {
 	ID      string `json:"id"`
 	Mime    string `json:"mime"`
@@ -39,6 +47,23 @@ type ingestResponse struct {
 	URL     string `json:"url"`
 }
 
+type resolveRequest struct {
+	IDs []string `json:"ids" binding:"required"`
+}
+
+type resolvedMedia struct {
+	ID        string `json:"id"`
+	Mime      string `json:"mime"`
+	Bytes     int64  `json:"bytes"`
+	URL       string `json:"url"`
+	ExpiresAt *int64 `json:"expires_at,omitempty"` // Unix seconds; omitted when the URL does not expire
+}
+
+type resolveResponse struct {
+	Data    []resolvedMedia `json:"data"`
+	Missing []string        `json:"missing"`
+}
+
 // Ingest godoc
 // @Summary      Upload media
 // @Description  Accepts data URLs or remote URLs and stores content privately.
@@ -121,9 +146,61 @@ func (h *MediaHandler) Proxy(c *gin.Context) {
 	}
 }
 
+// Resolve godoc
+// @Summary      Resolve media IDs
+// @Description  Looks up stored objects by media ID and returns their type, size and a download URL. The URL is presigned when MEDIA_S3_URL_ENABLED is set without a public endpoint, and expires after MEDIA_S3_PRESIGN_TTL; otherwise it is the storage or media-api URL returned on upload. Unknown IDs are listed in missing. At most 100 IDs per request.
+// @Tags         media
+// @Accept       json
+// @Produce      json
+// @Param        request  body      resolveRequest  true  "Media IDs"
+// @Success      200      {object}  resolveResponse
+// @Failure      400      {object}  map[string]string
+// @Security     ApiKeyAuth
+// @Router       /v1/media/resolve [post]
+func (h *MediaHandler) Resolve(c *gin.Context) {
+	var req resolveRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		responses.HandleNewError(c, platformerrors.ErrorTypeValidation, "invalid request body", "6e2d8b41-93c7-4f0a-b5d2-1c8e7a4f9036")
+		return
+	}
+	if len(req.IDs) > maxResolveIDs {
+		responses.HandleNewError(c, platformerrors.ErrorTypeValidation, fmt.Sprintf("at most %d ids may be resolved at once", maxResolveIDs), "0b7f4c92-5d1e-4a83-9e6b-2f8d3c7a1e54")
+		return
+	}
+
+	resp := resolveResponse{Data: []resolvedMedia{}, Missing: []string{}}
+	seen := make(map[string]bool, len(req.IDs))
+	for _, id := range req.IDs {
+		id = strings.TrimSpace(id)
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		obj, err := h.service.Get(c.Request.Context(), id)
+		if err != nil {
+			resp.Missing = append(resp.Missing, id)
+			continue
+		}
+		resolved := resolvedMedia{ID: obj.ID, Mime: obj.MimeType, Bytes: obj.Bytes, URL: h.buildMediaURL(obj)}
+		if h.presignsURLs() {
+			url, expiresAt, ok, err := h.service.PresignDownload(c.Request.Context(), obj)
+			if err != nil {
+				h.log.Warn().Err(err).Str("id", obj.ID).Msg("presign failed, returning direct url")
+			} else if ok {
+				unix := expiresAt.Unix()
+				resolved.URL = url
+				resolved.ExpiresAt = &unix
+			}
+		}
+		resp.Data = append(resp.Data, resolved)
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
 // DirectUpload godoc
 // @Summary      Direct file upload
-// @Description  Accepts multipart file upload for local storage.
+// @Description  Accepts multipart file upload for local storage. Alternative to presigned uploads. Files larger than MEDIA_MAX_BYTES are rejected with 413.
 // @Tags         media
 // @Accept       multipart/form-data
 // @Produce      json
@@ -131,48 +208,68 @@ func (h *MediaHandler) Proxy(c *gin.Context) {
 // @Param        user_id   formData  string  false "User ID"
 // @Success      200       {object}  ingestResponse
 // @Failure      400       {object}  map[string]string
+// @Failure      413       {object}  map[string]string
 // @Security     ApiKeyAuth
 // @Router       /v1/media/upload [post]
 func (h *MediaHandler) DirectUpload(c *gin.Context) {
-	file, header, err := c.Request.FormFile("file")
+	// Bound the whole body so an oversized upload is cut off instead of spooled to memory or disk
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.cfg.MaxMediaBytes+multipartOverheadBytes)
+
+	reader, err := c.Request.MultipartReader()
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "multipart/form-data body is required"})
 		return
 	}
-	defer file.Close()
 
-	userID := c.Request.FormValue("user_id")
-	if userID == "" {
-		userID = "anonymous"
-	}
+	var data []byte
+	fileFound := false
+	userID := ""
+	for {
+		part, err := reader.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			h.respondUploadReadError(c, err, "invalid multipart body")
+			return
+		}
 
-	// Read file content
-	data, err := io.ReadAll(file)
-	if err != nil {
-		h.log.Error().Err(err).Msg("failed to read file")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "failed to read file"})
-		return
+		switch part.FormName() {
+		case "file":
+			// Read one byte past the limit so oversized files are detected without buffering them
+			data, err = io.ReadAll(io.LimitReader(part, h.cfg.MaxMediaBytes+1))
+			if err != nil {
+				part.Close()
+				h.respondUploadReadError(c, err, "failed to read file")
+				return
+			}
+			if int64(len(data)) > h.cfg.MaxMediaBytes {
+				part.Close()
+				c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file exceeds max size of %d bytes", h.cfg.MaxMediaBytes)})
+				return
+			}
+			fileFound = true
+		case "user_id":
+			value, err := io.ReadAll(io.LimitReader(part, maxFormFieldBytes))
+			if err != nil {
+				part.Close()
+				h.respondUploadReadError(c, err, "invalid multipart body")
+				return
+			}
+			userID = strings.TrimSpace(string(value))
+		}
+		part.Close()
 	}
 
-	// Determine content type
-	contentType := header.Header.Get("Content-Type")
-	if contentType == "" {
-		contentType = "application/octet-stream"
+	if !fileFound {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "file is required"})
+		return
 	}
-
-	// Create an ingest request with data URL
-	dataURL := "data:" + contentType + ";base64," + base64.StdEncoding.EncodeToString(data)
-
-	req := domain.IngestRequest{
-		Source: domain.Source{
-			Type:    "data_url",
-			DataURL: dataURL,
-		},
-		Filename: header.Filename,
-		UserID:   userID,
+	if userID == "" {
+		userID = "anonymous"
 	}
 
-	obj, dedup, err := h.service.Ingest(c.Request.Context(), req)
+	obj, dedup, err := h.service.IngestBytes(c.Request.Context(), data, userID)
 	if err != nil {
 		h.log.Error().Err(err).Msg("ingest failed")
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
@@ -191,6 +288,18 @@ func (h *MediaHandler) DirectUpload(c *gin.Context) {
 	})
 }
 
+// respondUploadReadError maps a failed read of the upload body to 413 when the body
+// limit was hit and to 400 otherwise.
+func (h *MediaHandler) respondUploadReadError(c *gin.Context, err error, message string) {
+	var maxBytesErr *http.MaxBytesError
+	if errors.As(err, &maxBytesErr) {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": fmt.Sprintf("file exceeds max size of %d bytes", h.cfg.MaxMediaBytes)})
+		return
+	}
+	h.log.Error().Err(err).Msg(message)
+	c.JSON(http.StatusBadRequest, gin.H{"error": message})
+}
+
 // PublicServe godoc
 // @Summary      Serve media publicly
 // @Description  Streams the media file directly for use in HTML img src. This endpoint does not require authentication.
@@ -240,6 +349,12 @@ func (h *MediaHandler) buildMediaURL(obj *domain.MediaObject) string {
 	return h.buildDirectURL(obj.ID)
 }
 
+// presignsURLs reports whether storage URLs are handed out for a private bucket,
+// which can then only be read through presigned URLs.
+func (h *MediaHandler) presignsURLs() bool {
+	return h.cfg.S3URLEnabled && h.cfg.IsS3Storage() && strings.TrimSpace(h.cfg.S3PublicEndpoint) == ""
+}
+
 func (h *MediaHandler) buildDirectURL(id string) string {
 	publicURL := h.cfg.PublicURL
 	if publicURL == "" {
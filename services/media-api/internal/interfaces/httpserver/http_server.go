@@ -6,7 +6,10 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -16,32 +19,35 @@ import (
 	domain "jan-server/services/media-api/internal/domain/media"
 	"jan-server/services/media-api/internal/infrastructure/auth"
 	"jan-server/services/media-api/internal/interfaces/httpserver/handlers"
+	"jan-server/services/media-api/internal/interfaces/httpserver/middlewares"
 	v1 "jan-server/services/media-api/internal/interfaces/httpserver/routes/v1"
 )
 
 // HTTPServer wraps the gin engine with graceful shutdown helpers.
 type HTTPServer struct {
-	cfg    *config.Config
-	engine *gin.Engine
-	log    zerolog.Logger
-	auth   *auth.Validator
+	cfg         *config.Config
+	engine      *gin.Engine
+	log         zerolog.Logger
+	auth        *auth.Validator
+	maintenance *maintenance.Service
 }
 
 // New constructs the HTTP server with default middleware and routes.
-func New(cfg *config.Config, log zerolog.Logger, mediaService *domain.Service, authValidator *auth.Validator) *HTTPServer {
+// maintenanceService is nil when REDIS_URL is not set.
+func New(cfg *config.Config, log zerolog.Logger, mediaService *domain.Service, authValidator *auth.Validator, readiness *health.Checker, maintenanceService *maintenance.Service) *HTTPServer {
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
 	mediaapidocs.SwaggerInfo.BasePath = "/"
 
 	engine := gin.New()
-	engine.Use(gin.Recovery(), gin.Logger())
+	engine.Use(gin.Recovery(), gin.Logger(), middlewares.MetricsMiddleware())
 
 	handlerProvider := handlers.NewProvider(cfg, mediaService, log)
 	routeProvider := v1.NewRoutes(handlerProvider, cfg)
 
 	// Register public routes (health checks, swagger) without authentication
-	registerPublicRoutes(engine, cfg, authValidator)
+	registerPublicRoutes(engine, cfg, authValidator, readiness)
 
 	// Register public media serving endpoint (no auth required for img src usage)
 	engine.GET("/api/media/:id", handlerProvider.Media.PublicServe)
@@ -55,19 +61,23 @@ func New(cfg *config.Config, log zerolog.Logger, mediaService *domain.Service, a
 	routeProvider.Register(engine.Group("/"))
 
 	return &HTTPServer{
-		cfg:    cfg,
-		engine: engine,
-		log:    log,
-		auth:   authValidator,
+		cfg:         cfg,
+		engine:      engine,
+		log:         log,
+		auth:        authValidator,
+		maintenance: maintenanceService,
 	}
 }
 
 // Run starts the HTTP listener and handles graceful shutdown via context cancellation.
 func (s *HTTPServer) Run(ctx context.Context) error {
-	server := &http.Server{
-		Addr:    s.cfg.Addr(),
-		Handler: s.engine,
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		return err
 	}
+	// Maintenance mode turns away uploads and deletions before they reach the engine
+	server := serverbuilder.New(s.cfg.Addr(), s.maintenance.Middleware()(s.engine), serverCfg)
+	go s.maintenance.Run(ctx)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -91,16 +101,14 @@ func (s *HTTPServer) Run(ctx context.Context) error {
 	return server.Shutdown(shutdownCtx)
 }
 
-func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator *auth.Validator) {
+func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator *auth.Validator, readiness *health.Checker) {
 	engine.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"service": cfg.ServiceName, "status": "ok"})
 	})
 	engine.GET("/healthz", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
-	engine.GET("/readyz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ready"})
-	})
+	engine.GET("/readyz", gin.WrapH(readiness))
 	engine.GET("/health/auth", func(c *gin.Context) {
 		if authValidator == nil || authValidator.Ready() {
 			c.JSON(http.StatusOK, gin.H{"status": "ready"})
@@ -110,7 +118,7 @@ func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator
 	})
 
 	// Prometheus metrics endpoint
-	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	engine.GET("/metrics", gin.WrapH(gocommonmetrics.Handler()))
 
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
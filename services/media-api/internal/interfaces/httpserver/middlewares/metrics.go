@@ -0,0 +1,17 @@
+package middlewares
+
+import (
+	"github.com/gin-gonic/gin"
+
+	"jan-server/services/media-api/internal/infrastructure/metrics"
+)
+
+// MetricsMiddleware records HTTP request count, duration and status metrics.
+// The route template (c.FullPath) is used as endpoint label to bound cardinality.
+func MetricsMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		done := metrics.HTTP.Start()
+		c.Next()
+		done(c.Request.Method, c.FullPath(), c.Writer.Status())
+	}
+}
@@ -25,6 +25,7 @@ func (r *Routes) Register(router gin.IRouter) {
 	group := router.Group("/v1")
 	group.POST("/media", r.handlers.Media.Ingest)
 	group.POST("/media/upload", r.handlers.Media.DirectUpload)
+	group.POST("/media/resolve", r.handlers.Media.Resolve)
 	group.GET("/media/:id", r.handlers.Media.Proxy)
 
 	// Serve static files from local storage if configured
@@ -12,10 +12,14 @@ import (
 
 	"database/sql"
 
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/kms"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
 	"github.com/janhq/jan-server/services/memory-tools/internal/configs"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/embedding"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
 	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/database/repository/memoryrepo"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
 	"github.com/janhq/jan-server/services/memory-tools/internal/interfaces/httpserver/handlers"
 	"github.com/janhq/jan-server/services/memory-tools/internal/interfaces/httpserver/middleware"
 	"github.com/janhq/jan-server/services/memory-tools/internal/interfaces/httpserver/responses"
@@ -26,9 +30,11 @@ import (
 )
 
 type Application struct {
-	server *http.Server
-	db     *gorm.DB
-	sqlDB  *sql.DB
+	server      *http.Server
+	db          *gorm.DB
+	sqlDB       *sql.DB
+	reembedder  *memory.Reembedder
+	maintenance *maintenance.Service
 }
 
 func newApplication(cfg *configs.Config) (*Application, error) {
@@ -44,6 +50,13 @@ func newApplication(cfg *configs.Config) (*Application, error) {
 		return nil, fmt.Errorf("database handle: %w", err)
 	}
 
+	if err := metrics.RegisterDBPool(sqlDB, "memory"); err != nil {
+		return nil, fmt.Errorf("register db pool metrics: %w", err)
+	}
+	if err := metrics.RegisterDBQueries(db, "memory"); err != nil {
+		return nil, fmt.Errorf("register db query metrics: %w", err)
+	}
+
 	if err := db.WithContext(ctx).Raw("SELECT 1").Error; err != nil {
 		return nil, fmt.Errorf("ping database: %w", err)
 	}
@@ -61,28 +74,95 @@ func newApplication(cfg *configs.Config) (*Application, error) {
 		MaxSize:   cfg.EmbeddingCacheMaxSize,
 		TTL:       cfg.EmbeddingCacheTTL,
 	}
+	batchConfig := embedding.BatchConfig{
+		MaxBatchSize: cfg.EmbeddingBatchSize,
+		MaxInflight:  cfg.EmbeddingMaxInflight,
+		MaxQueued:    cfg.EmbeddingMaxQueued,
+	}
 
-	embeddingClient, err := embedding.NewBGE_M3_Client(cfg.EmbeddingServiceURL, cacheConfig)
+	models, err := embeddingModels(cfg)
 	if err != nil {
-		return nil, fmt.Errorf("create embedding client: %w", err)
+		return nil, err
 	}
+	embeddings, err := embedding.NewRegistry(models, cacheConfig, batchConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create embedding clients: %w", err)
+	}
+	embeddingClient := embeddings.Default()
 
 	if cfg.ValidateEmbedding {
 		validateCtx, cancel := context.WithTimeout(ctx, cfg.ValidateEmbeddingTimeout)
 		defer cancel()
 
-		if err := embeddingClient.ValidateServer(validateCtx); err != nil {
+		if err := embeddings.ValidateServers(validateCtx); err != nil {
 			return nil, fmt.Errorf("validate embedding server: %w", err)
 		}
 		log.Info().Msg("Embedding server validated successfully")
 	}
 
-	repo := memoryrepo.NewRepository(db)
-	memoryService := memory.NewService(repo, embeddingClient)
+	keyWrapper, err := kms.NewKeyWrapper(kms.Config{
+		Provider:     cfg.EncryptionKMSProvider,
+		MasterKey:    cfg.EncryptionMasterKey,
+		VaultAddr:    cfg.EncryptionVaultAddr,
+		VaultToken:   cfg.EncryptionVaultToken,
+		VaultKeyName: cfg.EncryptionVaultKeyName,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("encryption at rest: %w", err)
+	}
+	if cfg.EncryptionAtRestEnabled && keyWrapper == nil {
+		return nil, fmt.Errorf("ENCRYPTION_AT_REST_ENABLED requires ENCRYPTION_MASTER_KEY or ENCRYPTION_VAULT_ADDR")
+	}
+	encryptionService, err := encryption.NewService(cfg.EncryptionAtRestEnabled, keyWrapper, db)
+	if err != nil {
+		return nil, fmt.Errorf("encryption at rest: %w", err)
+	}
+	if cfg.EncryptionAtRestEnabled {
+		log.Info().Str("kms", keyWrapper.Name()).Msg("Encrypting memory text at rest")
+	}
+
+	repo := memoryrepo.NewRepository(db, encryptionService)
+	vectorIndexes, err := memoryrepo.NewVectorIndexes(db, memoryrepo.IndexConfig{
+		Type:               strings.ToLower(cfg.VectorIndexType),
+		HNSWM:              cfg.VectorIndexHNSWM,
+		HNSWEfConstruction: cfg.VectorIndexHNSWEfConstruction,
+		IVFFlatLists:       cfg.VectorIndexIVFFlatLists,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("vector indexes: %w", err)
+	}
+	for _, model := range models {
+		if err := vectorIndexes.CheckDimensions(ctx, model.ID, model.Dimension); err != nil {
+			return nil, fmt.Errorf("embedding model %s: %w", model.ID, err)
+		}
+		if err := vectorIndexes.Ensure(ctx, model.ID, model.Dimension); err != nil {
+			log.Warn().Err(err).Str("model", model.ID).Msg("Embedding model has no vector index")
+		}
+	}
+	log.Info().Str("default_model", embeddingClient.Model().ID).Int("models", len(models)).Msg("Embedding models configured")
+
+	memoryService := memory.NewService(repo, embeddings)
+	var reembedder *memory.Reembedder
+	if cfg.ReembedEnabled {
+		reembedder = memory.NewReembedder(repo, embeddings, cfg.ReembedBatchSize, cfg.ReembedInterval)
+	}
 	memoryHandler := handlers.NewMemoryHandler(memoryService)
+	adminHandler := handlers.NewAdminHandler(vectorIndexes, models)
 
 	mux := http.NewServeMux()
+	readiness := health.NewChecker("memory-tools", 3*time.Second)
+	readiness.Register("database", true, health.DatabaseCheck(db))
+	readiness.Register("embedding_server", true, embeddingClient.Health)
+	for _, client := range embeddings.Clients()[1:] {
+		// Only needed to search memories not yet re-embedded
+		readiness.Register("embedding_server:"+client.Model().ID, false, client.Health)
+	}
+	if cfg.EmbeddingCacheType == "redis" {
+		readiness.Register("embedding_cache", false, embeddings.CacheHealth)
+	}
+
 	mux.HandleFunc("/healthz", memoryHandler.HandleHealth)
+	mux.Handle("/readyz", readiness)
 	mux.HandleFunc("/v1/memory/load", memoryHandler.HandleLoad)
 	mux.HandleFunc("/v1/memory/observe", memoryHandler.HandleObserve)
 	mux.HandleFunc("/v1/memory/stats", memoryHandler.HandleStats)
@@ -90,6 +170,7 @@ func newApplication(cfg *configs.Config) (*Application, error) {
 	mux.HandleFunc("/v1/memory/user/upsert", memoryHandler.HandleUserUpsert)
 	mux.HandleFunc("/v1/memory/project/upsert", memoryHandler.HandleProjectUpsert)
 	mux.HandleFunc("/v1/memory/delete", memoryHandler.HandleDelete)
+	mux.HandleFunc("/v1/admin/indexes/rebuild", adminHandler.HandleRebuildIndexes)
 
 	// Prometheus metrics endpoint
 	mux.Handle("/metrics", metrics.Handler())
@@ -118,9 +199,20 @@ func newApplication(cfg *configs.Config) (*Application, error) {
 		})
 	})
 
+	maintenanceService, err := maintenance.FromRedisURL(cfg.RedisURL, cfg.MaintenanceRefreshInterval, log.Logger)
+	if err != nil {
+		return nil, err
+	}
+
 	handler := middleware.TimeoutMiddleware(cfg.RequestTimeout)(mux)
+	// Loading memories and the embedding test only read, despite being POSTs
+	handler = maintenanceService.Middleware("/v1/memory/load", "/v1/embed/test")(handler)
 	handler = middleware.AuthMiddleware(cfg.APIKey)(handler)
 	handler = middleware.RequestIDMiddleware()(handler)
+	handler = metrics.HTTP.Middleware(func(r *http.Request) string {
+		_, pattern := mux.Handler(r)
+		return pattern
+	})(handler)
 
 	server := &http.Server{
 		Addr:         fmt.Sprintf(":%d", cfg.HTTPPort),
@@ -131,15 +223,22 @@ func newApplication(cfg *configs.Config) (*Application, error) {
 	}
 
 	return &Application{
-		server: server,
-		db:     db,
-		sqlDB:  sqlDB,
+		server:      server,
+		db:          db,
+		sqlDB:       sqlDB,
+		reembedder:  reembedder,
+		maintenance: maintenanceService,
 	}, nil
 }
 
 func (a *Application) Start(ctx context.Context) error {
 	log.Info().Msg("Starting Memory Tools Service")
 
+	if a.reembedder != nil {
+		go a.reembedder.Run(ctx)
+	}
+	go a.maintenance.Run(ctx)
+
 	errCh := make(chan error, 1)
 	go func() {
 		log.Info().Str("addr", a.server.Addr).Msg("Memory Tools Service listening")
@@ -170,6 +269,32 @@ func (a *Application) Start(ctx context.Context) error {
 	return nil
 }
 
+// embeddingModels returns the configured embedding models, the default first.
+// The default model is served at EMBEDDING_SERVICE_URL unless EMBEDDING_MODELS
+// lists it with its own URL.
+func embeddingModels(cfg *configs.Config) ([]embedding.Model, error) {
+	models := []embedding.Model{{
+		ID:        cfg.EmbeddingModel,
+		Dimension: cfg.EmbeddingDimension,
+		URL:       strings.TrimRight(cfg.EmbeddingServiceURL, "/"),
+	}}
+	for _, spec := range cfg.EmbeddingModels {
+		if strings.TrimSpace(spec) == "" {
+			continue
+		}
+		model, err := embedding.ParseModel(spec)
+		if err != nil {
+			return nil, err
+		}
+		if model.ID == cfg.EmbeddingModel {
+			models[0] = model
+			continue
+		}
+		models = append(models, model)
+	}
+	return models, nil
+}
+
 func runMigrations(ctx context.Context, db *gorm.DB, dir string) error {
 	entries, err := os.ReadDir(dir)
 	if err != nil {
@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/janhq/jan-server/packages/go-common/logging"
 	"github.com/janhq/jan-server/services/memory-tools/internal/configs"
 	"github.com/rs/zerolog"
 	"github.com/rs/zerolog/log"
@@ -27,6 +28,7 @@ func main() {
 	if cfg.LogFormat == "console" {
 		log.Logger = log.Output(zerolog.ConsoleWriter{Out: os.Stderr})
 	}
+	log.Logger = log.Logger.Hook(logging.TraceHook{})
 
 	app, err := CreateApplication(cfg)
 	if err != nil {
@@ -17,12 +17,33 @@ type Config struct {
 	DBPostgresqlRead1DSN string `env:"DB_POSTGRESQL_READ1_DSN"` // Optional read replica
 
 	EmbeddingServiceURL     string        `env:"EMBEDDING_SERVICE_URL" envDefault:"http://localhost:8091"`
+	EmbeddingModel          string        `env:"EMBEDDING_MODEL" envDefault:"BAAI/bge-m3"` // Default model: new vectors are written with it
+	EmbeddingDimension      int           `env:"EMBEDDING_DIMENSION" envDefault:"1024"`
+	EmbeddingModels         []string      `env:"EMBEDDING_MODELS" envSeparator:","` // Other served models as id|dimension|url
 	EmbeddingCacheType      string        `env:"EMBEDDING_CACHE_TYPE" envDefault:"memory"`
 	EmbeddingCacheTTL       time.Duration `env:"EMBEDDING_CACHE_TTL" envDefault:"1h"`
 	EmbeddingCacheMaxSize   int           `env:"EMBEDDING_CACHE_MAX_SIZE" envDefault:"10000"`
 	EmbeddingCacheRedisURL  string        `env:"EMBEDDING_CACHE_REDIS_URL" envDefault:"redis://redis:6379/3"`
 	EmbeddingCacheKeyPrefix string        `env:"EMBEDDING_CACHE_KEY_PREFIX" envDefault:"emb:"`
 
+	// Requests to the embedding server: texts per request, concurrent requests
+	// and batches queued before requests are refused with 503
+	EmbeddingBatchSize   int `env:"EMBEDDING_BATCH_SIZE" envDefault:"32"`
+	EmbeddingMaxInflight int `env:"EMBEDDING_MAX_INFLIGHT" envDefault:"4"`
+	EmbeddingMaxQueued   int `env:"EMBEDDING_MAX_QUEUED" envDefault:"64"`
+
+	// Re-embedding of vectors left on a previous default model
+	ReembedEnabled   bool          `env:"EMBEDDING_REEMBED_ENABLED" envDefault:"true"`
+	ReembedBatchSize int           `env:"EMBEDDING_REEMBED_BATCH_SIZE" envDefault:"64"`
+	ReembedInterval  time.Duration `env:"EMBEDDING_REEMBED_INTERVAL" envDefault:"1m"`
+
+	// Per-model vector indexes: hnsw or ivfflat. IVFFlat lists of 0 are sized
+	// from the rows when the index is built; rebuild after bulk imports.
+	VectorIndexType               string `env:"VECTOR_INDEX_TYPE" envDefault:"hnsw"`
+	VectorIndexHNSWM              int    `env:"VECTOR_INDEX_HNSW_M" envDefault:"16"`
+	VectorIndexHNSWEfConstruction int    `env:"VECTOR_INDEX_HNSW_EF_CONSTRUCTION" envDefault:"64"`
+	VectorIndexIVFFlatLists       int    `env:"VECTOR_INDEX_IVFFLAT_LISTS" envDefault:"0"`
+
 	ValidateEmbedding        bool          `env:"VALIDATE_EMBEDDING_ON_START" envDefault:"true"`
 	ValidateEmbeddingTimeout time.Duration `env:"VALIDATE_EMBEDDING_TIMEOUT" envDefault:"10s"`
 
@@ -31,6 +52,18 @@ type Config struct {
 
 	APIKey string `env:"MEMORY_TOOLS_API_KEY"`
 
+	// Maintenance mode switched on llm-api, shared through this Redis; off when unset
+	RedisURL                   string        `env:"REDIS_URL"`
+	MaintenanceRefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL" envDefault:"5s"`
+
+	// Encryption at rest of memory text with per-user data keys wrapped by a KMS master key
+	EncryptionAtRestEnabled bool   `env:"ENCRYPTION_AT_REST_ENABLED" envDefault:"false"`
+	EncryptionKMSProvider   string `env:"ENCRYPTION_KMS_PROVIDER" envDefault:"local"` // local or vault
+	EncryptionMasterKey     string `env:"ENCRYPTION_MASTER_KEY"`                      // local: base64-encoded 32-byte key
+	EncryptionVaultAddr     string `env:"ENCRYPTION_VAULT_ADDR"`
+	EncryptionVaultToken    string `env:"ENCRYPTION_VAULT_TOKEN"`
+	EncryptionVaultKeyName  string `env:"ENCRYPTION_VAULT_KEY_NAME" envDefault:"jan-memory-tools"` // Transit key name
+
 	LogLevel  string `env:"LOG_LEVEL" envDefault:"info"`
 	LogFormat string `env:"LOG_FORMAT" envDefault:"console"`
 
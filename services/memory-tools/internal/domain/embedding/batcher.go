@@ -2,147 +2,158 @@ package embedding
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"sync"
-	"time"
+	"sync/atomic"
 
-	"github.com/rs/zerolog/log"
+	"github.com/janhq/jan-server/services/memory-tools/internal/metrics"
 )
 
-// Batcher handles batch processing of embedding requests
-type Batcher struct {
-	client    Client
-	batchSize int
-	timeout   time.Duration
-
-	mu       sync.Mutex
-	queue    []batchItem
-	timer    *time.Timer
-	stopCh   chan struct{}
-	resultCh chan batchResult
+// BatchConfig bounds the requests sent to an embedding server.
+type BatchConfig struct {
+	MaxBatchSize int // Texts per request, at most the server's max_client_batch_size
+	MaxInflight  int // Concurrent requests per server
+	MaxQueued    int // Batches waiting for a request slot before new ones are refused
 }
 
-type batchItem struct {
-	text     string
-	resultCh chan<- batchResult
+// DefaultBatchConfig matches the defaults of text-embeddings-inference.
+func DefaultBatchConfig() BatchConfig {
+	return BatchConfig{MaxBatchSize: 32, MaxInflight: 4, MaxQueued: 64}
 }
 
-type batchResult struct {
-	embedding []float32
-	err       error
+// ErrOverloaded is returned when an embedding server has no room for more
+// work. Callers should back off and retry.
+var ErrOverloaded = errors.New("embedding server overloaded")
+
+// PartialError is returned by Embed when only some texts could be embedded.
+// The vectors of the failed texts are nil.
+type PartialError struct {
+	Failed []int // Indexes of the failed texts
+	Err    error // Error of the first failed batch
 }
 
-// NewBatcher creates a new embedding batcher
-func NewBatcher(client Client, batchSize int, timeout time.Duration) *Batcher {
-	b := &Batcher{
-		client:    client,
-		batchSize: batchSize,
-		timeout:   timeout,
-		queue:     make([]batchItem, 0, batchSize),
-		stopCh:    make(chan struct{}),
-		resultCh:  make(chan batchResult, batchSize),
-	}
+func (e *PartialError) Error() string {
+	return fmt.Sprintf("%d texts could not be embedded: %v", len(e.Failed), e.Err)
+}
 
-	go b.run()
-	return b
+func (e *PartialError) Unwrap() error {
+	return e.Err
 }
 
-// Embed adds a text to the batch queue and returns the embedding
-func (b *Batcher) Embed(ctx context.Context, text string) ([]float32, error) {
-	resultCh := make(chan batchResult, 1)
-
-	b.mu.Lock()
-	b.queue = append(b.queue, batchItem{
-		text:     text,
-		resultCh: resultCh,
-	})
-
-	// Start timer if this is the first item
-	if len(b.queue) == 1 {
-		b.timer = time.AfterFunc(b.timeout, func() {
-			b.flush()
-		})
-	}
+// batcher splits texts into server-sized batches and sends them with bounded
+// concurrency. Batches wait for a request slot while the queue has room and
+// fail with ErrOverloaded once it is full, so bursts push back on callers
+// instead of piling up on the GPU.
+type batcher struct {
+	cfg     BatchConfig
+	slots   chan struct{}
+	waiting atomic.Int64
+	send    func(ctx context.Context, texts []string) ([][]float32, error)
+}
 
-	// Flush if batch is full
-	if len(b.queue) >= b.batchSize {
-		if b.timer != nil {
-			b.timer.Stop()
-		}
-		b.mu.Unlock()
-		b.flush()
-	} else {
-		b.mu.Unlock()
+func newBatcher(cfg BatchConfig, send func(ctx context.Context, texts []string) ([][]float32, error)) *batcher {
+	defaults := DefaultBatchConfig()
+	if cfg.MaxBatchSize <= 0 {
+		cfg.MaxBatchSize = defaults.MaxBatchSize
 	}
-
-	// Wait for result
-	select {
-	case result := <-resultCh:
-		return result.embedding, result.err
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	if cfg.MaxInflight <= 0 {
+		cfg.MaxInflight = defaults.MaxInflight
+	}
+	if cfg.MaxQueued < 0 {
+		cfg.MaxQueued = 0
+	}
+	return &batcher{
+		cfg:   cfg,
+		slots: make(chan struct{}, cfg.MaxInflight),
+		send:  send,
 	}
 }
 
-// flush processes the current batch
-func (b *Batcher) flush() {
-	b.mu.Lock()
-	if len(b.queue) == 0 {
-		b.mu.Unlock()
-		return
+// embed returns a vector per text. Texts of failed batches get no vector and
+// are returned as failed, with the first error.
+func (b *batcher) embed(ctx context.Context, texts []string) ([][]float32, []int, error) {
+	vectors := make([][]float32, len(texts))
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	for start := 0; start < len(texts); start += b.cfg.MaxBatchSize {
+		end := min(start+b.cfg.MaxBatchSize, len(texts))
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			// Batches write disjoint ranges of vectors
+			if err := b.embedBatch(ctx, texts[start:end], vectors[start:end]); err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}()
 	}
+	wg.Wait()
 
-	items := b.queue
-	b.queue = make([]batchItem, 0, b.batchSize)
-	b.mu.Unlock()
-
-	// Extract texts
-	texts := make([]string, len(items))
-	for i, item := range items {
-		texts[i] = item.text
+	var failed []int
+	for i, vector := range vectors {
+		if vector == nil {
+			failed = append(failed, i)
+		}
 	}
+	return vectors, failed, firstErr
+}
 
-	log.Debug().
-		Int("batch_size", len(texts)).
-		Msg("Processing embedding batch")
-
-	// Batch embed
-	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
-	defer cancel()
-
-	embeddings, err := b.client.Embed(ctx, texts)
+// embedBatch embeds one batch into out. A batch the server rejects as invalid
+// is split in halves until the offending texts are isolated, so one bad text
+// does not fail the others.
+func (b *batcher) embedBatch(ctx context.Context, texts []string, out [][]float32) error {
+	if err := b.acquire(ctx); err != nil {
+		return err
+	}
+	vectors, err := b.send(ctx, texts)
+	<-b.slots
 
-	// Send results back
-	for i, item := range items {
-		result := batchResult{err: err}
-		if err == nil && i < len(embeddings) {
-			result.embedding = embeddings[i]
-		}
+	if err == nil {
+		metrics.RecordEmbeddingBatch(len(texts))
+		copy(out, vectors)
+		return nil
+	}
 
-		select {
-		case item.resultCh <- result:
-		default:
-			log.Warn().Msg("Failed to send batch result")
+	var status *StatusError
+	if len(texts) > 1 && errors.As(err, &status) && status.RejectsInput() {
+		mid := len(texts) / 2
+		errLeft := b.embedBatch(ctx, texts[:mid], out[:mid])
+		errRight := b.embedBatch(ctx, texts[mid:], out[mid:])
+		if errLeft != nil {
+			return errLeft
 		}
+		return errRight
 	}
+	return err
 }
 
-// run is the background goroutine that handles batch processing
-func (b *Batcher) run() {
-	ticker := time.NewTicker(b.timeout)
-	defer ticker.Stop()
-
-	for {
-		select {
-		case <-ticker.C:
-			b.flush()
-		case <-b.stopCh:
-			b.flush()
-			return
-		}
+// acquire takes a request slot, waiting in the queue when all are busy.
+func (b *batcher) acquire(ctx context.Context) error {
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	default:
 	}
-}
 
-// Stop stops the batcher
-func (b *Batcher) Stop() {
-	close(b.stopCh)
+	if b.waiting.Add(1) > int64(b.cfg.MaxQueued) {
+		b.waiting.Add(-1)
+		metrics.RecordEmbeddingRejected()
+		return ErrOverloaded
+	}
+	defer b.waiting.Add(-1)
+
+	select {
+	case b.slots <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/rs/zerolog/log"
 
 	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/cache"
+	"github.com/janhq/jan-server/services/memory-tools/internal/metrics"
 )
 
 // Cache interface for embedding storage
@@ -55,6 +56,11 @@ func (c *RedisCache) Set(key string, value []float32, ttl time.Duration) {
 	c.cache.Set(key, value, ttl)
 }
 
+// HealthCheck pings the backing Redis instance.
+func (c *RedisCache) HealthCheck(ctx context.Context) error {
+	return c.cache.HealthCheck(ctx)
+}
+
 // 2. In-Memory LRU Cache (alternative, no Redis required)
 type MemoryCache struct {
 	cache *lru.Cache
@@ -141,16 +147,49 @@ func NewCache(config CacheConfig) (Cache, error) {
 // Client interface and implementation
 
 type Client interface {
+	// Embed returns a vector per text. When only some texts could be
+	// embedded it returns their vectors with a *PartialError.
 	Embed(ctx context.Context, texts []string) ([][]float32, error)
 	EmbedSingle(ctx context.Context, text string) ([]float32, error)
 	EmbedSparse(ctx context.Context, texts []string) ([]SparseEmbedding, error)
 	ValidateServer(ctx context.Context) error
+	// Model returns the embedding model the client serves.
+	Model() Model
 }
 
-type BGE_M3_Client struct {
-	baseURL    string
+// HTTPClient calls an embedding server (Hugging Face text-embeddings-inference
+// API) serving one model.
+type HTTPClient struct {
+	model      Model
 	httpClient *http.Client
 	cache      Cache
+	cacheType  string
+	batcher    *batcher
+}
+
+// StatusError is a non-200 response of the embedding server.
+type StatusError struct {
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("embedding service returned status %d", e.StatusCode)
+}
+
+// Is reports a server refusing work as ErrOverloaded.
+func (e *StatusError) Is(target error) bool {
+	return target == ErrOverloaded &&
+		(e.StatusCode == http.StatusTooManyRequests || e.StatusCode == http.StatusServiceUnavailable)
+}
+
+// RejectsInput reports whether the server refused the request itself, such as
+// a batch over its size limit or a text it cannot embed.
+func (e *StatusError) RejectsInput() bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusRequestEntityTooLarge, http.StatusUnprocessableEntity:
+		return true
+	}
+	return false
 }
 
 type EmbedRequest struct {
@@ -170,31 +209,41 @@ type ModelInfo struct {
 	ModelID string `json:"model_id"`
 }
 
-func NewBGE_M3_Client(baseURL string, cacheConfig CacheConfig) (*BGE_M3_Client, error) {
-	cache, err := NewCache(cacheConfig)
-	if err != nil {
-		return nil, fmt.Errorf("initialize cache: %w", err)
-	}
-
-	return &BGE_M3_Client{
-		baseURL: baseURL,
+func newHTTPClient(model Model, cache Cache, cacheType string, batchConfig BatchConfig) *HTTPClient {
+	c := &HTTPClient{
+		model: model,
 		httpClient: &http.Client{
 			Timeout: 30 * time.Second,
 		},
-		cache: cache,
-	}, nil
+		cache:     cache,
+		cacheType: cacheType,
+	}
+	c.batcher = newBatcher(batchConfig, c.embedBatch)
+	return c
+}
+
+// Model implements Client.
+func (c *HTTPClient) Model() Model {
+	return c.model
+}
+
+// cacheKey scopes cached vectors to the model, so models share one cache.
+func (c *HTTPClient) cacheKey(text string) string {
+	return c.model.ID + ":" + text
 }
 
-func (c *BGE_M3_Client) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+func (c *HTTPClient) Embed(ctx context.Context, texts []string) ([][]float32, error) {
 	// Check cache first
 	cachedResults := make([][]float32, len(texts))
 	uncachedIndices := []int{}
 	uncachedTexts := []string{}
 
 	for i, text := range texts {
-		if cached, found := c.cache.Get(text); found {
+		if cached, found := c.cache.Get(c.cacheKey(text)); found {
 			cachedResults[i] = cached
+			metrics.RecordCacheHit(c.cacheType)
 		} else {
+			metrics.RecordCacheMiss(c.cacheType)
 			uncachedIndices = append(uncachedIndices, i)
 			uncachedTexts = append(uncachedTexts, text)
 		}
@@ -204,9 +253,37 @@ func (c *BGE_M3_Client) Embed(ctx context.Context, texts []string) ([][]float32,
 		return cachedResults, nil
 	}
 
-	// Call BGE-M3 API for uncached items
+	// Call the embedding server for uncached items
+	embeddings, failed, err := c.batcher.embed(ctx, uncachedTexts)
+	if len(failed) == len(texts) {
+		return nil, err
+	}
+
+	// Merge results and cache
+	for i, idx := range uncachedIndices {
+		if embeddings[i] == nil {
+			continue
+		}
+		if len(embeddings[i]) != c.model.Dimension {
+			return nil, fmt.Errorf("model %s returned %d dimensions, expected %d", c.model.ID, len(embeddings[i]), c.model.Dimension)
+		}
+		cachedResults[idx] = embeddings[i]
+		c.cache.Set(c.cacheKey(uncachedTexts[i]), embeddings[i], 1*time.Hour)
+	}
+
+	if len(failed) > 0 {
+		for i, f := range failed {
+			failed[i] = uncachedIndices[f]
+		}
+		return cachedResults, &PartialError{Failed: failed, Err: err}
+	}
+	return cachedResults, nil
+}
+
+// embedBatch sends one batch of texts to the embedding server.
+func (c *HTTPClient) embedBatch(ctx context.Context, texts []string) ([][]float32, error) {
 	reqBody := EmbedRequest{
-		Inputs:    uncachedTexts,
+		Inputs:    texts,
 		Normalize: true,
 		Truncate:  true,
 	}
@@ -216,37 +293,35 @@ func (c *BGE_M3_Client) Embed(ctx context.Context, texts []string) ([][]float32,
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/embed", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.model.URL+"/embed", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
 	req.Header.Set("Content-Type", "application/json")
 
+	start := time.Now()
 	resp, err := c.httpClient.Do(req)
+	metrics.RecordEmbedding(time.Since(start).Seconds())
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("embedding service returned status %d", resp.StatusCode)
+		return nil, &StatusError{StatusCode: resp.StatusCode}
 	}
 
 	var embeddings EmbedResponse
 	if err := json.NewDecoder(resp.Body).Decode(&embeddings); err != nil {
 		return nil, fmt.Errorf("decode response: %w", err)
 	}
-
-	// Merge results and cache
-	for i, idx := range uncachedIndices {
-		cachedResults[idx] = embeddings[i]
-		c.cache.Set(uncachedTexts[i], embeddings[i], 1*time.Hour)
+	if len(embeddings) != len(texts) {
+		return nil, fmt.Errorf("embedding service returned %d vectors for %d texts", len(embeddings), len(texts))
 	}
-
-	return cachedResults, nil
+	return embeddings, nil
 }
 
-func (c *BGE_M3_Client) EmbedSingle(ctx context.Context, text string) ([]float32, error) {
+func (c *HTTPClient) EmbedSingle(ctx context.Context, text string) ([]float32, error) {
 	embeddings, err := c.Embed(ctx, []string{text})
 	if err != nil {
 		return nil, err
@@ -254,7 +329,7 @@ func (c *BGE_M3_Client) EmbedSingle(ctx context.Context, text string) ([]float32
 	return embeddings[0], nil
 }
 
-func (c *BGE_M3_Client) EmbedSparse(ctx context.Context, texts []string) ([]SparseEmbedding, error) {
+func (c *HTTPClient) EmbedSparse(ctx context.Context, texts []string) ([]SparseEmbedding, error) {
 	reqBody := EmbedRequest{
 		Inputs:   texts,
 		Truncate: true,
@@ -265,7 +340,7 @@ func (c *BGE_M3_Client) EmbedSparse(ctx context.Context, texts []string) ([]Spar
 		return nil, fmt.Errorf("marshal request: %w", err)
 	}
 
-	req, err := http.NewRequestWithContext(ctx, "POST", c.baseURL+"/embed_sparse", bytes.NewBuffer(jsonData))
+	req, err := http.NewRequestWithContext(ctx, "POST", c.model.URL+"/embed_sparse", bytes.NewBuffer(jsonData))
 	if err != nil {
 		return nil, fmt.Errorf("create request: %w", err)
 	}
@@ -307,16 +382,33 @@ func (c *BGE_M3_Client) EmbedSparse(ctx context.Context, texts []string) ([]Spar
 	return result, nil
 }
 
-func (c *BGE_M3_Client) ValidateServer(ctx context.Context) error {
+// Health checks the embedding server health endpoint without running a test embedding.
+func (c *HTTPClient) Health(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.model.URL+"/health", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("embedding server unreachable: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("embedding server returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+func (c *HTTPClient) ValidateServer(ctx context.Context) error {
 	// 1. Check health endpoint
-	resp, err := c.httpClient.Get(c.baseURL + "/health")
+	resp, err := c.httpClient.Get(c.model.URL + "/health")
 	if err != nil || resp.StatusCode != 200 {
 		return fmt.Errorf("embedding server not healthy")
 	}
 	resp.Body.Close()
 
 	// 2. Check model info
-	resp, err = c.httpClient.Get(c.baseURL + "/info")
+	resp, err = c.httpClient.Get(c.model.URL + "/info")
 	if err != nil {
 		return fmt.Errorf("failed to get model info: %w", err)
 	}
@@ -327,9 +419,9 @@ func (c *BGE_M3_Client) ValidateServer(ctx context.Context) error {
 		return fmt.Errorf("failed to decode model info: %w", err)
 	}
 
-	// 3. Verify it's BGE-M3
-	if info.ModelID != "BAAI/bge-m3" {
-		log.Warn().Str("model", info.ModelID).Msg("Expected BGE-M3, got different model")
+	// 3. Verify the server runs the configured model
+	if info.ModelID != c.model.ID {
+		log.Warn().Str("model", info.ModelID).Str("expected", c.model.ID).Msg("Embedding server reports a different model")
 	}
 
 	// 4. Test embedding
@@ -337,8 +429,8 @@ func (c *BGE_M3_Client) ValidateServer(ctx context.Context) error {
 	if err != nil {
 		return fmt.Errorf("test embedding failed: %w", err)
 	}
-	if len(embeddings) == 0 || len(embeddings[0]) != 1024 {
-		return fmt.Errorf("expected 1024 dimensions, got %d", len(embeddings[0]))
+	if len(embeddings) == 0 || len(embeddings[0]) != c.model.Dimension {
+		return fmt.Errorf("expected %d dimensions for %s", c.model.Dimension, c.model.ID)
 	}
 
 	return nil
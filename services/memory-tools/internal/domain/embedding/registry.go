@@ -0,0 +1,112 @@
+package embedding
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Model is an embedding model and the server that serves it.
+type Model struct {
+	ID        string // Model id stored next to each vector, e.g. BAAI/bge-m3
+	Dimension int
+	URL       string
+}
+
+// ParseModel parses a model spec of the form "id|dimension|url".
+func ParseModel(spec string) (Model, error) {
+	parts := strings.Split(spec, "|")
+	if len(parts) != 3 {
+		return Model{}, fmt.Errorf("embedding model %q: expected id|dimension|url", spec)
+	}
+	dimension, err := strconv.Atoi(strings.TrimSpace(parts[1]))
+	if err != nil || dimension <= 0 {
+		return Model{}, fmt.Errorf("embedding model %q: invalid dimension", spec)
+	}
+	model := Model{
+		ID:        strings.TrimSpace(parts[0]),
+		Dimension: dimension,
+		URL:       strings.TrimRight(strings.TrimSpace(parts[2]), "/"),
+	}
+	if model.ID == "" || model.URL == "" {
+		return Model{}, fmt.Errorf("embedding model %q: id and url are required", spec)
+	}
+	return model, nil
+}
+
+// Registry holds a client per configured embedding model. New vectors are
+// written with the default model; vectors of the other models stay searchable
+// until they are re-embedded with the default one.
+type Registry struct {
+	clients []*HTTPClient // Default first
+	byID    map[string]*HTTPClient
+	cache   Cache
+}
+
+// NewRegistry creates a client per model, sharing one cache. The first model
+// is the default. Each model's server gets its own batch limits.
+func NewRegistry(models []Model, cacheConfig CacheConfig, batchConfig BatchConfig) (*Registry, error) {
+	if len(models) == 0 {
+		return nil, fmt.Errorf("no embedding model configured")
+	}
+	cache, err := NewCache(cacheConfig)
+	if err != nil {
+		return nil, fmt.Errorf("initialize cache: %w", err)
+	}
+
+	registry := &Registry{byID: make(map[string]*HTTPClient, len(models)), cache: cache}
+	for _, model := range models {
+		if _, ok := registry.byID[model.ID]; ok {
+			return nil, fmt.Errorf("embedding model %s configured twice", model.ID)
+		}
+		client := newHTTPClient(model, cache, cacheConfig.Type, batchConfig)
+		registry.clients = append(registry.clients, client)
+		registry.byID[model.ID] = client
+	}
+	return registry, nil
+}
+
+// Default returns the client of the model new vectors are written with.
+func (r *Registry) Default() *HTTPClient {
+	return r.clients[0]
+}
+
+// Clients returns the clients of all models, the default first.
+func (r *Registry) Clients() []*HTTPClient {
+	return r.clients
+}
+
+// Get returns the client of a model.
+func (r *Registry) Get(modelID string) (*HTTPClient, bool) {
+	client, ok := r.byID[modelID]
+	return client, ok
+}
+
+// Models returns all configured models, the default first.
+func (r *Registry) Models() []Model {
+	models := make([]Model, len(r.clients))
+	for i, client := range r.clients {
+		models[i] = client.model
+	}
+	return models
+}
+
+// ValidateServers validates the server of every model.
+func (r *Registry) ValidateServers(ctx context.Context) error {
+	for _, client := range r.clients {
+		if err := client.ValidateServer(ctx); err != nil {
+			return fmt.Errorf("%s: %w", client.model.ID, err)
+		}
+	}
+	return nil
+}
+
+// CacheHealth pings the embedding cache when it is backed by a remote store.
+// In-memory and no-op caches are always healthy.
+func (r *Registry) CacheHealth(ctx context.Context) error {
+	if checker, ok := r.cache.(interface{ HealthCheck(context.Context) error }); ok {
+		return checker.HealthCheck(ctx)
+	}
+	return nil
+}
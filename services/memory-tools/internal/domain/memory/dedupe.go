@@ -0,0 +1,38 @@
+package memory
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// ContentHash identifies a memory text regardless of case and whitespace.
+// Stores keep one live memory per owner and hash, so observing the same
+// conversation twice does not duplicate its memories. A non-nil key, the
+// owner's hash key while encryption at rest is on, makes it an HMAC so the
+// stored hash can't be matched against guessed texts.
+func ContentHash(key []byte, text string) string {
+	normalized := strings.ToLower(strings.Join(strings.Fields(text), " "))
+	if key == nil {
+		sum := sha256.Sum256([]byte(normalized))
+		return hex.EncodeToString(sum[:])
+	}
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(normalized))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// observedItemID returns the idempotency key of an observed message: the
+// caller's item ID, or one derived from the message for callers that send
+// none, keyed like ContentHash.
+func observedItemID(item ConversationItem, key []byte) string {
+	if item.ItemID != "" {
+		return item.ItemID
+	}
+	prefix := "sha256:"
+	if key != nil {
+		prefix = "hmac-sha256:"
+	}
+	return prefix + ContentHash(key, item.Role+"\n"+item.Content)
+}
@@ -7,16 +7,17 @@ import (
 
 // UserMemoryItem represents a user's personal memory item
 type UserMemoryItem struct {
-	ID        string    `json:"id"`
-	UserID    string    `json:"user_id"`
-	Scope     string    `json:"scope"` // "core", "preference", "context"
-	Key       string    `json:"key"`
-	Text      string    `json:"text"`
-	Score     int       `json:"score"` // Importance: 1-5
-	Embedding []float32 `json:"-"`
-	IsDeleted bool      `json:"-"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string    `json:"id"`
+	UserID         string    `json:"user_id"`
+	Scope          string    `json:"scope"` // "core", "preference", "context"
+	Key            string    `json:"key"`
+	Text           string    `json:"text"`
+	Score          int       `json:"score"` // Importance: 1-5
+	Embedding      []float32 `json:"-"`
+	EmbeddingModel string    `json:"-"` // Model that produced Embedding
+	IsDeleted      bool      `json:"-"`
+	CreatedAt      time.Time `json:"created_at"`
+	UpdatedAt      time.Time `json:"updated_at"`
 
 	// Computed fields
 	Similarity float32 `json:"similarity,omitempty" db:"-"`
@@ -31,6 +32,7 @@ type ProjectFact struct {
 	Text                 string    `json:"text"`
 	Confidence           float32   `json:"confidence"` // 0.0-1.0
 	Embedding            []float32 `json:"-"`
+	EmbeddingModel       string    `json:"-"` // Model that produced Embedding
 	SourceConversationID string    `json:"source_conversation_id"`
 	IsDeleted            bool      `json:"-"`
 	CreatedAt            time.Time `json:"created_at"`
@@ -50,6 +52,7 @@ type EpisodicEvent struct {
 	Text           string    `json:"text"`
 	Kind           string    `json:"kind"` // "interaction", "decision", "milestone"
 	Embedding      []float32 `json:"-"`
+	EmbeddingModel string    `json:"-"` // Model that produced Embedding
 	IsDeleted      bool      `json:"-"`
 	CreatedAt      time.Time `json:"created_at"`
 
@@ -61,7 +64,8 @@ type EpisodicEvent struct {
 type ConversationItem struct {
 	ID             string    `json:"id"`
 	ConversationID string    `json:"conversation_id"`
-	Role           string    `json:"role"` // "user", "assistant", "system"
+	ItemID         string    `json:"item_id,omitempty"` // Caller's item ID; each item is observed once per conversation
+	Role           string    `json:"role"`              // "user", "assistant", "system"
 	Content        string    `json:"content"`
 	ToolCalls      string    `json:"tool_calls,omitempty"` // JSON array
 	CreatedAt      time.Time `json:"created_at"`
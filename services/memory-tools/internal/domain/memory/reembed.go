@@ -0,0 +1,97 @@
+package memory
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/janhq/jan-server/services/memory-tools/internal/domain/embedding"
+	"github.com/rs/zerolog/log"
+)
+
+// Reembedder moves stored memories to the default embedding model after it
+// changes, a batch per kind at a time. Replicas may run it concurrently: a
+// memory re-embedded twice ends up with the same vector.
+type Reembedder struct {
+	repo       Repository
+	embeddings *embedding.Registry
+	batchSize  int
+	interval   time.Duration
+}
+
+// NewReembedder creates a re-embedding worker.
+func NewReembedder(repo Repository, embeddings *embedding.Registry, batchSize int, interval time.Duration) *Reembedder {
+	return &Reembedder{
+		repo:       repo,
+		embeddings: embeddings,
+		batchSize:  batchSize,
+		interval:   interval,
+	}
+}
+
+// Run re-embeds stale memories until ctx is done. It works through batches
+// back to back and waits for the interval once nothing is left.
+func (r *Reembedder) Run(ctx context.Context) {
+	model := r.embeddings.Default().Model().ID
+	log.Info().Str("model", model).Msg("Re-embedding worker started")
+
+	for {
+		moved := 0
+		for _, kind := range EmbeddingKinds {
+			n, err := r.reembedBatch(ctx, kind)
+			if err != nil {
+				if ctx.Err() != nil {
+					return
+				}
+				log.Warn().Err(err).Str("kind", string(kind)).Msg("Re-embedding batch failed")
+				continue
+			}
+			moved += n
+		}
+		if moved > 0 {
+			log.Info().Int("memories", moved).Str("model", model).Msg("Re-embedded memories")
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(r.interval):
+		}
+	}
+}
+
+// reembedBatch re-embeds up to one batch of memories of a kind and returns how many it moved.
+func (r *Reembedder) reembedBatch(ctx context.Context, kind EmbeddingKind) (int, error) {
+	client := r.embeddings.Default()
+	model := client.Model().ID
+
+	targets, err := r.repo.ListStaleEmbeddings(ctx, kind, model, r.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("list stale embeddings: %w", err)
+	}
+	if len(targets) == 0 {
+		return 0, nil
+	}
+
+	texts := make([]string, len(targets))
+	for i, target := range targets {
+		texts[i] = target.Text
+	}
+	vectors, err := embedAvailable(ctx, client, texts)
+	if err != nil {
+		return 0, fmt.Errorf("embed: %w", err)
+	}
+
+	moved := 0
+	for i, target := range targets {
+		if vectors[i] == nil {
+			continue
+		}
+		if err := r.repo.UpdateEmbedding(ctx, kind, target.ID, model, vectors[i]); err != nil {
+			return moved, fmt.Errorf("update embedding %s: %w", target.ID, err)
+		}
+		moved++
+	}
+	return moved, nil
+}
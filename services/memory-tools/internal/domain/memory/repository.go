@@ -10,21 +10,50 @@ type Repository interface {
 	GetUserMemoryItems(ctx context.Context, userID string) ([]UserMemoryItem, error)
 	UpsertUserMemoryItem(ctx context.Context, item *UserMemoryItem) (string, error)
 	DeleteUserMemoryItem(ctx context.Context, id string) error
-	SearchUserMemory(ctx context.Context, userID string, queryEmbedding []float32, limit int, minSimilarity float32) ([]UserMemoryItem, error)
+	SearchUserMemory(ctx context.Context, userID, model string, queryEmbedding []float32, limit int, minSimilarity float32) ([]UserMemoryItem, error)
 
 	// Project Facts
 	GetProjectFacts(ctx context.Context, projectID string) ([]ProjectFact, error)
 	UpsertProjectFact(ctx context.Context, fact *ProjectFact) (string, error)
 	DeleteProjectFact(ctx context.Context, id string) error
-	SearchProjectFacts(ctx context.Context, projectID string, queryEmbedding []float32, limit int, minSimilarity float32) ([]ProjectFact, error)
+	SearchProjectFacts(ctx context.Context, projectID, model string, queryEmbedding []float32, limit int, minSimilarity float32) ([]ProjectFact, error)
 
 	// Episodic Events
 	GetEpisodicEvents(ctx context.Context, userID string, limit int) ([]EpisodicEvent, error)
 	CreateEpisodicEvent(ctx context.Context, event *EpisodicEvent) error
 	DeleteEpisodicEvent(ctx context.Context, id string) error
-	SearchEpisodicEvents(ctx context.Context, userID string, queryEmbedding []float32, limit int, minSimilarity float32) ([]EpisodicEvent, error)
+	SearchEpisodicEvents(ctx context.Context, userID, model string, queryEmbedding []float32, limit int, minSimilarity float32) ([]EpisodicEvent, error)
 
 	// Conversation Items
+	// CreateConversationItem does nothing when the conversation already holds
+	// an item with the same ItemID
 	CreateConversationItem(ctx context.Context, item *ConversationItem) error
+	// FindObservedItemIDs returns which of itemIDs the conversation holds
+	FindObservedItemIDs(ctx context.Context, conversationID string, itemIDs []string) (map[string]bool, error)
+	// ConversationHashKey returns the key for hashing the conversation's
+	// messages, or nil while they are stored in the clear
+	ConversationHashKey(ctx context.Context, conversationID string) ([]byte, error)
 	GetConversationItems(ctx context.Context, conversationID string) ([]ConversationItem, error)
+
+	// Re-embedding
+	ListStaleEmbeddings(ctx context.Context, kind EmbeddingKind, model string, limit int) ([]EmbeddingTarget, error)
+	UpdateEmbedding(ctx context.Context, kind EmbeddingKind, id, model string, embedding []float32) error
+}
+
+// EmbeddingKind names a store of embedded memories.
+type EmbeddingKind string
+
+const (
+	EmbeddingKindUserMemory    EmbeddingKind = "user_memory"
+	EmbeddingKindProjectFact   EmbeddingKind = "project_fact"
+	EmbeddingKindEpisodicEvent EmbeddingKind = "episodic_event"
+)
+
+// EmbeddingKinds lists every store of embedded memories.
+var EmbeddingKinds = []EmbeddingKind{EmbeddingKindUserMemory, EmbeddingKindProjectFact, EmbeddingKindEpisodicEvent}
+
+// EmbeddingTarget is a memory whose vector must be recomputed.
+type EmbeddingTarget struct {
+	ID   string
+	Text string
 }
@@ -3,25 +3,27 @@ package memory
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/embedding"
+	"github.com/janhq/jan-server/services/memory-tools/internal/metrics"
 	"github.com/rs/zerolog/log"
 )
 
 // Service handles memory operations
 type Service struct {
-	repo            Repository
-	embeddingClient embedding.Client
+	repo       Repository
+	embeddings *embedding.Registry
 }
 
 // NewService creates a new memory service
-func NewService(repo Repository, embeddingClient embedding.Client) *Service {
+func NewService(repo Repository, embeddings *embedding.Registry) *Service {
 	return &Service{
-		repo:            repo,
-		embeddingClient: embeddingClient,
+		repo:       repo,
+		embeddings: embeddings,
 	}
 }
 
@@ -41,29 +43,55 @@ func (s *Service) Load(ctx context.Context, req MemoryLoadRequest) (*MemoryLoadR
 		req.Options.MinSimilarity = 0.5
 	}
 
-	// Embed the query
-	queryEmbedding, err := s.embeddingClient.EmbedSingle(ctx, req.Query)
-	if err != nil {
-		return nil, fmt.Errorf("embed query: %w", err)
-	}
+	// Search with every configured model: memories embedded before the default
+	// model changed stay searchable until they are re-embedded.
+	var userMemory []UserMemoryItem
+	var projectFacts []ProjectFact
+	var episodicEvents []EpisodicEvent
+	for i, client := range s.embeddings.Clients() {
+		model := client.Model().ID
+		queryEmbedding, err := client.EmbedSingle(ctx, req.Query)
+		if err != nil {
+			if i == 0 {
+				return nil, fmt.Errorf("embed query: %w", err)
+			}
+			log.Warn().Err(err).Str("model", model).Msg("Skipping memories of unavailable embedding model")
+			continue
+		}
 
-	log.Debug().
-		Str("user_id", req.UserID).
-		Str("query", req.Query).
-		Int("embedding_dim", len(queryEmbedding)).
-		Msg("Query embedded successfully")
-
-	// Search user memory
-	userMemory, err := s.repo.SearchUserMemory(
-		ctx,
-		req.UserID,
-		queryEmbedding,
-		req.Options.MaxUserItems,
-		req.Options.MinSimilarity,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("search user memory: %w", err)
+		log.Debug().
+			Str("user_id", req.UserID).
+			Str("query", req.Query).
+			Str("model", model).
+			Int("embedding_dim", len(queryEmbedding)).
+			Msg("Query embedded successfully")
+
+		// Search user memory
+		items, err := s.repo.SearchUserMemory(ctx, req.UserID, model, queryEmbedding, req.Options.MaxUserItems, req.Options.MinSimilarity)
+		if err != nil {
+			return nil, fmt.Errorf("search user memory: %w", err)
+		}
+		userMemory = append(userMemory, items...)
+
+		// Search project facts if project_id provided
+		if req.ProjectID != "" {
+			facts, err := s.repo.SearchProjectFacts(ctx, req.ProjectID, model, queryEmbedding, req.Options.MaxProjectItems, req.Options.MinSimilarity)
+			if err != nil {
+				return nil, fmt.Errorf("search project facts: %w", err)
+			}
+			projectFacts = append(projectFacts, facts...)
+		}
+
+		// Search episodic events
+		events, err := s.repo.SearchEpisodicEvents(ctx, req.UserID, model, queryEmbedding, req.Options.MaxEpisodicItems, req.Options.MinSimilarity)
+		if err != nil {
+			return nil, fmt.Errorf("search episodic events: %w", err)
+		}
+		episodicEvents = append(episodicEvents, events...)
 	}
+	userMemory = topBySimilarity(userMemory, req.Options.MaxUserItems, func(item UserMemoryItem) float32 { return item.Similarity })
+	projectFacts = topBySimilarity(projectFacts, req.Options.MaxProjectItems, func(fact ProjectFact) float32 { return fact.Similarity })
+	episodicEvents = topBySimilarity(episodicEvents, req.Options.MaxEpisodicItems, func(event EpisodicEvent) float32 { return event.Similarity })
 
 	if len(userMemory) == 0 {
 		allUserMemory, err := s.repo.GetUserMemoryItems(ctx, req.UserID)
@@ -75,43 +103,16 @@ func (s *Service) Load(ctx context.Context, req MemoryLoadRequest) (*MemoryLoadR
 		}
 	}
 
-	// Search project facts if project_id provided
-	var projectFacts []ProjectFact
-	if req.ProjectID != "" {
-		projectFacts, err = s.repo.SearchProjectFacts(
-			ctx,
-			req.ProjectID,
-			queryEmbedding,
-			req.Options.MaxProjectItems,
-			req.Options.MinSimilarity,
-		)
-		if err != nil {
-			return nil, fmt.Errorf("search project facts: %w", err)
-		}
-
-		if len(projectFacts) == 0 {
-			allFacts, err := s.repo.GetProjectFacts(ctx, req.ProjectID)
-			if err == nil && len(allFacts) > 0 {
-				if req.Options.MaxProjectItems > 0 && len(allFacts) > req.Options.MaxProjectItems {
-					allFacts = allFacts[:req.Options.MaxProjectItems]
-				}
-				projectFacts = allFacts
+	if req.ProjectID != "" && len(projectFacts) == 0 {
+		allFacts, err := s.repo.GetProjectFacts(ctx, req.ProjectID)
+		if err == nil && len(allFacts) > 0 {
+			if req.Options.MaxProjectItems > 0 && len(allFacts) > req.Options.MaxProjectItems {
+				allFacts = allFacts[:req.Options.MaxProjectItems]
 			}
+			projectFacts = allFacts
 		}
 	}
 
-	// Search episodic events
-	episodicEvents, err := s.repo.SearchEpisodicEvents(
-		ctx,
-		req.UserID,
-		queryEmbedding,
-		req.Options.MaxEpisodicItems,
-		req.Options.MinSimilarity,
-	)
-	if err != nil {
-		return nil, fmt.Errorf("search episodic events: %w", err)
-	}
-
 	if len(episodicEvents) == 0 {
 		allEvents, err := s.repo.GetEpisodicEvents(ctx, req.UserID, req.Options.MaxEpisodicItems)
 		if err == nil && len(allEvents) > 0 {
@@ -161,16 +162,46 @@ func (s *Service) Load(ctx context.Context, req MemoryLoadRequest) (*MemoryLoadR
 	}, nil
 }
 
-// Observe stores conversation and extracts memories
+// Observe stores conversation and extracts memories. Messages already
+// observed in the conversation are skipped, so retried and replayed
+// observations are no-ops; they are recorded only once extraction succeeded,
+// so a failed observation can be retried.
 func (s *Service) Observe(ctx context.Context, req MemoryObserveRequest) error {
-	// Store conversation items
-	for _, msg := range req.Messages {
-		msg.ConversationID = req.ConversationID
-		if err := s.repo.CreateConversationItem(ctx, &msg); err != nil {
-			log.Error().Err(err).Msg("Failed to store conversation item")
-			// Continue processing even if storage fails
+	hashKey, err := s.repo.ConversationHashKey(ctx, req.ConversationID)
+	if err != nil {
+		return fmt.Errorf("conversation hash key: %w", err)
+	}
+	itemIDs := make([]string, len(req.Messages))
+	for i, msg := range req.Messages {
+		itemIDs[i] = observedItemID(msg, hashKey)
+	}
+	observed, err := s.repo.FindObservedItemIDs(ctx, req.ConversationID, itemIDs)
+	if err != nil {
+		return fmt.Errorf("find observed items: %w", err)
+	}
+
+	fresh := make([]ConversationItem, 0, len(req.Messages))
+	for i, msg := range req.Messages {
+		if observed[itemIDs[i]] {
+			continue
 		}
+		// Repeated within the request
+		observed[itemIDs[i]] = true
+		msg.ConversationID = req.ConversationID
+		msg.ItemID = itemIDs[i]
+		fresh = append(fresh, msg)
+	}
+	if skipped := len(req.Messages) - len(fresh); skipped > 0 {
+		metrics.RecordObserveDuplicates(skipped)
+		log.Info().
+			Str("conversation_id", req.ConversationID).
+			Int("skipped", skipped).
+			Msg("Skipping messages observed before")
+	}
+	if len(fresh) == 0 {
+		return nil
 	}
+	req.Messages = fresh
 
 	// Extract memory actions from conversation
 	memoryAction, err := s.extractMemoryActions(ctx, req)
@@ -183,6 +214,14 @@ func (s *Service) Observe(ctx context.Context, req MemoryObserveRequest) error {
 		return fmt.Errorf("process memory additions: %w", err)
 	}
 
+	// Store conversation items
+	for _, msg := range req.Messages {
+		if err := s.repo.CreateConversationItem(ctx, &msg); err != nil {
+			log.Error().Err(err).Msg("Failed to store conversation item")
+			// Continue processing even if storage fails
+		}
+	}
+
 	// Process deletions
 	for _, itemID := range memoryAction.Delete {
 		// Try to delete from all tables (soft delete)
@@ -278,7 +317,8 @@ func (s *Service) processMemoryAdditions(ctx context.Context, req MemoryObserveR
 	}
 
 	// Batch embed all texts
-	embeddings, err := s.embeddingClient.Embed(ctx, textsToEmbed)
+	client := s.embeddings.Default()
+	embeddings, err := embedAvailable(ctx, client, textsToEmbed)
 	if err != nil {
 		return fmt.Errorf("batch embed: %w", err)
 	}
@@ -290,17 +330,22 @@ func (s *Service) processMemoryAdditions(ctx context.Context, req MemoryObserveR
 	// Process embeddings and store
 	embeddingIndex := 0
 
-	// Store user memory items
+	// Store user memory items; texts that could not be embedded are skipped
 	for _, item := range additions.UserMemory {
+		vector := embeddings[embeddingIndex]
+		embeddingIndex++
+		if vector == nil {
+			continue
+		}
 		userItem := &UserMemoryItem{
-			UserID:    req.UserID,
-			Scope:     item.Scope,
-			Key:       item.Key,
-			Text:      item.Text,
-			Score:     importanceToScore(item.Importance),
-			Embedding: embeddings[embeddingIndex],
+			UserID:         req.UserID,
+			Scope:          item.Scope,
+			Key:            item.Key,
+			Text:           item.Text,
+			Score:          importanceToScore(item.Importance),
+			Embedding:      vector,
+			EmbeddingModel: client.Model().ID,
 		}
-		embeddingIndex++
 
 		if _, err := s.repo.UpsertUserMemoryItem(ctx, userItem); err != nil {
 			log.Error().Err(err).Msg("Failed to store user memory item")
@@ -309,16 +354,21 @@ func (s *Service) processMemoryAdditions(ctx context.Context, req MemoryObserveR
 
 	// Store project facts
 	for _, fact := range additions.ProjectMemory {
+		vector := embeddings[embeddingIndex]
+		embeddingIndex++
+		if vector == nil {
+			continue
+		}
 		projectFact := &ProjectFact{
 			ProjectID:            req.ProjectID,
 			Kind:                 fact.Kind,
 			Title:                fact.Title,
 			Text:                 fact.Text,
 			Confidence:           fact.Confidence,
-			Embedding:            embeddings[embeddingIndex],
+			Embedding:            vector,
+			EmbeddingModel:       client.Model().ID,
 			SourceConversationID: req.ConversationID,
 		}
-		embeddingIndex++
 
 		if _, err := s.repo.UpsertProjectFact(ctx, projectFact); err != nil {
 			log.Error().Err(err).Msg("Failed to store project fact")
@@ -327,6 +377,11 @@ func (s *Service) processMemoryAdditions(ctx context.Context, req MemoryObserveR
 
 	// Store episodic events
 	for _, event := range additions.Episodic {
+		vector := embeddings[embeddingIndex]
+		embeddingIndex++
+		if vector == nil {
+			continue
+		}
 		episodicEvent := &EpisodicEvent{
 			UserID:         req.UserID,
 			ProjectID:      req.ProjectID,
@@ -334,9 +389,9 @@ func (s *Service) processMemoryAdditions(ctx context.Context, req MemoryObserveR
 			Time:           req.Messages[len(req.Messages)-1].CreatedAt,
 			Text:           event.Text,
 			Kind:           event.Kind,
-			Embedding:      embeddings[embeddingIndex],
+			Embedding:      vector,
+			EmbeddingModel: client.Model().ID,
 		}
-		embeddingIndex++
 
 		if err := s.repo.CreateEpisodicEvent(ctx, episodicEvent); err != nil {
 			log.Error().Err(err).Msg("Failed to store episodic event")
@@ -346,6 +401,35 @@ func (s *Service) processMemoryAdditions(ctx context.Context, req MemoryObserveR
 	return nil
 }
 
+// embedAvailable embeds texts, skipping the ones that could not be embedded:
+// their vectors are nil. It fails only when no text was embedded.
+func embedAvailable(ctx context.Context, client embedding.Client, texts []string) ([][]float32, error) {
+	vectors, err := client.Embed(ctx, texts)
+	var partial *embedding.PartialError
+	if errors.As(err, &partial) {
+		log.Warn().
+			Err(partial.Err).
+			Int("failed", len(partial.Failed)).
+			Int("total", len(texts)).
+			Str("model", client.Model().ID).
+			Msg("Skipping texts that could not be embedded")
+		return vectors, nil
+	}
+	return vectors, err
+}
+
+// topBySimilarity returns at most limit items, most similar first. Results
+// from different embedding models are merged this way.
+func topBySimilarity[T any](items []T, limit int, similarity func(T) float32) []T {
+	sort.SliceStable(items, func(i, j int) bool {
+		return similarity(items[i]) > similarity(items[j])
+	})
+	if limit > 0 && len(items) > limit {
+		items = items[:limit]
+	}
+	return items
+}
+
 // Helper function to convert importance string to score
 func importanceToScore(importance string) int {
 	switch strings.ToLower(importance) {
@@ -496,7 +580,8 @@ func (s *Service) UpsertUserMemories(ctx context.Context, req UserMemoryUpsertRe
 	}
 
 	// Batch embed all texts
-	embeddings, err := s.embeddingClient.Embed(ctx, texts)
+	client := s.embeddings.Default()
+	embeddings, err := embedAvailable(ctx, client, texts)
 	if err != nil {
 		return nil, fmt.Errorf("embed texts: %w", err)
 	}
@@ -508,13 +593,17 @@ func (s *Service) UpsertUserMemories(ctx context.Context, req UserMemoryUpsertRe
 
 	// Upsert each item
 	for i, item := range req.Items {
+		if embeddings[i] == nil {
+			continue
+		}
 		userItem := &UserMemoryItem{
-			UserID:    req.UserID,
-			Scope:     item.Scope,
-			Key:       item.Key,
-			Text:      item.Text,
-			Score:     importanceToScore(item.Importance),
-			Embedding: embeddings[i],
+			UserID:         req.UserID,
+			Scope:          item.Scope,
+			Key:            item.Key,
+			Text:           item.Text,
+			Score:          importanceToScore(item.Importance),
+			Embedding:      embeddings[i],
+			EmbeddingModel: client.Model().ID,
 		}
 
 		id, err := s.repo.UpsertUserMemoryItem(ctx, userItem)
@@ -545,7 +634,8 @@ func (s *Service) UpsertProjectFacts(ctx context.Context, req ProjectFactUpsertR
 	}
 
 	// Batch embed all texts
-	embeddings, err := s.embeddingClient.Embed(ctx, texts)
+	client := s.embeddings.Default()
+	embeddings, err := embedAvailable(ctx, client, texts)
 	if err != nil {
 		return nil, fmt.Errorf("embed texts: %w", err)
 	}
@@ -557,13 +647,17 @@ func (s *Service) UpsertProjectFacts(ctx context.Context, req ProjectFactUpsertR
 
 	// Upsert each fact
 	for i, fact := range req.Facts {
+		if embeddings[i] == nil {
+			continue
+		}
 		projectFact := &ProjectFact{
-			ProjectID:  req.ProjectID,
-			Kind:       fact.Kind,
-			Title:      fact.Title,
-			Text:       fact.Text,
-			Confidence: fact.Confidence,
-			Embedding:  embeddings[i],
+			ProjectID:      req.ProjectID,
+			Kind:           fact.Kind,
+			Title:          fact.Title,
+			Text:           fact.Text,
+			Confidence:     fact.Confidence,
+			Embedding:      embeddings[i],
+			EmbeddingModel: client.Model().ID,
 		}
 
 		id, err := s.repo.UpsertProjectFact(ctx, projectFact)
@@ -9,10 +9,15 @@ import (
 type ConversationItem struct {
 	ID             string    `db:"id"`
 	ConversationID string    `db:"conversation_id"`
+	ItemID         string    `db:"item_id"`
 	Role           string    `db:"role"`
 	Content        string    `db:"content"`
 	ToolCalls      string    `db:"tool_calls"`
 	CreatedAt      time.Time `db:"created_at"`
+
+	// Set instead of Content when sealed by encryption at rest
+	ContentCiphertext []byte `db:"content_ciphertext"`
+	DataKeyID         *int64 `db:"data_key_id"`
 }
 
 func NewSchemaConversationItem(d *memory.ConversationItem) *ConversationItem {
@@ -23,6 +28,7 @@ func NewSchemaConversationItem(d *memory.ConversationItem) *ConversationItem {
 	return &ConversationItem{
 		ID:             d.ID,
 		ConversationID: d.ConversationID,
+		ItemID:         d.ItemID,
 		Role:           d.Role,
 		Content:        d.Content,
 		ToolCalls:      d.ToolCalls,
@@ -38,6 +44,7 @@ func (s *ConversationItem) EtoD() *memory.ConversationItem {
 	return &memory.ConversationItem{
 		ID:             s.ID,
 		ConversationID: s.ConversationID,
+		ItemID:         s.ItemID,
 		Role:           s.Role,
 		Content:        s.Content,
 		ToolCalls:      s.ToolCalls,
@@ -15,8 +15,13 @@ type EpisodicEvent struct {
 	Text           string    `db:"text"`
 	Kind           string    `db:"kind"`
 	Embedding      []float32 `db:"embedding"`
+	EmbeddingModel string    `db:"embedding_model"`
 	IsDeleted      bool      `db:"is_deleted"`
 	CreatedAt      time.Time `db:"created_at"`
+
+	// Set instead of Text when sealed by encryption at rest
+	TextCiphertext []byte `db:"text_ciphertext"`
+	DataKeyID      *int64 `db:"data_key_id"`
 }
 
 func NewSchemaEpisodicEvent(d *memory.EpisodicEvent) *EpisodicEvent {
@@ -33,6 +38,7 @@ func NewSchemaEpisodicEvent(d *memory.EpisodicEvent) *EpisodicEvent {
 		Text:           d.Text,
 		Kind:           d.Kind,
 		Embedding:      d.Embedding,
+		EmbeddingModel: d.EmbeddingModel,
 		IsDeleted:      d.IsDeleted,
 		CreatedAt:      d.CreatedAt,
 	}
@@ -52,6 +58,7 @@ func (s *EpisodicEvent) EtoD() *memory.EpisodicEvent {
 		Text:           s.Text,
 		Kind:           s.Kind,
 		Embedding:      s.Embedding,
+		EmbeddingModel: s.EmbeddingModel,
 		IsDeleted:      s.IsDeleted,
 		CreatedAt:      s.CreatedAt,
 	}
@@ -14,10 +14,15 @@ type ProjectFact struct {
 	Text                 string    `db:"text"`
 	Confidence           float32   `db:"confidence"`
 	Embedding            []float32 `db:"embedding"`
+	EmbeddingModel       string    `db:"embedding_model"`
 	SourceConversationID string    `db:"source_conversation_id"`
 	IsDeleted            bool      `db:"is_deleted"`
 	CreatedAt            time.Time `db:"created_at"`
 	UpdatedAt            time.Time `db:"updated_at"`
+
+	// Set instead of Text when sealed by encryption at rest
+	TextCiphertext []byte `db:"text_ciphertext"`
+	DataKeyID      *int64 `db:"data_key_id"`
 }
 
 func NewSchemaProjectFact(d *memory.ProjectFact) *ProjectFact {
@@ -33,6 +38,7 @@ func NewSchemaProjectFact(d *memory.ProjectFact) *ProjectFact {
 		Text:                 d.Text,
 		Confidence:           d.Confidence,
 		Embedding:            d.Embedding,
+		EmbeddingModel:       d.EmbeddingModel,
 		SourceConversationID: d.SourceConversationID,
 		IsDeleted:            d.IsDeleted,
 		CreatedAt:            d.CreatedAt,
@@ -53,6 +59,7 @@ func (s *ProjectFact) EtoD() *memory.ProjectFact {
 		Text:                 s.Text,
 		Confidence:           s.Confidence,
 		Embedding:            s.Embedding,
+		EmbeddingModel:       s.EmbeddingModel,
 		SourceConversationID: s.SourceConversationID,
 		IsDeleted:            s.IsDeleted,
 		CreatedAt:            s.CreatedAt,
@@ -7,16 +7,21 @@ import (
 )
 
 type UserMemoryItem struct {
-	ID        string    `db:"id"`
-	UserID    string    `db:"user_id"`
-	Scope     string    `db:"scope"`
-	Key       string    `db:"key"`
-	Text      string    `db:"text"`
-	Score     int       `db:"score"`
-	Embedding []float32 `db:"embedding"`
-	IsDeleted bool      `db:"is_deleted"`
-	CreatedAt time.Time `db:"created_at"`
-	UpdatedAt time.Time `db:"updated_at"`
+	ID             string    `db:"id"`
+	UserID         string    `db:"user_id"`
+	Scope          string    `db:"scope"`
+	Key            string    `db:"key"`
+	Text           string    `db:"text"`
+	Score          int       `db:"score"`
+	Embedding      []float32 `db:"embedding"`
+	EmbeddingModel string    `db:"embedding_model"`
+	IsDeleted      bool      `db:"is_deleted"`
+	CreatedAt      time.Time `db:"created_at"`
+	UpdatedAt      time.Time `db:"updated_at"`
+
+	// Set instead of Text when sealed by encryption at rest
+	TextCiphertext []byte `db:"text_ciphertext"`
+	DataKeyID      *int64 `db:"data_key_id"`
 }
 
 func NewSchemaUserMemoryItem(d *memory.UserMemoryItem) *UserMemoryItem {
@@ -25,16 +30,17 @@ func NewSchemaUserMemoryItem(d *memory.UserMemoryItem) *UserMemoryItem {
 	}
 
 	return &UserMemoryItem{
-		ID:        d.ID,
-		UserID:    d.UserID,
-		Scope:     d.Scope,
-		Key:       d.Key,
-		Text:      d.Text,
-		Score:     d.Score,
-		Embedding: d.Embedding,
-		IsDeleted: d.IsDeleted,
-		CreatedAt: d.CreatedAt,
-		UpdatedAt: d.UpdatedAt,
+		ID:             d.ID,
+		UserID:         d.UserID,
+		Scope:          d.Scope,
+		Key:            d.Key,
+		Text:           d.Text,
+		Score:          d.Score,
+		Embedding:      d.Embedding,
+		EmbeddingModel: d.EmbeddingModel,
+		IsDeleted:      d.IsDeleted,
+		CreatedAt:      d.CreatedAt,
+		UpdatedAt:      d.UpdatedAt,
 	}
 }
 
@@ -44,15 +50,16 @@ func (s *UserMemoryItem) EtoD() *memory.UserMemoryItem {
 	}
 
 	return &memory.UserMemoryItem{
-		ID:        s.ID,
-		UserID:    s.UserID,
-		Scope:     s.Scope,
-		Key:       s.Key,
-		Text:      s.Text,
-		Score:     s.Score,
-		Embedding: s.Embedding,
-		IsDeleted: s.IsDeleted,
-		CreatedAt: s.CreatedAt,
-		UpdatedAt: s.UpdatedAt,
+		ID:             s.ID,
+		UserID:         s.UserID,
+		Scope:          s.Scope,
+		Key:            s.Key,
+		Text:           s.Text,
+		Score:          s.Score,
+		Embedding:      s.Embedding,
+		EmbeddingModel: s.EmbeddingModel,
+		IsDeleted:      s.IsDeleted,
+		CreatedAt:      s.CreatedAt,
+		UpdatedAt:      s.UpdatedAt,
 	}
 }
@@ -8,6 +8,8 @@ import (
 	"github.com/google/uuid"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
 	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/database/dbschema"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
+	"gorm.io/gorm/clause"
 )
 
 func (r *Repository) CreateConversationItem(ctx context.Context, item *memory.ConversationItem) error {
@@ -20,28 +22,55 @@ func (r *Repository) CreateConversationItem(ctx context.Context, item *memory.Co
 	}
 
 	schema := dbschema.NewSchemaConversationItem(item)
+	values, err := r.textColumns(ctx, "content", encryption.ConversationOwner(schema.ConversationID), conversationItemAAD(schema.ID), schema.Content)
+	if err != nil {
+		return fmt.Errorf("create conversation item: %w", err)
+	}
+	values["id"] = schema.ID
+	values["conversation_id"] = schema.ConversationID
+	values["item_id"] = schema.ItemID
+	values["role"] = schema.Role
+	values["tool_calls"] = schema.ToolCalls
+	values["created_at"] = schema.CreatedAt
 
 	if err := r.db.WithContext(ctx).
 		Table("conversation_items").
-		Create(map[string]any{
-			"id":              schema.ID,
-			"conversation_id": schema.ConversationID,
-			"role":            schema.Role,
-			"content":         schema.Content,
-			"tool_calls":      schema.ToolCalls,
-			"created_at":      schema.CreatedAt,
-		}).Error; err != nil {
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "conversation_id"}, {Name: "item_id"}},
+			TargetWhere: clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "item_id IS NOT NULL"}}},
+			DoNothing:   true,
+		}).
+		Create(values).Error; err != nil {
 		return fmt.Errorf("create conversation item: %w", err)
 	}
 
 	return nil
 }
 
+func (r *Repository) FindObservedItemIDs(ctx context.Context, conversationID string, itemIDs []string) (map[string]bool, error) {
+	observed := make(map[string]bool, len(itemIDs))
+	if len(itemIDs) == 0 {
+		return observed, nil
+	}
+
+	var found []string
+	if err := r.db.WithContext(ctx).
+		Table("conversation_items").
+		Where("conversation_id = ? AND item_id IN ?", conversationID, itemIDs).
+		Pluck("item_id", &found).Error; err != nil {
+		return nil, fmt.Errorf("query observed items: %w", err)
+	}
+	for _, id := range found {
+		observed[id] = true
+	}
+	return observed, nil
+}
+
 func (r *Repository) GetConversationItems(ctx context.Context, conversationID string) ([]memory.ConversationItem, error) {
 	var rows []dbschema.ConversationItem
 	if err := r.db.WithContext(ctx).
 		Table("conversation_items").
-		Select("id, conversation_id, role, content, tool_calls, created_at").
+		Select("id, conversation_id, item_id, role, content, content_ciphertext, data_key_id, tool_calls, created_at").
 		Where("conversation_id = ?", conversationID).
 		Order("created_at ASC").
 		Find(&rows).Error; err != nil {
@@ -50,6 +79,11 @@ func (r *Repository) GetConversationItems(ctx context.Context, conversationID st
 
 	items := make([]memory.ConversationItem, 0, len(rows))
 	for _, row := range rows {
+		content, err := r.openText(ctx, conversationItemAAD(row.ID), row.Content, row.ContentCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("query conversation items: %w", err)
+		}
+		row.Content = content
 		items = append(items, *row.EtoD())
 	}
 
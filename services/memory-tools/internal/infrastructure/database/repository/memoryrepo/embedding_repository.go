@@ -0,0 +1,80 @@
+package memoryrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
+)
+
+// embeddingTable is a table of embedded memories.
+type embeddingTable struct {
+	name      string
+	indexName string // Prefix of the per-model vector indexes
+	aad       func(id string) string
+}
+
+var embeddingTables = map[memory.EmbeddingKind]embeddingTable{
+	memory.EmbeddingKindUserMemory:    {name: "user_memory_items", indexName: "idx_user_memory_embedding", aad: userMemoryAAD},
+	memory.EmbeddingKindProjectFact:   {name: "project_facts", indexName: "idx_project_facts_embedding", aad: projectFactAAD},
+	memory.EmbeddingKindEpisodicEvent: {name: "episodic_events", indexName: "idx_episodic_events_embedding", aad: episodicEventAAD},
+}
+
+func lookupEmbeddingTable(kind memory.EmbeddingKind) (embeddingTable, error) {
+	table, ok := embeddingTables[kind]
+	if !ok {
+		return embeddingTable{}, fmt.Errorf("unknown embedding kind %q", kind)
+	}
+	return table, nil
+}
+
+// ListStaleEmbeddings returns memories of a kind whose vector was not
+// produced by model, with their text opened.
+func (r *Repository) ListStaleEmbeddings(ctx context.Context, kind memory.EmbeddingKind, model string, limit int) ([]memory.EmbeddingTarget, error) {
+	table, err := lookupEmbeddingTable(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []struct {
+		ID             string
+		Text           string
+		TextCiphertext []byte
+		DataKeyID      *int64
+	}
+	if err := r.db.WithContext(ctx).
+		Table(table.name).
+		Select("id, text, text_ciphertext, data_key_id").
+		Where("is_deleted = false AND embedding_model IS DISTINCT FROM ?", model).
+		Order("id").
+		Limit(limit).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("list stale embeddings: %w", err)
+	}
+
+	targets := make([]memory.EmbeddingTarget, 0, len(rows))
+	for _, row := range rows {
+		text, err := r.openText(ctx, table.aad(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("list stale embeddings: %w", err)
+		}
+		targets = append(targets, memory.EmbeddingTarget{ID: row.ID, Text: text})
+	}
+	return targets, nil
+}
+
+// UpdateEmbedding replaces the vector of a memory. A memory rewritten with
+// model in the meantime keeps its newer vector.
+func (r *Repository) UpdateEmbedding(ctx context.Context, kind memory.EmbeddingKind, id, model string, embedding []float32) error {
+	table, err := lookupEmbeddingTable(kind)
+	if err != nil {
+		return err
+	}
+	return r.db.WithContext(ctx).
+		Table(table.name).
+		Where("id = ? AND embedding_model IS DISTINCT FROM ?", id, model).
+		Updates(map[string]any{
+			"embedding":       embeddingToString(embedding),
+			"embedding_model": model,
+		}).Error
+}
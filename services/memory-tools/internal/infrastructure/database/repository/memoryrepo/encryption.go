@@ -0,0 +1,70 @@
+package memoryrepo
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
+)
+
+// textColumns returns the values stored for a text column: the plaintext
+// while encryption at rest is off, otherwise an empty column next to its
+// sealed copy. The ciphertext and key columns are always set, so an upsert
+// never leaves a stale sealed copy behind.
+func (r *Repository) textColumns(ctx context.Context, column, owner, additionalData, text string) (map[string]any, error) {
+	if !r.encryption.Enabled() {
+		return map[string]any{column: text, column + "_ciphertext": nil, "data_key_id": nil}, nil
+	}
+	ciphertext, keyID, err := r.encryption.Seal(ctx, owner, additionalData, text)
+	if err != nil {
+		return nil, fmt.Errorf("encrypt %s: %w", column, err)
+	}
+	return map[string]any{column: "", column + "_ciphertext": ciphertext, "data_key_id": keyID}, nil
+}
+
+// openText returns the plaintext of a text column that may be sealed
+func (r *Repository) openText(ctx context.Context, additionalData, text string, ciphertext []byte, keyID *int64) (string, error) {
+	if keyID == nil {
+		return text, nil
+	}
+	plaintext, err := r.encryption.Open(ctx, *keyID, additionalData, ciphertext)
+	if err != nil {
+		return "", fmt.Errorf("decrypt: %w", err)
+	}
+	return plaintext, nil
+}
+
+// hashKey returns the owner's content hash key while encryption at rest is
+// on, and nil while text is stored in the clear
+func (r *Repository) hashKey(ctx context.Context, owner string) ([]byte, error) {
+	if !r.encryption.Enabled() {
+		return nil, nil
+	}
+	key, err := r.encryption.HashKey(ctx, owner)
+	if err != nil {
+		return nil, fmt.Errorf("content hash key: %w", err)
+	}
+	return key, nil
+}
+
+// contentHash returns the dedupe hash of text, keyed by the owner's hash key
+// while encryption at rest is on
+func (r *Repository) contentHash(ctx context.Context, owner, text string) (string, error) {
+	key, err := r.hashKey(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+	return memory.ContentHash(key, text), nil
+}
+
+// ConversationHashKey returns the key for hashing the conversation's messages
+func (r *Repository) ConversationHashKey(ctx context.Context, conversationID string) ([]byte, error) {
+	return r.hashKey(ctx, encryption.ConversationOwner(conversationID))
+}
+
+// Additional data binding each ciphertext to its row
+func userMemoryAAD(id string) string       { return "user_memory_items:" + id }
+func projectFactAAD(id string) string      { return "project_facts:" + id }
+func episodicEventAAD(id string) string    { return "episodic_events:" + id }
+func conversationItemAAD(id string) string { return "conversation_items:" + id }
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
 	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/database/dbschema"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
 	"gorm.io/gorm/clause"
 )
 
@@ -15,7 +16,7 @@ func (r *Repository) GetEpisodicEvents(ctx context.Context, userID string, limit
 	var rows []dbschema.EpisodicEvent
 	if err := r.db.WithContext(ctx).
 		Table("episodic_events").
-		Select("id, user_id, project_id, conversation_id, time, text, kind, created_at").
+		Select("id, user_id, project_id, conversation_id, time, text, text_ciphertext, data_key_id, kind, created_at").
 		Where("user_id = ? AND is_deleted = false", userID).
 		Order("time DESC").
 		Limit(limit).
@@ -25,12 +26,19 @@ func (r *Repository) GetEpisodicEvents(ctx context.Context, userID string, limit
 
 	events := make([]memory.EpisodicEvent, 0, len(rows))
 	for _, row := range rows {
+		text, err := r.openText(ctx, episodicEventAAD(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("query episodic events: %w", err)
+		}
+		row.Text = text
 		events = append(events, *row.EtoD())
 	}
 
 	return events, nil
 }
 
+// CreateEpisodicEvent does nothing when the conversation already holds a live
+// event of the user with the same text.
 func (r *Repository) CreateEpisodicEvent(ctx context.Context, event *memory.EpisodicEvent) error {
 	if event.ID == "" {
 		event.ID = uuid.New().String()
@@ -41,21 +49,34 @@ func (r *Repository) CreateEpisodicEvent(ctx context.Context, event *memory.Epis
 	}
 
 	schema := dbschema.NewSchemaEpisodicEvent(event)
+	values, err := r.textColumns(ctx, "text", encryption.UserOwner(schema.UserID), episodicEventAAD(schema.ID), schema.Text)
+	if err != nil {
+		return fmt.Errorf("create episodic event: %w", err)
+	}
+	contentHash, err := r.contentHash(ctx, encryption.UserOwner(schema.UserID), schema.Text)
+	if err != nil {
+		return fmt.Errorf("create episodic event: %w", err)
+	}
+	values["id"] = schema.ID
+	values["user_id"] = schema.UserID
+	values["project_id"] = schema.ProjectID
+	values["conversation_id"] = schema.ConversationID
+	values["time"] = schema.Time
+	values["kind"] = schema.Kind
+	values["content_hash"] = contentHash
+	values["embedding"] = embeddingToString(schema.Embedding)
+	values["embedding_model"] = schema.EmbeddingModel
+	values["is_deleted"] = schema.IsDeleted
+	values["created_at"] = schema.CreatedAt
 
 	if err := r.db.WithContext(ctx).
 		Table("episodic_events").
-		Create(map[string]any{
-			"id":              schema.ID,
-			"user_id":         schema.UserID,
-			"project_id":      schema.ProjectID,
-			"conversation_id": schema.ConversationID,
-			"time":            schema.Time,
-			"text":            schema.Text,
-			"kind":            schema.Kind,
-			"embedding":       embeddingToString(schema.Embedding),
-			"is_deleted":      schema.IsDeleted,
-			"created_at":      schema.CreatedAt,
-		}).Error; err != nil {
+		Clauses(clause.OnConflict{
+			Columns:     []clause.Column{{Name: "user_id"}, {Name: "conversation_id"}, {Name: "content_hash"}},
+			TargetWhere: liveRows,
+			DoNothing:   true,
+		}).
+		Create(values).Error; err != nil {
 		return fmt.Errorf("create episodic event: %w", err)
 	}
 
@@ -79,6 +100,7 @@ func (r *Repository) DeleteEpisodicEvent(ctx context.Context, id string) error {
 func (r *Repository) SearchEpisodicEvents(
 	ctx context.Context,
 	userID string,
+	model string,
 	queryEmbedding []float32,
 	limit int,
 	minSimilarity float32,
@@ -88,11 +110,13 @@ func (r *Repository) SearchEpisodicEvents(
 		Similarity float32 `db:"similarity"`
 	}
 
+	vector := embeddingToString(queryEmbedding)
+	distance := distanceExpr(len(queryEmbedding))
 	if err := r.db.WithContext(ctx).
 		Table("episodic_events").
-		Select("id, user_id, project_id, conversation_id, time, text, kind, created_at, 1 - (embedding <=> ?::vector) AS similarity", embeddingToString(queryEmbedding)).
-		Where("user_id = ? AND is_deleted = false AND time > NOW() - INTERVAL '2 weeks' AND 1 - (embedding <=> ?::vector) >= ?", userID, embeddingToString(queryEmbedding), minSimilarity).
-		Order(clause.Expr{SQL: "embedding <=> ?::vector", Vars: []any{embeddingToString(queryEmbedding)}}).
+		Select("id, user_id, project_id, conversation_id, time, text, text_ciphertext, data_key_id, kind, created_at, 1 - "+distance+" AS similarity", vector).
+		Where("user_id = ? AND embedding_model = ? AND is_deleted = false AND time > NOW() - INTERVAL '2 weeks' AND 1 - "+distance+" >= ?", userID, model, vector, minSimilarity).
+		Order(clause.Expr{SQL: distance, Vars: []any{vector}}).
 		Limit(limit).
 		Scan(&rows).Error; err != nil {
 		return nil, fmt.Errorf("search episodic events: %w", err)
@@ -100,6 +124,11 @@ func (r *Repository) SearchEpisodicEvents(
 
 	events := make([]memory.EpisodicEvent, 0, len(rows))
 	for _, row := range rows {
+		text, err := r.openText(ctx, episodicEventAAD(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("search episodic events: %w", err)
+		}
+		row.Text = text
 		event := row.EpisodicEvent.EtoD()
 		event.Similarity = row.Similarity
 		events = append(events, *event)
@@ -8,12 +8,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
 	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/database/dbschema"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 func (r *Repository) GetProjectFacts(ctx context.Context, projectID string) ([]memory.ProjectFact, error) {
 	query := `
-		id, project_id, kind, title, text, confidence, 
+		id, project_id, kind, title, text, text_ciphertext, data_key_id, confidence, 
 		source_conversation_id, created_at, updated_at
 	`
 
@@ -29,14 +31,23 @@ func (r *Repository) GetProjectFacts(ctx context.Context, projectID string) ([]m
 
 	facts := make([]memory.ProjectFact, 0, len(rows))
 	for _, row := range rows {
+		text, err := r.openText(ctx, projectFactAAD(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("query project facts: %w", err)
+		}
+		row.Text = text
 		facts = append(facts, *row.EtoD())
 	}
 
 	return facts, nil
 }
 
+// UpsertProjectFact updates the fact with fact.ID. A new fact whose text
+// matches a live fact of the project updates that fact instead and returns
+// its ID.
 func (r *Repository) UpsertProjectFact(ctx context.Context, fact *memory.ProjectFact) (string, error) {
-	if fact.ID == "" {
+	dedupe := fact.ID == ""
+	if dedupe {
 		fact.ID = uuid.New().String()
 	}
 
@@ -47,30 +58,62 @@ func (r *Repository) UpsertProjectFact(ctx context.Context, fact *memory.Project
 	fact.UpdatedAt = now
 
 	schema := dbschema.NewSchemaProjectFact(fact)
+	values, err := r.textColumns(ctx, "text", encryption.ProjectOwner(schema.ProjectID), projectFactAAD(schema.ID), schema.Text)
+	if err != nil {
+		return "", fmt.Errorf("upsert project fact: %w", err)
+	}
+	contentHash, err := r.contentHash(ctx, encryption.ProjectOwner(schema.ProjectID), schema.Text)
+	if err != nil {
+		return "", fmt.Errorf("upsert project fact: %w", err)
+	}
+	values["id"] = schema.ID
+	values["project_id"] = schema.ProjectID
+	values["kind"] = schema.Kind
+	values["title"] = schema.Title
+	values["confidence"] = schema.Confidence
+	values["content_hash"] = contentHash
+	values["embedding"] = embeddingToString(schema.Embedding)
+	values["embedding_model"] = schema.EmbeddingModel
+	values["source_conversation_id"] = schema.SourceConversationID
+	values["is_deleted"] = schema.IsDeleted
+	values["created_at"] = schema.CreatedAt
+	values["updated_at"] = schema.UpdatedAt
+
+	conflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"kind", "title", "text", "text_ciphertext", "data_key_id", "confidence", "content_hash", "embedding", "embedding_model", "is_deleted", "updated_at"}),
+	}
+	if dedupe {
+		// The stored text is sealed for the stored row's ID, so it is kept
+		conflict = clause.OnConflict{
+			Columns:     []clause.Column{{Name: "project_id"}, {Name: "content_hash"}},
+			TargetWhere: liveRows,
+			DoUpdates: append(clause.AssignmentColumns([]string{"kind", "title", "embedding", "embedding_model", "updated_at"}),
+				clause.Assignment{Column: clause.Column{Name: "confidence"}, Value: gorm.Expr("GREATEST(project_facts.confidence, EXCLUDED.confidence)")}),
+		}
+	}
 
 	if err := r.db.WithContext(ctx).
 		Table("project_facts").
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"kind", "title", "text", "confidence", "embedding", "is_deleted", "updated_at"}),
-		}).
-		Create(map[string]any{
-			"id":                     schema.ID,
-			"project_id":             schema.ProjectID,
-			"kind":                   schema.Kind,
-			"title":                  schema.Title,
-			"text":                   schema.Text,
-			"confidence":             schema.Confidence,
-			"embedding":              embeddingToString(schema.Embedding),
-			"source_conversation_id": schema.SourceConversationID,
-			"is_deleted":             schema.IsDeleted,
-			"created_at":             schema.CreatedAt,
-			"updated_at":             schema.UpdatedAt,
-		}).Error; err != nil {
+		Clauses(conflict).
+		Create(values).Error; err != nil {
 		return "", fmt.Errorf("upsert project fact: %w", err)
 	}
 
-	return schema.ID, nil
+	if dedupe {
+		var ids []string
+		if err := r.db.WithContext(ctx).
+			Table("project_facts").
+			Where("project_id = ? AND content_hash = ? AND is_deleted = false", schema.ProjectID, values["content_hash"]).
+			Pluck("id", &ids).Error; err != nil {
+			return "", fmt.Errorf("upsert project fact: %w", err)
+		}
+		if len(ids) > 0 {
+			fact.ID = ids[0]
+		}
+	}
+
+	return fact.ID, nil
 }
 
 func (r *Repository) DeleteProjectFact(ctx context.Context, id string) error {
@@ -93,6 +136,7 @@ func (r *Repository) DeleteProjectFact(ctx context.Context, id string) error {
 func (r *Repository) SearchProjectFacts(
 	ctx context.Context,
 	projectID string,
+	model string,
 	queryEmbedding []float32,
 	limit int,
 	minSimilarity float32,
@@ -102,11 +146,13 @@ func (r *Repository) SearchProjectFacts(
 		Similarity float32 `db:"similarity"`
 	}
 
+	vector := embeddingToString(queryEmbedding)
+	distance := distanceExpr(len(queryEmbedding))
 	if err := r.db.WithContext(ctx).
 		Table("project_facts").
-		Select("id, project_id, kind, title, text, confidence, source_conversation_id, created_at, updated_at, 1 - (embedding <=> ?::vector) AS similarity", embeddingToString(queryEmbedding)).
-		Where("project_id = ? AND is_deleted = false AND confidence >= 0.7 AND 1 - (embedding <=> ?::vector) >= ?", projectID, embeddingToString(queryEmbedding), minSimilarity).
-		Order(clause.Expr{SQL: "embedding <=> ?::vector", Vars: []any{embeddingToString(queryEmbedding)}}).
+		Select("id, project_id, kind, title, text, text_ciphertext, data_key_id, confidence, source_conversation_id, created_at, updated_at, 1 - "+distance+" AS similarity", vector).
+		Where("project_id = ? AND embedding_model = ? AND is_deleted = false AND confidence >= 0.7 AND 1 - "+distance+" >= ?", projectID, model, vector, minSimilarity).
+		Order(clause.Expr{SQL: distance, Vars: []any{vector}}).
 		Limit(limit).
 		Scan(&rows).Error; err != nil {
 		return nil, fmt.Errorf("search project facts: %w", err)
@@ -114,6 +160,11 @@ func (r *Repository) SearchProjectFacts(
 
 	facts := make([]memory.ProjectFact, 0, len(rows))
 	for _, row := range rows {
+		text, err := r.openText(ctx, projectFactAAD(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("search project facts: %w", err)
+		}
+		row.Text = text
 		fact := row.ProjectFact.EtoD()
 		fact.Similarity = row.Similarity
 		facts = append(facts, *fact)
@@ -6,15 +6,18 @@ import (
 	"strings"
 
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 )
 
 type Repository struct {
-	db *gorm.DB
+	db         *gorm.DB
+	encryption *encryption.Service
 }
 
-func NewRepository(db *gorm.DB) *Repository {
-	return &Repository{db: db}
+func NewRepository(db *gorm.DB, enc *encryption.Service) *Repository {
+	return &Repository{db: db, encryption: enc}
 }
 
 // helper converts embeddings to pgvector literal.
@@ -30,23 +33,39 @@ func embeddingToString(embedding []float32) string {
 	return "[" + strings.Join(parts, ",") + "]"
 }
 
+// liveRows limits a conflict target to the partial unique indexes over rows
+// that are not deleted.
+var liveRows = clause.Where{Exprs: []clause.Expression{clause.Expr{SQL: "is_deleted = FALSE"}}}
+
+// distanceExpr is the cosine distance between the stored embedding and the
+// query vector. Columns hold vectors of every model, so both sides are cast
+// to the query's dimension, which is also how the per-model indexes are built.
+func distanceExpr(dimension int) string {
+	return fmt.Sprintf("(embedding::vector(%d) <=> ?::vector(%d))", dimension, dimension)
+}
+
 // ensure interfaces are implemented
 var _ interface {
 	GetUserMemoryItems(ctx context.Context, userID string) ([]memory.UserMemoryItem, error)
 	UpsertUserMemoryItem(ctx context.Context, item *memory.UserMemoryItem) (string, error)
 	DeleteUserMemoryItem(ctx context.Context, id string) error
-	SearchUserMemory(ctx context.Context, userID string, queryEmbedding []float32, limit int, minSimilarity float32) ([]memory.UserMemoryItem, error)
+	SearchUserMemory(ctx context.Context, userID, model string, queryEmbedding []float32, limit int, minSimilarity float32) ([]memory.UserMemoryItem, error)
 
 	GetProjectFacts(ctx context.Context, projectID string) ([]memory.ProjectFact, error)
 	UpsertProjectFact(ctx context.Context, fact *memory.ProjectFact) (string, error)
 	DeleteProjectFact(ctx context.Context, id string) error
-	SearchProjectFacts(ctx context.Context, projectID string, queryEmbedding []float32, limit int, minSimilarity float32) ([]memory.ProjectFact, error)
+	SearchProjectFacts(ctx context.Context, projectID, model string, queryEmbedding []float32, limit int, minSimilarity float32) ([]memory.ProjectFact, error)
 
 	GetEpisodicEvents(ctx context.Context, userID string, limit int) ([]memory.EpisodicEvent, error)
 	CreateEpisodicEvent(ctx context.Context, event *memory.EpisodicEvent) error
 	DeleteEpisodicEvent(ctx context.Context, id string) error
-	SearchEpisodicEvents(ctx context.Context, userID string, queryEmbedding []float32, limit int, minSimilarity float32) ([]memory.EpisodicEvent, error)
+	SearchEpisodicEvents(ctx context.Context, userID, model string, queryEmbedding []float32, limit int, minSimilarity float32) ([]memory.EpisodicEvent, error)
 
 	CreateConversationItem(ctx context.Context, item *memory.ConversationItem) error
+	FindObservedItemIDs(ctx context.Context, conversationID string, itemIDs []string) (map[string]bool, error)
+	ConversationHashKey(ctx context.Context, conversationID string) ([]byte, error)
 	GetConversationItems(ctx context.Context, conversationID string) ([]memory.ConversationItem, error)
+
+	ListStaleEmbeddings(ctx context.Context, kind memory.EmbeddingKind, model string, limit int) ([]memory.EmbeddingTarget, error)
+	UpdateEmbedding(ctx context.Context, kind memory.EmbeddingKind, id, model string, embedding []float32) error
 } = (*Repository)(nil)
@@ -8,12 +8,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
 	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/database/dbschema"
+	"github.com/janhq/jan-server/services/memory-tools/internal/infrastructure/encryption"
+	"gorm.io/gorm"
 	"gorm.io/gorm/clause"
 )
 
 func (r *Repository) GetUserMemoryItems(ctx context.Context, userID string) ([]memory.UserMemoryItem, error) {
 	query := `
-		id, user_id, scope, key, text, score, created_at, updated_at
+		id, user_id, scope, key, text, text_ciphertext, data_key_id, score, created_at, updated_at
 	`
 
 	var rows []dbschema.UserMemoryItem
@@ -28,14 +30,23 @@ func (r *Repository) GetUserMemoryItems(ctx context.Context, userID string) ([]m
 
 	items := make([]memory.UserMemoryItem, 0, len(rows))
 	for _, row := range rows {
+		text, err := r.openText(ctx, userMemoryAAD(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("query user memory: %w", err)
+		}
+		row.Text = text
 		items = append(items, *row.EtoD())
 	}
 
 	return items, nil
 }
 
+// UpsertUserMemoryItem updates the item with item.ID. A new item whose text
+// matches a live memory of the user updates that memory instead and returns
+// its ID.
 func (r *Repository) UpsertUserMemoryItem(ctx context.Context, item *memory.UserMemoryItem) (string, error) {
-	if item.ID == "" {
+	dedupe := item.ID == ""
+	if dedupe {
 		item.ID = uuid.New().String()
 	}
 
@@ -46,29 +57,61 @@ func (r *Repository) UpsertUserMemoryItem(ctx context.Context, item *memory.User
 	item.UpdatedAt = now
 
 	schema := dbschema.NewSchemaUserMemoryItem(item)
+	values, err := r.textColumns(ctx, "text", encryption.UserOwner(schema.UserID), userMemoryAAD(schema.ID), schema.Text)
+	if err != nil {
+		return "", fmt.Errorf("upsert user memory item: %w", err)
+	}
+	contentHash, err := r.contentHash(ctx, encryption.UserOwner(schema.UserID), schema.Text)
+	if err != nil {
+		return "", fmt.Errorf("upsert user memory item: %w", err)
+	}
+	values["id"] = schema.ID
+	values["user_id"] = schema.UserID
+	values["scope"] = schema.Scope
+	values["key"] = schema.Key
+	values["score"] = schema.Score
+	values["content_hash"] = contentHash
+	values["embedding"] = embeddingToString(schema.Embedding)
+	values["embedding_model"] = schema.EmbeddingModel
+	values["is_deleted"] = schema.IsDeleted
+	values["created_at"] = schema.CreatedAt
+	values["updated_at"] = schema.UpdatedAt
+
+	conflict := clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"scope", "key", "text", "text_ciphertext", "data_key_id", "score", "content_hash", "embedding", "embedding_model", "is_deleted", "updated_at"}),
+	}
+	if dedupe {
+		// The stored text is sealed for the stored row's ID, so it is kept
+		conflict = clause.OnConflict{
+			Columns:     []clause.Column{{Name: "user_id"}, {Name: "content_hash"}},
+			TargetWhere: liveRows,
+			DoUpdates: append(clause.AssignmentColumns([]string{"scope", "key", "embedding", "embedding_model", "updated_at"}),
+				clause.Assignment{Column: clause.Column{Name: "score"}, Value: gorm.Expr("GREATEST(user_memory_items.score, EXCLUDED.score)")}),
+		}
+	}
 
 	if err := r.db.WithContext(ctx).
 		Table("user_memory_items").
-		Clauses(clause.OnConflict{
-			Columns:   []clause.Column{{Name: "id"}},
-			DoUpdates: clause.AssignmentColumns([]string{"scope", "key", "text", "score", "embedding", "is_deleted", "updated_at"}),
-		}).
-		Create(map[string]any{
-			"id":         schema.ID,
-			"user_id":    schema.UserID,
-			"scope":      schema.Scope,
-			"key":        schema.Key,
-			"text":       schema.Text,
-			"score":      schema.Score,
-			"embedding":  embeddingToString(schema.Embedding),
-			"is_deleted": schema.IsDeleted,
-			"created_at": schema.CreatedAt,
-			"updated_at": schema.UpdatedAt,
-		}).Error; err != nil {
+		Clauses(conflict).
+		Create(values).Error; err != nil {
 		return "", fmt.Errorf("upsert user memory item: %w", err)
 	}
 
-	return schema.ID, nil
+	if dedupe {
+		var ids []string
+		if err := r.db.WithContext(ctx).
+			Table("user_memory_items").
+			Where("user_id = ? AND content_hash = ? AND is_deleted = false", schema.UserID, values["content_hash"]).
+			Pluck("id", &ids).Error; err != nil {
+			return "", fmt.Errorf("upsert user memory item: %w", err)
+		}
+		if len(ids) > 0 {
+			item.ID = ids[0]
+		}
+	}
+
+	return item.ID, nil
 }
 
 func (r *Repository) DeleteUserMemoryItem(ctx context.Context, id string) error {
@@ -91,6 +134,7 @@ func (r *Repository) DeleteUserMemoryItem(ctx context.Context, id string) error
 func (r *Repository) SearchUserMemory(
 	ctx context.Context,
 	userID string,
+	model string,
 	queryEmbedding []float32,
 	limit int,
 	minSimilarity float32,
@@ -100,11 +144,13 @@ func (r *Repository) SearchUserMemory(
 		Similarity float32 `db:"similarity"`
 	}
 
+	vector := embeddingToString(queryEmbedding)
+	distance := distanceExpr(len(queryEmbedding))
 	if err := r.db.WithContext(ctx).
 		Table("user_memory_items").
-		Select("id, user_id, scope, key, text, score, created_at, updated_at, 1 - (embedding <=> ?::vector) AS similarity", embeddingToString(queryEmbedding)).
-		Where("user_id = ? AND is_deleted = false AND score >= 2 AND 1 - (embedding <=> ?::vector) >= ?", userID, embeddingToString(queryEmbedding), minSimilarity).
-		Order(clause.Expr{SQL: "embedding <=> ?::vector", Vars: []any{embeddingToString(queryEmbedding)}}).
+		Select("id, user_id, scope, key, text, text_ciphertext, data_key_id, score, created_at, updated_at, 1 - "+distance+" AS similarity", vector).
+		Where("user_id = ? AND embedding_model = ? AND is_deleted = false AND score >= 2 AND 1 - "+distance+" >= ?", userID, model, vector, minSimilarity).
+		Order(clause.Expr{SQL: distance, Vars: []any{vector}}).
 		Limit(limit).
 		Scan(&rows).Error; err != nil {
 		return nil, fmt.Errorf("search user memory: %w", err)
@@ -112,6 +158,11 @@ func (r *Repository) SearchUserMemory(
 
 	items := make([]memory.UserMemoryItem, 0, len(rows))
 	for _, row := range rows {
+		text, err := r.openText(ctx, userMemoryAAD(row.ID), row.Text, row.TextCiphertext, row.DataKeyID)
+		if err != nil {
+			return nil, fmt.Errorf("search user memory: %w", err)
+		}
+		row.Text = text
 		item := row.UserMemoryItem.EtoD()
 		item.Similarity = row.Similarity
 		items = append(items, *item)
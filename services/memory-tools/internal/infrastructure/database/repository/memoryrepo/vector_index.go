@@ -0,0 +1,194 @@
+package memoryrepo
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"strings"
+
+	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
+	"github.com/rs/zerolog/log"
+	"gorm.io/gorm"
+)
+
+// Vector index methods supported by pgvector.
+const (
+	IndexTypeHNSW    = "hnsw"
+	IndexTypeIVFFlat = "ivfflat"
+)
+
+// maxIndexedDimension is the largest vector pgvector can index.
+const maxIndexedDimension = 2000
+
+// IndexConfig selects how the per-model vector indexes are built.
+type IndexConfig struct {
+	Type               string // hnsw or ivfflat
+	HNSWM              int    // Connections per layer
+	HNSWEfConstruction int    // Candidate list size while building
+	IVFFlatLists       int    // Lists; 0 sizes them from the rows of the model
+}
+
+// VectorIndexes manages the vector indexes of the memory tables. Each
+// embedding model gets its own partial index per table, over its rows cast
+// to its dimension.
+type VectorIndexes struct {
+	db  *gorm.DB
+	cfg IndexConfig
+}
+
+// NewVectorIndexes validates the index configuration.
+func NewVectorIndexes(db *gorm.DB, cfg IndexConfig) (*VectorIndexes, error) {
+	switch cfg.Type {
+	case IndexTypeHNSW:
+		if cfg.HNSWM < 2 || cfg.HNSWM > 100 {
+			return nil, fmt.Errorf("hnsw m must be between 2 and 100, got %d", cfg.HNSWM)
+		}
+		if cfg.HNSWEfConstruction < 2*cfg.HNSWM {
+			return nil, fmt.Errorf("hnsw ef_construction must be at least twice m, got %d", cfg.HNSWEfConstruction)
+		}
+	case IndexTypeIVFFlat:
+	default:
+		return nil, fmt.Errorf("unknown vector index type %q", cfg.Type)
+	}
+	return &VectorIndexes{db: db, cfg: cfg}, nil
+}
+
+// indexName names the index of a model on a table. The name does not depend
+// on the index method, so a rebuild replaces the index in place.
+func indexName(table embeddingTable, model string, dimension int) string {
+	hash := fnv.New32a()
+	fmt.Fprintf(hash, "%s|%d", model, dimension)
+	return fmt.Sprintf("%s_%08x", table.indexName, hash.Sum32())
+}
+
+// CheckDimensions verifies that the stored vectors of a model have its
+// configured dimension. Searches cast vectors to it and fail on any other.
+func (v *VectorIndexes) CheckDimensions(ctx context.Context, model string, dimension int) error {
+	for _, kind := range memory.EmbeddingKinds {
+		table := embeddingTables[kind]
+		var dims []int
+		if err := v.db.WithContext(ctx).Raw(fmt.Sprintf(`SELECT vector_dims(embedding) FROM memory_tools.%s
+WHERE embedding_model = ? AND embedding IS NOT NULL AND vector_dims(embedding) <> ?
+LIMIT 1`, table.name), model, dimension).Scan(&dims).Error; err != nil {
+			return fmt.Errorf("check %s dimensions: %w", table.name, err)
+		}
+		if len(dims) > 0 {
+			return fmt.Errorf("%s holds %d-dimensional vectors of %s, configured %d", table.name, dims[0], model, dimension)
+		}
+	}
+	return nil
+}
+
+// Ensure creates the missing indexes of a model. An index built with another
+// method is kept and reported; Rebuild replaces it.
+func (v *VectorIndexes) Ensure(ctx context.Context, model string, dimension int) error {
+	if dimension > maxIndexedDimension {
+		return fmt.Errorf("%s has %d dimensions; vector indexes support up to %d, searches scan the table", model, dimension, maxIndexedDimension)
+	}
+
+	for _, kind := range memory.EmbeddingKinds {
+		table := embeddingTables[kind]
+		name := indexName(table, model, dimension)
+
+		var defs []string
+		if err := v.db.WithContext(ctx).
+			Raw("SELECT indexdef FROM pg_indexes WHERE schemaname = 'memory_tools' AND indexname = ?", name).
+			Scan(&defs).Error; err != nil {
+			return fmt.Errorf("look up %s: %w", name, err)
+		}
+		if len(defs) > 0 {
+			if !strings.Contains(defs[0], "USING "+v.cfg.Type+" ") {
+				log.Warn().Str("index", name).Str("type", v.cfg.Type).Msg("Vector index was built with another method; rebuild it to switch")
+			}
+			continue
+		}
+
+		sql, err := v.createSQL(ctx, table, name, model, dimension, false)
+		if err != nil {
+			return err
+		}
+		if err := v.db.WithContext(ctx).Exec(sql).Error; err != nil {
+			return fmt.Errorf("create %s vector index for %s: %w", table.name, model, err)
+		}
+	}
+	return nil
+}
+
+// Rebuild rebuilds the indexes of a model with the configured method and
+// parameters, e.g. after a bulk import or to size ivfflat lists to the data.
+// Each new index is built concurrently next to the old one and swapped in,
+// so reads and writes continue meanwhile.
+func (v *VectorIndexes) Rebuild(ctx context.Context, model string, dimension int) error {
+	if dimension > maxIndexedDimension {
+		return fmt.Errorf("%s has %d dimensions; vector indexes support up to %d", model, dimension, maxIndexedDimension)
+	}
+
+	for _, kind := range memory.EmbeddingKinds {
+		table := embeddingTables[kind]
+		name := indexName(table, model, dimension)
+		tmp := name + "_rebuild"
+
+		create, err := v.createSQL(ctx, table, tmp, model, dimension, true)
+		if err != nil {
+			return err
+		}
+		// A failed concurrent build leaves an invalid index behind
+		statements := []string{
+			"DROP INDEX CONCURRENTLY IF EXISTS memory_tools." + tmp,
+			create,
+			"DROP INDEX CONCURRENTLY IF EXISTS memory_tools." + name,
+			fmt.Sprintf("ALTER INDEX memory_tools.%s RENAME TO %s", tmp, name),
+		}
+		for _, sql := range statements {
+			if err := v.db.WithContext(ctx).Exec(sql).Error; err != nil {
+				return fmt.Errorf("rebuild %s vector index for %s: %w", table.name, model, err)
+			}
+		}
+		log.Info().Str("index", name).Str("type", v.cfg.Type).Msg("Vector index rebuilt")
+	}
+	return nil
+}
+
+// createSQL returns the statement creating the index of a model on a table.
+func (v *VectorIndexes) createSQL(ctx context.Context, table embeddingTable, name, model string, dimension int, concurrently bool) (string, error) {
+	var method string
+	switch v.cfg.Type {
+	case IndexTypeHNSW:
+		method = fmt.Sprintf("hnsw ((embedding::vector(%d)) vector_cosine_ops)\nWITH (m = %d, ef_construction = %d)",
+			dimension, v.cfg.HNSWM, v.cfg.HNSWEfConstruction)
+	case IndexTypeIVFFlat:
+		lists := v.cfg.IVFFlatLists
+		if lists <= 0 {
+			var err error
+			if lists, err = v.ivfflatLists(ctx, table, model); err != nil {
+				return "", err
+			}
+		}
+		method = fmt.Sprintf("ivfflat ((embedding::vector(%d)) vector_cosine_ops)\nWITH (lists = %d)", dimension, lists)
+	}
+
+	create := "CREATE INDEX IF NOT EXISTS"
+	if concurrently {
+		create = "CREATE INDEX CONCURRENTLY"
+	}
+	literal := "'" + strings.ReplaceAll(model, "'", "''") + "'"
+	return fmt.Sprintf(`%s %s ON memory_tools.%s
+USING %s
+WHERE is_deleted = FALSE AND embedding_model = %s`, create, name, table.name, method, literal), nil
+}
+
+// ivfflatLists sizes ivfflat lists as pgvector recommends: rows / 1000 up to
+// a million rows, the square root of the rows beyond.
+func (v *VectorIndexes) ivfflatLists(ctx context.Context, table embeddingTable, model string) (int, error) {
+	var rows int64
+	if err := v.db.WithContext(ctx).
+		Raw(fmt.Sprintf("SELECT count(*) FROM memory_tools.%s WHERE is_deleted = FALSE AND embedding_model = ?", table.name), model).
+		Scan(&rows).Error; err != nil {
+		return 0, fmt.Errorf("count %s rows: %w", table.name, err)
+	}
+	if rows > 1_000_000 {
+		return int(math.Sqrt(float64(rows))), nil
+	}
+	return max(int(rows/1000), 1), nil
+}
@@ -0,0 +1,237 @@
+// Package encryption seals memory text at rest with per-owner data keys. Each
+// user's (or project's) AES-256 data key is stored wrapped by a master key held
+// in a KMS, so a copy of the database reveals neither the memories nor the keys.
+package encryption
+
+import (
+	"context"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/janhq/jan-server/packages/go-common/kms"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// maxCachedKeys bounds the unwrapped data keys kept in memory
+const maxCachedKeys = 10000
+
+// hashKeyLabel separates the content hash key from the data key it is derived from
+const hashKeyLabel = "memory-tools content hash"
+
+// errNoKMS is returned when reading sealed text without a configured KMS
+var errNoKMS = errors.New("memory is encrypted but no KMS is configured")
+
+// dataKeyRow is a row of memory_tools.data_keys
+type dataKeyRow struct {
+	ID          int64
+	Owner       string
+	WrappedKey  []byte
+	KeyProvider string
+	CreatedAt   time.Time
+}
+
+func (dataKeyRow) TableName() string {
+	return "memory_tools.data_keys"
+}
+
+// dataKey is an unwrapped data key ready for use
+type dataKey struct {
+	aead    cipher.AEAD
+	hashKey []byte // Keys content hashes stored next to text sealed with aead
+}
+
+// UserOwner names the data key of a user's memories and episodic events
+func UserOwner(userID string) string {
+	return "user:" + userID
+}
+
+// ProjectOwner names the data key of a project's facts
+func ProjectOwner(projectID string) string {
+	return "project:" + projectID
+}
+
+// ConversationOwner names the data key of a conversation's stored messages
+func ConversationOwner(conversationID string) string {
+	return "conversation:" + conversationID
+}
+
+// Service seals text on write and opens it on read. Sealing only happens
+// while enabled; opening works whenever a wrapper is configured, so text
+// written while encryption was on stays readable after turning it off.
+type Service struct {
+	enabled bool
+	wrapper kms.KeyWrapper
+	db      *gorm.DB
+
+	mu        sync.RWMutex
+	keys      map[int64]*dataKey // Data key ID to key
+	ownerKeys map[string]int64   // Owner to data key ID
+}
+
+// NewService creates the encryption service. wrapper may be nil when no KMS
+// is configured, in which case enabled must be false.
+func NewService(enabled bool, wrapper kms.KeyWrapper, db *gorm.DB) (*Service, error) {
+	if enabled && wrapper == nil {
+		return nil, errors.New("encryption at rest needs a configured KMS")
+	}
+	return &Service{
+		enabled:   enabled,
+		wrapper:   wrapper,
+		db:        db,
+		keys:      make(map[int64]*dataKey),
+		ownerKeys: make(map[string]int64),
+	}, nil
+}
+
+// Enabled reports whether new text is sealed
+func (s *Service) Enabled() bool {
+	return s != nil && s.enabled
+}
+
+// Seal encrypts text with the owner's data key, creating the key on first
+// use. additionalData binds the ciphertext to the row it is stored in.
+func (s *Service) Seal(ctx context.Context, owner, additionalData, text string) ([]byte, int64, error) {
+	if !s.Enabled() {
+		return nil, 0, errors.New("encryption at rest is disabled")
+	}
+	keyID, key, err := s.ownerKey(ctx, owner)
+	if err != nil {
+		return nil, 0, err
+	}
+	ciphertext, err := kms.Seal(key.aead, []byte(text), []byte(additionalData))
+	if err != nil {
+		return nil, 0, fmt.Errorf("seal text: %w", err)
+	}
+	return ciphertext, keyID, nil
+}
+
+// Open decrypts text sealed with the data key keyID
+func (s *Service) Open(ctx context.Context, keyID int64, additionalData string, ciphertext []byte) (string, error) {
+	key, err := s.keyByID(ctx, keyID)
+	if err != nil {
+		return "", err
+	}
+	plaintext, err := kms.Open(key.aead, ciphertext, []byte(additionalData))
+	if err != nil {
+		return "", fmt.Errorf("open %s: %w", additionalData, err)
+	}
+	return string(plaintext), nil
+}
+
+// HashKey returns the secret that keys the owner's content hashes, derived
+// from the owner's data key and created with it on first use. A plain hash
+// stored next to sealed text would confirm any guessed text.
+func (s *Service) HashKey(ctx context.Context, owner string) ([]byte, error) {
+	if !s.Enabled() {
+		return nil, errors.New("encryption at rest is disabled")
+	}
+	_, key, err := s.ownerKey(ctx, owner)
+	if err != nil {
+		return nil, err
+	}
+	return key.hashKey, nil
+}
+
+// ownerKey returns the owner's data key, creating it on first use
+func (s *Service) ownerKey(ctx context.Context, owner string) (int64, *dataKey, error) {
+	s.mu.RLock()
+	keyID, ok := s.ownerKeys[owner]
+	key := s.keys[keyID]
+	s.mu.RUnlock()
+	if ok && key != nil {
+		return keyID, key, nil
+	}
+
+	db := s.db.WithContext(ctx)
+	var row dataKeyRow
+	if err := db.Where("owner = ?", owner).Limit(1).Find(&row).Error; err != nil {
+		return 0, nil, fmt.Errorf("load data key: %w", err)
+	}
+	if row.ID == 0 {
+		raw := make([]byte, kms.KeySize)
+		if _, err := rand.Read(raw); err != nil {
+			return 0, nil, err
+		}
+		wrapped, err := s.wrapper.Wrap(ctx, raw)
+		if err != nil {
+			return 0, nil, fmt.Errorf("wrap data key: %w", err)
+		}
+		candidate := dataKeyRow{Owner: owner, WrappedKey: wrapped, KeyProvider: s.wrapper.Name()}
+		err = db.Clauses(clause.OnConflict{Columns: []clause.Column{{Name: "owner"}}, DoNothing: true}).
+			Create(&candidate).Error
+		if err != nil {
+			return 0, nil, fmt.Errorf("create data key: %w", err)
+		}
+		// A concurrent request may have won the insert; everyone uses its key
+		if err := db.Where("owner = ?", owner).Limit(1).Find(&row).Error; err != nil {
+			return 0, nil, fmt.Errorf("load data key: %w", err)
+		}
+	}
+
+	key, err := s.unwrap(ctx, &row)
+	if err != nil {
+		return 0, nil, err
+	}
+	s.mu.Lock()
+	if len(s.ownerKeys) >= maxCachedKeys {
+		s.ownerKeys = make(map[string]int64)
+	}
+	s.ownerKeys[owner] = row.ID
+	s.mu.Unlock()
+	return row.ID, key, nil
+}
+
+// keyByID returns the data key a row was sealed with
+func (s *Service) keyByID(ctx context.Context, keyID int64) (*dataKey, error) {
+	if s == nil || s.wrapper == nil {
+		return nil, errNoKMS
+	}
+	s.mu.RLock()
+	key, ok := s.keys[keyID]
+	s.mu.RUnlock()
+	if ok {
+		return key, nil
+	}
+
+	var row dataKeyRow
+	if err := s.db.WithContext(ctx).Where("id = ?", keyID).Limit(1).Find(&row).Error; err != nil {
+		return nil, fmt.Errorf("load data key: %w", err)
+	}
+	if row.ID == 0 {
+		return nil, fmt.Errorf("data key %d not found", keyID)
+	}
+	return s.unwrap(ctx, &row)
+}
+
+// unwrap decrypts a stored data key with the KMS and caches it
+func (s *Service) unwrap(ctx context.Context, row *dataKeyRow) (*dataKey, error) {
+	if row.KeyProvider != s.wrapper.Name() {
+		return nil, fmt.Errorf("data key %d was wrapped by %s, but the configured KMS is %s", row.ID, row.KeyProvider, s.wrapper.Name())
+	}
+	raw, err := s.wrapper.Unwrap(ctx, row.WrappedKey)
+	if err != nil {
+		return nil, fmt.Errorf("unwrap data key %d: %w", row.ID, err)
+	}
+	aead, err := kms.NewAEAD(raw)
+	if err != nil {
+		return nil, err
+	}
+	mac := hmac.New(sha256.New, raw)
+	mac.Write([]byte(hashKeyLabel))
+	key := &dataKey{aead: aead, hashKey: mac.Sum(nil)}
+
+	s.mu.Lock()
+	if len(s.keys) >= maxCachedKeys {
+		s.keys = make(map[int64]*dataKey)
+	}
+	s.keys[row.ID] = key
+	s.mu.Unlock()
+	return key, nil
+}
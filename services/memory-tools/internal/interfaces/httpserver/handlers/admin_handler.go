@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/janhq/jan-server/services/memory-tools/internal/domain/embedding"
+	"github.com/janhq/jan-server/services/memory-tools/internal/interfaces/httpserver/responses"
+	"github.com/rs/zerolog/log"
+)
+
+// IndexRebuilder rebuilds the vector indexes of an embedding model.
+type IndexRebuilder interface {
+	Rebuild(ctx context.Context, model string, dimension int) error
+}
+
+type AdminHandler struct {
+	indexes    IndexRebuilder
+	models     []embedding.Model
+	rebuilding atomic.Bool
+}
+
+func NewAdminHandler(indexes IndexRebuilder, models []embedding.Model) *AdminHandler {
+	return &AdminHandler{indexes: indexes, models: models}
+}
+
+// RebuildIndexesRequest selects the model whose indexes are rebuilt; all
+// models when empty.
+type RebuildIndexesRequest struct {
+	Model string `json:"model,omitempty"`
+}
+
+// HandleRebuildIndexes handles POST /v1/admin/indexes/rebuild. Rebuilding
+// outlasts the request timeout, so it runs in the background; one rebuild
+// runs at a time.
+func (h *AdminHandler) HandleRebuildIndexes(w http.ResponseWriter, r *http.Request) {
+	logger := log.Ctx(r.Context())
+	if logger == nil {
+		logger = &log.Logger
+	}
+
+	if r.Method != http.MethodPost {
+		responses.Error(w, r, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+
+	var req RebuildIndexesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil && !errors.Is(err, io.EOF) {
+		responses.Error(w, r, http.StatusBadRequest, "invalid request body")
+		return
+	}
+
+	models := h.models
+	if req.Model != "" {
+		models = nil
+		for _, model := range h.models {
+			if model.ID == req.Model {
+				models = []embedding.Model{model}
+			}
+		}
+		if len(models) == 0 {
+			responses.Error(w, r, http.StatusNotFound, "embedding model not configured")
+			return
+		}
+	}
+
+	if !h.rebuilding.CompareAndSwap(false, true) {
+		responses.Error(w, r, http.StatusConflict, "an index rebuild is already running")
+		return
+	}
+
+	ids := make([]string, len(models))
+	for i, model := range models {
+		ids[i] = model.ID
+	}
+	logger.Info().Strs("models", ids).Msg("Vector index rebuild requested")
+
+	ctx := context.WithoutCancel(r.Context())
+	go func() {
+		defer h.rebuilding.Store(false)
+		for _, model := range models {
+			if err := h.indexes.Rebuild(ctx, model.ID, model.Dimension); err != nil {
+				logger.Error().Err(err).Str("model", model.ID).Msg("Vector index rebuild failed")
+			}
+		}
+	}()
+
+	responses.JSON(w, r, http.StatusAccepted, map[string]interface{}{
+		"status": "rebuilding",
+		"models": ids,
+	})
+}
@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 
+	"github.com/janhq/jan-server/services/memory-tools/internal/domain/embedding"
 	"github.com/janhq/jan-server/services/memory-tools/internal/domain/memory"
 	"github.com/janhq/jan-server/services/memory-tools/internal/interfaces/httpserver/responses"
 	"github.com/rs/zerolog/log"
@@ -17,6 +19,17 @@ func NewMemoryHandler(service *memory.Service) *MemoryHandler {
 	return &MemoryHandler{service: service}
 }
 
+// embeddingOverloaded answers 503 when the embedding server had no room for
+// the request, so callers back off and retry.
+func embeddingOverloaded(w http.ResponseWriter, r *http.Request, err error) bool {
+	if !errors.Is(err, embedding.ErrOverloaded) {
+		return false
+	}
+	w.Header().Set("Retry-After", "1")
+	responses.Error(w, r, http.StatusServiceUnavailable, "embedding server overloaded")
+	return true
+}
+
 // HandleLoad handles POST /v1/memory/load
 func (h *MemoryHandler) HandleLoad(w http.ResponseWriter, r *http.Request) {
 	logger := log.Ctx(r.Context())
@@ -56,6 +69,9 @@ func (h *MemoryHandler) HandleLoad(w http.ResponseWriter, r *http.Request) {
 	resp, err := h.service.Load(r.Context(), req)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to load memories")
+		if embeddingOverloaded(w, r, err) {
+			return
+		}
 		responses.Error(w, r, http.StatusInternalServerError, "failed to load memories")
 		return
 	}
@@ -107,6 +123,9 @@ func (h *MemoryHandler) HandleObserve(w http.ResponseWriter, r *http.Request) {
 	// Observe and store
 	if err := h.service.Observe(r.Context(), req); err != nil {
 		logger.Error().Err(err).Msg("Failed to observe memories")
+		if embeddingOverloaded(w, r, err) {
+			return
+		}
 		responses.Error(w, r, http.StatusInternalServerError, "failed to observe memories")
 		return
 	}
@@ -225,6 +244,9 @@ func (h *MemoryHandler) HandleUserUpsert(w http.ResponseWriter, r *http.Request)
 	ids, err := h.service.UpsertUserMemories(r.Context(), req)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to upsert user memories")
+		if embeddingOverloaded(w, r, err) {
+			return
+		}
 		responses.Error(w, r, http.StatusInternalServerError, "failed to upsert user memories")
 		return
 	}
@@ -275,6 +297,9 @@ func (h *MemoryHandler) HandleProjectUpsert(w http.ResponseWriter, r *http.Reque
 	ids, err := h.service.UpsertProjectFacts(r.Context(), req)
 	if err != nil {
 		logger.Error().Err(err).Msg("Failed to upsert project facts")
+		if embeddingOverloaded(w, r, err) {
+			return
+		}
 		responses.Error(w, r, http.StatusInternalServerError, "failed to upsert project facts")
 		return
 	}
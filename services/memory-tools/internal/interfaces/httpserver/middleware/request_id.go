@@ -6,11 +6,14 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/rs/zerolog/log"
+	"go.opentelemetry.io/otel/propagation"
 )
 
 type requestIDKey struct{}
 
 // RequestIDMiddleware ensures every request has an ID and logger bound to the context.
+// The caller's W3C trace context is bound too, so events logged through the
+// context logger carry the caller's trace_id and span_id.
 func RequestIDMiddleware() func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -19,8 +22,9 @@ func RequestIDMiddleware() func(http.Handler) http.Handler {
 				requestID = uuid.NewString()
 			}
 
-			ctx := context.WithValue(r.Context(), requestIDKey{}, requestID)
-			logger := log.With().Str("request_id", requestID).Logger()
+			ctx := propagation.TraceContext{}.Extract(r.Context(), propagation.HeaderCarrier(r.Header))
+			ctx = context.WithValue(ctx, requestIDKey{}, requestID)
+			logger := log.With().Str("request_id", requestID).Ctx(ctx).Logger()
 			ctx = logger.WithContext(ctx)
 
 			w.Header().Set("X-Request-ID", requestID)
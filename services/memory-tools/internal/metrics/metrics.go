@@ -1,37 +1,19 @@
 package metrics
 
 import (
+	"database/sql"
 	"net/http"
 
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"gorm.io/gorm"
 )
 
 // Memory-Tools Metrics
 var (
-	// Request counters
-	RequestsTotal = promauto.NewCounterVec(
-		prometheus.CounterOpts{
-			Namespace: "jan",
-			Subsystem: "memory",
-			Name:      "requests_total",
-			Help:      "Total number of HTTP requests",
-		},
-		[]string{"method", "endpoint", "status"},
-	)
-
-	// Request duration histogram
-	RequestDuration = promauto.NewHistogramVec(
-		prometheus.HistogramOpts{
-			Namespace: "jan",
-			Subsystem: "memory",
-			Name:      "request_duration_seconds",
-			Help:      "HTTP request duration in seconds",
-			Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10},
-		},
-		[]string{"method", "endpoint"},
-	)
+	// HTTP records request counts, duration/status histograms and in-flight requests
+	HTTP = gocommonmetrics.NewHTTPMetrics("jan", "memory", []float64{0.1, 0.5, 1, 2, 5, 10})
 
 	// Memory load operations
 	LoadTotal = promauto.NewCounterVec(
@@ -55,6 +37,16 @@ var (
 		[]string{"status"},
 	)
 
+	// Observed messages skipped because they were observed before
+	ObserveDuplicates = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "memory",
+			Name:      "observe_duplicate_messages_total",
+			Help:      "Observed messages skipped because the conversation already held them",
+		},
+	)
+
 	// Embedding duration
 	EmbeddingDuration = promauto.NewHistogram(
 		prometheus.HistogramOpts{
@@ -66,49 +58,55 @@ var (
 		},
 	)
 
-	// Vector search duration
-	VectorSearchDuration = promauto.NewHistogram(
+	// Texts per request sent to the embedding server
+	EmbeddingBatchSize = promauto.NewHistogram(
 		prometheus.HistogramOpts{
 			Namespace: "jan",
 			Subsystem: "memory",
-			Name:      "vector_search_duration_seconds",
-			Help:      "Vector search duration in seconds",
-			Buckets:   []float64{0.05, 0.1, 0.5, 1, 2},
+			Name:      "embedding_batch_size",
+			Help:      "Texts per embedding server request",
+			Buckets:   []float64{1, 2, 4, 8, 16, 32, 64},
 		},
 	)
 
-	// Cache hits
-	CacheHitsTotal = promauto.NewCounterVec(
+	// Embedding batches refused because the server queue was full
+	EmbeddingRejected = promauto.NewCounter(
 		prometheus.CounterOpts{
 			Namespace: "jan",
 			Subsystem: "memory",
-			Name:      "cache_hits_total",
-			Help:      "Total cache hits",
+			Name:      "embedding_rejected_total",
+			Help:      "Embedding batches refused because the queue was full",
 		},
-		[]string{"cache_type"},
 	)
 
-	// Cache misses
-	CacheMissesTotal = promauto.NewCounterVec(
-		prometheus.CounterOpts{
+	// Vector search duration
+	VectorSearchDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
 			Namespace: "jan",
 			Subsystem: "memory",
-			Name:      "cache_misses_total",
-			Help:      "Total cache misses",
+			Name:      "vector_search_duration_seconds",
+			Help:      "Vector search duration in seconds",
+			Buckets:   []float64{0.05, 0.1, 0.5, 1, 2},
 		},
-		[]string{"cache_type"},
 	)
+
+	// Cache records embedding cache hits, misses and the running hit ratio
+	Cache = gocommonmetrics.NewCacheMetrics("jan", "memory")
 )
 
 // Handler returns the Prometheus metrics handler
 func Handler() http.Handler {
-	return promhttp.Handler()
+	return gocommonmetrics.Handler()
+}
+
+// RegisterDBPool exposes the database connection pool gauges
+func RegisterDBPool(db *sql.DB, dbName string) error {
+	return gocommonmetrics.RegisterDBPool(db, dbName)
 }
 
-// RecordRequest records an HTTP request
-func RecordRequest(method, endpoint, status string, durationSec float64) {
-	RequestsTotal.WithLabelValues(method, endpoint, status).Inc()
-	RequestDuration.WithLabelValues(method, endpoint).Observe(durationSec)
+// RegisterDBQueries times every GORM query on db
+func RegisterDBQueries(db *gorm.DB, dbName string) error {
+	return db.Use(gocommonmetrics.NewGormPlugin(dbName))
 }
 
 // RecordLoad records a memory load operation
@@ -121,11 +119,26 @@ func RecordObserve(status string) {
 	ObserveTotal.WithLabelValues(status).Inc()
 }
 
+// RecordObserveDuplicates records observed messages skipped as duplicates
+func RecordObserveDuplicates(count int) {
+	ObserveDuplicates.Add(float64(count))
+}
+
 // RecordEmbedding records embedding computation time
 func RecordEmbedding(durationSec float64) {
 	EmbeddingDuration.Observe(durationSec)
 }
 
+// RecordEmbeddingBatch records the size of an embedding server request
+func RecordEmbeddingBatch(size int) {
+	EmbeddingBatchSize.Observe(float64(size))
+}
+
+// RecordEmbeddingRejected records an embedding batch refused by backpressure
+func RecordEmbeddingRejected() {
+	EmbeddingRejected.Inc()
+}
+
 // RecordVectorSearch records vector search time
 func RecordVectorSearch(durationSec float64) {
 	VectorSearchDuration.Observe(durationSec)
@@ -133,10 +146,10 @@ func RecordVectorSearch(durationSec float64) {
 
 // RecordCacheHit records a cache hit
 func RecordCacheHit(cacheType string) {
-	CacheHitsTotal.WithLabelValues(cacheType).Inc()
+	Cache.Hit(cacheType)
 }
 
 // RecordCacheMiss records a cache miss
 func RecordCacheMiss(cacheType string) {
-	CacheMissesTotal.WithLabelValues(cacheType).Inc()
+	Cache.Miss(cacheType)
 }
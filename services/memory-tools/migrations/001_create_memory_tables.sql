@@ -37,11 +37,7 @@ CREATE INDEX IF NOT EXISTS idx_user_memory_scope ON memory_tools.user_memory_ite
 CREATE INDEX IF NOT EXISTS idx_user_memory_score ON memory_tools.user_memory_items(score DESC) WHERE is_deleted = FALSE;
 CREATE INDEX IF NOT EXISTS idx_user_memory_updated_at ON memory_tools.user_memory_items(updated_at DESC);
 
--- Vector similarity index (IVFFlat for fast approximate search)
-CREATE INDEX IF NOT EXISTS idx_user_memory_embedding ON memory_tools.user_memory_items 
-USING ivfflat (embedding vector_cosine_ops) 
-WITH (lists = 100)
-WHERE is_deleted = FALSE;
+-- Vector similarity indexes are created per embedding model at startup (see 003)
 
 -- Project Facts Table
 CREATE TABLE IF NOT EXISTS memory_tools.project_facts (
@@ -67,11 +63,7 @@ CREATE INDEX IF NOT EXISTS idx_project_facts_kind ON memory_tools.project_facts(
 CREATE INDEX IF NOT EXISTS idx_project_facts_confidence ON memory_tools.project_facts(confidence DESC) WHERE is_deleted = FALSE;
 CREATE INDEX IF NOT EXISTS idx_project_facts_updated_at ON memory_tools.project_facts(updated_at DESC);
 
--- Vector similarity index
-CREATE INDEX IF NOT EXISTS idx_project_facts_embedding ON memory_tools.project_facts 
-USING ivfflat (embedding vector_cosine_ops) 
-WITH (lists = 100)
-WHERE is_deleted = FALSE;
+-- Vector similarity indexes are created per embedding model at startup (see 003)
 
 -- Update constraints to allow all supported kinds/scopes
 ALTER TABLE memory_tools.user_memory_items DROP CONSTRAINT IF EXISTS user_memory_scope_check;
@@ -103,11 +95,7 @@ CREATE INDEX IF NOT EXISTS idx_episodic_events_conversation_id ON memory_tools.e
 CREATE INDEX IF NOT EXISTS idx_episodic_events_time ON memory_tools.episodic_events(time DESC) WHERE is_deleted = FALSE;
 CREATE INDEX IF NOT EXISTS idx_episodic_events_kind ON memory_tools.episodic_events(kind) WHERE is_deleted = FALSE;
 
--- Vector similarity index
-CREATE INDEX IF NOT EXISTS idx_episodic_events_embedding ON memory_tools.episodic_events 
-USING ivfflat (embedding vector_cosine_ops) 
-WITH (lists = 100)
-WHERE is_deleted = FALSE;
+-- Vector similarity indexes are created per embedding model at startup (see 003)
 
 -- Conversation Items Table (for storing raw conversation history)
 CREATE TABLE IF NOT EXISTS memory_tools.conversation_items (
@@ -131,9 +119,9 @@ COMMENT ON TABLE project_facts IS 'Project-level facts, decisions, and requireme
 COMMENT ON TABLE episodic_events IS 'Time-bound events and interactions';
 COMMENT ON TABLE conversation_items IS 'Raw conversation history for memory extraction';
 
-COMMENT ON COLUMN user_memory_items.embedding IS 'BGE-M3 1024-dimensional embedding vector';
-COMMENT ON COLUMN project_facts.embedding IS 'BGE-M3 1024-dimensional embedding vector';
-COMMENT ON COLUMN episodic_events.embedding IS 'BGE-M3 1024-dimensional embedding vector';
+COMMENT ON COLUMN user_memory_items.embedding IS 'Embedding vector of the model named in embedding_model';
+COMMENT ON COLUMN project_facts.embedding IS 'Embedding vector of the model named in embedding_model';
+COMMENT ON COLUMN episodic_events.embedding IS 'Embedding vector of the model named in embedding_model';
 
 COMMENT ON COLUMN user_memory_items.score IS 'Importance level: 1=low, 2=medium, 3=normal, 4=high, 5=critical';
 COMMENT ON COLUMN project_facts.confidence IS 'Confidence level: 0.0-1.0, higher is more confident';
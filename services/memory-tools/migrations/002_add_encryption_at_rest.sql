@@ -0,0 +1,32 @@
+-- Migration: Encryption at rest of memory text
+-- Version: 002
+-- Date: 2026-10-16
+
+-- Data keys (AES-256) wrapped by the KMS master key (ENCRYPTION_KMS_PROVIDER).
+-- Owners are "user:<id>" for user memory and episodic events, "project:<id>"
+-- for project facts and "conversation:<id>" for stored conversation messages.
+CREATE TABLE IF NOT EXISTS memory_tools.data_keys (
+    id SERIAL PRIMARY KEY,
+    owner VARCHAR(300) NOT NULL UNIQUE,
+    wrapped_key BYTEA NOT NULL,
+    key_provider VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP NOT NULL DEFAULT NOW()
+);
+
+-- Sealed rows keep an empty text column and store the text in *_ciphertext;
+-- data_key_id names the key that sealed them.
+ALTER TABLE memory_tools.user_memory_items
+    ADD COLUMN IF NOT EXISTS text_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS data_key_id INTEGER;
+
+ALTER TABLE memory_tools.project_facts
+    ADD COLUMN IF NOT EXISTS text_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS data_key_id INTEGER;
+
+ALTER TABLE memory_tools.episodic_events
+    ADD COLUMN IF NOT EXISTS text_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS data_key_id INTEGER;
+
+ALTER TABLE memory_tools.conversation_items
+    ADD COLUMN IF NOT EXISTS content_ciphertext BYTEA,
+    ADD COLUMN IF NOT EXISTS data_key_id INTEGER;
@@ -0,0 +1,33 @@
+-- Migration: Embeddings from multiple models
+-- Version: 003
+-- Date: 2026-10-16
+
+-- Each vector records the model that produced it, and the embedding columns
+-- accept any dimension. Vectors stored before this migration came from
+-- BGE-M3. Vector indexes are created per configured model at startup, as
+-- partial indexes over that model's rows cast to its dimension.
+
+-- The original single-model indexes cannot cover columns without a dimension
+DROP INDEX IF EXISTS memory_tools.idx_user_memory_embedding;
+DROP INDEX IF EXISTS memory_tools.idx_project_facts_embedding;
+DROP INDEX IF EXISTS memory_tools.idx_episodic_events_embedding;
+
+DO $$
+DECLARE
+    tbl TEXT;
+BEGIN
+    FOREACH tbl IN ARRAY ARRAY['user_memory_items', 'project_facts', 'episodic_events'] LOOP
+        EXECUTE format('ALTER TABLE memory_tools.%I ADD COLUMN IF NOT EXISTS embedding_model VARCHAR(200)', tbl);
+
+        -- Runs once: afterwards the column no longer has a fixed dimension
+        IF EXISTS (
+            SELECT 1 FROM pg_attribute
+            WHERE attrelid = format('memory_tools.%I', tbl)::regclass
+              AND attname = 'embedding'
+              AND atttypmod > 0
+        ) THEN
+            EXECUTE format('UPDATE memory_tools.%I SET embedding_model = %L WHERE embedding_model IS NULL AND embedding IS NOT NULL', tbl, 'BAAI/bge-m3');
+            EXECUTE format('ALTER TABLE memory_tools.%I ALTER COLUMN embedding TYPE vector', tbl);
+        END IF;
+    END LOOP;
+END $$;
@@ -0,0 +1,39 @@
+-- Migration: Idempotent memory observation
+-- Version: 004
+-- Date: 2026-10-16
+
+-- Observed messages are keyed by the caller's item ID, or a hash of the
+-- message when it sends none; a conversation stores each key once and
+-- observing it again extracts nothing.
+ALTER TABLE memory_tools.conversation_items
+    ADD COLUMN IF NOT EXISTS item_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_conversation_items_item_id
+    ON memory_tools.conversation_items(conversation_id, item_id)
+    WHERE item_id IS NOT NULL;
+
+-- Hash of the lower-cased, whitespace-collapsed text: SHA-256 while
+-- encryption at rest is off, otherwise an HMAC-SHA256 keyed with a secret
+-- derived from the owner's data key, which can't be recomputed from the
+-- database alone. Each owner keeps one live memory per hash; rows stored
+-- before this migration have none and are not deduplicated.
+ALTER TABLE memory_tools.user_memory_items
+    ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+ALTER TABLE memory_tools.project_facts
+    ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+ALTER TABLE memory_tools.episodic_events
+    ADD COLUMN IF NOT EXISTS content_hash VARCHAR(64);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_user_memory_content_hash
+    ON memory_tools.user_memory_items(user_id, content_hash)
+    WHERE is_deleted = FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_project_facts_content_hash
+    ON memory_tools.project_facts(project_id, content_hash)
+    WHERE is_deleted = FALSE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_episodic_events_content_hash
+    ON memory_tools.episodic_events(user_id, conversation_id, content_hash)
+    WHERE is_deleted = FALSE;
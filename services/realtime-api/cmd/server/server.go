@@ -31,6 +31,7 @@ import (
 	"github.com/rs/zerolog"
 
 	"jan-server/services/realtime-api/internal/config"
+	"jan-server/services/realtime-api/internal/infrastructure/logger"
 	"jan-server/services/realtime-api/internal/infrastructure/observability"
 	"jan-server/services/realtime-api/internal/infrastructure/store"
 	"jan-server/services/realtime-api/internal/interfaces/httpserver"
@@ -70,6 +71,13 @@ func main() {
 		panic(fmt.Sprintf("failed to create application: %v", err))
 	}
 
+	defer func() {
+		// Flush exported logs last, after the shutdown errors are logged
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), app.Cfg.ShutdownTimeout)
+		defer cancel()
+		_ = logger.Shutdown(shutdownCtx)
+	}()
+
 	// Setup observability
 	shutdownTelemetry, err := observability.Setup(ctx, app.Cfg, app.Log)
 	if err != nil {
@@ -29,8 +29,13 @@ func CreateApplication(ctx context.Context) (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	httpServer := httpserver.New(config, logger, service, validator)
 	roomClient := infrastructure.ProvideRoomClient(config)
+	checker := infrastructure.ProvideReadinessChecker(config, roomClient)
+	maintenanceService, err := infrastructure.ProvideMaintenance(config, logger)
+	if err != nil {
+		return nil, err
+	}
+	httpServer := httpserver.New(config, logger, service, validator, checker, maintenanceService)
 	syncer := infrastructure.ProvideSyncer(store, roomClient, config, logger)
 	application := &Application{
 		HTTPServer: httpServer,
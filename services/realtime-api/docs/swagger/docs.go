@@ -30,7 +30,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Lists all active sessions for the current user",
+                "description": "Lists all active sessions for the current user, newest first. Filter by ` + "`" + `conversation_id` + "`" + ` to find the sessions that continued a conversation.",
                 "produces": [
                     "application/json"
                 ],
@@ -38,6 +38,14 @@ const docTemplate = `{
                     "Realtime API"
                 ],
                 "summary": "List realtime sessions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Only list sessions bound to this conversation",
+                        "name": "conversation_id",
+                        "in": "query"
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
@@ -65,7 +73,7 @@ const docTemplate = `{
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a new realtime session with LiveKit token. No request body required.",
+                "description": "Creates a new realtime session with a LiveKit token. The body is optional: by default the token can publish and subscribe and is valid for LIVEKIT_TOKEN_TTL.\n` + "`" + `preset` + "`" + ` selects the room permissions (` + "`" + `publisher` + "`" + `, ` + "`" + `subscriber` + "`" + ` or a hidden ` + "`" + `observer` + "`" + `), ` + "`" + `can_publish_sources` + "`" + ` limits a publisher to some tracks, and ` + "`" + `expires_in` + "`" + ` sets the token lifetime in seconds, up to LIVEKIT_TOKEN_MAX_TTL.\n` + "`" + `conversation_id` + "`" + ` binds the session to an existing conversation: the token carries it as the ` + "`" + `conversation_id` + "`" + ` participant attribute so the agent continues that conversation's history, which lets a client reconnect in a new room without losing context.",
                 "consumes": [
                     "application/json"
                 ],
@@ -76,6 +84,16 @@ const docTemplate = `{
                     "Realtime API"
                 ],
                 "summary": "Create a realtime session",
+                "parameters": [
+                    {
+                        "description": "Token permissions and lifetime",
+                        "name": "request",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/sessionreq.CreateSessionRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "201": {
                         "description": "Created",
@@ -83,6 +101,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/sessionres.SessionResponse"
                         }
                     },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -199,6 +223,126 @@ const docTemplate = `{
                     }
                 }
             }
+        },
+        "/realtime/sessions/{id}/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns how long the session has been connected and a summary of the connection quality, packet loss, jitter and round-trip time its client reported. Users can only access their own sessions.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime API"
+                ],
+                "summary": "Get session connection stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sessionres.SessionStatsResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Records a connection report from the session's client. LiveKit only sends connection quality to the participants themselves, so clients should forward it, with the packet loss, jitter and round-trip time from their WebRTC stats, every few seconds while connected.\nReports are exported as Prometheus metrics and summarized by GET /realtime/sessions/{id}/stats.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Realtime API"
+                ],
+                "summary": "Report session connection stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Connection report",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/sessionreq.ReportStatsRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/sessionres.SessionStatsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/responses.ErrorResponse"
+                        }
+                    }
+                }
+            }
         }
     },
     "definitions": {
@@ -224,6 +368,84 @@ const docTemplate = `{
                 }
             }
         },
+        "sessionreq.CreateSessionRequest": {
+            "type": "object",
+            "properties": {
+                "can_publish_data": {
+                    "description": "CanPublishData overrides whether the participant can send data messages.",
+                    "type": "boolean"
+                },
+                "can_publish_sources": {
+                    "description": "CanPublishSources limits a publisher to these track sources.",
+                    "type": "array",
+                    "items": {
+                        "type": "string",
+                        "enum": [
+                            "camera",
+                            "microphone",
+                            "screen_share",
+                            "screen_share_audio"
+                        ]
+                    }
+                },
+                "conversation_id": {
+                    "description": "ConversationID binds the session to an existing llm-api conversation, so a\nclient reconnecting in a new room continues the same history.",
+                    "type": "string"
+                },
+                "expires_in": {
+                    "description": "ExpiresIn is the token lifetime in seconds, from 60 up to LIVEKIT_TOKEN_MAX_TTL.\nDefaults to LIVEKIT_TOKEN_TTL.",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "model": {
+                    "description": "Model is an optional model identifier for future use.",
+                    "type": "string"
+                },
+                "preset": {
+                    "description": "Preset selects the participant's room permissions: publisher (default),\nsubscriber (receive only) or observer (receive only, hidden from others).",
+                    "type": "string",
+                    "enum": [
+                        "publisher",
+                        "subscriber",
+                        "observer"
+                    ]
+                },
+                "voice": {
+                    "description": "Voice is an optional voice identifier for future use.",
+                    "type": "string"
+                }
+            }
+        },
+        "sessionreq.ReportStatsRequest": {
+            "type": "object",
+            "required": [
+                "quality"
+            ],
+            "properties": {
+                "jitter_ms": {
+                    "description": "JitterMs is the current jitter in milliseconds.",
+                    "type": "number"
+                },
+                "packet_loss": {
+                    "description": "PacketLoss is the percentage of packets lost since the previous report.",
+                    "type": "number"
+                },
+                "quality": {
+                    "description": "Quality is the participant's LiveKit connection quality.",
+                    "type": "string",
+                    "enum": [
+                        "excellent",
+                        "good",
+                        "poor",
+                        "lost"
+                    ]
+                },
+                "round_trip_ms": {
+                    "description": "RoundTripMs is the round-trip time to the LiveKit server in milliseconds.",
+                    "type": "number"
+                }
+            }
+        },
         "sessionres.ClientSecretDetail": {
             "type": "object",
             "properties": {
@@ -263,18 +485,81 @@ const docTemplate = `{
                 }
             }
         },
+        "sessionres.MeasurementDetail": {
+            "type": "object",
+            "properties": {
+                "avg": {
+                    "type": "number"
+                },
+                "max": {
+                    "type": "number"
+                },
+                "samples": {
+                    "type": "integer"
+                }
+            }
+        },
+        "sessionres.PermissionsDetail": {
+            "type": "object",
+            "properties": {
+                "can_publish": {
+                    "type": "boolean"
+                },
+                "can_publish_data": {
+                    "type": "boolean"
+                },
+                "can_publish_sources": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "can_subscribe": {
+                    "type": "boolean"
+                },
+                "hidden": {
+                    "type": "boolean"
+                },
+                "preset": {
+                    "type": "string"
+                }
+            }
+        },
+        "sessionres.QualityDetail": {
+            "type": "object",
+            "properties": {
+                "counts": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "last": {
+                    "type": "string"
+                },
+                "last_report_at": {
+                    "type": "integer"
+                }
+            }
+        },
         "sessionres.SessionResponse": {
             "type": "object",
             "properties": {
                 "client_secret": {
                     "$ref": "#/definitions/sessionres.ClientSecretDetail"
                 },
+                "conversation_id": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
                 "object": {
                     "type": "string"
                 },
+                "permissions": {
+                    "$ref": "#/definitions/sessionres.PermissionsDetail"
+                },
                 "room_id": {
                     "type": "string"
                 },
@@ -288,6 +573,44 @@ const docTemplate = `{
                     "type": "string"
                 }
             }
+        },
+        "sessionres.SessionStatsResponse": {
+            "type": "object",
+            "properties": {
+                "connected_at": {
+                    "type": "integer"
+                },
+                "connection_quality": {
+                    "$ref": "#/definitions/sessionres.QualityDetail"
+                },
+                "created_at": {
+                    "type": "integer"
+                },
+                "duration_seconds": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "jitter_ms": {
+                    "$ref": "#/definitions/sessionres.MeasurementDetail"
+                },
+                "object": {
+                    "type": "string"
+                },
+                "packet_loss": {
+                    "$ref": "#/definitions/sessionres.MeasurementDetail"
+                },
+                "reports": {
+                    "type": "integer"
+                },
+                "round_trip_ms": {
+                    "$ref": "#/definitions/sessionres.MeasurementDetail"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
         }
     },
     "securityDefinitions": {
@@ -20,18 +20,28 @@ type Config struct {
 	// OpenTelemetry
 	EnableTracing bool   `env:"OTEL_ENABLED" envDefault:"false"`
 	OTLPEndpoint  string `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:""`
+	OTLPHeaders   string `env:"OTEL_EXPORTER_OTLP_HEADERS"`           // Sent with exported logs
+	LogExporter   string `env:"OTEL_LOGS_EXPORTER" envDefault:"none"` // otlp: also export logs to OTLPEndpoint
 
 	// Auth (Keycloak) - uses global auth vars
 	AuthEnabled  bool   `env:"AUTH_ENABLED" envDefault:"false"`
 	AuthIssuer   string `env:"ISSUER"`
 	AuthAudience string `env:"AUDIENCE"`
 	AuthJWKSURL  string `env:"JWKS_URL"`
+	// Third-party clients whose exchanged tokens only llm-api accepts; rejected here
+	IntegrationClients []string `env:"INTEGRATION_CLIENTS" envSeparator:","`
+
+	// Maintenance mode switched on llm-api, shared through this Redis; off when unset
+	RedisURL                   string        `env:"REDIS_URL"`
+	MaintenanceRefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL" envDefault:"5s"`
 
 	// LiveKit
 	LiveKitWsURL     string        `env:"LIVEKIT_WS_URL" envDefault:"ws://localhost:7880"`
 	LiveKitAPIKey    string        `env:"LIVEKIT_API_KEY"`
 	LiveKitAPISecret string        `env:"LIVEKIT_API_SECRET"`
 	LiveKitTokenTTL  time.Duration `env:"LIVEKIT_TOKEN_TTL" envDefault:"24h"`
+	// Longest token lifetime a session request can ask for with expires_in
+	LiveKitTokenMaxTTL time.Duration `env:"LIVEKIT_TOKEN_MAX_TTL" envDefault:"24h"`
 
 	// Session Management
 	SessionCleanupInterval time.Duration `env:"SESSION_CLEANUP_INTERVAL" envDefault:"15s"`
@@ -65,6 +75,9 @@ func Load() (*Config, error) {
 	if strings.TrimSpace(cfg.LiveKitAPISecret) == "" {
 		return nil, fmt.Errorf("LIVEKIT_API_SECRET is required")
 	}
+	if cfg.LiveKitTokenTTL > cfg.LiveKitTokenMaxTTL {
+		return nil, fmt.Errorf("LIVEKIT_TOKEN_TTL must not exceed LIVEKIT_TOKEN_MAX_TTL")
+	}
 
 	return cfg, nil
 }
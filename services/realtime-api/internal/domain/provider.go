@@ -20,6 +20,7 @@ func ProvideSessionService(
 		tokenGen,
 		cfg.LiveKitWsURL,
 		cfg.LiveKitTokenTTL,
+		cfg.LiveKitTokenMaxTTL,
 		log,
 	)
 }
@@ -21,11 +21,16 @@ type Session struct {
 	RoomID       string        `json:"room_id,omitempty"`
 	UserID       string        `json:"user_id,omitempty"`
 	Status       SessionState  `json:"status,omitempty"` // connection status for GET responses
+	Permissions  Permissions   `json:"permissions"`      // room grants of the token
+	// ConversationID is the llm-api conversation the session continues, if any
+	ConversationID string `json:"conversation_id,omitempty"`
 
 	// Internal tracking (not serialized to JSON response)
-	Room      string    `json:"-"` // internal room name (same as RoomID)
-	State     SessionState `json:"-"` // internal state tracking
-	CreatedAt time.Time `json:"-"`
+	Room        string       `json:"-"` // internal room name (same as RoomID)
+	State       SessionState `json:"-"` // internal state tracking
+	CreatedAt   time.Time    `json:"-"`
+	ConnectedAt time.Time    `json:"-"` // when a participant first joined the room
+	Stats       Stats        `json:"-"` // connection reports from the client
 }
 
 // ClientSecret contains the ephemeral token for client authentication.
@@ -34,10 +39,14 @@ type ClientSecret struct {
 	ExpiresAt int64  `json:"expires_at"` // actual token expiry timestamp
 }
 
-// CreateSessionRequest is the request body for creating a session.
-// Currently empty - no parameters required.
+// CreateSessionRequest holds the token options of a new session. The zero
+// value creates a publisher token valid for LIVEKIT_TOKEN_TTL.
 type CreateSessionRequest struct {
-	// No parameters - session creation uses server defaults
+	Preset            Preset        // Defaults to PresetPublisher
+	CanPublishSources []string      // Limits a publisher to these track sources
+	CanPublishData    *bool         // Overrides the preset's data permission
+	TTL               time.Duration // Token lifetime; zero uses LIVEKIT_TOKEN_TTL
+	ConversationID    string        // Conversation to continue; empty starts without one
 }
 
 // ListSessionsResponse is the response for listing sessions.
@@ -0,0 +1,79 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Preset names a set of LiveKit room permissions for a session's participant.
+type Preset string
+
+const (
+	// PresetPublisher can publish audio, video and data and subscribe to others (default).
+	PresetPublisher Preset = "publisher"
+	// PresetSubscriber can only receive the room's tracks and data.
+	PresetSubscriber Preset = "subscriber"
+	// PresetObserver subscribes without appearing in the room's participant list.
+	PresetObserver Preset = "observer"
+)
+
+// TrackSources are the LiveKit track sources a publisher can be limited to.
+var TrackSources = []string{"camera", "microphone", "screen_share", "screen_share_audio"}
+
+// Permissions are the room grants carried by a session's LiveKit token.
+type Permissions struct {
+	Preset         Preset   `json:"preset"`
+	CanPublish     bool     `json:"can_publish"`
+	CanSubscribe   bool     `json:"can_subscribe"`
+	CanPublishData bool     `json:"can_publish_data"`
+	Hidden         bool     `json:"hidden"`
+	Sources        []string `json:"can_publish_sources,omitempty"` // Empty allows every source
+}
+
+// PresetPermissions returns the grants of a preset, or false for an unknown preset.
+func PresetPermissions(preset Preset) (Permissions, bool) {
+	switch preset {
+	case PresetPublisher:
+		return Permissions{Preset: preset, CanPublish: true, CanSubscribe: true, CanPublishData: true}, true
+	case PresetSubscriber:
+		return Permissions{Preset: preset, CanSubscribe: true}, true
+	case PresetObserver:
+		return Permissions{Preset: preset, CanSubscribe: true, Hidden: true}, true
+	default:
+		return Permissions{}, false
+	}
+}
+
+// ResolvePermissions applies the track-level overrides of req to its preset.
+func ResolvePermissions(req *CreateSessionRequest) (Permissions, error) {
+	preset := req.Preset
+	if preset == "" {
+		preset = PresetPublisher
+	}
+	perms, ok := PresetPermissions(preset)
+	if !ok {
+		return Permissions{}, fmt.Errorf("preset must be one of %s, %s or %s", PresetPublisher, PresetSubscriber, PresetObserver)
+	}
+
+	if len(req.CanPublishSources) > 0 {
+		if !perms.CanPublish {
+			return Permissions{}, fmt.Errorf("can_publish_sources requires the %s preset", PresetPublisher)
+		}
+		for _, source := range req.CanPublishSources {
+			if !slices.Contains(TrackSources, source) {
+				return Permissions{}, fmt.Errorf("can_publish_sources must only contain %s", strings.Join(TrackSources, ", "))
+			}
+			if !slices.Contains(perms.Sources, source) {
+				perms.Sources = append(perms.Sources, source)
+			}
+		}
+	}
+	if req.CanPublishData != nil {
+		if *req.CanPublishData && perms.Hidden {
+			return Permissions{}, fmt.Errorf("hidden observers cannot publish data")
+		}
+		perms.CanPublishData = *req.CanPublishData
+	}
+	return perms, nil
+}
@@ -2,46 +2,82 @@ package session
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/rs/zerolog"
 
+	"jan-server/services/realtime-api/internal/infrastructure/metrics"
 	"jan-server/services/realtime-api/internal/utils/idgen"
+	"jan-server/services/realtime-api/internal/utils/platformerrors"
 )
 
+// MinTokenTTL is the shortest token lifetime a session can ask for.
+const MinTokenTTL = time.Minute
+
+// ConversationAttribute is the participant attribute that carries the
+// session's conversation ID, so the agent in the room can continue it.
+const ConversationAttribute = "conversation_id"
+
 // TokenGenerator defines the interface for generating LiveKit tokens.
 type TokenGenerator interface {
-	Generate(room, identity string, ttl time.Duration) (token string, err error)
+	Generate(room, identity string, perms Permissions, attributes map[string]string, ttl time.Duration) (token string, err error)
 }
 
 // Service defines the business operations for session management.
 type Service interface {
 	CreateSession(ctx context.Context, req *CreateSessionRequest, userID string) (*Session, error)
 	GetSession(ctx context.Context, id string) (*Session, error)
-	ListUserSessions(ctx context.Context, userID string) ([]*Session, error)
+	ListUserSessions(ctx context.Context, userID, conversationID string) ([]*Session, error)
 	DeleteSession(ctx context.Context, id string) error
+	ReportStats(ctx context.Context, id string, report StatsReport) (*SessionStats, error)
+	GetSessionStats(ctx context.Context, id string) (*SessionStats, error)
 }
 
 type service struct {
-	store    Store
-	tokenGen TokenGenerator
-	wsURL    string
-	tokenTTL time.Duration
-	log      zerolog.Logger
+	store       Store
+	tokenGen    TokenGenerator
+	wsURL       string
+	tokenTTL    time.Duration
+	maxTokenTTL time.Duration
+	log         zerolog.Logger
 }
 
-// NewService creates a new session service.
-func NewService(store Store, tokenGen TokenGenerator, wsURL string, tokenTTL time.Duration, log zerolog.Logger) Service {
+// NewService creates a new session service. Tokens are valid for tokenTTL
+// unless a request asks for a lifetime of up to maxTokenTTL.
+func NewService(store Store, tokenGen TokenGenerator, wsURL string, tokenTTL, maxTokenTTL time.Duration, log zerolog.Logger) Service {
 	return &service{
-		store:    store,
-		tokenGen: tokenGen,
-		wsURL:    wsURL,
-		tokenTTL: tokenTTL,
-		log:      log.With().Str("component", "session-service").Logger(),
+		store:       store,
+		tokenGen:    tokenGen,
+		wsURL:       wsURL,
+		tokenTTL:    tokenTTL,
+		maxTokenTTL: maxTokenTTL,
+		log:         log.With().Str("component", "session-service").Logger(),
 	}
 }
 
 func (s *service) CreateSession(ctx context.Context, req *CreateSessionRequest, userID string) (*Session, error) {
+	if req == nil {
+		req = &CreateSessionRequest{}
+	}
+	perms, err := ResolvePermissions(req)
+	if err != nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, err.Error(), err, "5d8e2a47-1c9f-4b36-a0e7-3f6b9c2d8e14")
+	}
+	ttl := s.tokenTTL
+	if req.TTL != 0 {
+		if req.TTL < MinTokenTTL || req.TTL > s.maxTokenTTL {
+			return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+				fmt.Sprintf("expires_in must be between %d and %d seconds", int(MinTokenTTL.Seconds()), int(s.maxTokenTTL.Seconds())), nil, "a3f7c1e9-6b2d-4e58-9d04-7c1b5e8a2f63")
+		}
+		ttl = req.TTL
+	}
+	if req.ConversationID != "" && !idgen.ValidateIDFormat(req.ConversationID, "conv") {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation,
+			"conversation_id must be a conversation ID like conv_abc123", nil, "e6b1d9a4-2f7c-4c83-8a5e-1d0f3b7c9e52")
+	}
+
 	sessionID, err := idgen.GenerateSecureID("sess", 24)
 	if err != nil {
 		s.log.Error().Err(err).Msg("failed to generate session ID")
@@ -63,15 +99,20 @@ func (s *service) CreateSession(ctx context.Context, req *CreateSessionRequest,
 		}
 	}
 
+	var attributes map[string]string
+	if req.ConversationID != "" {
+		attributes = map[string]string{ConversationAttribute: req.ConversationID}
+	}
+
 	// Generate LiveKit token
-	token, err := s.tokenGen.Generate(roomID, identity, s.tokenTTL)
+	token, err := s.tokenGen.Generate(roomID, identity, perms, attributes, ttl)
 	if err != nil {
 		s.log.Error().Err(err).Str("session_id", sessionID).Msg("failed to generate token")
 		return nil, err
 	}
 
 	now := time.Now()
-	tokenExpiresAt := now.Add(s.tokenTTL)
+	tokenExpiresAt := now.Add(ttl)
 
 	// Build session
 	session := &Session{
@@ -81,12 +122,14 @@ func (s *service) CreateSession(ctx context.Context, req *CreateSessionRequest,
 			Value:     token,
 			ExpiresAt: tokenExpiresAt.Unix(),
 		},
-		WsURL:     s.wsURL,
-		RoomID:    roomID,
-		UserID:    userID,
-		Room:      roomID, // internal tracking
-		State:     StateCreated,
-		CreatedAt: now,
+		WsURL:          s.wsURL,
+		RoomID:         roomID,
+		UserID:         userID,
+		Permissions:    perms,
+		ConversationID: req.ConversationID,
+		Room:           roomID, // internal tracking
+		State:          StateCreated,
+		CreatedAt:      now,
 	}
 
 	if err := s.store.Create(ctx, session); err != nil {
@@ -98,6 +141,9 @@ func (s *service) CreateSession(ctx context.Context, req *CreateSessionRequest,
 		Str("session_id", sessionID).
 		Str("user_id", userID).
 		Str("room_id", roomID).
+		Str("conversation_id", req.ConversationID).
+		Str("preset", string(perms.Preset)).
+		Dur("token_ttl", ttl).
 		Str("state", string(StateCreated)).
 		Msg("session created")
 
@@ -108,14 +154,79 @@ func (s *service) GetSession(ctx context.Context, id string) (*Session, error) {
 	return s.store.Get(ctx, id)
 }
 
-func (s *service) ListUserSessions(ctx context.Context, userID string) ([]*Session, error) {
-	return s.store.GetByUser(ctx, userID)
+func (s *service) ListUserSessions(ctx context.Context, userID, conversationID string) ([]*Session, error) {
+	sessions, err := s.store.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if conversationID != "" {
+		sessions = slices.DeleteFunc(sessions, func(sess *Session) bool {
+			return sess.ConversationID != conversationID
+		})
+	}
+	// Newest first, so the latest session of a conversation leads the list
+	slices.SortFunc(sessions, func(a, b *Session) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return sessions, nil
 }
 
 func (s *service) DeleteSession(ctx context.Context, id string) error {
+	sess, err := s.store.Get(ctx, id)
+	if err != nil {
+		return err
+	}
 	if err := s.store.Delete(ctx, id); err != nil {
 		return err
 	}
-	s.log.Info().Str("session_id", id).Msg("session deleted")
+	duration := sess.ConnectedDuration(time.Now())
+	metrics.RecordSessionEnded(duration)
+	s.log.Info().Str("session_id", id).Dur("duration", duration).Msg("session deleted")
 	return nil
 }
+
+func (s *service) ReportStats(ctx context.Context, id string, report StatsReport) (*SessionStats, error) {
+	if err := report.Validate(); err != nil {
+		return nil, platformerrors.NewError(ctx, platformerrors.LayerDomain, platformerrors.ErrorTypeValidation, err.Error(), err, "8c2e6f14-3a9b-4d71-b5e0-9f4a7c1d2e83")
+	}
+	sess, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.store.RecordStats(ctx, id, report, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	metrics.RecordConnectionReport(string(report.Quality), report.PacketLoss, report.JitterMs, report.RoundTripMs)
+	if report.Quality == QualityPoor || report.Quality == QualityLost {
+		s.log.Warn().
+			Str("session_id", id).
+			Str("user_id", sess.UserID).
+			Str("quality", string(report.Quality)).
+			Msg("degraded connection reported")
+	}
+	return newSessionStats(sess, stats), nil
+}
+
+func (s *service) GetSessionStats(ctx context.Context, id string) (*SessionStats, error) {
+	sess, err := s.store.Get(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	stats, err := s.store.GetStats(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return newSessionStats(sess, stats), nil
+}
+
+func newSessionStats(sess *Session, stats Stats) *SessionStats {
+	return &SessionStats{
+		SessionID:   sess.ID,
+		State:       sess.State,
+		CreatedAt:   sess.CreatedAt,
+		ConnectedAt: sess.ConnectedAt,
+		Duration:    sess.ConnectedDuration(time.Now()),
+		Stats:       stats,
+	}
+}
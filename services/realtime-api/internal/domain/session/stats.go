@@ -0,0 +1,120 @@
+package session
+
+import (
+	"fmt"
+	"maps"
+	"time"
+)
+
+// ConnectionQuality is LiveKit's rating of a participant's connection.
+type ConnectionQuality string
+
+const (
+	QualityExcellent ConnectionQuality = "excellent"
+	QualityGood      ConnectionQuality = "good"
+	QualityPoor      ConnectionQuality = "poor"
+	QualityLost      ConnectionQuality = "lost"
+)
+
+// StatsReport is one connection sample reported by a session's client. LiveKit
+// only sends connection quality to the participants themselves, so the client
+// forwards it along with the WebRTC stats of its connection.
+type StatsReport struct {
+	Quality     ConnectionQuality
+	PacketLoss  *float64 // Percentage of packets lost since the previous report
+	JitterMs    *float64
+	RoundTripMs *float64
+}
+
+// Validate checks that the report holds a known quality and sane measurements.
+func (r StatsReport) Validate() error {
+	switch r.Quality {
+	case QualityExcellent, QualityGood, QualityPoor, QualityLost:
+	default:
+		return fmt.Errorf("quality must be one of %s, %s, %s or %s", QualityExcellent, QualityGood, QualityPoor, QualityLost)
+	}
+	if r.PacketLoss != nil && (*r.PacketLoss < 0 || *r.PacketLoss > 100) {
+		return fmt.Errorf("packet_loss must be between 0 and 100")
+	}
+	if r.JitterMs != nil && *r.JitterMs < 0 {
+		return fmt.Errorf("jitter_ms must not be negative")
+	}
+	if r.RoundTripMs != nil && *r.RoundTripMs < 0 {
+		return fmt.Errorf("round_trip_ms must not be negative")
+	}
+	return nil
+}
+
+// Summary aggregates the samples of one measurement.
+type Summary struct {
+	Samples int
+	Sum     float64
+	Max     float64
+}
+
+// Avg returns the mean of the samples, or 0 without samples.
+func (s Summary) Avg() float64 {
+	if s.Samples == 0 {
+		return 0
+	}
+	return s.Sum / float64(s.Samples)
+}
+
+func (s *Summary) add(v *float64) {
+	if v == nil {
+		return
+	}
+	s.Samples++
+	s.Sum += *v
+	s.Max = max(s.Max, *v)
+}
+
+// Stats aggregates the connection reports of a session.
+type Stats struct {
+	Reports      int
+	Quality      map[ConnectionQuality]int // Reports per quality
+	LastQuality  ConnectionQuality
+	LastReportAt time.Time
+	PacketLoss   Summary
+	Jitter       Summary
+	RoundTrip    Summary
+}
+
+// Add folds a report received at the given time into the stats.
+func (s *Stats) Add(r StatsReport, at time.Time) {
+	if s.Quality == nil {
+		s.Quality = make(map[ConnectionQuality]int)
+	}
+	s.Reports++
+	s.Quality[r.Quality]++
+	s.LastQuality = r.Quality
+	s.LastReportAt = at
+	s.PacketLoss.add(r.PacketLoss)
+	s.Jitter.add(r.JitterMs)
+	s.RoundTrip.add(r.RoundTripMs)
+}
+
+// Clone returns a copy of the stats that shares no state with s.
+func (s Stats) Clone() Stats {
+	s.Quality = maps.Clone(s.Quality)
+	return s
+}
+
+// SessionStats is the quality and duration summary of a session.
+type SessionStats struct {
+	SessionID   string
+	State       SessionState
+	CreatedAt   time.Time
+	ConnectedAt time.Time // Zero until a participant joins the room
+	Duration    time.Duration
+	Stats
+}
+
+// ConnectedDuration returns how long the session has been connected at now,
+// or 0 if no participant has joined its room.
+func (s *Session) ConnectedDuration(now time.Time) time.Duration {
+	if s.ConnectedAt.IsZero() {
+		return 0
+	}
+	return now.Sub(s.ConnectedAt)
+}
@@ -1,6 +1,9 @@
 package session
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Store defines the interface for session storage.
 // This interface is storage-only - no lifecycle methods.
@@ -26,4 +29,10 @@ type Store interface {
 
 	// UpdateState updates the state of a session.
 	UpdateState(ctx context.Context, id string, state SessionState) error
+
+	// RecordStats adds a connection report to a session and returns its stats.
+	RecordStats(ctx context.Context, id string, report StatsReport, at time.Time) (Stats, error)
+
+	// GetStats returns a copy of the stats of a session.
+	GetStats(ctx context.Context, id string) (Stats, error)
 }
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	commonauth "github.com/janhq/jan-server/packages/go-common/auth"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/realtime-api/internal/config"
@@ -89,6 +90,12 @@ func (v *Validator) Middleware() gin.HandlerFunc {
 			return
 		}
 
+		// Integration scopes are only enforced by llm-api, so their tokens stop here
+		if commonauth.IsIntegrationClient(claims.AuthorizedParty, v.cfg.IntegrationClients) {
+			c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": commonauth.IntegrationTokenMessage})
+			return
+		}
+
 		// Create a jwt.Token to maintain compatibility with existing code
 		token := &jwt.Token{
 			Claims: jwt.MapClaims{
@@ -2,8 +2,12 @@ package infrastructure
 
 import (
 	"context"
+	"net/http"
+	"time"
 
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/realtime-api/internal/config"
@@ -54,6 +58,23 @@ func ProvideAuthValidator(ctx context.Context, cfg *config.Config, log zerolog.L
 	return auth.NewValidator(ctx, cfg, log)
 }
 
+// ProvideReadinessChecker registers the dependency probes served by /readyz.
+func ProvideReadinessChecker(cfg *config.Config, roomClient *livekit.RoomClient) *health.Checker {
+	checker := health.NewChecker(cfg.ServiceName, 3*time.Second)
+	checker.Register("livekit", true, roomClient.Health)
+	if cfg.AuthEnabled {
+		probeClient := &http.Client{Timeout: 3 * time.Second}
+		checker.Register("auth_jwks", true, health.HTTPCheck(probeClient, cfg.AuthJWKSURL))
+	}
+	return checker
+}
+
+// ProvideMaintenance follows the maintenance mode switched on llm-api. It
+// returns nil, never in maintenance, when REDIS_URL is not set.
+func ProvideMaintenance(cfg *config.Config, log zerolog.Logger) (*maintenance.Service, error) {
+	return maintenance.FromRedisURL(cfg.RedisURL, cfg.MaintenanceRefreshInterval, log)
+}
+
 // InfrastructureProvider provides all infrastructure dependencies.
 var InfrastructureProvider = wire.NewSet(
 	// Config
@@ -72,4 +93,10 @@ var InfrastructureProvider = wire.NewSet(
 
 	// Auth
 	ProvideAuthValidator,
+
+	// Readiness
+	ProvideReadinessChecker,
+
+	// Maintenance mode
+	ProvideMaintenance,
 )
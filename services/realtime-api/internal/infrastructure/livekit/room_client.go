@@ -43,6 +43,12 @@ func (c *RoomClient) ListActiveRooms(ctx context.Context) (map[string]RoomInfo,
 	return rooms, nil
 }
 
+// Health verifies the LiveKit server API is reachable.
+func (c *RoomClient) Health(ctx context.Context) error {
+	_, err := c.client.ListRooms(ctx, &livekit.ListRoomsRequest{})
+	return err
+}
+
 // ListParticipants returns participant identities for a room.
 func (c *RoomClient) ListParticipants(ctx context.Context, room string) ([]string, error) {
 	resp, err := c.client.ListParticipants(ctx, &livekit.ListParticipantsRequest{
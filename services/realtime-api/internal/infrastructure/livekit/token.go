@@ -6,6 +6,7 @@ import (
 	"github.com/livekit/protocol/auth"
 
 	"jan-server/services/realtime-api/internal/config"
+	"jan-server/services/realtime-api/internal/domain/session"
 )
 
 // TokenGenerator generates LiveKit access tokens.
@@ -22,25 +23,31 @@ func NewTokenGenerator(cfg *config.Config) *TokenGenerator {
 	}
 }
 
-// Generate creates a LiveKit access token for the given room and identity.
-func (g *TokenGenerator) Generate(room, identity string, ttl time.Duration) (string, error) {
+// Generate creates a LiveKit access token for the given room and identity,
+// granting perms. Attributes are set on the participant when it joins.
+func (g *TokenGenerator) Generate(room, identity string, perms session.Permissions, attributes map[string]string, ttl time.Duration) (string, error) {
 	at := auth.NewAccessToken(g.apiKey, g.apiSecret)
 
-	canPublish := true
-	canSubscribe := true
-	canPublishData := true
+	canPublish := perms.CanPublish
+	canSubscribe := perms.CanSubscribe
+	canPublishData := perms.CanPublishData
 
 	grant := &auth.VideoGrant{
-		RoomJoin:       true,
-		Room:           room,
-		CanPublish:     &canPublish,
-		CanSubscribe:   &canSubscribe,
-		CanPublishData: &canPublishData,
+		RoomJoin:          true,
+		Room:              room,
+		CanPublish:        &canPublish,
+		CanSubscribe:      &canSubscribe,
+		CanPublishData:    &canPublishData,
+		CanPublishSources: perms.Sources,
+		Hidden:            perms.Hidden,
 	}
 
 	at.AddGrant(grant).
 		SetIdentity(identity).
 		SetValidFor(ttl)
+	if len(attributes) > 0 {
+		at.SetAttributes(attributes)
+	}
 
 	return at.ToJWT()
 }
@@ -2,6 +2,8 @@
 package metrics
 
 import (
+	"time"
+
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
@@ -65,6 +67,51 @@ var (
 			Buckets: []float64{0.001, 0.005, 0.01, 0.025, 0.05, 0.1},
 		},
 	)
+
+	// SessionDuration tracks how long sessions stay connected.
+	SessionDuration = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "realtime_session_duration_seconds",
+			Help:    "Time from the first participant joining a session's room until the session ends",
+			Buckets: []float64{10, 30, 60, 300, 600, 1800, 3600, 7200},
+		},
+	)
+
+	// ConnectionQualityReports counts client connection reports by LiveKit quality.
+	ConnectionQualityReports = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "realtime_connection_quality_reports_total",
+			Help: "Total number of client connection reports by LiveKit connection quality",
+		},
+		[]string{"quality"},
+	)
+
+	// PacketLoss tracks the packet loss reported by clients.
+	PacketLoss = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "realtime_packet_loss_percent",
+			Help:    "Packet loss reported by clients, in percent",
+			Buckets: []float64{0.5, 1, 2, 5, 10, 20, 50},
+		},
+	)
+
+	// Jitter tracks the jitter reported by clients.
+	Jitter = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "realtime_jitter_seconds",
+			Help:    "Jitter reported by clients",
+			Buckets: []float64{0.005, 0.01, 0.02, 0.03, 0.05, 0.1, 0.2},
+		},
+	)
+
+	// RoundTripTime tracks the round-trip time reported by clients.
+	RoundTripTime = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "realtime_round_trip_seconds",
+			Help:    "Round-trip time to the LiveKit server reported by clients",
+			Buckets: []float64{0.025, 0.05, 0.1, 0.15, 0.2, 0.3, 0.5, 1},
+		},
+	)
 )
 
 // RecordSessionCreated increments session creation metrics.
@@ -83,3 +130,26 @@ func RecordSessionDeleted() {
 func RecordStateTransition(fromState, toState string) {
 	SessionStateTransitions.WithLabelValues(fromState, toState).Inc()
 }
+
+// RecordSessionEnded records the connected duration of an ended session.
+// Sessions that never connected are not observed.
+func RecordSessionEnded(connected time.Duration) {
+	if connected > 0 {
+		SessionDuration.Observe(connected.Seconds())
+	}
+}
+
+// RecordConnectionReport records a client connection report. Measurements the
+// client did not send are nil.
+func RecordConnectionReport(quality string, packetLossPercent, jitterMs, roundTripMs *float64) {
+	ConnectionQualityReports.WithLabelValues(quality).Inc()
+	if packetLossPercent != nil {
+		PacketLoss.Observe(*packetLossPercent)
+	}
+	if jitterMs != nil {
+		Jitter.Observe(*jitterMs / 1000)
+	}
+	if roundTripMs != nil {
+		RoundTripTime.Observe(*roundTripMs / 1000)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/rs/zerolog"
 
@@ -132,6 +133,34 @@ func (s *MemoryStore) UpdateState(ctx context.Context, id string, state session.
 	if !ok {
 		return ErrSessionNotFound
 	}
+	if state == session.StateConnected && sess.ConnectedAt.IsZero() {
+		sess.ConnectedAt = time.Now()
+	}
 	sess.State = state
 	return nil
 }
+
+// RecordStats adds a connection report to a session and returns its stats.
+func (s *MemoryStore) RecordStats(ctx context.Context, id string, report session.StatsReport, at time.Time) (session.Stats, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return session.Stats{}, ErrSessionNotFound
+	}
+	sess.Stats.Add(report, at)
+	return sess.Stats.Clone(), nil
+}
+
+// GetStats returns a copy of the stats of a session.
+func (s *MemoryStore) GetStats(ctx context.Context, id string) (session.Stats, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	sess, ok := s.sessions[id]
+	if !ok {
+		return session.Stats{}, ErrSessionNotFound
+	}
+	return sess.Stats.Clone(), nil
+}
@@ -10,6 +10,7 @@ import (
 
 	"jan-server/services/realtime-api/internal/domain/session"
 	"jan-server/services/realtime-api/internal/infrastructure/livekit"
+	"jan-server/services/realtime-api/internal/infrastructure/metrics"
 )
 
 // Syncer handles session synchronization with LiveKit.
@@ -130,11 +131,18 @@ func (s *Syncer) sync(ctx context.Context) {
 			// Room doesn't exist or is empty
 			if sess.State == session.StateConnected {
 				// Was connected, now room is gone → delete
+				stats, _ := s.store.GetStats(ctx, sess.ID)
 				if err := s.store.Delete(ctx, sess.ID); err == nil {
+					duration := sess.ConnectedDuration(now)
+					metrics.RecordSessionEnded(duration)
 					s.log.Info().
 						Str("action", "deleted").
 						Str("room", sess.Room).
 						Str("reason", "room_empty").
+						Dur("duration", duration).
+						Int("quality_reports", stats.Reports).
+						Int("poor_reports", stats.Quality[session.QualityPoor]+stats.Quality[session.QualityLost]).
+						Float64("avg_packet_loss", stats.PacketLoss.Avg()).
 						Msg("session cleanup")
 				}
 			} else if sess.State == session.StateCreated && now.Sub(sess.CreatedAt) > s.staleTTL {
@@ -26,12 +26,23 @@ func (h *SessionHandler) GetSession(ctx context.Context, id string) (*session.Se
 	return h.service.GetSession(ctx, id)
 }
 
-// ListUserSessions retrieves all sessions for a user.
-func (h *SessionHandler) ListUserSessions(ctx context.Context, userID string) ([]*session.Session, error) {
-	return h.service.ListUserSessions(ctx, userID)
+// ListUserSessions retrieves the sessions of a user, optionally only those
+// bound to conversationID.
+func (h *SessionHandler) ListUserSessions(ctx context.Context, userID, conversationID string) ([]*session.Session, error) {
+	return h.service.ListUserSessions(ctx, userID, conversationID)
 }
 
 // DeleteSession removes a session.
 func (h *SessionHandler) DeleteSession(ctx context.Context, id string) error {
 	return h.service.DeleteSession(ctx, id)
 }
+
+// ReportStats records a connection report from a session's client.
+func (h *SessionHandler) ReportStats(ctx context.Context, id string, report session.StatsReport) (*session.SessionStats, error) {
+	return h.service.ReportStats(ctx, id, report)
+}
+
+// GetSessionStats retrieves the quality and duration summary of a session.
+func (h *SessionHandler) GetSessionStats(ctx context.Context, id string) (*session.SessionStats, error) {
+	return h.service.GetSessionStats(ctx, id)
+}
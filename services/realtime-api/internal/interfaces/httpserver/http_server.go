@@ -6,6 +6,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
@@ -30,6 +33,7 @@ type HTTPServer struct {
 	log         zerolog.Logger
 	handlerProv *handlers.Provider
 	routeProv   *routes.Provider
+	maintenance *maintenance.Service
 }
 
 // New creates a new HTTP server.
@@ -38,6 +42,8 @@ func New(
 	log zerolog.Logger,
 	sessionService session.Service,
 	authValidator *auth.Validator,
+	readiness *health.Checker,
+	maintenanceService *maintenance.Service,
 ) *HTTPServer {
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
@@ -54,7 +60,7 @@ func New(
 	engine.Use(middlewares.RequestLoggerWithLogger(log))
 
 	// Public routes (no auth)
-	registerCoreRoutes(engine, cfg)
+	registerCoreRoutes(engine, cfg, readiness)
 
 	handlerProvider := handlers.NewProvider(sessionService)
 	routeProvider := routes.NewProvider(handlerProvider, authValidator)
@@ -67,15 +73,19 @@ func New(
 		log:         log,
 		handlerProv: handlerProvider,
 		routeProv:   routeProvider,
+		maintenance: maintenanceService,
 	}
 }
 
 // Run starts the HTTP server and blocks until context is cancelled.
 func (s *HTTPServer) Run(ctx context.Context) error {
-	server := &http.Server{
-		Addr:    s.cfg.Addr(),
-		Handler: s.engine,
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		return err
 	}
+	// Maintenance mode turns away new sessions before they reach the engine
+	server := serverbuilder.New(s.cfg.Addr(), s.maintenance.Middleware()(s.engine), serverCfg)
+	go s.maintenance.Run(ctx)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -105,7 +115,7 @@ func (s *HTTPServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func registerCoreRoutes(engine *gin.Engine, cfg *config.Config) {
+func registerCoreRoutes(engine *gin.Engine, cfg *config.Config, readiness *health.Checker) {
 	engine.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"service": cfg.ServiceName,
@@ -117,9 +127,7 @@ func registerCoreRoutes(engine *gin.Engine, cfg *config.Config) {
 		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
 
-	engine.GET("/readyz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ready"})
-	})
+	engine.GET("/readyz", gin.WrapH(readiness))
 
 	// Prometheus metrics endpoint
 	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
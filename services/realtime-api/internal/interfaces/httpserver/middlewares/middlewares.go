@@ -117,6 +117,7 @@ func RequestLoggerWithLogger(log zerolog.Logger) gin.HandlerFunc {
 		}
 
 		event.
+			Ctx(c.Request.Context()).
 			Str("method", c.Request.Method).
 			Str("path", path).
 			Str("query", query).
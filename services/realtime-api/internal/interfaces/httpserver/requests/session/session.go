@@ -2,10 +2,36 @@
 package session
 
 // CreateSessionRequest represents the request body for creating a session.
-// Currently minimal as session creation doesn't require many parameters.
+// Every field is optional; an empty body creates a publisher session.
 type CreateSessionRequest struct {
 	// Model is an optional model identifier for future use.
 	Model string `json:"model,omitempty"`
 	// Voice is an optional voice identifier for future use.
 	Voice string `json:"voice,omitempty"`
+	// Preset selects the participant's room permissions: publisher (default),
+	// subscriber (receive only) or observer (receive only, hidden from others).
+	Preset string `json:"preset,omitempty" enums:"publisher,subscriber,observer"`
+	// CanPublishSources limits a publisher to these track sources.
+	CanPublishSources []string `json:"can_publish_sources,omitempty" enums:"camera,microphone,screen_share,screen_share_audio"`
+	// CanPublishData overrides whether the participant can send data messages.
+	CanPublishData *bool `json:"can_publish_data,omitempty"`
+	// ExpiresIn is the token lifetime in seconds, from 60 up to LIVEKIT_TOKEN_MAX_TTL.
+	// Defaults to LIVEKIT_TOKEN_TTL.
+	ExpiresIn int `json:"expires_in,omitempty" binding:"omitempty,min=0"`
+	// ConversationID binds the session to an existing llm-api conversation, so a
+	// client reconnecting in a new room continues the same history.
+	ConversationID string `json:"conversation_id,omitempty"`
+}
+
+// ReportStatsRequest is a connection report sent by a session's client, taken
+// from the LiveKit client's connection quality and WebRTC stats.
+type ReportStatsRequest struct {
+	// Quality is the participant's LiveKit connection quality.
+	Quality string `json:"quality" binding:"required" enums:"excellent,good,poor,lost"`
+	// PacketLoss is the percentage of packets lost since the previous report.
+	PacketLoss *float64 `json:"packet_loss,omitempty"`
+	// JitterMs is the current jitter in milliseconds.
+	JitterMs *float64 `json:"jitter_ms,omitempty"`
+	// RoundTripMs is the round-trip time to the LiveKit server in milliseconds.
+	RoundTripMs *float64 `json:"round_trip_ms,omitempty"`
 }
@@ -7,13 +7,25 @@ import (
 
 // SessionResponse represents a session in API responses.
 type SessionResponse struct {
-	ID           string              `json:"id"`
-	Object       string              `json:"object"`
-	ClientSecret *ClientSecretDetail `json:"client_secret,omitempty"`
-	WsURL        string              `json:"ws_url,omitempty"`
-	RoomID       string              `json:"room_id,omitempty"`
-	UserID       string              `json:"user_id,omitempty"`
-	Status       string              `json:"status,omitempty"`
+	ID             string              `json:"id"`
+	Object         string              `json:"object"`
+	ClientSecret   *ClientSecretDetail `json:"client_secret,omitempty"`
+	WsURL          string              `json:"ws_url,omitempty"`
+	RoomID         string              `json:"room_id,omitempty"`
+	UserID         string              `json:"user_id,omitempty"`
+	Status         string              `json:"status,omitempty"`
+	Permissions    *PermissionsDetail  `json:"permissions,omitempty"`
+	ConversationID string              `json:"conversation_id,omitempty"`
+}
+
+// PermissionsDetail describes the room grants of a session's token.
+type PermissionsDetail struct {
+	Preset            string   `json:"preset"`
+	CanPublish        bool     `json:"can_publish"`
+	CanSubscribe      bool     `json:"can_subscribe"`
+	CanPublishData    bool     `json:"can_publish_data"`
+	Hidden            bool     `json:"hidden"`
+	CanPublishSources []string `json:"can_publish_sources,omitempty"`
 }
 
 // ClientSecretDetail contains the client secret for a session.
@@ -39,11 +51,13 @@ type DeleteSessionResponse struct {
 // Use this for POST responses that include client_secret.
 func NewSessionResponse(sess *domainsession.Session) *SessionResponse {
 	resp := &SessionResponse{
-		ID:     sess.ID,
-		Object: sess.Object,
-		WsURL:  sess.WsURL,
-		RoomID: sess.RoomID,
-		UserID: sess.UserID,
+		ID:             sess.ID,
+		Object:         sess.Object,
+		WsURL:          sess.WsURL,
+		RoomID:         sess.RoomID,
+		UserID:         sess.UserID,
+		Permissions:    newPermissionsDetail(sess.Permissions),
+		ConversationID: sess.ConversationID,
 	}
 
 	if sess.ClientSecret != nil {
@@ -60,12 +74,101 @@ func NewSessionResponse(sess *domainsession.Session) *SessionResponse {
 // Excludes client_secret and includes status.
 func NewSessionResponseForGet(sess *domainsession.Session) *SessionResponse {
 	return &SessionResponse{
-		ID:     sess.ID,
-		Object: sess.Object,
-		WsURL:  sess.WsURL,
-		RoomID: sess.Room,
-		UserID: sess.UserID,
-		Status: string(sess.State),
+		ID:             sess.ID,
+		Object:         sess.Object,
+		WsURL:          sess.WsURL,
+		RoomID:         sess.Room,
+		UserID:         sess.UserID,
+		Status:         string(sess.State),
+		Permissions:    newPermissionsDetail(sess.Permissions),
+		ConversationID: sess.ConversationID,
+	}
+}
+
+// newPermissionsDetail returns nil for sessions without recorded grants.
+func newPermissionsDetail(perms domainsession.Permissions) *PermissionsDetail {
+	if perms.Preset == "" {
+		return nil
+	}
+	return &PermissionsDetail{
+		Preset:            string(perms.Preset),
+		CanPublish:        perms.CanPublish,
+		CanSubscribe:      perms.CanSubscribe,
+		CanPublishData:    perms.CanPublishData,
+		Hidden:            perms.Hidden,
+		CanPublishSources: perms.Sources,
+	}
+}
+
+// SessionStatsResponse is the quality and duration summary of a session.
+type SessionStatsResponse struct {
+	ID                string             `json:"id"`
+	Object            string             `json:"object"`
+	Status            string             `json:"status"`
+	CreatedAt         int64              `json:"created_at"`
+	ConnectedAt       *int64             `json:"connected_at,omitempty"`
+	DurationSeconds   int64              `json:"duration_seconds"`
+	Reports           int                `json:"reports"`
+	ConnectionQuality *QualityDetail     `json:"connection_quality,omitempty"`
+	PacketLoss        *MeasurementDetail `json:"packet_loss,omitempty"`
+	JitterMs          *MeasurementDetail `json:"jitter_ms,omitempty"`
+	RoundTripMs       *MeasurementDetail `json:"round_trip_ms,omitempty"`
+}
+
+// QualityDetail summarizes the connection quality reported by a client.
+type QualityDetail struct {
+	Last         string         `json:"last"`
+	LastReportAt int64          `json:"last_report_at"`
+	Counts       map[string]int `json:"counts"`
+}
+
+// MeasurementDetail summarizes one reported measurement.
+type MeasurementDetail struct {
+	Samples int     `json:"samples"`
+	Avg     float64 `json:"avg"`
+	Max     float64 `json:"max"`
+}
+
+// NewSessionStatsResponse creates a SessionStatsResponse from domain stats.
+func NewSessionStatsResponse(stats *domainsession.SessionStats) *SessionStatsResponse {
+	resp := &SessionStatsResponse{
+		ID:              stats.SessionID,
+		Object:          "realtime.session.stats",
+		Status:          string(stats.State),
+		CreatedAt:       stats.CreatedAt.Unix(),
+		DurationSeconds: int64(stats.Duration.Seconds()),
+		Reports:         stats.Reports,
+		PacketLoss:      newMeasurementDetail(stats.PacketLoss),
+		JitterMs:        newMeasurementDetail(stats.Jitter),
+		RoundTripMs:     newMeasurementDetail(stats.RoundTrip),
+	}
+	if !stats.ConnectedAt.IsZero() {
+		connectedAt := stats.ConnectedAt.Unix()
+		resp.ConnectedAt = &connectedAt
+	}
+	if stats.Reports > 0 {
+		counts := make(map[string]int, len(stats.Quality))
+		for quality, n := range stats.Quality {
+			counts[string(quality)] = n
+		}
+		resp.ConnectionQuality = &QualityDetail{
+			Last:         string(stats.LastQuality),
+			LastReportAt: stats.LastReportAt.Unix(),
+			Counts:       counts,
+		}
+	}
+	return resp
+}
+
+// newMeasurementDetail returns nil for measurements the client never sent.
+func newMeasurementDetail(summary domainsession.Summary) *MeasurementDetail {
+	if summary.Samples == 0 {
+		return nil
+	}
+	return &MeasurementDetail{
+		Samples: summary.Samples,
+		Avg:     summary.Avg(),
+		Max:     summary.Max,
 	}
 }
 
@@ -1,13 +1,17 @@
 package v1
 
 import (
+	"errors"
+	"io"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 
 	domainsession "jan-server/services/realtime-api/internal/domain/session"
 	"jan-server/services/realtime-api/internal/interfaces/httpserver/handlers"
+	sessionreq "jan-server/services/realtime-api/internal/interfaces/httpserver/requests/session"
 	"jan-server/services/realtime-api/internal/interfaces/httpserver/responses"
 	sessionres "jan-server/services/realtime-api/internal/interfaces/httpserver/responses/session"
 	"jan-server/services/realtime-api/internal/utils/platformerrors"
@@ -22,15 +26,23 @@ func RegisterRealtimeRoutes(router gin.IRoutes, handler *handlers.SessionHandler
 	router.GET("/realtime/sessions", listSessions(handler))
 	router.GET("/realtime/sessions/:id", getSession(handler))
 	router.DELETE("/realtime/sessions/:id", deleteSession(handler))
+
+	// Connection quality
+	router.POST("/realtime/sessions/:id/stats", reportSessionStats(handler))
+	router.GET("/realtime/sessions/:id/stats", getSessionStats(handler))
 }
 
 // createSession godoc
 // @Summary      Create a realtime session
-// @Description  Creates a new realtime session with LiveKit token. No request body required.
+// @Description  Creates a new realtime session with a LiveKit token. The body is optional: by default the token can publish and subscribe and is valid for LIVEKIT_TOKEN_TTL.
+// @Description  `preset` selects the room permissions (`publisher`, `subscriber` or a hidden `observer`), `can_publish_sources` limits a publisher to some tracks, and `expires_in` sets the token lifetime in seconds, up to LIVEKIT_TOKEN_MAX_TTL.
+// @Description  `conversation_id` binds the session to an existing conversation: the token carries it as the `conversation_id` participant attribute so the agent continues that conversation's history, which lets a client reconnect in a new room without losing context.
 // @Tags         Realtime API
 // @Accept       json
 // @Produce      json
+// @Param        request body sessionreq.CreateSessionRequest false "Token permissions and lifetime"
 // @Success      201 {object} sessionres.SessionResponse
+// @Failure      400 {object} responses.ErrorResponse
 // @Failure      401 {object} responses.ErrorResponse
 // @Failure      500 {object} responses.ErrorResponse
 // @Security     BearerAuth
@@ -39,7 +51,20 @@ func createSession(handler *handlers.SessionHandler) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := extractUserID(c)
 
-		sess, err := handler.CreateSession(c.Request.Context(), &domainsession.CreateSessionRequest{}, userID)
+		// The body is optional: an empty one uses the server defaults
+		var req sessionreq.CreateSessionRequest
+		if err := c.ShouldBindJSON(&req); err != nil && !errors.Is(err, io.EOF) {
+			responses.HandleNewError(c, platformerrors.ErrorTypeValidation, "invalid request body")
+			return
+		}
+
+		sess, err := handler.CreateSession(c.Request.Context(), &domainsession.CreateSessionRequest{
+			Preset:            domainsession.Preset(req.Preset),
+			CanPublishSources: req.CanPublishSources,
+			CanPublishData:    req.CanPublishData,
+			TTL:               time.Duration(req.ExpiresIn) * time.Second,
+			ConversationID:    req.ConversationID,
+		}, userID)
 		if err != nil {
 			responses.HandleError(c, err, "failed to create session")
 			return
@@ -51,9 +76,10 @@ func createSession(handler *handlers.SessionHandler) gin.HandlerFunc {
 
 // listSessions godoc
 // @Summary      List realtime sessions
-// @Description  Lists all active sessions for the current user
+// @Description  Lists all active sessions for the current user, newest first. Filter by `conversation_id` to find the sessions that continued a conversation.
 // @Tags         Realtime API
 // @Produce      json
+// @Param        conversation_id query string false "Only list sessions bound to this conversation"
 // @Success      200 {object} sessionres.ListSessionsResponse
 // @Failure      401 {object} responses.ErrorResponse
 // @Failure      500 {object} responses.ErrorResponse
@@ -63,7 +89,7 @@ func listSessions(handler *handlers.SessionHandler) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		userID := extractUserID(c)
 
-		sessions, err := handler.ListUserSessions(c.Request.Context(), userID)
+		sessions, err := handler.ListUserSessions(c.Request.Context(), userID, c.Query("conversation_id"))
 		if err != nil {
 			responses.HandleError(c, err, "failed to list sessions")
 			return
@@ -145,6 +171,99 @@ func deleteSession(handler *handlers.SessionHandler) gin.HandlerFunc {
 	}
 }
 
+// reportSessionStats godoc
+// @Summary      Report session connection stats
+// @Description  Records a connection report from the session's client. LiveKit only sends connection quality to the participants themselves, so clients should forward it, with the packet loss, jitter and round-trip time from their WebRTC stats, every few seconds while connected.
+// @Description  Reports are exported as Prometheus metrics and summarized by GET /realtime/sessions/{id}/stats.
+// @Tags         Realtime API
+// @Accept       json
+// @Produce      json
+// @Param        id path string true "Session ID"
+// @Param        request body sessionreq.ReportStatsRequest true "Connection report"
+// @Success      200 {object} sessionres.SessionStatsResponse
+// @Failure      400 {object} responses.ErrorResponse
+// @Failure      403 {object} responses.ErrorResponse
+// @Failure      404 {object} responses.ErrorResponse
+// @Failure      500 {object} responses.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realtime/sessions/{id}/stats [post]
+func reportSessionStats(handler *handlers.SessionHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		userID := extractUserID(c)
+
+		var req sessionreq.ReportStatsRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			responses.HandleNewError(c, platformerrors.ErrorTypeValidation, "invalid request body")
+			return
+		}
+
+		sess, err := handler.GetSession(c.Request.Context(), id)
+		if err != nil {
+			responses.HandleError(c, err, "failed to get session")
+			return
+		}
+
+		// Authorization: verify session belongs to the authenticated user
+		if sess.UserID != userID {
+			responses.HandleNewError(c, platformerrors.ErrorTypeForbidden, "access denied")
+			return
+		}
+
+		stats, err := handler.ReportStats(c.Request.Context(), id, domainsession.StatsReport{
+			Quality:     domainsession.ConnectionQuality(req.Quality),
+			PacketLoss:  req.PacketLoss,
+			JitterMs:    req.JitterMs,
+			RoundTripMs: req.RoundTripMs,
+		})
+		if err != nil {
+			responses.HandleError(c, err, "failed to record session stats")
+			return
+		}
+
+		c.JSON(http.StatusOK, sessionres.NewSessionStatsResponse(stats))
+	}
+}
+
+// getSessionStats godoc
+// @Summary      Get session connection stats
+// @Description  Returns how long the session has been connected and a summary of the connection quality, packet loss, jitter and round-trip time its client reported. Users can only access their own sessions.
+// @Tags         Realtime API
+// @Produce      json
+// @Param        id path string true "Session ID"
+// @Success      200 {object} sessionres.SessionStatsResponse
+// @Failure      403 {object} responses.ErrorResponse
+// @Failure      404 {object} responses.ErrorResponse
+// @Failure      500 {object} responses.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realtime/sessions/{id}/stats [get]
+func getSessionStats(handler *handlers.SessionHandler) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.Param("id")
+		userID := extractUserID(c)
+
+		sess, err := handler.GetSession(c.Request.Context(), id)
+		if err != nil {
+			responses.HandleError(c, err, "failed to get session")
+			return
+		}
+
+		// Authorization: verify session belongs to the authenticated user
+		if sess.UserID != userID {
+			responses.HandleNewError(c, platformerrors.ErrorTypeForbidden, "access denied")
+			return
+		}
+
+		stats, err := handler.GetSessionStats(c.Request.Context(), id)
+		if err != nil {
+			responses.HandleError(c, err, "failed to get session stats")
+			return
+		}
+
+		c.JSON(http.StatusOK, sessionres.NewSessionStatsResponse(stats))
+	}
+}
+
 // Helper functions
 
 func extractUserID(c *gin.Context) string {
@@ -3,6 +3,7 @@ package idgen
 import (
 	"crypto/rand"
 	"fmt"
+	"strings"
 )
 
 // GenerateSecureID generates a cryptographically secure ID with the given prefix and length.
@@ -24,3 +25,18 @@ func GenerateSecureID(prefix string, length int) (string, error) {
 
 	return fmt.Sprintf("%s_%s", prefix, string(encoded)), nil
 }
+
+// ValidateIDFormat reports whether id is expectedPrefix, an underscore and a
+// non-empty alphanumeric suffix, as produced by GenerateSecureID.
+func ValidateIDFormat(id, expectedPrefix string) bool {
+	suffix, ok := strings.CutPrefix(id, expectedPrefix+"_")
+	if !ok || suffix == "" {
+		return false
+	}
+	for _, char := range suffix {
+		if !((char >= 'a' && char <= 'z') || (char >= '0' && char <= '9')) {
+			return false
+		}
+	}
+	return true
+}
@@ -2,15 +2,25 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
 	"os/signal"
+	"strings"
 	"syscall"
+	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
+	"github.com/janhq/jan-server/packages/go-common/leader"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
 	"github.com/joho/godotenv"
 	"github.com/rs/zerolog"
+	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
 
+	"jan-server/services/response-api/internal/canary"
 	"jan-server/services/response-api/internal/config"
 	"jan-server/services/response-api/internal/domain/response"
 	"jan-server/services/response-api/internal/domain/tool"
@@ -62,6 +72,12 @@ func main() {
 	}
 
 	log := logger.New(cfg)
+	defer func() {
+		// Flush exported logs last, after the shutdown errors are logged
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+		defer cancel()
+		_ = logger.Shutdown(shutdownCtx)
+	}()
 
 	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
 	defer stop()
@@ -101,8 +117,16 @@ func main() {
 	responseRepository := respRepo.NewPostgresRepository(db)
 	conversationRepository := conversationrepo.NewRepository(db)
 	conversationItemRepository := conversationrepo.NewItemRepository(db)
-	llmClient := llmprovider.NewClient(cfg.LLMAPIURL)
-	mcpClient := mcp.NewClient(cfg.MCPToolsURL)
+	llmHTTPClient, err := httpclient.New(cfg.LLMAPIHTTP)
+	if err != nil {
+		log.Fatal().Err(err).Msg("build llm-api http client")
+	}
+	mcpHTTPClient, err := httpclient.New(cfg.MCPToolsHTTP)
+	if err != nil {
+		log.Fatal().Err(err).Msg("build mcp-tools http client")
+	}
+	llmClient := llmprovider.NewClient(cfg.LLMAPIURL, llmHTTPClient)
+	mcpClient := mcp.NewClient(cfg.MCPToolsURL, mcpHTTPClient, cfg.MCPToolsBreaker)
 	orchestrator := tool.NewOrchestrator(llmClient, mcpClient, cfg.MaxToolDepth, cfg.ToolTimeout)
 
 	// Initialize webhook service
@@ -140,7 +164,22 @@ func main() {
 		workerPool.Stop()
 	}()
 
-	httpServer := httpserver.New(cfg, log, responseService, authValidator)
+	canaryRunner, err := newCanaryRunner(cfg, db, orchestrator, mcpClient, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("initialize canary")
+	}
+	if canaryRunner != nil {
+		canaryRunner.Start(ctx)
+		defer canaryRunner.Stop()
+	}
+
+	maintenanceService, err := newMaintenance(cfg, log)
+	if err != nil {
+		log.Fatal().Err(err).Msg("initialize maintenance mode")
+	}
+
+	readiness := newReadinessChecker(cfg, db, authValidator)
+	httpServer := httpserver.New(cfg, log, responseService, authValidator, readiness, canaryRunner, maintenanceService)
 	app := NewApplication(httpServer, log)
 
 	if err := app.Start(ctx); err != nil {
@@ -150,6 +189,58 @@ func main() {
 	log.Info().Msg("application exited cleanly")
 }
 
+// newReadinessChecker registers the dependency probes served by /readyz.
+func newReadinessChecker(cfg *config.Config, db *gorm.DB, authValidator *auth.Validator) *health.Checker {
+	checker := health.NewChecker(cfg.ServiceName, 3*time.Second)
+	checker.Register("database", true, health.DatabaseCheck(db))
+	if authValidator != nil {
+		checker.Register("auth_jwks", true, func(ctx context.Context) error {
+			if !authValidator.Ready() {
+				return errors.New("jwks not loaded")
+			}
+			return nil
+		})
+	}
+	probeClient := &http.Client{Timeout: 3 * time.Second}
+	checker.Register("llm_api", true, health.HTTPCheck(probeClient, strings.TrimSuffix(cfg.LLMAPIURL, "/")+"/healthz"))
+	checker.Register("mcp_tools", false, health.HTTPCheck(probeClient, strings.TrimSuffix(cfg.MCPToolsURL, "/")+"/healthz"))
+	return checker
+}
+
+// newCanaryRunner returns nil unless RESPONSE_CANARY_ENABLED is set. Unless
+// RESPONSE_CANARY_LEADER_ELECTION is off, replicas elect one runner through a
+// Postgres advisory lock so the canary doesn't run once per replica.
+func newCanaryRunner(cfg *config.Config, db *gorm.DB, orchestrator *tool.Orchestrator, mcpClient tool.MCPClient, log zerolog.Logger) (*canary.Runner, error) {
+	if !cfg.CanaryEnabled {
+		return nil, nil
+	}
+	canaryCfg := canary.Config{
+		Interval: cfg.CanaryInterval,
+		Timeout:  cfg.CanaryTimeout,
+		Model:    cfg.CanaryModel,
+		Tool:     cfg.CanaryTool,
+		Prompt:   cfg.CanaryPrompt,
+		APIKey:   cfg.CanaryAPIKey,
+		Identity: cfg.PodName,
+	}
+	if cfg.CanaryLeaderElection {
+		sqlDB, err := db.DB()
+		if err != nil {
+			return nil, err
+		}
+		canaryCfg.Locker = leader.NewPostgresLocker(sqlDB, cfg.CanaryLockName)
+	} else {
+		log.Warn().Msg("canary leader election disabled; every replica runs the canary")
+	}
+	return canary.NewRunner(orchestrator, mcpClient, canaryCfg, log), nil
+}
+
+// newMaintenance follows the maintenance mode switched on llm-api. It
+// returns nil, never in maintenance, when REDIS_URL is not set.
+func newMaintenance(cfg *config.Config, log zerolog.Logger) (*maintenance.Service, error) {
+	return maintenance.FromRedisURL(cfg.RedisURL, cfg.MaintenanceRefreshInterval, log)
+}
+
 func loadEnvFiles() {
 	paths := []string{".env", "../.env"}
 	for _, path := range paths {
@@ -6,6 +6,7 @@ import (
 	"context"
 
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 	gormlogger "gorm.io/gorm/logger"
@@ -53,6 +54,9 @@ func BuildApplication(ctx context.Context) (*Application, error) {
 		newGormDB,
 		newAuthValidator,
 		responseSet,
+		newReadinessChecker,
+		newCanaryRunner,
+		newMaintenance,
 		httpserver.New,
 		NewApplication,
 	)
@@ -84,12 +88,20 @@ func newAuthValidator(ctx context.Context, cfg *config.Config, log zerolog.Logge
 	return auth.NewValidator(ctx, cfg, log)
 }
 
-func newLLMProvider(cfg *config.Config) *llmprovider.Client {
-	return llmprovider.NewClient(cfg.LLMAPIURL)
+func newLLMProvider(cfg *config.Config) (*llmprovider.Client, error) {
+	httpClient, err := httpclient.New(cfg.LLMAPIHTTP)
+	if err != nil {
+		return nil, err
+	}
+	return llmprovider.NewClient(cfg.LLMAPIURL, httpClient), nil
 }
 
-func newMCPClient(cfg *config.Config) *mcp.Client {
-	return mcp.NewClient(cfg.MCPToolsURL)
+func newMCPClient(cfg *config.Config) (*mcp.Client, error) {
+	httpClient, err := httpclient.New(cfg.MCPToolsHTTP)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewClient(cfg.MCPToolsURL, httpClient, cfg.MCPToolsBreaker), nil
 }
 
 func newOrchestrator(cfg *config.Config, provider llm.Provider, mcpClient tool.MCPClient) *tool.Orchestrator {
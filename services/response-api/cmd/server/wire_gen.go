@@ -9,6 +9,7 @@ package main
 import (
 	"context"
 	"github.com/google/wire"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
 	"github.com/rs/zerolog"
 	"gorm.io/gorm"
 	logger2 "gorm.io/gorm/logger"
@@ -45,8 +46,14 @@ func BuildApplication(ctx context.Context) (*Application, error) {
 	postgresRepository := response.NewPostgresRepository(db)
 	repository := conversation.NewRepository(db)
 	itemRepository := conversation.NewItemRepository(db)
-	client := newLLMProvider(configConfig)
-	mcpClient := newMCPClient(configConfig)
+	client, err := newLLMProvider(configConfig)
+	if err != nil {
+		return nil, err
+	}
+	mcpClient, err := newMCPClient(configConfig)
+	if err != nil {
+		return nil, err
+	}
 	orchestrator := newOrchestrator(configConfig, client, mcpClient)
 	httpService := newWebhookService(zerologLogger)
 	service := newResponseService(postgresRepository, repository, itemRepository, postgresRepository, orchestrator, mcpClient, client, httpService, zerologLogger)
@@ -54,7 +61,16 @@ func BuildApplication(ctx context.Context) (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	httpServer := httpserver.New(configConfig, zerologLogger, service, validator)
+	checker := newReadinessChecker(configConfig, db, validator)
+	runner, err := newCanaryRunner(configConfig, db, orchestrator, mcpClient, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
+	maintenanceService, err := newMaintenance(configConfig, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
+	httpServer := httpserver.New(configConfig, zerologLogger, service, validator, checker, runner, maintenanceService)
 	application := NewApplication(httpServer, zerologLogger)
 	return application, nil
 }
@@ -90,12 +106,20 @@ func newAuthValidator(ctx context.Context, cfg *config.Config, log zerolog.Logge
 	return auth.NewValidator(ctx, cfg, log)
 }
 
-func newLLMProvider(cfg *config.Config) *llmprovider.Client {
-	return llmprovider.NewClient(cfg.LLMAPIURL)
+func newLLMProvider(cfg *config.Config) (*llmprovider.Client, error) {
+	httpClient, err := httpclient.New(cfg.LLMAPIHTTP)
+	if err != nil {
+		return nil, err
+	}
+	return llmprovider.NewClient(cfg.LLMAPIURL, httpClient), nil
 }
 
-func newMCPClient(cfg *config.Config) *mcp.Client {
-	return mcp.NewClient(cfg.MCPToolsURL)
+func newMCPClient(cfg *config.Config) (*mcp.Client, error) {
+	httpClient, err := httpclient.New(cfg.MCPToolsHTTP)
+	if err != nil {
+		return nil, err
+	}
+	return mcp.NewClient(cfg.MCPToolsURL, httpClient, cfg.MCPToolsBreaker), nil
 }
 
 func newOrchestrator(cfg *config.Config, provider llm.Provider, mcpClient tool.MCPClient) *tool.Orchestrator {
@@ -21,7 +21,7 @@ const docTemplate = `{
     "paths": {
         "/v1/responses": {
             "post": {
-                "description": "Creates a response and orchestrates MCP tool calls when required. When ` + "`" + `stream=true` + "`" + `, results are streamed as SSE events instead of a single JSON payload.",
+                "description": "Creates a response and orchestrates MCP tool calls when required. When ` + "`" + `stream=true` + "`" + `, results are streamed as SSE events instead of a single JSON payload. ` + "`" + `X-Jan-Stream-Version` + "`" + ` selects the event payload version; the stream echoes it.",
                 "consumes": [
                     "application/json"
                 ],
@@ -42,6 +42,12 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/requests.CreateResponseRequest"
                         }
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Stream event version (1 or 2); defaults to SSE_DEFAULT_STREAM_VERSION",
+                        "name": "X-Jan-Stream-Version",
+                        "in": "header"
                     }
                 ],
                 "responses": {
@@ -60,6 +66,15 @@ const docTemplate = `{
                             }
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "type": "object",
+                            "additionalProperties": {
+                                "type": "string"
+                            }
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -146,6 +161,7 @@ const docTemplate = `{
         },
         "/v1/responses/{response_id}/cancel": {
             "post": {
+                "description": "Marks a queued or in-progress response as cancelled. If the response is running on this instance, its in-flight LLM call and tool executions are aborted, completed usage is kept, and a streaming client receives a ` + "`" + `response.cancelled` + "`" + ` event.",
                 "produces": [
                     "application/json"
                 ],
@@ -338,6 +354,100 @@ const docTemplate = `{
                 }
             }
         },
+        "responses.ErrorObject": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "message": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.IncompleteDetails": {
+            "type": "object",
+            "properties": {
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.InputTokensDetails": {
+            "type": "object",
+            "properties": {
+                "cached_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responses.OutputContent": {
+            "type": "object",
+            "properties": {
+                "annotations": {
+                    "type": "array",
+                    "items": {}
+                },
+                "text": {
+                    "type": "string"
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.OutputItem": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "$ref": "#/definitions/responses.WebSearchCallAction"
+                },
+                "arguments": {
+                    "type": "string"
+                },
+                "call_id": {
+                    "type": "string"
+                },
+                "content": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.OutputContent"
+                    }
+                },
+                "explanation": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "steps": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/tool.PlanStep"
+                    }
+                },
+                "type": {
+                    "type": "string"
+                }
+            }
+        },
+        "responses.OutputTokensDetails": {
+            "type": "object",
+            "properties": {
+                "reasoning_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
         "responses.ResponsePayload": {
             "type": "object",
             "properties": {
@@ -354,11 +464,22 @@ const docTemplate = `{
                     "description": "Same as Created, for compatibility",
                     "type": "integer"
                 },
-                "error": {},
+                "error": {
+                    "$ref": "#/definitions/responses.ErrorObject"
+                },
                 "id": {
                     "type": "string"
                 },
+                "incomplete_details": {
+                    "$ref": "#/definitions/responses.IncompleteDetails"
+                },
                 "input": {},
+                "instructions": {
+                    "type": "string"
+                },
+                "max_output_tokens": {
+                    "type": "integer"
+                },
                 "metadata": {
                     "type": "object",
                     "additionalProperties": true
@@ -369,7 +490,18 @@ const docTemplate = `{
                 "object": {
                     "type": "string"
                 },
-                "output": {},
+                "output": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/responses.OutputItem"
+                    }
+                },
+                "output_text": {
+                    "type": "string"
+                },
+                "parallel_tool_calls": {
+                    "type": "boolean"
+                },
                 "previous_response_id": {
                     "type": "string"
                 },
@@ -385,8 +517,82 @@ const docTemplate = `{
                 "system_prompt": {
                     "type": "string"
                 },
-                "usage": {}
+                "temperature": {
+                    "type": "number"
+                },
+                "tool_choice": {
+                    "type": "string"
+                },
+                "tools": {
+                    "type": "array",
+                    "items": {}
+                },
+                "top_p": {
+                    "type": "number"
+                },
+                "usage": {
+                    "$ref": "#/definitions/responses.Usage"
+                }
+            }
+        },
+        "responses.Usage": {
+            "type": "object",
+            "properties": {
+                "input_tokens": {
+                    "type": "integer"
+                },
+                "input_tokens_details": {
+                    "$ref": "#/definitions/responses.InputTokensDetails"
+                },
+                "output_tokens": {
+                    "type": "integer"
+                },
+                "output_tokens_details": {
+                    "$ref": "#/definitions/responses.OutputTokensDetails"
+                },
+                "total_tokens": {
+                    "type": "integer"
+                }
+            }
+        },
+        "responses.WebSearchCallAction": {
+            "type": "object",
+            "properties": {
+                "query": {
+                    "type": "string"
+                },
+                "type": {
+                    "description": "\"search\" or \"open_page\"",
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
             }
+        },
+        "tool.PlanStep": {
+            "type": "object",
+            "properties": {
+                "status": {
+                    "$ref": "#/definitions/tool.PlanStepStatus"
+                },
+                "step": {
+                    "type": "string"
+                }
+            }
+        },
+        "tool.PlanStepStatus": {
+            "type": "string",
+            "enum": [
+                "pending",
+                "in_progress",
+                "completed"
+            ],
+            "x-enum-varnames": [
+                "PlanStepPending",
+                "PlanStepInProgress",
+                "PlanStepCompleted"
+            ]
         }
     },
     "securityDefinitions": {
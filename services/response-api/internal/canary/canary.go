@@ -0,0 +1,266 @@
+package canary
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/janhq/jan-server/packages/go-common/leader"
+	"github.com/rs/zerolog"
+
+	"jan-server/services/response-api/internal/domain/llm"
+	"jan-server/services/response-api/internal/domain/tool"
+	"jan-server/services/response-api/internal/infrastructure/metrics"
+)
+
+// Failure stages reported in metrics and status.
+const (
+	StageNone   = "none"
+	StageTools  = "list_tools"
+	StageLLM    = "llm"
+	StageTool   = "tool_call"
+	StageAnswer = "answer"
+)
+
+// Executor runs the tool orchestration loop; satisfied by *tool.Orchestrator.
+type Executor interface {
+	Execute(params tool.ExecuteParams) (*tool.ExecuteResult, error)
+}
+
+// Config contains canary configuration.
+type Config struct {
+	Interval time.Duration
+	Timeout  time.Duration
+	Model    string
+	Tool     string
+	Prompt   string
+	// APIKey authenticates against llm-api. "Bearer <token>" is sent as the
+	// Authorization header, anything else as X-API-Key.
+	APIKey string
+	// Locker elects the one replica that runs the canary; nil runs it on every
+	// replica. Identity names this replica to the locker.
+	Locker   leader.Locker
+	Identity string
+}
+
+// Result describes one canary run.
+type Result struct {
+	Success    bool      `json:"success"`
+	Stage      string    `json:"stage"`
+	Error      string    `json:"error,omitempty"`
+	StartedAt  time.Time `json:"started_at"`
+	DurationMs int64     `json:"duration_ms"`
+	ToolCalls  int       `json:"tool_calls"`
+}
+
+// Status is the canary summary served by /healthz.
+type Status struct {
+	Model         string     `json:"model"`
+	Tool          string     `json:"tool"`
+	Interval      string     `json:"interval"`
+	Last          *Result    `json:"last,omitempty"`
+	LastSuccessAt *time.Time `json:"last_success_at,omitempty"`
+	Failures      int        `json:"consecutive_failures"`
+	Leader        bool       `json:"leader"` // Whether this replica runs the canary
+}
+
+// Runner periodically executes a scripted conversation against the live
+// llm-api and mcp-tools to catch broken provider keys and tools before users do.
+type Runner struct {
+	executor  Executor
+	mcpClient tool.MCPClient
+	cfg       Config
+	log       zerolog.Logger
+	elector   *leader.Elector // nil elector is always the leader
+	stopChan  chan struct{}
+	wg        sync.WaitGroup
+
+	mu          sync.RWMutex
+	last        *Result
+	lastSuccess *time.Time
+	failures    int
+}
+
+// NewRunner creates a new canary runner.
+func NewRunner(executor Executor, mcpClient tool.MCPClient, cfg Config, log zerolog.Logger) *Runner {
+	return &Runner{
+		executor:  executor,
+		mcpClient: mcpClient,
+		cfg:       cfg,
+		log:       log.With().Str("component", "canary").Str("model", cfg.Model).Logger(),
+		stopChan:  make(chan struct{}),
+	}
+}
+
+// Start runs the canary immediately and then on every interval until ctx is
+// cancelled or Stop is called. With a Locker, only the elected replica runs it.
+func (r *Runner) Start(ctx context.Context) {
+	r.log.Info().Dur("interval", r.cfg.Interval).Str("tool", r.cfg.Tool).Msg("canary started")
+
+	runCtx, cancel := context.WithCancel(ctx)
+	if r.cfg.Locker != nil {
+		r.elector = leader.NewElector(r.cfg.Locker, leader.Config{
+			Identity: r.cfg.Identity,
+			// A new leader runs the canary right away instead of waiting an interval
+			OnStartedLeading: func(leaderCtx context.Context) {
+				r.log.Info().Str("identity", r.elector.Identity()).Msg("became canary leader")
+				r.RunOnce(leaderCtx)
+			},
+			OnStoppedLeading: func() {
+				r.log.Warn().Str("identity", r.elector.Identity()).Msg("lost canary leadership")
+				metrics.ClearCanaryUp(r.cfg.Model)
+			},
+			OnError: func(err error) {
+				r.log.Error().Err(err).Msg("canary leader election error")
+			},
+		})
+		r.wg.Add(1)
+		go func() {
+			defer r.wg.Done()
+			_ = r.elector.Run(runCtx)
+		}()
+	}
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		defer cancel()
+
+		ticker := time.NewTicker(r.cfg.Interval)
+		defer ticker.Stop()
+
+		for {
+			r.elector.RunIfLeader(func() { r.RunOnce(runCtx) })
+			select {
+			case <-ctx.Done():
+				return
+			case <-r.stopChan:
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Stop signals the canary loop to exit and waits for the current run.
+func (r *Runner) Stop() {
+	close(r.stopChan)
+	r.wg.Wait()
+	r.log.Info().Msg("canary stopped")
+}
+
+// RunOnce executes a single canary conversation and records its outcome.
+func (r *Runner) RunOnce(ctx context.Context) Result {
+	started := time.Now()
+	runCtx, cancel := context.WithTimeout(llm.ContextWithAuthToken(ctx, r.cfg.APIKey), r.cfg.Timeout)
+	defer cancel()
+
+	result := Result{StartedAt: started, Stage: StageNone}
+	toolCalls, stage, err := r.execute(runCtx)
+	result.DurationMs = time.Since(started).Milliseconds()
+	result.ToolCalls = toolCalls
+	if err != nil {
+		result.Stage = stage
+		result.Error = err.Error()
+	} else {
+		result.Success = true
+	}
+
+	metrics.RecordCanaryRun(r.cfg.Model, result.Success, result.Stage, time.Since(started).Seconds())
+	r.record(result)
+
+	if result.Success {
+		r.log.Debug().Int64("duration_ms", result.DurationMs).Int("tool_calls", toolCalls).Msg("canary succeeded")
+	} else {
+		r.log.Warn().Str("stage", stage).Err(err).Int64("duration_ms", result.DurationMs).Msg("canary failed")
+	}
+	return result
+}
+
+func (r *Runner) execute(ctx context.Context) (int, string, error) {
+	tools, err := r.mcpClient.ListTools(ctx)
+	if err != nil {
+		return 0, StageTools, fmt.Errorf("list tools: %w", err)
+	}
+	var definitions []llm.ToolDefinition
+	for _, t := range tools {
+		if t.Name == r.cfg.Tool {
+			definitions = append(definitions, t.ToLLMTool())
+		}
+	}
+	if len(definitions) == 0 {
+		return 0, StageTools, fmt.Errorf("tool %q not offered by mcp-tools", r.cfg.Tool)
+	}
+
+	// Only the canary tool is offered; tool_choice stays "auto" because the
+	// orchestrator re-sends it on every iteration and a forced choice would loop.
+	maxTokens := 256
+	result, err := r.executor.Execute(tool.ExecuteParams{
+		Ctx:             ctx,
+		Model:           r.cfg.Model,
+		RequestID:       fmt.Sprintf("canary_%d", time.Now().UnixNano()),
+		UserID:          "canary",
+		MaxTokens:       &maxTokens,
+		ToolDefinitions: definitions,
+		Messages: []llm.ChatMessage{
+			{Role: "system", Content: fmt.Sprintf("You are a health check. Always call the %s tool before answering.", r.cfg.Tool)},
+			{Role: "user", Content: r.cfg.Prompt},
+		},
+	})
+	if err != nil {
+		return 0, StageLLM, err
+	}
+
+	called := 0
+	for _, execution := range result.Executions {
+		if execution.ToolName != r.cfg.Tool {
+			continue
+		}
+		called++
+		if execution.Status != tool.ExecutionStatusCompleted {
+			return len(result.Executions), StageTool, fmt.Errorf("%s failed: %s", r.cfg.Tool, execution.ErrorMessage)
+		}
+	}
+	if called == 0 {
+		return len(result.Executions), StageTool, fmt.Errorf("model did not call %s", r.cfg.Tool)
+	}
+	if strings.TrimSpace(result.FinalMessage.GetContentAsString()) == "" {
+		return len(result.Executions), StageAnswer, errors.New("empty final answer")
+	}
+	return len(result.Executions), StageNone, nil
+}
+
+func (r *Runner) record(result Result) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.last = &result
+	if result.Success {
+		at := result.StartedAt
+		r.lastSuccess = &at
+		r.failures = 0
+	} else {
+		r.failures++
+	}
+}
+
+// Status returns a snapshot of the latest canary outcome.
+func (r *Runner) Status() Status {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	status := Status{
+		Model:         r.cfg.Model,
+		Tool:          r.cfg.Tool,
+		Interval:      r.cfg.Interval.String(),
+		LastSuccessAt: r.lastSuccess,
+		Failures:      r.failures,
+		Leader:        r.elector.IsLeader(),
+	}
+	if r.last != nil {
+		last := *r.last
+		status.Last = &last
+	}
+	return status
+}
@@ -7,6 +7,8 @@ import (
 	"time"
 
 	"github.com/caarlos0/env/v10"
+	"github.com/janhq/jan-server/packages/go-common/clients"
+	"github.com/janhq/jan-server/packages/go-common/httpclient"
 )
 
 // Config holds the environment driven configuration for the response service.
@@ -18,6 +20,8 @@ type Config struct {
 	LogLevel        string        `env:"RESPONSE_LOG_LEVEL" envDefault:"info"`
 	EnableTracing   bool          `env:"ENABLE_TRACING" envDefault:"false"`
 	OTLPEndpoint    string        `env:"OTEL_EXPORTER_OTLP_ENDPOINT" envDefault:""`
+	OTLPHeaders     string        `env:"OTEL_EXPORTER_OTLP_HEADERS"`           // Sent with exported logs
+	LogExporter     string        `env:"OTEL_LOGS_EXPORTER" envDefault:"none"` // otlp: also export logs to OTLPEndpoint
 	ShutdownTimeout time.Duration `env:"SHUTDOWN_TIMEOUT" envDefault:"10s"`
 
 	// Database - Read/Write Split (required, no defaults)
@@ -34,15 +38,38 @@ type Config struct {
 	AuthIssuer  string `env:"AUTH_ISSUER"`
 	Account     string `env:"ACCOUNT"`
 	AuthJWKSURL string `env:"AUTH_JWKS_URL"`
+	// Third-party clients whose exchanged tokens only llm-api accepts; rejected here
+	IntegrationClients []string `env:"INTEGRATION_CLIENTS" envSeparator:","`
+
+	// Maintenance mode switched on llm-api, shared through this Redis; off when unset
+	RedisURL                   string        `env:"REDIS_URL"`
+	MaintenanceRefreshInterval time.Duration `env:"MAINTENANCE_REFRESH_INTERVAL" envDefault:"5s"`
 
 	// External Services
 	LLMAPIURL   string `env:"RESPONSE_LLM_API_URL" envDefault:"http://localhost:8080"`
 	MCPToolsURL string `env:"RESPONSE_MCP_TOOLS_URL" envDefault:"http://localhost:8091"`
 
+	// Outbound HTTP clients, tuned per target through <PREFIX>TIMEOUT, <PREFIX>MAX_CONNS_PER_HOST,
+	// <PREFIX>RETRIES, <PREFIX>PROXY_URL, ... (see httpclient.FromEnv)
+	LLMAPIHTTP   httpclient.Config `env:"-"` // RESPONSE_LLM_API_HTTP_*: completions, including streams
+	MCPToolsHTTP httpclient.Config `env:"-"` // RESPONSE_MCP_TOOLS_HTTP_*: tool listing and calls
+
+	// Circuit breaker of the mcp-tools client: RESPONSE_MCP_TOOLS_HTTP_BREAKER_FAILURES
+	// and RESPONSE_MCP_TOOLS_HTTP_BREAKER_OPEN_TIMEOUT (see clients.BreakerFromEnv)
+	MCPToolsBreaker clients.BreakerConfig `env:"-"`
+
 	// Tool Execution
 	MaxToolDepth int           `env:"RESPONSE_MAX_TOOL_DEPTH" envDefault:"8"`
 	ToolTimeout  time.Duration `env:"TOOL_EXECUTION_TIMEOUT" envDefault:"300s"`
 
+	// Streaming
+	// SSEHeartbeatInterval controls how often an SSE comment is sent while a
+	// stream is idle so proxies keep the connection open. Zero disables it.
+	SSEHeartbeatInterval time.Duration `env:"SSE_HEARTBEAT_INTERVAL" envDefault:"15s"`
+	// SSEDefaultStreamVersion is the event version streamed to clients that do
+	// not send X-Jan-Stream-Version. Keep it at 1 until those clients upgrade.
+	SSEDefaultStreamVersion int `env:"SSE_DEFAULT_STREAM_VERSION" envDefault:"1"`
+
 	// Background Task Processing
 	BackgroundWorkerCount  int           `env:"BACKGROUND_WORKER_COUNT" envDefault:"4"`
 	BackgroundTaskTimeout  time.Duration `env:"BACKGROUND_TASK_TIMEOUT" envDefault:"600s"`
@@ -50,6 +77,19 @@ type Config struct {
 	WebhookTimeout         time.Duration `env:"WEBHOOK_TIMEOUT" envDefault:"10s"`
 	WebhookMaxRetries      int           `env:"WEBHOOK_MAX_RETRIES" envDefault:"3"`
 	WebhookRetryDelay      time.Duration `env:"WEBHOOK_RETRY_DELAY" envDefault:"2s"`
+
+	// Synthetic Canary
+	CanaryEnabled  bool          `env:"RESPONSE_CANARY_ENABLED" envDefault:"false"`
+	CanaryInterval time.Duration `env:"RESPONSE_CANARY_INTERVAL" envDefault:"5m"`
+	CanaryTimeout  time.Duration `env:"RESPONSE_CANARY_TIMEOUT" envDefault:"60s"`
+	CanaryModel    string        `env:"RESPONSE_CANARY_MODEL"`
+	CanaryTool     string        `env:"RESPONSE_CANARY_TOOL" envDefault:"google_search"`
+	CanaryPrompt   string        `env:"RESPONSE_CANARY_PROMPT" envDefault:"Search the web for the current Jan AI release and answer in one sentence."`
+	CanaryAPIKey   string        `env:"RESPONSE_CANARY_API_KEY"`
+	// Only the replica holding the Postgres advisory lock runs the canary
+	CanaryLeaderElection bool   `env:"RESPONSE_CANARY_LEADER_ELECTION" envDefault:"true"`
+	CanaryLockName       string `env:"RESPONSE_CANARY_LOCK_NAME" envDefault:"response-api-canary"`
+	PodName              string `env:"POD_NAME"` // Replica identity; defaults to the hostname
 }
 
 // Load parses environment variables into Config.
@@ -82,6 +122,43 @@ func Load() (*Config, error) {
 		cfg.ToolTimeout = 300 * time.Second
 	}
 
+	if cfg.SSEHeartbeatInterval < 0 {
+		cfg.SSEHeartbeatInterval = 0
+	}
+
+	if cfg.SSEDefaultStreamVersion <= 0 {
+		cfg.SSEDefaultStreamVersion = 1
+	}
+
+	// Completions may stream for a long time; tool calls are bounded by TOOL_EXECUTION_TIMEOUT
+	llmAPI := httpclient.DefaultConfig()
+	llmAPI.Timeout = 900 * time.Second
+	llmAPI.MaxIdleConnsPerHost = 32
+	mcpTools := httpclient.DefaultConfig()
+	mcpTools.Timeout = 0
+	var err error
+	if cfg.LLMAPIHTTP, err = httpclient.FromEnv("RESPONSE_LLM_API_HTTP_", llmAPI); err != nil {
+		return nil, err
+	}
+	if cfg.MCPToolsHTTP, err = httpclient.FromEnv("RESPONSE_MCP_TOOLS_HTTP_", mcpTools); err != nil {
+		return nil, err
+	}
+	if cfg.MCPToolsBreaker, err = clients.BreakerFromEnv("RESPONSE_MCP_TOOLS_HTTP_", clients.DefaultBreakerConfig()); err != nil {
+		return nil, err
+	}
+
+	if cfg.CanaryEnabled {
+		if strings.TrimSpace(cfg.CanaryModel) == "" {
+			return nil, fmt.Errorf("RESPONSE_CANARY_MODEL is required when RESPONSE_CANARY_ENABLED is true")
+		}
+		if strings.TrimSpace(cfg.CanaryAPIKey) == "" {
+			return nil, fmt.Errorf("RESPONSE_CANARY_API_KEY is required when RESPONSE_CANARY_ENABLED is true")
+		}
+		if cfg.CanaryInterval < time.Minute {
+			cfg.CanaryInterval = time.Minute
+		}
+	}
+
 	return cfg, nil
 }
 
@@ -32,6 +32,7 @@ const (
 type Item struct {
 	ID             uint                   `json:"-"`
 	ConversationID uint                   `json:"conversation_id"`
+	ResponseID     *uint                  `json:"-"` // Response that wrote the item; nil for items stored before responses were linked
 	Role           ItemRole               `json:"role"`
 	Status         ItemStatus             `json:"status"`
 	Content        map[string]interface{} `json:"content"`
@@ -12,4 +12,6 @@ type Repository interface {
 type ItemRepository interface {
 	BulkInsert(ctx context.Context, items []Item) error
 	ListByConversationID(ctx context.Context, conversationID uint) ([]Item, error)
+	ListByResponseIDs(ctx context.Context, responseIDs []uint) ([]Item, error)
+	NextSequence(ctx context.Context, conversationID uint) (int, error)
 }
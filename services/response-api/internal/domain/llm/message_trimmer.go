@@ -118,6 +118,20 @@ func TrimMessagesToFitContext(messages []ChatMessage, contextLength int) TrimMes
 	}
 }
 
+// RemainingContextTokens estimates how much of the context window is left for
+// new content such as tool results, after the messages, the safety margin and
+// the tokens reserved for the reply.
+func RemainingContextTokens(messages []ChatMessage, contextLength int, maxTokens *int) int {
+	if contextLength <= 0 {
+		contextLength = DefaultContextLength
+	}
+	remaining := int(float64(contextLength)*SafetyMarginRatio) - EstimateMessagesTokenCount(messages)
+	if maxTokens != nil && *maxTokens > 0 {
+		remaining -= *maxTokens
+	}
+	return max(remaining, 0)
+}
+
 // TrimToolResultContent truncates tool result content if it exceeds maxChars.
 // Returns the original content if within limits.
 func TrimToolResultContent(content interface{}, maxChars int) interface{} {
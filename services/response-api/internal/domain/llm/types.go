@@ -19,13 +19,19 @@ type Stream interface {
 
 // ChatCompletionRequest mirrors the OpenAI-compatible request shape exposed by llm-api.
 type ChatCompletionRequest struct {
-	Model       string           `json:"model"`
-	Messages    []ChatMessage    `json:"messages"`
-	Tools       []ToolDefinition `json:"tools,omitempty"`
-	ToolChoice  *ToolChoice      `json:"tool_choice,omitempty"`
-	Temperature *float64         `json:"temperature,omitempty"`
-	MaxTokens   *int             `json:"max_tokens,omitempty"`
-	Stream      bool             `json:"stream"`
+	Model         string           `json:"model"`
+	Messages      []ChatMessage    `json:"messages"`
+	Tools         []ToolDefinition `json:"tools,omitempty"`
+	ToolChoice    *ToolChoice      `json:"tool_choice,omitempty"`
+	Temperature   *float64         `json:"temperature,omitempty"`
+	MaxTokens     *int             `json:"max_tokens,omitempty"`
+	Stream        bool             `json:"stream"`
+	StreamOptions *StreamOptions   `json:"stream_options,omitempty"`
+}
+
+// StreamOptions mirrors the OpenAI stream_options request field.
+type StreamOptions struct {
+	IncludeUsage bool `json:"include_usage"`
 }
 
 // ChatMessage represents a single message in the conversation history.
@@ -104,14 +110,70 @@ type ChatCompletionChoice struct {
 
 // Usage contains token accounting metadata.
 type Usage struct {
-	PromptTokens     int `json:"prompt_tokens"`
-	CompletionTokens int `json:"completion_tokens"`
-	TotalTokens      int `json:"total_tokens"`
+	PromptTokens            int                      `json:"prompt_tokens"`
+	CompletionTokens        int                      `json:"completion_tokens"`
+	TotalTokens             int                      `json:"total_tokens"`
+	PromptTokensDetails     *PromptTokensDetails     `json:"prompt_tokens_details,omitempty"`
+	CompletionTokensDetails *CompletionTokensDetails `json:"completion_tokens_details,omitempty"`
+}
+
+// PromptTokensDetails breaks down prompt tokens, when the provider reports it.
+type PromptTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+// CompletionTokensDetails breaks down completion tokens, when the provider reports it.
+type CompletionTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+// Add returns the sum of two usage records; either may be nil.
+func (u *Usage) Add(other *Usage) *Usage {
+	if u == nil {
+		return other
+	}
+	if other == nil {
+		return u
+	}
+	sum := &Usage{
+		PromptTokens:     u.PromptTokens + other.PromptTokens,
+		CompletionTokens: u.CompletionTokens + other.CompletionTokens,
+		TotalTokens:      u.TotalTokens + other.TotalTokens,
+	}
+	if u.PromptTokensDetails != nil || other.PromptTokensDetails != nil {
+		sum.PromptTokensDetails = &PromptTokensDetails{
+			CachedTokens: u.CachedTokens() + other.CachedTokens(),
+		}
+	}
+	if u.CompletionTokensDetails != nil || other.CompletionTokensDetails != nil {
+		sum.CompletionTokensDetails = &CompletionTokensDetails{
+			ReasoningTokens: u.ReasoningTokens() + other.ReasoningTokens(),
+		}
+	}
+	return sum
+}
+
+// CachedTokens returns the prompt tokens served from the provider's cache.
+func (u *Usage) CachedTokens() int {
+	if u == nil || u.PromptTokensDetails == nil {
+		return 0
+	}
+	return u.PromptTokensDetails.CachedTokens
+}
+
+// ReasoningTokens returns the completion tokens spent on reasoning.
+func (u *Usage) ReasoningTokens() int {
+	if u == nil || u.CompletionTokensDetails == nil {
+		return 0
+	}
+	return u.CompletionTokensDetails.ReasoningTokens
 }
 
 // ChatCompletionDelta represents a streaming chunk.
+// Usage is only set on the final chunk when stream_options.include_usage is requested.
 type ChatCompletionDelta struct {
 	Choices []ChatCompletionDeltaChoice `json:"choices"`
+	Usage   *Usage                      `json:"usage,omitempty"`
 }
 
 // ChatCompletionDeltaChoice mirrors OpenAI streaming deltas.
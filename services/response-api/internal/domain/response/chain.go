@@ -0,0 +1,159 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"jan-server/services/response-api/internal/domain/conversation"
+	"jan-server/services/response-api/internal/utils/platformerrors"
+)
+
+// maxResponseChainLength bounds how many previous_response_id links are followed.
+const maxResponseChainLength = 1000
+
+var (
+	// ErrPreviousResponseNotFound is returned when previous_response_id does not
+	// name a response of the caller.
+	ErrPreviousResponseNotFound = errors.New("previous response not found")
+	// ErrConversationWithPreviousResponse is returned when a request sets both
+	// conversation and previous_response_id, which OpenAI rejects as well.
+	ErrConversationWithPreviousResponse = errors.New("conversation and previous_response_id are mutually exclusive")
+	// ErrResponseNotFound is returned when a response does not exist or
+	// belongs to another user.
+	ErrResponseNotFound = errors.New("response not found")
+)
+
+// responseContext is where a new response is stored and the history replayed
+// to the model before its input.
+type responseContext struct {
+	conversation *conversation.Conversation
+	history      []conversation.Item
+	nextSequence int
+}
+
+// loadContext resolves a new response's context. With previousResponseID the
+// history is the chain of responses ending at it, so branching from an older
+// response leaves out what came after it; with conversationID it is the whole
+// conversation; otherwise a new conversation is started.
+func (s *ServiceImpl) loadContext(ctx context.Context, userID string, previousResponseID, conversationID *string) (*responseContext, error) {
+	previousID := trimmedValue(previousResponseID)
+	convID := trimmedValue(conversationID)
+	if previousID != "" && convID != "" {
+		return nil, ErrConversationWithPreviousResponse
+	}
+	if previousID != "" {
+		return s.loadResponseChain(ctx, userID, previousID)
+	}
+
+	var conv *conversation.Conversation
+	if convID != "" {
+		found, err := s.conversations.FindByPublicID(ctx, convID)
+		if err != nil {
+			return nil, fmt.Errorf("fetch conversation: %w", err)
+		}
+		conv = found
+	} else {
+		conv = &conversation.Conversation{
+			PublicID: newPublicID("conv"),
+			UserID:   userID,
+		}
+		if err := s.conversations.Create(ctx, conv); err != nil {
+			return nil, fmt.Errorf("create conversation: %w", err)
+		}
+		return &responseContext{conversation: conv}, nil
+	}
+
+	items, err := s.conversationItems.ListByConversationID(ctx, conv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("list conversation items: %w", err)
+	}
+	next := 0
+	if len(items) > 0 {
+		next = items[len(items)-1].Sequence + 1
+	}
+	return &responseContext{conversation: conv, history: items, nextSequence: next}, nil
+}
+
+// loadResponseChain follows previous_response_id links back from previousID
+// and replays the items each response in the chain wrote, oldest first.
+func (s *ServiceImpl) loadResponseChain(ctx context.Context, userID, previousID string) (*responseContext, error) {
+	var chain []*Response
+	seen := make(map[string]bool)
+	for id := previousID; id != "" && !seen[id] && len(chain) < maxResponseChainLength; {
+		seen[id] = true
+		resp, err := s.responses.FindByPublicID(ctx, id)
+		if err != nil && !platformerrors.IsErrorType(err, platformerrors.ErrorTypeNotFound) {
+			return nil, fmt.Errorf("load previous response %s: %w", id, err)
+		}
+		if err != nil || resp.UserID != userID {
+			if len(chain) == 0 {
+				return nil, fmt.Errorf("%w: %s", ErrPreviousResponseNotFound, id)
+			}
+			// An older link is gone; replay the part of the chain that remains
+			s.log.Warn().Str("previous_response_id", previousID).Str("missing_response_id", id).Msg("response chain is broken, replaying the remaining responses")
+			break
+		}
+		chain = append(chain, resp)
+		id = trimmedValue(resp.PreviousResponseID)
+	}
+
+	head := chain[0]
+	if head.ConversationPublicID == nil {
+		return nil, fmt.Errorf("%w: %s has no conversation", ErrPreviousResponseNotFound, previousID)
+	}
+	conv, err := s.conversations.FindByPublicID(ctx, *head.ConversationPublicID)
+	if err != nil {
+		return nil, fmt.Errorf("fetch conversation: %w", err)
+	}
+	next, err := s.conversationItems.NextSequence(ctx, conv.ID)
+	if err != nil {
+		return nil, fmt.Errorf("next conversation sequence: %w", err)
+	}
+
+	ids := make([]uint, 0, len(chain))
+	for _, resp := range chain {
+		ids = append(ids, resp.ID)
+	}
+	items, err := s.conversationItems.ListByResponseIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("list response chain items: %w", err)
+	}
+
+	// Items come back in sequence order; regroup them in chain order, oldest
+	// first, since responses of different branches interleave in a conversation
+	byResponse := make(map[uint][]conversation.Item, len(chain))
+	for _, item := range items {
+		byResponse[*item.ResponseID] = append(byResponse[*item.ResponseID], item)
+	}
+
+	var history []conversation.Item
+	for _, resp := range chain {
+		if resp.Status == StatusCompleted && len(byResponse[resp.ID]) == 0 {
+			// The chain reaches responses stored before items were linked to
+			// them; their history is the conversation's unlinked items
+			legacy, err := s.conversationItems.ListByConversationID(ctx, conv.ID)
+			if err != nil {
+				return nil, fmt.Errorf("list conversation items: %w", err)
+			}
+			for _, item := range legacy {
+				if item.ResponseID == nil {
+					history = append(history, item)
+				}
+			}
+			break
+		}
+	}
+	for i := len(chain) - 1; i >= 0; i-- {
+		history = append(history, byResponse[chain[i].ID]...)
+	}
+	return &responseContext{conversation: conv, history: history, nextSequence: next}, nil
+}
+
+func trimmedValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return strings.TrimSpace(*value)
+}
@@ -0,0 +1,53 @@
+package response
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrResponseCancelled is the cancellation cause used when a running response is
+// stopped through Cancel rather than by its client disconnecting.
+var ErrResponseCancelled = errors.New("response cancelled")
+
+// inflightRegistry tracks the orchestration contexts of responses running in this
+// process so Cancel can abort provider calls and tool executions mid-flight.
+// Cancellation is process-local: a cancel request routed to another replica only
+// updates the stored status.
+type inflightRegistry struct {
+	mu      sync.Mutex
+	cancels map[string]context.CancelCauseFunc
+}
+
+func newInflightRegistry() *inflightRegistry {
+	return &inflightRegistry{cancels: make(map[string]context.CancelCauseFunc)}
+}
+
+// track derives a cancellable context for the response and registers it. The
+// returned release func must be called once the orchestration finishes.
+func (r *inflightRegistry) track(ctx context.Context, publicID string) (context.Context, func()) {
+	runCtx, cancel := context.WithCancelCause(ctx)
+
+	r.mu.Lock()
+	r.cancels[publicID] = cancel
+	r.mu.Unlock()
+
+	return runCtx, func() {
+		r.mu.Lock()
+		delete(r.cancels, publicID)
+		r.mu.Unlock()
+		cancel(nil)
+	}
+}
+
+// cancel aborts the response if it is running in this process.
+func (r *inflightRegistry) cancel(publicID string) bool {
+	r.mu.Lock()
+	cancel, ok := r.cancels[publicID]
+	r.mu.Unlock()
+
+	if ok {
+		cancel(ErrResponseCancelled)
+	}
+	return ok
+}
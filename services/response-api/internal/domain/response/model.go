@@ -18,6 +18,7 @@ const (
 	StatusCompleted  Status = "completed"
 	StatusFailed     Status = "failed"
 	StatusCancelled  Status = "cancelled"
+	StatusIncomplete Status = "incomplete"
 )
 
 // Response is the main aggregate persisted to the database.
@@ -29,7 +30,7 @@ type Response struct {
 	Model                string                 `json:"model"`
 	SystemPrompt         *string                `json:"system_prompt,omitempty"`
 	Input                interface{}            `json:"input"`
-	Output               interface{}            `json:"output,omitempty"`
+	Output               []OutputItem           `json:"output,omitempty"`
 	Status               Status                 `json:"status"`
 	Stream               bool                   `json:"stream"`
 	Background           bool                   `json:"background"`
@@ -38,6 +39,7 @@ type Response struct {
 	Metadata             map[string]interface{} `json:"metadata,omitempty"`
 	Usage                *llm.Usage             `json:"usage,omitempty"`
 	Error                *ErrorDetails          `json:"error,omitempty"`
+	IncompleteDetails    *IncompleteDetails     `json:"incomplete_details,omitempty"`
 	ConversationID       *uint                  `json:"-"`
 	ConversationPublicID *string                `json:"conversation_id,omitempty"`
 	PreviousResponseID   *string                `json:"previous_response_id,omitempty"`
@@ -81,7 +83,7 @@ type CreateParams struct {
 type Service interface {
 	Create(ctx context.Context, params CreateParams) (*Response, error)
 	GetByPublicID(ctx context.Context, publicID string) (*Response, error)
-	Cancel(ctx context.Context, publicID string) (*Response, error)
+	Cancel(ctx context.Context, publicID, userID string) (*Response, error)
 	ListConversationItems(ctx context.Context, publicID string) ([]ConversationItem, error)
 }
 
@@ -96,4 +98,5 @@ type ConversationItem struct {
 type StreamObserver interface {
 	tool.StreamObserver
 	OnResponseCreated(resp *Response)
+	OnPlanUpdated(item OutputItem)
 }
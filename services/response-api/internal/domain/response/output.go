@@ -0,0 +1,180 @@
+package response
+
+import (
+	"encoding/json"
+	"strings"
+
+	"jan-server/services/response-api/internal/domain/llm"
+	"jan-server/services/response-api/internal/domain/tool"
+)
+
+// OutputItemType identifies the kind of an output item.
+type OutputItemType string
+
+const (
+	OutputItemMessage  OutputItemType = "message"
+	OutputItemToolCall OutputItemType = "tool_call"
+	OutputItemPlan     OutputItemType = "plan"
+)
+
+// OutputItem is one step of a response's output, in the order it happened:
+// text from the assistant, an MCP tool call made by the tool loop, or the
+// model's plan in its latest state.
+type OutputItem struct {
+	Type      OutputItemType `json:"type"`
+	ID        string         `json:"id"`
+	Text      string         `json:"text,omitempty"`
+	CallID    string         `json:"call_id,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	Arguments string         `json:"arguments,omitempty"` // JSON-encoded arguments
+	Status    string         `json:"status,omitempty"`    // Tool execution status, or plan progress
+	Plan      *tool.Plan     `json:"plan,omitempty"`
+}
+
+// Plan progress reported in OutputItem.Status
+const (
+	PlanStatusInProgress = "in_progress"
+	PlanStatusCompleted  = "completed"
+)
+
+// NewPlanItem wraps a plan as an output item.
+func NewPlanItem(id string, plan tool.Plan) OutputItem {
+	status := PlanStatusInProgress
+	if plan.Completed() {
+		status = PlanStatusCompleted
+	}
+	return OutputItem{Type: OutputItemPlan, ID: id, Status: status, Plan: &plan}
+}
+
+// IncompleteDetails explains why a response stopped before the model finished.
+type IncompleteDetails struct {
+	Reason string `json:"reason"`
+}
+
+// BuildOutput converts the messages the tool loop appended after the user's
+// input into output items, taking each tool call's status from its execution.
+// update_plan calls become a single plan item, placed where the plan was first
+// published and holding its last valid version.
+func BuildOutput(messages []llm.ChatMessage, executions []tool.Execution) []OutputItem {
+	start := 0
+	for i := len(messages) - 1; i >= 0; i-- {
+		if messages[i].Role == "user" {
+			start = i + 1
+			break
+		}
+	}
+
+	statuses := make(map[string]tool.ExecutionStatus, len(executions))
+	arguments := make(map[string]map[string]any, len(executions))
+	for _, execution := range executions {
+		statuses[execution.CallID] = execution.Status
+		arguments[execution.CallID] = execution.Arguments
+	}
+
+	items := make([]OutputItem, 0, len(messages)-start)
+	planIndex := -1
+	for i := start; i < len(messages); i++ {
+		msg := messages[i]
+		if msg.Role != "assistant" {
+			continue
+		}
+		text := msg.GetContentAsString()
+		final := len(msg.ToolCalls) == 0
+		if text != "" || final {
+			items = append(items, OutputItem{Type: OutputItemMessage, ID: newPublicID("msg"), Text: text})
+		}
+		for _, call := range msg.ToolCalls {
+			if call.Function.Name == tool.PlanToolName {
+				parsedCall, err := tool.ParseToolCall(call)
+				if err != nil {
+					continue
+				}
+				plan, err := tool.ParsePlan(parsedCall.Arguments)
+				if err != nil {
+					continue
+				}
+				if planIndex < 0 {
+					planIndex = len(items)
+					items = append(items, NewPlanItem(newPublicID("plan"), plan))
+				} else {
+					items[planIndex] = NewPlanItem(items[planIndex].ID, plan)
+				}
+				continue
+			}
+			item := OutputItem{
+				Type:      OutputItemToolCall,
+				ID:        newPublicID("fc"),
+				CallID:    call.ID,
+				Name:      call.Function.Name,
+				Arguments: toolCallArguments(call, arguments[call.ID]),
+				Status:    string(tool.ExecutionStatusCancelled),
+			}
+			if status, ok := statuses[call.ID]; ok {
+				item.Status = string(status)
+			}
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// PlanItem returns the response's plan item, if the model published a plan.
+func (r *Response) PlanItem() *OutputItem {
+	for i := range r.Output {
+		if r.Output[i].Type == OutputItemPlan {
+			return &r.Output[i]
+		}
+	}
+	return nil
+}
+
+// OutputFromContent wraps output stored before responses kept output items,
+// which was the final message's content, as a single message item.
+func OutputFromContent(responseID string, content interface{}) []OutputItem {
+	msg := llm.ChatMessage{Content: content}
+	return []OutputItem{{
+		Type: OutputItemMessage,
+		ID:   "msg_" + strings.TrimPrefix(responseID, "resp_"),
+		Text: msg.GetContentAsString(),
+	}}
+}
+
+// OutputText joins the text of the response's messages.
+func (r *Response) OutputText() string {
+	var parts []string
+	for _, item := range r.Output {
+		if item.Type == OutputItemMessage && item.Text != "" {
+			parts = append(parts, item.Text)
+		}
+	}
+	return strings.Join(parts, "\n\n")
+}
+
+// incompleteDetailsFor maps the final completion's finish reason to the reason
+// a response is incomplete; nil means the model finished normally.
+func incompleteDetailsFor(finishReason string) *IncompleteDetails {
+	switch finishReason {
+	case "length":
+		return &IncompleteDetails{Reason: "max_output_tokens"}
+	case "content_filter":
+		return &IncompleteDetails{Reason: "content_filter"}
+	}
+	return nil
+}
+
+// toolCallArguments returns a tool call's arguments as a JSON object string,
+// preferring the arguments the execution parsed since providers may
+// double-encode them.
+func toolCallArguments(call llm.ToolCall, parsed map[string]any) string {
+	if parsed != nil {
+		if data, err := json.Marshal(parsed); err == nil {
+			return string(data)
+		}
+	}
+	if parsedCall, err := tool.ParseToolCall(call); err == nil && parsedCall.Arguments != nil {
+		if data, err := json.Marshal(parsedCall.Arguments); err == nil {
+			return string(data)
+		}
+	}
+	return "{}"
+}
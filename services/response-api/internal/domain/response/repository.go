@@ -10,6 +10,7 @@ import (
 type Repository interface {
 	Create(ctx context.Context, response *Response) error
 	Update(ctx context.Context, response *Response) error
+	UpdateOutput(ctx context.Context, id uint, output []OutputItem) error
 	FindByPublicID(ctx context.Context, publicID string) (*Response, error)
 	MarkCancelled(ctx context.Context, response *Response) error
 }
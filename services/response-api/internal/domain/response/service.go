@@ -14,6 +14,7 @@ import (
 	"jan-server/services/response-api/internal/domain/conversation"
 	"jan-server/services/response-api/internal/domain/llm"
 	"jan-server/services/response-api/internal/domain/tool"
+	"jan-server/services/response-api/internal/utils/platformerrors"
 	"jan-server/services/response-api/internal/webhook"
 )
 
@@ -27,6 +28,7 @@ type ServiceImpl struct {
 	mcpClient         tool.MCPClient
 	modelInfoProvider llm.ModelInfoProvider
 	webhookService    webhook.Service
+	inflight          *inflightRegistry
 	log               zerolog.Logger
 }
 
@@ -51,6 +53,7 @@ func NewService(
 		mcpClient:         mcpClient,
 		modelInfoProvider: modelInfoProvider,
 		webhookService:    webhookService,
+		inflight:          newInflightRegistry(),
 		log:               log.With().Str("component", "response-service").Logger(),
 	}
 }
@@ -72,38 +75,13 @@ func (s *ServiceImpl) Create(ctx context.Context, params CreateParams) (*Respons
 
 // createAsync creates a response record with status=queued and returns immediately.
 func (s *ServiceImpl) createAsync(ctx context.Context, params CreateParams) (*Response, error) {
-	var conv *conversation.Conversation
-	var err error
-
-	// Handle conversation context
-	if params.PreviousResponseID != nil && strings.TrimSpace(*params.PreviousResponseID) != "" {
-		prevResp, err := s.responses.FindByPublicID(ctx, *params.PreviousResponseID)
-		if err != nil {
-			s.log.Warn().Err(err).Str("previous_response_id", *params.PreviousResponseID).Msg("failed to load previous response")
-		} else if prevResp.ConversationPublicID != nil {
-			conv, err = s.conversations.FindByPublicID(ctx, *prevResp.ConversationPublicID)
-			if err != nil {
-				s.log.Warn().Err(err).Str("conversation_id", *prevResp.ConversationPublicID).Msg("failed to load conversation")
-			}
-		}
-	}
-
-	if conv == nil {
-		if params.ConversationID != nil && strings.TrimSpace(*params.ConversationID) != "" {
-			conv, err = s.conversations.FindByPublicID(ctx, *params.ConversationID)
-			if err != nil {
-				return nil, fmt.Errorf("fetch conversation: %w", err)
-			}
-		} else {
-			conv = &conversation.Conversation{
-				PublicID: newPublicID("conv"),
-				UserID:   params.UserID,
-			}
-			if err := s.conversations.Create(ctx, conv); err != nil {
-				return nil, fmt.Errorf("create conversation: %w", err)
-			}
-		}
+	// Resolve the context now so a bad previous_response_id fails the request
+	// instead of the background task
+	respCtx, err := s.loadContext(ctx, params.UserID, params.PreviousResponseID, params.ConversationID)
+	if err != nil {
+		return nil, err
 	}
+	conv := respCtx.conversation
 
 	now := time.Now()
 	responseModel := &Response{
@@ -142,50 +120,12 @@ func (s *ServiceImpl) createAsync(ctx context.Context, params CreateParams) (*Re
 
 // createSync executes the response synchronously (original behavior).
 func (s *ServiceImpl) createSync(ctx context.Context, params CreateParams) (*Response, error) {
-	var conv *conversation.Conversation
-	var err error
-	conversationID := ""
-
-	// If PreviousResponseID is provided, load that response's conversation for context
-	if params.PreviousResponseID != nil && strings.TrimSpace(*params.PreviousResponseID) != "" {
-		prevResp, err := s.responses.FindByPublicID(ctx, *params.PreviousResponseID)
-		if err != nil {
-			s.log.Warn().Err(err).Str("previous_response_id", *params.PreviousResponseID).Msg("failed to load previous response, continuing without context")
-		} else if prevResp.ConversationPublicID != nil {
-			// Use the previous response's conversation to maintain context
-			conv, err = s.conversations.FindByPublicID(ctx, *prevResp.ConversationPublicID)
-			if err != nil {
-				s.log.Warn().Err(err).Str("conversation_id", *prevResp.ConversationPublicID).Msg("failed to load conversation, creating new one")
-			}
-		}
-	}
-
-	if conv != nil {
-		conversationID = conv.PublicID
-	}
-
-	// If we still don't have a conversation, check for explicit conversation_id or create new
-	if conv == nil {
-		if params.ConversationID != nil && strings.TrimSpace(*params.ConversationID) != "" {
-			conv, err = s.conversations.FindByPublicID(ctx, *params.ConversationID)
-			if err != nil {
-				return nil, fmt.Errorf("fetch conversation: %w", err)
-			}
-		} else {
-			conv = &conversation.Conversation{
-				PublicID: newPublicID("conv"),
-				UserID:   params.UserID,
-			}
-			if err := s.conversations.Create(ctx, conv); err != nil {
-				return nil, fmt.Errorf("create conversation: %w", err)
-			}
-		}
-	}
-
-	existingItems, err := s.conversationItems.ListByConversationID(ctx, conv.ID)
+	respCtx, err := s.loadContext(ctx, params.UserID, params.PreviousResponseID, params.ConversationID)
 	if err != nil {
-		return nil, fmt.Errorf("list conversation items: %w", err)
+		return nil, err
 	}
+	conv := respCtx.conversation
+	conversationID := conv.PublicID
 
 	responseModel := &Response{
 		PublicID:             newPublicID("resp"),
@@ -214,12 +154,16 @@ func (s *ServiceImpl) createSync(ctx context.Context, params CreateParams) (*Res
 		return nil, fmt.Errorf("create response: %w", err)
 	}
 
-	baseMessages, err := s.buildBaseMessages(params.SystemPrompt, existingItems)
+	// runCtx is cancelled by Cancel as well as by the client disconnecting
+	runCtx, release := s.inflight.track(ctx, responseModel.PublicID)
+	defer release()
+
+	baseMessages, err := s.buildBaseMessages(params.SystemPrompt, respCtx.history)
 	if err != nil {
 		return s.failResponse(ctx, responseModel, fmt.Errorf("build base messages: %w", err))
 	}
 
-	userMessages, convoItems, err := s.convertInputToMessages(conv.ID, len(existingItems), params.Input)
+	userMessages, convoItems, err := s.convertInputToMessages(conv.ID, respCtx.nextSequence, params.Input)
 	if err != nil {
 		return s.failResponse(ctx, responseModel, err)
 	}
@@ -246,7 +190,7 @@ func (s *ServiceImpl) createSync(ctx context.Context, params CreateParams) (*Res
 
 	execParams := func(defs []llm.ToolDefinition, toolChoice *llm.ToolChoice) tool.ExecuteParams {
 		return tool.ExecuteParams{
-			Ctx:             ctx,
+			Ctx:             runCtx,
 			Model:           params.Model,
 			Messages:        messages,
 			RequestID:       params.RequestID,
@@ -258,6 +202,7 @@ func (s *ServiceImpl) createSync(ctx context.Context, params CreateParams) (*Res
 			ToolChoice:      toolChoice,
 			ToolDefinitions: defs,
 			StreamObserver:  params.StreamObserver,
+			OnPlanUpdate:    s.planRecorder(ctx, responseModel, params.StreamObserver),
 		}
 	}
 
@@ -266,13 +211,14 @@ func (s *ServiceImpl) createSync(ctx context.Context, params CreateParams) (*Res
 		s.log.Warn().Err(err).Str("response_id", responseModel.PublicID).Msg("llm provider rejected tool definitions, retrying without tools")
 		orchestratorResult, err = s.orchestrator.Execute(execParams(nil, nil))
 	}
+	if errors.Is(err, tool.ErrExecutionAborted) {
+		return s.abortResponse(ctx, responseModel, orchestratorResult, err)
+	}
 	if err != nil {
 		return s.failResponse(ctx, responseModel, err)
 	}
 
-	responseModel.Status = StatusCompleted
-	responseModel.Output = orchestratorResult.FinalMessage.Content
-	responseModel.Usage = orchestratorResult.Usage
+	responseModel.complete(orchestratorResult)
 	now := time.Now()
 	responseModel.CompletedAt = &now
 	responseModel.UpdatedAt = now
@@ -286,8 +232,8 @@ func (s *ServiceImpl) createSync(ctx context.Context, params CreateParams) (*Res
 	}
 
 	newMessages := orchestratorResult.Messages[initialLength:]
-	newItems := append(convoItems, s.convertMessagesToItems(conv.ID, len(existingItems)+len(convoItems), newMessages)...)
-	if err := s.conversationItems.BulkInsert(ctx, newItems); err != nil {
+	newItems := append(convoItems, s.convertMessagesToItems(conv.ID, respCtx.nextSequence+len(convoItems), newMessages)...)
+	if err := s.conversationItems.BulkInsert(ctx, linkItems(newItems, responseModel.ID)); err != nil {
 		s.log.Error().Err(err).Str("response_id", responseModel.PublicID).Msg("store conversation items failed")
 	}
 
@@ -302,25 +248,39 @@ func (s *ServiceImpl) GetByPublicID(ctx context.Context, publicID string) (*Resp
 // Cancel marks the response as cancelled.
 // For queued tasks, this prevents them from being picked up by workers.
 // For in-progress tasks, workers should periodically check cancellation status.
-func (s *ServiceImpl) Cancel(ctx context.Context, publicID string) (*Response, error) {
+// Only the user that created the response can cancel it; to anyone else it
+// does not exist.
+func (s *ServiceImpl) Cancel(ctx context.Context, publicID, userID string) (*Response, error) {
 	resp, err := s.responses.FindByPublicID(ctx, publicID)
 	if err != nil {
+		if platformerrors.IsErrorType(err, platformerrors.ErrorTypeNotFound) {
+			return nil, fmt.Errorf("%w: %s", ErrResponseNotFound, publicID)
+		}
 		return nil, err
 	}
+	if resp.UserID != userID {
+		return nil, fmt.Errorf("%w: %s", ErrResponseNotFound, publicID)
+	}
 
 	// Already in terminal state
-	if resp.Status == StatusCompleted || resp.Status == StatusCancelled || resp.Status == StatusFailed {
+	if resp.Status == StatusCompleted || resp.Status == StatusIncomplete || resp.Status == StatusCancelled || resp.Status == StatusFailed {
 		return resp, nil
 	}
 
+	previousStatus := resp.Status
+
 	// Cancel the response
 	if err := s.responses.MarkCancelled(ctx, resp); err != nil {
 		return nil, err
 	}
 
+	// Abort provider calls and tool executions if the response runs in this process
+	abortedInFlight := s.inflight.cancel(resp.PublicID)
+
 	s.log.Info().
 		Str("response_id", resp.PublicID).
-		Str("previous_status", string(resp.Status)).
+		Str("previous_status", string(previousStatus)).
+		Bool("aborted_in_flight", abortedInFlight).
 		Msg("response cancelled")
 
 	return resp, nil
@@ -353,6 +313,44 @@ func (s *ServiceImpl) ListConversationItems(ctx context.Context, publicID string
 	return result, nil
 }
 
+// complete records a finished tool loop: its output items, usage, and whether
+// the final completion was cut short.
+func (r *Response) complete(result *tool.ExecuteResult) {
+	var planID string
+	if item := r.PlanItem(); item != nil {
+		planID = item.ID
+	}
+	r.Status = StatusCompleted
+	r.Output = BuildOutput(result.Messages, result.Executions)
+	// Keep the ID clients saw while the plan was streamed or polled
+	if item := r.PlanItem(); item != nil && planID != "" {
+		item.ID = planID
+	}
+	r.Usage = result.Usage
+	if details := incompleteDetailsFor(result.FinishReason); details != nil {
+		r.Status = StatusIncomplete
+		r.IncompleteDetails = details
+	}
+}
+
+// planRecorder returns the OnPlanUpdate hook for a run: each plan the model
+// publishes is streamed and stored as the response's output, so clients polling
+// a background response, or reading one that failed or was cancelled, see how
+// far it got.
+func (s *ServiceImpl) planRecorder(ctx context.Context, resp *Response, observer StreamObserver) func(tool.Plan) {
+	id := "plan_" + strings.TrimPrefix(resp.PublicID, "resp_")
+	return func(plan tool.Plan) {
+		item := NewPlanItem(id, plan)
+		resp.Output = []OutputItem{item}
+		if observer != nil {
+			observer.OnPlanUpdated(item)
+		}
+		if err := s.responses.UpdateOutput(context.WithoutCancel(ctx), resp.ID, resp.Output); err != nil {
+			s.log.Warn().Err(err).Str("response_id", resp.PublicID).Msg("store plan failed")
+		}
+	}
+}
+
 func (s *ServiceImpl) failResponse(ctx context.Context, resp *Response, failure error) (*Response, error) {
 	now := time.Now()
 	resp.Status = StatusFailed
@@ -367,6 +365,44 @@ func (s *ServiceImpl) failResponse(ctx context.Context, resp *Response, failure
 	return nil, failure
 }
 
+// abortResponse records a response whose orchestration was aborted, either by
+// Cancel or by the client disconnecting. The request context may already be
+// cancelled, so writes use a detached context; usage and tool executions that
+// completed before the abort are kept for billing.
+func (s *ServiceImpl) abortResponse(ctx context.Context, resp *Response, partial *tool.ExecuteResult, cause error) (*Response, error) {
+	persistCtx := context.WithoutCancel(ctx)
+	code := "client_disconnected"
+	if errors.Is(cause, ErrResponseCancelled) {
+		code = "cancelled"
+	}
+	now := time.Now()
+	resp.Status = StatusCancelled
+	resp.CancelledAt = &now
+	resp.UpdatedAt = now
+	resp.Error = &ErrorDetails{
+		Code:    code,
+		Message: cause.Error(),
+	}
+	if partial != nil {
+		resp.Usage = partial.Usage
+	}
+	if err := s.responses.Update(persistCtx, resp); err != nil {
+		s.log.Error().Err(err).Str("response_id", resp.PublicID).Msg("update aborted response")
+	}
+	if partial != nil {
+		if err := s.toolExecutions.RecordExecutions(persistCtx, resp.ID, partial.Executions); err != nil {
+			s.log.Error().Err(err).Str("response_id", resp.PublicID).Msg("store tool executions failed")
+		}
+	}
+
+	event := s.log.Info().Str("response_id", resp.PublicID).Str("reason", code)
+	if resp.Usage != nil {
+		event = event.Int("prompt_tokens", resp.Usage.PromptTokens).Int("completion_tokens", resp.Usage.CompletionTokens)
+	}
+	event.Msg("response aborted")
+	return nil, cause
+}
+
 func (s *ServiceImpl) buildBaseMessages(systemPrompt *string, items []conversation.Item) ([]llm.ChatMessage, error) {
 	messages := make([]llm.ChatMessage, 0, len(items)+1)
 	if systemPrompt != nil && strings.TrimSpace(*systemPrompt) != "" {
@@ -508,6 +544,14 @@ func mapToChatMessage(messageData interface{}) (llm.ChatMessage, error) {
 	}, nil
 }
 
+// linkItems records the response that wrote each item, for previous_response_id chains.
+func linkItems(items []conversation.Item, responseID uint) []conversation.Item {
+	for i := range items {
+		items[i].ResponseID = &responseID
+	}
+	return items
+}
+
 func newPublicID(prefix string) string {
 	return fmt.Sprintf("%s_%s", prefix, uuid.NewString())
 }
@@ -546,29 +590,29 @@ func (s *ServiceImpl) ExecuteBackground(ctx context.Context, publicID string) er
 		ctx = llm.ContextWithAuthToken(ctx, *resp.APIKey)
 	}
 
-	// Load conversation for context
+	// Load the conversation or response chain for context; a response continuing
+	// a chain was stored in the conversation of the response it continues
 	if resp.ConversationID == nil {
 		return errors.New("response has no conversation")
 	}
-	conv, err := s.conversations.FindByPublicID(ctx, *resp.ConversationPublicID)
-	if err != nil {
-		return fmt.Errorf("failed to load conversation: %w", err)
+	var conversationRef *string
+	if trimmedValue(resp.PreviousResponseID) == "" {
+		conversationRef = resp.ConversationPublicID
 	}
-	conversationID := conv.PublicID
-
-	// Load conversation items (history)
-	existingItems, err := s.conversationItems.ListByConversationID(ctx, conv.ID)
+	respCtx, err := s.loadContext(ctx, resp.UserID, resp.PreviousResponseID, conversationRef)
 	if err != nil {
-		return fmt.Errorf("failed to load conversation items: %w", err)
+		return fmt.Errorf("failed to load response context: %w", err)
 	}
+	conv := respCtx.conversation
+	conversationID := conv.PublicID
 
 	// Build messages from history and current input
-	baseMessages, err := s.buildBaseMessages(resp.SystemPrompt, existingItems)
+	baseMessages, err := s.buildBaseMessages(resp.SystemPrompt, respCtx.history)
 	if err != nil {
 		return fmt.Errorf("build base messages: %w", err)
 	}
 
-	userMessages, convoItems, err := s.convertInputToMessages(conv.ID, len(existingItems), resp.Input)
+	userMessages, convoItems, err := s.convertInputToMessages(conv.ID, respCtx.nextSequence, resp.Input)
 	if err != nil {
 		return fmt.Errorf("convert input: %w", err)
 	}
@@ -606,8 +650,13 @@ func (s *ServiceImpl) ExecuteBackground(ctx context.Context, publicID string) er
 		ContextLength:   contextLength,
 		ToolDefinitions: toolDefs,
 		StreamObserver:  nil, // Background mode never streams
+		OnPlanUpdate:    s.planRecorder(ctx, resp, nil),
 	}
 
+	runCtx, release := s.inflight.track(ctx, resp.PublicID)
+	defer release()
+	execParams.Ctx = runCtx
+
 	orchestratorResult, execErr := s.orchestrator.Execute(execParams)
 	if execErr != nil && shouldRetryWithoutTools(execErr) && len(toolDefs) > 0 {
 		s.log.Warn().Err(execErr).Str("response_id", resp.PublicID).Msg("llm provider rejected tool definitions, retrying without tools")
@@ -617,15 +666,25 @@ func (s *ServiceImpl) ExecuteBackground(ctx context.Context, publicID string) er
 
 	// Update response status
 	now := time.Now()
-	if execErr != nil {
+	if errors.Is(execErr, ErrResponseCancelled) {
+		// Stopped through Cancel; keep what ran before the abort
+		resp.Status = StatusCancelled
+		resp.Error = &ErrorDetails{Code: "cancelled", Message: execErr.Error()}
+		resp.CancelledAt = &now
+		resp.UpdatedAt = now
+		if orchestratorResult != nil {
+			resp.Usage = orchestratorResult.Usage
+			if err := s.toolExecutions.RecordExecutions(ctx, resp.ID, orchestratorResult.Executions); err != nil {
+				s.log.Error().Err(err).Str("response_id", resp.PublicID).Msg("store tool executions failed")
+			}
+		}
+	} else if execErr != nil {
 		resp.Status = StatusFailed
 		resp.Error = &ErrorDetails{Message: execErr.Error()}
 		resp.CompletedAt = &now
 		resp.UpdatedAt = now
 	} else {
-		resp.Status = StatusCompleted
-		resp.Output = orchestratorResult.FinalMessage.Content
-		resp.Usage = orchestratorResult.Usage
+		resp.complete(orchestratorResult)
 		resp.CompletedAt = &now
 		resp.UpdatedAt = now
 
@@ -636,8 +695,8 @@ func (s *ServiceImpl) ExecuteBackground(ctx context.Context, publicID string) er
 
 		// Record conversation items (skip initial messages, only store new ones)
 		newMessages := orchestratorResult.Messages[initialLength:]
-		newItems := append(convoItems, s.convertMessagesToItems(conv.ID, len(existingItems)+len(convoItems), newMessages)...)
-		if err := s.conversationItems.BulkInsert(ctx, newItems); err != nil {
+		newItems := append(convoItems, s.convertMessagesToItems(conv.ID, respCtx.nextSequence+len(convoItems), newMessages)...)
+		if err := s.conversationItems.BulkInsert(ctx, linkItems(newItems, resp.ID)); err != nil {
 			s.log.Error().Err(err).Str("response_id", resp.PublicID).Msg("store conversation items failed")
 		}
 	}
@@ -663,11 +722,15 @@ func (s *ServiceImpl) ExecuteBackground(ctx context.Context, publicID string) er
 				s.log.Error().Err(err).Str("response_id", resp.PublicID).Msg("webhook notification failed")
 			}
 		} else {
-			if err := s.webhookService.NotifyCompleted(webhookCtx, resp.PublicID, resp.Output, resp.Metadata, resp.CompletedAt); err != nil {
+			if err := s.webhookService.NotifyCompleted(webhookCtx, resp.PublicID, resp.OutputText(), resp.Metadata, resp.CompletedAt); err != nil {
 				s.log.Error().Err(err).Str("response_id", resp.PublicID).Msg("webhook notification failed")
 			}
 		}
 	}()
 
+	if errors.Is(execErr, ErrResponseCancelled) {
+		// Already stored as cancelled; returning the error would make the worker mark it failed
+		return nil
+	}
 	return execErr
 }
@@ -9,12 +9,24 @@ import (
 	"strings"
 	"time"
 
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+
 	"jan-server/services/response-api/internal/domain/llm"
 )
 
+// tracer emits one span per orchestration loop, per iteration and per tool call,
+// all parented to the span carried by ExecuteParams.Ctx.
+var tracer = otel.Tracer("response-api/tool")
+
 var (
 	// ErrToolDepthExceeded is returned when the orchestrator hits the max recursion depth.
 	ErrToolDepthExceeded = errors.New("tool orchestration depth exceeded")
+	// ErrExecutionAborted is returned when ExecuteParams.Ctx is cancelled mid-loop,
+	// typically because the streaming client disconnected.
+	ErrExecutionAborted = errors.New("tool orchestration aborted")
 )
 
 // Orchestrator coordinates LLM reasoning with MCP tool execution until a final answer is produced.
@@ -49,11 +61,17 @@ type ExecuteParams struct {
 	ToolChoice      *llm.ToolChoice
 	ToolDefinitions []llm.ToolDefinition
 	StreamObserver  StreamObserver
+	// OnPlanUpdate, when set, offers the model the update_plan tool alongside
+	// ToolDefinitions and receives each plan it publishes.
+	OnPlanUpdate func(plan Plan)
 }
 
 // ExecuteResult captures the final assistant message and tool execution records.
+// When Execute returns ErrExecutionAborted the result is partial: FinalMessage is
+// empty and Usage covers only the provider calls that finished.
 type ExecuteResult struct {
 	FinalMessage llm.ChatMessage
+	FinishReason string // Finish reason of the completion that produced FinalMessage
 	Messages     []llm.ChatMessage
 	Usage        *llm.Usage
 	Executions   []Execution
@@ -63,6 +81,37 @@ type ExecuteResult struct {
 func (o *Orchestrator) Execute(params ExecuteParams) (*ExecuteResult, error) {
 	messages := append([]llm.ChatMessage(nil), params.Messages...)
 	var executions []Execution
+	var totalUsage *llm.Usage
+
+	loopCtx, loopSpan := tracer.Start(params.Ctx, "tool_loop", trace.WithAttributes(
+		attribute.String("llm.model", params.Model),
+		attribute.String("response.request_id", params.RequestID),
+		attribute.String("response.conversation_id", params.ConversationID),
+		attribute.Int("tool_loop.max_depth", o.maxDepth),
+	))
+	defer loopSpan.End()
+
+	// aborted stops the loop once the caller's context is gone, returning what
+	// ran so far so the caller can record partial usage and executions.
+	// iterSpan, when non-nil, is marked failed and ended like failIteration does.
+	aborted := func(ctx context.Context, iterSpan trace.Span) (*ExecuteResult, error) {
+		err := fmt.Errorf("%w: %w", ErrExecutionAborted, context.Cause(ctx))
+		if iterSpan != nil {
+			recordSpanError(iterSpan, err)
+			iterSpan.End()
+		}
+		recordSpanError(loopSpan, err)
+		return &ExecuteResult{
+			Messages:   messages,
+			Usage:      totalUsage,
+			Executions: executions,
+		}, err
+	}
+
+	toolDefs := params.ToolDefinitions
+	if params.OnPlanUpdate != nil && len(toolDefs) > 0 {
+		toolDefs = append(append([]llm.ToolDefinition(nil), toolDefs...), PlanToolDefinition())
+	}
 
 	// Get context length for message trimming
 	contextLength := llm.DefaultContextLength
@@ -71,6 +120,14 @@ func (o *Orchestrator) Execute(params ExecuteParams) (*ExecuteResult, error) {
 	}
 
 	for depth := 0; depth < o.maxDepth; depth++ {
+		if loopCtx.Err() != nil {
+			return aborted(loopCtx, nil)
+		}
+
+		iterCtx, iterSpan := tracer.Start(loopCtx, "tool_loop.iteration", trace.WithAttributes(
+			attribute.Int("tool_loop.depth", depth),
+		))
+
 		// Trim messages if they exceed context length
 		trimResult := llm.TrimMessagesToFitContext(messages, contextLength)
 		messages = trimResult.Messages
@@ -78,50 +135,71 @@ func (o *Orchestrator) Execute(params ExecuteParams) (*ExecuteResult, error) {
 		req := llm.ChatCompletionRequest{
 			Model:       params.Model,
 			Messages:    messages,
-			Tools:       params.ToolDefinitions,
+			Tools:       toolDefs,
 			ToolChoice:  params.ToolChoice,
 			Temperature: params.Temperature,
 			MaxTokens:   params.MaxTokens,
 			Stream:      false,
 		}
 		req.Stream = params.StreamObserver != nil
+		if req.Stream {
+			req.StreamOptions = &llm.StreamOptions{IncludeUsage: true}
+		}
 
 		var choice llm.ChatCompletionChoice
-		var usage *llm.Usage
 
 		if params.StreamObserver != nil {
-			streamChoice, err := o.streamChatCompletion(params.Ctx, req, params.StreamObserver)
+			streamChoice, usage, err := o.streamChatCompletion(iterCtx, req, params.StreamObserver)
+			totalUsage = totalUsage.Add(usage)
 			if err != nil {
-				return nil, err
+				if iterCtx.Err() != nil {
+					return aborted(iterCtx, iterSpan)
+				}
+				return nil, failIteration(err, iterSpan, loopSpan)
 			}
 			choice = *streamChoice
 		} else {
-			resp, err := o.llmProvider.CreateChatCompletion(params.Ctx, req)
+			resp, err := o.llmProvider.CreateChatCompletion(iterCtx, req)
 			if err != nil {
-				return nil, err
+				if iterCtx.Err() != nil {
+					return aborted(iterCtx, iterSpan)
+				}
+				return nil, failIteration(err, iterSpan, loopSpan)
 			}
 			if len(resp.Choices) == 0 {
-				return nil, errors.New("llm returned no choices")
+				return nil, failIteration(errors.New("llm returned no choices"), iterSpan, loopSpan)
 			}
 			choice = resp.Choices[0]
-			usage = resp.Usage
+			totalUsage = totalUsage.Add(resp.Usage)
 		}
 
 		messages = append(messages, choice.Message)
+		iterSpan.SetAttributes(attribute.Int("tool_loop.tool_calls", len(choice.Message.ToolCalls)))
 
 		if len(choice.Message.ToolCalls) == 0 {
+			iterSpan.End()
+			loopSpan.SetAttributes(attribute.Int("tool_loop.iterations", depth+1))
 			return &ExecuteResult{
 				FinalMessage: choice.Message,
+				FinishReason: choice.FinishReason,
 				Messages:     messages,
-				Usage:        usage,
+				Usage:        totalUsage,
 				Executions:   executions,
 			}, nil
 		}
 
+		// Parallel calls share what is left of the context, so their results fit together
+		contextBudget := llm.RemainingContextTokens(messages, contextLength, params.MaxTokens) / len(choice.Message.ToolCalls)
+
 		for _, call := range choice.Message.ToolCalls {
 			parsedCall, err := ParseToolCall(call)
 			if err != nil {
-				return nil, fmt.Errorf("parse tool call: %w", err)
+				return nil, failIteration(fmt.Errorf("parse tool call: %w", err), iterSpan, loopSpan)
+			}
+
+			if parsedCall.Name == PlanToolName && params.OnPlanUpdate != nil {
+				messages = append(messages, planResultToMessage(parsedCall, params.OnPlanUpdate))
+				continue
 			}
 
 			execution := Execution{
@@ -138,7 +216,13 @@ func (o *Orchestrator) Execute(params ExecuteParams) (*ExecuteResult, error) {
 				params.StreamObserver.OnToolCall(parsedCall)
 			}
 
-			callCtx := params.Ctx
+			callCtx, callSpan := tracer.Start(iterCtx, "tool.call "+parsedCall.Name,
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(
+					attribute.String("tool.name", parsedCall.Name),
+					attribute.String("tool.call_id", parsedCall.ID),
+				),
+			)
 			var cancel context.CancelFunc
 			if o.toolCallTimeout > 0 {
 				callCtx, cancel = context.WithTimeout(callCtx, o.toolCallTimeout)
@@ -151,12 +235,24 @@ func (o *Orchestrator) Execute(params ExecuteParams) (*ExecuteResult, error) {
 				RequestID:      params.RequestID,
 				ConversationID: params.ConversationID,
 				UserID:         params.UserID,
+				ContextBudget:  contextBudget,
 			}
 
 			result, err := o.mcpClient.CallTool(callCtx, callRequest)
 			if cancel != nil {
 				cancel()
 			}
+			if err != nil && iterCtx.Err() != nil {
+				// The client went away mid-call; the MCP request (and any sandbox
+				// run behind it) was cancelled with iterCtx.
+				execution.Status = ExecutionStatusCancelled
+				execution.ErrorMessage = err.Error()
+				execution.UpdatedAt = time.Now()
+				executions = append(executions, execution)
+				recordSpanError(callSpan, err)
+				callSpan.End()
+				return aborted(iterCtx, iterSpan)
+			}
 			if err != nil {
 				execution.Status = ExecutionStatusFailed
 				execution.ErrorMessage = err.Error()
@@ -171,25 +267,50 @@ func (o *Orchestrator) Execute(params ExecuteParams) (*ExecuteResult, error) {
 			execution.UpdatedAt = time.Now()
 			executions = append(executions, execution)
 
+			callSpan.SetAttributes(attribute.String("tool.status", string(execution.Status)))
+			if execution.Status == ExecutionStatusFailed {
+				callSpan.SetStatus(codes.Error, execution.ErrorMessage)
+			}
+			callSpan.End()
+
 			if params.StreamObserver != nil {
 				params.StreamObserver.OnToolResult(parsedCall.ID, execution.Result)
 			}
 
 			messages = append(messages, toolResultToMessage(parsedCall.ID, execution.Result, execution.ErrorMessage))
 		}
+		iterSpan.End()
 	}
 
-	return nil, ErrToolDepthExceeded
+	return nil, recordSpanError(loopSpan, ErrToolDepthExceeded)
+}
+
+// failIteration marks both spans as failed and ends the iteration span; the
+// loop span is ended by Execute's deferred call.
+func failIteration(err error, iterSpan, loopSpan trace.Span) error {
+	recordSpanError(iterSpan, err)
+	iterSpan.End()
+	return recordSpanError(loopSpan, err)
+}
+
+func recordSpanError(span trace.Span, err error) error {
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+	return err
 }
 
-func (o *Orchestrator) streamChatCompletion(ctx context.Context, req llm.ChatCompletionRequest, observer StreamObserver) (*llm.ChatCompletionChoice, error) {
+// streamChatCompletion relays one streamed completion to the observer. The
+// returned usage is whatever the provider reported before the stream ended,
+// and is returned even when the stream fails part-way.
+func (o *Orchestrator) streamChatCompletion(ctx context.Context, req llm.ChatCompletionRequest, observer StreamObserver) (*llm.ChatCompletionChoice, *llm.Usage, error) {
 	stream, err := o.llmProvider.CreateChatCompletionStream(ctx, req)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	defer stream.Close()
 
 	accumulator := newStreamAccumulator()
+	var usage *llm.Usage
 
 	for {
 		delta, err := stream.Recv()
@@ -197,7 +318,10 @@ func (o *Orchestrator) streamChatCompletion(ctx context.Context, req llm.ChatCom
 			break
 		}
 		if err != nil {
-			return nil, err
+			return nil, usage, err
+		}
+		if delta != nil && delta.Usage != nil {
+			usage = delta.Usage
 		}
 		if observer != nil && delta != nil {
 			observer.OnDelta(*delta)
@@ -207,9 +331,9 @@ func (o *Orchestrator) streamChatCompletion(ctx context.Context, req llm.ChatCom
 
 	choice := accumulator.Result()
 	if choice == nil {
-		return nil, errors.New("stream produced no choices")
+		return nil, usage, errors.New("stream produced no choices")
 	}
-	return choice, nil
+	return choice, usage, nil
 }
 
 func toolResultToMessage(toolCallID string, result *Result, errorMessage string) llm.ChatMessage {
@@ -221,6 +345,23 @@ func toolResultToMessage(toolCallID string, result *Result, errorMessage string)
 	}
 }
 
+// planResultToMessage applies an update_plan call; invalid plans are reported
+// back so the model can correct them.
+func planResultToMessage(call Call, onUpdate func(Plan)) llm.ChatMessage {
+	text := "Plan updated"
+	plan, err := ParsePlan(call.Arguments)
+	if err != nil {
+		text = "Plan rejected: " + err.Error()
+	} else {
+		onUpdate(plan)
+	}
+	return llm.ChatMessage{
+		Role:       "tool",
+		Content:    map[string]string{"type": "text", "text": text},
+		ToolCallID: &call.ID,
+	}
+}
+
 func buildContentFromResult(result *Result, errorMessage string) interface{} {
 	if result == nil {
 		return map[string]string{
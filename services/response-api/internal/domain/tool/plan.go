@@ -0,0 +1,126 @@
+package tool
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"jan-server/services/response-api/internal/domain/llm"
+)
+
+// PlanToolName is the built-in tool the model calls to publish its plan. The
+// orchestrator handles it itself; it is never sent to mcp-tools.
+const PlanToolName = "update_plan"
+
+// maxPlanSteps bounds the steps a plan may have.
+const maxPlanSteps = 50
+
+// PlanStepStatus is the progress of one plan step.
+type PlanStepStatus string
+
+const (
+	PlanStepPending    PlanStepStatus = "pending"
+	PlanStepInProgress PlanStepStatus = "in_progress"
+	PlanStepCompleted  PlanStepStatus = "completed"
+)
+
+// PlanStep is one entry of the model's checklist.
+type PlanStep struct {
+	Step   string         `json:"step"`
+	Status PlanStepStatus `json:"status"`
+}
+
+// Plan is the model's latest view of the steps a multi-step run takes.
+type Plan struct {
+	Explanation string     `json:"explanation,omitempty"`
+	Steps       []PlanStep `json:"steps"`
+}
+
+// Completed reports whether every step is done.
+func (p Plan) Completed() bool {
+	for _, step := range p.Steps {
+		if step.Status != PlanStepCompleted {
+			return false
+		}
+	}
+	return len(p.Steps) > 0
+}
+
+// PlanToolDefinition describes update_plan to the model.
+func PlanToolDefinition() llm.ToolDefinition {
+	return llm.ToolDefinition{
+		Type: "function",
+		Function: llm.ToolFunctionSchema{
+			Name: PlanToolName,
+			Description: "Publish or update your plan for a task that needs several steps or tool calls, " +
+				"such as research. Send the full list of steps each time, marking at most one in_progress, " +
+				"and update it as steps finish. Skip it for simple questions.",
+			Parameters: map[string]interface{}{
+				"type": "object",
+				"properties": map[string]interface{}{
+					"explanation": map[string]interface{}{
+						"type":        "string",
+						"description": "Optional note on why the plan changed",
+					},
+					"steps": map[string]interface{}{
+						"type": "array",
+						"items": map[string]interface{}{
+							"type": "object",
+							"properties": map[string]interface{}{
+								"step": map[string]interface{}{
+									"type":        "string",
+									"description": "Short description of the step",
+								},
+								"status": map[string]interface{}{
+									"type": "string",
+									"enum": []string{string(PlanStepPending), string(PlanStepInProgress), string(PlanStepCompleted)},
+								},
+							},
+							"required": []string{"step", "status"},
+						},
+					},
+				},
+				"required": []string{"steps"},
+			},
+		},
+	}
+}
+
+// ParsePlan validates the arguments of an update_plan call.
+func ParsePlan(args map[string]interface{}) (Plan, error) {
+	var plan Plan
+	plan.Explanation, _ = args["explanation"].(string)
+
+	rawSteps, ok := args["steps"].([]interface{})
+	if !ok || len(rawSteps) == 0 {
+		return Plan{}, errors.New("steps must be a non-empty array")
+	}
+	if len(rawSteps) > maxPlanSteps {
+		return Plan{}, fmt.Errorf("a plan may have at most %d steps", maxPlanSteps)
+	}
+
+	inProgress := 0
+	for i, raw := range rawSteps {
+		fields, ok := raw.(map[string]interface{})
+		if !ok {
+			return Plan{}, fmt.Errorf("step %d must be an object with step and status", i+1)
+		}
+		text, _ := fields["step"].(string)
+		status, _ := fields["status"].(string)
+		if strings.TrimSpace(text) == "" {
+			return Plan{}, fmt.Errorf("step %d has no description", i+1)
+		}
+		switch PlanStepStatus(status) {
+		case PlanStepPending, PlanStepCompleted:
+		case PlanStepInProgress:
+			inProgress++
+		default:
+			return Plan{}, fmt.Errorf("step %d has status %q; use pending, in_progress or completed", i+1, status)
+		}
+		plan.Steps = append(plan.Steps, PlanStep{Step: strings.TrimSpace(text), Status: PlanStepStatus(status)})
+	}
+	if inProgress > 1 {
+		return Plan{}, errors.New("at most one step may be in_progress")
+	}
+	return plan, nil
+}
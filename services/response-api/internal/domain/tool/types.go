@@ -17,6 +17,7 @@ const (
 	ExecutionStatusRunning   ExecutionStatus = "running"
 	ExecutionStatusCompleted ExecutionStatus = "completed"
 	ExecutionStatusFailed    ExecutionStatus = "failed"
+	ExecutionStatusCancelled ExecutionStatus = "cancelled"
 )
 
 // Call encapsulates one tool call requested by the LLM.
@@ -77,6 +78,7 @@ type CallRequest struct {
 	RequestID      string
 	ConversationID string
 	UserID         string
+	ContextBudget  int // Tokens left in the model's context for this result; 0 when unknown
 }
 
 // MCPTool describes the tool metadata returned by mcp-tools.
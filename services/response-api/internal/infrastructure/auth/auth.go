@@ -9,6 +9,7 @@ import (
 	"github.com/MicahParks/keyfunc/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	commonauth "github.com/janhq/jan-server/packages/go-common/auth"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/response-api/internal/config"
@@ -119,6 +120,11 @@ func (v *Validator) Middleware() gin.HandlerFunc {
 			}
 		}
 
+		if commonauth.IsIntegrationToken(claims, v.cfg.IntegrationClients) {
+			abortForbidden(c, commonauth.IntegrationTokenMessage)
+			return
+		}
+
 		c.Set("auth_token", token)
 		c.Next()
 	}
@@ -148,3 +154,9 @@ func abortUnauthorized(c *gin.Context, message string) {
 		"error": message,
 	})
 }
+
+func abortForbidden(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": message,
+	})
+}
@@ -10,6 +10,7 @@ import (
 type ConversationItem struct {
 	ID             uint           `gorm:"primaryKey"`
 	ConversationID uint           `gorm:"index"`
+	ResponseID     *uint          `gorm:"index"`
 	Role           string         `gorm:"size:32"`
 	Status         string         `gorm:"size:32"`
 	Content        datatypes.JSON `gorm:"type:jsonb"`
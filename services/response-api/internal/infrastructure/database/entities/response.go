@@ -29,6 +29,7 @@ type Response struct {
 	Metadata           datatypes.JSON `gorm:"type:jsonb"`
 	Usage              datatypes.JSON `gorm:"type:jsonb"`
 	Error              datatypes.JSON `gorm:"type:jsonb"`
+	IncompleteDetails  datatypes.JSON `gorm:"type:jsonb"`
 	ConversationID     *uint
 	Conversation       *Conversation
 	PreviousResponseID *string `gorm:"size:64"`
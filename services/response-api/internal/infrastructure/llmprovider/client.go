@@ -9,27 +9,29 @@ import (
 	"io"
 	"net/http"
 	"strings"
-	"time"
 
 	"github.com/go-resty/resty/v2"
 
 	"jan-server/services/response-api/internal/domain/llm"
+	"jan-server/services/response-api/internal/infrastructure/observability"
 )
 
 // Client implements the llm.Provider interface.
 type Client struct {
-	httpClient *resty.Client
-	baseURL    string
+	httpClient   *resty.Client
+	streamClient *http.Client
+	baseURL      string
 }
 
-// NewClient creates a Resty-backed client.
-func NewClient(baseURL string) *Client {
+// NewClient creates a Resty-backed client over httpClient, whose pooled
+// transport is shared by regular and streaming completions.
+func NewClient(baseURL string, httpClient *http.Client) *Client {
 	return &Client{
-		httpClient: resty.New().
+		httpClient: observability.PropagateTraceContext(resty.NewWithClient(httpClient).
 			SetBaseURL(baseURL).
-			SetHeader("Content-Type", "application/json").
-			SetTimeout(900 * time.Second),
-		baseURL: baseURL,
+			SetHeader("Content-Type", "application/json")),
+		streamClient: httpClient,
+		baseURL:      baseURL,
 	}
 }
 
@@ -90,9 +92,9 @@ func (c *Client) CreateChatCompletionStream(ctx context.Context, req llm.ChatCom
 			httpReq.Header.Set("X-API-Key", token)
 		}
 	}
+	observability.InjectHeaders(ctx, httpReq.Header)
 
-	httpClient := &http.Client{Timeout: 900 * time.Second}
-	resp, err := httpClient.Do(httpReq)
+	resp, err := c.streamClient.Do(httpReq)
 	if err != nil {
 		return nil, fmt.Errorf("execute request: %w", err)
 	}
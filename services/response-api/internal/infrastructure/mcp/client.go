@@ -2,11 +2,10 @@ package mcp
 
 import (
 	"context"
-	"encoding/json"
-	"fmt"
+	"net/http"
 	"strings"
 
-	"github.com/go-resty/resty/v2"
+	"github.com/janhq/jan-server/packages/go-common/clients"
 	"github.com/rs/zerolog/log"
 
 	"jan-server/services/response-api/internal/domain/tool"
@@ -14,50 +13,25 @@ import (
 
 // Client implements tool.MCPClient.
 type Client struct {
-	httpClient *resty.Client
+	mcp *clients.MCPClient
 }
 
-// NewClient constructs the MCP client.
-func NewClient(baseURL string) *Client {
-	return &Client{
-		httpClient: resty.New().
-			SetBaseURL(baseURL).
-			SetHeader("Content-Type", "application/json"),
-	}
+// NewClient constructs the MCP client over httpClient.
+func NewClient(baseURL string, httpClient *http.Client, breaker clients.BreakerConfig) *Client {
+	return &Client{mcp: clients.NewMCPClient(baseURL, httpClient, breaker)}
 }
 
 // ListTools fetches the tools via JSON-RPC call tools/list.
 func (c *Client) ListTools(ctx context.Context) ([]tool.MCPTool, error) {
-	payload := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  "tools/list",
-		"params":  map[string]interface{}{},
-		"id":      1,
-	}
-
-	var rpcResp rpcResponse
-	resp, err := c.httpClient.R().
-		SetContext(ctx).
-		SetBody(payload).
-		SetResult(&rpcResp).
-		Post("/v1/mcp")
+	listed, err := c.mcp.ListTools(ctx)
 	if err != nil {
 		return nil, err
 	}
-	if resp.IsError() {
-		return nil, fmt.Errorf("mcp list tools error: %s", resp.String())
-	}
-	if rpcResp.Error != nil {
-		return nil, rpcResp.Error
-	}
-
-	var result struct {
-		Tools []tool.MCPTool `json:"tools"`
+	tools := make([]tool.MCPTool, 0, len(listed))
+	for _, t := range listed {
+		tools = append(tools, tool.MCPTool{Name: t.Name, Description: t.Description, InputSchema: t.InputSchema})
 	}
-	if err := json.Unmarshal(rpcResp.Result, &result); err != nil {
-		return nil, err
-	}
-	return result.Tools, nil
+	return tools, nil
 }
 
 // CallTool triggers a tool execution via JSON-RPC tools/call.
@@ -76,65 +50,28 @@ func (c *Client) CallTool(ctx context.Context, req tool.CallRequest) (*tool.Resu
 		Str("user_id", req.UserID).
 		Msg("Calling MCP tool")
 
-	payload := map[string]interface{}{
-		"jsonrpc": "2.0",
-		"method":  "tools/call",
-		"params": map[string]interface{}{
-			"name":      req.Name,
-			"arguments": mergedArgs,
-		},
-		"id": rpcID,
-	}
-
-	var rpcResp rpcResponse
-	resp, err := c.httpClient.R().
-		SetContext(ctx).
-		SetBody(payload).
-		SetResult(&rpcResp).
-		Post("/v1/mcp")
+	result, err := c.mcp.CallTool(ctx, clients.MCPCallRequest{
+		ID:            rpcID,
+		Name:          req.Name,
+		Arguments:     mergedArgs,
+		ContextBudget: req.ContextBudget,
+	})
 	if err != nil {
 		return nil, err
 	}
-	if resp.IsError() {
-		return nil, fmt.Errorf("mcp call error: %s", resp.String())
-	}
-	if rpcResp.Error != nil {
-		return nil, rpcResp.Error
-	}
 
-	var result struct {
-		Content []tool.MCPContent `json:"content"`
-		IsError bool              `json:"isError"`
-		Error   string            `json:"error"`
-	}
-	if err := json.Unmarshal(rpcResp.Result, &result); err != nil {
-		return nil, err
+	content := make([]tool.MCPContent, 0, len(result.Content))
+	for _, part := range result.Content {
+		content = append(content, tool.MCPContent{Type: part.Type, Text: part.Text, Resource: part.Resource})
 	}
-
 	return &tool.Result{
 		ToolName: req.Name,
-		Content:  result.Content,
+		Content:  content,
 		IsError:  result.IsError,
 		Error:    result.Error,
 	}, nil
 }
 
-type rpcResponse struct {
-	JSONRPC string          `json:"jsonrpc"`
-	Result  json.RawMessage `json:"result"`
-	Error   *rpcError       `json:"error"`
-	ID      interface{}     `json:"id"`
-}
-
-type rpcError struct {
-	Code    int    `json:"code"`
-	Message string `json:"message"`
-}
-
-func (r *rpcError) Error() string {
-	return fmt.Sprintf("mcp error (%d): %s", r.Code, r.Message)
-}
-
 func mergeContextIntoArguments(args map[string]interface{}, requestID, conversationID, userID, toolCallID string) map[string]interface{} {
 	merged := make(map[string]interface{})
 	for k, v := range args {
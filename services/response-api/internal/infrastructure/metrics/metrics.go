@@ -1,33 +1,38 @@
 package metrics
 
 import (
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
 // Response-API Metrics
 var (
-	// Request counters
-	RequestsTotal = promauto.NewCounterVec(
+	// HTTP records request counts, duration/status histograms and in-flight requests
+	HTTP = gocommonmetrics.NewHTTPMetrics("jan", "response_api", []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60})
+
+	// Response body bytes per route and response kind (sse, json, other)
+	ResponseBytesTotal = promauto.NewCounterVec(
 		prometheus.CounterOpts{
 			Namespace: "jan",
 			Subsystem: "response_api",
-			Name:      "requests_total",
-			Help:      "Total number of HTTP requests",
+			Name:      "response_bytes_total",
+			Help:      "Total response body bytes written by route and response kind",
 		},
-		[]string{"method", "endpoint", "status"},
+		[]string{"endpoint", "kind"},
 	)
 
-	// Request duration histogram
-	RequestDuration = promauto.NewHistogramVec(
+	// Time until the response body is complete; for SSE this is how long the
+	// stream stayed open, so rate(_sum) is the average number of open streams
+	ResponseStreamDuration = promauto.NewHistogramVec(
 		prometheus.HistogramOpts{
 			Namespace: "jan",
 			Subsystem: "response_api",
-			Name:      "request_duration_seconds",
-			Help:      "HTTP request duration in seconds",
-			Buckets:   []float64{0.1, 0.5, 1, 2, 5, 10, 30, 60},
+			Name:      "response_stream_duration_seconds",
+			Help:      "Time until the response body is complete by route and response kind",
+			Buckets:   []float64{0.1, 0.5, 1, 5, 10, 30, 60, 120, 300, 600},
 		},
-		[]string{"method", "endpoint"},
+		[]string{"endpoint", "kind"},
 	)
 
 	// Tool call counters
@@ -85,12 +90,58 @@ var (
 		},
 		[]string{"query_type"},
 	)
+
+	// Synthetic canary runs, labelled by the stage that failed ("none" on success)
+	CanaryRunsTotal = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "jan",
+			Subsystem: "response_api",
+			Name:      "canary_runs_total",
+			Help:      "Total synthetic canary conversations",
+		},
+		[]string{"model", "status", "stage"},
+	)
+
+	// Canary end-to-end duration
+	CanaryDuration = promauto.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "jan",
+			Subsystem: "response_api",
+			Name:      "canary_duration_seconds",
+			Help:      "Synthetic canary conversation duration in seconds",
+			Buckets:   []float64{1, 2, 5, 10, 20, 30, 60, 120},
+		},
+		[]string{"model"},
+	)
+
+	// Canary health: 1 if the last run succeeded, 0 otherwise
+	CanaryUp = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "response_api",
+			Name:      "canary_up",
+			Help:      "Whether the last synthetic canary run succeeded",
+		},
+		[]string{"model"},
+	)
+
+	// Unix time of the last successful canary run
+	CanaryLastSuccess = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "jan",
+			Subsystem: "response_api",
+			Name:      "canary_last_success_timestamp_seconds",
+			Help:      "Unix timestamp of the last successful synthetic canary run",
+		},
+		[]string{"model"},
+	)
 )
 
-// RecordRequest records an HTTP request
-func RecordRequest(method, endpoint, status string, durationSec float64) {
-	RequestsTotal.WithLabelValues(method, endpoint, status).Inc()
-	RequestDuration.WithLabelValues(method, endpoint).Observe(durationSec)
+// RecordResponseStream records the bytes and duration of a response body.
+// kind is "sse", "json" or "other".
+func RecordResponseStream(endpoint, kind string, bytes int, durationSec float64) {
+	ResponseBytesTotal.WithLabelValues(endpoint, kind).Add(float64(bytes))
+	ResponseStreamDuration.WithLabelValues(endpoint, kind).Observe(durationSec)
 }
 
 // RecordToolCall records an MCP tool invocation
@@ -113,3 +164,25 @@ func RecordBackgroundJob(jobType, status string) {
 func RecordDBQuery(queryType string, durationSec float64) {
 	DBQueryDuration.WithLabelValues(queryType).Observe(durationSec)
 }
+
+// RecordCanaryRun records a synthetic canary run. stage is "none" on success.
+func RecordCanaryRun(model string, success bool, stage string, durationSec float64) {
+	status := "success"
+	up := 1.0
+	if !success {
+		status = "failed"
+		up = 0
+	}
+	CanaryRunsTotal.WithLabelValues(model, status, stage).Inc()
+	CanaryDuration.WithLabelValues(model).Observe(durationSec)
+	CanaryUp.WithLabelValues(model).Set(up)
+	if success {
+		CanaryLastSuccess.WithLabelValues(model).SetToCurrentTime()
+	}
+}
+
+// ClearCanaryUp drops the canary health series when this replica stops running
+// the canary, so a former leader's last result doesn't hold an alert open.
+func ClearCanaryUp(model string) {
+	CanaryUp.DeleteLabelValues(model)
+}
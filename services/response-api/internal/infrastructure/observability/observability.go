@@ -2,10 +2,12 @@ package observability
 
 import (
 	"context"
+	"strings"
 
 	"github.com/rs/zerolog"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
 	"go.opentelemetry.io/otel/sdk/resource"
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.21.0"
@@ -18,15 +20,30 @@ type Shutdown func(ctx context.Context) error
 
 // Setup configures OpenTelemetry tracing if enabled.
 func Setup(ctx context.Context, cfg *config.Config, log zerolog.Logger) (Shutdown, error) {
+	// Always propagate W3C trace context so upstream spans flow through to
+	// llm-api and mcp-tools even when this service does not export spans.
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(
+		propagation.TraceContext{},
+		propagation.Baggage{},
+	))
+
 	if !cfg.EnableTracing || cfg.OTLPEndpoint == "" {
 		log.Info().Msg("Tracing disabled")
 		return func(context.Context) error { return nil }, nil
 	}
 
-	exporter, err := otlptracehttp.New(ctx,
-		otlptracehttp.WithEndpoint(cfg.OTLPEndpoint),
-		otlptracehttp.WithInsecure(),
-	)
+	// Accept both "otel-collector:4318" and "http://otel-collector:4318".
+	endpoint := cfg.OTLPEndpoint
+	opts := []otlptracehttp.Option{}
+	if strings.HasPrefix(endpoint, "https://") {
+		endpoint = strings.TrimPrefix(endpoint, "https://")
+	} else {
+		endpoint = strings.TrimPrefix(endpoint, "http://")
+		opts = append(opts, otlptracehttp.WithInsecure())
+	}
+	opts = append(opts, otlptracehttp.WithEndpoint(endpoint))
+
+	exporter, err := otlptracehttp.New(ctx, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,48 @@
+package observability
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-resty/resty/v2"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// TracerName is the instrumentation scope used for response-api spans.
+const TracerName = "response-api"
+
+// StartSpan starts a span using the globally configured tracer provider.
+func StartSpan(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(TracerName).Start(ctx, spanName, opts...)
+}
+
+// RecordError marks the span as failed.
+func RecordError(span trace.Span, err error) {
+	if err == nil || !span.IsRecording() {
+		return
+	}
+	span.RecordError(err)
+	span.SetStatus(codes.Error, err.Error())
+}
+
+// InjectHeaders writes the trace context of ctx into outgoing HTTP headers.
+func InjectHeaders(ctx context.Context, header http.Header) {
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(header))
+}
+
+// ExtractHeaders returns ctx enriched with the trace context carried by incoming headers.
+func ExtractHeaders(ctx context.Context, header http.Header) context.Context {
+	return otel.GetTextMapPropagator().Extract(ctx, propagation.HeaderCarrier(header))
+}
+
+// PropagateTraceContext makes a resty client forward the caller's trace context
+// so downstream services (llm-api, mcp-tools) attach their spans to ours.
+func PropagateTraceContext(client *resty.Client) *resty.Client {
+	return client.OnBeforeRequest(func(_ *resty.Client, req *resty.Request) error {
+		InjectHeaders(req.Context(), req.Header)
+		return nil
+	})
+}
@@ -42,6 +42,7 @@ func (r *ItemRepository) BulkInsert(ctx context.Context, items []domain.Item) er
 		}
 		rows = append(rows, entities.ConversationItem{
 			ConversationID: item.ConversationID,
+			ResponseID:     item.ResponseID,
 			Role:           string(item.Role),
 			Status:         string(item.Status),
 			Content:        content,
@@ -80,6 +81,52 @@ func (r *ItemRepository) ListByConversationID(ctx context.Context, conversationI
 		)
 	}
 
+	return mapItems(rows), nil
+}
+
+// ListByResponseIDs returns the items written by the given responses, ordered by sequence.
+func (r *ItemRepository) ListByResponseIDs(ctx context.Context, responseIDs []uint) ([]domain.Item, error) {
+	if len(responseIDs) == 0 {
+		return nil, nil
+	}
+	var rows []entities.ConversationItem
+	if err := r.db.WithContext(ctx).
+		Where("response_id IN ?", responseIDs).
+		Order("sequence ASC").
+		Find(&rows).Error; err != nil {
+		return nil, platformerrors.NewError(
+			ctx,
+			platformerrors.LayerRepository,
+			platformerrors.ErrorTypeDatabaseError,
+			"failed to list response items",
+			err,
+			"3d6b1f0e-8c2a-4e57-9a41-5f7c2b8d0e93",
+		)
+	}
+	return mapItems(rows), nil
+}
+
+// NextSequence returns the sequence number for the next item of a conversation.
+func (r *ItemRepository) NextSequence(ctx context.Context, conversationID uint) (int, error) {
+	var next int
+	if err := r.db.WithContext(ctx).
+		Model(&entities.ConversationItem{}).
+		Where("conversation_id = ?", conversationID).
+		Select("COALESCE(MAX(sequence) + 1, 0)").
+		Scan(&next).Error; err != nil {
+		return 0, platformerrors.NewError(
+			ctx,
+			platformerrors.LayerRepository,
+			platformerrors.ErrorTypeDatabaseError,
+			"failed to find next conversation item sequence",
+			err,
+			"a4e27c95-0b1d-4f38-86c2-7d9e3f5a1b60",
+		)
+	}
+	return next, nil
+}
+
+func mapItems(rows []entities.ConversationItem) []domain.Item {
 	items := make([]domain.Item, 0, len(rows))
 	for _, row := range rows {
 		var content map[string]interface{}
@@ -89,6 +136,7 @@ func (r *ItemRepository) ListByConversationID(ctx context.Context, conversationI
 		items = append(items, domain.Item{
 			ID:             row.ID,
 			ConversationID: row.ConversationID,
+			ResponseID:     row.ResponseID,
 			Role:           domain.ItemRole(row.Role),
 			Status:         domain.ItemStatus(row.Status),
 			Content:        content,
@@ -96,5 +144,5 @@ func (r *ItemRepository) ListByConversationID(ctx context.Context, conversationI
 			CreatedAt:      row.CreatedAt,
 		})
 	}
-	return items, nil
+	return items
 }
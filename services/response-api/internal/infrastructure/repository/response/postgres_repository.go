@@ -82,6 +82,26 @@ func (r *PostgresRepository) Update(ctx context.Context, resp *domain.Response)
 	return nil
 }
 
+// UpdateOutput replaces only the output of a response, so progress written while
+// it runs cannot overwrite a concurrent status change such as a cancel.
+func (r *PostgresRepository) UpdateOutput(ctx context.Context, id uint, output []domain.OutputItem) error {
+	data, err := marshalJSON(output)
+	if err != nil {
+		return fmt.Errorf("marshal response output: %w", err)
+	}
+	if err := r.db.WithContext(ctx).Model(&entities.Response{ID: id}).Update("output", data).Error; err != nil {
+		return platformerrors.NewError(
+			ctx,
+			platformerrors.LayerRepository,
+			platformerrors.ErrorTypeDatabaseError,
+			"failed to update response output",
+			err,
+			"3c9e1f47-8a2d-4b6e-9f05-7d1a2c4e6b83",
+		)
+	}
+	return nil
+}
+
 // FindByPublicID fetches a response and hydrates the domain model.
 func (r *PostgresRepository) FindByPublicID(ctx context.Context, publicID string) (*domain.Response, error) {
 	var entity entities.Response
@@ -178,6 +198,10 @@ func mapToEntity(resp *domain.Response) (*entities.Response, error) {
 	if err != nil {
 		return nil, fmt.Errorf("marshal error: %w", err)
 	}
+	incompleteDetails, err := marshalJSON(resp.IncompleteDetails)
+	if err != nil {
+		return nil, fmt.Errorf("marshal incomplete details: %w", err)
+	}
 
 	return &entities.Response{
 		PublicID:           resp.PublicID,
@@ -194,6 +218,7 @@ func mapToEntity(resp *domain.Response) (*entities.Response, error) {
 		Metadata:           metadata,
 		Usage:              usage,
 		Error:              errJSON,
+		IncompleteDetails:  incompleteDetails,
 		ConversationID:     resp.ConversationID,
 		PreviousResponseID: resp.PreviousResponseID,
 		Object:             resp.Object,
@@ -232,7 +257,14 @@ func mapFromEntity(entity *entities.Response, resp *domain.Response) error {
 	}
 	if len(entity.Output) > 0 {
 		if err := json.Unmarshal(entity.Output, &resp.Output); err != nil {
-			return fmt.Errorf("unmarshal output: %w", err)
+			// Rows written before output items were kept hold the final message content
+			var content interface{}
+			if err := json.Unmarshal(entity.Output, &content); err != nil {
+				return fmt.Errorf("unmarshal output: %w", err)
+			}
+			if content != nil {
+				resp.Output = domain.OutputFromContent(entity.PublicID, content)
+			}
 		}
 	}
 	if len(entity.Metadata) > 0 {
@@ -252,6 +284,12 @@ func mapFromEntity(entity *entities.Response, resp *domain.Response) error {
 			resp.Error = &errDetails
 		}
 	}
+	if len(entity.IncompleteDetails) > 0 {
+		var details domain.IncompleteDetails
+		if err := json.Unmarshal(entity.IncompleteDetails, &details); err == nil && details.Reason != "" {
+			resp.IncompleteDetails = &details
+		}
+	}
 
 	if resp.ConversationPublicID == nil && entity.Conversation != nil {
 		resp.ConversationPublicID = &entity.Conversation.PublicID
@@ -1,6 +1,8 @@
 package handlers
 
 import (
+	"time"
+
 	"github.com/rs/zerolog"
 
 	domain "jan-server/services/response-api/internal/domain/response"
@@ -12,8 +14,10 @@ type Provider struct {
 }
 
 // NewProvider constructs the handler provider with domain services.
-func NewProvider(responseService domain.Service, log zerolog.Logger) *Provider {
+// heartbeatInterval controls SSE keep-alive comments on streaming responses and
+// defaultStreamVersion the event version used when clients do not ask for one.
+func NewProvider(responseService domain.Service, log zerolog.Logger, heartbeatInterval time.Duration, defaultStreamVersion int) *Provider {
 	return &Provider{
-		Response: NewResponseHandler(responseService, log),
+		Response: NewResponseHandler(responseService, log, heartbeatInterval, defaultStreamVersion),
 	}
 }
@@ -2,10 +2,13 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
@@ -20,29 +23,42 @@ import (
 
 // ResponseHandler exposes HTTP entrypoints for the Responses API.
 type ResponseHandler struct {
-	service response.Service
-	log     zerolog.Logger
+	service              response.Service
+	log                  zerolog.Logger
+	heartbeatInterval    time.Duration
+	defaultStreamVersion int
 }
 
 // NewResponseHandler constructs the handler.
-func NewResponseHandler(service response.Service, log zerolog.Logger) *ResponseHandler {
+// A zero heartbeatInterval disables SSE keep-alive comments. defaultStreamVersion
+// is the event version streamed to clients that do not send X-Jan-Stream-Version.
+func NewResponseHandler(service response.Service, log zerolog.Logger, heartbeatInterval time.Duration, defaultStreamVersion int) *ResponseHandler {
+	log = log.With().Str("handler", "response").Logger()
+	if _, ok := newStreamEncoder(defaultStreamVersion); !ok {
+		log.Warn().Int("version", defaultStreamVersion).Msg("unsupported default stream version; using 1")
+		defaultStreamVersion = StreamVersion1
+	}
 	return &ResponseHandler{
-		service: service,
-		log:     log.With().Str("handler", "response").Logger(),
+		service:              service,
+		log:                  log,
+		heartbeatInterval:    heartbeatInterval,
+		defaultStreamVersion: defaultStreamVersion,
 	}
 }
 
 // Create handles POST /v1/responses
 // @Summary Create a response
-// @Description Creates a response and orchestrates MCP tool calls when required. When `stream=true`, results are streamed as SSE events instead of a single JSON payload.
+// @Description Creates a response and orchestrates MCP tool calls when required. When `stream=true`, results are streamed as SSE events instead of a single JSON payload. `X-Jan-Stream-Version` selects the event payload version; the stream echoes it.
 // @Tags Responses
 // @Accept json
 // @Produce json
 // @Produce text/event-stream
 // @Param request body requests.CreateResponseRequest true "Create request"
+// @Param X-Jan-Stream-Version header int false "Stream event version (1 or 2); defaults to SSE_DEFAULT_STREAM_VERSION"
 // @Success 200 {object} responses.ResponsePayload "JSON response when stream=false"
 // @Success 200 {string} string "SSE event stream when stream=true"
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string
 // @Failure 500 {object} map[string]string
 // @Router /v1/responses [post]
 func (h *ResponseHandler) Create(c *gin.Context) {
@@ -64,7 +80,7 @@ func (h *ResponseHandler) Create(c *gin.Context) {
 
 	stream := req.Stream != nil && *req.Stream
 	background := req.Background != nil && *req.Background
-	store := req.Store != nil && *req.Store
+	store := req.Store == nil || *req.Store // stored by default, as in OpenAI
 
 	// Validate background mode constraints
 	if background && !store {
@@ -111,13 +127,25 @@ func (h *ResponseHandler) Create(c *gin.Context) {
 
 	resp, err := h.service.Create(c.Request.Context(), params)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(createErrorStatus(err), gin.H{"error": err.Error()})
 		return
 	}
 
 	c.JSON(http.StatusOK, responses.FromDomain(resp))
 }
 
+// createErrorStatus maps errors resolving a new response's context to client
+// errors; anything else is a server error.
+func createErrorStatus(err error) int {
+	switch {
+	case errors.Is(err, response.ErrPreviousResponseNotFound):
+		return http.StatusNotFound
+	case errors.Is(err, response.ErrConversationWithPreviousResponse):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
 // Get handles GET /v1/responses/:id
 // @Summary Get a response by ID
 // @Tags Responses
@@ -138,15 +166,25 @@ func (h *ResponseHandler) Get(c *gin.Context) {
 
 // Cancel handles POST /v1/responses/:id/cancel
 // @Summary Cancel a response
+// @Description Marks a queued or in-progress response as cancelled. If the response is running on this instance, its in-flight LLM call and tool executions are aborted, completed usage is kept, and a streaming client receives a `response.cancelled` event. Only the user that created the response (the token subject it was stored under) can cancel it.
 // @Tags Responses
 // @Produce json
 // @Param response_id path string true "Response ID"
 // @Success 200 {object} responses.ResponsePayload
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string "Response not found or owned by another user"
 // @Router /v1/responses/{response_id}/cancel [post]
 func (h *ResponseHandler) Cancel(c *gin.Context) {
 	id := c.Param("response_id")
-	resp, err := h.service.Cancel(c.Request.Context(), id)
+	userID := extractSubject(c)
+	if userID == "" {
+		userID = "guest"
+	}
+	resp, err := h.service.Cancel(c.Request.Context(), id, userID)
+	if errors.Is(err, response.ErrResponseNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
@@ -161,6 +199,7 @@ func (h *ResponseHandler) Cancel(c *gin.Context) {
 // @Param response_id path string true "Response ID"
 // @Success 200 {object} responses.ResponsePayload
 // @Failure 400 {object} map[string]string
+// @Failure 404 {object} map[string]string "Response not found or owned by another user"
 // @Router /v1/responses/{response_id} [delete]
 func (h *ResponseHandler) Delete(c *gin.Context) {
 	h.Cancel(c)
@@ -193,19 +232,42 @@ func (h *ResponseHandler) streamResponse(c *gin.Context, params response.CreateP
 		return
 	}
 
-	c.Header("Content-Type", "text/event-stream")
-	c.Header("Cache-Control", "no-cache")
-	c.Header("Connection", "keep-alive")
+	version, err := negotiateStreamVersion(c.GetHeader(StreamVersionHeader), h.defaultStreamVersion)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	encoder, _ := newStreamEncoder(version)
 
+	c.Header(StreamVersionHeader, strconv.Itoa(version))
 	c.Header("Content-Type", "text/event-stream")
 	c.Header("Cache-Control", "no-cache")
 	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
 
-	observer := newSSEObserver(writer, flusher, h.log)
+	observer := newSSEObserver(writer, flusher, encoder, h.log)
 	params.StreamObserver = observer
 
+	// Provider calls and tool execution can leave the stream silent for a long
+	// time; keep proxies from timing out the idle connection.
+	stopHeartbeat := observer.startHeartbeat(h.heartbeatInterval)
 	resp, err := h.service.Create(c.Request.Context(), params)
+	stopHeartbeat()
 	if err != nil {
+		if c.Request.Context().Err() != nil {
+			// Client disconnected; the service already cancelled upstream work.
+			return
+		}
+		if errors.Is(err, response.ErrResponseCancelled) {
+			observer.SendCancelled()
+			return
+		}
+		if status := createErrorStatus(err); status != http.StatusInternalServerError && !c.Writer.Written() {
+			// Rejected before the stream started; answer like a non-streaming request
+			c.Header("Content-Type", "application/json")
+			c.JSON(status, gin.H{"error": err.Error()})
+			return
+		}
 		observer.SendError(err)
 		c.Status(http.StatusInternalServerError)
 		return
@@ -265,22 +327,25 @@ func mapToolChoice(choice *requests.ToolChoice) *llm.ToolChoice {
 type sseObserver struct {
 	writer     http.ResponseWriter
 	flusher    http.Flusher
+	encoder    streamEncoder
 	log        zerolog.Logger
 	mu         sync.Mutex
 	responseID string
+	lastWrite  time.Time
 }
 
-func newSSEObserver(w http.ResponseWriter, flusher http.Flusher, log zerolog.Logger) *sseObserver {
+func newSSEObserver(w http.ResponseWriter, flusher http.Flusher, encoder streamEncoder, log zerolog.Logger) *sseObserver {
 	return &sseObserver{
 		writer:  w,
 		flusher: flusher,
+		encoder: encoder,
 		log:     log,
 	}
 }
 
 func (o *sseObserver) OnResponseCreated(resp *response.Response) {
 	o.responseID = resp.PublicID
-	o.sendEvent("response.created", responses.FromDomain(resp))
+	o.sendEvent(streamEvent{Name: eventResponseCreated, Response: resp})
 }
 
 func (o *sseObserver) OnDelta(delta llm.ChatCompletionDelta) {
@@ -288,53 +353,93 @@ func (o *sseObserver) OnDelta(delta llm.ChatCompletionDelta) {
 	if text == "" {
 		return
 	}
-	payload := map[string]interface{}{
-		"id":    o.responseID,
-		"delta": text,
-	}
-	o.sendEvent("response.output_text.delta", payload)
+	o.sendEvent(streamEvent{Name: eventOutputTextDelta, Delta: text})
 }
 
 func (o *sseObserver) OnToolCall(call tool.Call) {
-	payload := map[string]interface{}{
-		"id":   o.responseID,
-		"call": call,
-	}
-	o.sendEvent("response.tool_call", payload)
+	o.sendEvent(streamEvent{Name: eventToolCall, Call: &call})
 }
 
 func (o *sseObserver) OnToolResult(callID string, result *tool.Result) {
-	payload := map[string]interface{}{
-		"id":      o.responseID,
-		"call_id": callID,
-		"result":  result,
-	}
-	o.sendEvent("response.tool_result", payload)
+	o.sendEvent(streamEvent{Name: eventToolResult, CallID: callID, Result: result})
+}
+
+func (o *sseObserver) OnPlanUpdated(item response.OutputItem) {
+	o.sendEvent(streamEvent{Name: eventPlanUpdated, PlanItem: &item})
 }
 
 func (o *sseObserver) SendCompleted(resp *response.Response) {
-	o.sendEvent("response.completed", responses.FromDomain(resp))
+	o.sendEvent(streamEvent{Name: eventResponseCompleted, Response: resp})
+}
+
+func (o *sseObserver) SendCancelled() {
+	o.sendEvent(streamEvent{Name: eventResponseCancelled})
 }
 
 func (o *sseObserver) SendError(err error) {
-	o.sendEvent("response.error", map[string]string{
-		"message": err.Error(),
-	})
+	o.sendEvent(streamEvent{Name: eventResponseError, Err: err})
 }
 
-func (o *sseObserver) sendEvent(name string, payload interface{}) {
+func (o *sseObserver) sendEvent(event streamEvent) {
 	o.mu.Lock()
 	defer o.mu.Unlock()
 
-	data, err := json.Marshal(payload)
+	event.ResponseID = o.responseID
+	data, err := json.Marshal(o.encoder.encode(event))
 	if err != nil {
 		o.log.Error().Err(err).Msg("marshal SSE payload")
 		return
 	}
 
-	fmt.Fprintf(o.writer, "event: %s\n", name)
+	fmt.Fprintf(o.writer, "event: %s\n", event.Name)
 	fmt.Fprintf(o.writer, "data: %s\n\n", data)
 	o.flusher.Flush()
+	o.lastWrite = time.Now()
+}
+
+// startHeartbeat writes an SSE comment whenever the stream has been idle for
+// interval. The returned function stops the heartbeat and waits for it to exit.
+func (o *sseObserver) startHeartbeat(interval time.Duration) func() {
+	if interval <= 0 {
+		return func() {}
+	}
+
+	o.mu.Lock()
+	o.lastWrite = time.Now()
+	o.mu.Unlock()
+
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				o.sendPing(interval)
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+func (o *sseObserver) sendPing(interval time.Duration) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	if time.Since(o.lastWrite) < interval {
+		return
+	}
+	fmt.Fprint(o.writer, ": ping\n\n")
+	o.flusher.Flush()
+	o.lastWrite = time.Now()
 }
 
 func extractDeltaText(delta llm.ChatCompletionDelta) string {
@@ -0,0 +1,187 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"jan-server/services/response-api/internal/domain/response"
+	"jan-server/services/response-api/internal/domain/tool"
+	"jan-server/services/response-api/internal/interfaces/httpserver/responses"
+)
+
+// StreamVersionHeader selects the shape of streamed events. Responses echo the
+// version used, so clients can tell which shape they are reading.
+const StreamVersionHeader = "X-Jan-Stream-Version"
+
+const (
+	// StreamVersion1 is the original event shape, kept for clients that predate
+	// versioning and send no header.
+	StreamVersion1 = 1
+	// StreamVersion2 adds type, sequence_number and response_id to every event
+	// and flattens tool call and tool result events.
+	StreamVersion2 = 2
+)
+
+// supportedStreamVersions lists the versions clients may request, oldest first.
+var supportedStreamVersions = []int{StreamVersion1, StreamVersion2}
+
+// SSE event names; they are the same in every version.
+const (
+	eventResponseCreated   = "response.created"
+	eventOutputTextDelta   = "response.output_text.delta"
+	eventToolCall          = "response.tool_call"
+	eventToolResult        = "response.tool_result"
+	eventPlanUpdated       = "response.plan.updated"
+	eventResponseCompleted = "response.completed"
+	eventResponseCancelled = "response.cancelled"
+	eventResponseError     = "response.error"
+)
+
+// streamEvent carries what an SSE event reports; which fields are set depends
+// on Name.
+type streamEvent struct {
+	Name       string
+	ResponseID string
+	Response   *response.Response
+	Delta      string
+	Call       *tool.Call
+	CallID     string
+	Result     *tool.Result
+	PlanItem   *response.OutputItem
+	Err        error
+}
+
+// streamEncoder builds event payloads for one stream. Encoders may keep
+// per-stream state, so each stream gets its own.
+type streamEncoder interface {
+	encode(event streamEvent) interface{}
+}
+
+// newStreamEncoder returns the encoder for version, or false when the version is
+// not supported.
+func newStreamEncoder(version int) (streamEncoder, bool) {
+	switch version {
+	case StreamVersion1:
+		return streamEncoderV1{}, true
+	case StreamVersion2:
+		return &streamEncoderV2{}, true
+	}
+	return nil, false
+}
+
+// negotiateStreamVersion reads the version requested in StreamVersionHeader,
+// using fallback when the header is absent.
+func negotiateStreamVersion(header string, fallback int) (int, error) {
+	header = strings.TrimSpace(header)
+	if header == "" {
+		return fallback, nil
+	}
+	version, err := strconv.Atoi(header)
+	if err == nil {
+		if _, ok := newStreamEncoder(version); ok {
+			return version, nil
+		}
+	}
+	supported := make([]string, len(supportedStreamVersions))
+	for i, v := range supportedStreamVersions {
+		supported[i] = strconv.Itoa(v)
+	}
+	return 0, fmt.Errorf("unsupported %s %q; supported versions: %s",
+		StreamVersionHeader, header, strings.Join(supported, ", "))
+}
+
+// streamEncoderV1 reproduces the event payloads sent before versioning existed.
+// Do not change them; add a new version instead.
+type streamEncoderV1 struct{}
+
+func (streamEncoderV1) encode(event streamEvent) interface{} {
+	switch event.Name {
+	case eventResponseCreated, eventResponseCompleted:
+		return responses.FromDomain(event.Response)
+	case eventOutputTextDelta:
+		return map[string]interface{}{
+			"id":    event.ResponseID,
+			"delta": event.Delta,
+		}
+	case eventToolCall:
+		return map[string]interface{}{
+			"id":   event.ResponseID,
+			"call": event.Call,
+		}
+	case eventToolResult:
+		return map[string]interface{}{
+			"id":      event.ResponseID,
+			"call_id": event.CallID,
+			"result":  event.Result,
+		}
+	case eventPlanUpdated:
+		return map[string]interface{}{
+			"id":   event.ResponseID,
+			"item": responses.PlanFromDomain(*event.PlanItem),
+		}
+	case eventResponseCancelled:
+		return map[string]string{
+			"id": event.ResponseID,
+		}
+	case eventResponseError:
+		return map[string]string{
+			"message": event.Err.Error(),
+		}
+	}
+	return map[string]interface{}{}
+}
+
+// streamEncoderV2 wraps every payload in a common envelope: the event type, its
+// position in the stream and the response it belongs to. Tool calls carry their
+// arguments as a JSON string, as in the non-streaming output items.
+type streamEncoderV2 struct {
+	sequence int
+}
+
+func (e *streamEncoderV2) encode(event streamEvent) interface{} {
+	payload := map[string]interface{}{
+		"type":            event.Name,
+		"sequence_number": e.sequence,
+		"response_id":     event.ResponseID,
+	}
+	e.sequence++
+
+	switch event.Name {
+	case eventResponseCreated, eventResponseCompleted:
+		payload["response"] = responses.FromDomain(event.Response)
+	case eventOutputTextDelta:
+		payload["delta"] = event.Delta
+	case eventToolCall:
+		payload["call_id"] = event.Call.ID
+		payload["name"] = event.Call.Name
+		payload["arguments"] = encodeArguments(event.Call.Arguments)
+	case eventToolResult:
+		payload["call_id"] = event.CallID
+		if event.Result != nil {
+			payload["name"] = event.Result.ToolName
+			payload["output"] = event.Result.Content
+			payload["is_error"] = event.Result.IsError
+			if event.Result.Error != "" {
+				payload["error"] = event.Result.Error
+			}
+		}
+	case eventPlanUpdated:
+		payload["item"] = responses.PlanFromDomain(*event.PlanItem)
+	case eventResponseError:
+		payload["message"] = event.Err.Error()
+	}
+	return payload
+}
+
+func encodeArguments(args map[string]interface{}) string {
+	if len(args) == 0 {
+		return "{}"
+	}
+	data, err := json.Marshal(args)
+	if err != nil {
+		return "{}"
+	}
+	return string(data)
+}
@@ -6,16 +6,21 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
-	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	gocommonmetrics "github.com/janhq/jan-server/packages/go-common/metrics"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	responseapidocs "jan-server/services/response-api/docs/swagger"
+	"jan-server/services/response-api/internal/canary"
 	"jan-server/services/response-api/internal/config"
 	domain "jan-server/services/response-api/internal/domain/response"
 	"jan-server/services/response-api/internal/infrastructure/auth"
 	"jan-server/services/response-api/internal/interfaces/httpserver/handlers"
+	"jan-server/services/response-api/internal/interfaces/httpserver/middlewares"
 	"jan-server/services/response-api/internal/interfaces/httpserver/routes"
 )
 
@@ -27,10 +32,13 @@ type HTTPServer struct {
 	handlerProv *handlers.Provider
 	routeProv   *routes.Provider
 	auth        *auth.Validator
+	maintenance *maintenance.Service
 }
 
 // New constructs the HTTP server with default middleware and routes.
-func New(cfg *config.Config, log zerolog.Logger, responseService domain.Service, authValidator *auth.Validator) *HTTPServer {
+// canaryRunner may be nil when the synthetic canary is disabled, and
+// maintenanceService when REDIS_URL is not set.
+func New(cfg *config.Config, log zerolog.Logger, responseService domain.Service, authValidator *auth.Validator, readiness *health.Checker, canaryRunner *canary.Runner, maintenanceService *maintenance.Service) *HTTPServer {
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -39,12 +47,15 @@ func New(cfg *config.Config, log zerolog.Logger, responseService domain.Service,
 	engine := gin.New()
 	engine.Use(gin.Recovery())
 	engine.Use(gin.Logger())
+	engine.Use(middlewares.TracingMiddleware())
+	engine.Use(middlewares.MetricsMiddleware())
+	engine.Use(middlewares.StreamMetricsMiddleware())
 
-	handlerProvider := handlers.NewProvider(responseService, log)
+	handlerProvider := handlers.NewProvider(responseService, log, cfg.SSEHeartbeatInterval, cfg.SSEDefaultStreamVersion)
 	routeProvider := routes.NewProvider(handlerProvider)
 
 	// Register public routes (health checks, swagger) without authentication
-	registerPublicRoutes(engine, cfg, authValidator)
+	registerPublicRoutes(engine, cfg, authValidator, readiness, canaryRunner)
 
 	// Apply authentication middleware before protected routes
 	if authValidator != nil {
@@ -61,15 +72,19 @@ func New(cfg *config.Config, log zerolog.Logger, responseService domain.Service,
 		handlerProv: handlerProvider,
 		routeProv:   routeProvider,
 		auth:        authValidator,
+		maintenance: maintenanceService,
 	}
 }
 
 // Run starts the HTTP listener and handles graceful shutdown via context cancellation.
 func (s *HTTPServer) Run(ctx context.Context) error {
-	server := &http.Server{
-		Addr:    s.cfg.Addr(),
-		Handler: s.engine,
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		return err
 	}
+	// Maintenance mode turns away writes before they reach the engine
+	server := serverbuilder.New(s.cfg.Addr(), s.maintenance.Middleware()(s.engine), serverCfg)
+	go s.maintenance.Run(ctx)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -98,7 +113,7 @@ func (s *HTTPServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator *auth.Validator) {
+func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator *auth.Validator, readiness *health.Checker, canaryRunner *canary.Runner) {
 	engine.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"service": cfg.ServiceName,
@@ -106,13 +121,17 @@ func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator
 		})
 	})
 
+	// Liveness stays 200 regardless of the canary; its outcome is detail for
+	// dashboards and on-call, alerting is driven by the canary metrics.
 	engine.GET("/healthz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
+		body := gin.H{"status": "healthy"}
+		if canaryRunner != nil {
+			body["canary"] = canaryRunner.Status()
+		}
+		c.JSON(http.StatusOK, body)
 	})
 
-	engine.GET("/readyz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ready"})
-	})
+	engine.GET("/readyz", gin.WrapH(readiness))
 	engine.GET("/health/auth", func(c *gin.Context) {
 		if authValidator == nil || authValidator.Ready() {
 			c.JSON(http.StatusOK, gin.H{"status": "ready"})
@@ -122,7 +141,7 @@ func registerPublicRoutes(engine *gin.Engine, cfg *config.Config, authValidator
 	})
 
 	// Prometheus metrics endpoint
-	engine.GET("/metrics", gin.WrapH(promhttp.Handler()))
+	engine.GET("/metrics", gin.WrapH(gocommonmetrics.Handler()))
 
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 }
@@ -0,0 +1,201 @@
+package responses
+
+import (
+	"encoding/json"
+	"strings"
+
+	"jan-server/services/response-api/internal/domain/llm"
+	"jan-server/services/response-api/internal/domain/response"
+	"jan-server/services/response-api/internal/domain/tool"
+)
+
+// webSearchTools are the MCP tools reported as OpenAI web_search_call items
+// rather than function_call items.
+var webSearchTools = map[string]bool{
+	"google_search": true,
+	"scrape":        true,
+}
+
+// OutputItem is an entry of an OpenAI response's output array. Type selects
+// which of the other fields are set: message, function_call, web_search_call,
+// or plan, a Jan extension listing the steps of a multi-step run.
+type OutputItem struct {
+	Type        string               `json:"type"`
+	ID          string               `json:"id"`
+	Status      string               `json:"status"`
+	Role        string               `json:"role,omitempty"`
+	Content     []OutputContent      `json:"content,omitempty"`
+	CallID      string               `json:"call_id,omitempty"`
+	Name        string               `json:"name,omitempty"`
+	Arguments   string               `json:"arguments,omitempty"`
+	Action      *WebSearchCallAction `json:"action,omitempty"`
+	Explanation string               `json:"explanation,omitempty"`
+	Steps       []tool.PlanStep      `json:"steps,omitempty"`
+}
+
+// OutputContent is a part of a message output item.
+type OutputContent struct {
+	Type        string        `json:"type"`
+	Text        string        `json:"text"`
+	Annotations []interface{} `json:"annotations"`
+}
+
+// WebSearchCallAction describes what a web_search_call did.
+type WebSearchCallAction struct {
+	Type  string `json:"type"` // "search" or "open_page"
+	Query string `json:"query,omitempty"`
+	URL   string `json:"url,omitempty"`
+}
+
+// Usage is token accounting in the OpenAI Responses format.
+type Usage struct {
+	InputTokens         int                 `json:"input_tokens"`
+	InputTokensDetails  InputTokensDetails  `json:"input_tokens_details"`
+	OutputTokens        int                 `json:"output_tokens"`
+	OutputTokensDetails OutputTokensDetails `json:"output_tokens_details"`
+	TotalTokens         int                 `json:"total_tokens"`
+}
+
+// InputTokensDetails breaks down input tokens.
+type InputTokensDetails struct {
+	CachedTokens int `json:"cached_tokens"`
+}
+
+// OutputTokensDetails breaks down output tokens.
+type OutputTokensDetails struct {
+	ReasoningTokens int `json:"reasoning_tokens"`
+}
+
+// ErrorObject is the error of a failed response.
+type ErrorObject struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// IncompleteDetails explains why a response is incomplete.
+type IncompleteDetails struct {
+	Reason string `json:"reason"`
+}
+
+// outputFromDomain translates the tool loop's output items into OpenAI's.
+func outputFromDomain(items []response.OutputItem, responseStatus response.Status) []OutputItem {
+	result := make([]OutputItem, 0, len(items))
+	for _, item := range items {
+		switch item.Type {
+		case response.OutputItemMessage:
+			status := "completed"
+			if responseStatus == response.StatusIncomplete {
+				status = "incomplete"
+			}
+			result = append(result, OutputItem{
+				Type:   "message",
+				ID:     item.ID,
+				Status: status,
+				Role:   "assistant",
+				Content: []OutputContent{{
+					Type:        "output_text",
+					Text:        item.Text,
+					Annotations: []interface{}{},
+				}},
+			})
+		case response.OutputItemToolCall:
+			if webSearchTools[item.Name] {
+				result = append(result, webSearchCallFromDomain(item))
+				continue
+			}
+			status := "completed"
+			if item.Status != string(tool.ExecutionStatusCompleted) && item.Status != string(tool.ExecutionStatusFailed) {
+				status = "incomplete"
+			}
+			result = append(result, OutputItem{
+				Type:      "function_call",
+				ID:        item.ID,
+				Status:    status,
+				CallID:    item.CallID,
+				Name:      item.Name,
+				Arguments: item.Arguments,
+			})
+		case response.OutputItemPlan:
+			if item.Plan == nil {
+				continue
+			}
+			result = append(result, planFromDomain(item, responseStatus))
+		}
+	}
+	return result
+}
+
+// planFromDomain reports a plan left unfinished by a response that stopped as
+// incomplete rather than still in progress.
+func planFromDomain(item response.OutputItem, responseStatus response.Status) OutputItem {
+	status := item.Status
+	if status != response.PlanStatusCompleted {
+		switch responseStatus {
+		case response.StatusCompleted, response.StatusIncomplete, response.StatusCancelled, response.StatusFailed:
+			status = "incomplete"
+		}
+	}
+	return OutputItem{
+		Type:        "plan",
+		ID:          item.ID,
+		Status:      status,
+		Explanation: item.Plan.Explanation,
+		Steps:       item.Plan.Steps,
+	}
+}
+
+// PlanFromDomain converts a plan item for the response.plan.updated stream event.
+func PlanFromDomain(item response.OutputItem) OutputItem {
+	if item.Plan == nil {
+		return OutputItem{Type: "plan", ID: item.ID, Status: item.Status}
+	}
+	return planFromDomain(item, response.StatusInProgress)
+}
+
+func webSearchCallFromDomain(item response.OutputItem) OutputItem {
+	var args struct {
+		Q     string `json:"q"`
+		Query string `json:"query"`
+		URL   string `json:"url"`
+	}
+	_ = json.Unmarshal([]byte(item.Arguments), &args)
+	action := &WebSearchCallAction{Type: "search", Query: args.Q}
+	if action.Query == "" {
+		action.Query = args.Query
+	}
+	if item.Name == "scrape" {
+		action = &WebSearchCallAction{Type: "open_page", URL: args.URL}
+	}
+
+	status := "completed"
+	if item.Status != string(tool.ExecutionStatusCompleted) {
+		status = "failed"
+	}
+	return OutputItem{
+		Type:   "web_search_call",
+		ID:     "ws_" + strings.TrimPrefix(item.ID, "fc_"),
+		Status: status,
+		Action: action,
+	}
+}
+
+func usageFromDomain(usage *llm.Usage) *Usage {
+	if usage == nil {
+		return nil
+	}
+	return &Usage{
+		InputTokens:         usage.PromptTokens,
+		InputTokensDetails:  InputTokensDetails{CachedTokens: usage.CachedTokens()},
+		OutputTokens:        usage.CompletionTokens,
+		OutputTokensDetails: OutputTokensDetails{ReasoningTokens: usage.ReasoningTokens()},
+		TotalTokens:         usage.TotalTokens,
+	}
+}
+
+// statusFromDomain maps lifecycle states without an OpenAI equivalent.
+func statusFromDomain(status response.Status) string {
+	if status == response.StatusPending {
+		return string(response.StatusQueued)
+	}
+	return string(status)
+}
@@ -0,0 +1,78 @@
+package responses
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"jan-server/services/response-api/internal/domain/llm"
+	"jan-server/services/response-api/internal/domain/response"
+	"jan-server/services/response-api/internal/domain/tool"
+)
+
+// conformanceFixture is a stored response, the tool loop result or legacy
+// output it was built from, and the OpenAI Response object clients must see.
+type conformanceFixture struct {
+	Response     response.Response `json:"response"`
+	Messages     []llm.ChatMessage `json:"messages"`
+	Executions   []tool.Execution  `json:"executions"`
+	StoredOutput interface{}       `json:"stored_output"`
+	Expected     json.RawMessage   `json:"expected"`
+}
+
+func TestFromDomainMatchesOpenAIFixtures(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("testdata", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(paths) == 0 {
+		t.Fatal("no fixtures found in testdata")
+	}
+
+	for _, path := range paths {
+		t.Run(strings.TrimSuffix(filepath.Base(path), ".json"), func(t *testing.T) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var fixture conformanceFixture
+			if err := json.Unmarshal(data, &fixture); err != nil {
+				t.Fatalf("decode fixture: %v", err)
+			}
+
+			resp := fixture.Response
+			switch {
+			case fixture.Messages != nil:
+				resp.Output = response.BuildOutput(fixture.Messages, fixture.Executions)
+				// Item IDs are random; number them so the fixture can pin them
+				for i := range resp.Output {
+					prefix, _, _ := strings.Cut(resp.Output[i].ID, "_")
+					resp.Output[i].ID = fmt.Sprintf("%s_%d", prefix, i+1)
+				}
+			case fixture.StoredOutput != nil:
+				resp.Output = response.OutputFromContent(resp.PublicID, fixture.StoredOutput)
+			}
+
+			got, err := json.Marshal(FromDomain(&resp))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var gotValue, wantValue interface{}
+			if err := json.Unmarshal(got, &gotValue); err != nil {
+				t.Fatal(err)
+			}
+			if err := json.Unmarshal(fixture.Expected, &wantValue); err != nil {
+				t.Fatalf("decode expected: %v", err)
+			}
+			if !reflect.DeepEqual(gotValue, wantValue) {
+				want, _ := json.MarshalIndent(wantValue, "", "  ")
+				gotIndented, _ := json.MarshalIndent(gotValue, "", "  ")
+				t.Fatalf("payload mismatch\nwant:\n%s\ngot:\n%s", want, gotIndented)
+			}
+		})
+	}
+}
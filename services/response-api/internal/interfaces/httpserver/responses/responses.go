@@ -63,7 +63,8 @@ func HandleNewError(reqCtx *gin.Context, errorType platformerrors.ErrorType, mes
 	reqCtx.AbortWithStatusJSON(statusCode, errResp)
 }
 
-// ResponsePayload is returned to clients.
+// ResponsePayload is returned to clients. It follows the OpenAI Response
+// object so SDK clients can parse it, plus Jan's conversation and input fields.
 type ResponsePayload struct {
 	ID                 string                 `json:"id"`
 	Object             string                 `json:"object"`
@@ -72,40 +73,67 @@ type ResponsePayload struct {
 	Model              string                 `json:"model"`
 	Status             string                 `json:"status"`
 	Input              interface{}            `json:"input"`
-	Output             interface{}            `json:"output,omitempty"`
-	Usage              interface{}            `json:"usage,omitempty"`
-	Metadata           map[string]interface{} `json:"metadata,omitempty"`
+	Instructions       *string                `json:"instructions"`
+	Output             []OutputItem           `json:"output"`
+	OutputText         string                 `json:"output_text"`
+	Usage              *Usage                 `json:"usage"`
+	Metadata           map[string]interface{} `json:"metadata"`
 	ConversationID     *string                `json:"conversation_id,omitempty"`
-	PreviousResponseID *string                `json:"previous_response_id,omitempty"`
+	PreviousResponseID *string                `json:"previous_response_id"`
 	SystemPrompt       *string                `json:"system_prompt,omitempty"`
 	Stream             bool                   `json:"stream"`
 	Background         bool                   `json:"background"`
 	Store              bool                   `json:"store"`
-	Error              interface{}            `json:"error,omitempty"`
+	Error              *ErrorObject           `json:"error"`
+	IncompleteDetails  *IncompleteDetails     `json:"incomplete_details"`
+	ParallelToolCalls  bool                   `json:"parallel_tool_calls"`
+	ToolChoice         string                 `json:"tool_choice"`
+	Tools              []interface{}          `json:"tools"`
+	Temperature        *float64               `json:"temperature"`
+	TopP               *float64               `json:"top_p"`
+	MaxOutputTokens    *int                   `json:"max_output_tokens"`
 }
 
 // FromDomain maps the domain response to DTO.
 func FromDomain(r *response.Response) ResponsePayload {
 	createdUnix := r.CreatedAt.Unix()
-	return ResponsePayload{
+	metadata := r.Metadata
+	if metadata == nil {
+		metadata = map[string]interface{}{}
+	}
+	payload := ResponsePayload{
 		ID:                 r.PublicID,
 		Object:             r.Object,
 		Created:            createdUnix,
 		CreatedAt:          createdUnix, // Duplicate for compatibility
 		Model:              r.Model,
-		Status:             string(r.Status),
+		Status:             statusFromDomain(r.Status),
 		Input:              r.Input,
-		Output:             r.Output,
-		Usage:              r.Usage,
-		Metadata:           r.Metadata,
+		Instructions:       r.SystemPrompt,
+		Output:             outputFromDomain(r.Output, r.Status),
+		OutputText:         r.OutputText(),
+		Usage:              usageFromDomain(r.Usage),
+		Metadata:           metadata,
 		ConversationID:     r.ConversationPublicID,
 		PreviousResponseID: r.PreviousResponseID,
 		SystemPrompt:       r.SystemPrompt,
 		Stream:             r.Stream,
 		Background:         r.Background,
 		Store:              r.Store,
-		Error:              r.Error,
+		ParallelToolCalls:  true,
+		ToolChoice:         "auto",
+		Tools:              []interface{}{},
+	}
+	if r.Error != nil {
+		payload.Error = &ErrorObject{Code: r.Error.Code, Message: r.Error.Message}
+		if payload.Error.Code == "" {
+			payload.Error.Code = "server_error"
+		}
+	}
+	if r.IncompleteDetails != nil {
+		payload.IncompleteDetails = &IncompleteDetails{Reason: r.IncompleteDetails.Reason}
 	}
+	return payload
 }
 
 // ConversationItemsResponse wraps conversation input items for consistent responses.
@@ -0,0 +1 @@
+ALTER TABLE response_api.responses DROP COLUMN IF EXISTS incomplete_details;
@@ -0,0 +1,2 @@
+-- Why a response stopped early (e.g. max_output_tokens), reported as incomplete_details
+ALTER TABLE response_api.responses ADD COLUMN IF NOT EXISTS incomplete_details JSONB;
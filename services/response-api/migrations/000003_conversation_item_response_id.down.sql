@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS response_api.idx_conversation_items_response_id;
+ALTER TABLE response_api.conversation_items DROP COLUMN IF EXISTS response_id;
@@ -0,0 +1,6 @@
+-- Link each conversation item to the response that wrote it, so a
+-- previous_response_id chain can be replayed without the rest of the conversation
+ALTER TABLE response_api.conversation_items
+    ADD COLUMN IF NOT EXISTS response_id INTEGER REFERENCES response_api.responses(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_conversation_items_response_id ON response_api.conversation_items(response_id, sequence);
@@ -34,6 +34,8 @@ func BuildApplication(ctx context.Context) (*Application, error) {
 		newGormDB,
 		newAuthValidator,
 		sampleSet,
+		newReadinessChecker,
+		newMaintenance,
 		httpserver.New,
 		NewApplication,
 	)
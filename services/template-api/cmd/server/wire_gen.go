@@ -41,7 +41,12 @@ func BuildApplication(ctx context.Context) (*Application, error) {
 	if err != nil {
 		return nil, err
 	}
-	httpServer := httpserver.New(configConfig, zerologLogger, service, validator)
+	checker := newReadinessChecker(configConfig, db, validator)
+	maintenanceService, err := newMaintenance(configConfig, zerologLogger)
+	if err != nil {
+		return nil, err
+	}
+	httpServer := httpserver.New(configConfig, zerologLogger, service, validator, checker, maintenanceService)
 	application := NewApplication(httpServer, zerologLogger)
 	return application, nil
 }
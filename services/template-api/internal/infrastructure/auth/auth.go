@@ -9,6 +9,7 @@ import (
 	"github.com/MicahParks/keyfunc/v2"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
+	commonauth "github.com/janhq/jan-server/packages/go-common/auth"
 	"github.com/rs/zerolog"
 
 	"jan-server/services/template-api/internal/config"
@@ -73,11 +74,24 @@ func (v *Validator) Middleware() gin.HandlerFunc {
 			return
 		}
 
+		if claims, ok := token.Claims.(jwt.MapClaims); ok && commonauth.IsIntegrationToken(claims, v.cfg.IntegrationClients) {
+			abortForbidden(c, commonauth.IntegrationTokenMessage)
+			return
+		}
+
 		c.Set("auth_token", token)
 		c.Next()
 	}
 }
 
+// Ready indicates if the validator is prepared.
+func (v *Validator) Ready() bool {
+	if v == nil || !v.cfg.AuthEnabled {
+		return true
+	}
+	return v.jwks != nil
+}
+
 func bearerToken(header string) string {
 	if header == "" {
 		return ""
@@ -94,3 +108,9 @@ func abortUnauthorized(c *gin.Context, message string) {
 		"error": message,
 	})
 }
+
+func abortForbidden(c *gin.Context, message string) {
+	c.AbortWithStatusJSON(http.StatusForbidden, gin.H{
+		"error": message,
+	})
+}
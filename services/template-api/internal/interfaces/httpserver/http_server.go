@@ -6,6 +6,9 @@ import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/janhq/jan-server/packages/go-common/health"
+	"github.com/janhq/jan-server/packages/go-common/maintenance"
+	"github.com/janhq/jan-server/packages/go-common/serverbuilder"
 	"github.com/rs/zerolog"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -25,10 +28,12 @@ type HTTPServer struct {
 	log         zerolog.Logger
 	handlerProv *handlers.Provider
 	routeProv   *routes.Provider
+	maintenance *maintenance.Service
 }
 
 // New constructs the HTTP server with default middleware and routes.
-func New(cfg *config.Config, log zerolog.Logger, sampleService domain.Service, authValidator *auth.Validator) *HTTPServer {
+// maintenanceService is nil when REDIS_URL is not set.
+func New(cfg *config.Config, log zerolog.Logger, sampleService domain.Service, authValidator *auth.Validator, readiness *health.Checker, maintenanceService *maintenance.Service) *HTTPServer {
 	if cfg.Environment == "production" {
 		gin.SetMode(gin.ReleaseMode)
 	}
@@ -42,7 +47,7 @@ func New(cfg *config.Config, log zerolog.Logger, sampleService domain.Service, a
 	}
 	handlerProvider := handlers.NewProvider(sampleService)
 	routeProvider := routes.NewProvider(handlerProvider)
-	registerCoreRoutes(engine, cfg, routeProvider)
+	registerCoreRoutes(engine, cfg, routeProvider, readiness)
 
 	return &HTTPServer{
 		cfg:         cfg,
@@ -50,15 +55,19 @@ func New(cfg *config.Config, log zerolog.Logger, sampleService domain.Service, a
 		log:         log,
 		handlerProv: handlerProvider,
 		routeProv:   routeProvider,
+		maintenance: maintenanceService,
 	}
 }
 
 // Run starts the HTTP listener and handles graceful shutdown via context cancellation.
 func (s *HTTPServer) Run(ctx context.Context) error {
-	server := &http.Server{
-		Addr:    s.cfg.Addr(),
-		Handler: s.engine,
+	serverCfg, err := serverbuilder.FromEnv(serverbuilder.DefaultConfig())
+	if err != nil {
+		return err
 	}
+	// Maintenance mode turns away writes before they reach the engine
+	server := serverbuilder.New(s.cfg.Addr(), s.maintenance.Middleware()(s.engine), serverCfg)
+	go s.maintenance.Run(ctx)
 
 	errCh := make(chan error, 1)
 	go func() {
@@ -87,7 +96,7 @@ func (s *HTTPServer) Run(ctx context.Context) error {
 	return nil
 }
 
-func registerCoreRoutes(engine *gin.Engine, cfg *config.Config, routeProvider *routes.Provider) {
+func registerCoreRoutes(engine *gin.Engine, cfg *config.Config, routeProvider *routes.Provider, readiness *health.Checker) {
 	engine.GET("/", func(c *gin.Context) {
 		c.JSON(http.StatusOK, gin.H{
 			"service": cfg.ServiceName,
@@ -99,9 +108,7 @@ func registerCoreRoutes(engine *gin.Engine, cfg *config.Config, routeProvider *r
 		c.JSON(http.StatusOK, gin.H{"status": "healthy"})
 	})
 
-	engine.GET("/readyz", func(c *gin.Context) {
-		c.JSON(http.StatusOK, gin.H{"status": "ready"})
-	})
+	engine.GET("/readyz", gin.WrapH(readiness))
 
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
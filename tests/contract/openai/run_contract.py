@@ -0,0 +1,289 @@
+#!/usr/bin/env python3
+"""OpenAI compatibility contract tests for llm-api.
+
+Sends the vectors in vectors.json through the official openai Python SDK and
+checks that responses parse and match the OpenAI chat completions contract.
+Deviations not listed in known-deviations.txt fail the run.
+
+    pip install -r requirements.txt
+    python run_contract.py --base-url http://localhost:8000/v1 --junit report.xml
+"""
+
+import argparse
+import json
+import os
+import sys
+import time
+import urllib.request
+import xml.etree.ElementTree as ET
+from pathlib import Path
+
+import openai
+
+HERE = Path(__file__).resolve().parent
+FINISH_REASONS = {"stop", "length", "tool_calls", "content_filter", "function_call"}
+
+
+class Deviation(Exception):
+    """A check that ran and found behaviour different from the contract."""
+
+
+def guest_token(base_url):
+    gateway = base_url.rstrip("/")
+    if gateway.endswith("/v1"):
+        gateway = gateway[: -len("/v1")]
+    req = urllib.request.Request(
+        gateway + "/auth/guest-login",
+        data=b"{}",
+        headers={"Content-Type": "application/json"},
+        method="POST",
+    )
+    with urllib.request.urlopen(req, timeout=10) as resp:
+        return json.load(resp)["access_token"]
+
+
+def substitute(value, model):
+    if isinstance(value, str):
+        return value.replace("{{model}}", model)
+    if isinstance(value, list):
+        return [substitute(v, model) for v in value]
+    if isinstance(value, dict):
+        return {k: substitute(v, model) for k, v in value.items()}
+    return value
+
+
+def require(cond, message):
+    if not cond:
+        raise Deviation(message)
+
+
+# --- non-streaming checks -------------------------------------------------
+
+
+def check_chat(name, resp):
+    choice = resp.choices[0] if resp.choices else None
+    if name == "object":
+        require(resp.object == "chat.completion", f"object={resp.object!r}")
+    elif name == "id":
+        require(isinstance(resp.id, str) and resp.id, "missing id")
+    elif name == "created":
+        require(isinstance(resp.created, int) and resp.created > 0, f"created={resp.created!r}")
+    elif name == "model":
+        require(isinstance(resp.model, str) and resp.model, "missing model")
+    elif name == "assistant_message":
+        require(choice is not None, "no choices")
+        require(choice.message.role == "assistant", f"role={choice.message.role!r}")
+        require(choice.message.content, "empty assistant content")
+    elif name == "finish_reason":
+        require(choice is not None and choice.finish_reason in FINISH_REASONS, f"finish_reason={getattr(choice, 'finish_reason', None)!r}")
+    elif name == "finish_reason_length":
+        require(choice is not None and choice.finish_reason == "length", f"finish_reason={getattr(choice, 'finish_reason', None)!r}")
+    elif name == "finish_reason_tool_calls":
+        require(choice is not None and choice.finish_reason == "tool_calls", f"finish_reason={getattr(choice, 'finish_reason', None)!r}")
+    elif name == "usage":
+        u = resp.usage
+        require(u is not None, "missing usage")
+        require(u.prompt_tokens > 0 and u.completion_tokens >= 0, f"usage={u}")
+        require(u.total_tokens == u.prompt_tokens + u.completion_tokens, f"total_tokens {u.total_tokens} != prompt+completion")
+    elif name == "tool_calls":
+        require(choice is not None and choice.message.tool_calls, "no tool_calls")
+        call = choice.message.tool_calls[0]
+        require(call.type == "function", f"type={call.type!r}")
+        require(call.id, "tool call without id")
+        try:
+            json.loads(call.function.arguments)
+        except (TypeError, ValueError):
+            raise Deviation(f"arguments are not JSON: {call.function.arguments!r}")
+    else:
+        raise ValueError(f"unknown chat check {name!r}")
+
+
+# --- streaming checks -----------------------------------------------------
+
+
+def collect_stream(stream):
+    chunks = list(stream)
+    require(chunks, "stream produced no chunks")
+    return chunks
+
+
+def check_stream(name, chunks):
+    with_choices = [c for c in chunks if c.choices]
+    if name == "chunk_object":
+        bad = {c.object for c in chunks if c.object != "chat.completion.chunk"}
+        require(not bad, f"chunk object={bad}")
+    elif name == "chunk_stable_id":
+        ids = {c.id for c in chunks}
+        require(len(ids) == 1, f"chunk ids differ: {sorted(ids)[:3]}")
+    elif name == "chunk_role_first":
+        require(with_choices and with_choices[0].choices[0].delta.role == "assistant", "first delta has no assistant role")
+    elif name == "chunk_content":
+        text = "".join(c.choices[0].delta.content or "" for c in with_choices)
+        require(text.strip(), "no content in deltas")
+    elif name == "chunk_finish_reason":
+        reasons = [c.choices[0].finish_reason for c in with_choices if c.choices[0].finish_reason]
+        require(len(reasons) == 1, f"expected exactly one finish_reason, got {reasons}")
+        require(reasons[0] in FINISH_REASONS, f"finish_reason={reasons[0]!r}")
+    elif name == "chunk_usage_last":
+        last = chunks[-1]
+        require(last.usage is not None, "last chunk has no usage")
+        require(not last.choices, "usage chunk must have empty choices")
+        require(last.usage.total_tokens == last.usage.prompt_tokens + last.usage.completion_tokens, "usage totals do not add up")
+    elif name == "chunk_tool_calls":
+        args = {}
+        names = {}
+        for c in with_choices:
+            for tc in c.choices[0].delta.tool_calls or []:
+                require(tc.index is not None, "tool call delta without index")
+                if tc.function and tc.function.name:
+                    names[tc.index] = tc.function.name
+                if tc.function and tc.function.arguments:
+                    args[tc.index] = args.get(tc.index, "") + tc.function.arguments
+        require(names, "no tool call names streamed")
+        for idx, raw in args.items():
+            try:
+                json.loads(raw)
+            except ValueError:
+                raise Deviation(f"tool call {idx} arguments are not JSON after accumulation: {raw!r}")
+    else:
+        raise ValueError(f"unknown stream check {name!r}")
+
+
+# --- error checks ---------------------------------------------------------
+
+
+def check_error(name, err):
+    if name.startswith("status:"):
+        want = int(name.split(":", 1)[1])
+        require(err is not None, f"expected HTTP {want}, request succeeded")
+        require(err.status_code == want, f"status={err.status_code}, want {want}")
+    elif name == "error_envelope":
+        require(err is not None, "expected an error response")
+        body = err.body
+        require(isinstance(body, dict) and "message" in body and "type" in body,
+                f"body is not an OpenAI error object: {err.response.text[:200]}")
+    else:
+        raise ValueError(f"unknown error check {name!r}")
+
+
+# --- runner ---------------------------------------------------------------
+
+
+def run_vector(vector, client, args):
+    request = substitute(vector["request"], args.model)
+    kind = vector["kind"]
+    results = []
+
+    if vector.get("api_key"):
+        client = client.with_options(api_key=vector["api_key"])
+
+    started = time.monotonic()
+    try:
+        if kind == "chat":
+            payload, checker = client.chat.completions.create(**request), check_chat
+        elif kind == "stream":
+            payload, checker = collect_stream(client.chat.completions.create(stream=True, **request)), check_stream
+        elif kind == "error":
+            try:
+                client.chat.completions.create(**request)
+                payload = None
+            except openai.APIStatusError as exc:
+                payload = exc
+            checker = check_error
+        else:
+            raise ValueError(f"unknown kind {kind!r}")
+    except (openai.APIError, Deviation, ValueError) as exc:
+        # The SDK could not complete or parse the call: every check deviates.
+        elapsed = time.monotonic() - started
+        message = f"{type(exc).__name__}: {exc}"
+        return [(check, message, elapsed) for check in vector["expect"]]
+
+    elapsed = time.monotonic() - started
+    for check in vector["expect"]:
+        try:
+            checker(check, payload)
+            results.append((check, None, elapsed))
+        except Deviation as exc:
+            results.append((check, str(exc), elapsed))
+    return results
+
+
+def write_junit(path, rows):
+    suite = ET.Element("testsuite", name="openai-contract", tests=str(len(rows)),
+                       failures=str(sum(1 for r in rows if r["status"] == "fail")))
+    for row in rows:
+        case = ET.SubElement(suite, "testcase", classname=row["vector"], name=row["check"], time=f"{row['time']:.3f}")
+        if row["status"] == "fail":
+            ET.SubElement(case, "failure", message=row["detail"] or "")
+        elif row["status"] == "known":
+            ET.SubElement(case, "skipped", message="known deviation: " + (row["detail"] or ""))
+    root = ET.Element("testsuites")
+    root.append(suite)
+    Path(path).parent.mkdir(parents=True, exist_ok=True)
+    ET.ElementTree(root).write(path, encoding="utf-8", xml_declaration=True)
+
+
+def main():
+    parser = argparse.ArgumentParser(description=__doc__, formatter_class=argparse.RawDescriptionHelpFormatter)
+    parser.add_argument("--base-url", default=os.getenv("OPENAI_BASE_URL", "http://localhost:8000/v1"))
+    parser.add_argument("--api-key", default=os.getenv("OPENAI_API_KEY", ""), help="defaults to a guest token")
+    parser.add_argument("--model", default=os.getenv("CONTRACT_MODEL", ""), help="defaults to the first listed model")
+    parser.add_argument("--vectors", default=str(HERE / "vectors.json"))
+    parser.add_argument("--known", default=str(HERE / "known-deviations.txt"))
+    parser.add_argument("--only", default="", help="run vectors whose name starts with this prefix")
+    parser.add_argument("--junit", default="", help="write a JUnit XML report")
+    parser.add_argument("--json", default="", help="write a JSON report")
+    args = parser.parse_args()
+
+    api_key = args.api_key or guest_token(args.base_url)
+    client = openai.OpenAI(base_url=args.base_url, api_key=api_key, max_retries=0, timeout=120)
+
+    if not args.model:
+        models = client.models.list()
+        if not models.data:
+            sys.exit("no models available; pass --model")
+        args.model = models.data[0].id
+
+    vectors = json.loads(Path(args.vectors).read_text())["vectors"]
+    if args.only:
+        vectors = [v for v in vectors if v["name"].startswith(args.only)]
+    known = set()
+    if Path(args.known).exists():
+        for line in Path(args.known).read_text().splitlines():
+            line = line.strip()
+            if line and not line.startswith("#"):
+                known.add(line)
+
+    print(f"OpenAI contract: {len(vectors)} vectors against {args.base_url} (model {args.model}, SDK {openai.__version__})\n")
+
+    rows = []
+    for vector in vectors:
+        for check, detail, elapsed in run_vector(vector, client, args):
+            key = f"{vector['name']}/{check}"
+            if detail is None:
+                status = "fixed" if key in known else "pass"
+            else:
+                status = "known" if key in known else "fail"
+            rows.append({"vector": vector["name"], "check": check, "status": status, "detail": detail, "time": elapsed})
+            marker = {"pass": "ok  ", "fixed": "ok* ", "known": "known", "fail": "FAIL"}[status]
+            print(f"  {marker} {key}" + (f"  -- {detail}" if detail else ""))
+
+    failed = [r for r in rows if r["status"] == "fail"]
+    fixed = [r for r in rows if r["status"] == "fixed"]
+    print(f"\n{len(rows)} checks: {len(rows) - len(failed)} ok, {len(failed)} new deviations, "
+          f"{sum(1 for r in rows if r['status'] == 'known')} known")
+    if fixed:
+        print("Now passing, remove from known-deviations.txt:")
+        for r in fixed:
+            print(f"  {r['vector']}/{r['check']}")
+
+    if args.junit:
+        write_junit(args.junit, rows)
+    if args.json:
+        Path(args.json).write_text(json.dumps({"model": args.model, "sdk": openai.__version__, "results": rows}, indent=2) + "\n")
+
+    sys.exit(1 if failed else 0)
+
+
+if __name__ == "__main__":
+    main()
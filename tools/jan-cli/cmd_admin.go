@@ -0,0 +1,234 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var adminCmd = &cobra.Command{
+	Use:   "admin",
+	Short: "Operational views and controls of a running deployment",
+	Long: `Inspect conversations and usage, and switch maintenance mode, through the
+llm-api admin API.
+
+Requests are authenticated with an admin user's access token or API key,
+taken from --token or $JAN_API_TOKEN, or with the login stored by
+"jan-cli login". Conversation content is never shown, only metadata.`,
+}
+
+var adminConversationsCmd = &cobra.Command{
+	Use:   "conversations",
+	Short: "List recently active conversations",
+	Long: `List conversations active within a window, most recent first.
+
+Examples:
+  jan-cli admin conversations --since 24h
+  jan-cli admin conversations --user alice@example.com --since 7d`,
+	RunE: runAdminConversations,
+}
+
+var adminUsageCmd = &cobra.Command{
+	Use:   "usage",
+	Short: "Summarise completions by model, provider, user or day",
+	Long: `Summarise chat completions across users, including today's traffic.
+
+Examples:
+  jan-cli admin usage --by model
+  jan-cli admin usage --by day --user 42 --since 30d`,
+	RunE: runAdminUsage,
+}
+
+func init() {
+	adminCmd.AddCommand(adminConversationsCmd)
+	adminCmd.AddCommand(adminUsageCmd)
+
+	adminCmd.PersistentFlags().String("url", "http://localhost:8000", "Gateway base URL")
+	adminCmd.PersistentFlags().String("token", "", "Admin access token or API key (default: $JAN_API_TOKEN, else jan-cli login)")
+	adminCmd.PersistentFlags().Bool("json", false, "Print the raw JSON response")
+	adminCmd.PersistentFlags().String("user", "", "Only this user: user ID, subject, email or username")
+	adminCmd.PersistentFlags().String("since", "7d", "Window start: 7d, 12h, YYYY-MM-DD or RFC 3339")
+
+	adminConversationsCmd.Flags().Int("limit", 50, "Maximum conversations (up to 500)")
+
+	adminUsageCmd.Flags().String("by", "model", "Grouping: model, provider, user or day")
+}
+
+func runAdminConversations(cmd *cobra.Command, args []string) error {
+	limit, _ := cmd.Flags().GetInt("limit")
+
+	var resp struct {
+		Since time.Time `json:"since"`
+		Total int64     `json:"total"`
+		Data  []struct {
+			ID        string    `json:"id"`
+			UserID    uint      `json:"user_id"`
+			ProjectID string    `json:"project_id"`
+			Status    string    `json:"status"`
+			ItemCount int       `json:"item_count"`
+			CreatedAt time.Time `json:"created_at"`
+			UpdatedAt time.Time `json:"updated_at"`
+		} `json:"data"`
+	}
+	query := adminQuery(cmd)
+	query.Set("limit", strconv.Itoa(limit))
+	if done, err := adminGet(cmd, "/v1/admin/conversations", query, &resp); done || err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "CONVERSATION\tUSER\tPROJECT\tSTATUS\tITEMS\tCREATED\tLAST ACTIVE")
+	for _, conv := range resp.Data {
+		project := conv.ProjectID
+		if project == "" {
+			project = "-"
+		}
+		fmt.Fprintf(w, "%s\t%d\t%s\t%s\t%d\t%s\t%s\n", conv.ID, conv.UserID, project, conv.Status, conv.ItemCount,
+			conv.CreatedAt.Local().Format("2006-01-02 15:04"), conv.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	fmt.Printf("\nShowing %d of %d conversation(s) active since %s\n", len(resp.Data), resp.Total, resp.Since.Local().Format(time.RFC3339))
+	return nil
+}
+
+func runAdminUsage(cmd *cobra.Command, args []string) error {
+	by, _ := cmd.Flags().GetString("by")
+
+	var resp struct {
+		By    string    `json:"by"`
+		Since time.Time `json:"since"`
+		Until time.Time `json:"until"`
+		Data  []struct {
+			Key              string  `json:"key"`
+			Requests         int64   `json:"requests"`
+			FailedRequests   int64   `json:"failed_requests"`
+			PromptTokens     int64   `json:"prompt_tokens"`
+			CompletionTokens int64   `json:"completion_tokens"`
+			AverageLatencyMs float64 `json:"average_latency_ms"`
+		} `json:"data"`
+	}
+	query := adminQuery(cmd)
+	query.Set("by", by)
+	if done, err := adminGet(cmd, "/v1/admin/usage", query, &resp); done || err != nil {
+		return err
+	}
+
+	fmt.Printf("Usage by %s, %s to %s\n\n", resp.By, resp.Since.Local().Format(time.RFC3339), resp.Until.Local().Format(time.RFC3339))
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintf(w, "%s\tREQUESTS\tFAILED\tPROMPT TOKENS\tCOMPLETION TOKENS\tAVG LATENCY\t\n", strings.ToUpper(resp.By))
+	var requests, failed, prompt, completion int64
+	for _, row := range resp.Data {
+		fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%d\t%.0fms\t\n", row.Key, row.Requests, row.FailedRequests,
+			row.PromptTokens, row.CompletionTokens, row.AverageLatencyMs)
+		requests += row.Requests
+		failed += row.FailedRequests
+		prompt += row.PromptTokens
+		completion += row.CompletionTokens
+	}
+	fmt.Fprintf(w, "TOTAL\t%d\t%d\t%d\t%d\t\t\n", requests, failed, prompt, completion)
+	return w.Flush()
+}
+
+// adminQuery builds the query parameters shared by the admin commands
+func adminQuery(cmd *cobra.Command) url.Values {
+	user, _ := cmd.Flags().GetString("user")
+	since, _ := cmd.Flags().GetString("since")
+	query := url.Values{"since": {since}}
+	if user != "" {
+		query.Set("user", user)
+	}
+	return query
+}
+
+// adminGet calls an admin endpoint and decodes the response into out. With --json
+// it also prints the response and reports done.
+func adminGet(cmd *cobra.Command, path string, query url.Values, out any) (bool, error) {
+	return adminRequest(cmd, http.MethodGet, path+"?"+query.Encode(), nil, 30*time.Second, out)
+}
+
+// adminRequest sends payload, if any, as JSON to an admin endpoint and decodes
+// the response into out, also printing it with --json and reporting done
+func adminRequest(cmd *cobra.Command, method, path string, payload any, timeout time.Duration, out any) (bool, error) {
+	baseURL, _ := cmd.Flags().GetString("url")
+	token, _ := cmd.Flags().GetString("token")
+	asJSON, _ := cmd.Flags().GetBool("json")
+	if token == "" {
+		token = os.Getenv("JAN_API_TOKEN")
+	}
+	if token == "" {
+		stored, err := storedLoginToken(cmd.Context(), baseURL)
+		if errors.Is(err, errNoCredential) {
+			return false, fmt.Errorf("an admin access token or API key is required: pass --token, set JAN_API_TOKEN or run jan-cli login")
+		}
+		if err != nil {
+			return false, err
+		}
+		token = stored
+	}
+
+	var reqBody io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return false, err
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(cmd.Context(), method, strings.TrimRight(baseURL, "/")+path, reqBody)
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	path, _, _ = strings.Cut(path, "?")
+
+	client := &http.Client{Timeout: timeout}
+	resp, err := client.Do(req)
+	if err != nil {
+		return false, fmt.Errorf("request %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return false, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		var apiErr struct {
+			Message string `json:"message"`
+			Error   string `json:"error"`
+		}
+		_ = json.Unmarshal(body, &apiErr)
+		msg := apiErr.Message
+		if msg == "" {
+			msg = apiErr.Error
+		}
+		if msg == "" {
+			msg = strings.TrimSpace(string(body))
+		}
+		return false, fmt.Errorf("%s: %s %s", path, resp.Status, msg)
+	}
+
+	if asJSON {
+		if _, err := os.Stdout.Write(body); err != nil {
+			return true, err
+		}
+		fmt.Println()
+		return true, json.Unmarshal(body, out)
+	}
+	return false, json.Unmarshal(body, out)
+}
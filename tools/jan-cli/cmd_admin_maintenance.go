@@ -0,0 +1,122 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var adminMaintenanceCmd = &cobra.Command{
+	Use:   "maintenance",
+	Short: "Switch maintenance mode on or off",
+	Long: `Show or switch maintenance mode, e.g. around database migrations.
+
+While it is on, the services answer requests that change data (POST, PUT,
+PATCH and DELETE) with 503 and the maintenance message; reads, health checks
+and token refreshes keep working. Every replica and service sharing llm-api's
+REDIS_URL applies a switch within MAINTENANCE_REFRESH_INTERVAL.
+
+Examples:
+  jan-cli admin maintenance status
+  jan-cli admin maintenance on --until 30m --message "Upgrading the database"
+  jan-cli admin maintenance off`,
+}
+
+var adminMaintenanceStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show whether maintenance mode is on",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdminMaintenance(cmd, http.MethodGet, nil)
+	},
+}
+
+var adminMaintenanceOnCmd = &cobra.Command{
+	Use:   "on",
+	Short: "Turn maintenance mode on, or update its message and end",
+	Args:  cobra.NoArgs,
+	RunE:  runAdminMaintenanceOn,
+}
+
+var adminMaintenanceOffCmd = &cobra.Command{
+	Use:   "off",
+	Short: "Turn maintenance mode off",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runAdminMaintenance(cmd, http.MethodDelete, nil)
+	},
+}
+
+func init() {
+	adminCmd.AddCommand(adminMaintenanceCmd)
+	adminMaintenanceCmd.AddCommand(adminMaintenanceStatusCmd)
+	adminMaintenanceCmd.AddCommand(adminMaintenanceOnCmd)
+	adminMaintenanceCmd.AddCommand(adminMaintenanceOffCmd)
+
+	adminMaintenanceOnCmd.Flags().String("message", "", "Message shown to clients (default: a generic one)")
+	adminMaintenanceOnCmd.Flags().String("until", "", "Expected end, sent to clients as Retry-After: 30m, 2h or RFC 3339")
+}
+
+func runAdminMaintenanceOn(cmd *cobra.Command, args []string) error {
+	message, _ := cmd.Flags().GetString("message")
+	until, _ := cmd.Flags().GetString("until")
+
+	payload := map[string]any{"message": message}
+	if until != "" {
+		endsAt, err := parseMaintenanceEnd(until, time.Now())
+		if err != nil {
+			return err
+		}
+		payload["ends_at"] = endsAt
+	}
+	return runAdminMaintenance(cmd, http.MethodPut, payload)
+}
+
+func runAdminMaintenance(cmd *cobra.Command, method string, payload any) error {
+	var state struct {
+		Enabled   bool       `json:"enabled"`
+		Message   string     `json:"message"`
+		StartedAt *time.Time `json:"started_at"`
+		StartedBy string     `json:"started_by"`
+		EndsAt    *time.Time `json:"ends_at"`
+	}
+	done, err := adminRequest(cmd, method, "/v1/admin/maintenance", payload, 30*time.Second, &state)
+	if done || err != nil {
+		return err
+	}
+
+	if !state.Enabled {
+		printSuccess("Maintenance mode is off")
+		return nil
+	}
+	printWarning("Maintenance mode is on: requests that change data are rejected")
+	fmt.Printf("Message:  %s\n", state.Message)
+	if state.StartedAt != nil {
+		started := state.StartedAt.Local().Format(time.RFC3339)
+		if state.StartedBy != "" {
+			started += " by " + state.StartedBy
+		}
+		fmt.Printf("Started:  %s\n", started)
+	}
+	if state.EndsAt != nil {
+		fmt.Printf("Ends:     %s (expected)\n", state.EndsAt.Local().Format(time.RFC3339))
+	}
+	return nil
+}
+
+// parseMaintenanceEnd accepts a duration from now or an RFC 3339 time
+func parseMaintenanceEnd(value string, now time.Time) (time.Time, error) {
+	if d, err := time.ParseDuration(value); err == nil {
+		if d <= 0 {
+			return time.Time{}, fmt.Errorf("--until must be in the future")
+		}
+		return now.Add(d).UTC(), nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --until %q: use a duration such as 30m or an RFC 3339 time", value)
+	}
+	return t.UTC(), nil
+}
@@ -18,7 +18,7 @@ import (
 
 var apiTestCmd = &cobra.Command{
 	Use:   "api-test",
-	Short: "Run API tests from Postman collections",
+	Short: "Run API tests from Postman collections or YAML scenarios",
 	Long: `Run API integration tests using Postman collection JSON files.
 
 This is a lightweight cli api test that supports the essential
@@ -30,7 +30,9 @@ Examples:
   jan-cli api-test run tests/automation/auth-postman-scripts.json \
     --env-var "kong_url=http://localhost:8000" \
     --env-var "keycloak_admin=admin" \
-    --verbose`,
+    --verbose
+  jan-cli api-test scenario tests/e2e/scenarios/golden-path.yaml \
+    --auto-auth guest --auto-models --junit reports/api-scenarios.xml`,
 }
 
 var runApiTestCmd = &cobra.Command{
@@ -135,6 +137,23 @@ type TestResult struct {
 }
 
 func runApiTest(cmd *cobra.Command, args []string) error {
+	baseEnv, err := buildBaseEnv()
+	if err != nil {
+		return err
+	}
+
+	for _, collectionFile := range args {
+		envMap := cloneEnvMap(baseEnv)
+		if err := runCollection(collectionFile, envMap); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// buildBaseEnv merges --env-var and --env-file into one variable map.
+func buildBaseEnv() (map[string]string, error) {
 	baseEnv := make(map[string]string)
 	for _, ev := range envVars {
 		parts := strings.SplitN(ev, "=", 2)
@@ -145,18 +164,10 @@ func runApiTest(cmd *cobra.Command, args []string) error {
 
 	if envFile != "" {
 		if err := loadEnvFile(envFile, baseEnv); err != nil {
-			return fmt.Errorf("failed to load env file: %w", err)
+			return nil, fmt.Errorf("failed to load env file: %w", err)
 		}
 	}
-
-	for _, collectionFile := range args {
-		envMap := cloneEnvMap(baseEnv)
-		if err := runCollection(collectionFile, envMap); err != nil {
-			return err
-		}
-	}
-
-	return nil
+	return baseEnv, nil
 }
 
 func runCollection(collectionFile string, envMap map[string]string) error {
@@ -177,6 +188,32 @@ func runCollection(collectionFile string, envMap map[string]string) error {
 
 	processCollectionEvents(collection.Event, envMap)
 
+	bootstrapEnv(envMap)
+
+	results := []TestResult{}
+	totalStart := time.Now()
+
+	for _, item := range collection.Item {
+		itemResults := runItem(item, envMap, "", collection.Auth)
+		results = append(results, itemResults...)
+	}
+
+	totalDuration := time.Since(totalStart)
+
+	printResults(results, totalDuration)
+
+	for _, result := range results {
+		if !result.Passed {
+			return fmt.Errorf("tests failed")
+		}
+	}
+
+	return nil
+}
+
+// bootstrapEnv applies --auto-auth and --auto-models to envMap so collections
+// and scenarios can reference {{access_token}} and {{model_id}}.
+func bootstrapEnv(envMap map[string]string) {
 	if autoAuth != "" {
 		gatewayURL := envMap["gateway_url"]
 		if gatewayURL == "" {
@@ -216,26 +253,6 @@ func runCollection(collectionFile string, envMap map[string]string) error {
 			envMap["default_model_id"] = model
 		}
 	}
-
-	results := []TestResult{}
-	totalStart := time.Now()
-
-	for _, item := range collection.Item {
-		itemResults := runItem(item, envMap, "", collection.Auth)
-		results = append(results, itemResults...)
-	}
-
-	totalDuration := time.Since(totalStart)
-
-	printResults(results, totalDuration)
-
-	for _, result := range results {
-		if !result.Passed {
-			return fmt.Errorf("tests failed")
-		}
-	}
-
-	return nil
 }
 
 func runItem(item PostmanItem, envMap map[string]string, prefix string, parentAuth *PostmanAuth) []TestResult {
@@ -0,0 +1,579 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var scenarioApiTestCmd = &cobra.Command{
+	Use:   "scenario [scenario-file...]",
+	Short: "Run YAML API scenarios",
+	Long: `Run declarative YAML scenarios against any environment.
+
+A scenario is an ordered list of HTTP steps. Each step can assert on the
+status code and on JSON fields, extract values into variables for later
+steps, and retry until its assertions pass (for asynchronous transitions
+such as mcp_call items moving from in_progress to completed). Steps under
+"cleanup" always run, even after a failure.
+
+Examples:
+  jan-cli api-test scenario tests/e2e/scenarios/golden-path.yaml --auto-auth guest --auto-models
+  jan-cli api-test scenario tests/e2e/scenarios/*.yaml \
+    --env-var gateway_url=https://staging.example.com \
+    --junit reports/api-scenarios.xml`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: runApiTestScenarios,
+}
+
+var junitOutput string
+
+var unresolvedVariablePattern = regexp.MustCompile(`\{\{[^}]+\}\}`)
+
+func init() {
+	apiTestCmd.AddCommand(scenarioApiTestCmd)
+
+	scenarioApiTestCmd.Flags().StringArrayVar(&envVars, "env-var", []string{}, "Environment variable (key=value)")
+	scenarioApiTestCmd.Flags().StringVar(&envFile, "env-file", "", "Load environment variables from file")
+	scenarioApiTestCmd.Flags().StringVar(&autoAuth, "auto-auth", "", "Auto-login: 'guest' or 'admin'")
+	scenarioApiTestCmd.Flags().BoolVar(&autoModels, "auto-models", false, "Auto-fetch model IDs before running")
+	scenarioApiTestCmd.Flags().IntVar(&timeout, "timeout-request", 30000, "Request timeout in milliseconds")
+	scenarioApiTestCmd.Flags().BoolVar(&verbose, "verbose", false, "Verbose output")
+	scenarioApiTestCmd.Flags().BoolVar(&bail, "bail", false, "Stop a scenario on its first failed step")
+	scenarioApiTestCmd.Flags().StringVar(&junitOutput, "junit", "", "Write a JUnit XML report to this file")
+}
+
+// Scenario is a named sequence of HTTP steps loaded from YAML.
+type Scenario struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Vars        map[string]string `yaml:"vars"`
+	Headers     map[string]string `yaml:"headers"`
+	Steps       []ScenarioStep    `yaml:"steps"`
+	Cleanup     []ScenarioStep    `yaml:"cleanup"`
+}
+
+// ScenarioStep is one request with its assertions and extractions.
+type ScenarioStep struct {
+	Name    string            `yaml:"name"`
+	Request ScenarioRequest   `yaml:"request"`
+	Expect  ScenarioExpect    `yaml:"expect"`
+	Extract map[string]string `yaml:"extract"`
+	Retry   *ScenarioRetry    `yaml:"retry"`
+}
+
+type ScenarioRequest struct {
+	Method  string            `yaml:"method"`
+	URL     string            `yaml:"url"`
+	Headers map[string]string `yaml:"headers"`
+	Body    interface{}       `yaml:"body"`
+}
+
+type ScenarioExpect struct {
+	Status statusList          `yaml:"status"`
+	JSON   []ScenarioAssertion `yaml:"json"`
+}
+
+// ScenarioAssertion checks the value at Path. Paths are dot separated; use
+// numeric segments for array indexes and [key=value] to select the first
+// array element whose field matches, e.g. data[type=mcp_call].status.
+type ScenarioAssertion struct {
+	Path      string      `yaml:"path"`
+	Equals    interface{} `yaml:"equals"`
+	NotEquals interface{} `yaml:"not_equals"`
+	OneOf     []string    `yaml:"one_of"`
+	Contains  string      `yaml:"contains"`
+	Matches   string      `yaml:"matches"`
+	Exists    *bool       `yaml:"exists"`
+	NotEmpty  bool        `yaml:"not_empty"`
+	MinLength *int        `yaml:"min_length"`
+}
+
+type ScenarioRetry struct {
+	Attempts int           `yaml:"attempts"`
+	Interval time.Duration `yaml:"interval"`
+}
+
+// statusList accepts either a single status code or a list.
+type statusList []int
+
+func (s *statusList) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		var code int
+		if err := node.Decode(&code); err != nil {
+			return err
+		}
+		*s = statusList{code}
+		return nil
+	}
+	var codes []int
+	if err := node.Decode(&codes); err != nil {
+		return err
+	}
+	*s = codes
+	return nil
+}
+
+// scenarioReport collects per-scenario results for the JUnit writer.
+type scenarioReport struct {
+	Name     string
+	File     string
+	Results  []TestResult
+	Duration time.Duration
+}
+
+func runApiTestScenarios(cmd *cobra.Command, args []string) error {
+	baseEnv, err := buildBaseEnv()
+	if err != nil {
+		return err
+	}
+
+	var reports []scenarioReport
+	failed := false
+	for _, file := range args {
+		envMap := cloneEnvMap(baseEnv)
+		report, err := runScenarioFile(file, envMap)
+		if err != nil {
+			return err
+		}
+		reports = append(reports, report)
+		for _, result := range report.Results {
+			if !result.Passed {
+				failed = true
+			}
+		}
+	}
+
+	if junitOutput != "" {
+		if err := writeJUnitReport(junitOutput, reports); err != nil {
+			return err
+		}
+		printSuccess("JUnit report written to %s", junitOutput)
+	}
+
+	if failed {
+		return fmt.Errorf("tests failed")
+	}
+	return nil
+}
+
+func runScenarioFile(file string, envMap map[string]string) (scenarioReport, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return scenarioReport{}, fmt.Errorf("failed to read scenario file: %w", err)
+	}
+
+	var scenario Scenario
+	if err := yaml.Unmarshal(data, &scenario); err != nil {
+		return scenarioReport{}, fmt.Errorf("failed to parse scenario %s: %w", file, err)
+	}
+	if scenario.Name == "" {
+		scenario.Name = strings.TrimSuffix(filepath.Base(file), filepath.Ext(file))
+	}
+
+	fmt.Printf("\n==============================\n")
+	fmt.Printf(" Jan API Scenario Runner\n")
+	fmt.Printf("==============================\n\n")
+	fmt.Printf("Scenario: %s\n", scenario.Name)
+	if scenario.Description != "" {
+		fmt.Printf("  %s\n", strings.TrimSpace(scenario.Description))
+	}
+	fmt.Println()
+
+	if envMap["gateway_url"] == "" {
+		envMap["gateway_url"] = firstNonEmpty(envMap["kong_url"], "http://localhost:8000")
+	}
+	for key, value := range scenario.Vars {
+		if _, ok := envMap[key]; !ok {
+			envMap[key] = replaceVariables(value, envMap)
+		}
+	}
+	bootstrapEnv(envMap)
+
+	client := &http.Client{Timeout: time.Duration(timeout) * time.Millisecond}
+	report := scenarioReport{Name: scenario.Name, File: file}
+	start := time.Now()
+
+	stopped := false
+	for _, step := range scenario.Steps {
+		if stopped {
+			report.Results = append(report.Results, TestResult{Name: step.Name, Error: "skipped after earlier failure"})
+			continue
+		}
+		result := runScenarioStep(client, scenario, step, envMap)
+		report.Results = append(report.Results, result)
+		if !result.Passed && bail {
+			stopped = true
+		}
+	}
+	for _, step := range scenario.Cleanup {
+		report.Results = append(report.Results, runScenarioStep(client, scenario, step, envMap))
+	}
+
+	report.Duration = time.Since(start)
+	printResults(report.Results, report.Duration)
+	return report, nil
+}
+
+func runScenarioStep(client *http.Client, scenario Scenario, step ScenarioStep, envMap map[string]string) TestResult {
+	attempts := 1
+	interval := time.Second
+	if step.Retry != nil {
+		if step.Retry.Attempts > 1 {
+			attempts = step.Retry.Attempts
+		}
+		if step.Retry.Interval > 0 {
+			interval = step.Retry.Interval
+		}
+	}
+
+	start := time.Now()
+	var lastErr error
+	for attempt := 1; attempt <= attempts; attempt++ {
+		if attempt > 1 {
+			time.Sleep(interval)
+		}
+		lastErr = executeScenarioStep(client, scenario, step, envMap)
+		if lastErr == nil {
+			return TestResult{Name: step.Name, Passed: true, Duration: time.Since(start)}
+		}
+		if verbose {
+			fmt.Printf("  attempt %d/%d of %q failed: %v\n", attempt, attempts, step.Name, lastErr)
+		}
+	}
+	return TestResult{Name: step.Name, Duration: time.Since(start), Error: lastErr.Error()}
+}
+
+func executeScenarioStep(client *http.Client, scenario Scenario, step ScenarioStep, envMap map[string]string) error {
+	method := strings.ToUpper(firstNonEmpty(step.Request.Method, http.MethodGet))
+	url := replaceVariables(step.Request.URL, envMap)
+	if unresolved := unresolvedVariablePattern.FindString(url); unresolved != "" {
+		return fmt.Errorf("unresolved variable %s in url", unresolved)
+	}
+
+	var body io.Reader
+	if step.Request.Body != nil {
+		raw, err := json.Marshal(step.Request.Body)
+		if err != nil {
+			return fmt.Errorf("encode body: %w", err)
+		}
+		body = strings.NewReader(replaceVariables(string(raw), envMap))
+	}
+
+	req, err := http.NewRequest(method, url, body)
+	if err != nil {
+		return err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for key, value := range scenario.Headers {
+		req.Header.Set(key, replaceVariables(value, envMap))
+	}
+	for key, value := range step.Request.Headers {
+		req.Header.Set(key, replaceVariables(value, envMap))
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("read response: %w", err)
+	}
+	if debug || verbose {
+		fmt.Printf("  %s %s -> %d\n", method, url, resp.StatusCode)
+	}
+
+	expected := step.Expect.Status
+	if len(expected) == 0 {
+		expected = statusList{http.StatusOK, http.StatusCreated, http.StatusNoContent}
+	}
+	if !intSliceContains(expected, resp.StatusCode) {
+		return fmt.Errorf("expected status %v, got %d: %s", []int(expected), resp.StatusCode, truncateForReport(respBody))
+	}
+
+	if len(step.Expect.JSON) == 0 && len(step.Extract) == 0 {
+		return nil
+	}
+
+	var payload interface{}
+	if err := json.Unmarshal(respBody, &payload); err != nil {
+		return fmt.Errorf("response is not JSON: %s", truncateForReport(respBody))
+	}
+
+	for _, assertion := range step.Expect.JSON {
+		if err := checkAssertion(payload, assertion, envMap); err != nil {
+			return err
+		}
+	}
+
+	for name, path := range step.Extract {
+		value, ok := lookupJSONPath(payload, replaceVariables(path, envMap))
+		if !ok {
+			return fmt.Errorf("extract %s: path %q not found", name, path)
+		}
+		envMap[name] = stringifyJSON(value)
+	}
+	return nil
+}
+
+func checkAssertion(payload interface{}, a ScenarioAssertion, envMap map[string]string) error {
+	path := replaceVariables(a.Path, envMap)
+	value, found := lookupJSONPath(payload, path)
+
+	if a.Exists != nil {
+		if found != *a.Exists {
+			return fmt.Errorf("%s: exists=%t, want %t", path, found, *a.Exists)
+		}
+		if !found {
+			return nil
+		}
+	}
+	if !found {
+		return fmt.Errorf("%s: not found", path)
+	}
+
+	actual := stringifyJSON(value)
+	if a.Equals != nil {
+		want := replaceVariables(stringifyJSON(a.Equals), envMap)
+		if actual != want {
+			return fmt.Errorf("%s: got %q, want %q", path, actual, want)
+		}
+	}
+	if a.NotEquals != nil {
+		unwanted := replaceVariables(stringifyJSON(a.NotEquals), envMap)
+		if actual == unwanted {
+			return fmt.Errorf("%s: got %q, want anything else", path, actual)
+		}
+	}
+	if len(a.OneOf) > 0 {
+		matched := false
+		for _, option := range a.OneOf {
+			if actual == replaceVariables(option, envMap) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return fmt.Errorf("%s: got %q, want one of %v", path, actual, a.OneOf)
+		}
+	}
+	if a.Contains != "" {
+		want := replaceVariables(a.Contains, envMap)
+		if !strings.Contains(actual, want) {
+			return fmt.Errorf("%s: %q does not contain %q", path, truncateForReport([]byte(actual)), want)
+		}
+	}
+	if a.Matches != "" {
+		re, err := regexp.Compile(replaceVariables(a.Matches, envMap))
+		if err != nil {
+			return fmt.Errorf("%s: invalid pattern: %w", path, err)
+		}
+		if !re.MatchString(actual) {
+			return fmt.Errorf("%s: %q does not match %s", path, actual, a.Matches)
+		}
+	}
+	if a.NotEmpty && jsonLength(value) == 0 {
+		return fmt.Errorf("%s: is empty", path)
+	}
+	if a.MinLength != nil && jsonLength(value) < *a.MinLength {
+		return fmt.Errorf("%s: length %d, want at least %d", path, jsonLength(value), *a.MinLength)
+	}
+	return nil
+}
+
+// lookupJSONPath resolves a dotted path such as choices.0.message.tool_calls
+// or data[type=mcp_call].status against decoded JSON.
+func lookupJSONPath(data interface{}, path string) (interface{}, bool) {
+	current := data
+	if path == "" || path == "." {
+		return current, true
+	}
+
+	for _, segment := range strings.Split(path, ".") {
+		name, filter := segment, ""
+		if i := strings.Index(segment, "["); i >= 0 && strings.HasSuffix(segment, "]") {
+			name, filter = segment[:i], segment[i+1:len(segment)-1]
+		}
+
+		if name != "" {
+			next, ok := stepJSON(current, name)
+			if !ok {
+				return nil, false
+			}
+			current = next
+		}
+		if filter == "" {
+			continue
+		}
+
+		items, ok := current.([]interface{})
+		if !ok {
+			return nil, false
+		}
+		key, want, isMatch := strings.Cut(filter, "=")
+		if !isMatch {
+			next, ok := stepJSON(items, filter)
+			if !ok {
+				return nil, false
+			}
+			current = next
+			continue
+		}
+		found := false
+		for _, item := range items {
+			if field, ok := stepJSON(item, key); ok && stringifyJSON(field) == want {
+				current, found = item, true
+				break
+			}
+		}
+		if !found {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func stepJSON(current interface{}, key string) (interface{}, bool) {
+	switch node := current.(type) {
+	case map[string]interface{}:
+		value, ok := node[key]
+		return value, ok
+	case []interface{}:
+		idx, err := strconv.Atoi(key)
+		if err != nil || idx < 0 || idx >= len(node) {
+			return nil, false
+		}
+		return node[idx], true
+	default:
+		return nil, false
+	}
+}
+
+func stringifyJSON(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return "null"
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case int:
+		return strconv.Itoa(v)
+	default:
+		data, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(data)
+	}
+}
+
+func jsonLength(value interface{}) int {
+	switch v := value.(type) {
+	case nil:
+		return 0
+	case string:
+		return len(v)
+	case []interface{}:
+		return len(v)
+	case map[string]interface{}:
+		return len(v)
+	default:
+		return 1
+	}
+}
+
+func truncateForReport(body []byte) string {
+	const limit = 300
+	text := strings.TrimSpace(string(body))
+	if len(text) > limit {
+		return text[:limit] + "..."
+	}
+	return text
+}
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:"testsuites"`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name     string          `xml:"name,attr"`
+	Tests    int             `xml:"tests,attr"`
+	Failures int             `xml:"failures,attr"`
+	Time     string          `xml:"time,attr"`
+	Cases    []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	Classname string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Text    string `xml:",chardata"`
+}
+
+func writeJUnitReport(path string, reports []scenarioReport) error {
+	suites := junitTestSuites{}
+	for _, report := range reports {
+		suite := junitTestSuite{
+			Name: report.Name,
+			Time: fmt.Sprintf("%.3f", report.Duration.Seconds()),
+		}
+		for _, result := range report.Results {
+			tc := junitTestCase{
+				Name:      result.Name,
+				Classname: report.Name,
+				Time:      fmt.Sprintf("%.3f", result.Duration.Seconds()),
+			}
+			if !result.Passed {
+				suite.Failures++
+				tc.Failure = &junitFailure{Message: result.Error, Text: report.File}
+			}
+			suite.Cases = append(suite.Cases, tc)
+		}
+		suite.Tests = len(suite.Cases)
+		suites.Suites = append(suites.Suites, suite)
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return err
+	}
+	if dir := filepath.Dir(path); dir != "." {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return fmt.Errorf("create report directory: %w", err)
+		}
+	}
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	buf.Write(data)
+	buf.WriteByte('\n')
+	if err := os.WriteFile(path, buf.Bytes(), 0644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
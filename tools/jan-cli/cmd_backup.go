@@ -0,0 +1,598 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up and restore a Docker Compose deployment",
+	Long: `Create and restore disaster-recovery archives of a Docker Compose deployment.
+
+An archive holds a pg_dump of the shared API database (every service schema)
+and of the Keycloak database, a manifest of stored media objects, and the
+environment and provider configuration. It is encrypted with a passphrase
+read from $JAN_BACKUP_PASSPHRASE or --passphrase-file.
+
+Media objects themselves are not copied: back up the S3 bucket (or the local
+media directory) with your storage tooling; the manifest lists what a restore
+expects to find there.`,
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create",
+	Short: "Create a backup archive",
+	Long: `Dump the databases, media manifest and configuration into one archive.
+
+Examples:
+  JAN_BACKUP_PASSPHRASE=... jan-cli backup create
+  jan-cli backup create --output /mnt/backups --passphrase-file /run/secrets/backup`,
+	RunE: runBackupCreate,
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <archive>",
+	Short: "Restore a backup archive",
+	Long: `Restore the databases (and optionally the configuration) from an archive.
+
+Application services are stopped while the databases are restored and started
+again afterwards. Existing data in the restored databases is replaced.
+
+Examples:
+  jan-cli backup restore backups/jan-backup-20250101T020000Z.tar.gz.enc
+  jan-cli backup restore backup.tar.gz.enc --restore-config --yes`,
+	Args: cobra.ExactArgs(1),
+	RunE: runBackupRestore,
+}
+
+var backupInspectCmd = &cobra.Command{
+	Use:   "inspect <archive>",
+	Short: "Verify an archive and print its manifest",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runBackupInspect,
+}
+
+func init() {
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCmd.AddCommand(backupRestoreCmd)
+	backupCmd.AddCommand(backupInspectCmd)
+
+	for _, c := range []*cobra.Command{backupCreateCmd, backupRestoreCmd, backupInspectCmd} {
+		c.Flags().String("passphrase-file", "", "File holding the archive passphrase (default: $JAN_BACKUP_PASSPHRASE)")
+	}
+	for _, c := range []*cobra.Command{backupCreateCmd, backupRestoreCmd} {
+		c.Flags().String("env-file", ".env", "Environment file of the deployment")
+		c.Flags().Bool("skip-keycloak", false, "Leave the Keycloak database out")
+	}
+
+	backupCreateCmd.Flags().StringP("output", "o", "backups", "Directory to write the archive to")
+	backupCreateCmd.Flags().Bool("no-encrypt", false, "Write an unencrypted archive")
+
+	backupRestoreCmd.Flags().Bool("restore-config", false, "Also restore .env and provider configs (current files are kept as *.pre-restore)")
+	backupRestoreCmd.Flags().BoolP("yes", "y", false, "Do not ask for confirmation")
+}
+
+const backupFormatVersion = 1
+
+// backupManifest describes an archive; it is stored as manifest.json
+type backupManifest struct {
+	FormatVersion int               `json:"format_version"`
+	CreatedAt     time.Time         `json:"created_at"`
+	CLIVersion    string            `json:"cli_version"`
+	GitCommit     string            `json:"git_commit,omitempty"`
+	Databases     []backupDatabase  `json:"databases"`
+	Media         *backupMedia      `json:"media,omitempty"`
+	Config        []string          `json:"config"`
+	Files         map[string]string `json:"files"` // Archive path to SHA-256, manifest excluded
+}
+
+// backupDatabase records a database dump and the point in time it was taken
+type backupDatabase struct {
+	Service       string            `json:"service"` // Compose service running the database
+	Database      string            `json:"database"`
+	User          string            `json:"user"`
+	ServerVersion string            `json:"server_version"`
+	SnapshotAt    time.Time         `json:"snapshot_at"`          // Database clock when the dump started
+	WALLSN        string            `json:"wal_lsn"`              // WAL position when the dump started, for point-in-time recovery from WAL archives
+	Migrations    map[string]string `json:"migrations,omitempty"` // Schema to applied migration version
+	File          string            `json:"file"`
+}
+
+// backupMedia summarises the media object manifest
+type backupMedia struct {
+	Objects int64  `json:"objects"`
+	Bytes   int64  `json:"bytes"`
+	File    string `json:"file"`
+}
+
+// backupConfigFiles are the configuration files copied into an archive,
+// relative to the project root
+var backupConfigFiles = []string{"services/llm-api/configs/providers.yml"}
+
+// appServices are stopped during a restore so nothing writes to the databases
+var appServices = []string{"llm-api", "response-api", "media-api", "mcp-tools", "memory-tools", "realtime-api"}
+
+func runBackupCreate(cmd *cobra.Command, args []string) error {
+	envPath, _ := cmd.Flags().GetString("env-file")
+	outputDir, _ := cmd.Flags().GetString("output")
+	noEncrypt, _ := cmd.Flags().GetBool("no-encrypt")
+	skipKeycloak, _ := cmd.Flags().GetBool("skip-keycloak")
+
+	passphrase := ""
+	if !noEncrypt {
+		var err error
+		if passphrase, err = backupPassphrase(cmd); err != nil {
+			return err
+		}
+	}
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	env, err := readEnvValues(envPath)
+	if err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp("", "jan-backup-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	manifest := backupManifest{
+		FormatVersion: backupFormatVersion,
+		CreatedAt:     time.Now().UTC(),
+		CLIVersion:    version,
+		GitCommit:     gitCommit(root),
+		Files:         make(map[string]string),
+	}
+
+	// Databases
+	targets := []backupDatabase{apiDatabase(env)}
+	if !skipKeycloak {
+		targets = append(targets, keycloakDatabase())
+	}
+	for _, db := range targets {
+		printInfo("Dumping %s (%s)...", db.Database, db.Service)
+		if err := describeDatabase(root, &db); err != nil {
+			return fmt.Errorf("inspect %s: %w", db.Database, err)
+		}
+		db.File = "postgres/" + db.Database + ".dump"
+		err := stageFile(staging, db.File, func(w io.Writer) error {
+			return composeExec(root, db.Service, nil, w, "pg_dump", "-U", db.User, "-d", db.Database, "--format=custom")
+		})
+		if err != nil {
+			return fmt.Errorf("dump %s: %w", db.Database, err)
+		}
+		manifest.Databases = append(manifest.Databases, db)
+		printSuccess("Dumped %s at WAL %s", db.Database, db.WALLSN)
+	}
+
+	// Media object manifest
+	if media, err := stageMediaManifest(root, staging, targets[0]); err != nil {
+		printWarning("Skipping media manifest: %v", err)
+	} else {
+		manifest.Media = media
+		printSuccess("Listed %d media object(s)", media.Objects)
+	}
+
+	// Configuration
+	configFiles := map[string]string{"config/.env": envPath}
+	for _, rel := range backupConfigFiles {
+		configFiles["config/"+rel] = filepath.Join(root, filepath.FromSlash(rel))
+	}
+	for name, source := range configFiles {
+		data, err := os.ReadFile(source)
+		if err != nil {
+			printWarning("Skipping %s: %v", source, err)
+			continue
+		}
+		if err := stageFile(staging, name, func(w io.Writer) error {
+			_, err := w.Write(data)
+			return err
+		}); err != nil {
+			return err
+		}
+		manifest.Config = append(manifest.Config, name)
+	}
+	sort.Strings(manifest.Config)
+
+	// Checksums, then the manifest itself
+	var files []string
+	for _, db := range manifest.Databases {
+		files = append(files, db.File)
+	}
+	if manifest.Media != nil {
+		files = append(files, manifest.Media.File)
+	}
+	files = append(files, manifest.Config...)
+	for _, name := range files {
+		sum, err := fileSHA256(filepath.Join(staging, filepath.FromSlash(name)))
+		if err != nil {
+			return err
+		}
+		manifest.Files[name] = sum
+	}
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(staging, "manifest.json"), manifestJSON, 0600); err != nil {
+		return err
+	}
+	files = append([]string{"manifest.json"}, files...)
+
+	if err := os.MkdirAll(outputDir, 0700); err != nil {
+		return fmt.Errorf("create %s: %w", outputDir, err)
+	}
+	name := "jan-backup-" + manifest.CreatedAt.Format("20060102T150405Z") + ".tar.gz"
+	if passphrase != "" {
+		name += ".enc"
+	}
+	archivePath := filepath.Join(outputDir, name)
+	if err := writeBackupArchive(archivePath, staging, files, passphrase); err != nil {
+		os.Remove(archivePath)
+		return fmt.Errorf("write archive: %w", err)
+	}
+
+	fmt.Println()
+	printSuccess("Backup written to %s", archivePath)
+	if passphrase == "" {
+		printWarning("The archive is not encrypted and contains secrets from %s", envPath)
+	}
+	return nil
+}
+
+func runBackupRestore(cmd *cobra.Command, args []string) error {
+	envPath, _ := cmd.Flags().GetString("env-file")
+	skipKeycloak, _ := cmd.Flags().GetBool("skip-keycloak")
+	restoreConfig, _ := cmd.Flags().GetBool("restore-config")
+	yes, _ := cmd.Flags().GetBool("yes")
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	staging, err := os.MkdirTemp("", "jan-restore-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	manifest, err := openBackup(cmd, args[0], staging)
+	if err != nil {
+		return err
+	}
+	printManifest(manifest)
+
+	if !yes {
+		fmt.Println()
+		fmt.Print("This replaces the current databases. Continue? (y/N): ")
+		response, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+		response = strings.TrimSpace(strings.ToLower(response))
+		if response != "y" && response != "yes" {
+			return fmt.Errorf("restore cancelled")
+		}
+	}
+
+	// The dump's user and database names may differ from this deployment's
+	env, err := readEnvValues(envPath)
+	if err != nil {
+		return err
+	}
+	current := map[string]backupDatabase{"api-db": apiDatabase(env), "keycloak-db": keycloakDatabase()}
+
+	printInfo("Stopping application services...")
+	for _, service := range appServices {
+		// Services outside the active profiles are not running; ignore them
+		_ = composeCommand(root, nil, io.Discard, "stop", service)
+	}
+
+	restoreErr := func() error {
+		for _, db := range manifest.Databases {
+			if skipKeycloak && db.Service == "keycloak-db" {
+				continue
+			}
+			target, ok := current[db.Service]
+			if !ok {
+				return fmt.Errorf("archive has a database for unknown service %s", db.Service)
+			}
+			printInfo("Restoring %s into %s...", db.Database, target.Database)
+			dump, err := os.Open(filepath.Join(staging, filepath.FromSlash(db.File)))
+			if err != nil {
+				return err
+			}
+			err = composeExec(root, target.Service, dump, os.Stdout,
+				"pg_restore", "-U", target.User, "-d", target.Database, "--clean", "--if-exists", "--no-owner", "--single-transaction")
+			dump.Close()
+			if err != nil {
+				return fmt.Errorf("restore %s: %w", db.Database, err)
+			}
+			printSuccess("Restored %s", target.Database)
+		}
+		return nil
+	}()
+
+	printInfo("Starting application services...")
+	for _, service := range appServices {
+		_ = composeCommand(root, nil, io.Discard, "start", service)
+	}
+	if restoreErr != nil {
+		return restoreErr
+	}
+
+	if restoreConfig {
+		suffix := ".pre-restore-" + time.Now().UTC().Format("20060102T150405Z")
+		for _, name := range manifest.Config {
+			target := envPath
+			if rel := strings.TrimPrefix(name, "config/"); rel != ".env" {
+				target = filepath.Join(root, filepath.FromSlash(rel))
+			}
+			if _, err := os.Stat(target); err == nil {
+				if err := os.Rename(target, target+suffix); err != nil {
+					return fmt.Errorf("keep current %s: %w", target, err)
+				}
+			}
+			if err := copyFile(filepath.Join(staging, filepath.FromSlash(name)), target); err != nil {
+				return fmt.Errorf("restore %s: %w", target, err)
+			}
+			if err := os.Chmod(target, 0600); err != nil {
+				return err
+			}
+			printSuccess("Restored %s (previous file kept as %s)", target, filepath.Base(target+suffix))
+		}
+		printInfo("Restart the services to apply the restored configuration: make up-full")
+	}
+
+	if manifest.Media != nil {
+		printInfo("Media objects are not part of the archive; make sure the %d object(s) in %s are present in storage", manifest.Media.Objects, manifest.Media.File)
+	}
+	fmt.Println()
+	printSuccess("Restore complete")
+	return nil
+}
+
+func runBackupInspect(cmd *cobra.Command, args []string) error {
+	staging, err := os.MkdirTemp("", "jan-inspect-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(staging)
+
+	manifest, err := openBackup(cmd, args[0], staging)
+	if err != nil {
+		return err
+	}
+	printManifest(manifest)
+	fmt.Println()
+	printSuccess("All %d file checksums match", len(manifest.Files))
+	return nil
+}
+
+// openBackup extracts an archive into dir and verifies it against its manifest
+func openBackup(cmd *cobra.Command, path, dir string) (*backupManifest, error) {
+	names, err := extractBackupArchive(path, dir, func() (string, error) { return backupPassphrase(cmd) })
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, "manifest.json"))
+	if err != nil {
+		return nil, fmt.Errorf("archive has no manifest: %w", err)
+	}
+	var manifest backupManifest
+	if err := json.Unmarshal(data, &manifest); err != nil {
+		return nil, fmt.Errorf("parse manifest: %w", err)
+	}
+	if manifest.FormatVersion != backupFormatVersion {
+		return nil, fmt.Errorf("unsupported backup format version %d", manifest.FormatVersion)
+	}
+
+	extracted := make(map[string]bool, len(names))
+	for _, name := range names {
+		extracted[name] = true
+	}
+	for name, want := range manifest.Files {
+		if !extracted[name] {
+			return nil, fmt.Errorf("archive is missing %s", name)
+		}
+		got, err := fileSHA256(filepath.Join(dir, filepath.FromSlash(name)))
+		if err != nil {
+			return nil, err
+		}
+		if got != want {
+			return nil, fmt.Errorf("checksum mismatch for %s", name)
+		}
+	}
+	return &manifest, nil
+}
+
+func printManifest(m *backupManifest) {
+	fmt.Println("=== Backup ===")
+	fmt.Printf("Created:    %s (jan-cli %s", m.CreatedAt.Format(time.RFC3339), m.CLIVersion)
+	if m.GitCommit != "" {
+		fmt.Printf(", commit %.12s", m.GitCommit)
+	}
+	fmt.Println(")")
+	for _, db := range m.Databases {
+		fmt.Printf("Database:   %s on %s, PostgreSQL %s, snapshot %s, WAL %s\n",
+			db.Database, db.Service, db.ServerVersion, db.SnapshotAt.Format(time.RFC3339), db.WALLSN)
+		schemas := make([]string, 0, len(db.Migrations))
+		for schema := range db.Migrations {
+			schemas = append(schemas, schema)
+		}
+		sort.Strings(schemas)
+		for _, schema := range schemas {
+			fmt.Printf("            %s at migration %s\n", schema, db.Migrations[schema])
+		}
+	}
+	if m.Media != nil {
+		fmt.Printf("Media:      %d object(s), %d bytes\n", m.Media.Objects, m.Media.Bytes)
+	}
+	if len(m.Config) > 0 {
+		fmt.Printf("Config:     %s\n", strings.Join(m.Config, ", "))
+	}
+}
+
+// backupPassphrase reads the passphrase from --passphrase-file or $JAN_BACKUP_PASSPHRASE
+func backupPassphrase(cmd *cobra.Command) (string, error) {
+	if path, _ := cmd.Flags().GetString("passphrase-file"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return "", fmt.Errorf("read passphrase file: %w", err)
+		}
+		if passphrase := strings.TrimSpace(string(data)); passphrase != "" {
+			return passphrase, nil
+		}
+		return "", fmt.Errorf("passphrase file %s is empty", path)
+	}
+	if passphrase := os.Getenv("JAN_BACKUP_PASSPHRASE"); passphrase != "" {
+		return passphrase, nil
+	}
+	return "", fmt.Errorf("set JAN_BACKUP_PASSPHRASE or pass --passphrase-file (or --no-encrypt when creating)")
+}
+
+// apiDatabase is the shared database holding every service schema
+func apiDatabase(env map[string]string) backupDatabase {
+	user, database := env["POSTGRES_USER"], env["POSTGRES_DB"]
+	if user == "" {
+		user = "jan_user"
+	}
+	if database == "" {
+		database = "jan_llm_api"
+	}
+	return backupDatabase{Service: "api-db", Database: database, User: user}
+}
+
+func keycloakDatabase() backupDatabase {
+	return backupDatabase{Service: "keycloak-db", Database: "keycloak", User: "keycloak"}
+}
+
+// describeDatabase records the server version, clock, WAL position and
+// migration versions right before a dump
+func describeDatabase(root string, db *backupDatabase) error {
+	out, err := psqlQuery(root, *db, "SELECT current_setting('server_version'), to_char(now() AT TIME ZONE 'UTC', 'YYYY-MM-DD\"T\"HH24:MI:SS\"Z\"'), pg_current_wal_lsn()")
+	if err != nil {
+		return err
+	}
+	fields := strings.Split(out, "|")
+	if len(fields) != 3 {
+		return fmt.Errorf("unexpected psql output %q", out)
+	}
+	db.ServerVersion = fields[0]
+	db.SnapshotAt, _ = time.Parse(time.RFC3339, fields[1])
+	db.WALLSN = fields[2]
+
+	schemas, err := psqlQuery(root, *db, "SELECT table_schema FROM information_schema.tables WHERE table_name = 'schema_migrations' ORDER BY 1")
+	if err != nil {
+		return err
+	}
+	for _, schema := range strings.Fields(schemas) {
+		version, err := psqlQuery(root, *db, fmt.Sprintf("SELECT version FROM %q.schema_migrations LIMIT 1", schema))
+		if err != nil {
+			return err
+		}
+		if db.Migrations == nil {
+			db.Migrations = make(map[string]string)
+		}
+		db.Migrations[schema] = version
+	}
+	return nil
+}
+
+// stageMediaManifest writes a CSV of every stored media object
+func stageMediaManifest(root, staging string, db backupDatabase) (*backupMedia, error) {
+	summary, err := psqlQuery(root, db, "SELECT count(*), coalesce(sum(bytes), 0) FROM media_api.media_objects")
+	if err != nil {
+		return nil, err
+	}
+	fields := strings.Split(summary, "|")
+	if len(fields) != 2 {
+		return nil, fmt.Errorf("unexpected psql output %q", summary)
+	}
+	media := &backupMedia{File: "media/objects.csv"}
+	media.Objects, _ = strconv.ParseInt(fields[0], 10, 64)
+	media.Bytes, _ = strconv.ParseInt(fields[1], 10, 64)
+
+	query := "COPY (SELECT id, storage_provider, storage_key, mime_type, bytes, sha256, created_by, created_at " +
+		"FROM media_api.media_objects ORDER BY created_at, id) TO STDOUT WITH CSV HEADER"
+	err = stageFile(staging, media.File, func(w io.Writer) error {
+		return composeExec(root, db.Service, nil, w, "psql", "-U", db.User, "-d", db.Database, "-v", "ON_ERROR_STOP=1", "-c", query)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return media, nil
+}
+
+// stageFile creates name under the staging directory and fills it with write
+func stageFile(staging, name string, write func(io.Writer) error) error {
+	path := filepath.Join(staging, filepath.FromSlash(name))
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if err := write(f); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// psqlQuery runs a query in a database container and returns its unaligned output
+func psqlQuery(root string, db backupDatabase, query string) (string, error) {
+	var out bytes.Buffer
+	err := composeExec(root, db.Service, nil, &out, "psql", "-U", db.User, "-d", db.Database, "-v", "ON_ERROR_STOP=1", "-At", "-c", query)
+	return strings.TrimSpace(out.String()), err
+}
+
+// composeExec runs a command inside a running Compose service
+func composeExec(root, service string, stdin io.Reader, stdout io.Writer, args ...string) error {
+	return composeCommand(root, stdin, stdout, append([]string{"exec", "-T", service}, args...)...)
+}
+
+// composeCommand runs docker compose from the project root; stderr is kept
+// for the error message
+func composeCommand(root string, stdin io.Reader, stdout io.Writer, args ...string) error {
+	cmd := exec.Command("docker", append([]string{"compose"}, args...)...)
+	cmd.Dir = root
+	cmd.Stdin = stdin
+	cmd.Stdout = stdout
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+// gitCommit returns the checked-out commit of the project, if it is a git checkout
+func gitCommit(root string) string {
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = root
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
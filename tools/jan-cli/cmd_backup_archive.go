@@ -0,0 +1,331 @@
+package main
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Encrypted backups are a stream of AES-256-GCM chunks, so archives larger
+// than memory can be sealed and opened:
+//
+//	magic | salt (16) | PBKDF2 iterations (uint32) | nonce prefix (8)
+//	then per chunk: last flag (1) | length (uint32) | sealed chunk
+//
+// Each chunk's nonce is the prefix followed by the chunk counter, and the last
+// flag is authenticated, so reordered, dropped or truncated chunks fail to open.
+const (
+	backupMagic      = "JANBAK01"
+	backupChunkSize  = 64 * 1024
+	backupIterations = 600000
+)
+
+// deriveBackupKey turns a passphrase into an AES-256 key
+func deriveBackupKey(passphrase string, salt []byte, iterations int) (cipher.AEAD, error) {
+	key, err := pbkdf2.Key(sha256.New, passphrase, salt, iterations, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// encryptingWriter seals everything written to it in chunks
+type encryptingWriter struct {
+	w       io.Writer
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+}
+
+func newEncryptingWriter(w io.Writer, passphrase string) (*encryptingWriter, error) {
+	salt := make([]byte, 16)
+	prefix := make([]byte, 8)
+	if _, err := rand.Read(salt); err != nil {
+		return nil, err
+	}
+	if _, err := rand.Read(prefix); err != nil {
+		return nil, err
+	}
+	aead, err := deriveBackupKey(passphrase, salt, backupIterations)
+	if err != nil {
+		return nil, err
+	}
+
+	header := make([]byte, 0, len(backupMagic)+16+4+8)
+	header = append(header, backupMagic...)
+	header = append(header, salt...)
+	header = binary.BigEndian.AppendUint32(header, backupIterations)
+	header = append(header, prefix...)
+	if _, err := w.Write(header); err != nil {
+		return nil, err
+	}
+	return &encryptingWriter{w: w, aead: aead, prefix: prefix}, nil
+}
+
+func (e *encryptingWriter) Write(p []byte) (int, error) {
+	e.buf = append(e.buf, p...)
+	// Keep the last full chunk buffered: only Close knows which chunk is last
+	for len(e.buf) > backupChunkSize {
+		if err := e.seal(e.buf[:backupChunkSize], false); err != nil {
+			return 0, err
+		}
+		e.buf = e.buf[backupChunkSize:]
+	}
+	return len(p), nil
+}
+
+// Close seals the final chunk; it does not close the underlying writer
+func (e *encryptingWriter) Close() error {
+	return e.seal(e.buf, true)
+}
+
+func (e *encryptingWriter) seal(chunk []byte, last bool) error {
+	flag := []byte{0}
+	if last {
+		flag[0] = 1
+	}
+	sealed := e.aead.Seal(nil, e.nonce(), chunk, flag)
+	e.counter++
+
+	frame := make([]byte, 0, 5+len(sealed))
+	frame = append(frame, flag...)
+	frame = binary.BigEndian.AppendUint32(frame, uint32(len(sealed)))
+	frame = append(frame, sealed...)
+	_, err := e.w.Write(frame)
+	return err
+}
+
+func (e *encryptingWriter) nonce() []byte {
+	return binary.BigEndian.AppendUint32(append([]byte(nil), e.prefix...), e.counter)
+}
+
+// decryptingReader opens a stream written by encryptingWriter
+type decryptingReader struct {
+	r       *bufio.Reader
+	aead    cipher.AEAD
+	prefix  []byte
+	counter uint32
+	buf     []byte
+	done    bool
+}
+
+func newDecryptingReader(r *bufio.Reader, passphrase string) (*decryptingReader, error) {
+	header := make([]byte, len(backupMagic)+16+4+8)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("read backup header: %w", err)
+	}
+	if string(header[:len(backupMagic)]) != backupMagic {
+		return nil, errors.New("not an encrypted jan-cli backup")
+	}
+	salt := header[len(backupMagic) : len(backupMagic)+16]
+	iterations := binary.BigEndian.Uint32(header[len(backupMagic)+16:])
+	prefix := header[len(backupMagic)+20:]
+
+	aead, err := deriveBackupKey(passphrase, salt, int(iterations))
+	if err != nil {
+		return nil, err
+	}
+	return &decryptingReader{r: r, aead: aead, prefix: prefix}, nil
+}
+
+func (d *decryptingReader) Read(p []byte) (int, error) {
+	for len(d.buf) == 0 {
+		if d.done {
+			return 0, io.EOF
+		}
+		if err := d.next(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, d.buf)
+	d.buf = d.buf[n:]
+	return n, nil
+}
+
+func (d *decryptingReader) next() error {
+	frame := make([]byte, 5)
+	if _, err := io.ReadFull(d.r, frame); err != nil {
+		if errors.Is(err, io.EOF) {
+			return errors.New("backup is truncated")
+		}
+		return err
+	}
+	size := binary.BigEndian.Uint32(frame[1:])
+	if size > backupChunkSize+uint32(d.aead.Overhead()) {
+		return errors.New("backup is corrupt: oversized chunk")
+	}
+	sealed := make([]byte, size)
+	if _, err := io.ReadFull(d.r, sealed); err != nil {
+		return fmt.Errorf("backup is truncated: %w", err)
+	}
+
+	nonce := binary.BigEndian.AppendUint32(append([]byte(nil), d.prefix...), d.counter)
+	plain, err := d.aead.Open(nil, nonce, sealed, frame[:1])
+	if err != nil {
+		return errors.New("cannot decrypt backup: wrong passphrase or corrupt file")
+	}
+	d.counter++
+	d.buf = plain
+	if frame[0] == 1 {
+		d.done = true
+		if _, err := d.r.Peek(1); err != io.EOF {
+			return errors.New("backup is corrupt: data after the final chunk")
+		}
+	}
+	return nil
+}
+
+// writeBackupArchive packs the staged files into a gzipped tar at path,
+// encrypted when passphrase is set
+func writeBackupArchive(path, stagingDir string, files []string, passphrase string) error {
+	out, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("create archive: %w", err)
+	}
+	defer out.Close()
+
+	var sink io.Writer = out
+	var enc *encryptingWriter
+	if passphrase != "" {
+		enc, err = newEncryptingWriter(out, passphrase)
+		if err != nil {
+			return fmt.Errorf("start encryption: %w", err)
+		}
+		sink = enc
+	}
+	gz := gzip.NewWriter(sink)
+	tw := tar.NewWriter(gz)
+
+	for _, name := range files {
+		if err := addFileToTar(tw, stagingDir, name); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	if err := gz.Close(); err != nil {
+		return err
+	}
+	if enc != nil {
+		if err := enc.Close(); err != nil {
+			return err
+		}
+	}
+	return out.Close()
+}
+
+func addFileToTar(tw *tar.Writer, stagingDir, name string) error {
+	f, err := os.Open(filepath.Join(stagingDir, filepath.FromSlash(name)))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	header := &tar.Header{Name: name, Mode: 0600, Size: info.Size(), ModTime: info.ModTime()}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err = io.Copy(tw, f)
+	return err
+}
+
+// extractBackupArchive unpacks an archive into dir, decrypting it when it is
+// encrypted, and returns the names of the extracted files
+func extractBackupArchive(path, dir string, passphrase func() (string, error)) ([]string, error) {
+	in, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("open archive: %w", err)
+	}
+	defer in.Close()
+
+	reader := bufio.NewReader(in)
+	var source io.Reader = reader
+	magic, _ := reader.Peek(len(backupMagic))
+	if bytes.Equal(magic, []byte(backupMagic)) {
+		secret, err := passphrase()
+		if err != nil {
+			return nil, err
+		}
+		source, err = newDecryptingReader(reader, secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	gz, err := gzip.NewReader(source)
+	if err != nil {
+		return nil, fmt.Errorf("read archive: %w", err)
+	}
+	defer gz.Close()
+	tr := tar.NewReader(gz)
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read archive: %w", err)
+		}
+		name := filepath.ToSlash(filepath.Clean(header.Name))
+		if header.Typeflag != tar.TypeReg || strings.HasPrefix(name, "../") || filepath.IsAbs(name) {
+			return nil, fmt.Errorf("archive contains unexpected entry %q", header.Name)
+		}
+		target := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(target), 0700); err != nil {
+			return nil, err
+		}
+		f, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, err
+		}
+		_, copyErr := io.Copy(f, tr)
+		closeErr := f.Close()
+		if copyErr != nil {
+			return nil, fmt.Errorf("extract %s: %w", name, copyErr)
+		}
+		if closeErr != nil {
+			return nil, closeErr
+		}
+		names = append(names, name)
+	}
+	return names, nil
+}
+
+// fileSHA256 returns the hex SHA-256 of a file
+func fileSHA256(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
@@ -0,0 +1,416 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/janhq/jan-server/packages/go-common/testhelpers"
+	"github.com/spf13/cobra"
+)
+
+var benchCmd = &cobra.Command{
+	Use:   "bench",
+	Short: "Load-test a running Jan Server deployment",
+	Long:  `Drive load against a running deployment and report latency percentiles.`,
+}
+
+var benchChatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Benchmark streaming chat completions",
+	Long: `Send concurrent streaming chat completions through the gateway and report
+time-to-first-token, total latency, output throughput and errors.
+
+Requests are either a single synthetic prompt or replayed from a JSONL corpus
+where each line is a chat completion request body. Corpus lines are sent
+round-robin with "stream" forced on; --model overrides the model of each line.
+
+Examples:
+  jan-cli bench chat --model jan-v1-4b --concurrency 8 --duration 2m
+  jan-cli bench chat --corpus tests/bench/chat.jsonl --concurrency 16 --duration 5m
+  jan-cli bench chat --model jan-v1-4b --requests 100 --json bench.json`,
+	RunE: runBenchChat,
+}
+
+var (
+	benchURL         string
+	benchToken       string
+	benchModel       string
+	benchConcurrency int
+	benchDuration    time.Duration
+	benchRequests    int
+	benchPrompt      string
+	benchMaxTokens   int
+	benchCorpus      string
+	benchTimeout     time.Duration
+	benchJSONOutput  string
+)
+
+func init() {
+	benchCmd.AddCommand(benchChatCmd)
+
+	benchChatCmd.Flags().StringVar(&benchURL, "url", "http://localhost:8000", "Gateway base URL")
+	benchChatCmd.Flags().StringVar(&benchToken, "token", "", "Bearer token (default: guest login against --url)")
+	benchChatCmd.Flags().StringVar(&benchModel, "model", "", "Model ID (required unless every corpus line sets one)")
+	benchChatCmd.Flags().IntVarP(&benchConcurrency, "concurrency", "c", 4, "Number of concurrent streams")
+	benchChatCmd.Flags().DurationVarP(&benchDuration, "duration", "d", time.Minute, "How long to run")
+	benchChatCmd.Flags().IntVarP(&benchRequests, "requests", "n", 0, "Stop after this many requests (0 = run for --duration)")
+	benchChatCmd.Flags().StringVar(&benchPrompt, "prompt", "Write a short paragraph about the ocean.", "Prompt for synthetic requests")
+	benchChatCmd.Flags().IntVar(&benchMaxTokens, "max-tokens", 256, "max_tokens for synthetic requests")
+	benchChatCmd.Flags().StringVar(&benchCorpus, "corpus", "", "JSONL file of chat completion request bodies to replay")
+	benchChatCmd.Flags().DurationVar(&benchTimeout, "timeout", 2*time.Minute, "Per-request timeout")
+	benchChatCmd.Flags().StringVar(&benchJSONOutput, "json", "", "Also write the summary as JSON to this file")
+}
+
+// benchSample is the outcome of one streamed completion.
+type benchSample struct {
+	TTFT             time.Duration
+	Total            time.Duration
+	CompletionTokens int
+	Status           int
+	Err              string
+}
+
+// benchSummary is the aggregated report printed at the end of a run.
+type benchSummary struct {
+	Requests         int            `json:"requests"`
+	Succeeded        int            `json:"succeeded"`
+	Failed           int            `json:"failed"`
+	Errors           map[string]int `json:"errors"`
+	WallSeconds      float64        `json:"wall_seconds"`
+	RequestsPerSec   float64        `json:"requests_per_second"`
+	OutputTokensSec  float64        `json:"output_tokens_per_second"`
+	TTFTMs           percentiles    `json:"ttft_ms"`
+	LatencyMs        percentiles    `json:"latency_ms"`
+	StreamTokensSec  percentiles    `json:"stream_tokens_per_second"`
+	CompletionTokens int            `json:"completion_tokens"`
+}
+
+type percentiles struct {
+	P50 float64 `json:"p50"`
+	P90 float64 `json:"p90"`
+	P99 float64 `json:"p99"`
+	Max float64 `json:"max"`
+}
+
+func runBenchChat(cmd *cobra.Command, args []string) error {
+	if benchConcurrency < 1 {
+		return fmt.Errorf("--concurrency must be at least 1")
+	}
+
+	bodies, err := loadBenchBodies()
+	if err != nil {
+		return err
+	}
+
+	token := benchToken
+	if token == "" {
+		token, err = testhelpers.GuestLogin(benchURL)
+		if err != nil {
+			return fmt.Errorf("guest login failed (pass --token to skip): %w", err)
+		}
+	}
+
+	endpoint := strings.TrimSuffix(benchURL, "/") + "/v1/chat/completions"
+	client := &http.Client{Timeout: benchTimeout}
+
+	ctx, cancel := context.WithTimeout(cmd.Context(), benchDuration)
+	defer cancel()
+
+	printInfo("Benchmarking %s with %d streams for %s (%d request bodies)", endpoint, benchConcurrency, benchDuration, len(bodies))
+
+	var (
+		mu      sync.Mutex
+		samples []benchSample
+		next    int64
+	)
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < benchConcurrency; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for ctx.Err() == nil {
+				n := atomic.AddInt64(&next, 1)
+				if benchRequests > 0 && n > int64(benchRequests) {
+					return
+				}
+				sample := streamChatOnce(ctx, client, endpoint, token, bodies[int(n-1)%len(bodies)])
+				if ctx.Err() != nil && sample.Err != "" {
+					// Requests cut off by the end of the run are not failures.
+					return
+				}
+				mu.Lock()
+				samples = append(samples, sample)
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	summary := summarizeBench(samples, time.Since(start))
+	printBenchSummary(summary)
+
+	if benchJSONOutput != "" {
+		data, err := json.MarshalIndent(summary, "", "  ")
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(benchJSONOutput, append(data, '\n'), 0644); err != nil {
+			return fmt.Errorf("write %s: %w", benchJSONOutput, err)
+		}
+		printSuccess("Summary written to %s", benchJSONOutput)
+	}
+
+	if summary.Succeeded == 0 {
+		return fmt.Errorf("no request succeeded")
+	}
+	return nil
+}
+
+// loadBenchBodies returns the request bodies to send, either from --corpus or
+// a single synthetic prompt.
+func loadBenchBodies() ([][]byte, error) {
+	if benchCorpus == "" {
+		if benchModel == "" {
+			return nil, fmt.Errorf("--model is required without --corpus")
+		}
+		body := map[string]interface{}{
+			"model":      benchModel,
+			"max_tokens": benchMaxTokens,
+			"messages":   []map[string]string{{"role": "user", "content": benchPrompt}},
+		}
+		data, err := encodeBenchBody(body)
+		if err != nil {
+			return nil, err
+		}
+		return [][]byte{data}, nil
+	}
+
+	file, err := os.Open(benchCorpus)
+	if err != nil {
+		return nil, fmt.Errorf("open corpus: %w", err)
+	}
+	defer file.Close()
+
+	var bodies [][]byte
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		var body map[string]interface{}
+		if err := json.Unmarshal([]byte(text), &body); err != nil {
+			return nil, fmt.Errorf("corpus line %d: %w", line, err)
+		}
+		if benchModel != "" {
+			body["model"] = benchModel
+		}
+		if model, _ := body["model"].(string); model == "" {
+			return nil, fmt.Errorf("corpus line %d has no model; pass --model", line)
+		}
+		data, err := encodeBenchBody(body)
+		if err != nil {
+			return nil, err
+		}
+		bodies = append(bodies, data)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read corpus: %w", err)
+	}
+	if len(bodies) == 0 {
+		return nil, fmt.Errorf("corpus %s has no requests", benchCorpus)
+	}
+	return bodies, nil
+}
+
+func encodeBenchBody(body map[string]interface{}) ([]byte, error) {
+	body["stream"] = true
+	body["stream_options"] = map[string]bool{"include_usage": true}
+	return json.Marshal(body)
+}
+
+// streamChatOnce sends one streaming completion and measures it.
+func streamChatOnce(ctx context.Context, client *http.Client, endpoint, token string, body []byte) benchSample {
+	var sample benchSample
+	start := time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		sample.Err = err.Error()
+		return sample
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := client.Do(req)
+	if err != nil {
+		sample.Err = "transport"
+		return sample
+	}
+	defer resp.Body.Close()
+
+	sample.Status = resp.StatusCode
+	if resp.StatusCode >= http.StatusBadRequest {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		sample.Err = fmt.Sprintf("http_%d", resp.StatusCode)
+		return sample
+	}
+
+	chunks := 0
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 64*1024), 10*1024*1024)
+	for scanner.Scan() {
+		data, ok := strings.CutPrefix(scanner.Text(), "data: ")
+		if !ok {
+			continue
+		}
+		if data == "[DONE]" {
+			break
+		}
+
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content          string            `json:"content"`
+					ReasoningContent string            `json:"reasoning_content"`
+					ToolCalls        []json.RawMessage `json:"tool_calls"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Usage *struct {
+				CompletionTokens int `json:"completion_tokens"`
+			} `json:"usage"`
+			Error *struct {
+				Message string `json:"message"`
+			} `json:"error"`
+		}
+		if err := json.Unmarshal([]byte(data), &chunk); err != nil {
+			continue
+		}
+		if chunk.Error != nil {
+			sample.Err = "stream_error"
+			return sample
+		}
+		if chunk.Usage != nil && chunk.Usage.CompletionTokens > 0 {
+			sample.CompletionTokens = chunk.Usage.CompletionTokens
+		}
+		for _, choice := range chunk.Choices {
+			if choice.Delta.Content == "" && choice.Delta.ReasoningContent == "" && len(choice.Delta.ToolCalls) == 0 {
+				continue
+			}
+			chunks++
+			if sample.TTFT == 0 {
+				sample.TTFT = time.Since(start)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		sample.Err = "stream_read"
+		return sample
+	}
+	if sample.TTFT == 0 {
+		sample.Err = "empty_stream"
+		return sample
+	}
+
+	// Fall back to one token per content chunk when the provider omits usage.
+	if sample.CompletionTokens == 0 {
+		sample.CompletionTokens = chunks
+	}
+	sample.Total = time.Since(start)
+	return sample
+}
+
+func summarizeBench(samples []benchSample, wall time.Duration) benchSummary {
+	summary := benchSummary{
+		Requests:    len(samples),
+		Errors:      map[string]int{},
+		WallSeconds: wall.Seconds(),
+	}
+
+	var ttft, latency, throughput []float64
+	for _, s := range samples {
+		if s.Err != "" {
+			summary.Failed++
+			summary.Errors[s.Err]++
+			continue
+		}
+		summary.Succeeded++
+		summary.CompletionTokens += s.CompletionTokens
+		ttft = append(ttft, float64(s.TTFT.Milliseconds()))
+		latency = append(latency, float64(s.Total.Milliseconds()))
+		if generation := (s.Total - s.TTFT).Seconds(); generation > 0 {
+			throughput = append(throughput, float64(s.CompletionTokens)/generation)
+		}
+	}
+
+	if wall > 0 {
+		summary.RequestsPerSec = float64(summary.Succeeded) / wall.Seconds()
+		summary.OutputTokensSec = float64(summary.CompletionTokens) / wall.Seconds()
+	}
+	summary.TTFTMs = computePercentiles(ttft)
+	summary.LatencyMs = computePercentiles(latency)
+	summary.StreamTokensSec = computePercentiles(throughput)
+	return summary
+}
+
+func computePercentiles(values []float64) percentiles {
+	if len(values) == 0 {
+		return percentiles{}
+	}
+	sort.Float64s(values)
+	at := func(q float64) float64 {
+		idx := int(q*float64(len(values))+0.5) - 1
+		if idx < 0 {
+			idx = 0
+		}
+		if idx >= len(values) {
+			idx = len(values) - 1
+		}
+		return values[idx]
+	}
+	return percentiles{P50: at(0.5), P90: at(0.9), P99: at(0.99), Max: values[len(values)-1]}
+}
+
+func printBenchSummary(s benchSummary) {
+	fmt.Println()
+	fmt.Println("Results")
+	fmt.Println("=======")
+	fmt.Printf("  Requests:      %d (%d ok, %d failed) in %.1fs\n", s.Requests, s.Succeeded, s.Failed, s.WallSeconds)
+	fmt.Printf("  Throughput:    %.2f req/s, %.1f output tokens/s\n", s.RequestsPerSec, s.OutputTokensSec)
+	fmt.Println()
+	fmt.Printf("  %-22s %10s %10s %10s %10s\n", "", "p50", "p90", "p99", "max")
+	fmt.Printf("  %-22s %10.0f %10.0f %10.0f %10.0f\n", "TTFT (ms)", s.TTFTMs.P50, s.TTFTMs.P90, s.TTFTMs.P99, s.TTFTMs.Max)
+	fmt.Printf("  %-22s %10.0f %10.0f %10.0f %10.0f\n", "Latency (ms)", s.LatencyMs.P50, s.LatencyMs.P90, s.LatencyMs.P99, s.LatencyMs.Max)
+	fmt.Printf("  %-22s %10.1f %10.1f %10.1f %10.1f\n", "Stream tokens/s", s.StreamTokensSec.P50, s.StreamTokensSec.P90, s.StreamTokensSec.P99, s.StreamTokensSec.Max)
+
+	if len(s.Errors) > 0 {
+		fmt.Println()
+		fmt.Println("  Errors:")
+		keys := make([]string, 0, len(s.Errors))
+		for k := range s.Errors {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			fmt.Printf("    %-20s %d\n", k, s.Errors[k])
+		}
+	}
+	fmt.Println()
+}
@@ -0,0 +1,547 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/janhq/jan-server/packages/go-common/testhelpers"
+	"github.com/spf13/cobra"
+)
+
+// chatToolPreviewLimit caps how much of a tool's arguments or result is echoed
+const chatToolPreviewLimit = 240
+
+var chatCmd = &cobra.Command{
+	Use:   "chat",
+	Short: "Chat with a model from the terminal",
+	Long: `Start an interactive chat against a running Jan Server, streaming replies as
+they are generated. Useful for smoke testing a deployment without a browser.
+
+By default messages go to llm-api's /v1/chat/completions. Tool calls the model
+requests are shown but not executed. With --responses messages go to
+response-api instead, which runs MCP tools server-side; each tool call and its
+result are shown as they happen.
+
+With --conversation the server keeps the history and the exchange is stored
+in that conversation; otherwise the history lives in this session only.
+
+Requests are authenticated with --token or $JAN_API_TOKEN, then the login
+stored by "jan-cli login" for --url, falling back to a guest login.
+
+Commands inside the chat:
+  /model <id>   switch model
+  /new          start over with an empty history
+  /help         list commands
+  /exit         leave (also Ctrl-D)
+
+Ctrl-C stops the reply being streamed.
+
+Examples:
+  jan-cli chat --model jan-v1-4b
+  jan-cli chat --model jan-v1-4b --conversation conv_abc123
+  jan-cli chat --model jan-v1-4b --responses`,
+	Args: cobra.NoArgs,
+	RunE: runChat,
+}
+
+func init() {
+	chatCmd.Flags().String("model", "", "Model ID to chat with (required)")
+	chatCmd.Flags().String("conversation", "", "Conversation ID to continue and store the exchange in")
+	chatCmd.Flags().String("system", "", "System prompt for the session")
+	chatCmd.Flags().Bool("responses", false, "Use response-api with server-side MCP tools")
+	chatCmd.Flags().String("url", "http://localhost:8000", "Gateway base URL")
+	chatCmd.Flags().String("token", "", "Access token or API key (default: $JAN_API_TOKEN, else jan-cli login, else guest login)")
+	chatCmd.Flags().Bool("no-color", false, "Disable colored output")
+	_ = chatCmd.MarkFlagRequired("model")
+}
+
+// chatSession holds the state carried between turns
+type chatSession struct {
+	baseURL      string
+	token        string
+	model        string
+	system       string
+	responses    bool
+	conversation string
+	color        bool
+	client       *http.Client
+
+	// history is sent with every completion when no conversation holds it
+	history []chatMessage
+	// previousResponseID chains response-api turns outside a conversation
+	previousResponseID string
+}
+
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatTurnStats is printed after each reply
+type chatTurnStats struct {
+	startedAt  time.Time
+	firstToken time.Duration
+	tokens     int
+}
+
+func runChat(cmd *cobra.Command, args []string) error {
+	session := &chatSession{client: &http.Client{}}
+	session.baseURL, _ = cmd.Flags().GetString("url")
+	session.baseURL = strings.TrimRight(session.baseURL, "/")
+	session.token, _ = cmd.Flags().GetString("token")
+	session.model, _ = cmd.Flags().GetString("model")
+	session.system, _ = cmd.Flags().GetString("system")
+	session.responses, _ = cmd.Flags().GetBool("responses")
+	session.conversation, _ = cmd.Flags().GetString("conversation")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+	session.color = !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout)
+	if session.token == "" {
+		session.token = os.Getenv("JAN_API_TOKEN")
+	}
+	cmd.SilenceUsage = true // request failures are not usage errors
+	if session.token == "" {
+		token, err := storedLoginToken(cmd.Context(), session.baseURL)
+		if err != nil && !errors.Is(err, errNoCredential) {
+			return err
+		}
+		session.token = token
+	}
+	if session.token == "" {
+		token, err := testhelpers.GuestLogin(session.baseURL)
+		if err != nil {
+			return fmt.Errorf("guest login failed (pass --token or set JAN_API_TOKEN): %w", err)
+		}
+		session.token = token
+	}
+
+	endpoint := "/v1/chat/completions"
+	if session.responses {
+		endpoint = "/responses/v1/responses"
+	}
+	fmt.Printf("Chatting with %s via %s%s\n", session.model, session.baseURL, endpoint)
+	if session.conversation != "" {
+		fmt.Printf("Conversation: %s\n", session.conversation)
+	}
+	fmt.Println("Type /help for commands, /exit or Ctrl-D to leave.")
+
+	input := bufio.NewScanner(os.Stdin)
+	input.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for {
+		fmt.Print("\n" + session.paint("1;34", "you> "))
+		if !input.Scan() {
+			fmt.Println()
+			return input.Err()
+		}
+		line := strings.TrimSpace(input.Text())
+		if line == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "/") {
+			if session.command(line) {
+				return nil
+			}
+			continue
+		}
+
+		if err := session.turn(cmd.Context(), line); err != nil {
+			printError("%v", err)
+		}
+	}
+}
+
+// command runs a slash command and reports whether the chat should end
+func (s *chatSession) command(line string) bool {
+	name, arg, _ := strings.Cut(line, " ")
+	arg = strings.TrimSpace(arg)
+	switch name {
+	case "/exit", "/quit":
+		return true
+	case "/new":
+		s.history = nil
+		s.previousResponseID = ""
+		s.conversation = ""
+		printInfo("Started a new session")
+	case "/model":
+		if arg == "" {
+			printInfo("Model: %s", s.model)
+			break
+		}
+		s.model = arg
+		printInfo("Switched to %s", s.model)
+	case "/help":
+		printInfo("/model <id>   switch model (no argument shows the current one)")
+		printInfo("/new          start over with an empty history")
+		printInfo("/exit         leave the chat")
+	default:
+		printWarning("Unknown command %s, type /help for commands", name)
+	}
+	return false
+}
+
+// turn sends one user message and streams the reply; Ctrl-C cancels the
+// reply without leaving the chat
+func (s *chatSession) turn(parent context.Context, message string) error {
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt)
+	defer signal.Stop(interrupt)
+	go func() {
+		select {
+		case <-interrupt:
+			cancel()
+		case <-ctx.Done():
+		}
+	}()
+
+	stats := &chatTurnStats{startedAt: time.Now()}
+	fmt.Print(s.paint("1;32", s.model+"> "))
+	var err error
+	if s.responses {
+		err = s.streamResponse(ctx, message, stats)
+	} else {
+		err = s.streamCompletion(ctx, message, stats)
+	}
+	fmt.Println()
+	if errors.Is(ctx.Err(), context.Canceled) && parent.Err() == nil {
+		printWarning("Reply stopped")
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	summary := fmt.Sprintf("%.1fs", time.Since(stats.startedAt).Seconds())
+	if stats.firstToken > 0 {
+		summary += fmt.Sprintf(", first token %dms", stats.firstToken.Milliseconds())
+	}
+	if stats.tokens > 0 {
+		summary += fmt.Sprintf(", %d tokens", stats.tokens)
+	}
+	if s.conversation != "" {
+		summary += ", " + s.conversation
+	}
+	fmt.Println(s.paint("2", "["+summary+"]"))
+	return nil
+}
+
+// streamCompletion streams a chat completion from llm-api
+func (s *chatSession) streamCompletion(ctx context.Context, message string, stats *chatTurnStats) error {
+	user := chatMessage{Role: "user", Content: message}
+	payload := map[string]any{
+		"model":          s.model,
+		"stream":         true,
+		"stream_options": map[string]bool{"include_usage": true},
+	}
+	var messages []chatMessage
+	if s.system != "" {
+		messages = append(messages, chatMessage{Role: "system", Content: s.system})
+	}
+	if s.conversation != "" {
+		// The server prepends the conversation's items itself
+		payload["conversation"] = s.conversation
+		messages = append(messages, user)
+	} else {
+		messages = append(append(messages, s.history...), user)
+	}
+	payload["messages"] = messages
+
+	var reply strings.Builder
+	var reasoning bool
+	calls := map[int]*chatToolCall{}
+	err := s.stream(ctx, "/v1/chat/completions", payload, func(_ string, data []byte) error {
+		var chunk struct {
+			Choices []struct {
+				Delta struct {
+					Content          string `json:"content"`
+					ReasoningContent string `json:"reasoning_content"`
+					ToolCalls        []struct {
+						Index    int `json:"index"`
+						Function struct {
+							Name      string `json:"name"`
+							Arguments string `json:"arguments"`
+						} `json:"function"`
+					} `json:"tool_calls"`
+				} `json:"delta"`
+			} `json:"choices"`
+			Usage *struct {
+				TotalTokens int `json:"total_tokens"`
+			} `json:"usage"`
+			Conversation *struct {
+				ID string `json:"id"`
+			} `json:"conversation"`
+		}
+		if err := json.Unmarshal(data, &chunk); err != nil {
+			return nil
+		}
+		if chunk.Usage != nil {
+			stats.tokens = chunk.Usage.TotalTokens
+		}
+		if chunk.Conversation != nil && chunk.Conversation.ID != "" {
+			s.conversation = chunk.Conversation.ID
+		}
+		for _, choice := range chunk.Choices {
+			delta := choice.Delta
+			if delta.ReasoningContent != "" {
+				stats.markFirstToken()
+				reasoning = true
+				fmt.Print(s.paint("2;3", delta.ReasoningContent))
+			}
+			if delta.Content != "" {
+				stats.markFirstToken()
+				if reasoning {
+					reasoning = false
+					fmt.Print("\n\n")
+				}
+				reply.WriteString(delta.Content)
+				fmt.Print(delta.Content)
+			}
+			for _, tc := range delta.ToolCalls {
+				call, ok := calls[tc.Index]
+				if !ok {
+					call = &chatToolCall{}
+					calls[tc.Index] = call
+				}
+				call.name += tc.Function.Name
+				call.arguments.WriteString(tc.Function.Arguments)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	indexes := make([]int, 0, len(calls))
+	for index := range calls {
+		indexes = append(indexes, index)
+	}
+	sort.Ints(indexes)
+	for _, index := range indexes {
+		call := calls[index]
+		fmt.Printf("\n%s %s %s", s.paint("33", "⚙ tool call"), s.paint("1", call.name), s.paint("2", chatPreview(call.arguments.String())))
+	}
+	if len(calls) > 0 {
+		fmt.Printf("\n%s", s.paint("2", "(tools requested through chat completions are not executed; use --responses to run MCP tools)"))
+	}
+
+	if s.conversation == "" {
+		s.history = append(s.history, user, chatMessage{Role: "assistant", Content: reply.String()})
+	}
+	return nil
+}
+
+// streamResponse streams a response from response-api, which executes MCP
+// tools between model calls and reports each call and result as an event
+func (s *chatSession) streamResponse(ctx context.Context, message string, stats *chatTurnStats) error {
+	payload := map[string]any{
+		"model":  s.model,
+		"input":  message,
+		"stream": true,
+	}
+	if s.system != "" {
+		payload["system_prompt"] = s.system
+	}
+	if s.conversation != "" {
+		payload["conversation"] = s.conversation
+	} else if s.previousResponseID != "" {
+		payload["previous_response_id"] = s.previousResponseID
+	}
+
+	// Tool events interrupt the text, so they start on a fresh line
+	midLine := true
+	return s.stream(ctx, "/responses/v1/responses", payload, func(event string, data []byte) error {
+		switch event {
+		case "response.output_text.delta":
+			var delta struct {
+				Delta string `json:"delta"`
+			}
+			if json.Unmarshal(data, &delta) == nil && delta.Delta != "" {
+				stats.markFirstToken()
+				fmt.Print(delta.Delta)
+				midLine = true
+			}
+		case "response.tool_call":
+			var payload struct {
+				Call struct {
+					Name      string         `json:"name"`
+					Arguments map[string]any `json:"arguments"`
+				} `json:"call"`
+			}
+			if json.Unmarshal(data, &payload) != nil {
+				return nil
+			}
+			arguments, _ := json.Marshal(payload.Call.Arguments)
+			if midLine {
+				fmt.Println()
+			}
+			fmt.Printf("%s %s %s\n", s.paint("33", "⚙ tool call"), s.paint("1", payload.Call.Name), s.paint("2", chatPreview(string(arguments))))
+			midLine = false
+		case "response.tool_result":
+			var payload struct {
+				Result struct {
+					ToolName string `json:"tool_name"`
+					Content  []struct {
+						Type string `json:"type"`
+						Text string `json:"text"`
+					} `json:"content"`
+					IsError bool   `json:"is_error"`
+					Error   string `json:"error"`
+				} `json:"result"`
+			}
+			if json.Unmarshal(data, &payload) != nil {
+				return nil
+			}
+			result := payload.Result
+			if result.IsError || result.Error != "" {
+				fmt.Printf("%s %s %s\n", s.paint("31", "✗ tool error"), s.paint("1", result.ToolName), chatPreview(result.Error))
+				return nil
+			}
+			var text []string
+			for _, content := range result.Content {
+				if content.Text != "" {
+					text = append(text, content.Text)
+				} else {
+					text = append(text, "["+content.Type+"]")
+				}
+			}
+			fmt.Printf("%s %s %s\n", s.paint("32", "↳ tool result"), s.paint("1", result.ToolName), s.paint("2", chatPreview(strings.Join(text, " "))))
+		case "response.completed":
+			var resp struct {
+				ID             string          `json:"id"`
+				ConversationID *string         `json:"conversation_id"`
+				Usage          json.RawMessage `json:"usage"`
+			}
+			if json.Unmarshal(data, &resp) != nil {
+				return nil
+			}
+			s.previousResponseID = resp.ID
+			if resp.ConversationID != nil && *resp.ConversationID != "" {
+				s.conversation = *resp.ConversationID
+			}
+			var usage struct {
+				TotalTokens int `json:"total_tokens"`
+			}
+			if json.Unmarshal(resp.Usage, &usage) == nil {
+				stats.tokens = usage.TotalTokens
+			}
+		case "response.error":
+			var payload struct {
+				Message string `json:"message"`
+			}
+			_ = json.Unmarshal(data, &payload)
+			return fmt.Errorf("response failed: %s", payload.Message)
+		case "response.cancelled":
+			return fmt.Errorf("response was cancelled")
+		}
+		return nil
+	})
+}
+
+// stream POSTs payload to path and hands each server-sent event to handle
+// until the stream ends
+func (s *chatSession) stream(ctx context.Context, path string, payload any, handle func(event string, data []byte) error) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+path, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "text/event-stream")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("request %s: %w", path, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		var apiErr struct {
+			Message string `json:"message"`
+			Error   any    `json:"error"`
+		}
+		_ = json.Unmarshal(data, &apiErr)
+		msg := apiErr.Message
+		if msg == "" {
+			switch e := apiErr.Error.(type) {
+			case string:
+				msg = e
+			case map[string]any:
+				msg, _ = e["message"].(string)
+			}
+		}
+		if msg == "" {
+			msg = strings.TrimSpace(string(data))
+		}
+		return fmt.Errorf("%s: %s %s", path, resp.Status, msg)
+	}
+
+	event := ""
+	scanner := bufio.NewScanner(resp.Body)
+	scanner.Buffer(make([]byte, 0, 64*1024), 4*1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if name, found := strings.CutPrefix(line, "event:"); found {
+			event = strings.TrimSpace(name)
+			continue
+		}
+		data, found := strings.CutPrefix(line, "data:")
+		if !found {
+			if line == "" {
+				event = ""
+			}
+			continue
+		}
+		data = strings.TrimSpace(data)
+		if data == "" || data == "[DONE]" {
+			continue
+		}
+		if err := handle(event, []byte(data)); err != nil {
+			return err
+		}
+	}
+	return scanner.Err()
+}
+
+// chatToolCall accumulates a tool call streamed in chat completion deltas
+type chatToolCall struct {
+	name      string
+	arguments strings.Builder
+}
+
+func (t *chatTurnStats) markFirstToken() {
+	if t.firstToken == 0 {
+		t.firstToken = time.Since(t.startedAt)
+	}
+}
+
+func (s *chatSession) paint(color, text string) string {
+	if !s.color {
+		return text
+	}
+	return "\033[" + color + "m" + text + "\033[0m"
+}
+
+// chatPreview flattens text to one line and truncates it for display
+func chatPreview(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > chatToolPreviewLimit {
+		return string(runes[:chatToolPreviewLimit]) + "…"
+	}
+	return text
+}
@@ -24,8 +24,11 @@ var devSetupCmd = &cobra.Command{
 
 var devScaffoldCmd = &cobra.Command{
 	Use:   "scaffold [service-name]",
-	Short: "Scaffold a new service",
-	Long:  `Generate a new service from the template with proper structure.`,
+	Short: "Scaffold a new service, MCP tool or prompt module",
+	Long: `Generate a new service from the template with proper structure.
+
+Use the mcp-tool and prompt-module subcommands to add a built-in MCP tool to
+mcp-tools or a prompt orchestration module to llm-api, wired in end to end.`,
 	RunE:  runDevScaffold,
 	Args:  cobra.ExactArgs(1),
 }
@@ -0,0 +1,644 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/spf13/cobra"
+)
+
+var devScaffoldMCPToolCmd = &cobra.Command{
+	Use:   "mcp-tool <tool_name>",
+	Short: "Scaffold a built-in MCP tool in mcp-tools",
+	Long: `Generate a built-in MCP tool in services/mcp-tools and wire it in.
+
+Creates internal/interfaces/httpserver/routes/mcp/<tool_name>_mcp.go with the
+tool skeleton and a test that calls it over an in-memory MCP session, then:
+  - adds the MCP_ENABLE_<TOOL_NAME> flag (default off) to the config
+  - adds a wire provider and passes the tool to the MCP route
+  - registers the tool in NewMCPRoute, the legacy main.go and wire_gen.go
+  - lists the tool in the /v1/mcp swagger description
+
+Examples:
+  jan-cli dev scaffold mcp-tool stock_quote --description "Look up the latest stock price for a ticker"
+  jan-cli dev scaffold mcp-tool stock_quote --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevScaffoldMCPTool,
+}
+
+var devScaffoldPromptModuleCmd = &cobra.Command{
+	Use:   "prompt-module <module_name>",
+	Short: "Scaffold a prompt orchestration module in llm-api",
+	Long: `Generate a prompt orchestration module in services/llm-api and register it.
+
+Creates internal/domain/prompt/<module_name>_module.go with the Module
+skeleton and a test, then:
+  - adds the PROMPT_ORCHESTRATION_<MODULE_NAME> flag (default off) to the config
+  - adds the matching ProcessorConfig field and maps it in the domain provider
+  - registers the module in the processor with the given priority
+
+Examples:
+  jan-cli dev scaffold prompt-module citation_style
+  jan-cli dev scaffold prompt-module citation_style --priority 45 --dry-run`,
+	Args: cobra.ExactArgs(1),
+	RunE: runDevScaffoldPromptModule,
+}
+
+func init() {
+	devScaffoldCmd.AddCommand(devScaffoldMCPToolCmd)
+	devScaffoldCmd.AddCommand(devScaffoldPromptModuleCmd)
+
+	devScaffoldMCPToolCmd.Flags().String("description", "", "Tool description shown to models")
+	devScaffoldMCPToolCmd.Flags().Bool("dry-run", false, "Show the files that would change without writing them")
+
+	devScaffoldPromptModuleCmd.Flags().Int("priority", 60, "Processor priority; lower runs earlier (built-ins use -20 to 50)")
+	devScaffoldPromptModuleCmd.Flags().Bool("dry-run", false, "Show the files that would change without writing them")
+}
+
+// scaffoldNamePattern accepts snake_case names; hyphens are normalised to underscores
+var scaffoldNamePattern = regexp.MustCompile(`^[a-z][a-z0-9]*(_[a-z0-9]+)*$`)
+
+// scaffoldNames are the spellings of a generated component's name
+type scaffoldNames struct {
+	Name        string // stock_quote
+	Pascal      string // StockQuote
+	Camel       string // stockQuote
+	Upper       string // STOCK_QUOTE
+	Description string
+	Priority    int
+}
+
+func newScaffoldNames(raw string) (scaffoldNames, error) {
+	name := strings.ReplaceAll(strings.ToLower(strings.TrimSpace(raw)), "-", "_")
+	if !scaffoldNamePattern.MatchString(name) {
+		return scaffoldNames{}, fmt.Errorf("invalid name %q: use snake_case letters and digits, e.g. stock_quote", raw)
+	}
+	var pascal strings.Builder
+	for _, part := range strings.Split(name, "_") {
+		pascal.WriteString(strings.ToUpper(part[:1]) + part[1:])
+	}
+	p := pascal.String()
+	return scaffoldNames{
+		Name:   name,
+		Pascal: p,
+		Camel:  strings.ToLower(p[:1]) + p[1:],
+		Upper:  strings.ToUpper(name),
+	}, nil
+}
+
+// scaffoldEdit inserts text into an existing file at the position found by at
+type scaffoldEdit struct {
+	at     func(src string) (int, bool)
+	anchor string // shown when the position can't be found
+	text   string
+}
+
+// before positions an edit at the start of the single occurrence of anchor
+func before(anchor string) func(string) (int, bool) {
+	return func(src string) (int, bool) {
+		if strings.Count(src, anchor) != 1 {
+			return 0, false
+		}
+		return strings.Index(src, anchor), true
+	}
+}
+
+// afterLastLine positions an edit after the last line matching pattern
+func afterLastLine(pattern string) func(string) (int, bool) {
+	re := regexp.MustCompile(`(?m)` + pattern + `.*\n`)
+	return func(src string) (int, bool) {
+		matches := re.FindAllStringIndex(src, -1)
+		if len(matches) == 0 {
+			return 0, false
+		}
+		return matches[len(matches)-1][1], true
+	}
+}
+
+// scaffoldPlan collects the generated files and edits so nothing is written
+// unless every anchor was found
+type scaffoldPlan struct {
+	root    string
+	created []string
+	changed []string
+	files   map[string][]byte
+}
+
+func newScaffoldPlan(root string) *scaffoldPlan {
+	return &scaffoldPlan{root: root, files: map[string][]byte{}}
+}
+
+// create renders a new Go file from a template
+func (p *scaffoldPlan) create(rel, tmpl string, names scaffoldNames) error {
+	path := filepath.Join(p.root, rel)
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", rel)
+	}
+	var buf bytes.Buffer
+	if err := template.Must(template.New(rel).Parse(tmpl)).Execute(&buf, names); err != nil {
+		return err
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return fmt.Errorf("format %s: %w", rel, err)
+	}
+	p.files[path] = src
+	p.created = append(p.created, rel)
+	return nil
+}
+
+// patch applies edits to an existing file. Files that were gofmt-clean are
+// reformatted afterwards so inserted struct fields line up.
+func (p *scaffoldPlan) patch(rel string, edits ...scaffoldEdit) error {
+	path := filepath.Join(p.root, rel)
+	original, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	src := string(original)
+	for _, edit := range edits {
+		pos, ok := edit.at(src)
+		if !ok {
+			return fmt.Errorf("%s: could not find %q; the file has diverged from what the scaffold expects", rel, edit.anchor)
+		}
+		src = src[:pos] + edit.text + src[pos:]
+	}
+	out := []byte(src)
+	if formatted, err := format.Source(original); err == nil && bytes.Equal(formatted, original) {
+		if out, err = format.Source(out); err != nil {
+			return fmt.Errorf("format %s: %w", rel, err)
+		}
+	}
+	p.files[path] = out
+	p.changed = append(p.changed, rel)
+	return nil
+}
+
+func (p *scaffoldPlan) write(dryRun bool) error {
+	if !dryRun {
+		for path, content := range p.files {
+			if err := os.WriteFile(path, content, 0o644); err != nil {
+				return err
+			}
+		}
+	}
+	verb := "Created"
+	if dryRun {
+		verb = "Would create"
+	}
+	for _, rel := range p.created {
+		printSuccess("%s %s", verb, rel)
+	}
+	verb = "Updated"
+	if dryRun {
+		verb = "Would update"
+	}
+	for _, rel := range p.changed {
+		printSuccess("%s %s", verb, rel)
+	}
+	return nil
+}
+
+func runDevScaffoldMCPTool(cmd *cobra.Command, args []string) error {
+	names, err := newScaffoldNames(args[0])
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true // anchor and file errors are not usage errors
+	names.Description, _ = cmd.Flags().GetString("description")
+	if names.Description == "" {
+		names.Description = "TODO: describe what " + names.Name + " does and when to use it"
+	}
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	svc := filepath.Join("services", "mcp-tools")
+	mcpDir := filepath.Join(svc, "internal", "interfaces", "httpserver", "routes", "mcp")
+	variable := names.Camel + "MCP"
+
+	plan := newScaffoldPlan(root)
+	steps := []error{
+		plan.create(filepath.Join(mcpDir, names.Name+"_mcp.go"), mcpToolTemplate, names),
+		plan.create(filepath.Join(mcpDir, names.Name+"_mcp_test.go"), mcpToolTestTemplate, names),
+		plan.patch(filepath.Join(svc, "internal", "infrastructure", "config", "config.go"), scaffoldEdit{
+			at:     afterLastLine(`^\tEnable\w+\s+bool\s+` + "`" + `env:"MCP_ENABLE_`),
+			anchor: `Enable... bool env:"MCP_ENABLE_..."`,
+			text:   fmt.Sprintf("\tEnable%s bool `env:\"MCP_ENABLE_%s\" envDefault:\"false\"`\n", names.Pascal, names.Upper),
+		}),
+		plan.patch(filepath.Join(svc, "internal", "interfaces", "httpserver", "routes", "routes_provider.go"),
+			scaffoldEdit{at: before("\tProvideToolConfigCache,\n"), anchor: "ProvideToolConfigCache,",
+				text: fmt.Sprintf("\tProvide%sMCP,\n", names.Pascal)},
+			scaffoldEdit{at: before("// ProvideToolConfigCache "), anchor: "// ProvideToolConfigCache",
+				text: fmt.Sprintf(mcpToolProviderTemplate, names.Pascal, names.Name)},
+			scaffoldEdit{at: before("\tllmClient *llmapi.Client,\n\ttoolConfigCache *toolconfig.Cache,\n) *mcp.MCPRoute"), anchor: "ProvideMCPRoute parameters",
+				text: fmt.Sprintf("\t%s *mcp.%sMCP,\n", variable, names.Pascal)},
+			scaffoldEdit{at: before("llmClient, toolConfigCache)"), anchor: "mcp.NewMCPRoute(..., llmClient, toolConfigCache)",
+				text: variable + ", "},
+		),
+		plan.patch(filepath.Join(mcpDir, "mcp_route.go"),
+			scaffoldEdit{at: before("\tllmClient *llmapi.Client,\n\ttoolConfigCache *toolconfig.Cache,\n) *MCPRoute"), anchor: "NewMCPRoute parameters",
+				text: fmt.Sprintf("\t%s *%sMCP,\n", variable, names.Pascal)},
+			scaffoldEdit{at: before("\t// Register tools from external MCP providers\n"), anchor: "// Register tools from external MCP providers",
+				text: fmt.Sprintf("\t// Register %[1]s tool\n\tif %[2]s != nil {\n\t\t%[2]s.SetLLMClient(llmClient)\n\t\t%[2]s.RegisterTools(server)\n\t}\n\n", names.Name, variable)},
+			scaffoldEdit{at: before("// @Description\n// @Description **MCP Protocol:**"), anchor: "// @Description **MCP Protocol:**",
+				text: fmt.Sprintf("// @Description - `%s`: %s (params: query).\n", names.Name, strings.TrimSuffix(names.Description, "."))},
+		),
+		plan.patch(filepath.Join(svc, "cmd", "server", "wire_gen.go"),
+			scaffoldEdit{at: before("\tllmapiClient := infrastructure.ProvideLLMAPIClient(config)\n"), anchor: "llmapiClient := infrastructure.ProvideLLMAPIClient(config)",
+				text: fmt.Sprintf("\t%s := routes.Provide%sMCP(config)\n", variable, names.Pascal)},
+			scaffoldEdit{at: before("llmapiClient, cache)"), anchor: "routes.ProvideMCPRoute(..., llmapiClient, cache)",
+				text: variable + ", "},
+		),
+		plan.patch(filepath.Join(svc, "main.go"),
+			scaffoldEdit{at: before("\tmcpRoute := mcp.NewMCPRoute("), anchor: "mcpRoute := mcp.NewMCPRoute(",
+				text: fmt.Sprintf("\t// Initialize %[1]s MCP tool\n\tvar %[2]s *mcp.%[3]sMCP\n\tif cfg.Enable%[3]s {\n\t\t%[2]s = mcp.New%[3]sMCP(cfg.Enable%[3]s)\n\t}\n\n", names.Name, variable, names.Pascal)},
+			scaffoldEdit{at: before("llmClient, toolConfigCache)"), anchor: "mcp.NewMCPRoute(..., llmClient, toolConfigCache)",
+				text: variable + ", "},
+		),
+	}
+	for _, err := range steps {
+		if err != nil {
+			return fmt.Errorf("nothing was written: %w", err)
+		}
+	}
+	if err := plan.write(dryRun); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. Implement %sMCP.run and its input schema in %s\n", names.Pascal, filepath.Join(mcpDir, names.Name+"_mcp.go"))
+	fmt.Printf("  2. Enable it with MCP_ENABLE_%s=true and document the flag in services/mcp-tools/README.md\n", names.Upper)
+	fmt.Println("  3. cd services/mcp-tools && go test ./internal/interfaces/httpserver/routes/mcp/")
+	fmt.Println("  4. jan-cli swagger generate --service mcp-tools")
+	return nil
+}
+
+func runDevScaffoldPromptModule(cmd *cobra.Command, args []string) error {
+	names, err := newScaffoldNames(args[0])
+	if err != nil {
+		return err
+	}
+	cmd.SilenceUsage = true // anchor and file errors are not usage errors
+	names.Priority, _ = cmd.Flags().GetInt("priority")
+	dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+	svc := filepath.Join("services", "llm-api")
+	promptDir := filepath.Join(svc, "internal", "domain", "prompt")
+
+	plan := newScaffoldPlan(root)
+	steps := []error{
+		plan.create(filepath.Join(promptDir, names.Name+"_module.go"), promptModuleTemplate, names),
+		plan.create(filepath.Join(promptDir, names.Name+"_module_test.go"), promptModuleTestTemplate, names),
+		plan.patch(filepath.Join(svc, "internal", "config", "config.go"), scaffoldEdit{
+			at:     afterLastLine(`^\tPromptOrchestration\w+\s+bool\s+` + "`" + `env:"PROMPT_ORCHESTRATION_`),
+			anchor: `PromptOrchestration... bool env:"PROMPT_ORCHESTRATION_..."`,
+			text:   fmt.Sprintf("\tPromptOrchestrationEnable%s bool `env:\"PROMPT_ORCHESTRATION_%s\" envDefault:\"false\"`\n", names.Pascal, names.Upper),
+		}),
+		plan.patch(filepath.Join(promptDir, "types.go"), scaffoldEdit{
+			at:     afterLastLine(`^\tEnable\w+\s+bool$`),
+			anchor: "ProcessorConfig Enable... bool fields",
+			text:   fmt.Sprintf("\tEnable%s bool\n", names.Pascal),
+		}),
+		plan.patch(filepath.Join(svc, "internal", "domain", "provider.go"), scaffoldEdit{
+			at:     afterLastLine(`^\t\tEnable\w+:\s+cfg\.PromptOrchestrationEnable`),
+			anchor: "EnableTools: cfg.PromptOrchestrationEnableTools,",
+			text:   fmt.Sprintf("\t\tEnable%[1]s: cfg.PromptOrchestrationEnable%[1]s,\n", names.Pascal),
+		}),
+		plan.patch(filepath.Join(promptDir, "processor.go"),
+			scaffoldEdit{at: before("\tdefault:\n\t\treturn 100\n"), anchor: "modulePriority default case",
+				text: fmt.Sprintf("\tcase *%sModule:\n\t\treturn %d\n", names.Pascal, names.Priority)},
+			scaffoldEdit{at: before("\t// Register modules based on configuration\n"), anchor: "// Register modules based on configuration",
+				text: fmt.Sprintf("\tif config.Enable%[1]s {\n\t\tprocessor.RegisterModule(New%[1]sModule())\n\t}\n\n", names.Pascal)},
+		),
+	}
+	for _, err := range steps {
+		if err != nil {
+			return fmt.Errorf("nothing was written: %w", err)
+		}
+	}
+	if err := plan.write(dryRun); err != nil {
+		return err
+	}
+
+	fmt.Println()
+	fmt.Println("Next steps:")
+	fmt.Printf("  1. Implement %sModule.instruction in %s\n", names.Pascal, filepath.Join(promptDir, names.Name+"_module.go"))
+	fmt.Printf("  2. Enable it with PROMPT_ORCHESTRATION_ENABLED=true PROMPT_ORCHESTRATION_%s=true\n", names.Upper)
+	fmt.Println("  3. Document the module in internal/domain/prompt/README.md and docs/guides/prompt-orchestration.md")
+	fmt.Println("  4. cd services/llm-api && go test ./internal/domain/prompt/")
+	return nil
+}
+
+// mcpToolProviderTemplate is the wire provider added to routes_provider.go (Pascal, name)
+const mcpToolProviderTemplate = `// Provide%[1]sMCP creates a %[1]sMCP unless disabled
+func Provide%[1]sMCP(cfg *config.Config) *mcp.%[1]sMCP {
+	if !cfg.Enable%[1]s {
+		log.Warn().Msg("%[2]s MCP tool disabled via config")
+		return nil
+	}
+	return mcp.New%[1]sMCP(cfg.Enable%[1]s)
+}
+
+`
+
+const mcpToolTemplate = `package mcp
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"jan-server/services/mcp-tools/internal/domain/toolerror"
+	"jan-server/services/mcp-tools/internal/infrastructure/llmapi"
+	"jan-server/services/mcp-tools/internal/infrastructure/metrics"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/rs/zerolog/log"
+)
+
+// {{.Pascal}}Args defines the arguments for the {{.Name}} tool
+type {{.Pascal}}Args struct {
+	Query string ` + "`json:\"query\"`" + `
+	// Context passthrough
+	ToolCallID     string ` + "`json:\"tool_call_id,omitempty\"`" + `
+	RequestID      string ` + "`json:\"request_id,omitempty\"`" + `
+	ConversationID string ` + "`json:\"conversation_id,omitempty\"`" + `
+	UserID         string ` + "`json:\"user_id,omitempty\"`" + `
+}
+
+// {{.Pascal}}MCP serves the {{.Name}} tool.
+type {{.Pascal}}MCP struct {
+	llmClient *llmapi.Client // LLM-API client for tool tracking
+	enabled   bool
+}
+
+// New{{.Pascal}}MCP creates a new {{.Name}} MCP handler.
+func New{{.Pascal}}MCP(enabled bool) *{{.Pascal}}MCP {
+	return &{{.Pascal}}MCP{enabled: enabled}
+}
+
+// SetLLMClient sets the LLM-API client for tool call tracking
+func (t *{{.Pascal}}MCP) SetLLMClient(client *llmapi.Client) {
+	t.llmClient = client
+}
+
+// RegisterTools registers the {{.Name}} tool with the MCP server.
+func (t *{{.Pascal}}MCP) RegisterTools(server *mcp.Server) {
+	if t == nil {
+		return
+	}
+	if !t.enabled {
+		log.Warn().Msg("{{.Name}} MCP tool disabled via config")
+		return
+	}
+
+	mcp.AddTool(server, &mcp.Tool{
+		Name:        "{{.Name}}",
+		Description: {{printf "%q" .Description}},
+		InputSchema: map[string]any{
+			"type": "object",
+			"properties": map[string]any{
+				"query": map[string]any{
+					"type":        "string",
+					"description": "TODO: describe the argument",
+				},
+			},
+			"required": []string{"query"},
+		},
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input {{.Pascal}}Args) (*mcp.CallToolResult, map[string]any, error) {
+		startTime := time.Now()
+		logToolCall("{{.Name}}", req)
+
+		payload, err := t.run(ctx, input)
+		if err != nil {
+			metrics.RecordToolCall("{{.Name}}", "builtin", "error", time.Since(startTime).Seconds())
+			trackToolResult(ctx, t.llmClient, "{{.Name}}", input, nil, err)
+			return nil, nil, err
+		}
+
+		metrics.RecordToolCall("{{.Name}}", "builtin", "success", time.Since(startTime).Seconds())
+		trackToolResult(ctx, t.llmClient, "{{.Name}}", input, payload, nil)
+		return nil, payload, nil
+	})
+
+	log.Info().Msg("Registered {{.Name}} MCP tool")
+}
+
+// run executes the tool. Return toolerror errors so models can tell bad
+// arguments from upstream failures.
+func (t *{{.Pascal}}MCP) run(ctx context.Context, input {{.Pascal}}Args) (map[string]any, error) {
+	query := strings.TrimSpace(input.Query)
+	if query == "" {
+		return nil, toolerror.New(toolerror.CodeInvalidArguments, "query is required", false)
+	}
+
+	// TODO: implement {{.Name}}
+	return map[string]any{
+		"query": query,
+	}, nil
+}
+`
+
+const mcpToolTestTemplate = `package mcp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+)
+
+func Test{{.Pascal}}MCPRun(t *testing.T) {
+	tool := New{{.Pascal}}MCP(true)
+
+	payload, err := tool.run(context.Background(), {{.Pascal}}Args{Query: "example"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if payload["query"] != "example" {
+		t.Fatalf("expected query in payload, got %v", payload)
+	}
+
+	if _, err := tool.run(context.Background(), {{.Pascal}}Args{}); err == nil {
+		t.Fatalf("expected error for empty query")
+	}
+}
+
+func Test{{.Pascal}}MCPRegisterTools(t *testing.T) {
+	ctx := context.Background()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	New{{.Pascal}}MCP(true).RegisterTools(server)
+
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("connect server: %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("connect client: %v", err)
+	}
+	defer session.Close()
+
+	result, err := session.CallTool(ctx, &mcp.CallToolParams{Name: "{{.Name}}", Arguments: map[string]any{"query": "example"}})
+	if err != nil {
+		t.Fatalf("call tool: %v", err)
+	}
+	if result.IsError {
+		t.Fatalf("expected success, got %+v", result.Content)
+	}
+}
+
+func Test{{.Pascal}}MCPDisabled(t *testing.T) {
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "1.0.0"}, nil)
+	New{{.Pascal}}MCP(false).RegisterTools(server)
+
+	ctx := context.Background()
+	serverTransport, clientTransport := mcp.NewInMemoryTransports()
+	if _, err := server.Connect(ctx, serverTransport, nil); err != nil {
+		t.Fatalf("connect server: %v", err)
+	}
+	session, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "1.0.0"}, nil).Connect(ctx, clientTransport, nil)
+	if err != nil {
+		t.Fatalf("connect client: %v", err)
+	}
+	defer session.Close()
+
+	tools, err := session.ListTools(ctx, nil)
+	if err != nil {
+		t.Fatalf("list tools: %v", err)
+	}
+	for _, tool := range tools.Tools {
+		if tool.Name == "{{.Name}}" {
+			t.Fatalf("expected {{.Name}} not to be registered when disabled")
+		}
+	}
+}
+`
+
+const promptModuleTemplate = `package prompt
+
+import (
+	"context"
+	"strings"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+const {{.Camel}}ModuleName = "{{.Name}}"
+
+// {{.Pascal}}Module TODO: describe what the module adds to the prompt and when.
+// It applies when the {{.Name}} user preference is set.
+type {{.Pascal}}Module struct{}
+
+// New{{.Pascal}}Module creates a new {{.Name}} module.
+func New{{.Pascal}}Module() *{{.Pascal}}Module {
+	return &{{.Pascal}}Module{}
+}
+
+// Name returns the module identifier.
+func (m *{{.Pascal}}Module) Name() string {
+	return {{.Camel}}ModuleName
+}
+
+// ShouldApply applies when the module produces an instruction for the request.
+func (m *{{.Pascal}}Module) ShouldApply(ctx context.Context, promptCtx *Context, messages []openai.ChatCompletionMessage) bool {
+	if ctx == nil || ctx.Err() != nil {
+		return false
+	}
+	if promptCtx == nil {
+		return false
+	}
+	if promptCtx.Preferences != nil && isModuleDisabled(promptCtx.Preferences, m.Name()) {
+		return false
+	}
+	return m.instruction(promptCtx) != ""
+}
+
+// Apply adds the module's instruction as a system message.
+func (m *{{.Pascal}}Module) Apply(ctx context.Context, promptCtx *Context, messages []openai.ChatCompletionMessage) ([]openai.ChatCompletionMessage, error) {
+	if ctx != nil {
+		if err := ctx.Err(); err != nil {
+			return messages, err
+		}
+	}
+	instruction := m.instruction(promptCtx)
+	if instruction == "" {
+		return messages, nil
+	}
+	return appendSystemContent(messages, instruction, m.Name(), ""), nil
+}
+
+// instruction builds the text the module adds. TODO: replace the example,
+// which passes the {{.Name}} preference through.
+func (m *{{.Pascal}}Module) instruction(promptCtx *Context) string {
+	if promptCtx == nil || promptCtx.Preferences == nil {
+		return ""
+	}
+	value, _ := promptCtx.Preferences["{{.Name}}"].(string)
+	return strings.TrimSpace(value)
+}
+`
+
+const promptModuleTestTemplate = `package prompt
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+func Test{{.Pascal}}Module(t *testing.T) {
+	ctx := context.Background()
+	module := New{{.Pascal}}Module()
+	messages := []openai.ChatCompletionMessage{{"{{"}}Role: openai.ChatMessageRoleUser, Content: "Hello"{{"}}"}}
+
+	if module.ShouldApply(ctx, &Context{}, messages) {
+		t.Fatalf("expected module not to apply without the {{.Name}} preference")
+	}
+
+	promptCtx := &Context{Preferences: map[string]interface{}{"{{.Name}}": "example instruction"}}
+	if !module.ShouldApply(ctx, promptCtx, messages) {
+		t.Fatalf("expected module to apply")
+	}
+	result, err := module.Apply(ctx, promptCtx, messages)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var found bool
+	for _, msg := range result {
+		if msg.Role == openai.ChatMessageRoleSystem && strings.Contains(msg.Content, "example instruction") {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a system message with the instruction, got %+v", result)
+	}
+
+	promptCtx.Preferences["disable_modules"] = []string{"{{.Name}}"}
+	if module.ShouldApply(ctx, promptCtx, messages) {
+		t.Fatalf("expected module not to apply when disabled")
+	}
+}
+
+func Test{{.Pascal}}ModulePriority(t *testing.T) {
+	if got := modulePriority(New{{.Pascal}}Module()); got != {{.Priority}} {
+		t.Fatalf("expected priority {{.Priority}}, got %d", got)
+	}
+}
+`
@@ -0,0 +1,244 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to a Jan Server with your browser",
+	Long: `Log in with the OAuth device flow: jan-cli shows a code, you approve it in a
+browser on any machine, and the resulting refresh token is stored in the OS
+keyring (macOS Keychain or the Secret Service), falling back to a file only you
+can read when no keyring is available.
+
+Once logged in, chat and admin commands authenticate with the stored login
+when neither --token nor $JAN_API_TOKEN is given. Logins are kept per --url.
+
+Examples:
+  jan-cli login
+  jan-cli login --url https://api.example.com`,
+	Args: cobra.NoArgs,
+	RunE: runLogin,
+}
+
+var logoutCmd = &cobra.Command{
+	Use:   "logout",
+	Short: "Forget the login stored for a Jan Server",
+	Long: `Revoke the session started by "jan-cli login" and remove its refresh token
+from the keyring.
+
+Examples:
+  jan-cli logout
+  jan-cli logout --url https://api.example.com`,
+	Args: cobra.NoArgs,
+	RunE: runLogout,
+}
+
+func init() {
+	loginCmd.Flags().String("url", "http://localhost:8000", "Gateway base URL")
+	logoutCmd.Flags().String("url", "http://localhost:8000", "Gateway base URL")
+}
+
+// deviceCode is the response of POST /auth/device/code
+type deviceCode struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// loginTokens is the response of the device and refresh token endpoints
+type loginTokens struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// oauthError is the error body of the device endpoints
+type oauthError struct {
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func runLogin(cmd *cobra.Command, args []string) error {
+	baseURL, _ := cmd.Flags().GetString("url")
+	baseURL = strings.TrimRight(baseURL, "/")
+	cmd.SilenceUsage = true
+
+	var code deviceCode
+	if _, err := postLogin(cmd.Context(), baseURL+"/auth/device/code", map[string]string{"scope": "offline_access"}, &code); err != nil {
+		return fmt.Errorf("start login: %w", err)
+	}
+
+	fmt.Println("To log in, open this page in a browser:")
+	fmt.Println()
+	if code.VerificationURIComplete != "" {
+		fmt.Printf("  %s\n\n", code.VerificationURIComplete)
+		fmt.Printf("and check that it shows the code %s.\n", code.UserCode)
+	} else {
+		fmt.Printf("  %s\n\n", code.VerificationURI)
+		fmt.Printf("and enter the code %s.\n", code.UserCode)
+	}
+	fmt.Println()
+	fmt.Println("Waiting for approval...")
+
+	tokens, err := pollDeviceLogin(cmd.Context(), baseURL, code)
+	if err != nil {
+		return err
+	}
+	if tokens.RefreshToken == "" {
+		return fmt.Errorf("the server issued no refresh token")
+	}
+
+	store := newCredentialStore()
+	if err := store.Set(baseURL, tokens.RefreshToken); err != nil {
+		return fmt.Errorf("store login in %s: %w", store.Name(), err)
+	}
+	if _, ok := store.(fileStore); ok {
+		fmt.Fprintf(os.Stderr, "Warning: no OS keyring found, the login is stored in %s\n", store.Name())
+	}
+	fmt.Printf("Logged in to %s\n", baseURL)
+	return nil
+}
+
+// pollDeviceLogin polls until the user approves or denies the login, or the code expires
+func pollDeviceLogin(ctx context.Context, baseURL string, code deviceCode) (*loginTokens, error) {
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+	deadline := time.Now().Add(time.Duration(code.ExpiresIn) * time.Second)
+	if code.ExpiresIn <= 0 {
+		deadline = time.Now().Add(10 * time.Minute)
+	}
+
+	for time.Now().Before(deadline) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var tokens loginTokens
+		oauthErr, err := postLogin(ctx, baseURL+"/auth/device/token", map[string]string{"device_code": code.DeviceCode}, &tokens)
+		if err == nil {
+			return &tokens, nil
+		}
+		if oauthErr == nil {
+			return nil, err
+		}
+		switch oauthErr.Code {
+		case "authorization_pending":
+		case "slow_down":
+			interval += 5 * time.Second
+		case "access_denied":
+			return nil, fmt.Errorf("login was denied")
+		case "expired_token":
+			return nil, fmt.Errorf("login code expired, run jan-cli login again")
+		default:
+			return nil, err
+		}
+	}
+	return nil, fmt.Errorf("login code expired, run jan-cli login again")
+}
+
+func runLogout(cmd *cobra.Command, args []string) error {
+	baseURL, _ := cmd.Flags().GetString("url")
+	baseURL = strings.TrimRight(baseURL, "/")
+	cmd.SilenceUsage = true
+
+	store := newCredentialStore()
+	refreshToken, err := store.Get(baseURL)
+	if errors.Is(err, errNoCredential) {
+		fmt.Printf("Not logged in to %s\n", baseURL)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("read login from %s: %w", store.Name(), err)
+	}
+
+	// Revoking is best effort: the local login is removed even when the server is down
+	if _, err := postLogin(cmd.Context(), baseURL+"/auth/logout", map[string]string{"refresh_token": refreshToken}, nil); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: could not revoke the session: %v\n", err)
+	}
+	if err := store.Delete(baseURL); err != nil && !errors.Is(err, errNoCredential) {
+		return fmt.Errorf("remove login from %s: %w", store.Name(), err)
+	}
+	fmt.Printf("Logged out of %s\n", baseURL)
+	return nil
+}
+
+// storedLoginToken returns an access token for the login stored for baseURL, saving the
+// rotated refresh token. It returns errNoCredential when there is no stored login.
+func storedLoginToken(ctx context.Context, baseURL string) (string, error) {
+	baseURL = strings.TrimRight(baseURL, "/")
+	store := newCredentialStore()
+	refreshToken, err := store.Get(baseURL)
+	if err != nil {
+		return "", err
+	}
+
+	var tokens loginTokens
+	if _, err := postLogin(ctx, baseURL+"/auth/refresh-token", map[string]string{"refresh_token": refreshToken}, &tokens); err != nil {
+		return "", fmt.Errorf("stored login expired, run jan-cli login again: %w", err)
+	}
+	if tokens.RefreshToken != "" && tokens.RefreshToken != refreshToken {
+		if err := store.Set(baseURL, tokens.RefreshToken); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not update the stored login: %v\n", err)
+		}
+	}
+	return tokens.AccessToken, nil
+}
+
+// postLogin sends payload as JSON and decodes a successful response into out. OAuth
+// errors are returned as well, so callers can tell pending logins from failures.
+func postLogin(ctx context.Context, url string, payload any, out any) (*oauthError, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	client := &http.Client{Timeout: 30 * time.Second}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode >= http.StatusBadRequest {
+		var oauthErr oauthError
+		if json.Unmarshal(body, &oauthErr) == nil && oauthErr.Code != "" {
+			if oauthErr.Description != "" {
+				return &oauthErr, fmt.Errorf("%s: %s", oauthErr.Code, oauthErr.Description)
+			}
+			return &oauthErr, errors.New(oauthErr.Code)
+		}
+		return nil, fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	if out == nil {
+		return nil, nil
+	}
+	return nil, json.Unmarshal(body, out)
+}
@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/janhq/jan-server/packages/go-common/monitoring"
 	"github.com/spf13/cobra"
 )
 
@@ -28,6 +29,7 @@ Examples:
   jan-cli monitor test        # Validate all services are healthy
   jan-cli monitor status      # Show status and resource usage
   jan-cli monitor query       # Interactive queries
+  jan-cli monitor generate    # Regenerate SLO rules and RED dashboards
   jan-cli monitor down        # Stop monitoring stack`,
 }
 
@@ -79,6 +81,13 @@ var monitorExportCmd = &cobra.Command{
 	Run:   runMonitorExport,
 }
 
+var monitorGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Generate Prometheus SLO rules and Grafana dashboards from code",
+	Long:  `Render burn-rate alert rules and RED/model dashboards from the registry in packages/go-common/monitoring.`,
+	RunE:  runMonitorGenerate,
+}
+
 var monitorSetupCmd = &cobra.Command{
 	Use:   "setup",
 	Short: "Install monitoring dependencies",
@@ -95,6 +104,31 @@ func init() {
 	monitorCmd.AddCommand(monitorQueryCmd)
 	monitorCmd.AddCommand(monitorExportCmd)
 	monitorCmd.AddCommand(monitorSetupCmd)
+	monitorCmd.AddCommand(monitorGenerateCmd)
+
+	monitorGenerateCmd.Flags().StringP("output", "o", filepath.Join("integrations", "monitoring"), "Monitoring directory to write into")
+}
+
+func runMonitorGenerate(cmd *cobra.Command, args []string) error {
+	outputDir, err := resolveOutputDir(cmd)
+	if err != nil {
+		return fmt.Errorf("resolve output directory: %w", err)
+	}
+
+	fmt.Println("Generating monitoring assets from packages/go-common/monitoring...")
+
+	rulesPath := filepath.Join(outputDir, "prometheus-slo-rules.yml")
+	if err := monitoring.GeneratePrometheusRules(rulesPath); err != nil {
+		return fmt.Errorf("generate prometheus rules: %w", err)
+	}
+
+	dashboardDir := filepath.Join(outputDir, "grafana", "provisioning", "dashboards", "json")
+	if err := monitoring.GenerateDashboards(dashboardDir); err != nil {
+		return fmt.Errorf("generate dashboards: %w", err)
+	}
+
+	fmt.Println("✓ Monitoring generation complete!")
+	return nil
 }
 
 func runMonitorUp(cmd *cobra.Command, args []string) {
@@ -0,0 +1,158 @@
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var providerCmd = &cobra.Command{
+	Use:   "provider",
+	Short: "Check model providers of a running deployment",
+	Long: `Check model providers through the llm-api admin API.
+
+Requests are authenticated with an admin user's access token or API key,
+taken from --token or $JAN_API_TOKEN.`,
+}
+
+var providerTestCmd = &cobra.Command{
+	Use:   "test <provider-id>",
+	Short: "Validate a provider's credentials with live requests",
+	Long: `Validate a provider end to end and report latency and detected capabilities.
+
+Three checks run in order:
+  auth        list the provider's models, which fails on a wrong API key
+  completion  a minimal non-streaming chat completion
+  stream      a minimal streaming chat completion, timing the first token
+
+The completion checks use --model, else an active model of the provider,
+else the first model the provider lists. Exits non-zero when any check fails.
+
+Examples:
+  jan-cli provider test prov_abc123
+  jan-cli provider test prov_abc123 --model gpt-4o-mini`,
+	Args: cobra.ExactArgs(1),
+	RunE: runProviderTest,
+}
+
+func init() {
+	providerCmd.AddCommand(providerTestCmd)
+
+	providerCmd.PersistentFlags().String("url", "http://localhost:8000", "Gateway base URL")
+	providerCmd.PersistentFlags().String("token", "", "Admin access token or API key (default: $JAN_API_TOKEN)")
+	providerCmd.PersistentFlags().Bool("json", false, "Print the raw JSON response")
+
+	providerTestCmd.Flags().String("model", "", "Provider model ID to send completions to")
+}
+
+func runProviderTest(cmd *cobra.Command, args []string) error {
+	model, _ := cmd.Flags().GetString("model")
+	cmd.SilenceUsage = true // failed checks are not usage errors
+
+	var resp struct {
+		ProviderID string `json:"provider_id"`
+		Name       string `json:"name"`
+		Vendor     string `json:"vendor"`
+		BaseURL    string `json:"base_url"`
+		Model      string `json:"model"`
+		OK         bool   `json:"ok"`
+		Steps      []struct {
+			Name             string `json:"name"`
+			OK               bool   `json:"ok"`
+			Skipped          bool   `json:"skipped"`
+			LatencyMs        int64  `json:"latency_ms"`
+			TimeToFirstToken *int64 `json:"time_to_first_token_ms"`
+			Detail           string `json:"detail"`
+			Error            string `json:"error"`
+		} `json:"steps"`
+		Capabilities struct {
+			ModelsListed        int  `json:"models_listed"`
+			Streaming           bool `json:"streaming"`
+			UsageReported       bool `json:"usage_reported"`
+			StreamUsageReported bool `json:"stream_usage_reported"`
+			Cataloged           bool `json:"cataloged"`
+			SupportsTools       bool `json:"supports_tools"`
+			SupportsImages      bool `json:"supports_images"`
+			SupportsReasoning   bool `json:"supports_reasoning"`
+			SupportsAudio       bool `json:"supports_audio"`
+			ContextLength       *int `json:"context_length"`
+		} `json:"capabilities"`
+	}
+	path := "/v1/admin/providers/" + url.PathEscape(args[0]) + "/test"
+	payload := map[string]string{"model": model}
+	done, err := adminRequest(cmd, http.MethodPost, path, payload, 3*time.Minute, &resp)
+	if err != nil {
+		return err
+	}
+	if !done {
+		fmt.Printf("Provider:  %s (%s) %s\n", resp.Name, resp.Vendor, resp.BaseURL)
+		if resp.Model != "" {
+			fmt.Printf("Model:     %s\n", resp.Model)
+		}
+		fmt.Println()
+		for _, step := range resp.Steps {
+			switch {
+			case step.Skipped:
+				printWarning("%-10s skipped: %s", step.Name, step.Detail)
+			case step.OK:
+				line := fmt.Sprintf("%-10s %dms", step.Name, step.LatencyMs)
+				if step.TimeToFirstToken != nil {
+					line += fmt.Sprintf(" (first token %dms)", *step.TimeToFirstToken)
+				}
+				if step.Detail != "" {
+					line += ", " + step.Detail
+				}
+				printSuccess("%s", line)
+			default:
+				printError("%-10s %dms: %s", step.Name, step.LatencyMs, step.Error)
+			}
+		}
+
+		caps := resp.Capabilities
+		observed := []string{fmt.Sprintf("%d model(s) listed", caps.ModelsListed)}
+		if caps.Streaming {
+			observed = append(observed, "streaming")
+		}
+		if caps.UsageReported {
+			observed = append(observed, "usage")
+		}
+		if caps.StreamUsageReported {
+			observed = append(observed, "stream usage")
+		}
+		fmt.Printf("\nObserved:  %s\n", strings.Join(observed, ", "))
+		if caps.Cataloged {
+			var catalog []string
+			for _, c := range []struct {
+				name      string
+				supported bool
+			}{
+				{"tools", caps.SupportsTools},
+				{"images", caps.SupportsImages},
+				{"reasoning", caps.SupportsReasoning},
+				{"audio", caps.SupportsAudio},
+			} {
+				if c.supported {
+					catalog = append(catalog, c.name)
+				}
+			}
+			if len(catalog) == 0 {
+				catalog = append(catalog, "text only")
+			}
+			if caps.ContextLength != nil {
+				catalog = append(catalog, fmt.Sprintf("%d token context", *caps.ContextLength))
+			}
+			fmt.Printf("Catalog:   %s\n", strings.Join(catalog, ", "))
+		} else if resp.Model != "" {
+			fmt.Println("Catalog:   model not in the catalog, sync the provider to detect capabilities")
+		}
+	}
+
+	if !resp.OK {
+		return fmt.Errorf("provider %s failed its checks", args[0])
+	}
+	return nil
+}
@@ -1,7 +1,15 @@
 ﻿package main
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
 
 	"github.com/spf13/cobra"
 )
@@ -20,11 +28,20 @@ var serviceListCmd = &cobra.Command{
 }
 
 var serviceLogsCmd = &cobra.Command{
-	Use:   "logs [service]",
+	Use:   "logs [service...]",
 	Short: "Show service logs",
-	Long:  `Display logs for a specific service.`,
-	RunE:  runServiceLogs,
-	Args:  cobra.MinimumNArgs(1),
+	Long: `Display logs of one or more services, merged and colored by service.
+
+Without a service, the application services (llm-api, response-api,
+media-api, mcp-tools) are shown. zerolog JSON lines are pretty-printed;
+the correlation filters match their request_id, user_id and
+conversation_id fields, or the raw text of non-JSON lines.
+
+Examples:
+  jan-cli service logs llm-api
+  jan-cli service logs -f --request-id 7f3c9a
+  jan-cli service logs llm-api response-api mcp-tools --conversation-id conv_123`,
+	RunE: runServiceLogs,
 }
 
 var serviceStatusCmd = &cobra.Command{
@@ -34,14 +51,38 @@ var serviceStatusCmd = &cobra.Command{
 	RunE:  runServiceStatus,
 }
 
+var serviceHealthCmd = &cobra.Command{
+	Use:   "health [service...]",
+	Short: "Aggregate dependency readiness across services",
+	Long: `Query /readyz on every service and print per-dependency status.
+
+Exits non-zero when any service is unreachable or reports not_ready.
+
+Examples:
+  jan-cli service health
+  jan-cli service health llm-api response-api
+  jan-cli service health --json`,
+	RunE: runServiceHealth,
+}
+
 func init() {
 	serviceCmd.AddCommand(serviceListCmd)
 	serviceCmd.AddCommand(serviceLogsCmd)
 	serviceCmd.AddCommand(serviceStatusCmd)
+	serviceCmd.AddCommand(serviceHealthCmd)
 
 	// logs flags
-	serviceLogsCmd.Flags().IntP("tail", "n", 100, "Number of lines to show")
+	serviceLogsCmd.Flags().IntP("tail", "n", 100, "Number of lines to show per service, before filtering (0 for all)")
 	serviceLogsCmd.Flags().BoolP("follow", "f", false, "Follow log output")
+	serviceLogsCmd.Flags().String("request-id", "", "Only lines for this request ID")
+	serviceLogsCmd.Flags().String("user-id", "", "Only lines for this user ID")
+	serviceLogsCmd.Flags().String("conversation-id", "", "Only lines for this conversation ID")
+	serviceLogsCmd.Flags().Bool("raw", false, "Print lines as emitted instead of pretty-printing JSON")
+	serviceLogsCmd.Flags().Bool("no-color", false, "Disable colors (also disabled by NO_COLOR or when not a terminal)")
+
+	// health flags
+	serviceHealthCmd.Flags().Bool("json", false, "Print the aggregated report as JSON")
+	serviceHealthCmd.Flags().Duration("timeout", 10*time.Second, "Per-service request timeout")
 }
 
 func runServiceList(cmd *cobra.Command, args []string) error {
@@ -64,25 +105,6 @@ func runServiceList(cmd *cobra.Command, args []string) error {
 	return nil
 }
 
-func runServiceLogs(cmd *cobra.Command, args []string) error {
-	service := args[0]
-	tail, _ := cmd.Flags().GetInt("tail")
-	follow, _ := cmd.Flags().GetBool("follow")
-
-	fmt.Printf("Showing logs for %s\n", service)
-	fmt.Println()
-
-	// Build docker compose logs command
-	cmdArgs := []string{"compose", "logs"}
-	if follow {
-		cmdArgs = append(cmdArgs, "-f")
-	}
-	cmdArgs = append(cmdArgs, "--tail", fmt.Sprintf("%d", tail))
-	cmdArgs = append(cmdArgs, service)
-
-	return execCommand("docker", cmdArgs...)
-}
-
 func runServiceStatus(cmd *cobra.Command, args []string) error {
 	service := ""
 	if len(args) > 0 {
@@ -117,3 +139,135 @@ func runServiceStatus(cmd *cobra.Command, args []string) error {
 		return nil
 	}
 }
+
+// serviceReadiness mirrors the /readyz body returned by each service.
+type serviceReadiness struct {
+	Service      string                      `json:"service"`
+	Status       string                      `json:"status"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+	Dependencies map[string]dependencyHealth `json:"dependencies,omitempty"`
+	URL          string                      `json:"url"`
+	HTTPStatus   int                         `json:"http_status,omitempty"`
+	Error        string                      `json:"error,omitempty"`
+}
+
+type dependencyHealth struct {
+	Status    string `json:"status"`
+	Critical  bool   `json:"critical"`
+	LatencyMs int64  `json:"latency_ms"`
+	Error     string `json:"error,omitempty"`
+}
+
+func runServiceHealth(cmd *cobra.Command, args []string) error {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	timeout, _ := cmd.Flags().GetDuration("timeout")
+
+	targets := args
+	if len(targets) == 0 {
+		for name := range readinessURLs() {
+			targets = append(targets, name)
+		}
+	}
+	sort.Strings(targets)
+
+	results := make([]serviceReadiness, len(targets))
+	client := &http.Client{Timeout: timeout}
+	var wg sync.WaitGroup
+	for i, name := range targets {
+		url := getReadyURL(name)
+		if url == "" {
+			return fmt.Errorf("unknown service: %s", name)
+		}
+		wg.Add(1)
+		go func(i int, name, url string) {
+			defer wg.Done()
+			results[i] = fetchReadiness(cmd.Context(), client, name, url)
+		}(i, name, url)
+	}
+	wg.Wait()
+
+	healthy := true
+	for _, r := range results {
+		if r.Status != "ready" && r.Status != "degraded" {
+			healthy = false
+		}
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(results); err != nil {
+			return err
+		}
+	} else {
+		printReadiness(results)
+	}
+
+	if !healthy {
+		return fmt.Errorf("one or more services are not ready")
+	}
+	return nil
+}
+
+func fetchReadiness(ctx context.Context, client *http.Client, name, url string) serviceReadiness {
+	result := serviceReadiness{Service: name, URL: url, Status: "unreachable"}
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	defer resp.Body.Close()
+
+	result.HTTPStatus = resp.StatusCode
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		result.Status = "unknown"
+		result.Error = fmt.Sprintf("decode response: %v", err)
+	}
+	// Keep the CLI name even if the service reports its configured SERVICE_NAME
+	result.Service = name
+	return result
+}
+
+func printReadiness(results []serviceReadiness) {
+	fmt.Println("=== Service Readiness ===")
+	fmt.Println()
+	for _, r := range results {
+		switch r.Status {
+		case "ready":
+			printSuccess("%-15s %s", r.Service, r.Status)
+		case "degraded":
+			printWarning("%-15s %s", r.Service, r.Status)
+		default:
+			printError("%-15s %s", r.Service, r.Status)
+		}
+		if r.Error != "" {
+			fmt.Printf("    %s\n", r.Error)
+		}
+
+		names := make([]string, 0, len(r.Dependencies))
+		for dep := range r.Dependencies {
+			names = append(names, dep)
+		}
+		sort.Strings(names)
+		for _, dep := range names {
+			d := r.Dependencies[dep]
+			line := fmt.Sprintf("    %-28s %-5s %5dms", dep, d.Status, d.LatencyMs)
+			if !d.Critical {
+				line += " (optional)"
+			}
+			if d.Error != "" {
+				line += "  " + strings.TrimSpace(d.Error)
+			}
+			fmt.Println(line)
+		}
+	}
+}
@@ -0,0 +1,304 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultLogServices are tailed when service logs is given no service
+var defaultLogServices = []string{"llm-api", "response-api", "media-api", "mcp-tools"}
+
+// logServiceColors are the ANSI colors assigned to services in argument order
+var logServiceColors = []string{"36", "35", "33", "32", "34", "96", "95", "93"}
+
+// logFilterFields are the zerolog fields each correlation filter matches
+var logFilterFields = map[string][]string{
+	"request-id":      {"request_id", "x_request_id"},
+	"user-id":         {"user_id"},
+	"conversation-id": {"conversation_id", "conv_id"},
+}
+
+// logLine is one line of a service's docker output
+type logLine struct {
+	service string
+	at      time.Time
+	text    string
+}
+
+// logFilter keeps lines whose zerolog fields, or text for non-JSON lines,
+// match every given value
+type logFilter map[string]string // field alias key -> wanted value
+
+func (f logFilter) matches(fields map[string]any, text string) bool {
+	for key, want := range f {
+		if fields == nil {
+			if !strings.Contains(text, want) {
+				return false
+			}
+			continue
+		}
+		found := false
+		for _, name := range logFilterFields[key] {
+			if value, ok := fields[name]; ok && fmt.Sprint(value) == want {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// logPrinter renders lines with a colored service prefix, pretty-printing
+// zerolog JSON unless raw output was requested
+type logPrinter struct {
+	out    io.Writer
+	color  bool
+	raw    bool
+	width  int
+	colors map[string]string
+	filter logFilter
+}
+
+func runServiceLogs(cmd *cobra.Command, args []string) error {
+	tail, _ := cmd.Flags().GetInt("tail")
+	follow, _ := cmd.Flags().GetBool("follow")
+	raw, _ := cmd.Flags().GetBool("raw")
+	noColor, _ := cmd.Flags().GetBool("no-color")
+
+	services := args
+	if len(services) == 0 {
+		services = defaultLogServices
+	}
+	filter := logFilter{}
+	for key := range logFilterFields {
+		if value, _ := cmd.Flags().GetString(key); value != "" {
+			filter[key] = value
+		}
+	}
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return err
+	}
+
+	printer := &logPrinter{
+		out:    os.Stdout,
+		color:  !noColor && os.Getenv("NO_COLOR") == "" && isTerminal(os.Stdout),
+		raw:    raw,
+		colors: map[string]string{},
+		filter: filter,
+	}
+	for i, service := range services {
+		printer.width = max(printer.width, len(service))
+		printer.colors[service] = logServiceColors[i%len(logServiceColors)]
+	}
+
+	lines := make(chan logLine, 256)
+	var wg sync.WaitGroup
+	for _, service := range services {
+		wg.Add(1)
+		go func(service string) {
+			defer wg.Done()
+			if err := streamServiceLogs(root, service, tail, follow, lines); err != nil {
+				printWarning("%s: %v", service, err)
+			}
+		}(service)
+	}
+	go func() {
+		wg.Wait()
+		close(lines)
+	}()
+
+	if follow {
+		for line := range lines {
+			printer.print(line)
+		}
+		return nil
+	}
+
+	// Without --follow every service has finished; merge them in time order
+	var collected []logLine
+	for line := range lines {
+		collected = append(collected, line)
+	}
+	sort.SliceStable(collected, func(i, j int) bool { return collected[i].at.Before(collected[j].at) })
+	for _, line := range collected {
+		printer.print(line)
+	}
+	return nil
+}
+
+// streamServiceLogs sends a service's log lines, timestamped by docker, to lines
+func streamServiceLogs(root, service string, tail int, follow bool, lines chan<- logLine) error {
+	tailArg := "all"
+	if tail > 0 {
+		tailArg = strconv.Itoa(tail)
+	}
+	args := []string{"compose", "logs", "--no-color", "--no-log-prefix", "--timestamps", "--tail", tailArg}
+	if follow {
+		args = append(args, "--follow")
+	}
+	cmd := exec.Command("docker", append(args, service)...)
+	cmd.Dir = root
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := logLine{service: service, text: scanner.Text()}
+		if stamp, rest, found := strings.Cut(line.text, " "); found {
+			if at, err := time.Parse(time.RFC3339Nano, stamp); err == nil {
+				line.at, line.text = at, rest
+			}
+		}
+		lines <- line
+	}
+	if err := cmd.Wait(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%w: %s", err, msg)
+		}
+		return err
+	}
+	return nil
+}
+
+func (p *logPrinter) print(line logLine) {
+	fields := parseLogFields(line.text)
+	if !p.filter.matches(fields, line.text) {
+		return
+	}
+
+	prefix := fmt.Sprintf("%-*s |", p.width, line.service)
+	if p.color {
+		prefix = "\033[" + p.colors[line.service] + "m" + prefix + "\033[0m"
+	}
+	if p.raw || fields == nil {
+		fmt.Fprintf(p.out, "%s %s\n", prefix, line.text)
+		return
+	}
+	fmt.Fprintf(p.out, "%s %s\n", prefix, p.pretty(line.at, fields))
+}
+
+// pretty renders a zerolog entry as "time LVL message key=value ..."
+func (p *logPrinter) pretty(at time.Time, fields map[string]any) string {
+	var b strings.Builder
+	if at.IsZero() {
+		if stamp, ok := fields["time"].(string); ok {
+			at, _ = time.Parse(time.RFC3339Nano, stamp)
+		}
+	}
+	if !at.IsZero() {
+		b.WriteString(at.Local().Format("15:04:05.000") + " ")
+	}
+
+	level, _ := fields["level"].(string)
+	b.WriteString(p.level(level) + " ")
+	message, _ := fields["message"].(string)
+	b.WriteString(message)
+
+	keys := make([]string, 0, len(fields))
+	for key := range fields {
+		switch key {
+		case "time", "level", "message":
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		value := formatLogValue(fields[key])
+		if !p.color {
+			b.WriteString(" " + key + "=" + value)
+			continue
+		}
+		if key == "error" {
+			value = "\033[31m" + value + "\033[0m"
+		}
+		b.WriteString(" \033[2m" + key + "=\033[0m" + value)
+	}
+	return b.String()
+}
+
+// formatLogValue quotes strings only where needed and keeps nested values as JSON
+func formatLogValue(value any) string {
+	switch v := value.(type) {
+	case string:
+		if v == "" || strings.ContainsAny(v, " \t\"=") {
+			return strconv.Quote(v)
+		}
+		return v
+	case json.Number:
+		return v.String()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+// level abbreviates a zerolog level the way zerolog's console writer does
+func (p *logPrinter) level(level string) string {
+	abbrev, color := "???", "0"
+	switch level {
+	case "trace":
+		abbrev, color = "TRC", "35"
+	case "debug":
+		abbrev, color = "DBG", "33"
+	case "info":
+		abbrev, color = "INF", "32"
+	case "warn":
+		abbrev, color = "WRN", "31"
+	case "error":
+		abbrev, color = "ERR", "1;31"
+	case "fatal", "panic":
+		abbrev, color = strings.ToUpper(level[:3]), "1;31"
+	}
+	if !p.color {
+		return abbrev
+	}
+	return "\033[" + color + "m" + abbrev + "\033[0m"
+}
+
+// parseLogFields decodes a zerolog JSON line; other lines return nil
+func parseLogFields(text string) map[string]any {
+	if !strings.HasPrefix(strings.TrimSpace(text), "{") {
+		return nil
+	}
+	decoder := json.NewDecoder(strings.NewReader(text))
+	decoder.UseNumber()
+	var fields map[string]any
+	if err := decoder.Decode(&fields); err != nil {
+		return nil
+	}
+	return fields
+}
+
+// isTerminal reports whether f is attached to a terminal
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,519 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var setupCmd = &cobra.Command{
+	Use:   "setup",
+	Short: "Unattended setup from a profile, for CI and infrastructure as code",
+	Long: `Render .env from a setup profile and an optional answers file, generate
+secrets, and start the services without prompting. For the interactive
+wizard use 'jan-cli setup-and-run'.
+
+Profiles:
+  minimal  Remote OpenAI-compatible provider, SearXNG search, core services only
+  gpu      Local vLLM (needs a GPU and HF token), SearXNG search, local media storage
+  full     gpu plus memory tools, monitoring and the platform app
+
+The answers file overrides the profile. ${VAR} references in it are expanded
+from the environment, so secrets can come from CI variables:
+
+  provider:
+    type: remote                      # vllm or remote
+    url: https://api.openai.com/v1
+    api_key: ${OPENAI_API_KEY}
+  search:
+    engine: serper                    # serper, searxng or none
+    serper_api_key: ${SERPER_API_KEY}
+  memory:
+    enabled: true
+  env:                                # Raw .env values, applied last
+    LOG_LEVEL: debug
+
+Secrets still holding their template placeholders (database and Keycloak admin
+passwords, MODEL_PROVIDER_SECRET, ...) are generated when .env is created, or
+taken from the answers file's secrets section.
+
+Examples:
+  jan-cli setup --profile minimal --non-interactive --answers-file ci/jan.yml
+  jan-cli setup --profile gpu --non-interactive --answers-file prod.yml --no-start`,
+	RunE: runSetup,
+}
+
+func init() {
+	setupCmd.Flags().String("profile", "", "Setup profile: minimal, gpu or full (default: answers file profile, else minimal)")
+	setupCmd.Flags().Bool("non-interactive", false, "Run without prompts (required)")
+	setupCmd.Flags().String("answers-file", "", "YAML file with answers that override the profile")
+	setupCmd.Flags().String("env-file", ".env", "Environment file to render")
+	setupCmd.Flags().Bool("no-start", false, "Only render the environment file; do not start services")
+	setupCmd.Flags().Duration("wait", 5*time.Minute, "How long to wait for services to become ready (0 skips the check)")
+}
+
+// setupAnswers is the answers file of 'jan-cli setup'. Unset fields take the
+// profile's defaults.
+type setupAnswers struct {
+	Profile  string `yaml:"profile"`
+	Provider struct {
+		Type    string `yaml:"type"` // vllm or remote
+		URL     string `yaml:"url"`  // remote: OpenAI-compatible base URL
+		APIKey  string `yaml:"api_key"`
+		HFToken string `yaml:"hf_token"` // vllm
+	} `yaml:"provider"`
+	Search struct {
+		Engine       string `yaml:"engine"` // serper, searxng or none
+		SerperAPIKey string `yaml:"serper_api_key"`
+	} `yaml:"search"`
+	Memory struct {
+		Enabled      *bool  `yaml:"enabled"`
+		EmbeddingURL string `yaml:"embedding_url"` // Empty uses the bundled BGE-M3 mock
+		RedisURL     string `yaml:"redis_url"`     // Empty keeps the embedding cache in memory
+	} `yaml:"memory"`
+	Media struct {
+		Enabled *bool  `yaml:"enabled"`
+		Storage string `yaml:"storage"` // local or s3
+		S3      struct {
+			Endpoint        string `yaml:"endpoint"`
+			Bucket          string `yaml:"bucket"`
+			AccessKeyID     string `yaml:"access_key_id"`
+			SecretAccessKey string `yaml:"secret_access_key"`
+			Region          string `yaml:"region"`
+			PublicEndpoint  string `yaml:"public_endpoint"` // Set to return public S3 URLs
+		} `yaml:"s3"`
+	} `yaml:"media"`
+	Realtime struct {
+		Enabled   *bool  `yaml:"enabled"`
+		WSURL     string `yaml:"ws_url"`
+		APIKey    string `yaml:"api_key"`
+		APISecret string `yaml:"api_secret"`
+	} `yaml:"realtime"`
+	Monitoring *bool             `yaml:"monitoring"`
+	Platform   *bool             `yaml:"platform"`
+	Secrets    map[string]string `yaml:"secrets"` // Values for the generated secrets
+	Env        map[string]string `yaml:"env"`
+}
+
+// setupProfile holds the defaults a profile gives unanswered questions
+type setupProfile struct {
+	provider   string
+	memory     bool
+	media      bool
+	monitoring bool
+	platform   bool
+}
+
+var setupProfiles = map[string]setupProfile{
+	"minimal": {provider: "remote"},
+	"gpu":     {provider: "vllm", media: true},
+	"full":    {provider: "vllm", memory: true, media: true, monitoring: true, platform: true},
+}
+
+// generatedSecret is a .env secret setup fills in while it holds the template placeholder
+type generatedSecret struct {
+	keys        []string // Keys sharing one value
+	placeholder string
+}
+
+var generatedSecrets = []generatedSecret{
+	{keys: []string{"POSTGRES_PASSWORD"}, placeholder: "jan_password"},
+	{keys: []string{"KEYCLOAK_ADMIN_PASSWORD"}, placeholder: "admin"},
+	{keys: []string{"MODEL_PROVIDER_SECRET"}, placeholder: "jan-model-provider-secret-2024"},
+	{keys: []string{"VLLM_INTERNAL_KEY"}, placeholder: "changeme"},
+	{keys: []string{"MEDIA_SERVICE_KEY", "MEDIA_API_KEY"}, placeholder: "changeme-media-key"},
+	{keys: []string{"GRAFANA_ADMIN_PASSWORD"}, placeholder: "admin"},
+}
+
+// setupPlan is the rendered outcome of a profile and its answers
+type setupPlan struct {
+	updates    map[string]string
+	services   []string // Services whose readiness is awaited after start
+	monitoring bool
+}
+
+func runSetup(cmd *cobra.Command, args []string) error {
+	nonInteractive, _ := cmd.Flags().GetBool("non-interactive")
+	profileName, _ := cmd.Flags().GetString("profile")
+	answersFile, _ := cmd.Flags().GetString("answers-file")
+	envPath, _ := cmd.Flags().GetString("env-file")
+	noStart, _ := cmd.Flags().GetBool("no-start")
+	wait, _ := cmd.Flags().GetDuration("wait")
+
+	if !nonInteractive {
+		return fmt.Errorf("setup only runs unattended: pass --non-interactive, or use 'jan-cli setup-and-run' for the wizard")
+	}
+
+	answers := &setupAnswers{}
+	if answersFile != "" {
+		loaded, err := loadSetupAnswers(answersFile)
+		if err != nil {
+			return err
+		}
+		answers = loaded
+	}
+	if profileName == "" {
+		profileName = answers.Profile
+	}
+	if profileName == "" {
+		profileName = "minimal"
+	}
+	profile, ok := setupProfiles[profileName]
+	if !ok {
+		return fmt.Errorf("unknown profile %q: must be minimal, gpu or full", profileName)
+	}
+
+	fmt.Printf("Jan Server setup (profile: %s)\n", profileName)
+	fmt.Println()
+
+	plan, err := renderSetupPlan(profile, answers)
+	if err != nil {
+		return fmt.Errorf("profile %s: %w", profileName, err)
+	}
+
+	created := false
+	if _, err := os.Stat(envPath); os.IsNotExist(err) {
+		if err := copyEnvTemplate(envPath); err != nil {
+			return fmt.Errorf("failed to copy .env template: %w", err)
+		}
+		created = true
+		printSuccess("Created %s from .env.template", envPath)
+	}
+
+	generated, err := resolveSetupSecrets(envPath, created, answers.Secrets, plan.updates)
+	if err != nil {
+		return err
+	}
+	for key, value := range answers.Env {
+		plan.updates[key] = value
+	}
+	if err := applyEnvUpdates(envPath, plan.updates); err != nil {
+		return err
+	}
+	if created || len(generated) > 0 {
+		// The file now holds generated credentials
+		if err := os.Chmod(envPath, 0600); err != nil {
+			printWarning("Could not restrict permissions on %s: %v", envPath, err)
+		}
+	}
+	printSuccess("Rendered %s (%d values)", envPath, len(plan.updates))
+	if len(generated) > 0 {
+		printSuccess("Generated secrets: %s", strings.Join(generated, ", "))
+	}
+
+	if noStart {
+		fmt.Println()
+		fmt.Println("Skipping service start (--no-start). Start later with: make up-full")
+		return nil
+	}
+
+	fmt.Println()
+	if err := execCommand("make", "setup"); err != nil {
+		return fmt.Errorf("setup failed: %w", err)
+	}
+	if err := execCommand("make", "up-full"); err != nil {
+		return fmt.Errorf("failed to start services: %w", err)
+	}
+	if plan.monitoring {
+		if err := execCommand("make", "monitor-up"); err != nil {
+			return fmt.Errorf("failed to start monitoring stack: %w", err)
+		}
+	}
+
+	if wait <= 0 {
+		return nil
+	}
+	fmt.Println()
+	fmt.Printf("Waiting up to %s for services to become ready...\n", wait)
+	ctx, cancel := context.WithTimeout(cmd.Context(), wait)
+	defer cancel()
+	if err := waitForServices(ctx, plan.services); err != nil {
+		return err
+	}
+	printSuccess("Jan Server is ready at http://localhost:8000")
+	return nil
+}
+
+// loadSetupAnswers reads an answers file, expanding ${VAR} references from the environment
+func loadSetupAnswers(path string) (*setupAnswers, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read answers file: %w", err)
+	}
+	var answers setupAnswers
+	decoder := yaml.NewDecoder(strings.NewReader(os.ExpandEnv(string(data))))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(&answers); err != nil {
+		return nil, fmt.Errorf("parse answers file %s: %w", path, err)
+	}
+	return &answers, nil
+}
+
+// renderSetupPlan turns a profile and its answers into .env updates, mirroring
+// what the setup-and-run wizard writes for the same choices
+func renderSetupPlan(profile setupProfile, answers *setupAnswers) (*setupPlan, error) {
+	updates := make(map[string]string)
+	profiles := []string{"infra", "api", "mcp", "web"}
+	services := []string{"llm-api", "response-api", "mcp-tools"}
+	updates["JAN_PROVIDER_CONFIGS"] = "true"
+
+	// LLM provider
+	providerType := strings.ToLower(strings.TrimSpace(answers.Provider.Type))
+	if providerType == "" {
+		providerType = profile.provider
+	}
+	switch providerType {
+	case "vllm":
+		if answers.Provider.HFToken == "" {
+			return nil, fmt.Errorf("local vLLM needs provider.hf_token")
+		}
+		updates["HF_TOKEN"] = answers.Provider.HFToken
+		updates["VLLM_ENABLED"] = "true"
+		updates["REMOTE_LLM_ENABLED"] = "false"
+		updates["VLLM_PROVIDER_URL"] = "http://vllm-jan-gpu:8001/v1"
+		updates["VLLM_TOOL_SUPPORT"] = "true"
+		profiles = append(profiles, "full")
+	case "remote":
+		if answers.Provider.URL == "" {
+			return nil, fmt.Errorf("a remote provider needs provider.url")
+		}
+		updates["VLLM_ENABLED"] = "false"
+		updates["REMOTE_LLM_ENABLED"] = "true"
+		updates["REMOTE_LLM_PROVIDER_URL"] = answers.Provider.URL
+		updates["REMOTE_API_KEY"] = answers.Provider.APIKey
+		updates["HF_TOKEN"] = "not_required_for_remote_provider"
+	default:
+		return nil, fmt.Errorf("unknown provider.type %q: must be vllm or remote", answers.Provider.Type)
+	}
+
+	// MCP search
+	engine := strings.ToLower(strings.TrimSpace(answers.Search.Engine))
+	if engine == "" {
+		engine = "searxng"
+		if answers.Search.SerperAPIKey != "" {
+			engine = "serper"
+		}
+	}
+	switch engine {
+	case "serper":
+		if answers.Search.SerperAPIKey == "" {
+			return nil, fmt.Errorf("serper search needs search.serper_api_key")
+		}
+		updates["SEARCH_ENGINE"] = "serper"
+		updates["SERPER_API_KEY"] = answers.Search.SerperAPIKey
+	case "searxng":
+		updates["SEARCH_ENGINE"] = "searxng"
+		updates["SERPER_API_KEY"] = "not_required_for_searxng"
+	case "none":
+		updates["SEARCH_ENGINE"] = "none"
+		updates["SERPER_API_KEY"] = "mcp_search_disabled"
+	default:
+		return nil, fmt.Errorf("unknown search.engine %q: must be serper, searxng or none", answers.Search.Engine)
+	}
+
+	// Memory tools
+	enableMemory := boolAnswer(answers.Memory.Enabled, profile.memory)
+	externalEmbedding := answers.Memory.EmbeddingURL != ""
+	useRedis := answers.Memory.RedisURL != ""
+	if externalEmbedding {
+		updates["EMBEDDING_SERVICE_URL"] = answers.Memory.EmbeddingURL
+	}
+	applyMemorySettings(updates, &profiles, enableMemory, externalEmbedding, useRedis)
+	if enableMemory {
+		services = append(services, "memory-tools")
+		if useRedis {
+			updates["EMBEDDING_CACHE_TYPE"] = "redis"
+			updates["EMBEDDING_CACHE_REDIS_URL"] = answers.Memory.RedisURL
+		}
+	}
+
+	// Media API
+	if boolAnswer(answers.Media.Enabled, profile.media) {
+		storage := strings.ToLower(strings.TrimSpace(answers.Media.Storage))
+		if storage == "" {
+			storage = "local"
+			if providerType == "remote" {
+				// Remote models cannot fetch local media URLs
+				storage = "s3"
+			}
+		}
+		updates["MEDIA_API_ENABLED"] = "true"
+		updates["MEDIA_API_URL"] = "http://media-api:8285"
+		updates["MEDIA_RESOLVE_URL"] = "http://media-api:8285/v1/media/resolve"
+		switch storage {
+		case "local":
+			updates["MEDIA_STORAGE_BACKEND"] = "local"
+			updates["MEDIA_LOCAL_STORAGE_PATH"] = "./media-data"
+			updates["MEDIA_LOCAL_STORAGE_BASE_URL"] = "http://localhost:8285/v1/files"
+		case "s3":
+			s3 := answers.Media.S3
+			if s3.Endpoint == "" || s3.Bucket == "" || s3.AccessKeyID == "" || s3.SecretAccessKey == "" {
+				return nil, fmt.Errorf("s3 media storage needs media.s3.endpoint, bucket, access_key_id and secret_access_key")
+			}
+			region := s3.Region
+			if region == "" {
+				region = "us-west-2"
+			}
+			updates["MEDIA_STORAGE_BACKEND"] = "s3"
+			updates["MEDIA_S3_ENDPOINT"] = s3.Endpoint
+			updates["MEDIA_S3_BUCKET"] = s3.Bucket
+			updates["MEDIA_S3_ACCESS_KEY_ID"] = s3.AccessKeyID
+			updates["MEDIA_S3_SECRET_ACCESS_KEY"] = s3.SecretAccessKey
+			updates["MEDIA_S3_REGION"] = region
+			updates["MEDIA_S3_URL_ENABLED"] = fmt.Sprintf("%t", s3.PublicEndpoint != "")
+			if s3.PublicEndpoint != "" {
+				updates["MEDIA_S3_PUBLIC_ENDPOINT"] = s3.PublicEndpoint
+			}
+		default:
+			return nil, fmt.Errorf("unknown media.storage %q: must be local or s3", answers.Media.Storage)
+		}
+		services = append(services, "media-api")
+	} else {
+		updates["MEDIA_API_ENABLED"] = "false"
+	}
+
+	// Realtime API
+	if boolAnswer(answers.Realtime.Enabled, false) {
+		rt := answers.Realtime
+		if rt.WSURL == "" || rt.APIKey == "" || rt.APISecret == "" {
+			return nil, fmt.Errorf("the realtime API needs realtime.ws_url, api_key and api_secret")
+		}
+		updates["REALTIME_API_ENABLED"] = "true"
+		updates["LIVEKIT_WS_URL"] = rt.WSURL
+		updates["LIVEKIT_API_KEY"] = rt.APIKey
+		updates["LIVEKIT_API_SECRET"] = rt.APISecret
+		profiles = append(profiles, "realtime")
+		services = append(services, "realtime-api")
+	} else {
+		updates["REALTIME_API_ENABLED"] = "false"
+	}
+
+	monitoring := boolAnswer(answers.Monitoring, profile.monitoring)
+	if monitoring {
+		updates["OTEL_ENABLED"] = "true"
+	}
+	if boolAnswer(answers.Platform, profile.platform) {
+		profiles = append(profiles, "platform")
+	}
+
+	updates["KEYCLOAK_PUBLIC_URL"] = "http://localhost:8085"
+	updates["KEYCLOAK_ADMIN_URL"] = "http://localhost:8085"
+	updates["KEYCLOAK_BASE_URL"] = "http://keycloak:8085"
+	updates["ISSUER"] = "http://localhost:8085/realms/jan"
+	updates["COMPOSE_PROFILES"] = strings.Join(profiles, ",")
+
+	return &setupPlan{updates: updates, services: services, monitoring: monitoring}, nil
+}
+
+// resolveSetupSecrets adds secrets to updates: values given in the answers
+// file, and generated ones for placeholders in a newly created .env. Existing
+// files keep their secrets, since the database and Keycloak were initialised
+// with them. It returns the keys that were generated.
+func resolveSetupSecrets(envPath string, created bool, given map[string]string, updates map[string]string) ([]string, error) {
+	current, err := readEnvValues(envPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var generated []string
+	for _, secret := range generatedSecrets {
+		value := ""
+		for _, key := range secret.keys {
+			if given[key] != "" {
+				value = given[key]
+				break
+			}
+		}
+		if value == "" && current[secret.keys[0]] == secret.placeholder {
+			if !created {
+				printWarning("%s still holds its template placeholder; set it under secrets: in the answers file", secret.keys[0])
+				continue
+			}
+			token, err := randomSecret()
+			if err != nil {
+				return nil, fmt.Errorf("generate %s: %w", secret.keys[0], err)
+			}
+			value = token
+			generated = append(generated, secret.keys...)
+		}
+		if value != "" {
+			for _, key := range secret.keys {
+				updates[key] = value
+			}
+		}
+	}
+	sort.Strings(generated)
+	return generated, nil
+}
+
+// readEnvValues parses KEY=value lines of an environment file
+func readEnvValues(envPath string) (map[string]string, error) {
+	data, err := os.ReadFile(envPath)
+	if err != nil {
+		return nil, fmt.Errorf("read .env: %w", err)
+	}
+	values := make(map[string]string)
+	for _, line := range strings.Split(string(data), "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		if key, value, ok := strings.Cut(trimmed, "="); ok {
+			values[strings.TrimSpace(key)] = strings.TrimSpace(value)
+		}
+	}
+	return values, nil
+}
+
+// randomSecret returns 32 random bytes as hex, safe to embed in DSNs
+func randomSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// waitForServices polls /readyz of each service until all report ready or ctx ends
+func waitForServices(ctx context.Context, services []string) error {
+	client := &http.Client{Timeout: 5 * time.Second}
+	pending := append([]string(nil), services...)
+	for {
+		var still []string
+		for _, name := range pending {
+			result := fetchReadiness(ctx, client, name, getReadyURL(name))
+			if result.Status == "ready" || result.Status == "degraded" {
+				printSuccess("%s is %s", name, result.Status)
+				continue
+			}
+			still = append(still, name)
+		}
+		if len(still) == 0 {
+			return nil
+		}
+		pending = still
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("services not ready in time: %s (check with: jan-cli service health)", strings.Join(pending, ", "))
+		case <-time.After(5 * time.Second):
+		}
+	}
+}
+
+// boolAnswer returns the answer when given, else the profile default
+func boolAnswer(answer *bool, fallback bool) bool {
+	if answer != nil {
+		return *answer
+	}
+	return fallback
+}
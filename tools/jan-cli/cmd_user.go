@@ -0,0 +1,708 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var userCmd = &cobra.Command{
+	Use:   "user",
+	Short: "Manage user accounts",
+	Long: `Manage accounts in Keycloak and the llm-api user table together.
+
+Keycloak is reached with the master realm admin credentials from the
+environment file (KEYCLOAK_ADMIN, KEYCLOAK_ADMIN_PASSWORD); the user table is
+read and written through the api-db container of the Docker Compose stack.
+
+Users can be given as a username, an email address, a Keycloak user ID or
+an llm-api user ID.`,
+}
+
+var userListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List users",
+	Long: `List Keycloak users with their realm roles and llm-api records.
+
+Examples:
+  jan-cli user list
+  jan-cli user list --search alice --json`,
+	RunE: runUserList,
+}
+
+var userCreateCmd = &cobra.Command{
+	Use:   "create <username>",
+	Short: "Create a user",
+	Long: `Create a Keycloak user and its llm-api record.
+
+Without --password-stdin a temporary password is generated and printed; the
+user has to change it at first login.
+
+Examples:
+  jan-cli user create alice --email alice@example.com
+  echo "$PASSWORD" | jan-cli user create bob --email bob@example.com --role admin --password-stdin`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUserCreate,
+}
+
+var userDisableCmd = &cobra.Command{
+	Use:   "disable <user>",
+	Short: "Disable a user",
+	Long: `Disable a user in Keycloak, end their sessions and revoke their API keys.
+
+API keys are checked against the llm-api database only, so they are revoked
+explicitly; re-enabling the user does not bring them back.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUserDisable,
+}
+
+var userEnableCmd = &cobra.Command{
+	Use:   "enable <user>",
+	Short: "Re-enable a disabled user",
+	Args:  cobra.ExactArgs(1),
+	RunE:  runUserEnable,
+}
+
+var userSetRoleCmd = &cobra.Command{
+	Use:   "set-role <user> <role>",
+	Short: "Set a user's role",
+	Long: `Make role (admin, user or guest) the user's only Jan realm role.
+
+Other realm roles, such as default-roles-jan, are left untouched. The new
+role applies to tokens issued after the change.`,
+	Args: cobra.ExactArgs(2),
+	RunE: runUserSetRole,
+}
+
+var userResetPasswordCmd = &cobra.Command{
+	Use:   "reset-password <user>",
+	Short: "Reset a user's password",
+	Long: `Set a new password and end the user's sessions.
+
+Without --password-stdin a temporary password is generated and printed.`,
+	Args: cobra.ExactArgs(1),
+	RunE: runUserResetPassword,
+}
+
+func init() {
+	userCmd.AddCommand(userListCmd)
+	userCmd.AddCommand(userCreateCmd)
+	userCmd.AddCommand(userDisableCmd)
+	userCmd.AddCommand(userEnableCmd)
+	userCmd.AddCommand(userSetRoleCmd)
+	userCmd.AddCommand(userResetPasswordCmd)
+
+	userCmd.PersistentFlags().String("env-file", ".env", "Environment file of the deployment")
+	userCmd.PersistentFlags().String("keycloak-url", "", "Keycloak base URL (default: $KEYCLOAK_ADMIN_URL from the env file)")
+
+	userListCmd.Flags().String("search", "", "Filter by username, email or name")
+	userListCmd.Flags().Int("max", 100, "Maximum number of users")
+	userListCmd.Flags().Bool("json", false, "Print JSON")
+
+	userCreateCmd.Flags().String("email", "", "Email address")
+	userCreateCmd.Flags().String("first-name", "", "First name")
+	userCreateCmd.Flags().String("last-name", "", "Last name")
+	userCreateCmd.Flags().String("role", "user", "Role: admin, user or guest")
+	userCreateCmd.Flags().Bool("password-stdin", false, "Read a permanent password from stdin")
+
+	userDisableCmd.Flags().Bool("keep-api-keys", false, "Do not revoke the user's API keys")
+
+	userResetPasswordCmd.Flags().Bool("password-stdin", false, "Read the password from stdin")
+	userResetPasswordCmd.Flags().Bool("temporary", true, "Require a password change at next login")
+}
+
+// janRoles are the realm roles set-role switches between
+var janRoles = []string{"admin", "user", "guest"}
+
+var uuidPattern = regexp.MustCompile(`^[0-9a-fA-F]{8}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{4}-[0-9a-fA-F]{12}$`)
+
+// kcUser is a Keycloak user representation
+type kcUser struct {
+	ID            string `json:"id,omitempty"`
+	Username      string `json:"username,omitempty"`
+	Email         string `json:"email,omitempty"`
+	FirstName     string `json:"firstName,omitempty"`
+	LastName      string `json:"lastName,omitempty"`
+	Enabled       bool   `json:"enabled"`
+	EmailVerified bool   `json:"emailVerified,omitempty"`
+}
+
+// apiUser is a row of llm_api.users with its active API key count
+type apiUser struct {
+	ID         int64
+	Subject    string
+	APIKeys    int
+	LastUsedAt string
+}
+
+// userAdmin talks to Keycloak and the llm-api database of one deployment
+type userAdmin struct {
+	root     string
+	db       backupDatabase
+	baseURL  string
+	realm    string
+	issuer   string
+	username string
+	password string
+	client   *http.Client
+	token    string
+}
+
+func newUserAdmin(cmd *cobra.Command) (*userAdmin, error) {
+	envPath, _ := cmd.Flags().GetString("env-file")
+	baseURL, _ := cmd.Flags().GetString("keycloak-url")
+
+	root, err := findProjectRoot()
+	if err != nil {
+		return nil, err
+	}
+	env, err := readEnvValues(envPath)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &userAdmin{
+		root:     root,
+		db:       apiDatabase(env),
+		realm:    expandEnvValue(env, "KEYCLOAK_REALM", "jan"),
+		username: expandEnvValue(env, "KEYCLOAK_ADMIN", "admin"),
+		password: expandEnvValue(env, "KEYCLOAK_ADMIN_PASSWORD", ""),
+		client:   &http.Client{Timeout: 15 * time.Second},
+	}
+	if baseURL == "" {
+		baseURL = expandEnvValue(env, "KEYCLOAK_ADMIN_URL", "http://localhost:8085")
+	}
+	a.baseURL = strings.TrimRight(baseURL, "/")
+	a.issuer = expandEnvValue(env, "ISSUER", a.baseURL+"/realms/"+a.realm)
+	if a.password == "" {
+		return nil, fmt.Errorf("KEYCLOAK_ADMIN_PASSWORD is not set in %s", envPath)
+	}
+	return a, nil
+}
+
+// expandEnvValue returns key from a .env file with ${VAR} and ${VAR:-default}
+// references resolved against the same file
+func expandEnvValue(env map[string]string, key, fallback string) string {
+	var expand func(string, int) string
+	expand = func(value string, depth int) string {
+		if depth > 10 {
+			return value
+		}
+		return os.Expand(value, func(name string) string {
+			name, def, _ := strings.Cut(name, ":-")
+			if v, ok := env[name]; ok && v != "" {
+				return expand(strings.Trim(v, `"'`), depth+1)
+			}
+			if v := os.Getenv(name); v != "" {
+				return v
+			}
+			return def
+		})
+	}
+	value := expand("${"+key+"}", 0)
+	if value == "" {
+		return fallback
+	}
+	return value
+}
+
+func runUserList(cmd *cobra.Command, args []string) error {
+	search, _ := cmd.Flags().GetString("search")
+	max, _ := cmd.Flags().GetInt("max")
+	asJSON, _ := cmd.Flags().GetBool("json")
+
+	a, err := newUserAdmin(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	query := url.Values{"max": {strconv.Itoa(max)}, "briefRepresentation": {"false"}}
+	if search != "" {
+		query.Set("search", search)
+	}
+	var users []kcUser
+	if err := a.keycloak(ctx, http.MethodGet, "/users?"+query.Encode(), nil, &users); err != nil {
+		return err
+	}
+
+	records, err := a.apiUsers()
+	if err != nil {
+		printWarning("Could not read llm-api users: %v", err)
+	}
+
+	type row struct {
+		ID       string   `json:"id"`
+		Username string   `json:"username"`
+		Email    string   `json:"email,omitempty"`
+		Enabled  bool     `json:"enabled"`
+		Roles    []string `json:"roles"`
+		APIUser  *int64   `json:"llm_api_user_id,omitempty"`
+		APIKeys  int      `json:"active_api_keys"`
+		LastUsed string   `json:"api_key_last_used_at,omitempty"`
+	}
+	rows := make([]row, 0, len(users))
+	for _, u := range users {
+		roles, err := a.userRoles(ctx, u.ID)
+		if err != nil {
+			return err
+		}
+		r := row{ID: u.ID, Username: u.Username, Email: u.Email, Enabled: u.Enabled, Roles: roles}
+		if rec, ok := records[u.ID]; ok {
+			id := rec.ID
+			r.APIUser, r.APIKeys, r.LastUsed = &id, rec.APIKeys, rec.LastUsedAt
+		}
+		rows = append(rows, r)
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(rows)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "USERNAME\tEMAIL\tENABLED\tROLES\tLLM-API ID\tAPI KEYS\tKEYCLOAK ID")
+	for _, r := range rows {
+		apiID := "-"
+		if r.APIUser != nil {
+			apiID = strconv.FormatInt(*r.APIUser, 10)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%t\t%s\t%s\t%d\t%s\n",
+			r.Username, r.Email, r.Enabled, strings.Join(r.Roles, ","), apiID, r.APIKeys, r.ID)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+	if len(users) == max {
+		printInfo("Showing the first %d users; use --search or --max to see others", max)
+	}
+	return nil
+}
+
+func runUserCreate(cmd *cobra.Command, args []string) error {
+	email, _ := cmd.Flags().GetString("email")
+	firstName, _ := cmd.Flags().GetString("first-name")
+	lastName, _ := cmd.Flags().GetString("last-name")
+	role, _ := cmd.Flags().GetString("role")
+	fromStdin, _ := cmd.Flags().GetBool("password-stdin")
+
+	if !isJanRole(role) {
+		return fmt.Errorf("unknown role %q (expected %s)", role, strings.Join(janRoles, ", "))
+	}
+	password, temporary, err := newPassword(fromStdin)
+	if err != nil {
+		return err
+	}
+	a, err := newUserAdmin(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+
+	user := kcUser{Username: args[0], Email: email, FirstName: firstName, LastName: lastName, Enabled: true, EmailVerified: email != ""}
+	if err := a.keycloak(ctx, http.MethodPost, "/users", user, nil); err != nil {
+		return fmt.Errorf("create user: %w", err)
+	}
+	created, err := a.findUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.setPassword(ctx, created.ID, password, temporary); err != nil {
+		return err
+	}
+	if err := a.setRole(ctx, created.ID, role); err != nil {
+		return err
+	}
+	printSuccess("Created %s in Keycloak (%s)", created.Username, created.ID)
+
+	// llm-api creates the record at first login as well; creating it now lets
+	// operators manage keys and settings before that
+	id, err := a.ensureAPIUser(created)
+	if err != nil {
+		printWarning("Could not create the llm-api user record: %v", err)
+		printInfo("It is created automatically when the user first signs in")
+	} else {
+		printSuccess("llm-api user ID %d", id)
+	}
+
+	if temporary {
+		fmt.Println()
+		fmt.Printf("Temporary password: %s\n", password)
+		printInfo("The user must change it at first login")
+	}
+	return nil
+}
+
+func runUserDisable(cmd *cobra.Command, args []string) error {
+	keepKeys, _ := cmd.Flags().GetBool("keep-api-keys")
+
+	a, err := newUserAdmin(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	user, err := a.resolveUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+
+	if err := a.keycloak(ctx, http.MethodPut, "/users/"+user.ID, map[string]bool{"enabled": false}, nil); err != nil {
+		return fmt.Errorf("disable user: %w", err)
+	}
+	if err := a.keycloak(ctx, http.MethodPost, "/users/"+user.ID+"/logout", nil, nil); err != nil {
+		printWarning("Could not end sessions: %v", err)
+	}
+	printSuccess("Disabled %s and ended their sessions", user.Username)
+
+	if keepKeys {
+		return nil
+	}
+	revoked, err := a.psql("UPDATE llm_api.api_keys SET revoked_at = NOW(), updated_at = NOW() "+
+		"WHERE revoked_at IS NULL AND user_id IN (SELECT id FROM llm_api.users WHERE issuer = :'issuer' AND subject = :'subject') "+
+		"RETURNING id", map[string]string{"issuer": a.issuer, "subject": user.ID})
+	if err != nil {
+		return fmt.Errorf("revoke API keys: %w", err)
+	}
+	printSuccess("Revoked %d API key(s)", len(strings.Fields(revoked)))
+	return nil
+}
+
+func runUserEnable(cmd *cobra.Command, args []string) error {
+	a, err := newUserAdmin(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	user, err := a.resolveUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.keycloak(ctx, http.MethodPut, "/users/"+user.ID, map[string]bool{"enabled": true}, nil); err != nil {
+		return fmt.Errorf("enable user: %w", err)
+	}
+	printSuccess("Enabled %s", user.Username)
+	return nil
+}
+
+func runUserSetRole(cmd *cobra.Command, args []string) error {
+	role := args[1]
+	if !isJanRole(role) {
+		return fmt.Errorf("unknown role %q (expected %s)", role, strings.Join(janRoles, ", "))
+	}
+	a, err := newUserAdmin(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	user, err := a.resolveUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.setRole(ctx, user.ID, role); err != nil {
+		return err
+	}
+	printSuccess("%s now has the %s role", user.Username, role)
+	printInfo("Existing tokens keep their old roles until they are refreshed")
+	return nil
+}
+
+func runUserResetPassword(cmd *cobra.Command, args []string) error {
+	fromStdin, _ := cmd.Flags().GetBool("password-stdin")
+	temporaryFlag, _ := cmd.Flags().GetBool("temporary")
+
+	password, generated, err := newPassword(fromStdin)
+	if err != nil {
+		return err
+	}
+	temporary := generated || temporaryFlag
+	a, err := newUserAdmin(cmd)
+	if err != nil {
+		return err
+	}
+	ctx := cmd.Context()
+	user, err := a.resolveUser(ctx, args[0])
+	if err != nil {
+		return err
+	}
+	if err := a.setPassword(ctx, user.ID, password, temporary); err != nil {
+		return err
+	}
+	if err := a.keycloak(ctx, http.MethodPost, "/users/"+user.ID+"/logout", nil, nil); err != nil {
+		printWarning("Could not end sessions: %v", err)
+	}
+	printSuccess("Reset the password of %s and ended their sessions", user.Username)
+	if generated {
+		fmt.Println()
+		fmt.Printf("Temporary password: %s\n", password)
+	}
+	if temporary {
+		printInfo("The user must change it at next login")
+	}
+	return nil
+}
+
+// newPassword reads a password from stdin, or generates a temporary one
+func newPassword(fromStdin bool) (string, bool, error) {
+	if !fromStdin {
+		secret, err := randomSecret()
+		if err != nil {
+			return "", false, err
+		}
+		return secret[:20], true, nil
+	}
+	line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", false, fmt.Errorf("read password: %w", err)
+	}
+	password := strings.TrimRight(line, "\r\n")
+	if password == "" {
+		return "", false, fmt.Errorf("no password on stdin")
+	}
+	return password, false, nil
+}
+
+func isJanRole(role string) bool {
+	for _, r := range janRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+// resolveUser finds a user by Keycloak ID, llm-api ID, email or username
+func (a *userAdmin) resolveUser(ctx context.Context, ref string) (*kcUser, error) {
+	ref = strings.TrimSpace(ref)
+	if _, err := strconv.ParseInt(ref, 10, 64); err == nil {
+		subject, err := a.psql("SELECT subject FROM llm_api.users WHERE id = :'id'::int AND deleted_at IS NULL",
+			map[string]string{"id": ref})
+		if err != nil {
+			return nil, fmt.Errorf("look up llm-api user %s: %w", ref, err)
+		}
+		if subject == "" {
+			return nil, fmt.Errorf("llm-api user %s not found", ref)
+		}
+		ref = subject
+	}
+	if uuidPattern.MatchString(ref) {
+		var user kcUser
+		if err := a.keycloak(ctx, http.MethodGet, "/users/"+ref, nil, &user); err == nil {
+			return &user, nil
+		}
+	}
+	return a.findUser(ctx, ref)
+}
+
+// findUser looks a user up by exact username or email
+func (a *userAdmin) findUser(ctx context.Context, ref string) (*kcUser, error) {
+	field := "username"
+	if strings.Contains(ref, "@") {
+		field = "email"
+	}
+	var users []kcUser
+	query := url.Values{field: {ref}, "exact": {"true"}}
+	if err := a.keycloak(ctx, http.MethodGet, "/users?"+query.Encode(), nil, &users); err != nil {
+		return nil, err
+	}
+	if len(users) == 0 {
+		return nil, fmt.Errorf("user %q not found", ref)
+	}
+	return &users[0], nil
+}
+
+func (a *userAdmin) setPassword(ctx context.Context, userID, password string, temporary bool) error {
+	credential := map[string]any{"type": "password", "value": password, "temporary": temporary}
+	if err := a.keycloak(ctx, http.MethodPut, "/users/"+userID+"/reset-password", credential, nil); err != nil {
+		return fmt.Errorf("set password: %w", err)
+	}
+	return nil
+}
+
+// userRoles returns the Jan roles mapped directly to a user
+func (a *userAdmin) userRoles(ctx context.Context, userID string) ([]string, error) {
+	var mappings []struct {
+		Name string `json:"name"`
+	}
+	if err := a.keycloak(ctx, http.MethodGet, "/users/"+userID+"/role-mappings/realm", nil, &mappings); err != nil {
+		return nil, err
+	}
+	roles := []string{}
+	for _, m := range mappings {
+		if isJanRole(m.Name) {
+			roles = append(roles, m.Name)
+		}
+	}
+	return roles, nil
+}
+
+// setRole grants role and removes the other Jan roles
+func (a *userAdmin) setRole(ctx context.Context, userID, role string) error {
+	var mappings []map[string]any
+	if err := a.keycloak(ctx, http.MethodGet, "/users/"+userID+"/role-mappings/realm", nil, &mappings); err != nil {
+		return err
+	}
+	var remove []map[string]any
+	hasRole := false
+	for _, m := range mappings {
+		name, _ := m["name"].(string)
+		switch {
+		case name == role:
+			hasRole = true
+		case isJanRole(name):
+			remove = append(remove, m)
+		}
+	}
+
+	if !hasRole {
+		var representation map[string]any
+		if err := a.keycloak(ctx, http.MethodGet, "/roles/"+url.PathEscape(role), nil, &representation); err != nil {
+			return fmt.Errorf("look up role %s: %w", role, err)
+		}
+		if err := a.keycloak(ctx, http.MethodPost, "/users/"+userID+"/role-mappings/realm", []map[string]any{representation}, nil); err != nil {
+			return fmt.Errorf("grant role %s: %w", role, err)
+		}
+	}
+	if len(remove) > 0 {
+		if err := a.keycloak(ctx, http.MethodDelete, "/users/"+userID+"/role-mappings/realm", remove, nil); err != nil {
+			return fmt.Errorf("remove previous roles: %w", err)
+		}
+	}
+	return nil
+}
+
+// apiUsers returns llm-api users of this deployment's issuer by subject
+func (a *userAdmin) apiUsers() (map[string]apiUser, error) {
+	out, err := a.psql("SELECT u.subject, u.id, count(k.id), coalesce(max(k.last_used_at)::text, '') "+
+		"FROM llm_api.users u LEFT JOIN llm_api.api_keys k ON k.user_id = u.id AND k.revoked_at IS NULL AND k.expires_at > NOW() "+
+		"WHERE u.issuer = :'issuer' AND u.deleted_at IS NULL GROUP BY u.id", map[string]string{"issuer": a.issuer})
+	if err != nil {
+		return nil, err
+	}
+	users := make(map[string]apiUser)
+	for _, line := range strings.Split(out, "\n") {
+		fields := strings.Split(line, "|")
+		if len(fields) != 4 {
+			continue
+		}
+		u := apiUser{Subject: fields[0], LastUsedAt: fields[3]}
+		u.ID, _ = strconv.ParseInt(fields[1], 10, 64)
+		u.APIKeys, _ = strconv.Atoi(fields[2])
+		users[u.Subject] = u
+	}
+	return users, nil
+}
+
+// ensureAPIUser creates the llm-api record of a Keycloak user the way llm-api
+// does at login, and returns its ID
+func (a *userAdmin) ensureAPIUser(user *kcUser) (int64, error) {
+	name := strings.TrimSpace(user.FirstName + " " + user.LastName)
+	out, err := a.psql("INSERT INTO llm_api.users (auth_provider, issuer, subject, username, email, name) "+
+		"VALUES ('keycloak', :'issuer', :'subject', :'username', NULLIF(:'email', ''), NULLIF(:'name', '')) "+
+		"ON CONFLICT (issuer, subject) DO UPDATE SET updated_at = NOW() RETURNING id",
+		map[string]string{"issuer": a.issuer, "subject": user.ID, "username": user.Username, "email": user.Email, "name": name})
+	if err != nil {
+		return 0, err
+	}
+	return strconv.ParseInt(strings.TrimSpace(out), 10, 64)
+}
+
+// psql runs a statement in the llm-api database. Values are passed as psql
+// variables and referenced as :'name' so they are quoted by psql.
+func (a *userAdmin) psql(query string, vars map[string]string) (string, error) {
+	args := []string{"psql", "-U", a.db.User, "-d", a.db.Database, "-v", "ON_ERROR_STOP=1", "-At", "-q"}
+	for name, value := range vars {
+		args = append(args, "-v", name+"="+value)
+	}
+	var out bytes.Buffer
+	if err := composeExec(a.root, a.db.Service, strings.NewReader(query+";\n"), &out, args...); err != nil {
+		return "", err
+	}
+	return strings.TrimSpace(out.String()), nil
+}
+
+// keycloak calls the realm's admin API; body and out are JSON
+func (a *userAdmin) keycloak(ctx context.Context, method, path string, body, out any) error {
+	if a.token == "" {
+		if err := a.login(ctx); err != nil {
+			return err
+		}
+	}
+
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, a.baseURL+"/admin/realms/"+url.PathEscape(a.realm)+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+a.token)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("keycloak unreachable at %s: %w", a.baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		payload, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("keycloak %s %s: %s %s", method, path, resp.Status, strings.TrimSpace(string(payload)))
+	}
+	if out != nil {
+		return json.NewDecoder(resp.Body).Decode(out)
+	}
+	return nil
+}
+
+// login gets an admin token from the master realm with the admin-cli client
+func (a *userAdmin) login(ctx context.Context) error {
+	form := url.Values{
+		"grant_type": {"password"},
+		"client_id":  {"admin-cli"},
+		"username":   {a.username},
+		"password":   {a.password},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, a.baseURL+"/realms/master/protocol/openid-connect/token", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := a.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("keycloak unreachable at %s: %w", a.baseURL, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		payload, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return fmt.Errorf("keycloak admin login failed: %s %s", resp.Status, strings.TrimSpace(string(payload)))
+	}
+	var token struct {
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&token); err != nil {
+		return err
+	}
+	a.token = token.AccessToken
+	return nil
+}
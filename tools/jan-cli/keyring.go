@@ -0,0 +1,222 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+// keyringService names jan-cli's entries in the OS keyring
+const keyringService = "jan-cli"
+
+// errNoCredential means no refresh token is stored for a gateway
+var errNoCredential = errors.New("not logged in")
+
+// credentialStore keeps refresh tokens per gateway URL. The macOS Keychain and the
+// Secret Service (via secret-tool) are used when present; otherwise tokens go to a
+// file only the current user can read.
+type credentialStore interface {
+	Get(account string) (string, error)
+	Set(account, secret string) error
+	Delete(account string) error
+	Name() string
+}
+
+// newCredentialStore picks the most secure store available on this machine
+func newCredentialStore() credentialStore {
+	switch runtime.GOOS {
+	case "darwin":
+		if _, err := exec.LookPath("security"); err == nil {
+			return keychainStore{}
+		}
+	case "linux", "freebsd", "openbsd":
+		if _, err := exec.LookPath("secret-tool"); err == nil && os.Getenv("DBUS_SESSION_BUS_ADDRESS") != "" {
+			return secretServiceStore{}
+		}
+	}
+	return fileStore{}
+}
+
+// keychainStore stores secrets in the macOS login keychain
+type keychainStore struct{}
+
+func (keychainStore) Name() string { return "macOS keychain" }
+
+func (keychainStore) Get(account string) (string, error) {
+	out, err := exec.Command("security", "find-generic-password", "-s", keyringService, "-a", account, "-w").Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", errNoCredential
+		}
+		return "", err
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// Set passes the command on stdin (security -i) so the token never shows up in ps
+func (keychainStore) Set(account, secret string) error {
+	command := fmt.Sprintf("add-generic-password -U -s %s -a %s -w %s\n",
+		keychainQuote(keyringService), keychainQuote(account), keychainQuote(secret))
+	return runWithInput(exec.Command("security", "-i"), command)
+}
+
+func (keychainStore) Delete(account string) error {
+	err := exec.Command("security", "delete-generic-password", "-s", keyringService, "-a", account).Run()
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		return errNoCredential
+	}
+	return err
+}
+
+// keychainQuote quotes an argument for security's interactive mode
+func keychainQuote(s string) string {
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(s) + `"`
+}
+
+// secretServiceStore stores secrets with the freedesktop Secret Service
+// (GNOME Keyring, KWallet) through secret-tool
+type secretServiceStore struct{}
+
+func (secretServiceStore) Name() string { return "Secret Service keyring" }
+
+func (secretServiceStore) Get(account string) (string, error) {
+	out, err := exec.Command("secret-tool", "lookup", "service", keyringService, "account", account).Output()
+	if err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return "", errNoCredential
+		}
+		return "", err
+	}
+	secret := strings.TrimSpace(string(out))
+	if secret == "" {
+		return "", errNoCredential
+	}
+	return secret, nil
+}
+
+// Set passes the secret on stdin so it never shows up in ps
+func (secretServiceStore) Set(account, secret string) error {
+	cmd := exec.Command("secret-tool", "store", "--label=jan-cli ("+account+")", "service", keyringService, "account", account)
+	return runWithInput(cmd, secret)
+}
+
+func (secretServiceStore) Delete(account string) error {
+	if _, err := (secretServiceStore{}).Get(account); err != nil {
+		return err
+	}
+	return exec.Command("secret-tool", "clear", "service", keyringService, "account", account).Run()
+}
+
+// fileStore keeps secrets in a 0600 JSON file under the user config directory
+type fileStore struct{}
+
+func (fileStore) Name() string {
+	path, err := credentialsFile()
+	if err != nil {
+		return "credentials file"
+	}
+	return path
+}
+
+func (fileStore) Get(account string) (string, error) {
+	secrets, err := readCredentialsFile()
+	if err != nil {
+		return "", err
+	}
+	secret, ok := secrets[account]
+	if !ok {
+		return "", errNoCredential
+	}
+	return secret, nil
+}
+
+func (fileStore) Set(account, secret string) error {
+	secrets, err := readCredentialsFile()
+	if err != nil {
+		return err
+	}
+	secrets[account] = secret
+	return writeCredentialsFile(secrets)
+}
+
+func (fileStore) Delete(account string) error {
+	secrets, err := readCredentialsFile()
+	if err != nil {
+		return err
+	}
+	if _, ok := secrets[account]; !ok {
+		return errNoCredential
+	}
+	delete(secrets, account)
+	return writeCredentialsFile(secrets)
+}
+
+func credentialsFile() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "jan-cli", "credentials.json"), nil
+}
+
+func readCredentialsFile() (map[string]string, error) {
+	path, err := credentialsFile()
+	if err != nil {
+		return nil, err
+	}
+	secrets := make(map[string]string)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return secrets, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &secrets); err != nil {
+		return nil, fmt.Errorf("read %s: %w", path, err)
+	}
+	return secrets, nil
+}
+
+func writeCredentialsFile(secrets map[string]string) error {
+	path, err := credentialsFile()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(secrets, "", "  ")
+	if err != nil {
+		return err
+	}
+	// Write then rename so a crash never leaves a truncated file behind
+	tmp := path + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, path)
+}
+
+// runWithInput runs cmd with input on stdin, returning its stderr on failure
+func runWithInput(cmd *exec.Cmd, input string) error {
+	var stderr bytes.Buffer
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return fmt.Errorf("%s: %s", filepath.Base(cmd.Path), msg)
+		}
+		return err
+	}
+	return nil
+}
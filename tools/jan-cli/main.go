@@ -26,6 +26,8 @@ database management, deployment, and development workflows.
 
 Quick Start:
   jan-cli setup-and-run          # Interactive setup and start all services
+  jan-cli setup --profile minimal --non-interactive --answers-file jan.yml
+                                 # Unattended setup for CI and automation
 
 Examples:
   # Configuration management
@@ -49,9 +51,18 @@ func init() {
 	rootCmd.AddCommand(swaggerCmd)
 	rootCmd.AddCommand(installCmd)
 	rootCmd.AddCommand(setupAndRunCmd)
+	rootCmd.AddCommand(setupCmd)
+	rootCmd.AddCommand(backupCmd)
+	rootCmd.AddCommand(userCmd)
+	rootCmd.AddCommand(adminCmd)
+	rootCmd.AddCommand(providerCmd)
+	rootCmd.AddCommand(chatCmd)
 	rootCmd.AddCommand(monitorCmd)
 	rootCmd.AddCommand(apiTestCmd)
+	rootCmd.AddCommand(benchCmd)
 	rootCmd.AddCommand(docsCmd)
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
 
 	rootCmd.PersistentFlags().BoolP("verbose", "v", false, "Enable verbose output")
 	rootCmd.PersistentFlags().String("config-dir", "config", "Configuration directory")
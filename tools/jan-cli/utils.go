@@ -56,3 +56,20 @@ func getHealthURL(service string) string {
 
 	return healthURLs[service]
 }
+
+// readinessURLs returns the /readyz endpoint of every Go service
+func readinessURLs() map[string]string {
+	return map[string]string{
+		"llm-api":      "http://localhost:8080/readyz",
+		"media-api":    "http://localhost:8285/readyz",
+		"response-api": "http://localhost:8082/readyz",
+		"mcp-tools":    "http://localhost:8091/readyz",
+		"memory-tools": "http://localhost:8090/readyz",
+		"realtime-api": "http://localhost:8186/readyz",
+	}
+}
+
+// getReadyURL returns the readiness URL for a given service
+func getReadyURL(service string) string {
+	return readinessURLs()[service]
+}